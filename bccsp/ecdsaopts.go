@@ -47,3 +47,19 @@ func (opts *ECDSAP384KeyGenOpts) Algorithm() string {
 func (opts *ECDSAP384KeyGenOpts) Ephemeral() bool {
 	return opts.Temporary
 }
+
+// ECDSASecp256k1KeyGenOpts contains options for ECDSA key generation with curve secp256k1.
+type ECDSASecp256k1KeyGenOpts struct {
+	Temporary bool
+}
+
+// Algorithm returns the key generation algorithm identifier (to be used).
+func (opts *ECDSASecp256k1KeyGenOpts) Algorithm() string {
+	return ECDSASECP256K1
+}
+
+// Ephemeral returns true if the key to generate has to be ephemeral,
+// false otherwise.
+func (opts *ECDSASecp256k1KeyGenOpts) Ephemeral() bool {
+	return opts.Temporary
+}
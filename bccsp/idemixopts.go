@@ -0,0 +1,245 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package bccsp
+
+import "crypto"
+
+// IDEMIX constant to identify Idemix related algorithms
+const IDEMIX = "IDEMIX"
+
+// IdemixAttributeType represents the type of an idemix attribute
+type IdemixAttributeType int
+
+const (
+	// IdemixHiddenAttribute represents an attribute whose value is not disclosed
+	IdemixHiddenAttribute IdemixAttributeType = iota
+	// IdemixBytesAttribute represents an attribute whose value is a byte array
+	IdemixBytesAttribute
+	// IdemixIntAttribute represents an attribute whose value is an int
+	IdemixIntAttribute
+)
+
+// IdemixAttribute is an attribute of an idemix credential
+type IdemixAttribute struct {
+	// Type is the type of the attribute
+	Type IdemixAttributeType
+	// Value is the value of the attribute: a []byte for IdemixBytesAttribute,
+	// an int for IdemixIntAttribute, and nil for IdemixHiddenAttribute
+	Value interface{}
+}
+
+// IdemixIssuerKeyGenOpts contains the options for the Idemix Issuer key-generation.
+// A list of attribute names may be optionally passed
+type IdemixIssuerKeyGenOpts struct {
+	// Temporary tells if the key is ephemeral
+	Temporary bool
+	// AttributeNames is a list of attributes
+	AttributeNames []string
+}
+
+// Algorithm returns the key generation algorithm identifier (to be used).
+func (o *IdemixIssuerKeyGenOpts) Algorithm() string {
+	return IDEMIX
+}
+
+// Ephemeral returns true if the key to generate has to be ephemeral,
+// false otherwise.
+func (o *IdemixIssuerKeyGenOpts) Ephemeral() bool {
+	return o.Temporary
+}
+
+// IdemixIssuerKeyImportOpts contains the options for importing an Idemix issuer secret key.
+// The raw material is the serialized issuer secret key, while the serialized
+// issuer public key is passed in the options.
+type IdemixIssuerKeyImportOpts struct {
+	Temporary bool
+	// AttributeNames is a list of attributes the imported public key must have
+	AttributeNames []string
+	// PublicKey is the serialized issuer public key
+	PublicKey []byte
+}
+
+// Algorithm returns the key importation algorithm identifier (to be used).
+func (o *IdemixIssuerKeyImportOpts) Algorithm() string {
+	return IDEMIX
+}
+
+// Ephemeral returns true if the key to generate has to be ephemeral,
+// false otherwise.
+func (o *IdemixIssuerKeyImportOpts) Ephemeral() bool {
+	return o.Temporary
+}
+
+// IdemixIssuerPublicKeyImportOpts contains the options for importing of an Idemix issuer public key.
+type IdemixIssuerPublicKeyImportOpts struct {
+	Temporary bool
+	// AttributeNames is a list of attributes the imported public key must have
+	AttributeNames []string
+}
+
+// Algorithm returns the key importation algorithm identifier (to be used).
+func (o *IdemixIssuerPublicKeyImportOpts) Algorithm() string {
+	return IDEMIX
+}
+
+// Ephemeral returns true if the key to generate has to be ephemeral,
+// false otherwise.
+func (o *IdemixIssuerPublicKeyImportOpts) Ephemeral() bool {
+	return o.Temporary
+}
+
+// IdemixUserSecretKeyGenOpts contains the options for the generation of an Idemix user secret key.
+type IdemixUserSecretKeyGenOpts struct {
+	Temporary bool
+}
+
+// Algorithm returns the key generation algorithm identifier (to be used).
+func (o *IdemixUserSecretKeyGenOpts) Algorithm() string {
+	return IDEMIX
+}
+
+// Ephemeral returns true if the key to generate has to be ephemeral,
+// false otherwise.
+func (o *IdemixUserSecretKeyGenOpts) Ephemeral() bool {
+	return o.Temporary
+}
+
+// IdemixUserSecretKeyImportOpts contains the options for importing of an Idemix user secret key.
+type IdemixUserSecretKeyImportOpts struct {
+	Temporary bool
+}
+
+// Algorithm returns the key importation algorithm identifier (to be used).
+func (o *IdemixUserSecretKeyImportOpts) Algorithm() string {
+	return IDEMIX
+}
+
+// Ephemeral returns true if the key to generate has to be ephemeral,
+// false otherwise.
+func (o *IdemixUserSecretKeyImportOpts) Ephemeral() bool {
+	return o.Temporary
+}
+
+// IdemixNymKeyDerivationOpts contains the options to create a new unlinkable pseudonym from a
+// user secret key with respect to the specified issuer public key
+type IdemixNymKeyDerivationOpts struct {
+	// Temporary tells if the key is ephemeral
+	Temporary bool
+	// IssuerPK is the public-key of the issuer
+	IssuerPK Key
+}
+
+// Algorithm returns the key derivation algorithm identifier (to be used).
+func (o *IdemixNymKeyDerivationOpts) Algorithm() string {
+	return IDEMIX
+}
+
+// Ephemeral returns true if the key to derive has to be ephemeral,
+// false otherwise.
+func (o *IdemixNymKeyDerivationOpts) Ephemeral() bool {
+	return o.Temporary
+}
+
+// IdemixNymPublicKeyImportOpts contains the options to import the public part of a pseudonym.
+// The raw material is the concatenation of the x and y coordinates of the pseudonym.
+type IdemixNymPublicKeyImportOpts struct {
+	// Temporary tells if the key is ephemeral
+	Temporary bool
+}
+
+// Algorithm returns the key importation algorithm identifier (to be used).
+func (o *IdemixNymPublicKeyImportOpts) Algorithm() string {
+	return IDEMIX
+}
+
+// Ephemeral returns true if the key to import has to be ephemeral,
+// false otherwise.
+func (o *IdemixNymPublicKeyImportOpts) Ephemeral() bool {
+	return o.Temporary
+}
+
+// IdemixCredentialRequestSignerOpts contains the options to create an Idemix credential request.
+// The credential request is created by signing the issuer nonce with the user secret key,
+// and it is verified with the issuer public key.
+type IdemixCredentialRequestSignerOpts struct {
+	// IssuerPK is the public-key of the issuer
+	IssuerPK Key
+}
+
+// HashFunc returns an identifier for the hash function used to produce
+// the message passed to Signer.Sign, or else zero to indicate that no
+// hashing was done.
+func (o *IdemixCredentialRequestSignerOpts) HashFunc() crypto.Hash {
+	return 0
+}
+
+// IdemixCredentialSignerOpts contains the options to produce a credential starting from a credential request.
+// The credential is created by signing the serialized credential request with the issuer secret key.
+type IdemixCredentialSignerOpts struct {
+	// Attributes to include in the credentials. IdemixHiddenAttribute is not allowed here
+	Attributes []IdemixAttribute
+}
+
+// HashFunc returns an identifier for the hash function used to produce
+// the message passed to Signer.Sign, or else zero to indicate that no
+// hashing was done.
+func (o *IdemixCredentialSignerOpts) HashFunc() crypto.Hash {
+	return 0
+}
+
+// IdemixSignerOpts contains the options to generate an Idemix signature.
+// The signature is created with the user secret key, and verified with the issuer public key.
+type IdemixSignerOpts struct {
+	// Nym is the pseudonym to be used
+	Nym Key
+	// IssuerPK is the public-key of the issuer
+	IssuerPK Key
+	// Credential is the byte representation of the credential signed by the issuer
+	Credential []byte
+	// Attributes specifies which attribute should be disclosed and which not.
+	// If Attributes[i].Type = IdemixHiddenAttribute
+	// then the i-th credential attribute should not be disclosed, otherwise the i-th
+	// credential attribute will be disclosed.
+	// At verification time, if the i-th attribute is disclosed (Attributes[i].Type != IdemixHiddenAttribute),
+	// then Attributes[i].Value must be set accordingly.
+	Attributes []IdemixAttribute
+	// RhIndex is the index of the attribute containing the revocation handle.
+	// Notice that this attribute cannot be disclosed
+	RhIndex int
+	// CRI contains the credential revocation information,
+	// nil if no revocation is performed
+	CRI []byte
+	// RevocationPublicKey is the long term revocation public key
+	// used at verification time, nil if revocation is not checked
+	RevocationPublicKey Key
+	// Epoch is the epoch in which the signature must prove non-revocation,
+	// used at verification time together with RevocationPublicKey
+	Epoch int
+}
+
+// HashFunc returns an identifier for the hash function used to produce
+// the message passed to Signer.Sign, or else zero to indicate that no
+// hashing was done.
+func (o *IdemixSignerOpts) HashFunc() crypto.Hash {
+	return 0
+}
+
+// IdemixNymSignerOpts contains the options to generate an idemix pseudonym signature.
+// The signature is created with the user secret key, and verified with the public part of the pseudonym.
+type IdemixNymSignerOpts struct {
+	// Nym is the pseudonym to be used
+	Nym Key
+	// IssuerPK is the public-key of the issuer
+	IssuerPK Key
+}
+
+// HashFunc returns an identifier for the hash function used to produce
+// the message passed to Signer.Sign, or else zero to indicate that no
+// hashing was done.
+func (o *IdemixNymSignerOpts) HashFunc() crypto.Hash {
+	return 0
+}
@@ -29,9 +29,16 @@ const (
 	// ECDSA Elliptic Curve Digital Signature Algorithm over P-384 curve
 	ECDSAP384 = "ECDSAP384"
 
+	// ECDSA Elliptic Curve Digital Signature Algorithm over secp256k1 curve
+	ECDSASECP256K1 = "ECDSASECP256K1"
+
 	// ECDSAReRand ECDSA key re-randomization
 	ECDSAReRand = "ECDSA_RERAND"
 
+	// ED25519 Edwards-curve Digital Signature Algorithm over Curve25519
+	// (key gen, import, sign, verify)
+	ED25519 = "ED25519"
+
 	// RSA at the default security level.
 	// Each BCCSP may or may not support default security level. If not supported than
 	// an error will be returned.
@@ -17,6 +17,7 @@ package pkcs11
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rsa"
 	"crypto/sha256"
@@ -309,7 +310,8 @@ func (csp *impl) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (k bccsp.K
 		ecPt := elliptic.Marshal(ecdsaPK.Curve, ecdsaPK.X, ecdsaPK.Y)
 		oid, ok := oidFromNamedCurve(ecdsaPK.Curve)
 		if !ok {
-			return nil, errors.New("Do not know OID for this Curve.")
+			// Keys on curves not supported by the token, such as secp256k1, are handled in software
+			return csp.BCCSP.KeyImport(raw, opts)
 		}
 
 		var ski []byte
@@ -361,7 +363,8 @@ func (csp *impl) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (k bccsp.K
 		ecPt := elliptic.Marshal(ecdsaSK.Curve, ecdsaSK.X, ecdsaSK.Y)
 		oid, ok := oidFromNamedCurve(ecdsaSK.Curve)
 		if !ok {
-			return nil, errors.New("Do not know OID for this Curve.")
+			// Keys on curves not supported by the token, such as secp256k1, are handled in software
+			return csp.BCCSP.KeyImport(raw, opts)
 		}
 
 		ski, err := csp.importECKey(oid, ecdsaSK.D.Bytes(), ecPt, opts.Ephemeral(), privateKeyFlag)
@@ -381,7 +384,8 @@ func (csp *impl) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (k bccsp.K
 		ecPt := elliptic.Marshal(lowLevelKey.Curve, lowLevelKey.X, lowLevelKey.Y)
 		oid, ok := oidFromNamedCurve(lowLevelKey.Curve)
 		if !ok {
-			return nil, errors.New("Do not know OID for this Curve.")
+			// Keys on curves not supported by the token, such as secp256k1, are handled in software
+			return csp.BCCSP.KeyImport(raw, opts)
 		}
 
 		var ski []byte
@@ -419,8 +423,10 @@ func (csp *impl) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (k bccsp.K
 			return csp.KeyImport(pk, &bccsp.ECDSAGoPublicKeyImportOpts{Temporary: opts.Ephemeral()})
 		case *rsa.PublicKey:
 			return csp.KeyImport(pk, &bccsp.RSAGoPublicKeyImportOpts{Temporary: opts.Ephemeral()})
+		case ed25519.PublicKey:
+			return csp.BCCSP.KeyImport(pk, &bccsp.ED25519GoPublicKeyImportOpts{Temporary: opts.Ephemeral()})
 		default:
-			return nil, errors.New("Certificate's public key type not recognized. Supported keys: [ECDSA, RSA]")
+			return nil, errors.New("Certificate's public key type not recognized. Supported keys: [ECDSA, RSA, ED25519]")
 		}
 
 	default:
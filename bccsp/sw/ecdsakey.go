@@ -17,7 +17,6 @@ package sw
 
 import (
 	"crypto/ecdsa"
-	"crypto/x509"
 	"fmt"
 
 	"crypto/sha256"
@@ -27,6 +26,7 @@ import (
 	"crypto/elliptic"
 
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/utils"
 )
 
 type ecdsaPrivateKey struct {
@@ -79,7 +79,7 @@ type ecdsaPublicKey struct {
 // Bytes converts this key to its byte representation,
 // if this operation is allowed.
 func (k *ecdsaPublicKey) Bytes() (raw []byte, err error) {
-	raw, err = x509.MarshalPKIXPublicKey(k.pubKey)
+	raw, err = utils.PublicKeyToDER(k.pubKey)
 	if err != nil {
 		return nil, fmt.Errorf("Failed marshalling key [%s]", err)
 	}
@@ -0,0 +1,34 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"crypto/ed25519"
+
+	"github.com/hyperledger/fabric/bccsp"
+)
+
+// Ed25519 signs the passed bytes as they are: Ed25519 hashes the message
+// internally, so the caller can pass either the message or its digest.
+
+type ed25519Signer struct{}
+
+func (s *ed25519Signer) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) (signature []byte, err error) {
+	return ed25519.Sign(k.(*ed25519PrivateKey).privKey, digest), nil
+}
+
+type ed25519PrivateKeyVerifier struct{}
+
+func (v *ed25519PrivateKeyVerifier) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (valid bool, err error) {
+	return ed25519.Verify(k.(*ed25519PrivateKey).privKey.Public().(ed25519.PublicKey), digest, signature), nil
+}
+
+type ed25519PublicKeyKeyVerifier struct{}
+
+func (v *ed25519PublicKeyKeyVerifier) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (valid bool, err error) {
+	return ed25519.Verify(k.(*ed25519PublicKey).pubKey, digest, signature), nil
+}
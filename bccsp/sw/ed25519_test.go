@@ -0,0 +1,156 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestED25519(t *testing.T) {
+	ksPath, err := ioutil.TempDir("", "bccspks")
+	assert.NoError(t, err)
+	defer os.RemoveAll(ksPath)
+	ks, err := NewFileBasedKeyStore(nil, ksPath, false)
+	assert.NoError(t, err)
+	csp, err := NewDefaultSecurityLevelWithKeystore(ks)
+	assert.NoError(t, err)
+
+	k, err := csp.KeyGen(&bccsp.ED25519KeyGenOpts{})
+	assert.NoError(t, err)
+	assert.True(t, k.Private())
+	assert.False(t, k.Symmetric())
+	_, err = k.Bytes()
+	assert.Error(t, err)
+
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+	assert.Equal(t, k.SKI(), pk.SKI())
+
+	// Sign and verify
+	digest := []byte("hello world")
+	signature, err := csp.Sign(k, digest, nil)
+	assert.NoError(t, err)
+	valid, err := csp.Verify(k, signature, digest, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	valid, err = csp.Verify(pk, signature, digest, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	valid, err = csp.Verify(pk, signature, []byte("another message"), nil)
+	assert.NoError(t, err)
+	assert.False(t, valid)
+
+	// The private key has been stored in the key store
+	k2, err := csp.GetKey(k.SKI())
+	assert.NoError(t, err)
+	assert.True(t, k2.Private())
+	signature, err = csp.Sign(k2, digest, nil)
+	assert.NoError(t, err)
+	valid, err = csp.Verify(pk, signature, digest, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// Import the public key
+	pkRaw, err := pk.Bytes()
+	assert.NoError(t, err)
+	pk2, err := csp.KeyImport(pkRaw, &bccsp.ED25519PKIXPublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, pk.SKI(), pk2.SKI())
+	_, err = csp.KeyImport([]byte{1, 2, 3}, &bccsp.ED25519PKIXPublicKeyImportOpts{Temporary: true})
+	assert.Error(t, err)
+	_, err = csp.KeyImport(ed25519.PublicKey{1, 2, 3}, &bccsp.ED25519GoPublicKeyImportOpts{Temporary: true})
+	assert.Error(t, err)
+
+	// Import the private key
+	_, sk, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	skRaw, err := x509.MarshalPKCS8PrivateKey(sk)
+	assert.NoError(t, err)
+	k3, err := csp.KeyImport(skRaw, &bccsp.ED25519PrivateKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	pk3, err := k3.PublicKey()
+	assert.NoError(t, err)
+	assert.Equal(t, ed25519SKI(sk.Public().(ed25519.PublicKey)), pk3.SKI())
+
+	// Import a certificate
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "ed25519"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certRaw, err := x509.CreateCertificate(rand.Reader, template, template, sk.Public(), sk)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(certRaw)
+	assert.NoError(t, err)
+	certPK, err := csp.KeyImport(cert, &bccsp.X509PublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, pk3.SKI(), certPK.SKI())
+}
+
+func TestSecp256k1(t *testing.T) {
+	ksPath, err := ioutil.TempDir("", "bccspks")
+	assert.NoError(t, err)
+	defer os.RemoveAll(ksPath)
+	ks, err := NewFileBasedKeyStore(nil, ksPath, false)
+	assert.NoError(t, err)
+	csp, err := NewDefaultSecurityLevelWithKeystore(ks)
+	assert.NoError(t, err)
+
+	k, err := csp.KeyGen(&bccsp.ECDSASecp256k1KeyGenOpts{})
+	assert.NoError(t, err)
+	assert.Equal(t, utils.Secp256k1(), k.(*ecdsaPrivateKey).privKey.Curve)
+
+	digest, err := csp.Hash([]byte("hello world"), &bccsp.SHA256Opts{})
+	assert.NoError(t, err)
+	signature, err := csp.Sign(k, digest, nil)
+	assert.NoError(t, err)
+
+	pk, err := k.PublicKey()
+	assert.NoError(t, err)
+	valid, err := csp.Verify(pk, signature, digest, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// The private key has been stored in the key store
+	k2, err := csp.GetKey(k.SKI())
+	assert.NoError(t, err)
+	signature, err = csp.Sign(k2, digest, nil)
+	assert.NoError(t, err)
+	valid, err = csp.Verify(pk, signature, digest, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// Export and import the public key
+	pkRaw, err := pk.Bytes()
+	assert.NoError(t, err)
+	pk2, err := csp.KeyImport(pkRaw, &bccsp.ECDSAPKIXPublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, pk.SKI(), pk2.SKI())
+	valid, err = csp.Verify(pk2, signature, digest, nil)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	// Import the private key
+	skRaw, err := utils.PrivateKeyToDER(k.(*ecdsaPrivateKey).privKey)
+	assert.NoError(t, err)
+	k3, err := csp.KeyImport(skRaw, &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, k.SKI(), k3.SKI())
+}
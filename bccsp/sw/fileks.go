@@ -25,15 +25,65 @@ import (
 	"strings"
 
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"encoding/hex"
 	"fmt"
 	"path/filepath"
 
+	"reflect"
+
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/milagro-crypto/amcl/version3/go/amcl/FP256BN"
 )
 
+// idemixKeyFile describes how the keys of an idemix key type are stored
+type idemixKeyFile struct {
+	suffix  string
+	pemType string
+	// private keys are preferred to the public keys sharing their SKI
+	private   bool
+	marshal   func(k bccsp.Key) ([]byte, error)
+	unmarshal func(raw []byte) (bccsp.Key, error)
+}
+
+// idemixKeyFiles maps the idemix key types to the files storing them
+var idemixKeyFiles = map[reflect.Type]*idemixKeyFile{
+	reflect.TypeOf(&idemixIssuerSecretKey{}): {
+		suffix: "idemix_isk", pemType: "IDEMIX ISSUER SECRET KEY", private: true,
+		marshal: marshalIdemixIssuerSecretKey, unmarshal: unmarshalIdemixIssuerSecretKey,
+	},
+	reflect.TypeOf(&idemixUserSecretKey{}): {
+		suffix: "idemix_usk", pemType: "IDEMIX USER SECRET KEY", private: true,
+		marshal: marshalIdemixUserSecretKey, unmarshal: unmarshalIdemixUserSecretKey,
+	},
+	reflect.TypeOf(&idemixNymSecretKey{}): {
+		suffix: "idemix_nsk", pemType: "IDEMIX NYM SECRET KEY", private: true,
+		marshal: marshalIdemixNymSecretKey, unmarshal: unmarshalIdemixNymSecretKey,
+	},
+	reflect.TypeOf(&idemixIssuerPublicKey{}): {
+		suffix: "idemix_ipk", pemType: "IDEMIX ISSUER PUBLIC KEY",
+		marshal: marshalIdemixIssuerPublicKey, unmarshal: unmarshalIdemixIssuerPublicKey,
+	},
+	reflect.TypeOf(&idemixNymPublicKey{}): {
+		suffix: "idemix_npk", pemType: "IDEMIX NYM PUBLIC KEY",
+		marshal: marshalIdemixNymPublicKey, unmarshal: unmarshalIdemixNymPublicKey,
+	},
+}
+
+// idemixKeyFileBySuffix returns the idemix key file with the supplied suffix, if any
+func idemixKeyFileBySuffix(suffix string) *idemixKeyFile {
+	for _, keyFile := range idemixKeyFiles {
+		if keyFile.suffix == suffix {
+			return keyFile
+		}
+	}
+	return nil
+}
+
 // NewFileBasedKeyStore instantiated a file-based key store at a given position.
 // The key store can be encrypted if a non-empty password is specifiec.
 // It can be also be set as read only. In this case, any store operation
@@ -141,6 +191,8 @@ func (ks *fileBasedKeyStore) GetKey(ski []byte) (k bccsp.Key, err error) {
 			return &ecdsaPrivateKey{key.(*ecdsa.PrivateKey)}, nil
 		case *rsa.PrivateKey:
 			return &rsaPrivateKey{key.(*rsa.PrivateKey)}, nil
+		case ed25519.PrivateKey:
+			return &ed25519PrivateKey{key.(ed25519.PrivateKey)}, nil
 		default:
 			return nil, errors.New("Secret key type not recognized")
 		}
@@ -156,10 +208,20 @@ func (ks *fileBasedKeyStore) GetKey(ski []byte) (k bccsp.Key, err error) {
 			return &ecdsaPublicKey{key.(*ecdsa.PublicKey)}, nil
 		case *rsa.PublicKey:
 			return &rsaPublicKey{key.(*rsa.PublicKey)}, nil
+		case ed25519.PublicKey:
+			return &ed25519PublicKey{key.(ed25519.PublicKey)}, nil
 		default:
 			return nil, errors.New("Public key type not recognized")
 		}
 	default:
+		if keyFile := idemixKeyFileBySuffix(suffix); keyFile != nil {
+			key, err := ks.loadIdemixKey(hex.EncodeToString(ski), keyFile)
+			if err != nil {
+				return nil, fmt.Errorf("Failed loading idemix key [%x] [%s]", ski, err)
+			}
+			return key, nil
+		}
+
 		return ks.searchKeystoreForSKI(ski)
 	}
 }
@@ -207,6 +269,22 @@ func (ks *fileBasedKeyStore) StoreKey(k bccsp.Key) (err error) {
 			return fmt.Errorf("Failed storing RSA public key [%s]", err)
 		}
 
+	case *ed25519PrivateKey:
+		kk := k.(*ed25519PrivateKey)
+
+		err = ks.storePrivateKey(hex.EncodeToString(k.SKI()), kk.privKey)
+		if err != nil {
+			return fmt.Errorf("Failed storing ED25519 private key [%s]", err)
+		}
+
+	case *ed25519PublicKey:
+		kk := k.(*ed25519PublicKey)
+
+		err = ks.storePublicKey(hex.EncodeToString(k.SKI()), kk.pubKey)
+		if err != nil {
+			return fmt.Errorf("Failed storing ED25519 public key [%s]", err)
+		}
+
 	case *aesPrivateKey:
 		kk := k.(*aesPrivateKey)
 
@@ -215,6 +293,12 @@ func (ks *fileBasedKeyStore) StoreKey(k bccsp.Key) (err error) {
 			return fmt.Errorf("Failed storing AES key [%s]", err)
 		}
 
+	case *idemixIssuerSecretKey, *idemixIssuerPublicKey, *idemixUserSecretKey, *idemixNymSecretKey, *idemixNymPublicKey:
+		err = ks.storeIdemixKey(k)
+		if err != nil {
+			return fmt.Errorf("Failed storing idemix key [%s]", err)
+		}
+
 	default:
 		return fmt.Errorf("Key type not reconigned [%s]", k)
 	}
@@ -249,6 +333,8 @@ func (ks *fileBasedKeyStore) searchKeystoreForSKI(ski []byte) (k bccsp.Key, err
 			k = &ecdsaPrivateKey{key.(*ecdsa.PrivateKey)}
 		case *rsa.PrivateKey:
 			k = &rsaPrivateKey{key.(*rsa.PrivateKey)}
+		case ed25519.PrivateKey:
+			k = &ed25519PrivateKey{key.(ed25519.PrivateKey)}
 		default:
 			continue
 		}
@@ -264,8 +350,20 @@ func (ks *fileBasedKeyStore) searchKeystoreForSKI(ski []byte) (k bccsp.Key, err
 
 func (ks *fileBasedKeyStore) getSuffix(alias string) string {
 	files, _ := ioutil.ReadDir(ks.path)
+	idemixSuffix := ""
 	for _, f := range files {
 		if strings.HasPrefix(f.Name(), alias) {
+			// An idemix secret key may share its SKI with an idemix public key
+			if keyFile := idemixKeyFileBySuffix(strings.TrimPrefix(f.Name(), alias+"_")); keyFile != nil {
+				if keyFile.private {
+					return keyFile.suffix
+				}
+				idemixSuffix = keyFile.suffix
+				continue
+			}
+			if idemixSuffix != "" {
+				break
+			}
 			if strings.HasSuffix(f.Name(), "sk") {
 				return "sk"
 			}
@@ -278,7 +376,7 @@ func (ks *fileBasedKeyStore) getSuffix(alias string) string {
 			break
 		}
 	}
-	return ""
+	return idemixSuffix
 }
 
 func (ks *fileBasedKeyStore) storePrivateKey(alias string, privateKey interface{}) error {
@@ -329,6 +427,148 @@ func (ks *fileBasedKeyStore) storeKey(alias string, key []byte) error {
 	return nil
 }
 
+// storeIdemixKey stores an idemix key in a PEM block, which is encrypted if
+// the KeyStore has a password
+func (ks *fileBasedKeyStore) storeIdemixKey(k bccsp.Key) error {
+	keyFile, ok := idemixKeyFiles[reflect.TypeOf(k)]
+	if !ok {
+		return fmt.Errorf("Key type not reconigned [%s]", k)
+	}
+	raw, err := keyFile.marshal(k)
+	if err != nil {
+		logger.Errorf("Failed serializing idemix key: [%s]", err)
+		return err
+	}
+	alias := hex.EncodeToString(k.SKI())
+
+	pemBytes, err := utils.RawKeyToPEM(raw, keyFile.pemType, ks.pwd)
+	if err != nil {
+		logger.Errorf("Failed converting idemix key to PEM [%s]: [%s]", alias, err)
+		return err
+	}
+
+	err = ioutil.WriteFile(ks.getPathForAlias(alias, keyFile.suffix), pemBytes, 0700)
+	if err != nil {
+		logger.Errorf("Failed storing idemix key [%s]: [%s]", alias, err)
+		return err
+	}
+
+	return nil
+}
+
+func (ks *fileBasedKeyStore) loadIdemixKey(alias string, keyFile *idemixKeyFile) (bccsp.Key, error) {
+	path := ks.getPathForAlias(alias, keyFile.suffix)
+	logger.Debugf("Loading idemix key [%s] at [%s]...", alias, path)
+
+	pemBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		logger.Errorf("Failed loading idemix key [%s]: [%s].", alias, err.Error())
+
+		return nil, err
+	}
+
+	raw, err := utils.PEMtoRawKey(pemBytes, keyFile.pemType, ks.pwd)
+	if err != nil {
+		logger.Errorf("Failed parsing idemix key [%s]: [%s]", alias, err)
+
+		return nil, err
+	}
+
+	return keyFile.unmarshal(raw)
+}
+
+func marshalIdemixIssuerSecretKey(k bccsp.Key) ([]byte, error) {
+	isk := k.(*idemixIssuerSecretKey).isk
+	if isk == nil {
+		return nil, errors.New("Invalid idemix issuer secret key. It must be different from nil.")
+	}
+	return proto.Marshal(isk)
+}
+
+func unmarshalIdemixIssuerSecretKey(raw []byte) (bccsp.Key, error) {
+	isk := &idemix.IssuerKey{}
+	if err := proto.Unmarshal(raw, isk); err != nil {
+		return nil, fmt.Errorf("Failed unmarshalling issuer secret key [%s]", err)
+	}
+	ipkBytes, err := proto.Marshal(isk.IPk)
+	if err != nil {
+		return nil, err
+	}
+	isk.IPk, err = importIssuerPublicKey(ipkBytes, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &idemixIssuerSecretKey{isk}, nil
+}
+
+func marshalIdemixUserSecretKey(k bccsp.Key) ([]byte, error) {
+	sk := k.(*idemixUserSecretKey).sk
+	if sk == nil {
+		return nil, errors.New("Invalid idemix user secret key. It must be different from nil.")
+	}
+	return idemix.BigToBytes(sk), nil
+}
+
+func unmarshalIdemixUserSecretKey(raw []byte) (bccsp.Key, error) {
+	if len(raw) != idemix.FieldBytes {
+		return nil, errors.New("Invalid idemix user secret key length")
+	}
+	return &idemixUserSecretKey{FP256BN.FromBytes(raw)}, nil
+}
+
+// marshalIdemixNymSecretKey encodes a pseudonym secret key as the user secret
+// key, the randomness and the pseudonym
+func marshalIdemixNymSecretKey(k bccsp.Key) ([]byte, error) {
+	kk := k.(*idemixNymSecretKey)
+	if kk.sk == nil || kk.rNym == nil || kk.nym == nil {
+		return nil, errors.New("Invalid idemix pseudonym secret key. It must be different from nil.")
+	}
+	return append(append(idemix.BigToBytes(kk.sk), idemix.BigToBytes(kk.rNym)...), nymBytes(kk.nym)...), nil
+}
+
+func unmarshalIdemixNymSecretKey(raw []byte) (bccsp.Key, error) {
+	if len(raw) != 4*idemix.FieldBytes {
+		return nil, errors.New("Invalid idemix pseudonym secret key length")
+	}
+	nymKey, err := (&idemixNymPublicKeyImporter{}).KeyImport(raw[2*idemix.FieldBytes:], nil)
+	if err != nil {
+		return nil, err
+	}
+	return &idemixNymSecretKey{
+		sk:   FP256BN.FromBytes(raw[:idemix.FieldBytes]),
+		rNym: FP256BN.FromBytes(raw[idemix.FieldBytes : 2*idemix.FieldBytes]),
+		nym:  nymKey.(*idemixNymPublicKey).nym,
+	}, nil
+}
+
+func marshalIdemixIssuerPublicKey(k bccsp.Key) ([]byte, error) {
+	ipk := k.(*idemixIssuerPublicKey).ipk
+	if ipk == nil {
+		return nil, errors.New("Invalid idemix issuer public key. It must be different from nil.")
+	}
+	return proto.Marshal(ipk)
+}
+
+func unmarshalIdemixIssuerPublicKey(raw []byte) (bccsp.Key, error) {
+	ipk, err := importIssuerPublicKey(raw, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &idemixIssuerPublicKey{ipk}, nil
+}
+
+func marshalIdemixNymPublicKey(k bccsp.Key) ([]byte, error) {
+	nym := k.(*idemixNymPublicKey).nym
+	if nym == nil {
+		return nil, errors.New("Invalid idemix pseudonym public key. It must be different from nil.")
+	}
+	return nymBytes(nym), nil
+}
+
+func unmarshalIdemixNymPublicKey(raw []byte) (bccsp.Key, error) {
+	return (&idemixNymPublicKeyImporter{}).KeyImport(raw, nil)
+}
+
 func (ks *fileBasedKeyStore) loadPrivateKey(alias string) (interface{}, error) {
 	path := ks.getPathForAlias(alias, "sk")
 	logger.Debugf("Loading private key [%s] at [%s]...", alias, path)
@@ -28,6 +28,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/utils"
 )
 
@@ -78,6 +79,10 @@ func TestInvalidStoreKey(t *testing.T) {
 	if err == nil {
 		t.Fatal("Error should be different from nil in this case")
 	}
+
+	for _, k := range []bccsp.Key{&idemixIssuerSecretKey{}, &idemixIssuerPublicKey{}, &idemixUserSecretKey{}, &idemixNymSecretKey{}, &idemixNymPublicKey{}} {
+		assert.Error(t, ks.StoreKey(k))
+	}
 }
 
 func TestBigKeyFile(t *testing.T) {
@@ -0,0 +1,460 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/milagro-crypto/amcl/version3/go/amcl/FP256BN"
+	"github.com/pkg/errors"
+)
+
+type idemixIssuerKeyGenerator struct{}
+
+func (kg *idemixIssuerKeyGenerator) KeyGen(opts bccsp.KeyGenOpts) (k bccsp.Key, err error) {
+	issuerOpts, ok := opts.(*bccsp.IdemixIssuerKeyGenOpts)
+	if !ok {
+		return nil, errors.New("invalid options, expected *bccsp.IdemixIssuerKeyGenOpts")
+	}
+
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed getting PRNG")
+	}
+
+	isk, err := idemix.NewIssuerKey(issuerOpts.AttributeNames, rng)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed generating issuer key")
+	}
+
+	return &idemixIssuerSecretKey{isk}, nil
+}
+
+type idemixUserSecretKeyGenerator struct{}
+
+func (kg *idemixUserSecretKeyGenerator) KeyGen(opts bccsp.KeyGenOpts) (k bccsp.Key, err error) {
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed getting PRNG")
+	}
+
+	return &idemixUserSecretKey{idemix.RandModOrder(rng)}, nil
+}
+
+type idemixIssuerKeyImporter struct{}
+
+func (ki *idemixIssuerKeyImporter) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (k bccsp.Key, err error) {
+	issuerOpts, ok := opts.(*bccsp.IdemixIssuerKeyImportOpts)
+	if !ok {
+		return nil, errors.New("invalid options, expected *bccsp.IdemixIssuerKeyImportOpts")
+	}
+
+	der, ok := raw.([]byte)
+	if !ok {
+		return nil, errors.New("invalid raw material, expected byte array")
+	}
+	if len(der) != idemix.FieldBytes {
+		return nil, errors.Errorf("invalid issuer secret key, expected %d bytes", idemix.FieldBytes)
+	}
+
+	ipk, err := importIssuerPublicKey(issuerOpts.PublicKey, issuerOpts.AttributeNames)
+	if err != nil {
+		return nil, err
+	}
+
+	return &idemixIssuerSecretKey{&idemix.IssuerKey{ISk: der, IPk: ipk}}, nil
+}
+
+type idemixIssuerPublicKeyImporter struct{}
+
+func (ki *idemixIssuerPublicKeyImporter) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (k bccsp.Key, err error) {
+	issuerOpts, ok := opts.(*bccsp.IdemixIssuerPublicKeyImportOpts)
+	if !ok {
+		return nil, errors.New("invalid options, expected *bccsp.IdemixIssuerPublicKeyImportOpts")
+	}
+
+	der, ok := raw.([]byte)
+	if !ok {
+		return nil, errors.New("invalid raw material, expected byte array")
+	}
+
+	ipk, err := importIssuerPublicKey(der, issuerOpts.AttributeNames)
+	if err != nil {
+		return nil, err
+	}
+
+	return &idemixIssuerPublicKey{ipk}, nil
+}
+
+// importIssuerPublicKey unmarshals and checks a serialized issuer public key
+func importIssuerPublicKey(raw []byte, attributeNames []string) (*idemix.IssuerPublicKey, error) {
+	if len(raw) == 0 {
+		return nil, errors.New("invalid issuer public key, it must not be empty")
+	}
+
+	ipk := &idemix.IssuerPublicKey{}
+	err := proto.Unmarshal(raw, ipk)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal issuer public key")
+	}
+	err = ipk.SetHash()
+	if err != nil {
+		return nil, errors.WithMessage(err, "setting the hash of the issuer public key failed")
+	}
+	err = ipk.Check()
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid issuer public key")
+	}
+
+	if len(attributeNames) != 0 {
+		if len(ipk.AttributeNames) != len(attributeNames) {
+			return nil, errors.Errorf("invalid issuer public key: expected %d attributes, got %d", len(attributeNames), len(ipk.AttributeNames))
+		}
+		for i, name := range attributeNames {
+			if ipk.AttributeNames[i] != name {
+				return nil, errors.Errorf("invalid issuer public key: expected attribute %s at index %d, got %s", name, i, ipk.AttributeNames[i])
+			}
+		}
+	}
+
+	return ipk, nil
+}
+
+type idemixUserSecretKeyImporter struct{}
+
+func (ki *idemixUserSecretKeyImporter) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (k bccsp.Key, err error) {
+	der, ok := raw.([]byte)
+	if !ok {
+		return nil, errors.New("invalid raw material, expected byte array")
+	}
+	if len(der) != idemix.FieldBytes {
+		return nil, errors.Errorf("invalid user secret key, expected %d bytes", idemix.FieldBytes)
+	}
+
+	return &idemixUserSecretKey{FP256BN.FromBytes(der)}, nil
+}
+
+type idemixNymPublicKeyImporter struct{}
+
+func (ki *idemixNymPublicKeyImporter) KeyImport(raw interface{}, opts bccsp.KeyImportOpts) (k bccsp.Key, err error) {
+	der, ok := raw.([]byte)
+	if !ok {
+		return nil, errors.New("invalid raw material, expected byte array")
+	}
+	if len(der) != 2*idemix.FieldBytes {
+		return nil, errors.Errorf("invalid pseudonym, expected %d bytes", 2*idemix.FieldBytes)
+	}
+
+	nym := FP256BN.NewECPbigs(FP256BN.FromBytes(der[:idemix.FieldBytes]), FP256BN.FromBytes(der[idemix.FieldBytes:]))
+	if nym.Is_infinity() {
+		return nil, errors.New("invalid pseudonym, it is not a point on the curve")
+	}
+
+	return &idemixNymPublicKey{nym}, nil
+}
+
+type idemixUserSecretKeyKeyDeriver struct{}
+
+func (kd *idemixUserSecretKeyKeyDeriver) KeyDeriv(k bccsp.Key, opts bccsp.KeyDerivOpts) (dk bccsp.Key, err error) {
+	nymOpts, ok := opts.(*bccsp.IdemixNymKeyDerivationOpts)
+	if !ok {
+		return nil, errors.Errorf("Unsupported 'KeyDerivOpts' provided [%v]", opts)
+	}
+
+	ipk, err := issuerPublicKey(nymOpts.IssuerPK)
+	if err != nil {
+		return nil, err
+	}
+
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed getting PRNG")
+	}
+
+	sk := k.(*idemixUserSecretKey).sk
+	nym, rNym := idemix.MakeNym(sk, ipk, rng)
+
+	return &idemixNymSecretKey{sk: sk, nym: nym, rNym: rNym}, nil
+}
+
+type idemixUserSecretKeySigner struct{}
+
+func (s *idemixUserSecretKeySigner) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) (signature []byte, err error) {
+	sk := k.(*idemixUserSecretKey).sk
+
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed getting PRNG")
+	}
+
+	switch o := opts.(type) {
+	case *bccsp.IdemixCredentialRequestSignerOpts:
+		ipk, err := issuerPublicKey(o.IssuerPK)
+		if err != nil {
+			return nil, err
+		}
+		if len(digest) != idemix.FieldBytes {
+			return nil, errors.Errorf("invalid issuer nonce, expected %d bytes", idemix.FieldBytes)
+		}
+
+		// The credential request does not blind the commitment to the user secret key,
+		// so that the issued credential can be used as is
+		credRequest := idemix.NewCredRequest(sk, FP256BN.NewBIGint(0), FP256BN.FromBytes(digest), ipk, rng)
+		return proto.Marshal(credRequest)
+
+	case *bccsp.IdemixSignerOpts:
+		ipk, err := issuerPublicKey(o.IssuerPK)
+		if err != nil {
+			return nil, err
+		}
+		nym, ok := o.Nym.(*idemixNymSecretKey)
+		if !ok {
+			return nil, errors.New("invalid nym key, expected an idemix pseudonym secret key")
+		}
+
+		cred := &idemix.Credential{}
+		err = proto.Unmarshal(o.Credential, cred)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal credential")
+		}
+		err = cred.Ver(sk, ipk)
+		if err != nil {
+			return nil, errors.WithMessage(err, "credential is not cryptographically valid")
+		}
+
+		var cri *idemix.CredentialRevocationInformation
+		if len(o.CRI) != 0 {
+			cri = &idemix.CredentialRevocationInformation{}
+			err = proto.Unmarshal(o.CRI, cri)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to unmarshal credential revocation information")
+			}
+		}
+
+		disclosure, _, err := idemixDisclosure(o.Attributes, false)
+		if err != nil {
+			return nil, err
+		}
+
+		sig, err := idemix.NewSignature(cred, sk, nym.nym, nym.rNym, ipk, disclosure, digest, o.RhIndex, cri, rng)
+		if err != nil {
+			return nil, err
+		}
+		return proto.Marshal(sig)
+
+	case *bccsp.IdemixNymSignerOpts:
+		ipk, err := issuerPublicKey(o.IssuerPK)
+		if err != nil {
+			return nil, err
+		}
+		nym, ok := o.Nym.(*idemixNymSecretKey)
+		if !ok {
+			return nil, errors.New("invalid nym key, expected an idemix pseudonym secret key")
+		}
+
+		sig, err := idemix.NewNymSignature(sk, nym.nym, nym.rNym, ipk, digest, rng)
+		if err != nil {
+			return nil, err
+		}
+		return proto.Marshal(sig)
+
+	default:
+		return nil, errors.Errorf("unsupported 'SignerOpts' provided [%v]", opts)
+	}
+}
+
+type idemixIssuerSecretKeySigner struct{}
+
+func (s *idemixIssuerSecretKeySigner) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) (signature []byte, err error) {
+	credOpts, ok := opts.(*bccsp.IdemixCredentialSignerOpts)
+	if !ok {
+		return nil, errors.Errorf("unsupported 'SignerOpts' provided [%v]", opts)
+	}
+
+	credRequest := &idemix.CredRequest{}
+	err = proto.Unmarshal(digest, credRequest)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal credential request")
+	}
+
+	attrs := make([]*FP256BN.BIG, len(credOpts.Attributes))
+	for i, attr := range credOpts.Attributes {
+		attrs[i], err = idemixAttributeValue(attr)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid attribute")
+		}
+	}
+
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed getting PRNG")
+	}
+
+	cred, err := idemix.NewCredential(k.(*idemixIssuerSecretKey).isk, credRequest, attrs, rng)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to issue a credential")
+	}
+	return proto.Marshal(cred)
+}
+
+type idemixIssuerPublicKeyVerifier struct{}
+
+func (v *idemixIssuerPublicKeyVerifier) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (valid bool, err error) {
+	ipk := k.(*idemixIssuerPublicKey).ipk
+
+	switch o := opts.(type) {
+	case *bccsp.IdemixCredentialRequestSignerOpts:
+		credRequest := &idemix.CredRequest{}
+		err = proto.Unmarshal(signature, credRequest)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to unmarshal credential request")
+		}
+		if !bytes.Equal(credRequest.IssuerNonce, digest) {
+			return false, errors.New("credential request is for another issuer nonce")
+		}
+		err = credRequest.Check(ipk)
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+
+	case *bccsp.IdemixSignerOpts:
+		sig := &idemix.Signature{}
+		err = proto.Unmarshal(signature, sig)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to unmarshal signature")
+		}
+
+		var revocationPK *ecdsa.PublicKey
+		if o.RevocationPublicKey != nil {
+			revocationPK, err = revocationPublicKey(o.RevocationPublicKey)
+			if err != nil {
+				return false, err
+			}
+		}
+
+		disclosure, attributeValues, err := idemixDisclosure(o.Attributes, true)
+		if err != nil {
+			return false, err
+		}
+
+		err = sig.Ver(disclosure, ipk, digest, attributeValues, o.RhIndex, revocationPK, o.Epoch)
+		if err != nil {
+			return false, err
+		}
+		return true, nil
+
+	default:
+		return false, errors.Errorf("unsupported 'SignerOpts' provided [%v]", opts)
+	}
+}
+
+type idemixNymPublicKeyVerifier struct{}
+
+func (v *idemixNymPublicKeyVerifier) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.SignerOpts) (valid bool, err error) {
+	nymOpts, ok := opts.(*bccsp.IdemixNymSignerOpts)
+	if !ok {
+		return false, errors.Errorf("unsupported 'SignerOpts' provided [%v]", opts)
+	}
+
+	ipk, err := issuerPublicKey(nymOpts.IssuerPK)
+	if err != nil {
+		return false, err
+	}
+
+	sig := &idemix.NymSignature{}
+	err = proto.Unmarshal(signature, sig)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to unmarshal signature")
+	}
+
+	err = sig.Ver(k.(*idemixNymPublicKey).nym, ipk, digest)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// issuerPublicKey returns the idemix issuer public key contained in k
+func issuerPublicKey(k bccsp.Key) (*idemix.IssuerPublicKey, error) {
+	switch key := k.(type) {
+	case *idemixIssuerPublicKey:
+		return key.ipk, nil
+	case *idemixIssuerSecretKey:
+		return key.isk.IPk, nil
+	default:
+		return nil, errors.New("invalid issuer public key, expected an idemix issuer key")
+	}
+}
+
+// revocationPublicKey returns the ECDSA public key contained in k.
+// Keys of other providers are converted from their PKIX representation.
+func revocationPublicKey(k bccsp.Key) (*ecdsa.PublicKey, error) {
+	if pk, ok := k.(*ecdsaPublicKey); ok {
+		return pk.pubKey, nil
+	}
+
+	raw, err := k.Bytes()
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid revocation public key")
+	}
+	pk, err := utils.DERToPublicKey(raw)
+	if err != nil {
+		return nil, errors.WithMessage(err, "invalid revocation public key")
+	}
+	ecdsaPK, ok := pk.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.New("invalid revocation public key, expected an ECDSA public key")
+	}
+	return ecdsaPK, nil
+}
+
+// idemixAttributeValue returns the value of a disclosed attribute
+func idemixAttributeValue(attr bccsp.IdemixAttribute) (*FP256BN.BIG, error) {
+	switch attr.Type {
+	case bccsp.IdemixBytesAttribute:
+		value, ok := attr.Value.([]byte)
+		if !ok {
+			return nil, errors.New("expected a byte array attribute value")
+		}
+		return idemix.HashModOrder(value), nil
+	case bccsp.IdemixIntAttribute:
+		value, ok := attr.Value.(int)
+		if !ok {
+			return nil, errors.New("expected an int attribute value")
+		}
+		return FP256BN.NewBIGint(value), nil
+	default:
+		return nil, errors.Errorf("attribute type %d has no value", attr.Type)
+	}
+}
+
+// idemixDisclosure returns the disclosure flags corresponding to attributes and,
+// if withValues is true, the values of the disclosed attributes
+func idemixDisclosure(attributes []bccsp.IdemixAttribute, withValues bool) ([]byte, []*FP256BN.BIG, error) {
+	disclosure := make([]byte, len(attributes))
+	values := make([]*FP256BN.BIG, len(attributes))
+	for i, attr := range attributes {
+		if attr.Type == bccsp.IdemixHiddenAttribute {
+			continue
+		}
+		disclosure[i] = 1
+		if withValues {
+			value, err := idemixAttributeValue(attr)
+			if err != nil {
+				return nil, nil, errors.WithMessage(err, "invalid disclosed attribute")
+			}
+			values[i] = value
+		}
+	}
+	return disclosure, values, nil
+}
@@ -0,0 +1,282 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/milagro-crypto/amcl/version3/go/amcl"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdemix(t *testing.T) {
+	csp, err := NewDefaultSecurityLevelWithKeystore(NewDummyKeyStore())
+	assert.NoError(t, err)
+
+	attributeNames := []string{"OU", "Role", "RevocationHandle"}
+
+	// Issuer key
+	issuerKey, err := csp.KeyGen(&bccsp.IdemixIssuerKeyGenOpts{Temporary: true, AttributeNames: attributeNames})
+	assert.NoError(t, err)
+	assert.True(t, issuerKey.Private())
+	issuerPK, err := issuerKey.PublicKey()
+	assert.NoError(t, err)
+
+	// Export and import the issuer keys
+	ipkBytes, err := issuerPK.Bytes()
+	assert.NoError(t, err)
+	iskBytes, err := issuerKey.Bytes()
+	assert.NoError(t, err)
+	importedPK, err := csp.KeyImport(ipkBytes, &bccsp.IdemixIssuerPublicKeyImportOpts{Temporary: true, AttributeNames: attributeNames})
+	assert.NoError(t, err)
+	assert.Equal(t, issuerPK.SKI(), importedPK.SKI())
+	_, err = csp.KeyImport(ipkBytes, &bccsp.IdemixIssuerPublicKeyImportOpts{Temporary: true, AttributeNames: []string{"OU", "Role", "Other"}})
+	assert.Error(t, err)
+	importedKey, err := csp.KeyImport(iskBytes, &bccsp.IdemixIssuerKeyImportOpts{Temporary: true, AttributeNames: attributeNames, PublicKey: ipkBytes})
+	assert.NoError(t, err)
+	assert.Equal(t, issuerKey.SKI(), importedKey.SKI())
+	_, err = csp.KeyImport([]byte{1, 2, 3}, &bccsp.IdemixIssuerKeyImportOpts{Temporary: true, PublicKey: ipkBytes})
+	assert.Error(t, err)
+
+	// User secret key
+	userKey, err := csp.KeyGen(&bccsp.IdemixUserSecretKeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	userKeyBytes, err := userKey.Bytes()
+	assert.NoError(t, err)
+	importedUserKey, err := csp.KeyImport(userKeyBytes, &bccsp.IdemixUserSecretKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, userKey.SKI(), importedUserKey.SKI())
+
+	// Credential request
+	nonce := idemix.BigToBytes(idemix.RandModOrder(newRand(t)))
+	credRequest, err := csp.Sign(userKey, nonce, &bccsp.IdemixCredentialRequestSignerOpts{IssuerPK: importedPK})
+	assert.NoError(t, err)
+	valid, err := csp.Verify(importedPK, credRequest, nonce, &bccsp.IdemixCredentialRequestSignerOpts{})
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	otherNonce := idemix.BigToBytes(idemix.RandModOrder(newRand(t)))
+	valid, err = csp.Verify(importedPK, credRequest, otherNonce, &bccsp.IdemixCredentialRequestSignerOpts{})
+	assert.Error(t, err)
+	assert.False(t, valid)
+
+	// Credential
+	credential, err := csp.Sign(issuerKey, credRequest, &bccsp.IdemixCredentialSignerOpts{
+		Attributes: []bccsp.IdemixAttribute{
+			{Type: bccsp.IdemixBytesAttribute, Value: []byte("ou")},
+			{Type: bccsp.IdemixIntAttribute, Value: 1},
+			{Type: bccsp.IdemixIntAttribute, Value: 7},
+		},
+	})
+	assert.NoError(t, err)
+	_, err = csp.Sign(issuerKey, credRequest, &bccsp.IdemixCredentialSignerOpts{
+		Attributes: []bccsp.IdemixAttribute{{Type: bccsp.IdemixHiddenAttribute}},
+	})
+	assert.Error(t, err)
+
+	// Pseudonym
+	nymKey, err := csp.KeyDeriv(userKey, &bccsp.IdemixNymKeyDerivationOpts{Temporary: true, IssuerPK: importedPK})
+	assert.NoError(t, err)
+	nymPK, err := nymKey.PublicKey()
+	assert.NoError(t, err)
+	nymBytes, err := nymPK.Bytes()
+	assert.NoError(t, err)
+	importedNymPK, err := csp.KeyImport(nymBytes, &bccsp.IdemixNymPublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	assert.Equal(t, nymPK.SKI(), importedNymPK.SKI())
+
+	// Revocation
+	revocationKey, err := idemix.GenerateLongTermRevocationKey()
+	assert.NoError(t, err)
+	revocationPK, err := csp.KeyImport(&revocationKey.PublicKey, &bccsp.ECDSAGoPublicKeyImportOpts{Temporary: true})
+	assert.NoError(t, err)
+	cri, err := idemix.CreateCRI(revocationKey, nil, 0, idemix.ALG_NO_REVOCATION, newRand(t))
+	assert.NoError(t, err)
+	criBytes, err := proto.Marshal(cri)
+	assert.NoError(t, err)
+
+	// Signature
+	msg := []byte("message")
+	signature, err := csp.Sign(userKey, msg, &bccsp.IdemixSignerOpts{
+		Nym:        nymKey,
+		IssuerPK:   importedPK,
+		Credential: credential,
+		Attributes: []bccsp.IdemixAttribute{
+			{Type: bccsp.IdemixBytesAttribute},
+			{Type: bccsp.IdemixHiddenAttribute},
+			{Type: bccsp.IdemixHiddenAttribute},
+		},
+		RhIndex: 2,
+		CRI:     criBytes,
+	})
+	assert.NoError(t, err)
+
+	verifierOpts := &bccsp.IdemixSignerOpts{
+		Attributes: []bccsp.IdemixAttribute{
+			{Type: bccsp.IdemixBytesAttribute, Value: []byte("ou")},
+			{Type: bccsp.IdemixHiddenAttribute},
+			{Type: bccsp.IdemixHiddenAttribute},
+		},
+		RhIndex:             2,
+		RevocationPublicKey: revocationPK,
+		Epoch:               0,
+	}
+	valid, err = csp.Verify(importedPK, signature, msg, verifierOpts)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+
+	valid, err = csp.Verify(importedPK, signature, []byte("another message"), verifierOpts)
+	assert.Error(t, err)
+	assert.False(t, valid)
+
+	verifierOpts.Attributes[0].Value = []byte("another ou")
+	valid, err = csp.Verify(importedPK, signature, msg, verifierOpts)
+	assert.Error(t, err)
+	assert.False(t, valid)
+
+	// A signature cannot be created with a credential of another user
+	otherUserKey, err := csp.KeyGen(&bccsp.IdemixUserSecretKeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+	_, err = csp.Sign(otherUserKey, msg, &bccsp.IdemixSignerOpts{
+		Nym:        nymKey,
+		IssuerPK:   importedPK,
+		Credential: credential,
+		Attributes: make([]bccsp.IdemixAttribute, 3),
+		RhIndex:    2,
+		CRI:        criBytes,
+	})
+	assert.Error(t, err)
+
+	// Nym signature
+	nymSignature, err := csp.Sign(userKey, msg, &bccsp.IdemixNymSignerOpts{Nym: nymKey, IssuerPK: importedPK})
+	assert.NoError(t, err)
+	valid, err = csp.Verify(importedNymPK, nymSignature, msg, &bccsp.IdemixNymSignerOpts{IssuerPK: importedPK})
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	valid, err = csp.Verify(importedNymPK, nymSignature, []byte("another message"), &bccsp.IdemixNymSignerOpts{IssuerPK: importedPK})
+	assert.Error(t, err)
+	assert.False(t, valid)
+}
+
+func TestIdemixInvalidOpts(t *testing.T) {
+	csp, err := NewDefaultSecurityLevelWithKeystore(NewDummyKeyStore())
+	assert.NoError(t, err)
+
+	issuerKey, err := csp.KeyGen(&bccsp.IdemixIssuerKeyGenOpts{Temporary: true, AttributeNames: []string{"A"}})
+	assert.NoError(t, err)
+	userKey, err := csp.KeyGen(&bccsp.IdemixUserSecretKeyGenOpts{Temporary: true})
+	assert.NoError(t, err)
+
+	_, err = csp.Sign(userKey, []byte{1, 2, 3}, &bccsp.IdemixCredentialRequestSignerOpts{IssuerPK: issuerKey})
+	assert.Error(t, err)
+	_, err = csp.Sign(userKey, []byte{1, 2, 3}, &bccsp.IdemixCredentialSignerOpts{})
+	assert.Error(t, err)
+	_, err = csp.Sign(issuerKey, []byte{1, 2, 3}, &bccsp.IdemixSignerOpts{})
+	assert.Error(t, err)
+	_, err = csp.KeyDeriv(userKey, &bccsp.IdemixNymKeyDerivationOpts{IssuerPK: userKey})
+	assert.Error(t, err)
+	_, err = csp.KeyImport([]byte{1, 2, 3}, &bccsp.IdemixNymPublicKeyImportOpts{})
+	assert.Error(t, err)
+	_, err = csp.KeyImport([]byte{1, 2, 3}, &bccsp.IdemixIssuerPublicKeyImportOpts{})
+	assert.Error(t, err)
+	_, err = userKey.PublicKey()
+	assert.Error(t, err)
+}
+
+func TestIdemixKeyStore(t *testing.T) {
+	for _, pwd := range [][]byte{nil, []byte("password")} {
+		tempDir, err := ioutil.TempDir("", "idemixks")
+		assert.NoError(t, err)
+		defer os.RemoveAll(tempDir)
+
+		ks, err := NewFileBasedKeyStore(pwd, tempDir, false)
+		assert.NoError(t, err)
+		csp, err := NewDefaultSecurityLevelWithKeystore(ks)
+		assert.NoError(t, err)
+
+		// keys which are not temporary are stored
+		issuerKey, err := csp.KeyGen(&bccsp.IdemixIssuerKeyGenOpts{AttributeNames: []string{"OU", "Role"}})
+		assert.NoError(t, err)
+		userKey, err := csp.KeyGen(&bccsp.IdemixUserSecretKeyGenOpts{})
+		assert.NoError(t, err)
+		issuerPK, err := issuerKey.PublicKey()
+		assert.NoError(t, err)
+		nymKey, err := csp.KeyDeriv(userKey, &bccsp.IdemixNymKeyDerivationOpts{IssuerPK: issuerPK})
+		assert.NoError(t, err)
+		nymPK, err := nymKey.PublicKey()
+		assert.NoError(t, err)
+		nymPKBytes, err := nymPK.Bytes()
+		assert.NoError(t, err)
+		_, err = csp.KeyImport(nymPKBytes, &bccsp.IdemixNymPublicKeyImportOpts{})
+		assert.NoError(t, err)
+		ipkBytes, err := issuerPK.Bytes()
+		assert.NoError(t, err)
+		otherIssuerKey, err := csp.KeyGen(&bccsp.IdemixIssuerKeyGenOpts{Temporary: true, AttributeNames: []string{"OU"}})
+		assert.NoError(t, err)
+		otherIssuerPK, err := otherIssuerKey.PublicKey()
+		assert.NoError(t, err)
+		otherIPKBytes, err := otherIssuerPK.Bytes()
+		assert.NoError(t, err)
+		_, err = csp.KeyImport(otherIPKBytes, &bccsp.IdemixIssuerPublicKeyImportOpts{})
+		assert.NoError(t, err)
+
+		// and loaded back by another provider
+		ks, err = NewFileBasedKeyStore(pwd, tempDir, true)
+		assert.NoError(t, err)
+		csp, err = NewDefaultSecurityLevelWithKeystore(ks)
+		assert.NoError(t, err)
+
+		// the secret key is preferred to the public key of the same issuer
+		loadedIssuerKey, err := csp.GetKey(issuerKey.SKI())
+		assert.NoError(t, err)
+		assert.Equal(t, issuerKey, loadedIssuerKey)
+		loadedIPK, err := loadedIssuerKey.PublicKey()
+		assert.NoError(t, err)
+		loadedIPKBytes, err := loadedIPK.Bytes()
+		assert.NoError(t, err)
+		assert.Equal(t, ipkBytes, loadedIPKBytes)
+
+		loadedOtherIssuerPK, err := csp.GetKey(otherIssuerPK.SKI())
+		assert.NoError(t, err)
+		assert.False(t, loadedOtherIssuerPK.Private())
+		loadedOtherIPKBytes, err := loadedOtherIssuerPK.Bytes()
+		assert.NoError(t, err)
+		assert.Equal(t, otherIPKBytes, loadedOtherIPKBytes)
+
+		loadedUserKey, err := csp.GetKey(userKey.SKI())
+		assert.NoError(t, err)
+		assert.Equal(t, userKey, loadedUserKey)
+
+		// the pseudonym secret key and its public part share the same SKI
+		loadedNymKey, err := csp.GetKey(nymKey.SKI())
+		assert.NoError(t, err)
+		assert.True(t, loadedNymKey.Private())
+		assert.Equal(t, nymKey.(*idemixNymSecretKey).rNym, loadedNymKey.(*idemixNymSecretKey).rNym)
+		loadedNymPK, err := loadedNymKey.PublicKey()
+		assert.NoError(t, err)
+		loadedNymPKBytes, err := loadedNymPK.Bytes()
+		assert.NoError(t, err)
+		assert.Equal(t, nymPKBytes, loadedNymPKBytes)
+
+		// the user can sign with the loaded keys
+		nymSignature, err := csp.Sign(loadedUserKey, []byte("message"), &bccsp.IdemixNymSignerOpts{Nym: loadedNymKey, IssuerPK: loadedIPK})
+		assert.NoError(t, err)
+		valid, err := csp.Verify(nymPK, nymSignature, []byte("message"), &bccsp.IdemixNymSignerOpts{IssuerPK: issuerPK})
+		assert.NoError(t, err)
+		assert.True(t, valid)
+	}
+}
+
+func newRand(t *testing.T) *amcl.RAND {
+	rng, err := idemix.GetRand()
+	assert.NoError(t, err)
+	return rng
+}
@@ -0,0 +1,200 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package sw
+
+import (
+	"crypto/sha256"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/idemix"
+	"github.com/milagro-crypto/amcl/version3/go/amcl/FP256BN"
+	"github.com/pkg/errors"
+)
+
+// idemixIssuerSecretKey contains the issuer secret key
+// and the corresponding public key
+type idemixIssuerSecretKey struct {
+	isk *idemix.IssuerKey
+}
+
+// Bytes converts this key to its byte representation.
+// The issuer secret key can be exported, so that the issuer
+// can persist it.
+func (k *idemixIssuerSecretKey) Bytes() (raw []byte, err error) {
+	return k.isk.ISk, nil
+}
+
+// SKI returns the subject key identifier of this key.
+func (k *idemixIssuerSecretKey) SKI() (ski []byte) {
+	return k.isk.IPk.Hash
+}
+
+// Symmetric returns true if this key is a symmetric key,
+// false if this key is asymmetric
+func (k *idemixIssuerSecretKey) Symmetric() bool {
+	return false
+}
+
+// Private returns true if this key is a private key,
+// false otherwise.
+func (k *idemixIssuerSecretKey) Private() bool {
+	return true
+}
+
+// PublicKey returns the corresponding public key part of an asymmetric public/private key pair.
+func (k *idemixIssuerSecretKey) PublicKey() (bccsp.Key, error) {
+	return &idemixIssuerPublicKey{k.isk.IPk}, nil
+}
+
+// idemixIssuerPublicKey contains the issuer public key
+type idemixIssuerPublicKey struct {
+	ipk *idemix.IssuerPublicKey
+}
+
+// Bytes converts this key to its byte representation.
+func (k *idemixIssuerPublicKey) Bytes() (raw []byte, err error) {
+	return proto.Marshal(k.ipk)
+}
+
+// SKI returns the subject key identifier of this key.
+func (k *idemixIssuerPublicKey) SKI() (ski []byte) {
+	return k.ipk.Hash
+}
+
+// Symmetric returns true if this key is a symmetric key,
+// false if this key is asymmetric
+func (k *idemixIssuerPublicKey) Symmetric() bool {
+	return false
+}
+
+// Private returns true if this key is a private key,
+// false otherwise.
+func (k *idemixIssuerPublicKey) Private() bool {
+	return false
+}
+
+// PublicKey returns the corresponding public key part of an asymmetric public/private key pair.
+func (k *idemixIssuerPublicKey) PublicKey() (bccsp.Key, error) {
+	return k, nil
+}
+
+// idemixUserSecretKey contains the secret key of an idemix user
+type idemixUserSecretKey struct {
+	sk *FP256BN.BIG
+}
+
+// Bytes converts this key to its byte representation.
+// The user secret key can be exported, so that it can be
+// stored in the signer configuration.
+func (k *idemixUserSecretKey) Bytes() (raw []byte, err error) {
+	return idemix.BigToBytes(k.sk), nil
+}
+
+// SKI returns the subject key identifier of this key.
+func (k *idemixUserSecretKey) SKI() (ski []byte) {
+	hash := sha256.New()
+	hash.Write(idemix.BigToBytes(k.sk))
+	return hash.Sum(nil)
+}
+
+// Symmetric returns true if this key is a symmetric key,
+// false if this key is asymmetric
+func (k *idemixUserSecretKey) Symmetric() bool {
+	return true
+}
+
+// Private returns true if this key is a private key,
+// false otherwise.
+func (k *idemixUserSecretKey) Private() bool {
+	return true
+}
+
+// PublicKey returns the corresponding public key part of an asymmetric public/private key pair.
+// This method returns an error in symmetric key schemes.
+func (k *idemixUserSecretKey) PublicKey() (bccsp.Key, error) {
+	return nil, errors.New("cannot call this method on a symmetric key")
+}
+
+// idemixNymSecretKey contains a pseudonym of an idemix user,
+// together with the randomness used to create it
+type idemixNymSecretKey struct {
+	sk   *FP256BN.BIG
+	nym  *FP256BN.ECP
+	rNym *FP256BN.BIG
+}
+
+// Bytes converts this key to its byte representation,
+// if this operation is allowed.
+func (k *idemixNymSecretKey) Bytes() (raw []byte, err error) {
+	return nil, errors.New("Not supported.")
+}
+
+// SKI returns the subject key identifier of this key.
+func (k *idemixNymSecretKey) SKI() (ski []byte) {
+	return nymSKI(k.nym)
+}
+
+// Symmetric returns true if this key is a symmetric key,
+// false if this key is asymmetric
+func (k *idemixNymSecretKey) Symmetric() bool {
+	return false
+}
+
+// Private returns true if this key is a private key,
+// false otherwise.
+func (k *idemixNymSecretKey) Private() bool {
+	return true
+}
+
+// PublicKey returns the corresponding public key part of an asymmetric public/private key pair.
+func (k *idemixNymSecretKey) PublicKey() (bccsp.Key, error) {
+	return &idemixNymPublicKey{k.nym}, nil
+}
+
+// idemixNymPublicKey contains the public part of a pseudonym
+type idemixNymPublicKey struct {
+	nym *FP256BN.ECP
+}
+
+// Bytes converts this key to its byte representation,
+// which is the concatenation of the coordinates of the pseudonym.
+func (k *idemixNymPublicKey) Bytes() (raw []byte, err error) {
+	return nymBytes(k.nym), nil
+}
+
+// SKI returns the subject key identifier of this key.
+func (k *idemixNymPublicKey) SKI() (ski []byte) {
+	return nymSKI(k.nym)
+}
+
+// Symmetric returns true if this key is a symmetric key,
+// false if this key is asymmetric
+func (k *idemixNymPublicKey) Symmetric() bool {
+	return false
+}
+
+// Private returns true if this key is a private key,
+// false otherwise.
+func (k *idemixNymPublicKey) Private() bool {
+	return false
+}
+
+// PublicKey returns the corresponding public key part of an asymmetric public/private key pair.
+func (k *idemixNymPublicKey) PublicKey() (bccsp.Key, error) {
+	return k, nil
+}
+
+func nymBytes(nym *FP256BN.ECP) []byte {
+	return append(idemix.BigToBytes(nym.GetX()), idemix.BigToBytes(nym.GetY())...)
+}
+
+func nymSKI(nym *FP256BN.ECP) []byte {
+	hash := sha256.New()
+	hash.Write(nymBytes(nym))
+	return hash.Sum(nil)
+}
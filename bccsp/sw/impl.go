@@ -23,6 +23,7 @@ import (
 	"reflect"
 
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/utils"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/sha3"
@@ -76,6 +77,9 @@ func New(securityLevel int, hashFamily string, keyStore bccsp.KeyStore) (bccsp.B
 	signers := make(map[reflect.Type]Signer)
 	signers[reflect.TypeOf(&ecdsaPrivateKey{})] = &ecdsaSigner{}
 	signers[reflect.TypeOf(&rsaPrivateKey{})] = &rsaSigner{}
+	signers[reflect.TypeOf(&ed25519PrivateKey{})] = &ed25519Signer{}
+	signers[reflect.TypeOf(&idemixUserSecretKey{})] = &idemixUserSecretKeySigner{}
+	signers[reflect.TypeOf(&idemixIssuerSecretKey{})] = &idemixIssuerSecretKeySigner{}
 
 	// Set the verifiers
 	verifiers := make(map[reflect.Type]Verifier)
@@ -83,6 +87,10 @@ func New(securityLevel int, hashFamily string, keyStore bccsp.KeyStore) (bccsp.B
 	verifiers[reflect.TypeOf(&ecdsaPublicKey{})] = &ecdsaPublicKeyKeyVerifier{}
 	verifiers[reflect.TypeOf(&rsaPrivateKey{})] = &rsaPrivateKeyVerifier{}
 	verifiers[reflect.TypeOf(&rsaPublicKey{})] = &rsaPublicKeyKeyVerifier{}
+	verifiers[reflect.TypeOf(&ed25519PrivateKey{})] = &ed25519PrivateKeyVerifier{}
+	verifiers[reflect.TypeOf(&ed25519PublicKey{})] = &ed25519PublicKeyKeyVerifier{}
+	verifiers[reflect.TypeOf(&idemixIssuerPublicKey{})] = &idemixIssuerPublicKeyVerifier{}
+	verifiers[reflect.TypeOf(&idemixNymPublicKey{})] = &idemixNymPublicKeyVerifier{}
 
 	// Set the hashers
 	hashers := make(map[reflect.Type]Hasher)
@@ -106,6 +114,8 @@ func New(securityLevel int, hashFamily string, keyStore bccsp.KeyStore) (bccsp.B
 	keyGenerators[reflect.TypeOf(&bccsp.ECDSAKeyGenOpts{})] = &ecdsaKeyGenerator{curve: conf.ellipticCurve}
 	keyGenerators[reflect.TypeOf(&bccsp.ECDSAP256KeyGenOpts{})] = &ecdsaKeyGenerator{curve: elliptic.P256()}
 	keyGenerators[reflect.TypeOf(&bccsp.ECDSAP384KeyGenOpts{})] = &ecdsaKeyGenerator{curve: elliptic.P384()}
+	keyGenerators[reflect.TypeOf(&bccsp.ECDSASecp256k1KeyGenOpts{})] = &ecdsaKeyGenerator{curve: utils.Secp256k1()}
+	keyGenerators[reflect.TypeOf(&bccsp.ED25519KeyGenOpts{})] = &ed25519KeyGenerator{}
 	keyGenerators[reflect.TypeOf(&bccsp.AESKeyGenOpts{})] = &aesKeyGenerator{length: conf.aesBitLength}
 	keyGenerators[reflect.TypeOf(&bccsp.AES256KeyGenOpts{})] = &aesKeyGenerator{length: 32}
 	keyGenerators[reflect.TypeOf(&bccsp.AES192KeyGenOpts{})] = &aesKeyGenerator{length: 24}
@@ -115,6 +125,8 @@ func New(securityLevel int, hashFamily string, keyStore bccsp.KeyStore) (bccsp.B
 	keyGenerators[reflect.TypeOf(&bccsp.RSA2048KeyGenOpts{})] = &rsaKeyGenerator{length: 2048}
 	keyGenerators[reflect.TypeOf(&bccsp.RSA3072KeyGenOpts{})] = &rsaKeyGenerator{length: 3072}
 	keyGenerators[reflect.TypeOf(&bccsp.RSA4096KeyGenOpts{})] = &rsaKeyGenerator{length: 4096}
+	keyGenerators[reflect.TypeOf(&bccsp.IdemixIssuerKeyGenOpts{})] = &idemixIssuerKeyGenerator{}
+	keyGenerators[reflect.TypeOf(&bccsp.IdemixUserSecretKeyGenOpts{})] = &idemixUserSecretKeyGenerator{}
 	impl.keyGenerators = keyGenerators
 
 	// Set the key generators
@@ -122,6 +134,7 @@ func New(securityLevel int, hashFamily string, keyStore bccsp.KeyStore) (bccsp.B
 	keyDerivers[reflect.TypeOf(&ecdsaPrivateKey{})] = &ecdsaPrivateKeyKeyDeriver{}
 	keyDerivers[reflect.TypeOf(&ecdsaPublicKey{})] = &ecdsaPublicKeyKeyDeriver{}
 	keyDerivers[reflect.TypeOf(&aesPrivateKey{})] = &aesPrivateKeyKeyDeriver{bccsp: impl}
+	keyDerivers[reflect.TypeOf(&idemixUserSecretKey{})] = &idemixUserSecretKeyKeyDeriver{}
 	impl.keyDerivers = keyDerivers
 
 	// Set the key importers
@@ -132,7 +145,14 @@ func New(securityLevel int, hashFamily string, keyStore bccsp.KeyStore) (bccsp.B
 	keyImporters[reflect.TypeOf(&bccsp.ECDSAPrivateKeyImportOpts{})] = &ecdsaPrivateKeyImportOptsKeyImporter{}
 	keyImporters[reflect.TypeOf(&bccsp.ECDSAGoPublicKeyImportOpts{})] = &ecdsaGoPublicKeyImportOptsKeyImporter{}
 	keyImporters[reflect.TypeOf(&bccsp.RSAGoPublicKeyImportOpts{})] = &rsaGoPublicKeyImportOptsKeyImporter{}
+	keyImporters[reflect.TypeOf(&bccsp.ED25519PKIXPublicKeyImportOpts{})] = &ed25519PKIXPublicKeyImportOptsKeyImporter{}
+	keyImporters[reflect.TypeOf(&bccsp.ED25519PrivateKeyImportOpts{})] = &ed25519PrivateKeyImportOptsKeyImporter{}
+	keyImporters[reflect.TypeOf(&bccsp.ED25519GoPublicKeyImportOpts{})] = &ed25519GoPublicKeyImportOptsKeyImporter{}
 	keyImporters[reflect.TypeOf(&bccsp.X509PublicKeyImportOpts{})] = &x509PublicKeyImportOptsKeyImporter{bccsp: impl}
+	keyImporters[reflect.TypeOf(&bccsp.IdemixIssuerKeyImportOpts{})] = &idemixIssuerKeyImporter{}
+	keyImporters[reflect.TypeOf(&bccsp.IdemixIssuerPublicKeyImportOpts{})] = &idemixIssuerPublicKeyImporter{}
+	keyImporters[reflect.TypeOf(&bccsp.IdemixUserSecretKeyImportOpts{})] = &idemixUserSecretKeyImporter{}
+	keyImporters[reflect.TypeOf(&bccsp.IdemixNymPublicKeyImportOpts{})] = &idemixNymPublicKeyImporter{}
 
 	impl.keyImporters = keyImporters
 
@@ -312,7 +332,7 @@ func (csp *impl) Sign(k bccsp.Key, digest []byte, opts bccsp.SignerOpts) (signat
 	if k == nil {
 		return nil, errors.New("Invalid Key. It must not be nil.")
 	}
-	if len(digest) == 0 {
+	if len(digest) == 0 && !isIdemixProof(opts) {
 		return nil, errors.New("Invalid digest. Cannot be empty.")
 	}
 
@@ -339,7 +359,7 @@ func (csp *impl) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.Signer
 	if len(signature) == 0 {
 		return false, errors.New("Invalid signature. Cannot be empty.")
 	}
-	if len(digest) == 0 {
+	if len(digest) == 0 && !isIdemixProof(opts) {
 		return false, errors.New("Invalid digest. Cannot be empty.")
 	}
 
@@ -356,6 +376,13 @@ func (csp *impl) Verify(k bccsp.Key, signature, digest []byte, opts bccsp.Signer
 	return
 }
 
+// isIdemixProof returns whether the opts are the ones of an idemix signature,
+// which may sign no message when it only proves the possession of a credential
+func isIdemixProof(opts bccsp.SignerOpts) bool {
+	_, ok := opts.(*bccsp.IdemixSignerOpts)
+	return ok
+}
+
 // Encrypt encrypts plaintext using key k.
 // The opts argument should be appropriate for the primitive used.
 func (csp *impl) Encrypt(k bccsp.Key, plaintext []byte, opts bccsp.EncrypterOpts) (ciphertext []byte, err error) {
@@ -29,6 +29,7 @@ var (
 		elliptic.P256(): new(big.Int).Rsh(elliptic.P256().Params().N, 1),
 		elliptic.P384(): new(big.Int).Rsh(elliptic.P384().Params().N, 1),
 		elliptic.P521(): new(big.Int).Rsh(elliptic.P521().Params().N, 1),
+		Secp256k1():     new(big.Int).Rsh(Secp256k1().Params().N, 1),
 	}
 )
 
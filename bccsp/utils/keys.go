@@ -18,14 +18,17 @@ package utils
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/asn1"
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"math/big"
 )
 
 // struct to hold info required for PKCS#8
@@ -47,6 +50,8 @@ var (
 	oidNamedCurveP256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
 	oidNamedCurveP384 = asn1.ObjectIdentifier{1, 3, 132, 0, 34}
 	oidNamedCurveP521 = asn1.ObjectIdentifier{1, 3, 132, 0, 35}
+
+	oidNamedCurveSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
 )
 
 var oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
@@ -61,21 +66,125 @@ func oidFromNamedCurve(curve elliptic.Curve) (asn1.ObjectIdentifier, bool) {
 		return oidNamedCurveP384, true
 	case elliptic.P521():
 		return oidNamedCurveP521, true
+	case Secp256k1():
+		return oidNamedCurveSecp256k1, true
 	}
 	return nil, false
 }
 
+// publicKeyInfo is the PKIX representation of a public key
+type publicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// marshalPKIXPublicKey marshals a public key to PKIX.
+// Go's x509 package does not support secp256k1 keys, which are marshalled here.
+func marshalPKIXPublicKey(publicKey interface{}) ([]byte, error) {
+	k, ok := publicKey.(*ecdsa.PublicKey)
+	if !ok || k.Curve != Secp256k1() {
+		return x509.MarshalPKIXPublicKey(publicKey)
+	}
+
+	paramBytes, err := asn1.Marshal(oidNamedCurveSecp256k1)
+	if err != nil {
+		return nil, err
+	}
+	point := elliptic.Marshal(k.Curve, k.X, k.Y)
+	return asn1.Marshal(publicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPublicKeyECDSA,
+			Parameters: asn1.RawValue{FullBytes: paramBytes},
+		},
+		PublicKey: asn1.BitString{Bytes: point, BitLength: 8 * len(point)},
+	})
+}
+
+// parseSecp256k1PublicKey parses a secp256k1 public key in PKIX format
+func parseSecp256k1PublicKey(der []byte) (*ecdsa.PublicKey, error) {
+	var pki publicKeyInfo
+	rest, err := asn1.Unmarshal(der, &pki)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after public key")
+	}
+	if !pki.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) {
+		return nil, errors.New("not an ECDSA public key")
+	}
+	var curveOID asn1.ObjectIdentifier
+	_, err = asn1.Unmarshal(pki.Algorithm.Parameters.FullBytes, &curveOID)
+	if err != nil || !curveOID.Equal(oidNamedCurveSecp256k1) {
+		return nil, errors.New("not a secp256k1 public key")
+	}
+	x, y := elliptic.Unmarshal(Secp256k1(), pki.PublicKey.RightAlign())
+	if x == nil {
+		return nil, errors.New("invalid secp256k1 point")
+	}
+	return &ecdsa.PublicKey{Curve: Secp256k1(), X: x, Y: y}, nil
+}
+
+// marshalECPrivateKey marshals an EC private key to SEC 1 format.
+// Go's x509 package does not support secp256k1 keys, which are marshalled here.
+func marshalECPrivateKey(k *ecdsa.PrivateKey) ([]byte, error) {
+	if k.Curve != Secp256k1() {
+		return x509.MarshalECPrivateKey(k)
+	}
+
+	privateKeyBytes := k.D.Bytes()
+	paddedPrivateKey := make([]byte, (k.Curve.Params().N.BitLen()+7)/8)
+	copy(paddedPrivateKey[len(paddedPrivateKey)-len(privateKeyBytes):], privateKeyBytes)
+	return asn1.Marshal(ecPrivateKey{
+		Version:       1,
+		PrivateKey:    paddedPrivateKey,
+		NamedCurveOID: oidNamedCurveSecp256k1,
+		PublicKey:     asn1.BitString{Bytes: elliptic.Marshal(k.Curve, k.X, k.Y)},
+	})
+}
+
+// parseSecp256k1PrivateKey parses a secp256k1 private key in PKCS#8 or SEC 1 format
+func parseSecp256k1PrivateKey(der []byte) (*ecdsa.PrivateKey, error) {
+	var pkcs8Key pkcs8Info
+	if _, err := asn1.Unmarshal(der, &pkcs8Key); err == nil {
+		if len(pkcs8Key.PrivateKeyAlgorithm) != 2 ||
+			!pkcs8Key.PrivateKeyAlgorithm[0].Equal(oidPublicKeyECDSA) ||
+			!pkcs8Key.PrivateKeyAlgorithm[1].Equal(oidNamedCurveSecp256k1) {
+			return nil, errors.New("not a secp256k1 private key")
+		}
+		der = pkcs8Key.PrivateKey
+	}
+
+	var privKey ecPrivateKey
+	if _, err := asn1.Unmarshal(der, &privKey); err != nil {
+		return nil, fmt.Errorf("failed parsing EC private key [%s]", err)
+	}
+	if len(privKey.NamedCurveOID) != 0 && !privKey.NamedCurveOID.Equal(oidNamedCurveSecp256k1) {
+		return nil, errors.New("not a secp256k1 private key")
+	}
+
+	curve := Secp256k1()
+	d := new(big.Int).SetBytes(privKey.PrivateKey)
+	if d.Sign() <= 0 || d.Cmp(curve.Params().N) >= 0 {
+		return nil, errors.New("invalid secp256k1 private key value")
+	}
+	key := &ecdsa.PrivateKey{D: d}
+	key.Curve = curve
+	key.X, key.Y = curve.ScalarBaseMult(privKey.PrivateKey)
+	return key, nil
+}
+
 // PrivateKeyToDER marshals a private key to der
 func PrivateKeyToDER(privateKey *ecdsa.PrivateKey) ([]byte, error) {
 	if privateKey == nil {
 		return nil, errors.New("Invalid ecdsa private key. It must be different from nil.")
 	}
 
-	return x509.MarshalECPrivateKey(privateKey)
+	return marshalECPrivateKey(privateKey)
 }
 
 // PrivateKeyToPEM converts the private key to PEM format.
-// EC private keys are converted to PKCS#8 format.
+// EC and Ed25519 private keys are converted to PKCS#8 format.
 // RSA private keys are converted to PKCS#1 format.
 func PrivateKeyToPEM(privateKey interface{}, pwd []byte) ([]byte, error) {
 	// Validate inputs
@@ -142,8 +251,23 @@ func PrivateKeyToPEM(privateKey interface{}, pwd []byte) ([]byte, error) {
 				Bytes: raw,
 			},
 		), nil
+	case ed25519.PrivateKey:
+		if len(k) != ed25519.PrivateKeySize {
+			return nil, errors.New("Invalid ed25519 private key. It must be different from nil.")
+		}
+		raw, err := x509.MarshalPKCS8PrivateKey(k)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling ed25519 key to asn1 [%s]", err)
+		}
+
+		return pem.EncodeToMemory(
+			&pem.Block{
+				Type:  "PRIVATE KEY",
+				Bytes: raw,
+			},
+		), nil
 	default:
-		return nil, errors.New("Invalid key type. It must be *ecdsa.PrivateKey or *rsa.PrivateKey")
+		return nil, errors.New("Invalid key type. It must be *ecdsa.PrivateKey, *rsa.PrivateKey or ed25519.PrivateKey")
 	}
 }
 
@@ -158,7 +282,7 @@ func PrivateKeyToEncryptedPEM(privateKey interface{}, pwd []byte) ([]byte, error
 		if k == nil {
 			return nil, errors.New("Invalid ecdsa private key. It must be different from nil.")
 		}
-		raw, err := x509.MarshalECPrivateKey(k)
+		raw, err := marshalECPrivateKey(k)
 
 		if err != nil {
 			return nil, err
@@ -177,8 +301,30 @@ func PrivateKeyToEncryptedPEM(privateKey interface{}, pwd []byte) ([]byte, error
 
 		return pem.EncodeToMemory(block), nil
 
+	case ed25519.PrivateKey:
+		if len(k) != ed25519.PrivateKeySize {
+			return nil, errors.New("Invalid ed25519 private key. It must be different from nil.")
+		}
+		raw, err := x509.MarshalPKCS8PrivateKey(k)
+		if err != nil {
+			return nil, err
+		}
+
+		block, err := x509.EncryptPEMBlock(
+			rand.Reader,
+			"PRIVATE KEY",
+			raw,
+			pwd,
+			x509.PEMCipherAES256)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return pem.EncodeToMemory(block), nil
+
 	default:
-		return nil, errors.New("Invalid key type. It must be *ecdsa.PrivateKey")
+		return nil, errors.New("Invalid key type. It must be *ecdsa.PrivateKey or ed25519.PrivateKey")
 	}
 }
 
@@ -191,7 +337,7 @@ func DERToPrivateKey(der []byte) (key interface{}, err error) {
 
 	if key, err = x509.ParsePKCS8PrivateKey(der); err == nil {
 		switch key.(type) {
-		case *rsa.PrivateKey, *ecdsa.PrivateKey:
+		case *rsa.PrivateKey, *ecdsa.PrivateKey, ed25519.PrivateKey:
 			return
 		default:
 			return nil, errors.New("Found unknown private key type in PKCS#8 wrapping")
@@ -202,7 +348,11 @@ func DERToPrivateKey(der []byte) (key interface{}, err error) {
 		return
 	}
 
-	return nil, errors.New("Invalid key type. The DER must contain an rsa.PrivateKey or ecdsa.PrivateKey")
+	if key, err = parseSecp256k1PrivateKey(der); err == nil {
+		return
+	}
+
+	return nil, errors.New("Invalid key type. The DER must contain an rsa.PrivateKey, ecdsa.PrivateKey or ed25519.PrivateKey")
 }
 
 // PEMtoPrivateKey unmarshals a pem to private key
@@ -294,6 +444,59 @@ func AEStoEncryptedPEM(raw []byte, pwd []byte) ([]byte, error) {
 	return pem.EncodeToMemory(block), nil
 }
 
+// RawKeyToPEM encapsulates a raw key of the supplied PEM type in the PEM
+// format, which is encrypted if a password is supplied
+func RawKeyToPEM(raw []byte, pemType string, pwd []byte) ([]byte, error) {
+	if len(raw) == 0 {
+		return nil, errors.New("Invalid key. It must be different from nil")
+	}
+	if len(pwd) == 0 {
+		return pem.EncodeToMemory(&pem.Block{Type: pemType, Bytes: raw}), nil
+	}
+
+	block, err := x509.EncryptPEMBlock(
+		rand.Reader,
+		pemType,
+		raw,
+		pwd,
+		x509.PEMCipherAES256)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(block), nil
+}
+
+// PEMtoRawKey extracts a raw key of the supplied PEM type from the PEM
+// format, decrypting it if needed
+func PEMtoRawKey(raw []byte, pemType string, pwd []byte) ([]byte, error) {
+	if len(raw) == 0 {
+		return nil, errors.New("Invalid PEM. It must be different from nil.")
+	}
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, fmt.Errorf("Failed decoding PEM. Block must be different from nil. [% x]", raw)
+	}
+	if block.Type != pemType {
+		return nil, fmt.Errorf("Invalid PEM type [%s], expected [%s]", block.Type, pemType)
+	}
+
+	if x509.IsEncryptedPEMBlock(block) {
+		if len(pwd) == 0 {
+			return nil, errors.New("Encrypted Key. Password must be different fom nil")
+		}
+
+		decrypted, err := x509.DecryptPEMBlock(block, pwd)
+		if err != nil {
+			return nil, fmt.Errorf("Failed PEM decryption. [%s]", err)
+		}
+		return decrypted, nil
+	}
+
+	return block.Bytes, nil
+}
+
 // PublicKeyToPEM marshals a public key to the pem format
 func PublicKeyToPEM(publicKey interface{}, pwd []byte) ([]byte, error) {
 	if len(pwd) != 0 {
@@ -309,7 +512,7 @@ func PublicKeyToPEM(publicKey interface{}, pwd []byte) ([]byte, error) {
 		if k == nil {
 			return nil, errors.New("Invalid ecdsa public key. It must be different from nil.")
 		}
-		PubASN1, err := x509.MarshalPKIXPublicKey(k)
+		PubASN1, err := marshalPKIXPublicKey(k)
 		if err != nil {
 			return nil, err
 		}
@@ -335,9 +538,24 @@ func PublicKeyToPEM(publicKey interface{}, pwd []byte) ([]byte, error) {
 				Bytes: PubASN1,
 			},
 		), nil
+	case ed25519.PublicKey:
+		if len(k) != ed25519.PublicKeySize {
+			return nil, errors.New("Invalid ed25519 public key. It must be different from nil.")
+		}
+		PubASN1, err := x509.MarshalPKIXPublicKey(k)
+		if err != nil {
+			return nil, err
+		}
+
+		return pem.EncodeToMemory(
+			&pem.Block{
+				Type:  "PUBLIC KEY",
+				Bytes: PubASN1,
+			},
+		), nil
 
 	default:
-		return nil, errors.New("Invalid key type. It must be *ecdsa.PublicKey or *rsa.PublicKey")
+		return nil, errors.New("Invalid key type. It must be *ecdsa.PublicKey, *rsa.PublicKey or ed25519.PublicKey")
 	}
 }
 
@@ -352,7 +570,7 @@ func PublicKeyToDER(publicKey interface{}) ([]byte, error) {
 		if k == nil {
 			return nil, errors.New("Invalid ecdsa public key. It must be different from nil.")
 		}
-		PubASN1, err := x509.MarshalPKIXPublicKey(k)
+		PubASN1, err := marshalPKIXPublicKey(k)
 		if err != nil {
 			return nil, err
 		}
@@ -370,8 +588,14 @@ func PublicKeyToDER(publicKey interface{}) ([]byte, error) {
 
 		return PubASN1, nil
 
+	case ed25519.PublicKey:
+		if len(k) != ed25519.PublicKeySize {
+			return nil, errors.New("Invalid ed25519 public key. It must be different from nil.")
+		}
+		return x509.MarshalPKIXPublicKey(k)
+
 	default:
-		return nil, errors.New("Invalid key type. It must be *ecdsa.PublicKey or *rsa.PublicKey")
+		return nil, errors.New("Invalid key type. It must be *ecdsa.PublicKey, *rsa.PublicKey or ed25519.PublicKey")
 	}
 }
 
@@ -389,6 +613,28 @@ func PublicKeyToEncryptedPEM(publicKey interface{}, pwd []byte) ([]byte, error)
 		if k == nil {
 			return nil, errors.New("Invalid ecdsa public key. It must be different from nil.")
 		}
+		raw, err := marshalPKIXPublicKey(k)
+		if err != nil {
+			return nil, err
+		}
+
+		block, err := x509.EncryptPEMBlock(
+			rand.Reader,
+			"PUBLIC KEY",
+			raw,
+			pwd,
+			x509.PEMCipherAES256)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return pem.EncodeToMemory(block), nil
+
+	case ed25519.PublicKey:
+		if len(k) != ed25519.PublicKeySize {
+			return nil, errors.New("Invalid ed25519 public key. It must be different from nil.")
+		}
 		raw, err := x509.MarshalPKIXPublicKey(k)
 		if err != nil {
 			return nil, err
@@ -408,7 +654,7 @@ func PublicKeyToEncryptedPEM(publicKey interface{}, pwd []byte) ([]byte, error)
 		return pem.EncodeToMemory(block), nil
 
 	default:
-		return nil, errors.New("Invalid key type. It must be *ecdsa.PublicKey")
+		return nil, errors.New("Invalid key type. It must be *ecdsa.PublicKey or ed25519.PublicKey")
 	}
 }
 
@@ -454,6 +700,12 @@ func DERToPublicKey(raw []byte) (pub interface{}, err error) {
 	}
 
 	key, err := x509.ParsePKIXPublicKey(raw)
+	if err != nil {
+		// Go's x509 package does not support secp256k1
+		if secp256k1Key, secp256k1Err := parseSecp256k1PublicKey(raw); secp256k1Err == nil {
+			return secp256k1Key, nil
+		}
+	}
 
 	return key, err
 }
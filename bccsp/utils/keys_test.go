@@ -18,6 +18,7 @@ package utils
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
@@ -344,6 +345,32 @@ func TestAESKey(t *testing.T) {
 	assert.Equal(t, k, k2)
 }
 
+func TestRawKey(t *testing.T) {
+	k := []byte{0, 1, 2, 3, 4, 5}
+	pem, err := RawKeyToPEM(k, "TEST KEY", nil)
+	assert.NoError(t, err)
+
+	k2, err := PEMtoRawKey(pem, "TEST KEY", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, k, k2)
+
+	_, err = PEMtoRawKey(pem, "OTHER KEY", nil)
+	assert.EqualError(t, err, "Invalid PEM type [TEST KEY], expected [OTHER KEY]")
+
+	pem, err = RawKeyToPEM(k, "TEST KEY", k)
+	assert.NoError(t, err)
+
+	k2, err = PEMtoRawKey(pem, "TEST KEY", k)
+	assert.NoError(t, err)
+	assert.Equal(t, k, k2)
+
+	_, err = PEMtoRawKey(pem, "TEST KEY", nil)
+	assert.Error(t, err)
+
+	_, err = RawKeyToPEM(nil, "TEST KEY", nil)
+	assert.Error(t, err)
+}
+
 func TestDERToPublicKey(t *testing.T) {
 	_, err := DERToPublicKey(nil)
 	assert.Error(t, err)
@@ -425,3 +452,41 @@ func TestPrivateKeyToPEM(t *testing.T) {
 	assert.Equal(t, key.PublicKey.E, key3.(*rsa.PublicKey).E)
 	assert.Equal(t, key.PublicKey.N, key3.(*rsa.PublicKey).N)
 }
+
+func TestEd25519Keys(t *testing.T) {
+	pk, sk, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	pemBytes, err := PrivateKeyToPEM(sk, nil)
+	assert.NoError(t, err)
+	skFromPEM, err := PEMtoPrivateKey(pemBytes, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, sk, skFromPEM)
+
+	pemBytes, err = PrivateKeyToPEM(sk, []byte("passwd"))
+	assert.NoError(t, err)
+	skFromPEM, err = PEMtoPrivateKey(pemBytes, []byte("passwd"))
+	assert.NoError(t, err)
+	assert.Equal(t, sk, skFromPEM)
+
+	pemBytes, err = PublicKeyToPEM(pk, nil)
+	assert.NoError(t, err)
+	pkFromPEM, err := PEMtoPublicKey(pemBytes, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, pk, pkFromPEM)
+
+	pemBytes, err = PublicKeyToPEM(pk, []byte("passwd"))
+	assert.NoError(t, err)
+	pkFromPEM, err = PEMtoPublicKey(pemBytes, []byte("passwd"))
+	assert.NoError(t, err)
+	assert.Equal(t, pk, pkFromPEM)
+
+	der, err := PublicKeyToDER(pk)
+	assert.NoError(t, err)
+	pkFromDER, err := DERToPublicKey(der)
+	assert.NoError(t, err)
+	assert.Equal(t, pk, pkFromDER)
+
+	_, err = PublicKeyToDER(ed25519.PublicKey{1, 2, 3})
+	assert.Error(t, err)
+}
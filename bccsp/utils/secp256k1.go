@@ -0,0 +1,241 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"crypto/elliptic"
+	"math/big"
+	"sync"
+)
+
+// secp256k1Curve implements elliptic.Curve for the secp256k1 curve
+// y² = x³ + 7 defined in SEC 2.
+// The generic implementation of elliptic.CurveParams assumes a = -3,
+// which does not hold for secp256k1, hence the arithmetic is implemented here
+// using Jacobian coordinates. The implementation is not constant time.
+type secp256k1Curve struct {
+	params *elliptic.CurveParams
+}
+
+var (
+	initSecp256k1 sync.Once
+	secp256k1     *secp256k1Curve
+)
+
+// Secp256k1 returns an elliptic.Curve which implements secp256k1
+func Secp256k1() elliptic.Curve {
+	initSecp256k1.Do(func() {
+		params := &elliptic.CurveParams{Name: "secp256k1", BitSize: 256}
+		params.P, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEFFFFFC2F", 16)
+		params.N, _ = new(big.Int).SetString("FFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFEBAAEDCE6AF48A03BBFD25E8CD0364141", 16)
+		params.B = big.NewInt(7)
+		params.Gx, _ = new(big.Int).SetString("79BE667EF9DCBBAC55A06295CE870B07029BFCDB2DCE28D959F2815B16F81798", 16)
+		params.Gy, _ = new(big.Int).SetString("483ADA7726A3C4655DA4FBFC0E1108A8FD17B448A68554199C47D08FFB10D4B8", 16)
+		secp256k1 = &secp256k1Curve{params}
+	})
+	return secp256k1
+}
+
+func (c *secp256k1Curve) Params() *elliptic.CurveParams {
+	return c.params
+}
+
+func (c *secp256k1Curve) IsOnCurve(x, y *big.Int) bool {
+	if x.Sign() < 0 || x.Cmp(c.params.P) >= 0 || y.Sign() < 0 || y.Cmp(c.params.P) >= 0 {
+		return false
+	}
+
+	// y² = x³ + 7
+	y2 := new(big.Int).Mul(y, y)
+	y2.Mod(y2, c.params.P)
+
+	x3 := new(big.Int).Mul(x, x)
+	x3.Mul(x3, x)
+	x3.Add(x3, c.params.B)
+	x3.Mod(x3, c.params.P)
+
+	return x3.Cmp(y2) == 0
+}
+
+func (c *secp256k1Curve) Add(x1, y1, x2, y2 *big.Int) (*big.Int, *big.Int) {
+	X1, Y1, Z1 := c.toJacobian(x1, y1)
+	X2, Y2, Z2 := c.toJacobian(x2, y2)
+	return c.toAffine(c.addJacobian(X1, Y1, Z1, X2, Y2, Z2))
+}
+
+func (c *secp256k1Curve) Double(x1, y1 *big.Int) (*big.Int, *big.Int) {
+	return c.toAffine(c.doubleJacobian(c.toJacobian(x1, y1)))
+}
+
+func (c *secp256k1Curve) ScalarMult(x1, y1 *big.Int, k []byte) (*big.Int, *big.Int) {
+	px, py, pz := c.toJacobian(x1, y1)
+	X, Y, Z := new(big.Int), new(big.Int), new(big.Int)
+
+	for _, b := range k {
+		for bit := 0; bit < 8; bit++ {
+			X, Y, Z = c.doubleJacobian(X, Y, Z)
+			if b&0x80 == 0x80 {
+				X, Y, Z = c.addJacobian(X, Y, Z, px, py, pz)
+			}
+			b <<= 1
+		}
+	}
+
+	return c.toAffine(X, Y, Z)
+}
+
+func (c *secp256k1Curve) ScalarBaseMult(k []byte) (*big.Int, *big.Int) {
+	return c.ScalarMult(c.params.Gx, c.params.Gy, k)
+}
+
+// toJacobian converts an affine point to Jacobian coordinates.
+// By convention, (0, 0) is the point at infinity, which has Z = 0.
+func (c *secp256k1Curve) toJacobian(x, y *big.Int) (*big.Int, *big.Int, *big.Int) {
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return new(big.Int), new(big.Int), new(big.Int)
+	}
+	return new(big.Int).Set(x), new(big.Int).Set(y), big.NewInt(1)
+}
+
+// toAffine converts a point in Jacobian coordinates to affine coordinates
+func (c *secp256k1Curve) toAffine(X, Y, Z *big.Int) (*big.Int, *big.Int) {
+	if Z.Sign() == 0 {
+		return new(big.Int), new(big.Int)
+	}
+	p := c.params.P
+
+	zInv := new(big.Int).ModInverse(Z, p)
+	zInv2 := new(big.Int).Mul(zInv, zInv)
+
+	x := new(big.Int).Mul(X, zInv2)
+	x.Mod(x, p)
+
+	zInv2.Mul(zInv2, zInv)
+	y := new(big.Int).Mul(Y, zInv2)
+	y.Mod(y, p)
+
+	return x, y
+}
+
+// doubleJacobian doubles a point in Jacobian coordinates
+// (see http://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html#doubling-dbl-2009-l)
+func (c *secp256k1Curve) doubleJacobian(X1, Y1, Z1 *big.Int) (*big.Int, *big.Int, *big.Int) {
+	if Z1.Sign() == 0 || Y1.Sign() == 0 {
+		return new(big.Int), new(big.Int), new(big.Int)
+	}
+	p := c.params.P
+
+	a := new(big.Int).Mul(X1, X1)
+	a.Mod(a, p)
+	b := new(big.Int).Mul(Y1, Y1)
+	b.Mod(b, p)
+	cc := new(big.Int).Mul(b, b)
+	cc.Mod(cc, p)
+
+	// d = 2*((X1+B)²-A-C)
+	d := new(big.Int).Add(X1, b)
+	d.Mul(d, d)
+	d.Sub(d, a)
+	d.Sub(d, cc)
+	d.Lsh(d, 1)
+	d.Mod(d, p)
+
+	// e = 3*A, f = E²
+	e := new(big.Int).Lsh(a, 1)
+	e.Add(e, a)
+	f := new(big.Int).Mul(e, e)
+
+	// X3 = F-2*D
+	X3 := new(big.Int).Sub(f, new(big.Int).Lsh(d, 1))
+	X3.Mod(X3, p)
+
+	// Y3 = E*(D-X3)-8*C
+	Y3 := new(big.Int).Sub(d, X3)
+	Y3.Mul(Y3, e)
+	Y3.Sub(Y3, new(big.Int).Lsh(cc, 3))
+	Y3.Mod(Y3, p)
+
+	// Z3 = 2*Y1*Z1
+	Z3 := new(big.Int).Mul(Y1, Z1)
+	Z3.Lsh(Z3, 1)
+	Z3.Mod(Z3, p)
+
+	return X3, Y3, Z3
+}
+
+// addJacobian adds two points in Jacobian coordinates
+// (see http://hyperelliptic.org/EFD/g1p/auto-shortw-jacobian-0.html#addition-add-2007-bl)
+func (c *secp256k1Curve) addJacobian(X1, Y1, Z1, X2, Y2, Z2 *big.Int) (*big.Int, *big.Int, *big.Int) {
+	if Z1.Sign() == 0 {
+		return new(big.Int).Set(X2), new(big.Int).Set(Y2), new(big.Int).Set(Z2)
+	}
+	if Z2.Sign() == 0 {
+		return new(big.Int).Set(X1), new(big.Int).Set(Y1), new(big.Int).Set(Z1)
+	}
+	p := c.params.P
+
+	z1z1 := new(big.Int).Mul(Z1, Z1)
+	z1z1.Mod(z1z1, p)
+	z2z2 := new(big.Int).Mul(Z2, Z2)
+	z2z2.Mod(z2z2, p)
+
+	u1 := new(big.Int).Mul(X1, z2z2)
+	u1.Mod(u1, p)
+	u2 := new(big.Int).Mul(X2, z1z1)
+	u2.Mod(u2, p)
+
+	s1 := new(big.Int).Mul(Y1, Z2)
+	s1.Mul(s1, z2z2)
+	s1.Mod(s1, p)
+	s2 := new(big.Int).Mul(Y2, Z1)
+	s2.Mul(s2, z1z1)
+	s2.Mod(s2, p)
+
+	h := new(big.Int).Sub(u2, u1)
+	h.Mod(h, p)
+	r := new(big.Int).Sub(s2, s1)
+	r.Mod(r, p)
+
+	if h.Sign() == 0 {
+		if r.Sign() == 0 {
+			return c.doubleJacobian(X1, Y1, Z1)
+		}
+		// the points are the inverse of each other
+		return new(big.Int), new(big.Int), new(big.Int)
+	}
+	r.Lsh(r, 1)
+
+	// I = (2*H)², J = H*I, V = U1*I
+	i := new(big.Int).Lsh(h, 1)
+	i.Mul(i, i)
+	j := new(big.Int).Mul(h, i)
+	v := new(big.Int).Mul(u1, i)
+
+	// X3 = r²-J-2*V
+	X3 := new(big.Int).Mul(r, r)
+	X3.Sub(X3, j)
+	X3.Sub(X3, new(big.Int).Lsh(v, 1))
+	X3.Mod(X3, p)
+
+	// Y3 = r*(V-X3)-2*S1*J
+	Y3 := new(big.Int).Sub(v, X3)
+	Y3.Mul(Y3, r)
+	s1.Mul(s1, j)
+	s1.Lsh(s1, 1)
+	Y3.Sub(Y3, s1)
+	Y3.Mod(Y3, p)
+
+	// Z3 = ((Z1+Z2)²-Z1Z1-Z2Z2)*H
+	Z3 := new(big.Int).Add(Z1, Z2)
+	Z3.Mul(Z3, Z3)
+	Z3.Sub(Z3, z1z1)
+	Z3.Sub(Z3, z2z2)
+	Z3.Mul(Z3, h)
+	Z3.Mod(Z3, p)
+
+	return X3, Y3, Z3
+}
@@ -0,0 +1,132 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func hexToBig(t *testing.T, s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 16)
+	assert.True(t, ok)
+	return n
+}
+
+func TestSecp256k1Arithmetic(t *testing.T) {
+	curve := Secp256k1()
+	params := curve.Params()
+	assert.True(t, curve.IsOnCurve(params.Gx, params.Gy))
+	assert.False(t, curve.IsOnCurve(params.Gx, new(big.Int).Add(params.Gy, big.NewInt(1))))
+
+	// Known multiples of the generator
+	x2 := hexToBig(t, "C6047F9441ED7D6D3045406E95C07CD85C778E4B8CEF3CA7ABAC09B95C709EE5")
+	y2 := hexToBig(t, "1AE168FEA63DC339A3C58419466CEAEEF7F632653266D0E1236431A950CFE52A")
+	x3 := hexToBig(t, "F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9")
+	y3 := hexToBig(t, "388F7B0F632DE8140FE337E62A37F3566500A99934C2231B6CB9FD7584B8E672")
+
+	x, y := curve.Double(params.Gx, params.Gy)
+	assert.Equal(t, x2, x)
+	assert.Equal(t, y2, y)
+
+	x, y = curve.ScalarBaseMult([]byte{2})
+	assert.Equal(t, x2, x)
+	assert.Equal(t, y2, y)
+
+	x, y = curve.Add(params.Gx, params.Gy, x2, y2)
+	assert.Equal(t, x3, x)
+	assert.Equal(t, y3, y)
+
+	x, y = curve.ScalarBaseMult([]byte{3})
+	assert.Equal(t, x3, x)
+	assert.Equal(t, y3, y)
+
+	// The order of the generator is N
+	x, y = curve.ScalarBaseMult(params.N.Bytes())
+	assert.Equal(t, 0, x.Sign())
+	assert.Equal(t, 0, y.Sign())
+
+	// (N-1)*G = -G
+	x, y = curve.ScalarBaseMult(new(big.Int).Sub(params.N, big.NewInt(1)).Bytes())
+	assert.Equal(t, params.Gx, x)
+	assert.Equal(t, new(big.Int).Sub(params.P, params.Gy), y)
+}
+
+func TestSecp256k1ECDSA(t *testing.T) {
+	k, err := ecdsa.GenerateKey(Secp256k1(), rand.Reader)
+	assert.NoError(t, err)
+	assert.True(t, k.Curve.IsOnCurve(k.X, k.Y))
+
+	digest := sha256.Sum256([]byte("hello world"))
+	r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+	assert.NoError(t, err)
+	s, _, err = ToLowS(&k.PublicKey, s)
+	assert.NoError(t, err)
+	assert.True(t, ecdsa.Verify(&k.PublicKey, digest[:], r, s))
+
+	otherDigest := sha256.Sum256([]byte("another message"))
+	assert.False(t, ecdsa.Verify(&k.PublicKey, otherDigest[:], r, s))
+}
+
+func TestSecp256k1Keys(t *testing.T) {
+	key, err := ecdsa.GenerateKey(Secp256k1(), rand.Reader)
+	assert.NoError(t, err)
+
+	// Private key to PEM and back
+	pemBytes, err := PrivateKeyToPEM(key, nil)
+	assert.NoError(t, err)
+	keyFromPEM, err := PEMtoPrivateKey(pemBytes, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, key.D, keyFromPEM.(*ecdsa.PrivateKey).D)
+	assert.Equal(t, key.X, keyFromPEM.(*ecdsa.PrivateKey).X)
+	assert.Equal(t, Secp256k1(), keyFromPEM.(*ecdsa.PrivateKey).Curve)
+
+	// Private key to encrypted PEM and back
+	pemBytes, err = PrivateKeyToPEM(key, []byte("passwd"))
+	assert.NoError(t, err)
+	keyFromPEM, err = PEMtoPrivateKey(pemBytes, []byte("passwd"))
+	assert.NoError(t, err)
+	assert.Equal(t, key.D, keyFromPEM.(*ecdsa.PrivateKey).D)
+
+	// Private key to DER and back
+	der, err := PrivateKeyToDER(key)
+	assert.NoError(t, err)
+	keyFromDER, err := DERToPrivateKey(der)
+	assert.NoError(t, err)
+	assert.Equal(t, key.D, keyFromDER.(*ecdsa.PrivateKey).D)
+
+	// Public key to PEM, encrypted PEM and DER and back
+	pemBytes, err = PublicKeyToPEM(&key.PublicKey, nil)
+	assert.NoError(t, err)
+	pkFromPEM, err := PEMtoPublicKey(pemBytes, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, key.X, pkFromPEM.(*ecdsa.PublicKey).X)
+	assert.Equal(t, key.Y, pkFromPEM.(*ecdsa.PublicKey).Y)
+
+	pemBytes, err = PublicKeyToPEM(&key.PublicKey, []byte("passwd"))
+	assert.NoError(t, err)
+	pkFromPEM, err = PEMtoPublicKey(pemBytes, []byte("passwd"))
+	assert.NoError(t, err)
+	assert.Equal(t, key.X, pkFromPEM.(*ecdsa.PublicKey).X)
+
+	der, err = PublicKeyToDER(&key.PublicKey)
+	assert.NoError(t, err)
+	pkFromDER, err := DERToPublicKey(der)
+	assert.NoError(t, err)
+	assert.Equal(t, Secp256k1(), pkFromDER.(*ecdsa.PublicKey).Curve)
+	assert.Equal(t, key.Y, pkFromDER.(*ecdsa.PublicKey).Y)
+
+	// Corrupted point
+	der[len(der)-1] ^= 1
+	_, err = DERToPublicKey(der)
+	assert.Error(t, err)
+}
@@ -17,10 +17,248 @@ limitations under the License.
 package utils
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha1"
 	"crypto/x509"
+	"encoding/asn1"
+	"io"
+
+	"github.com/pkg/errors"
 )
 
-// DERToX509Certificate converts der to x509
+// DERToX509Certificate converts der to x509.
+// Certificates for secp256k1 keys, which the x509 package cannot parse,
+// are supported as well.
 func DERToX509Certificate(asn1Data []byte) (*x509.Certificate, error) {
-	return x509.ParseCertificate(asn1Data)
+	cert, err := x509.ParseCertificate(asn1Data)
+	if err == nil {
+		return cert, nil
+	}
+	cert, secp256k1Err := parseSecp256k1Certificate(asn1Data)
+	if secp256k1Err != nil {
+		return nil, err
+	}
+	return cert, nil
+}
+
+// CreateX509Certificate creates a new certificate as x509.CreateCertificate
+// does. In addition, the certified public key and the key of the issuer
+// may be secp256k1 keys.
+func CreateX509Certificate(rand io.Reader, template, parent *x509.Certificate, pub, priv interface{}) ([]byte, error) {
+	signer, ok := priv.(crypto.Signer)
+	if !ok || (!isSecp256k1(pub) && !isSecp256k1(signer.Public())) {
+		return x509.CreateCertificate(rand, template, parent, pub, priv)
+	}
+
+	// The certificate is created for, and signed with, a P-256 key standing
+	// in for the secp256k1 keys. The public key is then replaced and the
+	// certificate signed again with priv.
+	placeholder, err := ecdsa.GenerateKey(elliptic.P256(), rand)
+	if err != nil {
+		return nil, err
+	}
+
+	certTemplate := *template
+	var certPub interface{} = pub
+	if isSecp256k1(pub) {
+		certPub = &placeholder.PublicKey
+		if len(certTemplate.SubjectKeyId) == 0 && certTemplate.IsCA {
+			k := pub.(*ecdsa.PublicKey)
+			ski := sha1.Sum(elliptic.Marshal(k.Curve, k.X, k.Y))
+			certTemplate.SubjectKeyId = ski[:]
+		}
+	}
+	certParent := parent
+	var certSigner crypto.Signer = signer
+	if isSecp256k1(signer.Public()) {
+		p := *parent
+		p.PublicKey = &placeholder.PublicKey
+		certParent = &p
+		certSigner = placeholder
+	}
+	if parent == template {
+		certParent = &certTemplate
+		if isSecp256k1(signer.Public()) {
+			certParent.PublicKey = &placeholder.PublicKey
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand, &certTemplate, certParent, certPub, certSigner)
+	if err != nil {
+		return nil, err
+	}
+	placeholderCert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	var c rawCertificate
+	if err = unmarshalCertificate(der, &c); err != nil {
+		return nil, err
+	}
+	if isSecp256k1(pub) {
+		spki, err := marshalPKIXPublicKey(pub)
+		if err != nil {
+			return nil, err
+		}
+		if c.TBSCertificate.FullBytes, err = replaceSubjectPublicKeyInfo(c.TBSCertificate.FullBytes, spki); err != nil {
+			return nil, err
+		}
+	}
+
+	var hashFunc crypto.Hash
+	switch placeholderCert.SignatureAlgorithm {
+	case x509.ECDSAWithSHA256:
+		hashFunc = crypto.SHA256
+	case x509.ECDSAWithSHA384:
+		hashFunc = crypto.SHA384
+	case x509.ECDSAWithSHA512:
+		hashFunc = crypto.SHA512
+	case x509.PureEd25519:
+	default:
+		return nil, errors.Errorf("unsupported signature algorithm %s", placeholderCert.SignatureAlgorithm)
+	}
+	signed := c.TBSCertificate.FullBytes
+	if hashFunc != 0 {
+		h := hashFunc.New()
+		h.Write(signed)
+		signed = h.Sum(nil)
+	}
+	signature, err := signer.Sign(rand, signed, hashFunc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed signing certificate")
+	}
+	sigValue, err := asn1.Marshal(asn1.BitString{Bytes: signature, BitLength: 8 * len(signature)})
+	if err != nil {
+		return nil, err
+	}
+	c.SignatureValue = asn1.RawValue{FullBytes: sigValue}
+
+	return asn1.Marshal(c)
+}
+
+// rawCertificate is the outer structure of an X.509 certificate
+type rawCertificate struct {
+	TBSCertificate     asn1.RawValue
+	SignatureAlgorithm asn1.RawValue
+	SignatureValue     asn1.RawValue
+}
+
+func unmarshalCertificate(der []byte, c *rawCertificate) error {
+	rest, err := asn1.Unmarshal(der, c)
+	if err != nil {
+		return errors.Wrap(err, "failed unmarshalling certificate")
+	}
+	if len(rest) != 0 {
+		return errors.New("trailing data after certificate")
+	}
+	return nil
+}
+
+// parseSecp256k1Certificate parses a certificate whose subject public key
+// is a secp256k1 key. The certificate is parsed by the x509 package with
+// the key replaced by a P-256 point, and the fields derived from the key
+// are then restored.
+func parseSecp256k1Certificate(der []byte) (*x509.Certificate, error) {
+	var c rawCertificate
+	if err := unmarshalCertificate(der, &c); err != nil {
+		return nil, err
+	}
+	tbs := c.TBSCertificate.FullBytes
+	spki, err := subjectPublicKeyInfo(tbs)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := parseSecp256k1PublicKey(spki)
+	if err != nil {
+		return nil, err
+	}
+
+	p256 := elliptic.P256().Params()
+	placeholder, err := x509.MarshalPKIXPublicKey(&ecdsa.PublicKey{Curve: elliptic.P256(), X: p256.Gx, Y: p256.Gy})
+	if err != nil {
+		return nil, err
+	}
+	if c.TBSCertificate.FullBytes, err = replaceSubjectPublicKeyInfo(tbs, placeholder); err != nil {
+		return nil, err
+	}
+	placeholderDER, err := asn1.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	cert, err := x509.ParseCertificate(placeholderDER)
+	if err != nil {
+		return nil, err
+	}
+
+	cert.Raw = der
+	cert.RawTBSCertificate = tbs
+	cert.RawSubjectPublicKeyInfo = spki
+	cert.PublicKey = pub
+	return cert, nil
+}
+
+// tbsCertificateElements splits a TBSCertificate in its elements and returns
+// them together with the position of the SubjectPublicKeyInfo
+func tbsCertificateElements(tbs []byte) ([]asn1.RawValue, int, error) {
+	var seq asn1.RawValue
+	rest, err := asn1.Unmarshal(tbs, &seq)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed unmarshalling TBSCertificate")
+	}
+	if len(rest) != 0 || seq.Class != asn1.ClassUniversal || seq.Tag != asn1.TagSequence {
+		return nil, 0, errors.New("invalid TBSCertificate")
+	}
+
+	var elements []asn1.RawValue
+	for data := seq.Bytes; len(data) > 0; {
+		var element asn1.RawValue
+		data, err = asn1.Unmarshal(data, &element)
+		if err != nil {
+			return nil, 0, errors.Wrap(err, "failed unmarshalling TBSCertificate")
+		}
+		elements = append(elements, element)
+	}
+
+	// serialNumber, signature, issuer, validity, subject, subjectPublicKeyInfo,
+	// preceded by the optional version
+	index := 5
+	if len(elements) > 0 && elements[0].Class == asn1.ClassContextSpecific && elements[0].Tag == 0 {
+		index++
+	}
+	if len(elements) <= index {
+		return nil, 0, errors.New("invalid TBSCertificate: missing subject public key info")
+	}
+	return elements, index, nil
+}
+
+func subjectPublicKeyInfo(tbs []byte) ([]byte, error) {
+	elements, index, err := tbsCertificateElements(tbs)
+	if err != nil {
+		return nil, err
+	}
+	return elements[index].FullBytes, nil
+}
+
+func replaceSubjectPublicKeyInfo(tbs, spki []byte) ([]byte, error) {
+	elements, index, err := tbsCertificateElements(tbs)
+	if err != nil {
+		return nil, err
+	}
+	var content []byte
+	for i, element := range elements {
+		if i == index {
+			content = append(content, spki...)
+			continue
+		}
+		content = append(content, element.FullBytes...)
+	}
+	return asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSequence, IsCompound: true, Bytes: content})
+}
+
+func isSecp256k1(pub interface{}) bool {
+	k, ok := pub.(*ecdsa.PublicKey)
+	return ok && k.Curve == Secp256k1()
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package utils
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
@@ -99,3 +100,81 @@ func TestDERToX509Certificate(t *testing.T) {
 	assert.Equal(t, cert.Raw, certRaw)
 
 }
+
+func TestSecp256k1X509Certificate(t *testing.T) {
+	template := func(cn string, isCA bool) *x509.Certificate {
+		return &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: cn},
+			NotBefore:             time.Now().Add(-1 * time.Hour),
+			NotAfter:              time.Now().Add(1 * time.Hour),
+			KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+			BasicConstraintsValid: true,
+			IsCA:                  isCA,
+		}
+	}
+
+	k1CAKey, err := ecdsa.GenerateKey(Secp256k1(), rand.Reader)
+	assert.NoError(t, err)
+	p256CAKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	k1Key, err := ecdsa.GenerateKey(Secp256k1(), rand.Reader)
+	assert.NoError(t, err)
+	p256Key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+
+	// self-signed secp256k1 CA
+	k1CATemplate := template("k1 ca", true)
+	raw, err := CreateX509Certificate(rand.Reader, k1CATemplate, k1CATemplate, &k1CAKey.PublicKey, k1CAKey)
+	assert.NoError(t, err)
+	_, err = x509.ParseCertificate(raw)
+	assert.Error(t, err)
+	k1CA, err := DERToX509Certificate(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, raw, k1CA.Raw)
+	assert.Equal(t, &k1CAKey.PublicKey, k1CA.PublicKey)
+	assert.NotEmpty(t, k1CA.SubjectKeyId)
+	assert.NoError(t, k1CA.CheckSignatureFrom(k1CA))
+
+	p256CATemplate := template("p256 ca", true)
+	raw, err = x509.CreateCertificate(rand.Reader, p256CATemplate, p256CATemplate, &p256CAKey.PublicKey, p256CAKey)
+	assert.NoError(t, err)
+	p256CA, err := DERToX509Certificate(raw)
+	assert.NoError(t, err)
+
+	for _, tc := range []struct {
+		name   string
+		parent *x509.Certificate
+		signer *ecdsa.PrivateKey
+		pub    *ecdsa.PublicKey
+	}{
+		{"secp256k1 issued by secp256k1", k1CA, k1CAKey, &k1Key.PublicKey},
+		{"P-256 issued by secp256k1", k1CA, k1CAKey, &p256Key.PublicKey},
+		{"secp256k1 issued by P-256", p256CA, p256CAKey, &k1Key.PublicKey},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			raw, err := CreateX509Certificate(rand.Reader, template("leaf", false), tc.parent, tc.pub, tc.signer)
+			assert.NoError(t, err)
+			cert, err := DERToX509Certificate(raw)
+			assert.NoError(t, err)
+			assert.Equal(t, raw, cert.Raw)
+			assert.Equal(t, tc.pub, cert.PublicKey)
+			assert.Equal(t, "leaf", cert.Subject.CommonName)
+
+			roots := x509.NewCertPool()
+			roots.AddCert(tc.parent)
+			_, err = cert.Verify(x509.VerifyOptions{Roots: roots})
+			assert.NoError(t, err)
+		})
+	}
+
+	// a certificate with a broken key is not parsed
+	raw, err = CreateX509Certificate(rand.Reader, template("leaf", false), k1CA, &k1Key.PublicKey, k1CAKey)
+	assert.NoError(t, err)
+	point := elliptic.Marshal(Secp256k1(), k1Key.X, k1Key.Y)
+	i := bytes.Index(raw, point)
+	assert.True(t, i > 0)
+	raw[i+len(point)-1] ^= 0xff
+	_, err = DERToX509Certificate(raw)
+	assert.Error(t, err)
+}
@@ -28,6 +28,25 @@ func TestApplicationV11(t *testing.T) {
 	assert.True(t, op.V1_1Validation())
 }
 
+func TestApplicationV12(t *testing.T) {
+	op := NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_2: {},
+	})
+	assert.NoError(t, op.Supported())
+	assert.True(t, op.ForbidDuplicateTXIdInBlock())
+	assert.True(t, op.V1_1Validation())
+	assert.True(t, op.ACLs())
+	assert.True(t, op.ImplicitCollections())
+	assert.True(t, op.SignaturePolicyExtensions())
+
+	op = NewApplicationProvider(map[string]*cb.Capability{
+		ApplicationV1_1: {},
+	})
+	assert.False(t, op.ACLs())
+	assert.False(t, op.ImplicitCollections())
+	assert.False(t, op.SignaturePolicyExtensions())
+}
+
 func TestApplicationPvtDataExperimental(t *testing.T) {
 	op := NewApplicationProvider(map[string]*cb.Capability{
 		ApplicationPvtDataExperimental: {},
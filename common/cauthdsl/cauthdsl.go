@@ -8,13 +8,16 @@ package cauthdsl
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
 	mb "github.com/hyperledger/fabric/protos/msp"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/op/go-logging"
 )
 
@@ -22,18 +25,29 @@ var cauthdslLogger = flogging.MustGetLogger("cauthdsl")
 
 // deduplicate removes any duplicated identities while otherwise preserving identity order
 func deduplicate(sds []*cb.SignedData, deserializer msp.IdentityDeserializer) []*cb.SignedData {
+	return deduplicateTraced(sds, deserializer, nil)
+}
+
+// deduplicateTraced is like deduplicate, but records the discarded signatures in the trace, if there is one
+func deduplicateTraced(sds []*cb.SignedData, deserializer msp.IdentityDeserializer, trace *policies.EvaluationTrace) []*cb.SignedData {
 	ids := make(map[string]struct{})
 	result := make([]*cb.SignedData, 0, len(sds))
 	for i, sd := range sds {
 		identity, err := deserializer.DeserializeIdentity(sd.Identity)
 		if err != nil {
 			cauthdslLogger.Errorf("Principal deserialization failure (%s) for identity %x", err, sd.Identity)
+			if trace != nil {
+				trace.Discarded = append(trace.Discarded, fmt.Sprintf("signature %d: identity cannot be deserialized: %s", i, err))
+			}
 			continue
 		}
 		key := identity.GetIdentifier().Mspid + identity.GetIdentifier().Id
 
 		if _, ok := ids[key]; ok {
 			cauthdslLogger.Warningf("De-duplicating identity %x at index %d in signature set", sd.Identity, i)
+			if trace != nil {
+				trace.Discarded = append(trace.Discarded, fmt.Sprintf("signature %d: duplicate of an earlier signature by %s", i, identityString(identity)))
+			}
 		} else {
 			result = append(result, sd)
 			ids[key] = struct{}{}
@@ -42,32 +56,103 @@ func deduplicate(sds []*cb.SignedData, deserializer msp.IdentityDeserializer) []
 	return result
 }
 
+// evaluator evaluates a compiled policy against the signed data, the used flags of which mark the signatures
+// already matched by other principals. When the parent trace is not nil, the evaluation is traced as one
+// of its sub-policies
+type evaluator func(signedData []*cb.SignedData, used []bool, parent *policies.EvaluationTrace) bool
+
+// newTrace adds a trace for the evaluation of the described policy to the parent trace, if there is one
+func newTrace(parent *policies.EvaluationTrace, description string) *policies.EvaluationTrace {
+	if parent == nil {
+		return nil
+	}
+	trace := &policies.EvaluationTrace{Policy: description}
+	parent.SubPolicies = append(parent.SubPolicies, trace)
+	return trace
+}
+
+// principalString describes the principal as it would be written in the policy language
+func principalString(principal *mb.MSPPrincipal) string {
+	switch principal.PrincipalClassification {
+	case mb.MSPPrincipal_ROLE:
+		role := &mb.MSPRole{}
+		if err := proto.Unmarshal(principal.Principal, role); err == nil {
+			return fmt.Sprintf("'%s.%s'", role.MspIdentifier, strings.ToLower(role.Role.String()))
+		}
+	case mb.MSPPrincipal_ORGANIZATION_UNIT, mb.MSPPrincipal_ORGANIZATION_UNIT_NAME:
+		ou := &mb.OrganizationUnit{}
+		if err := proto.Unmarshal(principal.Principal, ou); err == nil {
+			return fmt.Sprintf("'%s.OU=%s'", ou.MspIdentifier, ou.OrganizationalUnitIdentifier)
+		}
+	case mb.MSPPrincipal_IDENTITY:
+		sID := &mb.SerializedIdentity{}
+		if err := proto.Unmarshal(principal.Principal, sID); err == nil {
+			return fmt.Sprintf("identity of %s", sID.Mspid)
+		}
+	case mb.MSPPrincipal_IDENTITY_HASH:
+		idHash := &mb.IdentityHash{}
+		if err := proto.Unmarshal(principal.Principal, idHash); err == nil {
+			return fmt.Sprintf("'%s.id=%x'", idHash.MspIdentifier, idHash.Hash)
+		}
+	}
+	return fmt.Sprintf("principal of type %s", principal.PrincipalClassification)
+}
+
+// identityString describes the identity of a signature for traces
+func identityString(identity msp.Identity) string {
+	return fmt.Sprintf("%s identity %s", identity.GetIdentifier().Mspid, identity.GetIdentifier().Id)
+}
+
+// extendedPrincipal returns whether the principal is of a type that is only understood by peers
+// which support the signature policy extensions
+func extendedPrincipal(principal *mb.MSPPrincipal) bool {
+	switch principal.PrincipalClassification {
+	case mb.MSPPrincipal_IDENTITY_HASH, mb.MSPPrincipal_ORGANIZATION_UNIT_NAME:
+		return true
+	}
+	return false
+}
+
 // compile recursively builds a go evaluatable function corresponding to the policy specified, remember to call deduplicate on identities before
-// passing them to this function for evaluation
-func compile(policy *cb.SignaturePolicy, identities []*mb.MSPPrincipal, deserializer msp.IdentityDeserializer) (func([]*cb.SignedData, []bool) bool, error) {
+// passing them to this function for evaluation. Unless the signature policy extensions are enabled, policy references are rejected and
+// the identity hash and organization unit name principals are never satisfied, as for the peers which predate them. Policy references
+// are resolved through the supplied policy manager, which may be nil, in which case policy references are never satisfied
+func compile(policy *cb.SignaturePolicy, identities []*mb.MSPPrincipal, deserializer msp.IdentityDeserializer, policyManager policies.Manager, extensions bool) (func([]*cb.SignedData, []bool) bool, error) {
+	eval, err := compileEvaluator(policy, identities, deserializer, policyManager, extensions)
+	if err != nil {
+		return nil, err
+	}
+	return func(signedData []*cb.SignedData, used []bool) bool {
+		return eval(signedData, used, nil)
+	}, nil
+}
+
+// compileEvaluator is like compile, but builds an evaluator which can trace the evaluation
+func compileEvaluator(policy *cb.SignaturePolicy, identities []*mb.MSPPrincipal, deserializer msp.IdentityDeserializer, policyManager policies.Manager, extensions bool) (evaluator, error) {
 	if policy == nil {
 		return nil, fmt.Errorf("Empty policy element")
 	}
 
 	switch t := policy.Type.(type) {
 	case *cb.SignaturePolicy_NOutOf_:
-		policies := make([]func([]*cb.SignedData, []bool) bool, len(t.NOutOf.Rules))
+		subPolicies := make([]evaluator, len(t.NOutOf.Rules))
 		for i, policy := range t.NOutOf.Rules {
-			compiledPolicy, err := compile(policy, identities, deserializer)
+			compiledPolicy, err := compileEvaluator(policy, identities, deserializer, policyManager, extensions)
 			if err != nil {
 				return nil, err
 			}
-			policies[i] = compiledPolicy
+			subPolicies[i] = compiledPolicy
 
 		}
-		return func(signedData []*cb.SignedData, used []bool) bool {
+		return func(signedData []*cb.SignedData, used []bool, parent *policies.EvaluationTrace) bool {
 			grepKey := time.Now().UnixNano()
 			cauthdslLogger.Debugf("%p gate %d evaluation starts", signedData, grepKey)
+			trace := newTrace(parent, fmt.Sprintf("OutOf(%d)", t.NOutOf.N))
 			verified := int32(0)
 			_used := make([]bool, len(used))
-			for _, policy := range policies {
+			for _, policy := range subPolicies {
 				copy(_used, used)
-				if policy(signedData, _used) {
+				if policy(signedData, _used, trace) {
 					verified++
 					copy(used, _used)
 				}
@@ -79,6 +164,11 @@ func compile(policy *cb.SignaturePolicy, identities []*mb.MSPPrincipal, deserial
 				cauthdslLogger.Debugf("%p gate %d evaluation fails", signedData, grepKey)
 			}
 
+			if trace != nil {
+				trace.Satisfied = verified >= t.NOutOf.N
+				trace.Reason = fmt.Sprintf("%d of %d sub-policies satisfied, %d required", verified, len(subPolicies), t.NOutOf.N)
+			}
+
 			return verified >= t.NOutOf.N
 		}, nil
 	case *cb.SignaturePolicy_SignedBy:
@@ -86,11 +176,26 @@ func compile(policy *cb.SignaturePolicy, identities []*mb.MSPPrincipal, deserial
 			return nil, fmt.Errorf("identity index out of range, requested %v, but identies length is %d", t.SignedBy, len(identities))
 		}
 		signedByID := identities[t.SignedBy]
-		return func(signedData []*cb.SignedData, used []bool) bool {
+		unsupported := !extensions && extendedPrincipal(signedByID)
+		return func(signedData []*cb.SignedData, used []bool, parent *policies.EvaluationTrace) bool {
 			cauthdslLogger.Debugf("%p signed by %d principal evaluation starts (used %v)", signedData, t.SignedBy, used)
+			trace := newTrace(parent, "SignedBy "+principalString(signedByID))
+			if unsupported {
+				cauthdslLogger.Warningf("Principal of type %s requires the signature policy extensions, which are not enabled", signedByID.PrincipalClassification)
+				if trace != nil {
+					trace.Reason = "the principal requires the signature policy extensions, which are not enabled"
+				}
+				return false
+			}
+			traceSignature := func(format string, args ...interface{}) {
+				if trace != nil {
+					trace.Signatures = append(trace.Signatures, fmt.Sprintf(format, args...))
+				}
+			}
 			for i, sd := range signedData {
 				if used[i] {
 					cauthdslLogger.Debugf("%p skipping identity %d because it has already been used", signedData, i)
+					traceSignature("signature %d already used by another principal", i)
 					continue
 				}
 				if cauthdslLogger.IsEnabledFor(logging.DEBUG) {
@@ -100,27 +205,101 @@ func compile(policy *cb.SignaturePolicy, identities []*mb.MSPPrincipal, deserial
 				identity, err := deserializer.DeserializeIdentity(sd.Identity)
 				if err != nil {
 					cauthdslLogger.Errorf("Principal deserialization failure (%s) for identity %x", err, sd.Identity)
+					traceSignature("signature %d has an identity which cannot be deserialized: %s", i, err)
 					continue
 				}
 				err = identity.SatisfiesPrincipal(signedByID)
 				if err != nil {
 					cauthdslLogger.Debugf("%p identity %d does not satisfy principal: %s", signedData, i, err)
+					traceSignature("signature %d by %s does not satisfy the principal: %s", i, identityString(identity), err)
 					continue
 				}
 				cauthdslLogger.Debugf("%p principal matched by identity %d", signedData, i)
 				err = identity.Verify(sd.Data, sd.Signature)
 				if err != nil {
 					cauthdslLogger.Debugf("%p signature for identity %d is invalid: %s", signedData, i, err)
+					traceSignature("signature %d by %s satisfies the principal but is invalid: %s", i, identityString(identity), err)
 					continue
 				}
 				cauthdslLogger.Debugf("%p principal evaluation succeeds for identity %d", signedData, i)
+				traceSignature("signature %d by %s satisfies the principal", i, identityString(identity))
+				if trace != nil {
+					trace.Satisfied = true
+				}
 				used[i] = true
 				return true
 			}
 			cauthdslLogger.Debugf("%p principal evaluation fails", signedData)
+			if trace != nil {
+				trace.Reason = "no valid unused signature satisfies the principal"
+			}
 			return false
 		}, nil
+	case *cb.SignaturePolicy_PolicyRef:
+		if !extensions {
+			return nil, fmt.Errorf("Policy reference %s requires the signature policy extensions, which are not enabled", t.PolicyRef)
+		}
+		if t.PolicyRef == "" {
+			return nil, fmt.Errorf("Empty policy reference")
+		}
+		policyRef := t.PolicyRef
+		return func(signedData []*cb.SignedData, used []bool, parent *policies.EvaluationTrace) bool {
+			cauthdslLogger.Debugf("%p policy reference %s evaluation starts (used %v)", signedData, policyRef, used)
+			if policyManager == nil {
+				cauthdslLogger.Warningf("Policy reference %s cannot be resolved without a policy manager", policyRef)
+				if trace := newTrace(parent, "PolicyRef "+policyRef); trace != nil {
+					trace.Reason = "no policy manager to resolve the reference"
+				}
+				return false
+			}
+			referencedPolicy, ok := policyManager.GetPolicy(policyRef)
+			if !ok {
+				cauthdslLogger.Warningf("Policy reference %s does not refer to an existing policy", policyRef)
+				if trace := newTrace(parent, "PolicyRef "+policyRef); trace != nil {
+					trace.Reason = "the referenced policy does not exist"
+				}
+				return false
+			}
+			unusedIdx := make([]int, 0, len(signedData))
+			for i := range signedData {
+				if !used[i] {
+					unusedIdx = append(unusedIdx, i)
+				}
+			}
+			unused := signaturesAt(signedData, unusedIdx)
+			if parent != nil {
+				subTrace, err := policies.Explain(referencedPolicy, unused)
+				trace := newTrace(parent, "PolicyRef "+policyRef)
+				trace.Satisfied = err == nil
+				trace.SubPolicies = []*policies.EvaluationTrace{subTrace}
+			}
+			refUsed, err := policies.EvaluateUsed(referencedPolicy, unused)
+			if err != nil {
+				cauthdslLogger.Debugf("%p policy reference %s evaluation fails: %s", signedData, policyRef, err)
+				return false
+			}
+			// the signatures used by the referenced policy are marked as used,
+			// so that they cannot satisfy other principals as well
+			var usedIdx []int
+			for j, i := range unusedIdx {
+				if refUsed[j] {
+					used[i] = true
+					usedIdx = append(usedIdx, i)
+				}
+			}
+			cauthdslLogger.Debugf("%p policy reference %s evaluation succeeds with identities %v", signedData, policyRef, usedIdx)
+			return true
+		}, nil
 	default:
 		return nil, fmt.Errorf("Unknown type: %T:%v", t, t)
 	}
 }
+
+// signaturesAt returns the signatures at the given indexes of the signed data
+func signaturesAt(signedData []*cb.SignedData, indexes []int) []*cb.SignedData {
+	result := make([]*cb.SignedData, len(indexes))
+	for j, i := range indexes {
+		result[j] = signedData[i]
+	}
+	return result
+}
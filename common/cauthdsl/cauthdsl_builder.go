@@ -77,6 +77,16 @@ func SignedBy(index int32) *cb.SignaturePolicy {
 	}
 }
 
+// SignedByPolicyRef creates a SignaturePolicy requiring the policy
+// of the channel with the given fully qualified name to be satisfied
+func SignedByPolicyRef(policyRef string) *cb.SignaturePolicy {
+	return &cb.SignaturePolicy{
+		Type: &cb.SignaturePolicy_PolicyRef{
+			PolicyRef: policyRef,
+		},
+	}
+}
+
 // SignedByMspMember creates a SignaturePolicyEnvelope
 // requiring 1 signature from any member of the specified MSP
 func SignedByMspMember(mspId string) *cb.SignaturePolicyEnvelope {
@@ -13,6 +13,8 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/msp"
 	cb "github.com/hyperledger/fabric/protos/common"
 	mb "github.com/hyperledger/fabric/protos/msp"
@@ -105,7 +107,7 @@ var moreMsgs = [][]byte{nil, nil, nil}
 func TestSimpleSignature(t *testing.T) {
 	policy := Envelope(SignedBy(0), signers)
 
-	spe, err := compile(policy.Rule, policy.Identities, &mockDeserializer{})
+	spe, err := compile(policy.Rule, policy.Identities, &mockDeserializer{}, nil, false)
 	if err != nil {
 		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
 	}
@@ -124,7 +126,7 @@ func TestSimpleSignature(t *testing.T) {
 func TestMultipleSignature(t *testing.T) {
 	policy := Envelope(And(SignedBy(0), SignedBy(1)), signers)
 
-	spe, err := compile(policy.Rule, policy.Identities, &mockDeserializer{})
+	spe, err := compile(policy.Rule, policy.Identities, &mockDeserializer{}, nil, false)
 	if err != nil {
 		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
 	}
@@ -143,7 +145,7 @@ func TestMultipleSignature(t *testing.T) {
 func TestComplexNestedSignature(t *testing.T) {
 	policy := Envelope(And(Or(And(SignedBy(0), SignedBy(1)), And(SignedBy(0), SignedBy(0))), SignedBy(0)), signers)
 
-	spe, err := compile(policy.Rule, policy.Identities, &mockDeserializer{})
+	spe, err := compile(policy.Rule, policy.Identities, &mockDeserializer{}, nil, false)
 	if err != nil {
 		t.Fatalf("Could not create a new SignaturePolicyEvaluator using the given policy, crypto-helper: %s", err)
 	}
@@ -171,14 +173,14 @@ func TestNegatively(t *testing.T) {
 	b, _ := proto.Marshal(rpolicy)
 	policy := &cb.SignaturePolicyEnvelope{}
 	_ = proto.Unmarshal(b, policy)
-	_, err := compile(policy.Rule, policy.Identities, &mockDeserializer{})
+	_, err := compile(policy.Rule, policy.Identities, &mockDeserializer{}, nil, false)
 	if err == nil {
 		t.Fatal("Should have errored compiling because the Type field was nil")
 	}
 }
 
 func TestNilSignaturePolicyEnvelope(t *testing.T) {
-	_, err := compile(nil, nil, &mockDeserializer{})
+	_, err := compile(nil, nil, &mockDeserializer{}, nil, false)
 	assert.Error(t, err, "Fail to compile")
 }
 
@@ -269,3 +271,113 @@ func TestSignedByMspPeer(t *testing.T) {
 	assert.Equal(t, role.MspIdentifier, "A")
 	assert.Equal(t, role.Role, mb.MSPRole_PEER)
 }
+
+func TestPolicyRef(t *testing.T) {
+	policy := Envelope(Or(SignedByPolicyRef("/Channel/Application/Admins"), SignedBy(0)), [][]byte{[]byte("signer0")})
+	signedData, used := toSignedData([][]byte{nil}, [][]byte{[]byte("signer1")}, [][]byte{validSignature})
+
+	pm := &mockpolicies.Manager{
+		PolicyMap: map[string]policies.Policy{
+			"/Channel/Application/Admins": &mockpolicies.Policy{},
+		},
+	}
+	spe, err := compile(policy.Rule, policy.Identities, &mockDeserializer{}, pm, true)
+	assert.NoError(t, err)
+	assert.True(t, spe(signedData, used), "Policy reference should have been satisfied")
+
+	pm.PolicyMap["/Channel/Application/Admins"] = &mockpolicies.Policy{Err: errors.New("nope")}
+	assert.False(t, spe(signedData, make([]bool, 1)), "Failing policy reference should not have been satisfied")
+
+	delete(pm.PolicyMap, "/Channel/Application/Admins")
+	assert.False(t, spe(signedData, make([]bool, 1)), "Missing policy reference should not have been satisfied")
+
+	spe, err = compile(policy.Rule, policy.Identities, &mockDeserializer{}, nil, true)
+	assert.NoError(t, err)
+	assert.False(t, spe(signedData, make([]bool, 1)), "Policy reference should not have been satisfied without a policy manager")
+
+	_, err = compile(SignedByPolicyRef(""), nil, &mockDeserializer{}, nil, true)
+	assert.Error(t, err)
+
+	_, err = compile(policy.Rule, policy.Identities, &mockDeserializer{}, pm, false)
+	assert.Error(t, err, "Policy reference should have been rejected without the signature policy extensions")
+}
+
+func TestPolicyRefTrace(t *testing.T) {
+	policy := Envelope(Or(SignedByPolicyRef("/Channel/Application/Admins"), SignedByPolicyRef("/Channel/Missing")), nil)
+	signedData, used := toSignedData([][]byte{nil}, [][]byte{[]byte("signer1")}, [][]byte{validSignature})
+
+	pm := &mockpolicies.Manager{
+		PolicyMap: map[string]policies.Policy{
+			"/Channel/Application/Admins": &mockpolicies.Policy{Err: errors.New("nope")},
+		},
+	}
+	eval, err := compileEvaluator(policy.Rule, policy.Identities, &mockDeserializer{}, pm, true)
+	assert.NoError(t, err)
+
+	trace := &policies.EvaluationTrace{}
+	assert.False(t, eval(signedData, used, trace))
+	gate := trace.SubPolicies[0]
+	assert.Len(t, gate.SubPolicies, 2)
+	assert.Equal(t, "PolicyRef /Channel/Application/Admins", gate.SubPolicies[0].Policy)
+	assert.Equal(t, "nope", gate.SubPolicies[0].SubPolicies[0].Reason)
+	assert.Equal(t, "PolicyRef /Channel/Missing", gate.SubPolicies[1].Policy)
+	assert.Equal(t, "the referenced policy does not exist", gate.SubPolicies[1].Reason)
+}
+
+func TestPolicyRefConsumesSignatures(t *testing.T) {
+	// the admins of Org1 are signer0 and signer1
+	admins, _, err := NewPolicyProvider(&mockDeserializer{}).NewPolicy(marshalOrPanic(Envelope(Or(SignedBy(0), SignedBy(1)), signers)))
+	assert.NoError(t, err)
+	pm := &mockpolicies.Manager{
+		PolicyMap: map[string]policies.Policy{
+			"/Channel/Application/Org1/Admins": admins,
+		},
+	}
+
+	// an admin of Org1, and signer0
+	policy := Envelope(NOutOf(2, []*cb.SignaturePolicy{SignedByPolicyRef("/Channel/Application/Org1/Admins"), SignedBy(0)}), [][]byte{[]byte("signer0")})
+	spe, err := compile(policy.Rule, policy.Identities, &mockDeserializer{}, pm, true)
+	assert.NoError(t, err)
+
+	signedData, used := toSignedData([][]byte{nil}, [][]byte{[]byte("signer0")}, [][]byte{validSignature})
+	assert.False(t, spe(signedData, used), "A single signer should not have satisfied both the policy reference and the principal")
+
+	// the referenced policy uses the signatures of both admins, as its inline equivalent does
+	inline := Envelope(NOutOf(2, []*cb.SignaturePolicy{Or(SignedBy(0), SignedBy(1)), SignedBy(0)}), signers)
+	inlineSpe, err := compile(inline.Rule, inline.Identities, &mockDeserializer{}, nil, true)
+	assert.NoError(t, err)
+	signedData, used = toSignedData(msgs, signers, [][]byte{validSignature, validSignature})
+	assert.False(t, inlineSpe(signedData, used), "The inline policy should have used both signatures")
+	assert.False(t, spe(signedData, make([]bool, 2)), "The policy reference should have used both signatures")
+
+	// the other way around, the principal takes the signature first
+	policy = Envelope(NOutOf(2, []*cb.SignaturePolicy{SignedBy(0), SignedByPolicyRef("/Channel/Application/Org1/Admins")}), [][]byte{[]byte("signer0")})
+	spe, err = compile(policy.Rule, policy.Identities, &mockDeserializer{}, pm, true)
+	assert.NoError(t, err)
+
+	signedData, used = toSignedData([][]byte{nil}, [][]byte{[]byte("signer0")}, [][]byte{validSignature})
+	assert.False(t, spe(signedData, used), "A single signer should not have satisfied both the principal and the policy reference")
+
+	signedData, used = toSignedData(msgs, signers, [][]byte{validSignature, validSignature})
+	assert.True(t, spe(signedData, used), "Two distinct signers should have satisfied the policy")
+	assert.Equal(t, []bool{true, true}, used)
+}
+
+func TestExtendedPrincipalsWithoutExtensions(t *testing.T) {
+	for _, classification := range []mb.MSPPrincipal_Classification{mb.MSPPrincipal_IDENTITY_HASH, mb.MSPPrincipal_ORGANIZATION_UNIT_NAME} {
+		// the mock identity satisfies the principal whose bytes are its own
+		policy := &cb.SignaturePolicyEnvelope{
+			Rule:       SignedBy(0),
+			Identities: []*mb.MSPPrincipal{{PrincipalClassification: classification, Principal: []byte("signer0")}},
+		}
+		signedData, used := toSignedData([][]byte{nil}, [][]byte{[]byte("signer0")}, [][]byte{validSignature})
+
+		spe, err := compile(policy.Rule, policy.Identities, &mockDeserializer{}, nil, true)
+		assert.NoError(t, err)
+		assert.True(t, spe(signedData, used), "%s principal should have been satisfied with the signature policy extensions", classification)
+
+		spe, err = compile(policy.Rule, policy.Identities, &mockDeserializer{}, nil, false)
+		assert.NoError(t, err)
+		assert.False(t, spe(signedData, make([]bool, 1)), "%s principal should not have been satisfied without the signature policy extensions", classification)
+	}
+}
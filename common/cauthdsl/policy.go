@@ -18,7 +18,9 @@ import (
 )
 
 type provider struct {
-	deserializer msp.IdentityDeserializer
+	deserializer  msp.IdentityDeserializer
+	policyManager policies.Manager
+	extensions    bool
 }
 
 // NewProviderImpl provides a policy generator for cauthdsl type policies
@@ -28,6 +30,18 @@ func NewPolicyProvider(deserializer msp.IdentityDeserializer) policies.Provider
 	}
 }
 
+// NewPolicyProviderWithPolicyManager provides a policy generator for cauthdsl type policies
+// which support the signature policy extensions, and whose policy references are resolved
+// through the supplied policy manager of the channel. The policy manager may be nil, in which
+// case policy references are never satisfied
+func NewPolicyProviderWithPolicyManager(deserializer msp.IdentityDeserializer, policyManager policies.Manager) policies.Provider {
+	return &provider{
+		deserializer:  deserializer,
+		policyManager: policyManager,
+		extensions:    true,
+	}
+}
+
 // NewPolicy creates a new policy based on the policy bytes
 func (pr *provider) NewPolicy(data []byte) (policies.Policy, proto.Message, error) {
 	sigPolicy := &cb.SignaturePolicyEnvelope{}
@@ -39,7 +53,7 @@ func (pr *provider) NewPolicy(data []byte) (policies.Policy, proto.Message, erro
 		return nil, nil, fmt.Errorf("This evaluator only understands messages of version 0, but version was %d", sigPolicy.Version)
 	}
 
-	compiled, err := compile(sigPolicy.Rule, sigPolicy.Identities, pr.deserializer)
+	compiled, err := compileEvaluator(sigPolicy.Rule, sigPolicy.Identities, pr.deserializer, pr.policyManager, pr.extensions)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -52,7 +66,7 @@ func (pr *provider) NewPolicy(data []byte) (policies.Policy, proto.Message, erro
 }
 
 type policy struct {
-	evaluator    func([]*cb.SignedData, []bool) bool
+	evaluator    evaluator
 	deserializer msp.IdentityDeserializer
 }
 
@@ -62,9 +76,48 @@ func (p *policy) Evaluate(signatureSet []*cb.SignedData) error {
 		return fmt.Errorf("No such policy")
 	}
 
-	ok := p.evaluator(deduplicate(signatureSet, p.deserializer), make([]bool, len(signatureSet)))
+	ok := p.evaluator(deduplicate(signatureSet, p.deserializer), make([]bool, len(signatureSet)), nil)
 	if !ok {
 		return errors.New("signature set did not satisfy policy")
 	}
 	return nil
 }
+
+// EvaluateUsed evaluates the policy like Evaluate does, and returns which signatures of the set were used to satisfy it
+func (p *policy) EvaluateUsed(signatureSet []*cb.SignedData) ([]bool, error) {
+	if p == nil {
+		return nil, fmt.Errorf("No such policy")
+	}
+
+	deduplicated := deduplicate(signatureSet, p.deserializer)
+	deduplicatedUsed := make([]bool, len(deduplicated))
+	if !p.evaluator(deduplicated, deduplicatedUsed, nil) {
+		return nil, errors.New("signature set did not satisfy policy")
+	}
+	// the deduplicated signatures keep their order in the set, so they are matched in turn
+	used := make([]bool, len(signatureSet))
+	j := 0
+	for i, sd := range signatureSet {
+		if j < len(deduplicated) && sd == deduplicated[j] {
+			used[i] = deduplicatedUsed[j]
+			j++
+		}
+	}
+	return used, nil
+}
+
+// Explain evaluates the policy like Evaluate does, and returns the trace of the evaluation
+func (p *policy) Explain(signatureSet []*cb.SignedData) (*policies.EvaluationTrace, error) {
+	if p == nil {
+		return &policies.EvaluationTrace{Policy: "SignaturePolicy", Reason: "No such policy"}, fmt.Errorf("No such policy")
+	}
+
+	trace := &policies.EvaluationTrace{Policy: "SignaturePolicy"}
+	ok := p.evaluator(deduplicateTraced(signatureSet, p.deserializer, trace), make([]bool, len(signatureSet)), trace)
+	trace.Satisfied = ok
+	if !ok {
+		trace.Reason = "signature set did not satisfy policy"
+		return trace, errors.New("signature set did not satisfy policy")
+	}
+	return trace, nil
+}
@@ -103,3 +103,61 @@ func TestRejectOnUnknown(t *testing.T) {
 	err = policy.Evaluate([]*cb.SignedData{})
 	assert.Error(t, err, "Should have errored evaluating the default policy")
 }
+
+func TestEvaluateUsed(t *testing.T) {
+	policy, _, err := NewPolicyProvider(&mockDeserializer{}).NewPolicy(marshalOrPanic(Envelope(Or(SignedBy(0), SignedBy(1)), signers)))
+	assert.NoError(t, err)
+	evaluator, ok := policy.(policies.UsedSignaturesEvaluator)
+	assert.True(t, ok, "Signature policies should report the signatures they use")
+
+	// the duplicate of the signature of signer1 is not used
+	signedData, _ := toSignedData(moreMsgs, [][]byte{signers[1], signers[1], signers[0]}, [][]byte{validSignature, validSignature, validSignature})
+	used, err := evaluator.EvaluateUsed(signedData)
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true}, used)
+
+	signedData, _ = toSignedData(moreMsgs, [][]byte{signers[1], signers[1], []byte("signer2")}, [][]byte{validSignature, validSignature, validSignature})
+	used, err = evaluator.EvaluateUsed(signedData)
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, false}, used)
+
+	signedData, _ = toSignedData(moreMsgs[:1], [][]byte{signers[1]}, [][]byte{invalidSignature})
+	_, err = evaluator.EvaluateUsed(signedData)
+	assert.EqualError(t, err, "signature set did not satisfy policy")
+}
+
+func TestExplain(t *testing.T) {
+	policy, _, err := NewPolicyProvider(&mockDeserializer{}).NewPolicy(marshalOrPanic(Envelope(And(SignedBy(0), SignedBy(1)), signers)))
+	assert.NoError(t, err)
+	explainer, ok := policy.(policies.Explainer)
+	assert.True(t, ok, "Signature policies should explain their evaluation")
+
+	signedData, _ := toSignedData(moreMsgs, [][]byte{signers[0], signers[0], signers[1]}, [][]byte{validSignature, validSignature, invalidSignature})
+	trace, err := explainer.Explain(signedData)
+	assert.EqualError(t, err, "signature set did not satisfy policy")
+	assert.Equal(t, policy.Evaluate(signedData).Error(), err.Error())
+
+	assert.Equal(t, "SignaturePolicy", trace.Policy)
+	assert.False(t, trace.Satisfied)
+	assert.Equal(t, []string{"signature 1: duplicate of an earlier signature by Mock identity signer0"}, trace.Discarded)
+
+	assert.Len(t, trace.SubPolicies, 1)
+	gate := trace.SubPolicies[0]
+	assert.Equal(t, "OutOf(2)", gate.Policy)
+	assert.Equal(t, "1 of 2 sub-policies satisfied, 2 required", gate.Reason)
+
+	assert.Len(t, gate.SubPolicies, 2)
+	assert.True(t, gate.SubPolicies[0].Satisfied)
+	assert.Equal(t, []string{"signature 0 by Mock identity signer0 satisfies the principal"}, gate.SubPolicies[0].Signatures)
+	assert.False(t, gate.SubPolicies[1].Satisfied)
+	assert.Equal(t, []string{
+		"signature 0 already used by another principal",
+		"signature 1 by Mock identity signer1 satisfies the principal but is invalid: Invalid signature",
+	}, gate.SubPolicies[1].Signatures)
+
+	signedData, _ = toSignedData(msgs, signers, [][]byte{validSignature, validSignature})
+	trace, err = explainer.Explain(signedData)
+	assert.NoError(t, err)
+	assert.True(t, trace.Satisfied)
+	assert.True(t, trace.SubPolicies[0].Satisfied)
+}
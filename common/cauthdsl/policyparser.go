@@ -17,7 +17,10 @@ limitations under the License.
 package cauthdsl
 
 import (
+	"encoding/hex"
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -30,6 +33,81 @@ import (
 
 var regex *regexp.Regexp = regexp.MustCompile("^([[:alnum:].-]+)([.])(member|admin|client|peer|orderer)$")
 var regexErr *regexp.Regexp = regexp.MustCompile("^No parameter '([^']+)' found[.]$")
+var regexOU *regexp.Regexp = regexp.MustCompile("^([[:alnum:].-]+)[.]OU=([^']+)$")
+var regexCert *regexp.Regexp = regexp.MustCompile("^([[:alnum:].-]+)[.]cert=([^']+)$")
+var regexIDHash *regexp.Regexp = regexp.MustCompile("^([[:alnum:].-]+)[.]id=([[:xdigit:]]+)$")
+var regexPolicyRef *regexp.Regexp = regexp.MustCompile("^(/[[:alnum:]_.-]+)+$")
+
+// isPrincipal returns whether the string is a principal or a policy reference,
+// as opposed to the intermediate result of a previous pass of the parser
+func isPrincipal(s string) bool {
+	return regex.MatchString(s) || regexOU.MatchString(s) || regexCert.MatchString(s) ||
+		regexIDHash.MatchString(s) || regexPolicyRef.MatchString(s)
+}
+
+// principalFromString builds the principal described by the string, which
+// is either <MSP_ID>.<ROLE>, <MSP_ID>.OU=<OU>, <MSP_ID>.cert=<PATH> or <MSP_ID>.id=<HASH>
+func principalFromString(s string) (*msp.MSPPrincipal, error) {
+	/* <MSP_ID> . <ROLE>, where ROLE is either a member, an admin, a client, a peer or an orderer */
+	if subm := regex.FindAllStringSubmatch(s, -1); subm != nil {
+		if len(subm) != 1 || len(subm[0]) != 4 {
+			return nil, fmt.Errorf("Error parsing principal %s", s)
+		}
+
+		/* get the right role */
+		var r msp.MSPRole_MSPRoleType
+		switch subm[0][3] {
+		case "member":
+			r = msp.MSPRole_MEMBER
+		case "admin":
+			r = msp.MSPRole_ADMIN
+		case "client":
+			r = msp.MSPRole_CLIENT
+		case "peer":
+			r = msp.MSPRole_PEER
+		default:
+			return nil, fmt.Errorf("Error parsing role %s", s)
+		}
+
+		return &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_ROLE,
+			Principal:               utils.MarshalOrPanic(&msp.MSPRole{MspIdentifier: subm[0][1], Role: r})}, nil
+	}
+
+	/* <MSP_ID> . OU= <OU>, where OU is the name of an organizational unit of the MSP */
+	if subm := regexOU.FindStringSubmatch(s); subm != nil {
+		return &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_ORGANIZATION_UNIT_NAME,
+			Principal:               utils.MarshalOrPanic(&msp.OrganizationUnit{MspIdentifier: subm[1], OrganizationalUnitIdentifier: subm[2]})}, nil
+	}
+
+	/* <MSP_ID> . cert= <PATH>, where PATH is a file holding the PEM encoded certificate of the identity */
+	if subm := regexCert.FindStringSubmatch(s); subm != nil {
+		certPEM, err := ioutil.ReadFile(subm[2])
+		if err != nil {
+			return nil, fmt.Errorf("Error reading certificate of principal %s: %s", s, err)
+		}
+		if block, _ := pem.Decode(certPEM); block == nil {
+			return nil, fmt.Errorf("Error parsing principal %s: no PEM encoded certificate found", s)
+		}
+		return &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_IDENTITY,
+			Principal:               utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: subm[1], IdBytes: certPEM})}, nil
+	}
+
+	/* <MSP_ID> . id= <HASH>, where HASH is the hex encoded hash of the certificate of the identity */
+	if subm := regexIDHash.FindStringSubmatch(s); subm != nil {
+		hash, err := hex.DecodeString(subm[2])
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing identity hash of principal %s: %s", s, err)
+		}
+		return &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_IDENTITY_HASH,
+			Principal:               utils.MarshalOrPanic(&msp.IdentityHash{MspIdentifier: subm[1], Hash: hash})}, nil
+	}
+
+	return nil, fmt.Errorf("Error parsing principal %s", s)
+}
 
 // a stub function - it returns the same string as it's passed.
 // This will be evaluated by second/third passes to convert to a proto policy
@@ -55,7 +133,7 @@ func outof(args ...interface{}) (interface{}, error) {
 		toret += ", "
 		switch t := arg.(type) {
 		case string:
-			if regex.MatchString(t) {
+			if isPrincipal(t) {
 				toret += "'" + t + "'"
 			} else {
 				toret += t
@@ -83,7 +161,7 @@ func firstPass(args ...interface{}) (interface{}, error) {
 		toret += ", "
 		switch t := arg.(type) {
 		case string:
-			if regex.MatchString(t) {
+			if isPrincipal(t) {
 				toret += "'" + t + "'"
 			} else {
 				toret += t
@@ -137,35 +215,19 @@ func secondPass(args ...interface{}) (interface{}, error) {
 	/* handle the rest of the arguments */
 	for _, principal := range args[2:] {
 		switch t := principal.(type) {
-		/* if it's a string, we expect it to be formed as
-		   <MSP_ID> . <ROLE>, where MSP_ID is the MSP identifier
-		   and ROLE is either a member, an admin, a client, a peer or an orderer*/
+		/* if it's a string, we expect it to be either a reference to
+		   a policy of the channel, or a principal as described in FromString */
 		case string:
-			/* split the string */
-			subm := regex.FindAllStringSubmatch(t, -1)
-			if subm == nil || len(subm) != 1 || len(subm[0]) != 4 {
-				return nil, fmt.Errorf("Error parsing principal %s", t)
-			}
-
-			/* get the right role */
-			var r msp.MSPRole_MSPRoleType
-			switch subm[0][3] {
-			case "member":
-				r = msp.MSPRole_MEMBER
-			case "admin":
-				r = msp.MSPRole_ADMIN
-			case "client":
-				r = msp.MSPRole_CLIENT
-			case "peer":
-				r = msp.MSPRole_PEER
-			default:
-				return nil, fmt.Errorf("Error parsing role %s", t)
+			if regexPolicyRef.MatchString(t) {
+				policies = append(policies, SignedByPolicyRef(t))
+				continue
 			}
 
 			/* build the principal we've been told */
-			p := &msp.MSPPrincipal{
-				PrincipalClassification: msp.MSPPrincipal_ROLE,
-				Principal:               utils.MarshalOrPanic(&msp.MSPRole{MspIdentifier: subm[0][1], Role: r})}
+			p, err := principalFromString(t)
+			if err != nil {
+				return nil, err
+			}
 			ctx.principals = append(ctx.principals, p)
 
 			/* create a SignaturePolicy that requires a signature from
@@ -211,13 +273,22 @@ func newContext() *context {
 //	- GATE is either "and" or "or"
 //	- P is either a principal or another nested call to GATE
 //
-// a principal is defined as
+// a principal is defined as either of
 //
 // ORG.ROLE
+// ORG.OU=UNIT
+// ORG.cert=PATH
+// ORG.id=HASH
+// /PATH/TO/POLICY
 //
 // where
 //	- ORG is a string (representing the MSP identifier)
 //	- ROLE is either the string "member", "admin", "client", "peer", or the string "orderer" representing the required role
+//	- UNIT is the name of an organizational unit of the MSP, satisfied by its members regardless of the certifiers of the unit
+//	- PATH is the path of a file holding the PEM encoded certificate of a specific identity
+//	- HASH is the hex encoded hash of the certificate of a specific identity, as used by the MSP as its identifier
+//	- /PATH/TO/POLICY is the fully qualified name of a policy of the channel (e.g. /Channel/Application/Org1/Admins),
+//	  which is only satisfied when evaluated by a policy provider with access to the policy manager of the channel
 func FromString(policy string) (*common.SignaturePolicyEnvelope, error) {
 	// first we translate the and/or business into outof gates
 	intermediate, err := govaluate.NewEvaluableExpressionWithFunctions(policy, map[string]govaluate.ExpressionFunction{"AND": and, "and": and, "OR": or, "or": or, "OUTOF": outof, "outof": outof, "OutOf": outof})
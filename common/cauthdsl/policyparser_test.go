@@ -17,6 +17,10 @@ limitations under the License.
 package cauthdsl
 
 import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 
@@ -233,3 +237,69 @@ func TestBadStringsNoPanic(t *testing.T) {
 	_, err = FromString("OR('A.member', Bmember)")
 	assert.Error(t, err)
 }
+
+func TestOUAndIdentityPrincipals(t *testing.T) {
+	certPEM := []byte("-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n")
+	dir, err := ioutil.TempDir("", "policyparser")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certFile := filepath.Join(dir, "cert.pem")
+	assert.NoError(t, ioutil.WriteFile(certFile, certPEM, 0600))
+
+	hash := "0a1b2c3d"
+	p1, err := FromString("AND('A.OU=finance', OR('B.cert=" + certFile + "', 'C.id=" + hash + "'))")
+	assert.NoError(t, err)
+
+	hashBytes, _ := hex.DecodeString(hash)
+	principals := []*msp.MSPPrincipal{
+		{
+			PrincipalClassification: msp.MSPPrincipal_IDENTITY,
+			Principal:               utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "B", IdBytes: certPEM}),
+		},
+		{
+			PrincipalClassification: msp.MSPPrincipal_IDENTITY_HASH,
+			Principal:               utils.MarshalOrPanic(&msp.IdentityHash{MspIdentifier: "C", Hash: hashBytes}),
+		},
+		{
+			PrincipalClassification: msp.MSPPrincipal_ORGANIZATION_UNIT_NAME,
+			Principal:               utils.MarshalOrPanic(&msp.OrganizationUnit{MspIdentifier: "A", OrganizationalUnitIdentifier: "finance"}),
+		},
+	}
+
+	p2 := &common.SignaturePolicyEnvelope{
+		Version:    0,
+		Rule:       And(SignedBy(2), Or(SignedBy(0), SignedBy(1))),
+		Identities: principals,
+	}
+
+	assert.Equal(t, p1, p2)
+
+	_, err = FromString("OR('A.cert=" + filepath.Join(dir, "missing.pem") + "')")
+	assert.Error(t, err)
+
+	assert.NoError(t, ioutil.WriteFile(certFile, []byte("not a certificate"), 0600))
+	_, err = FromString("OR('A.cert=" + certFile + "')")
+	assert.Error(t, err)
+
+	_, err = FromString("OR('A.id=abc')")
+	assert.Error(t, err)
+}
+
+func TestPolicyRefs(t *testing.T) {
+	p1, err := FromString("OR('/Channel/Application/Org1/Admins', AND('A.member', '/Channel/Application/Writers'))")
+	assert.NoError(t, err)
+
+	p2 := &common.SignaturePolicyEnvelope{
+		Version: 0,
+		Rule: Or(
+			SignedByPolicyRef("/Channel/Application/Org1/Admins"),
+			And(SignedBy(0), SignedByPolicyRef("/Channel/Application/Writers")),
+		),
+		Identities: []*msp.MSPPrincipal{{
+			PrincipalClassification: msp.MSPPrincipal_ROLE,
+			Principal:               utils.MarshalOrPanic(&msp.MSPRole{Role: msp.MSPRole_MEMBER, MspIdentifier: "A"}),
+		}},
+	}
+
+	assert.Equal(t, p1, p2)
+}
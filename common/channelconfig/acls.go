@@ -0,0 +1,51 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channelconfig
+
+import (
+	"strings"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+const (
+	// aclPolicyPrefix is the prefix of the policy references of the ACLs
+	// which are not already fully qualified
+	aclPolicyPrefix = "/" + RootGroupKey + "/" + ApplicationGroupKey + "/"
+)
+
+// aclsProvider provides mappings for resource to policy names
+type aclsProvider struct {
+	aclPolicyRefs map[string]string
+}
+
+func (ag *aclsProvider) PolicyRefForAPI(aclName string) string {
+	return ag.aclPolicyRefs[aclName]
+}
+
+// newACLsProvider fully qualifies the policy references of the given ACLs,
+// references which are relative are taken to be relative to the Application group
+func newACLsProvider(acls map[string]*pb.APIResource) *aclsProvider {
+	aclPolicyRefs := make(map[string]string)
+
+	for key, acl := range acls {
+		if len(acl.GetPolicyRef()) == 0 {
+			logger.Warningf("Policy reference for ACL %s is empty, ignoring", key)
+			continue
+		}
+
+		if strings.HasPrefix(acl.PolicyRef, "/") {
+			aclPolicyRefs[key] = acl.PolicyRef
+		} else {
+			aclPolicyRefs[key] = aclPolicyPrefix + acl.PolicyRef
+		}
+	}
+
+	return &aclsProvider{
+		aclPolicyRefs: aclPolicyRefs,
+	}
+}
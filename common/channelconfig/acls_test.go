@@ -0,0 +1,27 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channelconfig
+
+import (
+	"testing"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestACLsProvider(t *testing.T) {
+	ap := newACLsProvider(map[string]*pb.APIResource{
+		"Relative": {PolicyRef: "Writers"},
+		"Absolute": {PolicyRef: "/Channel/Application/Org1/Admins"},
+		"Empty":    {},
+	})
+
+	assert.Equal(t, "/Channel/Application/Writers", ap.PolicyRefForAPI("Relative"))
+	assert.Equal(t, "/Channel/Application/Org1/Admins", ap.PolicyRefForAPI("Absolute"))
+	assert.Empty(t, ap.PolicyRefForAPI("Empty"))
+	assert.Empty(t, ap.PolicyRefForAPI("Unknown"))
+}
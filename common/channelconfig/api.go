@@ -41,6 +41,17 @@ type Application interface {
 
 	// Capabilities defines the capabilities for the application portion of a channel
 	Capabilities() ApplicationCapabilities
+
+	// APIPolicyMapper returns a way to map the names of the resources of the peer
+	// to the policies governing their access, as defined by the ACLs of the channel
+	APIPolicyMapper() PolicyMapper
+}
+
+// PolicyMapper is an interface for mapping the names of APIs to policies
+type PolicyMapper interface {
+	// PolicyRefForAPI takes the name of an API, and returns the fully
+	// qualified policy name, or the empty string if the API is not found
+	PolicyRefForAPI(apiName string) string
 }
 
 // Channel gives read only access to the channel configuration
@@ -129,6 +140,19 @@ type ApplicationCapabilities interface {
 	// V1_1Validation returns true is this channel is configured to perform stricter validation
 	// of transactions (as introduced in v1.1).
 	V1_1Validation() bool
+
+	// ACLs returns true if the ACLs of the resources of the peer may be specified in the
+	// application portion of the channel configuration (as introduced in v1.2).
+	ACLs() bool
+
+	// ImplicitCollections returns true if the names of the implicit collections of the orgs
+	// are reserved, and may not be used by the collections of chaincodes (as introduced in v1.2).
+	ImplicitCollections() bool
+
+	// SignaturePolicyExtensions returns true if signature policies may reference the policies of
+	// the channel, and be satisfied by identity hash and organization unit name principals
+	// (as introduced in v1.2).
+	SignaturePolicyExtensions() bool
 }
 
 // OrdererCapabilities defines the capabilities for the orderer portion of a channel
@@ -9,6 +9,7 @@ package channelconfig
 import (
 	"github.com/hyperledger/fabric/common/capabilities"
 	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
 
 	"github.com/pkg/errors"
 )
@@ -16,10 +17,14 @@ import (
 const (
 	// ApplicationGroupKey is the group name for the Application config
 	ApplicationGroupKey = "Application"
+
+	// ACLsKey is the name of the ACLs config
+	ACLsKey = "ACLs"
 )
 
 // ApplicationProtos is used as the source of the ApplicationConfig
 type ApplicationProtos struct {
+	ACLs         *pb.ACLs
 	Capabilities *cb.Capabilities
 }
 
@@ -27,6 +32,7 @@ type ApplicationProtos struct {
 type ApplicationConfig struct {
 	applicationOrgs map[string]ApplicationOrg
 	protos          *ApplicationProtos
+	aclsProvider    *aclsProvider
 }
 
 // NewApplicationConfig creates config from an Application config group
@@ -40,6 +46,14 @@ func NewApplicationConfig(appGroup *cb.ConfigGroup, mspConfig *MSPConfigHandler)
 		return nil, errors.Wrap(err, "failed to deserialize values")
 	}
 
+	if !ac.Capabilities().ACLs() {
+		if _, ok := appGroup.Values[ACLsKey]; ok {
+			return nil, errors.New("ACLs may not be specified without the required capability")
+		}
+	}
+
+	ac.aclsProvider = newACLsProvider(ac.protos.ACLs.Acls)
+
 	var err error
 	for orgName, orgGroup := range appGroup.Groups {
 		ac.applicationOrgs[orgName], err = NewApplicationOrgConfig(orgName, orgGroup, mspConfig)
@@ -60,3 +74,8 @@ func (ac *ApplicationConfig) Organizations() map[string]ApplicationOrg {
 func (ac *ApplicationConfig) Capabilities() ApplicationCapabilities {
 	return capabilities.NewApplicationProvider(ac.protos.Capabilities.Capabilities)
 }
+
+// APIPolicyMapper returns a PolicyMapper that maps API names to policies
+func (ac *ApplicationConfig) APIPolicyMapper() PolicyMapper {
+	return ac.aclsProvider
+}
@@ -9,7 +9,12 @@ package channelconfig
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/common/capabilities"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+
 	logging "github.com/op/go-logging"
+	"github.com/stretchr/testify/assert"
 )
 
 func init() {
@@ -19,3 +24,23 @@ func init() {
 func TestApplicationInterface(t *testing.T) {
 	_ = Application((*ApplicationConfig)(nil))
 }
+
+func TestApplicationACLs(t *testing.T) {
+	acls := ACLsValue(map[string]string{"peer/Propose": "Writers"})
+	appGroup := &cb.ConfigGroup{
+		Values: map[string]*cb.ConfigValue{
+			acls.Key(): {Value: utils.MarshalOrPanic(acls.Value())},
+		},
+	}
+
+	_, err := NewApplicationConfig(appGroup, nil)
+	assert.EqualError(t, err, "ACLs may not be specified without the required capability")
+
+	caps := CapabilitiesValue(map[string]bool{capabilities.ApplicationV1_2: true})
+	appGroup.Values[caps.Key()] = &cb.ConfigValue{Value: utils.MarshalOrPanic(caps.Value())}
+
+	ac, err := NewApplicationConfig(appGroup, nil)
+	assert.NoError(t, err)
+	assert.True(t, ac.Capabilities().ACLs())
+	assert.Equal(t, "/Channel/Application/Writers", ac.APIPolicyMapper().PolicyRefForAPI("peer/Propose"))
+}
@@ -191,7 +191,7 @@ func NewBundle(channelID string, config *cb.Config) (*Bundle, error) {
 		case cb.Policy_UNKNOWN:
 			// Do not register a handler
 		case cb.Policy_SIGNATURE:
-			policyProviderMap[pType] = cauthdsl.NewPolicyProvider(channelConfig.MSPManager())
+			policyProviderMap[pType] = signaturePolicyProvider(channelConfig)
 		case cb.Policy_MSP:
 			// Add hook for MSP Handler here
 		}
@@ -214,6 +214,16 @@ func NewBundle(channelID string, config *cb.Config) (*Bundle, error) {
 	}, nil
 }
 
+// signaturePolicyProvider returns the provider of the signature policies of the channel, which
+// support the signature policy extensions only when the application capabilities enable them.
+// The policies of the channel are being built, so their policy references are never satisfied
+func signaturePolicyProvider(channelConfig *ChannelConfig) policies.Provider {
+	if ac := channelConfig.ApplicationConfig(); ac != nil && ac.Capabilities().SignaturePolicyExtensions() {
+		return cauthdsl.NewPolicyProviderWithPolicyManager(channelConfig.MSPManager(), nil)
+	}
+	return cauthdsl.NewPolicyProvider(channelConfig.MSPManager())
+}
+
 func preValidate(config *cb.Config) error {
 	if config == nil {
 		return errors.New("channelconfig Config cannot be nil")
@@ -9,8 +9,11 @@ package channelconfig
 import (
 	"testing"
 
+	"github.com/hyperledger/fabric/common/capabilities"
+	"github.com/hyperledger/fabric/common/cauthdsl"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -275,3 +278,20 @@ func TestPrevalidation(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+func TestSignaturePolicyProvider(t *testing.T) {
+	policyRef := utils.MarshalOrPanic(cauthdsl.Envelope(cauthdsl.SignedByPolicyRef("/Channel/Application/Admins"), nil))
+
+	_, _, err := signaturePolicyProvider(&ChannelConfig{}).NewPolicy(policyRef)
+	assert.Error(t, err, "policy references require an application config")
+
+	cc := &ChannelConfig{
+		appConfig: &ApplicationConfig{protos: &ApplicationProtos{Capabilities: &cb.Capabilities{}}},
+	}
+	_, _, err = signaturePolicyProvider(cc).NewPolicy(policyRef)
+	assert.Error(t, err, "policy references require the V1_2 application capability")
+
+	cc.appConfig.protos.Capabilities.Capabilities = map[string]*cb.Capability{capabilities.ApplicationV1_2: {}}
+	_, _, err = signaturePolicyProvider(cc).NewPolicy(policyRef)
+	assert.NoError(t, err)
+}
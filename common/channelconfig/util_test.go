@@ -41,5 +41,6 @@ func TestUtilsBasic(t *testing.T) {
 	basicTest(t, MSPValue(&mspprotos.MSPConfig{}))
 	basicTest(t, CapabilitiesValue(map[string]bool{"foo": true, "bar": false}))
 	basicTest(t, AnchorPeersValue([]*pb.AnchorPeer{{}, {}}))
+	basicTest(t, ACLsValue(map[string]string{"foo": "fooval", "bar": "barval"}))
 	basicTest(t, ChannelCreationPolicyValue(&cb.Policy{}))
 }
@@ -0,0 +1,166 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package configtx
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/policies"
+	cb "github.com/hyperledger/fabric/protos/common"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+const (
+	// GroupElement is the UpdateElement type for config groups
+	GroupElement = "Group"
+
+	// ValueElement is the UpdateElement type for config values
+	ValueElement = "Value"
+
+	// PolicyElement is the UpdateElement type for config policies
+	PolicyElement = "Policy"
+)
+
+// UpdateElement describes a single element of the config which is modified by
+// a config update, along with whether the signatures on the update satisfy
+// the mod_policy governing that modification
+type UpdateElement struct {
+	// Type is one of GroupElement, ValueElement, or PolicyElement
+	Type string
+
+	// Path is the path of the element, starting at the root group and ending with its key
+	Path []string
+
+	// Original is the element as it exists in the current config, or nil if the element is new
+	Original proto.Message
+
+	// Updated is the element as it appears in the write set of the update
+	Updated proto.Message
+
+	// ModPolicy is the mod_policy of the existing element, which must be satisfied to modify it.
+	// It is empty for new elements, which are authorized through the modification of their parent group.
+	ModPolicy string
+
+	// ModPolicyPath is the fully qualified path of ModPolicy
+	ModPolicyPath string
+
+	// PolicyErr is nil if the signatures on the update satisfy ModPolicy
+	PolicyErr error
+}
+
+// DescribeUpdate computes the set of config elements which the supplied config
+// update would modify, and evaluates the signatures on the update against the
+// mod_policy of each of them.  Unlike ProposeConfigUpdate, it does not fail
+// when a mod_policy is not satisfied, instead recording the failure in the
+// corresponding UpdateElement.
+func (vi *ValidatorImpl) DescribeUpdate(configUpdateEnv *cb.ConfigUpdateEnvelope) ([]*UpdateElement, error) {
+	if configUpdateEnv == nil {
+		return nil, errors.Errorf("cannot process nil ConfigUpdateEnvelope")
+	}
+
+	configUpdate, err := UnmarshalConfigUpdate(configUpdateEnv.ConfigUpdate)
+	if err != nil {
+		return nil, err
+	}
+
+	if configUpdate.ChannelId != vi.channelID {
+		return nil, errors.Errorf("Update not for correct channel: %s for %s", configUpdate.ChannelId, vi.channelID)
+	}
+
+	readSet, err := mapConfig(configUpdate.ReadSet, vi.namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error mapping ReadSet")
+	}
+	err = vi.verifyReadSet(readSet)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error validating ReadSet")
+	}
+
+	writeSet, err := mapConfig(configUpdate.WriteSet, vi.namespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error mapping WriteSet")
+	}
+
+	signedData, err := configUpdateEnv.AsSignedData()
+	if err != nil {
+		return nil, err
+	}
+
+	deltaSet := computeDeltaSet(readSet, writeSet)
+	keys := make([]string, 0, len(deltaSet))
+	for key := range deltaSet {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]*UpdateElement, 0, len(keys))
+	for _, key := range keys {
+		value := deltaSet[key]
+		element := &UpdateElement{
+			Type:    elementType(value),
+			Path:    append(append([]string{}, value.path...), value.key),
+			Updated: elementProto(value),
+		}
+		result = append(result, element)
+
+		existing, ok := vi.configMap[key]
+		if !ok {
+			continue
+		}
+
+		element.Original = elementProto(existing)
+		element.ModPolicy = existing.modPolicy()
+		element.ModPolicyPath = policyPathForItem(existing)
+
+		policy, ok := vi.policyForItem(existing)
+		if !ok {
+			element.PolicyErr = errors.Errorf("unexpected missing policy %s for item %s", existing.modPolicy(), key)
+			continue
+		}
+		element.PolicyErr = policy.Evaluate(signedData)
+	}
+
+	return result, nil
+}
+
+// policyPathForItem returns the fully qualified path of the mod_policy of an item
+func policyPathForItem(item comparable) string {
+	modPolicy := item.modPolicy()
+	if strings.HasPrefix(modPolicy, policies.PathSeparator) {
+		return modPolicy
+	}
+
+	path := append([]string{}, item.path...)
+	if item.ConfigGroup != nil {
+		path = append(path, item.key)
+	}
+
+	return policies.PathSeparator + strings.Join(append(path, modPolicy), policies.PathSeparator)
+}
+
+func elementType(item comparable) string {
+	switch {
+	case item.ConfigGroup != nil:
+		return GroupElement
+	case item.ConfigValue != nil:
+		return ValueElement
+	}
+	return PolicyElement
+}
+
+func elementProto(item comparable) proto.Message {
+	switch {
+	case item.ConfigGroup != nil:
+		return item.ConfigGroup
+	case item.ConfigValue != nil:
+		return item.ConfigValue
+	}
+	return item.ConfigPolicy
+}
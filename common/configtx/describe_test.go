@@ -0,0 +1,134 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package configtx
+
+import (
+	"fmt"
+	"testing"
+
+	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
+	"github.com/hyperledger/fabric/common/policies"
+	cb "github.com/hyperledger/fabric/protos/common"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeUpdate(t *testing.T) {
+	pm := &mockpolicies.Manager{
+		Policy: &mockpolicies.Policy{},
+		PolicyMap: map[string]policies.Policy{
+			"bar": &mockpolicies.Policy{Err: fmt.Errorf("err")},
+		},
+	}
+
+	vi, err := NewValidatorImpl(
+		defaultChain,
+		makeConfig(
+			makeConfigPair("foo", "foo", 0, []byte("foo")),
+			makeConfigPair("bar", "bar", 0, []byte("bar")),
+		),
+		"foonamespace",
+		pm)
+	assert.NoError(t, err)
+
+	newConfig := makeConfigUpdateEnvelope(
+		defaultChain,
+		makeConfigSet(),
+		makeConfigSet(
+			makeConfigPair("foo", "foo", 1, []byte("foo1")),
+			makeConfigPair("bar", "bar", 1, []byte("bar1")),
+			makeConfigPair("baz", "foo", 0, []byte("baz")),
+		),
+	)
+	configUpdateEnv, err := envelopeToConfigUpdate(newConfig)
+	assert.NoError(t, err)
+
+	elements, err := vi.DescribeUpdate(configUpdateEnv)
+	assert.NoError(t, err)
+	assert.Len(t, elements, 3)
+
+	bar, baz, foo := elements[0], elements[1], elements[2]
+
+	assert.Equal(t, ValueElement, bar.Type)
+	assert.Equal(t, []string{"foonamespace", "bar"}, bar.Path)
+	assert.Equal(t, "bar", bar.ModPolicy)
+	assert.Equal(t, "/foonamespace/bar", bar.ModPolicyPath)
+	assert.Error(t, bar.PolicyErr)
+	assert.Equal(t, []byte("bar"), bar.Original.(*cb.ConfigValue).Value)
+	assert.Equal(t, []byte("bar1"), bar.Updated.(*cb.ConfigValue).Value)
+
+	assert.Nil(t, baz.Original)
+	assert.Empty(t, baz.ModPolicy)
+	assert.NoError(t, baz.PolicyErr)
+
+	assert.Equal(t, "foo", foo.ModPolicy)
+	assert.NoError(t, foo.PolicyErr)
+}
+
+func TestDescribeUpdateFailures(t *testing.T) {
+	vi, err := NewValidatorImpl(
+		defaultChain,
+		makeConfig(makeConfigPair("foo", "foo", 1, []byte("foo"))),
+		"foonamespace",
+		defaultPolicyManager())
+	assert.NoError(t, err)
+
+	t.Run("NilEnvelope", func(t *testing.T) {
+		_, err := vi.DescribeUpdate(nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("WrongChannel", func(t *testing.T) {
+		configUpdateEnv, err := envelopeToConfigUpdate(makeConfigUpdateEnvelope("wrongChain", makeConfigSet(), makeConfigSet()))
+		assert.NoError(t, err)
+		_, err = vi.DescribeUpdate(configUpdateEnv)
+		assert.EqualError(t, err, "Update not for correct channel: wrongChain for "+defaultChain)
+	})
+
+	t.Run("StaleReadSet", func(t *testing.T) {
+		configUpdateEnv, err := envelopeToConfigUpdate(makeConfigUpdateEnvelope(
+			defaultChain,
+			makeConfigSet(makeConfigPair("foo", "foo", 0, []byte("foo"))),
+			makeConfigSet(makeConfigPair("foo", "foo", 1, []byte("foo"))),
+		))
+		assert.NoError(t, err)
+		_, err = vi.DescribeUpdate(configUpdateEnv)
+		assert.Error(t, err)
+	})
+
+	t.Run("BadConfigUpdate", func(t *testing.T) {
+		_, err := vi.DescribeUpdate(&cb.ConfigUpdateEnvelope{ConfigUpdate: []byte("garbage")})
+		assert.Error(t, err)
+	})
+}
+
+func TestPolicyPathForItem(t *testing.T) {
+	assert.Equal(t, "/Channel/Admins", policyPathForItem(comparable{
+		key:         "Channel",
+		ConfigGroup: &cb.ConfigGroup{ModPolicy: "Admins"},
+	}))
+	assert.Equal(t, "/Channel/Application/Org1/Admins", policyPathForItem(comparable{
+		key:         "Org1",
+		path:        []string{"Channel", "Application"},
+		ConfigGroup: &cb.ConfigGroup{ModPolicy: "Admins"},
+	}))
+	assert.Equal(t, "/Channel/Orderer/Admins", policyPathForItem(comparable{
+		key:         "BatchSize",
+		path:        []string{"Channel", "Orderer"},
+		ConfigValue: &cb.ConfigValue{ModPolicy: "Admins"},
+	}))
+	assert.Equal(t, "/Channel/Orderer/Admins", policyPathForItem(comparable{
+		key:          "Writers",
+		path:         []string{"Channel", "Application"},
+		ConfigPolicy: &cb.ConfigPolicy{ModPolicy: "/Channel/Orderer/Admins"},
+	}))
+	assert.Equal(t, "/Channel/Application/Org1/Admins", policyPathForItem(comparable{
+		key:         "Application",
+		path:        []string{"Channel"},
+		ConfigGroup: &cb.ConfigGroup{ModPolicy: "Org1/Admins"},
+	}))
+}
@@ -96,6 +96,7 @@ func (vi *ValidatorImpl) verifyDeltaSet(deltaSet map[string]comparable, signedDa
 
 		// Ensure the policy is satisfied
 		if err := policy.Evaluate(signedData); err != nil {
+			policies.DebugExplain(logger, existing.modPolicy(), policy, signedData)
 			return errors.Wrapf(err, "policy for %s not satisfied", key)
 		}
 	}
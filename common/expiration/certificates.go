@@ -0,0 +1,172 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package expiration
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	mspprovider "github.com/hyperledger/fabric/msp"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/pkg/errors"
+)
+
+// FromPEM returns the certificates PEM encoded in pemBytes
+func FromPEM(source string, pemBytes []byte) ([]*Certificate, error) {
+	var certs []*Certificate
+	for rest := pemBytes; len(rest) > 0; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed parsing the %s", source)
+		}
+		certs = append(certs, &Certificate{Source: source, Cert: cert})
+	}
+	if len(certs) == 0 {
+		return nil, errors.Errorf("no PEM encoded certificate found for the %s", source)
+	}
+	return certs, nil
+}
+
+// FromSerializedIdentity returns the certificate of a serialized X.509 identity
+func FromSerializedIdentity(source string, identity []byte) ([]*Certificate, error) {
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(identity, sID); err != nil {
+		return nil, errors.Wrapf(err, "failed unmarshaling the %s", source)
+	}
+	return FromPEM(source, sID.IdBytes)
+}
+
+// FromTLSCertificate returns the leaf certificate of a TLS key pair
+func FromTLSCertificate(source string, cert tls.Certificate) ([]*Certificate, error) {
+	if cert.Leaf != nil {
+		return []*Certificate{{Source: source, Cert: cert.Leaf}}, nil
+	}
+	if len(cert.Certificate) == 0 {
+		return nil, errors.Errorf("the %s is empty", source)
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed parsing the %s", source)
+	}
+	return []*Certificate{{Source: source, Cert: leaf}}, nil
+}
+
+// FromChannelConfig returns the root, intermediate and admin certificates, along with
+// the TLS root and intermediate certificates, of every MSP defined in a channel config.
+// An MSP defined more than once in the config, as in the consortiums of the orderer
+// system channel, is returned once
+func FromChannelConfig(channelID string, config *cb.Config) ([]*Certificate, error) {
+	if config == nil || config.ChannelGroup == nil {
+		return nil, errors.Errorf("the config of channel %s is empty", channelID)
+	}
+	mspConfigs := map[string]*msp.FabricMSPConfig{}
+	if err := collectMSPConfigs(config.ChannelGroup, mspConfigs); err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("invalid config of channel %s", channelID))
+	}
+
+	var mspIDs []string
+	for mspID := range mspConfigs {
+		mspIDs = append(mspIDs, mspID)
+	}
+	sort.Strings(mspIDs)
+
+	var certs []*Certificate
+	for _, mspID := range mspIDs {
+		conf := mspConfigs[mspID]
+		for _, set := range []struct {
+			role  string
+			certs [][]byte
+		}{
+			{"root CA certificate", conf.RootCerts},
+			{"intermediate CA certificate", conf.IntermediateCerts},
+			{"admin certificate", conf.Admins},
+			{"TLS root CA certificate", conf.TlsRootCerts},
+			{"TLS intermediate CA certificate", conf.TlsIntermediateCerts},
+		} {
+			source := fmt.Sprintf("%s of MSP %s of channel %s", set.role, mspID, channelID)
+			for _, pemBytes := range set.certs {
+				c, err := FromPEM(source, pemBytes)
+				if err != nil {
+					return nil, err
+				}
+				certs = append(certs, c...)
+			}
+		}
+	}
+	return certs, nil
+}
+
+func collectMSPConfigs(group *cb.ConfigGroup, mspConfigs map[string]*msp.FabricMSPConfig) error {
+	if value, exists := group.Values[channelconfig.MSPKey]; exists {
+		mspConfig := &msp.MSPConfig{}
+		if err := proto.Unmarshal(value.Value, mspConfig); err != nil {
+			return errors.Wrap(err, "failed unmarshaling MSP config")
+		}
+		// Only X.509 based MSPs have certificates to monitor
+		if mspConfig.Type == int32(mspprovider.FABRIC) {
+			fabricConfig := &msp.FabricMSPConfig{}
+			if err := proto.Unmarshal(mspConfig.Config, fabricConfig); err != nil {
+				return errors.Wrap(err, "failed unmarshaling fabric MSP config")
+			}
+			mspConfigs[fabricConfig.Name] = mergeMSPConfigs(mspConfigs[fabricConfig.Name], fabricConfig)
+		}
+	}
+	for _, subGroup := range group.Groups {
+		if err := collectMSPConfigs(subGroup, mspConfigs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeMSPConfigs merges the certificates of two definitions of the same MSP, which
+// should be identical but are not required to be
+func mergeMSPConfigs(existing, conf *msp.FabricMSPConfig) *msp.FabricMSPConfig {
+	if existing == nil {
+		return conf
+	}
+	return &msp.FabricMSPConfig{
+		Name:                 conf.Name,
+		RootCerts:            mergeCerts(existing.RootCerts, conf.RootCerts),
+		IntermediateCerts:    mergeCerts(existing.IntermediateCerts, conf.IntermediateCerts),
+		Admins:               mergeCerts(existing.Admins, conf.Admins),
+		TlsRootCerts:         mergeCerts(existing.TlsRootCerts, conf.TlsRootCerts),
+		TlsIntermediateCerts: mergeCerts(existing.TlsIntermediateCerts, conf.TlsIntermediateCerts),
+	}
+}
+
+func mergeCerts(existing, certs [][]byte) [][]byte {
+	merged := append([][]byte(nil), existing...)
+	for _, cert := range certs {
+		found := false
+		for _, e := range merged {
+			if bytes.Equal(e, cert) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, cert)
+		}
+	}
+	return merged
+}
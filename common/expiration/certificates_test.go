@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package expiration
+
+import (
+	"crypto/tls"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromPEM(t *testing.T) {
+	cert1 := newCertificate(t, "cert1", time.Now().Add(day))
+	cert2 := newCertificate(t, "cert2", time.Now().Add(2*day))
+
+	certs, err := FromPEM("bundle", pemEncode(cert1, cert2))
+	assert.NoError(t, err)
+	assert.Equal(t, []*Certificate{{Source: "bundle", Cert: cert1}, {Source: "bundle", Cert: cert2}}, certs)
+
+	_, err = FromPEM("bundle", []byte("garbage"))
+	assert.EqualError(t, err, "no PEM encoded certificate found for the bundle")
+
+	_, err = FromPEM("bundle", []byte("-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed parsing the bundle")
+}
+
+func TestFromSerializedIdentity(t *testing.T) {
+	cert := newCertificate(t, "peer", time.Now().Add(day))
+	identity := utils.MarshalOrPanic(&msp.SerializedIdentity{Mspid: "SampleOrg", IdBytes: pemEncode(cert)})
+
+	certs, err := FromSerializedIdentity("signing certificate", identity)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Certificate{{Source: "signing certificate", Cert: cert}}, certs)
+
+	_, err = FromSerializedIdentity("signing certificate", []byte{1, 2, 3})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed unmarshaling the signing certificate")
+}
+
+func TestFromTLSCertificate(t *testing.T) {
+	cert := newCertificate(t, "localhost", time.Now().Add(day))
+
+	certs, err := FromTLSCertificate("TLS server certificate", tls.Certificate{Certificate: [][]byte{cert.Raw}})
+	assert.NoError(t, err)
+	assert.Equal(t, []*Certificate{{Source: "TLS server certificate", Cert: cert}}, certs)
+
+	certs, err = FromTLSCertificate("TLS server certificate", tls.Certificate{Leaf: cert})
+	assert.NoError(t, err)
+	assert.Equal(t, []*Certificate{{Source: "TLS server certificate", Cert: cert}}, certs)
+
+	_, err = FromTLSCertificate("TLS client certificate", tls.Certificate{})
+	assert.EqualError(t, err, "the TLS client certificate is empty")
+}
+
+func TestFromChannelConfig(t *testing.T) {
+	channelGroup, err := encoder.NewChannelGroup(genesisconfig.Load(genesisconfig.SampleSingleMSPSoloProfile))
+	assert.NoError(t, err)
+
+	// The sample org is defined both as an orderer org and as a consortium member,
+	// but its certificates are returned once
+	certs, err := FromChannelConfig("testchainid", &cb.Config{ChannelGroup: channelGroup})
+	assert.NoError(t, err)
+	var sources []string
+	for _, c := range certs {
+		sources = append(sources, c.Source)
+	}
+	assert.Equal(t, []string{
+		"root CA certificate of MSP DEFAULT of channel testchainid",
+		"admin certificate of MSP DEFAULT of channel testchainid",
+		"TLS root CA certificate of MSP DEFAULT of channel testchainid",
+		"TLS intermediate CA certificate of MSP DEFAULT of channel testchainid",
+	}, sources)
+
+	_, err = FromChannelConfig("foo", &cb.Config{})
+	assert.EqualError(t, err, "the config of channel foo is empty")
+
+	badGroup := cb.NewConfigGroup()
+	badGroup.Values["MSP"] = &cb.ConfigValue{Value: []byte{1, 2, 3}}
+	_, err = FromChannelConfig("foo", &cb.Config{ChannelGroup: badGroup})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid config of channel foo")
+}
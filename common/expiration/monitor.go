@@ -0,0 +1,193 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package expiration
+
+import (
+	"crypto/x509"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/metrics"
+)
+
+var logger = flogging.MustGetLogger("expiration")
+
+const (
+	day = 24 * time.Hour
+
+	// DefaultInterval is how often the certificates are checked by default
+	DefaultInterval = day
+	// DefaultWarningPeriod is how long before a certificate expires warnings are logged by default
+	DefaultWarningPeriod = 30 * day
+	// DefaultCriticalPeriod is how long before a certificate expires errors are logged by default
+	DefaultCriticalPeriod = 7 * day
+
+	metricsSubScope = "certificate"
+	metricsGauge    = "days_to_expiry"
+)
+
+// Certificate is a certificate to monitor along with where it comes from
+type Certificate struct {
+	// Source describes the role of the certificate, such as the TLS server
+	// certificate, or the root CA certificate of an MSP of a channel
+	Source string
+	Cert   *x509.Certificate
+}
+
+// Collector returns the certificates to monitor. It is called on every check,
+// so that certificates which are replaced or added at runtime are monitored too
+type Collector func() ([]*Certificate, error)
+
+// Status is the result of checking a certificate
+type Status struct {
+	Source   string
+	Subject  string
+	NotAfter time.Time
+	// DaysToExpiry is the number of whole days left until the certificate
+	// expires, which is negative once the certificate has expired
+	DaysToExpiry int
+}
+
+// Config configures a Monitor
+type Config struct {
+	// Interval is how often the certificates are checked.
+	// It defaults to DefaultInterval
+	Interval time.Duration
+	// WarningPeriod is how long before a certificate expires warnings are logged.
+	// It defaults to DefaultWarningPeriod
+	WarningPeriod time.Duration
+	// CriticalPeriod is how long before a certificate expires errors are logged.
+	// It defaults to DefaultCriticalPeriod
+	CriticalPeriod time.Duration
+}
+
+// Monitor checks the expiration dates of certificates at startup and periodically,
+// logs increasingly severe messages as their expiration dates approach, and reports
+// the number of days left until they expire through the metrics scope
+type Monitor struct {
+	conf       Config
+	scope      metrics.Scope
+	collectors []Collector
+
+	lock   sync.RWMutex
+	status []Status
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewMonitor creates a Monitor of the certificates returned by the collectors.
+// The scope may be nil, in which case no metrics are reported
+func NewMonitor(conf Config, scope metrics.Scope, collectors ...Collector) *Monitor {
+	if conf.Interval <= 0 {
+		conf.Interval = DefaultInterval
+	}
+	if conf.WarningPeriod <= 0 {
+		conf.WarningPeriod = DefaultWarningPeriod
+	}
+	if conf.CriticalPeriod <= 0 {
+		conf.CriticalPeriod = DefaultCriticalPeriod
+	}
+	if scope != nil {
+		scope = scope.SubScope(metricsSubScope)
+	}
+	return &Monitor{
+		conf:       conf,
+		scope:      scope,
+		collectors: collectors,
+		stop:       make(chan struct{}),
+	}
+}
+
+// Start checks the certificates, and keeps checking them in the background once per interval
+func (m *Monitor) Start() {
+	m.Check()
+	go m.run()
+}
+
+// Stop stops checking the certificates
+func (m *Monitor) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+	})
+}
+
+func (m *Monitor) run() {
+	ticker := time.NewTicker(m.conf.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+			m.Check()
+		}
+	}
+}
+
+// Check checks the certificates returned by the collectors, and returns their status
+// sorted by expiration date
+func (m *Monitor) Check() []Status {
+	now := time.Now()
+	var status []Status
+	for _, collect := range m.collectors {
+		certs, err := collect()
+		if err != nil {
+			logger.Warningf("Failed collecting certificates to check for expiration: %s", err)
+		}
+		for _, c := range certs {
+			s := newStatus(c, now)
+			m.log(s, now)
+			if m.scope != nil {
+				m.scope.Tagged(map[string]string{"source": s.Source, "subject": s.Subject}).
+					Gauge(metricsGauge).Update(float64(s.DaysToExpiry))
+			}
+			status = append(status, s)
+		}
+	}
+	sort.SliceStable(status, func(i, j int) bool {
+		return status[i].NotAfter.Before(status[j].NotAfter)
+	})
+
+	m.lock.Lock()
+	m.status = status
+	m.lock.Unlock()
+	return status
+}
+
+// Status returns the status of the certificates as of the last check
+func (m *Monitor) Status() []Status {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return append([]Status(nil), m.status...)
+}
+
+func (m *Monitor) log(s Status, now time.Time) {
+	left := s.NotAfter.Sub(now)
+	switch {
+	case left <= 0:
+		logger.Errorf("The %s [%s] expired at %s", s.Source, s.Subject, s.NotAfter)
+	case left <= m.conf.CriticalPeriod:
+		logger.Errorf("The %s [%s] expires in %d days, at %s", s.Source, s.Subject, s.DaysToExpiry, s.NotAfter)
+	case left <= m.conf.WarningPeriod:
+		logger.Warningf("The %s [%s] expires in %d days, at %s", s.Source, s.Subject, s.DaysToExpiry, s.NotAfter)
+	default:
+		logger.Debugf("The %s [%s] expires in %d days, at %s", s.Source, s.Subject, s.DaysToExpiry, s.NotAfter)
+	}
+}
+
+func newStatus(c *Certificate, now time.Time) Status {
+	return Status{
+		Source:       c.Source,
+		Subject:      c.Cert.Subject.String(),
+		NotAfter:     c.Cert.NotAfter,
+		DaysToExpiry: int(math.Floor(float64(c.Cert.NotAfter.Sub(now)) / float64(day))),
+	}
+}
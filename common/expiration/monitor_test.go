@@ -0,0 +1,151 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package expiration
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCertificate(t *testing.T, cn string, notAfter time.Time) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    notAfter.Add(-365 * day),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return cert
+}
+
+func pemEncode(certs ...*x509.Certificate) []byte {
+	var pemBytes []byte
+	for _, cert := range certs {
+		pemBytes = append(pemBytes, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})...)
+	}
+	return pemBytes
+}
+
+type gaugeRecorder struct {
+	tags   map[string]string
+	values map[string]float64
+}
+
+func (g *gaugeRecorder) Counter(name string) metrics.Counter { return nil }
+func (g *gaugeRecorder) SubScope(name string) metrics.Scope  { return g }
+func (g *gaugeRecorder) Start() error                        { return nil }
+func (g *gaugeRecorder) Close() error                        { return nil }
+
+func (g *gaugeRecorder) Tagged(tags map[string]string) metrics.Scope {
+	return &gaugeRecorder{tags: tags, values: g.values}
+}
+
+func (g *gaugeRecorder) Gauge(name string) metrics.Gauge {
+	return &taggedGauge{recorder: g, name: name + " " + g.tags["source"]}
+}
+
+type taggedGauge struct {
+	recorder *gaugeRecorder
+	name     string
+}
+
+func (tg *taggedGauge) Update(value float64) {
+	tg.recorder.values[tg.name] = value
+}
+
+func TestCheck(t *testing.T) {
+	now := time.Now()
+	expired := newCertificate(t, "expired", now.Add(-36*time.Hour))
+	critical := newCertificate(t, "critical", now.Add(3*day+time.Hour))
+	warning := newCertificate(t, "warning", now.Add(20*day+time.Hour))
+	valid := newCertificate(t, "valid", now.Add(100*day+time.Hour))
+
+	recorder := &gaugeRecorder{values: map[string]float64{}}
+	m := NewMonitor(Config{}, recorder,
+		func() ([]*Certificate, error) {
+			return []*Certificate{{Source: "valid cert", Cert: valid}, {Source: "critical cert", Cert: critical}}, nil
+		},
+		func() ([]*Certificate, error) {
+			return nil, errors.New("no certificates")
+		},
+		func() ([]*Certificate, error) {
+			return []*Certificate{{Source: "warning cert", Cert: warning}, {Source: "expired cert", Cert: expired}}, nil
+		},
+	)
+	assert.Empty(t, m.Status())
+
+	status := m.Check()
+	assert.Equal(t, []Status{
+		{Source: "expired cert", Subject: "CN=expired", NotAfter: expired.NotAfter, DaysToExpiry: -2},
+		{Source: "critical cert", Subject: "CN=critical", NotAfter: critical.NotAfter, DaysToExpiry: 3},
+		{Source: "warning cert", Subject: "CN=warning", NotAfter: warning.NotAfter, DaysToExpiry: 20},
+		{Source: "valid cert", Subject: "CN=valid", NotAfter: valid.NotAfter, DaysToExpiry: 100},
+	}, status)
+	assert.Equal(t, status, m.Status())
+	assert.Equal(t, map[string]float64{
+		"days_to_expiry expired cert":  -2,
+		"days_to_expiry critical cert": 3,
+		"days_to_expiry warning cert":  20,
+		"days_to_expiry valid cert":    100,
+	}, recorder.values)
+}
+
+func TestStartStop(t *testing.T) {
+	cert := newCertificate(t, "cert", time.Now().Add(day))
+	checks := make(chan struct{}, 10)
+	m := NewMonitor(Config{Interval: 10 * time.Millisecond}, nil, func() ([]*Certificate, error) {
+		checks <- struct{}{}
+		return []*Certificate{{Source: "cert", Cert: cert}}, nil
+	})
+
+	// Start checks the certificates right away
+	m.Start()
+	assert.NotEmpty(t, checks)
+	assert.Len(t, m.Status(), 1)
+	<-checks
+
+	// and then periodically
+	select {
+	case <-checks:
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "certificates weren't checked periodically")
+	}
+
+	m.Stop()
+	m.Stop()
+	time.Sleep(50 * time.Millisecond)
+	for len(checks) > 0 {
+		<-checks
+	}
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, checks)
+}
+
+func TestDefaults(t *testing.T) {
+	m := NewMonitor(Config{}, nil)
+	assert.Equal(t, Config{
+		Interval:       DefaultInterval,
+		WarningPeriod:  DefaultWarningPeriod,
+		CriticalPeriod: DefaultCriticalPeriod,
+	}, m.conf)
+}
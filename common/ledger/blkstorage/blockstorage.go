@@ -18,6 +18,7 @@ package blkstorage
 
 import (
 	"errors"
+	"time"
 
 	"github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/protos/common"
@@ -35,6 +36,10 @@ const (
 	IndexableAttrBlockNumTranNum  = IndexableAttr("BlockNumTranNum")
 	IndexableAttrBlockTxID        = IndexableAttr("BlockTxID")
 	IndexableAttrTxValidationCode = IndexableAttr("TxValidationCode")
+	IndexableAttrBlockNumTxStatus = IndexableAttr("BlockNumTxStatus")
+	IndexableAttrBlockTime        = IndexableAttr("BlockTime")
+	IndexableAttrTxCreator        = IndexableAttr("TxCreator")
+	IndexableAttrTxNamespace      = IndexableAttr("TxNamespace")
 )
 
 // IndexConfig - a configuration that includes a list of attributes that should be indexed
@@ -71,5 +76,18 @@ type BlockStore interface {
 	RetrieveTxByBlockNumTranNum(blockNum uint64, tranNum uint64) (*common.Envelope, error)
 	RetrieveBlockByTxID(txID string) (*common.Block, error)
 	RetrieveTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	// RetrieveTxStatusesByBlockRange returns the transactions of the blocks in
+	// the range [startNum, endNum] along with their validation codes
+	RetrieveTxStatusesByBlockRange(startNum uint64, endNum uint64) (*peer.TransactionStatuses, error)
+	// RetrieveBlockByTimestamp returns the block with the earliest timestamp that is
+	// not before the given time. The timestamp of a block is the latest timestamp of the first
+	// transactions of the block and of the blocks before it, as the timestamps are set by clients
+	RetrieveBlockByTimestamp(timestamp time.Time) (*common.Block, error)
+	// RetrieveTxStatusesByCreator returns the transactions of the blocks in the range
+	// [startNum, endNum] that were created by an identity of the given MSP
+	RetrieveTxStatusesByCreator(mspID string, startNum uint64, endNum uint64) (*peer.TransactionStatuses, error)
+	// RetrieveTxStatusesByNamespace returns the transactions of the blocks in the range
+	// [startNum, endNum] that read or wrote keys of the given namespace
+	RetrieveTxStatusesByNamespace(namespace string, startNum uint64, endNum uint64) (*peer.TransactionStatuses, error)
 	Shutdown()
 }
@@ -18,8 +18,11 @@ package fsblkstorage
 
 import (
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	ledgerutil "github.com/hyperledger/fabric/common/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/msp"
 	"github.com/hyperledger/fabric/protos/utils"
 )
 
@@ -33,6 +36,11 @@ type serializedBlockInfo struct {
 type txindexInfo struct {
 	txID string
 	loc  *locPointer
+	// creatorMSPID, namespaces and timestamp are extracted on a best effort basis
+	// and are left empty for the transactions from which they cannot be extracted
+	creatorMSPID string
+	namespaces   []string
+	timestamp    *timestamp.Timestamp
 }
 
 func serializeBlock(block *common.Block) ([]byte, *serializedBlockInfo, error) {
@@ -60,7 +68,7 @@ func deserializeBlock(serializedBlockBytes []byte) (*common.Block, error) {
 	if block.Header, err = extractHeader(b); err != nil {
 		return nil, err
 	}
-	if block.Data, _, err = extractData(b); err != nil {
+	if block.Data, _, err = extractData(b, extractTxIDIndexInfo); err != nil {
 		return nil, err
 	}
 	if block.Metadata, err = extractMetadata(b); err != nil {
@@ -77,7 +85,9 @@ func extractSerializedBlockInfo(serializedBlockBytes []byte) (*serializedBlockIn
 	if err != nil {
 		return nil, err
 	}
-	_, info.txOffsets, err = extractData(b)
+	// The attributes of the transactions other than their IDs are only
+	// extracted here, as they are not needed to deserialize blocks
+	_, info.txOffsets, err = extractData(b, extractStoredTxIndexInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -111,14 +121,14 @@ func addDataBytes(blockData *common.BlockData, buf *proto.Buffer) ([]*txindexInf
 	}
 	for _, txEnvelopeBytes := range blockData.Data {
 		offset := len(buf.Bytes())
-		txid, err := extractTxID(txEnvelopeBytes)
+		idxInfo, err := extractStoredTxIndexInfo(txEnvelopeBytes)
 		if err != nil {
 			return nil, err
 		}
 		if err := buf.EncodeRawBytes(txEnvelopeBytes); err != nil {
 			return nil, err
 		}
-		idxInfo := &txindexInfo{txid, &locPointer{offset, len(buf.Bytes()) - offset}}
+		idxInfo.loc = &locPointer{offset, len(buf.Bytes()) - offset}
 		txOffsets = append(txOffsets, idxInfo)
 	}
 	return txOffsets, nil
@@ -158,7 +168,9 @@ func extractHeader(buf *ledgerutil.Buffer) (*common.BlockHeader, error) {
 	return header, nil
 }
 
-func extractData(buf *ledgerutil.Buffer) (*common.BlockData, []*txindexInfo, error) {
+// extractData extracts the transactions of a block along with
+// their attributes extracted by the given function
+func extractData(buf *ledgerutil.Buffer, extractIdxInfo func([]byte) (*txindexInfo, error)) (*common.BlockData, []*txindexInfo, error) {
 	data := &common.BlockData{}
 	var txOffsets []*txindexInfo
 	var numItems uint64
@@ -169,16 +181,16 @@ func extractData(buf *ledgerutil.Buffer) (*common.BlockData, []*txindexInfo, err
 	}
 	for i := uint64(0); i < numItems; i++ {
 		var txEnvBytes []byte
-		var txid string
+		var idxInfo *txindexInfo
 		txOffset := buf.GetBytesConsumed()
 		if txEnvBytes, err = buf.DecodeRawBytes(false); err != nil {
 			return nil, nil, err
 		}
-		if txid, err = extractTxID(txEnvBytes); err != nil {
+		if idxInfo, err = extractIdxInfo(txEnvBytes); err != nil {
 			return nil, nil, err
 		}
 		data.Data = append(data.Data, txEnvBytes)
-		idxInfo := &txindexInfo{txid, &locPointer{txOffset, buf.GetBytesConsumed() - txOffset}}
+		idxInfo.loc = &locPointer{txOffset, buf.GetBytesConsumed() - txOffset}
 		txOffsets = append(txOffsets, idxInfo)
 	}
 	return data, txOffsets, nil
@@ -216,3 +228,101 @@ func extractTxID(txEnvelopBytes []byte) (string, error) {
 	}
 	return chdr.TxId, nil
 }
+
+// extractTxIDIndexInfo extracts only the ID of a transaction
+func extractTxIDIndexInfo(txEnvelopBytes []byte) (*txindexInfo, error) {
+	txid, err := extractTxID(txEnvelopBytes)
+	if err != nil {
+		return nil, err
+	}
+	return &txindexInfo{txID: txid}, nil
+}
+
+// malformedPayloadError is returned by extractTxIndexInfo
+// for the transactions whose payload cannot be unmarshaled
+type malformedPayloadError struct {
+	error
+}
+
+// extractStoredTxIndexInfo extracts the attributes of a transaction that is stored in a block.
+// The ledgers of the orderer may contain transactions with opaque payloads, so the transactions
+// with a malformed payload are stored without being indexed by their attributes
+func extractStoredTxIndexInfo(txEnvelopBytes []byte) (*txindexInfo, error) {
+	idxInfo, err := extractTxIndexInfo(txEnvelopBytes)
+	if _, ok := err.(malformedPayloadError); ok {
+		logger.Warningf("Transaction with a malformed payload is not indexed: %s", err)
+		return &txindexInfo{}, nil
+	}
+	return idxInfo, err
+}
+
+// extractTxIndexInfo extracts the attributes of a transaction that are indexed,
+// except for its location which is only known once the block is serialized
+func extractTxIndexInfo(txEnvelopBytes []byte) (*txindexInfo, error) {
+	idxInfo := &txindexInfo{}
+	txEnvelope, err := utils.GetEnvelopeFromBlock(txEnvelopBytes)
+	if err != nil {
+		return nil, err
+	}
+	txPayload, err := utils.GetPayload(txEnvelope)
+	if err != nil {
+		return nil, malformedPayloadError{err}
+	}
+	chdr, err := utils.UnmarshalChannelHeader(txPayload.Header.ChannelHeader)
+	if err != nil {
+		return nil, err
+	}
+	idxInfo.txID = chdr.TxId
+	idxInfo.timestamp = chdr.Timestamp
+	if shdr, err := utils.GetSignatureHeader(txPayload.Header.SignatureHeader); err == nil {
+		idxInfo.creatorMSPID = extractMSPID(shdr.Creator)
+	}
+	if common.HeaderType(chdr.Type) == common.HeaderType_ENDORSER_TRANSACTION {
+		idxInfo.namespaces = extractNamespaces(txPayload.Data)
+	}
+	return idxInfo, nil
+}
+
+func extractMSPID(creator []byte) string {
+	sID := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(creator, sID); err != nil {
+		return ""
+	}
+	return sID.Mspid
+}
+
+// extractNamespaces returns the namespaces of the read-write sets
+// of the actions of an endorser transaction
+func extractNamespaces(txBytes []byte) []string {
+	var namespaces []string
+	seen := make(map[string]bool)
+	tx, err := utils.GetTransaction(txBytes)
+	if err != nil {
+		return nil
+	}
+	for _, action := range tx.Actions {
+		ccActionPayload, err := utils.GetChaincodeActionPayload(action.Payload)
+		if err != nil || ccActionPayload.Action == nil {
+			continue
+		}
+		prp, err := utils.GetProposalResponsePayload(ccActionPayload.Action.ProposalResponsePayload)
+		if err != nil {
+			continue
+		}
+		ccAction, err := utils.GetChaincodeAction(prp.Extension)
+		if err != nil {
+			continue
+		}
+		txRWSet := &rwset.TxReadWriteSet{}
+		if err := proto.Unmarshal(ccAction.Results, txRWSet); err != nil {
+			continue
+		}
+		for _, nsRWSet := range txRWSet.NsRwset {
+			if !seen[nsRWSet.Namespace] {
+				seen[nsRWSet.Namespace] = true
+				namespaces = append(namespaces, nsRWSet.Namespace)
+			}
+		}
+	}
+	return namespaces
+}
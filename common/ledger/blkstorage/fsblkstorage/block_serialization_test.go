@@ -21,6 +21,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/protos/common"
 	putils "github.com/hyperledger/fabric/protos/utils"
 )
 
@@ -41,6 +42,23 @@ func TestExtractTxid(t *testing.T) {
 	testutil.AssertEquals(t, extractedTxid, txid)
 }
 
+func TestExtractTxIndexInfoMalformedPayload(t *testing.T) {
+	txEnvBytes, _ := putils.GetBytesEnvelope(&common.Envelope{Payload: []byte("malformed payload")})
+	idxInfo, err := extractTxIndexInfo(txEnvBytes)
+	testutil.AssertError(t, err, "Expected an error for a malformed payload")
+	testutil.AssertNil(t, idxInfo)
+
+	// the transaction is still stored, without being indexed by its attributes
+	block := testutil.NewBlock([]*common.Envelope{{Payload: []byte("malformed payload")}}, 1, []byte("previousHash"))
+	bb, info, err := serializeBlock(block)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, len(info.txOffsets), 1)
+	testutil.AssertEquals(t, info.txOffsets[0].txID, "")
+	deserializedBlock, err := deserializeBlock(bb)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, deserializedBlock, block)
+}
+
 func TestSerializedBlockInfo(t *testing.T) {
 	block := testutil.ConstructTestBlock(t, 1, 10, 100)
 	bb, info, err := serializeBlock(block)
@@ -21,6 +21,7 @@ import (
 	"math"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/davecgh/go-spew/spew"
 
@@ -321,6 +322,10 @@ func (mgr *blockfileMgr) syncIndex() error {
 	var lastBlockIndexed uint64
 	var indexEmpty bool
 	var err error
+	//build the indexes added since the blocks already indexed were indexed
+	if err = mgr.backfillTxStatusIndexes(); err != nil {
+		return err
+	}
 	//from the database, get the last block that was indexed
 	if lastBlockIndexed, err = mgr.index.getLastBlockIndexed(); err != nil {
 		if err != errIndexEmpty {
@@ -387,26 +392,10 @@ func (mgr *blockfileMgr) syncIndex() error {
 		if blockBytes == nil {
 			break
 		}
-		info, err := extractSerializedBlockInfo(blockBytes)
-		if err != nil {
+		if blockIdxInfo, err = newBlockIdxInfo(blockBytes, blockPlacementInfo); err != nil {
 			return err
 		}
 
-		//The blockStartOffset will get applied to the txOffsets prior to indexing within indexBlock(),
-		//therefore just shift by the difference between blockBytesOffset and blockStartOffset
-		numBytesToShift := int(blockPlacementInfo.blockBytesOffset - blockPlacementInfo.blockStartOffset)
-		for _, offset := range info.txOffsets {
-			offset.loc.offset += numBytesToShift
-		}
-
-		//Update the blockIndexInfo with what was actually stored in file system
-		blockIdxInfo.blockHash = info.blockHeader.Hash()
-		blockIdxInfo.blockNum = info.blockHeader.Number
-		blockIdxInfo.flp = &fileLocPointer{fileSuffixNum: blockPlacementInfo.fileNum,
-			locPointer: locPointer{offset: int(blockPlacementInfo.blockStartOffset)}}
-		blockIdxInfo.txOffsets = info.txOffsets
-		blockIdxInfo.metadata = info.metadata
-
 		logger.Debugf("syncIndex() indexing block [%d]", blockIdxInfo.blockNum)
 		if err = mgr.index.indexBlock(blockIdxInfo); err != nil {
 			return err
@@ -419,6 +408,75 @@ func (mgr *blockfileMgr) syncIndex() error {
 	return nil
 }
 
+// backfillTxStatusIndexes builds the indexes of transaction statuses for the blocks
+// that were indexed by a version that did not maintain these indexes
+func (mgr *blockfileMgr) backfillTxStatusIndexes() error {
+	incomplete, err := mgr.index.txStatusIndexesIncomplete()
+	if err != nil || !incomplete {
+		return err
+	}
+	lastBlockIndexed, err := mgr.index.getLastBlockIndexed()
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("Start building transaction status indexes from block [0] to block [%d]", lastBlockIndexed)
+	stream, err := newBlockStream(mgr.rootDir, 0, 0, mgr.cpInfo.latestFileChunkSuffixNum)
+	if err != nil {
+		return err
+	}
+	defer stream.close()
+	for {
+		blockBytes, blockPlacementInfo, err := stream.nextBlockBytesAndPlacementInfo()
+		if err != nil {
+			return err
+		}
+		if blockBytes == nil {
+			return fmt.Errorf("block [%d] is indexed but was not found in the block files", lastBlockIndexed)
+		}
+		blockIdxInfo, err := newBlockIdxInfo(blockBytes, blockPlacementInfo)
+		if err != nil {
+			return err
+		}
+		if err = mgr.index.indexTxStatuses(blockIdxInfo); err != nil {
+			return err
+		}
+		if blockIdxInfo.blockNum%10000 == 0 {
+			logger.Infof("Built transaction status indexes up to block [%d]", blockIdxInfo.blockNum)
+		}
+		if blockIdxInfo.blockNum == lastBlockIndexed {
+			break
+		}
+	}
+	logger.Infof("Finished building transaction status indexes up to block [%d]", lastBlockIndexed)
+	return mgr.index.markTxStatusIndexesComplete()
+}
+
+// newBlockIdxInfo returns the index information of a block read from the block files
+func newBlockIdxInfo(blockBytes []byte, blockPlacementInfo *blockPlacementInfo) (*blockIdxInfo, error) {
+	info, err := extractSerializedBlockInfo(blockBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	//The blockStartOffset will get applied to the txOffsets prior to indexing within indexBlock(),
+	//therefore just shift by the difference between blockBytesOffset and blockStartOffset
+	numBytesToShift := int(blockPlacementInfo.blockBytesOffset - blockPlacementInfo.blockStartOffset)
+	for _, offset := range info.txOffsets {
+		offset.loc.offset += numBytesToShift
+	}
+
+	//Update the blockIndexInfo with what was actually stored in file system
+	return &blockIdxInfo{
+		blockHash: info.blockHeader.Hash(),
+		blockNum:  info.blockHeader.Number,
+		flp: &fileLocPointer{fileSuffixNum: blockPlacementInfo.fileNum,
+			locPointer: locPointer{offset: int(blockPlacementInfo.blockStartOffset)}},
+		txOffsets: info.txOffsets,
+		metadata:  info.metadata,
+	}, nil
+}
+
 func (mgr *blockfileMgr) getBlockchainInfo() *common.BlockchainInfo {
 	return mgr.bcInfo.Load().(*common.BlockchainInfo)
 }
@@ -481,6 +539,30 @@ func (mgr *blockfileMgr) retrieveTxValidationCodeByTxID(txID string) (peer.TxVal
 	return mgr.index.getTxValidationCodeByTxID(txID)
 }
 
+func (mgr *blockfileMgr) retrieveTxStatusesByBlockRange(startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	logger.Debugf("retrieveTxStatusesByBlockRange() - startNum = [%d], endNum = [%d]", startNum, endNum)
+	return mgr.index.getTxStatusesByBlockRange(startNum, endNum)
+}
+
+func (mgr *blockfileMgr) retrieveBlockByTimestamp(timestamp time.Time) (*common.Block, error) {
+	logger.Debugf("retrieveBlockByTimestamp() - timestamp = [%s]", timestamp)
+	blockNum, err := mgr.index.getBlockNumByTimestamp(timestamp)
+	if err != nil {
+		return nil, err
+	}
+	return mgr.retrieveBlockByNumber(blockNum)
+}
+
+func (mgr *blockfileMgr) retrieveTxStatusesByCreator(mspID string, startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	logger.Debugf("retrieveTxStatusesByCreator() - mspID = [%s], startNum = [%d], endNum = [%d]", mspID, startNum, endNum)
+	return mgr.index.getTxStatusesByCreator(mspID, startNum, endNum)
+}
+
+func (mgr *blockfileMgr) retrieveTxStatusesByNamespace(namespace string, startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	logger.Debugf("retrieveTxStatusesByNamespace() - namespace = [%s], startNum = [%d], endNum = [%d]", namespace, startNum, endNum)
+	return mgr.index.getTxStatusesByNamespace(namespace, startNum, endNum)
+}
+
 func (mgr *blockfileMgr) retrieveBlockHeaderByNumber(blockNum uint64) (*common.BlockHeader, error) {
 	logger.Debugf("retrieveBlockHeaderByNumber() - blockNum = [%d]", blockNum)
 	loc, err := mgr.index.getBlockLocByBlockNum(blockNum)
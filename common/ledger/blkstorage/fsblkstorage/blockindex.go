@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
@@ -37,21 +38,54 @@ const (
 	blockNumTranNumIdxKeyPrefix    = 'a'
 	blockTxIDIdxKeyPrefix          = 'b'
 	txValidationResultIdxKeyPrefix = 'v'
+	blockNumTxStatusIdxKeyPrefix   = 's'
+	blockTimeIdxKeyPrefix          = 'm'
+	txCreatorIdxKeyPrefix          = 'c'
+	txNamespaceIdxKeyPrefix        = 'x'
+	idxKeySep                      = byte(0x00)
 	indexCheckpointKeyStr          = "indexCheckpointKey"
+	txStatusIndexesKeyStr          = "indexTxStatusesComplete"
+	lastBlockTimeKeyStr            = "indexLastBlockTime"
 )
 
 var indexCheckpointKey = []byte(indexCheckpointKeyStr)
+
+// txStatusIndexesKey marks that the indexes of transaction statuses cover every indexed block,
+// including the blocks indexed by the versions that did not maintain these indexes
+var txStatusIndexesKey = []byte(txStatusIndexesKeyStr)
+
+// lastBlockTimeKey stores the timestamp of the last block indexed by its timestamp
+var lastBlockTimeKey = []byte(lastBlockTimeKeyStr)
 var errIndexEmpty = errors.New("NoBlockIndexed")
 
+// txStatusesQueryLimit is the number of transaction statuses after which the queries
+// of transaction statuses stop at the next block, which the caller can query from next
+var txStatusesQueryLimit = 10000
+
+// txStatusIndexAttrs are the attributes of the indexes of transaction statuses
+var txStatusIndexAttrs = []blkstorage.IndexableAttr{
+	blkstorage.IndexableAttrBlockNumTxStatus,
+	blkstorage.IndexableAttrBlockTime,
+	blkstorage.IndexableAttrTxCreator,
+	blkstorage.IndexableAttrTxNamespace,
+}
+
 type index interface {
 	getLastBlockIndexed() (uint64, error)
 	indexBlock(blockIdxInfo *blockIdxInfo) error
+	txStatusIndexesIncomplete() (bool, error)
+	indexTxStatuses(blockIdxInfo *blockIdxInfo) error
+	markTxStatusIndexesComplete() error
 	getBlockLocByHash(blockHash []byte) (*fileLocPointer, error)
 	getBlockLocByBlockNum(blockNum uint64) (*fileLocPointer, error)
 	getTxLoc(txID string) (*fileLocPointer, error)
 	getTXLocByBlockNumTranNum(blockNum uint64, tranNum uint64) (*fileLocPointer, error)
 	getBlockLocByTxID(txID string) (*fileLocPointer, error)
 	getTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	getTxStatusesByBlockRange(startNum uint64, endNum uint64) (*peer.TransactionStatuses, error)
+	getBlockNumByTimestamp(timestamp time.Time) (uint64, error)
+	getTxStatusesByCreator(mspID string, startNum uint64, endNum uint64) (*peer.TransactionStatuses, error)
+	getTxStatusesByNamespace(namespace string, startNum uint64, endNum uint64) (*peer.TransactionStatuses, error)
 }
 
 type blockIdxInfo struct {
@@ -155,6 +189,15 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 		}
 	}
 
+	// Index7 to Index10 - Store transaction statuses by block number, timestamp, creator and namespace
+	if err := index.addTxStatusIndexes(blockIdxInfo, txsfltr, batch); err != nil {
+		return err
+	}
+	// The indexes of transaction statuses are complete if they are built from the genesis block
+	if blockIdxInfo.blockNum == 0 && index.indexesTxStatuses() {
+		batch.Put(txStatusIndexesKey, []byte{1})
+	}
+
 	batch.Put(indexCheckpointKey, encodeBlockNum(blockIdxInfo.blockNum))
 	// Setting snyc to true as a precaution, false may be an ok optimization after further testing.
 	if err := index.db.WriteBatch(batch, true); err != nil {
@@ -163,6 +206,128 @@ func (index *blockIndex) indexBlock(blockIdxInfo *blockIdxInfo) error {
 	return nil
 }
 
+// addTxStatusIndexes adds the transaction statuses of the block to the indexes of transaction statuses
+func (index *blockIndex) addTxStatusIndexes(blockIdxInfo *blockIdxInfo, txsfltr ledgerUtil.TxValidationFlags, batch *leveldbhelper.UpdateBatch) error {
+	txOffsets := blockIdxInfo.txOffsets
+
+	// Index7 - Store transaction statuses by block number and transaction number
+	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrBlockNumTxStatus]; ok {
+		for idx, txoffset := range txOffsets {
+			txStatusBytes, err := marshalTxStatus(blockIdxInfo.blockNum, idx, txoffset.txID, txsfltr)
+			if err != nil {
+				return err
+			}
+			batch.Put(constructBlockNumTxStatusKey(blockIdxInfo.blockNum, uint64(idx)), txStatusBytes)
+		}
+	}
+
+	// Index8 - Store block number by the timestamp of the block
+	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrBlockTime]; ok {
+		blockTime, err := index.blockTime(blockIdxInfo)
+		if err != nil {
+			return err
+		}
+		if blockTime > 0 {
+			batch.Put(constructBlockTimeKey(blockTime, blockIdxInfo.blockNum), encodeBlockNum(blockIdxInfo.blockNum))
+			batch.Put(lastBlockTimeKey, encodeBlockNum(blockTime))
+		}
+	}
+
+	// Index9 - Store transaction statuses by the MSP ID of the creator of the transaction
+	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrTxCreator]; ok {
+		for idx, txoffset := range txOffsets {
+			if txoffset.creatorMSPID == "" {
+				continue
+			}
+			txStatusBytes, err := marshalTxStatus(blockIdxInfo.blockNum, idx, txoffset.txID, txsfltr)
+			if err != nil {
+				return err
+			}
+			batch.Put(constructTxCreatorKey(txoffset.creatorMSPID, blockIdxInfo.blockNum, uint64(idx)), txStatusBytes)
+		}
+	}
+
+	// Index10 - Store transaction statuses by the namespaces the transaction read or wrote
+	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrTxNamespace]; ok {
+		for idx, txoffset := range txOffsets {
+			for _, ns := range txoffset.namespaces {
+				txStatusBytes, err := marshalTxStatus(blockIdxInfo.blockNum, idx, txoffset.txID, txsfltr)
+				if err != nil {
+					return err
+				}
+				batch.Put(constructTxNamespaceKey(ns, blockIdxInfo.blockNum, uint64(idx)), txStatusBytes)
+			}
+		}
+	}
+	return nil
+}
+
+// blockTime returns the timestamp of a block in nanoseconds since the epoch.
+// The timestamps of transactions are set by their clients, so the timestamp of a block
+// is the latest timestamp of the first transactions of the block and of the blocks before it,
+// which keeps the timestamps of the blocks monotonic.
+// The blocks are indexed in order, so the timestamp of the previous block is the last one stored
+func (index *blockIndex) blockTime(blockIdxInfo *blockIdxInfo) (uint64, error) {
+	var blockTime uint64
+	if blockIdxInfo.blockNum > 0 {
+		lastBlockTimeBytes, err := index.db.Get(lastBlockTimeKey)
+		if err != nil {
+			return 0, err
+		}
+		if lastBlockTimeBytes != nil {
+			blockTime = decodeBlockNum(lastBlockTimeBytes)
+		}
+	}
+	if txOffsets := blockIdxInfo.txOffsets; len(txOffsets) > 0 && txOffsets[0].timestamp != nil {
+		if txTime := unixNanos(txOffsets[0].timestamp.Seconds, txOffsets[0].timestamp.Nanos); txTime > blockTime {
+			blockTime = txTime
+		}
+	}
+	return blockTime, nil
+}
+
+func (index *blockIndex) indexesTxStatuses() bool {
+	for _, attr := range txStatusIndexAttrs {
+		if index.indexItemsMap[attr] {
+			return true
+		}
+	}
+	return false
+}
+
+// txStatusIndexesIncomplete returns true if blocks were indexed by a version
+// that did not maintain the indexes of transaction statuses
+func (index *blockIndex) txStatusIndexesIncomplete() (bool, error) {
+	if !index.indexesTxStatuses() {
+		return false, nil
+	}
+	complete, err := index.db.Get(txStatusIndexesKey)
+	if err != nil || complete != nil {
+		return false, err
+	}
+	if _, err = index.getLastBlockIndexed(); err != nil {
+		if err == errIndexEmpty {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// indexTxStatuses adds an already indexed block to the indexes of transaction statuses
+func (index *blockIndex) indexTxStatuses(blockIdxInfo *blockIdxInfo) error {
+	txsfltr := ledgerUtil.TxValidationFlags(blockIdxInfo.metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	batch := leveldbhelper.NewUpdateBatch()
+	if err := index.addTxStatusIndexes(blockIdxInfo, txsfltr, batch); err != nil {
+		return err
+	}
+	return index.db.WriteBatch(batch, false)
+}
+
+func (index *blockIndex) markTxStatusIndexesComplete() error {
+	return index.db.Put(txStatusIndexesKey, []byte{1}, true)
+}
+
 func (index *blockIndex) getBlockLocByHash(blockHash []byte) (*fileLocPointer, error) {
 	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrBlockHash]; !ok {
 		return nil, blkstorage.ErrAttrNotIndexed
@@ -263,6 +428,83 @@ func (index *blockIndex) getTxValidationCodeByTxID(txID string) (peer.TxValidati
 	return result, nil
 }
 
+func (index *blockIndex) getTxStatusesByBlockRange(startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrBlockNumTxStatus]; !ok {
+		return nil, blkstorage.ErrAttrNotIndexed
+	}
+	return index.scanTxStatuses([]byte{blockNumTxStatusIdxKeyPrefix}, startNum, endNum)
+}
+
+func (index *blockIndex) getBlockNumByTimestamp(timestamp time.Time) (uint64, error) {
+	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrBlockTime]; !ok {
+		return 0, blkstorage.ErrAttrNotIndexed
+	}
+	itr := index.db.GetIterator(constructBlockTimeKey(unixNanos(timestamp.Unix(), int32(timestamp.Nanosecond())), 0),
+		[]byte{blockTimeIdxKeyPrefix + 1})
+	defer itr.Release()
+	if !itr.Next() {
+		if err := itr.Error(); err != nil {
+			return 0, err
+		}
+		return 0, blkstorage.ErrNotFoundInIndex
+	}
+	return decodeBlockNum(itr.Value()), nil
+}
+
+func (index *blockIndex) getTxStatusesByCreator(mspID string, startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrTxCreator]; !ok {
+		return nil, blkstorage.ErrAttrNotIndexed
+	}
+	return index.scanTxStatuses(constructAttrKeyPrefix(txCreatorIdxKeyPrefix, mspID), startNum, endNum)
+}
+
+func (index *blockIndex) getTxStatusesByNamespace(namespace string, startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	if _, ok := index.indexItemsMap[blkstorage.IndexableAttrTxNamespace]; !ok {
+		return nil, blkstorage.ErrAttrNotIndexed
+	}
+	return index.scanTxStatuses(constructAttrKeyPrefix(txNamespaceIdxKeyPrefix, namespace), startNum, endNum)
+}
+
+// scanTxStatuses returns the transaction statuses stored under the given key prefix,
+// followed by the block number and the transaction number, for the blocks in [startNum, endNum].
+// Once txStatusesQueryLimit statuses are found, the scan stops at the next block.
+func (index *blockIndex) scanTxStatuses(keyPrefix []byte, startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	startKey := append(append([]byte{}, keyPrefix...), util.EncodeOrderPreservingVarUint64(startNum)...)
+	endKey := append([]byte{}, keyPrefix...)
+	endKey[len(endKey)-1]++
+	itr := index.db.GetIterator(startKey, endKey)
+	defer itr.Release()
+
+	txStatuses := &peer.TransactionStatuses{Transactions: []*peer.TransactionStatus{}}
+	for itr.Next() {
+		txStatus := &peer.TransactionStatus{}
+		if err := proto.Unmarshal(itr.Value(), txStatus); err != nil {
+			return nil, err
+		}
+		if txStatus.BlockNumber > endNum {
+			break
+		}
+		if n := len(txStatuses.Transactions); n >= txStatusesQueryLimit && txStatuses.Transactions[n-1].BlockNumber != txStatus.BlockNumber {
+			txStatuses.NextBlockNumber = txStatus.BlockNumber
+			break
+		}
+		txStatuses.Transactions = append(txStatuses.Transactions, txStatus)
+	}
+	if err := itr.Error(); err != nil {
+		return nil, err
+	}
+	return txStatuses, nil
+}
+
+func marshalTxStatus(blockNum uint64, txNum int, txID string, txsfltr ledgerUtil.TxValidationFlags) ([]byte, error) {
+	return proto.Marshal(&peer.TransactionStatus{
+		TxId:           txID,
+		BlockNumber:    blockNum,
+		TxNumber:       uint64(txNum),
+		ValidationCode: txsfltr.Flag(txNum),
+	})
+}
+
 func constructBlockNumKey(blockNum uint64) []byte {
 	blkNumBytes := util.EncodeOrderPreservingVarUint64(blockNum)
 	return append([]byte{blockNumIdxKeyPrefix}, blkNumBytes...)
@@ -291,6 +533,46 @@ func constructBlockNumTranNumKey(blockNum uint64, txNum uint64) []byte {
 	return append([]byte{blockNumTranNumIdxKeyPrefix}, key...)
 }
 
+func constructBlockNumTxStatusKey(blockNum uint64, txNum uint64) []byte {
+	blkNumBytes := util.EncodeOrderPreservingVarUint64(blockNum)
+	tranNumBytes := util.EncodeOrderPreservingVarUint64(txNum)
+	key := append(blkNumBytes, tranNumBytes...)
+	return append([]byte{blockNumTxStatusIdxKeyPrefix}, key...)
+}
+
+func constructBlockTimeKey(blockTime uint64, blockNum uint64) []byte {
+	key := append(util.EncodeOrderPreservingVarUint64(blockTime), util.EncodeOrderPreservingVarUint64(blockNum)...)
+	return append([]byte{blockTimeIdxKeyPrefix}, key...)
+}
+
+// unixNanos returns the nanoseconds since the epoch of a timestamp,
+// or 0 if the timestamp is before the epoch
+func unixNanos(seconds int64, nanos int32) uint64 {
+	if seconds < 0 {
+		return 0
+	}
+	return uint64(seconds)*uint64(time.Second) + uint64(nanos)
+}
+
+// constructAttrKeyPrefix returns the prefix of the keys of an index by a string attribute,
+// which is separated from the block number and transaction number that follow it
+func constructAttrKeyPrefix(idxKeyPrefix byte, attr string) []byte {
+	key := append([]byte{idxKeyPrefix}, []byte(attr)...)
+	return append(key, idxKeySep)
+}
+
+func constructTxCreatorKey(mspID string, blockNum uint64, txNum uint64) []byte {
+	key := constructAttrKeyPrefix(txCreatorIdxKeyPrefix, mspID)
+	key = append(key, util.EncodeOrderPreservingVarUint64(blockNum)...)
+	return append(key, util.EncodeOrderPreservingVarUint64(txNum)...)
+}
+
+func constructTxNamespaceKey(namespace string, blockNum uint64, txNum uint64) []byte {
+	key := constructAttrKeyPrefix(txNamespaceIdxKeyPrefix, namespace)
+	key = append(key, util.EncodeOrderPreservingVarUint64(blockNum)...)
+	return append(key, util.EncodeOrderPreservingVarUint64(txNum)...)
+}
+
 func encodeBlockNum(blockNum uint64) []byte {
 	return proto.EncodeVarint(blockNum)
 }
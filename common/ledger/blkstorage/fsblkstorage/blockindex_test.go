@@ -18,14 +18,23 @@ package fsblkstorage
 
 import (
 	"fmt"
+	"math"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	mspproto "github.com/hyperledger/fabric/protos/msp"
 	"github.com/hyperledger/fabric/protos/peer"
+	ptestutils "github.com/hyperledger/fabric/protos/testutils"
 	putil "github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
 )
 
 type noopIndex struct {
@@ -58,6 +67,34 @@ func (i *noopIndex) getTxValidationCodeByTxID(txID string) (peer.TxValidationCod
 	return peer.TxValidationCode(-1), nil
 }
 
+func (i *noopIndex) getTxStatusesByBlockRange(startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	return nil, nil
+}
+
+func (i *noopIndex) getBlockNumByTimestamp(timestamp time.Time) (uint64, error) {
+	return 0, nil
+}
+
+func (i *noopIndex) getTxStatusesByCreator(mspID string, startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	return nil, nil
+}
+
+func (i *noopIndex) getTxStatusesByNamespace(namespace string, startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	return nil, nil
+}
+
+func (i *noopIndex) txStatusIndexesIncomplete() (bool, error) {
+	return false, nil
+}
+
+func (i *noopIndex) indexTxStatuses(blockIdxInfo *blockIdxInfo) error {
+	return nil
+}
+
+func (i *noopIndex) markTxStatusIndexesComplete() error {
+	return nil
+}
+
 func TestBlockIndexSync(t *testing.T) {
 	testBlockIndexSync(t, 10, 5, false)
 	testBlockIndexSync(t, 10, 5, true)
@@ -128,6 +165,9 @@ func TestBlockIndexSelectiveIndexing(t *testing.T) {
 	testBlockIndexSelectiveIndexing(t, []blkstorage.IndexableAttr{blkstorage.IndexableAttrTxID, blkstorage.IndexableAttrBlockNumTranNum})
 	testBlockIndexSelectiveIndexing(t, []blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockTxID})
 	testBlockIndexSelectiveIndexing(t, []blkstorage.IndexableAttr{blkstorage.IndexableAttrTxValidationCode})
+	testBlockIndexSelectiveIndexing(t, []blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockNumTxStatus})
+	testBlockIndexSelectiveIndexing(t, []blkstorage.IndexableAttr{blkstorage.IndexableAttrBlockNum, blkstorage.IndexableAttrBlockTime})
+	testBlockIndexSelectiveIndexing(t, []blkstorage.IndexableAttr{blkstorage.IndexableAttrTxCreator, blkstorage.IndexableAttrTxNamespace})
 }
 
 func testBlockIndexSelectiveIndexing(t *testing.T, indexItems []blkstorage.IndexableAttr) {
@@ -202,6 +242,34 @@ func testBlockIndexSelectiveIndexing(t *testing.T, indexItems []blkstorage.Index
 			testutil.AssertSame(t, err, blkstorage.ErrAttrNotIndexed)
 		}
 
+		// test 'retrieveTxStatusesByBlockRange'
+		txStatuses, err := blockfileMgr.retrieveTxStatusesByBlockRange(0, 2)
+		if testutil.Contains(indexItems, blkstorage.IndexableAttrBlockNumTxStatus) {
+			testutil.AssertNoError(t, err, "Error while retrieving tx statuses by block range")
+			testutil.AssertEquals(t, len(txStatuses.Transactions), len(blocks[0].Data.Data)+len(blocks[1].Data.Data)+len(blocks[2].Data.Data))
+		} else {
+			testutil.AssertSame(t, err, blkstorage.ErrAttrNotIndexed)
+		}
+
+		// test 'retrieveBlockByTimestamp'
+		block, err = blockfileMgr.retrieveBlockByTimestamp(time.Unix(0, 0))
+		if testutil.Contains(indexItems, blkstorage.IndexableAttrBlockTime) {
+			testutil.AssertNoError(t, err, "Error while retrieving block by timestamp")
+			testutil.AssertEquals(t, block, blocks[0])
+		} else {
+			testutil.AssertSame(t, err, blkstorage.ErrAttrNotIndexed)
+		}
+
+		// test 'retrieveTxStatusesByCreator' and 'retrieveTxStatusesByNamespace'
+		_, err = blockfileMgr.retrieveTxStatusesByCreator("Org1MSP", 0, 2)
+		if !testutil.Contains(indexItems, blkstorage.IndexableAttrTxCreator) {
+			testutil.AssertSame(t, err, blkstorage.ErrAttrNotIndexed)
+		}
+		_, err = blockfileMgr.retrieveTxStatusesByNamespace("foo", 0, 2)
+		if !testutil.Contains(indexItems, blkstorage.IndexableAttrTxNamespace) {
+			testutil.AssertSame(t, err, blkstorage.ErrAttrNotIndexed)
+		}
+
 		for _, block := range blocks {
 			flags := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
 
@@ -224,3 +292,239 @@ func testBlockIndexSelectiveIndexing(t *testing.T, indexItems []blkstorage.Index
 		}
 	})
 }
+
+// mspSigner is a signing identity that belongs to the given MSP
+type mspSigner struct {
+	msp.SigningIdentity
+	mspID string
+}
+
+func (s *mspSigner) Serialize() ([]byte, error) {
+	return proto.Marshal(&mspproto.SerializedIdentity{Mspid: s.mspID, IdBytes: []byte("cert")})
+}
+
+func (s *mspSigner) Sign(msg []byte) ([]byte, error) {
+	return []byte("signature"), nil
+}
+
+// constructTxQueriesTestTx constructs a transaction of a creator
+// of the given MSP that writes a key in each of the given namespaces
+func constructTxQueriesTestTx(t *testing.T, txID string, mspID string, namespaces ...string) *common.Envelope {
+	txRWSet := &rwset.TxReadWriteSet{DataModel: rwset.TxReadWriteSet_KV}
+	for _, ns := range namespaces {
+		txRWSet.NsRwset = append(txRWSet.NsRwset, &rwset.NsReadWriteSet{Namespace: ns})
+	}
+	simulationResults, err := proto.Marshal(txRWSet)
+	assert.NoError(t, err)
+	env, _, err := ptestutils.ConstructSingedTxEnv("testchain", &peer.ChaincodeID{Name: namespaces[0]}, nil,
+		simulationResults, txID, nil, nil, &mspSigner{mspID: mspID})
+	assert.NoError(t, err)
+	return env
+}
+
+func TestBlockIndexTxQueries(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testledger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+
+	blocks := testutil.ConstructTestBlocks(t, 1)
+	block1 := testutil.NewBlock([]*common.Envelope{
+		constructTxQueriesTestTx(t, "tx1", "Org1MSP", "mycc"),
+		constructTxQueriesTestTx(t, "tx2", "Org2MSP", "mycc", "othercc"),
+	}, 1, blocks[0].Header.Hash())
+	util.TxValidationFlags(block1.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER]).
+		SetFlag(1, peer.TxValidationCode_MVCC_READ_CONFLICT)
+	timeOfBlock2 := time.Now()
+	block2 := testutil.NewBlock([]*common.Envelope{
+		constructTxQueriesTestTx(t, "tx3", "Org1MSP", "othercc"),
+	}, 2, block1.Header.Hash())
+	blkfileMgrWrapper.addBlocks(append(blocks, block1, block2))
+
+	txIDsOf := func(txStatuses *peer.TransactionStatuses) []string {
+		txIDs := []string{}
+		for _, txStatus := range txStatuses.Transactions {
+			txIDs = append(txIDs, txStatus.TxId)
+		}
+		return txIDs
+	}
+
+	// Transaction statuses by block range
+	txStatuses, err := blkfileMgr.retrieveTxStatusesByBlockRange(1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []*peer.TransactionStatus{
+		{TxId: "tx1", BlockNumber: 1, TxNumber: 0, ValidationCode: peer.TxValidationCode_VALID},
+		{TxId: "tx2", BlockNumber: 1, TxNumber: 1, ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT},
+	}, txStatuses.Transactions)
+	txStatuses, err = blkfileMgr.retrieveTxStatusesByBlockRange(1, math.MaxUint64)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tx1", "tx2", "tx3"}, txIDsOf(txStatuses))
+	txStatuses, err = blkfileMgr.retrieveTxStatusesByBlockRange(3, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, txStatuses.Transactions)
+
+	// Block by timestamp
+	block, err := blkfileMgr.retrieveBlockByTimestamp(time.Unix(0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), block.Header.Number)
+	block, err = blkfileMgr.retrieveBlockByTimestamp(timeOfBlock2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), block.Header.Number)
+	_, err = blkfileMgr.retrieveBlockByTimestamp(time.Now().Add(time.Hour))
+	assert.Equal(t, blkstorage.ErrNotFoundInIndex, err)
+
+	// Transaction statuses by creator
+	txStatuses, err = blkfileMgr.retrieveTxStatusesByCreator("Org1MSP", 0, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tx1", "tx3"}, txIDsOf(txStatuses))
+	txStatuses, err = blkfileMgr.retrieveTxStatusesByCreator("Org1MSP", 2, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tx3"}, txIDsOf(txStatuses))
+	txStatuses, err = blkfileMgr.retrieveTxStatusesByCreator("Org2MSP", 0, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []*peer.TransactionStatus{
+		{TxId: "tx2", BlockNumber: 1, TxNumber: 1, ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT},
+	}, txStatuses.Transactions)
+	// MSP IDs that are a prefix of another one don't match it
+	txStatuses, err = blkfileMgr.retrieveTxStatusesByCreator("Org", 0, 2)
+	assert.NoError(t, err)
+	assert.Empty(t, txStatuses.Transactions)
+
+	// Transaction statuses by namespace
+	txStatuses, err = blkfileMgr.retrieveTxStatusesByNamespace("mycc", 0, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tx1", "tx2"}, txIDsOf(txStatuses))
+	txStatuses, err = blkfileMgr.retrieveTxStatusesByNamespace("othercc", 0, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tx2"}, txIDsOf(txStatuses))
+	txStatuses, err = blkfileMgr.retrieveTxStatusesByNamespace("othercc", 0, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"tx2", "tx3"}, txIDsOf(txStatuses))
+}
+
+func TestBlockTimeIsMonotonic(t *testing.T) {
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testledger")
+	defer blkfileMgrWrapper.close()
+	index := blkfileMgrWrapper.blockfileMgr.index.(*blockIndex)
+
+	indexBlock := func(blockNum uint64, seconds int64) {
+		metadata := &common.BlockMetadata{Metadata: make([][]byte, len(common.BlockMetadataIndex_name))}
+		metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = util.NewTxValidationFlags(1)
+		txOffsets := []*txindexInfo{{
+			txID:      fmt.Sprintf("tx%d", blockNum),
+			loc:       &locPointer{},
+			timestamp: &timestamp.Timestamp{Seconds: seconds},
+		}}
+		assert.NoError(t, index.indexBlock(&blockIdxInfo{blockNum: blockNum, flp: &fileLocPointer{}, txOffsets: txOffsets, metadata: metadata}))
+	}
+	// The first transaction of block 2 claims to be older than the one of block 1
+	indexBlock(0, 100)
+	indexBlock(1, 300)
+	indexBlock(2, 200)
+	indexBlock(3, 400)
+
+	blockNum, err := index.getBlockNumByTimestamp(time.Unix(150, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), blockNum)
+	blockNum, err = index.getBlockNumByTimestamp(time.Unix(300, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), blockNum)
+}
+
+func TestBlockIndexTxStatusesBackfill(t *testing.T) {
+	conf := NewConf(testPath(), 0)
+	ledgerid := "testledger"
+	// index the blocks without the indexes of transaction statuses, as a previous version did
+	env := newTestEnvSelectiveIndexing(t, conf, []blkstorage.IndexableAttr{
+		blkstorage.IndexableAttrBlockHash,
+		blkstorage.IndexableAttrBlockNum,
+		blkstorage.IndexableAttrTxID,
+	})
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, ledgerid)
+	blocks := testutil.ConstructTestBlocks(t, 1)
+	block1 := testutil.NewBlock([]*common.Envelope{
+		constructTxQueriesTestTx(t, "tx1", "Org1MSP", "mycc"),
+		constructTxQueriesTestTx(t, "tx2", "Org2MSP", "othercc"),
+	}, 1, blocks[0].Header.Hash())
+	blkfileMgrWrapper.addBlocks(append(blocks, block1))
+	blkfileMgrWrapper.close()
+	env.provider.Close()
+
+	// reopen the block store with all the indexes
+	env = newTestEnv(t, conf)
+	defer env.Cleanup()
+	blkfileMgrWrapper = newTestBlockfileWrapper(env, ledgerid)
+	defer blkfileMgrWrapper.close()
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+
+	incomplete, err := blkfileMgr.index.txStatusIndexesIncomplete()
+	assert.NoError(t, err)
+	assert.False(t, incomplete)
+	txStatuses, err := blkfileMgr.retrieveTxStatusesByBlockRange(1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []*peer.TransactionStatus{
+		{TxId: "tx1", BlockNumber: 1, TxNumber: 0, ValidationCode: peer.TxValidationCode_VALID},
+		{TxId: "tx2", BlockNumber: 1, TxNumber: 1, ValidationCode: peer.TxValidationCode_VALID},
+	}, txStatuses.Transactions)
+	txStatuses, err = blkfileMgr.retrieveTxStatusesByCreator("Org2MSP", 0, 1)
+	assert.NoError(t, err)
+	assert.Len(t, txStatuses.Transactions, 1)
+	assert.Equal(t, "tx2", txStatuses.Transactions[0].TxId)
+	block, err := blkfileMgr.retrieveBlockByTimestamp(time.Unix(0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), block.Header.Number)
+
+	// blocks added after the backfill are indexed as usual
+	block2 := testutil.NewBlock([]*common.Envelope{
+		constructTxQueriesTestTx(t, "tx3", "Org1MSP", "mycc"),
+	}, 2, block1.Header.Hash())
+	blkfileMgrWrapper.addBlocks([]*common.Block{block2})
+	txStatuses, err = blkfileMgr.retrieveTxStatusesByNamespace("mycc", 0, 2)
+	assert.NoError(t, err)
+	assert.Len(t, txStatuses.Transactions, 2)
+}
+
+func TestBlockIndexTxStatusesQueryLimit(t *testing.T) {
+	defer func(limit int) { txStatusesQueryLimit = limit }(txStatusesQueryLimit)
+	txStatusesQueryLimit = 2
+
+	env := newTestEnv(t, NewConf(testPath(), 0))
+	defer env.Cleanup()
+	blkfileMgrWrapper := newTestBlockfileWrapper(env, "testledger")
+	defer blkfileMgrWrapper.close()
+	blkfileMgr := blkfileMgrWrapper.blockfileMgr
+
+	blocks := testutil.ConstructTestBlocks(t, 1)
+	block1 := testutil.NewBlock([]*common.Envelope{
+		constructTxQueriesTestTx(t, "tx1", "Org1MSP", "mycc"),
+		constructTxQueriesTestTx(t, "tx2", "Org1MSP", "mycc"),
+		constructTxQueriesTestTx(t, "tx3", "Org1MSP", "mycc"),
+	}, 1, blocks[0].Header.Hash())
+	block2 := testutil.NewBlock([]*common.Envelope{
+		constructTxQueriesTestTx(t, "tx4", "Org1MSP", "mycc"),
+	}, 2, block1.Header.Hash())
+	block3 := testutil.NewBlock([]*common.Envelope{
+		constructTxQueriesTestTx(t, "tx5", "Org1MSP", "mycc"),
+	}, 3, block2.Header.Hash())
+	blkfileMgrWrapper.addBlocks(append(blocks, block1, block2, block3))
+
+	// the transactions of a block are never split across pages
+	txStatuses, err := blkfileMgr.retrieveTxStatusesByNamespace("mycc", 1, math.MaxUint64)
+	assert.NoError(t, err)
+	assert.Len(t, txStatuses.Transactions, 3)
+	assert.Equal(t, uint64(2), txStatuses.NextBlockNumber)
+
+	txStatuses, err = blkfileMgr.retrieveTxStatusesByNamespace("mycc", txStatuses.NextBlockNumber, math.MaxUint64)
+	assert.NoError(t, err)
+	assert.Len(t, txStatuses.Transactions, 2)
+	assert.Equal(t, uint64(0), txStatuses.NextBlockNumber)
+
+	// the end of the range completes the query
+	txStatuses, err = blkfileMgr.retrieveTxStatusesByBlockRange(1, 1)
+	assert.NoError(t, err)
+	assert.Len(t, txStatuses.Transactions, 3)
+	assert.Equal(t, uint64(0), txStatuses.NextBlockNumber)
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package fsblkstorage
 
 import (
+	"time"
+
 	"github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/common/ledger/blkstorage"
 	"github.com/hyperledger/fabric/common/ledger/util/leveldbhelper"
@@ -86,6 +88,26 @@ func (store *fsBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return store.fileMgr.retrieveTxValidationCodeByTxID(txID)
 }
 
+// RetrieveTxStatusesByBlockRange returns the statuses of the transactions of the blocks in [startNum, endNum]
+func (store *fsBlockStore) RetrieveTxStatusesByBlockRange(startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	return store.fileMgr.retrieveTxStatusesByBlockRange(startNum, endNum)
+}
+
+// RetrieveBlockByTimestamp returns the block with the earliest timestamp that is not before the given time
+func (store *fsBlockStore) RetrieveBlockByTimestamp(timestamp time.Time) (*common.Block, error) {
+	return store.fileMgr.retrieveBlockByTimestamp(timestamp)
+}
+
+// RetrieveTxStatusesByCreator returns the statuses of the transactions created by an identity of the given MSP
+func (store *fsBlockStore) RetrieveTxStatusesByCreator(mspID string, startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	return store.fileMgr.retrieveTxStatusesByCreator(mspID, startNum, endNum)
+}
+
+// RetrieveTxStatusesByNamespace returns the statuses of the transactions that read or wrote keys of the given namespace
+func (store *fsBlockStore) RetrieveTxStatusesByNamespace(namespace string, startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	return store.fileMgr.retrieveTxStatusesByNamespace(namespace, startNum, endNum)
+}
+
 // Shutdown shuts down the block store
 func (store *fsBlockStore) Shutdown() {
 	logger.Debugf("closing fs blockStore:%s", store.id)
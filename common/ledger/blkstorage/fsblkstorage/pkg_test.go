@@ -56,6 +56,10 @@ func newTestEnv(t testing.TB, conf *Conf) *testEnv {
 		blkstorage.IndexableAttrBlockNumTranNum,
 		blkstorage.IndexableAttrBlockTxID,
 		blkstorage.IndexableAttrTxValidationCode,
+		blkstorage.IndexableAttrBlockNumTxStatus,
+		blkstorage.IndexableAttrBlockTime,
+		blkstorage.IndexableAttrTxCreator,
+		blkstorage.IndexableAttrTxNamespace,
 	}
 	return newTestEnvSelectiveIndexing(t, conf, attrsToIndex)
 }
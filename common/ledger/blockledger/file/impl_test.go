@@ -22,6 +22,7 @@ import (
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	cl "github.com/hyperledger/fabric/common/ledger"
@@ -117,6 +118,22 @@ func (mbs *mockBlockStore) RetrieveTxValidationCodeByTxID(txID string) (peer.TxV
 	return mbs.txValidationCode, mbs.defaultError
 }
 
+func (mbs *mockBlockStore) RetrieveTxStatusesByBlockRange(startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	return nil, mbs.defaultError
+}
+
+func (mbs *mockBlockStore) RetrieveBlockByTimestamp(timestamp time.Time) (*cb.Block, error) {
+	return mbs.block, mbs.defaultError
+}
+
+func (mbs *mockBlockStore) RetrieveTxStatusesByCreator(mspID string, startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	return nil, mbs.defaultError
+}
+
+func (mbs *mockBlockStore) RetrieveTxStatusesByNamespace(namespace string, startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	return nil, mbs.defaultError
+}
+
 func (*mockBlockStore) Shutdown() {
 }
 
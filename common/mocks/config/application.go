@@ -9,7 +9,8 @@ package config
 import "github.com/hyperledger/fabric/common/channelconfig"
 
 type MockApplication struct {
-	CapabilitiesRv channelconfig.ApplicationCapabilities
+	CapabilitiesRv    channelconfig.ApplicationCapabilities
+	APIPolicyMapperRv channelconfig.PolicyMapper
 }
 
 func (m *MockApplication) Organizations() map[string]channelconfig.ApplicationOrg {
@@ -20,12 +21,19 @@ func (m *MockApplication) Capabilities() channelconfig.ApplicationCapabilities {
 	return m.CapabilitiesRv
 }
 
+func (m *MockApplication) APIPolicyMapper() channelconfig.PolicyMapper {
+	return m.APIPolicyMapperRv
+}
+
 type MockApplicationCapabilities struct {
 	SupportedRv                  error
 	ForbidDuplicateTXIdInBlockRv bool
 	ResourcesTreeRv              bool
 	PrivateChannelDataRv         bool
 	V1_1ValidationRv             bool
+	ACLsRv                       bool
+	ImplicitCollectionsRv        bool
+	SignaturePolicyExtensionsRv  bool
 }
 
 func (mac *MockApplicationCapabilities) Supported() error {
@@ -47,3 +55,15 @@ func (mac *MockApplicationCapabilities) PrivateChannelData() bool {
 func (mac *MockApplicationCapabilities) V1_1Validation() bool {
 	return mac.V1_1ValidationRv
 }
+
+func (mac *MockApplicationCapabilities) ACLs() bool {
+	return mac.ACLsRv
+}
+
+func (mac *MockApplicationCapabilities) ImplicitCollections() bool {
+	return mac.ImplicitCollectionsRv
+}
+
+func (mac *MockApplicationCapabilities) SignaturePolicyExtensions() bool {
+	return mac.SignaturePolicyExtensionsRv
+}
@@ -60,6 +60,10 @@ func (m *MockQueryExecutor) GetPrivateData(namespace, collection, key string) ([
 	return nil, nil
 }
 
+func (m *MockQueryExecutor) GetPrivateDataHash(namespace, collection, key string) ([]byte, error) {
+	return nil, nil
+}
+
 func (m *MockQueryExecutor) GetPrivateDataMultipleKeys(namespace, collection string, keys []string) ([][]byte, error) {
 	return nil, nil
 }
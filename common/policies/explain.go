@@ -0,0 +1,109 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policies
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+
+	logging "github.com/op/go-logging"
+)
+
+// EvaluationTrace is a structured account of the evaluation of a policy against a signature set
+type EvaluationTrace struct {
+	// Policy describes the evaluated policy
+	Policy string `json:"policy"`
+
+	// Satisfied is whether the signature set satisfied the policy
+	Satisfied bool `json:"satisfied"`
+
+	// Reason explains the outcome of the evaluation
+	Reason string `json:"reason,omitempty"`
+
+	// Discarded lists the signatures which were discarded before the evaluation,
+	// such as those of duplicated identities
+	Discarded []string `json:"discarded,omitempty"`
+
+	// Signatures lists the outcome of matching each signature against the principal of the policy.
+	// Unlike those of the discarded signatures, the indexes are those in the signature set left once
+	// the discarded signatures are removed
+	Signatures []string `json:"signatures,omitempty"`
+
+	// SubPolicies holds the traces of the evaluation of the sub-policies of the policy
+	SubPolicies []*EvaluationTrace `json:"sub_policies,omitempty"`
+}
+
+// Explainer is implemented by the policies which can explain their evaluation
+type Explainer interface {
+	// Explain evaluates the policy like Evaluate does, and returns the trace of the evaluation
+	Explain(signatureSet []*cb.SignedData) (*EvaluationTrace, error)
+}
+
+// Explain evaluates the policy against the signature set and returns the trace of the evaluation,
+// along with the outcome of Evaluate. Policies which cannot explain their evaluation are traced by
+// their outcome only
+func Explain(policy Policy, signatureSet []*cb.SignedData) (*EvaluationTrace, error) {
+	if explainer, ok := policy.(Explainer); ok {
+		return explainer.Explain(signatureSet)
+	}
+
+	err := policy.Evaluate(signatureSet)
+	trace := &EvaluationTrace{
+		Policy:    fmt.Sprintf("%T", policy),
+		Satisfied: err == nil,
+	}
+	if err != nil {
+		trace.Reason = err.Error()
+	}
+	return trace, err
+}
+
+// DebugExplain logs, at debug level of the given logger, the trace of the evaluation of the named policy
+// against the signature set. As the policy is evaluated again, this is meant to be called once the policy
+// was found not to be satisfied, and does nothing unless debug logging is enabled
+func DebugExplain(l *logging.Logger, policyName string, policy Policy, signatureSet []*cb.SignedData) {
+	if !l.IsEnabledFor(logging.DEBUG) {
+		return
+	}
+
+	trace, _ := Explain(policy, signatureSet)
+	l.Debugf("Evaluation of policy %s against %d signatures:\n%s", policyName, len(signatureSet), trace)
+}
+
+// String renders the trace as an indented tree
+func (et *EvaluationTrace) String() string {
+	var b bytes.Buffer
+	et.write(&b, 0)
+	return b.String()
+}
+
+func (et *EvaluationTrace) write(b *bytes.Buffer, depth int) {
+	indent := strings.Repeat("  ", depth)
+
+	outcome := "NOT SATISFIED"
+	if et.Satisfied {
+		outcome = "SATISFIED"
+	}
+	fmt.Fprintf(b, "%s%s: %s", indent, et.Policy, outcome)
+	if et.Reason != "" {
+		fmt.Fprintf(b, " (%s)", et.Reason)
+	}
+	b.WriteString("\n")
+
+	for _, discarded := range et.Discarded {
+		fmt.Fprintf(b, "%s  - discarded %s\n", indent, discarded)
+	}
+	for _, signature := range et.Signatures {
+		fmt.Fprintf(b, "%s  - %s\n", indent, signature)
+	}
+	for _, subPolicy := range et.SubPolicies {
+		subPolicy.write(b, depth+1)
+	}
+}
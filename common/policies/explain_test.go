@@ -0,0 +1,96 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policies
+
+import (
+	"testing"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExplainNonExplainer(t *testing.T) {
+	trace, err := Explain(acceptPolicy{}, nil)
+	assert.NoError(t, err)
+	assert.True(t, trace.Satisfied)
+	assert.Equal(t, "policies.acceptPolicy", trace.Policy)
+
+	trace, err = Explain(errorPolicy{}, nil)
+	assert.EqualError(t, err, "not satisfied")
+	assert.False(t, trace.Satisfied)
+	assert.Equal(t, "not satisfied", trace.Reason)
+}
+
+func TestExplainImplicitMeta(t *testing.T) {
+	imp, err := newImplicitMetaPolicy(utils.MarshalOrPanic(&cb.ImplicitMetaPolicy{
+		Rule:      cb.ImplicitMetaPolicy_MAJORITY,
+		SubPolicy: TestPolicyName,
+	}), makeManagers(3, 1))
+	assert.NoError(t, err)
+
+	trace, err := imp.Explain(nil)
+	assert.EqualError(t, err, "Failed to reach implicit threshold of 2 sub-policies, required 1 remaining")
+	assert.Equal(t, imp.Evaluate(nil).Error(), err.Error())
+	assert.False(t, trace.Satisfied)
+	assert.Equal(t, "ImplicitMeta(MAJORITY TestPolicyName)", trace.Policy)
+	assert.Equal(t, "1 of 3 sub-policies satisfied, 2 required", trace.Reason)
+
+	// unlike Evaluate, every sub-policy is accounted for
+	assert.Len(t, trace.SubPolicies, 3)
+	satisfied := 0
+	for _, subTrace := range trace.SubPolicies {
+		if subTrace.Satisfied {
+			satisfied++
+		} else {
+			assert.Equal(t, "No such policy: 'TestPolicyName'", subTrace.Reason)
+		}
+	}
+	assert.Equal(t, 1, satisfied)
+
+	imp, err = newImplicitMetaPolicy(utils.MarshalOrPanic(&cb.ImplicitMetaPolicy{
+		Rule:      cb.ImplicitMetaPolicy_ANY,
+		SubPolicy: TestPolicyName,
+	}), makeManagers(3, 1))
+	assert.NoError(t, err)
+
+	trace, err = imp.Explain(nil)
+	assert.NoError(t, err)
+	assert.True(t, trace.Satisfied)
+}
+
+func TestExplainPolicyLogger(t *testing.T) {
+	pl := &policyLogger{
+		policy:     errorPolicy{},
+		policyName: "/Channel/Foo",
+	}
+
+	trace, err := pl.Explain(nil)
+	assert.Error(t, err)
+	assert.Equal(t, "/Channel/Foo", trace.Policy)
+	assert.Equal(t, "policies.errorPolicy: not satisfied", trace.Reason)
+}
+
+func TestEvaluationTraceString(t *testing.T) {
+	trace := &EvaluationTrace{
+		Policy:    "OutOf(1)",
+		Reason:    "0 of 1 sub-policies satisfied, 1 required",
+		Discarded: []string{"signature 1"},
+		SubPolicies: []*EvaluationTrace{
+			{
+				Policy:     "SignedBy 'Org1.member'",
+				Signatures: []string{"signature 0"},
+			},
+		},
+	}
+
+	assert.Equal(t, "OutOf(1): NOT SATISFIED (0 of 1 sub-policies satisfied, 1 required)\n"+
+		"  - discarded signature 1\n"+
+		"  SignedBy 'Org1.member': NOT SATISFIED\n"+
+		"    - signature 0\n", trace.String())
+}
@@ -19,6 +19,7 @@ import (
 type implicitMetaPolicy struct {
 	threshold   int
 	subPolicies []Policy
+	rule        cb.ImplicitMetaPolicy_Rule
 
 	// Only used for logging
 	managers      map[string]*ManagerImpl
@@ -59,6 +60,7 @@ func newImplicitMetaPolicy(data []byte, managers map[string]*ManagerImpl) (*impl
 	return &implicitMetaPolicy{
 		subPolicies:   subPolicies,
 		threshold:     threshold,
+		rule:          definition.Rule,
 		managers:      managers,
 		subPolicyName: definition.SubPolicy,
 	}, nil
@@ -100,3 +102,51 @@ func (imp *implicitMetaPolicy) Evaluate(signatureSet []*cb.SignedData) error {
 	}
 	return fmt.Errorf("Failed to reach implicit threshold of %d sub-policies, required %d remaining", imp.threshold, remaining)
 }
+
+// EvaluateUsed evaluates the policy like Evaluate does, and returns the signatures
+// used by the sub-policies which were satisfied before the threshold was reached
+func (imp *implicitMetaPolicy) EvaluateUsed(signatureSet []*cb.SignedData) ([]bool, error) {
+	used := make([]bool, len(signatureSet))
+	remaining := imp.threshold
+	for _, policy := range imp.subPolicies {
+		if remaining == 0 {
+			break
+		}
+		subUsed, err := EvaluateUsed(policy, signatureSet)
+		if err != nil {
+			continue
+		}
+		remaining--
+		for i := range used {
+			used[i] = used[i] || subUsed[i]
+		}
+	}
+	if remaining != 0 {
+		return nil, fmt.Errorf("Failed to reach implicit threshold of %d sub-policies, required %d remaining", imp.threshold, remaining)
+	}
+	return used, nil
+}
+
+// Explain evaluates every sub-policy, rather than stopping once the threshold is reached,
+// so that the trace accounts for each of them
+func (imp *implicitMetaPolicy) Explain(signatureSet []*cb.SignedData) (*EvaluationTrace, error) {
+	trace := &EvaluationTrace{
+		Policy: fmt.Sprintf("ImplicitMeta(%s %s)", imp.rule, imp.subPolicyName),
+	}
+
+	satisfied := 0
+	for _, policy := range imp.subPolicies {
+		subTrace, err := Explain(policy, signatureSet)
+		if err == nil {
+			satisfied++
+		}
+		trace.SubPolicies = append(trace.SubPolicies, subTrace)
+	}
+
+	trace.Satisfied = satisfied >= imp.threshold
+	trace.Reason = fmt.Sprintf("%d of %d sub-policies satisfied, %d required", satisfied, len(imp.subPolicies), imp.threshold)
+	if !trace.Satisfied {
+		return trace, fmt.Errorf("Failed to reach implicit threshold of %d sub-policies, required %d remaining", imp.threshold, imp.threshold-satisfied)
+	}
+	return trace, nil
+}
@@ -158,6 +158,14 @@ func (rp rejectPolicy) Evaluate(signedData []*cb.SignedData) error {
 	return fmt.Errorf("No such policy: '%s'", rp)
 }
 
+func (rp rejectPolicy) Explain(signedData []*cb.SignedData) (*EvaluationTrace, error) {
+	err := rp.Evaluate(signedData)
+	return &EvaluationTrace{
+		Policy: string(rp),
+		Reason: err.Error(),
+	}, err
+}
+
 // Manager returns the sub-policy manager for a given path and whether it exists
 func (pm *ManagerImpl) Manager(path []string) (Manager, bool) {
 	logger.Debugf("Manager %s looking up path %v", pm.path, path)
@@ -196,6 +204,33 @@ func (pl *policyLogger) Evaluate(signatureSet []*cb.SignedData) error {
 	return err
 }
 
+func (pl *policyLogger) EvaluateUsed(signatureSet []*cb.SignedData) ([]bool, error) {
+	if logger.IsEnabledFor(logging.DEBUG) {
+		logger.Debugf("== Evaluating %T Policy %s ==", pl.policy, pl.policyName)
+		defer logger.Debugf("== Done Evaluating %T Policy %s", pl.policy, pl.policyName)
+	}
+
+	used, err := EvaluateUsed(pl.policy, signatureSet)
+	if err != nil {
+		logger.Debugf("Signature set did not satisfy policy %s", pl.policyName)
+	} else {
+		logger.Debugf("Signature set satisfies policy %s", pl.policyName)
+	}
+	return used, err
+}
+
+func (pl *policyLogger) Explain(signatureSet []*cb.SignedData) (*EvaluationTrace, error) {
+	trace, err := Explain(pl.policy, signatureSet)
+	// the name of the policy is more telling than its description
+	if trace.Reason == "" {
+		trace.Reason = trace.Policy
+	} else {
+		trace.Reason = trace.Policy + ": " + trace.Reason
+	}
+	trace.Policy = pl.policyName
+	return trace, err
+}
+
 // GetPolicy returns a policy and true if it was the policy requested, or false if it is the default reject policy
 func (pm *ManagerImpl) GetPolicy(id string) (Policy, bool) {
 	if id == "" {
@@ -0,0 +1,36 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policies
+
+import (
+	cb "github.com/hyperledger/fabric/protos/common"
+)
+
+// UsedSignaturesEvaluator is implemented by the policies which can report the signatures they were satisfied by
+type UsedSignaturesEvaluator interface {
+	// EvaluateUsed evaluates the policy like Evaluate does, and returns
+	// which signatures of the set were used to satisfy the policy
+	EvaluateUsed(signatureSet []*cb.SignedData) ([]bool, error)
+}
+
+// EvaluateUsed evaluates the policy against the signature set and returns which signatures were used
+// to satisfy it. The policies which cannot report the signatures they used are deemed to use every
+// signature of the set
+func EvaluateUsed(policy Policy, signatureSet []*cb.SignedData) ([]bool, error) {
+	if evaluator, ok := policy.(UsedSignaturesEvaluator); ok {
+		return evaluator.EvaluateUsed(signatureSet)
+	}
+
+	if err := policy.Evaluate(signatureSet); err != nil {
+		return nil, err
+	}
+	used := make([]bool, len(signatureSet))
+	for i := range used {
+		used[i] = true
+	}
+	return used, nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policies
+
+import (
+	"errors"
+	"testing"
+
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type errorPolicy struct{}
+
+func (ep errorPolicy) Evaluate(signedData []*cb.SignedData) error {
+	return errors.New("not satisfied")
+}
+
+// signaturePolicy is satisfied by the signature at its index
+type signaturePolicy int
+
+func (sp signaturePolicy) Evaluate(signedData []*cb.SignedData) error {
+	_, err := sp.EvaluateUsed(signedData)
+	return err
+}
+
+func (sp signaturePolicy) EvaluateUsed(signedData []*cb.SignedData) ([]bool, error) {
+	used := make([]bool, len(signedData))
+	used[sp] = true
+	return used, nil
+}
+
+func TestEvaluateUsedNonEvaluator(t *testing.T) {
+	used, err := EvaluateUsed(acceptPolicy{}, make([]*cb.SignedData, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, true}, used)
+
+	_, err = EvaluateUsed(errorPolicy{}, make([]*cb.SignedData, 2))
+	assert.EqualError(t, err, "not satisfied")
+}
+
+func TestEvaluateUsedImplicitMeta(t *testing.T) {
+	managers := map[string]*ManagerImpl{
+		"0": {policies: map[string]Policy{TestPolicyName: signaturePolicy(0)}},
+		"1": {policies: map[string]Policy{TestPolicyName: signaturePolicy(2)}},
+		"2": {policies: map[string]Policy{TestPolicyName: errorPolicy{}}},
+	}
+	imp, err := newImplicitMetaPolicy(utils.MarshalOrPanic(&cb.ImplicitMetaPolicy{
+		Rule:      cb.ImplicitMetaPolicy_MAJORITY,
+		SubPolicy: TestPolicyName,
+	}), managers)
+	assert.NoError(t, err)
+
+	used, err := imp.EvaluateUsed(make([]*cb.SignedData, 3))
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, true}, used)
+
+	imp, err = newImplicitMetaPolicy(utils.MarshalOrPanic(&cb.ImplicitMetaPolicy{
+		Rule:      cb.ImplicitMetaPolicy_ALL,
+		SubPolicy: TestPolicyName,
+	}), managers)
+	assert.NoError(t, err)
+
+	_, err = imp.EvaluateUsed(make([]*cb.SignedData, 3))
+	assert.EqualError(t, err, "Failed to reach implicit threshold of 3 sub-policies, required 1 remaining")
+}
+
+func TestEvaluateUsedPolicyLogger(t *testing.T) {
+	pl := &policyLogger{
+		policy:     signaturePolicy(1),
+		policyName: "/Channel/Foo",
+	}
+
+	used, err := EvaluateUsed(pl, make([]*cb.SignedData, 2))
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{false, true}, used)
+}
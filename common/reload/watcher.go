@@ -0,0 +1,156 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package reload
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/hyperledger/fabric/common/flogging"
+)
+
+var logger = flogging.MustGetLogger("reload")
+
+// Watcher watches a set of files and directories, and calls a reload function
+// whenever their contents change or whenever it is triggered. Changes are
+// detected by periodically fingerprinting the contents of the watched paths,
+// which, unlike file system notifications, also works for the symbolic links
+// swapped by secret stores and for mounted volumes
+type Watcher struct {
+	name     string
+	paths    []string
+	interval time.Duration
+	reload   func() error
+
+	fingerprint []byte
+	trigger     chan struct{}
+	stop        chan struct{}
+	stopOnce    sync.Once
+}
+
+// NewWatcher creates a Watcher of the supplied paths which calls reload at most
+// once per interval, once their contents change. The name describes what is
+// reloaded in the logs
+func NewWatcher(name string, paths []string, interval time.Duration, reload func() error) *Watcher {
+	return &Watcher{
+		name:     name,
+		paths:    paths,
+		interval: interval,
+		reload:   reload,
+		trigger:  make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start fingerprints the watched paths and starts watching them in the background.
+// A zero interval disables the watching, leaving Trigger as the only way to reload
+func (w *Watcher) Start() {
+	w.fingerprint = fingerprint(w.paths)
+	go w.run()
+}
+
+// Trigger requests a reload regardless of whether the watched paths changed
+func (w *Watcher) Trigger() {
+	select {
+	case w.trigger <- struct{}{}:
+	default:
+		// a reload is already pending
+	}
+}
+
+// Stop stops watching
+func (w *Watcher) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.stop)
+	})
+}
+
+func (w *Watcher) run() {
+	var tick <-chan time.Time
+	if w.interval > 0 {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-tick:
+			current := fingerprint(w.paths)
+			if bytes.Equal(current, w.fingerprint) {
+				continue
+			}
+			w.fingerprint = current
+			logger.Infof("Detected a change of the %s, reloading", w.name)
+			w.doReload()
+		case <-w.trigger:
+			w.fingerprint = fingerprint(w.paths)
+			logger.Infof("Reloading the %s on request", w.name)
+			w.doReload()
+		}
+	}
+}
+
+func (w *Watcher) doReload() {
+	if err := w.reload(); err != nil {
+		logger.Errorf("Failed reloading the %s, keeping the current ones: %s", w.name, err)
+		return
+	}
+	logger.Infof("Reloaded the %s", w.name)
+}
+
+// NotifyOnSignal triggers the supplied watchers whenever the process receives one of the signals
+func NotifyOnSignal(watchers []*Watcher, sig ...os.Signal) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, sig...)
+	go func() {
+		for s := range signals {
+			logger.Infof("Received %s, reloading", s)
+			for _, w := range watchers {
+				w.Trigger()
+			}
+		}
+	}()
+}
+
+// fingerprint hashes the names and contents of the files found at, or under, the supplied paths.
+// Files which cannot be read, including missing ones, are hashed by the error reading them
+func fingerprint(paths []string) []byte {
+	h := sha256.New()
+	for _, path := range paths {
+		err := filepath.Walk(path, func(name string, info os.FileInfo, err error) error {
+			h.Write([]byte(name))
+			if err != nil {
+				h.Write([]byte(err.Error()))
+				return nil
+			}
+			if info.IsDir() {
+				return nil
+			}
+			// symbolic links are followed, so that the contents of the files they point to are hashed
+			contents, err := ioutil.ReadFile(name)
+			if err != nil {
+				h.Write([]byte(err.Error()))
+				return nil
+			}
+			h.Write(contents)
+			return nil
+		})
+		if err != nil {
+			h.Write([]byte(err.Error()))
+		}
+	}
+	return h.Sum(nil)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package reload
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func waitForReload(t *testing.T, reloads <-chan struct{}) {
+	select {
+	case <-reloads:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for a reload")
+	}
+}
+
+func assertNoReload(t *testing.T, reloads <-chan struct{}) {
+	select {
+	case <-reloads:
+		t.Fatal("Reloaded although nothing changed")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reload")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "sub", "file")
+	missing := fingerprint([]string{dir, file})
+
+	assert.NoError(t, os.MkdirAll(filepath.Dir(file), 0755))
+	assert.NoError(t, ioutil.WriteFile(file, []byte("foo"), 0644))
+	foo := fingerprint([]string{dir, file})
+	assert.NotEqual(t, missing, foo)
+	assert.Equal(t, foo, fingerprint([]string{dir, file}))
+
+	assert.NoError(t, ioutil.WriteFile(file, []byte("bar"), 0644))
+	assert.NotEqual(t, foo, fingerprint([]string{dir, file}))
+
+	// links are followed
+	link := filepath.Join(dir, "link")
+	assert.NoError(t, os.Symlink(file, link))
+	linked := fingerprint([]string{link})
+	assert.NoError(t, ioutil.WriteFile(file, []byte("baz"), 0644))
+	assert.NotEqual(t, linked, fingerprint([]string{link}))
+}
+
+func TestWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "reload")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "file")
+	assert.NoError(t, ioutil.WriteFile(file, []byte("foo"), 0644))
+
+	reloads := make(chan struct{}, 10)
+	w := NewWatcher("test files", []string{dir}, 10*time.Millisecond, func() error {
+		reloads <- struct{}{}
+		return errors.New("ignored")
+	})
+	w.Start()
+	defer w.Stop()
+
+	assertNoReload(t, reloads)
+
+	assert.NoError(t, ioutil.WriteFile(file, []byte("bar"), 0644))
+	waitForReload(t, reloads)
+	// a failed reload is not retried until the next change
+	assertNoReload(t, reloads)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "other"), []byte("baz"), 0644))
+	waitForReload(t, reloads)
+
+	w.Trigger()
+	waitForReload(t, reloads)
+
+	w.Stop()
+	w.Stop()
+	assert.NoError(t, ioutil.WriteFile(file, []byte("qux"), 0644))
+	assertNoReload(t, reloads)
+}
+
+func TestWatcherWithoutInterval(t *testing.T) {
+	reloads := make(chan struct{}, 10)
+	w := NewWatcher("test files", nil, 0, func() error {
+		reloads <- struct{}{}
+		return nil
+	})
+	w.Start()
+	defer w.Stop()
+
+	NotifyOnSignal([]*Watcher{w}, syscall.SIGHUP)
+	assert.NoError(t, syscall.Kill(os.Getpid(), syscall.SIGHUP))
+	waitForReload(t, reloads)
+	assertNoReload(t, reloads)
+}
@@ -204,6 +204,10 @@ func NewApplicationGroup(conf *genesisconfig.Application) (*cb.ConfigGroup, erro
 		addValue(applicationGroup, channelconfig.CapabilitiesValue(conf.Capabilities), channelconfig.AdminsPolicyKey)
 	}
 
+	if len(conf.ACLs) > 0 {
+		addValue(applicationGroup, channelconfig.ACLsValue(conf.ACLs), channelconfig.AdminsPolicyKey)
+	}
+
 	for _, org := range conf.Organizations {
 		var err error
 		applicationGroup.Groups[org.Name], err = NewApplicationOrgGroup(org)
@@ -15,6 +15,7 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/localmsp"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/msp"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	cb "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
@@ -197,6 +198,19 @@ func TestNewApplicationGroup(t *testing.T) {
 		assert.NotNil(t, group)
 	})
 
+	t.Run("Application with ACLs", func(t *testing.T) {
+		config := genesisconfig.Load(genesisconfig.SampleSingleMSPChannelV11Profile)
+		config.Application.Capabilities = map[string]bool{capabilities.ApplicationV1_2: true}
+		config.Application.ACLs = map[string]string{"PROPOSE": "Writers"}
+		group, err := NewApplicationGroup(config.Application)
+		assert.NoError(t, err)
+		assert.Contains(t, group.Values, channelconfig.ACLsKey)
+
+		ac, err := channelconfig.NewApplicationConfig(group, channelconfig.NewMSPConfigHandler(msp.MSPv1_0))
+		assert.NoError(t, err)
+		assert.Equal(t, "/Channel/Application/Writers", ac.APIPolicyMapper().PolicyRefForAPI("PROPOSE"))
+	})
+
 	t.Run("Application unknown MSP", func(t *testing.T) {
 		config := genesisconfig.Load(genesisconfig.SampleSingleMSPChannelV11Profile)
 		config.Application.Organizations[0] = &genesisconfig.Organization{Name: "FakeOrg", ID: "FakeOrg"}
@@ -113,9 +113,10 @@ type Consortium struct {
 
 // Application encodes the application-level configuration needed in config transactions.
 type Application struct {
-	Organizations []*Organization `yaml:"Organizations"`
-	Capabilities  map[string]bool `yaml:"Capabilities"`
-	Resources     *Resources      `yaml:"Resources"`
+	Organizations []*Organization   `yaml:"Organizations"`
+	Capabilities  map[string]bool   `yaml:"Capabilities"`
+	Resources     *Resources        `yaml:"Resources"`
+	ACLs          map[string]string `yaml:"ACLs"`
 }
 
 // Resouces encodes the application-level resources configuration needed to seed the resource tree
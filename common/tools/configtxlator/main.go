@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -24,10 +25,13 @@ import (
 	"reflect"
 
 	"github.com/hyperledger/fabric/common/tools/configtxlator/metadata"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/policyexplain"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/rest"
 	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/updatereport"
 	"github.com/hyperledger/fabric/common/tools/protolator"
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/op/go-logging"
@@ -61,6 +65,17 @@ var (
 	computeUpdateChannelID = computeUpdate.Flag("channel_id", "The name of the channel for this update.").Required().String()
 	computeUpdateDest      = computeUpdate.Flag("output", "A file to write the JSON document to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
 
+	describeUpdate       = app.Command("describe_update", "Takes a marshaled common.Config message and a marshaled common.Envelope containing a config update, and reports the changes and signature status of the update.")
+	describeUpdateConfig = describeUpdate.Flag("config", "The current config message.").Required().File()
+	describeUpdateUpdate = describeUpdate.Flag("update", "The config update envelope.").Required().File()
+	describeUpdateDest   = describeUpdate.Flag("output", "A file to write the JSON report to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
+	explainPolicy         = app.Command("explain_policy", "Takes a marshaled common.Config message and a marshaled common.Envelope, and reports how the signatures of the envelope, or of the config update it contains, evaluate against a policy of the config.")
+	explainPolicyConfig   = explainPolicy.Flag("config", "The config message.").Required().File()
+	explainPolicyPath     = explainPolicy.Flag("policy", "The fully qualified path of the policy, or the name of a resource with an ACL in the config.  For example, '/Channel/Application/Admins' or 'peer/Propose'.").Required().String()
+	explainPolicyEnvelope = explainPolicy.Flag("envelope", "The signed envelope.").Required().File()
+	explainPolicyDest     = explainPolicy.Flag("output", "A file to write the JSON trace to.").Default(os.Stdout.Name()).OpenFile(os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+
 	version = app.Command("version", "Show version information")
 )
 
@@ -93,6 +108,22 @@ func main() {
 		if err != nil {
 			app.Fatalf("Error computing update: %s", err)
 		}
+	case describeUpdate.FullCommand():
+		defer (*describeUpdateConfig).Close()
+		defer (*describeUpdateUpdate).Close()
+		defer (*describeUpdateDest).Close()
+		err := describeUpdt(*describeUpdateConfig, *describeUpdateUpdate, *describeUpdateDest)
+		if err != nil {
+			app.Fatalf("Error describing update: %s", err)
+		}
+	case explainPolicy.FullCommand():
+		defer (*explainPolicyConfig).Close()
+		defer (*explainPolicyEnvelope).Close()
+		defer (*explainPolicyDest).Close()
+		err := explainPol(*explainPolicyConfig, *explainPolicyPath, *explainPolicyEnvelope, *explainPolicyDest)
+		if err != nil {
+			app.Fatalf("Error explaining policy: %s", err)
+		}
 	// "version" command
 	case version.FullCommand():
 		printVersion()
@@ -203,3 +234,89 @@ func computeUpdt(original, updated, output *os.File, channelID string) error {
 
 	return nil
 }
+
+func describeUpdt(config, updateEnvelope, output *os.File) error {
+	configIn, err := ioutil.ReadAll(config)
+	if err != nil {
+		return errors.Wrapf(err, "error reading config")
+	}
+
+	conf := &cb.Config{}
+	err = proto.Unmarshal(configIn, conf)
+	if err != nil {
+		return errors.Wrapf(err, "error unmarshaling config")
+	}
+
+	envIn, err := ioutil.ReadAll(updateEnvelope)
+	if err != nil {
+		return errors.Wrapf(err, "error reading config update envelope")
+	}
+
+	env, err := utils.UnmarshalEnvelope(envIn)
+	if err != nil {
+		return errors.Wrapf(err, "error unmarshaling envelope")
+	}
+
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{}
+	_, err = utils.UnmarshalEnvelopeOfType(env, cb.HeaderType_CONFIG_UPDATE, configUpdateEnv)
+	if err != nil {
+		return errors.Wrapf(err, "error extracting config update from envelope")
+	}
+
+	report, err := updatereport.Generate(conf, configUpdateEnv)
+	if err != nil {
+		return errors.Wrapf(err, "error generating report")
+	}
+
+	outBytes, err := json.MarshalIndent(report, "", "\t")
+	if err != nil {
+		return errors.Wrapf(err, "error marshaling report")
+	}
+
+	_, err = output.Write(append(outBytes, '\n'))
+	if err != nil {
+		return errors.Wrapf(err, "error writing report to output")
+	}
+
+	return nil
+}
+
+func explainPol(config *os.File, policyPath string, envelope, output *os.File) error {
+	configIn, err := ioutil.ReadAll(config)
+	if err != nil {
+		return errors.Wrapf(err, "error reading config")
+	}
+
+	conf := &cb.Config{}
+	err = proto.Unmarshal(configIn, conf)
+	if err != nil {
+		return errors.Wrapf(err, "error unmarshaling config")
+	}
+
+	envIn, err := ioutil.ReadAll(envelope)
+	if err != nil {
+		return errors.Wrapf(err, "error reading envelope")
+	}
+
+	env, err := utils.UnmarshalEnvelope(envIn)
+	if err != nil {
+		return errors.Wrapf(err, "error unmarshaling envelope")
+	}
+
+	trace, err := policyexplain.Explain(conf, policyPath, env)
+	if err != nil {
+		return errors.Wrapf(err, "error explaining policy")
+	}
+
+	outBytes, err := json.MarshalIndent(trace, "", "\t")
+	if err != nil {
+		return errors.Wrapf(err, "error marshaling trace")
+	}
+
+	_, err = output.Write(append(outBytes, '\n'))
+	if err != nil {
+		return errors.Wrapf(err, "error writing trace to output")
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policyexplain
+
+import (
+	"strings"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/policies"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// Explain evaluates the policy at the supplied fully qualified path of the channel config against
+// the signed data of the envelope, and returns the trace of the evaluation. The policy may also be
+// designated by the name of a resource of the peer, such as peer/Propose, in which case the policy
+// is the one the ACLs of the channel config map the resource to. The resources without an ACL in
+// the channel config are governed by the defaults of the peer, which cannot be explained.
+// The signed data of config update envelopes is that of the signatures of the config update,
+// while that of any other envelope is the signature of the envelope itself
+func Explain(config *cb.Config, policyPath string, env *cb.Envelope) (*policies.EvaluationTrace, error) {
+	if env == nil {
+		return nil, errors.New("nil envelope")
+	}
+
+	chdr, err := utils.ChannelHeader(env)
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting channel header")
+	}
+
+	bundle, err := channelconfig.NewBundle(chdr.ChannelId, config)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing config")
+	}
+
+	if !strings.HasPrefix(policyPath, "/") {
+		if policyPath, err = resourcePolicyPath(bundle, policyPath); err != nil {
+			return nil, err
+		}
+	}
+
+	policy, ok := bundle.PolicyManager().GetPolicy(policyPath)
+	if !ok {
+		return nil, errors.Errorf("policy %s not found", policyPath)
+	}
+
+	signedData, err := envelopeSignedData(env, chdr)
+	if err != nil {
+		return nil, err
+	}
+
+	// the outcome of the evaluation is part of the trace
+	trace, _ := policies.Explain(policy, signedData)
+	return trace, nil
+}
+
+// resourcePolicyPath returns the path of the policy the ACLs of the channel config map the resource to
+func resourcePolicyPath(bundle *channelconfig.Bundle, resource string) (string, error) {
+	ac, ok := bundle.ApplicationConfig()
+	if !ok {
+		return "", errors.Errorf("resource %s cannot be resolved without an application config", resource)
+	}
+	policyPath := ac.APIPolicyMapper().PolicyRefForAPI(resource)
+	if policyPath == "" {
+		return "", errors.Errorf("no ACL for resource %s in the channel config, its policy is the default of the peer", resource)
+	}
+	return policyPath, nil
+}
+
+func envelopeSignedData(env *cb.Envelope, chdr *cb.ChannelHeader) ([]*cb.SignedData, error) {
+	if chdr.Type != int32(cb.HeaderType_CONFIG_UPDATE) {
+		signedData, err := env.AsSignedData()
+		if err != nil {
+			return nil, errors.Wrap(err, "error extracting signature")
+		}
+		return signedData, nil
+	}
+
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling payload")
+	}
+
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{}
+	if err := proto.Unmarshal(payload.Data, configUpdateEnv); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling config update envelope")
+	}
+
+	signedData, err := configUpdateEnv.AsSignedData()
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting config update signatures")
+	}
+	return signedData, nil
+}
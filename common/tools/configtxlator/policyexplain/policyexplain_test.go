@@ -0,0 +1,118 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package policyexplain
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/common/util"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var singleMSPConfig *cb.Config
+
+func init() {
+	err := mspmgmt.LoadDevMsp()
+	if err != nil {
+		panic(err)
+	}
+
+	channelGroup, err := encoder.NewChannelGroup(genesisconfig.Load(genesisconfig.SampleSingleMSPSoloProfile))
+	if err != nil {
+		panic(err)
+	}
+	singleMSPConfig = &cb.Config{ChannelGroup: channelGroup}
+}
+
+func configUpdateEnvelope(t *testing.T, signed bool) *cb.Envelope {
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{
+		ConfigUpdate: utils.MarshalOrPanic(&cb.ConfigUpdate{ChannelId: "foo"}),
+	}
+
+	signer := localmsp.NewSigner()
+	if signed {
+		sigHeader, err := signer.NewSignatureHeader()
+		assert.NoError(t, err)
+		configSig := &cb.ConfigSignature{
+			SignatureHeader: utils.MarshalOrPanic(sigHeader),
+		}
+		configSig.Signature, err = signer.Sign(util.ConcatenateBytes(configSig.SignatureHeader, configUpdateEnv.ConfigUpdate))
+		assert.NoError(t, err)
+		configUpdateEnv.Signatures = []*cb.ConfigSignature{configSig}
+	}
+
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_CONFIG_UPDATE, "foo", signer, configUpdateEnv, 0, 0)
+	assert.NoError(t, err)
+	return env
+}
+
+func TestSignedConfigUpdate(t *testing.T) {
+	trace, err := Explain(singleMSPConfig, "/Channel/Orderer/Admins", configUpdateEnvelope(t, true))
+	assert.NoError(t, err)
+	assert.True(t, trace.Satisfied)
+	assert.Equal(t, "/Channel/Orderer/Admins", trace.Policy)
+	assert.Len(t, trace.SubPolicies, 1)
+	assert.True(t, trace.SubPolicies[0].Satisfied)
+}
+
+func TestUnsignedConfigUpdate(t *testing.T) {
+	// the signature of the envelope itself does not count for config updates
+	trace, err := Explain(singleMSPConfig, "/Channel/Orderer/Admins", configUpdateEnvelope(t, false))
+	assert.NoError(t, err)
+	assert.False(t, trace.Satisfied)
+	assert.Regexp(t, "0 of 1 sub-policies satisfied, 1 required", trace.Reason)
+	assert.Len(t, trace.SubPolicies, 1)
+	assert.False(t, trace.SubPolicies[0].Satisfied)
+	assert.Equal(t, "/Channel/Orderer/"+genesisconfig.SampleOrgName+"/Admins", trace.SubPolicies[0].Policy)
+}
+
+func TestEnvelope(t *testing.T) {
+	env, err := utils.CreateSignedEnvelope(cb.HeaderType_ENDORSER_TRANSACTION, "foo", localmsp.NewSigner(), &cb.ConfigEnvelope{}, 0, 0)
+	assert.NoError(t, err)
+
+	trace, err := Explain(singleMSPConfig, "/Channel/Writers", env)
+	assert.NoError(t, err)
+	assert.True(t, trace.Satisfied)
+}
+
+func TestResourceACL(t *testing.T) {
+	profile := genesisconfig.Load(genesisconfig.SampleSingleMSPSoloV11Profile)
+	profile.Application = genesisconfig.Load(genesisconfig.SampleSingleMSPChannelV11Profile).Application
+	profile.Application.Capabilities = map[string]bool{"V1_2": true}
+	profile.Application.ACLs = map[string]string{"peer/Propose": "Admins"}
+	channelGroup, err := encoder.NewChannelGroup(profile)
+	assert.NoError(t, err)
+	config := &cb.Config{ChannelGroup: channelGroup}
+
+	trace, err := Explain(config, "peer/Propose", configUpdateEnvelope(t, true))
+	assert.NoError(t, err)
+	assert.Equal(t, "/Channel/Application/Admins", trace.Policy)
+
+	_, err = Explain(config, "qscc/GetChainInfo", configUpdateEnvelope(t, true))
+	assert.EqualError(t, err, "no ACL for resource qscc/GetChainInfo in the channel config, its policy is the default of the peer")
+
+	_, err = Explain(singleMSPConfig, "peer/Propose", configUpdateEnvelope(t, true))
+	assert.EqualError(t, err, "resource peer/Propose cannot be resolved without an application config")
+}
+
+func TestExplainFailures(t *testing.T) {
+	_, err := Explain(singleMSPConfig, "/Channel/Orderer/Admins", nil)
+	assert.EqualError(t, err, "nil envelope")
+
+	_, err = Explain(singleMSPConfig, "/Channel/Missing", configUpdateEnvelope(t, true))
+	assert.EqualError(t, err, "policy /Channel/Missing not found")
+
+	_, err = Explain(singleMSPConfig, "/Channel/Orderer/Admins", &cb.Envelope{Payload: []byte("garbage")})
+	assert.Error(t, err)
+}
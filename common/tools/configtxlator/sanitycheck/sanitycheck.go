@@ -103,14 +103,22 @@ func checkPolicyPrincipals(group *cb.ConfigGroup, basePath string, mspMap map[st
 					continue
 				}
 				mspID = role.MspIdentifier
-			case mspprotos.MSPPrincipal_ORGANIZATION_UNIT:
+			case mspprotos.MSPPrincipal_ORGANIZATION_UNIT, mspprotos.MSPPrincipal_ORGANIZATION_UNIT_NAME:
 				ou := &mspprotos.OrganizationUnit{}
 				err = proto.Unmarshal(identity.Principal, ou)
 				if err != nil {
-					appendError(fmt.Sprintf("value of identities array at index %d is of type ORGANIZATION_UNIT, but could not be unmarshaled to msp.OrganizationUnit: %s", i, err))
+					appendError(fmt.Sprintf("value of identities array at index %d is of type %s, but could not be unmarshaled to msp.OrganizationUnit: %s", i, identity.PrincipalClassification, err))
 					continue
 				}
 				mspID = ou.MspIdentifier
+			case mspprotos.MSPPrincipal_IDENTITY_HASH:
+				idHash := &mspprotos.IdentityHash{}
+				err = proto.Unmarshal(identity.Principal, idHash)
+				if err != nil {
+					appendError(fmt.Sprintf("value of identities array at index %d is of type IDENTITY_HASH, but could not be unmarshaled to msp.IdentityHash: %s", i, err))
+					continue
+				}
+				mspID = idHash.MspIdentifier
 			default:
 				continue
 			}
@@ -0,0 +1,396 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package updatereport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/configtx"
+	"github.com/hyperledger/fabric/common/policies"
+	"github.com/hyperledger/fabric/common/tools/protolator"
+	cb "github.com/hyperledger/fabric/protos/common"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/pkg/errors"
+)
+
+// Report describes what a config update changes and which of the
+// policies governing those changes are satisfied by its signatures
+type Report struct {
+	ChannelID   string     `json:"channel_id"`
+	Signers     []*Signer  `json:"signers"`
+	Elements    []*Element `json:"elements"`
+	Policies    []*Policy  `json:"policies"`
+	Satisfied   bool       `json:"satisfied"`
+	PendingMSPs []string   `json:"pending_msps"`
+}
+
+// Signer describes a signature attached to the config update
+type Signer struct {
+	MSPID string `json:"msp_id"`
+	Valid bool   `json:"valid"`
+	Error string `json:"error,omitempty"`
+}
+
+// Element describes a single config element modified by the config update
+type Element struct {
+	Type             string          `json:"type"`
+	Path             string          `json:"path"`
+	OriginalVersion  *uint64         `json:"original_version,omitempty"`
+	UpdatedVersion   uint64          `json:"updated_version"`
+	ModPolicy        string          `json:"mod_policy,omitempty"`
+	UpdatedModPolicy string          `json:"updated_mod_policy"`
+	Original         json.RawMessage `json:"original,omitempty"`
+	Updated          json.RawMessage `json:"updated"`
+}
+
+// Policy describes the evaluation of a policy against the signatures of the config update
+type Policy struct {
+	Path        string    `json:"path"`
+	Type        string    `json:"type"`
+	Rule        string    `json:"rule,omitempty"`
+	Satisfied   bool      `json:"satisfied"`
+	Error       string    `json:"error,omitempty"`
+	SubPolicies []*Policy `json:"sub_policies,omitempty"`
+}
+
+// Generate produces a Report for a config update envelope against the supplied current channel config
+func Generate(config *cb.Config, configUpdateEnv *cb.ConfigUpdateEnvelope) (*Report, error) {
+	if configUpdateEnv == nil {
+		return nil, errors.New("nil config update envelope")
+	}
+
+	configUpdate, err := configtx.UnmarshalConfigUpdate(configUpdateEnv.ConfigUpdate)
+	if err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling config update")
+	}
+
+	bundle, err := channelconfig.NewBundle(configUpdate.ChannelId, config)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing current config")
+	}
+
+	validator, err := configtx.NewValidatorImpl(configUpdate.ChannelId, config, channelconfig.RootGroupKey, bundle.PolicyManager())
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating config validator")
+	}
+
+	updateElements, err := validator.DescribeUpdate(configUpdateEnv)
+	if err != nil {
+		return nil, errors.Wrap(err, "error describing config update")
+	}
+
+	signedData, err := configUpdateEnv.AsSignedData()
+	if err != nil {
+		return nil, errors.Wrap(err, "error extracting signatures")
+	}
+
+	report := &Report{
+		ChannelID: configUpdate.ChannelId,
+		Signers:   describeSigners(bundle, signedData),
+		Elements:  []*Element{},
+		Policies:  []*Policy{},
+		Satisfied: true,
+	}
+
+	evaluated := make(map[string]struct{})
+	pendingMSPs := make(map[string]struct{})
+	for _, updateElement := range updateElements {
+		element, err := newElement(updateElement)
+		if err != nil {
+			return nil, err
+		}
+		report.Elements = append(report.Elements, element)
+
+		if updateElement.ModPolicyPath == "" {
+			continue
+		}
+		if _, ok := evaluated[updateElement.ModPolicyPath]; ok {
+			continue
+		}
+		evaluated[updateElement.ModPolicyPath] = struct{}{}
+
+		policy := evaluatePolicy(config, bundle.PolicyManager(), updateElement.ModPolicyPath, signedData)
+		if !policy.Satisfied {
+			report.Satisfied = false
+			collectPendingMSPs(config, policy, pendingMSPs)
+		}
+		report.Policies = append(report.Policies, policy)
+	}
+
+	// MSPs which already signed the update are not waited for, even if
+	// their signature does not satisfy the policies on its own
+	for _, signer := range report.Signers {
+		if signer.Valid {
+			delete(pendingMSPs, signer.MSPID)
+		}
+	}
+
+	report.PendingMSPs = []string{}
+	for mspID := range pendingMSPs {
+		report.PendingMSPs = append(report.PendingMSPs, mspID)
+	}
+	sort.Strings(report.PendingMSPs)
+
+	return report, nil
+}
+
+func describeSigners(bundle *channelconfig.Bundle, signedData []*cb.SignedData) []*Signer {
+	signers := make([]*Signer, len(signedData))
+	for i, sd := range signedData {
+		signer := &Signer{}
+		signers[i] = signer
+
+		sID := &mspprotos.SerializedIdentity{}
+		if err := proto.Unmarshal(sd.Identity, sID); err != nil {
+			signer.Error = fmt.Sprintf("could not unmarshal signer identity: %s", err)
+			continue
+		}
+		signer.MSPID = sID.Mspid
+
+		identity, err := bundle.MSPManager().DeserializeIdentity(sd.Identity)
+		if err != nil {
+			signer.Error = fmt.Sprintf("could not deserialize signer identity: %s", err)
+			continue
+		}
+		if err := identity.Validate(); err != nil {
+			signer.Error = fmt.Sprintf("signer identity is not valid: %s", err)
+			continue
+		}
+		if err := identity.Verify(sd.Data, sd.Signature); err != nil {
+			signer.Error = fmt.Sprintf("signature did not verify: %s", err)
+			continue
+		}
+		signer.Valid = true
+	}
+	return signers
+}
+
+func newElement(updateElement *configtx.UpdateElement) (*Element, error) {
+	element := &Element{
+		Type: updateElement.Type,
+		Path: policies.PathSeparator + strings.Join(updateElement.Path, policies.PathSeparator),
+	}
+
+	var err error
+	element.UpdatedVersion, element.UpdatedModPolicy = versionAndModPolicy(updateElement.Updated)
+	element.Updated, err = renderElement(updateElement.Type, updateElement.Path, updateElement.Updated)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error rendering updated element %s", element.Path))
+	}
+
+	if updateElement.Original == nil {
+		return element, nil
+	}
+
+	originalVersion, _ := versionAndModPolicy(updateElement.Original)
+	element.OriginalVersion = &originalVersion
+	element.ModPolicy = updateElement.ModPolicy
+	element.Original, err = renderElement(updateElement.Type, updateElement.Path, updateElement.Original)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("error rendering original element %s", element.Path))
+	}
+
+	return element, nil
+}
+
+func versionAndModPolicy(msg proto.Message) (uint64, string) {
+	switch element := msg.(type) {
+	case *cb.ConfigGroup:
+		return element.Version, element.ModPolicy
+	case *cb.ConfigValue:
+		return element.Version, element.ModPolicy
+	case *cb.ConfigPolicy:
+		return element.Version, element.ModPolicy
+	}
+	return 0, ""
+}
+
+// renderElement encodes a config element as JSON via protolator.  Because the
+// type of opaque values depends on where they sit in the config tree, the
+// element is embedded into a skeleton config at its original path, encoded
+// as a whole, and extracted again from the result.  Groups are rendered
+// without their members, which are reported as elements of their own.
+func renderElement(elementType string, path []string, msg proto.Message) (json.RawMessage, error) {
+	if len(path) == 0 {
+		return nil, errors.New("empty element path")
+	}
+
+	groupPath := path[1:]
+	if elementType != configtx.GroupElement {
+		groupPath = path[1 : len(path)-1]
+	}
+
+	root := &cb.ConfigGroup{}
+	parent := root
+	for _, groupName := range groupPath {
+		child := &cb.ConfigGroup{}
+		parent.Groups = map[string]*cb.ConfigGroup{groupName: child}
+		parent = child
+	}
+
+	switch element := msg.(type) {
+	case *cb.ConfigGroup:
+		parent.Version = element.Version
+		parent.ModPolicy = element.ModPolicy
+	case *cb.ConfigValue:
+		parent.Values = map[string]*cb.ConfigValue{path[len(path)-1]: element}
+	case *cb.ConfigPolicy:
+		parent.Policies = map[string]*cb.ConfigPolicy{path[len(path)-1]: element}
+	}
+
+	buffer := &bytes.Buffer{}
+	if err := protolator.DeepMarshalJSON(buffer, &cb.Config{ChannelGroup: root}); err != nil {
+		return nil, err
+	}
+
+	current := json.RawMessage(buffer.Bytes())
+	fields := []string{"channel_group"}
+	for _, groupName := range groupPath {
+		fields = append(fields, "groups", groupName)
+	}
+	switch elementType {
+	case configtx.ValueElement:
+		fields = append(fields, "values", path[len(path)-1])
+	case configtx.PolicyElement:
+		fields = append(fields, "policies", path[len(path)-1])
+	}
+
+	for _, field := range fields {
+		object := map[string]json.RawMessage{}
+		if err := json.Unmarshal(current, &object); err != nil {
+			return nil, errors.Wrapf(err, "error decoding field %s", field)
+		}
+		var ok bool
+		current, ok = object[field]
+		if !ok {
+			return nil, errors.Errorf("missing field %s in rendered config", field)
+		}
+	}
+
+	return current, nil
+}
+
+// evaluatePolicy evaluates the policy at the supplied path, expanding implicit meta policies
+// into their sub-policies so that it is visible which of them still lack signatures
+func evaluatePolicy(config *cb.Config, pm policies.Manager, policyPath string, signedData []*cb.SignedData) *Policy {
+	result := &Policy{Path: policyPath}
+
+	policy, ok := pm.GetPolicy(policyPath)
+	if !ok {
+		result.Error = fmt.Sprintf("policy %s not found", policyPath)
+		return result
+	}
+
+	if err := policy.Evaluate(signedData); err != nil {
+		result.Error = err.Error()
+	} else {
+		result.Satisfied = true
+	}
+
+	group, configPolicy := lookupPolicy(config, policyPath)
+	if configPolicy == nil || configPolicy.Policy == nil {
+		return result
+	}
+
+	result.Type = cb.Policy_PolicyType(configPolicy.Policy.Type).String()
+	if configPolicy.Policy.Type != int32(cb.Policy_IMPLICIT_META) {
+		return result
+	}
+
+	implicitMeta := &cb.ImplicitMetaPolicy{}
+	if err := proto.Unmarshal(configPolicy.Policy.Value, implicitMeta); err != nil {
+		return result
+	}
+	result.Rule = fmt.Sprintf("%s %s", implicitMeta.Rule, implicitMeta.SubPolicy)
+
+	groupPath := policyPath[:strings.LastIndex(policyPath, policies.PathSeparator)]
+	subGroupNames := make([]string, 0, len(group.Groups))
+	for subGroupName := range group.Groups {
+		subGroupNames = append(subGroupNames, subGroupName)
+	}
+	sort.Strings(subGroupNames)
+
+	for _, subGroupName := range subGroupNames {
+		subPolicyPath := groupPath + policies.PathSeparator + subGroupName + policies.PathSeparator + implicitMeta.SubPolicy
+		result.SubPolicies = append(result.SubPolicies, evaluatePolicy(config, pm, subPolicyPath, signedData))
+	}
+
+	return result
+}
+
+// lookupPolicy returns the config policy at a fully qualified path along with its containing group
+func lookupPolicy(config *cb.Config, policyPath string) (*cb.ConfigGroup, *cb.ConfigPolicy) {
+	pathElements := strings.Split(strings.TrimPrefix(policyPath, policies.PathSeparator), policies.PathSeparator)
+	if len(pathElements) < 2 || pathElements[0] != channelconfig.RootGroupKey {
+		return nil, nil
+	}
+
+	group := config.ChannelGroup
+	for _, groupName := range pathElements[1 : len(pathElements)-1] {
+		var ok bool
+		group, ok = group.Groups[groupName]
+		if !ok {
+			return nil, nil
+		}
+	}
+
+	return group, group.Policies[pathElements[len(pathElements)-1]]
+}
+
+// collectPendingMSPs adds to the supplied set the MSP IDs referenced by the
+// unsatisfied signature policies underneath an unsatisfied policy
+func collectPendingMSPs(config *cb.Config, policy *Policy, pendingMSPs map[string]struct{}) {
+	if policy.Satisfied {
+		return
+	}
+
+	for _, subPolicy := range policy.SubPolicies {
+		collectPendingMSPs(config, subPolicy, pendingMSPs)
+	}
+
+	if policy.Type != cb.Policy_SIGNATURE.String() {
+		return
+	}
+
+	_, configPolicy := lookupPolicy(config, policy.Path)
+	sigPolicy := &cb.SignaturePolicyEnvelope{}
+	if err := proto.Unmarshal(configPolicy.Policy.Value, sigPolicy); err != nil {
+		return
+	}
+
+	for _, principal := range sigPolicy.Identities {
+		switch principal.PrincipalClassification {
+		case mspprotos.MSPPrincipal_ROLE:
+			role := &mspprotos.MSPRole{}
+			if err := proto.Unmarshal(principal.Principal, role); err == nil {
+				pendingMSPs[role.MspIdentifier] = struct{}{}
+			}
+		case mspprotos.MSPPrincipal_ORGANIZATION_UNIT, mspprotos.MSPPrincipal_ORGANIZATION_UNIT_NAME:
+			ou := &mspprotos.OrganizationUnit{}
+			if err := proto.Unmarshal(principal.Principal, ou); err == nil {
+				pendingMSPs[ou.MspIdentifier] = struct{}{}
+			}
+		case mspprotos.MSPPrincipal_IDENTITY:
+			sID := &mspprotos.SerializedIdentity{}
+			if err := proto.Unmarshal(principal.Principal, sID); err == nil {
+				pendingMSPs[sID.Mspid] = struct{}{}
+			}
+		case mspprotos.MSPPrincipal_IDENTITY_HASH:
+			idHash := &mspprotos.IdentityHash{}
+			if err := proto.Unmarshal(principal.Principal, idHash); err == nil {
+				pendingMSPs[idHash.MspIdentifier] = struct{}{}
+			}
+		}
+	}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package updatereport
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
+	"github.com/hyperledger/fabric/common/util"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/stretchr/testify/assert"
+)
+
+// sampleMSPID is the MSP ID of the sample org, which matches the dev MSP
+const sampleMSPID = "DEFAULT"
+
+var singleMSPConfig *cb.Config
+
+func init() {
+	err := mspmgmt.LoadDevMsp()
+	if err != nil {
+		panic(err)
+	}
+
+	channelGroup, err := encoder.NewChannelGroup(genesisconfig.Load(genesisconfig.SampleSingleMSPSoloProfile))
+	if err != nil {
+		panic(err)
+	}
+
+	// Round trip the config so that it is in the form it would be read from a block
+	singleMSPConfig = &cb.Config{}
+	err = proto.Unmarshal(utils.MarshalOrPanic(&cb.Config{ChannelGroup: channelGroup}), singleMSPConfig)
+	if err != nil {
+		panic(err)
+	}
+}
+
+func batchSizeUpdate(t *testing.T, signed bool) *cb.ConfigUpdateEnvelope {
+	updated := proto.Clone(singleMSPConfig).(*cb.Config)
+	updated.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Values[channelconfig.BatchSizeKey].Value = utils.MarshalOrPanic(&ab.BatchSize{
+		MaxMessageCount:   500,
+		AbsoluteMaxBytes:  1024 * 1024,
+		PreferredMaxBytes: 512 * 1024,
+	})
+
+	configUpdate, err := update.Compute(singleMSPConfig, updated)
+	assert.NoError(t, err)
+	configUpdate.ChannelId = "foo"
+
+	configUpdateEnv := &cb.ConfigUpdateEnvelope{
+		ConfigUpdate: utils.MarshalOrPanic(configUpdate),
+	}
+
+	if signed {
+		signer := localmsp.NewSigner()
+		sigHeader, err := signer.NewSignatureHeader()
+		assert.NoError(t, err)
+		configSig := &cb.ConfigSignature{
+			SignatureHeader: utils.MarshalOrPanic(sigHeader),
+		}
+		configSig.Signature, err = signer.Sign(util.ConcatenateBytes(configSig.SignatureHeader, configUpdateEnv.ConfigUpdate))
+		assert.NoError(t, err)
+		configUpdateEnv.Signatures = []*cb.ConfigSignature{configSig}
+	}
+
+	return configUpdateEnv
+}
+
+func TestUnsignedUpdate(t *testing.T) {
+	report, err := Generate(singleMSPConfig, batchSizeUpdate(t, false))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "foo", report.ChannelID)
+	assert.Empty(t, report.Signers)
+	assert.False(t, report.Satisfied)
+	assert.Equal(t, []string{sampleMSPID}, report.PendingMSPs)
+
+	assert.Len(t, report.Elements, 1)
+	element := report.Elements[0]
+	assert.Equal(t, "Value", element.Type)
+	assert.Equal(t, "/Channel/Orderer/BatchSize", element.Path)
+	assert.Equal(t, uint64(0), *element.OriginalVersion)
+	assert.Equal(t, uint64(1), element.UpdatedVersion)
+	assert.Equal(t, "Admins", element.ModPolicy)
+
+	original := &struct {
+		Value *ab.BatchSize `json:"value"`
+	}{}
+	assert.NoError(t, json.Unmarshal(element.Original, original))
+	assert.Equal(t, uint32(10), original.Value.MaxMessageCount)
+
+	updated := &struct {
+		Value *ab.BatchSize `json:"value"`
+	}{}
+	assert.NoError(t, json.Unmarshal(element.Updated, updated))
+	assert.Equal(t, uint32(500), updated.Value.MaxMessageCount)
+
+	assert.Len(t, report.Policies, 1)
+	policy := report.Policies[0]
+	assert.Equal(t, "/Channel/Orderer/Admins", policy.Path)
+	assert.Equal(t, "IMPLICIT_META", policy.Type)
+	assert.Equal(t, "MAJORITY Admins", policy.Rule)
+	assert.False(t, policy.Satisfied)
+	assert.Len(t, policy.SubPolicies, 1)
+	assert.Equal(t, "/Channel/Orderer/"+genesisconfig.SampleOrgName+"/Admins", policy.SubPolicies[0].Path)
+	assert.Equal(t, "SIGNATURE", policy.SubPolicies[0].Type)
+	assert.False(t, policy.SubPolicies[0].Satisfied)
+}
+
+func TestSignedUpdate(t *testing.T) {
+	report, err := Generate(singleMSPConfig, batchSizeUpdate(t, true))
+	assert.NoError(t, err)
+
+	assert.Len(t, report.Signers, 1)
+	assert.Equal(t, sampleMSPID, report.Signers[0].MSPID)
+	assert.True(t, report.Signers[0].Valid)
+	assert.True(t, report.Satisfied)
+	assert.Empty(t, report.PendingMSPs)
+	assert.True(t, report.Policies[0].Satisfied)
+	assert.True(t, report.Policies[0].SubPolicies[0].Satisfied)
+}
+
+func TestPartiallySignedUpdate(t *testing.T) {
+	// Require the signature of a second org along with the one of the sample org
+	sigPolicy, err := cauthdsl.FromString("AND('DEFAULT.member', 'OtherMSP.member')")
+	assert.NoError(t, err)
+	config := proto.Clone(singleMSPConfig).(*cb.Config)
+	orgAdmins := config.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Groups[genesisconfig.SampleOrgName].Policies[channelconfig.AdminsPolicyKey]
+	orgAdmins.Policy.Value = utils.MarshalOrPanic(sigPolicy)
+
+	report, err := Generate(config, batchSizeUpdate(t, true))
+	assert.NoError(t, err)
+	assert.True(t, report.Signers[0].Valid)
+	assert.False(t, report.Satisfied)
+	assert.Equal(t, []string{"OtherMSP"}, report.PendingMSPs)
+}
+
+func TestBadSignature(t *testing.T) {
+	configUpdateEnv := batchSizeUpdate(t, true)
+	configUpdateEnv.Signatures[0].Signature = []byte("garbage")
+
+	report, err := Generate(singleMSPConfig, configUpdateEnv)
+	assert.NoError(t, err)
+	assert.False(t, report.Signers[0].Valid)
+	assert.Regexp(t, "signature did not verify", report.Signers[0].Error)
+	assert.False(t, report.Satisfied)
+}
+
+func TestNewGroup(t *testing.T) {
+	updated := proto.Clone(singleMSPConfig).(*cb.Config)
+	updated.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Groups["NewOrg"] = &cb.ConfigGroup{
+		ModPolicy: "Admins",
+		Values: map[string]*cb.ConfigValue{
+			channelconfig.MSPKey: updated.ChannelGroup.Groups[channelconfig.OrdererGroupKey].Groups[genesisconfig.SampleOrgName].Values[channelconfig.MSPKey],
+		},
+	}
+
+	configUpdate, err := update.Compute(singleMSPConfig, updated)
+	assert.NoError(t, err)
+	configUpdate.ChannelId = "foo"
+
+	report, err := Generate(singleMSPConfig, &cb.ConfigUpdateEnvelope{ConfigUpdate: utils.MarshalOrPanic(configUpdate)})
+	assert.NoError(t, err)
+
+	paths := []string{}
+	for _, element := range report.Elements {
+		paths = append(paths, element.Path)
+	}
+	assert.Equal(t, []string{"/Channel/Orderer", "/Channel/Orderer/NewOrg", "/Channel/Orderer/NewOrg/MSP"}, paths)
+	assert.Nil(t, report.Elements[1].OriginalVersion)
+	assert.Empty(t, report.Elements[1].ModPolicy)
+	assert.JSONEq(t, `{"mod_policy":"Admins","version":"1"}`, string(report.Elements[0].Updated))
+	assert.Len(t, report.Policies, 1)
+	assert.Equal(t, "/Channel/Orderer/Admins", report.Policies[0].Path)
+}
+
+func TestGenerateFailures(t *testing.T) {
+	t.Run("NilEnvelope", func(t *testing.T) {
+		_, err := Generate(singleMSPConfig, nil)
+		assert.EqualError(t, err, "nil config update envelope")
+	})
+
+	t.Run("BadConfigUpdate", func(t *testing.T) {
+		_, err := Generate(singleMSPConfig, &cb.ConfigUpdateEnvelope{ConfigUpdate: []byte("garbage")})
+		assert.Regexp(t, "error unmarshaling config update", err.Error())
+	})
+
+	t.Run("BadConfig", func(t *testing.T) {
+		_, err := Generate(&cb.Config{}, batchSizeUpdate(t, false))
+		assert.Regexp(t, "error parsing current config", err.Error())
+	})
+
+	t.Run("StaleUpdate", func(t *testing.T) {
+		configUpdateEnv := batchSizeUpdate(t, false)
+		configUpdate := &cb.ConfigUpdate{}
+		assert.NoError(t, proto.Unmarshal(configUpdateEnv.ConfigUpdate, configUpdate))
+		configUpdate.ReadSet.Version = 3
+		configUpdateEnv.ConfigUpdate = utils.MarshalOrPanic(configUpdate)
+
+		_, err := Generate(singleMSPConfig, configUpdateEnv)
+		assert.Regexp(t, "error describing config update", err.Error())
+	})
+}
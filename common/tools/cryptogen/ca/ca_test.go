@@ -49,7 +49,7 @@ func TestLoadCertificateECDSA(t *testing.T) {
 	assert.NotNil(t, ecPubKey, "Failed to generate signed certificate")
 
 	// create our CA
-	rootCA, err := ca.NewCA(caDir, testCA3Name, testCA3Name, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	rootCA, err := ca.NewCA(caDir, testCA3Name, testCA3Name, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, "")
 	assert.NoError(t, err, "Error generating CA")
 
 	cert, err := rootCA.SignCertificate(certDir, testName3, nil, nil, ecPubKey,
@@ -71,7 +71,7 @@ func TestLoadCertificateECDSA(t *testing.T) {
 func TestNewCA(t *testing.T) {
 
 	caDir := filepath.Join(testDir, "ca")
-	rootCA, err := ca.NewCA(caDir, testCAName, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	rootCA, err := ca.NewCA(caDir, testCAName, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, "")
 	assert.NoError(t, err, "Error generating CA")
 	assert.NotNil(t, rootCA, "Failed to return CA")
 	assert.NotNil(t, rootCA.Signer,
@@ -115,7 +115,7 @@ func TestGenerateSignCertificate(t *testing.T) {
 	assert.NotNil(t, ecPubKey, "Failed to generate signed certificate")
 
 	// create our CA
-	rootCA, err := ca.NewCA(caDir, testCA2Name, testCA2Name, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	rootCA, err := ca.NewCA(caDir, testCA2Name, testCA2Name, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, "")
 	assert.NoError(t, err, "Error generating CA")
 
 	cert, err := rootCA.SignCertificate(certDir, testName, nil, nil, ecPubKey,
@@ -167,6 +167,82 @@ func TestGenerateSignCertificate(t *testing.T) {
 
 }
 
+func TestNewIntermediateCA(t *testing.T) {
+	rootCA, err := ca.NewCA(filepath.Join(testDir, "ca"), testCAName, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, "")
+	assert.NoError(t, err, "Error generating CA")
+
+	icaDir := filepath.Join(testDir, "ica")
+	intermediateCA, err := ca.NewIntermediateCA(icaDir, testCA2Name, testCA2Name, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, rootCA)
+	assert.NoError(t, err, "Error generating intermediate CA")
+	assert.True(t, intermediateCA.SignCert.IsCA)
+	assert.Equal(t, rootCA.SignCert.Subject.CommonName, intermediateCA.SignCert.Issuer.CommonName)
+	assert.NoError(t, intermediateCA.SignCert.CheckSignatureFrom(rootCA.SignCert))
+	assert.True(t, checkForFile(filepath.Join(icaDir, testCA2Name+"-cert.pem")))
+
+	assert.Equal(t, rootCA, intermediateCA.Root())
+	assert.Equal(t, rootCA, rootCA.Root())
+	assert.Equal(t, []*ca.CA{intermediateCA}, intermediateCA.Intermediates())
+	assert.Empty(t, rootCA.Intermediates())
+
+	// certificates issued by the intermediate CA chain up to the root
+	priv, _, err := csp.GeneratePrivateKey(filepath.Join(testDir, "certs"))
+	assert.NoError(t, err)
+	ecPubKey, err := csp.GetECPublicKey(priv)
+	assert.NoError(t, err)
+	cert, err := intermediateCA.SignCertificate(filepath.Join(testDir, "certs"), testName, nil, nil, ecPubKey,
+		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{x509.ExtKeyUsageAny})
+	assert.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(rootCA.SignCert)
+	intermediates := x509.NewCertPool()
+	intermediates.AddCert(intermediateCA.SignCert)
+	chains, err := cert.Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}})
+	assert.NoError(t, err)
+	assert.Len(t, chains, 1)
+
+	cleanup(testDir)
+}
+
+func TestCrossSign(t *testing.T) {
+	oldCA, err := ca.NewCA(filepath.Join(testDir, "oldca"), testCAName, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, "")
+	assert.NoError(t, err, "Error generating CA")
+	newCA, err := ca.NewCA(filepath.Join(testDir, "newca"), testCAName, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, "")
+	assert.NoError(t, err, "Error generating CA")
+
+	crossDir := filepath.Join(testDir, "cross")
+	crossCert, err := oldCA.CrossSign(crossDir, newCA)
+	assert.NoError(t, err, "Error cross-signing CA")
+	assert.True(t, checkForFile(filepath.Join(crossDir, testCAName+"-cert.pem")))
+	assert.Equal(t, newCA.SignCert.RawSubject, crossCert.RawSubject)
+	assert.Equal(t, newCA.SignCert.SubjectKeyId, crossCert.SubjectKeyId)
+	assert.Equal(t, newCA.SignCert.PublicKey, crossCert.PublicKey)
+	assert.NoError(t, crossCert.CheckSignatureFrom(oldCA.SignCert))
+
+	// certificates issued by the new CA are valid for those who only trust the old CA
+	priv, _, err := csp.GeneratePrivateKey(filepath.Join(testDir, "certs"))
+	assert.NoError(t, err)
+	ecPubKey, err := csp.GetECPublicKey(priv)
+	assert.NoError(t, err)
+	cert, err := newCA.SignCertificate(filepath.Join(testDir, "certs"), testName, nil, nil, ecPubKey,
+		x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{x509.ExtKeyUsageAny})
+	assert.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(oldCA.SignCert)
+	intermediates := x509.NewCertPool()
+	intermediates.AddCert(crossCert)
+	chains, err := cert.Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny}})
+	assert.NoError(t, err)
+	assert.Len(t, chains, 1)
+
+	badCA := &ca.CA{Name: "badCA", SignCert: &x509.Certificate{}}
+	_, err = oldCA.CrossSign(crossDir, badCA)
+	assert.Error(t, err, "Non ECDSA CA should not be cross-signed")
+
+	cleanup(testDir)
+}
+
 func cleanup(dir string) {
 	os.RemoveAll(dir)
 }
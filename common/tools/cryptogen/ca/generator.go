@@ -7,7 +7,6 @@ package ca
 
 import (
 	"crypto"
-	"crypto/ecdsa"
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
@@ -22,6 +21,7 @@ import (
 
 	"github.com/hyperledger/fabric/bccsp/utils"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/csp"
+	"github.com/pkg/errors"
 )
 
 type CA struct {
@@ -35,22 +35,28 @@ type CA struct {
 	//SignKey  *ecdsa.PrivateKey
 	Signer   crypto.Signer
 	SignCert *x509.Certificate
+	// Parent is the CA which issued SignCert, or nil if SignCert is self-signed
+	Parent *CA
+	// KeyAlgorithm is the algorithm of the keys generated for the CA and
+	// the identities it certifies
+	KeyAlgorithm string
 }
 
 // NewCA creates an instance of CA and saves the signing key pair in
-// baseDir/name
-func NewCA(baseDir, org, name, country, province, locality, orgUnit, streetAddress, postalCode string) (*CA, error) {
+// baseDir/name.  The key pair is generated with keyAlgorithm, which
+// defaults to ECDSA if empty.
+func NewCA(baseDir, org, name, country, province, locality, orgUnit, streetAddress, postalCode, keyAlgorithm string) (*CA, error) {
 
 	var response error
 	var ca *CA
 
 	err := os.MkdirAll(baseDir, 0755)
 	if err == nil {
-		priv, signer, err := csp.GeneratePrivateKey(baseDir)
+		priv, signer, err := csp.GeneratePrivateKeyWithAlgorithm(baseDir, keyAlgorithm)
 		response = err
 		if err == nil {
 			// get public signing certificate
-			ecPubKey, err := csp.GetECPublicKey(priv)
+			pubKey, err := csp.GetPublicKey(priv)
 			response = err
 			if err == nil {
 				template := x509Template()
@@ -69,8 +75,8 @@ func NewCA(baseDir, org, name, country, province, locality, orgUnit, streetAddre
 				template.Subject = subject
 				template.SubjectKeyId = priv.SKI()
 
-				x509Cert, err := genCertificateECDSA(baseDir, name, &template, &template,
-					ecPubKey, signer)
+				x509Cert, err := genCertificate(baseDir, name, &template, &template,
+					pubKey, signer)
 				response = err
 				if err == nil {
 					ca = &CA{
@@ -83,6 +89,7 @@ func NewCA(baseDir, org, name, country, province, locality, orgUnit, streetAddre
 						OrganizationalUnit: orgUnit,
 						StreetAddress:      streetAddress,
 						PostalCode:         postalCode,
+						KeyAlgorithm:       keyAlgorithm,
 					}
 				}
 			}
@@ -91,9 +98,108 @@ func NewCA(baseDir, org, name, country, province, locality, orgUnit, streetAddre
 	return ca, response
 }
 
+// NewIntermediateCA creates an instance of CA whose certificate is issued by
+// parent, and saves the signing key pair in baseDir/name.  The key pair is
+// generated with the key algorithm of parent.
+func NewIntermediateCA(baseDir, org, name, country, province, locality, orgUnit, streetAddress, postalCode string, parent *CA) (*CA, error) {
+	err := os.MkdirAll(baseDir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	priv, signer, err := csp.GeneratePrivateKeyWithAlgorithm(baseDir, parent.KeyAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, err := csp.GetPublicKey(priv)
+	if err != nil {
+		return nil, err
+	}
+
+	template := x509Template()
+	template.IsCA = true
+	template.KeyUsage |= x509.KeyUsageDigitalSignature |
+		x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign |
+		x509.KeyUsageCRLSign
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageAny}
+
+	subject := subjectTemplateAdditional(country, province, locality, orgUnit, streetAddress, postalCode)
+	subject.Organization = []string{org}
+	subject.CommonName = name
+
+	template.Subject = subject
+	template.SubjectKeyId = priv.SKI()
+
+	x509Cert, err := genCertificate(baseDir, name, &template, parent.SignCert,
+		pubKey, parent.Signer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CA{
+		Name:               name,
+		Signer:             signer,
+		SignCert:           x509Cert,
+		Country:            country,
+		Province:           province,
+		Locality:           locality,
+		OrganizationalUnit: orgUnit,
+		StreetAddress:      streetAddress,
+		PostalCode:         postalCode,
+		Parent:             parent,
+		KeyAlgorithm:       parent.KeyAlgorithm,
+	}, nil
+}
+
+// CrossSign creates a certificate for the subject and public key of other's
+// certificate, issued by ca, and saves it in baseDir/other.Name.  The
+// resulting certificate allows identities issued by other to be validated
+// by those who only trust ca.
+func (ca *CA) CrossSign(baseDir string, other *CA) (*x509.Certificate, error) {
+	_, err := csp.PublicKeyAlgorithm(other.SignCert.PublicKey)
+	if err != nil {
+		return nil, errors.WithMessage(err, "CA certificate cannot be cross-signed")
+	}
+
+	err = os.MkdirAll(baseDir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	template := x509Template()
+	template.IsCA = true
+	template.KeyUsage = other.SignCert.KeyUsage
+	template.ExtKeyUsage = other.SignCert.ExtKeyUsage
+	template.Subject = other.SignCert.Subject
+	template.RawSubject = other.SignCert.RawSubject
+	template.SubjectKeyId = other.SignCert.SubjectKeyId
+
+	return genCertificate(baseDir, other.Name, &template, ca.SignCert, other.SignCert.PublicKey, ca.Signer)
+}
+
+// Root returns the self-signed CA at the top of the chain of ca
+func (ca *CA) Root() *CA {
+	root := ca
+	for root.Parent != nil {
+		root = root.Parent
+	}
+	return root
+}
+
+// Intermediates returns the chain of intermediate CAs from ca up to, but
+// excluding, its root
+func (ca *CA) Intermediates() []*CA {
+	var intermediates []*CA
+	for current := ca; current.Parent != nil; current = current.Parent {
+		intermediates = append(intermediates, current)
+	}
+	return intermediates
+}
+
 // SignCertificate creates a signed certificate based on a built-in template
 // and saves it in baseDir/name
-func (ca *CA) SignCertificate(baseDir, name string, ous, sans []string, pub *ecdsa.PublicKey,
+func (ca *CA) SignCertificate(baseDir, name string, ous, sans []string, pub crypto.PublicKey,
 	ku x509.KeyUsage, eku []x509.ExtKeyUsage) (*x509.Certificate, error) {
 
 	template := x509Template()
@@ -117,7 +223,7 @@ func (ca *CA) SignCertificate(baseDir, name string, ous, sans []string, pub *ecd
 		}
 	}
 
-	cert, err := genCertificateECDSA(baseDir, name, &template, ca.SignCert,
+	cert, err := genCertificate(baseDir, name, &template, ca.SignCert,
 		pub, ca.Signer)
 
 	if err != nil {
@@ -184,12 +290,13 @@ func x509Template() x509.Certificate {
 
 }
 
-// generate a signed X509 certificate using ECDSA
-func genCertificateECDSA(baseDir, name string, template, parent *x509.Certificate, pub *ecdsa.PublicKey,
+// generate a signed X509 certificate for pub, which is either an ECDSA
+// (including secp256k1) or an Ed25519 public key
+func genCertificate(baseDir, name string, template, parent *x509.Certificate, pub crypto.PublicKey,
 	priv interface{}) (*x509.Certificate, error) {
 
 	//create the x509 public cert
-	certBytes, err := x509.CreateCertificate(rand.Reader, template, parent, pub, priv)
+	certBytes, err := utils.CreateX509Certificate(rand.Reader, template, parent, pub, priv)
 	if err != nil {
 		return nil, err
 	}
@@ -207,7 +314,7 @@ func genCertificateECDSA(baseDir, name string, template, parent *x509.Certificat
 		return nil, err
 	}
 
-	x509Cert, err := x509.ParseCertificate(certBytes)
+	x509Cert, err := utils.DERToX509Certificate(certBytes)
 	if err != nil {
 		return nil, err
 	}
@@ -8,7 +8,7 @@ package csp
 import (
 	"crypto"
 	"crypto/ecdsa"
-	"crypto/x509"
+	"crypto/ed25519"
 	"encoding/pem"
 	"io/ioutil"
 	"os"
@@ -18,8 +18,57 @@ import (
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/bccsp/signer"
+	"github.com/hyperledger/fabric/bccsp/utils"
+	"github.com/pkg/errors"
 )
 
+// Key algorithms which can be used for the keys generated by cryptogen
+const (
+	ECDSA     = "ECDSA"
+	ED25519   = "ED25519"
+	SECP256K1 = "SECP256K1"
+)
+
+// KeyGenOpts returns the options to generate a key for the given algorithm.
+// An empty algorithm selects ECDSA.
+func KeyGenOpts(algorithm string, temporary bool) (bccsp.KeyGenOpts, error) {
+	switch strings.ToUpper(algorithm) {
+	case "", ECDSA:
+		return &bccsp.ECDSAP256KeyGenOpts{Temporary: temporary}, nil
+	case ED25519:
+		return &bccsp.ED25519KeyGenOpts{Temporary: temporary}, nil
+	case SECP256K1:
+		return &bccsp.ECDSASecp256k1KeyGenOpts{Temporary: temporary}, nil
+	default:
+		return nil, errors.Errorf("unknown key algorithm %s, supported algorithms are [%s, %s, %s]", algorithm, ECDSA, ED25519, SECP256K1)
+	}
+}
+
+// TLSKeyAlgorithm returns the algorithm of the TLS keys of an org whose
+// identities use algorithm. TLS does not support secp256k1, so orgs using
+// it get ECDSA TLS keys.
+func TLSKeyAlgorithm(algorithm string) string {
+	if strings.ToUpper(algorithm) == SECP256K1 {
+		return ECDSA
+	}
+	return algorithm
+}
+
+// PublicKeyAlgorithm returns the key algorithm of the given public key
+func PublicKeyAlgorithm(pub crypto.PublicKey) (string, error) {
+	switch k := pub.(type) {
+	case *ecdsa.PublicKey:
+		if k.Curve == utils.Secp256k1() {
+			return SECP256K1, nil
+		}
+		return ECDSA, nil
+	case ed25519.PublicKey:
+		return ED25519, nil
+	default:
+		return "", errors.Errorf("unsupported public key type %T", pub)
+	}
+}
+
 // LoadPrivateKey loads a private key from file in keystorePath
 func LoadPrivateKey(keystorePath string) (bccsp.Key, crypto.Signer, error) {
 	var err error
@@ -51,7 +100,15 @@ func LoadPrivateKey(keystorePath string) (bccsp.Key, crypto.Signer, error) {
 			}
 
 			block, _ := pem.Decode(rawKey)
-			priv, err = csp.KeyImport(block.Bytes, &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true})
+			key, err := utils.DERToPrivateKey(block.Bytes)
+			if err != nil {
+				return err
+			}
+			var importOpts bccsp.KeyImportOpts = &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true}
+			if _, isEd25519 := key.(ed25519.PrivateKey); isEd25519 {
+				importOpts = &bccsp.ED25519PrivateKeyImportOpts{Temporary: true}
+			}
+			priv, err = csp.KeyImport(block.Bytes, importOpts)
 			if err != nil {
 				return err
 			}
@@ -74,14 +131,26 @@ func LoadPrivateKey(keystorePath string) (bccsp.Key, crypto.Signer, error) {
 	return priv, s, err
 }
 
-// GeneratePrivateKey creates a private key and stores it in keystorePath
+// GeneratePrivateKey creates an ECDSA private key and stores it in keystorePath
 func GeneratePrivateKey(keystorePath string) (bccsp.Key,
 	crypto.Signer, error) {
+	return GeneratePrivateKeyWithAlgorithm(keystorePath, ECDSA)
+}
+
+// GeneratePrivateKeyWithAlgorithm creates a private key for the given
+// algorithm and stores it in keystorePath
+func GeneratePrivateKeyWithAlgorithm(keystorePath, algorithm string) (bccsp.Key,
+	crypto.Signer, error) {
 
 	var err error
 	var priv bccsp.Key
 	var s crypto.Signer
 
+	keyGenOpts, err := KeyGenOpts(algorithm, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	opts := &factory.FactoryOpts{
 		ProviderName: "SW",
 		SwOpts: &factory.SwOpts{
@@ -96,7 +165,7 @@ func GeneratePrivateKey(keystorePath string) (bccsp.Key,
 	csp, err := factory.GetBCCSPFromOpts(opts)
 	if err == nil {
 		// generate a key
-		priv, err = csp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: false})
+		priv, err = csp.KeyGen(keyGenOpts)
 		if err == nil {
 			// create a crypto.Signer
 			s, err = signer.New(csp, priv)
@@ -105,7 +174,8 @@ func GeneratePrivateKey(keystorePath string) (bccsp.Key,
 	return priv, s, err
 }
 
-func GetECPublicKey(priv bccsp.Key) (*ecdsa.PublicKey, error) {
+// GetPublicKey returns the public key of priv as a crypto.PublicKey
+func GetPublicKey(priv bccsp.Key) (crypto.PublicKey, error) {
 
 	// get the public key
 	pubKey, err := priv.PublicKey()
@@ -118,9 +188,17 @@ func GetECPublicKey(priv bccsp.Key) (*ecdsa.PublicKey, error) {
 		return nil, err
 	}
 	// unmarshal using pkix
-	ecPubKey, err := x509.ParsePKIXPublicKey(pubKeyBytes)
+	return utils.DERToPublicKey(pubKeyBytes)
+}
+
+func GetECPublicKey(priv bccsp.Key) (*ecdsa.PublicKey, error) {
+	pubKey, err := GetPublicKey(priv)
 	if err != nil {
 		return nil, err
 	}
-	return ecPubKey.(*ecdsa.PublicKey), nil
+	ecPubKey, ok := pubKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, errors.Errorf("expected an ECDSA public key, got %T", pubKey)
+	}
+	return ecPubKey, nil
 }
@@ -7,6 +7,7 @@ package csp_test
 
 import (
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"encoding/hex"
 	"errors"
 	"os"
@@ -14,6 +15,7 @@ import (
 	"testing"
 
 	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/utils"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/csp"
 	"github.com/stretchr/testify/assert"
 )
@@ -73,6 +75,50 @@ func TestGeneratePrivateKey(t *testing.T) {
 
 }
 
+func TestGeneratePrivateKeyWithAlgorithm(t *testing.T) {
+	defer cleanup(testDir)
+
+	priv, signer, err := csp.GeneratePrivateKeyWithAlgorithm(testDir, csp.ED25519)
+	assert.NoError(t, err, "Failed to generate private key")
+	assert.NotNil(t, signer, "Should have returned a crypto.Signer")
+	pubKey, err := csp.GetPublicKey(priv)
+	assert.NoError(t, err)
+	assert.IsType(t, ed25519.PublicKey{}, pubKey)
+	algorithm, err := csp.PublicKeyAlgorithm(pubKey)
+	assert.NoError(t, err)
+	assert.Equal(t, csp.ED25519, algorithm)
+	_, err = csp.GetECPublicKey(priv)
+	assert.Error(t, err)
+
+	loadedPriv, _, err := csp.LoadPrivateKey(testDir)
+	assert.NoError(t, err)
+	assert.Equal(t, priv.SKI(), loadedPriv.SKI(), "Should have same subject identifier")
+
+	_, _, err = csp.GeneratePrivateKeyWithAlgorithm(testDir, "DSA")
+	assert.EqualError(t, err, "unknown key algorithm DSA, supported algorithms are [ECDSA, ED25519, SECP256K1]")
+}
+
+func TestGeneratePrivateKeySecp256k1(t *testing.T) {
+	defer cleanup(testDir)
+
+	priv, signer, err := csp.GeneratePrivateKeyWithAlgorithm(testDir, csp.SECP256K1)
+	assert.NoError(t, err, "Failed to generate private key")
+	assert.NotNil(t, signer, "Should have returned a crypto.Signer")
+	pubKey, err := csp.GetECPublicKey(priv)
+	assert.NoError(t, err)
+	assert.Equal(t, utils.Secp256k1(), pubKey.Curve)
+	algorithm, err := csp.PublicKeyAlgorithm(pubKey)
+	assert.NoError(t, err)
+	assert.Equal(t, csp.SECP256K1, algorithm)
+
+	loadedPriv, _, err := csp.LoadPrivateKey(testDir)
+	assert.NoError(t, err)
+	assert.Equal(t, priv.SKI(), loadedPriv.SKI(), "Should have same subject identifier")
+
+	assert.Equal(t, csp.ECDSA, csp.TLSKeyAlgorithm(csp.SECP256K1))
+	assert.Equal(t, csp.ED25519, csp.TLSKeyAlgorithm(csp.ED25519))
+}
+
 func TestGetECPublicKey(t *testing.T) {
 
 	priv, _, err := csp.GeneratePrivateKey(testDir)
@@ -14,10 +14,19 @@ import (
 	"path/filepath"
 	"text/template"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
+	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/common/tools/configtxlator/update"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/ca"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/csp"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/metadata"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/msp"
+	"github.com/hyperledger/fabric/common/tools/protolator"
+	cb "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"gopkg.in/alecthomas/kingpin.v2"
 	"gopkg.in/yaml.v2"
 )
@@ -27,6 +36,9 @@ const (
 	adminBaseName           = "Admin"
 	defaultHostnameTemplate = "{{.Prefix}}{{.Index}}"
 	defaultCNTemplate       = "{{.Hostname}}.{{.Domain}}"
+	caRotationDir           = "ca-rotation"
+	orgConfigFile           = "msp-config.json"
+	orgConfigUpdateFile     = "msp-config-update.json"
 )
 
 type HostnameData struct {
@@ -65,13 +77,16 @@ type UsersSpec struct {
 }
 
 type OrgSpec struct {
-	Name          string       `yaml:"Name"`
-	Domain        string       `yaml:"Domain"`
-	EnableNodeOUs bool         `yaml:"EnableNodeOUs"`
-	CA            NodeSpec     `yaml:"CA"`
-	Template      NodeTemplate `yaml:"Template"`
-	Specs         []NodeSpec   `yaml:"Specs"`
-	Users         UsersSpec    `yaml:"Users"`
+	Name           string       `yaml:"Name"`
+	MSPID          string       `yaml:"MSPID"`
+	Domain         string       `yaml:"Domain"`
+	EnableNodeOUs  bool         `yaml:"EnableNodeOUs"`
+	KeyAlgorithm   string       `yaml:"KeyAlgorithm"`
+	CA             NodeSpec     `yaml:"CA"`
+	IntermediateCA *NodeSpec    `yaml:"IntermediateCA"`
+	Template       NodeTemplate `yaml:"Template"`
+	Specs          []NodeSpec   `yaml:"Specs"`
+	Users          UsersSpec    `yaml:"Users"`
 }
 
 type Config struct {
@@ -104,8 +119,10 @@ PeerOrgs:
   # Org1
   # ---------------------------------------------------------------------------
   - Name: Org1
+    # MSPID: Org1MSP # default is the Name, used in msp-config.json
     Domain: org1.example.com
     EnableNodeOUs: false
+    # KeyAlgorithm: ECDSA # algorithm of the generated keys, ECDSA (default), ED25519 or SECP256K1
 
     # ---------------------------------------------------------------------------
     # "CA"
@@ -122,6 +139,16 @@ PeerOrgs:
     #    StreetAddress: address for org # default nil
     #    PostalCode: postalCode for org # default nil
 
+    # ---------------------------------------------------------------------------
+    # "IntermediateCA"
+    # ---------------------------------------------------------------------------
+    # Uncomment this section to have the CA above issue an intermediate CA which
+    # in turn signs the certificates of all nodes and users of this organization.
+    # This entry is a Spec, as for CA.
+    # ---------------------------------------------------------------------------
+    # IntermediateCA:
+    #    Hostname: ica # implicitly ica.org1.example.com
+
     # ---------------------------------------------------------------------------
     # "Specs"
     # ---------------------------------------------------------------------------
@@ -210,6 +237,17 @@ var (
 	ext           = app.Command("extend", "Extend existing network")
 	inputDir      = ext.Flag("input", "The input directory in which existing network place").Default("crypto-config").String()
 	extConfigFile = ext.Flag("config", "The configuration template to use").File()
+
+	ren             = app.Command("renew", "Renew the certificates of existing nodes and users")
+	renInputDir     = ren.Flag("input", "The input directory in which existing network place").Default("crypto-config").String()
+	renConfigFile   = ren.Flag("config", "The configuration template to use").File()
+	renNewKey       = ren.Flag("newkey", "Generate new key pairs instead of reusing the existing ones").Bool()
+	rotate          = app.Command("rotateca", "Rotate the signing CA of existing organizations")
+	rotateInputDir  = rotate.Flag("input", "The input directory in which existing network place").Default("crypto-config").String()
+	rotateConfig    = rotate.Flag("config", "The configuration template to use").File()
+	rotateCompleted = rotate.Flag("complete", "Complete a previously started rotation by removing the previous CA").Bool()
+	rotateChConfig  = rotate.Flag("channelconfig", "The JSON channel config from which to compute the config updates of the organizations").File()
+	rotateChannelID = rotate.Flag("channelid", "The channel of the config updates").String()
 )
 
 func main() {
@@ -223,6 +261,12 @@ func main() {
 	case ext.FullCommand():
 		extend()
 
+	case ren.FullCommand():
+		renewAll()
+
+	case rotate.FullCommand():
+		rotateCAs()
+
 		// "showtemplate" command
 	case showtemplate.FullCommand():
 		fmt.Print(defaultConfig)
@@ -236,24 +280,20 @@ func main() {
 }
 
 func getConfig() (*Config, error) {
-	var configData string
+	configData := defaultConfig
 
-	if *genConfigFile != nil {
-		data, err := ioutil.ReadAll(*genConfigFile)
-		if err != nil {
-			return nil, fmt.Errorf("Error reading configuration: %s", err)
+	for _, configFile := range []*os.File{*genConfigFile, *extConfigFile, *renConfigFile, *rotateConfig} {
+		if configFile == nil {
+			continue
 		}
 
-		configData = string(data)
-	} else if *extConfigFile != nil {
-		data, err := ioutil.ReadAll(*extConfigFile)
+		data, err := ioutil.ReadAll(configFile)
 		if err != nil {
 			return nil, fmt.Errorf("Error reading configuration: %s", err)
 		}
 
 		configData = string(data)
-	} else {
-		configData = defaultConfig
+		break
 	}
 
 	config := &Config{}
@@ -302,11 +342,10 @@ func extendPeerOrg(orgSpec OrgSpec) {
 
 	peersDir := filepath.Join(orgDir, "peers")
 	usersDir := filepath.Join(orgDir, "users")
-	caDir := filepath.Join(orgDir, "ca")
 	tlscaDir := filepath.Join(orgDir, "tlsca")
 
-	signCA := getCA(caDir, orgSpec, orgSpec.CA.CommonName)
-	tlsCA := getCA(tlscaDir, orgSpec, "tls"+orgSpec.CA.CommonName)
+	signCA := getSignCA(orgDir, orgSpec)
+	tlsCA := getCA(tlscaDir, orgSpec.CA, "tls"+orgSpec.CA.CommonName)
 
 	generateNodes(peersDir, orgSpec.Specs, signCA, tlsCA, msp.PEER, orgSpec.EnableNodeOUs)
 
@@ -341,7 +380,6 @@ func extendOrdererOrg(orgSpec OrgSpec) {
 	orgName := orgSpec.Domain
 
 	orgDir := filepath.Join(*inputDir, "ordererOrganizations", orgName)
-	caDir := filepath.Join(orgDir, "ca")
 	usersDir := filepath.Join(orgDir, "users")
 	tlscaDir := filepath.Join(orgDir, "tlsca")
 	orderersDir := filepath.Join(orgDir, "orderers")
@@ -350,8 +388,8 @@ func extendOrdererOrg(orgSpec OrgSpec) {
 		return
 	}
 
-	signCA := getCA(caDir, orgSpec, orgSpec.CA.CommonName)
-	tlsCA := getCA(tlscaDir, orgSpec, "tls"+orgSpec.CA.CommonName)
+	signCA := getSignCA(orgDir, orgSpec)
+	tlsCA := getCA(tlscaDir, orgSpec.CA, "tls"+orgSpec.CA.CommonName)
 
 	generateNodes(orderersDir, orgSpec.Specs, signCA, tlsCA, msp.ORDERER, false)
 
@@ -370,6 +408,378 @@ func extendOrdererOrg(orgSpec OrgSpec) {
 	}
 }
 
+// orgKind describes where the artifacts of peer and orderer orgs live
+type orgKind struct {
+	prefix   string
+	orgsDir  string
+	nodesDir string
+	nodeType int
+}
+
+var (
+	peerOrgKind    = orgKind{prefix: "peer", orgsDir: "peerOrganizations", nodesDir: "peers", nodeType: msp.PEER}
+	ordererOrgKind = orgKind{prefix: "orderer", orgsDir: "ordererOrganizations", nodesDir: "orderers", nodeType: msp.ORDERER}
+)
+
+// forEachOrg renders each org of config and invokes fn with the org's
+// directory under baseDir and whether NodeOUs are enabled for it
+func forEachOrg(config *Config, baseDir string, fn func(orgDir string, orgSpec OrgSpec, kind orgKind, nodeOUs bool) error) {
+	for _, kind := range []orgKind{peerOrgKind, ordererOrgKind} {
+		orgSpecs := config.PeerOrgs
+		if kind.nodeType == msp.ORDERER {
+			orgSpecs = config.OrdererOrgs
+		}
+
+		for _, orgSpec := range orgSpecs {
+			err := renderOrgSpec(&orgSpec, kind.prefix)
+			if err != nil {
+				fmt.Printf("Error processing %s configuration: %s", kind.prefix, err)
+				os.Exit(-1)
+			}
+
+			orgDir := filepath.Join(baseDir, kind.orgsDir, orgSpec.Domain)
+			if _, err := os.Stat(orgDir); err != nil {
+				fmt.Printf("Error accessing org %s:\n%v\n", orgSpec.Domain, err)
+				os.Exit(1)
+			}
+
+			// orderer orgs are always generated without NodeOUs
+			nodeOUs := orgSpec.EnableNodeOUs && kind.nodeType != msp.ORDERER
+			err = fn(orgDir, orgSpec, kind, nodeOUs)
+			if err != nil {
+				fmt.Printf("Error processing org %s:\n%v\n", orgSpec.Domain, err)
+				os.Exit(1)
+			}
+		}
+	}
+}
+
+func renewAll() {
+	config, err := getConfig()
+	if err != nil {
+		fmt.Printf("Error reading config: %s", err)
+		os.Exit(-1)
+	}
+
+	forEachOrg(config, *renInputDir, func(orgDir string, orgSpec OrgSpec, kind orgKind, nodeOUs bool) error {
+		return renewOrg(orgDir, orgSpec, kind, nodeOUs, *renNewKey)
+	})
+}
+
+// renewOrg renews the certificates of all the nodes and users found in
+// orgDir, and refreshes the copies of the admin certificate
+func renewOrg(orgDir string, orgSpec OrgSpec, kind orgKind, nodeOUs, newKey bool) error {
+	signCA := getSignCA(orgDir, orgSpec)
+	tlsCA := getCA(filepath.Join(orgDir, "tlsca"), orgSpec.CA, "tls"+orgSpec.CA.CommonName)
+
+	err := forEachNode(filepath.Join(orgDir, kind.nodesDir), func(nodeDir, name string) error {
+		return msp.RenewLocalMSP(nodeDir, name, signCA, tlsCA, kind.nodeType, nodeOUs, newKey)
+	})
+	if err != nil {
+		return err
+	}
+
+	err = forEachNode(filepath.Join(orgDir, "users"), func(nodeDir, name string) error {
+		return msp.RenewLocalMSP(nodeDir, name, signCA, tlsCA, msp.CLIENT, nodeOUs, newKey)
+	})
+	if err != nil {
+		return err
+	}
+
+	return refreshAdminCerts(orgDir, orgSpec, kind)
+}
+
+func rotateCAs() {
+	config, err := getConfig()
+	if err != nil {
+		fmt.Printf("Error reading config: %s", err)
+		os.Exit(-1)
+	}
+
+	var channelConfig *cb.Config
+	if *rotateChConfig != nil {
+		if *rotateChannelID == "" {
+			fmt.Printf("A channel ID must be provided with the channel config")
+			os.Exit(-1)
+		}
+		channelConfig = &cb.Config{}
+		err = protolator.DeepUnmarshalJSON(*rotateChConfig, channelConfig)
+		if err != nil {
+			fmt.Printf("Error reading channel config: %s", err)
+			os.Exit(-1)
+		}
+	}
+
+	forEachOrg(config, *rotateInputDir, func(orgDir string, orgSpec OrgSpec, kind orgKind, nodeOUs bool) error {
+		return stageOrgDir(orgDir, func(stagingDir string) error {
+			var err error
+			if *rotateCompleted {
+				err = completeCARotation(stagingDir, orgSpec, kind, nodeOUs)
+			} else {
+				err = startCARotation(stagingDir, orgSpec, kind, nodeOUs)
+			}
+			if err != nil {
+				return err
+			}
+			return writeOrgConfig(stagingDir, orgSpec, kind, channelConfig, *rotateChannelID)
+		})
+	})
+}
+
+// stageOrgDir invokes fn with a copy of orgDir, which replaces orgDir once fn
+// succeeds, so that the material of the org is never left half updated
+func stageOrgDir(orgDir string, fn func(stagingDir string) error) error {
+	stagingDir := orgDir + ".staging"
+	previousDir := orgDir + ".previous"
+
+	err := os.RemoveAll(stagingDir)
+	if err != nil {
+		return err
+	}
+	err = copyDir(orgDir, stagingDir)
+	if err == nil {
+		err = fn(stagingDir)
+	}
+	if err != nil {
+		os.RemoveAll(stagingDir)
+		return err
+	}
+
+	err = os.Rename(orgDir, previousDir)
+	if err != nil {
+		os.RemoveAll(stagingDir)
+		return err
+	}
+	err = os.Rename(stagingDir, orgDir)
+	if err != nil {
+		os.Rename(previousDir, orgDir)
+		return err
+	}
+
+	return os.RemoveAll(previousDir)
+}
+
+// startCARotation replaces the signing CA of the org in orgDir with a new one
+// which is cross-signed by the previous CA.  All certificates are reissued by
+// the new CA with their existing keys, and the MSPs trust the previous CA as
+// root and the cross-signed certificate as intermediate, so that certificates
+// issued by either CA are valid until the rotation is completed.
+func startCARotation(orgDir string, orgSpec OrgSpec, kind orgKind, nodeOUs bool) error {
+	if orgSpec.IntermediateCA != nil {
+		return fmt.Errorf("CA rotation is not supported for organizations with an intermediate CA")
+	}
+
+	caDir := filepath.Join(orgDir, "ca")
+	rotationDir := filepath.Join(orgDir, caRotationDir)
+	previousDir := filepath.Join(rotationDir, "previous")
+	if _, err := os.Stat(rotationDir); err == nil {
+		return fmt.Errorf("a CA rotation is already in progress, use --complete to finish it")
+	}
+
+	err := os.MkdirAll(rotationDir, 0755)
+	if err != nil {
+		return err
+	}
+	err = os.Rename(caDir, previousDir)
+	if err != nil {
+		return err
+	}
+	previousCA := getCA(previousDir, orgSpec.CA, orgSpec.CA.CommonName)
+
+	newCA, err := ca.NewCA(caDir, orgSpec.Domain, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, orgSpec.KeyAlgorithm)
+	if err != nil {
+		return err
+	}
+	_, err = previousCA.CrossSign(filepath.Join(rotationDir, "cross"), newCA)
+	if err != nil {
+		return err
+	}
+
+	err = renewOrg(orgDir, orgSpec, kind, nodeOUs, false)
+	if err != nil {
+		return err
+	}
+
+	tlsCA := getCA(filepath.Join(orgDir, "tlsca"), orgSpec.CA, "tls"+orgSpec.CA.CommonName)
+	return msp.UpdateCAs(filepath.Join(orgDir, "msp"), getSignCA(orgDir, orgSpec), tlsCA, nodeOUs)
+}
+
+// completeCARotation removes the previous CA of the org in orgDir from all of
+// its MSPs, leaving the new CA as the only root
+func completeCARotation(orgDir string, orgSpec OrgSpec, kind orgKind, nodeOUs bool) error {
+	rotationDir := filepath.Join(orgDir, caRotationDir)
+	if _, err := os.Stat(rotationDir); err != nil {
+		return fmt.Errorf("no CA rotation is in progress")
+	}
+
+	err := os.RemoveAll(rotationDir)
+	if err != nil {
+		return err
+	}
+
+	signCA := getSignCA(orgDir, orgSpec)
+	tlsCA := getCA(filepath.Join(orgDir, "tlsca"), orgSpec.CA, "tls"+orgSpec.CA.CommonName)
+
+	err = msp.UpdateCAs(filepath.Join(orgDir, "msp"), signCA, tlsCA, nodeOUs)
+	if err != nil {
+		return err
+	}
+
+	err = forEachNode(filepath.Join(orgDir, kind.nodesDir), func(nodeDir, name string) error {
+		return msp.UpdateCAs(filepath.Join(nodeDir, "msp"), signCA, tlsCA, nodeOUs)
+	})
+	if err != nil {
+		return err
+	}
+
+	return forEachNode(filepath.Join(orgDir, "users"), func(nodeDir, name string) error {
+		return msp.UpdateCAs(filepath.Join(nodeDir, "msp"), signCA, tlsCA, nodeOUs)
+	})
+}
+
+// forEachNode invokes fn for each node directory found in baseDir
+func forEachNode(baseDir string, fn func(nodeDir, name string) error) error {
+	infos, err := ioutil.ReadDir(baseDir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, info := range infos {
+		if !info.IsDir() {
+			continue
+		}
+		err = fn(filepath.Join(baseDir, info.Name()), info.Name())
+		if err != nil {
+			return fmt.Errorf("%s: %s", info.Name(), err)
+		}
+	}
+
+	return nil
+}
+
+// refreshAdminCerts replaces the admin certificate in the org MSP and in the
+// MSPs of the org's nodes with the current certificate of the admin user
+func refreshAdminCerts(orgDir string, orgSpec OrgSpec, kind orgKind) error {
+	usersDir := filepath.Join(orgDir, "users")
+	adminUserName := fmt.Sprintf("%s@%s", adminBaseName, orgSpec.Domain)
+
+	refresh := func(mspDir string) error {
+		adminCertsDir := filepath.Join(mspDir, "admincerts")
+		err := os.RemoveAll(adminCertsDir)
+		if err != nil {
+			return err
+		}
+		return copyAdminCert(usersDir, adminCertsDir, adminUserName)
+	}
+
+	err := refresh(filepath.Join(orgDir, "msp"))
+	if err != nil {
+		return err
+	}
+
+	return forEachNode(filepath.Join(orgDir, kind.nodesDir), func(nodeDir, name string) error {
+		return refresh(filepath.Join(nodeDir, "msp"))
+	})
+}
+
+// writeOrgConfig writes the definition of the org in orgDir as JSON, in the
+// same form as configtxgen -printOrg.  If a channel config is provided, the
+// config update which sets the MSP of the org on that channel is written as
+// well, ready to be signed and submitted
+func writeOrgConfig(orgDir string, orgSpec OrgSpec, kind orgKind, channelConfig *cb.Config, channelID string) error {
+	org := &genesisconfig.Organization{
+		Name:           orgSpec.Name,
+		ID:             orgSpec.MSPID,
+		MSPDir:         filepath.Join(orgDir, "msp"),
+		MSPType:        "bccsp",
+		AdminPrincipal: genesisconfig.AdminRoleAdminPrincipal,
+	}
+
+	var og *cb.ConfigGroup
+	var orgDef proto.Message
+	var err error
+	groupKey := channelconfig.ApplicationGroupKey
+	if kind.nodeType == msp.ORDERER {
+		groupKey = channelconfig.OrdererGroupKey
+		og, err = encoder.NewOrdererOrgGroup(org)
+		orgDef = &ab.DynamicOrdererOrgGroup{ConfigGroup: og}
+	} else {
+		og, err = encoder.NewApplicationOrgGroup(org)
+		orgDef = &pb.DynamicApplicationOrgGroup{ConfigGroup: og}
+	}
+	if err != nil {
+		return fmt.Errorf("Error encoding org definition: %s", err)
+	}
+
+	err = writeJSON(filepath.Join(orgDir, orgConfigFile), orgDef)
+	if err != nil {
+		return err
+	}
+
+	if channelConfig == nil {
+		return nil
+	}
+
+	configUpdate, err := computeMSPUpdate(channelConfig, channelID, groupKey, orgSpec.Name, og.Values[channelconfig.MSPKey])
+	if err != nil {
+		return err
+	}
+
+	return writeJSON(filepath.Join(orgDir, orgConfigUpdateFile), configUpdate)
+}
+
+// computeMSPUpdate computes the config update which replaces the MSP value of
+// the org named orgName in the groupKey group of channelConfig
+func computeMSPUpdate(channelConfig *cb.Config, channelID, groupKey, orgName string, mspValue *cb.ConfigValue) (*cb.ConfigUpdate, error) {
+	// both configs are decoded from the same bytes, so that the values left
+	// untouched compare equal
+	configBytes, err := proto.Marshal(channelConfig)
+	if err != nil {
+		return nil, err
+	}
+	original, updated := &cb.Config{}, &cb.Config{}
+	if err = proto.Unmarshal(configBytes, original); err != nil {
+		return nil, err
+	}
+	if err = proto.Unmarshal(configBytes, updated); err != nil {
+		return nil, err
+	}
+
+	group, ok := updated.ChannelGroup.GetGroups()[groupKey]
+	if !ok {
+		return nil, fmt.Errorf("channel config has no %s group", groupKey)
+	}
+	orgGroup, ok := group.Groups[orgName]
+	if !ok {
+		return nil, fmt.Errorf("organization %s not found in the %s group of the channel config", orgName, groupKey)
+	}
+	orgGroup.Values[channelconfig.MSPKey] = &cb.ConfigValue{
+		Value:     mspValue.Value,
+		ModPolicy: mspValue.ModPolicy,
+	}
+
+	configUpdate, err := update.Compute(original, updated)
+	if err != nil {
+		return nil, fmt.Errorf("Error computing config update: %s", err)
+	}
+	configUpdate.ChannelId = channelID
+
+	return configUpdate, nil
+}
+
+func writeJSON(path string, msg proto.Message) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return protolator.DeepMarshalJSON(file, msg)
+}
+
 func generate() {
 
 	config, err := getConfig()
@@ -497,6 +907,20 @@ func renderOrgSpec(orgSpec *OrgSpec, prefix string) error {
 		return err
 	}
 
+	if orgSpec.IntermediateCA != nil {
+		if len(orgSpec.IntermediateCA.Hostname) == 0 {
+			orgSpec.IntermediateCA.Hostname = "ica"
+		}
+		err = renderNodeSpec(orgSpec.Domain, orgSpec.IntermediateCA)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(orgSpec.MSPID) == 0 {
+		orgSpec.MSPID = orgSpec.Name
+	}
+
 	return nil
 }
 
@@ -514,13 +938,22 @@ func generatePeerOrg(baseDir string, orgSpec OrgSpec) {
 	usersDir := filepath.Join(orgDir, "users")
 	adminCertsDir := filepath.Join(mspDir, "admincerts")
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, orgSpec.KeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating signCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
 	}
+	// generate intermediate CA
+	if orgSpec.IntermediateCA != nil {
+		ica := orgSpec.IntermediateCA
+		signCA, err = ca.NewIntermediateCA(filepath.Join(orgDir, "ica"), orgName, ica.CommonName, ica.Country, ica.Province, ica.Locality, ica.OrganizationalUnit, ica.StreetAddress, ica.PostalCode, signCA)
+		if err != nil {
+			fmt.Printf("Error generating intermediate CA for org %s:\n%v\n", orgName, err)
+			os.Exit(1)
+		}
+	}
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, csp.TLSKeyAlgorithm(orgSpec.KeyAlgorithm))
 	if err != nil {
 		fmt.Printf("Error generating tlsCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
@@ -623,13 +1056,22 @@ func generateOrdererOrg(baseDir string, orgSpec OrgSpec) {
 	usersDir := filepath.Join(orgDir, "users")
 	adminCertsDir := filepath.Join(mspDir, "admincerts")
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	signCA, err := ca.NewCA(caDir, orgName, orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, orgSpec.KeyAlgorithm)
 	if err != nil {
 		fmt.Printf("Error generating signCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
 	}
+	// generate intermediate CA
+	if orgSpec.IntermediateCA != nil {
+		ica := orgSpec.IntermediateCA
+		signCA, err = ca.NewIntermediateCA(filepath.Join(orgDir, "ica"), orgName, ica.CommonName, ica.Country, ica.Province, ica.Locality, ica.OrganizationalUnit, ica.StreetAddress, ica.PostalCode, signCA)
+		if err != nil {
+			fmt.Printf("Error generating intermediate CA for org %s:\n%v\n", orgName, err)
+			os.Exit(1)
+		}
+	}
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode)
+	tlsCA, err := ca.NewCA(tlsCADir, orgName, "tls"+orgSpec.CA.CommonName, orgSpec.CA.Country, orgSpec.CA.Province, orgSpec.CA.Locality, orgSpec.CA.OrganizationalUnit, orgSpec.CA.StreetAddress, orgSpec.CA.PostalCode, csp.TLSKeyAlgorithm(orgSpec.KeyAlgorithm))
 	if err != nil {
 		fmt.Printf("Error generating tlsCA for org %s:\n%v\n", orgName, err)
 		os.Exit(1)
@@ -693,23 +1135,73 @@ func copyFile(src, dst string) error {
 	return cerr
 }
 
+// copyDir copies the content of src to dst, keeping the permissions of files
+// such as private keys
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, info.Mode())
+		}
+		err = copyFile(path, target)
+		if err != nil {
+			return err
+		}
+		return os.Chmod(target, info.Mode())
+	})
+}
+
 func printVersion() {
 	fmt.Println(metadata.GetVersionInfo())
 }
 
-func getCA(caDir string, spec OrgSpec, name string) *ca.CA {
+func getCA(caDir string, spec NodeSpec, name string) *ca.CA {
 	_, signer, _ := csp.LoadPrivateKey(caDir)
 	cert, _ := ca.LoadCertificateECDSA(caDir)
+	var keyAlgorithm string
+	if cert != nil {
+		keyAlgorithm, _ = csp.PublicKeyAlgorithm(cert.PublicKey)
+	}
 
 	return &ca.CA{
 		Name:               name,
 		Signer:             signer,
 		SignCert:           cert,
-		Country:            spec.CA.Country,
-		Province:           spec.CA.Province,
-		Locality:           spec.CA.Locality,
-		OrganizationalUnit: spec.CA.OrganizationalUnit,
-		StreetAddress:      spec.CA.StreetAddress,
-		PostalCode:         spec.CA.PostalCode,
+		Country:            spec.Country,
+		Province:           spec.Province,
+		Locality:           spec.Locality,
+		OrganizationalUnit: spec.OrganizationalUnit,
+		StreetAddress:      spec.StreetAddress,
+		PostalCode:         spec.PostalCode,
+		KeyAlgorithm:       keyAlgorithm,
+	}
+}
+
+// getSignCA loads the CA which signs the certificates of the org in orgDir.
+// While a CA rotation is in progress, the new CA is presented by its
+// certificate cross-signed by the previous CA.  If the org has an
+// intermediate CA, that is returned with the root CA as its parent.
+func getSignCA(orgDir string, orgSpec OrgSpec) *ca.CA {
+	signCA := getCA(filepath.Join(orgDir, "ca"), orgSpec.CA, orgSpec.CA.CommonName)
+
+	rotationDir := filepath.Join(orgDir, caRotationDir)
+	if _, err := os.Stat(rotationDir); err == nil {
+		signCA.SignCert, _ = ca.LoadCertificateECDSA(filepath.Join(rotationDir, "cross"))
+		signCA.Parent = getCA(filepath.Join(rotationDir, "previous"), orgSpec.CA, orgSpec.CA.CommonName)
 	}
+
+	if orgSpec.IntermediateCA != nil {
+		ica := getCA(filepath.Join(orgDir, "ica"), *orgSpec.IntermediateCA, orgSpec.IntermediateCA.CommonName)
+		ica.Parent = signCA
+		signCA = ica
+	}
+
+	return signCA
 }
@@ -6,16 +6,21 @@ SPDX-License-Identifier: Apache-2.0
 package msp
 
 import (
+	"crypto"
 	"crypto/x509"
 	"encoding/hex"
 	"encoding/pem"
+	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 
+	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
 
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/bccsp/utils"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/ca"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/csp"
 	fabricmsp "github.com/hyperledger/fabric/msp"
@@ -61,13 +66,13 @@ func GenerateLocalMSP(baseDir, name string, sans []string, signCA *ca.CA,
 	keystore := filepath.Join(mspDir, "keystore")
 
 	// generate private key
-	priv, _, err := csp.GeneratePrivateKey(keystore)
+	priv, _, err := csp.GeneratePrivateKeyWithAlgorithm(keystore, signCA.KeyAlgorithm)
 	if err != nil {
 		return err
 	}
 
 	// get public key
-	ecPubKey, err := csp.GetECPublicKey(priv)
+	pubKey, err := csp.GetPublicKey(priv)
 	if err != nil {
 		return err
 	}
@@ -77,29 +82,17 @@ func GenerateLocalMSP(baseDir, name string, sans []string, signCA *ca.CA,
 		ous = []string{nodeOUMap[nodeType]}
 	}
 	cert, err := signCA.SignCertificate(filepath.Join(mspDir, "signcerts"),
-		name, ous, nil, ecPubKey, x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+		name, ous, nil, pubKey, x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
 	if err != nil {
 		return err
 	}
 
 	// write artifacts to MSP folders
-
-	// the signing CA certificate goes into cacerts
-	err = x509Export(filepath.Join(mspDir, "cacerts", x509Filename(signCA.Name)), signCA.SignCert)
-	if err != nil {
-		return err
-	}
-	// the TLS CA certificate goes into tlscacerts
-	err = x509Export(filepath.Join(mspDir, "tlscacerts", x509Filename(tlsCA.Name)), tlsCA.SignCert)
+	err = UpdateCAs(mspDir, signCA, tlsCA, nodeOUs && hasNodeOU(nodeType))
 	if err != nil {
 		return err
 	}
 
-	// generate config.yaml if required
-	if nodeOUs && nodeType == PEER {
-		exportConfig(mspDir, "cacerts/"+x509Filename(signCA.Name), true)
-	}
-
 	// the signing identity goes into admincerts.
 	// This means that the signing identity
 	// of this MSP is also an admin of this MSP
@@ -117,12 +110,12 @@ func GenerateLocalMSP(baseDir, name string, sans []string, signCA *ca.CA,
 	*/
 
 	// generate private key
-	tlsPrivKey, _, err := csp.GeneratePrivateKey(tlsDir)
+	tlsPrivKey, _, err := csp.GeneratePrivateKeyWithAlgorithm(tlsDir, tlsCA.KeyAlgorithm)
 	if err != nil {
 		return err
 	}
 	// get public key
-	tlsPubKey, err := csp.GetECPublicKey(tlsPrivKey)
+	tlsPubKey, err := csp.GetPublicKey(tlsPrivKey)
 	if err != nil {
 		return err
 	}
@@ -162,23 +155,12 @@ func GenerateVerifyingMSP(baseDir string, signCA *ca.CA, tlsCA *ca.CA, nodeOUs b
 	// create folder structure and write artifacts to proper locations
 	err := createFolderStructure(baseDir, false)
 	if err == nil {
-		// the signing CA certificate goes into cacerts
-		err = x509Export(filepath.Join(baseDir, "cacerts", x509Filename(signCA.Name)), signCA.SignCert)
-		if err != nil {
-			return err
-		}
-		// the TLS CA certificate goes into tlscacerts
-		err = x509Export(filepath.Join(baseDir, "tlscacerts", x509Filename(tlsCA.Name)), tlsCA.SignCert)
+		err = UpdateCAs(baseDir, signCA, tlsCA, nodeOUs)
 		if err != nil {
 			return err
 		}
 	}
 
-	// generate config.yaml if required
-	if nodeOUs {
-		exportConfig(baseDir, "cacerts/"+x509Filename(signCA.Name), true)
-	}
-
 	// create a throwaway cert to act as an admin cert
 	// NOTE: the admincerts folder is going to be
 	// cleared up anyway by copyAdminCert, but
@@ -186,20 +168,246 @@ func GenerateVerifyingMSP(baseDir string, signCA *ca.CA, tlsCA *ca.CA, nodeOUs b
 	// of unit tests
 	factory.InitFactories(nil)
 	bcsp := factory.GetDefault()
-	priv, err := bcsp.KeyGen(&bccsp.ECDSAP256KeyGenOpts{Temporary: true})
-	ecPubKey, err := csp.GetECPublicKey(priv)
+	keyGenOpts, err := csp.KeyGenOpts(signCA.KeyAlgorithm, true)
+	if err != nil {
+		return err
+	}
+	priv, err := bcsp.KeyGen(keyGenOpts)
+	if err != nil {
+		return err
+	}
+	pubKey, err := csp.GetPublicKey(priv)
 	if err != nil {
 		return err
 	}
 	_, err = signCA.SignCertificate(filepath.Join(baseDir, "admincerts"), signCA.Name,
-		nil, nil, ecPubKey, x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+		nil, nil, pubKey, x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RenewLocalMSP issues new signing and TLS certificates for the local MSP in
+// baseDir, which must have been created by GenerateLocalMSP.  The subject
+// alternative names of the existing TLS certificate are preserved.  If newKey
+// is set, new key pairs are generated, otherwise the existing keys are kept.
+func RenewLocalMSP(baseDir, name string, signCA *ca.CA, tlsCA *ca.CA,
+	nodeType int, nodeOUs, newKey bool) error {
+
+	mspDir := filepath.Join(baseDir, "msp")
+	tlsDir := filepath.Join(baseDir, "tls")
+	signcertsDir := filepath.Join(mspDir, "signcerts")
+	keystore := filepath.Join(mspDir, "keystore")
+
+	/*
+		Renew the MSP identity artifacts
+	*/
+	oldCert, err := ca.LoadCertificateECDSA(signcertsDir)
+	if err != nil {
+		return err
+	}
+	if oldCert == nil {
+		return errors.Errorf("no signing certificate found in %s", signcertsDir)
+	}
+
+	pubKey, err := renewPublicKey(oldCert, keystore, newKey)
+	if err != nil {
+		return err
+	}
+
+	err = os.RemoveAll(signcertsDir)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(signcertsDir, 0755)
+	if err != nil {
+		return err
+	}
+	var ous []string
+	if nodeOUs && hasNodeOU(nodeType) {
+		ous = []string{nodeOUMap[nodeType]}
+	}
+	cert, err := signCA.SignCertificate(signcertsDir,
+		name, ous, nil, pubKey, x509.KeyUsageDigitalSignature, []x509.ExtKeyUsage{})
+	if err != nil {
+		return err
+	}
+
+	err = UpdateCAs(mspDir, signCA, tlsCA, nodeOUs && hasNodeOU(nodeType))
+	if err != nil {
+		return err
+	}
+
+	// the identity is an admin of its own MSP until copyAdminCert replaces it,
+	// so keep that entry current
+	ownAdminCert := filepath.Join(mspDir, "admincerts", x509Filename(name))
+	if _, err := os.Stat(ownAdminCert); err == nil {
+		err = x509Export(ownAdminCert, cert)
+		if err != nil {
+			return err
+		}
+	}
+
+	/*
+		Renew the TLS artifacts in the TLS folder
+	*/
+	tlsFilePrefix := "server"
+	if nodeType == CLIENT {
+		tlsFilePrefix = "client"
+	}
+
+	oldTLSCert, err := x509Load(filepath.Join(tlsDir, tlsFilePrefix+".crt"))
+	if err != nil {
+		return err
+	}
+
+	var tlsPrivKey bccsp.Key
+	tlsPubKey := oldTLSCert.PublicKey
+	tlsKeyAlgorithm, err := csp.PublicKeyAlgorithm(tlsPubKey)
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("TLS certificate in %s cannot be renewed", tlsDir))
+	}
+	if newKey {
+		tlsPrivKey, _, err = csp.GeneratePrivateKeyWithAlgorithm(tlsDir, tlsKeyAlgorithm)
+		if err != nil {
+			return err
+		}
+		tlsPubKey, err = csp.GetPublicKey(tlsPrivKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	var sans []string
+	for _, dnsName := range oldTLSCert.DNSNames {
+		sans = append(sans, dnsName)
+	}
+	for _, ip := range oldTLSCert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+
+	_, err = tlsCA.SignCertificate(tlsDir,
+		name, nil, sans, tlsPubKey, x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment,
+		[]x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth})
+	if err != nil {
+		return err
+	}
+	err = x509Export(filepath.Join(tlsDir, "ca.crt"), tlsCA.SignCert)
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(filepath.Join(tlsDir, x509Filename(name)),
+		filepath.Join(tlsDir, tlsFilePrefix+".crt"))
 	if err != nil {
 		return err
 	}
 
+	if tlsPrivKey != nil {
+		err = keyExport(tlsDir, filepath.Join(tlsDir, tlsFilePrefix+".key"), tlsPrivKey)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// renewPublicKey returns the public key to certify when renewing oldCert,
+// replacing the private key in keystore with a new one of the same algorithm
+// if newKey is set
+func renewPublicKey(oldCert *x509.Certificate, keystore string, newKey bool) (crypto.PublicKey, error) {
+	algorithm, err := csp.PublicKeyAlgorithm(oldCert.PublicKey)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("certificate %s cannot be renewed", oldCert.Subject.CommonName))
+	}
+	if !newKey {
+		return oldCert.PublicKey, nil
+	}
+
+	err = os.RemoveAll(keystore)
+	if err != nil {
+		return nil, err
+	}
+	priv, _, err := csp.GeneratePrivateKeyWithAlgorithm(keystore, algorithm)
+	if err != nil {
+		return nil, err
+	}
+	return csp.GetPublicKey(priv)
+}
+
+// UpdateCAs replaces the CA certificates of the MSP in mspDir with those of
+// signCA and tlsCA.  The root of signCA goes into cacerts and the rest of its
+// chain into intermediatecerts.  If nodeOUs is set, config.yaml is generated
+// with NodeOUs bound to signCA.
+func UpdateCAs(mspDir string, signCA *ca.CA, tlsCA *ca.CA, nodeOUs bool) error {
+	cacertsDir := filepath.Join(mspDir, "cacerts")
+	intermediatecertsDir := filepath.Join(mspDir, "intermediatecerts")
+	tlscacertsDir := filepath.Join(mspDir, "tlscacerts")
+
+	for _, dir := range []string{cacertsDir, intermediatecertsDir, tlscacertsDir} {
+		err := os.RemoveAll(dir)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := os.MkdirAll(cacertsDir, 0755)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(tlscacertsDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	// the root of the signing CA chain goes into cacerts
+	root := signCA.Root()
+	err = x509Export(filepath.Join(cacertsDir, x509Filename(root.Name)), root.SignCert)
+	if err != nil {
+		return err
+	}
+
+	// the rest of the signing CA chain goes into intermediatecerts
+	intermediates := signCA.Intermediates()
+	if len(intermediates) > 0 {
+		err = os.MkdirAll(intermediatecertsDir, 0755)
+		if err != nil {
+			return err
+		}
+	}
+	for _, intermediate := range intermediates {
+		err = x509Export(filepath.Join(intermediatecertsDir, x509Filename(intermediate.Name)), intermediate.SignCert)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the TLS CA certificate goes into tlscacerts
+	err = x509Export(filepath.Join(tlscacertsDir, x509Filename(tlsCA.Name)), tlsCA.SignCert)
+	if err != nil {
+		return err
+	}
+
+	// generate config.yaml if required
+	if nodeOUs {
+		caFile := "cacerts/" + x509Filename(signCA.Name)
+		if signCA.Parent != nil {
+			caFile = "intermediatecerts/" + x509Filename(signCA.Name)
+		}
+		return exportConfig(mspDir, caFile, true)
+	}
+
+	return nil
+}
+
+func hasNodeOU(nodeType int) bool {
+	_, ok := nodeOUMap[nodeType]
+	return ok
+}
+
 func createFolderStructure(rootDir string, local bool) error {
 
 	var folders []string
@@ -232,6 +440,18 @@ func x509Export(path string, cert *x509.Certificate) error {
 	return pemExport(path, "CERTIFICATE", cert.Raw)
 }
 
+func x509Load(path string) (*x509.Certificate, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(bytes)
+	if block == nil {
+		return nil, errors.Errorf("no PEM data found in %s", path)
+	}
+	return utils.DERToX509Certificate(block.Bytes)
+}
+
 func keyExport(keystore, output string, key bccsp.Key) error {
 	id := hex.EncodeToString(key.SKI())
 
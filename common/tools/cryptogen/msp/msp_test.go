@@ -6,17 +6,26 @@ SPDX-License-Identifier: Apache-2.0
 package msp_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/pem"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
 
+	bccsputils "github.com/hyperledger/fabric/bccsp/utils"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/ca"
+	"github.com/hyperledger/fabric/common/tools/cryptogen/csp"
 	"github.com/hyperledger/fabric/common/tools/cryptogen/msp"
 	fabricmsp "github.com/hyperledger/fabric/msp"
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/utils"
 )
 
 const (
@@ -46,10 +55,10 @@ func TestGenerateLocalMSP(t *testing.T) {
 	tlsDir := filepath.Join(testDir, "tls")
 
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, "")
 	assert.NoError(t, err, "Error generating CA")
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, "")
 	assert.NoError(t, err, "Error generating CA")
 
 	assert.NotEmpty(t, signCA.SignCert.Subject.Country, "country cannot be empty.")
@@ -127,16 +136,112 @@ func TestGenerateLocalMSP(t *testing.T) {
 
 }
 
+func TestGenerateLocalMSPEd25519(t *testing.T) {
+	cleanup(testDir)
+	defer cleanup(testDir)
+
+	signCA, err := ca.NewCA(filepath.Join(testDir, "ca"), testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.ED25519)
+	assert.NoError(t, err, "Error generating CA")
+	tlsCA, err := ca.NewCA(filepath.Join(testDir, "tlsca"), testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.ED25519)
+	assert.NoError(t, err, "Error generating CA")
+	assert.Equal(t, x509.Ed25519, signCA.SignCert.PublicKeyAlgorithm)
+
+	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.PEER, true)
+	assert.NoError(t, err, "Failed to generate local MSP")
+	tlsCertPEM, err := ioutil.ReadFile(filepath.Join(testDir, "tls", "server.crt"))
+	assert.NoError(t, err)
+	block, _ := pem.Decode(tlsCertPEM)
+	tlsCert, err := x509.ParseCertificate(block.Bytes)
+	assert.NoError(t, err)
+	assert.Equal(t, x509.Ed25519, tlsCert.PublicKeyAlgorithm)
+
+	// the generated MSP can sign and verify with its Ed25519 identity
+	mspDir := filepath.Join(testDir, "msp")
+	testMSPConfig, err := fabricmsp.GetLocalMspConfig(mspDir, nil, testName)
+	assert.NoError(t, err, "Error parsing local MSP config")
+	testMSP, err := fabricmsp.New(&fabricmsp.BCCSPNewOpts{NewBaseOpts: fabricmsp.NewBaseOpts{Version: fabricmsp.MSPv1_0}})
+	assert.NoError(t, err, "Error creating new BCCSP MSP")
+	err = testMSP.Setup(testMSPConfig)
+	assert.NoError(t, err, "Error setting up local MSP")
+
+	id, err := testMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	assert.NoError(t, id.Validate())
+	msg := []byte("hello world")
+	sig, err := id.Sign(msg)
+	assert.NoError(t, err)
+	assert.NoError(t, id.Verify(msg, sig))
+	assert.Error(t, id.Verify([]byte("another message"), sig))
+
+	// renewing with a new key keeps the algorithm
+	err = msp.RenewLocalMSP(testDir, testName, signCA, tlsCA, msp.PEER, true, true)
+	assert.NoError(t, err, "Failed to renew local MSP")
+	cert, err := ca.LoadCertificateECDSA(filepath.Join(mspDir, "signcerts"))
+	assert.NoError(t, err)
+	assert.Equal(t, x509.Ed25519, cert.PublicKeyAlgorithm)
+}
+
+func TestGenerateLocalMSPSecp256k1(t *testing.T) {
+	cleanup(testDir)
+	defer cleanup(testDir)
+
+	rootCA, err := ca.NewCA(filepath.Join(testDir, "ca"), testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.SECP256K1)
+	assert.NoError(t, err, "Error generating CA")
+	signCA, err := ca.NewIntermediateCA(filepath.Join(testDir, "ica"), testCAOrg, "ica."+testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, rootCA)
+	assert.NoError(t, err, "Error generating intermediate CA")
+	tlsCA, err := ca.NewCA(filepath.Join(testDir, "tlsca"), testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, csp.TLSKeyAlgorithm(csp.SECP256K1))
+	assert.NoError(t, err, "Error generating CA")
+	assert.Equal(t, bccsputils.Secp256k1(), signCA.SignCert.PublicKey.(*ecdsa.PublicKey).Curve)
+
+	err = msp.GenerateLocalMSP(testDir, testName, nil, signCA, tlsCA, msp.PEER, true)
+	assert.NoError(t, err, "Failed to generate local MSP")
+	tlsCert, err := loadCert(filepath.Join(testDir, "tls", "server.crt"))
+	assert.NoError(t, err)
+	assert.Equal(t, elliptic.P256(), tlsCert.PublicKey.(*ecdsa.PublicKey).Curve)
+
+	// the generated MSP can sign, verify and deserialize its secp256k1 identity
+	mspDir := filepath.Join(testDir, "msp")
+	testMSPConfig, err := fabricmsp.GetLocalMspConfig(mspDir, nil, testName)
+	assert.NoError(t, err, "Error parsing local MSP config")
+	testMSP, err := fabricmsp.New(&fabricmsp.BCCSPNewOpts{NewBaseOpts: fabricmsp.NewBaseOpts{Version: fabricmsp.MSPv1_0}})
+	assert.NoError(t, err, "Error creating new BCCSP MSP")
+	err = testMSP.Setup(testMSPConfig)
+	assert.NoError(t, err, "Error setting up local MSP")
+
+	id, err := testMSP.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	assert.NoError(t, id.Validate())
+	msg := []byte("hello world")
+	sig, err := id.Sign(msg)
+	assert.NoError(t, err)
+	assert.NoError(t, id.Verify(msg, sig))
+	assert.Error(t, id.Verify([]byte("another message"), sig))
+
+	serialized, err := id.Serialize()
+	assert.NoError(t, err)
+	deserialized, err := testMSP.DeserializeIdentity(serialized)
+	assert.NoError(t, err)
+	assert.NoError(t, deserialized.Validate())
+	assert.NoError(t, deserialized.Verify(msg, sig))
+
+	// renewing with a new key keeps the algorithm
+	err = msp.RenewLocalMSP(testDir, testName, signCA, tlsCA, msp.PEER, true, true)
+	assert.NoError(t, err, "Failed to renew local MSP")
+	cert, err := ca.LoadCertificateECDSA(filepath.Join(mspDir, "signcerts"))
+	assert.NoError(t, err)
+	assert.Equal(t, bccsputils.Secp256k1(), cert.PublicKey.(*ecdsa.PublicKey).Curve)
+}
+
 func TestGenerateVerifyingMSP(t *testing.T) {
 
 	caDir := filepath.Join(testDir, "ca")
 	tlsCADir := filepath.Join(testDir, "tlsca")
 	mspDir := filepath.Join(testDir, "msp")
 	// generate signing CA
-	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, "")
 	assert.NoError(t, err, "Error generating CA")
 	// generate TLS CA
-	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode)
+	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, "")
 	assert.NoError(t, err, "Error generating CA")
 
 	err = msp.GenerateVerifyingMSP(mspDir, signCA, tlsCA, true)
@@ -172,6 +277,188 @@ func TestGenerateVerifyingMSP(t *testing.T) {
 	cleanup(testDir)
 }
 
+func TestRenewLocalMSP(t *testing.T) {
+	cleanup(testDir)
+	defer cleanup(testDir)
+
+	caDir := filepath.Join(testDir, "ca")
+	tlsCADir := filepath.Join(testDir, "tlsca")
+	nodeDir := filepath.Join(testDir, "node")
+	mspDir := filepath.Join(nodeDir, "msp")
+	tlsDir := filepath.Join(nodeDir, "tls")
+
+	signCA, err := ca.NewCA(caDir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, "")
+	assert.NoError(t, err, "Error generating CA")
+	tlsCA, err := ca.NewCA(tlsCADir, testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, "")
+	assert.NoError(t, err, "Error generating CA")
+
+	err = msp.RenewLocalMSP(nodeDir, testName, signCA, tlsCA, msp.PEER, true, false)
+	assert.Error(t, err, "Renewing a non-existent MSP should have failed")
+
+	sans := []string{testName + "." + testCAOrg, "172.16.10.31"}
+	err = msp.GenerateLocalMSP(nodeDir, testName, sans, signCA, tlsCA, msp.PEER, true)
+	assert.NoError(t, err, "Failed to generate local MSP")
+
+	origCert, err := ca.LoadCertificateECDSA(filepath.Join(mspDir, "signcerts"))
+	assert.NoError(t, err)
+	origTLSCert, err := loadCert(filepath.Join(tlsDir, "server.crt"))
+	assert.NoError(t, err)
+	origTLSKey, err := ioutil.ReadFile(filepath.Join(tlsDir, "server.key"))
+	assert.NoError(t, err)
+
+	// renew with the same keys
+	err = msp.RenewLocalMSP(nodeDir, testName, signCA, tlsCA, msp.PEER, true, false)
+	assert.NoError(t, err, "Failed to renew local MSP")
+
+	cert, err := ca.LoadCertificateECDSA(filepath.Join(mspDir, "signcerts"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, origCert.SerialNumber, cert.SerialNumber)
+	assert.Equal(t, origCert.PublicKey, cert.PublicKey)
+	assert.Equal(t, origCert.Subject.OrganizationalUnit, cert.Subject.OrganizationalUnit)
+
+	tlsCert, err := loadCert(filepath.Join(tlsDir, "server.crt"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, origTLSCert.SerialNumber, tlsCert.SerialNumber)
+	assert.Equal(t, origTLSCert.PublicKey, tlsCert.PublicKey)
+	assert.Equal(t, origTLSCert.DNSNames, tlsCert.DNSNames)
+	assert.Equal(t, origTLSCert.IPAddresses, tlsCert.IPAddresses)
+	tlsKey, err := ioutil.ReadFile(filepath.Join(tlsDir, "server.key"))
+	assert.NoError(t, err)
+	assert.Equal(t, origTLSKey, tlsKey)
+	assertValidLocalMSP(t, mspDir)
+
+	// renew with new keys
+	err = msp.RenewLocalMSP(nodeDir, testName, signCA, tlsCA, msp.PEER, true, true)
+	assert.NoError(t, err, "Failed to renew local MSP")
+
+	newCert, err := ca.LoadCertificateECDSA(filepath.Join(mspDir, "signcerts"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, cert.PublicKey, newCert.PublicKey)
+	newTLSCert, err := loadCert(filepath.Join(tlsDir, "server.crt"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, tlsCert.PublicKey, newTLSCert.PublicKey)
+	assert.Equal(t, origTLSCert.DNSNames, newTLSCert.DNSNames)
+	newTLSKey, err := ioutil.ReadFile(filepath.Join(tlsDir, "server.key"))
+	assert.NoError(t, err)
+	assert.NotEqual(t, tlsKey, newTLSKey)
+	keys, err := ioutil.ReadDir(filepath.Join(mspDir, "keystore"))
+	assert.NoError(t, err)
+	assert.Len(t, keys, 1)
+	assertValidLocalMSP(t, mspDir)
+}
+
+func TestIntermediateCA(t *testing.T) {
+	cleanup(testDir)
+	defer cleanup(testDir)
+
+	rootCA, err := ca.NewCA(filepath.Join(testDir, "ca"), testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, "")
+	assert.NoError(t, err, "Error generating CA")
+	signCA, err := ca.NewIntermediateCA(filepath.Join(testDir, "ica"), testCAOrg, "ica."+testCAOrg, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, rootCA)
+	assert.NoError(t, err, "Error generating intermediate CA")
+	tlsCA, err := ca.NewCA(filepath.Join(testDir, "tlsca"), testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, "")
+	assert.NoError(t, err, "Error generating CA")
+
+	verifyingMSPDir := filepath.Join(testDir, "msp")
+	err = msp.GenerateVerifyingMSP(verifyingMSPDir, signCA, tlsCA, true)
+	assert.NoError(t, err, "Failed to generate verifying MSP")
+
+	nodeDir := filepath.Join(testDir, "node")
+	err = msp.GenerateLocalMSP(nodeDir, testName, nil, signCA, tlsCA, msp.PEER, true)
+	assert.NoError(t, err, "Failed to generate local MSP")
+
+	for _, mspDir := range []string{verifyingMSPDir, filepath.Join(nodeDir, "msp")} {
+		assert.True(t, checkForFile(filepath.Join(mspDir, "cacerts", testCAName+"-cert.pem")))
+		assert.True(t, checkForFile(filepath.Join(mspDir, "intermediatecerts", "ica."+testCAOrg+"-cert.pem")))
+
+		config := readConfig(t, mspDir)
+		assert.Equal(t, "intermediatecerts/ica."+testCAOrg+"-cert.pem", config.NodeOUs.PeerOUIdentifier.Certificate)
+	}
+
+	assertValidLocalMSP(t, filepath.Join(nodeDir, "msp"))
+}
+
+func TestUpdateCAsCrossSigned(t *testing.T) {
+	cleanup(testDir)
+	defer cleanup(testDir)
+
+	oldCA, err := ca.NewCA(filepath.Join(testDir, "oldca"), testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, "")
+	assert.NoError(t, err, "Error generating CA")
+	newCA, err := ca.NewCA(filepath.Join(testDir, "ca"), testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, "")
+	assert.NoError(t, err, "Error generating CA")
+	tlsCA, err := ca.NewCA(filepath.Join(testDir, "tlsca"), testCAOrg, testCAName, testCountry, testProvince, testLocality, testOrganizationalUnit, testStreetAddress, testPostalCode, "")
+	assert.NoError(t, err, "Error generating CA")
+
+	nodeDir := filepath.Join(testDir, "node")
+	mspDir := filepath.Join(nodeDir, "msp")
+	err = msp.GenerateLocalMSP(nodeDir, testName, nil, oldCA, tlsCA, msp.PEER, true)
+	assert.NoError(t, err, "Failed to generate local MSP")
+
+	// while rotating, the new CA is an intermediate cross-signed by the old one
+	crossCert, err := oldCA.CrossSign(filepath.Join(testDir, "cross"), newCA)
+	assert.NoError(t, err, "Error cross-signing CA")
+	rotatingCA := &ca.CA{
+		Name:     newCA.Name,
+		Signer:   newCA.Signer,
+		SignCert: crossCert,
+		Parent:   oldCA,
+	}
+	err = msp.RenewLocalMSP(nodeDir, testName, rotatingCA, tlsCA, msp.PEER, true, false)
+	assert.NoError(t, err, "Failed to renew local MSP")
+	assert.True(t, checkForFile(filepath.Join(mspDir, "intermediatecerts", testCAName+"-cert.pem")))
+	assertValidLocalMSP(t, mspDir)
+
+	// once the rotation is complete, the new CA replaces the old one
+	err = msp.UpdateCAs(mspDir, newCA, tlsCA, true)
+	assert.NoError(t, err, "Failed to update CAs")
+	assert.False(t, checkForFile(filepath.Join(mspDir, "intermediatecerts")))
+	cacert, err := ca.LoadCertificateECDSA(filepath.Join(mspDir, "cacerts"))
+	assert.NoError(t, err)
+	assert.Equal(t, newCA.SignCert.Raw, cacert.Raw)
+	assertValidLocalMSP(t, mspDir)
+}
+
+func assertValidLocalMSP(t *testing.T, mspDir string) {
+	testMSPConfig, err := fabricmsp.GetVerifyingMspConfig(mspDir, testName, "bccsp")
+	assert.NoError(t, err, "Error parsing MSP config")
+	// the node's own certificate stands in as admin until cryptogen replaces
+	// it with the Admin user's, and is not a valid admin with NodeOUs enabled
+	fabricMSPConfig := &mspprotos.FabricMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(testMSPConfig.Config, fabricMSPConfig))
+	fabricMSPConfig.Admins = nil
+	testMSPConfig.Config = utils.MarshalOrPanic(fabricMSPConfig)
+	testMSP, err := fabricmsp.New(&fabricmsp.BCCSPNewOpts{NewBaseOpts: fabricmsp.NewBaseOpts{Version: fabricmsp.MSPv1_1}})
+	assert.NoError(t, err, "Error creating new BCCSP MSP")
+	err = testMSP.Setup(testMSPConfig)
+	assert.NoError(t, err, "Error setting up MSP")
+
+	cert, err := ca.LoadCertificateECDSA(filepath.Join(mspDir, "signcerts"))
+	assert.NoError(t, err)
+	sid := &mspprotos.SerializedIdentity{
+		Mspid:   testName,
+		IdBytes: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}),
+	}
+	id, err := testMSP.DeserializeIdentity(utils.MarshalOrPanic(sid))
+	assert.NoError(t, err, "Error deserializing signing certificate")
+	assert.NoError(t, testMSP.Validate(id), "Signing certificate should be valid")
+}
+
+func loadCert(path string) (*x509.Certificate, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(bytes)
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func readConfig(t *testing.T, mspDir string) *fabricmsp.Configuration {
+	configBytes, err := ioutil.ReadFile(filepath.Join(mspDir, "config.yaml"))
+	assert.NoError(t, err)
+	config := &fabricmsp.Configuration{}
+	assert.NoError(t, yaml.Unmarshal(configBytes, config))
+	return config
+}
+
 func TestExportConfig(t *testing.T) {
 	path := filepath.Join(testDir, "export-test")
 	configFile := filepath.Join(path, "config.yaml")
@@ -7,7 +7,14 @@ SPDX-License-Identifier: Apache-2.0
 package idemixca
 
 import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/sw"
 	"github.com/hyperledger/fabric/idemix"
 	"github.com/hyperledger/fabric/msp"
 	m "github.com/hyperledger/fabric/protos/msp"
@@ -16,36 +23,44 @@ import (
 )
 
 // GenerateIssuerKey invokes Idemix library to generate an issuer (CA) signing key pair
-// currently two attributes are supported by the issuer:
+// currently three attributes are supported by the issuer:
 // AttributeNameOU is the organization unit name
 // AttributeNameRole is the role (member or admin) name
+// AttributeNameRevocationHandle is the revocation handle of the credential
 // Generated keys are serialized to bytes
 func GenerateIssuerKey() ([]byte, []byte, error) {
-	rng, err := idemix.GetRand()
+	csp, err := newCSP()
 	if err != nil {
 		return nil, nil, err
 	}
-	AttributeNames := []string{msp.AttributeNameOU, msp.AttributeNameRole}
-	key, err := idemix.NewIssuerKey(AttributeNames, rng)
+	AttributeNames := []string{msp.AttributeNameOU, msp.AttributeNameRole, msp.AttributeNameRevocationHandle}
+	key, err := csp.KeyGen(&bccsp.IdemixIssuerKeyGenOpts{Temporary: true, AttributeNames: AttributeNames})
 	if err != nil {
 		return nil, nil, errors.WithMessage(err, "cannot generate CA key")
 	}
-	ipkSerialized, err := proto.Marshal(key.IPk)
+	iskSerialized, err := key.Bytes()
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "cannot serialize CA secret key")
+	}
+	ipk, err := key.PublicKey()
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "cannot get CA public key")
+	}
+	ipkSerialized, err := ipk.Bytes()
 
-	return key.ISk, ipkSerialized, err
+	return iskSerialized, ipkSerialized, err
 }
 
 // GenerateMSPConfig creates a new MSP config
 // If the new MSP config contains a signer then
 // it generates a fresh user secret and issues a credential
-// with two attributes (described above)
+// with three attributes (described above)
 // using the CA's key pair from the file
 // If the new MSP config does not contain a signer
 // (meaning it is used only for verification)
 // then only a public key of the CA (issuer) is added to the MSP config (besides the name)
-func GenerateSignerConfig(isAdmin bool, ouString string, key *idemix.IssuerKey) ([]byte, error) {
-	attrs := make([]*FP256BN.BIG, 2)
-
+// The credential revocation information cri must contain the revocation handle of the new credential.
+func GenerateSignerConfig(isAdmin bool, ouString string, revocationHandle int, key *idemix.IssuerKey, cri []byte) ([]byte, error) {
 	if ouString == "" {
 		return nil, errors.Errorf("the OU attribute value is empty")
 	}
@@ -56,33 +71,133 @@ func GenerateSignerConfig(isAdmin bool, ouString string, key *idemix.IssuerKey)
 		role = m.MSPRole_ADMIN
 	}
 
-	attrs[0] = idemix.HashModOrder([]byte(ouString))
-	attrs[1] = FP256BN.NewBIGint(int(role))
+	attrs := []bccsp.IdemixAttribute{
+		{Type: bccsp.IdemixBytesAttribute, Value: []byte(ouString)},
+		{Type: bccsp.IdemixIntAttribute, Value: int(role)},
+		{Type: bccsp.IdemixIntAttribute, Value: revocationHandle},
+	}
+
+	csp, err := newCSP()
+	if err != nil {
+		return nil, err
+	}
+	ipkBytes, err := proto.Marshal(key.IPk)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal issuer public key")
+	}
+	issuerKey, err := csp.KeyImport(key.ISk, &bccsp.IdemixIssuerKeyImportOpts{Temporary: true, PublicKey: ipkBytes})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to import issuer key")
+	}
+
+	userKey, err := csp.KeyGen(&bccsp.IdemixUserSecretKeyGenOpts{Temporary: true})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to generate user secret key")
+	}
+	sk, err := userKey.Bytes()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to serialize user secret key")
+	}
 
 	rng, err := idemix.GetRand()
 	if err != nil {
 		return nil, errors.WithMessage(err, "Error getting PRNG")
 	}
-	sk := idemix.RandModOrder(rng)
-	randCred := idemix.RandModOrder(rng)
-	ni := idemix.RandModOrder(rng)
-	msg := idemix.NewCredRequest(sk, randCred, ni, key.IPk, rng)
-	cred, err := idemix.NewCredential(key, msg, attrs, rng)
+	nonce := idemix.BigToBytes(idemix.RandModOrder(rng))
+	credRequest, err := csp.Sign(userKey, nonce, &bccsp.IdemixCredentialRequestSignerOpts{IssuerPK: issuerKey})
 	if err != nil {
-		return nil, errors.WithMessage(err, "failed to generate a credential")
+		return nil, errors.WithMessage(err, "failed to create a credential request")
 	}
-	cred.Complete(randCred)
-
-	credBytes, err := proto.Marshal(cred)
+	credBytes, err := csp.Sign(issuerKey, credRequest, &bccsp.IdemixCredentialSignerOpts{Attributes: attrs})
 	if err != nil {
-		return nil, errors.WithMessage(err, "failed to marshal credential")
+		return nil, errors.WithMessage(err, "failed to generate a credential")
 	}
 
 	signer := &m.IdemixMSPSignerConfig{
-		credBytes,
-		idemix.BigToBytes(sk),
-		ouString,
-		isAdmin,
+		Cred:                            credBytes,
+		Sk:                              sk,
+		OrganizationalUnitIdentifier:    ouString,
+		IsAdmin:                         isAdmin,
+		CredentialRevocationInformation: cri,
 	}
 	return proto.Marshal(signer)
 }
+
+// newCSP returns a software BCCSP that does not store keys
+func newCSP() (bccsp.BCCSP, error) {
+	csp, err := sw.NewDefaultSecurityLevelWithKeystore(sw.NewDummyKeyStore())
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to initialize BCCSP")
+	}
+	return csp, nil
+}
+
+// GenerateRevocationKey generates the long term key pair of the revocation authority
+// and returns the PEM encoded private and public keys
+func GenerateRevocationKey() ([]byte, []byte, error) {
+	key, err := idemix.GenerateLongTermRevocationKey()
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "cannot generate revocation key")
+	}
+	skBytes, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to marshal revocation private key")
+	}
+	pkBytes, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to marshal revocation public key")
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: skBytes}),
+		pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkBytes}),
+		nil
+}
+
+// ParseRevocationKey parses the PEM encoded private key of the revocation authority
+func ParseRevocationKey(raw []byte) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.New("failed to decode revocation key")
+	}
+	key, err := x509.ParseECPrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse revocation key")
+	}
+	return key, nil
+}
+
+// CreateCRI creates the serialized credential revocation information for the given epoch,
+// in which exactly the passed revocation handles are not revoked
+func CreateCRI(key *ecdsa.PrivateKey, unrevokedHandles []int, epoch int) ([]byte, error) {
+	rng, err := idemix.GetRand()
+	if err != nil {
+		return nil, errors.WithMessage(err, "Error getting PRNG")
+	}
+	handles := make([]*FP256BN.BIG, len(unrevokedHandles))
+	for i, rh := range unrevokedHandles {
+		handles[i] = FP256BN.NewBIGint(rh)
+	}
+	cri, err := idemix.CreateCRI(key, handles, epoch, idemix.ALG_WBB, rng)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create credential revocation information")
+	}
+	return proto.Marshal(cri)
+}
+
+// ReadCRI returns the epoch and the unrevoked revocation handles of
+// serialized credential revocation information
+func ReadCRI(criBytes []byte) (int, []int, error) {
+	cri := &idemix.CredentialRevocationInformation{}
+	err := proto.Unmarshal(criBytes, cri)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "failed to unmarshal credential revocation information")
+	}
+	handles, err := idemix.UnrevokedHandles(cri)
+	if err != nil {
+		return 0, nil, err
+	}
+	unrevokedHandles := make([]int, len(handles))
+	for i, rh := range handles {
+		unrevokedHandles[i] = int(new(big.Int).SetBytes(idemix.BigToBytes(rh)).Int64())
+	}
+	return int(cri.Epoch), unrevokedHandles, nil
+}
@@ -32,27 +32,56 @@ func TestIdemixCa(t *testing.T) {
 	err = proto.Unmarshal(ipkBytes, ipk)
 	assert.NoError(t, err)
 
-	writeVerifierToFile(ipkBytes)
+	revocationKeyBytes, revocationPkBytes, err := GenerateRevocationKey()
+	assert.NoError(t, err)
+	revocationKey, err := ParseRevocationKey(revocationKeyBytes)
+	assert.NoError(t, err)
+
+	writeVerifierToFile(ipkBytes, revocationPkBytes)
 
 	key := &idemix.IssuerKey{isk, ipk}
 
-	conf, err := GenerateSignerConfig(false, "OU1", key)
+	cri, err := CreateCRI(revocationKey, []int{1, 2}, 0)
+	assert.NoError(t, err)
+	epoch, handles, err := ReadCRI(cri)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, epoch)
+	assert.Equal(t, []int{1, 2}, handles)
+
+	conf, err := GenerateSignerConfig(false, "OU1", 1, key, cri)
 	assert.NoError(t, err)
 	cleanupSigner()
 	assert.NoError(t, writeSignerToFile(conf))
 	assert.NoError(t, setupMSP())
 
-	conf, err = GenerateSignerConfig(true, "OU1", key)
+	conf, err = GenerateSignerConfig(true, "OU1", 2, key, cri)
 	assert.NoError(t, err)
 	cleanupSigner()
 	assert.NoError(t, writeSignerToFile(conf))
 	assert.NoError(t, setupMSP())
 
+	// A signer whose revocation handle is not in the revocation information cannot be setup
+	conf, err = GenerateSignerConfig(false, "OU1", 3, key, cri)
+	assert.NoError(t, err)
+	cleanupSigner()
+	assert.NoError(t, writeSignerToFile(conf))
+	assert.Error(t, setupMSP())
+
+	// Once the revocation information of the msp revokes a signer, it cannot be setup
+	conf, err = GenerateSignerConfig(false, "OU1", 1, key, cri)
+	assert.NoError(t, err)
+	cleanupSigner()
+	assert.NoError(t, writeSignerToFile(conf))
+	cri, err = CreateCRI(revocationKey, []int{2}, 1)
+	assert.NoError(t, err)
+	assert.NoError(t, writeRevocationInformationToFile(cri))
+	assert.Error(t, setupMSP())
+
 	// Without the verifier dir present, setup should give an error
 	cleanupVerifier()
 	assert.Error(t, setupMSP())
 
-	_, err = GenerateSignerConfig(true, "", key)
+	_, err = GenerateSignerConfig(true, "", 1, key, cri)
 	assert.EqualError(t, err, "the OU attribute value is empty")
 }
 
@@ -73,12 +102,20 @@ func cleanupVerifier() {
 	os.RemoveAll(filepath.Join(testDir, m.IdemixConfigDirMsp))
 }
 
-func writeVerifierToFile(ipkBytes []byte) error {
+func writeVerifierToFile(ipkBytes []byte, revocationPkBytes []byte) error {
 	err := os.Mkdir(filepath.Join(testDir, m.IdemixConfigDirMsp), os.ModePerm)
 	if err != nil {
 		return err
 	}
-	return ioutil.WriteFile(filepath.Join(testDir, m.IdemixConfigDirMsp, m.IdemixConfigFileIssuerPublicKey), ipkBytes, 0644)
+	err = ioutil.WriteFile(filepath.Join(testDir, m.IdemixConfigDirMsp, m.IdemixConfigFileIssuerPublicKey), ipkBytes, 0644)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(testDir, m.IdemixConfigDirMsp, m.IdemixConfigFileRevocationPublicKey), revocationPkBytes, 0644)
+}
+
+func writeRevocationInformationToFile(criBytes []byte) error {
+	return ioutil.WriteFile(filepath.Join(testDir, m.IdemixConfigDirMsp, m.IdemixConfigFileRevocationInformation), criBytes, 0644)
 }
 
 func writeSignerToFile(signerBytes []byte) error {
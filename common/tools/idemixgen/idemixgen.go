@@ -12,10 +12,13 @@ package main
 // the Identity Mixer MSP
 
 import (
+	"crypto/ecdsa"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/tools/idemixgen/idemixca"
@@ -29,6 +32,8 @@ import (
 const (
 	IdemixDirIssuer             = "ca"
 	IdemixConfigIssuerSecretKey = "IssuerSecretKey"
+	IdemixConfigRevocationKey   = "RevocationKey"
+	IdemixConfigRevokedHandles  = "RevokedHandles"
 )
 
 // command line flags
@@ -39,6 +44,12 @@ var (
 	genSignerConfig = app.Command("signerconfig", "Generate a default signer for this Idemix MSP")
 	genCredOU       = genSignerConfig.Flag("org-unit", "The Organizational Unit of the default signer").Short('u').String()
 	genCredIsAdmin  = genSignerConfig.Flag("admin", "Make the default signer admin").Short('a').Bool()
+	genCredRH       = genSignerConfig.Flag("revocation-handle", "The revocation handle of the default signer (defaults to a fresh handle)").Short('r').Int()
+
+	revoke   = app.Command("revoke", "Revoke a credential from the next epoch on")
+	revokeRH = revoke.Flag("revocation-handle", "The revocation handle of the credential to revoke").Short('r').Required().Int()
+
+	publishEpoch = app.Command("publish-epoch", "Publish the revocation information of a new epoch, in which the revoked credentials are no longer valid")
 
 	version = app.Command("version", "Show version information")
 )
@@ -51,6 +62,8 @@ func main() {
 	case genIssuerKey.FullCommand():
 		isk, ipk, err := idemixca.GenerateIssuerKey()
 		handleError(err)
+		revocationKey, revocationPk, err := idemixca.GenerateRevocationKey()
+		handleError(err)
 
 		// Prevent overwriting the existing key
 		path := filepath.Join(IdemixDirIssuer)
@@ -65,17 +78,71 @@ func main() {
 		writeFile(filepath.Join(IdemixDirIssuer, IdemixConfigIssuerSecretKey), isk)
 		writeFile(filepath.Join(IdemixDirIssuer, msp.IdemixConfigFileIssuerPublicKey), ipk)
 		writeFile(filepath.Join(msp.IdemixConfigDirMsp, msp.IdemixConfigFileIssuerPublicKey), ipk)
+		writeFile(filepath.Join(IdemixDirIssuer, IdemixConfigRevocationKey), revocationKey)
+		writeFile(filepath.Join(msp.IdemixConfigDirMsp, msp.IdemixConfigFileRevocationPublicKey), revocationPk)
 
-	case genSignerConfig.FullCommand():
-		config, err := idemixca.GenerateSignerConfig(*genCredIsAdmin, *genCredOU, readIssuerKey())
+		// the first epoch contains no credentials
+		key, err := idemixca.ParseRevocationKey(revocationKey)
+		handleError(err)
+		cri, err := idemixca.CreateCRI(key, nil, 0)
 		handleError(err)
+		writeFile(filepath.Join(msp.IdemixConfigDirMsp, msp.IdemixConfigFileRevocationInformation), cri)
 
+	case genSignerConfig.FullCommand():
 		path := msp.IdemixConfigDirUser
 		checkDirectoryNotExists(path, fmt.Sprintf("This MSP config already contains a directory \"%s\"", path))
 
-		// Write config to file
+		// Add the revocation handle of the new credential to the revocation information of the current epoch
+		epoch, handles := readCRI()
+		revoked := readRevokedHandles()
+		rh := *genCredRH
+		if rh == 0 {
+			rh = nextRevocationHandle(handles, revoked)
+		} else if contains(handles, rh) || contains(revoked, rh) {
+			handleError(errors.Errorf("revocation handle %d is already in use", rh))
+		}
+		cri, err := idemixca.CreateCRI(readRevocationKey(), append(handles, rh), epoch)
+		handleError(err)
+
+		config, err := idemixca.GenerateSignerConfig(*genCredIsAdmin, *genCredOU, rh, readIssuerKey(), cri)
+		handleError(err)
+
+		// Write config and revocation information to file
 		handleError(os.Mkdir(msp.IdemixConfigDirUser, 0770))
 		writeFile(filepath.Join(msp.IdemixConfigDirUser, msp.IdemixConfigFileSigner), config)
+		writeFile(filepath.Join(msp.IdemixConfigDirMsp, msp.IdemixConfigFileRevocationInformation), cri)
+
+	case revoke.FullCommand():
+		_, handles := readCRI()
+		revoked := readRevokedHandles()
+		if contains(revoked, *revokeRH) {
+			handleError(errors.Errorf("revocation handle %d is already revoked", *revokeRH))
+		}
+		if !contains(handles, *revokeRH) {
+			handleError(errors.Errorf("revocation handle %d is unknown", *revokeRH))
+		}
+
+		// The credential is revoked when the next epoch is published
+		revoked = append(revoked, *revokeRH)
+		lines := make([]string, len(revoked))
+		for i, rh := range revoked {
+			lines[i] = strconv.Itoa(rh) + "\n"
+		}
+		writeFile(filepath.Join(IdemixDirIssuer, IdemixConfigRevokedHandles), []byte(strings.Join(lines, "")))
+
+	case publishEpoch.FullCommand():
+		epoch, handles := readCRI()
+		revoked := readRevokedHandles()
+		unrevoked := []int{}
+		for _, rh := range handles {
+			if !contains(revoked, rh) {
+				unrevoked = append(unrevoked, rh)
+			}
+		}
+		cri, err := idemixca.CreateCRI(readRevocationKey(), unrevoked, epoch+1)
+		handleError(err)
+		writeFile(filepath.Join(msp.IdemixConfigDirMsp, msp.IdemixConfigFileRevocationInformation), cri)
+		fmt.Printf("Published epoch %d with %d unrevoked credentials\n", epoch+1, len(unrevoked))
 
 	case version.FullCommand():
 		printVersion()
@@ -110,6 +177,70 @@ func readIssuerKey() *idemix.IssuerKey {
 	return key
 }
 
+// readRevocationKey reads the long term revocation key from the current directory
+func readRevocationKey() *ecdsa.PrivateKey {
+	path := filepath.Join(IdemixDirIssuer, IdemixConfigRevocationKey)
+	keyBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		handleError(errors.Wrapf(err, "failed to open revocation key file: %s", path))
+	}
+	key, err := idemixca.ParseRevocationKey(keyBytes)
+	handleError(err)
+
+	return key
+}
+
+// readCRI reads the revocation information of the current epoch from the current directory
+// and returns the epoch and the unrevoked revocation handles
+func readCRI() (int, []int) {
+	path := filepath.Join(msp.IdemixConfigDirMsp, msp.IdemixConfigFileRevocationInformation)
+	criBytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		handleError(errors.Wrapf(err, "failed to open revocation information file: %s", path))
+	}
+	epoch, handles, err := idemixca.ReadCRI(criBytes)
+	handleError(err)
+
+	return epoch, handles
+}
+
+// readRevokedHandles reads the revoked revocation handles from the current directory
+func readRevokedHandles() []int {
+	revoked := []int{}
+	contents, err := ioutil.ReadFile(filepath.Join(IdemixDirIssuer, IdemixConfigRevokedHandles))
+	if os.IsNotExist(err) {
+		return revoked
+	}
+	handleError(err)
+	for _, line := range strings.Fields(string(contents)) {
+		rh, err := strconv.Atoi(line)
+		handleError(errors.Wrapf(err, "invalid revocation handle %s", line))
+		revoked = append(revoked, rh)
+	}
+
+	return revoked
+}
+
+// nextRevocationHandle returns a revocation handle that was never used before
+func nextRevocationHandle(handles []int, revoked []int) int {
+	next := 1
+	for _, rh := range append(handles, revoked...) {
+		if rh >= next {
+			next = rh + 1
+		}
+	}
+	return next
+}
+
+func contains(handles []int, rh int) bool {
+	for _, h := range handles {
+		if h == rh {
+			return true
+		}
+	}
+	return false
+}
+
 // checkDirectoryNotExists checks whether a directory with the given path already exists and exits if this is the case
 func checkDirectoryNotExists(path string, errorMessage string) {
 	_, err := os.Stat(path)
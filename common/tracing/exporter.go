@@ -0,0 +1,180 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/pkg/errors"
+)
+
+var logger = flogging.MustGetLogger("tracing")
+
+// Exporter receives the spans once they finish. Implementations must be safe
+// for concurrent use, and should not block the callers for long
+type Exporter interface {
+	ExportSpan(span SpanData)
+}
+
+type exporterHolder struct {
+	Exporter
+}
+
+var exporter atomic.Value
+
+// SetExporter sets the exporter of the spans finished from then on.
+// A nil exporter disables tracing, which is the default
+func SetExporter(e Exporter) {
+	exporter.Store(exporterHolder{e})
+}
+
+// Enabled returns whether tracing is enabled
+func Enabled() bool {
+	return currentExporter() != nil
+}
+
+func currentExporter() Exporter {
+	holder, _ := exporter.Load().(exporterHolder)
+	return holder.Exporter
+}
+
+// Config configures tracing
+type Config struct {
+	// Enabled enables tracing
+	Enabled bool
+	// Exporter is the name of a registered exporter
+	Exporter string
+	// File is the path of the file the file exporter writes the spans to
+	File string
+}
+
+// ExporterFactory creates an exporter from the tracing config
+type ExporterFactory func(conf Config) (Exporter, error)
+
+var (
+	factoriesLock sync.RWMutex
+	factories     = map[string]ExporterFactory{
+		"file": func(conf Config) (Exporter, error) {
+			return NewFileExporter(conf.File)
+		},
+	}
+)
+
+// RegisterExporter registers an exporter under a name, which makes it selectable
+// in the tracing config. The file exporter is registered under the name "file"
+func RegisterExporter(name string, factory ExporterFactory) {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+	factories[name] = factory
+}
+
+// Init enables tracing with the configured exporter, or disables it
+func Init(conf Config) error {
+	if !conf.Enabled {
+		SetExporter(nil)
+		return nil
+	}
+	factoriesLock.RLock()
+	factory, exists := factories[conf.Exporter]
+	factoriesLock.RUnlock()
+	if !exists {
+		return errors.Errorf("unknown tracing exporter %q", conf.Exporter)
+	}
+	e, err := factory(conf)
+	if err != nil {
+		return errors.WithMessage(err, "failed creating tracing exporter")
+	}
+	SetExporter(e)
+	logger.Infof("Tracing enabled, exporting spans with the %s exporter", conf.Exporter)
+	return nil
+}
+
+// InMemoryExporter keeps the spans in memory, which is useful for tests
+type InMemoryExporter struct {
+	lock  sync.Mutex
+	spans []SpanData
+}
+
+// NewInMemoryExporter creates an InMemoryExporter
+func NewInMemoryExporter() *InMemoryExporter {
+	return &InMemoryExporter{}
+}
+
+// ExportSpan keeps the span
+func (e *InMemoryExporter) ExportSpan(span SpanData) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.spans = append(e.spans, span)
+}
+
+// Spans returns the spans exported so far, in the order they finished
+func (e *InMemoryExporter) Spans() []SpanData {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	return append([]SpanData(nil), e.spans...)
+}
+
+// Reset discards the spans exported so far
+func (e *InMemoryExporter) Reset() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.spans = nil
+}
+
+// FileExporter appends the spans to a file as JSON, one span per line,
+// for offline analysis
+type FileExporter struct {
+	lock sync.Mutex
+	file *os.File
+}
+
+// NewFileExporter creates a FileExporter which appends to the file at path
+func NewFileExporter(path string) (*FileExporter, error) {
+	if path == "" {
+		return nil, errors.New("the file of the file exporter is not set")
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed opening the file of the file exporter")
+	}
+	return &FileExporter{file: file}, nil
+}
+
+// ExportSpan appends the span to the file
+func (e *FileExporter) ExportSpan(span SpanData) {
+	line, err := json.Marshal(span)
+	if err != nil {
+		logger.Warningf("Failed marshaling span %s: %s", span.Name, err)
+		return
+	}
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.file == nil {
+		return
+	}
+	// Spans are written as they come, so that the file is complete even
+	// if the process doesn't exit gracefully
+	if _, err := e.file.Write(append(line, '\n')); err != nil {
+		logger.Warningf("Failed writing span %s: %s", span.Name, err)
+	}
+}
+
+// Close closes the file. Spans exported afterwards are dropped
+func (e *FileExporter) Close() error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	if e.file == nil {
+		return nil
+	}
+	err := e.file.Close()
+	e.file = nil
+	return err
+}
@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileExporter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tracing")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "traces.json")
+
+	_, err = NewFileExporter("")
+	assert.EqualError(t, err, "the file of the file exporter is not set")
+	_, err = NewFileExporter(filepath.Join(dir, "missing", "traces.json"))
+	assert.Error(t, err)
+
+	e, err := NewFileExporter(path)
+	assert.NoError(t, err)
+	SetExporter(e)
+	defer SetExporter(nil)
+
+	root, ctx := StartSpan(context.Background(), "root")
+	child, _ := StartSpan(ctx, "child", Tag("key", "value"))
+	child.Finish()
+	root.Finish()
+	assert.NoError(t, e.Close())
+	assert.NoError(t, e.Close())
+
+	// spans finished once the file is closed are dropped
+	span, _ := StartSpan(context.Background(), "dropped")
+	span.Finish()
+
+	f, err := os.Open(path)
+	assert.NoError(t, err)
+	defer f.Close()
+	var spans []SpanData
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var span SpanData
+		assert.NoError(t, json.Unmarshal(scanner.Bytes(), &span))
+		spans = append(spans, span)
+	}
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, map[string]string{"key": "value"}, spans[0].Tags)
+	assert.Equal(t, spans[1].SpanID, spans[0].ParentID)
+	assert.Equal(t, "root", spans[1].Name)
+}
+
+type countingExporter struct {
+	count int
+}
+
+func (e *countingExporter) ExportSpan(SpanData) {
+	e.count++
+}
+
+func TestInit(t *testing.T) {
+	defer SetExporter(nil)
+
+	assert.NoError(t, Init(Config{Enabled: false, Exporter: "unknown"}))
+	assert.False(t, Enabled())
+
+	err := Init(Config{Enabled: true, Exporter: "unknown"})
+	assert.EqualError(t, err, `unknown tracing exporter "unknown"`)
+	assert.False(t, Enabled())
+
+	err = Init(Config{Enabled: true, Exporter: "file"})
+	assert.EqualError(t, err, "failed creating tracing exporter: the file of the file exporter is not set")
+	assert.False(t, Enabled())
+
+	counting := &countingExporter{}
+	RegisterExporter("counting", func(conf Config) (Exporter, error) {
+		return counting, nil
+	})
+	assert.NoError(t, Init(Config{Enabled: true, Exporter: "counting"}))
+	assert.True(t, Enabled())
+	span, _ := StartSpan(context.Background(), "operation")
+	span.Finish()
+	assert.Equal(t, 1, counting.count)
+
+	assert.NoError(t, Init(Config{}))
+	assert.False(t, Enabled())
+}
@@ -0,0 +1,267 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// SpanContext identifies a span, and the trace it belongs to, across process boundaries
+type SpanContext struct {
+	TraceID string
+	SpanID  string
+}
+
+var traceParentRegexp = regexp.MustCompile("^00-([0-9a-f]{32})-([0-9a-f]{16})-[0-9a-f]{2}$")
+
+// ParseTraceParent parses a span context formatted as a W3C trace context traceparent
+func ParseTraceParent(traceParent string) (SpanContext, error) {
+	match := traceParentRegexp.FindStringSubmatch(traceParent)
+	if match == nil {
+		return SpanContext{}, errors.Errorf("invalid traceparent %q", traceParent)
+	}
+	return SpanContext{TraceID: match[1], SpanID: match[2]}, nil
+}
+
+// IsValid returns whether the span context identifies a span
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID != "" && sc.SpanID != ""
+}
+
+// TraceParent formats the span context as a W3C trace context traceparent,
+// or returns an empty string if the span context is not valid
+func (sc SpanContext) TraceParent() string {
+	if !sc.IsValid() {
+		return ""
+	}
+	return fmt.Sprintf("00-%s-%s-01", sc.TraceID, sc.SpanID)
+}
+
+// SpanData is the record of a finished span, which is handed to the exporter
+type SpanData struct {
+	TraceID  string            `json:"trace_id"`
+	SpanID   string            `json:"span_id"`
+	ParentID string            `json:"parent_id,omitempty"`
+	Name     string            `json:"name"`
+	Start    time.Time         `json:"start"`
+	End      time.Time         `json:"end"`
+	Duration time.Duration     `json:"duration_ns"`
+	Tags     map[string]string `json:"tags,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// Span times an operation. Spans are nil when tracing is disabled, and all of
+// their methods can be called on a nil span, so that callers don't need to
+// check whether tracing is enabled
+type Span struct {
+	exporter Exporter
+
+	lock     sync.Mutex
+	data     SpanData
+	finished bool
+}
+
+// StartOption configures a span as it starts
+type StartOption func(*startOptions)
+
+type startOptions struct {
+	parent    SpanContext
+	startTime time.Time
+	tags      map[string]string
+}
+
+// ChildOf makes the span a child of the given span context, instead of the span
+// or the remote parent carried by the context the span is started from
+func ChildOf(parent SpanContext) StartOption {
+	return func(o *startOptions) {
+		o.parent = parent
+	}
+}
+
+// StartTime sets when the span started, instead of now
+func StartTime(t time.Time) StartOption {
+	return func(o *startOptions) {
+		o.startTime = t
+	}
+}
+
+// Tag sets a tag of the span as it starts
+func Tag(key string, value interface{}) StartOption {
+	return func(o *startOptions) {
+		if o.tags == nil {
+			o.tags = map[string]string{}
+		}
+		o.tags[key] = fmt.Sprint(value)
+	}
+}
+
+// StartSpan starts a span named after the operation it times. The span is a child
+// of the span carried by ctx, or of the remote parent carried by ctx, or else the
+// root of a new trace. It returns the span along with a context carrying it, or a
+// nil span and ctx itself when tracing is disabled
+func StartSpan(ctx context.Context, name string, opts ...StartOption) (*Span, context.Context) {
+	exporter := currentExporter()
+	if exporter == nil {
+		return nil, ctx
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	o := &startOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	parent := o.parent
+	if !parent.IsValid() {
+		if s := SpanFromContext(ctx); s != nil {
+			parent = s.Context()
+		} else {
+			parent = RemoteParentFromContext(ctx)
+		}
+	}
+	if o.startTime.IsZero() {
+		o.startTime = time.Now()
+	}
+
+	s := &Span{
+		exporter: exporter,
+		data: SpanData{
+			TraceID:  parent.TraceID,
+			SpanID:   newID(8),
+			ParentID: parent.SpanID,
+			Name:     name,
+			Start:    o.startTime,
+			Tags:     o.tags,
+		},
+	}
+	if !parent.IsValid() {
+		s.data.TraceID = newID(16)
+		s.data.ParentID = ""
+	}
+	return s, ContextWithSpan(ctx, s)
+}
+
+// Context returns the span context of the span, or an invalid span context for a nil span
+func (s *Span) Context() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return SpanContext{TraceID: s.data.TraceID, SpanID: s.data.SpanID}
+}
+
+// StartTime returns when the span started, or the zero time for a nil span
+func (s *Span) StartTime() time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	return s.data.Start
+}
+
+// SetTag sets a tag of the span, formatting the value with fmt.Sprint
+func (s *Span) SetTag(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.data.Tags == nil {
+		s.data.Tags = map[string]string{}
+	}
+	s.data.Tags[key] = fmt.Sprint(value)
+}
+
+// SetError records that the operation timed by the span failed. Nil errors are ignored
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.data.Error = err.Error()
+}
+
+// Finish ends the span and exports it. Only the first call has an effect
+func (s *Span) Finish() {
+	if s == nil {
+		return
+	}
+	s.lock.Lock()
+	if s.finished {
+		s.lock.Unlock()
+		return
+	}
+	s.finished = true
+	s.data.End = time.Now()
+	s.data.Duration = s.data.End.Sub(s.data.Start)
+	data := s.data
+	s.lock.Unlock()
+
+	s.exporter.ExportSpan(data)
+}
+
+type spanKey struct{}
+
+type remoteParentKey struct{}
+
+// ContextWithSpan returns a context carrying the span
+func ContextWithSpan(ctx context.Context, s *Span) context.Context {
+	if s == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, spanKey{}, s)
+}
+
+// SpanFromContext returns the span carried by ctx, or nil
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	s, _ := ctx.Value(spanKey{}).(*Span)
+	return s
+}
+
+// ContextWithRemoteParent returns a context carrying the span context of a span
+// of another process, which spans started from the context are children of
+func ContextWithRemoteParent(ctx context.Context, parent SpanContext) context.Context {
+	if !parent.IsValid() {
+		return ctx
+	}
+	return context.WithValue(ctx, remoteParentKey{}, parent)
+}
+
+// RemoteParentFromContext returns the span context of the remote parent carried
+// by ctx, or an invalid span context
+func RemoteParentFromContext(ctx context.Context) SpanContext {
+	if ctx == nil {
+		return SpanContext{}
+	}
+	sc, _ := ctx.Value(remoteParentKey{}).(SpanContext)
+	return sc
+}
+
+// TraceParentFromContext returns the traceparent of the span carried by ctx, to be
+// propagated to another process, or an empty string
+func TraceParentFromContext(ctx context.Context) string {
+	return SpanFromContext(ctx).Context().TraceParent()
+}
+
+func newID(size int) string {
+	id := make([]byte, size)
+	if _, err := rand.Read(id); err != nil {
+		panic(errors.Wrap(err, "failed generating span ID"))
+	}
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,127 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func enableInMemoryExporter() *InMemoryExporter {
+	e := NewInMemoryExporter()
+	SetExporter(e)
+	return e
+}
+
+func TestParseTraceParent(t *testing.T) {
+	sc, err := ParseTraceParent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+	assert.NoError(t, err)
+	assert.Equal(t, SpanContext{TraceID: "0af7651916cd43dd8448eb211c80319c", SpanID: "b7ad6b7169203331"}, sc)
+	assert.True(t, sc.IsValid())
+	assert.Equal(t, "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01", sc.TraceParent())
+
+	for _, traceParent := range []string{
+		"",
+		"garbage",
+		"01-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+		"00-0af7651916cd43dd8448eb211c80319c-b7ad6b71692033-01",
+		"00-0AF7651916CD43DD8448EB211C80319C-b7ad6b7169203331-01",
+	} {
+		_, err := ParseTraceParent(traceParent)
+		assert.Error(t, err, "traceparent %q", traceParent)
+	}
+
+	assert.False(t, SpanContext{}.IsValid())
+	assert.Empty(t, SpanContext{}.TraceParent())
+}
+
+func TestDisabled(t *testing.T) {
+	SetExporter(nil)
+	assert.False(t, Enabled())
+
+	ctx := context.Background()
+	span, spanCtx := StartSpan(ctx, "operation")
+	assert.Nil(t, span)
+	assert.Equal(t, ctx, spanCtx)
+	assert.Empty(t, TraceParentFromContext(spanCtx))
+
+	// the methods of nil spans are no-ops
+	span.SetTag("key", "value")
+	span.SetError(errors.New("failure"))
+	span.Finish()
+	assert.False(t, span.Context().IsValid())
+}
+
+func TestStartSpan(t *testing.T) {
+	e := enableInMemoryExporter()
+	defer SetExporter(nil)
+	assert.True(t, Enabled())
+
+	root, ctx := StartSpan(context.Background(), "root", Tag("number", 1))
+	assert.Equal(t, root, SpanFromContext(ctx))
+	child, _ := StartSpan(ctx, "child")
+	child.SetTag("key", "value")
+	child.SetError(errors.New("failure"))
+	child.Finish()
+	child.Finish()
+	root.Finish()
+
+	spans := e.Spans()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "child", spans[0].Name)
+	assert.Equal(t, "root", spans[1].Name)
+
+	assert.Len(t, spans[1].TraceID, 32)
+	assert.Len(t, spans[1].SpanID, 16)
+	assert.Empty(t, spans[1].ParentID)
+	assert.Equal(t, map[string]string{"number": "1"}, spans[1].Tags)
+
+	assert.Equal(t, spans[1].TraceID, spans[0].TraceID)
+	assert.Equal(t, spans[1].SpanID, spans[0].ParentID)
+	assert.NotEqual(t, spans[1].SpanID, spans[0].SpanID)
+	assert.Equal(t, map[string]string{"key": "value"}, spans[0].Tags)
+	assert.Equal(t, "failure", spans[0].Error)
+	assert.Equal(t, spans[0].End.Sub(spans[0].Start), spans[0].Duration)
+
+	e.Reset()
+	assert.Empty(t, e.Spans())
+}
+
+func TestStartSpanWithParent(t *testing.T) {
+	e := enableInMemoryExporter()
+	defer SetExporter(nil)
+
+	remote := SpanContext{TraceID: "0af7651916cd43dd8448eb211c80319c", SpanID: "b7ad6b7169203331"}
+	ctx := ContextWithRemoteParent(context.Background(), remote)
+	assert.Equal(t, remote, RemoteParentFromContext(ctx))
+
+	span, ctx := StartSpan(ctx, "remote child")
+	assert.Equal(t, span.Context().TraceParent(), TraceParentFromContext(ctx))
+	span.Finish()
+
+	// ChildOf takes precedence over the span carried by the context
+	explicit := SpanContext{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7"}
+	start := time.Now().Add(-time.Minute)
+	span, _ = StartSpan(ctx, "explicit child", ChildOf(explicit), StartTime(start))
+	span.Finish()
+
+	spans := e.Spans()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, remote.TraceID, spans[0].TraceID)
+	assert.Equal(t, remote.SpanID, spans[0].ParentID)
+	assert.Equal(t, explicit.TraceID, spans[1].TraceID)
+	assert.Equal(t, explicit.SpanID, spans[1].ParentID)
+	assert.Equal(t, start, spans[1].Start)
+	assert.True(t, spans[1].Duration >= time.Minute)
+
+	// invalid remote parents are ignored
+	assert.Equal(t, ctx, ContextWithRemoteParent(ctx, SpanContext{}))
+}
@@ -74,6 +74,11 @@ func (d *defaultACLProvider) initialize() {
 	d.cResourcePolicyMap[resources.QSCC_GetBlockByHash] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.QSCC_GetTransactionByID] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.QSCC_GetBlockByTxID] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.QSCC_GetTxStatusesByBlockRange] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.QSCC_GetBlockByTimestamp] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.QSCC_GetTxStatusesByCreator] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.QSCC_GetTxStatusesByChaincode] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.QSCC_GetMVCCConflictByTxID] = CHANNELREADERS
 
 	//--------------- CSCC resources -----------
 	//p resources (implemented by the chaincode currently)
@@ -95,6 +100,7 @@ func (d *defaultACLProvider) initialize() {
 	//Events (not used currently - for future)
 	d.cResourcePolicyMap[resources.BLOCKEVENT] = CHANNELREADERS
 	d.cResourcePolicyMap[resources.FILTEREDBLOCKEVENT] = CHANNELREADERS
+	d.cResourcePolicyMap[resources.CHAINCODEEVENT] = CHANNELREADERS
 }
 
 //this should cover an exhaustive list of everything called from the peer
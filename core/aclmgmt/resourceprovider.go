@@ -9,6 +9,7 @@ package aclmgmt
 import (
 	"fmt"
 
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/common/resourcesconfig"
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/protos/common"
@@ -45,7 +46,18 @@ type policyEvaluatorImpl struct {
 	bundle resourcesconfig.Resources
 }
 
+//PolicyRefForAPI returns the policy for the resource as defined by the ACLs of the
+//channel config if the V1_2 application capability is enabled, falling back to the
+//resources config otherwise
 func (pe *policyEvaluatorImpl) PolicyRefForAPI(resName string) string {
+	if cc := pe.bundle.ChannelConfig(); cc != nil {
+		if ac, ok := cc.ApplicationConfig(); ok && ac.Capabilities().ACLs() {
+			if policyRef := ac.APIPolicyMapper().PolicyRefForAPI(resName); policyRef != "" {
+				return policyRef
+			}
+		}
+	}
+
 	pm := pe.bundle.APIPolicyMapper()
 	if pm == nil {
 		return ""
@@ -60,7 +72,11 @@ func (pe *policyEvaluatorImpl) Evaluate(polName string, sd []*common.SignedData)
 		return PolicyNotFound(polName)
 	}
 
-	return policy.Evaluate(sd)
+	err := policy.Evaluate(sd)
+	if err != nil {
+		policies.DebugExplain(aclLogger, polName, policy, sd)
+	}
+	return err
 }
 
 //------ resourcePolicyProvider ----------
@@ -21,7 +21,10 @@ import (
 	"testing"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/localmsp"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/common/resourcesconfig"
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
@@ -106,3 +109,45 @@ func init() {
 		return
 	}
 }
+
+//mockResources overrides the parts of resourcesconfig.Resources used for policy lookup
+type mockResources struct {
+	resourcesconfig.Resources
+	channelConfig channelconfig.Resources
+	policyMapper  resourcesconfig.PolicyMapper
+}
+
+func (mr *mockResources) ChannelConfig() channelconfig.Resources {
+	return mr.channelConfig
+}
+
+func (mr *mockResources) APIPolicyMapper() resourcesconfig.PolicyMapper {
+	return mr.policyMapper
+}
+
+type mockPolicyMapper map[string]string
+
+func (pm mockPolicyMapper) PolicyRefForAPI(resName string) string {
+	return pm[resName]
+}
+
+func TestPolicyRefForAPIChannelConfigACLs(t *testing.T) {
+	app := &mockconfig.MockApplication{
+		CapabilitiesRv:    &mockconfig.MockApplicationCapabilities{ACLsRv: true},
+		APIPolicyMapperRv: mockPolicyMapper{"res": "/Channel/Application/Writers"},
+	}
+	res := &mockResources{
+		channelConfig: &mockconfig.Resources{ApplicationConfigVal: app},
+		policyMapper:  mockPolicyMapper{"res": "resPolicy", "other": "otherPolicy"},
+	}
+	pe := &policyEvaluatorImpl{res}
+
+	// the ACLs of the channel config take precedence
+	assert.Equal(t, "/Channel/Application/Writers", pe.PolicyRefForAPI("res"))
+	// resources not in the ACLs of the channel config fall back to the resources config
+	assert.Equal(t, "otherPolicy", pe.PolicyRefForAPI("other"))
+
+	// the ACLs of the channel config are ignored without the capability
+	app.CapabilitiesRv = &mockconfig.MockApplicationCapabilities{}
+	assert.Equal(t, "resPolicy", pe.PolicyRefForAPI("res"))
+}
@@ -30,6 +30,12 @@ const (
 	QSCC_GetTransactionByID = "QSCC.GetTransactionByID"
 	QSCC_GetBlockByTxID     = "QSCC.GetBlockByTxID"
 
+	QSCC_GetTxStatusesByBlockRange = "QSCC.GetTxStatusesByBlockRange"
+	QSCC_GetBlockByTimestamp       = "QSCC.GetBlockByTimestamp"
+	QSCC_GetTxStatusesByCreator    = "QSCC.GetTxStatusesByCreator"
+	QSCC_GetTxStatusesByChaincode  = "QSCC.GetTxStatusesByChaincode"
+	QSCC_GetMVCCConflictByTxID     = "QSCC.GetMVCCConflictByTxID"
+
 	//CSCC resources
 	CSCC_JoinChain                = "CSCC.JoinChain"
 	CSCC_GetConfigBlock           = "CSCC.GetConfigBlock"
@@ -43,4 +49,5 @@ const (
 	//Events
 	BLOCKEVENT         = "BLOCKEVENT"
 	FILTEREDBLOCKEVENT = "FILTEREDBLOCKEVENT"
+	CHAINCODEEVENT     = "CHAINCODEEVENT"
 )
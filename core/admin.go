@@ -19,7 +19,13 @@ package core
 import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	"github.com/hyperledger/fabric/gossip/service"
+	gossipproto "github.com/hyperledger/fabric/protos/gossip"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
 	"golang.org/x/net/context"
 )
 
@@ -27,12 +33,39 @@ var logger = flogging.MustGetLogger("server")
 
 // NewAdminServer creates and returns a Admin service instance.
 func NewAdminServer() *ServerAdmin {
-	s := new(ServerAdmin)
+	s := &ServerAdmin{
+		membershipProvider: func() MembershipProvider {
+			return service.GetGossipService()
+		},
+		leadershipProvider: func() LeadershipProvider {
+			return service.GetGossipService()
+		},
+	}
 	return s
 }
 
+// MembershipProvider provides the view of the gossip layer
+// of the peer on the membership of its channels
+type MembershipProvider interface {
+	// PeersOfChannel returns the NetworkMembers considered alive
+	// and also subscribed to the channel given
+	PeersOfChannel(common.ChainID) []discovery.NetworkMember
+
+	// SelfChannelInfo returns the peer's latest StateInfo message of a given channel
+	SelfChannelInfo(common.ChainID) *gossipproto.SignedGossipMessage
+}
+
+// LeadershipProvider manages the leadership of the
+// peer over the delivery of blocks of its channels
+type LeadershipProvider interface {
+	// YieldLeadership makes the peer relinquish its leadership of the given channel
+	YieldLeadership(chainID string) error
+}
+
 // ServerAdmin implementation of the Admin service for the Peer
 type ServerAdmin struct {
+	membershipProvider func() MembershipProvider
+	leadershipProvider func() LeadershipProvider
 }
 
 // GetStatus reports the status of the server
@@ -71,3 +104,62 @@ func (*ServerAdmin) RevertLogLevels(context.Context, *empty.Empty) (*empty.Empty
 
 	return &empty.Empty{}, err
 }
+
+// GetChannelMembership returns the peers of the requested channel
+// along with the properties they publish, as seen by this peer
+func (s *ServerAdmin) GetChannelMembership(ctx context.Context, request *pb.ChannelMembershipRequest) (*pb.ChannelMembershipResponse, error) {
+	if request.ChannelId == "" {
+		return nil, errors.New("channel ID must be provided")
+	}
+	mp := s.membershipProvider()
+	chainID := common.ChainID(request.ChannelId)
+	self := mp.SelfChannelInfo(chainID)
+	if self == nil {
+		return nil, errors.Errorf("peer is not a member of channel %s", request.ChannelId)
+	}
+
+	selfInfo := peerMembershipInfo(self.GetStateInfo().PkiId, self.GetStateInfo().Properties)
+	selfInfo.Self = true
+	if endpoint, err := peer.GetPeerEndpoint(); err == nil && endpoint != nil {
+		selfInfo.Endpoint = endpoint.Address
+	}
+	response := &pb.ChannelMembershipResponse{
+		Peers: []*pb.PeerMembershipInfo{selfInfo},
+	}
+	for _, member := range mp.PeersOfChannel(chainID) {
+		info := peerMembershipInfo(member.PKIid, member.Properties)
+		info.Endpoint = member.Endpoint
+		response.Peers = append(response.Peers, info)
+	}
+	return response, nil
+}
+
+// YieldLeadership makes the peer relinquish its leadership of the requested
+// channel, so that another peer of its organization is elected as a leader
+func (s *ServerAdmin) YieldLeadership(ctx context.Context, request *pb.YieldLeadershipRequest) (*empty.Empty, error) {
+	if request.ChannelId == "" {
+		return nil, errors.New("channel ID must be provided")
+	}
+	if err := s.leadershipProvider().YieldLeadership(request.ChannelId); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func peerMembershipInfo(pkiID common.PKIidType, props *gossipproto.Properties) *pb.PeerMembershipInfo {
+	return &pb.PeerMembershipInfo{
+		PkiId:               pkiID,
+		LedgerHeight:        props.GetLedgerHeight(),
+		Chaincodes:          chaincodeInfos(props.GetChaincodes()),
+		InstalledChaincodes: chaincodeInfos(props.GetInstalledChaincodes()),
+		Roles:               props.GetRoles(),
+	}
+}
+
+func chaincodeInfos(chaincodes []*gossipproto.Chaincode) []*pb.ChaincodeInfo {
+	var infos []*pb.ChaincodeInfo
+	for _, cc := range chaincodes {
+		infos = append(infos, &pb.ChaincodeInfo{Name: cc.Name, Version: cc.Version})
+	}
+	return infos
+}
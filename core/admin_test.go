@@ -23,7 +23,11 @@ import (
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/testutil"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	gossipproto "github.com/hyperledger/fabric/protos/gossip"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -68,3 +72,114 @@ func TestLoggingCalls(t *testing.T) {
 	assert.Equal(t, flogging.DefaultLevel(), logResponse.LogLevel, "logger level should have been the default")
 	assert.Nil(t, err, "Error should have been nil")
 }
+
+type mockMembershipProvider struct {
+	peers []discovery.NetworkMember
+	self  *gossipproto.SignedGossipMessage
+}
+
+func (mp *mockMembershipProvider) PeersOfChannel(common.ChainID) []discovery.NetworkMember {
+	return mp.peers
+}
+
+func (mp *mockMembershipProvider) SelfChannelInfo(chainID common.ChainID) *gossipproto.SignedGossipMessage {
+	if string(chainID) != "mychannel" {
+		return nil
+	}
+	return mp.self
+}
+
+func TestGetChannelMembership(t *testing.T) {
+	mp := &mockMembershipProvider{
+		self: &gossipproto.SignedGossipMessage{
+			GossipMessage: &gossipproto.GossipMessage{
+				Content: &gossipproto.GossipMessage_StateInfo{
+					StateInfo: &gossipproto.StateInfo{
+						PkiId: []byte("p0"),
+						Properties: &gossipproto.Properties{
+							LedgerHeight:        5,
+							InstalledChaincodes: []*gossipproto.Chaincode{{Name: "cc1", Version: "1.0"}},
+							Roles:               []string{"endorser"},
+						},
+					},
+				},
+			},
+		},
+		peers: []discovery.NetworkMember{
+			{
+				Endpoint: "p1:7051",
+				PKIid:    common.PKIidType("p1"),
+				Properties: &gossipproto.Properties{
+					LedgerHeight: 4,
+					Chaincodes:   []*gossipproto.Chaincode{{Name: "cc1", Version: "1.0"}},
+				},
+			},
+			{
+				Endpoint: "p2:7051",
+				PKIid:    common.PKIidType("p2"),
+			},
+		},
+	}
+	adminServer := &ServerAdmin{
+		membershipProvider: func() MembershipProvider {
+			return mp
+		},
+	}
+
+	_, err := adminServer.GetChannelMembership(context.Background(), &pb.ChannelMembershipRequest{})
+	assert.EqualError(t, err, "channel ID must be provided")
+
+	_, err = adminServer.GetChannelMembership(context.Background(), &pb.ChannelMembershipRequest{ChannelId: "otherchannel"})
+	assert.EqualError(t, err, "peer is not a member of channel otherchannel")
+
+	response, err := adminServer.GetChannelMembership(context.Background(), &pb.ChannelMembershipRequest{ChannelId: "mychannel"})
+	assert.NoError(t, err)
+	assert.Len(t, response.Peers, 3)
+
+	self := response.Peers[0]
+	assert.True(t, self.Self)
+	assert.Equal(t, []byte("p0"), self.PkiId)
+	assert.Equal(t, uint64(5), self.LedgerHeight)
+	assert.Equal(t, []*pb.ChaincodeInfo{{Name: "cc1", Version: "1.0"}}, self.InstalledChaincodes)
+	assert.Equal(t, []string{"endorser"}, self.Roles)
+
+	assert.Equal(t, &pb.PeerMembershipInfo{
+		Endpoint:     "p1:7051",
+		PkiId:        []byte("p1"),
+		LedgerHeight: 4,
+		Chaincodes:   []*pb.ChaincodeInfo{{Name: "cc1", Version: "1.0"}},
+	}, response.Peers[1])
+	assert.Equal(t, &pb.PeerMembershipInfo{Endpoint: "p2:7051", PkiId: []byte("p2")}, response.Peers[2])
+}
+
+type mockLeadershipProvider struct {
+	yielded []string
+}
+
+func (lp *mockLeadershipProvider) YieldLeadership(chainID string) error {
+	if chainID != "mychannel" {
+		return errors.Errorf("peer is not a leader of channel %s", chainID)
+	}
+	lp.yielded = append(lp.yielded, chainID)
+	return nil
+}
+
+func TestYieldLeadership(t *testing.T) {
+	lp := &mockLeadershipProvider{}
+	adminServer := &ServerAdmin{
+		leadershipProvider: func() LeadershipProvider {
+			return lp
+		},
+	}
+
+	_, err := adminServer.YieldLeadership(context.Background(), &pb.YieldLeadershipRequest{})
+	assert.EqualError(t, err, "channel ID must be provided")
+
+	_, err = adminServer.YieldLeadership(context.Background(), &pb.YieldLeadershipRequest{ChannelId: "otherchannel"})
+	assert.EqualError(t, err, "peer is not a leader of channel otherchannel")
+
+	response, err := adminServer.YieldLeadership(context.Background(), &pb.YieldLeadershipRequest{ChannelId: "mychannel"})
+	assert.NoError(t, err)
+	assert.NotNil(t, response)
+	assert.Equal(t, []string{"mychannel"}, lp.yielded)
+}
@@ -28,6 +28,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/core/chaincode/accesscontrol"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
@@ -799,9 +800,17 @@ func (chaincodeSupport *ChaincodeSupport) Execute(ctxt context.Context, cccid *c
 	}
 	chaincodeSupport.runningChaincodes.Unlock()
 
+	span, ctxt := tracing.StartSpan(ctxt, "chaincode.Execute",
+		tracing.Tag("chaincode", cccid.Name),
+		tracing.Tag("channel", cccid.ChainID),
+		tracing.Tag("txid", msg.Txid),
+		tracing.Tag("type", msg.Type))
+	defer span.Finish()
+
 	var notfy chan *pb.ChaincodeMessage
 	var err error
 	if notfy, err = chrte.handler.sendExecuteMessage(ctxt, cccid.ChainID, msg, cccid.SignedProposal, cccid.Proposal); err != nil {
+		span.SetError(err)
 		return nil, errors.WithMessage(err, fmt.Sprintf("error sending"))
 	}
 	var ccresp *pb.ChaincodeMessage
@@ -809,8 +818,12 @@ func (chaincodeSupport *ChaincodeSupport) Execute(ctxt context.Context, cccid *c
 	case ccresp = <-notfy:
 		//response is sent to user or calling chaincode. ChaincodeMessage_ERROR
 		//are typically treated as error
+		if ccresp.Type == pb.ChaincodeMessage_ERROR {
+			span.SetError(errors.New(string(ccresp.Payload)))
+		}
 	case <-time.After(timeout):
 		err = errors.New("timeout expired while executing transaction")
+		span.SetError(err)
 	}
 
 	//our responsibility to delete transaction context if sendExecuteMessage succeeded
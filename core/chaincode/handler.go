@@ -16,6 +16,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/aclmgmt"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
@@ -90,6 +91,9 @@ type Handler struct {
 
 	// used to do Send after making sure the state transition is complete
 	nextState chan *nextStateInfo
+
+	// spans of the pending requests of the chaincode, by transaction
+	requestSpans map[string]*tracing.Span
 }
 
 func shorttxid(txid string) string {
@@ -146,6 +150,8 @@ func (handler *Handler) serialSend(msg *pb.ChaincodeMessage) error {
 	handler.serialLock.Lock()
 	defer handler.serialLock.Unlock()
 
+	handler.finishRequestSpan(msg)
+
 	var err error
 	if err = handler.ChatStream.Send(msg); err != nil {
 		err = errors.WithMessage(err, fmt.Sprintf("[%s]Error sending %s", shorttxid(msg.Txid), msg.Type.String()))
@@ -421,6 +427,7 @@ func newChaincodeSupportHandler(chaincodeSupport *ChaincodeSupport, peerChatStre
 			{Name: pb.ChaincodeMessage_INVOKE_CHAINCODE.String(), Src: []string{readystate}, Dst: readystate},
 			{Name: pb.ChaincodeMessage_COMPLETED.String(), Src: []string{readystate}, Dst: readystate},
 			{Name: pb.ChaincodeMessage_GET_STATE.String(), Src: []string{readystate}, Dst: readystate},
+			{Name: pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH.String(), Src: []string{readystate}, Dst: readystate},
 			{Name: pb.ChaincodeMessage_GET_STATE_BY_RANGE.String(), Src: []string{readystate}, Dst: readystate},
 			{Name: pb.ChaincodeMessage_GET_QUERY_RESULT.String(), Src: []string{readystate}, Dst: readystate},
 			{Name: pb.ChaincodeMessage_GET_HISTORY_FOR_KEY.String(), Src: []string{readystate}, Dst: readystate},
@@ -432,20 +439,21 @@ func newChaincodeSupportHandler(chaincodeSupport *ChaincodeSupport, peerChatStre
 			{Name: pb.ChaincodeMessage_TRANSACTION.String(), Src: []string{readystate}, Dst: readystate},
 		},
 		fsm.Callbacks{
-			"before_" + pb.ChaincodeMessage_REGISTER.String():           func(e *fsm.Event) { v.beforeRegisterEvent(e, v.FSM.Current()) },
-			"before_" + pb.ChaincodeMessage_COMPLETED.String():          func(e *fsm.Event) { v.beforeCompletedEvent(e, v.FSM.Current()) },
-			"after_" + pb.ChaincodeMessage_GET_STATE.String():           func(e *fsm.Event) { v.afterGetState(e, v.FSM.Current()) },
-			"after_" + pb.ChaincodeMessage_GET_STATE_BY_RANGE.String():  func(e *fsm.Event) { v.afterGetStateByRange(e, v.FSM.Current()) },
-			"after_" + pb.ChaincodeMessage_GET_QUERY_RESULT.String():    func(e *fsm.Event) { v.afterGetQueryResult(e, v.FSM.Current()) },
-			"after_" + pb.ChaincodeMessage_GET_HISTORY_FOR_KEY.String(): func(e *fsm.Event) { v.afterGetHistoryForKey(e, v.FSM.Current()) },
-			"after_" + pb.ChaincodeMessage_QUERY_STATE_NEXT.String():    func(e *fsm.Event) { v.afterQueryStateNext(e, v.FSM.Current()) },
-			"after_" + pb.ChaincodeMessage_QUERY_STATE_CLOSE.String():   func(e *fsm.Event) { v.afterQueryStateClose(e, v.FSM.Current()) },
-			"after_" + pb.ChaincodeMessage_PUT_STATE.String():           func(e *fsm.Event) { v.enterBusyState(e, v.FSM.Current()) },
-			"after_" + pb.ChaincodeMessage_DEL_STATE.String():           func(e *fsm.Event) { v.enterBusyState(e, v.FSM.Current()) },
-			"after_" + pb.ChaincodeMessage_INVOKE_CHAINCODE.String():    func(e *fsm.Event) { v.enterBusyState(e, v.FSM.Current()) },
-			"enter_" + establishedstate:                                 func(e *fsm.Event) { v.enterEstablishedState(e, v.FSM.Current()) },
-			"enter_" + readystate:                                       func(e *fsm.Event) { v.enterReadyState(e, v.FSM.Current()) },
-			"enter_" + endstate:                                         func(e *fsm.Event) { v.enterEndState(e, v.FSM.Current()) },
+			"before_" + pb.ChaincodeMessage_REGISTER.String():             func(e *fsm.Event) { v.beforeRegisterEvent(e, v.FSM.Current()) },
+			"before_" + pb.ChaincodeMessage_COMPLETED.String():            func(e *fsm.Event) { v.beforeCompletedEvent(e, v.FSM.Current()) },
+			"after_" + pb.ChaincodeMessage_GET_STATE.String():             func(e *fsm.Event) { v.afterGetState(e, v.FSM.Current()) },
+			"after_" + pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH.String(): func(e *fsm.Event) { v.afterGetPrivateDataHash(e, v.FSM.Current()) },
+			"after_" + pb.ChaincodeMessage_GET_STATE_BY_RANGE.String():    func(e *fsm.Event) { v.afterGetStateByRange(e, v.FSM.Current()) },
+			"after_" + pb.ChaincodeMessage_GET_QUERY_RESULT.String():      func(e *fsm.Event) { v.afterGetQueryResult(e, v.FSM.Current()) },
+			"after_" + pb.ChaincodeMessage_GET_HISTORY_FOR_KEY.String():   func(e *fsm.Event) { v.afterGetHistoryForKey(e, v.FSM.Current()) },
+			"after_" + pb.ChaincodeMessage_QUERY_STATE_NEXT.String():      func(e *fsm.Event) { v.afterQueryStateNext(e, v.FSM.Current()) },
+			"after_" + pb.ChaincodeMessage_QUERY_STATE_CLOSE.String():     func(e *fsm.Event) { v.afterQueryStateClose(e, v.FSM.Current()) },
+			"after_" + pb.ChaincodeMessage_PUT_STATE.String():             func(e *fsm.Event) { v.enterBusyState(e, v.FSM.Current()) },
+			"after_" + pb.ChaincodeMessage_DEL_STATE.String():             func(e *fsm.Event) { v.enterBusyState(e, v.FSM.Current()) },
+			"after_" + pb.ChaincodeMessage_INVOKE_CHAINCODE.String():      func(e *fsm.Event) { v.enterBusyState(e, v.FSM.Current()) },
+			"enter_" + establishedstate:                                   func(e *fsm.Event) { v.enterEstablishedState(e, v.FSM.Current()) },
+			"enter_" + readystate:                                         func(e *fsm.Event) { v.enterReadyState(e, v.FSM.Current()) },
+			"enter_" + endstate:                                           func(e *fsm.Event) { v.enterEndState(e, v.FSM.Current()) },
 		},
 	)
 
@@ -583,6 +591,20 @@ func (handler *Handler) afterGetState(e *fsm.Event, state string) {
 	handler.handleGetState(msg)
 }
 
+// afterGetPrivateDataHash handles a GET_PRIVATE_DATA_HASH request from the chaincode.
+func (handler *Handler) afterGetPrivateDataHash(e *fsm.Event, state string) {
+	msg, ok := e.Args[0].(*pb.ChaincodeMessage)
+	if !ok {
+		e.Cancel(errors.New("received unexpected message type"))
+		return
+	}
+	chaincodeLogger.Debugf("[%s]Received %s, invoking get private data hash from ledger", shorttxid(msg.Txid), pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH)
+
+	// Query ledger for the hash of the private data, which is handled as a
+	// query for state whose value is the hash
+	handler.handleGetState(msg)
+}
+
 // is this a txid for which there is a valid txsim
 func (handler *Handler) isValidTxSim(channelID string, txid string, fmtStr string, args ...interface{}) (*transactionContext, *pb.ChaincodeMessage) {
 	txContext := handler.getTxContext(channelID, txid)
@@ -592,6 +614,13 @@ func (handler *Handler) isValidTxSim(channelID string, txid string, fmtStr strin
 		chaincodeLogger.Errorf(errStr)
 		return nil, &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, Payload: []byte(errStr), Txid: txid, ChannelId: channelID}
 	}
+	// make the reads of the ledger within the span of the request, so that
+	// the requests to the state database are traced as part of the transaction
+	if setter, ok := txContext.txsimulator.(ledger.ContextSetter); ok {
+		if span := handler.requestSpan(channelID, txid); span != nil {
+			setter.SetContext(tracing.ContextWithSpan(context.Background(), span))
+		}
+	}
 	return txContext, nil
 }
 
@@ -637,7 +666,13 @@ func (handler *Handler) handleGetState(msg *pb.ChaincodeMessage) {
 
 		var res []byte
 		var err error
-		if isCollectionSet(getState.Collection) {
+		if msg.Type == pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH {
+			if !isCollectionSet(getState.Collection) {
+				err = errors.New("collection must not be an empty string")
+			} else {
+				res, err = txContext.txsimulator.GetPrivateDataHash(chaincodeID, getState.Collection, getState.Key)
+			}
+		} else if isCollectionSet(getState.Collection) {
 			res, err = txContext.txsimulator.GetPrivateData(chaincodeID, getState.Collection, getState.Key)
 		} else {
 			res, err = txContext.txsimulator.GetState(chaincodeID, getState.Key)
@@ -1307,7 +1342,7 @@ func (handler *Handler) enterBusyState(e *fsm.Event, state string) {
 
 			// Set up a new context for the called chaincode if on a different channel
 			// We grab the called channel's ledger simulator to hold the new state
-			ctxt := context.Background()
+			ctxt := tracing.ContextWithSpan(context.Background(), handler.requestSpan(msg.ChannelId, msg.Txid))
 			txsim := txContext.txsimulator
 			historyQueryExecutor := txContext.historyQueryExecutor
 			if calledCcIns.ChainID != txContext.chainID {
@@ -1477,6 +1512,9 @@ func (handler *Handler) handleMessage(msg *pb.ChaincodeMessage) error {
 		// Other errors
 		return errors.Errorf("[%s]Chaincode handler validator FSM cannot handle message (%s) with payload size (%d) while in state: %s", msg.Txid, msg.Type.String(), len(msg.Payload), handler.FSM.Current())
 	}
+	// the span is finished once the request is answered, so it is only started
+	// for the messages which the FSM handles
+	handler.startRequestSpan(msg)
 	eventErr := handler.FSM.Event(msg.Type.String(), msg)
 	filteredErr := filterError(eventErr)
 	if filteredErr != nil {
@@ -1514,6 +1552,9 @@ func (handler *Handler) sendExecuteMessage(ctxt context.Context, chainID string,
 	}
 	chaincodeLogger.Debugf("[%s]Inside sendExecuteMessage. Message %s", shorttxid(msg.Txid), msg.Type.String())
 
+	// the chaincode echoes the span context on its requests, so that they join the trace
+	msg.TraceParent = tracing.TraceParentFromContext(ctxt)
+
 	//if security is disabled the context elements will just be nil
 	if err = handler.setChaincodeProposal(signedProp, prop, msg); err != nil {
 		return nil, err
@@ -0,0 +1,348 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cid
+
+import (
+	"reflect"
+	"regexp"
+
+	"github.com/Knetic/govaluate"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+var (
+	regexRole  = regexp.MustCompile("^([[:alnum:].-]+)[.](member|admin|client|peer)$")
+	regexOU    = regexp.MustCompile("^([[:alnum:].-]+)[.]OU=([^']+)$")
+	regexAttr  = regexp.MustCompile("^attr:([^=]+?)(==(.*))?$")
+	regexNoArg = regexp.MustCompile("^No parameter '([^']+)' found[.]$")
+)
+
+// NodeOUs are the organizational units which the certificates of clients and
+// peers carry when their MSP classifies identities with NodeOUs
+type NodeOUs struct {
+	ClientOUIdentifier string
+	PeerOUIdentifier   string
+}
+
+// DefaultNodeOUs are the organizational units of clients and peers issued by
+// the default configuration of Fabric CA and cryptogen
+var DefaultNodeOUs = NodeOUs{ClientOUIdentifier: "client", PeerOUIdentifier: "peer"}
+
+// Rule is an access control rule a client identity satisfies or not. Rules are
+// expressed in the syntax of the signature policies of cauthdsl:
+//
+// GATE(P[, P])
+//
+// where
+//	- GATE is either AND, OR or OutOf(N, ...)
+//	- P is either a condition or another nested call to GATE
+//
+// a condition is either of
+//
+// ORG.ROLE
+// ORG.OU=UNIT
+// attr:NAME==VALUE
+// attr:NAME
+//
+// where
+//	- ORG is the MSP ID of the client
+//	- ROLE is either "member", "admin", "client" or "peer". Clients identified by an
+//	  X509 certificate are clients or peers when the certificate carries the OU of
+//	  clients or peers of the NodeOUs, and only idemix identities disclose whether
+//	  the client is an admin
+//	- UNIT is an organizational unit of the client
+//	- NAME and VALUE are the name and the value of an attribute of the client
+//	  (attr:NAME is satisfied by any value)
+type Rule struct {
+	expression string
+	root       condition
+}
+
+type condition interface {
+	satisfiedBy(c *clientIdentityImpl, nodeOUs NodeOUs) bool
+}
+
+// NewRule compiles a rule
+func NewRule(expression string) (*Rule, error) {
+	// gateFunc builds the function of a gate, given how it gets its threshold
+	gateFunc := func(threshold func(args []interface{}) (int, []interface{}, error)) govaluate.ExpressionFunction {
+		return func(args ...interface{}) (interface{}, error) {
+			t, args, err := threshold(args)
+			if err != nil {
+				return nil, err
+			}
+			return newGate(t, args)
+		}
+	}
+	and := gateFunc(func(args []interface{}) (int, []interface{}, error) { return len(args), args, nil })
+	or := gateFunc(func(args []interface{}) (int, []interface{}, error) { return 1, args, nil })
+	outOf := gateFunc(func(args []interface{}) (int, []interface{}, error) {
+		if len(args) < 2 {
+			return 0, nil, errors.Errorf("expected at least two arguments to OutOf, got %d", len(args))
+		}
+		t, ok := args[0].(float64)
+		if !ok {
+			return 0, nil, errors.Errorf("expected a number as first argument to OutOf, got %s", reflect.TypeOf(args[0]))
+		}
+		return int(t), args[1:], nil
+	})
+
+	exp, err := govaluate.NewEvaluableExpressionWithFunctions(expression, map[string]govaluate.ExpressionFunction{
+		"AND": and, "and": and, "OR": or, "or": or, "OUTOF": outOf, "outof": outOf, "OutOf": outOf,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed parsing rule %s", expression)
+	}
+	res, err := exp.Evaluate(map[string]interface{}{})
+	if err != nil {
+		if subm := regexNoArg.FindStringSubmatch(err.Error()); subm != nil {
+			return nil, errors.Errorf("unrecognized token '%s' in rule %s", subm[1], expression)
+		}
+		return nil, errors.Wrapf(err, "failed parsing rule %s", expression)
+	}
+	root, err := toCondition(res)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed parsing rule "+expression)
+	}
+	return &Rule{expression: expression, root: root}, nil
+}
+
+// String returns the expression of the rule
+func (r *Rule) String() string {
+	return r.expression
+}
+
+// SatisfiedBy returns whether the client submitting the transaction satisfies
+// the rule, assuming that its MSP classifies identities with the DefaultNodeOUs
+func (r *Rule) SatisfiedBy(stub ChaincodeStubInterface) (bool, error) {
+	c, err := newClientIdentity(stub)
+	if err != nil {
+		return false, err
+	}
+	return r.root.satisfiedBy(c, DefaultNodeOUs), nil
+}
+
+// toCondition converts an argument of a gate to a condition
+func toCondition(arg interface{}) (condition, error) {
+	switch t := arg.(type) {
+	case condition:
+		return t, nil
+	case string:
+		return parseCondition(t)
+	default:
+		return nil, errors.Errorf("unexpected argument of type %s", reflect.TypeOf(arg))
+	}
+}
+
+func parseCondition(s string) (condition, error) {
+	if subm := regexRole.FindStringSubmatch(s); subm != nil {
+		return &roleCondition{mspID: subm[1], role: subm[2]}, nil
+	}
+	if subm := regexOU.FindStringSubmatch(s); subm != nil {
+		return &ouCondition{mspID: subm[1], ou: subm[2]}, nil
+	}
+	if subm := regexAttr.FindStringSubmatch(s); subm != nil {
+		return &attrCondition{name: subm[1], value: subm[3], anyValue: subm[2] == ""}, nil
+	}
+	return nil, errors.Errorf("unrecognized condition '%s'", s)
+}
+
+type gate struct {
+	t          int
+	conditions []condition
+}
+
+func newGate(t int, args []interface{}) (*gate, error) {
+	if t < 0 || t > len(args) {
+		return nil, errors.Errorf("invalid t-out-of-n gate, t %d, n %d", t, len(args))
+	}
+	g := &gate{t: t}
+	for _, arg := range args {
+		c, err := toCondition(arg)
+		if err != nil {
+			return nil, err
+		}
+		g.conditions = append(g.conditions, c)
+	}
+	return g, nil
+}
+
+func (g *gate) satisfiedBy(c *clientIdentityImpl, nodeOUs NodeOUs) bool {
+	satisfied := 0
+	for _, condition := range g.conditions {
+		if condition.satisfiedBy(c, nodeOUs) {
+			satisfied++
+		}
+		if satisfied >= g.t {
+			return true
+		}
+	}
+	return satisfied >= g.t
+}
+
+type roleCondition struct {
+	mspID string
+	role  string
+}
+
+func (rc *roleCondition) satisfiedBy(c *clientIdentityImpl, nodeOUs NodeOUs) bool {
+	if c.mspID != rc.mspID {
+		return false
+	}
+	switch rc.role {
+	case "member":
+		return true
+	case "admin":
+		return c.idemixRole != nil && c.idemixRole.Role == msp.MSPRole_ADMIN
+	case "client":
+		if c.idemixRole != nil {
+			return c.idemixRole.Role == msp.MSPRole_CLIENT
+		}
+		return hasOU(c, nodeOUs.ClientOUIdentifier)
+	case "peer":
+		if c.idemixRole != nil {
+			return c.idemixRole.Role == msp.MSPRole_PEER
+		}
+		return hasOU(c, nodeOUs.PeerOUIdentifier)
+	default:
+		return false
+	}
+}
+
+type ouCondition struct {
+	mspID string
+	ou    string
+}
+
+func (oc *ouCondition) satisfiedBy(c *clientIdentityImpl, _ NodeOUs) bool {
+	return c.mspID == oc.mspID && hasOU(c, oc.ou)
+}
+
+func hasOU(c *clientIdentityImpl, ou string) bool {
+	if ou == "" {
+		return false
+	}
+	ous, _ := c.GetOUs()
+	for _, o := range ous {
+		if o == ou {
+			return true
+		}
+	}
+	return false
+}
+
+type attrCondition struct {
+	name     string
+	value    string
+	anyValue bool
+}
+
+func (ac *attrCondition) satisfiedBy(c *clientIdentityImpl, _ NodeOUs) bool {
+	value, found, err := c.GetAttributeValue(ac.name)
+	if err != nil || !found {
+		return false
+	}
+	return ac.anyValue || value == ac.value
+}
+
+// AccessControl maps the functions of a chaincode to the rules the clients
+// invoking them must satisfy
+type AccessControl struct {
+	rules       map[string]*Rule
+	defaultRule *Rule
+	nodeOUs     NodeOUs
+}
+
+// Option configures an AccessControl
+type Option func(*AccessControl) error
+
+// WithDefaultRule sets the rule of the functions which don't have a rule of
+// their own. Without a default rule, such functions can be invoked by anyone
+func WithDefaultRule(expression string) Option {
+	return func(ac *AccessControl) error {
+		rule, err := NewRule(expression)
+		if err != nil {
+			return errors.WithMessage(err, "invalid default rule")
+		}
+		ac.defaultRule = rule
+		return nil
+	}
+}
+
+// WithNodeOUs sets the organizational units of clients and peers, when they
+// differ from the DefaultNodeOUs
+func WithNodeOUs(nodeOUs NodeOUs) Option {
+	return func(ac *AccessControl) error {
+		ac.nodeOUs = nodeOUs
+		return nil
+	}
+}
+
+// NewAccessControl compiles the rules of the functions of a chaincode
+func NewAccessControl(rules map[string]string, opts ...Option) (*AccessControl, error) {
+	ac := &AccessControl{
+		rules:   map[string]*Rule{},
+		nodeOUs: DefaultNodeOUs,
+	}
+	for function, expression := range rules {
+		rule, err := NewRule(expression)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid rule of function "+function)
+		}
+		ac.rules[function] = rule
+	}
+	for _, opt := range opts {
+		if err := opt(ac); err != nil {
+			return nil, err
+		}
+	}
+	return ac, nil
+}
+
+// CheckAccess returns an error unless the client submitting the transaction
+// satisfies the rule of the invoked function
+func (ac *AccessControl) CheckAccess(stub ChaincodeStubInterface, function string) error {
+	rule, exists := ac.rules[function]
+	if !exists {
+		rule = ac.defaultRule
+	}
+	if rule == nil {
+		return nil
+	}
+	c, err := newClientIdentity(stub)
+	if err != nil {
+		return err
+	}
+	if !rule.root.satisfiedBy(c, ac.nodeOUs) {
+		return errors.Errorf("access denied: the client of MSP %s does not satisfy the rule %s of function %s", c.mspID, rule, function)
+	}
+	return nil
+}
+
+// WithAccessControl wraps a chaincode so that each invocation is dispatched to
+// it only once the client satisfies the rule of the invoked function. Init is
+// not checked, since instantiation is governed by the instantiation policy
+func WithAccessControl(cc shim.Chaincode, ac *AccessControl) shim.Chaincode {
+	return &accessControlledChaincode{Chaincode: cc, ac: ac}
+}
+
+type accessControlledChaincode struct {
+	shim.Chaincode
+	ac *AccessControl
+}
+
+// Invoke checks the access of the client before invoking the wrapped chaincode
+func (cc *accessControlledChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	function, _ := stub.GetFunctionAndParameters()
+	if err := cc.ac.CheckAccess(stub, function); err != nil {
+		return shim.Error(err.Error())
+	}
+	return cc.Chaincode.Invoke(stub)
+}
@@ -0,0 +1,235 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cid_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/chaincode/lib/cid"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+// newX509Creator returns a serialized identity of the MSP, whose certificate has
+// the OUs and carries the attributes, formatted as by Fabric CA
+func newX509Creator(t *testing.T, mspID string, ous []string, attrs string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "user", OrganizationalUnit: ous},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	if attrs != "" {
+		template.ExtraExtensions = []pkix.Extension{{Id: asn1.ObjectIdentifier{1, 2, 3, 4, 5, 6, 7, 8, 1}, Value: []byte(attrs)}}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	return marshalIdentity(t, mspID, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+// newIdemixCreator returns a serialized idemix identity of the MSP disclosing the OU and the role
+func newIdemixCreator(t *testing.T, mspID string, ou string, role msp.MSPRole_MSPRoleType) []byte {
+	ouBytes, err := proto.Marshal(&msp.OrganizationUnit{MspIdentifier: mspID, OrganizationalUnitIdentifier: ou})
+	assert.NoError(t, err)
+	roleBytes, err := proto.Marshal(&msp.MSPRole{MspIdentifier: mspID, Role: role})
+	assert.NoError(t, err)
+	idBytes, err := proto.Marshal(&msp.SerializedIdemixIdentity{
+		NymX:  []byte("x"),
+		NymY:  []byte("y"),
+		OU:    ouBytes,
+		Role:  roleBytes,
+		Proof: []byte("proof"),
+	})
+	assert.NoError(t, err)
+	return marshalIdentity(t, mspID, idBytes)
+}
+
+func marshalIdentity(t *testing.T, mspID string, idBytes []byte) []byte {
+	b, err := proto.Marshal(&msp.SerializedIdentity{Mspid: mspID, IdBytes: idBytes})
+	assert.NoError(t, err)
+	return b
+}
+
+func TestIdemixClient(t *testing.T) {
+	stub := &mockStub{creator: newIdemixCreator(t, "Org1MSP", "accounting", msp.MSPRole_ADMIN)}
+	c, err := cid.New(stub)
+	assert.NoError(t, err)
+	mspID, err := c.GetMSPID()
+	assert.NoError(t, err)
+	assert.Equal(t, "Org1MSP", mspID)
+	ous, err := cid.GetOUs(stub)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"accounting"}, ous)
+	cert, err := c.GetX509Certificate()
+	assert.NoError(t, err)
+	assert.Nil(t, cert)
+	_, err = c.GetID()
+	assert.Error(t, err)
+	_, found, err := c.GetAttributeValue("role")
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestRules(t *testing.T) {
+	auditor := &mockStub{creator: newX509Creator(t, "Org1MSP", []string{"client", "accounting"}, `{"attrs":{"role":"auditor"}}`)}
+	peer := &mockStub{creator: newX509Creator(t, "Org1MSP", []string{"peer"}, "")}
+	org2 := &mockStub{creator: newX509Creator(t, "Org2MSP", []string{"client"}, `{"attrs":{"role":"auditor"}}`)}
+	idemixAdmin := &mockStub{creator: newIdemixCreator(t, "Org1MSP", "accounting", msp.MSPRole_ADMIN)}
+	idemixMember := &mockStub{creator: newIdemixCreator(t, "Org1MSP", "sales", msp.MSPRole_MEMBER)}
+
+	for _, test := range []struct {
+		rule      string
+		satisfied []*mockStub
+	}{
+		{"'Org1MSP.member'", []*mockStub{auditor, peer, idemixAdmin, idemixMember}},
+		{"'Org1MSP.client'", []*mockStub{auditor}},
+		{"'Org1MSP.peer'", []*mockStub{peer}},
+		{"'Org1MSP.admin'", []*mockStub{idemixAdmin}},
+		{"'Org1MSP.OU=accounting'", []*mockStub{auditor, idemixAdmin}},
+		{"'attr:role==auditor'", []*mockStub{auditor, org2}},
+		{"'attr:role'", []*mockStub{auditor, org2}},
+		{"'attr:role==admin'", nil},
+		{"AND('Org1MSP.member', 'attr:role==auditor')", []*mockStub{auditor}},
+		{"OR('Org1MSP.admin', 'Org2MSP.client')", []*mockStub{idemixAdmin, org2}},
+		{"OutOf(2, 'Org1MSP.OU=accounting', 'Org1MSP.client', 'attr:role')", []*mockStub{auditor}},
+		{"or(and('Org1MSP.peer'), outof(1, 'Org1MSP.OU=sales'))", []*mockStub{peer, idemixMember}},
+	} {
+		rule, err := cid.NewRule(test.rule)
+		assert.NoError(t, err, test.rule)
+		assert.Equal(t, test.rule, rule.String())
+		for _, stub := range []*mockStub{auditor, peer, org2, idemixAdmin, idemixMember} {
+			satisfied, err := rule.SatisfiedBy(stub)
+			assert.NoError(t, err)
+			assert.Equal(t, contains(test.satisfied, stub), satisfied, "rule %s", test.rule)
+		}
+	}
+
+	rule, err := cid.NewRule("'Org1MSP.member'")
+	assert.NoError(t, err)
+	_, err = rule.SatisfiedBy(&mockStub{creator: []byte("foo")})
+	assert.Error(t, err)
+}
+
+func contains(stubs []*mockStub, stub *mockStub) bool {
+	for _, s := range stubs {
+		if s == stub {
+			return true
+		}
+	}
+	return false
+}
+
+func TestBadRules(t *testing.T) {
+	for rule, expectedErr := range map[string]string{
+		"AND(member)":                          "unrecognized token 'member' in rule AND(member)",
+		"'Org1MSP.orderer'":                    "failed parsing rule 'Org1MSP.orderer': unrecognized condition 'Org1MSP.orderer'",
+		"'/Channel/Application/Admins'":        "failed parsing rule '/Channel/Application/Admins': unrecognized condition '/Channel/Application/Admins'",
+		"OutOf(3, 'Org1MSP.member', 'attr:a')": "invalid t-out-of-n gate, t 3, n 2",
+		"OutOf('Org1MSP.member', 'attr:a')":    "expected a number as first argument to OutOf, got string",
+		"OutOf(1)":                             "expected at least two arguments to OutOf, got 1",
+		"AND('Org1MSP.member', 3)":             "unexpected argument of type float64",
+		"AND('Org1MSP.member'":                 "failed parsing rule AND('Org1MSP.member'",
+	} {
+		_, err := cid.NewRule(rule)
+		if assert.Error(t, err, rule) {
+			assert.Contains(t, err.Error(), expectedErr, rule)
+		}
+	}
+}
+
+func TestAccessControl(t *testing.T) {
+	_, err := cid.NewAccessControl(map[string]string{"audit": "AND("})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid rule of function audit")
+	_, err = cid.NewAccessControl(nil, cid.WithDefaultRule("AND("))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid default rule")
+
+	auditor := &mockStub{creator: newX509Creator(t, "Org1MSP", []string{"user", "accounting"}, `{"attrs":{"role":"auditor"}}`)}
+	member := &mockStub{creator: newX509Creator(t, "Org1MSP", []string{"user"}, "")}
+
+	ac, err := cid.NewAccessControl(map[string]string{
+		"audit":    "AND('Org1MSP.client', 'attr:role==auditor')",
+		"transfer": "'Org1MSP.client'",
+	}, cid.WithNodeOUs(cid.NodeOUs{ClientOUIdentifier: "user", PeerOUIdentifier: "node"}))
+	assert.NoError(t, err)
+	assert.NoError(t, ac.CheckAccess(auditor, "audit"))
+	assert.NoError(t, ac.CheckAccess(member, "transfer"))
+	assert.NoError(t, ac.CheckAccess(member, "query"))
+	err = ac.CheckAccess(member, "audit")
+	assert.EqualError(t, err, "access denied: the client of MSP Org1MSP does not satisfy the rule AND('Org1MSP.client', 'attr:role==auditor') of function audit")
+	assert.Error(t, ac.CheckAccess(&mockStub{}, "audit"))
+
+	ac, err = cid.NewAccessControl(map[string]string{"query": "'Org1MSP.member'"}, cid.WithDefaultRule("'attr:role==auditor'"))
+	assert.NoError(t, err)
+	assert.NoError(t, ac.CheckAccess(member, "query"))
+	assert.NoError(t, ac.CheckAccess(auditor, "delete"))
+	assert.Error(t, ac.CheckAccess(member, "delete"))
+}
+
+type testChaincode struct{}
+
+func (testChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	return shim.Success(nil)
+}
+
+func (testChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	function, _ := stub.GetFunctionAndParameters()
+	return shim.Success([]byte(function))
+}
+
+type invocationStub struct {
+	shim.ChaincodeStubInterface
+	creator  []byte
+	function string
+}
+
+func (s *invocationStub) GetCreator() ([]byte, error) {
+	return s.creator, nil
+}
+
+func (s *invocationStub) GetFunctionAndParameters() (string, []string) {
+	return s.function, nil
+}
+
+func TestWithAccessControl(t *testing.T) {
+	ac, err := cid.NewAccessControl(map[string]string{"audit": "'attr:role==auditor'"})
+	assert.NoError(t, err)
+	cc := cid.WithAccessControl(testChaincode{}, ac)
+
+	auditor := newX509Creator(t, "Org1MSP", nil, `{"attrs":{"role":"auditor"}}`)
+	member := newX509Creator(t, "Org1MSP", nil, "")
+
+	resp := cc.Invoke(&invocationStub{creator: auditor, function: "audit"})
+	assert.Equal(t, int32(shim.OK), resp.Status)
+	assert.Equal(t, []byte("audit"), resp.Payload)
+
+	resp = cc.Invoke(&invocationStub{creator: member, function: "audit"})
+	assert.Equal(t, int32(shim.ERROR), resp.Status)
+	assert.Contains(t, resp.Message, "access denied")
+
+	resp = cc.Invoke(&invocationStub{creator: member, function: "query"})
+	assert.Equal(t, int32(shim.OK), resp.Status)
+
+	// Init is not checked
+	resp = cc.Init(&invocationStub{creator: member, function: "audit"})
+	assert.Equal(t, int32(shim.OK), resp.Status)
+}
@@ -79,16 +79,33 @@ func GetX509Certificate(stub ChaincodeStubInterface) (*x509.Certificate, error)
 	return c.GetX509Certificate()
 }
 
+// GetOUs returns the organizational units of the client
+func GetOUs(stub ChaincodeStubInterface) ([]string, error) {
+	c, err := New(stub)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetOUs()
+}
+
 // ClientIdentityImpl implements the ClientIdentity interface
 type clientIdentityImpl struct {
 	stub  ChaincodeStubInterface
 	mspID string
 	cert  *x509.Certificate
 	attrs *attrmgr.Attributes
+	// idemixOU and idemixRole are the OU and the role disclosed by
+	// an idemix identity, and are nil for X509 identities
+	idemixOU   *msp.OrganizationUnit
+	idemixRole *msp.MSPRole
 }
 
 // New returns an instance of ClientIdentity
 func New(stub ChaincodeStubInterface) (ClientIdentity, error) {
+	return newClientIdentity(stub)
+}
+
+func newClientIdentity(stub ChaincodeStubInterface) (*clientIdentityImpl, error) {
 	c := &clientIdentityImpl{stub: stub}
 	err := c.init()
 	if err != nil {
@@ -99,6 +116,10 @@ func New(stub ChaincodeStubInterface) (ClientIdentity, error) {
 
 // GetID returns a unique ID associated with the invoking identity.
 func (c *clientIdentityImpl) GetID() (string, error) {
+	if c.cert == nil {
+		// The pseudonym of an idemix identity changes with every transaction
+		return "", errors.New("the ID of a client identified by an idemix identity is not available")
+	}
 	// The leading "x509::" distinquishes this as an X509 certificate, and
 	// the subject and issuer DNs uniquely identify the X509 certificate.
 	// The resulting ID will remain the same if the certificate is renewed.
@@ -141,6 +162,18 @@ func (c *clientIdentityImpl) GetX509Certificate() (*x509.Certificate, error) {
 	return c.cert, nil
 }
 
+// GetOUs returns the organizational units of the client: the OUs of the subject
+// of its X509 certificate, or the OU disclosed by its idemix identity
+func (c *clientIdentityImpl) GetOUs() ([]string, error) {
+	if c.cert != nil {
+		return c.cert.Subject.OrganizationalUnit, nil
+	}
+	if c.idemixOU != nil && c.idemixOU.OrganizationalUnitIdentifier != "" {
+		return []string{c.idemixOU.OrganizationalUnitIdentifier}, nil
+	}
+	return nil, nil
+}
+
 // Initialize the client
 func (c *clientIdentityImpl) init() error {
 	signingID, err := c.getIdentity()
@@ -151,7 +184,10 @@ func (c *clientIdentityImpl) init() error {
 	idbytes := signingID.GetIdBytes()
 	block, _ := pem.Decode(idbytes)
 	if block == nil {
-		return errors.New("Expecting a PEM-encoded X509 certificate; PEM block not found")
+		if c.initIdemix(idbytes) {
+			return nil
+		}
+		return errors.New("Expecting a PEM-encoded X509 certificate or an idemix identity; PEM block not found")
 	}
 	cert, err := x509.ParseCertificate(block.Bytes)
 	if err != nil {
@@ -166,6 +202,26 @@ func (c *clientIdentityImpl) init() error {
 	return nil
 }
 
+// Initialize the client from the bytes of an idemix identity, keeping the OU and
+// the role it discloses. It returns false if the bytes are not an idemix identity
+func (c *clientIdentityImpl) initIdemix(idbytes []byte) bool {
+	sid := &msp.SerializedIdemixIdentity{}
+	if err := proto.Unmarshal(idbytes, sid); err != nil {
+		return false
+	}
+	if len(sid.NymX) == 0 || len(sid.NymY) == 0 || len(sid.Proof) == 0 {
+		return false
+	}
+	ou := &msp.OrganizationUnit{}
+	role := &msp.MSPRole{}
+	if proto.Unmarshal(sid.OU, ou) != nil || proto.Unmarshal(sid.Role, role) != nil {
+		return false
+	}
+	c.idemixOU = ou
+	c.idemixRole = role
+	return true
+}
+
 // Unmarshals the bytes returned by ChaincodeStubInterface.GetCreator method and
 // returns the resulting msp.SerializedIdentity object
 func (c *clientIdentityImpl) getIdentity() (*msp.SerializedIdentity, error) {
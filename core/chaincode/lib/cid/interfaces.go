@@ -32,7 +32,8 @@ type ChaincodeStubInterface interface {
 type ClientIdentity interface {
 
 	// GetID returns the ID associated with the invoking identity.  This ID
-	// is guaranteed to be unique within the MSP.
+	// is guaranteed to be unique within the MSP. It is not available for
+	// clients identified by an idemix identity.
 	GetID() (string, error)
 
 	// Return the MSP ID of the client
@@ -52,4 +53,8 @@ type ClientIdentity interface {
 	// GetX509Certificate returns the X509 certificate associated with the client,
 	// or nil if it was not identified by an X509 certificate.
 	GetX509Certificate() (*x509.Certificate, error)
+
+	// GetOUs returns the organizational units of the client: the OUs of the
+	// subject of its X509 certificate, or the OU disclosed by its idemix identity.
+	GetOUs() ([]string, error)
 }
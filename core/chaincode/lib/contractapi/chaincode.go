@@ -0,0 +1,193 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package contractapi
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+)
+
+// ContractChaincode is a chaincode made of contracts. Clients invoke the
+// function F of the contract C as "C:F", and the functions of the default
+// contract simply as "F". The arguments are converted to the types of the
+// parameters of the function, and the value it returns is the payload of the
+// response
+type ContractChaincode struct {
+	// DefaultContract is the name of the contract whose functions are invoked
+	// without the name of their contract, the first contract by default
+	DefaultContract string
+
+	// Info describes the chaincode in its metadata
+	Info InfoMetadata
+
+	contracts map[string]*contract
+	schemas   *schemas
+}
+
+type contract struct {
+	name         string
+	contract     ContractInterface
+	transactions map[string]*transaction
+}
+
+// NewChaincode creates a chaincode made of the contracts. It returns an error
+// if two contracts have the same name, or if a contract has a function whose
+// parameters or return values are not supported
+func NewChaincode(contracts ...ContractInterface) (*ContractChaincode, error) {
+	if len(contracts) == 0 {
+		return nil, errors.New("a chaincode needs at least one contract")
+	}
+	cc := &ContractChaincode{
+		contracts: map[string]*contract{},
+		schemas:   newSchemas(),
+	}
+	for _, c := range contracts {
+		if err := cc.addContract(c); err != nil {
+			return nil, err
+		}
+		if cc.DefaultContract == "" {
+			cc.DefaultContract = contractName(c)
+		}
+	}
+	if err := cc.addContract(&systemContract{Contract: Contract{Name: SystemContractName}, cc: cc}); err != nil {
+		return nil, err
+	}
+	return cc, nil
+}
+
+// contractName returns the name of a contract, which defaults to the name of its type
+func contractName(c ContractInterface) string {
+	if name := c.GetName(); name != "" {
+		return name
+	}
+	t := reflect.TypeOf(c)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+func (cc *ContractChaincode) addContract(ci ContractInterface) error {
+	name := contractName(ci)
+	if name == "" {
+		return errors.Errorf("contract of type %T has no name", ci)
+	}
+	if strings.Contains(name, ":") {
+		return errors.Errorf("invalid contract name %s", name)
+	}
+	if _, exists := cc.contracts[name]; exists {
+		if name == SystemContractName {
+			return errors.Errorf("contract name %s is reserved", name)
+		}
+		return errors.Errorf("contract %s already exists", name)
+	}
+
+	// the methods of ContractInterface are not functions of the contract
+	excluded := map[string]struct{}{}
+	ifaceType := reflect.TypeOf((*ContractInterface)(nil)).Elem()
+	for i := 0; i < ifaceType.NumMethod(); i++ {
+		excluded[ifaceType.Method(i).Name] = struct{}{}
+	}
+
+	c := &contract{name: name, contract: ci, transactions: map[string]*transaction{}}
+	v := reflect.ValueOf(ci)
+	for i := 0; i < v.NumMethod(); i++ {
+		method := v.Type().Method(i)
+		if _, exists := excluded[method.Name]; exists {
+			continue
+		}
+		tx, err := newTransaction(method.Name, v.Method(i), cc.schemas)
+		if err != nil {
+			return errors.WithMessage(err, "invalid function "+method.Name+" of contract "+name)
+		}
+		c.transactions[method.Name] = tx
+	}
+	cc.contracts[name] = c
+	return nil
+}
+
+// Metadata returns the metadata of the chaincode
+func (cc *ContractChaincode) Metadata() ContractChaincodeMetadata {
+	md := ContractChaincodeMetadata{
+		Info:       cc.Info,
+		Contracts:  map[string]ContractMetadata{},
+		Components: ComponentMetadata{Schemas: cc.schemas.components},
+	}
+	for name, c := range cc.contracts {
+		md.Contracts[name] = c.metadata(name == cc.DefaultContract)
+	}
+	return md
+}
+
+// Init invokes the function passed to the instantiation or the upgrade of the
+// chaincode, if any
+func (cc *ContractChaincode) Init(stub shim.ChaincodeStubInterface) pb.Response {
+	function, args := stub.GetFunctionAndParameters()
+	if function == "" {
+		return shim.Success(nil)
+	}
+	return cc.invoke(stub, function, args)
+}
+
+// Invoke routes the transaction to the invoked function of the invoked contract
+func (cc *ContractChaincode) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
+	function, args := stub.GetFunctionAndParameters()
+	return cc.invoke(stub, function, args)
+}
+
+func (cc *ContractChaincode) invoke(stub shim.ChaincodeStubInterface, function string, args []string) pb.Response {
+	payload, err := cc.route(stub, function, args)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(payload)
+}
+
+func (cc *ContractChaincode) route(stub shim.ChaincodeStubInterface, function string, args []string) ([]byte, error) {
+	name := cc.DefaultContract
+	if i := strings.Index(function, ":"); i != -1 {
+		name, function = function[:i], function[i+1:]
+	}
+	c, exists := cc.contracts[name]
+	if !exists {
+		return nil, errors.Errorf("contract %s not found", name)
+	}
+
+	ctx := NewTransactionContext(stub)
+	tx, exists := c.transactions[function]
+	if !exists {
+		if unknown := c.contract.GetUnknownTransaction(); unknown != nil {
+			return nil, unknown(ctx)
+		}
+		return nil, errors.Errorf("function %s not found in contract %s", function, name)
+	}
+
+	if before := c.contract.GetBeforeTransaction(); before != nil {
+		if err := before(ctx); err != nil {
+			return nil, err
+		}
+	}
+	result, payload, err := tx.call(ctx, args, cc.schemas.components)
+	if err != nil {
+		return nil, err
+	}
+	if after := c.contract.GetAfterTransaction(); after != nil {
+		if err := after(ctx, result); err != nil {
+			return nil, err
+		}
+	}
+	return payload, nil
+}
+
+// Start starts the chaincode
+func (cc *ContractChaincode) Start() error {
+	return shim.Start(cc)
+}
@@ -0,0 +1,354 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package contractapi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/chaincode/lib/contractapi"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+type Asset struct {
+	ID     string            `json:"id"`
+	Owner  string            `json:"owner"`
+	Value  uint32            `json:"value"`
+	Tags   []string          `json:"tags,omitempty"`
+	Extras map[string]string `json:"extras,omitempty"`
+}
+
+type AssetContract struct {
+	contractapi.Contract
+}
+
+func (ac *AssetContract) Create(ctx contractapi.TransactionContextInterface, asset Asset) error {
+	b, err := json.Marshal(asset)
+	if err != nil {
+		return err
+	}
+	return ctx.GetStub().PutState(asset.ID, b)
+}
+
+func (ac *AssetContract) Read(ctx contractapi.TransactionContextInterface, id string) (*Asset, error) {
+	b, err := ctx.GetStub().GetState(id)
+	if err != nil {
+		return nil, err
+	}
+	if b == nil {
+		return nil, errors.New("asset " + id + " not found")
+	}
+	asset := &Asset{}
+	return asset, json.Unmarshal(b, asset)
+}
+
+func (ac *AssetContract) Add(a int8, b float64, negate bool) float64 {
+	if negate {
+		return -(float64(a) + b)
+	}
+	return float64(a) + b
+}
+
+func (ac *AssetContract) Echo(data []byte, at time.Time) (string, error) {
+	return string(data) + "@" + at.UTC().Format(time.RFC3339), nil
+}
+
+type CounterContract struct {
+	contractapi.Contract
+	count int
+}
+
+func (cc *CounterContract) Increment() int {
+	cc.count++
+	return cc.count
+}
+
+func (cc *CounterContract) WhoAmI(ctx *contractapi.TransactionContext) (string, error) {
+	c, err := ctx.GetClientIdentity()
+	if err != nil {
+		return "", err
+	}
+	return c.GetMSPID()
+}
+
+func invoke(cc shim.Chaincode, stub *shim.MockStub, args ...string) pb.Response {
+	bargs := make([][]byte, len(args))
+	for i, arg := range args {
+		bargs[i] = []byte(arg)
+	}
+	return stub.MockInvoke("tx", bargs)
+}
+
+func TestRouting(t *testing.T) {
+	cc, err := contractapi.NewChaincode(&AssetContract{}, &CounterContract{Contract: contractapi.Contract{Name: "counter"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "AssetContract", cc.DefaultContract)
+	stub := shim.NewMockStub("assets", cc)
+
+	resp := invoke(cc, stub, "Create", `{"id":"a1","owner":"alice","value":10,"tags":["gold"]}`)
+	assert.Equal(t, int32(shim.OK), resp.Status, resp.Message)
+	assert.Empty(t, resp.Payload)
+
+	resp = invoke(cc, stub, "AssetContract:Read", "a1")
+	assert.Equal(t, int32(shim.OK), resp.Status, resp.Message)
+	assert.JSONEq(t, `{"id":"a1","owner":"alice","value":10,"tags":["gold"]}`, string(resp.Payload))
+
+	resp = invoke(cc, stub, "Read", "a2")
+	assert.Equal(t, int32(shim.ERROR), resp.Status)
+	assert.Equal(t, "asset a2 not found", resp.Message)
+
+	resp = invoke(cc, stub, "Add", "-3", "0.5", "true")
+	assert.Equal(t, int32(shim.OK), resp.Status, resp.Message)
+	assert.Equal(t, "2.5", string(resp.Payload))
+
+	resp = invoke(cc, stub, "Echo", "hello", "2018-03-01T10:00:00+01:00")
+	assert.Equal(t, int32(shim.OK), resp.Status, resp.Message)
+	assert.Equal(t, "hello@2018-03-01T09:00:00Z", string(resp.Payload))
+
+	resp = invoke(cc, stub, "counter:Increment")
+	assert.Equal(t, "1", string(resp.Payload))
+	resp = invoke(cc, stub, "counter:Increment")
+	assert.Equal(t, "2", string(resp.Payload))
+
+	for _, test := range []struct {
+		args        []string
+		expectedErr string
+	}{
+		{[]string{"Increment"}, "function Increment not found in contract AssetContract"},
+		{[]string{"foo:Increment"}, "contract foo not found"},
+		{[]string{"counter:GetName"}, "function GetName not found in contract counter"},
+		{[]string{"Read"}, "function Read expects 1 arguments, got 0"},
+		{[]string{"Add", "300", "1", "false"}, "invalid argument 0 of function Add: expected int8, got 300"},
+		{[]string{"Add", "1", "one", "false"}, "invalid argument 1 of function Add: expected float64, got one"},
+		{[]string{"Add", "1", "1", "maybe"}, "invalid argument 2 of function Add: expected boolean, got maybe"},
+		{[]string{"Echo", "hello", "yesterday"}, "invalid argument 1 of function Echo: expected RFC 3339 date-time, got yesterday"},
+		{[]string{"Create", `{"id":"a1"`}, "invalid argument 0 of function Create: invalid JSON"},
+		{[]string{"Create", `{"id":"a1","owner":"bob"}`}, "invalid argument 0 of function Create: value: missing required property value"},
+		{[]string{"Create", `{"id":"a1","owner":"bob","value":-1}`}, "invalid argument 0 of function Create: failed unmarshaling contractapi_test.Asset"},
+		{[]string{"Create", `{"id":"a1","owner":"bob","value":1.5}`}, "invalid argument 0 of function Create: value.value: expected integer, got number"},
+		{[]string{"Create", `{"id":"a1","owner":"bob","value":1,"color":"red"}`}, "invalid argument 0 of function Create: value: unexpected property color"},
+		{[]string{"Create", `{"id":"a1","owner":"bob","value":1,"tags":[1]}`}, "invalid argument 0 of function Create: value.tags[0]: expected string, got number"},
+		{[]string{"Create", `{"id":"a1","owner":"bob","value":1,"extras":{"a":true}}`}, "invalid argument 0 of function Create: value.extras.a: expected string, got boolean"},
+	} {
+		resp := invoke(cc, stub, test.args...)
+		assert.Equal(t, int32(shim.ERROR), resp.Status, "%v", test.args)
+		assert.Contains(t, resp.Message, test.expectedErr, "%v", test.args)
+	}
+}
+
+func TestInit(t *testing.T) {
+	cc, err := contractapi.NewChaincode(&CounterContract{})
+	assert.NoError(t, err)
+	stub := shim.NewMockStub("counter", cc)
+
+	resp := stub.MockInit("tx", nil)
+	assert.Equal(t, int32(shim.OK), resp.Status)
+	resp = stub.MockInit("tx", [][]byte{[]byte("Increment")})
+	assert.Equal(t, int32(shim.OK), resp.Status)
+	assert.Equal(t, "1", string(resp.Payload))
+}
+
+func TestHooks(t *testing.T) {
+	var calls []string
+	contract := &CounterContract{Contract: contractapi.Contract{
+		BeforeTransaction: func(ctx contractapi.TransactionContextInterface) error {
+			function, _ := ctx.GetStub().GetFunctionAndParameters()
+			calls = append(calls, "before "+function)
+			return nil
+		},
+		AfterTransaction: func(ctx contractapi.TransactionContextInterface, result interface{}) error {
+			calls = append(calls, "after")
+			if result == 2 {
+				return errors.New("two")
+			}
+			return nil
+		},
+		UnknownTransaction: func(ctx contractapi.TransactionContextInterface) error {
+			function, _ := ctx.GetStub().GetFunctionAndParameters()
+			calls = append(calls, "unknown "+function)
+			return errors.New("unknown function")
+		},
+	}}
+	cc, err := contractapi.NewChaincode(contract)
+	assert.NoError(t, err)
+	stub := shim.NewMockStub("counter", cc)
+
+	resp := invoke(cc, stub, "Increment")
+	assert.Equal(t, int32(shim.OK), resp.Status)
+	resp = invoke(cc, stub, "Increment")
+	assert.Equal(t, int32(shim.ERROR), resp.Status)
+	assert.Equal(t, "two", resp.Message)
+	resp = invoke(cc, stub, "Decrement")
+	assert.Equal(t, "unknown function", resp.Message)
+	assert.Equal(t, []string{"before Increment", "after", "before Increment", "after", "unknown Decrement"}, calls)
+
+	// a failing before hook prevents the function from being invoked
+	calls = nil
+	contract.BeforeTransaction = func(ctx contractapi.TransactionContextInterface) error {
+		calls = append(calls, "before")
+		return errors.New("forbidden")
+	}
+	resp = invoke(cc, stub, "Increment")
+	assert.Equal(t, "forbidden", resp.Message)
+	assert.Equal(t, []string{"before"}, calls)
+	assert.Equal(t, 2, contract.count)
+}
+
+type creatorStub struct {
+	shim.ChaincodeStubInterface
+	creator []byte
+	args    []string
+}
+
+func (s *creatorStub) GetCreator() ([]byte, error) {
+	return s.creator, nil
+}
+
+func (s *creatorStub) GetFunctionAndParameters() (string, []string) {
+	return s.args[0], s.args[1:]
+}
+
+func TestClientIdentity(t *testing.T) {
+	cc, err := contractapi.NewChaincode(&CounterContract{})
+	assert.NoError(t, err)
+
+	idBytes, err := proto.Marshal(&msp.SerializedIdemixIdentity{NymX: []byte("x"), NymY: []byte("y"), Proof: []byte("proof")})
+	assert.NoError(t, err)
+	creator, err := proto.Marshal(&msp.SerializedIdentity{Mspid: "Org1MSP", IdBytes: idBytes})
+	assert.NoError(t, err)
+
+	resp := cc.Invoke(&creatorStub{creator: creator, args: []string{"WhoAmI"}})
+	assert.Equal(t, int32(shim.OK), resp.Status, resp.Message)
+	assert.Equal(t, "Org1MSP", string(resp.Payload))
+
+	resp = cc.Invoke(&creatorStub{creator: []byte("foo"), args: []string{"WhoAmI"}})
+	assert.Equal(t, int32(shim.ERROR), resp.Status)
+}
+
+type Node struct {
+	Name     string  `json:"name"`
+	Children []*Node `json:"children"`
+}
+
+type TreeContract struct {
+	contractapi.Contract
+}
+
+func (tc *TreeContract) Count(node Node) int {
+	n := 1
+	for _, child := range node.Children {
+		n += tc.Count(*child)
+	}
+	return n
+}
+
+func TestMetadata(t *testing.T) {
+	cc, err := contractapi.NewChaincode(&TreeContract{Contract: contractapi.Contract{Name: "tree"}}, &CounterContract{})
+	assert.NoError(t, err)
+	cc.Info = contractapi.InfoMetadata{Title: "trees", Version: "1.0"}
+	stub := shim.NewMockStub("trees", cc)
+
+	resp := invoke(cc, stub, "Count", `{"name":"root","children":[{"name":"a","children":null},{"name":"b","children":[{"name":"c","children":[]}]}]}`)
+	assert.Equal(t, int32(shim.OK), resp.Status, resp.Message)
+	assert.Equal(t, "4", string(resp.Payload))
+
+	resp = invoke(cc, stub, "org.hyperledger.fabric:GetMetadata")
+	assert.Equal(t, int32(shim.OK), resp.Status, resp.Message)
+	assert.JSONEq(t, `{
+		"info": {"title": "trees", "version": "1.0"},
+		"contracts": {
+			"tree": {
+				"name": "tree",
+				"default": true,
+				"transactions": [
+					{"name": "Count", "parameters": [{"name": "param0", "schema": {"$ref": "#/components/schemas/Node"}}], "returns": {"type": "integer", "format": "int64"}}
+				]
+			},
+			"CounterContract": {
+				"name": "CounterContract",
+				"default": false,
+				"transactions": [
+					{"name": "Increment", "parameters": [], "returns": {"type": "integer", "format": "int64"}},
+					{"name": "WhoAmI", "parameters": [], "returns": {"type": "string"}}
+				]
+			},
+			"org.hyperledger.fabric": {
+				"name": "org.hyperledger.fabric",
+				"default": false,
+				"transactions": [
+					{"name": "GetMetadata", "parameters": [], "returns": {"type": "string"}}
+				]
+			}
+		},
+		"components": {
+			"schemas": {
+				"Node": {
+					"type": "object",
+					"properties": {
+						"name": {"type": "string"},
+						"children": {"type": "array", "items": {"$ref": "#/components/schemas/Node"}}
+					},
+					"required": ["children", "name"],
+					"additionalProperties": false
+				}
+			}
+		}
+	}`, string(resp.Payload))
+}
+
+type InvalidContract struct {
+	contractapi.Contract
+}
+
+func (ic *InvalidContract) Sum(values ...int) int {
+	return 0
+}
+
+type ChannelContract struct {
+	contractapi.Contract
+}
+
+func (cc *ChannelContract) Send(c chan int) {}
+
+type ContextContract struct {
+	contractapi.Contract
+}
+
+func (cc *ContextContract) Do(id string, ctx contractapi.TransactionContextInterface) {}
+
+type ReturnContract struct {
+	contractapi.Contract
+}
+
+func (rc *ReturnContract) Get() (string, string) {
+	return "", ""
+}
+
+func TestInvalidContracts(t *testing.T) {
+	for _, test := range []struct {
+		contracts   []contractapi.ContractInterface
+		expectedErr string
+	}{
+		{nil, "a chaincode needs at least one contract"},
+		{[]contractapi.ContractInterface{&InvalidContract{}}, "invalid function Sum of contract InvalidContract: variadic functions are not supported"},
+		{[]contractapi.ContractInterface{&ChannelContract{}}, "invalid function Send of contract ChannelContract: invalid parameter 0: type chan int is not supported"},
+		{[]contractapi.ContractInterface{&ContextContract{}}, "invalid function Do of contract ContextContract: the transaction context must be the first parameter"},
+		{[]contractapi.ContractInterface{&ReturnContract{}}, "invalid function Get of contract ReturnContract: the second return value must be an error"},
+		{[]contractapi.ContractInterface{&CounterContract{}, &CounterContract{}}, "contract CounterContract already exists"},
+		{[]contractapi.ContractInterface{&CounterContract{Contract: contractapi.Contract{Name: "a:b"}}}, "invalid contract name a:b"},
+		{[]contractapi.ContractInterface{&CounterContract{Contract: contractapi.Contract{Name: contractapi.SystemContractName}}}, "contract name org.hyperledger.fabric is reserved"},
+	} {
+		_, err := contractapi.NewChaincode(test.contracts...)
+		assert.EqualError(t, err, test.expectedErr)
+	}
+}
@@ -0,0 +1,52 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package contractapi
+
+import (
+	"github.com/hyperledger/fabric/core/chaincode/lib/cid"
+	"github.com/hyperledger/fabric/core/chaincode/shim"
+)
+
+// TransactionContextInterface gives the functions of contracts access to the
+// transaction they are invoked in. Functions receive it when their first
+// parameter is of type TransactionContextInterface
+type TransactionContextInterface interface {
+	// GetStub returns the stub of the transaction
+	GetStub() shim.ChaincodeStubInterface
+
+	// GetClientIdentity returns the identity of the client submitting the transaction
+	GetClientIdentity() (cid.ClientIdentity, error)
+}
+
+// TransactionContext implements TransactionContextInterface
+type TransactionContext struct {
+	stub           shim.ChaincodeStubInterface
+	clientIdentity cid.ClientIdentity
+}
+
+// NewTransactionContext creates the context of the transaction of the stub
+func NewTransactionContext(stub shim.ChaincodeStubInterface) *TransactionContext {
+	return &TransactionContext{stub: stub}
+}
+
+// GetStub returns the stub of the transaction
+func (ctx *TransactionContext) GetStub() shim.ChaincodeStubInterface {
+	return ctx.stub
+}
+
+// GetClientIdentity returns the identity of the client submitting the transaction.
+// The identity is only parsed once it's needed
+func (ctx *TransactionContext) GetClientIdentity() (cid.ClientIdentity, error) {
+	if ctx.clientIdentity == nil {
+		clientIdentity, err := cid.New(ctx.stub)
+		if err != nil {
+			return nil, err
+		}
+		ctx.clientIdentity = clientIdentity
+	}
+	return ctx.clientIdentity, nil
+}
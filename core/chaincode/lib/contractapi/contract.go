@@ -0,0 +1,69 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package contractapi
+
+// ContractInterface is implemented by contracts, usually by embedding Contract.
+// The exported methods of a contract, other than the ones of ContractInterface,
+// are the functions of the contract which clients can invoke
+type ContractInterface interface {
+	// GetName returns the name of the contract, which clients prefix the names
+	// of its functions with. An empty name stands for the name of the type of
+	// the contract
+	GetName() string
+
+	// GetBeforeTransaction returns the hook called before each function of the
+	// contract, or nil
+	GetBeforeTransaction() BeforeTransactionHook
+
+	// GetAfterTransaction returns the hook called after each function of the
+	// contract which succeeded, or nil
+	GetAfterTransaction() AfterTransactionHook
+
+	// GetUnknownTransaction returns the hook called instead of the functions
+	// the contract doesn't have, or nil
+	GetUnknownTransaction() UnknownTransactionHook
+}
+
+// BeforeTransactionHook is called before a function of a contract is invoked.
+// The function is not invoked if the hook returns an error, which fails the transaction
+type BeforeTransactionHook func(ctx TransactionContextInterface) error
+
+// AfterTransactionHook is called after a function of a contract succeeded, with
+// the value it returned, or nil. An error fails the transaction
+type AfterTransactionHook func(ctx TransactionContextInterface, result interface{}) error
+
+// UnknownTransactionHook is called when a client invokes a function the contract
+// doesn't have. An error fails the transaction
+type UnknownTransactionHook func(ctx TransactionContextInterface) error
+
+// Contract implements ContractInterface, and is meant to be embedded in contracts
+type Contract struct {
+	Name               string
+	BeforeTransaction  BeforeTransactionHook
+	AfterTransaction   AfterTransactionHook
+	UnknownTransaction UnknownTransactionHook
+}
+
+// GetName returns the name of the contract
+func (c *Contract) GetName() string {
+	return c.Name
+}
+
+// GetBeforeTransaction returns the hook called before each function of the contract
+func (c *Contract) GetBeforeTransaction() BeforeTransactionHook {
+	return c.BeforeTransaction
+}
+
+// GetAfterTransaction returns the hook called after each function of the contract
+func (c *Contract) GetAfterTransaction() AfterTransactionHook {
+	return c.AfterTransaction
+}
+
+// GetUnknownTransaction returns the hook called instead of unknown functions
+func (c *Contract) GetUnknownTransaction() UnknownTransactionHook {
+	return c.UnknownTransaction
+}
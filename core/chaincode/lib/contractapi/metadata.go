@@ -0,0 +1,99 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package contractapi
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+)
+
+// SystemContractName is the name of the contract every chaincode built with
+// NewChaincode has, whose GetMetadata function returns the metadata of the chaincode
+const SystemContractName = "org.hyperledger.fabric"
+
+// ContractChaincodeMetadata describes the contracts of a chaincode, their
+// functions and the schemas of their parameters and return values
+type ContractChaincodeMetadata struct {
+	Info       InfoMetadata                `json:"info"`
+	Contracts  map[string]ContractMetadata `json:"contracts"`
+	Components ComponentMetadata           `json:"components"`
+}
+
+// InfoMetadata describes the chaincode
+type InfoMetadata struct {
+	Title   string `json:"title,omitempty"`
+	Version string `json:"version,omitempty"`
+}
+
+// ContractMetadata describes a contract
+type ContractMetadata struct {
+	Name         string                `json:"name"`
+	Default      bool                  `json:"default"`
+	Transactions []TransactionMetadata `json:"transactions"`
+}
+
+// TransactionMetadata describes a function of a contract. Parameters which
+// take the transaction context are omitted, and the schema of the return value
+// is omitted when the function doesn't return a value
+type TransactionMetadata struct {
+	Name       string              `json:"name"`
+	Parameters []ParameterMetadata `json:"parameters"`
+	Returns    *Schema             `json:"returns,omitempty"`
+}
+
+// ParameterMetadata describes a parameter of a function. Since the names of
+// the parameters of Go methods are not available, parameters are named after
+// their position
+type ParameterMetadata struct {
+	Name   string  `json:"name"`
+	Schema *Schema `json:"schema"`
+}
+
+// ComponentMetadata holds the schemas of the structs which functions take or
+// return, which the schemas of parameters and return values refer to
+type ComponentMetadata struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+func (c *contract) metadata(isDefault bool) ContractMetadata {
+	cm := ContractMetadata{
+		Name:         c.name,
+		Default:      isDefault,
+		Transactions: []TransactionMetadata{},
+	}
+	names := make([]string, 0, len(c.transactions))
+	for name := range c.transactions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		tx := c.transactions[name]
+		tm := TransactionMetadata{
+			Name:       name,
+			Parameters: []ParameterMetadata{},
+			Returns:    tx.returnSchema,
+		}
+		for i, schema := range tx.paramSchemas {
+			tm.Parameters = append(tm.Parameters, ParameterMetadata{Name: "param" + strconv.Itoa(i), Schema: schema})
+		}
+		cm.Transactions = append(cm.Transactions, tm)
+	}
+	return cm
+}
+
+// systemContract is the contract serving the metadata of the chaincode
+type systemContract struct {
+	Contract
+	cc *ContractChaincode
+}
+
+// GetMetadata returns the metadata of the chaincode in JSON
+func (sc *systemContract) GetMetadata() (string, error) {
+	b, err := json.Marshal(sc.cc.Metadata())
+	return string(b), err
+}
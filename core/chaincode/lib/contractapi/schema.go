@@ -0,0 +1,297 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package contractapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Schema is the JSON schema of a parameter or of the return value of a function,
+// or of a struct they refer to
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"`
+}
+
+const componentsRef = "#/components/schemas/"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemas builds the schemas of types, and keeps the schemas of the structs they
+// refer to, which are named after the structs
+type schemas struct {
+	components map[string]*Schema
+	types      map[string]reflect.Type
+}
+
+func newSchemas() *schemas {
+	return &schemas{
+		components: map[string]*Schema{},
+		types:      map[string]reflect.Type{},
+	}
+}
+
+// schemaOf returns the schema of a type, or an error if values of the type
+// can't be passed to or returned by functions
+func (s *schemas) schemaOf(t reflect.Type) (*Schema, error) {
+	if t == timeType {
+		return &Schema{Type: "string", Format: "date-time"}, nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int" + bitSize(t)}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "uint" + bitSize(t)}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number", Format: "float" + bitSize(t)}, nil
+	case reflect.Ptr:
+		return s.schemaOf(t.Elem())
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json encodes byte slices in base64
+			return &Schema{Type: "string", Format: "byte"}, nil
+		}
+		items, err := s.schemaOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, errors.Errorf("map %s doesn't have string keys", t)
+		}
+		values, err := s.schemaOf(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return &Schema{}, nil
+		}
+	case reflect.Struct:
+		return s.structSchema(t)
+	}
+	return nil, errors.Errorf("type %s is not supported", t)
+}
+
+func bitSize(t reflect.Type) string {
+	return strconv.Itoa(t.Bits())
+}
+
+// structSchema returns a reference to the schema of a struct, which it builds
+// and keeps among the components the first time
+func (s *schemas) structSchema(t reflect.Type) (*Schema, error) {
+	name := t.Name()
+	if name == "" {
+		// anonymous structs are not named, so they are not kept
+		schema := &Schema{}
+		return schema, s.buildStructSchema(t, schema)
+	}
+	ref := &Schema{Ref: componentsRef + name}
+	if existing, exists := s.types[name]; exists {
+		if existing != t {
+			return nil, errors.Errorf("structs %s and %s have the same name", existing, t)
+		}
+		return ref, nil
+	}
+	schema := &Schema{}
+	// the schema is registered before it's built, so that structs can refer to themselves
+	s.types[name] = t
+	s.components[name] = schema
+	if err := s.buildStructSchema(t, schema); err != nil {
+		delete(s.types, name)
+		delete(s.components, name)
+		return nil, err
+	}
+	return ref, nil
+}
+
+func (s *schemas) buildStructSchema(t reflect.Type, schema *Schema) error {
+	schema.Type = "object"
+	schema.Properties = map[string]*Schema{}
+	schema.AdditionalProperties = false
+	if err := s.addFields(t, schema); err != nil {
+		return errors.WithMessage(err, "invalid struct "+t.String())
+	}
+	sort.Strings(schema.Required)
+	return nil
+}
+
+// addFields adds the properties of the exported fields of a struct to its schema,
+// naming them as encoding/json does
+func (s *schemas) addFields(t reflect.Type, schema *Schema) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts := parseTag(tag)
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				// the fields of embedded structs are promoted
+				if err := s.addFields(ft, schema); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fieldSchema, err := s.schemaOf(field.Type)
+		if err != nil {
+			return errors.WithMessage(err, "invalid field "+field.Name)
+		}
+		schema.Properties[name] = fieldSchema
+		if !strings.Contains(opts, "omitempty") {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return nil
+}
+
+func parseTag(tag string) (string, string) {
+	if i := strings.Index(tag, ","); i != -1 {
+		return tag[:i], tag[i+1:]
+	}
+	return tag, ""
+}
+
+// validate checks that a value decoded from JSON with numbers kept as json.Number
+// matches the schema
+func (s *Schema) validate(value interface{}, components map[string]*Schema, path string) error {
+	if s.Ref != "" {
+		component, exists := components[strings.TrimPrefix(s.Ref, componentsRef)]
+		if !exists {
+			return errors.Errorf("%s: unknown schema %s", path, s.Ref)
+		}
+		return component.validate(value, components, path)
+	}
+	if value == nil {
+		// encoding/json encodes nil slices, maps and pointers as null, byte slices included
+		switch {
+		case s.Type == "", s.Type == "array", s.Type == "object", s.Format == "byte":
+			return nil
+		default:
+			return errors.Errorf("%s: expected %s, got null", path, s.Type)
+		}
+	}
+
+	switch s.Type {
+	case "":
+		return nil
+	case "string":
+		if _, ok := value.(string); !ok {
+			return errors.Errorf("%s: expected string, got %s", path, jsonType(value))
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return errors.Errorf("%s: expected boolean, got %s", path, jsonType(value))
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			return errors.Errorf("%s: expected number, got %s", path, jsonType(value))
+		}
+	case "integer":
+		n, ok := value.(json.Number)
+		if !ok || strings.ContainsAny(n.String(), ".eE") {
+			return errors.Errorf("%s: expected integer, got %s", path, jsonType(value))
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return errors.Errorf("%s: expected array, got %s", path, jsonType(value))
+		}
+		for i, item := range items {
+			if err := s.Items.validate(item, components, path+"["+strconv.Itoa(i)+"]"); err != nil {
+				return err
+			}
+		}
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return errors.Errorf("%s: expected object, got %s", path, jsonType(value))
+		}
+		return s.validateObject(object, components, path)
+	}
+	return nil
+}
+
+func (s *Schema) validateObject(object map[string]interface{}, components map[string]*Schema, path string) error {
+	for _, name := range s.Required {
+		if _, exists := object[name]; !exists {
+			return errors.Errorf("%s: missing required property %s", path, name)
+		}
+	}
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		propertySchema, exists := s.Properties[name]
+		if !exists {
+			switch additional := s.AdditionalProperties.(type) {
+			case *Schema:
+				propertySchema = additional
+			case bool:
+				if !additional {
+					return errors.Errorf("%s: unexpected property %s", path, name)
+				}
+			}
+		}
+		if propertySchema == nil {
+			continue
+		}
+		if err := propertySchema.validate(object[name], components, path+"."+name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
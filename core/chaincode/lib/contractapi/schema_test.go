@@ -0,0 +1,113 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package contractapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Inner struct {
+	Value int `json:"value"`
+}
+
+type Base struct {
+	ID string
+}
+
+type Outer struct {
+	Base
+	Inner    *Inner            `json:"inner,omitempty"`
+	At       time.Time         `json:"at"`
+	Data     []byte            `json:"data"`
+	Any      interface{}       `json:"any"`
+	Scores   map[string]uint16 `json:"scores"`
+	Ignored  string            `json:"-"`
+	internal string
+}
+
+func TestSchemaOf(t *testing.T) {
+	s := newSchemas()
+	schema, err := s.schemaOf(reflect.TypeOf([]Outer{}))
+	assert.NoError(t, err)
+	assert.Equal(t, &Schema{Type: "array", Items: &Schema{Ref: "#/components/schemas/Outer"}}, schema)
+	assert.Equal(t, map[string]*Schema{
+		"Inner": {
+			Type:                 "object",
+			Properties:           map[string]*Schema{"value": {Type: "integer", Format: "int64"}},
+			Required:             []string{"value"},
+			AdditionalProperties: false,
+		},
+		"Outer": {
+			Type: "object",
+			Properties: map[string]*Schema{
+				"ID":     {Type: "string"},
+				"inner":  {Ref: "#/components/schemas/Inner"},
+				"at":     {Type: "string", Format: "date-time"},
+				"data":   {Type: "string", Format: "byte"},
+				"any":    {},
+				"scores": {Type: "object", AdditionalProperties: &Schema{Type: "integer", Format: "uint16"}},
+			},
+			Required:             []string{"ID", "any", "at", "data", "scores"},
+			AdditionalProperties: false,
+		},
+	}, s.components)
+
+	schema, err = s.schemaOf(reflect.TypeOf(struct{ X float32 }{}))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]*Schema{"X": {Type: "number", Format: "float32"}}, schema.Properties)
+	assert.Len(t, s.components, 2)
+}
+
+func TestUnsupportedSchemas(t *testing.T) {
+	s := newSchemas()
+	_, err := s.schemaOf(reflect.TypeOf(map[int]string{}))
+	assert.EqualError(t, err, "map map[int]string doesn't have string keys")
+	_, err = s.schemaOf(reflect.TypeOf((*error)(nil)).Elem())
+	assert.EqualError(t, err, "type error is not supported")
+	_, err = s.schemaOf(reflect.TypeOf(struct{ F func() }{}))
+	assert.EqualError(t, err, "invalid struct struct { F func() }: invalid field F: type func() is not supported")
+
+	_, err = s.schemaOf(reflect.TypeOf(Inner{}))
+	assert.NoError(t, err)
+	type Inner struct{}
+	_, err = s.schemaOf(reflect.TypeOf(Inner{}))
+	assert.EqualError(t, err, "structs contractapi.Inner and contractapi.Inner have the same name")
+}
+
+func TestValidate(t *testing.T) {
+	s := newSchemas()
+	schema, err := s.schemaOf(reflect.TypeOf(Outer{}))
+	assert.NoError(t, err)
+
+	validate := func(value string) error {
+		decoder := json.NewDecoder(strings.NewReader(value))
+		decoder.UseNumber()
+		var v interface{}
+		assert.NoError(t, decoder.Decode(&v))
+		return schema.validate(v, s.components, "value")
+	}
+	assert.NoError(t, validate(`{"ID":"a","at":"2018-01-01T00:00:00Z","data":"AAE=","any":[1,{}],"scores":{"x":1},"inner":{"value":-2}}`))
+	assert.NoError(t, validate(`{"ID":"a","at":"2018-01-01T00:00:00Z","data":null,"any":null,"scores":null}`))
+	assert.NoError(t, validate(`null`))
+	assert.EqualError(t, validate(`[]`), "value: expected object, got array")
+	assert.EqualError(t, validate(`{"ID":1,"at":"","data":"","any":1,"scores":{}}`), "value.ID: expected string, got number")
+	assert.EqualError(t, validate(`{"ID":null,"at":"","data":"","any":1,"scores":{}}`), "value.ID: expected string, got null")
+	assert.EqualError(t, validate(`{"ID":"a","at":"","data":"","any":1,"scores":{"x":1e3}}`), "value.scores.x: expected integer, got number")
+	assert.EqualError(t, validate(`{"ID":"a","at":"","data":"","any":1,"scores":{},"inner":{"value":"1"}}`), "value.inner.value: expected integer, got string")
+	assert.EqualError(t, validate(`{"ID":"a","at":"","data":"","any":1,"scores":{},"Ignored":"x"}`), "value: unexpected property Ignored")
+	assert.EqualError(t, validate(`{"ID":"a","data":"","any":1,"scores":{}}`), "value: missing required property at")
+
+	assert.EqualError(t, (&Schema{Ref: "#/components/schemas/Foo"}).validate(nil, s.components, "value"), "value: unknown schema #/components/schemas/Foo")
+	assert.EqualError(t, (&Schema{Type: "boolean"}).validate("true", nil, "value"), "value: expected boolean, got string")
+	assert.EqualError(t, (&Schema{Type: "number"}).validate(true, nil, "value"), "value: expected number, got boolean")
+}
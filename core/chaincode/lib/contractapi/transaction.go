@@ -0,0 +1,221 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package contractapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	contextInterfaceType = reflect.TypeOf((*TransactionContextInterface)(nil)).Elem()
+	contextType          = reflect.TypeOf((*TransactionContext)(nil))
+	errorType            = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// transaction is a function of a contract, which is a method taking an optional
+// transaction context followed by its arguments, and returning an optional
+// value followed by an optional error
+type transaction struct {
+	name         string
+	method       reflect.Value
+	takesContext bool
+	params       []reflect.Type
+	paramSchemas []*Schema
+	returns      reflect.Type
+	returnSchema *Schema
+	returnsError bool
+}
+
+func newTransaction(name string, method reflect.Value, s *schemas) (*transaction, error) {
+	t := method.Type()
+	if t.IsVariadic() {
+		return nil, errors.New("variadic functions are not supported")
+	}
+	tx := &transaction{name: name, method: method}
+
+	for i := 0; i < t.NumIn(); i++ {
+		param := t.In(i)
+		if param == contextInterfaceType || param == contextType {
+			if i != 0 {
+				return nil, errors.New("the transaction context must be the first parameter")
+			}
+			tx.takesContext = true
+			continue
+		}
+		schema, err := s.schemaOf(param)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid parameter "+strconv.Itoa(len(tx.params)))
+		}
+		tx.params = append(tx.params, param)
+		tx.paramSchemas = append(tx.paramSchemas, schema)
+	}
+
+	switch t.NumOut() {
+	case 0:
+	case 1:
+		if t.Out(0) == errorType {
+			tx.returnsError = true
+		} else {
+			tx.returns = t.Out(0)
+		}
+	case 2:
+		if t.Out(1) != errorType {
+			return nil, errors.New("the second return value must be an error")
+		}
+		tx.returns = t.Out(0)
+		tx.returnsError = true
+	default:
+		return nil, errors.New("functions return at most a value and an error")
+	}
+	if tx.returns != nil {
+		if tx.returns == errorType {
+			return nil, errors.New("only the last return value can be an error")
+		}
+		schema, err := s.schemaOf(tx.returns)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid return value")
+		}
+		tx.returnSchema = schema
+	}
+	return tx, nil
+}
+
+// call converts the arguments, invokes the function and returns the value it
+// returned along with its encoding as payload of the response
+func (tx *transaction) call(ctx *TransactionContext, args []string, components map[string]*Schema) (interface{}, []byte, error) {
+	if len(args) != len(tx.params) {
+		return nil, nil, errors.Errorf("function %s expects %d arguments, got %d", tx.name, len(tx.params), len(args))
+	}
+	in := make([]reflect.Value, 0, len(args)+1)
+	if tx.takesContext {
+		in = append(in, reflect.ValueOf(ctx))
+	}
+	for i, arg := range args {
+		v, err := convertArg(arg, tx.params[i], tx.paramSchemas[i], components)
+		if err != nil {
+			return nil, nil, errors.WithMessage(err, "invalid argument "+strconv.Itoa(i)+" of function "+tx.name)
+		}
+		in = append(in, v)
+	}
+
+	out := tx.method.Call(in)
+	if tx.returnsError {
+		if err, _ := out[len(out)-1].Interface().(error); err != nil {
+			return nil, nil, err
+		}
+	}
+	if tx.returns == nil {
+		return nil, nil, nil
+	}
+	payload, err := encodeResult(out[0])
+	if err != nil {
+		return nil, nil, errors.WithMessage(err, "failed encoding the value returned by function "+tx.name)
+	}
+	return out[0].Interface(), payload, nil
+}
+
+// convertArg converts an argument to the type of a parameter. Strings, byte
+// slices, booleans, numbers and times are passed as is, and other values in JSON
+func convertArg(arg string, t reflect.Type, schema *Schema, components map[string]*Schema) (reflect.Value, error) {
+	v := reflect.New(t).Elem()
+	if t == timeType {
+		tm, err := time.Parse(time.RFC3339Nano, arg)
+		if err != nil {
+			return v, errors.Errorf("expected RFC 3339 date-time, got %s", arg)
+		}
+		v.Set(reflect.ValueOf(tm))
+		return v, nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		v.SetString(arg)
+		return v, nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(arg)
+		if err != nil {
+			return v, errors.Errorf("expected boolean, got %s", arg)
+		}
+		v.SetBool(b)
+		return v, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(arg, 10, t.Bits())
+		if err != nil {
+			return v, errors.Errorf("expected %s, got %s", t, arg)
+		}
+		v.SetInt(i)
+		return v, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(arg, 10, t.Bits())
+		if err != nil {
+			return v, errors.Errorf("expected %s, got %s", t, arg)
+		}
+		v.SetUint(u)
+		return v, nil
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(arg, t.Bits())
+		if err != nil {
+			return v, errors.Errorf("expected %s, got %s", t, arg)
+		}
+		v.SetFloat(f)
+		return v, nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			v.SetBytes([]byte(arg))
+			return v, nil
+		}
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(arg))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return v, errors.Wrap(err, "invalid JSON")
+	}
+	if err := schema.validate(value, components, "value"); err != nil {
+		return v, err
+	}
+	if err := json.Unmarshal([]byte(arg), v.Addr().Interface()); err != nil {
+		return v, errors.Wrapf(err, "failed unmarshaling %s", t)
+	}
+	return v, nil
+}
+
+// encodeResult encodes a returned value the way arguments are converted
+func encodeResult(v reflect.Value) ([]byte, error) {
+	if v.Type() == timeType {
+		return []byte(v.Interface().(time.Time).Format(time.RFC3339Nano)), nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return []byte(v.String()), nil
+	case reflect.Bool:
+		return []byte(strconv.FormatBool(v.Bool())), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return []byte(strconv.FormatInt(v.Int(), 10)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return []byte(strconv.FormatUint(v.Uint(), 10)), nil
+	case reflect.Float32, reflect.Float64:
+		return []byte(strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits())), nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Bytes(), nil
+		}
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+	}
+	return json.Marshal(v.Interface())
+}
@@ -56,7 +56,10 @@ type Handler struct {
 	// Multiple queries (and one transaction) with different txids can be executing in parallel for this chaincode
 	// responseChannel is the channel on which responses are communicated by the shim to the chaincodeStub.
 	responseChannel map[string]chan pb.ChaincodeMessage
-	nextState       chan *nextStateInfo
+	// traceParents maps the transactions being executed to the span context the
+	// peer sent along with them, which is echoed on the requests to the peer
+	traceParents map[string]string
+	nextState    chan *nextStateInfo
 }
 
 func shorttxid(txid string) string {
@@ -133,7 +136,7 @@ func (handler *Handler) sendChannel(msg *pb.ChaincodeMessage) error {
 //sends a message and selects
 func (handler *Handler) sendReceive(msg *pb.ChaincodeMessage, c chan pb.ChaincodeMessage) (pb.ChaincodeMessage, error) {
 	errc := make(chan error, 1)
-	handler.serialSendAsync(msg, errc)
+	handler.serialSendAsync(handler.withTraceParent(msg), errc)
 
 	//the serialsend above will send an err or nil
 	//the select filters that first error(or nil)
@@ -158,6 +161,29 @@ func (handler *Handler) sendReceive(msg *pb.ChaincodeMessage, c chan pb.Chaincod
 	}
 }
 
+func (handler *Handler) setTraceParent(msg *pb.ChaincodeMessage) {
+	if msg.TraceParent == "" {
+		return
+	}
+	handler.Lock()
+	defer handler.Unlock()
+	handler.traceParents[handler.getTxCtxId(msg.ChannelId, msg.Txid)] = msg.TraceParent
+}
+
+func (handler *Handler) deleteTraceParent(channelID, txid string) {
+	handler.Lock()
+	defer handler.Unlock()
+	delete(handler.traceParents, handler.getTxCtxId(channelID, txid))
+}
+
+// withTraceParent sets the span context of the transaction a request to the peer belongs to
+func (handler *Handler) withTraceParent(msg *pb.ChaincodeMessage) *pb.ChaincodeMessage {
+	handler.RLock()
+	defer handler.RUnlock()
+	msg.TraceParent = handler.traceParents[handler.getTxCtxId(msg.ChannelId, msg.Txid)]
+	return msg
+}
+
 func (handler *Handler) deleteChannel(channelID, txid string) {
 	handler.Lock()
 	defer handler.Unlock()
@@ -174,6 +200,7 @@ func newChaincodeHandler(peerChatStream PeerChaincodeStream, chaincode Chaincode
 		cc:         chaincode,
 	}
 	v.responseChannel = make(map[string]chan pb.ChaincodeMessage)
+	v.traceParents = make(map[string]string)
 	v.nextState = make(chan *nextStateInfo)
 
 	// Create the shim side FSM
@@ -216,12 +243,14 @@ func (handler *Handler) handleInit(msg *pb.ChaincodeMessage) {
 	// The defer followed by triggering a go routine dance is needed to ensure that the previous state transition
 	// is completed before the next one is triggered. The previous state transition is deemed complete only when
 	// the beforeInit function is exited. Interesting bug fix!!
+	handler.setTraceParent(msg)
 	go func() {
 		var nextStateMsg *pb.ChaincodeMessage
 
 		send := true
 
 		defer func() {
+			handler.deleteTraceParent(msg.ChannelId, msg.Txid)
 			handler.triggerNextState(nextStateMsg, send)
 		}()
 
@@ -292,6 +321,7 @@ func (handler *Handler) handleTransaction(msg *pb.ChaincodeMessage) {
 	// The defer followed by triggering a go routine dance is needed to ensure that the previous state transition
 	// is completed before the next one is triggered. The previous state transition is deemed complete only when
 	// the beforeInit function is exited. Interesting bug fix!!
+	handler.setTraceParent(msg)
 	go func() {
 		//better not be nil
 		var nextStateMsg *pb.ChaincodeMessage
@@ -299,6 +329,7 @@ func (handler *Handler) handleTransaction(msg *pb.ChaincodeMessage) {
 		send := true
 
 		defer func() {
+			handler.deleteTraceParent(msg.ChannelId, msg.Txid)
 			handler.triggerNextState(nextStateMsg, send)
 		}()
 
@@ -430,6 +461,34 @@ func (handler *Handler) handleGetState(collection string, key string, channelId
 	return nil, errors.Errorf("[%s]incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
 }
 
+// handleGetPrivateDataHash communicates with the peer to fetch the hash of the value of a private data item from the ledger.
+func (handler *Handler) handleGetPrivateDataHash(collection string, key string, channelId string, txid string) ([]byte, error) {
+	// Construct payload for GET_PRIVATE_DATA_HASH
+	payloadBytes, _ := proto.Marshal(&pb.GetState{Collection: collection, Key: key})
+
+	msg := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH, Payload: payloadBytes, Txid: txid, ChannelId: channelId}
+	chaincodeLogger.Debugf("[%s]Sending %s", shorttxid(msg.Txid), pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH)
+
+	responseMsg, err := handler.callPeerWithChaincodeMsg(msg, channelId, txid)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("[%s]error sending GET_PRIVATE_DATA_HASH", shorttxid(txid)))
+	}
+
+	if responseMsg.Type.String() == pb.ChaincodeMessage_RESPONSE.String() {
+		// Success response
+		chaincodeLogger.Debugf("[%s]GetPrivateDataHash received payload %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_RESPONSE)
+		return responseMsg.Payload, nil
+	}
+	if responseMsg.Type.String() == pb.ChaincodeMessage_ERROR.String() {
+		// Error response
+		chaincodeLogger.Errorf("[%s]GetPrivateDataHash received error %s", shorttxid(responseMsg.Txid), pb.ChaincodeMessage_ERROR)
+		return nil, errors.New(string(responseMsg.Payload[:]))
+	}
+
+	// Incorrect chaincode message received
+	return nil, errors.Errorf("[%s]incorrect chaincode message %s received. Expecting %s or %s", shorttxid(responseMsg.Txid), responseMsg.Type, pb.ChaincodeMessage_RESPONSE, pb.ChaincodeMessage_ERROR)
+}
+
 // TODO: Implement a method to set multiple keys at a time [FAB-1244]
 // handlePutState communicates with the peer to put state information into the ledger.
 func (handler *Handler) handlePutState(collection string, key string, value []byte, channelId string, txid string) error {
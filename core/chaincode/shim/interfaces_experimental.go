@@ -169,6 +169,15 @@ type ChaincodeStubInterface interface {
 	// that has not been committed.
 	GetPrivateData(collection, key string) ([]byte, error)
 
+	// GetPrivateDataHash returns the hash of the value of the specified `key`
+	// from the specified `collection`, or nil if the key doesn't exist. The
+	// hashes of private data are available to every peer of the channel, hence
+	// GetPrivateDataHash can be invoked on peers of orgs that are not members
+	// of the `collection`, for instance to verify that a value presented by a
+	// member matches the one that was committed. The read of the hash is
+	// recorded in the readset of the transaction, as it is for GetPrivateData.
+	GetPrivateDataHash(collection, key string) ([]byte, error)
+
 	// PutPrivateData puts the specified `key` and `value` into the transaction's
 	// private writeset. Note that only hash of the private writeset goes into the
 	// transaction proposal response (which is sent to the client who issued the
@@ -0,0 +1,667 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"encoding/json"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/pkg/errors"
+)
+
+// mockQuery is a CouchDB query, which MockStub evaluates in memory against the
+// values which are JSON objects. It supports the selector syntax of CouchDB
+// (http://docs.couchdb.org/en/stable/api/database/find.html#selector-syntax),
+// and the fields, sort, limit and skip parameters. Values of different types
+// are ordered as in CouchDB, but strings are compared by code point rather
+// than with the ICU collation of CouchDB
+type mockQuery struct {
+	selector matcher
+	fields   []string
+	sort     []mockSortField
+	limit    int
+	skip     int
+}
+
+type mockSortField struct {
+	path []string
+	desc bool
+}
+
+// matcher returns whether a JSON value, if it exists, matches a condition
+type matcher func(value interface{}, exists bool) bool
+
+func parseMockQuery(query string) (*mockQuery, error) {
+	var params map[string]interface{}
+	if err := json.Unmarshal([]byte(query), &params); err != nil {
+		return nil, errors.Wrapf(err, "invalid query %s", query)
+	}
+	q := &mockQuery{}
+	for name, value := range params {
+		var err error
+		switch name {
+		case "selector":
+			q.selector, err = compileSelector(value)
+		case "fields":
+			q.fields, err = parseFields(value)
+		case "sort":
+			q.sort, err = parseSort(value)
+		case "limit":
+			q.limit, err = parseCount(value)
+		case "skip":
+			q.skip, err = parseCount(value)
+		case "use_index":
+			// there are no indexes in memory
+		default:
+			err = errors.Errorf("unsupported parameter %s", name)
+		}
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid query "+query)
+		}
+	}
+	if q.selector == nil {
+		return nil, errors.Errorf("invalid query %s: missing selector", query)
+	}
+	return q, nil
+}
+
+func parseFields(value interface{}) ([]string, error) {
+	values, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.New("fields must be an array of field names")
+	}
+	var fields []string
+	for _, v := range values {
+		field, ok := v.(string)
+		if !ok {
+			return nil, errors.New("fields must be an array of field names")
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func parseSort(value interface{}) ([]mockSortField, error) {
+	values, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.New("sort must be an array")
+	}
+	var fields []mockSortField
+	for _, v := range values {
+		switch field := v.(type) {
+		case string:
+			fields = append(fields, mockSortField{path: strings.Split(field, ".")})
+		case map[string]interface{}:
+			if len(field) != 1 {
+				return nil, errors.New("each sort field must be a field name or an object with a single field")
+			}
+			for name, direction := range field {
+				if direction != "asc" && direction != "desc" {
+					return nil, errors.Errorf("invalid sort direction %v of field %s", direction, name)
+				}
+				fields = append(fields, mockSortField{path: strings.Split(name, "."), desc: direction == "desc"})
+			}
+		default:
+			return nil, errors.New("each sort field must be a field name or an object with a single field")
+		}
+	}
+	return fields, nil
+}
+
+func parseCount(value interface{}) (int, error) {
+	n, ok := value.(float64)
+	if !ok || n < 0 || n != math.Trunc(n) {
+		return 0, errors.Errorf("expected a non-negative integer, got %v", value)
+	}
+	return int(n), nil
+}
+
+// compileSelector compiles a selector, whose conditions must all be satisfied
+func compileSelector(value interface{}) (matcher, error) {
+	selector, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("expected a selector, got %v", value)
+	}
+	var matchers []matcher
+	for name, arg := range selector {
+		var m matcher
+		var err error
+		if strings.HasPrefix(name, "$") {
+			m, err = compileCombination(name, arg)
+		} else {
+			m, err = compileField(strings.Split(name, "."), arg)
+		}
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	return all(matchers), nil
+}
+
+func all(matchers []matcher) matcher {
+	return func(value interface{}, exists bool) bool {
+		for _, m := range matchers {
+			if !m(value, exists) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// compileCombination compiles the $and, $or, $nor and $not operators, which
+// combine selectors
+func compileCombination(operator string, arg interface{}) (matcher, error) {
+	if operator == "$not" {
+		m, err := compileSelector(arg)
+		if err != nil {
+			return nil, err
+		}
+		return func(value interface{}, exists bool) bool { return !m(value, exists) }, nil
+	}
+
+	args, ok := arg.([]interface{})
+	if !ok {
+		return nil, errors.Errorf("expected an array of selectors as argument of %s", operator)
+	}
+	var matchers []matcher
+	for _, a := range args {
+		m, err := compileSelector(a)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	matchesAny := func(value interface{}, exists bool) bool {
+		for _, m := range matchers {
+			if m(value, exists) {
+				return true
+			}
+		}
+		return false
+	}
+	switch operator {
+	case "$and":
+		return all(matchers), nil
+	case "$or":
+		return matchesAny, nil
+	case "$nor":
+		return func(value interface{}, exists bool) bool { return !matchesAny(value, exists) }, nil
+	default:
+		return nil, errors.Errorf("unknown operator %s", operator)
+	}
+}
+
+// compileField compiles the condition on the field at a path of objects
+func compileField(path []string, arg interface{}) (matcher, error) {
+	m, err := compileCondition(arg)
+	if err != nil {
+		return nil, err
+	}
+	return func(value interface{}, exists bool) bool {
+		if !exists {
+			return false
+		}
+		field, exists := getField(value, path)
+		return m(field, exists)
+	}, nil
+}
+
+func getField(value interface{}, path []string) (interface{}, bool) {
+	for _, name := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[name]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// compileCondition compiles the condition on a field, which is either an
+// object of operators, a nested selector or a value the field must equal
+func compileCondition(arg interface{}) (matcher, error) {
+	object, ok := arg.(map[string]interface{})
+	if !ok {
+		return compileOperator("$eq", arg)
+	}
+	operators := len(object) > 0
+	for name := range object {
+		operators = operators && strings.HasPrefix(name, "$")
+	}
+	if !operators {
+		return compileSelector(object)
+	}
+	var matchers []matcher
+	for operator, operatorArg := range object {
+		m, err := compileOperator(operator, operatorArg)
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, m)
+	}
+	return all(matchers), nil
+}
+
+func compileOperator(operator string, arg interface{}) (matcher, error) {
+	// compare builds the matcher of the operators comparing fields to the argument
+	compare := func(satisfied func(int) bool) (matcher, error) {
+		return func(value interface{}, exists bool) bool {
+			return exists && satisfied(collate(value, arg))
+		}, nil
+	}
+
+	switch operator {
+	case "$eq":
+		return compare(func(c int) bool { return c == 0 })
+	case "$ne":
+		return compare(func(c int) bool { return c != 0 })
+	case "$lt":
+		return compare(func(c int) bool { return c < 0 })
+	case "$lte":
+		return compare(func(c int) bool { return c <= 0 })
+	case "$gt":
+		return compare(func(c int) bool { return c > 0 })
+	case "$gte":
+		return compare(func(c int) bool { return c >= 0 })
+	case "$exists":
+		expected, ok := arg.(bool)
+		if !ok {
+			return nil, errors.New("expected a boolean as argument of $exists")
+		}
+		return func(_ interface{}, exists bool) bool { return exists == expected }, nil
+	case "$type":
+		expected, ok := arg.(string)
+		if !ok {
+			return nil, errors.New("expected a string as argument of $type")
+		}
+		return func(value interface{}, exists bool) bool { return exists && jsonTypeOf(value) == expected }, nil
+	case "$in", "$nin":
+		args, ok := arg.([]interface{})
+		if !ok {
+			return nil, errors.Errorf("expected an array as argument of %s", operator)
+		}
+		in := func(value interface{}) bool {
+			// an array is in the arguments when one of its elements is
+			values, isArray := value.([]interface{})
+			if !isArray {
+				values = []interface{}{value}
+			}
+			for _, v := range values {
+				if contains(args, v) {
+					return true
+				}
+			}
+			return false
+		}
+		if operator == "$in" {
+			return func(value interface{}, exists bool) bool { return exists && in(value) }, nil
+		}
+		return func(value interface{}, exists bool) bool { return exists && !in(value) }, nil
+	case "$size":
+		size, err := parseCount(arg)
+		if err != nil {
+			return nil, errors.WithMessage(err, "invalid argument of $size")
+		}
+		return func(value interface{}, exists bool) bool {
+			values, ok := value.([]interface{})
+			return ok && len(values) == size
+		}, nil
+	case "$mod":
+		args, ok := arg.([]interface{})
+		if !ok || len(args) != 2 {
+			return nil, errors.New("expected [divisor, remainder] as argument of $mod")
+		}
+		divisor, err1 := parseCount(args[0])
+		remainder, err2 := parseCount(args[1])
+		if err1 != nil || err2 != nil || divisor == 0 {
+			return nil, errors.New("expected [divisor, remainder] as argument of $mod")
+		}
+		return func(value interface{}, exists bool) bool {
+			n, ok := value.(float64)
+			return ok && n == math.Trunc(n) && int64(n)%int64(divisor) == int64(remainder)
+		}, nil
+	case "$regex":
+		pattern, ok := arg.(string)
+		if !ok {
+			return nil, errors.New("expected a string as argument of $regex")
+		}
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid argument of $regex")
+		}
+		return func(value interface{}, exists bool) bool {
+			s, ok := value.(string)
+			return ok && regex.MatchString(s)
+		}, nil
+	case "$all":
+		args, ok := arg.([]interface{})
+		if !ok {
+			return nil, errors.New("expected an array as argument of $all")
+		}
+		return func(value interface{}, exists bool) bool {
+			values, ok := value.([]interface{})
+			if !ok {
+				return false
+			}
+			for _, a := range args {
+				if !contains(values, a) {
+					return false
+				}
+			}
+			return true
+		}, nil
+	case "$elemMatch":
+		m, err := compileCondition(arg)
+		if err != nil {
+			return nil, err
+		}
+		return func(value interface{}, exists bool) bool {
+			values, _ := value.([]interface{})
+			for _, v := range values {
+				if m(v, true) {
+					return true
+				}
+			}
+			return false
+		}, nil
+	case "$allMatch":
+		m, err := compileCondition(arg)
+		if err != nil {
+			return nil, err
+		}
+		return func(value interface{}, exists bool) bool {
+			values, ok := value.([]interface{})
+			if !ok || len(values) == 0 {
+				return false
+			}
+			for _, v := range values {
+				if !m(v, true) {
+					return false
+				}
+			}
+			return true
+		}, nil
+	case "$not":
+		m, err := compileCondition(arg)
+		if err != nil {
+			return nil, err
+		}
+		return func(value interface{}, exists bool) bool { return exists && !m(value, exists) }, nil
+	default:
+		return nil, errors.Errorf("unknown operator %s", operator)
+	}
+}
+
+func contains(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if collate(v, value) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonTypeOf(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// collationRank ranks the types of JSON values in the order of CouchDB
+func collationRank(value interface{}) int {
+	switch v := value.(type) {
+	case nil:
+		return 0
+	case bool:
+		if !v {
+			return 1
+		}
+		return 2
+	case float64:
+		return 3
+	case string:
+		return 4
+	case []interface{}:
+		return 5
+	default:
+		return 6
+	}
+}
+
+// collate compares two JSON values, returning -1, 0 or 1
+func collate(v1, v2 interface{}) int {
+	r1, r2 := collationRank(v1), collationRank(v2)
+	if r1 != r2 {
+		return compareInts(r1, r2)
+	}
+	switch v1 := v1.(type) {
+	case float64:
+		n2 := v2.(float64)
+		switch {
+		case v1 < n2:
+			return -1
+		case v1 > n2:
+			return 1
+		}
+		return 0
+	case string:
+		return strings.Compare(v1, v2.(string))
+	case []interface{}:
+		a2 := v2.([]interface{})
+		for i := 0; i < len(v1) && i < len(a2); i++ {
+			if c := collate(v1[i], a2[i]); c != 0 {
+				return c
+			}
+		}
+		return compareInts(len(v1), len(a2))
+	case map[string]interface{}:
+		o2 := v2.(map[string]interface{})
+		k1, k2 := sortedKeys(v1), sortedKeys(o2)
+		for i := 0; i < len(k1) && i < len(k2); i++ {
+			if c := strings.Compare(k1[i], k2[i]); c != 0 {
+				return c
+			}
+			if c := collate(v1[k1[i]], o2[k2[i]]); c != 0 {
+				return c
+			}
+		}
+		return compareInts(len(k1), len(k2))
+	}
+	return 0
+}
+
+func compareInts(i1, i2 int) int {
+	switch {
+	case i1 < i2:
+		return -1
+	case i1 > i2:
+		return 1
+	}
+	return 0
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// execute returns the values matching the query, in the order of their keys
+// unless the query sorts them. Only the requested fields of the values are returned
+func (q *mockQuery) execute(kvs []*queryresult.KV) []*queryresult.KV {
+	type document struct {
+		key     string
+		value   []byte
+		content map[string]interface{}
+	}
+	var docs []*document
+	for _, kv := range kvs {
+		var content map[string]interface{}
+		if err := json.Unmarshal(kv.Value, &content); err != nil || content == nil {
+			// values which are not JSON objects are not documents
+			continue
+		}
+		if q.selector(content, true) {
+			docs = append(docs, &document{key: kv.Key, value: kv.Value, content: content})
+		}
+	}
+
+	if len(q.sort) > 0 {
+		sort.SliceStable(docs, func(i, j int) bool {
+			for _, field := range q.sort {
+				v1, exists1 := getField(docs[i].content, field.path)
+				v2, exists2 := getField(docs[j].content, field.path)
+				c := compareInts(boolRank(exists1), boolRank(exists2))
+				if c == 0 {
+					c = collate(v1, v2)
+				}
+				if c != 0 {
+					return (c < 0) != field.desc
+				}
+			}
+			return false
+		})
+	}
+
+	if q.skip >= len(docs) {
+		docs = nil
+	} else {
+		docs = docs[q.skip:]
+	}
+	if q.limit > 0 && q.limit < len(docs) {
+		docs = docs[:q.limit]
+	}
+
+	results := make([]*queryresult.KV, 0, len(docs))
+	for _, doc := range docs {
+		value := doc.value
+		if len(q.fields) > 0 {
+			value = project(doc.content, q.fields)
+		}
+		results = append(results, &queryresult.KV{Key: doc.key, Value: value})
+	}
+	return results
+}
+
+func boolRank(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// project returns a document with only the fields of a document
+func project(content map[string]interface{}, fields []string) []byte {
+	projection := map[string]interface{}{}
+	for _, field := range fields {
+		path := strings.Split(field, ".")
+		value, exists := getField(content, path)
+		if !exists {
+			continue
+		}
+		object := projection
+		for _, name := range path[:len(path)-1] {
+			child, ok := object[name].(map[string]interface{})
+			if !ok {
+				child = map[string]interface{}{}
+				object[name] = child
+			}
+			object = child
+		}
+		object[path[len(path)-1]] = value
+	}
+	b, _ := json.Marshal(projection)
+	return b
+}
+
+/*****************************
+ Query Result Iterators
+*****************************/
+
+// mockQueryIterator iterates over results computed beforehand
+type mockQueryIterator struct {
+	results []*queryresult.KV
+	closed  bool
+}
+
+func newMockQueryIterator(results []*queryresult.KV) *mockQueryIterator {
+	return &mockQueryIterator{results: results}
+}
+
+// HasNext returns true if the iterator contains additional results
+func (iter *mockQueryIterator) HasNext() bool {
+	return !iter.closed && len(iter.results) > 0
+}
+
+// Next returns the next result
+func (iter *mockQueryIterator) Next() (*queryresult.KV, error) {
+	if !iter.HasNext() {
+		return nil, errors.New("no more results")
+	}
+	kv := iter.results[0]
+	iter.results = iter.results[1:]
+	return kv, nil
+}
+
+// Close closes the iterator
+func (iter *mockQueryIterator) Close() error {
+	iter.closed = true
+	return nil
+}
+
+// mockHistoryQueryIterator iterates over the modifications of a key
+type mockHistoryQueryIterator struct {
+	modifications []*queryresult.KeyModification
+	closed        bool
+}
+
+func newMockHistoryQueryIterator(modifications []*queryresult.KeyModification) *mockHistoryQueryIterator {
+	return &mockHistoryQueryIterator{modifications: modifications}
+}
+
+// HasNext returns true if the iterator contains additional modifications
+func (iter *mockHistoryQueryIterator) HasNext() bool {
+	return !iter.closed && len(iter.modifications) > 0
+}
+
+// Next returns the next modification
+func (iter *mockHistoryQueryIterator) Next() (*queryresult.KeyModification, error) {
+	if !iter.HasNext() {
+		return nil, errors.New("no more modifications")
+	}
+	modification := iter.modifications[0]
+	iter.modifications = iter.modifications[1:]
+	return modification, nil
+}
+
+// Close closes the iterator
+func (iter *mockHistoryQueryIterator) Close() error {
+	iter.closed = true
+	return nil
+}
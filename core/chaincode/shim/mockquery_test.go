@@ -0,0 +1,158 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newQueryTestStub(t *testing.T) *MockStub {
+	stub := NewMockStub("query", nil)
+	stub.MockTransactionStart("init")
+	for key, value := range map[string]string{
+		"marble1": `{"color":"blue","size":35,"owner":{"name":"tom","org":"Org1"},"tags":["round","shiny"]}`,
+		"marble2": `{"color":"red","size":50,"owner":{"name":"ann","org":"Org2"},"tags":["round"]}`,
+		"marble3": `{"color":"blue","size":70,"owner":{"name":"ann","org":"Org2"},"tags":[]}`,
+		"marble4": `{"color":"green","size":null,"owner":{"name":"bob","org":"Org1"}}`,
+		"binary":  "not JSON",
+		"array":   `["blue"]`,
+	} {
+		assert.NoError(t, stub.PutState(key, []byte(value)))
+	}
+	stub.MockTransactionEnd("init")
+	return stub
+}
+
+func queryKeys(t *testing.T, iter StateQueryIteratorInterface) []string {
+	keys := []string{}
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		assert.NoError(t, err)
+		keys = append(keys, kv.Key)
+	}
+	assert.NoError(t, iter.Close())
+	return keys
+}
+
+func TestMockQuerySelectors(t *testing.T) {
+	stub := newQueryTestStub(t)
+
+	for query, expectedKeys := range map[string][]string{
+		`{"selector":{}}`:                                                         {"marble1", "marble2", "marble3", "marble4"},
+		`{"selector":{"color":"blue"}}`:                                           {"marble1", "marble3"},
+		`{"selector":{"color":{"$eq":"blue"},"size":{"$gt":40}}}`:                 {"marble3"},
+		`{"selector":{"color":{"$ne":"blue"}}}`:                                   {"marble2", "marble4"},
+		`{"selector":{"size":{"$gte":35,"$lt":70}}}`:                              {"marble1", "marble2"},
+		`{"selector":{"size":{"$lte":35}}}`:                                       {"marble1", "marble4"},
+		`{"selector":{"owner.org":"Org1"}}`:                                       {"marble1", "marble4"},
+		`{"selector":{"owner":{"name":"ann","org":"Org2"}}}`:                      {"marble2", "marble3"},
+		`{"selector":{"owner":{"$eq":{"org":"Org2","name":"ann"}}}}`:              {"marble2", "marble3"},
+		`{"selector":{"tags":{"$exists":false}}}`:                                 {"marble4"},
+		`{"selector":{"size":{"$type":"null"}}}`:                                  {"marble4"},
+		`{"selector":{"color":{"$in":["red","green"]}}}`:                          {"marble2", "marble4"},
+		`{"selector":{"color":{"$nin":["red","green"]}}}`:                         {"marble1", "marble3"},
+		`{"selector":{"tags":{"$in":["shiny"]}}}`:                                 {"marble1"},
+		`{"selector":{"tags":{"$size":1}}}`:                                       {"marble2"},
+		`{"selector":{"tags":{"$all":["shiny","round"]}}}`:                        {"marble1"},
+		`{"selector":{"tags":{"$elemMatch":{"$eq":"round"}}}}`:                    {"marble1", "marble2"},
+		`{"selector":{"tags":{"$allMatch":{"$regex":"^r"}}}}`:                     {"marble2"},
+		`{"selector":{"size":{"$mod":[7,0]}}}`:                                    {"marble1", "marble3"},
+		`{"selector":{"owner.name":{"$regex":"^[ab]"}}}`:                          {"marble2", "marble3", "marble4"},
+		`{"selector":{"$or":[{"color":"red"},{"owner.name":"bob"}]}}`:             {"marble2", "marble4"},
+		`{"selector":{"$and":[{"color":"blue"},{"owner.name":"ann"}]}}`:           {"marble3"},
+		`{"selector":{"$nor":[{"color":"blue"},{"color":"red"}]}}`:                {"marble4"},
+		`{"selector":{"$not":{"color":"blue"}}}`:                                  {"marble2", "marble4"},
+		`{"selector":{"size":{"$not":{"$gt":40}}}}`:                               {"marble1", "marble4"},
+		`{"selector":{"color":"blue"},"use_index":["_design/indexColorDoc"]}`:     {"marble1", "marble3"},
+		`{"selector":{"color":"purple"}}`:                                         {},
+		`{"selector":{"owner.name":{"$gt":null}},"sort":[{"owner.name":"desc"}]}`: {"marble1", "marble4", "marble2", "marble3"},
+		`{"selector":{},"sort":["color","size"]}`:                                 {"marble1", "marble3", "marble4", "marble2"},
+		`{"selector":{},"sort":[{"size":"desc"}],"skip":1,"limit":2}`:             {"marble2", "marble1"},
+		`{"selector":{},"skip":10}`:                                               {},
+	} {
+		iter, err := stub.GetQueryResult(query)
+		if assert.NoError(t, err, query) {
+			assert.Equal(t, expectedKeys, queryKeys(t, iter), query)
+		}
+	}
+}
+
+func TestMockQueryFields(t *testing.T) {
+	stub := newQueryTestStub(t)
+	iter, err := stub.GetQueryResult(`{"selector":{"color":"red"},"fields":["size","owner.name","missing"]}`)
+	assert.NoError(t, err)
+	kv, err := iter.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, "marble2", kv.Key)
+	assert.JSONEq(t, `{"size":50,"owner":{"name":"ann"}}`, string(kv.Value))
+	assert.False(t, iter.HasNext())
+	_, err = iter.Next()
+	assert.Error(t, err)
+}
+
+func TestMockQueryErrors(t *testing.T) {
+	stub := newQueryTestStub(t)
+	for query, expectedErr := range map[string]string{
+		`q`:                                      "invalid query q",
+		`{"fields":["color"]}`:                   "missing selector",
+		`{"selector":[]}`:                        "expected a selector",
+		`{"selector":{},"limit":-1}`:             "expected a non-negative integer, got -1",
+		`{"selector":{},"skip":1.5}`:             "expected a non-negative integer, got 1.5",
+		`{"selector":{},"fields":"color"}`:       "fields must be an array of field names",
+		`{"selector":{},"sort":[{"size":"up"}]}`: "invalid sort direction up of field size",
+		`{"selector":{},"bookmark":"b"}`:         "unsupported parameter bookmark",
+		`{"selector":{"$xor":[]}}`:               "unknown operator $xor",
+		`{"selector":{"$or":{}}}`:                "expected an array of selectors as argument of $or",
+		`{"selector":{"size":{"$near":1}}}`:      "unknown operator $near",
+		`{"selector":{"size":{"$exists":1}}}`:    "expected a boolean as argument of $exists",
+		`{"selector":{"size":{"$in":1}}}`:        "expected an array as argument of $in",
+		`{"selector":{"size":{"$mod":[0,1]}}}`:   "expected [divisor, remainder] as argument of $mod",
+		`{"selector":{"color":{"$regex":"("}}}`:  "invalid argument of $regex",
+	} {
+		_, err := stub.GetQueryResult(query)
+		if assert.Error(t, err, query) {
+			assert.Contains(t, err.Error(), expectedErr, query)
+		}
+	}
+}
+
+func TestMockPrivateDataQueries(t *testing.T) {
+	stub := NewMockStub("pvt", nil)
+	stub.MockTransactionStart("init")
+	compositeKey, _ := stub.CreateCompositeKey("marble", []string{"blue", "marble1"})
+	assert.NoError(t, stub.PutPrivateData("coll1", compositeKey, []byte(`{"color":"blue"}`)))
+	assert.NoError(t, stub.PutPrivateData("coll1", "a", []byte(`{"color":"red"}`)))
+	assert.NoError(t, stub.PutPrivateData("coll1", "b", []byte(`{"color":"blue"}`)))
+	assert.NoError(t, stub.PutPrivateData("coll1", "c", []byte(`{"color":"green"}`)))
+	assert.NoError(t, stub.PutPrivateData("coll2", "b", []byte(`{"color":"blue"}`)))
+	stub.MockTransactionEnd("init")
+
+	iter, err := stub.GetPrivateDataByRange("coll1", "a", "c")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, queryKeys(t, iter))
+	iter, err = stub.GetPrivateDataByRange("coll1", "b", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, queryKeys(t, iter))
+	iter, err = stub.GetPrivateDataByPartialCompositeKey("coll1", "marble", []string{"blue"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{compositeKey}, queryKeys(t, iter))
+	iter, err = stub.GetPrivateDataQueryResult("coll1", `{"selector":{"color":"blue"}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{compositeKey, "b"}, queryKeys(t, iter))
+	iter, err = stub.GetPrivateDataQueryResult("coll2", `{"selector":{"color":"blue"}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b"}, queryKeys(t, iter))
+
+	_, err = stub.GetPrivateDataByRange("coll1", compositeKey, "")
+	assert.Error(t, err)
+	_, err = stub.GetPrivateDataQueryResult("coll1", `{}`)
+	assert.Error(t, err)
+	_, err = stub.GetPrivateDataByRange("", "a", "b")
+	assert.EqualError(t, err, "collection must not be an empty string")
+}
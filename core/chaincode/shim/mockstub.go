@@ -11,11 +11,15 @@ package shim
 import (
 	"container/list"
 	"fmt"
+	"sort"
 	"strings"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/timestamp"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/msp"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/op/go-logging"
 	"github.com/pkg/errors"
@@ -26,6 +30,12 @@ var mockLogger = logging.MustGetLogger("mock")
 
 // MockStub is an implementation of ChaincodeStubInterface for unit testing chaincode.
 // Use this instead of ChaincodeStub in your chaincode's unit test calls to Init or Invoke.
+//
+// MockStub keeps the versions and the history of the keys, records the read-write
+// set of each transaction in RWSet, and evaluates CouchDB queries in memory.
+// MockInvoke applies the writes of a transaction as it makes them, while
+// MockSimulate and MockCommit simulate and validate transactions separately, as
+// endorsing and committing peers do, which reproduces MVCC conflicts.
 type MockStub struct {
 	// arguments the stub was called with
 	args [][]byte
@@ -40,6 +50,9 @@ type MockStub struct {
 	// State keeps name value pairs
 	State map[string][]byte
 
+	// PvtState keeps the name value pairs of the private data collections
+	PvtState map[string]map[string][]byte
+
 	// Keys stores the list of mapped values in lexical order
 	Keys *list.List
 
@@ -57,6 +70,36 @@ type MockStub struct {
 
 	// stores a channel ID of the proposal
 	ChannelID string
+
+	// Creator is the serialized identity of the client submitting the transactions
+	Creator []byte
+
+	// TransientMap is the transient data passed to the transactions
+	TransientMap map[string][]byte
+
+	// ChaincodeEventsChannel receives the event of each committed transaction which set one
+	ChaincodeEventsChannel chan *pb.ChaincodeEvent
+
+	// RWSet is the read-write set of the last transaction
+	RWSet *MockRWSet
+
+	// the simulation of the current transaction
+	sim *mockTxSimulator
+
+	// the number of committed transactions, which is the block number of the
+	// version of the keys written by the last one
+	height uint64
+
+	// the versions of the keys of the state, and of the private data collections
+	versions    map[string]*kvrwset.Version
+	pvtVersions map[string]map[string]*kvrwset.Version
+
+	// the modifications of the keys of the state, oldest first
+	history map[string][]*queryresult.KeyModification
+
+	// the private data collections of the chaincode, mapped to whether the
+	// peer is a member of them, when declared
+	collections map[string]bool
 }
 
 func (stub *MockStub) GetTxID() string {
@@ -98,10 +141,28 @@ func (stub *MockStub) MockTransactionStart(txid string) {
 	stub.TxID = txid
 	stub.setSignedProposal(&pb.SignedProposal{})
 	stub.setTxTimestamp(util.CreateUtcTimestamp())
+	stub.sim = newMockTxSimulator(txid, stub.TxTimestamp)
 }
 
 // End a mocked transaction, clearing the UUID.
+// The writes of the transaction were applied as it made them, and are now committed.
 func (stub *MockStub) MockTransactionEnd(uuid string) {
+	stub.endTransaction(true)
+}
+
+// endTransaction ends the current transaction, whose writes and event are committed
+// if it succeeded, and discarded otherwise, as endorsers reject failed transactions
+func (stub *MockStub) endTransaction(succeeded bool) {
+	if stub.sim != nil {
+		tx := stub.sim.transaction()
+		stub.RWSet = tx.RWSet
+		if succeeded {
+			stub.commit(tx, false)
+		} else {
+			stub.rollback(stub.sim)
+		}
+		stub.sim = nil
+	}
 	stub.signedProposal = nil
 	stub.TxID = ""
 }
@@ -114,20 +175,22 @@ func (stub *MockStub) MockPeerChaincode(invokableChaincodeName string, otherStub
 }
 
 // Initialise this chaincode,  also starts and ends a transaction.
+// The writes and the event of the transaction are discarded if Init returns an error.
 func (stub *MockStub) MockInit(uuid string, args [][]byte) pb.Response {
 	stub.args = args
 	stub.MockTransactionStart(uuid)
 	res := stub.cc.Init(stub)
-	stub.MockTransactionEnd(uuid)
+	stub.endTransaction(res.Status < ERRORTHRESHOLD)
 	return res
 }
 
 // Invoke this chaincode, also starts and ends a transaction.
+// The writes and the event of the transaction are discarded if Invoke returns an error.
 func (stub *MockStub) MockInvoke(uuid string, args [][]byte) pb.Response {
 	stub.args = args
 	stub.MockTransactionStart(uuid)
 	res := stub.cc.Invoke(stub)
-	stub.MockTransactionEnd(uuid)
+	stub.endTransaction(res.Status < ERRORTHRESHOLD)
 	return res
 }
 
@@ -141,41 +204,182 @@ func (stub *MockStub) MockInvokeWithSignedProposal(uuid string, args [][]byte, s
 	stub.MockTransactionStart(uuid)
 	stub.signedProposal = sp
 	res := stub.cc.Invoke(stub)
-	stub.MockTransactionEnd(uuid)
+	stub.endTransaction(res.Status < ERRORTHRESHOLD)
 	return res
 }
 
+// MockCollection declares a private data collection of the chaincode, and whether the
+// peer is a member of it. Once collections are declared, accessing the private data of
+// other collections fails, and the private data of the collections the peer is not a
+// member of can't be read, as on peers which only have the hashes of the private data
+func (stub *MockStub) MockCollection(collection string, member bool) {
+	if stub.collections == nil {
+		stub.collections = make(map[string]bool)
+	}
+	stub.collections[collection] = member
+}
+
+func (stub *MockStub) checkCollection(collection string) error {
+	if collection == "" {
+		return errors.New("collection must not be an empty string")
+	}
+	if stub.collections == nil {
+		return nil
+	}
+	if _, defined := stub.collections[collection]; !defined {
+		return errors.Errorf("collection %s is not defined", collection)
+	}
+	return nil
+}
+
+// isMember returns whether the peer has the private data of a collection
+func (stub *MockStub) isMember(collection string) bool {
+	return stub.collections == nil || stub.collections[collection]
+}
+
+// GetPrivateData retrieves the value for a given key from a private data collection.
+// As on peers, reading a key of a collection the peer is not a member of fails if the
+// peer has the hash of its value
 func (stub *MockStub) GetPrivateData(collection string, key string) ([]byte, error) {
-	return nil, errors.New("Not Implemented")
+	if err := stub.checkCollection(collection); err != nil {
+		return nil, err
+	}
+	value := stub.PvtState[collection][key]
+	if !stub.isMember(collection) && value != nil {
+		return nil, errors.Errorf("private data of key %s of collection %s is not available", key, collection)
+	}
+	if stub.sim != nil {
+		stub.sim.readPrivate(collection, key, stub.pvtVersions[collection][key])
+	}
+	return value, nil
+}
+
+// GetPrivateDataHash retrieves the hash of the value for a given key from a private data collection
+func (stub *MockStub) GetPrivateDataHash(collection, key string) ([]byte, error) {
+	if err := stub.checkCollection(collection); err != nil {
+		return nil, err
+	}
+	if stub.sim != nil {
+		stub.sim.readPrivate(collection, key, stub.pvtVersions[collection][key])
+	}
+	value := stub.PvtState[collection][key]
+	if value == nil {
+		return nil, nil
+	}
+	return util.ComputeSHA256(value), nil
 }
 
+// PutPrivateData writes the specified `value` and `key` into a private data collection
 func (stub *MockStub) PutPrivateData(collection string, key string, value []byte) error {
-	return errors.New("Not Implemented")
+	if stub.TxID == "" {
+		err := errors.New("cannot PutPrivateData without a transactions - call stub.MockTransactionStart()?")
+		mockLogger.Errorf("%+v", err)
+		return err
+	}
+	if err := stub.checkCollection(collection); err != nil {
+		return err
+	}
+
+	mockLogger.Debug("MockStub", stub.Name, "Putting", key, value, "in collection", collection)
+	if stub.sim != nil {
+		stub.sim.writePrivate(collection, key, value, false)
+		if stub.sim.buffered {
+			return nil
+		}
+		stub.sim.savePrivate(collection, key, stub.PvtState[collection])
+	}
+	stub.putPrivateData(collection, key, value)
+	return nil
 }
 
+func (stub *MockStub) putPrivateData(collection string, key string, value []byte) {
+	if _, in := stub.PvtState[collection]; !in {
+		stub.PvtState[collection] = make(map[string][]byte)
+	}
+	stub.PvtState[collection][key] = value
+}
+
+// DelPrivateData removes the specified `key` and its value from a private data collection
 func (stub *MockStub) DelPrivateData(collection string, key string) error {
-	return errors.New("Not Implemented")
+	if err := stub.checkCollection(collection); err != nil {
+		return err
+	}
+
+	mockLogger.Debug("MockStub", stub.Name, "Deleting", key, "from collection", collection)
+	if stub.sim != nil {
+		stub.sim.writePrivate(collection, key, nil, true)
+		if stub.sim.buffered {
+			return nil
+		}
+		stub.sim.savePrivate(collection, key, stub.PvtState[collection])
+	}
+	delete(stub.PvtState[collection], key)
+	return nil
 }
 
+// GetPrivateDataByRange returns the keys of a private data collection between the
+// startKey (inclusive) and the endKey (exclusive). Empty keys leave the range open
 func (stub *MockStub) GetPrivateDataByRange(collection, startKey, endKey string) (StateQueryIteratorInterface, error) {
-	return nil, errors.New("Not Implemented")
+	if err := stub.checkCollection(collection); err != nil {
+		return nil, err
+	}
+	if err := validateSimpleKeys(startKey, endKey); err != nil {
+		return nil, err
+	}
+	return newMockQueryIterator(stub.privateDataInRange(collection, startKey, endKey)), nil
 }
 
 func (stub *MockStub) GetPrivateDataByPartialCompositeKey(collection, objectType string, attributes []string) (StateQueryIteratorInterface, error) {
-	return nil, errors.New("Not Implemented")
+	if err := stub.checkCollection(collection); err != nil {
+		return nil, err
+	}
+	partialCompositeKey, err := stub.CreateCompositeKey(objectType, attributes)
+	if err != nil {
+		return nil, err
+	}
+	return newMockQueryIterator(stub.privateDataInRange(collection, partialCompositeKey, partialCompositeKey+string(maxUnicodeRuneValue))), nil
 }
 
+// GetPrivateDataQueryResult performs a CouchDB query against the JSON values of a
+// private data collection, see GetQueryResult
 func (stub *MockStub) GetPrivateDataQueryResult(collection, query string) (StateQueryIteratorInterface, error) {
-	// Not implemented since the mock engine does not have a query engine.
-	// However, a very simple query engine that supports string matching
-	// could be implemented to test that the framework supports queries
-	return nil, errors.New("Not Implemented")
+	if err := stub.checkCollection(collection); err != nil {
+		return nil, err
+	}
+	q, err := parseMockQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	return newMockQueryIterator(q.execute(stub.privateDataInRange(collection, "", ""))), nil
+}
+
+// privateDataInRange returns the keys and values of a private data collection between
+// the startKey (inclusive) and the endKey (exclusive), in the order of the keys
+func (stub *MockStub) privateDataInRange(collection, startKey, endKey string) []*queryresult.KV {
+	if !stub.isMember(collection) {
+		return nil
+	}
+	var keys []string
+	for key := range stub.PvtState[collection] {
+		if (startKey == "" || key >= startKey) && (endKey == "" || key < endKey) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	kvs := make([]*queryresult.KV, 0, len(keys))
+	for _, key := range keys {
+		kvs = append(kvs, &queryresult.KV{Key: key, Value: stub.PvtState[collection][key]})
+	}
+	return kvs
 }
 
 // GetState retrieves the value for a given key from the ledger
 func (stub *MockStub) GetState(key string) ([]byte, error) {
 	value := stub.State[key]
 	mockLogger.Debug("MockStub", stub.Name, "Getting", key, value)
+	if stub.sim != nil {
+		stub.sim.read(key, stub.versions[key])
+	}
 	return value, nil
 }
 
@@ -188,6 +392,18 @@ func (stub *MockStub) PutState(key string, value []byte) error {
 	}
 
 	mockLogger.Debug("MockStub", stub.Name, "Putting", key, value)
+	if stub.sim != nil {
+		stub.sim.write(key, value, false)
+		if stub.sim.buffered {
+			return nil
+		}
+		stub.sim.save(key, stub.State)
+	}
+	stub.putState(key, value)
+	return nil
+}
+
+func (stub *MockStub) putState(key string, value []byte) {
 	stub.State[key] = value
 
 	// insert key into ordered list of keys
@@ -219,13 +435,23 @@ func (stub *MockStub) PutState(key string, value []byte) error {
 		stub.Keys.PushFront(key)
 		mockLogger.Debug("MockStub", stub.Name, "Key", key, "is first element in list")
 	}
-
-	return nil
 }
 
 // DelState removes the specified `key` and its value from the ledger.
 func (stub *MockStub) DelState(key string) error {
 	mockLogger.Debug("MockStub", stub.Name, "Deleting", key, stub.State[key])
+	if stub.sim != nil {
+		stub.sim.write(key, nil, true)
+		if stub.sim.buffered {
+			return nil
+		}
+		stub.sim.save(key, stub.State)
+	}
+	stub.delState(key)
+	return nil
+}
+
+func (stub *MockStub) delState(key string) {
 	delete(stub.State, key)
 
 	for elem := stub.Keys.Front(); elem != nil; elem = elem.Next() {
@@ -233,15 +459,23 @@ func (stub *MockStub) DelState(key string) error {
 			stub.Keys.Remove(elem)
 		}
 	}
-
-	return nil
 }
 
 func (stub *MockStub) GetStateByRange(startKey, endKey string) (StateQueryIteratorInterface, error) {
 	if err := validateSimpleKeys(startKey, endKey); err != nil {
 		return nil, err
 	}
-	return NewMockStateRangeQueryIterator(stub, startKey, endKey), nil
+	return stub.newRangeQueryIterator(startKey, endKey), nil
+}
+
+// newRangeQueryIterator creates a range query iterator whose results are recorded
+// in the read-write set of the current transaction
+func (stub *MockStub) newRangeQueryIterator(startKey, endKey string) *MockStateRangeQueryIterator {
+	iter := NewMockStateRangeQueryIterator(stub, startKey, endKey)
+	if stub.sim != nil {
+		iter.rangeQuery = stub.sim.rangeQuery(startKey, endKey)
+	}
+	return iter
 }
 
 // GetQueryResult function can be invoked by a chaincode to perform a
@@ -249,17 +483,30 @@ func (stub *MockStub) GetStateByRange(startKey, endKey string) (StateQueryIterat
 // that support rich query.  The query string is in the syntax of the underlying
 // state database. An iterator is returned which can be used to iterate (next) over
 // the query result set
+//
+// MockStub evaluates CouchDB queries in memory against the values of the state
+// which are JSON objects. As on peers, the results of rich queries are not recorded
+// in the read-write set of the transaction
 func (stub *MockStub) GetQueryResult(query string) (StateQueryIteratorInterface, error) {
-	// Not implemented since the mock engine does not have a query engine.
-	// However, a very simple query engine that supports string matching
-	// could be implemented to test that the framework supports queries
-	return nil, errors.New("not implemented")
+	q, err := parseMockQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	kvs := make([]*queryresult.KV, 0, stub.Keys.Len())
+	for elem := stub.Keys.Front(); elem != nil; elem = elem.Next() {
+		key := elem.Value.(string)
+		kvs = append(kvs, &queryresult.KV{Key: key, Value: stub.State[key]})
+	}
+	return newMockQueryIterator(q.execute(kvs)), nil
 }
 
 // GetHistoryForKey function can be invoked by a chaincode to return a history of
 // key values across time. GetHistoryForKey is intended to be used for read-only queries.
+//
+// MockStub returns the modifications of the key by the transactions committed so
+// far, oldest first
 func (stub *MockStub) GetHistoryForKey(key string) (HistoryQueryIteratorInterface, error) {
-	return nil, errors.New("not implemented")
+	return newMockHistoryQueryIterator(stub.history[key]), nil
 }
 
 //GetStateByPartialCompositeKey function can be invoked by a chaincode to query the
@@ -273,7 +520,7 @@ func (stub *MockStub) GetStateByPartialCompositeKey(objectType string, attribute
 	if err != nil {
 		return nil, err
 	}
-	return NewMockStateRangeQueryIterator(stub, partialCompositeKey, partialCompositeKey+string(maxUnicodeRuneValue)), nil
+	return stub.newRangeQueryIterator(partialCompositeKey, partialCompositeKey+string(maxUnicodeRuneValue)), nil
 }
 
 // CreateCompositeKey combines the list of attributes
@@ -306,14 +553,27 @@ func (stub *MockStub) InvokeChaincode(chaincodeName string, args [][]byte, chann
 	return res
 }
 
-// Not implemented
+// GetCreator returns the Creator of the stub
 func (stub *MockStub) GetCreator() ([]byte, error) {
-	return nil, nil
+	return stub.Creator, nil
 }
 
-// Not implemented
+// MockCreator sets the Creator of the stub to the identity of a client of an MSP,
+// so that chaincode can identify the client with the client identity library.
+// idBytes is the PEM-encoded X509 certificate of the client, or its serialized
+// idemix identity
+func (stub *MockStub) MockCreator(mspID string, idBytes []byte) error {
+	creator, err := proto.Marshal(&msp.SerializedIdentity{Mspid: mspID, IdBytes: idBytes})
+	if err != nil {
+		return errors.Wrap(err, "failed marshaling the identity of the creator")
+	}
+	stub.Creator = creator
+	return nil
+}
+
+// GetTransient returns the TransientMap of the stub
 func (stub *MockStub) GetTransient() (map[string][]byte, error) {
-	return nil, nil
+	return stub.TransientMap, nil
 }
 
 // Not implemented
@@ -346,8 +606,17 @@ func (stub *MockStub) GetTxTimestamp() (*timestamp.Timestamp, error) {
 	return stub.TxTimestamp, nil
 }
 
-// Not implemented
+// SetEvent sets the event of the current transaction, which is sent to the
+// ChaincodeEventsChannel once the transaction is committed
 func (stub *MockStub) SetEvent(name string, payload []byte) error {
+	if name == "" {
+		return errors.New("event name can not be nil string")
+	}
+	if stub.sim == nil {
+		// events set outside of a transaction are ignored
+		return nil
+	}
+	stub.sim.event = &pb.ChaincodeEvent{ChaincodeId: stub.Name, TxId: stub.TxID, EventName: name, Payload: payload}
 	return nil
 }
 
@@ -358,8 +627,13 @@ func NewMockStub(name string, cc Chaincode) *MockStub {
 	s.Name = name
 	s.cc = cc
 	s.State = make(map[string][]byte)
+	s.PvtState = make(map[string]map[string][]byte)
 	s.Invokables = make(map[string]*MockStub)
 	s.Keys = list.New()
+	s.ChaincodeEventsChannel = make(chan *pb.ChaincodeEvent, 100)
+	s.versions = make(map[string]*kvrwset.Version)
+	s.pvtVersions = make(map[string]map[string]*kvrwset.Version)
+	s.history = make(map[string][]*queryresult.KeyModification)
 
 	return s
 }
@@ -374,11 +648,22 @@ type MockStateRangeQueryIterator struct {
 	StartKey string
 	EndKey   string
 	Current  *list.Element
+
+	// the range query recorded in the read-write set of the transaction, if any
+	rangeQuery *kvrwset.RangeQueryInfo
 }
 
 // HasNext returns true if the range query iterator contains additional keys
 // and values.
 func (iter *MockStateRangeQueryIterator) HasNext() bool {
+	hasNext := iter.hasNext()
+	if !hasNext && !iter.Closed && iter.rangeQuery != nil {
+		iter.rangeQuery.ItrExhausted = true
+	}
+	return hasNext
+}
+
+func (iter *MockStateRangeQueryIterator) hasNext() bool {
 	if iter.Closed {
 		// previously called Close()
 		mockLogger.Error("HasNext() but already closed")
@@ -437,9 +722,13 @@ func (iter *MockStateRangeQueryIterator) Next() (*queryresult.KV, error) {
 		// all keys, it should always return the key and value
 		if (comp1 >= 0 && comp2 <= 0) || (iter.StartKey == "" && iter.EndKey == "") {
 			key := iter.Current.Value.(string)
-			value, err := iter.Stub.GetState(key)
+			value := iter.Stub.State[key]
+			if iter.rangeQuery != nil {
+				reads := iter.rangeQuery.GetRawReads()
+				reads.KvReads = append(reads.KvReads, &kvrwset.KVRead{Key: key, Version: iter.Stub.versions[key]})
+			}
 			iter.Current = iter.Current.Next()
-			return &queryresult.KV{Key: key, Value: value}, err
+			return &queryresult.KV{Key: key, Value: value}, nil
 		}
 		iter.Current = iter.Current.Next()
 	}
@@ -22,7 +22,10 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protos/msp"
 	"github.com/spf13/viper"
 )
 
@@ -235,6 +238,139 @@ func TestGetTxTimestamp(t *testing.T) {
 	stub.MockTransactionEnd("init")
 }
 
+func TestMockPrivateData(t *testing.T) {
+	stub := NewMockStub("PrivateData", nil)
+	stub.MockTransactionStart("init")
+
+	err := stub.PutPrivateData("coll1", "key1", []byte("value1"))
+	if err != nil {
+		t.Fatalf("Failed to put private data: %s", err)
+	}
+	value, _ := stub.GetPrivateData("coll1", "key1")
+	if string(value) != "value1" {
+		t.Fatalf("Expected value1, got %s", value)
+	}
+	value, _ = stub.GetPrivateData("coll2", "key1")
+	if value != nil {
+		t.Fatalf("Expected no value in another collection, got %s", value)
+	}
+
+	hash, _ := stub.GetPrivateDataHash("coll1", "key1")
+	if !reflect.DeepEqual(hash, util.ComputeSHA256([]byte("value1"))) {
+		t.Fatalf("Expected the hash of value1, got %x", hash)
+	}
+	hash, _ = stub.GetPrivateDataHash("coll1", "key2")
+	if hash != nil {
+		t.Fatalf("Expected no hash for a missing key, got %x", hash)
+	}
+
+	stub.DelPrivateData("coll1", "key1")
+	value, _ = stub.GetPrivateData("coll1", "key1")
+	if value != nil {
+		t.Fatalf("Expected the value to be deleted, got %s", value)
+	}
+	stub.MockTransactionEnd("init")
+
+	err = stub.PutPrivateData("coll1", "key1", []byte("value1"))
+	if err == nil {
+		t.Fatal("Expected private data not to be put outside of a transaction")
+	}
+}
+
+func TestMockCollections(t *testing.T) {
+	stub := NewMockStub("Collections", nil)
+	stub.MockCollection("coll1", true)
+	stub.MockCollection("coll2", false)
+	stub.MockTransactionStart("init")
+
+	for _, collection := range []string{"coll1", "coll2"} {
+		if err := stub.PutPrivateData(collection, "key1", []byte("value1")); err != nil {
+			t.Fatalf("Failed to put private data in %s: %s", collection, err)
+		}
+	}
+	value, err := stub.GetPrivateData("coll1", "key1")
+	if err != nil || string(value) != "value1" {
+		t.Fatalf("Expected value1, got %s, %v", value, err)
+	}
+	// the peer only has the hashes of the private data of coll2
+	if _, err = stub.GetPrivateData("coll2", "key1"); err == nil {
+		t.Fatal("Expected the private data of a collection the peer is not a member of not to be available")
+	}
+	value, err = stub.GetPrivateData("coll2", "key2")
+	if err != nil || value != nil {
+		t.Fatalf("Expected no value for a key without a hash, got %s, %v", value, err)
+	}
+	hash, err := stub.GetPrivateDataHash("coll2", "key1")
+	if err != nil || !reflect.DeepEqual(hash, util.ComputeSHA256([]byte("value1"))) {
+		t.Fatalf("Expected the hash of value1, got %x, %v", hash, err)
+	}
+	iter, err := stub.GetPrivateDataByRange("coll2", "", "")
+	if err != nil || iter.HasNext() {
+		t.Fatalf("Expected no private data of coll2, got %v", err)
+	}
+
+	if _, err = stub.GetPrivateData("coll3", "key1"); err == nil || err.Error() != "collection coll3 is not defined" {
+		t.Fatalf("Expected an error for an undefined collection, got %v", err)
+	}
+	if err = stub.PutPrivateData("coll3", "key1", nil); err == nil {
+		t.Fatal("Expected private data not to be put in an undefined collection")
+	}
+	if err = stub.DelPrivateData("coll3", "key1"); err == nil {
+		t.Fatal("Expected private data not to be deleted from an undefined collection")
+	}
+	stub.MockTransactionEnd("init")
+
+	rwset := stub.RWSet.Private
+	if len(rwset) != 2 || len(rwset["coll1"].Reads) != 1 || len(rwset["coll2"].Writes) != 1 {
+		t.Fatalf("Unexpected private read-write set %v", rwset)
+	}
+}
+
+func TestMockCreatorAndTransient(t *testing.T) {
+	stub := NewMockStub("Creator", nil)
+	if err := stub.MockCreator("Org1MSP", []byte("certificate")); err != nil {
+		t.Fatalf("Failed to set the creator: %s", err)
+	}
+	creator, _ := stub.GetCreator()
+	sid := &msp.SerializedIdentity{}
+	if err := proto.Unmarshal(creator, sid); err != nil || sid.Mspid != "Org1MSP" || string(sid.IdBytes) != "certificate" {
+		t.Fatalf("Unexpected creator %v, %v", sid, err)
+	}
+
+	stub.TransientMap = map[string][]byte{"secret": []byte("value")}
+	transient, _ := stub.GetTransient()
+	if string(transient["secret"]) != "value" {
+		t.Fatalf("Unexpected transient map %v", transient)
+	}
+}
+
+func TestMockEvents(t *testing.T) {
+	stub := NewMockStub("Events", nil)
+	// events set outside of a transaction are ignored
+	if err := stub.SetEvent("e", nil); err != nil {
+		t.Fatalf("Expected an event set outside of a transaction to be ignored, got %s", err)
+	}
+	stub.MockTransactionStart("tx1")
+	if err := stub.SetEvent("", nil); err == nil {
+		t.Fatal("Expected an event without a name to be rejected")
+	}
+	stub.SetEvent("first", nil)
+	stub.SetEvent("second", []byte("payload"))
+	if len(stub.ChaincodeEventsChannel) != 0 {
+		t.Fatal("Expected no event before the transaction is committed")
+	}
+	stub.MockTransactionEnd("tx1")
+
+	// only the last event of a transaction is kept
+	event := <-stub.ChaincodeEventsChannel
+	if event.EventName != "second" || string(event.Payload) != "payload" || event.TxId != "tx1" || event.ChaincodeId != "Events" {
+		t.Fatalf("Unexpected event %v", event)
+	}
+	if len(stub.ChaincodeEventsChannel) != 0 {
+		t.Fatal("Expected a single event")
+	}
+}
+
 //TestMockMock clearly cheating for coverage... but not. Mock should
 //be tucked away under common/mocks package which is not
 //included for coverage. Moving mockstub to another package
@@ -0,0 +1,336 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"sort"
+
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+// MockRWSet is the read-write set of a transaction of a MockStub, as endorsers
+// record it. Reads and writes are sorted by key, and unlike on peers the writes
+// of private data hold the values rather than their hashes
+type MockRWSet struct {
+	// Public holds the reads, the range queries and the writes of the state
+	Public *kvrwset.KVRWSet
+
+	// Private holds the reads and the writes of each private data collection
+	Private map[string]*kvrwset.KVRWSet
+}
+
+// MockTransaction is a transaction simulated by MockSimulate, which MockCommit
+// validates and commits
+type MockTransaction struct {
+	TxID      string
+	Timestamp *timestamp.Timestamp
+	RWSet     *MockRWSet
+
+	// Event is the event the transaction set, if any
+	Event *pb.ChaincodeEvent
+
+	committed bool
+}
+
+// MockSimulate simulates the invocation of the chaincode by an endorser. Unlike
+// with MockInvoke, the writes of the transaction are only recorded in its
+// read-write set: the transaction doesn't read its own writes, and they are not
+// applied until the transaction is passed to MockCommit. Simulating several
+// transactions before committing them reproduces the conflicts between
+// concurrent transactions
+func (stub *MockStub) MockSimulate(uuid string, args [][]byte) (pb.Response, *MockTransaction) {
+	stub.args = args
+	stub.MockTransactionStart(uuid)
+	stub.sim.buffered = true
+	res := stub.cc.Invoke(stub)
+	tx := stub.sim.transaction()
+	stub.sim = nil
+	stub.MockTransactionEnd(uuid)
+	stub.RWSet = tx.RWSet
+	return res, tx
+}
+
+// MockCommit validates a transaction simulated by MockSimulate as committing
+// peers do, and applies its writes if it's valid. A transaction is invalid if a
+// key it read, or the results of a range query it executed, changed since it
+// was simulated, or if it was already committed
+func (stub *MockStub) MockCommit(tx *MockTransaction) pb.TxValidationCode {
+	if tx.committed {
+		return pb.TxValidationCode_DUPLICATE_TXID
+	}
+	tx.committed = true
+	code := stub.validate(tx.RWSet)
+	mockLogger.Debug("MockStub", stub.Name, "Transaction", tx.TxID, "is", code)
+	if code == pb.TxValidationCode_VALID {
+		stub.commit(tx, true)
+	}
+	return code
+}
+
+func (stub *MockStub) validate(rwset *MockRWSet) pb.TxValidationCode {
+	for _, read := range rwset.Public.Reads {
+		if !versionsEqual(stub.versions[read.Key], read.Version) {
+			return pb.TxValidationCode_MVCC_READ_CONFLICT
+		}
+	}
+	for collection, pvtRWSet := range rwset.Private {
+		for _, read := range pvtRWSet.Reads {
+			if !versionsEqual(stub.pvtVersions[collection][read.Key], read.Version) {
+				return pb.TxValidationCode_MVCC_READ_CONFLICT
+			}
+		}
+	}
+	for _, rangeQuery := range rwset.Public.RangeQueriesInfo {
+		if !stub.validateRangeQuery(rangeQuery) {
+			return pb.TxValidationCode_PHANTOM_READ_CONFLICT
+		}
+	}
+	return pb.TxValidationCode_VALID
+}
+
+// validateRangeQuery executes a range query again, and returns whether it
+// returns the keys the transaction read, in the same versions
+func (stub *MockStub) validateRangeQuery(rangeQuery *kvrwset.RangeQueryInfo) bool {
+	reads := rangeQuery.GetRawReads().GetKvReads()
+	iter := NewMockStateRangeQueryIterator(stub, rangeQuery.StartKey, rangeQuery.EndKey)
+	for i := 0; ; i++ {
+		if !iter.HasNext() {
+			return i == len(reads)
+		}
+		if i == len(reads) {
+			// further results only matter if the transaction went through all of them
+			return !rangeQuery.ItrExhausted
+		}
+		kv, err := iter.Next()
+		if err != nil || kv.Key != reads[i].Key || !versionsEqual(stub.versions[kv.Key], reads[i].Version) {
+			return false
+		}
+	}
+}
+
+func versionsEqual(v1, v2 *kvrwset.Version) bool {
+	if v1 == nil || v2 == nil {
+		return v1 == v2
+	}
+	return v1.BlockNum == v2.BlockNum && v1.TxNum == v2.TxNum
+}
+
+// commit records the versions and the history of the keys a transaction wrote,
+// and applies its writes unless they were applied while it was simulated. The
+// keys written by each transaction get the version of a block of its own
+func (stub *MockStub) commit(tx *MockTransaction, apply bool) {
+	stub.height++
+	version := &kvrwset.Version{BlockNum: stub.height}
+
+	for _, write := range tx.RWSet.Public.Writes {
+		if apply {
+			if write.IsDelete {
+				stub.delState(write.Key)
+			} else {
+				stub.putState(write.Key, write.Value)
+			}
+		}
+		if write.IsDelete {
+			delete(stub.versions, write.Key)
+		} else {
+			stub.versions[write.Key] = version
+		}
+		stub.history[write.Key] = append(stub.history[write.Key], &queryresult.KeyModification{
+			TxId:      tx.TxID,
+			Value:     write.Value,
+			Timestamp: tx.Timestamp,
+			IsDelete:  write.IsDelete,
+		})
+	}
+
+	for collection, pvtRWSet := range tx.RWSet.Private {
+		for _, write := range pvtRWSet.Writes {
+			if apply {
+				if write.IsDelete {
+					delete(stub.PvtState[collection], write.Key)
+				} else {
+					stub.putPrivateData(collection, write.Key, write.Value)
+				}
+			}
+			if _, in := stub.pvtVersions[collection]; !in {
+				stub.pvtVersions[collection] = make(map[string]*kvrwset.Version)
+			}
+			if write.IsDelete {
+				delete(stub.pvtVersions[collection], write.Key)
+			} else {
+				stub.pvtVersions[collection][write.Key] = version
+			}
+		}
+	}
+
+	if tx.Event != nil {
+		select {
+		case stub.ChaincodeEventsChannel <- tx.Event:
+		default:
+			mockLogger.Warning("MockStub", stub.Name, "Dropping the event of transaction", tx.TxID, "since the ChaincodeEventsChannel is full")
+		}
+	}
+}
+
+// rollback restores the values the keys written by a transaction had before
+// the transaction applied its writes
+func (stub *MockStub) rollback(s *mockTxSimulator) {
+	for key, prior := range s.priorValues {
+		if prior.IsDelete {
+			stub.delState(key)
+		} else {
+			stub.putState(key, prior.Value)
+		}
+	}
+	for collection, priorValues := range s.pvtPriorValues {
+		for key, prior := range priorValues {
+			if prior.IsDelete {
+				delete(stub.PvtState[collection], key)
+			} else {
+				stub.putPrivateData(collection, key, prior.Value)
+			}
+		}
+	}
+}
+
+// mockTxSimulator records the read-write set of a transaction as it is simulated
+type mockTxSimulator struct {
+	txID      string
+	timestamp *timestamp.Timestamp
+
+	// buffered is set when the writes of the transaction must not be applied
+	// until the transaction is committed
+	buffered bool
+
+	reads        map[string]*kvrwset.KVRead
+	rangeQueries []*kvrwset.RangeQueryInfo
+	writes       map[string]*kvrwset.KVWrite
+	pvtReads     map[string]map[string]*kvrwset.KVRead
+	pvtWrites    map[string]map[string]*kvrwset.KVWrite
+	event        *pb.ChaincodeEvent
+
+	// the values of the keys before the transaction applied its writes,
+	// which are restored if the transaction fails
+	priorValues    map[string]*kvrwset.KVWrite
+	pvtPriorValues map[string]map[string]*kvrwset.KVWrite
+}
+
+func newMockTxSimulator(txID string, timestamp *timestamp.Timestamp) *mockTxSimulator {
+	return &mockTxSimulator{
+		txID:      txID,
+		timestamp: timestamp,
+		reads:     make(map[string]*kvrwset.KVRead),
+		writes:    make(map[string]*kvrwset.KVWrite),
+		pvtReads:  make(map[string]map[string]*kvrwset.KVRead),
+		pvtWrites: make(map[string]map[string]*kvrwset.KVWrite),
+
+		priorValues:    make(map[string]*kvrwset.KVWrite),
+		pvtPriorValues: make(map[string]map[string]*kvrwset.KVWrite),
+	}
+}
+
+// read records the version of a key the first time the transaction reads it
+func (s *mockTxSimulator) read(key string, version *kvrwset.Version) {
+	if _, read := s.reads[key]; !read {
+		s.reads[key] = &kvrwset.KVRead{Key: key, Version: version}
+	}
+}
+
+func (s *mockTxSimulator) write(key string, value []byte, isDelete bool) {
+	s.writes[key] = &kvrwset.KVWrite{Key: key, Value: value, IsDelete: isDelete}
+}
+
+// save records the value of a key in the given state before the transaction first writes it
+func (s *mockTxSimulator) save(key string, state map[string][]byte) {
+	if _, saved := s.priorValues[key]; !saved {
+		s.priorValues[key] = priorValue(key, state)
+	}
+}
+
+func (s *mockTxSimulator) savePrivate(collection, key string, state map[string][]byte) {
+	if _, in := s.pvtPriorValues[collection]; !in {
+		s.pvtPriorValues[collection] = make(map[string]*kvrwset.KVWrite)
+	}
+	if _, saved := s.pvtPriorValues[collection][key]; !saved {
+		s.pvtPriorValues[collection][key] = priorValue(key, state)
+	}
+}
+
+// priorValue returns the write which restores the value of a key in the given state
+func priorValue(key string, state map[string][]byte) *kvrwset.KVWrite {
+	value, exists := state[key]
+	return &kvrwset.KVWrite{Key: key, Value: value, IsDelete: !exists}
+}
+
+func (s *mockTxSimulator) readPrivate(collection, key string, version *kvrwset.Version) {
+	if _, in := s.pvtReads[collection]; !in {
+		s.pvtReads[collection] = make(map[string]*kvrwset.KVRead)
+	}
+	if _, read := s.pvtReads[collection][key]; !read {
+		s.pvtReads[collection][key] = &kvrwset.KVRead{Key: key, Version: version}
+	}
+}
+
+func (s *mockTxSimulator) writePrivate(collection, key string, value []byte, isDelete bool) {
+	if _, in := s.pvtWrites[collection]; !in {
+		s.pvtWrites[collection] = make(map[string]*kvrwset.KVWrite)
+	}
+	s.pvtWrites[collection][key] = &kvrwset.KVWrite{Key: key, Value: value, IsDelete: isDelete}
+}
+
+// rangeQuery records a range query, whose iterator records the keys it returns
+func (s *mockTxSimulator) rangeQuery(startKey, endKey string) *kvrwset.RangeQueryInfo {
+	rangeQuery := &kvrwset.RangeQueryInfo{
+		StartKey:  startKey,
+		EndKey:    endKey,
+		ReadsInfo: &kvrwset.RangeQueryInfo_RawReads{RawReads: &kvrwset.QueryReads{}},
+	}
+	s.rangeQueries = append(s.rangeQueries, rangeQuery)
+	return rangeQuery
+}
+
+func (s *mockTxSimulator) transaction() *MockTransaction {
+	rwset := &MockRWSet{
+		Public: &kvrwset.KVRWSet{
+			Reads:            sortedReads(s.reads),
+			RangeQueriesInfo: s.rangeQueries,
+			Writes:           sortedWrites(s.writes),
+		},
+		Private: make(map[string]*kvrwset.KVRWSet),
+	}
+	for collection, reads := range s.pvtReads {
+		rwset.Private[collection] = &kvrwset.KVRWSet{Reads: sortedReads(reads)}
+	}
+	for collection, writes := range s.pvtWrites {
+		if _, in := rwset.Private[collection]; !in {
+			rwset.Private[collection] = &kvrwset.KVRWSet{}
+		}
+		rwset.Private[collection].Writes = sortedWrites(writes)
+	}
+	return &MockTransaction{TxID: s.txID, Timestamp: s.timestamp, RWSet: rwset, Event: s.event}
+}
+
+func sortedReads(reads map[string]*kvrwset.KVRead) []*kvrwset.KVRead {
+	sorted := make([]*kvrwset.KVRead, 0, len(reads))
+	for _, read := range reads {
+		sorted = append(sorted, read)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Key < sorted[j].Key })
+	return sorted
+}
+
+func sortedWrites(writes map[string]*kvrwset.KVWrite) []*kvrwset.KVWrite {
+	sorted := make([]*kvrwset.KVWrite, 0, len(writes))
+	for _, write := range writes {
+		sorted = append(sorted, write)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Key < sorted[j].Key })
+	return sorted
+}
@@ -0,0 +1,254 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package shim
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+// counterCC increments counters, and counts the counters in a range
+type counterCC struct{}
+
+func (counterCC) Init(stub ChaincodeStubInterface) pb.Response {
+	return Success(nil)
+}
+
+func (counterCC) Invoke(stub ChaincodeStubInterface) pb.Response {
+	function, args := stub.GetFunctionAndParameters()
+	switch function {
+	case "increment":
+		value, _ := stub.GetState(args[0])
+		n, _ := strconv.Atoi(string(value))
+		if err := stub.PutState(args[0], []byte(strconv.Itoa(n+1))); err != nil {
+			return Error(err.Error())
+		}
+		if err := stub.SetEvent("incremented", []byte(args[0])); err != nil {
+			return Error(err.Error())
+		}
+		return Success([]byte(strconv.Itoa(n + 1)))
+	case "delete":
+		if err := stub.DelState(args[0]); err != nil {
+			return Error(err.Error())
+		}
+		return Success(nil)
+	case "count":
+		iter, err := stub.GetStateByRange(args[0], args[1])
+		if err != nil {
+			return Error(err.Error())
+		}
+		defer iter.Close()
+		count := 0
+		for iter.HasNext() {
+			if _, err := iter.Next(); err != nil {
+				return Error(err.Error())
+			}
+			count++
+		}
+		if err := stub.PutState("count", []byte(strconv.Itoa(count))); err != nil {
+			return Error(err.Error())
+		}
+		return Success([]byte(strconv.Itoa(count)))
+	case "share":
+		// private data is only part of ChaincodeStubInterface in experimental builds
+		pvtStub := stub.(*MockStub)
+		if err := pvtStub.PutPrivateData("coll1", args[0], []byte(args[1])); err != nil {
+			return Error(err.Error())
+		}
+		value, err := pvtStub.GetPrivateData("coll1", args[0])
+		if err != nil {
+			return Error(err.Error())
+		}
+		return Success(value)
+	case "fail":
+		// the writes and the event of a failed transaction are discarded
+		pvtStub := stub.(*MockStub)
+		for _, key := range args {
+			stub.PutState(key, []byte("garbage"))
+			pvtStub.DelPrivateData("coll1", key)
+		}
+		stub.SetEvent("failed", nil)
+		return Error("failed")
+	}
+	return Error("unknown function " + function)
+}
+
+func args(strs ...string) [][]byte {
+	bytes := make([][]byte, len(strs))
+	for i, s := range strs {
+		bytes[i] = []byte(s)
+	}
+	return bytes
+}
+
+func TestMockRWSet(t *testing.T) {
+	stub := NewMockStub("counter", counterCC{})
+
+	res := stub.MockInvoke("tx1", args("increment", "a"))
+	assert.Equal(t, int32(OK), res.Status)
+	assert.Equal(t, &MockRWSet{
+		Public: &kvrwset.KVRWSet{
+			Reads:  []*kvrwset.KVRead{{Key: "a"}},
+			Writes: []*kvrwset.KVWrite{{Key: "a", Value: []byte("1")}},
+		},
+		Private: map[string]*kvrwset.KVRWSet{},
+	}, stub.RWSet)
+
+	res = stub.MockInvoke("tx2", args("increment", "a"))
+	assert.Equal(t, "2", string(res.Payload))
+	assert.Equal(t, []*kvrwset.KVRead{{Key: "a", Version: &kvrwset.Version{BlockNum: 1}}}, stub.RWSet.Public.Reads)
+
+	stub.MockInvoke("tx3", args("increment", "b"))
+	stub.MockInvoke("tx4", args("count", "a", "z"))
+	assert.Equal(t, []*kvrwset.RangeQueryInfo{{
+		StartKey:     "a",
+		EndKey:       "z",
+		ItrExhausted: true,
+		ReadsInfo: &kvrwset.RangeQueryInfo_RawReads{RawReads: &kvrwset.QueryReads{KvReads: []*kvrwset.KVRead{
+			{Key: "a", Version: &kvrwset.Version{BlockNum: 2}},
+			{Key: "b", Version: &kvrwset.Version{BlockNum: 3}},
+		}}},
+	}}, stub.RWSet.Public.RangeQueriesInfo)
+	assert.Empty(t, stub.RWSet.Public.Reads)
+
+	stub.MockInvoke("tx5", args("delete", "b"))
+	assert.Equal(t, []*kvrwset.KVWrite{{Key: "b", IsDelete: true}}, stub.RWSet.Public.Writes)
+
+	// the event of each transaction is sent once it's committed
+	for _, txID := range []string{"tx1", "tx2", "tx3"} {
+		event := <-stub.ChaincodeEventsChannel
+		assert.Equal(t, &pb.ChaincodeEvent{ChaincodeId: "counter", TxId: txID, EventName: "incremented", Payload: event.Payload}, event)
+	}
+	assert.Len(t, stub.ChaincodeEventsChannel, 0)
+}
+
+func TestMockMVCCConflicts(t *testing.T) {
+	stub := NewMockStub("counter", counterCC{})
+	stub.MockInvoke("tx0", args("increment", "a"))
+	<-stub.ChaincodeEventsChannel
+
+	// transactions simulated concurrently increment the same counter
+	res1, tx1 := stub.MockSimulate("tx1", args("increment", "a"))
+	res2, tx2 := stub.MockSimulate("tx2", args("increment", "a"))
+	assert.Equal(t, "2", string(res1.Payload))
+	assert.Equal(t, "2", string(res2.Payload))
+	assert.Equal(t, "1", string(stub.State["a"]))
+	assert.Equal(t, tx2.RWSet, stub.RWSet)
+	assert.Len(t, stub.ChaincodeEventsChannel, 0)
+
+	assert.Equal(t, pb.TxValidationCode_VALID, stub.MockCommit(tx1))
+	assert.Equal(t, "2", string(stub.State["a"]))
+	assert.Equal(t, "tx1", (<-stub.ChaincodeEventsChannel).TxId)
+	assert.Equal(t, pb.TxValidationCode_MVCC_READ_CONFLICT, stub.MockCommit(tx2))
+	assert.Equal(t, "2", string(stub.State["a"]))
+	assert.Len(t, stub.ChaincodeEventsChannel, 0)
+	assert.Equal(t, pb.TxValidationCode_DUPLICATE_TXID, stub.MockCommit(tx1))
+
+	// a transaction doesn't read its own writes until it's committed
+	_, tx3 := stub.MockSimulate("tx3", args("share", "k", "v"))
+	assert.Equal(t, &kvrwset.KVRWSet{
+		Reads:  []*kvrwset.KVRead{{Key: "k"}},
+		Writes: []*kvrwset.KVWrite{{Key: "k", Value: []byte("v")}},
+	}, tx3.RWSet.Private["coll1"])
+	assert.Nil(t, stub.PvtState["coll1"])
+	assert.Equal(t, pb.TxValidationCode_VALID, stub.MockCommit(tx3))
+	assert.Equal(t, []byte("v"), stub.PvtState["coll1"]["k"])
+
+	// private data conflicts as the state does
+	_, tx4 := stub.MockSimulate("tx4", args("share", "k", "v2"))
+	stub.MockInvoke("tx5", args("share", "k", "v3"))
+	assert.Equal(t, pb.TxValidationCode_MVCC_READ_CONFLICT, stub.MockCommit(tx4))
+	assert.Equal(t, []byte("v3"), stub.PvtState["coll1"]["k"])
+}
+
+func TestMockPhantomReads(t *testing.T) {
+	stub := NewMockStub("counter", counterCC{})
+	stub.MockInvoke("tx0", args("increment", "a"))
+	stub.MockInvoke("tx1", args("increment", "c"))
+
+	_, count := stub.MockSimulate("tx2", args("count", "a", "d"))
+	_, insert := stub.MockSimulate("tx3", args("increment", "b"))
+	assert.Equal(t, pb.TxValidationCode_VALID, stub.MockCommit(insert))
+	assert.Equal(t, pb.TxValidationCode_PHANTOM_READ_CONFLICT, stub.MockCommit(count))
+
+	_, count = stub.MockSimulate("tx4", args("count", "a", "d"))
+	_, outside := stub.MockSimulate("tx5", args("increment", "e"))
+	assert.Equal(t, pb.TxValidationCode_VALID, stub.MockCommit(outside))
+	assert.Equal(t, pb.TxValidationCode_VALID, stub.MockCommit(count))
+	assert.Equal(t, "3", string(stub.State["count"]))
+
+	_, count = stub.MockSimulate("tx6", args("count", "a", "d"))
+	_, del := stub.MockSimulate("tx7", args("delete", "c"))
+	assert.Equal(t, pb.TxValidationCode_VALID, stub.MockCommit(del))
+	assert.Equal(t, pb.TxValidationCode_PHANTOM_READ_CONFLICT, stub.MockCommit(count))
+}
+
+func TestMockFailedTransaction(t *testing.T) {
+	stub := NewMockStub("counter", counterCC{})
+	stub.MockInvoke("tx1", args("increment", "a"))
+	stub.MockInvoke("tx2", args("share", "a", "secret"))
+	<-stub.ChaincodeEventsChannel
+
+	res := stub.MockInvoke("tx3", args("fail", "a", "b"))
+	assert.Equal(t, int32(ERROR), res.Status)
+	assert.Equal(t, map[string][]byte{"a": []byte("1")}, stub.State)
+	assert.Equal(t, 1, stub.Keys.Len())
+	assert.Equal(t, map[string][]byte{"a": []byte("secret")}, stub.PvtState["coll1"])
+	assert.Len(t, stub.ChaincodeEventsChannel, 0)
+
+	iter, err := stub.GetHistoryForKey("a")
+	assert.NoError(t, err)
+	modification, err := iter.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, "tx1", modification.TxId)
+	assert.False(t, iter.HasNext())
+
+	// the versions of the keys are left unchanged as well
+	stub.MockInvoke("tx4", args("increment", "a"))
+	assert.Equal(t, []*kvrwset.KVRead{{Key: "a", Version: &kvrwset.Version{BlockNum: 1}}}, stub.RWSet.Public.Reads)
+}
+
+func TestMockHistory(t *testing.T) {
+	stub := NewMockStub("counter", counterCC{})
+	stub.MockInvoke("tx1", args("increment", "a"))
+	stub.MockInvoke("tx2", args("increment", "a"))
+	stub.MockInvoke("tx3", args("delete", "a"))
+	_, tx := stub.MockSimulate("tx4", args("increment", "a"))
+
+	iter, err := stub.GetHistoryForKey("a")
+	assert.NoError(t, err)
+	var txIDs []string
+	var values []string
+	for iter.HasNext() {
+		modification, err := iter.Next()
+		assert.NoError(t, err)
+		assert.NotNil(t, modification.Timestamp)
+		txIDs = append(txIDs, modification.TxId)
+		values = append(values, string(modification.Value))
+		assert.Equal(t, modification.TxId == "tx3", modification.IsDelete)
+	}
+	assert.Equal(t, []string{"tx1", "tx2", "tx3"}, txIDs)
+	assert.Equal(t, []string{"1", "2", ""}, values)
+	_, err = iter.Next()
+	assert.Error(t, err)
+	assert.NoError(t, iter.Close())
+
+	stub.MockCommit(tx)
+	iter, _ = stub.GetHistoryForKey("a")
+	count := 0
+	for ; iter.HasNext(); count++ {
+		iter.Next()
+	}
+	assert.Equal(t, 4, count)
+
+	iter, _ = stub.GetHistoryForKey("b")
+	assert.False(t, iter.HasNext())
+}
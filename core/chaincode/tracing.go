@@ -0,0 +1,87 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"github.com/hyperledger/fabric/common/tracing"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"golang.org/x/net/context"
+)
+
+// requestTypes are the types of the messages the chaincode sends to the peer
+// to request something on behalf of a transaction, which the peer answers
+// with a RESPONSE or an ERROR
+var requestTypes = map[pb.ChaincodeMessage_Type]bool{
+	pb.ChaincodeMessage_GET_STATE:             true,
+	pb.ChaincodeMessage_GET_PRIVATE_DATA_HASH: true,
+	pb.ChaincodeMessage_PUT_STATE:             true,
+	pb.ChaincodeMessage_DEL_STATE:             true,
+	pb.ChaincodeMessage_INVOKE_CHAINCODE:      true,
+	pb.ChaincodeMessage_GET_STATE_BY_RANGE:    true,
+	pb.ChaincodeMessage_GET_QUERY_RESULT:      true,
+	pb.ChaincodeMessage_QUERY_STATE_NEXT:      true,
+	pb.ChaincodeMessage_QUERY_STATE_CLOSE:     true,
+	pb.ChaincodeMessage_GET_HISTORY_FOR_KEY:   true,
+}
+
+// startRequestSpan times a request of the chaincode until the peer answers it, in a
+// span which joins the trace of the transaction the request belongs to
+func (handler *Handler) startRequestSpan(msg *pb.ChaincodeMessage) {
+	if !tracing.Enabled() || !requestTypes[msg.Type] {
+		return
+	}
+	var parent tracing.SpanContext
+	if msg.TraceParent != "" {
+		var err error
+		if parent, err = tracing.ParseTraceParent(msg.TraceParent); err != nil {
+			chaincodeLogger.Debugf("[%s]Ignoring the span context of %s: %s", shorttxid(msg.Txid), msg.Type, err)
+		}
+	}
+
+	handler.Lock()
+	defer handler.Unlock()
+	txCtxID := handler.getTxCtxId(msg.ChannelId, msg.Txid)
+	// A request which is not answered because another request of the
+	// transaction is pending doesn't replace the span of the pending one
+	if _, exists := handler.requestSpans[txCtxID]; exists {
+		return
+	}
+	span, _ := tracing.StartSpan(context.Background(), "chaincode."+msg.Type.String(), tracing.ChildOf(parent),
+		tracing.Tag("chaincode", handler.ChaincodeID.Name),
+		tracing.Tag("channel", msg.ChannelId),
+		tracing.Tag("txid", msg.Txid))
+	if handler.requestSpans == nil {
+		handler.requestSpans = map[string]*tracing.Span{}
+	}
+	handler.requestSpans[txCtxID] = span
+}
+
+// requestSpan returns the span of the pending request of a transaction, or nil
+func (handler *Handler) requestSpan(channelID, txid string) *tracing.Span {
+	handler.RLock()
+	defer handler.RUnlock()
+	return handler.requestSpans[handler.getTxCtxId(channelID, txid)]
+}
+
+// finishRequestSpan finishes the span of the pending request of the transaction
+// a RESPONSE or an ERROR sent to the chaincode belongs to
+func (handler *Handler) finishRequestSpan(msg *pb.ChaincodeMessage) {
+	if msg.Type != pb.ChaincodeMessage_RESPONSE && msg.Type != pb.ChaincodeMessage_ERROR {
+		return
+	}
+	handler.Lock()
+	txCtxID := handler.getTxCtxId(msg.ChannelId, msg.Txid)
+	span := handler.requestSpans[txCtxID]
+	delete(handler.requestSpans, txCtxID)
+	handler.Unlock()
+
+	if msg.Type == pb.ChaincodeMessage_ERROR {
+		span.SetError(errors.New(string(msg.Payload)))
+	}
+	span.Finish()
+}
@@ -0,0 +1,76 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/tracing"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestRequestSpans(t *testing.T) {
+	handler := &Handler{ChaincodeID: &pb.ChaincodeID{Name: "mycc"}}
+	getState := &pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE, ChannelId: "mychannel", Txid: "tx1"}
+
+	// requests are not traced when tracing is disabled
+	handler.startRequestSpan(getState)
+	assert.Nil(t, handler.requestSpan("mychannel", "tx1"))
+
+	exporter := tracing.NewInMemoryExporter()
+	tracing.SetExporter(exporter)
+	defer tracing.SetExporter(nil)
+
+	execute, ctx := tracing.StartSpan(context.Background(), "chaincode.Execute")
+	getState.TraceParent = tracing.TraceParentFromContext(ctx)
+	handler.startRequestSpan(getState)
+	span := handler.requestSpan("mychannel", "tx1")
+	assert.NotNil(t, span)
+
+	// a request sent while another one of the transaction is pending doesn't replace it
+	handler.startRequestSpan(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_PUT_STATE, ChannelId: "mychannel", Txid: "tx1"})
+	assert.Equal(t, span, handler.requestSpan("mychannel", "tx1"))
+
+	// messages which are not requests are not traced
+	handler.startRequestSpan(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_COMPLETED, ChannelId: "mychannel", Txid: "tx2"})
+	assert.Nil(t, handler.requestSpan("mychannel", "tx2"))
+
+	handler.finishRequestSpan(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_RESPONSE, ChannelId: "mychannel", Txid: "tx1"})
+	assert.Nil(t, handler.requestSpan("mychannel", "tx1"))
+
+	handler.startRequestSpan(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_DEL_STATE, ChannelId: "mychannel", Txid: "tx1", TraceParent: "garbage"})
+	handler.finishRequestSpan(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_ERROR, ChannelId: "mychannel", Txid: "tx1", Payload: []byte("failure")})
+
+	spans := exporter.Spans()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "chaincode.GET_STATE", spans[0].Name)
+	assert.Equal(t, execute.Context().TraceID, spans[0].TraceID)
+	assert.Equal(t, execute.Context().SpanID, spans[0].ParentID)
+	assert.Equal(t, map[string]string{"chaincode": "mycc", "channel": "mychannel", "txid": "tx1"}, spans[0].Tags)
+	assert.Empty(t, spans[0].Error)
+
+	assert.Equal(t, "chaincode.DEL_STATE", spans[1].Name)
+	assert.NotEqual(t, execute.Context().TraceID, spans[1].TraceID)
+	assert.Equal(t, "failure", spans[1].Error)
+}
+
+func TestRequestSpanOfRejectedMessage(t *testing.T) {
+	exporter := tracing.NewInMemoryExporter()
+	tracing.SetExporter(exporter)
+	defer tracing.SetExporter(nil)
+
+	// a handler which is not ready rejects the requests of the chaincode, which are
+	// never answered, so their spans must not be started
+	handler := newChaincodeSupportHandler(nil, nil)
+	handler.ChaincodeID = &pb.ChaincodeID{Name: "mycc"}
+	err := handler.handleMessage(&pb.ChaincodeMessage{Type: pb.ChaincodeMessage_GET_STATE, ChannelId: "mychannel", Txid: "tx1"})
+	assert.Error(t, err)
+	assert.Nil(t, handler.requestSpan("mychannel", "tx1"))
+	assert.Empty(t, handler.requestSpans)
+}
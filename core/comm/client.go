@@ -211,6 +211,7 @@ func (client *grpcClient) NewConnection(address string, serverNameOverride strin
 	dialOpts = append(dialOpts, grpc.WithDefaultCallOptions(
 		grpc.MaxCallRecvMsgSize(client.maxRecvMsgSize),
 		grpc.MaxCallSendMsgSize(client.maxSendMsgSize)))
+	dialOpts = append(dialOpts, TracingDialOptions()...)
 
 	ctx, cancel := context.WithTimeout(context.Background(), client.timeout)
 	defer cancel()
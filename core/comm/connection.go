@@ -110,11 +110,15 @@ func (cas *CASupport) GetClientRootCAs() (appRootCAs, ordererRootCAs [][]byte) {
 // SetClientCertificate sets the tls.Certificate to use for gRPC client
 // connections
 func (cs *CredentialSupport) SetClientCertificate(cert tls.Certificate) {
+	cs.Lock()
+	defer cs.Unlock()
 	cs.clientCert = cert
 }
 
 // GetClientCertificate returns the client certificate of the CredentialSupport
 func (cs *CredentialSupport) GetClientCertificate() tls.Certificate {
+	cs.RLock()
+	defer cs.RUnlock()
 	return cs.clientCert
 }
 
@@ -160,7 +164,7 @@ func (cs *CredentialSupport) GetDeliverServiceCredentials(channelID string) (cre
 func (cs *CredentialSupport) GetPeerCredentials() credentials.TransportCredentials {
 	var creds credentials.TransportCredentials
 	tlsConfig := &tls.Config{
-		Certificates: []tls.Certificate{cs.clientCert},
+		Certificates: []tls.Certificate{cs.GetClientCertificate()},
 	}
 	certPool := x509.NewCertPool()
 	// loop through the server root CAs
@@ -207,6 +211,7 @@ func NewClientConnectionWithAddress(peerAddress string, block bool, tslEnabled b
 	}
 	opts = append(opts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(MaxRecvMsgSize()),
 		grpc.MaxCallSendMsgSize(MaxSendMsgSize())))
+	opts = append(opts, TracingDialOptions()...)
 	ctx := context.Background()
 	ctx, _ = context.WithTimeout(ctx, defaultTimeout)
 	conn, err := grpc.DialContext(ctx, peerAddress, opts...)
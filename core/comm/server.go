@@ -164,6 +164,8 @@ func NewGRPCServerFromListener(listener net.Listener, serverConfig ServerConfig)
 	serverOpts = append(serverOpts, grpc.MaxRecvMsgSize(MaxRecvMsgSize()))
 	// set the keepalive options
 	serverOpts = append(serverOpts, ServerKeepaliveOptions(serverConfig.KaOpts)...)
+	// time the calls in spans, once tracing is enabled
+	serverOpts = append(serverOpts, TracingServerOptions()...)
 
 	grpcServer.server = grpc.NewServer(serverOpts...)
 
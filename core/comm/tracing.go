@@ -0,0 +1,121 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"github.com/hyperledger/fabric/common/tracing"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// TraceParentKey is the gRPC metadata key carrying the span context of the
+// caller, formatted as a W3C trace context traceparent
+const TraceParentKey = "traceparent"
+
+// ContextWithTraceParent returns an outgoing context carrying the span context of
+// the span carried by ctx in its gRPC metadata, so that the spans of the callee
+// join the trace of the caller
+func ContextWithTraceParent(ctx context.Context) context.Context {
+	traceParent := tracing.TraceParentFromContext(ctx)
+	if traceParent == "" {
+		return ctx
+	}
+	md, _ := metadata.FromOutgoingContext(ctx)
+	md = md.Copy()
+	md[TraceParentKey] = []string{traceParent}
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// TraceParentFromIncomingContext returns the span context of the caller carried
+// by the gRPC metadata of an incoming context, or an invalid span context
+func TraceParentFromIncomingContext(ctx context.Context) tracing.SpanContext {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md[TraceParentKey]) == 0 {
+		return tracing.SpanContext{}
+	}
+	sc, err := tracing.ParseTraceParent(md[TraceParentKey][0])
+	if err != nil {
+		commLogger.Debugf("Ignoring the span context of the caller: %s", err)
+		return tracing.SpanContext{}
+	}
+	return sc
+}
+
+// TracingUnaryServerInterceptor times unary calls in spans named after the called
+// method, which join the trace of the caller
+func TracingUnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !tracing.Enabled() {
+		return handler(ctx, req)
+	}
+	span, ctx := tracing.StartSpan(ctx, info.FullMethod, tracing.ChildOf(TraceParentFromIncomingContext(ctx)),
+		tracing.Tag("span.kind", "server"))
+	defer span.Finish()
+	resp, err := handler(ctx, req)
+	span.SetError(err)
+	return resp, err
+}
+
+// TracingStreamServerInterceptor times streams in spans named after the called
+// method, which join the trace of the caller
+func TracingStreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !tracing.Enabled() {
+		return handler(srv, ss)
+	}
+	span, ctx := tracing.StartSpan(ss.Context(), info.FullMethod, tracing.ChildOf(TraceParentFromIncomingContext(ss.Context())),
+		tracing.Tag("span.kind", "server"))
+	defer span.Finish()
+	err := handler(srv, &tracedServerStream{ServerStream: ss, ctx: ctx})
+	span.SetError(err)
+	return err
+}
+
+type tracedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context carrying the span of the stream
+func (ss *tracedServerStream) Context() context.Context {
+	return ss.ctx
+}
+
+// TracingUnaryClientInterceptor times unary calls in spans named after the called
+// method, and propagates their span context to the callee
+func TracingUnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	if !tracing.Enabled() {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+	span, ctx := tracing.StartSpan(ctx, method, tracing.Tag("span.kind", "client"))
+	defer span.Finish()
+	err := invoker(ContextWithTraceParent(ctx), method, req, reply, cc, opts...)
+	span.SetError(err)
+	return err
+}
+
+// TracingStreamClientInterceptor propagates the span context carried by the context
+// a stream is opened with to the callee. Streams are long lived, so the callee
+// times them instead of the caller
+func TracingStreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return streamer(ContextWithTraceParent(ctx), desc, cc, method, opts...)
+}
+
+// TracingServerOptions returns the server options installing the tracing interceptors
+func TracingServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(TracingUnaryServerInterceptor),
+		grpc.StreamInterceptor(TracingStreamServerInterceptor),
+	}
+}
+
+// TracingDialOptions returns the dial options installing the tracing interceptors
+func TracingDialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithUnaryInterceptor(TracingUnaryClientInterceptor),
+		grpc.WithStreamInterceptor(TracingStreamClientInterceptor),
+	}
+}
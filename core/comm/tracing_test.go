@@ -0,0 +1,131 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package comm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hyperledger/fabric/common/tracing"
+	testpb "github.com/hyperledger/fabric/core/comm/testdata/grpc"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type tracingTestServer struct {
+	spans chan *tracing.Span
+}
+
+func (tts *tracingTestServer) EmptyCall(ctx context.Context, _ *testpb.Empty) (*testpb.Empty, error) {
+	tts.spans <- tracing.SpanFromContext(ctx)
+	return new(testpb.Empty), nil
+}
+
+func TestTraceParentPropagation(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, ctx, ContextWithTraceParent(ctx))
+	assert.False(t, TraceParentFromIncomingContext(ctx).IsValid())
+
+	exporter := tracing.NewInMemoryExporter()
+	tracing.SetExporter(exporter)
+	defer tracing.SetExporter(nil)
+
+	span, ctx := tracing.StartSpan(ctx, "caller")
+	outgoing, ok := metadata.FromOutgoingContext(ContextWithTraceParent(ctx))
+	assert.True(t, ok)
+	assert.Equal(t, []string{span.Context().TraceParent()}, outgoing[TraceParentKey])
+
+	incoming := metadata.NewIncomingContext(context.Background(), outgoing)
+	assert.Equal(t, span.Context(), TraceParentFromIncomingContext(incoming))
+
+	incoming = metadata.NewIncomingContext(context.Background(), metadata.Pairs(TraceParentKey, "garbage"))
+	assert.False(t, TraceParentFromIncomingContext(incoming).IsValid())
+}
+
+func TestTracingInterceptors(t *testing.T) {
+	exporter := tracing.NewInMemoryExporter()
+	tracing.SetExporter(exporter)
+	defer tracing.SetExporter(nil)
+
+	srv, err := NewGRPCServer("localhost:0", ServerConfig{SecOpts: &SecureOptions{UseTLS: false}})
+	assert.NoError(t, err)
+	service := &tracingTestServer{spans: make(chan *tracing.Span, 1)}
+	testpb.RegisterTestServiceServer(srv.Server(), service)
+	go srv.Start()
+	defer srv.Stop()
+
+	conn, err := NewClientConnectionWithAddress(srv.Address(), true, false, nil, nil)
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	caller, ctx := tracing.StartSpan(context.Background(), "caller")
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	_, err = testpb.NewTestServiceClient(conn).EmptyCall(ctx, new(testpb.Empty))
+	assert.NoError(t, err)
+	caller.Finish()
+	assert.NotNil(t, <-service.spans)
+
+	spans := map[string]tracing.SpanData{}
+	for _, span := range exporter.Spans() {
+		spans[span.Tags["span.kind"]] = span
+	}
+	assert.Len(t, spans, 3)
+	method := "/TestService/EmptyCall"
+	client, server := spans["client"], spans["server"]
+	assert.Equal(t, method, client.Name)
+	assert.Equal(t, method, server.Name)
+	assert.Equal(t, caller.Context().TraceID, client.TraceID)
+	assert.Equal(t, caller.Context().SpanID, client.ParentID)
+	assert.Equal(t, client.TraceID, server.TraceID)
+	assert.Equal(t, client.SpanID, server.ParentID)
+}
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (ss *mockServerStream) Context() context.Context {
+	return ss.ctx
+}
+
+func TestTracingStreamServerInterceptor(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: "/service/Stream"}
+	caller := tracing.SpanContext{TraceID: "0af7651916cd43dd8448eb211c80319c", SpanID: "b7ad6b7169203331"}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(TraceParentKey, caller.TraceParent()))
+
+	// streams are passed as they are when tracing is disabled
+	ss := &mockServerStream{ctx: ctx}
+	err := TracingStreamServerInterceptor(nil, ss, info, func(srv interface{}, stream grpc.ServerStream) error {
+		assert.Equal(t, ss, stream)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	exporter := tracing.NewInMemoryExporter()
+	tracing.SetExporter(exporter)
+	defer tracing.SetExporter(nil)
+
+	var streamSpan *tracing.Span
+	err = TracingStreamServerInterceptor(nil, ss, info, func(srv interface{}, stream grpc.ServerStream) error {
+		streamSpan = tracing.SpanFromContext(stream.Context())
+		return context.Canceled
+	})
+	assert.Equal(t, context.Canceled, err)
+	assert.NotNil(t, streamSpan)
+
+	spans := exporter.Spans()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "/service/Stream", spans[0].Name)
+	assert.Equal(t, caller.TraceID, spans[0].TraceID)
+	assert.Equal(t, caller.SpanID, spans[0].ParentID)
+	assert.Equal(t, streamSpan.Context().SpanID, spans[0].SpanID)
+	assert.Equal(t, context.Canceled.Error(), spans[0].Error)
+}
@@ -8,8 +8,11 @@ package comm
 
 import (
 	"bytes"
+	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
+	"io/ioutil"
+	"time"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/util"
@@ -31,6 +34,48 @@ func AddPemToCertPool(pemCerts []byte, pool *x509.CertPool) error {
 	return nil
 }
 
+// LoadTLSKeyPair reads a PEM-encoded TLS key pair from the supplied files and
+// validates it with ValidateTLSCertificate
+func LoadTLSKeyPair(certFile, keyFile string) (tls.Certificate, error) {
+	certPEM, err := ioutil.ReadFile(certFile)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrap(err, "failed reading TLS certificate")
+	}
+	keyPEM, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrap(err, "failed reading TLS key")
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return tls.Certificate{}, errors.Wrap(err, "failed parsing TLS key pair")
+	}
+	if err := ValidateTLSCertificate(&cert); err != nil {
+		return tls.Certificate{}, err
+	}
+	return cert, nil
+}
+
+// ValidateTLSCertificate parses the certificate of the TLS key pair, which it
+// retains in the Leaf of the key pair, and checks that it is currently valid
+func ValidateTLSCertificate(cert *tls.Certificate) error {
+	if len(cert.Certificate) == 0 {
+		return errors.New("TLS key pair has no certificate")
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return errors.Wrap(err, "failed parsing TLS certificate")
+	}
+	now := time.Now()
+	if now.Before(leaf.NotBefore) {
+		return errors.Errorf("TLS certificate is not valid before %s", leaf.NotBefore)
+	}
+	if now.After(leaf.NotAfter) {
+		return errors.Errorf("TLS certificate expired at %s", leaf.NotAfter)
+	}
+	cert.Leaf = leaf
+	return nil
+}
+
 //utility function to parse PEM-encoded certs
 func pemToX509Certs(pemCerts []byte) ([]*x509.Certificate, []string, error) {
 
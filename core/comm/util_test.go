@@ -8,8 +8,17 @@ package comm_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -219,3 +228,61 @@ func envelopeWithChannelHeader(ch []byte) *common.Envelope {
 		Payload: payload,
 	}
 }
+
+func newTLSKeyPairPEM(t *testing.T, notBefore, notAfter time.Time) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+func TestLoadTLSKeyPair(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+
+	writeKeyPair := func(notBefore, notAfter time.Time) {
+		certPEM, keyPEM := newTLSKeyPairPEM(t, notBefore, notAfter)
+		assert.NoError(t, ioutil.WriteFile(certFile, certPEM, 0600))
+		assert.NoError(t, ioutil.WriteFile(keyFile, keyPEM, 0600))
+	}
+
+	_, err = comm.LoadTLSKeyPair(certFile, keyFile)
+	assert.Contains(t, err.Error(), "failed reading TLS certificate")
+
+	now := time.Now()
+	writeKeyPair(now.Add(-time.Hour), now.Add(time.Hour))
+	cert, err := comm.LoadTLSKeyPair(certFile, keyFile)
+	assert.NoError(t, err)
+	assert.NotNil(t, cert.Leaf)
+	assert.Equal(t, now.Add(time.Hour).Unix(), cert.Leaf.NotAfter.Unix())
+
+	writeKeyPair(now.Add(-2*time.Hour), now.Add(-time.Hour))
+	_, err = comm.LoadTLSKeyPair(certFile, keyFile)
+	assert.Contains(t, err.Error(), "TLS certificate expired at")
+
+	writeKeyPair(now.Add(time.Hour), now.Add(2*time.Hour))
+	_, err = comm.LoadTLSKeyPair(certFile, keyFile)
+	assert.Contains(t, err.Error(), "TLS certificate is not valid before")
+
+	// a key which does not match the certificate
+	_, otherKeyPEM := newTLSKeyPairPEM(t, now.Add(-time.Hour), now.Add(time.Hour))
+	writeKeyPair(now.Add(-time.Hour), now.Add(time.Hour))
+	assert.NoError(t, ioutil.WriteFile(keyFile, otherKeyPEM, 0600))
+	_, err = comm.LoadTLSKeyPair(certFile, keyFile)
+	assert.Contains(t, err.Error(), "failed parsing TLS key pair")
+
+	assert.EqualError(t, comm.ValidateTLSCertificate(&tls.Certificate{}), "TLS key pair has no certificate")
+}
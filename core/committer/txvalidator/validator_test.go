@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	ctxt "github.com/hyperledger/fabric/common/configtx/test"
@@ -43,6 +44,7 @@ import (
 	"github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/msp/mgmt/testtools"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/spf13/viper"
@@ -450,6 +452,36 @@ func (m *mockLedger) GetTxValidationCodeByTxID(txID string) (peer.TxValidationCo
 	return args.Get(0).(peer.TxValidationCode), nil
 }
 
+// GetTxStatusesByBlockRange returns the statuses of the transactions of a block range
+func (m *mockLedger) GetTxStatusesByBlockRange(startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	args := m.Called(startNum, endNum)
+	return args.Get(0).(*peer.TransactionStatuses), nil
+}
+
+// GetBlockByTimestamp returns the first block at or after the timestamp
+func (m *mockLedger) GetBlockByTimestamp(timestamp time.Time) (*common.Block, error) {
+	args := m.Called(timestamp)
+	return args.Get(0).(*common.Block), nil
+}
+
+// GetTxStatusesByCreator returns the statuses of the transactions of a creator
+func (m *mockLedger) GetTxStatusesByCreator(mspID string, startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	args := m.Called(mspID, startNum, endNum)
+	return args.Get(0).(*peer.TransactionStatuses), nil
+}
+
+// GetTxStatusesByNamespace returns the statuses of the transactions of a namespace
+func (m *mockLedger) GetTxStatusesByNamespace(namespace string, startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	args := m.Called(namespace, startNum, endNum)
+	return args.Get(0).(*peer.TransactionStatuses), nil
+}
+
+// ValidateReadSet validates the read set of a transaction against the committed state
+func (m *mockLedger) ValidateReadSet(pubSimulationResults *rwset.TxReadWriteSet) (*peer.MVCCConflict, error) {
+	args := m.Called(pubSimulationResults)
+	return args.Get(0).(*peer.MVCCConflict), nil
+}
+
 // NewTxSimulator creates new transaction simulator
 func (m *mockLedger) NewTxSimulator(txid string) (ledger.TxSimulator, error) {
 	args := m.Called()
@@ -559,6 +591,11 @@ func (exec *mockQueryExecutor) GetPrivateData(namespace, collection, key string)
 	return args.Get(0).([]byte), args.Error(1)
 }
 
+func (exec *mockQueryExecutor) GetPrivateDataHash(namespace, collection, key string) ([]byte, error) {
+	args := exec.Called(namespace, collection, key)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
 func (exec *mockQueryExecutor) GetPrivateDataMultipleKeys(namespace, collection string, keys []string) ([][]byte, error) {
 	args := exec.Called(namespace, collection, keys)
 	return args.Get(0).([][]byte), args.Error(1)
@@ -0,0 +1,405 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ccpackage
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/msp"
+	"github.com/hyperledger/fabric/protos/peer"
+)
+
+// This file provides the tar.gz chaincode package format. A package is a
+// gzipped tar file made of the following parts
+//     metadata.json            - the TGZMetadata of the chaincode
+//     code.tar.gz              - the code package of the chaincode, i.e. its
+//                                source along with the META-INF directory
+//                                holding its statedb indexes
+//     instantiation-policy.pb  - (optional) the instantiation policy of the
+//                                chaincode, as a SignaturePolicyEnvelope
+//     signatures/<n>.pb        - (optional) the owner endorsements of the
+//                                package, as Endorsements
+// Owners sign the concatenation of metadata.json, code.tar.gz, the
+// instantiation policy and their serialized identity, as they do for the
+// signed deployment spec packages
+
+const (
+	// TGZMetadataFile is the name of the metadata of a tar.gz package
+	TGZMetadataFile = "metadata.json"
+
+	// TGZCodeFile is the name of the code package of a tar.gz package
+	TGZCodeFile = "code.tar.gz"
+
+	// TGZInstantiationPolicyFile is the name of the instantiation policy of
+	// a tar.gz package
+	TGZInstantiationPolicyFile = "instantiation-policy.pb"
+
+	// TGZSignaturesDir is the directory of the owner endorsements of a
+	// tar.gz package
+	TGZSignaturesDir = "signatures/"
+)
+
+// MaxTGZSize is the largest size a chaincode package in the tar.gz format, or
+// the code package it holds, may have once decompressed. It bounds the memory
+// and the time spent reading a package which decompresses to more, such as a
+// gzip bomb
+var MaxTGZSize int64 = 100 * 1024 * 1024
+
+var labelRegExp = regexp.MustCompile(`^[[:alnum:]][[:alnum:]_.+-]*$`)
+
+var signatureFileRegExp = regexp.MustCompile(`^signatures/(0|[1-9][0-9]*)\.pb$`)
+
+// TGZMetadata describes the chaincode of a tar.gz package
+type TGZMetadata struct {
+	// Type is the language of the chaincode, e.g. golang
+	Type string `json:"type"`
+
+	// Path is the path of the chaincode, as in its ChaincodeID
+	Path string `json:"path"`
+
+	// Label is the human readable name of the package, which its package
+	// ID is derived from
+	Label string `json:"label"`
+
+	// Name and Version identify the chaincode the package installs, as
+	// in its ChaincodeID
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// TGZ is a chaincode package in the tar.gz format
+type TGZ struct {
+	Metadata            *TGZMetadata
+	Code                []byte
+	InstantiationPolicy []byte
+	OwnerEndorsements   []*peer.Endorsement
+
+	// metadataBytes holds metadata.json as read or first serialized, so
+	// that owner signatures don't depend on how it is serialized
+	metadataBytes []byte
+}
+
+// IsTGZ returns whether the bytes may be a chaincode package in the tar.gz
+// format, i.e. whether they start with the gzip magic number
+func IsTGZ(buf []byte) bool {
+	return len(buf) > 2 && buf[0] == 0x1f && buf[1] == 0x8b
+}
+
+// PackageID returns the ID of a chaincode package, which is made of its label
+// and of the hex encoded SHA-256 hash of the package
+func PackageID(label string, pkg []byte) string {
+	return label + ":" + hex.EncodeToString(util.ComputeSHA256(pkg))
+}
+
+// ValidateLabel checks that a label only contains alphanumerics and the '_',
+// '.', '+' and '-' characters, and starts with an alphanumeric
+func ValidateLabel(label string) error {
+	if !labelRegExp.MatchString(label) {
+		return fmt.Errorf("invalid label '%s', the label must match %s", label, labelRegExp)
+	}
+	return nil
+}
+
+func (m *TGZMetadata) validate() error {
+	if err := ValidateLabel(m.Label); err != nil {
+		return err
+	}
+
+	if t, ok := peer.ChaincodeSpec_Type_value[strings.ToUpper(m.Type)]; !ok || t == int32(peer.ChaincodeSpec_UNDEFINED) {
+		return fmt.Errorf("invalid chaincode type '%s'", m.Type)
+	}
+
+	if m.Name == "" || m.Version == "" {
+		return fmt.Errorf("chaincode name and version must be set")
+	}
+
+	return nil
+}
+
+// ChaincodeType returns the type of the chaincode of the package
+func (m *TGZMetadata) ChaincodeType() peer.ChaincodeSpec_Type {
+	return peer.ChaincodeSpec_Type(peer.ChaincodeSpec_Type_value[strings.ToUpper(m.Type)])
+}
+
+// sizeLimitedReader fails once more than limit bytes are read from r
+type sizeLimitedReader struct {
+	r     io.Reader
+	limit int64
+	read  int64
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.read > l.limit {
+		return n, fmt.Errorf("larger than %d bytes once decompressed", l.limit)
+	}
+	return n, err
+}
+
+// newTarReader returns a reader of a gzipped tar file, which fails once more
+// than MaxTGZSize bytes are decompressed
+func newTarReader(buf []byte) (*tar.Reader, io.Closer, error) {
+	gr, err := gzip.NewReader(bytes.NewReader(buf))
+	if err != nil {
+		return nil, nil, err
+	}
+	return tar.NewReader(&sizeLimitedReader{r: gr, limit: MaxTGZSize}), gr, nil
+}
+
+// ParseTGZ parses and validates a chaincode package in the tar.gz format
+func ParseTGZ(buf []byte) (*TGZ, error) {
+	tr, gr, err := newTarReader(buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open chaincode package: %s", err)
+	}
+	defer gr.Close()
+
+	pkg := &TGZ{}
+	endorsements := make(map[int]*peer.Endorsement)
+	seen := make(map[string]bool)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read chaincode package: %s", err)
+		}
+
+		if hdr.Typeflag == tar.TypeDir {
+			continue
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			return nil, fmt.Errorf("unexpected entry %s of type %c in chaincode package", hdr.Name, hdr.Typeflag)
+		}
+
+		if seen[hdr.Name] {
+			return nil, fmt.Errorf("duplicate file %s in chaincode package", hdr.Name)
+		}
+		seen[hdr.Name] = true
+
+		if hdr.Size > MaxTGZSize {
+			return nil, fmt.Errorf("%s of chaincode package is larger than %d bytes", hdr.Name, MaxTGZSize)
+		}
+		content, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s from chaincode package: %s", hdr.Name, err)
+		}
+
+		switch {
+		case hdr.Name == TGZMetadataFile:
+			metadata := &TGZMetadata{}
+			if err := json.Unmarshal(content, metadata); err != nil {
+				return nil, fmt.Errorf("invalid %s: %s", TGZMetadataFile, err)
+			}
+			pkg.Metadata = metadata
+			pkg.metadataBytes = content
+		case hdr.Name == TGZCodeFile:
+			pkg.Code = content
+		case hdr.Name == TGZInstantiationPolicyFile:
+			pkg.InstantiationPolicy = content
+		case signatureFileRegExp.MatchString(hdr.Name):
+			endorsement := &peer.Endorsement{}
+			if err := proto.Unmarshal(content, endorsement); err != nil {
+				return nil, fmt.Errorf("invalid owner endorsement %s: %s", hdr.Name, err)
+			}
+			index, _ := strconv.Atoi(signatureFileRegExp.FindStringSubmatch(hdr.Name)[1])
+			endorsements[index] = endorsement
+		default:
+			return nil, fmt.Errorf("unexpected file %s in chaincode package", hdr.Name)
+		}
+	}
+
+	if pkg.Metadata == nil {
+		return nil, fmt.Errorf("%s not found in chaincode package", TGZMetadataFile)
+	}
+	if err := pkg.Metadata.validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %s", TGZMetadataFile, err)
+	}
+	if pkg.Code == nil {
+		return nil, fmt.Errorf("%s not found in chaincode package", TGZCodeFile)
+	}
+
+	for i := 0; i < len(endorsements); i++ {
+		endorsement, ok := endorsements[i]
+		if !ok {
+			return nil, fmt.Errorf("owner endorsement %s%d.pb not found in chaincode package", TGZSignaturesDir, i)
+		}
+		pkg.OwnerEndorsements = append(pkg.OwnerEndorsements, endorsement)
+	}
+	if len(pkg.OwnerEndorsements) > 0 && pkg.InstantiationPolicy == nil {
+		return nil, fmt.Errorf("owner endorsements require an instantiation policy")
+	}
+
+	return pkg, nil
+}
+
+// MetadataBytes returns metadata.json
+func (pkg *TGZ) MetadataBytes() ([]byte, error) {
+	if pkg.metadataBytes == nil {
+		if pkg.Metadata == nil {
+			return nil, fmt.Errorf("nil metadata")
+		}
+		b, err := json.Marshal(pkg.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		pkg.metadataBytes = b
+	}
+	return pkg.metadataBytes, nil
+}
+
+// Bytes returns the package in the tar.gz format. The package is the same for
+// the same parts, so that its package ID only depends on them
+func (pkg *TGZ) Bytes() ([]byte, error) {
+	if pkg.Metadata == nil {
+		return nil, fmt.Errorf("nil metadata")
+	}
+	if err := pkg.Metadata.validate(); err != nil {
+		return nil, err
+	}
+	if pkg.Code == nil {
+		return nil, fmt.Errorf("nil code package")
+	}
+	if len(pkg.OwnerEndorsements) > 0 && pkg.InstantiationPolicy == nil {
+		return nil, fmt.Errorf("owner endorsements require an instantiation policy")
+	}
+
+	metadataBytes, err := pkg.MetadataBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{
+		TGZMetadataFile: metadataBytes,
+		TGZCodeFile:     pkg.Code,
+	}
+	names := []string{TGZMetadataFile, TGZCodeFile}
+	if pkg.InstantiationPolicy != nil {
+		files[TGZInstantiationPolicyFile] = pkg.InstantiationPolicy
+		names = append(names, TGZInstantiationPolicyFile)
+	}
+	for i, endorsement := range pkg.OwnerEndorsements {
+		b, err := proto.Marshal(endorsement)
+		if err != nil {
+			return nil, err
+		}
+		name := fmt.Sprintf("%s%d.pb", TGZSignaturesDir, i)
+		files[name] = b
+		names = append(names, name)
+	}
+
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for _, name := range names {
+		hdr := &tar.Header{
+			Name:     name,
+			Mode:     0644,
+			Size:     int64(len(files[name])),
+			Typeflag: tar.TypeReg,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// SignedBytes returns the bytes the owners of the package sign, along with
+// their serialized identity
+func (pkg *TGZ) SignedBytes() ([]byte, error) {
+	metadataBytes, err := pkg.MetadataBytes()
+	if err != nil {
+		return nil, err
+	}
+	signed := append([]byte{}, metadataBytes...)
+	signed = append(signed, pkg.Code...)
+	return append(signed, pkg.InstantiationPolicy...), nil
+}
+
+// Sign adds the endorsement of an owner to a package, which must have an
+// instantiation policy
+func (pkg *TGZ) Sign(owner msp.SigningIdentity) error {
+	if owner == nil {
+		return fmt.Errorf("owner not provided")
+	}
+
+	if pkg.InstantiationPolicy == nil {
+		return fmt.Errorf("must provide an instantiation policy")
+	}
+
+	signed, err := pkg.SignedBytes()
+	if err != nil {
+		return err
+	}
+
+	// serialize the signing identity
+	endorser, err := owner.Serialize()
+	if err != nil {
+		return fmt.Errorf("Could not serialize the signing identity for %s, err %s", owner.GetIdentifier(), err)
+	}
+
+	// sign the concatenation of the package parts and the serialized endorser identity with this endorser's key
+	signature, err := owner.Sign(append(signed, endorser...))
+	if err != nil {
+		return fmt.Errorf("Could not sign the ccpackage, err %s", err)
+	}
+
+	pkg.OwnerEndorsements = append(pkg.OwnerEndorsements, &peer.Endorsement{Signature: signature, Endorser: endorser})
+
+	return nil
+}
+
+// ListCodePackage returns the names of the files of a code package, sorted
+func ListCodePackage(codePackage []byte) ([]string, error) {
+	tr, gr, err := newTarReader(codePackage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open code package: %s", err)
+	}
+	defer gr.Close()
+
+	var files []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read code package: %s", err)
+		}
+		if hdr.Typeflag == tar.TypeReg || hdr.Typeflag == tar.TypeRegA {
+			files = append(files, hdr.Name)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
@@ -0,0 +1,186 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ccpackage
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	mspprotos "github.com/hyperledger/fabric/protos/msp"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeTarGz writes the files in the given order in a tar.gz
+func writeTarGz(t *testing.T, files ...string) []byte {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for i := 0; i < len(files); i += 2 {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{Name: files[i], Mode: 0644, Size: int64(len(files[i+1])), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(files[i+1]))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func newTestTGZ(t *testing.T) *TGZ {
+	return &TGZ{
+		Metadata: &TGZMetadata{Type: "golang", Path: "github.com/example/cc", Label: "mycc_1.0", Name: "mycc", Version: "1.0"},
+		Code:     writeTarGz(t, "src/github.com/example/cc/cc.go", "package main", "META-INF/statedb/couchdb/indexes/index.json", "{}"),
+	}
+}
+
+func TestTGZRoundTrip(t *testing.T) {
+	pkg := newTestTGZ(t)
+	b, err := pkg.Bytes()
+	assert.NoError(t, err)
+	assert.True(t, IsTGZ(b))
+
+	// the same parts make the same package
+	b2, err := newTestTGZ(t).Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, b, b2)
+	assert.Equal(t, PackageID("mycc_1.0", b), PackageID("mycc_1.0", b2))
+	assert.Regexp(t, "^mycc_1.0:[0-9a-f]{64}$", PackageID("mycc_1.0", b))
+
+	parsed, err := ParseTGZ(b)
+	assert.NoError(t, err)
+	assert.Equal(t, pkg.Metadata, parsed.Metadata)
+	assert.Equal(t, pkg.Code, parsed.Code)
+	assert.Nil(t, parsed.InstantiationPolicy)
+	assert.Empty(t, parsed.OwnerEndorsements)
+	assert.Equal(t, peer.ChaincodeSpec_GOLANG, parsed.Metadata.ChaincodeType())
+
+	files, err := ListCodePackage(parsed.Code)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"META-INF/statedb/couchdb/indexes/index.json", "src/github.com/example/cc/cc.go"}, files)
+}
+
+func TestTGZSign(t *testing.T) {
+	pkg := newTestTGZ(t)
+	assert.EqualError(t, pkg.Sign(signer), "must provide an instantiation policy")
+	assert.EqualError(t, pkg.Sign(nil), "owner not provided")
+
+	mspid, _ := localmsp.GetIdentifier()
+	pkg.InstantiationPolicy = utils.MarshalOrPanic(createInstantiationPolicy(mspid, mspprotos.MSPRole_ADMIN))
+	unsigned, err := pkg.Bytes()
+	assert.NoError(t, err)
+
+	assert.NoError(t, pkg.Sign(signer))
+	b, err := pkg.Bytes()
+	assert.NoError(t, err)
+	assert.NotEqual(t, PackageID("mycc_1.0", unsigned), PackageID("mycc_1.0", b))
+
+	// owners sign parsed packages in turn
+	parsed, err := ParseTGZ(b)
+	assert.NoError(t, err)
+	assert.NoError(t, parsed.Sign(signer))
+	b, err = parsed.Bytes()
+	assert.NoError(t, err)
+	parsed, err = ParseTGZ(b)
+	assert.NoError(t, err)
+	assert.Equal(t, pkg.InstantiationPolicy, parsed.InstantiationPolicy)
+	assert.Len(t, parsed.OwnerEndorsements, 2)
+
+	signed, err := parsed.SignedBytes()
+	assert.NoError(t, err)
+	for _, endorsement := range parsed.OwnerEndorsements {
+		identity, err := localmsp.DeserializeIdentity(endorsement.Endorser)
+		assert.NoError(t, err)
+		assert.NoError(t, identity.Verify(append(signed, endorsement.Endorser...), endorsement.Signature))
+	}
+}
+
+func TestParseTGZErrors(t *testing.T) {
+	metadata := `{"type":"golang","path":"cc","label":"mycc","name":"mycc","version":"1"}`
+	endorsement := string(utils.MarshalOrPanic(&peer.Endorsement{Endorser: []byte("endorser")}))
+
+	for expectedErr, pkg := range map[string][]byte{
+		"failed to open chaincode package":                      []byte("not a package"),
+		"metadata.json not found in chaincode package":          writeTarGz(t, "code.tar.gz", "code"),
+		"code.tar.gz not found in chaincode package":            writeTarGz(t, "metadata.json", metadata),
+		"invalid metadata.json: invalid character":              writeTarGz(t, "metadata.json", "{x", "code.tar.gz", "code"),
+		"invalid label ' mycc'":                                 writeTarGz(t, "metadata.json", `{"type":"golang","label":" mycc","name":"a","version":"1"}`, "code.tar.gz", "code"),
+		"invalid chaincode type 'cobol'":                        writeTarGz(t, "metadata.json", `{"type":"cobol","label":"mycc","name":"a","version":"1"}`, "code.tar.gz", "code"),
+		"chaincode name and version must be set":                writeTarGz(t, "metadata.json", `{"type":"golang","label":"mycc","name":"a"}`, "code.tar.gz", "code"),
+		"unexpected file src/cc.go in chaincode package":        writeTarGz(t, "metadata.json", metadata, "code.tar.gz", "code", "src/cc.go", "package main"),
+		"duplicate file code.tar.gz in chaincode package":       writeTarGz(t, "metadata.json", metadata, "code.tar.gz", "code", "code.tar.gz", "code"),
+		"owner endorsements require an instantiation policy":    writeTarGz(t, "metadata.json", metadata, "code.tar.gz", "code", "signatures/0.pb", endorsement),
+		"owner endorsement signatures/1.pb not found":           writeTarGz(t, "metadata.json", metadata, "code.tar.gz", "code", "instantiation-policy.pb", "policy", "signatures/0.pb", endorsement, "signatures/2.pb", endorsement),
+		"invalid owner endorsement signatures/0.pb":             writeTarGz(t, "metadata.json", metadata, "code.tar.gz", "code", "instantiation-policy.pb", "policy", "signatures/0.pb", "\xff"),
+		"unexpected file signatures/01.pb in chaincode package": writeTarGz(t, "metadata.json", metadata, "code.tar.gz", "code", "signatures/01.pb", endorsement),
+	} {
+		_, err := ParseTGZ(pkg)
+		if assert.Error(t, err, expectedErr) {
+			assert.Contains(t, err.Error(), expectedErr)
+		}
+	}
+
+	// the parts may come in any order
+	pkg, err := ParseTGZ(writeTarGz(t, "code.tar.gz", "code", "metadata.json", metadata))
+	assert.NoError(t, err)
+	assert.Equal(t, "mycc", pkg.Metadata.Label)
+}
+
+func TestTGZMaxSize(t *testing.T) {
+	defer func(maxSize int64) { MaxTGZSize = maxSize }(MaxTGZSize)
+	MaxTGZSize = 4096
+
+	metadata := `{"type":"golang","path":"cc","label":"mycc","name":"mycc","version":"1"}`
+	large := string(make([]byte, 8192))
+	code := writeTarGz(t, "src/cc.go", "package main")
+
+	// a part larger than the maximum size is rejected before it is read
+	_, err := ParseTGZ(writeTarGz(t, "metadata.json", metadata, "code.tar.gz", large))
+	assert.EqualError(t, err, "code.tar.gz of chaincode package is larger than 4096 bytes")
+
+	// so is a package which decompresses to more than the maximum size
+	_, err = ParseTGZ(writeTarGz(t, "metadata.json", metadata, "code.tar.gz", string(code), "instantiation-policy.pb", large[:2000]))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "larger than 4096 bytes once decompressed")
+	}
+	_, err = ListCodePackage(writeTarGz(t, "src/cc.go", large[:2000], "src/cc2.go", large[:2000]))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "larger than 4096 bytes once decompressed")
+	}
+
+	pkg, err := ParseTGZ(writeTarGz(t, "metadata.json", metadata, "code.tar.gz", string(code)))
+	assert.NoError(t, err)
+	files, err := ListCodePackage(pkg.Code)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"src/cc.go"}, files)
+}
+
+func TestTGZBytesErrors(t *testing.T) {
+	_, err := (&TGZ{}).Bytes()
+	assert.EqualError(t, err, "nil metadata")
+
+	pkg := newTestTGZ(t)
+	pkg.Code = nil
+	_, err = pkg.Bytes()
+	assert.EqualError(t, err, "nil code package")
+
+	pkg = newTestTGZ(t)
+	pkg.Metadata.Label = "my label"
+	_, err = pkg.Bytes()
+	assert.Error(t, err)
+}
+
+func TestValidateLabel(t *testing.T) {
+	for _, label := range []string{"a", "mycc_1.0", "A-b+c.d_e", "0"} {
+		assert.NoError(t, ValidateLabel(label), label)
+	}
+	for _, label := range []string{"", "_a", "a:b", "a b", "a/b"} {
+		assert.Error(t, ValidateLabel(label), label)
+	}
+}
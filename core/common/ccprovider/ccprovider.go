@@ -27,6 +27,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/core/common/ccpackage"
 	"github.com/hyperledger/fabric/core/ledger"
 	pb "github.com/hyperledger/fabric/protos/peer"
 )
@@ -129,7 +130,12 @@ func (*CCInfoFSImpl) GetChaincode(ccname string, ccversion string) (CCPackage, e
 		ccscdspack := &SignedCDSPackage{}
 		_, _, err = ccscdspack.InitFromFS(ccname, ccversion)
 		if err != nil {
-			return nil, err
+			//try tar.gz package
+			cctgzpack := &TGZPackage{}
+			if _, _, tgzerr := cctgzpack.InitFromFS(ccname, ccversion); tgzerr != nil {
+				return nil, err
+			}
+			return cctgzpack, nil
 		}
 		return ccscdspack, nil
 	}
@@ -249,7 +255,15 @@ func GetCCPackage(buf []byte) (CCPackage, error) {
 		//try signed CDS
 		ccscdspack := &SignedCDSPackage{}
 		if _, err := ccscdspack.InitFromBuffer(buf); err != nil {
-			return nil, err
+			if !ccpackage.IsTGZ(buf) {
+				return nil, err
+			}
+			//try tar.gz package
+			cctgzpack := &TGZPackage{}
+			if _, err := cctgzpack.InitFromBuffer(buf); err != nil {
+				return nil, err
+			}
+			return cctgzpack, nil
 		}
 		return ccscdspack, nil
 	}
@@ -307,6 +321,18 @@ func GetInstalledChaincodes() (*pb.ChaincodeQueryResponse, error) {
 			ccInfoArray = append(ccInfoArray, ccInfo)
 		}
 	}
+
+	// tar.gz packages are stored under their package ID, which is returned
+	// along with the chaincode they install
+	tgzpacks, err := getTGZPackagesFromFS()
+	if err != nil {
+		return nil, err
+	}
+	for _, ccpack := range tgzpacks {
+		ccid := ccpack.GetDepSpec().GetChaincodeSpec().GetChaincodeId()
+		ccInfo := &pb.ChaincodeInfo{Name: ccid.Name, Version: ccid.Version, Path: ccid.Path, Id: ccpack.GetId(), PackageId: ccpack.GetPackageID()}
+		ccInfoArray = append(ccInfoArray, ccInfo)
+	}
 	// add array with info about all instantiated chaincodes to the query
 	// response proto
 	cqr := &pb.ChaincodeQueryResponse{Chaincodes: ccInfoArray}
@@ -379,7 +405,7 @@ func (cccid *CCContext) GetCanonicalName() string {
 
 //ChaincodeData defines the datastructure for chaincodes to be serialized by proto
 //Type provides an additional check by directing to use a specific package after instantiation
-//Data is Type specifc (see CDSPackage and SignedCDSPackage)
+//Data is Type specifc (see CDSPackage, SignedCDSPackage and TGZPackage)
 type ChaincodeData struct {
 	//Name of the chaincode
 	Name string `protobuf:"bytes,1,opt,name=name"`
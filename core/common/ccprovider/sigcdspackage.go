@@ -338,6 +338,15 @@ func (ccpack *SignedCDSPackage) PutChaincodeToFS() error {
 		return fmt.Errorf("chaincode %s exists", path)
 	}
 
+	//return error if the chaincode is installed by a tar.gz package
+	tgzpack, err := findTGZPackage(ccname, ccversion)
+	if err != nil {
+		return err
+	}
+	if tgzpack != nil {
+		return fmt.Errorf("chaincode %s:%s exists in package %s", ccname, ccversion, tgzpack.GetPackageID())
+	}
+
 	if err := ioutil.WriteFile(path, ccpack.buf, 0644); err != nil {
 		return err
 	}
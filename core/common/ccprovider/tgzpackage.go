@@ -0,0 +1,507 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ccprovider
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/core/common/ccpackage"
+	pb "github.com/hyperledger/fabric/protos/peer"
+)
+
+//----- TGZData ------
+
+//TGZData is data stored in the LSCC on instantiation of a CC
+//for TGZPackage. This needs to be serialized for ChaincodeData
+//hence the protobuf format
+type TGZData struct {
+	CodeHash      []byte `protobuf:"bytes,1,opt,name=codehash"`
+	MetaDataHash  []byte `protobuf:"bytes,2,opt,name=metadatahash"`
+	SignatureHash []byte `protobuf:"bytes,3,opt,name=signaturehash"`
+}
+
+//----implement functions needed from proto.Message for proto's mar/unmarshal functions
+
+//Reset resets
+func (data *TGZData) Reset() { *data = TGZData{} }
+
+//String converts to string
+func (data *TGZData) String() string { return proto.CompactTextString(data) }
+
+//ProtoMessage just exists to make proto happy
+func (*TGZData) ProtoMessage() {}
+
+//Equals data equals other
+func (data *TGZData) Equals(other *TGZData) bool {
+	return other != nil &&
+		bytes.Equal(data.CodeHash, other.CodeHash) &&
+		bytes.Equal(data.MetaDataHash, other.MetaDataHash) &&
+		bytes.Equal(data.SignatureHash, other.SignatureHash)
+}
+
+//-------- TGZPackage ---------
+
+//tgzPackagesDir is the directory of the chaincode install path which the
+//tar.gz packages are stored in, under their package ID
+const tgzPackagesDir = "packages"
+
+//tgzPackagePath returns the path of a tar.gz package in the file system. The
+//':' of the package ID is replaced as not every file system allows it
+func tgzPackagePath(packageID string) string {
+	return filepath.Join(chaincodeInstallPath, tgzPackagesDir, strings.Replace(packageID, ":", ".", 1)+".tar.gz")
+}
+
+//getTGZPackagesFromFS returns the tar.gz packages of the file system. The
+//packages which can't be read, or whose file name doesn't match their package
+//ID, are skipped
+func getTGZPackagesFromFS() ([]*TGZPackage, error) {
+	files, err := ioutil.ReadDir(filepath.Join(chaincodeInstallPath, tgzPackagesDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var ccpacks []*TGZPackage
+	for _, file := range files {
+		path := filepath.Join(chaincodeInstallPath, tgzPackagesDir, file.Name())
+		buf, err := ioutil.ReadFile(path)
+		if err != nil {
+			ccproviderLogger.Errorf("Unreadable chaincode package found on filesystem: %s", path)
+			continue
+		}
+		ccpack := &TGZPackage{}
+		if _, err = ccpack.InitFromBuffer(buf); err != nil {
+			ccproviderLogger.Errorf("Unreadable chaincode package found on filesystem: %s", path)
+			continue
+		}
+		if tgzPackagePath(ccpack.GetPackageID()) != path {
+			ccproviderLogger.Errorf("Chaincode package's file name has been modified on the filesystem: %s", path)
+			continue
+		}
+		ccpacks = append(ccpacks, ccpack)
+	}
+	return ccpacks, nil
+}
+
+//tgzPackageIndex maps the name and version of the chaincodes installed by
+//tar.gz packages to the IDs of the packages, so that the package of a
+//chaincode is found without reading every package of the file system. It is
+//built from the file system on first use, and rebuilt if the chaincode
+//install path changes
+type tgzPackageIndex struct {
+	sync.Mutex
+	path string
+	ids  map[string]string
+}
+
+var tgzPackages = &tgzPackageIndex{}
+
+//load builds the index from the file system, unless it is built for the
+//current chaincode install path. It must be called with the lock held
+func (idx *tgzPackageIndex) load() error {
+	if idx.ids != nil && idx.path == chaincodeInstallPath {
+		return nil
+	}
+
+	ccpacks, err := getTGZPackagesFromFS()
+	if err != nil {
+		return err
+	}
+	ids := make(map[string]string)
+	for _, ccpack := range ccpacks {
+		ids[ccpack.tgz.Metadata.Name+":"+ccpack.tgz.Metadata.Version] = ccpack.GetPackageID()
+	}
+	idx.path = chaincodeInstallPath
+	idx.ids = ids
+	return nil
+}
+
+//get returns the ID of the package which installs the given chaincode, or
+//"" if there is none
+func (idx *tgzPackageIndex) get(ccname string, ccversion string) (string, error) {
+	idx.Lock()
+	defer idx.Unlock()
+
+	if err := idx.load(); err != nil {
+		return "", err
+	}
+	return idx.ids[ccname+":"+ccversion], nil
+}
+
+//put records the package which installs the given chaincode
+func (idx *tgzPackageIndex) put(ccname string, ccversion string, packageID string) error {
+	idx.Lock()
+	defer idx.Unlock()
+
+	if err := idx.load(); err != nil {
+		return err
+	}
+	idx.ids[ccname+":"+ccversion] = packageID
+	return nil
+}
+
+//remove forgets the package which installs the given chaincode
+func (idx *tgzPackageIndex) remove(ccname string, ccversion string) {
+	idx.Lock()
+	defer idx.Unlock()
+
+	delete(idx.ids, ccname+":"+ccversion)
+}
+
+//findTGZPackage returns the tar.gz package of the file system which installs
+//the given chaincode, or nil if there is none
+func findTGZPackage(ccname string, ccversion string) (*TGZPackage, error) {
+	packageID, err := tgzPackages.get(ccname, ccversion)
+	if err != nil || packageID == "" {
+		return nil, err
+	}
+
+	buf, err := ioutil.ReadFile(tgzPackagePath(packageID))
+	if os.IsNotExist(err) {
+		//the package has been removed from the file system
+		tgzPackages.remove(ccname, ccversion)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ccpack := &TGZPackage{}
+	if _, err = ccpack.InitFromBuffer(buf); err != nil {
+		return nil, err
+	}
+	if ccpack.GetPackageID() != packageID {
+		return nil, fmt.Errorf("chaincode package %s has been modified on the filesystem", packageID)
+	}
+	return ccpack, nil
+}
+
+//TGZPackage encapsulates a chaincode package in the tar.gz format, made of
+//a metadata.json and a code.tar.gz (see ccpackage.TGZ). The deployment spec
+//of the chaincode is built from them. The package is stored in the file
+//system under its package ID, and is found by the name and version of the
+//chaincode it installs too
+type TGZPackage struct {
+	buf          []byte
+	tgz          *ccpackage.TGZ
+	depSpec      *pb.ChaincodeDeploymentSpec
+	depSpecBytes []byte
+	data         *TGZData
+	datab        []byte
+	id           []byte
+}
+
+// resets data
+func (ccpack *TGZPackage) reset() {
+	*ccpack = TGZPackage{}
+}
+
+// GetId gets the fingerprint of the chaincode based on package computation
+func (ccpack *TGZPackage) GetId() []byte {
+	//this has to be after creating a package and initializing it
+	//If those steps fail, GetId() should never be called
+	if ccpack.id == nil {
+		panic("GetId called on uninitialized package")
+	}
+	return ccpack.id
+}
+
+// GetPackageID gets the ID of the package, made of its label and its hash
+func (ccpack *TGZPackage) GetPackageID() string {
+	if ccpack.tgz == nil {
+		panic("GetPackageID called on uninitialized package")
+	}
+	return ccpackage.PackageID(ccpack.tgz.Metadata.Label, ccpack.buf)
+}
+
+// GetMetadata gets the metadata of the package
+func (ccpack *TGZPackage) GetMetadata() *ccpackage.TGZMetadata {
+	if ccpack.tgz == nil {
+		panic("GetMetadata called on uninitialized package")
+	}
+	return ccpack.tgz.Metadata
+}
+
+// GetDepSpec gets the ChaincodeDeploymentSpec built from the package
+func (ccpack *TGZPackage) GetDepSpec() *pb.ChaincodeDeploymentSpec {
+	//this has to be after creating a package and initializing it
+	//If those steps fail, GetDepSpec() should never be called
+	if ccpack.depSpec == nil {
+		panic("GetDepSpec called on uninitialized package")
+	}
+	return ccpack.depSpec
+}
+
+// GetInstantiationPolicy gets the instantiation policy from the package, if any
+func (ccpack *TGZPackage) GetInstantiationPolicy() []byte {
+	if ccpack.tgz == nil {
+		panic("GetInstantiationPolicy called on uninitialized package")
+	}
+	return ccpack.tgz.InstantiationPolicy
+}
+
+// GetOwnerEndorsements gets the owner endorsements from the package, if any
+func (ccpack *TGZPackage) GetOwnerEndorsements() []*pb.Endorsement {
+	if ccpack.tgz == nil {
+		panic("GetOwnerEndorsements called on uninitialized package")
+	}
+	return ccpack.tgz.OwnerEndorsements
+}
+
+// GetDepSpecBytes gets the serialized ChaincodeDeploymentSpec built from the package
+func (ccpack *TGZPackage) GetDepSpecBytes() []byte {
+	//this has to be after creating a package and initializing it
+	//If those steps fail, GetDepSpecBytes() should never be called
+	if ccpack.depSpecBytes == nil {
+		panic("GetDepSpecBytes called on uninitialized package")
+	}
+	return ccpack.depSpecBytes
+}
+
+// GetPackageObject gets the ChaincodeDeploymentSpec built from the package as
+// proto.Message, since the package itself is not one
+func (ccpack *TGZPackage) GetPackageObject() proto.Message {
+	return ccpack.depSpec
+}
+
+// GetChaincodeData gets the ChaincodeData
+func (ccpack *TGZPackage) GetChaincodeData() *ChaincodeData {
+	//this has to be after creating a package and initializing it
+	//If those steps fail, GetChaincodeData() should never be called
+	if ccpack.depSpec == nil || ccpack.datab == nil || ccpack.id == nil {
+		panic("GetChaincodeData called on uninitialized package")
+	}
+
+	return &ChaincodeData{
+		Name:                ccpack.depSpec.ChaincodeSpec.ChaincodeId.Name,
+		Version:             ccpack.depSpec.ChaincodeSpec.ChaincodeId.Version,
+		Data:                ccpack.datab,
+		Id:                  ccpack.id,
+		InstantiationPolicy: ccpack.tgz.InstantiationPolicy,
+	}
+}
+
+func (ccpack *TGZPackage) getTGZData(tgz *ccpackage.TGZ) ([]byte, []byte, *TGZData, error) {
+	// check for nil argument. It is an assertion that getTGZData
+	// is never called on a package that did not go through/succeed
+	// package initialization.
+	if tgz == nil {
+		panic("nil tgz")
+	}
+
+	if err := factory.InitFactories(nil); err != nil {
+		return nil, nil, nil, fmt.Errorf("Internal error, BCCSP could not be initialized : %s", err)
+	}
+
+	//get the hash object
+	hash, err := factory.GetDefault().GetHash(&bccsp.SHAOpts{})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	tgzdata := &TGZData{}
+
+	//get the code hash
+	hash.Write(tgz.Code)
+	tgzdata.CodeHash = hash.Sum(nil)
+
+	hash.Reset()
+
+	//get the metadata hash
+	hash.Write([]byte(tgz.Metadata.Name))
+	hash.Write([]byte(tgz.Metadata.Version))
+
+	tgzdata.MetaDataHash = hash.Sum(nil)
+
+	//get the signature hashes, if the package is meant to be signed
+	if tgz.InstantiationPolicy != nil {
+		hash.Reset()
+
+		hash.Write(tgz.InstantiationPolicy)
+		for _, o := range tgz.OwnerEndorsements {
+			hash.Write(o.Endorser)
+		}
+		tgzdata.SignatureHash = hash.Sum(nil)
+	}
+
+	//marshall data
+	b, err := proto.Marshal(tgzdata)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	hash.Reset()
+
+	//compute the id
+	hash.Write(tgzdata.CodeHash)
+	hash.Write(tgzdata.MetaDataHash)
+	hash.Write(tgzdata.SignatureHash)
+
+	id := hash.Sum(nil)
+
+	return b, id, tgzdata, nil
+}
+
+// ValidateCC returns error if the chaincode is not found or if its not a
+// tar.gz package
+func (ccpack *TGZPackage) ValidateCC(ccdata *ChaincodeData) error {
+	if ccpack.tgz == nil || ccpack.depSpec == nil {
+		return fmt.Errorf("uninitialized package")
+	}
+
+	if ccpack.data == nil {
+		return fmt.Errorf("nil data")
+	}
+
+	if ccdata.Name != ccpack.depSpec.ChaincodeSpec.ChaincodeId.Name || ccdata.Version != ccpack.depSpec.ChaincodeSpec.ChaincodeId.Version {
+		return fmt.Errorf("invalid chaincode data %v (%v)", ccdata, ccpack.depSpec.ChaincodeSpec.ChaincodeId)
+	}
+
+	otherdata := &TGZData{}
+	err := proto.Unmarshal(ccdata.Data, otherdata)
+	if err != nil {
+		return err
+	}
+
+	if !ccpack.data.Equals(otherdata) {
+		return fmt.Errorf("data mismatch")
+	}
+
+	return nil
+}
+
+//InitFromBuffer sets the buffer if valid and returns ChaincodeData
+func (ccpack *TGZPackage) InitFromBuffer(buf []byte) (*ChaincodeData, error) {
+	//incase ccpack is reused
+	ccpack.reset()
+
+	if !ccpackage.IsTGZ(buf) {
+		return nil, fmt.Errorf("not a tar.gz chaincode package")
+	}
+
+	tgz, err := ccpackage.ParseTGZ(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	depSpec := &pb.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{
+			Type: tgz.Metadata.ChaincodeType(),
+			ChaincodeId: &pb.ChaincodeID{
+				Path:    tgz.Metadata.Path,
+				Name:    tgz.Metadata.Name,
+				Version: tgz.Metadata.Version,
+			},
+		},
+		CodePackage: tgz.Code,
+	}
+
+	depSpecBytes, err := proto.Marshal(depSpec)
+	if err != nil {
+		return nil, err
+	}
+
+	databytes, id, data, err := ccpack.getTGZData(tgz)
+	if err != nil {
+		return nil, err
+	}
+
+	ccpack.buf = buf
+	ccpack.tgz = tgz
+	ccpack.depSpec = depSpec
+	ccpack.depSpecBytes = depSpecBytes
+	ccpack.data = data
+	ccpack.datab = databytes
+	ccpack.id = id
+
+	return ccpack.GetChaincodeData(), nil
+}
+
+//InitFromFS returns the chaincode and the package which installs it from the
+//file system
+func (ccpack *TGZPackage) InitFromFS(ccname string, ccversion string) ([]byte, *pb.ChaincodeDeploymentSpec, error) {
+	//incase ccpack is reused
+	ccpack.reset()
+
+	found, err := findTGZPackage(ccname, ccversion)
+	if err != nil {
+		return nil, nil, err
+	}
+	if found == nil {
+		return nil, nil, fmt.Errorf("chaincode %s:%s not found in a tar.gz package", ccname, ccversion)
+	}
+
+	*ccpack = *found
+
+	return ccpack.buf, ccpack.depSpec, nil
+}
+
+//PutChaincodeToFS - writes the package as is to the file system, under its
+//package ID
+func (ccpack *TGZPackage) PutChaincodeToFS() error {
+	if ccpack.buf == nil {
+		return fmt.Errorf("uninitialized package")
+	}
+
+	if ccpack.id == nil {
+		return fmt.Errorf("id cannot be nil if buf is not nil")
+	}
+
+	if ccpack.tgz == nil || ccpack.depSpec == nil {
+		return fmt.Errorf("depspec cannot be nil if buf is not nil")
+	}
+
+	if ccpack.data == nil {
+		return fmt.Errorf("nil data")
+	}
+
+	if ccpack.datab == nil {
+		return fmt.Errorf("nil data bytes")
+	}
+
+	//return error if the package exists
+	path := tgzPackagePath(ccpack.GetPackageID())
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("chaincode package %s exists", ccpack.GetPackageID())
+	}
+
+	//return error if the chaincode is installed by another package, so
+	//that it is found unambiguously by its name and version
+	ccname := ccpack.tgz.Metadata.Name
+	ccversion := ccpack.tgz.Metadata.Version
+	if exists, _ := ChaincodePackageExists(ccname, ccversion); exists {
+		return fmt.Errorf("chaincode %s:%s exists", ccname, ccversion)
+	}
+	found, err := findTGZPackage(ccname, ccversion)
+	if err != nil {
+		return err
+	}
+	if found != nil {
+		return fmt.Errorf("chaincode %s:%s exists in package %s", ccname, ccversion, found.GetPackageID())
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(path, ccpack.buf, 0644); err != nil {
+		return err
+	}
+
+	return tgzPackages.put(ccname, ccversion, ccpack.GetPackageID())
+}
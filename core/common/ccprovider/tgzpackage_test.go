@@ -0,0 +1,235 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package ccprovider
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/common/ccpackage"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// codePackage returns a code package holding a source file and an index
+func codePackage(t *testing.T, source string) []byte {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	files := []string{
+		"src/cc/cc.go", source,
+		"META-INF/statedb/couchdb/indexes/indexOwner.json", `{"index":{"fields":["owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`,
+	}
+	for i := 0; i < len(files); i += 2 {
+		assert.NoError(t, tw.WriteHeader(&tar.Header{Name: files[i], Mode: 0644, Size: int64(len(files[i+1]))}))
+		_, err := tw.Write([]byte(files[i+1]))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, tw.Close())
+	assert.NoError(t, gw.Close())
+	return buf.Bytes()
+}
+
+func tgzPackage(t *testing.T, name, version, source string, instantiationPolicy []byte) []byte {
+	tgz := &ccpackage.TGZ{
+		Metadata:            &ccpackage.TGZMetadata{Type: "golang", Path: "cc", Label: name + "_" + version, Name: name, Version: version},
+		Code:                codePackage(t, source),
+		InstantiationPolicy: instantiationPolicy,
+	}
+	b, err := tgz.Bytes()
+	assert.NoError(t, err)
+	return b
+}
+
+func TestPutTGZCC(t *testing.T) {
+	ccdir := setupccdir()
+	defer os.RemoveAll(ccdir)
+
+	b := tgzPackage(t, "testcc", "0", "package main", nil)
+	ccpack := &TGZPackage{}
+	cd, err := ccpack.InitFromBuffer(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "testcc", cd.Name)
+	assert.Equal(t, "0", cd.Version)
+	assert.Nil(t, cd.InstantiationPolicy)
+	assert.Equal(t, ccpackage.PackageID("testcc_0", b), ccpack.GetPackageID())
+	assert.Equal(t, "testcc_0", ccpack.GetMetadata().Label)
+
+	cds := ccpack.GetDepSpec()
+	assert.Equal(t, pb.ChaincodeSpec_GOLANG, cds.ChaincodeSpec.Type)
+	assert.Equal(t, &pb.ChaincodeID{Path: "cc", Name: "testcc", Version: "0"}, cds.ChaincodeSpec.ChaincodeId)
+	assert.Equal(t, codePackage(t, "package main"), cds.CodePackage)
+	depSpec := &pb.ChaincodeDeploymentSpec{}
+	assert.NoError(t, proto.Unmarshal(ccpack.GetDepSpecBytes(), depSpec))
+	assert.Equal(t, cds, depSpec)
+
+	assert.NoError(t, ccpack.PutChaincodeToFS())
+	assert.EqualError(t, ccpack.PutChaincodeToFS(), "chaincode package "+ccpack.GetPackageID()+" exists")
+	// the package is stored under its package ID
+	fsb, err := ioutil.ReadFile(filepath.Join(ccdir, "packages", "testcc_0."+strings.TrimPrefix(ccpack.GetPackageID(), "testcc_0:")+".tar.gz"))
+	assert.NoError(t, err)
+	assert.Equal(t, b, fsb)
+
+	// the package is loaded from the FS next to the other formats
+	fspack, err := GetChaincodeFromFS("testcc", "0")
+	assert.NoError(t, err)
+	assert.IsType(t, &TGZPackage{}, fspack)
+	assert.NoError(t, fspack.ValidateCC(cd))
+	assert.Equal(t, ccpack.GetId(), fspack.GetId())
+
+	installed, err := GetInstalledChaincodes()
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.ChaincodeInfo{{Name: "testcc", Version: "0", Path: "cc", Id: ccpack.GetId(), PackageId: ccpack.GetPackageID()}}, installed.Chaincodes)
+
+	// the indexes are in the code package
+	installed2, artifacts, err := ExtractStatedbArtifactsForChaincode("testcc", "0")
+	assert.NoError(t, err)
+	assert.True(t, installed2)
+	entries, err := ExtractFileEntries(artifacts, map[string]bool{"META-INF/statedb/couchdb/indexes": true})
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "META-INF/statedb/couchdb/indexes/indexOwner.json", entries[0].FileHeader.Name)
+}
+
+func TestTGZGetCCPackage(t *testing.T) {
+	ccpack, err := GetCCPackage(tgzPackage(t, "testcc", "0", "package main", []byte("policy")))
+	assert.NoError(t, err)
+	tgzpack, ok := ccpack.(*TGZPackage)
+	assert.True(t, ok)
+	assert.Equal(t, []byte("policy"), tgzpack.GetInstantiationPolicy())
+	assert.Empty(t, tgzpack.GetOwnerEndorsements())
+	assert.Equal(t, []byte("policy"), tgzpack.GetChaincodeData().InstantiationPolicy)
+
+	// errors of invalid tar.gz packages are reported as such
+	_, err = GetCCPackage(codePackage(t, "package main"))
+	assert.EqualError(t, err, "unexpected file src/cc/cc.go in chaincode package")
+
+	_, err = (&TGZPackage{}).InitFromBuffer([]byte("not a package"))
+	assert.EqualError(t, err, "not a tar.gz chaincode package")
+}
+
+func TestValidateTGZCC(t *testing.T) {
+	ccpack := &TGZPackage{}
+	_, err := ccpack.InitFromBuffer(tgzPackage(t, "testcc", "0", "package main", nil))
+	assert.NoError(t, err)
+
+	for _, b := range [][]byte{
+		tgzPackage(t, "testcc", "0", "package badcode", nil),
+		tgzPackage(t, "testcc", "0", "package main", []byte("policy")),
+	} {
+		other := &TGZPackage{}
+		cd, err := other.InitFromBuffer(b)
+		assert.NoError(t, err)
+		assert.NotEqual(t, ccpack.GetId(), other.GetId())
+		assert.EqualError(t, ccpack.ValidateCC(cd), "data mismatch")
+	}
+
+	cd := ccpack.GetChaincodeData()
+	cd.Version = "1"
+	assert.Error(t, ccpack.ValidateCC(cd))
+
+	cd = ccpack.GetChaincodeData()
+	cd.Data = []byte("bad data")
+	assert.Error(t, ccpack.ValidateCC(cd))
+
+	assert.EqualError(t, (&TGZPackage{}).ValidateCC(cd), "uninitialized package")
+}
+
+func TestPutTGZErrorPaths(t *testing.T) {
+	ccdir := setupccdir()
+	defer os.RemoveAll(ccdir)
+
+	assert.EqualError(t, (&TGZPackage{}).PutChaincodeToFS(), "uninitialized package")
+
+	ccpack := &TGZPackage{}
+	_, err := ccpack.InitFromBuffer(tgzPackage(t, "testcc", "0", "package main", nil))
+	assert.NoError(t, err)
+	ccpack.id = nil
+	assert.EqualError(t, ccpack.PutChaincodeToFS(), "id cannot be nil if buf is not nil")
+
+	_, err = ccpack.InitFromBuffer(tgzPackage(t, "testcc", "0", "package main", nil))
+	assert.NoError(t, err)
+	ccpack.data = nil
+	assert.EqualError(t, ccpack.PutChaincodeToFS(), "nil data")
+
+	_, err = ccpack.InitFromBuffer(tgzPackage(t, "testcc", "0", "package main", nil))
+	assert.NoError(t, err)
+	ccpack.datab = nil
+	assert.EqualError(t, ccpack.PutChaincodeToFS(), "nil data bytes")
+
+	_, _, err = ccpack.InitFromFS("testcc", "1")
+	assert.Error(t, err)
+}
+
+func TestTGZPackageIndex(t *testing.T) {
+	ccdir := setupccdir()
+	defer os.RemoveAll(ccdir)
+
+	// a chaincode is installed by a single package, whatever its format
+	b := tgzPackage(t, "testcc", "0", "package main", nil)
+	named := &TGZPackage{}
+	_, err := named.InitFromBuffer(b)
+	assert.NoError(t, err)
+	assert.NoError(t, named.PutChaincodeToFS())
+
+	other := &TGZPackage{}
+	_, err = other.InitFromBuffer(tgzPackage(t, "testcc", "0", "package other", nil))
+	assert.NoError(t, err)
+	assert.EqualError(t, other.PutChaincodeToFS(), "chaincode testcc:0 exists in package "+named.GetPackageID())
+
+	cdspack := &CDSPackage{}
+	_, err = cdspack.InitFromBuffer(utils.MarshalOrPanic(&pb.ChaincodeDeploymentSpec{
+		ChaincodeSpec: &pb.ChaincodeSpec{Type: pb.ChaincodeSpec_GOLANG, ChaincodeId: &pb.ChaincodeID{Name: "testcc", Version: "0", Path: "cc"}},
+		CodePackage:   codePackage(t, "package main"),
+	}))
+	assert.NoError(t, err)
+	assert.EqualError(t, cdspack.PutChaincodeToFS(), "chaincode testcc:0 exists in package "+named.GetPackageID())
+
+	_, err = other.InitFromBuffer(tgzPackage(t, "testcc2", "0", "package main", nil))
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(ccdir, "testcc2.0"), []byte("a chaincode"), 0644))
+	assert.EqualError(t, other.PutChaincodeToFS(), "chaincode testcc2:0 exists")
+
+	// packages whose file name doesn't match their package ID are skipped
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(ccdir, "packages", "renamed.0000.tar.gz"), b, 0644))
+
+	installed, err := GetInstalledChaincodes()
+	assert.NoError(t, err)
+	packageIDs := map[string]string{}
+	for _, ccinfo := range installed.Chaincodes {
+		packageIDs[ccinfo.PackageId] = ccinfo.Name
+	}
+	assert.Equal(t, map[string]string{named.GetPackageID(): "testcc"}, packageIDs)
+
+	// the package of a chaincode is looked up in the index, which forgets
+	// the packages removed from the file system
+	found, err := findTGZPackage("testcc", "0")
+	assert.NoError(t, err)
+	assert.Equal(t, named.GetId(), found.GetId())
+	assert.NoError(t, os.Remove(tgzPackagePath(named.GetPackageID())))
+	found, err = findTGZPackage("testcc", "0")
+	assert.NoError(t, err)
+	assert.Nil(t, found)
+	assert.NoError(t, named.PutChaincodeToFS())
+
+	// the index is rebuilt from the file system of another install path
+	ccdir2 := setupccdir()
+	defer os.RemoveAll(ccdir2)
+	assert.NoError(t, os.MkdirAll(filepath.Join(ccdir2, "packages"), 0755))
+	assert.NoError(t, ioutil.WriteFile(tgzPackagePath(named.GetPackageID()), b, 0644))
+	found, err = findTGZPackage("testcc", "0")
+	assert.NoError(t, err)
+	assert.Equal(t, named.GetId(), found.GetId())
+}
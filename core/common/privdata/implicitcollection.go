@@ -0,0 +1,72 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"strings"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/protos/common"
+)
+
+const (
+	// ImplicitCollectionPrefix is the prefix of the names of the implicit
+	// collections, which exist for every application org of a channel without
+	// being defined in the collection configuration of chaincodes
+	ImplicitCollectionPrefix = "_implicit_org_"
+
+	// implicitCollectionRequiredPeerCount is the minimum number of peers of the
+	// org the private data of an implicit collection is sent to upon endorsement
+	implicitCollectionRequiredPeerCount = 0
+	// implicitCollectionMaximumPeerCount is the maximum number of peers of the
+	// org the private data of an implicit collection is sent to upon endorsement
+	implicitCollectionMaximumPeerCount = 1
+)
+
+// ImplicitCollectionNameForOrg returns the name of the implicit collection of the org with the given MSP ID
+func ImplicitCollectionNameForOrg(mspID string) string {
+	return ImplicitCollectionPrefix + mspID
+}
+
+// MSPIDIfImplicitCollection returns the MSP ID of the org of the given collection,
+// and whether the collection is an implicit collection
+func MSPIDIfImplicitCollection(collectionName string) (string, bool) {
+	if !strings.HasPrefix(collectionName, ImplicitCollectionPrefix) {
+		return "", false
+	}
+	return collectionName[len(ImplicitCollectionPrefix):], true
+}
+
+// GenerateImplicitCollectionForOrg returns the static configuration of the implicit collection
+// of the org with the given MSP ID, whose members are the members of the org
+func GenerateImplicitCollectionForOrg(mspID string) *common.StaticCollectionConfig {
+	return &common.StaticCollectionConfig{
+		Name: ImplicitCollectionNameForOrg(mspID),
+		MemberOrgsPolicy: &common.CollectionPolicyConfig{
+			Payload: &common.CollectionPolicyConfig_SignaturePolicy{
+				SignaturePolicy: cauthdsl.SignedByMspMember(mspID),
+			},
+		},
+		RequiredPeerCount: implicitCollectionRequiredPeerCount,
+		MaximumPeerCount:  implicitCollectionMaximumPeerCount,
+	}
+}
+
+// ImplicitCollectionOrgs returns the MSP IDs of the orgs that have implicit collections,
+// which are the application orgs of the given application config if the V1_2 capability
+// is enabled, and none otherwise
+func ImplicitCollectionOrgs(ac channelconfig.Application) []string {
+	if !ac.Capabilities().ImplicitCollections() {
+		return nil
+	}
+	var orgs []string
+	for _, org := range ac.Organizations() {
+		orgs = append(orgs, org.MSPID())
+	}
+	return orgs
+}
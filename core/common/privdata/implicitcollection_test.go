@@ -0,0 +1,71 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/channelconfig"
+	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImplicitCollectionNames(t *testing.T) {
+	name := ImplicitCollectionNameForOrg("Org1MSP")
+	assert.Equal(t, "_implicit_org_Org1MSP", name)
+
+	mspID, isImplicit := MSPIDIfImplicitCollection(name)
+	assert.True(t, isImplicit)
+	assert.Equal(t, "Org1MSP", mspID)
+
+	_, isImplicit = MSPIDIfImplicitCollection("mycollection")
+	assert.False(t, isImplicit)
+}
+
+func TestGenerateImplicitCollectionForOrg(t *testing.T) {
+	conf := GenerateImplicitCollectionForOrg("Org1MSP")
+	assert.Equal(t, "_implicit_org_Org1MSP", conf.Name)
+	assert.Equal(t, cauthdsl.SignedByMspMember("Org1MSP"), conf.MemberOrgsPolicy.GetSignaturePolicy())
+	assert.Equal(t, int32(0), conf.RequiredPeerCount)
+	assert.Equal(t, int32(1), conf.MaximumPeerCount)
+
+	sc := &SimpleCollection{}
+	assert.NoError(t, sc.Setup(conf, &mockDeserializer{}))
+	assert.Equal(t, []string{"Org1MSP"}, sc.MemberOrgs())
+}
+
+type mockApplicationOrg struct {
+	channelconfig.ApplicationOrg
+	mspID string
+}
+
+func (o *mockApplicationOrg) MSPID() string {
+	return o.mspID
+}
+
+type mockApplication struct {
+	mockconfig.MockApplication
+	orgs map[string]channelconfig.ApplicationOrg
+}
+
+func (a *mockApplication) Organizations() map[string]channelconfig.ApplicationOrg {
+	return a.orgs
+}
+
+func TestImplicitCollectionOrgs(t *testing.T) {
+	capabilities := &mockconfig.MockApplicationCapabilities{}
+	ac := &mockApplication{
+		MockApplication: mockconfig.MockApplication{CapabilitiesRv: capabilities},
+		orgs:            map[string]channelconfig.ApplicationOrg{"Org1": &mockApplicationOrg{mspID: "Org1MSP"}},
+	}
+	// The orgs have no implicit collections without the V1_2 capability
+	assert.Empty(t, ImplicitCollectionOrgs(ac))
+
+	capabilities.ImplicitCollectionsRv = true
+	assert.Equal(t, []string{"Org1MSP"}, ImplicitCollectionOrgs(ac))
+}
@@ -61,6 +61,14 @@ func (sc *SimpleCollection) AccessFilter() Filter {
 // Setup configures a simple collection object based on a given
 // StaticCollectionConfig proto that has all the necessary information
 func (sc *SimpleCollection) Setup(collectionConfig *common.StaticCollectionConfig, deserializer msp.IdentityDeserializer) error {
+	return sc.SetupWithPolicyManager(collectionConfig, deserializer, nil)
+}
+
+// SetupWithPolicyManager configures a simple collection object like Setup, resolving the
+// policy references of the member orgs policy through the given policy manager of the channel.
+// Policy references only govern access to the collection: the member orgs of the collection,
+// to which its private data is disseminated, are those of the principals of the policy
+func (sc *SimpleCollection) SetupWithPolicyManager(collectionConfig *common.StaticCollectionConfig, deserializer msp.IdentityDeserializer, policyManager policies.Manager) error {
 	if collectionConfig == nil {
 		return errors.New("Nil config passed to collection setup")
 	}
@@ -78,7 +86,7 @@ func (sc *SimpleCollection) Setup(collectionConfig *common.StaticCollectionConfi
 	}
 
 	// create access policy from the envelope
-	npp := cauthdsl.NewPolicyProvider(deserializer)
+	npp := cauthdsl.NewPolicyProviderWithPolicyManager(deserializer, policyManager)
 	polBytes, err := proto.Marshal(accessPolicyEnvelope)
 	if err != nil {
 		return err
@@ -105,13 +113,20 @@ func (sc *SimpleCollection) Setup(collectionConfig *common.StaticCollectionConfi
 				return errors.Wrap(err, "Invalid identity principal, not a certificate")
 			}
 			sc.memberOrgs = append(sc.memberOrgs, principalId.GetMSPIdentifier())
-		case m.MSPPrincipal_ORGANIZATION_UNIT:
+		case m.MSPPrincipal_ORGANIZATION_UNIT, m.MSPPrincipal_ORGANIZATION_UNIT_NAME:
 			OU := &m.OrganizationUnit{}
 			err := proto.Unmarshal(principal.Principal, OU)
 			if err != nil {
 				return errors.Wrap(err, "Could not unmarshal OrganizationUnit from principal")
 			}
 			sc.memberOrgs = append(sc.memberOrgs, OU.MspIdentifier)
+		case m.MSPPrincipal_IDENTITY_HASH:
+			idHash := &m.IdentityHash{}
+			err := proto.Unmarshal(principal.Principal, idHash)
+			if err != nil {
+				return errors.Wrap(err, "Could not unmarshal IdentityHash from principal")
+			}
+			sc.memberOrgs = append(sc.memberOrgs, idHash.MspIdentifier)
 		default:
 			return errors.New(fmt.Sprintf("Invalid principal type %d", int32(principal.PrincipalClassification)))
 		}
@@ -13,6 +13,8 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/common/cauthdsl"
+	mockpolicies "github.com/hyperledger/fabric/common/mocks/policies"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/msp"
 	pb "github.com/hyperledger/fabric/protos/common"
 	mb "github.com/hyperledger/fabric/protos/msp"
@@ -162,3 +164,37 @@ func TestSimpleCollectionFilter(t *testing.T) {
 	}
 	assert.True(t, accessFilter(member))
 }
+
+func TestSetupWithPolicyManager(t *testing.T) {
+	// member access policy: either the admins of the channel, or a specific identity of Org1
+	policyEnvelope, err := cauthdsl.FromString("OR('/Channel/Application/Admins', 'Org1.id=0a1b2c3d')")
+	assert.NoError(t, err)
+
+	collectionConfig := &pb.StaticCollectionConfig{
+		Name:             "test collection",
+		MemberOrgsPolicy: createCollectionPolicyConfig(policyEnvelope),
+	}
+
+	pm := &mockpolicies.Manager{
+		PolicyMap: map[string]policies.Policy{
+			"/Channel/Application/Admins": &mockpolicies.Policy{},
+		},
+	}
+
+	var sc SimpleCollection
+	err = sc.SetupWithPolicyManager(collectionConfig, &mockDeserializer{}, pm)
+	assert.NoError(t, err)
+
+	// the member orgs are those of the principals of the policy
+	assert.Equal(t, []string{"Org1"}, sc.MemberOrgs())
+
+	// access is granted through the referenced policy
+	accessFilter := sc.AccessFilter()
+	assert.True(t, accessFilter(pb.SignedData{Identity: []byte{1, 2, 3}}))
+
+	// but not without a policy manager to resolve it
+	err = sc.Setup(collectionConfig, &mockDeserializer{})
+	assert.NoError(t, err)
+	accessFilter = sc.AccessFilter()
+	assert.False(t, accessFilter(pb.SignedData{Identity: []byte{1, 2, 3}}))
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/protos/common"
@@ -28,6 +29,15 @@ type Support interface {
 	// GetIdentityDeserializer returns an IdentityDeserializer
 	// instance for the specified chain
 	GetIdentityDeserializer(chainID string) msp.IdentityDeserializer
+
+	// GetImplicitCollectionOrgs returns the MSP IDs of the
+	// orgs of the specified chain that have implicit collections
+	GetImplicitCollectionOrgs(chainID string) ([]string, error)
+
+	// GetPolicyManager returns the policy manager of the specified
+	// chain, through which the policy references of the collection
+	// member policies are resolved
+	GetPolicyManager(chainID string) policies.Manager
 }
 
 type NoSuchCollectionError common.CollectionCriteria
@@ -73,6 +83,20 @@ func (c *simpleCollectionStore) retrieveCollectionConfigPackage(cc common.Collec
 }
 
 func (c *simpleCollectionStore) retrieveSimpleCollection(cc common.CollectionCriteria) (*SimpleCollection, error) {
+	sc, err := c.retrieveDefinedCollection(cc)
+	if _, notFound := err.(NoSuchCollectionError); notFound {
+		// A collection defined by the chaincode takes precedence over an implicit collection
+		// of the same name, which could be defined before the names were reserved
+		if mspID, isImplicit := MSPIDIfImplicitCollection(cc.Collection); isImplicit {
+			return c.retrieveImplicitCollection(cc, mspID)
+		}
+	}
+	return sc, err
+}
+
+// retrieveDefinedCollection returns the collection defined
+// in the collection configuration of the chaincode
+func (c *simpleCollectionStore) retrieveDefinedCollection(cc common.CollectionCriteria) (*SimpleCollection, error) {
 	collections, err := c.retrieveCollectionConfigPackage(cc)
 	if err != nil {
 		return nil, err
@@ -87,7 +111,7 @@ func (c *simpleCollectionStore) retrieveSimpleCollection(cc common.CollectionCri
 			if cconf.StaticCollectionConfig.Name == cc.Collection {
 				sc := &SimpleCollection{}
 
-				err = sc.Setup(cconf.StaticCollectionConfig, c.s.GetIdentityDeserializer(cc.Channel))
+				err = sc.SetupWithPolicyManager(cconf.StaticCollectionConfig, c.s.GetIdentityDeserializer(cc.Channel), c.s.GetPolicyManager(cc.Channel))
 				if err != nil {
 					return nil, errors.WithMessage(err, fmt.Sprintf("error setting up collection for collection criteria %#v", cc))
 				}
@@ -102,6 +126,29 @@ func (c *simpleCollectionStore) retrieveSimpleCollection(cc common.CollectionCri
 	return nil, NoSuchCollectionError(cc)
 }
 
+// retrieveImplicitCollection returns the implicit collection of the org with the supplied
+// MSP ID, which exists as long as the org has implicit collections in the channel
+func (c *simpleCollectionStore) retrieveImplicitCollection(cc common.CollectionCriteria, mspID string) (*SimpleCollection, error) {
+	orgs, err := c.s.GetImplicitCollectionOrgs(cc.Channel)
+	if err != nil {
+		return nil, errors.WithMessage(err, fmt.Sprintf("could not retrieve the orgs with implicit collections for collection criteria %#v", cc))
+	}
+
+	for _, org := range orgs {
+		if org != mspID {
+			continue
+		}
+		sc := &SimpleCollection{}
+		err = sc.Setup(GenerateImplicitCollectionForOrg(mspID), c.s.GetIdentityDeserializer(cc.Channel))
+		if err != nil {
+			return nil, errors.WithMessage(err, fmt.Sprintf("error setting up implicit collection for collection criteria %#v", cc))
+		}
+		return sc, nil
+	}
+
+	return nil, NoSuchCollectionError(cc)
+}
+
 func (c *simpleCollectionStore) RetrieveCollection(cc common.CollectionCriteria) (Collection, error) {
 	return c.retrieveSimpleCollection(cc)
 }
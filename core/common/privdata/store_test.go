@@ -12,6 +12,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	lm "github.com/hyperledger/fabric/common/mocks/ledger"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/protos/common"
@@ -20,8 +21,10 @@ import (
 )
 
 type mockStoreSupport struct {
-	Qe   *lm.MockQueryExecutor
-	QErr error
+	Qe      *lm.MockQueryExecutor
+	QErr    error
+	Orgs    []string
+	OrgsErr error
 }
 
 func (c *mockStoreSupport) GetQueryExecutorForLedger(cid string) (ledger.QueryExecutor, error) {
@@ -36,6 +39,14 @@ func (c *mockStoreSupport) GetIdentityDeserializer(chainID string) msp.IdentityD
 	return &mockDeserializer{}
 }
 
+func (c *mockStoreSupport) GetImplicitCollectionOrgs(chainID string) ([]string, error) {
+	return c.Orgs, c.OrgsErr
+}
+
+func (c *mockStoreSupport) GetPolicyManager(chainID string) policies.Manager {
+	return nil
+}
+
 func TestCollectionStore(t *testing.T) {
 	wState := make(map[string]map[string][]byte)
 	support := &mockStoreSupport{Qe: &lm.MockQueryExecutor{wState}}
@@ -98,3 +109,52 @@ func TestCollectionStore(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, ccc)
 }
+
+func TestCollectionStoreImplicitCollections(t *testing.T) {
+	wState := make(map[string]map[string][]byte)
+	wState["lscc"] = make(map[string][]byte)
+	support := &mockStoreSupport{Qe: &lm.MockQueryExecutor{wState}, Orgs: []string{"Org1MSP", "Org2MSP"}}
+	cs := NewSimpleCollectionStore(support)
+
+	// implicit collections don't need to be defined by the chaincode
+	ccr := common.CollectionCriteria{Channel: "ch", Namespace: "cc", Collection: ImplicitCollectionNameForOrg("Org2MSP")}
+	c, err := cs.RetrieveCollection(ccr)
+	assert.NoError(t, err)
+	assert.Equal(t, "_implicit_org_Org2MSP", c.CollectionID())
+	assert.Equal(t, []string{"Org2MSP"}, c.MemberOrgs())
+
+	ca, err := cs.RetrieveCollectionAccessPolicy(ccr)
+	assert.NoError(t, err)
+	assert.NotNil(t, ca.AccessFilter())
+	assert.Equal(t, 0, ca.RequiredPeerCount())
+	assert.Equal(t, 1, ca.MaximumPeerCount())
+
+	// but aren't part of the collection configuration of the chaincode
+	_, err = cs.RetrieveCollectionConfigPackage(ccr)
+	assert.IsType(t, NoSuchCollectionError{}, err)
+
+	// the org must be an application org of the channel
+	ccr.Collection = ImplicitCollectionNameForOrg("Org3MSP")
+	_, err = cs.RetrieveCollection(ccr)
+	assert.IsType(t, NoSuchCollectionError{}, err)
+
+	support.OrgsErr = errors.New("channel not found")
+	ccr.Collection = ImplicitCollectionNameForOrg("Org1MSP")
+	_, err = cs.RetrieveCollectionAccessPolicy(ccr)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "channel not found")
+
+	// a collection defined by the chaincode takes precedence over the implicit collection
+	accessPolicy := createCollectionPolicyConfig(cauthdsl.Envelope(cauthdsl.SignedBy(0), [][]byte{[]byte("signer0")}))
+	cc := &common.CollectionConfig{Payload: &common.CollectionConfig_StaticCollectionConfig{&common.StaticCollectionConfig{
+		Name:             ImplicitCollectionNameForOrg("Org1MSP"),
+		MemberOrgsPolicy: accessPolicy,
+		MaximumPeerCount: 3,
+	}}}
+	ccpBytes, err := proto.Marshal(&common.CollectionConfigPackage{[]*common.CollectionConfig{cc}})
+	assert.NoError(t, err)
+	wState["lscc"][support.GetCollectionKVSKey(ccr)] = ccpBytes
+	ca, err = cs.RetrieveCollectionAccessPolicy(ccr)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, ca.MaximumPeerCount())
+}
@@ -95,6 +95,8 @@ type blocksProviderImpl struct {
 
 	mcs api.MessageCryptoService
 
+	ledgerInfo LedgerInfo
+
 	done int32
 
 	wrongStatusThreshold int
@@ -110,13 +112,15 @@ func init() {
 	logger = flogging.MustGetLogger("blocksProvider")
 }
 
-// NewBlocksProvider constructor function to create blocks deliverer instance
-func NewBlocksProvider(chainID string, client streamClient, gossip GossipServiceAdapter, mcs api.MessageCryptoService) BlocksProvider {
+// NewBlocksProvider constructor function to create blocks deliverer instance,
+// the ledger info is used to skip blocks that were already committed
+func NewBlocksProvider(chainID string, client streamClient, gossip GossipServiceAdapter, mcs api.MessageCryptoService, ledgerInfo LedgerInfo) BlocksProvider {
 	return &blocksProviderImpl{
 		chainID:              chainID,
 		client:               client,
 		gossip:               gossip,
 		mcs:                  mcs,
+		ledgerInfo:           ledgerInfo,
 		wrongStatusThreshold: wrongStatusThreshold,
 	}
 }
@@ -167,6 +171,13 @@ func (b *blocksProviderImpl) DeliverBlocks() {
 			statusCounter = 0
 			seqNum := t.Block.Header.Number
 
+			// When several peers of the organization are leaders, the block
+			// might have already been received from one of them via gossip
+			if b.isCommitted(seqNum) {
+				logger.Debugf("[%s] Block [%d] was already committed, skipping it", b.chainID, seqNum)
+				continue
+			}
+
 			marshaledBlock, err := proto.Marshal(t.Block)
 			if err != nil {
 				logger.Errorf("[%s] Error serializing block with sequence number %d, due to %s", b.chainID, seqNum, err)
@@ -234,6 +245,20 @@ func (b *blocksProviderImpl) isEndpointsUpdated(endpoints []string) bool {
 	return false
 }
 
+// isCommitted returns whether the block with the given sequence
+// number was already committed to the ledger
+func (b *blocksProviderImpl) isCommitted(seqNum uint64) bool {
+	if b.ledgerInfo == nil {
+		return false
+	}
+	height, err := b.ledgerInfo.LedgerHeight()
+	if err != nil {
+		logger.Warningf("[%s] Failed obtaining ledger height: %s", b.chainID, err)
+		return false
+	}
+	return seqNum < height
+}
+
 // Check whenever provider is stopped
 func (b *blocksProviderImpl) isDone() bool {
 	return atomic.LoadInt32(&b.done) == 1
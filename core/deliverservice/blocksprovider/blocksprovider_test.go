@@ -71,7 +71,7 @@ func makeTestCase(ledgerHeight uint64, mcs api.MessageCryptoService, shouldSucce
 		gossipServiceAdapter := &mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64)}
 		deliverer := &mocks.MockBlocksDeliverer{Pos: ledgerHeight}
 		deliverer.MockRecv = rcv
-		provider := NewBlocksProvider("***TEST_CHAINID***", deliverer, gossipServiceAdapter, mcs)
+		provider := NewBlocksProvider("***TEST_CHAINID***", deliverer, gossipServiceAdapter, mcs, &mocks.MockLedgerInfo{Height: ledgerHeight})
 		defer provider.Stop()
 		ready := make(chan struct{})
 		go func() {
@@ -443,3 +443,26 @@ func TestBlockVerificationFailure(t *testing.T) {
 	mcs.On("VerifyBlock", mock.Anything).Return(errors.New("Invalid signature"))
 	makeTestCase(uint64(0), mcs, false, rcvr)(t)
 }
+
+func TestBlocksProvider_SkipCommittedBlocks(t *testing.T) {
+	// Scenario: The ordering service delivers blocks that were already
+	// committed, since they were received via gossip from another leader.
+	// Only the blocks that weren't committed should be disseminated.
+	mcs := &mockMCS{}
+	mcs.On("VerifyBlock", mock.Anything).Return(nil)
+	gossipServiceAdapter := &mocks.MockGossipServiceAdapter{GossipBlockDisseminations: make(chan uint64)}
+	deliverer := &mocks.MockBlocksDeliverer{Pos: 5}
+	deliverer.MockRecv = mocks.MockRecv
+	provider := NewBlocksProvider("***TEST_CHAINID***", deliverer, gossipServiceAdapter, mcs, &mocks.MockLedgerInfo{Height: 8})
+	defer provider.Stop()
+	go provider.DeliverBlocks()
+
+	for _, expectedSeqNum := range []uint64{8, 9} {
+		select {
+		case seqNum := <-gossipServiceAdapter.GossipBlockDisseminations:
+			assert.Equal(t, expectedSeqNum, seqNum)
+		case <-time.After(time.Second):
+			assert.Fail(t, "Didn't gossip a block within a timely manner")
+		}
+	}
+}
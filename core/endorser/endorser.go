@@ -12,6 +12,7 @@ import (
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/resourcesconfig"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
@@ -22,6 +23,7 @@ import (
 	pb "github.com/hyperledger/fabric/protos/peer"
 	putils "github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
+	"github.com/spf13/viper"
 	"golang.org/x/net/context"
 )
 
@@ -88,12 +90,17 @@ type Support interface {
 	// GetApplicationConfig returns the configtxapplication.SharedConfig for the channel
 	// and whether the Application config exists
 	GetApplicationConfig(cid string) (channelconfig.Application, bool)
+
+	// ValidateReadSet validates the read set of the public simulation results
+	// against the latest committed state of the specified ledger
+	ValidateReadSet(ledgername string, pubSimResBytes []byte) (*pb.MVCCConflict, error)
 }
 
 // Endorser provides the Endorser service ProcessProposal
 type Endorser struct {
 	distributePrivateData privateDataDistributor
 	s                     Support
+	validateReadSet       bool
 }
 
 // validateResult provides the result of endorseProposal verification
@@ -109,7 +116,8 @@ type validateResult struct {
 func NewEndorserServer(privDist privateDataDistributor, s Support) pb.EndorserServer {
 	e := &Endorser{
 		distributePrivateData: privDist,
-		s: s,
+		s:                     s,
+		validateReadSet:       viper.GetBool("peer.endorser.validateReadSet"),
 	}
 	return e
 }
@@ -376,6 +384,23 @@ func (e *Endorser) endorseProposal(ctx context.Context, chainID string, txid str
 	return pResp, nil
 }
 
+// checkReadSet re-validates the read set of the simulation results against the latest
+// committed state, and returns the conflict that the transaction is bound to fail with.
+// It is a best-effort hint: as blocks cannot be committed during the simulation, it only
+// catches the blocks committed since the simulation ended, and it reports no conflict
+// when the read set cannot be validated
+func (e *Endorser) checkReadSet(chainID string, txid string, simRes []byte) *pb.MVCCConflict {
+	conflict, err := e.s.ValidateReadSet(chainID, simRes)
+	if err != nil {
+		endorserLogger.Warningf("[%s][%s] Failed to validate the read set: %s", chainID, shorttxid(txid), err)
+		return nil
+	}
+	if conflict != nil {
+		endorserLogger.Infof("[%s][%s] The read set conflicts with the committed state: %v", chainID, shorttxid(txid), conflict)
+	}
+	return conflict
+}
+
 //preProcess checks the tx proposal headers, uniqueness and ACL
 func (e *Endorser) preProcess(signedProp *pb.SignedProposal) (*validateResult, error) {
 	vr := &validateResult{}
@@ -447,11 +472,17 @@ func (e *Endorser) preProcess(signedProp *pb.SignedProposal) (*validateResult, e
 }
 
 // ProcessProposal process the Proposal
-func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedProposal) (*pb.ProposalResponse, error) {
+func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedProposal) (resp *pb.ProposalResponse, err error) {
 	addr := util.ExtractRemoteAddress(ctx)
 	endorserLogger.Debug("Entering: Got request from", addr)
 	defer endorserLogger.Debugf("Exit: request from", addr)
 
+	span, ctx := tracing.StartSpan(ctx, "endorser.ProcessProposal")
+	defer func() {
+		span.SetError(err)
+		span.Finish()
+	}()
+
 	//0 -- check and validate
 	vr, err := e.preProcess(signedProp)
 	if err != nil {
@@ -460,6 +491,9 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	}
 
 	prop, hdrExt, chainID, txid := vr.prop, vr.hdrExt, vr.chainID, vr.txid
+	span.SetTag("channel", chainID)
+	span.SetTag("txid", txid)
+	span.SetTag("chaincode", hdrExt.ChaincodeId.GetName())
 
 	// obtaining once the tx simulator for this proposal. This will be nil
 	// for chainless proposals
@@ -488,7 +522,10 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	//       to validate the supplied action before endorsing it
 
 	//1 -- simulate
-	cd, res, simulationResult, ccevent, err := e.simulateProposal(ctx, chainID, txid, signedProp, prop, hdrExt.ChaincodeId, txsim)
+	simulateSpan, simulateCtx := tracing.StartSpan(ctx, "endorser.simulateProposal")
+	cd, res, simulationResult, ccevent, err := e.simulateProposal(simulateCtx, chainID, txid, signedProp, prop, hdrExt.ChaincodeId, txsim)
+	simulateSpan.SetError(err)
+	simulateSpan.Finish()
 	if err != nil {
 		return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
 	}
@@ -519,7 +556,10 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 	if chainID == "" {
 		pResp = &pb.ProposalResponse{Response: res}
 	} else {
-		pResp, err = e.endorseProposal(ctx, chainID, txid, signedProp, prop, res, simulationResult, ccevent, hdrExt.PayloadVisibility, hdrExt.ChaincodeId, txsim, cd)
+		endorseSpan, endorseCtx := tracing.StartSpan(ctx, "endorser.endorseProposal")
+		pResp, err = e.endorseProposal(endorseCtx, chainID, txid, signedProp, prop, res, simulationResult, ccevent, hdrExt.PayloadVisibility, hdrExt.ChaincodeId, txsim, cd)
+		endorseSpan.SetError(err)
+		endorseSpan.Finish()
 		if err != nil {
 			return &pb.ProposalResponse{Response: &pb.Response{Status: 500, Message: err.Error()}}, err
 		}
@@ -528,6 +568,13 @@ func (e *Endorser) ProcessProposal(ctx context.Context, signedProp *pb.SignedPro
 				endorserLogger.Debugf("[%s][%s] endorseProposal() resulted in chaincode %s error for txid: %s", chainID, shorttxid(txid), hdrExt.ChaincodeId, txid)
 				return pResp, &chaincodeError{res.Status, res.Message}
 			}
+			if e.validateReadSet {
+				// the simulator prevents blocks from being committed until it is done,
+				// so it is released for the read set to be checked against the latest ones.
+				// The conflicts found are a best-effort hint, not a guarantee of validity
+				txsim.Done()
+				pResp.MvccConflict = e.checkReadSet(chainID, txid, simulationResult)
+			}
 		}
 	}
 
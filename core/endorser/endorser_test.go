@@ -26,6 +26,7 @@ import (
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -60,7 +61,7 @@ func TestEndorserNilProp(t *testing.T) {
 		return nil
 	}, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
-		GetApplicationConfigRv:     &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 		GetTransactionByIDErr:      errors.New(""),
 		ChaincodeDefinitionRv:      &resourceconfig.MockChaincodeDefinition{EndorsementStr: "ESCC"},
 		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
@@ -76,7 +77,7 @@ func TestEndorserUninvokableSysCC(t *testing.T) {
 		return nil
 	}, &em.MockSupport{
 		GetApplicationConfigBoolRv:       true,
-		GetApplicationConfigRv:           &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		GetApplicationConfigRv:           &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 		GetTransactionByIDErr:            errors.New(""),
 		IsSysCCAndNotInvokableExternalRv: true,
 	})
@@ -92,7 +93,7 @@ func TestEndorserCCInvocationFailed(t *testing.T) {
 		return nil
 	}, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
-		GetApplicationConfigRv:     &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 		GetTransactionByIDErr:      errors.New(""),
 		ChaincodeDefinitionRv:      &resourceconfig.MockChaincodeDefinition{EndorsementStr: "ESCC"},
 		ExecuteResp:                &pb.Response{Status: 1000, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
@@ -110,7 +111,7 @@ func TestEndorserNoCCDef(t *testing.T) {
 		return nil
 	}, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
-		GetApplicationConfigRv:     &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 		GetTransactionByIDErr:      errors.New(""),
 		ChaincodeDefinitionError:   errors.New(""),
 		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
@@ -128,7 +129,7 @@ func TestEndorserBadInstPolicy(t *testing.T) {
 		return nil
 	}, &em.MockSupport{
 		GetApplicationConfigBoolRv:    true,
-		GetApplicationConfigRv:        &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		GetApplicationConfigRv:        &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 		GetTransactionByIDErr:         errors.New(""),
 		CheckInstantiationPolicyError: errors.New(""),
 		ChaincodeDefinitionRv:         &resourceconfig.MockChaincodeDefinition{EndorsementStr: "ESCC"},
@@ -147,7 +148,7 @@ func TestEndorserSysCC(t *testing.T) {
 		return nil
 	}, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
-		GetApplicationConfigRv:     &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 		GetTransactionByIDErr:      errors.New(""),
 		IsSysCCRv:                  true,
 		ChaincodeDefinitionRv:      &resourceconfig.MockChaincodeDefinition{EndorsementStr: "ESCC"},
@@ -166,7 +167,7 @@ func TestEndorserCCInvocationError(t *testing.T) {
 		return nil
 	}, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
-		GetApplicationConfigRv:     &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 		GetTransactionByIDErr:      errors.New(""),
 		ExecuteError:               errors.New(""),
 		ChaincodeDefinitionRv:      &resourceconfig.MockChaincodeDefinition{EndorsementStr: "ESCC"},
@@ -184,7 +185,7 @@ func TestEndorserLSCCBadType(t *testing.T) {
 		return nil
 	}, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
-		GetApplicationConfigRv:     &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 		GetTransactionByIDErr:      errors.New(""),
 		ChaincodeDefinitionRv:      &resourceconfig.MockChaincodeDefinition{EndorsementStr: "ESCC"},
 		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
@@ -210,7 +211,7 @@ func TestEndorserDupTXId(t *testing.T) {
 		return nil
 	}, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
-		GetApplicationConfigRv:     &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 		ChaincodeDefinitionRv:      &resourceconfig.MockChaincodeDefinition{EndorsementStr: "ESCC"},
 		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
 		GetTxSimulatorRv:           &ccprovider.MockTxSim{&ledger.TxSimulationResults{PubSimulationResults: &rwset.TxReadWriteSet{}}},
@@ -227,7 +228,7 @@ func TestEndorserBadACL(t *testing.T) {
 		return nil
 	}, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
-		GetApplicationConfigRv:     &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 		CheckACLErr:                errors.New(""),
 		GetTransactionByIDErr:      errors.New(""),
 		ChaincodeDefinitionRv:      &resourceconfig.MockChaincodeDefinition{EndorsementStr: "ESCC"},
@@ -246,7 +247,7 @@ func TestEndorserGoodPathEmptyChannel(t *testing.T) {
 		return nil
 	}, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
-		GetApplicationConfigRv:     &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 		GetTransactionByIDErr:      errors.New(""),
 		ChaincodeDefinitionRv:      &resourceconfig.MockChaincodeDefinition{EndorsementStr: "ESCC"},
 		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
@@ -264,7 +265,7 @@ func TestEndorserLSCCInitFails(t *testing.T) {
 		return nil
 	}, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
-		GetApplicationConfigRv:     &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 		GetTransactionByIDErr:      errors.New(""),
 		ChaincodeDefinitionRv:      &resourceconfig.MockChaincodeDefinition{EndorsementStr: "ESCC"},
 		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
@@ -294,7 +295,7 @@ func TestEndorserLSCCDeploySysCC(t *testing.T) {
 		return nil
 	}, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
-		GetApplicationConfigRv:     &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 		GetTransactionByIDErr:      errors.New(""),
 		ChaincodeDefinitionRv:      &resourceconfig.MockChaincodeDefinition{EndorsementStr: "ESCC"},
 		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
@@ -325,7 +326,7 @@ func TestEndorserLSCCJava1(t *testing.T) {
 		return nil
 	}, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
-		GetApplicationConfigRv:     &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 		IsJavaRV:                   true,
 		GetTransactionByIDErr:      errors.New(""),
 		ChaincodeDefinitionRv:      &resourceconfig.MockChaincodeDefinition{EndorsementStr: "ESCC"},
@@ -356,7 +357,7 @@ func TestEndorserLSCCJava2(t *testing.T) {
 		return nil
 	}, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
-		GetApplicationConfigRv:     &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 		IsJavaErr:                  errors.New(""),
 		GetTransactionByIDErr:      errors.New(""),
 		ChaincodeDefinitionRv:      &resourceconfig.MockChaincodeDefinition{EndorsementStr: "ESCC"},
@@ -383,7 +384,7 @@ func TestEndorserGoodPathWEvents(t *testing.T) {
 		return nil
 	}, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
-		GetApplicationConfigRv:     &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 		GetTransactionByIDErr:      errors.New(""),
 		ChaincodeDefinitionRv:      &resourceconfig.MockChaincodeDefinition{EndorsementStr: "ESCC"},
 		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
@@ -402,7 +403,7 @@ func TestEndorserBadChannel(t *testing.T) {
 		return nil
 	}, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
-		GetApplicationConfigRv:     &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 		GetTransactionByIDErr:      errors.New(""),
 		ChaincodeDefinitionRv:      &resourceconfig.MockChaincodeDefinition{EndorsementStr: "ESCC"},
 		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
@@ -420,7 +421,7 @@ func TestEndorserGoodPath(t *testing.T) {
 		return nil
 	}, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
-		GetApplicationConfigRv:     &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 		GetTransactionByIDErr:      errors.New(""),
 		ChaincodeDefinitionRv:      &resourceconfig.MockChaincodeDefinition{EndorsementStr: "ESCC"},
 		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
@@ -433,12 +434,42 @@ func TestEndorserGoodPath(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestEndorserValidateReadSet(t *testing.T) {
+	viper.Set("peer.endorser.validateReadSet", true)
+	defer viper.Set("peer.endorser.validateReadSet", false)
+
+	support := &em.MockSupport{
+		GetApplicationConfigBoolRv: true,
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
+		GetTransactionByIDErr:      errors.New(""),
+		ChaincodeDefinitionRv:      &resourceconfig.MockChaincodeDefinition{EndorsementStr: "ESCC"},
+		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
+		GetTxSimulatorRv:           &ccprovider.MockTxSim{&ledger.TxSimulationResults{PubSimulationResults: &rwset.TxReadWriteSet{}}},
+		ValidateReadSetRv:          &pb.MVCCConflict{Namespace: "ccid", Key: "key1"},
+	}
+	es := NewEndorserServer(func(channel string, txID string, privateData *rwset.TxPvtReadWriteSet) error {
+		return nil
+	}, support)
+
+	// The conflict of the read set is returned along with the response
+	resp, err := es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.MVCCConflict{Namespace: "ccid", Key: "key1"}, resp.MvccConflict)
+
+	// A failure to validate the read set doesn't fail the endorsement
+	support.ValidateReadSetRv = nil
+	support.ValidateReadSetErr = errors.New("ledger is closed")
+	resp, err = es.ProcessProposal(context.Background(), getSignedProp("ccid", "0", t))
+	assert.NoError(t, err)
+	assert.Nil(t, resp.MvccConflict)
+}
+
 func TestEndorserLSCC(t *testing.T) {
 	es := NewEndorserServer(func(channel string, txID string, privateData *rwset.TxPvtReadWriteSet) error {
 		return nil
 	}, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
-		GetApplicationConfigRv:     &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 		GetTransactionByIDErr:      errors.New(""),
 		ChaincodeDefinitionRv:      &resourceconfig.MockChaincodeDefinition{EndorsementStr: "ESCC"},
 		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
@@ -464,7 +495,7 @@ func TestSimulateProposal(t *testing.T) {
 		return nil
 	}, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
-		GetApplicationConfigRv:     &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 		GetTransactionByIDErr:      errors.New(""),
 		ChaincodeDefinitionRv:      &resourceconfig.MockChaincodeDefinition{EndorsementStr: "ESCC"},
 		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
@@ -484,7 +515,7 @@ func TestEndorserJavaChecks(t *testing.T) {
 		return nil
 	}, &em.MockSupport{
 		GetApplicationConfigBoolRv: true,
-		GetApplicationConfigRv:     &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		GetApplicationConfigRv:     &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 		GetTransactionByIDErr:      errors.New(""),
 		ChaincodeDefinitionRv:      &resourceconfig.MockChaincodeDefinition{EndorsementStr: "ESCC"},
 		ExecuteResp:                &pb.Response{Status: 200, Payload: utils.MarshalOrPanic(&pb.ProposalResponse{Response: &pb.Response{}})},
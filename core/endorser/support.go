@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package endorser
 
 import (
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/resourcesconfig"
 	"github.com/hyperledger/fabric/core/aclmgmt"
@@ -19,6 +20,7 @@ import (
 	"github.com/hyperledger/fabric/core/peer"
 	"github.com/hyperledger/fabric/core/scc"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
@@ -135,6 +137,20 @@ func (s *SupportImpl) GetApplicationConfig(cid string) (channelconfig.Applicatio
 	return peer.GetSupport().GetApplicationConfig(cid)
 }
 
+// ValidateReadSet validates the read set of the public simulation results
+// against the latest committed state of the specified ledger
+func (s *SupportImpl) ValidateReadSet(ledgername string, pubSimResBytes []byte) (*pb.MVCCConflict, error) {
+	lgr := peer.GetLedger(ledgername)
+	if lgr == nil {
+		return nil, errors.Errorf("channel does not exist: %s", ledgername)
+	}
+	pubSimRes := &rwset.TxReadWriteSet{}
+	if err := proto.Unmarshal(pubSimResBytes, pubSimRes); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the simulation results")
+	}
+	return lgr.ValidateReadSet(pubSimRes)
+}
+
 // shorttxid replicates the chaincode package function to shorten txids.
 // TODO utilize a common shorttxid utility across packages.
 func shorttxid(txid string) string {
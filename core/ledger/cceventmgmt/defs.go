@@ -31,6 +31,10 @@ type ChaincodeLifecycleEventListener interface {
 	HandleChaincodeDeploy(chaincodeDefinition *ChaincodeDefinition, dbArtifactsTar []byte) error
 }
 
+// ChaincodeDeployCallback is invoked with the chaincodes deployed (i.e., instantiated or upgraded)
+// on a channel in a block, after the block is committed
+type ChaincodeDeployCallback func(chainid string, chaincodeDefinitions []*ChaincodeDefinition)
+
 // ChaincodeInfoProvider interface enables event mgr to retrieve chaincode info for a given chaincode
 type ChaincodeInfoProvider interface {
 	// IsChaincodeDeployed returns true if the given chaincode is deployed on the given channel
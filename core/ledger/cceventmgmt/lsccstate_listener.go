@@ -48,3 +48,9 @@ func (listener *KVLedgerLSCCStateListener) HandleStateUpdates(channelName string
 	}
 	return GetMgr().HandleChaincodeDeploy(channelName, chaincodeDefs)
 }
+
+// StateCommitDone is invoked once the state updates handled by `HandleStateUpdates` are committed,
+// and lets the chaincode event manager report the chaincodes deployed in the block
+func (listener *KVLedgerLSCCStateListener) StateCommitDone(channelName string) {
+	GetMgr().ChaincodeDeployDone(channelName)
+}
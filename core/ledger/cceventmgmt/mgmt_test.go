@@ -64,6 +64,38 @@ func TestCCEventMgmt(t *testing.T) {
 	assert.NotContains(t, handler2.eventsRecieved, cc2ExpectedEvent)
 }
 
+func TestDeployCallback(t *testing.T) {
+	cc1Def := &ChaincodeDefinition{Name: "cc1", Version: "v1", Hash: []byte("cc1")}
+	cc2Def := &ChaincodeDefinition{Name: "cc2", Version: "v1", Hash: []byte("cc2")}
+
+	// cc1 is installed, cc2 is not installed
+	mockProvider := newMockProvider()
+	mockProvider.setChaincodeInstalled(cc1Def, []byte("cc1DBArtifacts"))
+	setEventMgrForTest(newMgr(mockProvider))
+	defer clearEventMgrForTest()
+
+	deployed := map[string][]*ChaincodeDefinition{}
+	GetMgr().RegisterDeployCallback(func(chainid string, chaincodeDefinitions []*ChaincodeDefinition) {
+		deployed[chainid] = append(deployed[chainid], chaincodeDefinitions...)
+	})
+
+	// The callback is only invoked once the block is committed,
+	// for installed and not installed chaincodes alike
+	assert.NoError(t, GetMgr().HandleChaincodeDeploy("channel1", []*ChaincodeDefinition{cc1Def, cc2Def}))
+	assert.NotContains(t, deployed, "channel1")
+	GetMgr().ChaincodeDeployDone("channel1")
+	assert.Equal(t, []*ChaincodeDefinition{cc1Def, cc2Def}, deployed["channel1"])
+
+	// The chaincodes are reported once
+	GetMgr().ChaincodeDeployDone("channel1")
+	assert.Len(t, deployed["channel1"], 2)
+
+	// No chaincodes are deployed in the block, hence the callback isn't invoked
+	assert.NoError(t, GetMgr().HandleChaincodeDeploy("channel2", []*ChaincodeDefinition{}))
+	GetMgr().ChaincodeDeployDone("channel2")
+	assert.NotContains(t, deployed, "channel2")
+}
+
 func TestLSCCListener(t *testing.T) {
 	channelName := "testChannel"
 
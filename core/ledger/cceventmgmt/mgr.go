@@ -45,13 +45,19 @@ type Mgr struct {
 	// where we could miss 'deployed AND installed' state. So, we explicitly maintain the chaincodes deplyed
 	// in the last block
 	latestChaincodeDeploys map[string][]*ChaincodeDefinition
+	deployCallbacks        []ChaincodeDeployCallback
+	// pendingDeploys maintains the chaincodes deployed in the block being committed on a ledger,
+	// which are passed to the deploy callbacks once the block is committed
+	pendingDeploys     map[string][]*ChaincodeDefinition
+	pendingDeploysLock sync.Mutex
 }
 
 func newMgr(chaincodeInfoProvider ChaincodeInfoProvider) *Mgr {
 	return &Mgr{
 		infoProvider:           chaincodeInfoProvider,
 		ccLifecycleListeners:   make(map[string]ChaincodeLifecycleEventListener),
-		latestChaincodeDeploys: make(map[string][]*ChaincodeDefinition)}
+		latestChaincodeDeploys: make(map[string][]*ChaincodeDefinition),
+		pendingDeploys:         make(map[string][]*ChaincodeDefinition)}
 }
 
 // Register registers a ChaincodeLifecycleEventListener for given ledgerid
@@ -63,6 +69,15 @@ func (m *Mgr) Register(ledgerid string, l ChaincodeLifecycleEventListener) {
 	m.ccLifecycleListeners[ledgerid] = l
 }
 
+// RegisterDeployCallback registers a ChaincodeDeployCallback that is invoked
+// whenever chaincodes are deployed on any of the ledgers, once the block
+// deploying them is committed
+func (m *Mgr) RegisterDeployCallback(cb ChaincodeDeployCallback) {
+	m.rwlock.Lock()
+	defer m.rwlock.Unlock()
+	m.deployCallbacks = append(m.deployCallbacks, cb)
+}
+
 // HandleChaincodeDeploy is expected to be invoked when a chaincode is deployed via a deploy transaction
 // The `chaincodeDefinitions` parameter contains all the chaincodes deployed in a block
 // We need to store the last received `chaincodeDefinitions` because this function is expected to be invoked
@@ -94,9 +109,32 @@ func (m *Mgr) HandleChaincodeDeploy(chainid string, chaincodeDefinitions []*Chai
 		}
 		logger.Debugf("Channel [%s]: Handled chaincode deploy event for chaincode [%s]", chainid, chaincodeDefinitions)
 	}
+	m.pendingDeploysLock.Lock()
+	m.pendingDeploys[chainid] = chaincodeDefinitions
+	m.pendingDeploysLock.Unlock()
 	return nil
 }
 
+// ChaincodeDeployDone is expected to be invoked once the block whose chaincodes were passed to
+// `HandleChaincodeDeploy` is committed to the ledger. It invokes the deploy callbacks, without holding
+// any lock, so that the chaincodes are only reported as deployed once their deployment is committed
+func (m *Mgr) ChaincodeDeployDone(chainid string) {
+	m.pendingDeploysLock.Lock()
+	chaincodeDefinitions := m.pendingDeploys[chainid]
+	delete(m.pendingDeploys, chainid)
+	m.pendingDeploysLock.Unlock()
+	if len(chaincodeDefinitions) == 0 {
+		return
+	}
+
+	m.rwlock.RLock()
+	deployCallbacks := m.deployCallbacks
+	m.rwlock.RUnlock()
+	for _, cb := range deployCallbacks {
+		cb(chainid, chaincodeDefinitions)
+	}
+}
+
 // HandleChaincodeInstall is expected to gets invoked when a during installation of a chaincode package
 func (m *Mgr) HandleChaincodeInstall(chaincodeDefinition *ChaincodeDefinition, dbArtifacts []byte) error {
 	logger.Debugf("HandleChaincodeInstall() - chaincodeDefinition=%#v", chaincodeDefinition)
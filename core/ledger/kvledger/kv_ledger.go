@@ -7,12 +7,15 @@ SPDX-License-Identifier: Apache-2.0
 package kvledger
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
@@ -22,8 +25,11 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr/lockbasedtxmgr"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/core/ledger/ledgerstorage"
+	lutil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
 	"github.com/hyperledger/fabric/protos/peer"
+	putils "github.com/hyperledger/fabric/protos/utils"
 )
 
 var logger = flogging.MustGetLogger("kvledger")
@@ -200,6 +206,38 @@ func (l *kvLedger) GetTxValidationCodeByTxID(txID string) (peer.TxValidationCode
 	return txValidationCode, err
 }
 
+// GetTxStatusesByBlockRange returns the statuses of the transactions of the blocks in [startNum, endNum]
+func (l *kvLedger) GetTxStatusesByBlockRange(startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	txStatuses, err := l.blockStore.RetrieveTxStatusesByBlockRange(startNum, endNum)
+	l.blockAPIsRWLock.RLock()
+	l.blockAPIsRWLock.RUnlock()
+	return txStatuses, err
+}
+
+// GetBlockByTimestamp returns the block with the earliest timestamp that is not before the given time
+func (l *kvLedger) GetBlockByTimestamp(timestamp time.Time) (*common.Block, error) {
+	block, err := l.blockStore.RetrieveBlockByTimestamp(timestamp)
+	l.blockAPIsRWLock.RLock()
+	l.blockAPIsRWLock.RUnlock()
+	return block, err
+}
+
+// GetTxStatusesByCreator returns the statuses of the transactions created by an identity of the given MSP
+func (l *kvLedger) GetTxStatusesByCreator(mspID string, startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	txStatuses, err := l.blockStore.RetrieveTxStatusesByCreator(mspID, startNum, endNum)
+	l.blockAPIsRWLock.RLock()
+	l.blockAPIsRWLock.RUnlock()
+	return txStatuses, err
+}
+
+// GetTxStatusesByNamespace returns the statuses of the transactions that read or wrote keys of the given namespace
+func (l *kvLedger) GetTxStatusesByNamespace(namespace string, startNum uint64, endNum uint64) (*peer.TransactionStatuses, error) {
+	txStatuses, err := l.blockStore.RetrieveTxStatusesByNamespace(namespace, startNum, endNum)
+	l.blockAPIsRWLock.RLock()
+	l.blockAPIsRWLock.RUnlock()
+	return txStatuses, err
+}
+
 //Prune prunes the blocks/transactions that satisfy the given policy
 func (l *kvLedger) Prune(policy commonledger.PrunePolicy) error {
 	return errors.New("Not yet implemented")
@@ -210,6 +248,12 @@ func (l *kvLedger) NewTxSimulator(txid string) (ledger.TxSimulator, error) {
 	return l.txtmgmt.NewTxSimulator(txid)
 }
 
+// ValidateReadSet validates the read set of the public simulation results
+// of a transaction against the latest committed state
+func (l *kvLedger) ValidateReadSet(pubSimulationResults *rwset.TxReadWriteSet) (*peer.MVCCConflict, error) {
+	return l.txtmgmt.ValidateReadSet(pubSimulationResults)
+}
+
 // NewQueryExecutor gives handle to a query executor.
 // A client can obtain more than one 'QueryExecutor's for parallel execution.
 // Any synchronization should be performed at the implementation level if required
@@ -226,13 +270,24 @@ func (l *kvLedger) NewHistoryQueryExecutor() (ledger.HistoryQueryExecutor, error
 }
 
 // CommitWithPvtData commits the block and the corresponding pvt data in an atomic operation
-func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) error {
-	var err error
+func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) (err error) {
 	block := pvtdataAndBlock.Block
 	blockNo := pvtdataAndBlock.Block.Header.Number
 
+	span, ctx := startCommitSpan(l.ledgerID, block)
+	defer func() {
+		if err == nil {
+			traceTransactions(ctx, block)
+		}
+		span.SetError(err)
+		span.Finish()
+	}()
+
 	logger.Debugf("Channel [%s]: Validating state for block [%d]", l.ledgerID, blockNo)
+	validateSpan, _ := tracing.StartSpan(ctx, "kvledger.ValidateAndPrepare")
 	err = l.txtmgmt.ValidateAndPrepare(pvtdataAndBlock, true)
+	validateSpan.SetError(err)
+	validateSpan.Finish()
 	if err != nil {
 		return err
 	}
@@ -241,15 +296,21 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 
 	l.blockAPIsRWLock.Lock()
 	defer l.blockAPIsRWLock.Unlock()
-	if err = l.blockStore.CommitWithPvtData(pvtdataAndBlock); err != nil {
+	blockStoreSpan, _ := tracing.StartSpan(ctx, "kvledger.CommitBlock")
+	err = l.blockStore.CommitWithPvtData(pvtdataAndBlock)
+	blockStoreSpan.SetError(err)
+	blockStoreSpan.Finish()
+	if err != nil {
 		return err
 	}
 	logger.Infof("Channel [%s]: Committed block [%d] with %d transaction(s)", l.ledgerID, block.Header.Number, len(block.Data.Data))
 
 	logger.Debugf("Channel [%s]: Committing block [%d] transactions to state database", l.ledgerID, blockNo)
+	stateSpan, _ := tracing.StartSpan(ctx, "kvledger.CommitState")
 	if err = l.txtmgmt.Commit(); err != nil {
 		panic(fmt.Errorf(`Error during commit to txmgr:%s`, err))
 	}
+	stateSpan.Finish()
 
 	// History database could be written in parallel with state and/or async as a future optimization
 	if ledgerconfig.IsHistoryDBEnabled() {
@@ -261,6 +322,47 @@ func (l *kvLedger) CommitWithPvtData(pvtdataAndBlock *ledger.BlockAndPvtData) er
 	return nil
 }
 
+// startCommitSpan starts the span timing the commit of a block. A block gathers
+// the transactions of many traces, so the span is the root of a trace of its own
+func startCommitSpan(ledgerID string, block *common.Block) (*tracing.Span, context.Context) {
+	return tracing.StartSpan(context.Background(), "kvledger.CommitWithPvtData",
+		tracing.Tag("channel", ledgerID),
+		tracing.Tag("block_number", block.Header.Number),
+		tracing.Tag("tx_count", len(block.Data.Data)))
+}
+
+// traceTransactions adds a span per transaction of a committed block to the trace of
+// the commit, timing the commit of the block the transaction is part of. The spans are
+// tagged with the ID and the validation code of their transaction, so that the commit
+// of a transaction can be looked up from its ID
+func traceTransactions(ctx context.Context, block *common.Block) {
+	commitSpan := tracing.SpanFromContext(ctx)
+	if commitSpan == nil {
+		return
+	}
+	start := commitSpan.StartTime()
+	var txFlags lutil.TxValidationFlags
+	if block.Metadata != nil && len(block.Metadata.Metadata) > int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		txFlags = lutil.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	}
+	for i := range block.Data.Data {
+		env, err := putils.ExtractEnvelope(block, i)
+		if err != nil {
+			continue
+		}
+		chdr, err := putils.ChannelHeader(env)
+		if err != nil || chdr.TxId == "" {
+			continue
+		}
+		opts := []tracing.StartOption{tracing.StartTime(start), tracing.Tag("txid", chdr.TxId), tracing.Tag("tx_number", i)}
+		if i < len(txFlags) {
+			opts = append(opts, tracing.Tag("validation_code", txFlags.Flag(i)))
+		}
+		span, _ := tracing.StartSpan(ctx, "kvledger.CommitTransaction", opts...)
+		span.Finish()
+	}
+}
+
 // GetPvtDataAndBlockByNum returns the block and the corresponding pvt data.
 // The pvt data is filtered by the list of 'collections' supplied
 func (l *kvLedger) GetPvtDataAndBlockByNum(blockNum uint64, filter ledger.PvtNsCollFilter) (*ledger.BlockAndPvtData, error) {
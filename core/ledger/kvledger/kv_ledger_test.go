@@ -17,16 +17,20 @@ limitations under the License.
 package kvledger
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/common/util"
 	lgr "github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
+	lutil "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
 	"github.com/hyperledger/fabric/protos/peer"
@@ -125,6 +129,19 @@ func TestKVLedgerBlockStorage(t *testing.T) {
 	// get the transaction validation code for this transaction id
 	validCode, _ := ledger.GetTxValidationCodeByTxID(txID2)
 	testutil.AssertEquals(t, validCode, peer.TxValidationCode_VALID)
+
+	// get the statuses of the transactions by block range and by namespace
+	txStatuses, err := ledger.GetTxStatusesByBlockRange(1, 1)
+	testutil.AssertNoError(t, err, "Error upon GetTxStatusesByBlockRange")
+	testutil.AssertEquals(t, txStatuses.Transactions, []*peer.TransactionStatus{{TxId: txID2, BlockNumber: 1}})
+	txStatuses, err = ledger.GetTxStatusesByNamespace("ns1", 0, 2)
+	testutil.AssertNoError(t, err, "Error upon GetTxStatusesByNamespace")
+	testutil.AssertEquals(t, len(txStatuses.Transactions), 2)
+	testutil.AssertEquals(t, txStatuses.Transactions[0].TxId, txID2)
+
+	// the genesis block is the first block after the epoch
+	b0, _ = ledger.GetBlockByTimestamp(time.Unix(0, 0))
+	testutil.AssertEquals(t, b0, gb)
 }
 
 func TestKVLedgerBlockStorageWithPvtdata(t *testing.T) {
@@ -561,3 +578,44 @@ type bcSummary struct {
 	historyKey         string
 	historyVals        []string
 }
+
+func TestTraceTransactions(t *testing.T) {
+	exporter := tracing.NewInMemoryExporter()
+	tracing.SetExporter(exporter)
+	defer tracing.SetExporter(nil)
+
+	block := testutil.ConstructBlockWithTxid(t, 1, []byte("previousHash"),
+		[][]byte{[]byte("simRes1"), []byte("simRes2")}, []string{"txid1", "txid2"}, false)
+	txFlags := lutil.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	txFlags.SetFlag(0, peer.TxValidationCode_VALID)
+	txFlags.SetFlag(1, peer.TxValidationCode_MVCC_READ_CONFLICT)
+
+	span, ctx := startCommitSpan("testLedger", block)
+	traceTransactions(ctx, block)
+	span.Finish()
+
+	spans := exporter.Spans()
+	testutil.AssertEquals(t, len(spans), 3)
+	commit := spans[2]
+	testutil.AssertEquals(t, commit.Name, "kvledger.CommitWithPvtData")
+	testutil.AssertEquals(t, commit.Tags["tx_count"], "2")
+	_, exists := commit.Tags["txids"]
+	testutil.AssertEquals(t, exists, false)
+	for i, expectedCode := range []string{"VALID", "MVCC_READ_CONFLICT"} {
+		testutil.AssertEquals(t, spans[i].Name, "kvledger.CommitTransaction")
+		testutil.AssertEquals(t, spans[i].TraceID, commit.TraceID)
+		testutil.AssertEquals(t, spans[i].ParentID, commit.SpanID)
+		testutil.AssertEquals(t, spans[i].Start, commit.Start)
+		testutil.AssertEquals(t, spans[i].Tags["txid"], fmt.Sprintf("txid%d", i+1))
+		testutil.AssertEquals(t, spans[i].Tags["validation_code"], expectedCode)
+	}
+
+	// without tracing, no span is started
+	tracing.SetExporter(nil)
+	exporter.Reset()
+	span, ctx = startCommitSpan("testLedger", block)
+	traceTransactions(ctx, block)
+	span.Finish()
+	testutil.AssertEquals(t, len(exporter.Spans()), 0)
+	testutil.AssertEquals(t, ctx, context.Background())
+}
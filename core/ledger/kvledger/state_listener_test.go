@@ -63,6 +63,7 @@ func TestStateListener(t *testing.T) {
 	assert.Equal(t, channelid, mockListener.channelName)
 	assert.Contains(t, mockListener.kvWrites, &kvrwset.KVWrite{Key: "key1", Value: []byte("value1")})
 	assert.Contains(t, mockListener.kvWrites, &kvrwset.KVWrite{Key: "key2", Value: []byte("value2")})
+	assert.True(t, mockListener.commitDone)
 	// commit tx2 and this should not cause mock listener to recieve the state changes made by tx2
 	// (because, tx2 should be found as invalid)
 	mockListener.reset()
@@ -73,6 +74,7 @@ func TestStateListener(t *testing.T) {
 	assert.NoError(t, lgr.CommitWithPvtData(&ledger.BlockAndPvtData{Block: blk2}))
 	assert.Equal(t, "", mockListener.channelName)
 	assert.Nil(t, mockListener.kvWrites)
+	assert.False(t, mockListener.commitDone)
 
 	// commit tx3 and thsi should cause mock listener to recieve changes made by tx3
 	mockListener.reset()
@@ -90,6 +92,7 @@ func TestStateListener(t *testing.T) {
 type mockStateListener struct {
 	channelName string
 	kvWrites    []*kvrwset.KVWrite
+	commitDone  bool
 }
 
 func (l *mockStateListener) HandleStateUpdates(channelName string, stateUpdates ledger.StateUpdates) error {
@@ -98,7 +101,12 @@ func (l *mockStateListener) HandleStateUpdates(channelName string, stateUpdates
 	return nil
 }
 
+func (l *mockStateListener) StateCommitDone(channelName string) {
+	l.commitDone = true
+}
+
 func (l *mockStateListener) reset() {
 	l.channelName = ""
 	l.kvWrites = nil
+	l.commitDone = false
 }
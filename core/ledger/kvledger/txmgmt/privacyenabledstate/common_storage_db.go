@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package privacyenabledstate
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 
@@ -76,6 +77,16 @@ func (s *CommonStorageDB) IsBulkOptimizable() bool {
 	return ok
 }
 
+// WithContext implements function in interface ContextBinder. If the wrapped db cannot
+// make its reads within a context, the db itself is returned
+func (s *CommonStorageDB) WithContext(ctx context.Context) DB {
+	binder, ok := s.VersionedDB.(statedb.ContextBinder)
+	if !ok {
+		return s
+	}
+	return &CommonStorageDB{VersionedDB: binder.WithContext(ctx)}
+}
+
 // LoadCommittedVersionsOfPubAndHashedKeys implements corresponding function in interface DB
 func (s *CommonStorageDB) LoadCommittedVersionsOfPubAndHashedKeys(pubKeys []*statedb.CompositeKey,
 	hashedKeys []*HashedCompositeKey) error {
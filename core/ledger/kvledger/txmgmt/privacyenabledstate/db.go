@@ -7,6 +7,8 @@ SPDX-License-Identifier: Apache-2.0
 package privacyenabledstate
 
 import (
+	"context"
+
 	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
@@ -37,6 +39,12 @@ type DB interface {
 	ApplyPrivacyAwareUpdates(updates *UpdateBatch, height *version.Height) error
 }
 
+// ContextBinder is implemented by the DBs which can make their reads within a context
+type ContextBinder interface {
+	// WithContext returns a view of the db whose reads are made within ctx
+	WithContext(ctx context.Context) DB
+}
+
 // HashedCompositeKey encloses Namespace, CollectionName and KeyHash components
 type HashedCompositeKey struct {
 	Namespace      string
@@ -281,7 +281,7 @@ func collPvtRwSetFromProtoMsg(protoMsg *rwset.CollectionPvtReadWriteSet) (*CollP
 
 // NewKVRead helps constructing proto message kvrwset.KVRead
 func NewKVRead(key string, version *version.Height) *kvrwset.KVRead {
-	return &kvrwset.KVRead{Key: key, Version: newProtoVersion(version)}
+	return &kvrwset.KVRead{Key: key, Version: NewProtoVersion(version)}
 }
 
 // NewVersion helps converting proto message kvrwset.Version to version.Height
@@ -292,7 +292,8 @@ func NewVersion(protoVersion *kvrwset.Version) *version.Height {
 	return version.NewHeight(protoVersion.BlockNum, protoVersion.TxNum)
 }
 
-func newProtoVersion(height *version.Height) *kvrwset.Version {
+// NewProtoVersion helps converting version.Height to proto message kvrwset.Version
+func NewProtoVersion(height *version.Height) *kvrwset.Version {
 	if height == nil {
 		return nil
 	}
@@ -304,7 +305,7 @@ func newKVWrite(key string, value []byte) *kvrwset.KVWrite {
 }
 
 func newPvtKVReadHash(key string, version *version.Height) (*kvrwset.KVReadHash, error) {
-	return &kvrwset.KVReadHash{KeyHash: util.ComputeStringHash(key), Version: newProtoVersion(version)}, nil
+	return &kvrwset.KVReadHash{KeyHash: util.ComputeStringHash(key), Version: NewProtoVersion(version)}, nil
 }
 
 func newPvtKVWriteAndHash(key string, value []byte) (*kvrwset.KVWrite, *kvrwset.KVWriteHash, error) {
@@ -199,6 +199,6 @@ func TestVersionConversion(t *testing.T) {
 	testutil.AssertEquals(t, NewVersion(protoVer), internalVer)
 
 	// convert internal to proto
-	testutil.AssertNil(t, newProtoVersion(nil))
-	testutil.AssertEquals(t, newProtoVersion(internalVer), protoVer)
+	testutil.AssertNil(t, NewProtoVersion(nil))
+	testutil.AssertEquals(t, NewProtoVersion(internalVer), protoVer)
 }
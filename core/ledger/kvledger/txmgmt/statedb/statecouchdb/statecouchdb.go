@@ -7,6 +7,7 @@ package statecouchdb
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -231,11 +232,30 @@ func (vdb *VersionedDB) BytesKeySuppoted() bool {
 	return false
 }
 
+// WithContext implements method in statedb.ContextBinder interface
+func (vdb *VersionedDB) WithContext(ctx context.Context) statedb.VersionedDB {
+	return &contextVersionedDB{VersionedDB: vdb, ctx: ctx}
+}
+
+// getNamespaceDBHandleWithContext gets the handle to a named chaincode database
+// whose requests are made within ctx
+func (vdb *VersionedDB) getNamespaceDBHandleWithContext(ctx context.Context, namespace string) (*couchdb.CouchDatabase, error) {
+	db, err := vdb.getNamespaceDBHandle(namespace)
+	if err != nil {
+		return nil, err
+	}
+	return db.WithContext(ctx), nil
+}
+
 // GetState implements method in VersionedDB interface
 func (vdb *VersionedDB) GetState(namespace string, key string) (*statedb.VersionedValue, error) {
+	return vdb.getState(context.Background(), namespace, key)
+}
+
+func (vdb *VersionedDB) getState(ctx context.Context, namespace string, key string) (*statedb.VersionedValue, error) {
 	logger.Debugf("GetState(). ns=%s, key=%s", namespace, key)
 
-	db, err := vdb.getNamespaceDBHandle(namespace)
+	db, err := vdb.getNamespaceDBHandleWithContext(ctx, namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -278,13 +298,17 @@ func (vdb *VersionedDB) GetCachedVersion(namespace string, key string) (*version
 
 // GetVersion implements method in VersionedDB interface
 func (vdb *VersionedDB) GetVersion(namespace string, key string) (*version.Height, error) {
+	return vdb.getVersion(context.Background(), namespace, key)
+}
+
+func (vdb *VersionedDB) getVersion(ctx context.Context, namespace string, key string) (*version.Height, error) {
 
 	returnVersion, keyFound := vdb.GetCachedVersion(namespace, key)
 
 	// If the version was not found in the committed data cache, retrieve it from statedb.
 	if !keyFound {
 
-		db, err := vdb.getNamespaceDBHandle(namespace)
+		db, err := vdb.getNamespaceDBHandleWithContext(ctx, namespace)
 		if err != nil {
 			return nil, err
 		}
@@ -367,10 +391,14 @@ func getValueAndVersionFromDoc(persistedValue []byte, attachments []*couchdb.Att
 
 // GetStateMultipleKeys implements method in VersionedDB interface
 func (vdb *VersionedDB) GetStateMultipleKeys(namespace string, keys []string) ([]*statedb.VersionedValue, error) {
+	return vdb.getStateMultipleKeys(context.Background(), namespace, keys)
+}
+
+func (vdb *VersionedDB) getStateMultipleKeys(ctx context.Context, namespace string, keys []string) ([]*statedb.VersionedValue, error) {
 
 	vals := make([]*statedb.VersionedValue, len(keys))
 	for i, key := range keys {
-		val, err := vdb.GetState(namespace, key)
+		val, err := vdb.getState(ctx, namespace, key)
 		if err != nil {
 			return nil, err
 		}
@@ -384,11 +412,15 @@ func (vdb *VersionedDB) GetStateMultipleKeys(namespace string, keys []string) ([
 // startKey is inclusive
 // endKey is exclusive
 func (vdb *VersionedDB) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (statedb.ResultsIterator, error) {
+	return vdb.getStateRangeScanIterator(context.Background(), namespace, startKey, endKey)
+}
+
+func (vdb *VersionedDB) getStateRangeScanIterator(ctx context.Context, namespace string, startKey string, endKey string) (statedb.ResultsIterator, error) {
 
 	// Get the querylimit from core.yaml
 	queryLimit := ledgerconfig.GetQueryLimit()
 
-	db, err := vdb.getNamespaceDBHandle(namespace)
+	db, err := vdb.getNamespaceDBHandleWithContext(ctx, namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -405,6 +437,10 @@ func (vdb *VersionedDB) GetStateRangeScanIterator(namespace string, startKey str
 
 // ExecuteQuery implements method in VersionedDB interface
 func (vdb *VersionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
+	return vdb.executeQuery(context.Background(), namespace, query)
+}
+
+func (vdb *VersionedDB) executeQuery(ctx context.Context, namespace, query string) (statedb.ResultsIterator, error) {
 
 	// Get the querylimit from core.yaml
 	queryLimit := ledgerconfig.GetQueryLimit()
@@ -417,7 +453,7 @@ func (vdb *VersionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIt
 		return nil, err
 	}
 
-	db, err := vdb.getNamespaceDBHandle(namespace)
+	db, err := vdb.getNamespaceDBHandleWithContext(ctx, namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -1141,6 +1177,38 @@ func splitCompositeKey(compositeKey []byte) (string, string) {
 }
 */
 
+// contextVersionedDB is a view of a VersionedDB whose reads are made within ctx.
+// The results of the scans are fetched up front, so iterating them makes no further requests
+type contextVersionedDB struct {
+	*VersionedDB
+	ctx context.Context
+}
+
+// GetState implements method in VersionedDB interface
+func (vdb *contextVersionedDB) GetState(namespace string, key string) (*statedb.VersionedValue, error) {
+	return vdb.getState(vdb.ctx, namespace, key)
+}
+
+// GetVersion implements method in VersionedDB interface
+func (vdb *contextVersionedDB) GetVersion(namespace string, key string) (*version.Height, error) {
+	return vdb.getVersion(vdb.ctx, namespace, key)
+}
+
+// GetStateMultipleKeys implements method in VersionedDB interface
+func (vdb *contextVersionedDB) GetStateMultipleKeys(namespace string, keys []string) ([]*statedb.VersionedValue, error) {
+	return vdb.getStateMultipleKeys(vdb.ctx, namespace, keys)
+}
+
+// GetStateRangeScanIterator implements method in VersionedDB interface
+func (vdb *contextVersionedDB) GetStateRangeScanIterator(namespace string, startKey string, endKey string) (statedb.ResultsIterator, error) {
+	return vdb.getStateRangeScanIterator(vdb.ctx, namespace, startKey, endKey)
+}
+
+// ExecuteQuery implements method in VersionedDB interface
+func (vdb *contextVersionedDB) ExecuteQuery(namespace, query string) (statedb.ResultsIterator, error) {
+	return vdb.executeQuery(vdb.ctx, namespace, query)
+}
+
 type kvScanner struct {
 	cursor    int
 	namespace string
@@ -6,6 +6,7 @@ SPDX-License-Identifier: Apache-2.0
 package statedb
 
 import (
+	"context"
 	"sort"
 
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
@@ -62,6 +63,13 @@ type BulkOptimizable interface {
 	ClearCachedVersions()
 }
 
+// ContextBinder is implemented by the VersionedDBs which can make their reads
+// within a context, such as the one carrying the span of the transaction being simulated
+type ContextBinder interface {
+	// WithContext returns a view of the db whose reads are made within ctx
+	WithContext(ctx context.Context) VersionedDB
+}
+
 // CompositeKey encloses Namespace and Key components
 type CompositeKey struct {
 	Namespace string
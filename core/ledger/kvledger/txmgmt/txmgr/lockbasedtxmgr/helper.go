@@ -17,12 +17,14 @@ limitations under the License.
 package lockbasedtxmgr
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 
 	commonledger "github.com/hyperledger/fabric/common/ledger"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
@@ -38,13 +40,26 @@ type queryHelper struct {
 	itrs         []*resultsItr
 	err          error
 	doneInvoked  bool
+	ctx          context.Context
+}
+
+// db returns the state db the reads are made from. If a context is set and the
+// state db supports it, the reads are made within that context
+func (h *queryHelper) db() privacyenabledstate.DB {
+	if h.ctx == nil {
+		return h.txmgr.db
+	}
+	if binder, ok := h.txmgr.db.(privacyenabledstate.ContextBinder); ok {
+		return binder.WithContext(h.ctx)
+	}
+	return h.txmgr.db
 }
 
 func (h *queryHelper) getState(ns string, key string) ([]byte, error) {
 	if err := h.checkDone(); err != nil {
 		return nil, err
 	}
-	versionedValue, err := h.txmgr.db.GetState(ns, key)
+	versionedValue, err := h.db().GetState(ns, key)
 	if err != nil {
 		return nil, err
 	}
@@ -59,7 +74,7 @@ func (h *queryHelper) getStateMultipleKeys(namespace string, keys []string) ([][
 	if err := h.checkDone(); err != nil {
 		return nil, err
 	}
-	versionedValues, err := h.txmgr.db.GetStateMultipleKeys(namespace, keys)
+	versionedValues, err := h.db().GetStateMultipleKeys(namespace, keys)
 	if err != nil {
 		return nil, nil
 	}
@@ -78,7 +93,7 @@ func (h *queryHelper) getStateRangeScanIterator(namespace string, startKey strin
 	if err := h.checkDone(); err != nil {
 		return nil, err
 	}
-	itr, err := newResultsItr(namespace, startKey, endKey, h.txmgr.db, h.rwsetBuilder,
+	itr, err := newResultsItr(namespace, startKey, endKey, h.db(), h.rwsetBuilder,
 		ledgerconfig.IsQueryReadsHashingEnabled(), ledgerconfig.GetMaxDegreeQueryReadsHashing())
 	if err != nil {
 		return nil, err
@@ -91,7 +106,7 @@ func (h *queryHelper) executeQuery(namespace, query string) (commonledger.Result
 	if err := h.checkDone(); err != nil {
 		return nil, err
 	}
-	dbItr, err := h.txmgr.db.ExecuteQuery(namespace, query)
+	dbItr, err := h.db().ExecuteQuery(namespace, query)
 	if err != nil {
 		return nil, err
 	}
@@ -107,14 +122,14 @@ func (h *queryHelper) getPrivateData(ns, coll, key string) ([]byte, error) {
 	var hashVersion *version.Height
 	var versionedValue *statedb.VersionedValue
 
-	if versionedValue, err = h.txmgr.db.GetPrivateData(ns, coll, key); err != nil {
+	if versionedValue, err = h.db().GetPrivateData(ns, coll, key); err != nil {
 		return nil, err
 	}
 
 	val, ver := decomposeVersionedValue(versionedValue)
 
 	keyHash := util.ComputeStringHash(key)
-	if hashVersion, err = h.txmgr.db.GetKeyHashVersion(ns, coll, keyHash); err != nil {
+	if hashVersion, err = h.db().GetKeyHashVersion(ns, coll, keyHash); err != nil {
 		return nil, err
 	}
 	if !version.AreSame(hashVersion, ver) {
@@ -128,11 +143,27 @@ func (h *queryHelper) getPrivateData(ns, coll, key string) ([]byte, error) {
 	return val, nil
 }
 
+func (h *queryHelper) getPrivateDataValueHash(ns, coll, key string) ([]byte, error) {
+	if err := h.checkDone(); err != nil {
+		return nil, err
+	}
+	keyHash := util.ComputeStringHash(key)
+	versionedValueHash, err := h.db().GetValueHash(ns, coll, keyHash)
+	if err != nil {
+		return nil, err
+	}
+	valueHash, ver := decomposeVersionedValue(versionedValueHash)
+	if h.rwsetBuilder != nil {
+		h.rwsetBuilder.AddToHashedReadSet(ns, coll, key, ver)
+	}
+	return valueHash, nil
+}
+
 func (h *queryHelper) getPrivateDataMultipleKeys(ns, coll string, keys []string) ([][]byte, error) {
 	if err := h.checkDone(); err != nil {
 		return nil, err
 	}
-	versionedValues, err := h.txmgr.db.GetPrivateDataMultipleKeys(ns, coll, keys)
+	versionedValues, err := h.db().GetPrivateDataMultipleKeys(ns, coll, keys)
 	if err != nil {
 		return nil, nil
 	}
@@ -151,7 +182,7 @@ func (h *queryHelper) getPrivateDataRangeScanIterator(namespace, collection, sta
 	if err := h.checkDone(); err != nil {
 		return nil, err
 	}
-	dbItr, err := h.txmgr.db.GetPrivateDataRangeScanIterator(namespace, collection, startKey, endKey)
+	dbItr, err := h.db().GetPrivateDataRangeScanIterator(namespace, collection, startKey, endKey)
 	if err != nil {
 		return nil, err
 	}
@@ -162,7 +193,7 @@ func (h *queryHelper) executeQueryOnPrivateData(namespace, collection, query str
 	if err := h.checkDone(); err != nil {
 		return nil, err
 	}
-	dbItr, err := h.txmgr.db.ExecuteQueryOnPrivateData(namespace, collection, query)
+	dbItr, err := h.db().ExecuteQueryOnPrivateData(namespace, collection, query)
 	if err != nil {
 		return nil, err
 	}
@@ -17,6 +17,8 @@ limitations under the License.
 package lockbasedtxmgr
 
 import (
+	"context"
+
 	"github.com/hyperledger/fabric/common/ledger"
 )
 
@@ -60,6 +62,11 @@ func (q *lockBasedQueryExecutor) GetPrivateData(namespace, collection, key strin
 	return q.helper.getPrivateData(namespace, collection, key)
 }
 
+// GetPrivateDataHash implements method in interface `ledger.QueryExecutor`
+func (q *lockBasedQueryExecutor) GetPrivateDataHash(namespace, collection, key string) ([]byte, error) {
+	return q.helper.getPrivateDataValueHash(namespace, collection, key)
+}
+
 // GetPrivateDataMultipleKeys implements method in interface `ledger.QueryExecutor`
 func (q *lockBasedQueryExecutor) GetPrivateDataMultipleKeys(namespace, collection string, keys []string) ([][]byte, error) {
 	return q.helper.getPrivateDataMultipleKeys(namespace, collection, keys)
@@ -75,6 +82,11 @@ func (q *lockBasedQueryExecutor) ExecuteQueryOnPrivateData(namespace, collection
 	return q.helper.executeQueryOnPrivateData(namespace, collection, query)
 }
 
+// SetContext implements method in interface `ledger.ContextSetter`
+func (q *lockBasedQueryExecutor) SetContext(ctx context.Context) {
+	q.helper.ctx = ctx
+}
+
 // Done implements method in interface `ledger.QueryExecutor`
 func (q *lockBasedQueryExecutor) Done() {
 	logger.Debugf("Done with transaction simulation / query execution [%s]", q.txid)
@@ -13,10 +13,13 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/valimpl"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/hyperledger/fabric/protos/peer"
 )
 
 var logger = flogging.MustGetLogger("lockbasedtxmgr")
@@ -30,7 +33,9 @@ type LockBasedTxMgr struct {
 	batch          *privacyenabledstate.UpdateBatch
 	currentBlock   *common.Block
 	stateListeners ledger.StateListeners
-	commitRWLock   sync.RWMutex
+	// invokedListeners are the state listeners which handled the updates of the current block
+	invokedListeners []ledger.StateListener
+	commitRWLock     sync.RWMutex
 }
 
 // NewLockBasedTxMgr constructs a new instance of NewLockBasedTxMgr
@@ -79,7 +84,19 @@ func (txmgr *LockBasedTxMgr) ValidateAndPrepare(blockAndPvtdata *ledger.BlockAnd
 	return txmgr.invokeNamespaceListeners(batch)
 }
 
+// ValidateReadSet implements method in interface `txmgmt.TxMgr`
+func (txmgr *LockBasedTxMgr) ValidateReadSet(pubSimulationResults *rwset.TxReadWriteSet) (*peer.MVCCConflict, error) {
+	txRWSet, err := rwsetutil.TxRwSetFromProtoMsg(pubSimulationResults)
+	if err != nil {
+		return nil, err
+	}
+	txmgr.commitRWLock.RLock()
+	defer txmgr.commitRWLock.RUnlock()
+	return txmgr.validator.ValidateReadSet(txRWSet)
+}
+
 func (txmgr *LockBasedTxMgr) invokeNamespaceListeners(batch *privacyenabledstate.UpdateBatch) error {
+	txmgr.invokedListeners = nil
 	namespaces := batch.PubUpdates.GetUpdatedNamespaces()
 	for _, namespace := range namespaces {
 		listener := txmgr.stateListeners[namespace]
@@ -95,10 +112,20 @@ func (txmgr *LockBasedTxMgr) invokeNamespaceListeners(batch *privacyenabledstate
 		if err := listener.HandleStateUpdates(txmgr.ledgerid, kvwrites); err != nil {
 			return err
 		}
+		txmgr.invokedListeners = append(txmgr.invokedListeners, listener)
 	}
 	return nil
 }
 
+// updateStateListeners notifies the state listeners which handled the updates of the
+// current block that the block is committed
+func (txmgr *LockBasedTxMgr) updateStateListeners() {
+	for _, listener := range txmgr.invokedListeners {
+		listener.StateCommitDone(txmgr.ledgerid)
+	}
+	txmgr.invokedListeners = nil
+}
+
 // Shutdown implements method in interface `txmgmt.TxMgr`
 func (txmgr *LockBasedTxMgr) Shutdown() {
 	txmgr.db.Close()
@@ -112,6 +139,18 @@ func (txmgr *LockBasedTxMgr) Commit() error {
 	defer txmgr.clearCache()
 
 	logger.Debugf("Committing updates to state database")
+	if err := txmgr.commitUpdates(); err != nil {
+		return err
+	}
+	logger.Debugf("Updates committed to state database")
+
+	// The state listeners are notified outside of the commit lock,
+	// so that they can query the state committed with the block
+	txmgr.updateStateListeners()
+	return nil
+}
+
+func (txmgr *LockBasedTxMgr) commitUpdates() error {
 	txmgr.commitRWLock.Lock()
 	defer txmgr.commitRWLock.Unlock()
 	logger.Debugf("Write lock acquired for committing updates to state database")
@@ -119,18 +158,14 @@ func (txmgr *LockBasedTxMgr) Commit() error {
 		panic("validateAndPrepare() method should have been called before calling commit()")
 	}
 	defer func() { txmgr.batch = nil }()
-	if err := txmgr.db.ApplyPrivacyAwareUpdates(txmgr.batch,
-		version.NewHeight(txmgr.currentBlock.Header.Number, uint64(len(txmgr.currentBlock.Data.Data)-1))); err != nil {
-		return err
-	}
-	logger.Debugf("Updates committed to state database")
-
-	return nil
+	return txmgr.db.ApplyPrivacyAwareUpdates(txmgr.batch,
+		version.NewHeight(txmgr.currentBlock.Header.Number, uint64(len(txmgr.currentBlock.Data.Data)-1)))
 }
 
 // Rollback implements method in interface `txmgmt.TxMgr`
 func (txmgr *LockBasedTxMgr) Rollback() {
 	txmgr.batch = nil
+	txmgr.invokedListeners = nil
 	// If statedb implementation needed bulk read optimization, cache might have been populated by
 	// ValidateAndPrepareBatch(). As the block commit is rollbacked, populated cache needs to
 	// be cleared now.
@@ -15,11 +15,13 @@ import (
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/txmgr"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
 	"github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -689,6 +691,43 @@ func TestTxSimulatorMissingPvtdata(t *testing.T) {
 	testutil.AssertNil(t, val)
 }
 
+func TestTxSimulatorPvtdataHash(t *testing.T) {
+	testEnv := testEnvs[0]
+	testEnv.init(t, "TestTxSimulatorPvtdataHash")
+	defer testEnv.cleanup()
+
+	// The peer holds only the hashes of the private data of coll1, as it is not a member of the collection
+	db := testEnv.getVDB()
+	updateBatch := privacyenabledstate.NewUpdateBatch()
+	updateBatch.HashUpdates.Put("ns1", "coll1", util.ComputeStringHash("key1"), util.ComputeStringHash("value1"), version.NewHeight(1, 1))
+	db.ApplyPrivacyAwareUpdates(updateBatch, version.NewHeight(1, 1))
+
+	txMgr := testEnv.getTxMgr()
+	simulator, _ := txMgr.NewTxSimulator("testTxid1")
+	valueHash, err := simulator.GetPrivateDataHash("ns1", "coll1", "key1")
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, valueHash, util.ComputeStringHash("value1"))
+	valueHash, err = simulator.GetPrivateDataHash("ns1", "coll1", "key2")
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertNil(t, valueHash)
+	_, err = simulator.GetPrivateData("ns1", "coll1", "key1")
+	_, ok := err.(*txmgr.ErrPvtdataNotAvailable)
+	testutil.AssertEquals(t, ok, true)
+	simulator.Done()
+
+	// The reads of the hashes are recorded as hashed reads, for the mvcc validation
+	simRes, err := simulator.GetTxSimulationResults()
+	testutil.AssertNoError(t, err, "")
+	txRWSet, err := rwsetutil.TxRwSetFromProtoMsg(simRes.PubSimulationResults)
+	testutil.AssertNoError(t, err, "")
+	hashedReads := txRWSet.NsRwSets[0].CollHashedRwSets[0].HashedRwSet.HashedReads
+	testutil.AssertEquals(t, len(hashedReads), 2)
+	testutil.AssertEquals(t, hashedReads[0].KeyHash, util.ComputeStringHash("key1"))
+	testutil.AssertEquals(t, hashedReads[0].Version, &kvrwset.Version{BlockNum: 1, TxNum: 1})
+	testutil.AssertEquals(t, hashedReads[1].KeyHash, util.ComputeStringHash("key2"))
+	testutil.AssertNil(t, hashedReads[1].Version)
+}
+
 func TestDeleteOnCursor(t *testing.T) {
 	cID := "cid"
 	env := testEnvs[0]
@@ -0,0 +1,168 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statebasedval
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/valinternal"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/peer"
+)
+
+// txDependencies holds, for each transaction of a block, the (sorted) indexes in the block of
+// the preceding transactions that write the keys it reads, or that write keys within the ranges
+// it queries. The validity of a transaction depends only on the validity of these transactions
+type txDependencies [][]int
+
+// computeTxDependencies builds the dependency graph of the transactions of a block from their read-write sets
+func computeTxDependencies(txs []*valinternal.Transaction) txDependencies {
+	pubWriters := make(map[string]map[string][]int)
+	hashedWriters := make(map[privacyenabledstate.HashedCompositeKey][]int)
+	deps := make(txDependencies, len(txs))
+
+	for i, tx := range txs {
+		txDeps := make(map[int]struct{})
+		addDeps := func(writers []int) {
+			for _, writer := range writers {
+				txDeps[writer] = struct{}{}
+			}
+		}
+		for _, nsRWSet := range tx.RWSet.NsRwSets {
+			nsWriters := pubWriters[nsRWSet.NameSpace]
+			for _, kvRead := range nsRWSet.KvRwSet.Reads {
+				addDeps(nsWriters[kvRead.Key])
+			}
+			for _, rqi := range nsRWSet.KvRwSet.RangeQueriesInfo {
+				for key, writers := range nsWriters {
+					if keyInRange(key, rqi) {
+						addDeps(writers)
+					}
+				}
+			}
+			for _, collHashedRWSet := range nsRWSet.CollHashedRwSets {
+				for _, kvReadHash := range collHashedRWSet.HashedRwSet.HashedReads {
+					addDeps(hashedWriters[privacyenabledstate.HashedCompositeKey{
+						Namespace:      nsRWSet.NameSpace,
+						CollectionName: collHashedRWSet.CollectionName,
+						KeyHash:        string(kvReadHash.KeyHash),
+					}])
+				}
+			}
+		}
+		for dep := range txDeps {
+			deps[i] = append(deps[i], dep)
+		}
+		sort.Ints(deps[i])
+		recordWrites(i, tx.RWSet, pubWriters, hashedWriters)
+	}
+	return deps
+}
+
+func recordWrites(txIndex int, txRWSet *rwsetutil.TxRwSet,
+	pubWriters map[string]map[string][]int, hashedWriters map[privacyenabledstate.HashedCompositeKey][]int) {
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		ns := nsRWSet.NameSpace
+		for _, kvWrite := range nsRWSet.KvRwSet.Writes {
+			if pubWriters[ns] == nil {
+				pubWriters[ns] = make(map[string][]int)
+			}
+			pubWriters[ns][kvWrite.Key] = append(pubWriters[ns][kvWrite.Key], txIndex)
+		}
+		for _, collHashedRWSet := range nsRWSet.CollHashedRwSets {
+			for _, hashedWrite := range collHashedRWSet.HashedRwSet.HashedWrites {
+				key := privacyenabledstate.HashedCompositeKey{
+					Namespace:      ns,
+					CollectionName: collHashedRWSet.CollectionName,
+					KeyHash:        string(hashedWrite.KeyHash),
+				}
+				hashedWriters[key] = append(hashedWriters[key], txIndex)
+			}
+		}
+	}
+}
+
+// keyInRange returns whether a key may be part of the results of a range query.
+// The end key is treated as inclusive, as it is when the iterator was not exhausted
+// during simulation, and an empty end key stands for an unbounded range
+func keyInRange(key string, rqi *kvrwset.RangeQueryInfo) bool {
+	return key >= rqi.StartKey && (rqi.EndKey == "" || key <= rqi.EndKey)
+}
+
+// levels groups the transactions so that each transaction only depends on transactions of preceding
+// levels, and hence the transactions of a level can be validated concurrently
+func (deps txDependencies) levels() [][]int {
+	txLevels := make([]int, len(deps))
+	var levels [][]int
+	for i, txDeps := range deps {
+		level := 0
+		for _, dep := range txDeps {
+			if txLevels[dep]+1 > level {
+				level = txLevels[dep] + 1
+			}
+		}
+		txLevels[i] = level
+		if level == len(levels) {
+			levels = append(levels, nil)
+		}
+		levels[level] = append(levels[level], i)
+	}
+	return levels
+}
+
+// validateTxsInParallel performs the mvcc validation of the transactions of a block, validating the
+// transactions that don't depend on each other concurrently. Each transaction is validated against
+// the writes of its valid dependencies, which are the only preceding transactions of the block whose
+// writes are visible to its reads, and hence the results are the same as the ones of the sequential validation
+func (v *Validator) validateTxsInParallel(block *valinternal.Block) error {
+	deps := computeTxDependencies(block.Txs)
+	for _, level := range deps.levels() {
+		if err := v.validateLevel(block, level, deps); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v *Validator) validateLevel(block *valinternal.Block, level []int, deps txDependencies) error {
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	var validationErr error
+	sem := make(chan struct{}, v.parallelism)
+	for _, i := range level {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			updates := valinternal.NewPubAndHashUpdates()
+			for _, dep := range deps[i] {
+				depTx := block.Txs[dep]
+				if depTx.ValidationCode == peer.TxValidationCode_VALID {
+					updates.ApplyWriteSet(depTx.RWSet, version.NewHeight(block.Num, uint64(depTx.IndexInBlock)))
+				}
+			}
+			tx := block.Txs[i]
+			validationCode, conflict, err := v.validateTx(tx.RWSet, updates)
+			if err != nil {
+				lock.Lock()
+				validationErr = err
+				lock.Unlock()
+				return
+			}
+			tx.ValidationCode = validationCode
+			tx.MVCCConflict = conflict
+		}(i)
+	}
+	wg.Wait()
+	return validationErr
+}
@@ -0,0 +1,131 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package statebasedval
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/valinternal"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
+	"github.com/hyperledger/fabric/protos/peer"
+)
+
+func TestComputeTxDependencies(t *testing.T) {
+	// tx0 writes key1 and pvtKey1
+	rwsetBuilder0 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder0.AddToWriteSet("ns1", "key1", []byte("value1"))
+	rwsetBuilder0.AddToPvtAndHashedWriteSet("ns1", "coll1", "pvtKey1", []byte("pvtValue1"))
+	// tx1 reads key1 and writes key2
+	rwsetBuilder1 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder1.AddToReadSet("ns1", "key1", nil)
+	rwsetBuilder1.AddToWriteSet("ns1", "key2", []byte("value2"))
+	// tx2 queries the range that includes key1 and key2
+	rwsetBuilder2 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder2.AddToRangeQuerySet("ns1", &kvrwset.RangeQueryInfo{StartKey: "key0", EndKey: "key2", ItrExhausted: true})
+	// tx3 reads a key written by no preceding transaction, and key1 in another namespace
+	rwsetBuilder3 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder3.AddToReadSet("ns1", "key9", nil)
+	rwsetBuilder3.AddToReadSet("ns2", "key1", nil)
+	// tx4 reads pvtKey1
+	rwsetBuilder4 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder4.AddToHashedReadSet("ns1", "coll1", "pvtKey1", nil)
+	// tx5 queries an unbounded range that only includes key2
+	rwsetBuilder5 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder5.AddToRangeQuerySet("ns1", &kvrwset.RangeQueryInfo{StartKey: "key11", ItrExhausted: true})
+
+	var txs []*valinternal.Transaction
+	for i, txRWSet := range getTestPubSimulationRWSet(t, rwsetBuilder0, rwsetBuilder1, rwsetBuilder2, rwsetBuilder3, rwsetBuilder4, rwsetBuilder5) {
+		txs = append(txs, &valinternal.Transaction{IndexInBlock: i, RWSet: txRWSet})
+	}
+	deps := computeTxDependencies(txs)
+	testutil.AssertEquals(t, deps, txDependencies{nil, {0}, {0, 1}, nil, {0}, {1}})
+	testutil.AssertEquals(t, deps.levels(), [][]int{{0, 3}, {1, 4}, {2, 5}})
+}
+
+func TestParallelValidationMatchesSequentialValidation(t *testing.T) {
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	//populate db with initial data
+	numKeys := 10
+	batch := privacyenabledstate.NewUpdateBatch()
+	for i := 0; i < numKeys; i++ {
+		batch.PubUpdates.Put("ns1", fmt.Sprintf("key%d", i), []byte(fmt.Sprintf("value%d", i)), version.NewHeight(1, uint64(i)))
+	}
+	db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, uint64(numKeys-1)))
+
+	// Build transactions that read, query and write random keys, so that
+	// some of them are invalidated by the writes of the preceding ones
+	rand := rand.New(rand.NewSource(0))
+	var rwsetBuilders []*rwsetutil.RWSetBuilder
+	for i := 0; i < 100; i++ {
+		rwsetBuilder := rwsetutil.NewRWSetBuilder()
+		for j := 0; j < 2; j++ {
+			keyNum := rand.Intn(numKeys)
+			rwsetBuilder.AddToReadSet("ns1", fmt.Sprintf("key%d", keyNum), version.NewHeight(1, uint64(keyNum)))
+		}
+		if rand.Intn(4) == 0 {
+			startKeyNum := rand.Intn(numKeys - 2)
+			rqi := &kvrwset.RangeQueryInfo{
+				StartKey:     fmt.Sprintf("key%d", startKeyNum),
+				EndKey:       fmt.Sprintf("key%d", startKeyNum+2),
+				ItrExhausted: true,
+			}
+			rqi.SetRawReads([]*kvrwset.KVRead{
+				rwsetutil.NewKVRead(fmt.Sprintf("key%d", startKeyNum), version.NewHeight(1, uint64(startKeyNum))),
+				rwsetutil.NewKVRead(fmt.Sprintf("key%d", startKeyNum+1), version.NewHeight(1, uint64(startKeyNum+1)))})
+			rwsetBuilder.AddToRangeQuerySet("ns1", rqi)
+		}
+		if rand.Intn(8) == 0 {
+			rwsetBuilder.AddToWriteSet("ns1", fmt.Sprintf("key%d", rand.Intn(numKeys)), nil)
+		} else {
+			rwsetBuilder.AddToWriteSet("ns1", fmt.Sprintf("key%d", rand.Intn(numKeys*4)), []byte(fmt.Sprintf("value_%d", i)))
+		}
+		rwsetBuilders = append(rwsetBuilders, rwsetBuilder)
+	}
+	txRWSets := getTestPubSimulationRWSet(t, rwsetBuilders...)
+
+	validate := func(parallelism int) (*valinternal.Block, *valinternal.PubAndHashUpdates) {
+		validator := NewValidator(db)
+		validator.parallelism = parallelism
+		var txs []*valinternal.Transaction
+		for i, txRWSet := range txRWSets {
+			txs = append(txs, &valinternal.Transaction{
+				ID:             fmt.Sprintf("txid-%d", i),
+				IndexInBlock:   i,
+				ValidationCode: peer.TxValidationCode_VALID,
+				RWSet:          txRWSet,
+			})
+		}
+		block := &valinternal.Block{Num: 2, Txs: txs}
+		updates, err := validator.ValidateAndPrepareBatch(block, true)
+		testutil.AssertNoError(t, err, "")
+		return block, updates
+	}
+
+	sequentialBlock, sequentialUpdates := validate(1)
+	parallelBlock, parallelUpdates := validate(8)
+	numValid := 0
+	for i, tx := range sequentialBlock.Txs {
+		testutil.AssertEquals(t, parallelBlock.Txs[i].ValidationCode, tx.ValidationCode)
+		testutil.AssertEquals(t, parallelBlock.Txs[i].MVCCConflict, tx.MVCCConflict)
+		if tx.ValidationCode == peer.TxValidationCode_VALID {
+			numValid++
+		}
+	}
+	// Make sure that the transactions do conflict with each other
+	testutil.AssertNotEquals(t, numValid, 0)
+	testutil.AssertNotEquals(t, numValid, len(sequentialBlock.Txs))
+	testutil.AssertEquals(t, parallelUpdates.PubUpdates.GetUpdates("ns1"), sequentialUpdates.PubUpdates.GetUpdates("ns1"))
+}
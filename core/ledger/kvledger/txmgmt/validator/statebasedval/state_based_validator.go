@@ -12,6 +12,7 @@ import (
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/statedb"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/valinternal"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/version"
+	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	"github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 	"github.com/hyperledger/fabric/protos/peer"
 )
@@ -21,12 +22,13 @@ var logger = flogging.MustGetLogger("statebasedval")
 // Validator validates a tx against the latest committed state
 // and preceding valid transactions with in the same block
 type Validator struct {
-	db privacyenabledstate.DB
+	db          privacyenabledstate.DB
+	parallelism int
 }
 
 // NewValidator constructs StateValidator
 func NewValidator(db privacyenabledstate.DB) *Validator {
-	return &Validator{db}
+	return &Validator{db: db, parallelism: ledgerconfig.GetValidationParallelism()}
 }
 
 // preLoadCommittedVersionOfRSet loads committed version of all keys in each
@@ -97,94 +99,119 @@ func (v *Validator) ValidateAndPrepareBatch(block *valinternal.Block, doMVCCVali
 		}
 	}
 
+	// The transactions that don't depend on each other are validated concurrently upfront,
+	// and the updates are then prepared in the order of the transactions in the block
+	parallel := doMVCCValidation && v.parallelism > 1 && len(block.Txs) > 1
+	if parallel {
+		if err := v.validateTxsInParallel(block); err != nil {
+			return nil, err
+		}
+	}
+
 	updates := valinternal.NewPubAndHashUpdates()
 	for _, tx := range block.Txs {
-		var validationCode peer.TxValidationCode
-		var err error
-		if validationCode, err = v.validateEndorserTX(tx.RWSet, doMVCCValidation, updates); err != nil {
-			return nil, err
+		if !parallel {
+			validationCode, conflict, err := v.validateEndorserTX(tx.RWSet, doMVCCValidation, updates)
+			if err != nil {
+				return nil, err
+			}
+			tx.ValidationCode = validationCode
+			tx.MVCCConflict = conflict
 		}
 
-		tx.ValidationCode = validationCode
-		if validationCode == peer.TxValidationCode_VALID {
+		if tx.ValidationCode == peer.TxValidationCode_VALID {
 			logger.Debugf("Block [%d] Transaction index [%d] TxId [%s] marked as valid by state validator", block.Num, tx.IndexInBlock, tx.ID)
 			committingTxHeight := version.NewHeight(block.Num, uint64(tx.IndexInBlock))
 			updates.ApplyWriteSet(tx.RWSet, committingTxHeight)
 		} else {
 			logger.Warningf("Block [%d] Transaction index [%d] TxId [%s] marked as invalid by state validator. Reason code [%s]",
-				block.Num, tx.IndexInBlock, tx.ID, validationCode.String())
+				block.Num, tx.IndexInBlock, tx.ID, tx.ValidationCode.String())
 		}
 	}
 	return updates, nil
 }
 
+// ValidateReadSet implements method in Validator interface
+func (v *Validator) ValidateReadSet(txRWSet *rwsetutil.TxRwSet) (*peer.MVCCConflict, error) {
+	_, conflict, err := v.validateTx(txRWSet, valinternal.NewPubAndHashUpdates())
+	return conflict, err
+}
+
 // validateEndorserTX validates endorser transaction
 func (v *Validator) validateEndorserTX(
 	txRWSet *rwsetutil.TxRwSet,
 	doMVCCValidation bool,
-	updates *valinternal.PubAndHashUpdates) (peer.TxValidationCode, error) {
+	updates *valinternal.PubAndHashUpdates) (peer.TxValidationCode, *peer.MVCCConflict, error) {
 
 	var validationCode = peer.TxValidationCode_VALID
+	var conflict *peer.MVCCConflict
 	var err error
 	//mvccvalidation, may invalidate transaction
 	if doMVCCValidation {
-		validationCode, err = v.validateTx(txRWSet, updates)
+		validationCode, conflict, err = v.validateTx(txRWSet, updates)
 	}
-	return validationCode, err
+	return validationCode, conflict, err
 }
 
-func (v *Validator) validateTx(txRWSet *rwsetutil.TxRwSet, updates *valinternal.PubAndHashUpdates) (peer.TxValidationCode, error) {
+// validateTx validates the read set of a transaction and returns, along with the validation code,
+// the conflict that invalidates the transaction if any
+func (v *Validator) validateTx(txRWSet *rwsetutil.TxRwSet, updates *valinternal.PubAndHashUpdates) (peer.TxValidationCode, *peer.MVCCConflict, error) {
 	// Uncomment the following only for local debugging. Don't want to print data in the logs in production
 	//logger.Debugf("validateTx - validating txRWSet: %s", spew.Sdump(txRWSet))
 	for _, nsRWSet := range txRWSet.NsRwSets {
 		ns := nsRWSet.NameSpace
 		// Validate public reads
-		if valid, err := v.validateReadSet(ns, nsRWSet.KvRwSet.Reads, updates.PubUpdates); !valid || err != nil {
+		if conflict, err := v.validateReadSet(ns, nsRWSet.KvRwSet.Reads, updates.PubUpdates); conflict != nil || err != nil {
 			if err != nil {
-				return peer.TxValidationCode(-1), err
+				return peer.TxValidationCode(-1), nil, err
 			}
-			return peer.TxValidationCode_MVCC_READ_CONFLICT, nil
+			return peer.TxValidationCode_MVCC_READ_CONFLICT, conflict, nil
 		}
 		// Validate range queries for phantom items
-		if valid, err := v.validateRangeQueries(ns, nsRWSet.KvRwSet.RangeQueriesInfo, updates.PubUpdates); !valid || err != nil {
+		if conflict, err := v.validateRangeQueries(ns, nsRWSet.KvRwSet.RangeQueriesInfo, updates.PubUpdates); conflict != nil || err != nil {
 			if err != nil {
-				return peer.TxValidationCode(-1), err
+				return peer.TxValidationCode(-1), nil, err
 			}
-			return peer.TxValidationCode_PHANTOM_READ_CONFLICT, nil
+			return peer.TxValidationCode_PHANTOM_READ_CONFLICT, conflict, nil
 		}
 		// Validate hashes for private reads
-		if valid, err := v.validateNsHashedReadSets(ns, nsRWSet.CollHashedRwSets, updates.HashUpdates); !valid || err != nil {
+		if conflict, err := v.validateNsHashedReadSets(ns, nsRWSet.CollHashedRwSets, updates.HashUpdates); conflict != nil || err != nil {
 			if err != nil {
-				return peer.TxValidationCode(-1), err
+				return peer.TxValidationCode(-1), nil, err
 			}
-			return peer.TxValidationCode_MVCC_READ_CONFLICT, nil
+			return peer.TxValidationCode_MVCC_READ_CONFLICT, conflict, nil
 		}
 	}
-	return peer.TxValidationCode_VALID, nil
+	return peer.TxValidationCode_VALID, nil, nil
 }
 
 ////////////////////////////////////////////////////////////////////////////////
 /////                 Validation of public read-set
 ////////////////////////////////////////////////////////////////////////////////
-func (v *Validator) validateReadSet(ns string, kvReads []*kvrwset.KVRead, updates *privacyenabledstate.PubUpdateBatch) (bool, error) {
+func (v *Validator) validateReadSet(ns string, kvReads []*kvrwset.KVRead, updates *privacyenabledstate.PubUpdateBatch) (*peer.MVCCConflict, error) {
 	for _, kvRead := range kvReads {
-		if valid, err := v.validateKVRead(ns, kvRead, updates); !valid || err != nil {
-			return valid, err
+		if conflict, err := v.validateKVRead(ns, kvRead, updates); conflict != nil || err != nil {
+			return conflict, err
 		}
 	}
-	return true, nil
+	return nil, nil
 }
 
 // validateKVRead performs mvcc check for a key read during transaction simulation.
 // i.e., it checks whether a key/version combination is already updated in the statedb (by an already committed block)
 // or in the updates (by a preceding valid transaction in the current block)
-func (v *Validator) validateKVRead(ns string, kvRead *kvrwset.KVRead, updates *privacyenabledstate.PubUpdateBatch) (bool, error) {
+func (v *Validator) validateKVRead(ns string, kvRead *kvrwset.KVRead, updates *privacyenabledstate.PubUpdateBatch) (*peer.MVCCConflict, error) {
 	if updates.Exists(ns, kvRead.Key) {
-		return false, nil
+		return &peer.MVCCConflict{
+			Namespace:        ns,
+			Key:              kvRead.Key,
+			ReadVersion:      kvRead.Version,
+			CommittedVersion: rwsetutil.NewProtoVersion(updates.Get(ns, kvRead.Key).Version),
+		}, nil
 	}
 	committedVersion, err := v.db.GetVersion(ns, kvRead.Key)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	logger.Debugf("Comparing versions for key [%s]: committed version=%#v and read version=%#v",
@@ -192,21 +219,34 @@ func (v *Validator) validateKVRead(ns string, kvRead *kvrwset.KVRead, updates *p
 	if !version.AreSame(committedVersion, rwsetutil.NewVersion(kvRead.Version)) {
 		logger.Debugf("Version mismatch for key [%s:%s]. Committed version = [%#v], Version in readSet [%#v]",
 			ns, kvRead.Key, committedVersion, kvRead.Version)
-		return false, nil
+		return &peer.MVCCConflict{
+			Namespace:        ns,
+			Key:              kvRead.Key,
+			ReadVersion:      kvRead.Version,
+			CommittedVersion: rwsetutil.NewProtoVersion(committedVersion),
+		}, nil
 	}
-	return true, nil
+	return nil, nil
 }
 
 ////////////////////////////////////////////////////////////////////////////////
 /////                 Validation of range queries
 ////////////////////////////////////////////////////////////////////////////////
-func (v *Validator) validateRangeQueries(ns string, rangeQueriesInfo []*kvrwset.RangeQueryInfo, updates *privacyenabledstate.PubUpdateBatch) (bool, error) {
+func (v *Validator) validateRangeQueries(ns string, rangeQueriesInfo []*kvrwset.RangeQueryInfo, updates *privacyenabledstate.PubUpdateBatch) (*peer.MVCCConflict, error) {
 	for _, rqi := range rangeQueriesInfo {
-		if valid, err := v.validateRangeQuery(ns, rqi, updates); !valid || err != nil {
-			return valid, err
+		valid, err := v.validateRangeQuery(ns, rqi, updates)
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			return &peer.MVCCConflict{
+				Namespace:     ns,
+				RangeStartKey: rqi.StartKey,
+				RangeEndKey:   rqi.EndKey,
+			}, nil
 		}
 	}
-	return true, nil
+	return nil, nil
 }
 
 // validateRangeQuery performs a phantom read check i.e., it
@@ -243,42 +283,54 @@ func (v *Validator) validateRangeQuery(ns string, rangeQueryInfo *kvrwset.RangeQ
 /////                 Validation of hashed read-set
 ////////////////////////////////////////////////////////////////////////////////
 func (v *Validator) validateNsHashedReadSets(ns string, collHashedRWSets []*rwsetutil.CollHashedRwSet,
-	updates *privacyenabledstate.HashedUpdateBatch) (bool, error) {
+	updates *privacyenabledstate.HashedUpdateBatch) (*peer.MVCCConflict, error) {
 	for _, collHashedRWSet := range collHashedRWSets {
-		if valid, err := v.validateCollHashedReadSet(ns, collHashedRWSet.CollectionName, collHashedRWSet.HashedRwSet.HashedReads, updates); !valid || err != nil {
-			return valid, err
+		if conflict, err := v.validateCollHashedReadSet(ns, collHashedRWSet.CollectionName, collHashedRWSet.HashedRwSet.HashedReads, updates); conflict != nil || err != nil {
+			return conflict, err
 		}
 	}
-	return true, nil
+	return nil, nil
 }
 
 func (v *Validator) validateCollHashedReadSet(ns, coll string, kvReadHashes []*kvrwset.KVReadHash,
-	updates *privacyenabledstate.HashedUpdateBatch) (bool, error) {
+	updates *privacyenabledstate.HashedUpdateBatch) (*peer.MVCCConflict, error) {
 	for _, kvReadHash := range kvReadHashes {
-		if valid, err := v.validateKVReadHash(ns, coll, kvReadHash, updates); !valid || err != nil {
-			return valid, err
+		if conflict, err := v.validateKVReadHash(ns, coll, kvReadHash, updates); conflict != nil || err != nil {
+			return conflict, err
 		}
 	}
-	return true, nil
+	return nil, nil
 }
 
 // validateKVReadHash performs mvcc check for a hash of a key that is present in the private data space
 // i.e., it checks whether a key/version combination is already updated in the statedb (by an already committed block)
 // or in the updates (by a preceding valid transaction in the current block)
 func (v *Validator) validateKVReadHash(ns, coll string, kvReadHash *kvrwset.KVReadHash,
-	updates *privacyenabledstate.HashedUpdateBatch) (bool, error) {
+	updates *privacyenabledstate.HashedUpdateBatch) (*peer.MVCCConflict, error) {
 	if updates.Contains(ns, coll, kvReadHash.KeyHash) {
-		return false, nil
+		return &peer.MVCCConflict{
+			Namespace:        ns,
+			Collection:       coll,
+			KeyHash:          kvReadHash.KeyHash,
+			ReadVersion:      kvReadHash.Version,
+			CommittedVersion: rwsetutil.NewProtoVersion(updates.Get(ns, coll, string(kvReadHash.KeyHash)).Version),
+		}, nil
 	}
 	committedVersion, err := v.db.GetKeyHashVersion(ns, coll, kvReadHash.KeyHash)
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	if !version.AreSame(committedVersion, rwsetutil.NewVersion(kvReadHash.Version)) {
 		logger.Debugf("Version mismatch for key hash [%s:%s:%#v]. Committed version = [%s], Version in hashedReadSet [%s]",
 			ns, coll, kvReadHash.KeyHash, committedVersion, kvReadHash.Version)
-		return false, nil
+		return &peer.MVCCConflict{
+			Namespace:        ns,
+			Collection:       coll,
+			KeyHash:          kvReadHash.KeyHash,
+			ReadVersion:      kvReadHash.Version,
+			CommittedVersion: rwsetutil.NewProtoVersion(committedVersion),
+		}, nil
 	}
-	return true, nil
+	return nil, nil
 }
@@ -346,29 +346,118 @@ func TestPhantomHashBasedValidation(t *testing.T) {
 	checkValidation(t, validator, getTestPubSimulationRWSet(t, rwsetBuilder2), []int{0})
 }
 
-func checkValidation(t *testing.T, val *Validator, transRWSets []*rwsetutil.TxRwSet, expectedInvalidTxIndexes []int) {
+func TestValidatorMVCCConflicts(t *testing.T) {
+	testDBEnv := privacyenabledstate.LevelDBCommonStorageTestEnv{}
+	testDBEnv.Init(t)
+	defer testDBEnv.Cleanup()
+	db := testDBEnv.GetDBHandle("TestDB")
+
+	//populate db with initial data
+	batch := privacyenabledstate.NewUpdateBatch()
+	batch.PubUpdates.Put("ns1", "key1", []byte("value1"), version.NewHeight(1, 0))
+	batch.PubUpdates.Put("ns1", "key2", []byte("value2"), version.NewHeight(1, 1))
+	batch.HashUpdates.Put("ns1", "coll1", util.ComputeStringHash("pvtKey1"), util.ComputeStringHash("pvtValue1"), version.NewHeight(1, 2))
+	db.ApplyPrivacyAwareUpdates(batch, version.NewHeight(1, 2))
+
+	validator := NewValidator(db)
+
+	// tx0 reads a stale version of key1
+	rwsetBuilder0 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder0.AddToReadSet("ns1", "key1", version.NewHeight(0, 5))
+	// tx1 is valid and updates key2, which invalidates tx2
+	rwsetBuilder1 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder1.AddToReadSet("ns1", "key2", version.NewHeight(1, 1))
+	rwsetBuilder1.AddToWriteSet("ns1", "key2", []byte("value2_new"))
+	rwsetBuilder2 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder2.AddToReadSet("ns1", "key2", version.NewHeight(1, 1))
+	// tx3 misses key2 in its range query
+	rwsetBuilder3 := rwsetutil.NewRWSetBuilder()
+	rqi3 := &kvrwset.RangeQueryInfo{StartKey: "key1", EndKey: "key3", ItrExhausted: true}
+	rqi3.SetRawReads([]*kvrwset.KVRead{rwsetutil.NewKVRead("key1", version.NewHeight(1, 0))})
+	rwsetBuilder3.AddToRangeQuerySet("ns1", rqi3)
+	// tx4 reads pvtKey1 while it doesn't exist yet
+	rwsetBuilder4 := rwsetutil.NewRWSetBuilder()
+	rwsetBuilder4.AddToHashedReadSet("ns1", "coll1", "pvtKey1", nil)
+
 	var trans []*valinternal.Transaction
-	for i, tranRWSet := range transRWSets {
-		tx := &valinternal.Transaction{
+	for i, tranRWSet := range getTestPubSimulationRWSet(t, rwsetBuilder0, rwsetBuilder1, rwsetBuilder2, rwsetBuilder3, rwsetBuilder4) {
+		trans = append(trans, &valinternal.Transaction{
 			ID:             fmt.Sprintf("txid-%d", i),
 			IndexInBlock:   i,
 			ValidationCode: peer.TxValidationCode_VALID,
 			RWSet:          tranRWSet,
-		}
-		trans = append(trans, tx)
+		})
 	}
-	block := &valinternal.Block{Num: 1, Txs: trans}
-	_, err := val.ValidateAndPrepareBatch(block, true)
+	block := &valinternal.Block{Num: 2, Txs: trans}
+	_, err := validator.ValidateAndPrepareBatch(block, true)
 	testutil.AssertNoError(t, err, "")
-	t.Logf("block.Txs[0].ValidationCode = %d", block.Txs[0].ValidationCode)
-	var invalidTxs []int
-	for _, tx := range block.Txs {
-		if tx.ValidationCode != peer.TxValidationCode_VALID {
-			invalidTxs = append(invalidTxs, tx.IndexInBlock)
+
+	testutil.AssertEquals(t, block.Txs[0].ValidationCode, peer.TxValidationCode_MVCC_READ_CONFLICT)
+	testutil.AssertEquals(t, block.Txs[0].MVCCConflict, &peer.MVCCConflict{
+		Namespace:        "ns1",
+		Key:              "key1",
+		ReadVersion:      &kvrwset.Version{BlockNum: 0, TxNum: 5},
+		CommittedVersion: &kvrwset.Version{BlockNum: 1, TxNum: 0},
+	})
+	testutil.AssertEquals(t, block.Txs[1].ValidationCode, peer.TxValidationCode_VALID)
+	testutil.AssertNil(t, block.Txs[1].MVCCConflict)
+	testutil.AssertEquals(t, block.Txs[2].ValidationCode, peer.TxValidationCode_MVCC_READ_CONFLICT)
+	testutil.AssertEquals(t, block.Txs[2].MVCCConflict, &peer.MVCCConflict{
+		Namespace:        "ns1",
+		Key:              "key2",
+		ReadVersion:      &kvrwset.Version{BlockNum: 1, TxNum: 1},
+		CommittedVersion: &kvrwset.Version{BlockNum: 2, TxNum: 1},
+	})
+	testutil.AssertEquals(t, block.Txs[3].ValidationCode, peer.TxValidationCode_PHANTOM_READ_CONFLICT)
+	testutil.AssertEquals(t, block.Txs[3].MVCCConflict, &peer.MVCCConflict{
+		Namespace:     "ns1",
+		RangeStartKey: "key1",
+		RangeEndKey:   "key3",
+	})
+	testutil.AssertEquals(t, block.Txs[4].ValidationCode, peer.TxValidationCode_MVCC_READ_CONFLICT)
+	testutil.AssertEquals(t, block.Txs[4].MVCCConflict, &peer.MVCCConflict{
+		Namespace:        "ns1",
+		Collection:       "coll1",
+		KeyHash:          util.ComputeStringHash("pvtKey1"),
+		CommittedVersion: &kvrwset.Version{BlockNum: 1, TxNum: 2},
+	})
+
+	// The read set of a transaction is validated against the committed state only
+	conflict, err := validator.ValidateReadSet(trans[2].RWSet)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertNil(t, conflict)
+	conflict, err = validator.ValidateReadSet(trans[0].RWSet)
+	testutil.AssertNoError(t, err, "")
+	testutil.AssertEquals(t, conflict, block.Txs[0].MVCCConflict)
+}
+
+func checkValidation(t *testing.T, val *Validator, transRWSets []*rwsetutil.TxRwSet, expectedInvalidTxIndexes []int) {
+	// The results must be the same whether the transactions are validated sequentially or in parallel
+	for _, parallelism := range []int{1, 4} {
+		val.parallelism = parallelism
+		var trans []*valinternal.Transaction
+		for i, tranRWSet := range transRWSets {
+			tx := &valinternal.Transaction{
+				ID:             fmt.Sprintf("txid-%d", i),
+				IndexInBlock:   i,
+				ValidationCode: peer.TxValidationCode_VALID,
+				RWSet:          tranRWSet,
+			}
+			trans = append(trans, tx)
+		}
+		block := &valinternal.Block{Num: 1, Txs: trans}
+		_, err := val.ValidateAndPrepareBatch(block, true)
+		testutil.AssertNoError(t, err, "")
+		t.Logf("block.Txs[0].ValidationCode = %d", block.Txs[0].ValidationCode)
+		var invalidTxs []int
+		for _, tx := range block.Txs {
+			if tx.ValidationCode != peer.TxValidationCode_VALID {
+				invalidTxs = append(invalidTxs, tx.IndexInBlock)
+			}
 		}
+		testutil.AssertEquals(t, len(invalidTxs), len(expectedInvalidTxIndexes))
+		testutil.AssertContainsAll(t, invalidTxs, expectedInvalidTxIndexes)
 	}
-	testutil.AssertEquals(t, len(invalidTxs), len(expectedInvalidTxIndexes))
-	testutil.AssertContainsAll(t, invalidTxs, expectedInvalidTxIndexes)
 }
 
 func buildTestHashResults(t *testing.T, maxDegree int, kvReads []*kvrwset.KVRead) *kvrwset.QueryReadsMerkleSummary {
@@ -19,11 +19,16 @@ package validator
 import (
 	"github.com/hyperledger/fabric/core/ledger"
 	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/privacyenabledstate"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/protos/peer"
 )
 
-// Validator validates the transactions present in a block and returns a batch that should be used to update the state
+// Validator validates the transactions present in a block and returns a batch that should be used to update the state.
+// It also validates the read set of a transaction against the committed state, to tell ahead of its commit whether
+// it is bound to be invalidated
 type Validator interface {
 	ValidateAndPrepareBatch(blockAndPvtdata *ledger.BlockAndPvtData, doMVCCValidation bool) (*privacyenabledstate.UpdateBatch, error)
+	ValidateReadSet(txRWSet *rwsetutil.TxRwSet) (*peer.MVCCConflict, error)
 }
 
 // ErrPvtdataHashMissmatch is to be thrown if the hash of a collection present in the public read-write set
@@ -180,13 +180,33 @@ func processNonEndorserTx(txEnv *common.Envelope, txid string, txType common.Hea
 	return simRes.PubSimulationResults, nil
 }
 
-// postprocessProtoBlock updates the proto block's validation flags (in metadata) by the results of validation process
+// postprocessProtoBlock updates the proto block's validation flags (in metadata) by the results of validation process,
+// and records the MVCC conflicts of the invalid transactions alongside them
 func postprocessProtoBlock(block *common.Block, validatedBlock *valinternal.Block) {
 	txsFilter := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	var conflicts []*peer.TxMVCCConflict
 	for _, tx := range validatedBlock.Txs {
 		txsFilter.SetFlag(tx.IndexInBlock, tx.ValidationCode)
+		if tx.MVCCConflict != nil {
+			conflicts = append(conflicts, &peer.TxMVCCConflict{
+				TxId:           tx.ID,
+				TxNumber:       uint64(tx.IndexInBlock),
+				ValidationCode: tx.ValidationCode,
+				Conflict:       tx.MVCCConflict,
+			})
+		}
 	}
 	block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = txsFilter
+
+	// the blocks created by orderers that predate the MVCC conflicts have no room for them in their metadata
+	for len(block.Metadata.Metadata) <= int(common.BlockMetadataIndex_MVCC_CONFLICTS) {
+		block.Metadata.Metadata = append(block.Metadata.Metadata, []byte{})
+	}
+	// any conflicts that the block carries, as received from another peer, are overwritten
+	block.Metadata.Metadata[common.BlockMetadataIndex_MVCC_CONFLICTS] = []byte{}
+	if len(conflicts) > 0 {
+		block.Metadata.Metadata[common.BlockMetadataIndex_MVCC_CONFLICTS] = utils.MarshalOrPanic(&peer.TxMVCCConflicts{Conflicts: conflicts})
+	}
 }
 
 func addPvtRWSetToPvtUpdateBatch(pvtRWSet *rwsetutil.TxPvtRwSet, pvtUpdateBatch *privacyenabledstate.PvtUpdateBatch, ver *version.Height) {
@@ -12,6 +12,7 @@ import (
 
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/validator/valinternal"
 	lutils "github.com/hyperledger/fabric/core/ledger/util"
 	"github.com/hyperledger/fabric/protos/common"
 	"github.com/hyperledger/fabric/protos/peer"
@@ -89,6 +90,42 @@ func TestPreprocessProtoBlock(t *testing.T) {
 
 }
 
+func TestPostprocessProtoBlock(t *testing.T) {
+	conflict := &peer.MVCCConflict{Namespace: "ns1", Key: "key1"}
+	validatedBlock := &valinternal.Block{
+		Num: 10,
+		Txs: []*valinternal.Transaction{
+			{IndexInBlock: 0, ID: "txid0", ValidationCode: peer.TxValidationCode_VALID},
+			{IndexInBlock: 1, ID: "txid1", ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT, MVCCConflict: conflict},
+		},
+	}
+
+	// the metadata of the block is extended to record the conflicts
+	gb := testutil.ConstructTestBlock(t, 10, 2, 1)
+	gb.Metadata.Metadata = gb.Metadata.Metadata[:common.BlockMetadataIndex_ORDERER+1]
+	gb.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER] = lutils.NewTxValidationFlags(len(gb.Data.Data))
+	postprocessProtoBlock(gb, validatedBlock)
+	txsFilter := lutils.TxValidationFlags(gb.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	assert.True(t, txsFilter.IsValid(0))
+	assert.Equal(t, peer.TxValidationCode_MVCC_READ_CONFLICT, txsFilter.Flag(1))
+	conflicts, err := putils.GetMVCCConflictsFromBlock(gb)
+	assert.NoError(t, err)
+	assert.Equal(t, []*peer.TxMVCCConflict{{
+		TxId:           "txid1",
+		TxNumber:       1,
+		ValidationCode: peer.TxValidationCode_MVCC_READ_CONFLICT,
+		Conflict:       conflict,
+	}}, conflicts.Conflicts)
+
+	// the conflicts that the block carries are overwritten
+	validatedBlock.Txs[1].ValidationCode = peer.TxValidationCode_VALID
+	validatedBlock.Txs[1].MVCCConflict = nil
+	postprocessProtoBlock(gb, validatedBlock)
+	conflicts, err = putils.GetMVCCConflictsFromBlock(gb)
+	assert.NoError(t, err)
+	assert.Empty(t, conflicts.Conflicts)
+}
+
 // from go-logging memory_test.go
 func memoryRecordN(b *logging.MemoryBackend, n int) *logging.Record {
 	node := b.Head()
@@ -17,6 +17,7 @@ import (
 // and returns a batch that should be used to update the state
 type InternalValidator interface {
 	ValidateAndPrepareBatch(block *Block, doMVCCValidation bool) (*PubAndHashUpdates, error)
+	ValidateReadSet(txRWSet *rwsetutil.TxRwSet) (*peer.MVCCConflict, error)
 }
 
 // Block is used to used to hold the information from its proto format to a structure
@@ -33,6 +34,7 @@ type Transaction struct {
 	ID             string
 	RWSet          *rwsetutil.TxRwSet
 	ValidationCode peer.TxValidationCode
+	MVCCConflict   *peer.MVCCConflict
 }
 
 // PubAndHashUpdates encapsulates public and hash updates. The intended use of this to hold the updates
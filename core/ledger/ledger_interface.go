@@ -17,6 +17,9 @@ limitations under the License.
 package ledger
 
 import (
+	"context"
+	"time"
+
 	"github.com/golang/protobuf/proto"
 	commonledger "github.com/hyperledger/fabric/common/ledger"
 	"github.com/hyperledger/fabric/protos/common"
@@ -53,6 +56,23 @@ type PeerLedger interface {
 	GetBlockByTxID(txID string) (*common.Block, error)
 	// GetTxValidationCodeByTxID returns reason code of transaction validation
 	GetTxValidationCodeByTxID(txID string) (peer.TxValidationCode, error)
+	// GetTxStatusesByBlockRange returns the IDs and validation codes of the transactions
+	// of the blocks in the range [startNum, endNum]
+	GetTxStatusesByBlockRange(startNum uint64, endNum uint64) (*peer.TransactionStatuses, error)
+	// GetBlockByTimestamp returns the block with the earliest timestamp that is not before the given time.
+	// The timestamp of a block is the latest timestamp of the first transactions of the block
+	// and of the blocks before it, as the timestamps of transactions are set by their clients
+	GetBlockByTimestamp(timestamp time.Time) (*common.Block, error)
+	// GetTxStatusesByCreator returns the IDs and validation codes of the transactions
+	// of the blocks in the range [startNum, endNum] that were created by an identity of the given MSP
+	GetTxStatusesByCreator(mspID string, startNum uint64, endNum uint64) (*peer.TransactionStatuses, error)
+	// GetTxStatusesByNamespace returns the IDs and validation codes of the transactions
+	// of the blocks in the range [startNum, endNum] that read or wrote keys of the given namespace
+	GetTxStatusesByNamespace(namespace string, startNum uint64, endNum uint64) (*peer.TransactionStatuses, error)
+	// ValidateReadSet validates the read set of the public simulation results of a transaction against
+	// the latest committed state. It returns the conflict that would cause the transaction to be invalidated
+	// if it were committed next, or nil if there is none
+	ValidateReadSet(pubSimulationResults *rwset.TxReadWriteSet) (*peer.MVCCConflict, error)
 	// NewTxSimulator gives handle to a transaction simulator.
 	// A client can obtain more than one 'TxSimulator's for parallel execution.
 	// Any snapshoting/synchronization should be performed at the implementation level if required
@@ -114,6 +134,9 @@ type QueryExecutor interface {
 	ExecuteQuery(namespace, query string) (commonledger.ResultsIterator, error)
 	// GetPrivateData gets the value of a private data item identified by a tuple <namespace, collection, key>
 	GetPrivateData(namespace, collection, key string) ([]byte, error)
+	// GetPrivateDataHash gets the hash of the value of a private data item identified by a tuple <namespace, collection, key>
+	// The hash is available to every peer of the channel, regardless of whether it is a member of the collection
+	GetPrivateDataHash(namespace, collection, key string) ([]byte, error)
 	// GetPrivateDataMultipleKeys gets the values for the multiple private data items in a single call
 	GetPrivateDataMultipleKeys(namespace, collection string, keys []string) ([][]byte, error)
 	// GetPrivateDataRangeScanIterator returns an iterator that contains all the key-values between given key ranges.
@@ -138,6 +161,13 @@ type HistoryQueryExecutor interface {
 	GetHistoryForKey(namespace string, key string) (commonledger.ResultsIterator, error)
 }
 
+// ContextSetter is implemented by the QueryExecutors and TxSimulators which can make
+// their reads within a context, so that the reads are traced as part of the span it carries
+type ContextSetter interface {
+	// SetContext sets the context the subsequent reads are made within
+	SetContext(ctx context.Context)
+}
+
 // TxSimulator simulates a transaction on a consistent snapshot of the 'as recent state as possible'
 // Set* methods are for supporting KV-based data model. ExecuteUpdate method is for supporting a rich datamodel and query support
 type TxSimulator interface {
@@ -277,9 +307,12 @@ func (txSim *TxSimulationResults) ContainsPvtWrites() bool {
 // `github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset.KVWrite`
 // Function `HandleStateUpdates` is expected to be invoked before block is committed and if this
 // function returns an error, the ledger implementation is expected to halt block commit operation
-// and result in a panic
+// and result in a panic.
+// Function `StateCommitDone` is invoked once the block whose state updates were passed to
+// `HandleStateUpdates` is committed
 type StateListener interface {
 	HandleStateUpdates(ledgerID string, stateUpdates StateUpdates) error
+	StateCommitDone(ledgerID string)
 }
 
 // StateUpdates is the generic type to represent the state updates
@@ -45,6 +45,7 @@ const confEnableHistoryDatabase = "ledger.history.enableHistoryDatabase"
 const confMaxBatchSize = "ledger.state.couchDBConfig.maxBatchUpdateSize"
 const confAutoWarmIndexes = "ledger.state.couchDBConfig.autoWarmIndexes"
 const confWarmIndexesAfterNBlocks = "ledger.state.couchDBConfig.warmIndexesAfterNBlocks"
+const confValidationParallelism = "ledger.state.validationParallelism"
 
 // GetRootPath returns the filesystem path.
 // All ledger related contents are expected to be stored under this path
@@ -145,3 +146,14 @@ func GetWarmIndexesAfterNBlocks() int {
 	}
 	return warmAfterNBlocks
 }
+
+//GetValidationParallelism exposes the validationParallelism variable, which is the maximum number
+//of transactions of a block whose read sets are validated concurrently
+func GetValidationParallelism() int {
+	validationParallelism := viper.GetInt(confValidationParallelism)
+	// if validationParallelism was unset, default to 1, i.e. a sequential validation
+	if validationParallelism <= 0 {
+		validationParallelism = 1
+	}
+	return validationParallelism
+}
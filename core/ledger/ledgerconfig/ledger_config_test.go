@@ -164,6 +164,20 @@ func TestGetWarmIndexesAfterNBlocks(t *testing.T) {
 	testutil.AssertEquals(t, updatedValue, 10)
 }
 
+func TestGetValidationParallelismDefault(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defaultValue := GetValidationParallelism()
+	testutil.AssertEquals(t, defaultValue, 1) //test default config is 1
+}
+
+func TestGetValidationParallelism(t *testing.T) {
+	setUpCoreYAMLConfig()
+	defer ledgertestutil.ResetConfigToDefaultValues()
+	viper.Set("ledger.state.validationParallelism", 8)
+	updatedValue := GetValidationParallelism()
+	testutil.AssertEquals(t, updatedValue, 8)
+}
+
 func setUpCoreYAMLConfig() {
 	//call a helper method to load the core.yaml
 	ledgertestutil.SetupCoreYAMLConfig()
@@ -51,6 +51,10 @@ func NewProvider() *Provider {
 		blkstorage.IndexableAttrBlockNumTranNum,
 		blkstorage.IndexableAttrBlockTxID,
 		blkstorage.IndexableAttrTxValidationCode,
+		blkstorage.IndexableAttrBlockNumTxStatus,
+		blkstorage.IndexableAttrBlockTime,
+		blkstorage.IndexableAttrTxCreator,
+		blkstorage.IndexableAttrTxNamespace,
 	}
 	indexConfig := &blkstorage.IndexConfig{AttrsToIndex: attrsToIndex}
 	blockStoreProvider := fsblkstorage.NewProvider(
@@ -94,6 +94,7 @@ func ResetConfigToDefaultValues() {
 	viper.Set("ledger.history.enableHistoryDatabase", false)
 	viper.Set("ledger.state.couchDBConfig.autoWarmIndexes", true)
 	viper.Set("ledger.state.couchDBConfig.warmIndexesAfterNBlocks", 1)
+	viper.Set("ledger.state.validationParallelism", 0)
 	viper.Set("peer.fileSystemPath", "/var/hyperledger/production")
 }
 
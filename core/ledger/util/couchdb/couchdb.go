@@ -19,6 +19,7 @@ package couchdb
 import (
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -38,6 +39,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	logging "github.com/op/go-logging"
 )
@@ -147,6 +149,23 @@ type CouchDatabase struct {
 	CouchInstance    CouchInstance //connection configuration
 	DBName           string
 	IndexWarmCounter int
+	ctx              context.Context //context the requests to the database are made within
+}
+
+//WithContext returns a copy of the database handle whose requests are made within ctx,
+//so that they are traced as part of the span ctx carries
+func (dbclient *CouchDatabase) WithContext(ctx context.Context) *CouchDatabase {
+	db := *dbclient
+	db.ctx = ctx
+	return &db
+}
+
+//context returns the context the requests to the database are made within
+func (dbclient *CouchDatabase) context() context.Context {
+	if dbclient.ctx == nil {
+		return context.Background()
+	}
+	return dbclient.ctx
 }
 
 //DBReturn contains an error reported by CouchDB
@@ -303,7 +322,7 @@ func (dbclient *CouchDatabase) CreateDatabaseIfNotExist() error {
 	maxRetries := dbclient.CouchInstance.conf.MaxRetries
 
 	//process the URL with a PUT, creates the database
-	resp, _, err := dbclient.CouchInstance.handleRequest(http.MethodPut, connectURL.String(), nil, "", "", maxRetries, true)
+	resp, _, err := dbclient.CouchInstance.handleRequest(dbclient.context(), http.MethodPut, connectURL.String(), nil, "", "", maxRetries, true)
 
 	if err != nil {
 
@@ -345,7 +364,7 @@ func (dbclient *CouchDatabase) GetDatabaseInfo() (*DBInfo, *DBReturn, error) {
 	//get the number of retries
 	maxRetries := dbclient.CouchInstance.conf.MaxRetries
 
-	resp, couchDBReturn, err := dbclient.CouchInstance.handleRequest(http.MethodGet, connectURL.String(), nil, "", "", maxRetries, true)
+	resp, couchDBReturn, err := dbclient.CouchInstance.handleRequest(dbclient.context(), http.MethodGet, connectURL.String(), nil, "", "", maxRetries, true)
 	if err != nil {
 		return nil, couchDBReturn, err
 	}
@@ -385,7 +404,7 @@ func (couchInstance *CouchInstance) VerifyCouchConfig() (*ConnectionInfo, *DBRet
 	//get the number of retries for startup
 	maxRetriesOnStartup := couchInstance.conf.MaxRetriesOnStartup
 
-	resp, couchDBReturn, err := couchInstance.handleRequest(http.MethodGet, connectURL.String(), nil,
+	resp, couchDBReturn, err := couchInstance.handleRequest(context.Background(), http.MethodGet, connectURL.String(), nil,
 		couchInstance.conf.Username, couchInstance.conf.Password, maxRetriesOnStartup, true)
 
 	if err != nil {
@@ -434,7 +453,7 @@ func (dbclient *CouchDatabase) DropDatabase() (*DBOperationResponse, error) {
 	//get the number of retries
 	maxRetries := dbclient.CouchInstance.conf.MaxRetries
 
-	resp, _, err := dbclient.CouchInstance.handleRequest(http.MethodDelete, connectURL.String(), nil, "", "", maxRetries, true)
+	resp, _, err := dbclient.CouchInstance.handleRequest(dbclient.context(), http.MethodDelete, connectURL.String(), nil, "", "", maxRetries, true)
 	if err != nil {
 		return nil, err
 	}
@@ -477,7 +496,7 @@ func (dbclient *CouchDatabase) EnsureFullCommit() (*DBOperationResponse, error)
 	//get the number of retries
 	maxRetries := dbclient.CouchInstance.conf.MaxRetries
 
-	resp, _, err := dbclient.CouchInstance.handleRequest(http.MethodPost, connectURL.String(), nil, "", "", maxRetries, true)
+	resp, _, err := dbclient.CouchInstance.handleRequest(dbclient.context(), http.MethodPost, connectURL.String(), nil, "", "", maxRetries, true)
 	if err != nil {
 		logger.Errorf("Failed to invoke _ensure_full_commit Error: %s\n", err.Error())
 		return nil, err
@@ -747,7 +766,7 @@ func (dbclient *CouchDatabase) ReadDoc(id string) (*CouchDoc, string, error) {
 	//get the number of retries
 	maxRetries := dbclient.CouchInstance.conf.MaxRetries
 
-	resp, couchDBReturn, err := dbclient.CouchInstance.handleRequest(http.MethodGet, readURL.String(), nil, "", "", maxRetries, true)
+	resp, couchDBReturn, err := dbclient.CouchInstance.handleRequest(dbclient.context(), http.MethodGet, readURL.String(), nil, "", "", maxRetries, true)
 	if err != nil {
 		if couchDBReturn != nil && couchDBReturn.StatusCode == 404 {
 			logger.Debug("Document not found (404), returning nil value instead of 404 error")
@@ -899,7 +918,7 @@ func (dbclient *CouchDatabase) ReadDocRange(startKey, endKey string, limit, skip
 	//get the number of retries
 	maxRetries := dbclient.CouchInstance.conf.MaxRetries
 
-	resp, _, err := dbclient.CouchInstance.handleRequest(http.MethodGet, rangeURL.String(), nil, "", "", maxRetries, true)
+	resp, _, err := dbclient.CouchInstance.handleRequest(dbclient.context(), http.MethodGet, rangeURL.String(), nil, "", "", maxRetries, true)
 	if err != nil {
 		return nil, err
 	}
@@ -1021,7 +1040,7 @@ func (dbclient *CouchDatabase) QueryDocuments(query string) (*[]QueryResult, err
 	//get the number of retries
 	maxRetries := dbclient.CouchInstance.conf.MaxRetries
 
-	resp, _, err := dbclient.CouchInstance.handleRequest(http.MethodPost, queryURL.String(), []byte(query), "", "", maxRetries, true)
+	resp, _, err := dbclient.CouchInstance.handleRequest(dbclient.context(), http.MethodPost, queryURL.String(), []byte(query), "", "", maxRetries, true)
 	if err != nil {
 		return nil, err
 	}
@@ -1097,7 +1116,7 @@ func (dbclient *CouchDatabase) ListIndex() (*[]IndexResult, error) {
 	//get the number of retries
 	maxRetries := dbclient.CouchInstance.conf.MaxRetries
 
-	resp, _, err := dbclient.CouchInstance.handleRequest(http.MethodGet, indexURL.String(), nil, "", "", maxRetries, true)
+	resp, _, err := dbclient.CouchInstance.handleRequest(dbclient.context(), http.MethodGet, indexURL.String(), nil, "", "", maxRetries, true)
 	if err != nil {
 		return nil, err
 	}
@@ -1161,7 +1180,7 @@ func (dbclient *CouchDatabase) CreateIndex(indexdefinition string) (*CreateIndex
 	//get the number of retries
 	maxRetries := dbclient.CouchInstance.conf.MaxRetries
 
-	resp, _, err := dbclient.CouchInstance.handleRequest(http.MethodPost, indexURL.String(), []byte(indexdefinition), "", "", maxRetries, true)
+	resp, _, err := dbclient.CouchInstance.handleRequest(dbclient.context(), http.MethodPost, indexURL.String(), []byte(indexdefinition), "", "", maxRetries, true)
 	if err != nil {
 		return nil, err
 	}
@@ -1216,7 +1235,7 @@ func (dbclient *CouchDatabase) DeleteIndex(designdoc, indexname string) error {
 	//get the number of retries
 	maxRetries := dbclient.CouchInstance.conf.MaxRetries
 
-	resp, _, err := dbclient.CouchInstance.handleRequest(http.MethodDelete, indexURL.String(), nil, "", "", maxRetries, true)
+	resp, _, err := dbclient.CouchInstance.handleRequest(dbclient.context(), http.MethodDelete, indexURL.String(), nil, "", "", maxRetries, true)
 	if err != nil {
 		return err
 	}
@@ -1249,7 +1268,7 @@ func (dbclient *CouchDatabase) WarmIndex(designdoc, indexname string) error {
 	//get the number of retries
 	maxRetries := dbclient.CouchInstance.conf.MaxRetries
 
-	resp, _, err := dbclient.CouchInstance.handleRequest(http.MethodGet, indexURL.String(), nil, "", "", maxRetries, true)
+	resp, _, err := dbclient.CouchInstance.handleRequest(dbclient.context(), http.MethodGet, indexURL.String(), nil, "", "", maxRetries, true)
 	if err != nil {
 		return err
 	}
@@ -1332,7 +1351,7 @@ func (dbclient *CouchDatabase) BatchRetrieveDocumentMetadata(keys []string) ([]*
 	//get the number of retries
 	maxRetries := dbclient.CouchInstance.conf.MaxRetries
 
-	resp, _, err := dbclient.CouchInstance.handleRequest(http.MethodPost, batchRetrieveURL.String(), jsonKeys, "", "", maxRetries, true)
+	resp, _, err := dbclient.CouchInstance.handleRequest(dbclient.context(), http.MethodPost, batchRetrieveURL.String(), jsonKeys, "", "", maxRetries, true)
 	if err != nil {
 		return nil, err
 	}
@@ -1438,7 +1457,7 @@ func (dbclient *CouchDatabase) BatchUpdateDocuments(documents []*CouchDoc) ([]*B
 	//get the number of retries
 	maxRetries := dbclient.CouchInstance.conf.MaxRetries
 
-	resp, _, err := dbclient.CouchInstance.handleRequest(http.MethodPost, batchUpdateURL.String(), bulkDocsJSON, "", "", maxRetries, true)
+	resp, _, err := dbclient.CouchInstance.handleRequest(dbclient.context(), http.MethodPost, batchUpdateURL.String(), bulkDocsJSON, "", "", maxRetries, true)
 	if err != nil {
 		return nil, err
 	}
@@ -1493,7 +1512,7 @@ func (dbclient *CouchDatabase) handleRequestWithRevisionRetry(id, method string,
 		}
 
 		//handle the request for saving/deleting the couchdb data
-		resp, couchDBReturn, errResp = dbclient.CouchInstance.handleRequest(method, connectURL.String(),
+		resp, couchDBReturn, errResp = dbclient.CouchInstance.handleRequest(dbclient.context(), method, connectURL.String(),
 			data, rev, multipartBoundary, maxRetries, keepConnectionOpen)
 
 		//If there was a 409 conflict error during the save/delete, log it and retry it.
@@ -1514,15 +1533,37 @@ func (dbclient *CouchDatabase) handleRequestWithRevisionRetry(id, method string,
 // If it returns an error, it ensures that the response body is closed, else it is the
 // callee's responsibility to close response correctly.
 // Any http error or CouchDB error (4XX or 500) will result in a golang error getting returned
-func (couchInstance *CouchInstance) handleRequest(method, connectURL string, data []byte, rev string,
-	multipartBoundary string, maxRetries int, keepConnectionOpen bool) (*http.Response, *DBReturn, error) {
+func (couchInstance *CouchInstance) handleRequest(ctx context.Context, method, connectURL string, data []byte, rev string,
+	multipartBoundary string, maxRetries int, keepConnectionOpen bool) (resp *http.Response, couchDBReturn *DBReturn, err error) {
 
 	logger.Debugf("Entering handleRequest()  method=%s  url=%v", method, connectURL)
 
+	//time the request, including its retries, as part of the trace of the caller, such as
+	//that of the transaction whose simulation reads the state. Requests made outside of a
+	//trace are not timed, rather than being the roots of traces of their own
+	var span *tracing.Span
+	if tracing.SpanFromContext(ctx) != nil {
+		span, _ = tracing.StartSpan(ctx, "couchdb."+method, tracing.Tag("method", method))
+	}
+	retries := 0
+	defer func() {
+		if span == nil {
+			return
+		}
+		if u, err := url.Parse(connectURL); err == nil {
+			span.SetTag("path", u.Path)
+		}
+		if couchDBReturn != nil && couchDBReturn.StatusCode != 0 {
+			span.SetTag("status", couchDBReturn.StatusCode)
+		}
+		span.SetTag("retries", retries)
+		span.SetError(err)
+		span.Finish()
+	}()
+
 	//create the return objects for couchDB
-	var resp *http.Response
 	var errResp error
-	couchDBReturn := &DBReturn{}
+	couchDBReturn = &DBReturn{}
 
 	//set initial wait duration for retries
 	waitDuration := retryWaitTime * time.Millisecond
@@ -1537,6 +1578,7 @@ func (couchInstance *CouchInstance) handleRequest(method, connectURL string, dat
 	// if maxRetries is 3 (default), a maximum of 4 attempts (one attempt with 3 retries)
 	//    will be made with warning entries for unsuccessful attempts
 	for attempts := 0; attempts <= maxRetries; attempts++ {
+		retries = attempts
 
 		//Set up a buffer for the payload data
 		payloadData := new(bytes.Buffer)
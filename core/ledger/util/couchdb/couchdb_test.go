@@ -17,9 +17,11 @@ limitations under the License.
 package couchdb
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -27,6 +29,7 @@ import (
 	"unicode/utf8"
 
 	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/core/ledger/ledgerconfig"
 	ledgertestutil "github.com/hyperledger/fabric/core/ledger/testutil"
 	logging "github.com/op/go-logging"
@@ -153,7 +156,7 @@ func TestBadCouchDBInstance(t *testing.T) {
 	badCouchDBInstance := CouchInstance{badConnectDef, client}
 
 	//Create a bad CouchDatabase
-	badDB := CouchDatabase{badCouchDBInstance, "baddb", 1}
+	badDB := CouchDatabase{CouchInstance: badCouchDBInstance, DBName: "baddb", IndexWarmCounter: 1}
 
 	//Test CreateCouchDatabase with bad connection
 	_, err := CreateCouchDatabase(badCouchDBInstance, "baddbtest")
@@ -1618,3 +1621,46 @@ func addRevisionAndDeleteStatus(revision string, value []byte, deleted bool) []b
 	return returnJSON
 
 }
+
+func TestRequestSpans(t *testing.T) {
+	exporter := tracing.NewInMemoryExporter()
+	tracing.SetExporter(exporter)
+	defer tracing.SetExporter(nil)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"not_found","reason":"missing"}`))
+	}))
+	defer server.Close()
+
+	couchInstance := &CouchInstance{
+		conf:   CouchConnectionDef{URL: server.URL, MaxRetries: 1, RequestTimeout: time.Second},
+		client: &http.Client{Timeout: time.Second},
+	}
+	db := &CouchDatabase{CouchInstance: *couchInstance, DBName: "testrequestspans"}
+
+	// requests made outside of a trace are not timed
+	doc, _, err := db.ReadDoc("key1")
+	testutil.AssertNoError(t, err, "Error when reading a missing document")
+	testutil.AssertNil(t, doc)
+	testutil.AssertEquals(t, len(exporter.Spans()), 0)
+
+	// requests made within a trace are timed as children of its span
+	parent, ctx := tracing.StartSpan(context.Background(), "simulation")
+	doc, _, err = db.WithContext(ctx).ReadDoc("key1")
+	testutil.AssertNoError(t, err, "Error when reading a missing document")
+	testutil.AssertNil(t, doc)
+	parent.Finish()
+
+	spans := exporter.Spans()
+	testutil.AssertEquals(t, len(spans), 2)
+	testutil.AssertEquals(t, spans[0].Name, "couchdb.GET")
+	testutil.AssertEquals(t, spans[0].TraceID, parent.Context().TraceID)
+	testutil.AssertEquals(t, spans[0].ParentID, parent.Context().SpanID)
+	testutil.AssertEquals(t, spans[0].Tags["path"], "/testrequestspans/key1")
+	testutil.AssertEquals(t, spans[0].Tags["status"], "404")
+
+	// the handle the context was bound to is left untouched
+	testutil.AssertEquals(t, db.context(), context.Background())
+}
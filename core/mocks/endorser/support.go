@@ -37,6 +37,8 @@ type MockSupport struct {
 	IsJavaErr                        error
 	GetApplicationConfigRv           channelconfig.Application
 	GetApplicationConfigBoolRv       bool
+	ValidateReadSetRv                *pb.MVCCConflict
+	ValidateReadSetErr               error
 }
 
 func (s *MockSupport) IsSysCCAndNotInvokableExternal(name string) bool {
@@ -92,3 +94,7 @@ func (s *MockSupport) CheckInstantiationPolicy(name, version string, cd resource
 func (s *MockSupport) GetApplicationConfig(cid string) (channelconfig.Application, bool) {
 	return s.GetApplicationConfigRv, s.GetApplicationConfigBoolRv
 }
+
+func (s *MockSupport) ValidateReadSet(ledgername string, pubSimResBytes []byte) (*pb.MVCCConflict, error) {
+	return s.ValidateReadSetRv, s.ValidateReadSetErr
+}
@@ -0,0 +1,240 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"regexp"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/deliver"
+	"github.com/hyperledger/fabric/core/aclmgmt/resources"
+	"github.com/hyperledger/fabric/core/ledger/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/pkg/errors"
+)
+
+// chaincodeEventsServer delivers the chaincode events of
+// committed blocks, and leverages the deliver handler
+// being used for block events
+type chaincodeEventsServer struct {
+	dh                    deliver.Handler
+	policyCheckerProvider PolicyCheckerProvider
+}
+
+// NewChaincodeEventsServer creates a peer.ChaincodeEvents server
+// to deliver the chaincode events of committed blocks
+func NewChaincodeEventsServer(mutualTLS bool, policyCheckerProvider PolicyCheckerProvider, supportManager deliver.SupportManager) peer.ChaincodeEventsServer {
+	return &chaincodeEventsServer{
+		dh:                    deliver.NewHandlerImpl(supportManager, authenticationTimeWindow(), mutualTLS),
+		policyCheckerProvider: policyCheckerProvider,
+	}
+}
+
+// Deliver sends a stream of the chaincode events of blocks to a client after commitment
+func (s *chaincodeEventsServer) Deliver(srv peer.ChaincodeEvents_DeliverServer) error {
+	logger.Debugf("Starting new chaincode events Deliver handler")
+	defer dumpStacktraceOnPanic()
+	srvSupport := &deliverChaincodeEventsSupport{
+		ChaincodeEvents_DeliverServer: srv,
+	}
+	// getting policy checker based on resources.CHAINCODEEVENT resource name
+	return s.dh.Handle(deliver.NewDeliverServer(srvSupport, s.policyCheckerProvider(resources.CHAINCODEEVENT), srvSupport.send))
+}
+
+// deliverChaincodeEventsSupport support structure used to generate
+// chaincode events responses, according to the request of the client
+type deliverChaincodeEventsSupport struct {
+	peer.ChaincodeEvents_DeliverServer
+	filter *chaincodeEventsFilter
+}
+
+// Recv receives the next request of the client, and extracts
+// the chaincode events request from the extension of its channel header
+func (d *deliverChaincodeEventsSupport) Recv() (*common.Envelope, error) {
+	envelope, err := d.ChaincodeEvents_DeliverServer.Recv()
+	if err != nil {
+		return nil, err
+	}
+	chdr, err := channelHeaderOf(envelope)
+	if err != nil {
+		// The deliver handler rejects malformed envelopes
+		return envelope, nil
+	}
+	d.filter, err = newChaincodeEventsFilter(chdr.ChannelId, chdr.Extension)
+	if err != nil {
+		logger.Warningf("Received a malformed chaincode events request: %s", err)
+		return nil, err
+	}
+	return envelope, nil
+}
+
+// CreateStatusReply generates status reply proto message
+func (*deliverChaincodeEventsSupport) CreateStatusReply(status common.Status) proto.Message {
+	return &peer.ChaincodeEventsResponse{
+		Type: &peer.ChaincodeEventsResponse_Status{Status: status},
+	}
+}
+
+// CreateBlockReply generates chaincode events response with
+// the events of the block that match the request
+func (d *deliverChaincodeEventsSupport) CreateBlockReply(block *common.Block) proto.Message {
+	events, err := d.filter.chaincodeEvents(block)
+	if err != nil {
+		logger.Warningf("Failed to extract the chaincode events of block %d due to: %s", block.Header.Number, err)
+		return d.CreateStatusReply(common.Status_INTERNAL_SERVER_ERROR)
+	}
+	return &peer.ChaincodeEventsResponse{
+		Type: &peer.ChaincodeEventsResponse_ChaincodeEvents{ChaincodeEvents: events},
+	}
+}
+
+// send sends the response to the client, unless it
+// is for a block that has no events that match the request
+func (d *deliverChaincodeEventsSupport) send(msg proto.Message) error {
+	response, ok := msg.(*peer.ChaincodeEventsResponse)
+	if !ok {
+		logger.Errorf("received wrong response type, expected response type peer.ChaincodeEventsResponse")
+		return errors.New("expected response type peer.ChaincodeEventsResponse")
+	}
+	if events := response.GetChaincodeEvents(); events != nil && len(events.Events) == 0 {
+		return nil
+	}
+	return d.ChaincodeEvents_DeliverServer.Send(response)
+}
+
+// chaincodeEventsFilter selects the chaincode events
+// that are delivered according to a request
+type chaincodeEventsFilter struct {
+	channelID      string
+	chaincodeID    string
+	eventName      *regexp.Regexp
+	includeInvalid bool
+	checkpoint     *peer.ChaincodeEventsCheckpoint
+}
+
+func newChaincodeEventsFilter(channelID string, requestBytes []byte) (*chaincodeEventsFilter, error) {
+	request := &peer.ChaincodeEventsRequest{}
+	if err := proto.Unmarshal(requestBytes, request); err != nil {
+		return nil, errors.Wrap(err, "error unmarshaling chaincode events request")
+	}
+	if request.ChaincodeId == "" {
+		return nil, errors.New("chaincode ID must be provided")
+	}
+	filter := &chaincodeEventsFilter{
+		channelID:      channelID,
+		chaincodeID:    request.ChaincodeId,
+		includeInvalid: request.IncludeInvalid,
+		checkpoint:     request.Checkpoint,
+	}
+	if request.EventNameFilter != "" {
+		eventName, err := regexp.Compile(request.EventNameFilter)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid event name filter %s", request.EventNameFilter)
+		}
+		filter.eventName = eventName
+	}
+	return filter, nil
+}
+
+// precedesCheckpoint returns whether the events of the
+// given transaction precede the checkpoint of the request
+func (f *chaincodeEventsFilter) precedesCheckpoint(blockNum uint64, txIndex uint64) bool {
+	if f.checkpoint == nil {
+		return false
+	}
+	if blockNum != f.checkpoint.BlockNumber {
+		return blockNum < f.checkpoint.BlockNumber
+	}
+	return txIndex < f.checkpoint.TransactionIndex
+}
+
+func (f *chaincodeEventsFilter) matches(ccEvent *peer.ChaincodeEvent) bool {
+	if ccEvent.ChaincodeId != f.chaincodeID {
+		return false
+	}
+	return f.eventName == nil || f.eventName.MatchString(ccEvent.EventName)
+}
+
+// chaincodeEvents returns the chaincode events of the block that match the filter
+func (f *chaincodeEventsFilter) chaincodeEvents(block *common.Block) (*peer.ChaincodeEventsBlock, error) {
+	blockNum := block.Header.Number
+	events := &peer.ChaincodeEventsBlock{
+		ChannelId:   f.channelID,
+		BlockNumber: blockNum,
+		Checkpoint:  &peer.ChaincodeEventsCheckpoint{BlockNumber: blockNum + 1},
+	}
+
+	txsFltr := util.TxValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+	for txIndex, ebytes := range block.Data.Data {
+		if ebytes == nil {
+			continue
+		}
+		if !f.includeInvalid && txsFltr.IsInvalid(txIndex) {
+			continue
+		}
+		if f.precedesCheckpoint(blockNum, uint64(txIndex)) {
+			continue
+		}
+
+		env, err := utils.GetEnvelopeFromBlock(ebytes)
+		if err != nil {
+			logger.Errorf("error getting tx from block, %s", err)
+			continue
+		}
+		payload, err := utils.GetPayload(env)
+		if err != nil {
+			return nil, errors.WithMessage(err, "could not extract payload from envelope")
+		}
+		if payload.Header == nil {
+			logger.Debugf("transaction payload header is nil, %d, block num %d", txIndex, blockNum)
+			continue
+		}
+		chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+		if err != nil {
+			return nil, err
+		}
+		if common.HeaderType(chdr.Type) != common.HeaderType_ENDORSER_TRANSACTION {
+			continue
+		}
+
+		tx, err := utils.GetTransaction(payload.Data)
+		if err != nil {
+			return nil, errors.WithMessage(err, "error unmarshal transaction payload for chaincode events")
+		}
+		ccEvents, err := transactionActions(tx.Actions).chaincodeEvents()
+		if err != nil {
+			return nil, err
+		}
+		for _, ccEvent := range ccEvents {
+			if !f.matches(ccEvent) {
+				continue
+			}
+			events.Events = append(events.Events, &peer.ChaincodeEventInfo{
+				ChaincodeEvent:   ccEvent,
+				BlockNumber:      blockNum,
+				TransactionIndex: uint64(txIndex),
+				TxValidationCode: txsFltr.Flag(txIndex),
+				Checkpoint:       &peer.ChaincodeEventsCheckpoint{BlockNumber: blockNum, TransactionIndex: uint64(txIndex) + 1},
+			})
+		}
+	}
+	return events, nil
+}
+
+// channelHeaderOf returns the channel header of the envelope
+func channelHeaderOf(env *common.Envelope) (*common.ChannelHeader, error) {
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return nil, errors.WithMessage(err, "could not extract payload from envelope")
+	}
+	if payload.Header == nil {
+		return nil, errors.New("envelope has no header")
+	}
+	return utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+}
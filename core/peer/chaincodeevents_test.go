@@ -0,0 +1,220 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"io"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	peer2 "google.golang.org/grpc/peer"
+)
+
+// mockChaincodeEventsServer mock implementation of the ChaincodeEvents_DeliverServer
+type mockChaincodeEventsServer struct {
+	grpc.ServerStream
+	requests  []*common.Envelope
+	responses []*peer.ChaincodeEventsResponse
+}
+
+func (m *mockChaincodeEventsServer) Context() context.Context {
+	return peer2.NewContext(context.TODO(), &peer2.Peer{})
+}
+
+func (m *mockChaincodeEventsServer) Recv() (*common.Envelope, error) {
+	if len(m.requests) == 0 {
+		return nil, io.EOF
+	}
+	request := m.requests[0]
+	m.requests = m.requests[1:]
+	return request, nil
+}
+
+func (m *mockChaincodeEventsServer) Send(response *peer.ChaincodeEventsResponse) error {
+	m.responses = append(m.responses, response)
+	return nil
+}
+
+// createChaincodeEventsBlock creates a block with a transaction
+// for each of the given events, and the given validation codes
+func createChaincodeEventsBlock(t *testing.T, events []*peer.ChaincodeEvent, codes []peer.TxValidationCode) *common.Block {
+	var envs []*common.Envelope
+	for _, event := range events {
+		chaincodeActionPayload, err := createChaincodeAction(event.ChaincodeId, event.EventName, event.TxId)
+		assert.NoError(t, err)
+		payload, err := createEndorsement("testChainID", event.TxId, chaincodeActionPayload)
+		assert.NoError(t, err)
+		envs = append(envs, &common.Envelope{Payload: utils.MarshalOrPanic(payload)})
+	}
+	block, err := createTestBlock(envs)
+	assert.NoError(t, err)
+	for i, code := range codes {
+		block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER][i] = uint8(code)
+	}
+	return block
+}
+
+func TestChaincodeEventsFilter(t *testing.T) {
+	block := createChaincodeEventsBlock(t, []*peer.ChaincodeEvent{
+		{ChaincodeId: "mycc", EventName: "transfer", TxId: "tx0"},
+		{ChaincodeId: "mycc", EventName: "transfer", TxId: "tx1"},
+		{ChaincodeId: "othercc", EventName: "transfer", TxId: "tx2"},
+		{ChaincodeId: "mycc", EventName: "audit", TxId: "tx3"},
+	}, []peer.TxValidationCode{
+		peer.TxValidationCode_VALID,
+		peer.TxValidationCode_MVCC_READ_CONFLICT,
+		peer.TxValidationCode_VALID,
+		peer.TxValidationCode_VALID,
+	})
+	txIDsOf := func(request *peer.ChaincodeEventsRequest) []string {
+		filter, err := newChaincodeEventsFilter("testChainID", utils.MarshalOrPanic(request))
+		assert.NoError(t, err)
+		events, err := filter.chaincodeEvents(block)
+		assert.NoError(t, err)
+		assert.Equal(t, "testChainID", events.ChannelId)
+		assert.Equal(t, &peer.ChaincodeEventsCheckpoint{BlockNumber: 1}, events.Checkpoint)
+		txIDs := []string{}
+		for _, event := range events.Events {
+			txIDs = append(txIDs, event.ChaincodeEvent.TxId)
+		}
+		return txIDs
+	}
+
+	// Only the events of valid transactions are delivered by default
+	assert.Equal(t, []string{"tx0", "tx3"}, txIDsOf(&peer.ChaincodeEventsRequest{ChaincodeId: "mycc"}))
+	assert.Equal(t, []string{"tx0", "tx1", "tx3"}, txIDsOf(&peer.ChaincodeEventsRequest{ChaincodeId: "mycc", IncludeInvalid: true}))
+	assert.Equal(t, []string{"tx2"}, txIDsOf(&peer.ChaincodeEventsRequest{ChaincodeId: "othercc"}))
+
+	// Events are filtered by their names
+	assert.Equal(t, []string{"tx0"}, txIDsOf(&peer.ChaincodeEventsRequest{ChaincodeId: "mycc", EventNameFilter: "^trans"}))
+	assert.Equal(t, []string{"tx0", "tx3"}, txIDsOf(&peer.ChaincodeEventsRequest{ChaincodeId: "mycc", EventNameFilter: "transfer|audit"}))
+	assert.Equal(t, []string{}, txIDsOf(&peer.ChaincodeEventsRequest{ChaincodeId: "mycc", EventNameFilter: "^mint$"}))
+
+	// Events that precede the checkpoint aren't delivered
+	assert.Equal(t, []string{"tx3"}, txIDsOf(&peer.ChaincodeEventsRequest{
+		ChaincodeId: "mycc",
+		Checkpoint:  &peer.ChaincodeEventsCheckpoint{BlockNumber: 0, TransactionIndex: 1},
+	}))
+	assert.Equal(t, []string{}, txIDsOf(&peer.ChaincodeEventsRequest{
+		ChaincodeId: "mycc",
+		Checkpoint:  &peer.ChaincodeEventsCheckpoint{BlockNumber: 1},
+	}))
+
+	// Events carry their transaction and the checkpoint that follows them
+	filter, err := newChaincodeEventsFilter("testChainID", utils.MarshalOrPanic(&peer.ChaincodeEventsRequest{ChaincodeId: "mycc", IncludeInvalid: true}))
+	assert.NoError(t, err)
+	events, err := filter.chaincodeEvents(block)
+	assert.NoError(t, err)
+	assert.Len(t, events.Events, 3)
+	event := events.Events[1]
+	assert.Equal(t, "transfer", event.ChaincodeEvent.EventName)
+	assert.Equal(t, uint64(0), event.BlockNumber)
+	assert.Equal(t, uint64(1), event.TransactionIndex)
+	assert.Equal(t, peer.TxValidationCode_MVCC_READ_CONFLICT, event.TxValidationCode)
+	assert.Equal(t, &peer.ChaincodeEventsCheckpoint{BlockNumber: 0, TransactionIndex: 2}, event.Checkpoint)
+}
+
+func TestNewChaincodeEventsFilter(t *testing.T) {
+	_, err := newChaincodeEventsFilter("testChainID", []byte("garbage"))
+	assert.Contains(t, err.Error(), "error unmarshaling chaincode events request")
+
+	_, err = newChaincodeEventsFilter("testChainID", nil)
+	assert.EqualError(t, err, "chaincode ID must be provided")
+
+	_, err = newChaincodeEventsFilter("testChainID", utils.MarshalOrPanic(&peer.ChaincodeEventsRequest{ChaincodeId: "mycc", EventNameFilter: "("}))
+	assert.Contains(t, err.Error(), "invalid event name filter (")
+}
+
+func createChaincodeEventsEnvelope(request *peer.ChaincodeEventsRequest) *common.Envelope {
+	return &common.Envelope{
+		Payload: utils.MarshalOrPanic(&common.Payload{
+			Header: &common.Header{
+				ChannelHeader: utils.MarshalOrPanic(&common.ChannelHeader{
+					ChannelId: "testChainID",
+					Timestamp: util.CreateUtcTimestamp(),
+					Extension: utils.MarshalOrPanic(request),
+				}),
+				SignatureHeader: utils.MarshalOrPanic(&common.SignatureHeader{}),
+			},
+			Data: utils.MarshalOrPanic(&orderer.SeekInfo{
+				Start:    &orderer.SeekPosition{Type: &orderer.SeekPosition_Specified{Specified: &orderer.SeekSpecified{Number: 0}}},
+				Stop:     &orderer.SeekPosition{Type: &orderer.SeekPosition_Newest{Newest: &orderer.SeekNewest{}}},
+				Behavior: orderer.SeekInfo_BLOCK_UNTIL_READY,
+			}),
+		}),
+	}
+}
+
+func TestChaincodeEventsServer_Deliver(t *testing.T) {
+	viper.Set("peer.authentication.timewindow", "1s")
+	config := testConfig{
+		channelID:     "testChainID",
+		eventName:     "testEvent",
+		chaincodeName: "mycc",
+		txID:          "testID",
+		Assertions:    assert.New(t),
+	}
+	chaincodeActionPayload, err := createChaincodeAction(config.chaincodeName, config.eventName, config.txID)
+	assert.NoError(t, err)
+	server := NewChaincodeEventsServer(false, defaultPolicyCheckerProvider, createDefaultSupportMamangerMock(config, chaincodeActionPayload))
+
+	// The events of the block are delivered
+	srv := &mockChaincodeEventsServer{
+		requests: []*common.Envelope{createChaincodeEventsEnvelope(&peer.ChaincodeEventsRequest{ChaincodeId: "mycc"})},
+	}
+	assert.NoError(t, server.Deliver(srv))
+	assert.Len(t, srv.responses, 2)
+	events := srv.responses[0].GetChaincodeEvents()
+	assert.NotNil(t, events)
+	assert.Equal(t, "testChainID", events.ChannelId)
+	assert.Len(t, events.Events, 1)
+	assert.Equal(t, "testEvent", events.Events[0].ChaincodeEvent.EventName)
+	assert.Equal(t, "testID", events.Events[0].ChaincodeEvent.TxId)
+	assert.Equal(t, peer.TxValidationCode_VALID, events.Events[0].TxValidationCode)
+	assert.Equal(t, common.Status_SUCCESS, srv.responses[1].GetStatus())
+
+	// Blocks without matching events aren't delivered
+	srv = &mockChaincodeEventsServer{
+		requests: []*common.Envelope{createChaincodeEventsEnvelope(&peer.ChaincodeEventsRequest{ChaincodeId: "othercc"})},
+	}
+	assert.NoError(t, server.Deliver(srv))
+	assert.Len(t, srv.responses, 1)
+	assert.Equal(t, common.Status_SUCCESS, srv.responses[0].GetStatus())
+
+	// Malformed requests end the stream
+	srv = &mockChaincodeEventsServer{
+		requests: []*common.Envelope{createChaincodeEventsEnvelope(&peer.ChaincodeEventsRequest{})},
+	}
+	assert.EqualError(t, server.Deliver(srv), "chaincode ID must be provided")
+	assert.Empty(t, srv.responses)
+
+	// Malformed envelopes are rejected by the deliver handler
+	srv = &mockChaincodeEventsServer{
+		requests: []*common.Envelope{{Payload: utils.MarshalOrPanic(&common.Payload{})}},
+	}
+	assert.NoError(t, server.Deliver(srv))
+	assert.Len(t, srv.responses, 1)
+	assert.Equal(t, common.Status_BAD_REQUEST, srv.responses[0].GetStatus())
+}
+
+func TestChaincodeEventsSupportSend(t *testing.T) {
+	srv := &mockChaincodeEventsServer{}
+	support := &deliverChaincodeEventsSupport{ChaincodeEvents_DeliverServer: srv}
+	assert.EqualError(t, support.send(&peer.DeliverResponse{}), "expected response type peer.ChaincodeEventsResponse")
+	assert.NoError(t, support.send(&peer.ChaincodeEventsResponse{
+		Type: &peer.ChaincodeEventsResponse_ChaincodeEvents{ChaincodeEvents: &peer.ChaincodeEventsBlock{}},
+	}))
+	assert.Empty(t, srv.responses)
+}
@@ -0,0 +1,180 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/common/privdata"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	gossipcommon "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/service"
+	gossipproto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/hyperledger/fabric/protos/ledger/queryresult"
+	"github.com/pkg/errors"
+)
+
+const lsccNamespace = "lscc"
+
+// installed caches the chaincodes installed on the peer, which are
+// read from the file system once and then updated on each install
+var installed struct {
+	sync.Mutex
+	loaded     bool
+	chaincodes []*gossipproto.Chaincode
+}
+
+// PublishInstalledChaincodes publishes the chaincodes installed on the peer
+// to the members of the channels the peer has joined
+func PublishInstalledChaincodes() {
+	publishInstalledChaincodes(nil)
+}
+
+// PublishInstalledChaincode adds a chaincode that was just installed to
+// the chaincodes published to the members of the peer's channels
+func PublishInstalledChaincode(name, version string) {
+	publishInstalledChaincodes(&gossipproto.Chaincode{Name: name, Version: version})
+}
+
+func publishInstalledChaincodes(newChaincode *gossipproto.Chaincode) {
+	installed.Lock()
+	defer installed.Unlock()
+
+	// Until the chaincodes are loaded, a new chaincode is found on the file system along with the others.
+	// The chaincodes might have been published already, so a new slice is built
+	if installed.loaded && newChaincode != nil && !containsChaincode(installed.chaincodes, newChaincode) {
+		installed.chaincodes = append(append([]*gossipproto.Chaincode{}, installed.chaincodes...), newChaincode)
+	}
+	if !hasJoinedChannels() {
+		return
+	}
+	if !installed.loaded {
+		chaincodes, err := installedChaincodes()
+		if err != nil {
+			peerLogger.Warningf("Failed retrieving the installed chaincodes: %+v", err)
+			return
+		}
+		installed.chaincodes = chaincodes
+		installed.loaded = true
+	}
+	service.GetGossipService().UpdateInstalledChaincodes(installed.chaincodes)
+}
+
+func containsChaincode(chaincodes []*gossipproto.Chaincode, chaincode *gossipproto.Chaincode) bool {
+	for _, cc := range chaincodes {
+		if cc.Name == chaincode.Name && cc.Version == chaincode.Version {
+			return true
+		}
+	}
+	return false
+}
+
+// hasJoinedChannels returns whether the peer has joined a channel
+// and hence publishes its state to other peers via gossip
+func hasJoinedChannels() bool {
+	chains.RLock()
+	defer chains.RUnlock()
+	for _, c := range chains.list {
+		if c.committer != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// handleChaincodeDeploy publishes the chaincodes deployed in a block
+// to the members of the channel, along with the chaincodes
+// that were instantiated on the channel beforehand
+func handleChaincodeDeploy(cid string, chaincodeDefinitions []*cceventmgmt.ChaincodeDefinition) {
+	chains.Lock()
+	c, exists := chains.list[cid]
+	if !exists || c.committer == nil {
+		chains.Unlock()
+		return
+	}
+	c.chaincodes = mergeChaincodes(c.chaincodes, chaincodeDefinitions)
+	chaincodes := c.chaincodes
+	chains.Unlock()
+
+	service.GetGossipService().UpdateChaincodes(chaincodes, gossipcommon.ChainID(cid))
+}
+
+// mergeChaincodes returns the given chaincodes with the chaincodes
+// of the chaincode definitions added, or upgraded to the version
+// of the chaincode definition
+func mergeChaincodes(chaincodes []*gossipproto.Chaincode, chaincodeDefinitions []*cceventmgmt.ChaincodeDefinition) []*gossipproto.Chaincode {
+	// The chaincodes might have been published already, so a new slice is built
+	merged := make([]*gossipproto.Chaincode, len(chaincodes))
+	copy(merged, chaincodes)
+	for _, ccDef := range chaincodeDefinitions {
+		cc := &gossipproto.Chaincode{Name: ccDef.Name, Version: ccDef.Version}
+		upgraded := false
+		for i, existing := range merged {
+			if existing.Name == cc.Name {
+				merged[i] = cc
+				upgraded = true
+				break
+			}
+		}
+		if !upgraded {
+			merged = append(merged, cc)
+		}
+	}
+	return merged
+}
+
+// instantiatedChaincodes returns the chaincodes instantiated
+// on the channel of the given ledger
+func instantiatedChaincodes(ledger ledger.PeerLedger) ([]*gossipproto.Chaincode, error) {
+	qe, err := ledger.NewQueryExecutor()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed creating query executor")
+	}
+	defer qe.Done()
+
+	itr, err := qe.GetStateRangeScanIterator(lsccNamespace, "", "")
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed querying the chaincodes")
+	}
+	defer itr.Close()
+
+	var chaincodes []*gossipproto.Chaincode
+	for {
+		res, err := itr.Next()
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed querying the chaincodes")
+		}
+		if res == nil {
+			return chaincodes, nil
+		}
+		kv := res.(*queryresult.KV)
+		if privdata.IsCollectionConfigKey(kv.Key) {
+			continue
+		}
+		ccData := &ccprovider.ChaincodeData{}
+		if err := proto.Unmarshal(kv.Value, ccData); err != nil {
+			return nil, errors.Wrapf(err, "failed unmarshaling chaincode data of %s", kv.Key)
+		}
+		chaincodes = append(chaincodes, &gossipproto.Chaincode{Name: ccData.Name, Version: ccData.Version})
+	}
+}
+
+// installedChaincodes returns the chaincodes installed on the peer
+func installedChaincodes() ([]*gossipproto.Chaincode, error) {
+	cqr, err := ccprovider.GetInstalledChaincodes()
+	if err != nil {
+		return nil, err
+	}
+	var chaincodes []*gossipproto.Chaincode
+	for _, cc := range cqr.Chaincodes {
+		chaincodes = append(chaincodes, &gossipproto.Chaincode{Name: cc.Name, Version: cc.Version})
+	}
+	return chaincodes, nil
+}
@@ -0,0 +1,95 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package peer
+
+import (
+	"os"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/ledger/testutil"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
+	gossipproto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeChaincodes(t *testing.T) {
+	chaincodes := []*gossipproto.Chaincode{
+		{Name: "cc1", Version: "1.0"},
+		{Name: "cc2", Version: "1.0"},
+	}
+	merged := mergeChaincodes(chaincodes, []*cceventmgmt.ChaincodeDefinition{
+		{Name: "cc2", Version: "2.0"},
+		{Name: "cc3", Version: "1.0"},
+	})
+	assert.Equal(t, []*gossipproto.Chaincode{
+		{Name: "cc1", Version: "1.0"},
+		{Name: "cc2", Version: "2.0"},
+		{Name: "cc3", Version: "1.0"},
+	}, merged)
+	// The given chaincodes are left untouched
+	assert.Equal(t, "1.0", chaincodes[1].Version)
+	assert.Len(t, chaincodes, 2)
+
+	assert.Equal(t, []*gossipproto.Chaincode{{Name: "cc1", Version: "1.0"}},
+		mergeChaincodes(nil, []*cceventmgmt.ChaincodeDefinition{{Name: "cc1", Version: "1.0"}}))
+}
+
+func TestInstantiatedChaincodes(t *testing.T) {
+	viper.Set("peer.fileSystemPath", "/var/hyperledger/test/")
+	defer os.RemoveAll("/var/hyperledger/test/")
+	chainID := "instantiatedchaincodes"
+	MockInitialize()
+	assert.NoError(t, MockCreateChain(chainID))
+	l := GetLedger(chainID)
+	defer l.Close()
+
+	chaincodes, err := instantiatedChaincodes(l)
+	assert.NoError(t, err)
+	assert.Empty(t, chaincodes)
+
+	simulator, err := l.NewTxSimulator(util.GenerateUUID())
+	assert.NoError(t, err)
+	for _, ccData := range []*ccprovider.ChaincodeData{
+		{Name: "cc1", Version: "1.0"},
+		{Name: "cc2", Version: "2.0"},
+	} {
+		b, err := proto.Marshal(ccData)
+		assert.NoError(t, err)
+		assert.NoError(t, simulator.SetState(lsccNamespace, ccData.Name, b))
+	}
+	// Collection configurations are not chaincodes
+	assert.NoError(t, simulator.SetState(lsccNamespace, "cc1~collection", []byte("collections")))
+	simulator.Done()
+	simRes, err := simulator.GetTxSimulationResults()
+	assert.NoError(t, err)
+	pubSimResBytes, err := simRes.GetPubSimulationBytes()
+	assert.NoError(t, err)
+	bg, _ := testutil.NewBlockGenerator(t, chainID, false)
+	assert.NoError(t, l.CommitWithPvtData(&ledger.BlockAndPvtData{Block: bg.NextBlock([][]byte{pubSimResBytes})}))
+
+	chaincodes, err = instantiatedChaincodes(l)
+	assert.NoError(t, err)
+	assert.Equal(t, []*gossipproto.Chaincode{
+		{Name: "cc1", Version: "1.0"},
+		{Name: "cc2", Version: "2.0"},
+	}, chaincodes)
+
+	// Chains that are mocked don't publish their state via gossip,
+	// so deploy events and installs are ignored
+	assert.False(t, hasJoinedChannels())
+	handleChaincodeDeploy(chainID, []*cceventmgmt.ChaincodeDefinition{{Name: "cc3", Version: "1.0"}})
+	assert.Empty(t, chains.list[chainID].chaincodes)
+	PublishInstalledChaincodes()
+	PublishInstalledChaincode("cc3", "1.0")
+	// The installed chaincodes are only read once they are published
+	assert.False(t, installed.loaded)
+}
@@ -131,16 +131,22 @@ func (s *server) Deliver(srv peer.Deliver_DeliverServer) error {
 // NewDeliverEventsServer creates a peer.Deliver server to deliver block and
 // filtered block events
 func NewDeliverEventsServer(mutualTLS bool, policyCheckerProvider PolicyCheckerProvider, supportManager deliver.SupportManager) peer.DeliverServer {
+	return &server{
+		dh: deliver.NewHandlerImpl(supportManager, authenticationTimeWindow(), mutualTLS),
+		policyCheckerProvider: policyCheckerProvider,
+	}
+}
+
+// authenticationTimeWindow returns the time window within which
+// the timestamps of the requests of clients must be
+func authenticationTimeWindow() time.Duration {
 	timeWindow := viper.GetDuration("peer.authentication.timewindow")
 	if timeWindow == 0 {
 		defaultTimeWindow := 15 * time.Minute
 		logger.Warningf("`peer.authentication.timewindow` not set; defaulting to %s", defaultTimeWindow)
 		timeWindow = defaultTimeWindow
 	}
-	return &server{
-		dh: deliver.NewHandlerImpl(supportManager, timeWindow, mutualTLS),
-		policyCheckerProvider: policyCheckerProvider,
-	}
+	return timeWindow
 }
 
 func (s *server) sendProducer(srv peer.Deliver_DeliverFilteredServer) func(msg proto.Message) error {
@@ -220,6 +226,28 @@ func (block *blockEvent) toFilteredBlock() (*peer.FilteredBlock, error) {
 
 func (ta transactionActions) toFilteredActions() (*peer.FilteredTransaction_TransactionActions, error) {
 	transactionActions := &peer.FilteredTransactionActions{}
+	ccEvents, err := ta.chaincodeEvents()
+	if err != nil {
+		return nil, err
+	}
+	for _, ccEvent := range ccEvents {
+		filteredAction := &peer.FilteredChaincodeAction{
+			ChaincodeEvent: &peer.ChaincodeEvent{
+				TxId:        ccEvent.TxId,
+				ChaincodeId: ccEvent.ChaincodeId,
+				EventName:   ccEvent.EventName,
+			},
+		}
+		transactionActions.ChaincodeActions = append(transactionActions.ChaincodeActions, filteredAction)
+	}
+	return &peer.FilteredTransaction_TransactionActions{
+		TransactionActions: transactionActions,
+	}, nil
+}
+
+// chaincodeEvents returns the chaincode events set by the transaction actions
+func (ta transactionActions) chaincodeEvents() ([]*peer.ChaincodeEvent, error) {
+	var ccEvents []*peer.ChaincodeEvent
 	for _, action := range ta {
 		chaincodeActionPayload, err := utils.GetChaincodeActionPayload(action.Payload)
 		if err != nil {
@@ -246,19 +274,10 @@ func (ta transactionActions) toFilteredActions() (*peer.FilteredTransaction_Tran
 		}
 
 		if ccEvent.GetChaincodeId() != "" {
-			filteredAction := &peer.FilteredChaincodeAction{
-				ChaincodeEvent: &peer.ChaincodeEvent{
-					TxId:        ccEvent.TxId,
-					ChaincodeId: ccEvent.ChaincodeId,
-					EventName:   ccEvent.EventName,
-				},
-			}
-			transactionActions.ChaincodeActions = append(transactionActions.ChaincodeActions, filteredAction)
+			ccEvents = append(ccEvents, ccEvent)
 		}
 	}
-	return &peer.FilteredTransaction_TransactionActions{
-		TransactionActions: transactionActions,
-	}, nil
+	return ccEvents, nil
 }
 
 func dumpStacktraceOnPanic() {
@@ -31,14 +31,17 @@ import (
 	"github.com/hyperledger/fabric/core/committer/txvalidator"
 	"github.com/hyperledger/fabric/core/common/privdata"
 	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/core/ledger/cceventmgmt"
 	"github.com/hyperledger/fabric/core/ledger/customtx"
 	"github.com/hyperledger/fabric/core/ledger/ledgermgmt"
 	"github.com/hyperledger/fabric/core/transientstore"
 	"github.com/hyperledger/fabric/gossip/api"
+	gossipcommon "github.com/hyperledger/fabric/gossip/common"
 	"github.com/hyperledger/fabric/gossip/service"
 	"github.com/hyperledger/fabric/msp"
 	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
 	"github.com/hyperledger/fabric/protos/common"
+	gossipproto "github.com/hyperledger/fabric/protos/gossip"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
@@ -161,6 +164,8 @@ type chain struct {
 	cs        *chainSupport
 	cb        *common.Block
 	committer committer.Committer
+	// chaincodes are the chaincodes instantiated on the chain
+	chaincodes []*gossipproto.Chaincode
 }
 
 // chains is a local map of chainID->chainObject
@@ -204,6 +209,7 @@ func Initialize(init func(string)) {
 	var cb *common.Block
 	var ledger ledger.PeerLedger
 	ledgermgmt.Initialize(ConfigTxProcessors)
+	cceventmgmt.GetMgr().RegisterDeployCallback(handleChaincodeDeploy)
 	ledgerIds, err := ledgermgmt.GetLedgerIDs()
 	if err != nil {
 		panic(fmt.Errorf("Error in initializing ledgermgmt: %s", err))
@@ -404,20 +410,28 @@ func createChain(cid string, ledger ledger.PeerLedger, cb *common.Block) error {
 	simpleCollectionStore := privdata.NewSimpleCollectionStore(&collectionSupport{
 		PeerLedger: ledger,
 	})
+	chaincodes, err := instantiatedChaincodes(ledger)
+	if err != nil {
+		peerLogger.Warningf("Failed retrieving the chaincodes instantiated on channel %s: %+v", cid, err)
+	}
 	service.GetGossipService().InitializeChannel(bundle.ConfigtxValidator().ChainID(), ordererAddresses, service.Support{
 		Validator: validator,
 		Committer: c,
 		Store:     store,
 		Cs:        simpleCollectionStore,
 	})
+	service.GetGossipService().UpdateChaincodes(chaincodes, gossipcommon.ChainID(cid))
 
 	chains.Lock()
-	defer chains.Unlock()
 	chains.list[cid] = &chain{
-		cs:        cs,
-		cb:        cb,
-		committer: c,
+		cs:         cs,
+		cb:         cb,
+		committer:  c,
+		chaincodes: chaincodes,
 	}
+	chains.Unlock()
+
+	PublishInstalledChaincodes()
 
 	return nil
 }
@@ -753,6 +767,22 @@ func (*collectionSupport) GetIdentityDeserializer(chainID string) msp.IdentityDe
 	return mspmgmt.GetManagerForChain(chainID)
 }
 
+func (*collectionSupport) GetImplicitCollectionOrgs(chainID string) ([]string, error) {
+	cc := GetChannelConfig(chainID)
+	if cc == nil {
+		return nil, errors.Errorf("channel %s not found", chainID)
+	}
+	ac, exists := cc.ApplicationConfig()
+	if !exists {
+		return nil, errors.Errorf("application config for channel %s not found", chainID)
+	}
+	return privdata.ImplicitCollectionOrgs(ac), nil
+}
+
+func (*collectionSupport) GetPolicyManager(chainID string) policies.Manager {
+	return GetPolicyManager(chainID)
+}
+
 //
 //  Deliver service support structs for the peer
 //
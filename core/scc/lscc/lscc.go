@@ -392,34 +392,34 @@ func isValidStatedbArtifactsTar(statedbArtifactsTar []byte) error {
 }
 
 // executeInstall implements the "install" Invoke transaction
-func (lscc *lifeCycleSysCC) executeInstall(stub shim.ChaincodeStubInterface, ccbytes []byte) error {
+func (lscc *lifeCycleSysCC) executeInstall(stub shim.ChaincodeStubInterface, ccbytes []byte) (ccprovider.CCPackage, error) {
 	ccpack, err := ccprovider.GetCCPackage(ccbytes)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	cds := ccpack.GetDepSpec()
 
 	if cds == nil {
-		return fmt.Errorf("nil deployment spec from from the CC package")
+		return nil, fmt.Errorf("nil deployment spec from from the CC package")
 	}
 
 	if err = lscc.isValidChaincodeName(cds.ChaincodeSpec.ChaincodeId.Name); err != nil {
-		return err
+		return nil, err
 	}
 
 	if err = lscc.isValidChaincodeVersion(cds.ChaincodeSpec.ChaincodeId.Name, cds.ChaincodeSpec.ChaincodeId.Version); err != nil {
-		return err
+		return nil, err
 	}
 
 	// Get any statedb artifacts from the chaincode package, e.g. couchdb index definitions
 	statedbArtifactsTar, err := ccprovider.ExtractStatedbArtifactsFromCCPackage(ccpack)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if err = isValidStatedbArtifactsTar(statedbArtifactsTar); err != nil {
-		return InvalidStatedbArtifactsErr(err.Error())
+		return nil, InvalidStatedbArtifactsErr(err.Error())
 	}
 
 	chaincodeDefinition := &cceventmgmt.ChaincodeDefinition{
@@ -433,17 +433,17 @@ func (lscc *lifeCycleSysCC) executeInstall(stub shim.ChaincodeStubInterface, ccb
 	// that is, if there are errors deploying the indexes the chaincode install can safely be re-attempted later.
 	err = cceventmgmt.GetMgr().HandleChaincodeInstall(chaincodeDefinition, statedbArtifactsTar)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Finally, if everything is good above, install the chaincode to local peer file system so that endorsements can start
 	if err = lscc.support.PutChaincodeToLocalStorage(ccpack); err != nil {
-		return err
+		return nil, err
 	}
 
 	logger.Infof("Installed Chaincode [%s] Version [%s] to peer", ccpack.GetChaincodeData().Name, ccpack.GetChaincodeData().Version)
 
-	return nil
+	return ccpack, nil
 }
 
 // executeDeployOrUpgrade routes the code path either to executeDeploy or executeUpgrade
@@ -638,10 +638,15 @@ func (lscc *lifeCycleSysCC) Invoke(stub shim.ChaincodeStubInterface) pb.Response
 
 		depSpec := args[1]
 
-		err := lscc.executeInstall(stub, depSpec)
+		ccpack, err := lscc.executeInstall(stub, depSpec)
 		if err != nil {
 			return shim.Error(err.Error())
 		}
+		// tar.gz packages are identified by their package ID, which is
+		// returned to the installer
+		if tgzpack, ok := ccpack.(*ccprovider.TGZPackage); ok {
+			return shim.Success([]byte(tgzpack.GetPackageID()))
+		}
 		return shim.Success([]byte("OK"))
 	case DEPLOY, UPGRADE:
 		// we expect a minimum of 3 arguments, the function
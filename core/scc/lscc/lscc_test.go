@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric/core/aclmgmt/mocks"
 	"github.com/hyperledger/fabric/core/aclmgmt/resources"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
+	"github.com/hyperledger/fabric/core/common/ccpackage"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/sysccprovider"
 	cutil "github.com/hyperledger/fabric/core/container/util"
@@ -130,6 +131,50 @@ func TestInstall(t *testing.T) {
 }
 
 func testInstall(t *testing.T, ccname string, version string, path string, createInvalidIndex bool, expectedErrorMsg string, caller string, scc *lifeCycleSysCC, stub *shim.MockStub) {
+	cds, err := constructDeploymentSpec(ccname, path, version, [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}, createInvalidIndex, false, scc)
+	assert.NoError(t, err)
+	b := utils.MarshalOrPanic(cds)
+
+	testInstallPackage(t, b, expectedErrorMsg, caller, scc, stub)
+}
+
+//TestInstallTGZ tests the install function with tar.gz packages
+func TestInstallTGZ(t *testing.T) {
+	cceventmgmt.Initialize()
+
+	scc := &lifeCycleSysCC{support: &lscc.MockSupport{}}
+	stub := shim.NewMockStub("lscc", scc)
+	res := stub.MockInit("1", nil)
+	assert.Equal(t, res.Status, int32(shim.OK), res.Message)
+
+	path := "github.com/hyperledger/fabric/examples/chaincode/go/chaincode_example02"
+
+	testInstallTGZ(t, "example02", "0", path, false, "", scc, stub)
+	testInstallTGZ(t, "example02.go", "0", path, false, InvalidChaincodeNameErr("example02.go").Error(), scc, stub)
+	testInstallTGZ(t, "example02", "1{}0", path, false, InvalidVersionErr("1{}0").Error(), scc, stub)
+	testInstallTGZ(t, "example02", "0", path, true, InvalidStatedbArtifactsErr("").Error(), scc, stub)
+
+	testInstallPackage(t, []byte{0x1f, 0x8b, 0}, "failed to open chaincode package", "Alice", scc, stub)
+}
+
+func testInstallTGZ(t *testing.T, ccname string, version string, path string, createInvalidIndex bool, expectedErrorMsg string, scc *lifeCycleSysCC, stub *shim.MockStub) {
+	cds, err := constructDeploymentSpec(ccname, path, version, nil, createInvalidIndex, false, scc)
+	assert.NoError(t, err)
+	tgz := &ccpackage.TGZ{
+		Metadata: &ccpackage.TGZMetadata{Type: "golang", Path: path, Label: "example", Name: ccname, Version: version},
+		Code:     cds.CodePackage,
+	}
+	b, err := tgz.Bytes()
+	assert.NoError(t, err)
+
+	res := testInstallPackage(t, b, expectedErrorMsg, "Alice", scc, stub)
+	if expectedErrorMsg == "" {
+		// the package ID is returned to the installer
+		assert.Equal(t, ccpackage.PackageID("example", b), string(res.Payload))
+	}
+}
+
+func testInstallPackage(t *testing.T, b []byte, expectedErrorMsg string, caller string, scc *lifeCycleSysCC, stub *shim.MockStub) pb.Response {
 	identityDeserializer := &policymocks.MockIdentityDeserializer{[]byte("Alice"), []byte("msg1")}
 	policyManagerGetter := &policymocks.MockChannelPolicyManagerGetter{
 		Managers: map[string]policies.Manager{
@@ -142,10 +187,6 @@ func testInstall(t *testing.T, ccname string, version string, path string, creat
 		&policymocks.MockMSPPrincipalGetter{Principal: []byte("Alice")},
 	)
 
-	cds, err := constructDeploymentSpec(ccname, path, version, [][]byte{[]byte("init"), []byte("a"), []byte("100"), []byte("b"), []byte("200")}, createInvalidIndex, false, scc)
-	assert.NoError(t, err)
-	b := utils.MarshalOrPanic(cds)
-
 	//constructDeploymentSpec puts the depspec on the FS. This should succeed
 	args := [][]byte{[]byte(INSTALL), b}
 
@@ -153,13 +194,13 @@ func testInstall(t *testing.T, ccname string, version string, path string, creat
 	identityDeserializer.Msg = sProp.ProposalBytes
 	sProp.Signature = sProp.ProposalBytes
 
+	res := stub.MockInvokeWithSignedProposal("1", args, sProp)
 	if expectedErrorMsg == "" {
-		res := stub.MockInvokeWithSignedProposal("1", args, sProp)
 		assert.Equal(t, res.Status, int32(shim.OK), res.Message)
 	} else {
-		res := stub.MockInvokeWithSignedProposal("1", args, sProp)
 		assert.True(t, strings.HasPrefix(string(res.Message), expectedErrorMsg), res.Message)
 	}
+	return res
 }
 
 func TestDeploy(t *testing.T) {
@@ -550,7 +591,7 @@ func TestExecuteInstall(t *testing.T) {
 	res := stub.MockInit("1", nil)
 	assert.Equal(t, res.Status, int32(shim.OK), res.Message)
 
-	err := scc.executeInstall(stub, []byte("barf"))
+	_, err := scc.executeInstall(stub, []byte("barf"))
 	assert.Error(t, err)
 }
 
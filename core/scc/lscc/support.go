@@ -22,11 +22,14 @@ type supportImpl struct {
 
 // PutChaincodeToLocalStorage stores the supplied chaincode
 // package to local storage (i.e. the file system)
+// and publishes it to the members of the peer's channels
 func (s *supportImpl) PutChaincodeToLocalStorage(ccpack ccprovider.CCPackage) error {
 	if err := ccpack.PutChaincodeToFS(); err != nil {
 		return errors.Errorf("Error installing chaincode code %s:%s(%s)", ccpack.GetChaincodeData().CCName(), ccpack.GetChaincodeData().CCVersion(), err)
 	}
 
+	peer.PublishInstalledChaincode(ccpack.GetChaincodeData().CCName(), ccpack.GetChaincodeData().CCVersion())
+
 	return nil
 }
 
@@ -47,13 +50,17 @@ func (s *supportImpl) GetChaincodesFromLocalStorage() (*pb.ChaincodeQueryRespons
 func (s *supportImpl) GetInstantiationPolicy(channel string, ccpack ccprovider.CCPackage) ([]byte, error) {
 	var ip []byte
 	var err error
-	// if ccpack is a SignedCDSPackage, return its IP, otherwise use a default IP
+	// if ccpack is a SignedCDSPackage, or a TGZPackage with an IP, return
+	// its IP, otherwise use a default IP
 	sccpack, isSccpack := ccpack.(*ccprovider.SignedCDSPackage)
+	tgzpack, isTgzpack := ccpack.(*ccprovider.TGZPackage)
 	if isSccpack {
 		ip = sccpack.GetInstantiationPolicy()
 		if ip == nil {
 			return nil, errors.Errorf("Instantiation policy cannot be null for a SignedCCDeploymentSpec")
 		}
+	} else if isTgzpack && tgzpack.GetInstantiationPolicy() != nil {
+		ip = tgzpack.GetInstantiationPolicy()
 	} else {
 		// the default instantiation policy allows any of the channel MSP admins
 		// to be able to instantiate
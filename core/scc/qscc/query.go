@@ -18,7 +18,9 @@ package qscc
 
 import (
 	"fmt"
+	"math"
 	"strconv"
+	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
 
@@ -35,6 +37,11 @@ import (
 // - GetBlockByNumber returns a block
 // - GetBlockByHash returns a block
 // - GetTransactionByID returns a transaction
+// - GetTxStatusesByBlockRange returns TransactionStatuses
+// - GetBlockByTimestamp returns a block
+// - GetTxStatusesByCreator returns TransactionStatuses
+// - GetTxStatusesByChaincode returns TransactionStatuses
+// - GetMVCCConflictByTxID returns TxMVCCConflict
 type LedgerQuerier struct {
 }
 
@@ -47,6 +54,12 @@ const (
 	GetBlockByHash     string = "GetBlockByHash"
 	GetTransactionByID string = "GetTransactionByID"
 	GetBlockByTxID     string = "GetBlockByTxID"
+
+	GetTxStatusesByBlockRange string = "GetTxStatusesByBlockRange"
+	GetBlockByTimestamp       string = "GetBlockByTimestamp"
+	GetTxStatusesByCreator    string = "GetTxStatusesByCreator"
+	GetTxStatusesByChaincode  string = "GetTxStatusesByChaincode"
+	GetMVCCConflictByTxID     string = "GetMVCCConflictByTxID"
 )
 
 // Init is called once per chain when the chain is created.
@@ -65,6 +78,15 @@ func (e *LedgerQuerier) Init(stub shim.ChaincodeStubInterface) pb.Response {
 // # GetBlockByNumber: Return the block specified by block number in args[2]
 // # GetBlockByHash: Return the block specified by block hash in args[2]
 // # GetTransactionByID: Return the transaction specified by ID in args[2]
+// # GetTxStatusesByBlockRange: Return the transactions of the blocks from args[2] to the optional args[3]
+// # GetBlockByTimestamp: Return the first block at or after the RFC 3339 timestamp in args[2]
+// # GetTxStatusesByCreator: Return the transactions created by the MSP in args[2]
+// # GetTxStatusesByChaincode: Return the transactions that touched the chaincode in args[2]
+// # GetMVCCConflictByTxID: Return the conflict that invalidated the transaction specified by ID in args[2]
+// The transactions are returned along with their validation codes. The functions that
+// return the transactions of a creator or chaincode take an optional block range in args[3:].
+// When a query finds too many transactions, it stops at a block boundary and returns the
+// number of the block from which the query can be continued.
 func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 	args := stub.GetArgs()
 
@@ -109,6 +131,16 @@ func (e *LedgerQuerier) Invoke(stub shim.ChaincodeStubInterface) pb.Response {
 		return getChainInfo(targetLedger)
 	case GetBlockByTxID:
 		return getBlockByTxID(targetLedger, args[2])
+	case GetTxStatusesByBlockRange:
+		return getTxStatusesByBlockRange(targetLedger, args[2:])
+	case GetBlockByTimestamp:
+		return getBlockByTimestamp(targetLedger, args[2])
+	case GetTxStatusesByCreator:
+		return getTxStatusesByCreator(targetLedger, args[2], args[3:])
+	case GetTxStatusesByChaincode:
+		return getTxStatusesByChaincode(targetLedger, args[2], args[3:])
+	case GetMVCCConflictByTxID:
+		return getMVCCConflictByTxID(targetLedger, args[2])
 	}
 
 	return shim.Error(fmt.Sprintf("Requested function %s not found.", fname))
@@ -208,6 +240,122 @@ func getBlockByTxID(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
 	return shim.Success(bytes)
 }
 
+func getTxStatusesByBlockRange(vledger ledger.PeerLedger, blockRange [][]byte) pb.Response {
+	startNum, endNum, err := parseBlockRange(blockRange)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	txStatuses, err := vledger.GetTxStatusesByBlockRange(startNum, endNum)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transactions of blocks %d to %d, error %s", startNum, endNum, err))
+	}
+	return marshalTxStatuses(txStatuses)
+}
+
+func getBlockByTimestamp(vledger ledger.PeerLedger, rawTimestamp []byte) pb.Response {
+	timestamp, err := time.Parse(time.RFC3339Nano, string(rawTimestamp))
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to parse timestamp with error %s", err))
+	}
+	block, err := vledger.GetBlockByTimestamp(timestamp)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get block at or after %s, error %s", timestamp.Format(time.RFC3339Nano), err))
+	}
+
+	bytes, err := utils.Marshal(block)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
+func getTxStatusesByCreator(vledger ledger.PeerLedger, rawMSPID []byte, blockRange [][]byte) pb.Response {
+	mspID := string(rawMSPID)
+	if mspID == "" {
+		return shim.Error("MSP ID must not be empty.")
+	}
+	startNum, endNum, err := parseBlockRange(blockRange)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	txStatuses, err := vledger.GetTxStatusesByCreator(mspID, startNum, endNum)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transactions created by %s, error %s", mspID, err))
+	}
+	return marshalTxStatuses(txStatuses)
+}
+
+func getTxStatusesByChaincode(vledger ledger.PeerLedger, rawCCName []byte, blockRange [][]byte) pb.Response {
+	ccName := string(rawCCName)
+	if ccName == "" {
+		return shim.Error("Chaincode name must not be empty.")
+	}
+	startNum, endNum, err := parseBlockRange(blockRange)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	txStatuses, err := vledger.GetTxStatusesByNamespace(ccName, startNum, endNum)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get transactions of chaincode %s, error %s", ccName, err))
+	}
+	return marshalTxStatuses(txStatuses)
+}
+
+func getMVCCConflictByTxID(vledger ledger.PeerLedger, rawTxID []byte) pb.Response {
+	txID := string(rawTxID)
+	block, err := vledger.GetBlockByTxID(txID)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get block for txID %s, error %s", txID, err))
+	}
+	conflicts, err := utils.GetMVCCConflictsFromBlock(block)
+	if err != nil {
+		return shim.Error(fmt.Sprintf("Failed to get MVCC conflicts of block %d, error %s", block.Header.Number, err))
+	}
+	for _, conflict := range conflicts.Conflicts {
+		if conflict.TxId != txID {
+			continue
+		}
+		bytes, err := utils.Marshal(conflict)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(bytes)
+	}
+
+	return shim.Error(fmt.Sprintf("No MVCC conflict was recorded for txID %s", txID))
+}
+
+// parseBlockRange parses the optional start and end block numbers of a range,
+// which default to the genesis block and the last block of the ledger respectively
+func parseBlockRange(blockRange [][]byte) (uint64, uint64, error) {
+	startNum, endNum := uint64(0), uint64(math.MaxUint64)
+	var err error
+	if len(blockRange) > 0 && len(blockRange[0]) > 0 {
+		if startNum, err = strconv.ParseUint(string(blockRange[0]), 10, 64); err != nil {
+			return 0, 0, fmt.Errorf("Failed to parse start block number with error %s", err)
+		}
+	}
+	if len(blockRange) > 1 && len(blockRange[1]) > 0 {
+		if endNum, err = strconv.ParseUint(string(blockRange[1]), 10, 64); err != nil {
+			return 0, 0, fmt.Errorf("Failed to parse end block number with error %s", err)
+		}
+	}
+	if startNum > endNum {
+		return 0, 0, fmt.Errorf("Start block number %d is greater than end block number %d", startNum, endNum)
+	}
+	return startNum, endNum, nil
+}
+
+func marshalTxStatuses(txStatuses *pb.TransactionStatuses) pb.Response {
+	bytes, err := utils.Marshal(txStatuses)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	return shim.Success(bytes)
+}
+
 func getACLResource(fname string) string {
 	return "QSCC." + fname
 }
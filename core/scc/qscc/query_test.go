@@ -19,7 +19,9 @@ import (
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/ledger/testutil"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/aclmgmt"
@@ -309,6 +311,121 @@ func TestQueryGeneratedBlock(t *testing.T) {
 	}
 }
 
+// TestQueryTxStatuses tests the queries that look up transactions
+// by block range, creator and chaincode, and blocks by timestamp
+func TestQueryTxStatuses(t *testing.T) {
+	chainid := "mytestchainid9"
+	path := "/var/hyperledger/test9/"
+	stub, err := setupTestLedger(chainid, path)
+	defer os.RemoveAll(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timeOfBlock1 := time.Now()
+	block1 := addBlockForTesting(t, chainid)
+	var txIDs []string
+	for _, d := range block1.Data.Data {
+		env, err := utils.GetEnvelopeFromBlock(d)
+		assert.NoError(t, err)
+		chdr, err := utils.ChannelHeader(env)
+		assert.NoError(t, err)
+		txIDs = append(txIDs, chdr.TxId)
+	}
+	invoke := func(res string, args ...string) peer2.Response {
+		rawArgs := [][]byte{}
+		for _, arg := range args {
+			rawArgs = append(rawArgs, []byte(arg))
+		}
+		prop := resetProvider(res, chainid, &peer2.SignedProposal{}, nil)
+		return stub.MockInvokeWithSignedProposal("1", rawArgs, prop)
+	}
+	txIDsOf := func(res peer2.Response) []string {
+		assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+		txStatuses := &peer2.TransactionStatuses{}
+		assert.NoError(t, proto.Unmarshal(res.Payload, txStatuses))
+		ids := []string{}
+		for _, txStatus := range txStatuses.Transactions {
+			assert.Equal(t, uint64(1), txStatus.BlockNumber)
+			assert.Equal(t, peer2.TxValidationCode_VALID, txStatus.ValidationCode)
+			ids = append(ids, txStatus.TxId)
+		}
+		return ids
+	}
+
+	// GetTxStatusesByBlockRange
+	res := invoke(resources.QSCC_GetTxStatusesByBlockRange, GetTxStatusesByBlockRange, chainid, "1")
+	assert.Equal(t, txIDs, txIDsOf(res))
+	res = invoke(resources.QSCC_GetTxStatusesByBlockRange, GetTxStatusesByBlockRange, chainid, "1", "1")
+	assert.Equal(t, txIDs, txIDsOf(res))
+	res = invoke(resources.QSCC_GetTxStatusesByBlockRange, GetTxStatusesByBlockRange, chainid, "2", "1")
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "Start block number 2 is greater than end block number 1")
+	res = invoke(resources.QSCC_GetTxStatusesByBlockRange, GetTxStatusesByBlockRange, chainid, "one")
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "Failed to parse start block number")
+
+	// GetBlockByTimestamp
+	res = invoke(resources.QSCC_GetBlockByTimestamp, GetBlockByTimestamp, chainid, timeOfBlock1.Format(time.RFC3339Nano))
+	assert.Equal(t, int32(shim.OK), res.Status, res.Message)
+	block := &common.Block{}
+	assert.NoError(t, proto.Unmarshal(res.Payload, block))
+	assert.Equal(t, uint64(1), block.Header.Number)
+	res = invoke(resources.QSCC_GetBlockByTimestamp, GetBlockByTimestamp, chainid, time.Now().Add(time.Hour).Format(time.RFC3339))
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	res = invoke(resources.QSCC_GetBlockByTimestamp, GetBlockByTimestamp, chainid, "yesterday")
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "Failed to parse timestamp")
+
+	// GetTxStatusesByChaincode
+	res = invoke(resources.QSCC_GetTxStatusesByChaincode, GetTxStatusesByChaincode, chainid, "ns2")
+	assert.Equal(t, txIDs[1:], txIDsOf(res))
+	res = invoke(resources.QSCC_GetTxStatusesByChaincode, GetTxStatusesByChaincode, chainid, "ns1", "0", "1")
+	assert.Equal(t, txIDs[:1], txIDsOf(res))
+	res = invoke(resources.QSCC_GetTxStatusesByChaincode, GetTxStatusesByChaincode, chainid, "ns1", "2")
+	assert.Empty(t, txIDsOf(res))
+	res = invoke(resources.QSCC_GetTxStatusesByChaincode, GetTxStatusesByChaincode, chainid, "")
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+
+	// GetTxStatusesByCreator
+	res = invoke(resources.QSCC_GetTxStatusesByCreator, GetTxStatusesByCreator, chainid, "Org1MSP", "0")
+	assert.Empty(t, txIDsOf(res))
+	res = invoke(resources.QSCC_GetTxStatusesByCreator, GetTxStatusesByCreator, chainid, "")
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+}
+
+func TestQueryGetMVCCConflictByTxID(t *testing.T) {
+	chainid := "mytestchainid10"
+	path := "/var/hyperledger/test10/"
+	stub, err := setupTestLedger(chainid, path)
+	defer os.RemoveAll(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block1 := addBlockForTesting(t, chainid)
+	env, err := utils.GetEnvelopeFromBlock(block1.Data.Data[0])
+	assert.NoError(t, err)
+	chdr, err := utils.ChannelHeader(env)
+	assert.NoError(t, err)
+
+	args := [][]byte{[]byte(GetMVCCConflictByTxID), []byte(chainid), []byte(chdr.TxId)}
+	prop := resetProvider(resources.QSCC_GetMVCCConflictByTxID, chainid, &peer2.SignedProposal{}, nil)
+	res := stub.MockInvokeWithSignedProposal("1", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+	assert.Contains(t, res.Message, "No MVCC conflict was recorded for txID "+chdr.TxId)
+
+	args = [][]byte{[]byte(GetMVCCConflictByTxID), []byte(chainid), []byte("nonexistent")}
+	prop = resetProvider(resources.QSCC_GetMVCCConflictByTxID, chainid, &peer2.SignedProposal{}, nil)
+	res = stub.MockInvokeWithSignedProposal("2", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+
+	args = [][]byte{[]byte(GetMVCCConflictByTxID), []byte(chainid)}
+	prop = resetProvider(resources.QSCC_GetMVCCConflictByTxID, chainid, &peer2.SignedProposal{}, nil)
+	res = stub.MockInvokeWithSignedProposal("3", args, prop)
+	assert.Equal(t, int32(shim.ERROR), res.Status)
+}
+
 func addBlockForTesting(t *testing.T, chainid string) *common.Block {
 	bg, _ := testutil.NewBlockGenerator(t, chainid, false)
 	ledger := peer.GetLedger(chainid)
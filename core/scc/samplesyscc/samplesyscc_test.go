@@ -239,6 +239,10 @@ func (stub *mockStub) GetPrivateData(collection string, key string) ([]byte, err
 	panic("implement me")
 }
 
+func (stub *mockStub) GetPrivateDataHash(collection string, key string) ([]byte, error) {
+	panic("implement me")
+}
+
 func (stub *mockStub) PutPrivateData(collection string, key string, value []byte) error {
 	panic("implement me")
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric/common/cauthdsl"
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/policies"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/core/common/privdata"
@@ -74,6 +75,19 @@ func (c *collectionStoreSupport) GetIdentityDeserializer(chainID string) m.Ident
 	return mspmgmt.GetIdentityDeserializer(chainID)
 }
 
+func (c *collectionStoreSupport) GetImplicitCollectionOrgs(chainID string) ([]string, error) {
+	ac, exists := c.GetApplicationConfig(chainID)
+	if !exists {
+		return nil, errors.Errorf("application config for channel %s not found", chainID)
+	}
+	return privdata.ImplicitCollectionOrgs(ac), nil
+}
+
+func (c *collectionStoreSupport) GetPolicyManager(chainID string) policies.Manager {
+	pm, _ := c.PolicyManager(chainID)
+	return pm
+}
+
 // Init is called once when the chaincode started the first time
 func (vscc *ValidatorOneValidSignature) Init(stub shim.ChaincodeStubInterface) pb.Response {
 	vscc.sccprovider = sysccprovider.GetSystemChaincodeProvider()
@@ -136,9 +150,14 @@ func (vscc *ValidatorOneValidSignature) Invoke(stub shim.ChaincodeStubInterface)
 		return shim.Error(err.Error())
 	}
 
-	// get the policy
+	// get the policy, whose policy references are resolved through the policies
+	// of the channel if the capabilities of the channel support them
 	mgr := mspmgmt.GetManagerForChain(chdr.ChannelId)
 	pProvider := cauthdsl.NewPolicyProvider(mgr)
+	if ac.Capabilities().SignaturePolicyExtensions() {
+		pm, _ := vscc.sccprovider.PolicyManager(chdr.ChannelId)
+		pProvider = cauthdsl.NewPolicyProviderWithPolicyManager(mgr, pm)
+	}
 	policy, _, err := pProvider.NewPolicy(args[2])
 	if err != nil {
 		logger.Errorf("VSCC error: pProvider.NewPolicy failed, err %s", err)
@@ -175,6 +194,7 @@ func (vscc *ValidatorOneValidSignature) Invoke(stub shim.ChaincodeStubInterface)
 		err = policy.Evaluate(signatureSet)
 		if err != nil {
 			logger.Warningf("Endorsement policy failure for transaction txid=%s, err: %s", chdr.GetTxId(), err.Error())
+			policies.DebugExplain(logger, "endorsement policy", policy, signatureSet)
 			if len(signatureSet) < len(cap.Action.Endorsements) {
 				// Warning: duplicated identities exist, endorsement failure might be cause by this reason
 				return shim.Error(DUPLICATED_IDENTITY_ERROR)
@@ -235,6 +255,7 @@ func (vscc *ValidatorOneValidSignature) checkInstantiationPolicy(chainName strin
 	}}
 	err = instPol.Evaluate(sd)
 	if err != nil {
+		policies.DebugExplain(logger, "instantiation policy", instPol, sd)
 		return fmt.Errorf("chaincode instantiation policy violated, error %s", err)
 	}
 	return nil
@@ -248,6 +269,7 @@ func (vscc *ValidatorOneValidSignature) validateDeployRWSetAndCollection(
 	cdRWSet *ccprovider.ChaincodeData,
 	lsccArgs [][]byte,
 	chid, ccid string,
+	ac channelconfig.ApplicationCapabilities,
 ) error {
 	/********************************************/
 	/* security check 0.a - validation of rwset */
@@ -302,6 +324,20 @@ func (vscc *ValidatorOneValidSignature) validateDeployRWSetAndCollection(
 			return errors.Errorf("invalid collection configuration supplied for chaincode %s:%s",
 				cdRWSet.Name, cdRWSet.Version)
 		}
+		// the names of implicit collections are reserved, as
+		// these collections exist without being defined
+		if ac.ImplicitCollections() {
+			for _, cconf := range collections.Config {
+				sconf := cconf.GetStaticCollectionConfig()
+				if sconf == nil {
+					continue
+				}
+				if _, isImplicit := privdata.MSPIDIfImplicitCollection(sconf.Name); isImplicit {
+					return errors.Errorf("collection %s of chaincode %s:%s uses the reserved prefix %s",
+						sconf.Name, cdRWSet.Name, cdRWSet.Version, privdata.ImplicitCollectionPrefix)
+				}
+			}
+		}
 	}
 
 	// TODO: FAB-6526 - to add validation of the collections object
@@ -443,7 +479,7 @@ func (vscc *ValidatorOneValidSignature) ValidateLSCCInvocation(
 			/****************************************************************************/
 			if ac.PrivateChannelData() {
 				// do extra validation for collections
-				err = vscc.validateDeployRWSetAndCollection(lsccrwset, cdRWSet, lsccArgs, chid, cdsArgs.ChaincodeSpec.ChaincodeId.Name)
+				err = vscc.validateDeployRWSetAndCollection(lsccrwset, cdRWSet, lsccArgs, chid, cdsArgs.ChaincodeSpec.ChaincodeId.Name, ac)
 				if err != nil {
 					return err
 				}
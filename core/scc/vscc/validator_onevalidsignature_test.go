@@ -389,7 +389,7 @@ func TestInvalidFunction(t *testing.T) {
 	sysccprovider.RegisterSystemChaincodeProviderFactory(&scc.MocksccProviderFactory{
 		Qe: lm.NewMockQueryExecutor(State),
 		ApplicationConfigBool: true,
-		ApplicationConfigRv:   &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		ApplicationConfigRv:   &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 	})
 	stub.MockPeerChaincode("lscc", stublccc)
 
@@ -445,7 +445,7 @@ func TestRWSetTooBig(t *testing.T) {
 	sysccprovider.RegisterSystemChaincodeProviderFactory(&scc.MocksccProviderFactory{
 		Qe: lm.NewMockQueryExecutor(State),
 		ApplicationConfigBool: true,
-		ApplicationConfigRv:   &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		ApplicationConfigRv:   &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 	})
 	stub.MockPeerChaincode("lscc", stublccc)
 
@@ -514,7 +514,7 @@ func TestValidateDeployFail(t *testing.T) {
 	sysccprovider.RegisterSystemChaincodeProviderFactory(&scc.MocksccProviderFactory{
 		Qe: lm.NewMockQueryExecutor(State),
 		ApplicationConfigBool: true,
-		ApplicationConfigRv:   &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		ApplicationConfigRv:   &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 	})
 	stub.MockPeerChaincode("lscc", stublccc)
 
@@ -803,7 +803,7 @@ func TestAlreadyDeployed(t *testing.T) {
 	sysccprovider.RegisterSystemChaincodeProviderFactory(&scc.MocksccProviderFactory{
 		Qe: lm.NewMockQueryExecutor(State),
 		ApplicationConfigBool: true,
-		ApplicationConfigRv:   &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		ApplicationConfigRv:   &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 	})
 	stub.MockPeerChaincode("lscc", stublccc)
 
@@ -882,7 +882,7 @@ func TestValidateDeployNoLedger(t *testing.T) {
 	sysccprovider.RegisterSystemChaincodeProviderFactory(&scc.MocksccProviderFactory{
 		QErr: fmt.Errorf("Simulated error"),
 		ApplicationConfigBool: true,
-		ApplicationConfigRv:   &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		ApplicationConfigRv:   &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 	})
 	stub.MockPeerChaincode("lscc", stublccc)
 
@@ -940,7 +940,7 @@ func TestValidateDeployOK(t *testing.T) {
 	sysccprovider.RegisterSystemChaincodeProviderFactory(&scc.MocksccProviderFactory{
 		Qe: lm.NewMockQueryExecutor(State),
 		ApplicationConfigBool: true,
-		ApplicationConfigRv:   &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		ApplicationConfigRv:   &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 	})
 	stub.MockPeerChaincode("lscc", stublccc)
 
@@ -998,7 +998,7 @@ func TestValidateDeployWithPolicies(t *testing.T) {
 	sysccprovider.RegisterSystemChaincodeProviderFactory(&scc.MocksccProviderFactory{
 		Qe: lm.NewMockQueryExecutor(State),
 		ApplicationConfigBool: true,
-		ApplicationConfigRv:   &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		ApplicationConfigRv:   &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 	})
 	stub.MockPeerChaincode("lscc", stublccc)
 
@@ -1086,7 +1086,7 @@ func TestInvalidUpgrade(t *testing.T) {
 	sysccprovider.RegisterSystemChaincodeProviderFactory(&scc.MocksccProviderFactory{
 		Qe: lm.NewMockQueryExecutor(State),
 		ApplicationConfigBool: true,
-		ApplicationConfigRv:   &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		ApplicationConfigRv:   &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 	})
 	stub.MockPeerChaincode("lscc", stublccc)
 
@@ -1142,7 +1142,7 @@ func TestValidateUpgradeOK(t *testing.T) {
 	sysccprovider.RegisterSystemChaincodeProviderFactory(&scc.MocksccProviderFactory{
 		Qe: lm.NewMockQueryExecutor(State),
 		ApplicationConfigBool: true,
-		ApplicationConfigRv:   &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		ApplicationConfigRv:   &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 	})
 	stub.MockPeerChaincode("lscc", stublccc)
 
@@ -1223,7 +1223,7 @@ func TestInvalidateUpgradeBadVersion(t *testing.T) {
 	sysccprovider.RegisterSystemChaincodeProviderFactory(&scc.MocksccProviderFactory{
 		Qe: lm.NewMockQueryExecutor(State),
 		ApplicationConfigBool: true,
-		ApplicationConfigRv:   &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		ApplicationConfigRv:   &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 	})
 	stub.MockPeerChaincode("lscc", stublccc)
 
@@ -1302,7 +1302,7 @@ func TestValidateUpgradeWithPoliciesOK(t *testing.T) {
 	sysccprovider.RegisterSystemChaincodeProviderFactory(&scc.MocksccProviderFactory{
 		Qe: lm.NewMockQueryExecutor(State),
 		ApplicationConfigBool: true,
-		ApplicationConfigRv:   &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		ApplicationConfigRv:   &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 	})
 	stub.MockPeerChaincode("lscc", stublccc)
 
@@ -1400,7 +1400,7 @@ func validateUpgradeWithNewFailAllIP(t *testing.T, v11capability, expecterr bool
 	sysccprovider.RegisterSystemChaincodeProviderFactory(&scc.MocksccProviderFactory{
 		Qe: lm.NewMockQueryExecutor(State),
 		ApplicationConfigBool: true,
-		ApplicationConfigRv:   &mc.MockApplication{&mc.MockApplicationCapabilities{V1_1ValidationRv: v11capability}},
+		ApplicationConfigRv:   &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{V1_1ValidationRv: v11capability}},
 	})
 	stub.MockPeerChaincode("lscc", stublccc)
 
@@ -1497,7 +1497,7 @@ func TestValidateUpgradeWithPoliciesFail(t *testing.T) {
 	sysccprovider.RegisterSystemChaincodeProviderFactory(&scc.MocksccProviderFactory{
 		Qe: lm.NewMockQueryExecutor(State),
 		ApplicationConfigBool: true,
-		ApplicationConfigRv:   &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		ApplicationConfigRv:   &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 	})
 	stub.MockPeerChaincode("lscc", stublccc)
 
@@ -1614,40 +1614,41 @@ func TestValidateDeployRWSetAndCollection(t *testing.T) {
 		t.FailNow()
 	}
 
+	ac := &mc.MockApplicationCapabilities{}
 	rwset := &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "a"}, {Key: "b"}, {Key: "c"}}}
 
-	err := v.validateDeployRWSetAndCollection(rwset, nil, nil, chid, ccid)
+	err := v.validateDeployRWSetAndCollection(rwset, nil, nil, chid, ccid, ac)
 	assert.Error(t, err)
 
 	rwset = &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "a"}, {Key: "b"}}}
 
-	err = v.validateDeployRWSetAndCollection(rwset, cd, nil, chid, ccid)
+	err = v.validateDeployRWSetAndCollection(rwset, cd, nil, chid, ccid, ac)
 	assert.Error(t, err)
 
 	rwset = &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "a"}}}
 
-	err = v.validateDeployRWSetAndCollection(rwset, cd, nil, chid, ccid)
+	err = v.validateDeployRWSetAndCollection(rwset, cd, nil, chid, ccid, ac)
 	assert.NoError(t, err)
 
 	lsccargs := [][]byte{nil, nil, nil, nil, nil, nil}
 
-	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid)
+	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid, ac)
 	assert.NoError(t, err)
 
 	rwset = &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "a"}, {Key: privdata.BuildCollectionKVSKey("mycc")}}}
 
-	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid)
+	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid, ac)
 	assert.NoError(t, err)
 
 	lsccargs = [][]byte{nil, nil, nil, nil, nil, []byte("barf")}
 
-	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid)
+	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid, ac)
 	assert.Error(t, err)
 
 	lsccargs = [][]byte{nil, nil, nil, nil, nil, []byte("barf")}
 	rwset = &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "a"}, {Key: privdata.BuildCollectionKVSKey("mycc"), Value: []byte("barf")}}}
 
-	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid)
+	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid, ac)
 	assert.Error(t, err)
 
 	cc := &common.CollectionConfig{Payload: &common.CollectionConfig_StaticCollectionConfig{&common.StaticCollectionConfig{Name: "mycollection"}}}
@@ -1659,17 +1660,32 @@ func TestValidateDeployRWSetAndCollection(t *testing.T) {
 	lsccargs = [][]byte{nil, nil, nil, nil, nil, ccpBytes}
 	rwset = &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "a"}, {Key: privdata.BuildCollectionKVSKey("mycc"), Value: ccpBytes}}}
 
-	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid)
+	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid, ac)
 	assert.NoError(t, err)
 
+	implicitcc := &common.CollectionConfig{Payload: &common.CollectionConfig_StaticCollectionConfig{&common.StaticCollectionConfig{Name: privdata.ImplicitCollectionNameForOrg("Org1MSP")}}}
+	implicitccpBytes, err := proto.Marshal(&common.CollectionConfigPackage{[]*common.CollectionConfig{cc, implicitcc}})
+	assert.NoError(t, err)
+
+	implicitlsccargs := [][]byte{nil, nil, nil, nil, nil, implicitccpBytes}
+	implicitrwset := &kvrwset.KVRWSet{Writes: []*kvrwset.KVWrite{{Key: "a"}, {Key: privdata.BuildCollectionKVSKey("mycc"), Value: implicitccpBytes}}}
+
+	// the names of implicit collections are only reserved with the V1_2 capability
+	err = v.validateDeployRWSetAndCollection(implicitrwset, cd, implicitlsccargs, chid, ccid, ac)
+	assert.NoError(t, err)
+
+	err = v.validateDeployRWSetAndCollection(implicitrwset, cd, implicitlsccargs, chid, ccid, &mc.MockApplicationCapabilities{ImplicitCollectionsRv: true})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "reserved prefix")
+
 	State["lscc"][(&collectionStoreSupport{v.sccprovider}).GetCollectionKVSKey(common.CollectionCriteria{Channel: chid, Namespace: ccid})] = []byte("barf")
 
-	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid)
+	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid, ac)
 	assert.Error(t, err)
 
 	State["lscc"][(&collectionStoreSupport{v.sccprovider}).GetCollectionKVSKey(common.CollectionCriteria{Channel: chid, Namespace: ccid})] = ccpBytes
 
-	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid)
+	err = v.validateDeployRWSetAndCollection(rwset, cd, lsccargs, chid, ccid, ac)
 	assert.Error(t, err)
 }
 
@@ -1679,7 +1695,7 @@ func TestMain(m *testing.M) {
 	ccprovider.SetChaincodesPath(lccctestpath)
 	sysccprovider.RegisterSystemChaincodeProviderFactory(&scc.MocksccProviderFactory{
 		ApplicationConfigBool: true,
-		ApplicationConfigRv:   &mc.MockApplication{&mc.MockApplicationCapabilities{}},
+		ApplicationConfigRv:   &mc.MockApplication{CapabilitiesRv: &mc.MockApplicationCapabilities{}},
 	})
 	policy.RegisterPolicyCheckerFactory(&mockPolicyCheckerFactory{})
 
@@ -34,14 +34,27 @@ func (mi *msgImpl) IsDeclaration() bool {
 	return mi.msg.GetLeadershipMsg().IsDeclaration
 }
 
+func (mi *msgImpl) Weight() uint64 {
+	return mi.msg.GetLeadershipMsg().Weight
+}
+
+func (mi *msgImpl) LedgerHeight() uint64 {
+	return mi.msg.GetLeadershipMsg().LedgerHeight
+}
+
 type peerImpl struct {
 	member discovery.NetworkMember
+	height uint64
 }
 
 func (pi *peerImpl) ID() peerID {
 	return peerID(pi.member.PKIid)
 }
 
+func (pi *peerImpl) LedgerHeight() uint64 {
+	return pi.height
+}
+
 type gossip interface {
 	// Peers returns the NetworkMembers considered alive
 	Peers() []discovery.NetworkMember
@@ -54,6 +67,13 @@ type gossip interface {
 
 	// Gossip sends a message to other peers to the network
 	Gossip(msg *proto.GossipMessage)
+
+	// PeersOfChannel returns the NetworkMembers considered alive
+	// and also subscribed to the channel given
+	PeersOfChannel(common.ChainID) []discovery.NetworkMember
+
+	// SelfChannelInfo returns the peer's latest StateInfo message of a given channel
+	SelfChannelInfo(common.ChainID) *proto.SignedGossipMessage
 }
 
 type adapterImpl struct {
@@ -65,6 +85,8 @@ type adapterImpl struct {
 
 	channel common.ChainID
 
+	weight uint64
+
 	logger *logging.Logger
 
 	doneCh   chan struct{}
@@ -82,6 +104,8 @@ func NewAdapter(gossip gossip, pkiid common.PKIidType, channel common.ChainID) L
 
 		channel: channel,
 
+		weight: getWeight(),
+
 		logger: util.GetLogger(util.LoggingElectionModule, ""),
 
 		doneCh:   make(chan struct{}),
@@ -131,6 +155,8 @@ func (ai *adapterImpl) CreateMessage(isDeclaration bool) Msg {
 			IncNum: ai.incTime,
 			SeqNum: seqNum,
 		},
+		Weight:       ai.weight,
+		LedgerHeight: ai.LedgerHeight(),
 	}
 
 	msg := &proto.GossipMessage{
@@ -145,14 +171,33 @@ func (ai *adapterImpl) CreateMessage(isDeclaration bool) Msg {
 func (ai *adapterImpl) Peers() []Peer {
 	peers := ai.gossip.Peers()
 
+	heights := make(map[string]uint64)
+	for _, member := range ai.gossip.PeersOfChannel(ai.channel) {
+		if member.Properties != nil {
+			heights[string(member.PKIid)] = member.Properties.LedgerHeight
+		}
+	}
+
 	var res []Peer
 	for _, peer := range peers {
-		res = append(res, &peerImpl{peer})
+		res = append(res, &peerImpl{member: peer, height: heights[string(peer.PKIid)]})
 	}
 
 	return res
 }
 
+func (ai *adapterImpl) Weight() uint64 {
+	return ai.weight
+}
+
+func (ai *adapterImpl) LedgerHeight() uint64 {
+	stateInfo := ai.gossip.SelfChannelInfo(ai.channel)
+	if stateInfo == nil || stateInfo.GetStateInfo() == nil {
+		return 0
+	}
+	return stateInfo.GetStateInfo().GetProperties().GetLedgerHeight()
+}
+
 func (ai *adapterImpl) Stop() {
 	stopFunc := func() {
 		close(ai.doneCh)
@@ -18,6 +18,8 @@ import (
 	"github.com/hyperledger/fabric/gossip/discovery"
 	"github.com/hyperledger/fabric/gossip/util"
 	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
 )
 
 func init() {
@@ -94,6 +96,33 @@ func TestAdapterImpl_Peers(t *testing.T) {
 
 }
 
+func TestAdapterImpl_LedgerHeights(t *testing.T) {
+	cluster, adapters := createCluster(0, 1, 2)
+	for i, peerEndpoint := range []string{"Peer0", "Peer1", "Peer2"} {
+		cluster.peersGossip[peerEndpoint].height = uint64(10 * (i + 1))
+	}
+
+	adapter := adapters["Peer1"]
+	assert.Equal(t, uint64(20), adapter.LedgerHeight())
+	msg := adapter.CreateMessage(true)
+	assert.Equal(t, uint64(20), msg.LedgerHeight())
+	assert.Equal(t, uint64(20), msg.(*msgImpl).msg.GetLeadershipMsg().LedgerHeight)
+
+	for _, peer := range adapter.Peers() {
+		assert.Equal(t, uint64(10*(int(peer.ID()[0])+1)), peer.LedgerHeight())
+	}
+}
+
+func TestAdapterImpl_Weight(t *testing.T) {
+	viper.Set("peer.gossip.election.weight", 7)
+	defer viper.Set("peer.gossip.election.weight", 0)
+	_, adapters := createCluster(0)
+
+	adapter := adapters["Peer0"]
+	assert.Equal(t, uint64(7), adapter.Weight())
+	assert.Equal(t, uint64(7), adapter.CreateMessage(false).Weight())
+}
+
 func TestAdapterImpl_Stop(t *testing.T) {
 	_, adapters := createCluster(0, 1, 2, 3, 4, 5)
 
@@ -166,6 +195,7 @@ type peerMockGossip struct {
 	acceptorLock *sync.RWMutex
 	clusterLock  *sync.RWMutex
 	id           string
+	height       uint64
 }
 
 func (g *peerMockGossip) Peers() []discovery.NetworkMember {
@@ -189,6 +219,38 @@ func (g *peerMockGossip) Peers() []discovery.NetworkMember {
 	return res
 }
 
+func (g *peerMockGossip) PeersOfChannel(common.ChainID) []discovery.NetworkMember {
+	g.clusterLock.RLock()
+	if g.cluster == nil {
+		g.clusterLock.RUnlock()
+		return nil
+	}
+	peerLock := g.cluster.peersLock
+	g.clusterLock.RUnlock()
+
+	peerLock.RLock()
+	res := make([]discovery.NetworkMember, 0)
+	g.clusterLock.RLock()
+	for _, val := range g.cluster.peersGossip {
+		member := *val.member
+		member.Properties = &proto.Properties{LedgerHeight: val.height}
+		res = append(res, member)
+	}
+	g.clusterLock.RUnlock()
+	peerLock.RUnlock()
+	return res
+}
+
+func (g *peerMockGossip) SelfChannelInfo(common.ChainID) *proto.SignedGossipMessage {
+	return &proto.SignedGossipMessage{
+		GossipMessage: &proto.GossipMessage{
+			Content: &proto.GossipMessage_StateInfo{
+				StateInfo: &proto.StateInfo{Properties: &proto.Properties{LedgerHeight: g.height}},
+			},
+		},
+	}
+}
+
 func (g *peerMockGossip) Accept(acceptor common.MessageAcceptor, passThrough bool) (<-chan *proto.GossipMessage, <-chan proto.ReceivedMessage) {
 	ch := make(chan *proto.GossipMessage, 100)
 	g.acceptorLock.Lock()
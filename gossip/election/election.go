@@ -19,14 +19,17 @@ import (
 
 // Gossip leader election module
 // Algorithm properties:
-// - Peers break symmetry by comparing IDs
+// - Peers break symmetry by comparing their weights, then whether their ledgers
+//   are behind the highest ledger height by more than a threshold, and then their IDs
 // - Each peer is either a leader or a follower,
-//   and the aim is to have exactly 1 leader if the membership view
+//   and the aim is to have exactly N leaders (1 by default) if the membership view
 //   is the same for all peers
 // - If the network is partitioned into 2 or more sets, the number of leaders
-//   is the number of network partitions, but when the partition heals,
-//   only 1 leader should be left eventually
+//   is N times the number of network partitions, but when the partition heals,
+//   only N leaders should be left eventually
 // - Peers communicate by gossiping leadership proposal or declaration messages
+// - A leader whose ledger falls behind the ledger of another peer by more than
+//   the threshold yields its leadership
 
 // The Algorithm, in pseudo code:
 //
@@ -49,23 +52,26 @@ import (
 //		If leaderKnown is false:
 // 			LeaderElection()
 //		If you are the leader:
+//			If your ledger is behind the ledger of another peer
+//			by more than a threshold:
+//				yield
 //			Broadcast leadership declaration
-//			If a leadership declaration was received from
-// 			a peer with a lower ID,
+//			If leadership declarations were received from
+// 			N peers that are better candidates than yourself,
 //			become a follower
 //		Else, you're a follower:
-//			If haven't received a leadership declaration within
-// 			a time threshold:
+//			If haven't received leadership declarations from N peers
+// 			within a time threshold:
 //				set leaderKnown to false
 //
 // LeaderElection():
 // 	Gossip leadership proposal message
 //	Collect messages from other peers sent within a time period
-//	If received a leadership declaration:
+//	If received leadership declarations from N peers:
 //		return
-//	Iterate over all proposal messages collected.
-// 	If a proposal message from a peer with an ID lower
-// 	than yourself was received, return.
+//	Iterate over all proposal and declaration messages collected.
+// 	If N messages from peers that are better candidates
+// 	than yourself were received, return.
 //	Else, declare yourself a leader
 
 // LeaderElectionAdapter is used by the leader election module
@@ -82,6 +88,12 @@ type LeaderElectionAdapter interface {
 
 	// Peers returns a list of peers considered alive
 	Peers() []Peer
+
+	// Weight returns the weight of the peer
+	Weight() uint64
+
+	// LedgerHeight returns the ledger height of the peer
+	LedgerHeight() uint64
 }
 
 type leadershipCallback func(isLeader bool)
@@ -105,6 +117,8 @@ type peerID []byte
 type Peer interface {
 	// ID returns the ID of the peer
 	ID() peerID
+	// LedgerHeight returns the ledger height of the peer
+	LedgerHeight() uint64
 }
 
 // Msg describes a message sent from a remote peer
@@ -115,6 +129,57 @@ type Msg interface {
 	IsProposal() bool
 	// IsDeclaration returns whether this message is a leadership declaration
 	IsDeclaration() bool
+	// Weight returns the weight of the peer sent the message
+	Weight() uint64
+	// LedgerHeight returns the ledger height of the peer sent the message
+	LedgerHeight() uint64
+}
+
+// candidate describes a peer competing for leadership
+type candidate struct {
+	id     peerID
+	weight uint64
+	height uint64
+}
+
+func candidateOf(msg Msg) candidate {
+	return candidate{id: msg.SenderID(), weight: msg.Weight(), height: msg.LedgerHeight()}
+}
+
+// isBetterThan returns whether the candidate is preferred over the given candidate.
+// A candidate with a higher weight is preferred, and among candidates with
+// the same weight, a candidate whose ledger height is at least the given
+// minimal height is preferred over a candidate whose ledger is behind it.
+// Otherwise the candidate with the lower ID is preferred.
+// Since candidates are compared by their weights, whether their ledgers are
+// behind and their IDs in turn, the relation is transitive.
+func (c candidate) isBetterThan(o candidate, minHeight uint64) bool {
+	if c.weight != o.weight {
+		return c.weight > o.weight
+	}
+	if cBehind, oBehind := c.height < minHeight, o.height < minHeight; cBehind != oBehind {
+		return oBehind
+	}
+	return bytes.Compare(c.id, o.id) < 0
+}
+
+// minLeaderHeight returns the ledger height below which a candidate is considered
+// behind the others, which is the maximal ledger height among the given candidates
+// minus the given threshold. No candidate is behind if the threshold is 0.
+func minLeaderHeight(self candidate, candidates map[string]candidate, heightThreshold uint64) uint64 {
+	if heightThreshold == 0 {
+		return 0
+	}
+	maxHeight := self.height
+	for _, c := range candidates {
+		if c.height > maxHeight {
+			maxHeight = c.height
+		}
+	}
+	if maxHeight < heightThreshold {
+		return 0
+	}
+	return maxHeight - heightThreshold
 }
 
 func noopCallback(_ bool) {
@@ -122,12 +187,18 @@ func noopCallback(_ bool) {
 
 // NewLeaderElectionService returns a new LeaderElectionService
 func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback leadershipCallback) LeaderElectionService {
+	return newLeaderElectionService(adapter, id, callback, getLeadersPerOrg())
+}
+
+func newLeaderElectionService(adapter LeaderElectionAdapter, id string, callback leadershipCallback, leadersPerOrg int) LeaderElectionService {
 	if len(id) == 0 {
 		panic("Empty id")
 	}
 	le := &leaderElectionSvcImpl{
 		id:            peerID(id),
-		proposals:     util.NewSet(),
+		proposals:     make(map[string]candidate),
+		leaders:       make(map[string]candidate),
+		leadersPerOrg: leadersPerOrg,
 		adapter:       adapter,
 		stopChan:      make(chan struct{}, 1),
 		interruptChan: make(chan struct{}, 1),
@@ -145,8 +216,10 @@ func NewLeaderElectionService(adapter LeaderElectionAdapter, id string, callback
 
 // leaderElectionSvcImpl is an implementation of a LeaderElectionService
 type leaderElectionSvcImpl struct {
-	id        peerID
-	proposals *util.Set
+	id            peerID
+	proposals     map[string]candidate
+	leaders       map[string]candidate
+	leadersPerOrg int
 	sync.Mutex
 	stopChan      chan struct{}
 	interruptChan chan struct{}
@@ -195,17 +268,19 @@ func (le *leaderElectionSvcImpl) handleMessage(msg Msg) {
 		msgType = "declaration"
 	}
 	le.logger.Debug(le.id, ":", msg.SenderID(), "sent us", msgType)
+	// The ledger height is read before taking the lock
+	self := le.self()
 	le.Lock()
 	defer le.Unlock()
 
 	if msg.IsProposal() {
-		le.proposals.Add(string(msg.SenderID()))
+		le.proposals[string(msg.SenderID())] = candidateOf(msg)
 	} else if msg.IsDeclaration() {
-		atomic.StoreInt32(&le.leaderExists, int32(1))
-		if le.sleeping && len(le.interruptChan) == 0 {
+		le.addLeader(candidateOf(msg))
+		if le.isLeaderExists() && le.sleeping && len(le.interruptChan) == 0 {
 			le.interruptChan <- struct{}{}
 		}
-		if bytes.Compare(msg.SenderID(), le.id) < 0 && le.IsLeader() {
+		if le.IsLeader() && betterCandidates(self, le.leaders) >= le.leadersPerOrg {
 			le.stopBeingLeader()
 		}
 	} else {
@@ -242,7 +317,7 @@ func (le *leaderElectionSvcImpl) waitForInterrupt(timeout time.Duration) {
 func (le *leaderElectionSvcImpl) run() {
 	defer le.stopWG.Done()
 	for !le.shouldStop() {
-		if !le.isLeaderExists() {
+		if !le.IsLeader() && !le.isLeaderExists() {
 			le.leaderElection()
 		}
 		// If we are yielding and some leader has been elected,
@@ -284,18 +359,77 @@ func (le *leaderElectionSvcImpl) leaderElection() {
 		le.logger.Debug(le.id, ": Aborting leader election because yielding")
 		return
 	}
-	// Leader doesn't exist, let's see if there is a better candidate than us
-	// for being a leader
-	for _, o := range le.proposals.ToArray() {
-		id := o.(string)
-		if bytes.Compare(peerID(id), le.id) < 0 {
-			return
-		}
+	// Not enough leaders exist, let's see if there are enough
+	// better candidates than us for being a leader
+	le.Lock()
+	candidates := make(map[string]candidate, len(le.proposals)+len(le.leaders))
+	for id, c := range le.proposals {
+		candidates[id] = c
+	}
+	for id, c := range le.leaders {
+		candidates[id] = c
 	}
-	// If we got here, there is no one that proposed being a leader
-	// that's a better candidate than us.
+	le.Unlock()
+	if betterCandidates(le.self(), candidates) >= le.leadersPerOrg {
+		return
+	}
+	// If we got here, there are not enough peers that proposed being
+	// a leader or declared themselves as leaders that are better candidates than us.
 	le.beLeader()
-	atomic.StoreInt32(&le.leaderExists, int32(1))
+	le.Lock()
+	le.addLeader(le.self())
+	le.Unlock()
+}
+
+// self returns the peer as a candidate for leadership
+func (le *leaderElectionSvcImpl) self() candidate {
+	return candidate{id: le.id, weight: le.adapter.Weight(), height: le.adapter.LedgerHeight()}
+}
+
+// betterCandidates returns the number of the given candidates
+// that are better candidates for being a leader than us
+func betterCandidates(self candidate, candidates map[string]candidate) int {
+	minHeight := minLeaderHeight(self, candidates, getLeaderHeightThreshold())
+	count := 0
+	for _, c := range candidates {
+		if c.isBetterThan(self, minHeight) {
+			count++
+		}
+	}
+	return count
+}
+
+// addLeader records the given candidate as a leader,
+// and is expected to be called while holding the lock
+func (le *leaderElectionSvcImpl) addLeader(c candidate) {
+	le.leaders[string(c.id)] = c
+	if len(le.leaders) >= le.leadersPerOrg {
+		atomic.StoreInt32(&le.leaderExists, int32(1))
+	}
+}
+
+// clearLeaders forgets the leaders that declared themselves,
+// and is expected to be called while holding the lock
+func (le *leaderElectionSvcImpl) clearLeaders() {
+	le.leaders = make(map[string]candidate)
+	atomic.StoreInt32(&le.leaderExists, int32(0))
+}
+
+// isLaggingBehind returns whether the ledger of some peer
+// is ahead of our ledger by more than the threshold
+func (le *leaderElectionSvcImpl) isLaggingBehind() bool {
+	threshold := getLeaderHeightThreshold()
+	if threshold == 0 {
+		return false
+	}
+	height := le.adapter.LedgerHeight()
+	for _, p := range le.adapter.Peers() {
+		if p.LedgerHeight() > height+threshold {
+			le.logger.Info(le.id, ": Ledger height", height, "is behind the ledger height", p.LedgerHeight(), "of", p.ID())
+			return true
+		}
+	}
+	return false
 }
 
 // propose sends a leadership proposal message to remote peers
@@ -310,8 +444,10 @@ func (le *leaderElectionSvcImpl) follower() {
 	le.logger.Debug(le.id, ": Entering")
 	defer le.logger.Debug(le.id, ": Exiting")
 
-	le.proposals.Clear()
-	atomic.StoreInt32(&le.leaderExists, int32(0))
+	le.Lock()
+	le.proposals = make(map[string]candidate)
+	le.clearLeaders()
+	le.Unlock()
 	select {
 	case <-time.After(getLeaderAliveThreshold()):
 	case <-le.stopChan:
@@ -320,6 +456,16 @@ func (le *leaderElectionSvcImpl) follower() {
 }
 
 func (le *leaderElectionSvcImpl) leader() {
+	if le.isLaggingBehind() {
+		le.logger.Info(le.id, ": Yielding leadership to a peer with a more up to date ledger")
+		le.Yield()
+		return
+	}
+	// Only the leaders that declare themselves in this round are counted
+	le.Lock()
+	le.clearLeaders()
+	le.addLeader(le.self())
+	le.Unlock()
 	leaderDeclaration := le.adapter.CreateMessage(true)
 	le.adapter.Gossip(leaderDeclaration)
 	le.waitForInterrupt(getLeadershipDeclarationInterval())
@@ -411,8 +557,8 @@ func (le *leaderElectionSvcImpl) Yield() {
 	atomic.StoreInt32(&le.yield, int32(1))
 	// Stop being a leader
 	le.stopBeingLeader()
-	// Clear the leader exists flag since it could be that we are the leader
-	atomic.StoreInt32(&le.leaderExists, int32(0))
+	// Clear the leaders since it could be that we are a leader
+	le.clearLeaders()
 	// Clear the yield flag in any case afterwards
 	le.yieldTimer = time.AfterFunc(getLeaderAliveThreshold()*6, func() {
 		atomic.StoreInt32(&le.yield, int32(0))
@@ -471,6 +617,35 @@ func getLeaderElectionDuration() time.Duration {
 	return util.GetDurationOrDefault("peer.gossip.election.leaderElectionDuration", time.Second*5)
 }
 
+// SetLeadersPerOrg configures the number of leaders elected in each organization
+func SetLeadersPerOrg(n int) {
+	viper.Set("peer.gossip.election.leadersPerOrg", n)
+}
+
+// SetLeaderHeightThreshold configures the number of blocks the ledger of a peer
+// needs to be ahead of the ledger of another peer in order to be preferred as a leader
+func SetLeaderHeightThreshold(threshold uint64) {
+	viper.Set("peer.gossip.election.leaderHeightThreshold", int(threshold))
+}
+
+func getLeadersPerOrg() int {
+	if n := viper.GetInt("peer.gossip.election.leadersPerOrg"); n > 0 {
+		return n
+	}
+	return 1
+}
+
+func getLeaderHeightThreshold() uint64 {
+	if n := viper.GetInt("peer.gossip.election.leaderHeightThreshold"); n > 0 {
+		return uint64(n)
+	}
+	return 0
+}
+
+func getWeight() uint64 {
+	return uint64(viper.GetInt("peer.gossip.election.weight"))
+}
+
 // GetMsgExpirationTimeout return leadership message expiration timeout
 func GetMsgExpirationTimeout() time.Duration {
 	return getLeaderAliveThreshold() * 10
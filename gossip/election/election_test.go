@@ -32,11 +32,14 @@ func init() {
 	SetMembershipSampleInterval(time.Millisecond * 100)
 	SetLeaderAliveThreshold(time.Millisecond * 500)
 	SetLeaderElectionDuration(time.Millisecond * 500)
+	SetLeaderHeightThreshold(5)
 }
 
 type msg struct {
 	sender   string
 	proposal bool
+	weight   uint64
+	height   uint64
 }
 
 func (m *msg) SenderID() peerID {
@@ -51,6 +54,14 @@ func (m *msg) IsDeclaration() bool {
 	return !m.proposal
 }
 
+func (m *msg) Weight() uint64 {
+	return m.weight
+}
+
+func (m *msg) LedgerHeight() uint64 {
+	return m.height
+}
+
 type peer struct {
 	mockedMethods map[string]struct{}
 	mock.Mock
@@ -61,6 +72,8 @@ type peer struct {
 	leaderFromCallback bool
 	callbackInvoked    bool
 	lock               sync.RWMutex
+	weight             uint64
+	height             uint64
 	LeaderElectionService
 }
 
@@ -104,7 +117,25 @@ func (p *peer) Accept() <-chan Msg {
 }
 
 func (p *peer) CreateMessage(isDeclaration bool) Msg {
-	return &msg{proposal: !isDeclaration, sender: p.id}
+	return &msg{proposal: !isDeclaration, sender: p.id, weight: p.Weight(), height: p.LedgerHeight()}
+}
+
+func (p *peer) Weight() uint64 {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.weight
+}
+
+func (p *peer) LedgerHeight() uint64 {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.height
+}
+
+func (p *peer) setLedgerHeight(height uint64) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.height = height
 }
 
 func (p *peer) Peers() []Peer {
@@ -117,8 +148,8 @@ func (p *peer) Peers() []Peer {
 	}
 
 	var peers []Peer
-	for id := range p.peers {
-		peers = append(peers, &peer{id: id})
+	for id, remotePeer := range p.peers {
+		peers = append(peers, &peer{id: id, height: remotePeer.LedgerHeight()})
 	}
 	return peers
 }
@@ -143,11 +174,17 @@ func (p *peer) isCallbackInvoked() bool {
 }
 
 func createPeers(spawnInterval time.Duration, ids ...int) []*peer {
+	return createPeersWithConfig(spawnInterval, 1, func(*peer) {}, ids...)
+}
+
+// createPeersWithConfig creates peers that elect the given number of leaders,
+// after they are set up by the given function
+func createPeersWithConfig(spawnInterval time.Duration, leadersPerOrg int, setup func(*peer), ids ...int) []*peer {
 	peers := make([]*peer, len(ids))
 	peerMap := make(map[string]*peer)
 	l := &sync.RWMutex{}
 	for i, id := range ids {
-		p := createPeer(id, peerMap, l)
+		p := createPeer(id, peerMap, l, leadersPerOrg, setup)
 		if spawnInterval != 0 {
 			time.Sleep(spawnInterval)
 		}
@@ -156,11 +193,12 @@ func createPeers(spawnInterval time.Duration, ids ...int) []*peer {
 	return peers
 }
 
-func createPeer(id int, peerMap map[string]*peer, l *sync.RWMutex) *peer {
+func createPeer(id int, peerMap map[string]*peer, l *sync.RWMutex, leadersPerOrg int, setup func(*peer)) *peer {
 	idStr := fmt.Sprintf("p%d", id)
 	c := make(chan Msg, 100)
 	p := &peer{id: idStr, peers: peerMap, sharedLock: l, msgChan: c, mockedMethods: make(map[string]struct{}), leaderFromCallback: false, callbackInvoked: false}
-	p.LeaderElectionService = NewLeaderElectionService(p, idStr, p.leaderCallback)
+	setup(p)
+	p.LeaderElectionService = newLeaderElectionService(p, idStr, p.leaderCallback, leadersPerOrg)
 	l.Lock()
 	peerMap[idStr] = p
 	l.Unlock()
@@ -417,11 +455,144 @@ func TestPartition(t *testing.T) {
 
 }
 
+func TestCandidateIsBetterThan(t *testing.T) {
+	p0 := candidate{id: peerID("p0")}
+	p1 := candidate{id: peerID("p1")}
+	assert.True(t, p0.isBetterThan(p1, 0))
+	assert.False(t, p1.isBetterThan(p0, 0))
+
+	// Candidates whose ledgers are not behind are preferred
+	p1.height = 6
+	assert.True(t, p1.isBetterThan(p0, 1))
+	assert.False(t, p0.isBetterThan(p1, 1))
+	// Otherwise the ledger heights don't matter
+	assert.True(t, p0.isBetterThan(p1, 0))
+
+	// But weights matter most
+	p0.weight = 1
+	assert.True(t, p0.isBetterThan(p1, 1))
+	assert.False(t, p1.isBetterThan(p0, 1))
+}
+
+func TestMinLeaderHeight(t *testing.T) {
+	self := candidate{id: peerID("p0"), height: 0}
+	candidates := map[string]candidate{
+		"p1": {id: peerID("p1"), height: 6},
+		"p2": {id: peerID("p2"), height: 12},
+	}
+	assert.Equal(t, uint64(2), minLeaderHeight(self, candidates, 10))
+	assert.Equal(t, uint64(0), minLeaderHeight(self, candidates, 20))
+	assert.Equal(t, uint64(0), minLeaderHeight(self, candidates, 0))
+}
+
+func TestBetterCandidatesIsTransitive(t *testing.T) {
+	// Scenario: the ledger heights of the peers differ by less than
+	// the threshold pairwise, but not overall.
+	// Expected outcome: exactly one of them has no better candidate
+	preLeaderHeightThreshold := getLeaderHeightThreshold()
+	defer SetLeaderHeightThreshold(preLeaderHeightThreshold)
+	SetLeaderHeightThreshold(10)
+	candidates := map[string]candidate{
+		"A": {id: peerID("A"), height: 0},
+		"B": {id: peerID("B"), height: 6},
+		"C": {id: peerID("C"), height: 12},
+	}
+	var leaders []string
+	for id, self := range candidates {
+		others := make(map[string]candidate)
+		for otherID, c := range candidates {
+			if otherID != id {
+				others[otherID] = c
+			}
+		}
+		if betterCandidates(self, others) == 0 {
+			leaders = append(leaders, id)
+		}
+	}
+	assert.Equal(t, []string{"B"}, leaders)
+}
+
+func TestWeightedElection(t *testing.T) {
+	t.Parallel()
+	// Scenario: Peers are spawned at the same time, and p3 has the highest weight
+	// expected outcome: p3 is the leader although its ID isn't the lowest
+	peers := createPeersWithConfig(0, 1, func(p *peer) {
+		if p.id == "p3" {
+			p.weight = 10
+		}
+		if p.id == "p2" {
+			p.weight = 5
+		}
+	}, 3, 2, 1, 0)
+	leaders := waitForLeaderElection(t, peers)
+	assert.Equal(t, []string{"p3"}, leaders)
+	peers[0].Stop()
+	// p2 has the next highest weight
+	time.Sleep(getLeadershipDeclarationInterval() + getLeaderAliveThreshold()*3)
+	leaders = waitForLeaderElection(t, peers[1:])
+	assert.Equal(t, []string{"p2"}, leaders)
+}
+
+func TestHeightAwareElection(t *testing.T) {
+	t.Parallel()
+	// Scenario: Peers are spawned at the same time, and p2 has a ledger
+	// that is ahead of the ledgers of the others by more than the threshold
+	// expected outcome: p2 is the leader although its ID isn't the lowest
+	peers := createPeersWithConfig(0, 1, func(p *peer) {
+		p.height = 10
+		if p.id == "p2" {
+			p.height = 20
+		}
+	}, 3, 2, 1, 0)
+	leaders := waitForLeaderElection(t, peers)
+	assert.Equal(t, []string{"p2"}, leaders)
+}
+
+func TestLaggingLeaderYields(t *testing.T) {
+	t.Parallel()
+	// Scenario: Peers are spawned at the same time and p0 is elected.
+	// Then the ledgers of the other peers advance while p0's ledger doesn't.
+	// expected outcome: p0 yields its leadership and another peer is elected
+	peers := createPeers(0, 0, 1, 2, 3)
+	leaders := waitForLeaderElection(t, peers)
+	assert.Equal(t, []string{"p0"}, leaders)
+	for _, p := range peers[1:] {
+		p.setLedgerHeight(20)
+	}
+	isP1Leader := func() bool {
+		leaders := waitForLeaderElection(t, peers)
+		return len(leaders) == 1 && leaders[0] == "p1"
+	}
+	waitForBoolFunc(t, isP1Leader, true)
+	assert.False(t, peers[0].isLeaderFromCallback())
+}
+
+func TestMultipleLeaders(t *testing.T) {
+	t.Parallel()
+	// Scenario: Peers that elect 2 leaders are spawned at the same time
+	// expected outcome: the 2 peers that have the lowest IDs are the leaders
+	peers := createPeersWithConfig(0, 2, func(*peer) {}, 5, 4, 3, 2, 1, 0)
+	time.Sleep(getStartupGracePeriod() + getLeaderElectionDuration())
+	leaders := waitForMultipleLeadersElection(t, peers, 2)
+	assert.Len(t, leaders, 2)
+	assert.Contains(t, leaders, "p0")
+	assert.Contains(t, leaders, "p1")
+
+	// One of the leaders stops, and p2 takes over
+	peers[len(peers)-1].Stop()
+	time.Sleep(getLeadershipDeclarationInterval() + getLeaderAliveThreshold()*3)
+	leaders = waitForMultipleLeadersElection(t, peers[:len(peers)-1], 2)
+	assert.Len(t, leaders, 2)
+	assert.Contains(t, leaders, "p1")
+	assert.Contains(t, leaders, "p2")
+}
+
 func TestConfigFromFile(t *testing.T) {
 	preStartupGracePeriod := getStartupGracePeriod()
 	preMembershipSampleInterval := getMembershipSampleInterval()
 	preLeaderAliveThreshold := getLeaderAliveThreshold()
 	preLeaderElectionDuration := getLeaderElectionDuration()
+	preLeaderHeightThreshold := getLeaderHeightThreshold()
 
 	// Recover the config values in order to avoid impacting other tests
 	defer func() {
@@ -429,6 +600,7 @@ func TestConfigFromFile(t *testing.T) {
 		SetMembershipSampleInterval(preMembershipSampleInterval)
 		SetLeaderAliveThreshold(preLeaderAliveThreshold)
 		SetLeaderElectionDuration(preLeaderElectionDuration)
+		SetLeaderHeightThreshold(preLeaderHeightThreshold)
 	}()
 
 	// Verify if using default values when config is missing
@@ -438,6 +610,9 @@ func TestConfigFromFile(t *testing.T) {
 	assert.Equal(t, time.Second*10, getLeaderAliveThreshold())
 	assert.Equal(t, time.Second*5, getLeaderElectionDuration())
 	assert.Equal(t, getLeaderAliveThreshold()/2, getLeadershipDeclarationInterval())
+	assert.Equal(t, 1, getLeadersPerOrg())
+	assert.Equal(t, uint64(0), getLeaderHeightThreshold())
+	assert.Equal(t, uint64(0), getWeight())
 
 	//Verify reading the values from config file
 	viper.Reset()
@@ -453,6 +628,9 @@ func TestConfigFromFile(t *testing.T) {
 	assert.Equal(t, time.Second*10, getLeaderAliveThreshold())
 	assert.Equal(t, time.Second*5, getLeaderElectionDuration())
 	assert.Equal(t, getLeaderAliveThreshold()/2, getLeadershipDeclarationInterval())
+	assert.Equal(t, 1, getLeadersPerOrg())
+	assert.Equal(t, uint64(0), getLeaderHeightThreshold())
+	assert.Equal(t, uint64(0), getWeight())
 }
 
 func waitForBoolFunc(t *testing.T, f func() bool, expectedValue bool, msgAndArgs ...interface{}) {
@@ -59,6 +59,10 @@ type GossipChannel interface {
 	// that is periodically published
 	UpdateStateInfo(msg *proto.SignedGossipMessage)
 
+	// Self returns this channel's latest StateInfo message,
+	// or nil if none was published yet
+	Self() *proto.SignedGossipMessage
+
 	// IsOrgInChannel returns whether the given organization is in the channel
 	IsOrgInChannel(membersOrg api.OrgIdentityType) bool
 
@@ -788,6 +792,14 @@ func (gc *gossipChannel) UpdateStateInfo(msg *proto.SignedGossipMessage) {
 	atomic.StoreInt32(&gc.shouldGossipStateInfo, int32(1))
 }
 
+// Self returns this channel's latest StateInfo message,
+// or nil if none was published yet
+func (gc *gossipChannel) Self() *proto.SignedGossipMessage {
+	gc.RLock()
+	defer gc.RUnlock()
+	return gc.stateInfoMsg
+}
+
 func newStateInfoCache(sweepInterval time.Duration, hasExpired func(interface{}) bool, verifyFunc membershipPredicate) *stateInfoCache {
 	membershipStore := util.NewMembershipStore()
 	pol := proto.NewGossipMessageComparator(0)
@@ -42,6 +42,22 @@ type Gossip interface {
 	// publishes to other peers about its channel-related state
 	UpdateChannelMetadata(metadata []byte, chainID common.ChainID)
 
+	// UpdateChaincodes updates the chaincodes the peer publishes
+	// to other peers as instantiated on the channel
+	UpdateChaincodes(chaincodes []*proto.Chaincode, chainID common.ChainID)
+
+	// UpdateInstalledChaincodes updates the chaincodes the peer publishes
+	// to other peers as installed on it, in all channels it has joined
+	UpdateInstalledChaincodes(chaincodes []*proto.Chaincode)
+
+	// SelfChannelInfo returns the peer's latest StateInfo message of a given channel
+	SelfChannelInfo(common.ChainID) *proto.SignedGossipMessage
+
+	// PeersOfChannelMatching returns the NetworkMembers considered alive,
+	// subscribed to the channel given, and that advertise properties
+	// that match the given PeerCriteria
+	PeersOfChannelMatching(common.ChainID, PeerCriteria) []discovery.NetworkMember
+
 	// Gossip sends a message to other peers to the network
 	Gossip(msg *proto.GossipMessage)
 
@@ -120,4 +136,6 @@ type Config struct {
 
 	InternalEndpoint string // Endpoint we publish to peers in our organization
 	ExternalEndpoint string // Peer publishes this endpoint instead of SelfEndpoint to foreign organizations
+
+	Roles []string // Roles the peer advertises in its StateInfo messages
 }
@@ -61,6 +61,7 @@ type gossipServiceImpl struct {
 	mcs               api.MessageCryptoService
 	stateInfoMsgStore msgstore.MessageStore
 	certPuller        pull.Mediator
+	selfProps         *selfProperties
 }
 
 // NewGossipService creates a gossip instance attached to a gRPC server
@@ -85,6 +86,7 @@ func NewGossipService(conf *Config, s *grpc.Server, secAdvisor api.SecurityAdvis
 		stopFlag:              int32(0),
 		stopSignal:            &sync.WaitGroup{},
 		includeIdentityPeriod: time.Now().Add(conf.PublishCertPeriod),
+		selfProps:             newSelfProperties(conf.Roles),
 	}
 	g.stateInfoMsgStore = g.newStateInfoMsgStore()
 
@@ -193,8 +195,11 @@ func (g *gossipServiceImpl) LeaveChan(chainID common.ChainID) {
 		g.logger.Debug("No such channel", chainID)
 		return
 	}
+	g.selfProps.Lock()
+	defer g.selfProps.Unlock()
+	g.selfProps.channel(chainID).left = true
 	b, _ := (&common.NodeMetastate{}).Bytes()
-	stateInfMsg, err := g.createStateInfoMsg(b, chainID, true)
+	stateInfMsg, err := g.createStateInfoMsg(b, chainID)
 	if err != nil {
 		g.logger.Errorf("Failed creating StateInfo message: %+v", errors.WithStack(err))
 		return
@@ -776,7 +781,52 @@ func (g *gossipServiceImpl) UpdateChannelMetadata(md []byte, chainID common.Chai
 		g.logger.Debug("No such channel", chainID)
 		return
 	}
-	stateInfMsg, err := g.createStateInfoMsg(md, chainID, false)
+	g.selfProps.Lock()
+	defer g.selfProps.Unlock()
+	g.selfProps.channel(chainID).metadata = md
+	g.updateStateInfo(gc, chainID)
+}
+
+// UpdateChaincodes updates the chaincodes the peer publishes
+// to other peers as instantiated on the channel
+func (g *gossipServiceImpl) UpdateChaincodes(chaincodes []*proto.Chaincode, chainID common.ChainID) {
+	gc := g.chanState.getGossipChannelByChainID(chainID)
+	if gc == nil {
+		g.logger.Debug("No such channel", chainID)
+		return
+	}
+	g.selfProps.Lock()
+	defer g.selfProps.Unlock()
+	g.selfProps.channel(chainID).chaincodes = chaincodes
+	g.updateStateInfo(gc, chainID)
+}
+
+// UpdateInstalledChaincodes updates the chaincodes the peer publishes
+// to other peers as installed on it, in all channels it has joined
+func (g *gossipServiceImpl) UpdateInstalledChaincodes(chaincodes []*proto.Chaincode) {
+	g.selfProps.Lock()
+	defer g.selfProps.Unlock()
+	g.selfProps.installed = chaincodes
+	for chainID := range g.selfProps.channels {
+		gc := g.chanState.getGossipChannelByChainID(common.ChainID(chainID))
+		if gc == nil {
+			continue
+		}
+		g.updateStateInfo(gc, common.ChainID(chainID))
+	}
+}
+
+// updateStateInfo replaces the StateInfo message of the given channel
+// with one that reflects the current properties of the peer.
+// It is expected to be called while holding the selfProps lock.
+func (g *gossipServiceImpl) updateStateInfo(gc channel.GossipChannel, chainID common.ChainID) {
+	md := g.selfProps.channel(chainID).metadata
+	if md == nil {
+		// The ledger height isn't known yet, the StateInfo message
+		// would be published once the channel metadata is updated
+		return
+	}
+	stateInfMsg, err := g.createStateInfoMsg(md, chainID)
 	if err != nil {
 		g.logger.Errorf("Failed creating StateInfo message: %+v", errors.WithStack(err))
 		return
@@ -784,6 +834,29 @@ func (g *gossipServiceImpl) UpdateChannelMetadata(md []byte, chainID common.Chai
 	gc.UpdateStateInfo(stateInfMsg)
 }
 
+// SelfChannelInfo returns the peer's latest StateInfo message of a given channel
+func (g *gossipServiceImpl) SelfChannelInfo(chainID common.ChainID) *proto.SignedGossipMessage {
+	gc := g.chanState.getGossipChannelByChainID(chainID)
+	if gc == nil {
+		g.logger.Debug("No such channel", chainID)
+		return nil
+	}
+	return gc.Self()
+}
+
+// PeersOfChannelMatching returns the NetworkMembers considered alive,
+// subscribed to the channel given, and that advertise properties
+// that match the given PeerCriteria
+func (g *gossipServiceImpl) PeersOfChannelMatching(chainID common.ChainID, criteria PeerCriteria) []discovery.NetworkMember {
+	var members []discovery.NetworkMember
+	for _, member := range g.PeersOfChannel(chainID) {
+		if criteria.Matches(member.Properties) {
+			members = append(members, member)
+		}
+	}
+	return members
+}
+
 // Accept returns a dedicated read-only channel for messages sent by other nodes that match a certain predicate.
 // If passThrough is false, the messages are processed by the gossip layer beforehand.
 // If passThrough is true, the gossip layer doesn't intervene and the messages
@@ -1154,7 +1227,10 @@ func (g *gossipServiceImpl) connect2BootstrapPeers() {
 
 }
 
-func (g *gossipServiceImpl) createStateInfoMsg(metadata []byte, chainID common.ChainID, leftChannel bool) (*proto.SignedGossipMessage, error) {
+// createStateInfoMsg creates a StateInfo message with the given metadata
+// and the current properties of the peer in the channel.
+// It is expected to be called while holding the selfProps lock.
+func (g *gossipServiceImpl) createStateInfoMsg(metadata []byte, chainID common.ChainID) (*proto.SignedGossipMessage, error) {
 	metaState, err := common.FromBytes(metadata)
 	if err != nil {
 		return nil, err
@@ -1168,12 +1244,7 @@ func (g *gossipServiceImpl) createStateInfoMsg(metadata []byte, chainID common.C
 			IncNum: uint64(g.incTime.UnixNano()),
 			SeqNum: uint64(time.Now().UnixNano()),
 		},
-		Properties: &proto.Properties{
-			LedgerHeight: metaState.LedgerHeight,
-		},
-	}
-	if leftChannel {
-		stateInfMsg.Properties.LeftChannel = true
+		Properties: g.selfProps.properties(chainID, metaState.LedgerHeight),
 	}
 	m := &proto.GossipMessage{
 		Nonce: 0,
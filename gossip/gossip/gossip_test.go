@@ -51,6 +51,7 @@ var tests = []func(t *testing.T){
 	TestAnchorPeer,
 	TestBootstrapPeerMisConfiguration,
 	TestNoMessagesSelfLoop,
+	TestPeerProperties,
 }
 
 func init() {
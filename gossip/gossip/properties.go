@@ -0,0 +1,122 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/hyperledger/fabric/gossip/common"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+)
+
+// PeerCriteria defines the properties a peer needs to publish
+// in its StateInfo message in order to be selected
+type PeerCriteria struct {
+	MinLedgerHeight       uint64           // MinLedgerHeight is the minimum ledger height of the peer
+	InstalledChaincode    *proto.Chaincode // InstalledChaincode needs to be installed on the peer
+	InstantiatedChaincode *proto.Chaincode // InstantiatedChaincode needs to be instantiated on the channel
+	Roles                 []string         // Roles are the roles the peer needs to take on
+}
+
+// String returns a string representation of this PeerCriteria
+func (pc PeerCriteria) String() string {
+	return fmt.Sprintf("minLedgerHeight: %d, installed: %v, instantiated: %v, roles: %v",
+		pc.MinLedgerHeight, pc.InstalledChaincode, pc.InstantiatedChaincode, pc.Roles)
+}
+
+// Matches returns whether the given properties satisfy the PeerCriteria.
+// A chaincode in the criteria with an empty version matches any version.
+func (pc PeerCriteria) Matches(props *proto.Properties) bool {
+	if props == nil {
+		return pc.MinLedgerHeight == 0 && pc.InstalledChaincode == nil &&
+			pc.InstantiatedChaincode == nil && len(pc.Roles) == 0
+	}
+	if props.LedgerHeight < pc.MinLedgerHeight {
+		return false
+	}
+	if pc.InstalledChaincode != nil && !containsChaincode(props.InstalledChaincodes, pc.InstalledChaincode) {
+		return false
+	}
+	if pc.InstantiatedChaincode != nil && !containsChaincode(props.Chaincodes, pc.InstantiatedChaincode) {
+		return false
+	}
+	for _, role := range pc.Roles {
+		if !containsRole(props.Roles, role) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsChaincode(chaincodes []*proto.Chaincode, cc *proto.Chaincode) bool {
+	for _, c := range chaincodes {
+		if c.Name != cc.Name {
+			continue
+		}
+		if cc.Version == "" || c.Version == cc.Version {
+			return true
+		}
+	}
+	return false
+}
+
+func containsRole(roles []string, role string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// selfProperties holds the properties the peer publishes
+// about itself in the StateInfo messages of its channels
+type selfProperties struct {
+	sync.Mutex
+	roles     []string
+	installed []*proto.Chaincode
+	channels  map[string]*channelProperties
+}
+
+// channelProperties holds the channel-related state of the peer
+type channelProperties struct {
+	metadata   []byte
+	chaincodes []*proto.Chaincode
+	left       bool
+}
+
+func newSelfProperties(roles []string) *selfProperties {
+	return &selfProperties{
+		roles:    roles,
+		channels: make(map[string]*channelProperties),
+	}
+}
+
+// channel returns the properties of the given channel,
+// and is expected to be called while holding the lock
+func (sp *selfProperties) channel(chainID common.ChainID) *channelProperties {
+	props, exists := sp.channels[string(chainID)]
+	if !exists {
+		props = &channelProperties{}
+		sp.channels[string(chainID)] = props
+	}
+	return props
+}
+
+// properties returns the Properties the peer publishes in the given channel,
+// and is expected to be called while holding the lock
+func (sp *selfProperties) properties(chainID common.ChainID, ledgerHeight uint64) *proto.Properties {
+	chanProps := sp.channel(chainID)
+	return &proto.Properties{
+		LedgerHeight:        ledgerHeight,
+		LeftChannel:         chanProps.left,
+		Chaincodes:          chanProps.chaincodes,
+		InstalledChaincodes: sp.installed,
+		Roles:               sp.roles,
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package gossip
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/gossip/common"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeerCriteriaMatches(t *testing.T) {
+	props := &proto.Properties{
+		LedgerHeight:        10,
+		Chaincodes:          []*proto.Chaincode{{Name: "cc1", Version: "1.0"}},
+		InstalledChaincodes: []*proto.Chaincode{{Name: "cc1", Version: "1.0"}, {Name: "cc2", Version: "2.0"}},
+		Roles:               []string{"endorser", "committer"},
+	}
+
+	for _, tst := range []struct {
+		name     string
+		criteria PeerCriteria
+		props    *proto.Properties
+		matches  bool
+	}{
+		{name: "empty criteria", criteria: PeerCriteria{}, props: props, matches: true},
+		{name: "empty criteria without properties", criteria: PeerCriteria{}, props: nil, matches: true},
+		{name: "criteria without properties", criteria: PeerCriteria{MinLedgerHeight: 1}, props: nil, matches: false},
+		{name: "ledger height reached", criteria: PeerCriteria{MinLedgerHeight: 10}, props: props, matches: true},
+		{name: "ledger height not reached", criteria: PeerCriteria{MinLedgerHeight: 11}, props: props, matches: false},
+		{name: "installed chaincode", criteria: PeerCriteria{InstalledChaincode: &proto.Chaincode{Name: "cc2", Version: "2.0"}}, props: props, matches: true},
+		{name: "installed chaincode any version", criteria: PeerCriteria{InstalledChaincode: &proto.Chaincode{Name: "cc2"}}, props: props, matches: true},
+		{name: "installed chaincode other version", criteria: PeerCriteria{InstalledChaincode: &proto.Chaincode{Name: "cc2", Version: "1.0"}}, props: props, matches: false},
+		{name: "instantiated chaincode", criteria: PeerCriteria{InstantiatedChaincode: &proto.Chaincode{Name: "cc1", Version: "1.0"}}, props: props, matches: true},
+		{name: "chaincode not instantiated", criteria: PeerCriteria{InstantiatedChaincode: &proto.Chaincode{Name: "cc2"}}, props: props, matches: false},
+		{name: "roles", criteria: PeerCriteria{Roles: []string{"committer", "endorser"}}, props: props, matches: true},
+		{name: "missing role", criteria: PeerCriteria{Roles: []string{"endorser", "orderer"}}, props: props, matches: false},
+		{
+			name: "all properties",
+			criteria: PeerCriteria{
+				MinLedgerHeight:       5,
+				InstalledChaincode:    &proto.Chaincode{Name: "cc1"},
+				InstantiatedChaincode: &proto.Chaincode{Name: "cc1", Version: "1.0"},
+				Roles:                 []string{"endorser"},
+			},
+			props:   props,
+			matches: true,
+		},
+	} {
+		t.Run(tst.name, func(t *testing.T) {
+			assert.Equal(t, tst.matches, tst.criteria.Matches(tst.props))
+		})
+	}
+}
+
+func TestPeerProperties(t *testing.T) {
+	t.Parallel()
+	defer testWG.Done()
+	portPrefix := 14610
+	// Scenario: Have 2 peers in a channel, and make the second one publish
+	// its chaincodes and roles. Ensure the first peer selects it by its properties
+	// and that the properties are kept after the ledger height changes.
+
+	channel := common.ChainID("A")
+	p0 := newGossipInstance(portPrefix, 0, 100)
+	p0.JoinChan(&joinChanMsg{}, channel)
+	p0.UpdateChannelMetadata(createMetadata(1), channel)
+	defer p0.Stop()
+
+	p1 := newGossipInstance(portPrefix, 1, 100, 0)
+	p1.(*gossipServiceImpl).selfProps.roles = []string{"endorser"}
+	p1.JoinChan(&joinChanMsg{}, channel)
+	// Chaincodes are only published along with the ledger height
+	p1.UpdateChaincodes([]*proto.Chaincode{{Name: "cc1", Version: "1.0"}}, channel)
+	assert.Nil(t, p1.SelfChannelInfo(channel))
+	p1.UpdateChannelMetadata(createMetadata(1), channel)
+	p1.UpdateInstalledChaincodes([]*proto.Chaincode{{Name: "cc1", Version: "1.0"}, {Name: "cc2", Version: "1.0"}})
+	defer p1.Stop()
+
+	self := p1.SelfChannelInfo(channel)
+	assert.NotNil(t, self)
+	assert.Equal(t, []string{"endorser"}, self.GetStateInfo().Properties.Roles)
+	assert.Len(t, self.GetStateInfo().Properties.Chaincodes, 1)
+	assert.Len(t, self.GetStateInfo().Properties.InstalledChaincodes, 2)
+	assert.Nil(t, p1.SelfChannelInfo(common.ChainID("B")))
+
+	matchingPeers := func(g Gossip, criteria PeerCriteria, expected int) func() bool {
+		return func() bool {
+			return len(g.PeersOfChannelMatching(channel, criteria)) == expected
+		}
+	}
+
+	waitUntilOrFail(t, matchingPeers(p0, PeerCriteria{
+		InstalledChaincode:    &proto.Chaincode{Name: "cc2"},
+		InstantiatedChaincode: &proto.Chaincode{Name: "cc1", Version: "1.0"},
+		Roles:                 []string{"endorser"},
+	}, 1))
+	waitUntilOrFail(t, matchingPeers(p1, PeerCriteria{}, 1))
+	assert.Empty(t, p1.PeersOfChannelMatching(channel, PeerCriteria{Roles: []string{"endorser"}}))
+
+	p1.UpdateChannelMetadata(createMetadata(5), channel)
+	waitUntilOrFail(t, matchingPeers(p0, PeerCriteria{
+		MinLedgerHeight:       5,
+		InstantiatedChaincode: &proto.Chaincode{Name: "cc1"},
+	}, 1))
+
+	p1.UpdateChaincodes([]*proto.Chaincode{{Name: "cc1", Version: "2.0"}}, channel)
+	waitUntilOrFail(t, matchingPeers(p0, PeerCriteria{
+		MinLedgerHeight:       5,
+		InstantiatedChaincode: &proto.Chaincode{Name: "cc1", Version: "2.0"},
+	}, 1))
+}
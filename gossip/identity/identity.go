@@ -14,10 +14,13 @@ import (
 
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/util"
 	errors "github.com/pkg/errors"
 )
 
 var (
+	logger = util.GetLogger(util.LoggingIdentityModule, "")
+
 	// identityUsageThreshold sets the maximum time that an identity
 	// can not be used to verify some signature before it will be deleted
 	usageThreshold = time.Hour
@@ -134,11 +137,12 @@ func (is *identityMapperImpl) Put(pkiID common.PKIidType, identity api.PeerIdent
 		// Identity would be wiped out a millisecond after its expiration date
 		timeToLive := expirationDate.Add(time.Millisecond).Sub(time.Now())
 		expirationTimer = time.AfterFunc(timeToLive, func() {
+			logger.Warningf("Purging identity of %v which expired at %s", pkiID, expirationDate)
 			is.delete(pkiID, identity)
 		})
 	}
 
-	is.pkiID2Cert[string(id)] = newStoredIdentity(pkiID, identity, expirationTimer)
+	is.pkiID2Cert[string(id)] = newStoredIdentity(pkiID, identity, expirationDate, expirationTimer)
 	return nil
 }
 
@@ -200,6 +204,13 @@ func (is *identityMapperImpl) validateIdentities(isSuspected api.PeerSuspector)
 			revokedIdentities = append(revokedIdentities, storedIdentity)
 			continue
 		}
+		// The expiration timer of an identity might fire late, e.g. after the host was suspended
+		// or its clock was changed, so expired identities are purged here as well
+		if pkiID != is.selfPKIID && storedIdentity.expired(now) {
+			logger.Warningf("Purging identity of %v which expired at %s", storedIdentity.pkiID, storedIdentity.expiration)
+			revokedIdentities = append(revokedIdentities, storedIdentity)
+			continue
+		}
 		if !isSuspected(storedIdentity.peerIdentity) {
 			continue
 		}
@@ -221,18 +232,25 @@ type storedIdentity struct {
 	pkiID           common.PKIidType
 	lastAccessTime  int64
 	peerIdentity    api.PeerIdentityType
+	expiration      time.Time
 	expirationTimer *time.Timer
 }
 
-func newStoredIdentity(pkiID common.PKIidType, identity api.PeerIdentityType, expirationTimer *time.Timer) *storedIdentity {
+func newStoredIdentity(pkiID common.PKIidType, identity api.PeerIdentityType, expiration time.Time, expirationTimer *time.Timer) *storedIdentity {
 	return &storedIdentity{
 		pkiID:           pkiID,
 		lastAccessTime:  time.Now().UnixNano(),
 		peerIdentity:    identity,
+		expiration:      expiration,
 		expirationTimer: expirationTimer,
 	}
 }
 
+// expired returns whether the identity has an expiration date which has passed
+func (si *storedIdentity) expired(now time.Time) bool {
+	return !si.expiration.IsZero() && now.After(si.expiration)
+}
+
 func (si *storedIdentity) fetchIdentity() api.PeerIdentityType {
 	atomic.StoreInt64(&si.lastAccessTime, time.Now().UnixNano())
 	return si.peerIdentity
@@ -292,6 +292,46 @@ func TestExpiration(t *testing.T) {
 	msgCryptoService.revokedIdentities = map[string]struct{}{}
 }
 
+func TestExpiredIdentityPurgedOnValidation(t *testing.T) {
+	// Simulates an expiration timer that didn't fire on time, such as after the
+	// host was suspended, in which case the periodical validation purges the identity
+	deletedIdentities := make(chan string, 1)
+	SetIdentityUsageThreshold(time.Second * 500)
+	idStore := NewIdentityMapper(msgCryptoService, dummyID, func(_ common.PKIidType, identity api.PeerIdentityType) {
+		deletedIdentities <- string(identity)
+	})
+	defer idStore.Stop()
+
+	lateIdentity := api.PeerIdentityType("lateExpirationIdentity")
+	latePkiID := idStore.GetPKIidOfCert(lateIdentity)
+	msgCryptoService.On("Expiration", lateIdentity).Return(time.Now().Add(time.Hour), nil)
+	assert.NoError(t, idStore.Put(latePkiID, lateIdentity))
+
+	mapper := idStore.(*identityMapperImpl)
+	mapper.Lock()
+	stored := mapper.pkiID2Cert[string(latePkiID)]
+	stored.cancelExpirationTimer()
+	stored.expiration = time.Now().Add(-time.Second)
+	mapper.Unlock()
+
+	// Identities that aren't suspected are still purged once they expire
+	idStore.SuspectPeers(func(_ api.PeerIdentityType) bool {
+		return false
+	})
+	select {
+	case actual := <-deletedIdentities:
+		assert.Equal(t, "lateExpirationIdentity", actual)
+	case <-time.After(time.Second * 10):
+		t.Fatal("Didn't detect a deleted identity, expected lateExpirationIdentity to be deleted")
+	}
+	_, err := idStore.Get(latePkiID)
+	assert.Error(t, err)
+
+	// Our own identity isn't purged
+	_, err = idStore.Get(idStore.GetPKIidOfCert(dummyID))
+	assert.NoError(t, err)
+}
+
 func TestExpirationPanic(t *testing.T) {
 	identity3 := []byte("invalidIdentity")
 	msgCryptoService.revokedIdentities[string(identity3)] = struct{}{}
@@ -52,6 +52,7 @@ func newConfig(selfEndpoint string, externalEndpoint string, certs *common.TLSCe
 		PublishStateInfoInterval:   util.GetDurationOrDefault("peer.gossip.publishStateInfoInterval", 4*time.Second),
 		SkipBlockVerification:      viper.GetBool("peer.gossip.skipBlockVerification"),
 		TLSCerts:                   certs,
+		Roles:                      viper.GetStringSlice("peer.gossip.roles"),
 	}
 
 	return conf, nil
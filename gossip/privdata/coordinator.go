@@ -10,6 +10,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -85,6 +86,11 @@ type Coordinator interface {
 	// Get recent block sequence number
 	LedgerHeight() (uint64, error)
 
+	// SetCommitHandler sets the handler that is notified of each block once it is committed,
+	// which may happen after StoreBlock returns when commits are pipelined.
+	// It is to be set before blocks are stored
+	SetCommitHandler(handler func(block *common.Block))
+
 	// Close coordinator, shuts down coordinator service
 	Close()
 }
@@ -119,6 +125,14 @@ type coordinator struct {
 	selfSignedData common.SignedData
 	Support
 	transientBlockRetention uint64
+	// pipelined indicates that the validation of each block
+	// overlaps with the commit of the previous block
+	pipelined bool
+	// inProgress is the commit that the pipeline proceeds with, if any
+	inProgress     *commitInProgress
+	inProgressLock sync.Mutex
+	// commitHandler is notified of the blocks once they are committed
+	commitHandler func(block *common.Block)
 }
 
 // NewCoordinator creates a new instance of coordinator
@@ -128,7 +142,12 @@ func NewCoordinator(support Support, selfSignedData common.SignedData) Coordinat
 		logger.Warning("Configuration key", transientBlockRetentionConfigKey, "isn't set, defaulting to", transientBlockRetentionDefault)
 		transientBlockRetention = transientBlockRetentionDefault
 	}
-	return &coordinator{Support: support, selfSignedData: selfSignedData, transientBlockRetention: transientBlockRetention}
+	return &coordinator{
+		Support:                 support,
+		selfSignedData:          selfSignedData,
+		transientBlockRetention: transientBlockRetention,
+		pipelined:               viper.GetBool(commitPipeliningConfigKey),
+	}
 }
 
 // StorePvtData used to persist private date into transient store
@@ -150,12 +169,24 @@ func (c *coordinator) StoreBlock(block *common.Block, privateDataSets util.PvtDa
 	}
 	logger.Infof("Received block [%d]", block.Header.Number)
 
+	// The validation of a block depends on the chaincode definitions and the
+	// channel configuration, hence it waits for the commit of the blocks that update them
+	inProgress := c.commitInProgress()
+	if inProgress != nil && inProgress.barrier {
+		if err := c.waitForCommitInProgress(); err != nil {
+			return err
+		}
+	}
+
 	logger.Debugf("Validating block [%d]", block.Header.Number)
 	err := c.Validator.Validate(block)
 	if err != nil {
 		logger.Errorf("Validation failed: %+v", err)
 		return err
 	}
+	if inProgress != nil {
+		inProgress.markDuplicates(block)
+	}
 
 	blockAndPvtData := &ledger.BlockAndPvtData{
 		Block:        block,
@@ -219,16 +250,77 @@ func (c *coordinator) StoreBlock(block *common.Block, privateDataSets util.PvtDa
 		})
 	}
 
+	if !c.pipelined {
+		return c.commitBlock(blockAndPvtData, privateInfo.txns)
+	}
+
+	// The block is committed while the next block is being validated
+	c.inProgressLock.Lock()
+	defer c.inProgressLock.Unlock()
+	if err := c.waitForCommitInProgressLocked(); err != nil {
+		return err
+	}
+	commit := newCommitInProgress(block)
+	c.inProgress = commit
+	go func() {
+		defer close(commit.done)
+		if commit.err = c.commitBlock(blockAndPvtData, privateInfo.txns); commit.err != nil {
+			logger.Errorf("Failed committing block [%d]: %+v", commit.blockNum, commit.err)
+		}
+	}()
+	return nil
+}
+
+// SetCommitHandler sets the handler that is notified of each block once it is committed
+func (c *coordinator) SetCommitHandler(handler func(block *common.Block)) {
+	c.commitHandler = handler
+}
+
+// commitInProgress returns the commit that the pipeline proceeds with, if any
+func (c *coordinator) commitInProgress() *commitInProgress {
+	c.inProgressLock.Lock()
+	defer c.inProgressLock.Unlock()
+	return c.inProgress
+}
+
+// waitForCommitInProgress waits for the commit of the previous block by the pipeline to complete
+func (c *coordinator) waitForCommitInProgress() error {
+	c.inProgressLock.Lock()
+	defer c.inProgressLock.Unlock()
+	return c.waitForCommitInProgressLocked()
+}
+
+// waitForCommitInProgressLocked is waitForCommitInProgress for the callers that hold inProgressLock,
+// which prevents the pipeline from starting another commit while the one in progress completes
+func (c *coordinator) waitForCommitInProgressLocked() error {
+	if c.inProgress == nil {
+		return nil
+	}
+	err := c.inProgress.wait()
+	c.inProgress = nil
+	return err
+}
+
+// Close waits for the commit of the previous block by the pipeline to complete, and closes the committer
+func (c *coordinator) Close() {
+	c.waitForCommitInProgress()
+	c.Committer.Close()
+}
+
+// commitBlock commits the block along with its private data, and purges the transient store
+func (c *coordinator) commitBlock(blockAndPvtData *ledger.BlockAndPvtData, txns txns) error {
+	block := blockAndPvtData.Block
+
 	// commit block and private data
-	err = c.CommitWithPvtData(blockAndPvtData)
+	err := c.CommitWithPvtData(blockAndPvtData)
 	if err != nil {
 		return errors.Wrap(err, "commit failed")
 	}
 
 	if len(blockAndPvtData.BlockPvtData) > 0 {
 		// Finally, purge all transactions in block - valid or not valid.
-		if err := c.PurgeByTxids(privateInfo.txns); err != nil {
-			logger.Error("Purging transactions", privateInfo.txns, "failed:", err)
+		if err := c.PurgeByTxids(txns); err != nil {
+			logger.Error("Purging transactions", txns, "failed:", err)
 		}
 	}
 
@@ -240,6 +332,9 @@ func (c *coordinator) StoreBlock(block *common.Block, privateDataSets util.PvtDa
 		}
 	}
 
+	if c.commitHandler != nil {
+		c.commitHandler(block)
+	}
 	return nil
 }
 
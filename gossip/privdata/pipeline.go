@@ -0,0 +1,117 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"github.com/hyperledger/fabric/core/ledger/kvledger/txmgmt/rwsetutil"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+)
+
+const (
+	commitPipeliningConfigKey = "peer.commitPipelining"
+	lsccNamespace             = "lscc"
+)
+
+// commitInProgress tracks the commit of a block by the commit pipeline,
+// which proceeds while the next block is being validated
+type commitInProgress struct {
+	blockNum uint64
+	// txIDs are the IDs of the transactions of the block, which can't
+	// be found in the ledger until the commit completes
+	txIDs map[string]struct{}
+	// barrier indicates that the block updates the chaincode definitions
+	// or the channel configuration, which the validation of the next block
+	// depends upon, and hence the next block is validated after the commit
+	barrier bool
+	done    chan struct{}
+	err     error
+}
+
+func newCommitInProgress(block *common.Block) *commitInProgress {
+	commit := &commitInProgress{
+		blockNum: block.Header.Number,
+		txIDs:    make(map[string]struct{}),
+		done:     make(chan struct{}),
+	}
+	txsFilter := txsFilterOf(block)
+	for seqInBlock, envBytes := range block.Data.Data {
+		env, err := utils.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			continue
+		}
+		chdr, err := utils.ChannelHeader(env)
+		if err != nil {
+			continue
+		}
+		if chdr.TxId != "" {
+			commit.txIDs[chdr.TxId] = struct{}{}
+		}
+		if seqInBlock >= len(txsFilter) || txsFilter[seqInBlock] != uint8(peer.TxValidationCode_VALID) {
+			continue
+		}
+		if chdr.Type != int32(common.HeaderType_ENDORSER_TRANSACTION) || writesToLSCC(envBytes) {
+			commit.barrier = true
+		}
+	}
+	return commit
+}
+
+// writesToLSCC returns whether an endorser transaction writes to the
+// namespace of lscc, i.e. deploys or upgrades a chaincode
+func writesToLSCC(envBytes []byte) bool {
+	respPayload, err := utils.GetActionFromEnvelope(envBytes)
+	if err != nil {
+		// Let the next block be validated after the commit, for the sake of safety
+		return true
+	}
+	txRWSet := &rwsetutil.TxRwSet{}
+	if err := txRWSet.FromProtoBytes(respPayload.Results); err != nil {
+		return true
+	}
+	for _, nsRWSet := range txRWSet.NsRwSets {
+		if nsRWSet.NameSpace == lsccNamespace && len(nsRWSet.KvRwSet.Writes) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// wait waits for the commit to complete, and returns its error if any
+func (commit *commitInProgress) wait() error {
+	<-commit.done
+	return commit.err
+}
+
+// markDuplicates marks as duplicates the transactions of the given block whose IDs are the
+// ones of transactions of the block being committed, as the validator of the block couldn't
+// find them in the ledger
+func (commit *commitInProgress) markDuplicates(block *common.Block) {
+	txsFilter := txsFilterOf(block)
+	for seqInBlock, envBytes := range block.Data.Data {
+		env, err := utils.GetEnvelopeFromBlock(envBytes)
+		if err != nil {
+			continue
+		}
+		chdr, err := utils.ChannelHeader(env)
+		if err != nil || chdr.Type != int32(common.HeaderType_ENDORSER_TRANSACTION) {
+			continue
+		}
+		if _, exists := commit.txIDs[chdr.TxId]; exists && seqInBlock < len(txsFilter) {
+			logger.Error("Duplicate transaction found, ", chdr.TxId, ", in block", commit.blockNum, "being committed, skipping")
+			txsFilter[seqInBlock] = uint8(peer.TxValidationCode_DUPLICATE_TXID)
+		}
+	}
+}
+
+func txsFilterOf(block *common.Block) txValidationFlags {
+	if block.Metadata == nil || len(block.Metadata.Metadata) <= int(common.BlockMetadataIndex_TRANSACTIONS_FILTER) {
+		return nil
+	}
+	return txValidationFlags(block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+}
@@ -0,0 +1,200 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package privdata
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/ledger"
+	"github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type validatorFunc func(block *common.Block) error
+
+func (f validatorFunc) Validate(block *common.Block) error {
+	return f(block)
+}
+
+func TestNewCommitInProgress(t *testing.T) {
+	bf := &blockFactory{channelID: "test"}
+
+	// Blocks that only touch the namespaces of chaincodes aren't barriers
+	commit := newCommitInProgress(bf.AddTxn("tx1", "ns1", nil).AddTxn("tx2", "ns2", nil).create())
+	assert.False(t, commit.barrier)
+	assert.Equal(t, map[string]struct{}{"tx1": {}, "tx2": {}}, commit.txIDs)
+
+	// Blocks that deploy or upgrade chaincodes are barriers
+	commit = newCommitInProgress(bf.AddTxn("tx1", "ns1", nil).AddTxn("tx2", lsccNamespace, nil).create())
+	assert.True(t, commit.barrier)
+
+	// unless the transactions that do so are invalid
+	commit = newCommitInProgress(bf.AddTxn("tx1", "ns1", nil).AddTxn("tx2", lsccNamespace, nil).withInvalidTxns(1).create())
+	assert.False(t, commit.barrier)
+	assert.Equal(t, map[string]struct{}{"tx1": {}, "tx2": {}}, commit.txIDs)
+
+	// Reading from lscc doesn't change the chaincode definitions
+	commit = newCommitInProgress(bf.AddReadOnlyTxn("tx1", "ns1", nil).create())
+	assert.False(t, commit.barrier)
+}
+
+func TestCommitInProgressMarkDuplicates(t *testing.T) {
+	bf := &blockFactory{channelID: "test"}
+	commit := newCommitInProgress(bf.AddTxn("tx1", "ns1", nil).AddTxn("tx2", "ns1", nil).create())
+	block := bf.AddTxn("tx3", "ns1", nil).AddTxn("tx2", "ns1", nil).create()
+	commit.markDuplicates(block)
+	assert.Equal(t, []byte{uint8(peer.TxValidationCode_VALID), uint8(peer.TxValidationCode_DUPLICATE_TXID)},
+		block.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+}
+
+func TestCoordinatorCommitPipeline(t *testing.T) {
+	viper.Set("peer.commitPipelining", true)
+	defer viper.Set("peer.commitPipelining", false)
+
+	peerSelfSignedData := common.SignedData{
+		Identity:  []byte{0, 1, 2},
+		Signature: []byte{3, 4, 5},
+		Data:      []byte{6, 7, 8},
+	}
+	// The commits wait to be released, and record the last committed block
+	committed := make(chan uint64, 10)
+	release := make(chan struct{})
+	var lastCommitted uint64
+	committer := &committerMock{}
+	committer.On("CommitWithPvtData", mock.Anything).Run(func(args mock.Arguments) {
+		blockNum := args.Get(0).(*ledger.BlockAndPvtData).Block.Header.Number
+		committed <- blockNum
+		<-release
+		atomic.StoreUint64(&lastCommitted, blockNum)
+	}).Return(nil)
+	committer.On("Close").Return()
+
+	// The validations record the last committed block at the time the blocks are validated
+	type validation struct {
+		blockNum      uint64
+		lastCommitted uint64
+	}
+	validated := make(chan validation, 10)
+	validator := validatorFunc(func(block *common.Block) error {
+		validated <- validation{blockNum: block.Header.Number, lastCommitted: atomic.LoadUint64(&lastCommitted)}
+		return nil
+	})
+
+	coordinator := NewCoordinator(Support{
+		CollectionStore: createcollectionStore(peerSelfSignedData).thatAcceptsAll(),
+		Committer:       committer,
+		Fetcher:         &fetcherMock{t: t},
+		TransientStore:  &mockTransientStore{t: t},
+		Validator:       validator,
+	}, peerSelfSignedData)
+	// The blocks are notified once they are committed
+	notified := make(chan uint64, 10)
+	coordinator.SetCommitHandler(func(block *common.Block) {
+		notified <- block.Header.Number
+	})
+
+	bf := &blockFactory{channelID: "test"}
+	storeBlock := func(blockNum uint64, bf *blockFactory) (*common.Block, chan error) {
+		block := bf.create()
+		block.Header.Number = blockNum
+		done := make(chan error, 1)
+		go func() {
+			done <- coordinator.StoreBlock(block, nil)
+		}()
+		return block, done
+	}
+
+	// Block 1 is being committed when StoreBlock returns
+	_, done := storeBlock(1, bf.AddTxn("tx1", "ns1", nil).AddTxn("tx2", "ns1", nil))
+	assert.Equal(t, validation{blockNum: 1, lastCommitted: 0}, <-validated)
+	assert.NoError(t, <-done)
+	assert.Equal(t, uint64(1), <-committed)
+	assert.Empty(t, notified)
+
+	// Block 2 is validated while block 1 is being committed, and its transaction
+	// that has the ID of a transaction of block 1 is marked as a duplicate
+	block2, done := storeBlock(2, bf.AddTxn("tx3", "ns1", nil).AddTxn("tx1", "ns1", nil))
+	assert.Equal(t, validation{blockNum: 2, lastCommitted: 0}, <-validated)
+	release <- struct{}{}
+	assert.NoError(t, <-done)
+	assert.Equal(t, uint64(1), <-notified)
+	assert.Equal(t, uint64(2), <-committed)
+	assert.Empty(t, notified)
+	assert.Equal(t, []byte{uint8(peer.TxValidationCode_VALID), uint8(peer.TxValidationCode_DUPLICATE_TXID)},
+		block2.Metadata.Metadata[common.BlockMetadataIndex_TRANSACTIONS_FILTER])
+
+	// Block 3 upgrades a chaincode, and is validated while block 2 is being committed
+	_, done = storeBlock(3, bf.AddTxn("tx4", lsccNamespace, nil))
+	assert.Equal(t, validation{blockNum: 3, lastCommitted: 1}, <-validated)
+	release <- struct{}{}
+	assert.NoError(t, <-done)
+	assert.Equal(t, uint64(2), <-notified)
+	assert.Equal(t, uint64(3), <-committed)
+
+	// Block 4 is validated after block 3 is committed
+	_, done = storeBlock(4, bf.AddTxn("tx5", "ns1", nil))
+	release <- struct{}{}
+	assert.Equal(t, validation{blockNum: 4, lastCommitted: 3}, <-validated)
+	assert.NoError(t, <-done)
+	assert.Equal(t, uint64(3), <-notified)
+	assert.Equal(t, uint64(4), <-committed)
+
+	// Closing the coordinator waits for the commit of block 4
+	closed := make(chan struct{})
+	go func() {
+		coordinator.Close()
+		close(closed)
+	}()
+	release <- struct{}{}
+	<-closed
+	assert.Equal(t, uint64(4), atomic.LoadUint64(&lastCommitted))
+	assert.Equal(t, uint64(4), <-notified)
+	committer.AssertCalled(t, "Close")
+}
+
+func TestCoordinatorCommitPipelineFailure(t *testing.T) {
+	viper.Set("peer.commitPipelining", true)
+	defer viper.Set("peer.commitPipelining", false)
+
+	peerSelfSignedData := common.SignedData{
+		Identity:  []byte{0, 1, 2},
+		Signature: []byte{3, 4, 5},
+		Data:      []byte{6, 7, 8},
+	}
+	committer := &committerMock{}
+	committer.On("CommitWithPvtData", mock.Anything).Return(errors.New("disk is full"))
+	coordinator := NewCoordinator(Support{
+		CollectionStore: createcollectionStore(peerSelfSignedData).thatAcceptsAll(),
+		Committer:       committer,
+		Fetcher:         &fetcherMock{t: t},
+		TransientStore:  &mockTransientStore{t: t},
+		Validator:       validatorFunc(func(block *common.Block) error { return nil }),
+	}, peerSelfSignedData)
+	var notified []uint64
+	coordinator.SetCommitHandler(func(block *common.Block) {
+		notified = append(notified, block.Header.Number)
+	})
+
+	bf := &blockFactory{channelID: "test"}
+	block1 := bf.AddTxn("tx1", "ns1", nil).create()
+	block1.Header.Number = 1
+	assert.NoError(t, coordinator.StoreBlock(block1, nil))
+
+	// The failure of the commit of block 1 is returned when block 2 is stored,
+	// and block 1 is never notified as committed
+	block2 := bf.AddTxn("tx2", "ns1", nil).create()
+	block2.Header.Number = 2
+	err := coordinator.StoreBlock(block2, nil)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "disk is full")
+	assert.Empty(t, notified)
+}
@@ -50,6 +50,8 @@ type GossipService interface {
 	InitializeChannel(chainID string, endpoints []string, support Support)
 	// AddPayload appends message payload to for given chain
 	AddPayload(chainID string, payload *gproto.Payload) error
+	// YieldLeadership makes the peer relinquish its leadership of the given chain
+	YieldLeadership(chainID string) error
 }
 
 // DeliveryServiceFactory factory to create and initialize delivery service instance
@@ -341,6 +343,23 @@ func (g *gossipServiceImpl) AddPayload(chainID string, payload *gproto.Payload)
 	return g.chains[chainID].AddPayload(payload)
 }
 
+// YieldLeadership makes the peer relinquish its leadership of the given chain,
+// so that another peer of the organization is elected
+func (g *gossipServiceImpl) YieldLeadership(chainID string) error {
+	g.lock.RLock()
+	le, exists := g.leaderElection[chainID]
+	g.lock.RUnlock()
+	if !exists {
+		return errors.Errorf("leader election is not enabled for channel %s", chainID)
+	}
+	if !le.IsLeader() {
+		return errors.Errorf("peer is not a leader of channel %s", chainID)
+	}
+	logger.Info("Yielding leadership of channel", chainID)
+	le.Yield()
+	return nil
+}
+
 // Stop stops the gossip component
 func (g *gossipServiceImpl) Stop() {
 	g.lock.Lock()
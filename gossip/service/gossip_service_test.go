@@ -161,6 +161,22 @@ func TestLeaderElectionWithDeliverClient(t *testing.T) {
 
 	assert.Equal(t, 1, startsNum, "Only for one peer delivery client should start")
 
+	// Only the leader can yield its leadership, and when it does it stops the delivery client
+	leader := -1
+	for i := 0; i < n; i++ {
+		if services[i].IsLeader() {
+			leader = i
+			continue
+		}
+		assert.EqualError(t, gossips[i].YieldLeadership(channelName), "peer is not a leader of channel chanA")
+	}
+	if leader == -1 {
+		t.Fatal("No leader was elected")
+	}
+	assert.NoError(t, gossips[leader].YieldLeadership(channelName))
+	assert.False(t, services[leader].IsLeader())
+	assert.False(t, gossips[leader].(*gossipServiceImpl).deliveryService[channelName].(*mockDeliverService).running[channelName])
+
 	stopPeers(gossips)
 }
 
@@ -260,6 +276,7 @@ func TestWithStaticDeliverClientNotLeader(t *testing.T) {
 	for i := 0; i < n; i++ {
 		assert.NotNil(t, gossips[i].(*gossipServiceImpl).deliveryService[channelName], "Delivery service for channel %s not initiated in peer %d", channelName, i)
 		assert.False(t, gossips[i].(*gossipServiceImpl).deliveryService[channelName].(*mockDeliverService).running[channelName], "Block deliverer should not be started for peer %d", i)
+		assert.EqualError(t, gossips[i].YieldLeadership(channelName), "leader election is not enabled for channel chanA")
 	}
 
 	stopPeers(gossips)
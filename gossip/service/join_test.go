@@ -68,6 +68,22 @@ func (*gossipMock) UpdateChannelMetadata(metadata []byte, chainID common.ChainID
 	panic("implement me")
 }
 
+func (*gossipMock) UpdateChaincodes(chaincodes []*proto.Chaincode, chainID common.ChainID) {
+	panic("implement me")
+}
+
+func (*gossipMock) UpdateInstalledChaincodes(chaincodes []*proto.Chaincode) {
+	panic("implement me")
+}
+
+func (*gossipMock) SelfChannelInfo(common.ChainID) *proto.SignedGossipMessage {
+	panic("implement me")
+}
+
+func (*gossipMock) PeersOfChannelMatching(common.ChainID, gossip.PeerCriteria) []discovery.NetworkMember {
+	panic("implement me")
+}
+
 func (*gossipMock) Gossip(msg *proto.GossipMessage) {
 	panic("implement me")
 }
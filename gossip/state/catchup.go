@@ -0,0 +1,655 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hyperledger/fabric/gossip/comm"
+	common2 "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+const (
+	defCatchUpThreshold       = 100
+	defCatchUpMaxPeers        = 4
+	defCatchUpResponseTimeout = defAntiEntropyStateResponseTimeout
+	defCatchUpWindow          = defMaxBlockDistance * 2
+	defCatchUpStallTimeout    = 10 * defAntiEntropyStateResponseTimeout
+
+	catchUpProgressInterval = 10 * time.Second
+	catchUpLatencyWeight    = 0.3
+
+	catchUpMetricsSubScope = "gossip_state_catchup"
+)
+
+// catchUpConfig defines the parameters of the catch-up mode, in which
+// a peer that is far behind fetches the missing blocks from several
+// peers in parallel
+type catchUpConfig struct {
+	// enabled determines whether the catch-up mode is used
+	enabled bool
+	// threshold is the minimal number of missing blocks for
+	// which the catch-up mode is used instead of the sequential
+	// anti-entropy requests
+	threshold uint64
+	// maxPeers is the maximal number of peers blocks are fetched from in parallel
+	maxPeers int
+	// maxBatchSize is the maximal number of blocks requested in a single state request
+	maxBatchSize uint64
+	// responseTimeout is the time to wait for a state response
+	responseTimeout time.Duration
+	// window is the maximal number of blocks ahead of the ledger
+	// that are requested, which bounds the size of the payloads buffer
+	window uint64
+}
+
+// stateBatchSize returns the maximal number of blocks requested
+// in a single state request, and served in a single state response
+func stateBatchSize() uint64 {
+	if viper.IsSet("peer.gossip.state.batchSize") {
+		if batchSize := uint64(viper.GetInt("peer.gossip.state.batchSize")); batchSize > 0 {
+			return batchSize
+		}
+	}
+	return defAntiEntropyBatchSize
+}
+
+func readCatchUpConfig() catchUpConfig {
+	conf := catchUpConfig{
+		enabled:         viper.GetBool("peer.gossip.state.catchUp.enabled"),
+		threshold:       defCatchUpThreshold,
+		maxPeers:        defCatchUpMaxPeers,
+		maxBatchSize:    stateBatchSize(),
+		responseTimeout: defCatchUpResponseTimeout,
+		window:          defCatchUpWindow,
+	}
+	if threshold := viper.GetInt("peer.gossip.state.catchUp.threshold"); threshold > 0 {
+		conf.threshold = uint64(threshold)
+	}
+	if maxPeers := viper.GetInt("peer.gossip.state.catchUp.maxPeers"); maxPeers > 0 {
+		conf.maxPeers = maxPeers
+	}
+	if timeout := viper.GetDuration("peer.gossip.state.catchUp.responseTimeout"); timeout > 0 {
+		conf.responseTimeout = timeout
+	}
+	return conf
+}
+
+// CatchUpStatus describes the progress of the catch-up mode
+type CatchUpStatus struct {
+	// Active is whether blocks are being fetched in catch-up mode
+	Active bool
+	// StartSeq is the sequence of the first block that was missing
+	// when the last catch-up started
+	StartSeq uint64
+	// TargetSeq is the sequence of the last block fetched by the last catch-up
+	TargetSeq uint64
+	// BlocksFetched is the number of blocks fetched by the last catch-up
+	BlocksFetched uint64
+	// StartTime is the time the last catch-up started
+	StartTime time.Time
+	// EndTime is the time the last catch-up ended, and is zero while it is active
+	EndTime time.Time
+	// Peers are the statistics of the peers blocks were fetched from
+	Peers []PeerStats
+}
+
+// PeerStats describes how well a peer serves state requests
+type PeerStats struct {
+	Endpoint string
+	PKIid    common2.PKIidType
+	// Latency is the moving average of the time it takes the peer to serve a block
+	Latency time.Duration
+	// Successes is the number of state requests the peer served
+	Successes int
+	// Failures is the number of state requests the peer failed serving
+	Failures int
+	// BlocksFetched is the number of blocks fetched from the peer
+	BlocksFetched uint64
+	// BatchSize is the current number of blocks requested from the peer at once
+	BatchSize uint64
+}
+
+// peerScore keeps track of the performance of a peer,
+// and adapts the batch size of its state requests
+type peerScore struct {
+	PeerStats
+	// consecutiveFailures is the number of failures since the last success
+	consecutiveFailures int
+}
+
+// score returns the score of the peer, where peers with lower scores are preferred
+func (ps *peerScore) score() float64 {
+	latency := ps.Latency
+	if ps.Successes == 0 && ps.Failures > 0 {
+		// Peers that never served a request are considered as slow as the timeout
+		latency = defCatchUpResponseTimeout
+	}
+	return float64(latency) * float64(1+ps.consecutiveFailures)
+}
+
+func (ps *peerScore) succeeded(blocks uint64, elapsed time.Duration, conf catchUpConfig) {
+	ps.Successes++
+	ps.consecutiveFailures = 0
+	ps.BlocksFetched += blocks
+	latency := elapsed / time.Duration(blocks)
+	if ps.Latency == 0 {
+		ps.Latency = latency
+	} else {
+		ps.Latency = time.Duration(catchUpLatencyWeight*float64(latency) + (1-catchUpLatencyWeight)*float64(ps.Latency))
+	}
+	// Peers that respond slowly are asked for fewer blocks at once,
+	// in order not to reach the response timeout
+	if elapsed > conf.responseTimeout/2 {
+		ps.shrinkBatch()
+		return
+	}
+	ps.BatchSize = min(ps.BatchSize*2, conf.maxBatchSize)
+}
+
+func (ps *peerScore) failed() {
+	ps.Failures++
+	ps.consecutiveFailures++
+	ps.shrinkBatch()
+}
+
+func (ps *peerScore) shrinkBatch() {
+	if ps.BatchSize > 1 {
+		ps.BatchSize /= 2
+	}
+}
+
+// seqRange is a range of block sequences [from...to]
+type seqRange struct {
+	from uint64
+	to   uint64
+}
+
+// rangeScheduler splits the range of missing blocks into chunks and
+// assigns them to the peers blocks are fetched from
+type rangeScheduler struct {
+	sync.Mutex
+	// next is the sequence of the first block that wasn't assigned yet
+	next uint64
+	end  uint64
+	// retries are the chunks that weren't fetched and need to be assigned again
+	retries  []seqRange
+	inFlight int
+}
+
+type assignment int
+
+const (
+	assigned assignment = iota
+	// wait means there are blocks the peer can serve, but they are too
+	// far ahead of the ledger, or are currently fetched by other peers
+	wait
+	// exhausted means there are no more blocks the peer can serve
+	exhausted
+)
+
+// assign returns the next chunk of at most size blocks, which doesn't
+// go beyond the given peer height, nor beyond the given window end
+func (rs *rangeScheduler) assign(peerHeight uint64, windowEnd uint64, size uint64) (seqRange, assignment) {
+	rs.Lock()
+	defer rs.Unlock()
+
+	// Missing blocks that were already assigned have precedence
+	// since the ledger can't advance without them
+	for i, r := range rs.retries {
+		if r.from > peerHeight {
+			continue
+		}
+		chunk := seqRange{from: r.from, to: min(r.to, min(peerHeight, r.from+size-1))}
+		if chunk.to == r.to {
+			rs.retries = append(rs.retries[:i], rs.retries[i+1:]...)
+		} else {
+			rs.retries[i].from = chunk.to + 1
+		}
+		rs.inFlight++
+		return chunk, assigned
+	}
+
+	if rs.next > rs.end || rs.next > peerHeight {
+		if rs.inFlight > 0 || len(rs.retries) > 0 {
+			// Chunks that are in flight might fail and need to be re-assigned
+			return seqRange{}, wait
+		}
+		return seqRange{}, exhausted
+	}
+	if rs.next > windowEnd {
+		return seqRange{}, wait
+	}
+	chunk := seqRange{from: rs.next, to: min(min(rs.end, peerHeight), min(windowEnd, rs.next+size-1))}
+	rs.next = chunk.to + 1
+	rs.inFlight++
+	return chunk, assigned
+}
+
+// complete marks the given chunk as no longer in flight,
+// and re-schedules the given missing ranges of it
+func (rs *rangeScheduler) complete(missing ...seqRange) {
+	rs.Lock()
+	defer rs.Unlock()
+	rs.inFlight--
+	rs.retries = append(rs.retries, missing...)
+	sort.Slice(rs.retries, func(i, j int) bool {
+		return rs.retries[i].from < rs.retries[j].from
+	})
+}
+
+// done returns whether all blocks were fetched
+func (rs *rangeScheduler) done() bool {
+	rs.Lock()
+	defer rs.Unlock()
+	return rs.next > rs.end && len(rs.retries) == 0 && rs.inFlight == 0
+}
+
+// missingRanges returns the sub-ranges of the given range
+// whose sequences are not among the given received sequences
+func missingRanges(r seqRange, received map[uint64]struct{}) []seqRange {
+	var missing []seqRange
+	for seq := r.from; seq <= r.to; seq++ {
+		if _, exists := received[seq]; exists {
+			continue
+		}
+		if n := len(missing); n > 0 && missing[n-1].to == seq-1 {
+			missing[n-1].to = seq
+			continue
+		}
+		missing = append(missing, seqRange{from: seq, to: seq})
+	}
+	return missing
+}
+
+// catchUpPeer is a peer blocks can be fetched from, along with its ledger height
+type catchUpPeer struct {
+	*comm.RemotePeer
+	height uint64
+}
+
+// responseRouter routes state responses to the requests they correspond to
+type responseRouter struct {
+	sync.Mutex
+	pending map[uint64]chan proto.ReceivedMessage
+}
+
+func (rr *responseRouter) register(nonce uint64) chan proto.ReceivedMessage {
+	rr.Lock()
+	defer rr.Unlock()
+	ch := make(chan proto.ReceivedMessage, 1)
+	rr.pending[nonce] = ch
+	return ch
+}
+
+func (rr *responseRouter) unregister(nonce uint64) {
+	rr.Lock()
+	defer rr.Unlock()
+	delete(rr.pending, nonce)
+}
+
+func (rr *responseRouter) route(msg proto.ReceivedMessage) {
+	rr.Lock()
+	defer rr.Unlock()
+	ch, exists := rr.pending[msg.GetGossipMessage().Nonce]
+	if !exists {
+		return
+	}
+	select {
+	case ch <- msg:
+	default:
+	}
+}
+
+// CatchUpStatus returns the progress of the catch-up mode
+func (s *GossipStateProviderImpl) CatchUpStatus() CatchUpStatus {
+	s.catchUpLock.RLock()
+	defer s.catchUpLock.RUnlock()
+	status := s.catchUpStatus
+	status.BlocksFetched = atomic.LoadUint64(&s.catchUpFetched)
+	status.Peers = make([]PeerStats, 0, len(s.peerScores))
+	for _, ps := range s.peerScores {
+		status.Peers = append(status.Peers, ps.PeerStats)
+	}
+	sort.Slice(status.Peers, func(i, j int) bool {
+		return status.Peers[i].Endpoint < status.Peers[j].Endpoint
+	})
+	return status
+}
+
+// catchUp fetches the blocks in the range [start...end] from several
+// peers in parallel, and pushes them into the payloads buffer which
+// reorders them before they are committed.
+// Returns false if there were no peers to fetch the blocks from.
+func (s *GossipStateProviderImpl) catchUp(start uint64, end uint64) bool {
+	atomic.StoreInt32(&s.stateTransferActive, 1)
+	defer atomic.StoreInt32(&s.stateTransferActive, 0)
+
+	s.refreshPeerScores()
+	peers := s.selectPeersToCatchUpFrom(start)
+	if len(peers) == 0 {
+		logger.Warningf("Cannot catch up blocks in range [%d...%d], there are no peers to ask for missing blocks from", start, end)
+		return false
+	}
+
+	s.catchUpLock.Lock()
+	s.catchUpStatus = CatchUpStatus{
+		Active:    true,
+		StartSeq:  start,
+		TargetSeq: end,
+		StartTime: time.Now(),
+	}
+	atomic.StoreUint64(&s.catchUpFetched, 0)
+	s.catchUpLock.Unlock()
+	s.reportCatchUpStatus()
+
+	logger.Infof("Catching up blocks in range [%d...%d] from %d peers for chainID %s", start, end, len(peers), s.chainID)
+
+	scheduler := &rangeScheduler{next: start, end: end}
+	router := &responseRouter{pending: make(map[uint64]chan proto.ReceivedMessage)}
+	abort := make(chan struct{})
+
+	var routerDone sync.WaitGroup
+	routerDone.Add(1)
+	go func() {
+		defer routerDone.Done()
+		for {
+			select {
+			case msg := <-s.stateResponseCh:
+				router.route(msg)
+			case <-abort:
+				return
+			}
+		}
+	}()
+
+	var workers sync.WaitGroup
+	workers.Add(len(peers))
+	for _, p := range peers {
+		go func(p catchUpPeer) {
+			defer workers.Done()
+			s.fetchBlocksFrom(p, scheduler, router, abort)
+		}(p)
+	}
+	workersDone := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(workersDone)
+	}()
+
+	stopped := false
+	progress := time.NewTicker(catchUpProgressInterval)
+	defer progress.Stop()
+	for finished := false; !finished; {
+		select {
+		case <-workersDone:
+			finished = true
+		case <-progress.C:
+			s.logCatchUpProgress()
+		case <-s.stopCh:
+			stopped = true
+			close(abort)
+			<-workersDone
+			finished = true
+		}
+	}
+	if !stopped {
+		close(abort)
+	}
+	routerDone.Wait()
+
+	s.catchUpLock.Lock()
+	s.catchUpStatus.Active = false
+	s.catchUpStatus.EndTime = time.Now()
+	s.catchUpLock.Unlock()
+	s.reportCatchUpStatus()
+
+	status := s.CatchUpStatus()
+	if scheduler.done() {
+		logger.Infof("Caught up blocks in range [%d...%d] for chainID %s, fetched %d blocks in %s",
+			start, end, s.chainID, status.BlocksFetched, status.EndTime.Sub(status.StartTime))
+	} else {
+		logger.Warningf("Catch up of blocks in range [%d...%d] for chainID %s ended prematurely, fetched %d blocks in %s",
+			start, end, s.chainID, status.BlocksFetched, status.EndTime.Sub(status.StartTime))
+	}
+
+	if stopped {
+		s.stopCh <- struct{}{}
+	}
+	return true
+}
+
+func (s *GossipStateProviderImpl) logCatchUpProgress() {
+	s.reportCatchUpStatus()
+	status := s.CatchUpStatus()
+	total := status.TargetSeq - status.StartSeq + 1
+	elapsed := time.Since(status.StartTime)
+	logger.Infof("Catching up blocks for chainID %s, fetched %d out of %d blocks in %s (%.1f blocks/sec), next block to commit is %d",
+		s.chainID, status.BlocksFetched, total, elapsed, float64(status.BlocksFetched)/elapsed.Seconds(), s.payloads.Next())
+}
+
+// fetchBlocksFrom repeatedly requests chunks of blocks from the given peer
+// until there are no more blocks it can serve, or it fails too many times
+func (s *GossipStateProviderImpl) fetchBlocksFrom(p catchUpPeer, scheduler *rangeScheduler, router *responseRouter, abort chan struct{}) {
+	ps := s.peerScore(p.RemotePeer)
+	lastProgress := time.Now()
+	lastNext := s.payloads.Next()
+
+	for {
+		s.catchUpLock.RLock()
+		batchSize, failures := ps.BatchSize, ps.consecutiveFailures
+		s.catchUpLock.RUnlock()
+		if failures > defAntiEntropyMaxRetries {
+			logger.Warningf("Stopped fetching blocks from %s after %d consecutive failures", p.Endpoint, failures)
+			return
+		}
+
+		next := s.payloads.Next()
+		if next != lastNext {
+			lastNext, lastProgress = next, time.Now()
+		}
+		chunk, res := scheduler.assign(p.height, next+s.catchUpConf.window-1, batchSize)
+		switch res {
+		case exhausted:
+			return
+		case wait:
+			if time.Since(lastProgress) > defCatchUpStallTimeout {
+				logger.Warningf("Stopped fetching blocks from %s since the ledger hasn't advanced past block %d", p.Endpoint, next)
+				return
+			}
+			select {
+			case <-time.After(enqueueRetryInterval):
+			case <-abort:
+				return
+			}
+			continue
+		}
+
+		received, elapsed, err := s.requestChunk(p, chunk, router, abort)
+
+		s.catchUpLock.Lock()
+		if err != nil {
+			ps.failed()
+		} else {
+			ps.succeeded(uint64(len(received)), elapsed, s.catchUpConf)
+		}
+		s.catchUpLock.Unlock()
+
+		if err != nil {
+			logger.Warningf("Failed fetching blocks in range [%d...%d] from %s, due to %+v", chunk.from, chunk.to, p.Endpoint, err)
+		}
+		scheduler.complete(missingRanges(chunk, received)...)
+
+		select {
+		case <-abort:
+			return
+		default:
+		}
+	}
+}
+
+// requestChunk requests the given range of blocks from the given peer, verifies
+// the blocks in the response and pushes them into the payloads buffer.
+// Returns the sequences of the blocks received, and the time it took to receive them.
+func (s *GossipStateProviderImpl) requestChunk(p catchUpPeer, chunk seqRange, router *responseRouter, abort chan struct{}) (map[uint64]struct{}, time.Duration, error) {
+	gossipMsg := s.stateRequestMessage(chunk.from, chunk.to)
+	responses := router.register(gossipMsg.Nonce)
+	defer router.unregister(gossipMsg.Nonce)
+
+	logger.Debugf("State transfer, with peer %s, requesting blocks in range [%d...%d], "+
+		"for chainID %s", p.Endpoint, chunk.from, chunk.to, s.chainID)
+
+	sent := time.Now()
+	s.mediator.Send(gossipMsg, p.RemotePeer)
+
+	var msg proto.ReceivedMessage
+	select {
+	case msg = <-responses:
+	case <-time.After(s.catchUpConf.responseTimeout):
+		return nil, 0, errors.Errorf("timed out after %s", s.catchUpConf.responseTimeout)
+	case <-abort:
+		return nil, 0, errors.New("catch up aborted")
+	}
+	elapsed := time.Since(sent)
+
+	received := make(map[uint64]struct{})
+	for _, payload := range msg.GetGossipMessage().GetStateResponse().GetPayloads() {
+		if payload.SeqNum < chunk.from || payload.SeqNum > chunk.to {
+			return nil, 0, errors.Errorf("received block with sequence number %d which wasn't requested", payload.SeqNum)
+		}
+		if err := s.mediator.VerifyBlock(common2.ChainID(s.chainID), payload.SeqNum, payload.Data); err != nil {
+			return nil, 0, errors.WithMessage(err, "failed verifying block")
+		}
+		received[payload.SeqNum] = struct{}{}
+	}
+	if len(received) == 0 {
+		return nil, 0, errors.New("received state transfer response without payload")
+	}
+
+	// The blocks are pushed only once all of them were verified, and the
+	// buffer isn't checked for its size since the blocks fetched are within
+	// the catch-up window, which bounds the size of the buffer
+	for _, payload := range msg.GetGossipMessage().GetStateResponse().GetPayloads() {
+		s.payloads.Push(payload)
+	}
+	atomic.AddUint64(&s.catchUpFetched, uint64(len(received)))
+	return received, elapsed, nil
+}
+
+// reportCatchUpStatus reports the progress of the catch-up mode through metrics
+func (s *GossipStateProviderImpl) reportCatchUpStatus() {
+	if s.metricsScope == nil {
+		return
+	}
+	status := s.CatchUpStatus()
+	active := 0.0
+	if status.Active {
+		active = 1
+	}
+	s.metricsScope.Gauge("active").Update(active)
+	s.metricsScope.Gauge("blocks_fetched").Update(float64(status.BlocksFetched))
+	s.metricsScope.Gauge("blocks_total").Update(float64(status.TargetSeq - status.StartSeq + 1))
+	s.metricsScope.Gauge("peers").Update(float64(len(status.Peers)))
+}
+
+// refreshPeerScores drops the scores of the peers that are no longer members of
+// the channel, and decays the consecutive failures of the others, so that peers
+// which failed transiently are asked for blocks again in later catch-ups
+func (s *GossipStateProviderImpl) refreshPeerScores() {
+	members := make(map[string]struct{})
+	for _, member := range s.mediator.PeersOfChannel(common2.ChainID(s.chainID)) {
+		members[string(member.PKIid)] = struct{}{}
+	}
+
+	s.catchUpLock.Lock()
+	defer s.catchUpLock.Unlock()
+	for pkiID, ps := range s.peerScores {
+		if _, exists := members[pkiID]; !exists {
+			delete(s.peerScores, pkiID)
+			continue
+		}
+		ps.consecutiveFailures /= 2
+	}
+}
+
+// peerScore returns the score of the given peer, creating it if needed
+func (s *GossipStateProviderImpl) peerScore(peer *comm.RemotePeer) *peerScore {
+	s.catchUpLock.Lock()
+	defer s.catchUpLock.Unlock()
+	ps, exists := s.peerScores[string(peer.PKIID)]
+	if !exists {
+		ps = &peerScore{
+			PeerStats: PeerStats{
+				Endpoint:  peer.Endpoint,
+				PKIid:     peer.PKIID,
+				BatchSize: max(1, s.catchUpConf.maxBatchSize/2),
+			},
+		}
+		s.peerScores[string(peer.PKIID)] = ps
+	}
+	return ps
+}
+
+// selectPeersToCatchUpFrom returns the peers with the best scores
+// among the peers that have the block with the given sequence
+func (s *GossipStateProviderImpl) selectPeersToCatchUpFrom(seq uint64) []catchUpPeer {
+	var peers []catchUpPeer
+	for _, member := range s.mediator.PeersOfChannel(common2.ChainID(s.chainID)) {
+		height, ok := ledgerHeightOf(member)
+		if !ok || height < seq {
+			continue
+		}
+		peers = append(peers, catchUpPeer{
+			RemotePeer: &comm.RemotePeer{Endpoint: member.PreferredEndpoint(), PKIID: member.PKIid},
+			height:     height,
+		})
+	}
+
+	s.catchUpLock.RLock()
+	score := func(p catchUpPeer) float64 {
+		// Peers that weren't asked yet are preferred, in order to learn their scores
+		if ps, exists := s.peerScores[string(p.PKIID)]; exists {
+			return ps.score()
+		}
+		return 0
+	}
+	sort.SliceStable(peers, func(i, j int) bool {
+		return score(peers[i]) < score(peers[j])
+	})
+	s.catchUpLock.RUnlock()
+
+	if len(peers) > s.catchUpConf.maxPeers {
+		peers = peers[:s.catchUpConf.maxPeers]
+	}
+	return peers
+}
+
+// ledgerHeightOf returns the ledger height the given member advertises
+func ledgerHeightOf(member discovery.NetworkMember) (uint64, bool) {
+	if member.Properties != nil {
+		return member.Properties.LedgerHeight, true
+	}
+	nodeMetastate, err := common2.FromBytes(member.Metadata)
+	if err != nil {
+		return 0, false
+	}
+	return nodeMetastate.LedgerHeight, true
+}
+
+func max(a uint64, b uint64) uint64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,433 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package state
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/util"
+	"github.com/hyperledger/fabric/gossip/comm"
+	"github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/gossip/discovery"
+	gutil "github.com/hyperledger/fabric/gossip/util"
+	pcomm "github.com/hyperledger/fabric/protos/common"
+	proto "github.com/hyperledger/fabric/protos/gossip"
+	"github.com/hyperledger/fabric/protos/ledger/rwset"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRangeScheduler(t *testing.T) {
+	rs := &rangeScheduler{next: 1, end: 20}
+
+	chunk, res := rs.assign(20, 100, 5)
+	assert.Equal(t, assigned, res)
+	assert.Equal(t, seqRange{from: 1, to: 5}, chunk)
+
+	// Chunks don't go beyond the height of the peer
+	chunk, res = rs.assign(7, 100, 5)
+	assert.Equal(t, assigned, res)
+	assert.Equal(t, seqRange{from: 6, to: 7}, chunk)
+
+	// Nor beyond the window
+	chunk, res = rs.assign(20, 10, 5)
+	assert.Equal(t, assigned, res)
+	assert.Equal(t, seqRange{from: 8, to: 10}, chunk)
+	_, res = rs.assign(20, 10, 5)
+	assert.Equal(t, wait, res)
+
+	// Missing blocks are assigned first
+	rs.complete(seqRange{from: 3, to: 5})
+	chunk, res = rs.assign(20, 10, 2)
+	assert.Equal(t, assigned, res)
+	assert.Equal(t, seqRange{from: 3, to: 4}, chunk)
+	chunk, res = rs.assign(20, 10, 2)
+	assert.Equal(t, assigned, res)
+	assert.Equal(t, seqRange{from: 5, to: 5}, chunk)
+
+	chunk, res = rs.assign(20, 100, 20)
+	assert.Equal(t, assigned, res)
+	assert.Equal(t, seqRange{from: 11, to: 20}, chunk)
+
+	// Chunks that are in flight might need to be re-assigned
+	_, res = rs.assign(20, 100, 20)
+	assert.Equal(t, wait, res)
+	assert.False(t, rs.done())
+	for i := 0; i < 4; i++ {
+		rs.complete()
+	}
+	assert.False(t, rs.done())
+	rs.complete()
+	assert.True(t, rs.done())
+	_, res = rs.assign(20, 100, 20)
+	assert.Equal(t, exhausted, res)
+}
+
+func TestMissingRanges(t *testing.T) {
+	received := map[uint64]struct{}{3: {}, 4: {}, 7: {}}
+	assert.Equal(t, []seqRange{{from: 1, to: 2}, {from: 5, to: 6}, {from: 8, to: 10}},
+		missingRanges(seqRange{from: 1, to: 10}, received))
+	assert.Empty(t, missingRanges(seqRange{from: 3, to: 4}, received))
+}
+
+func TestPeerScore(t *testing.T) {
+	conf := catchUpConfig{maxBatchSize: 10, responseTimeout: time.Second}
+	ps := &peerScore{PeerStats: PeerStats{BatchSize: 5}}
+
+	ps.succeeded(5, 100*time.Millisecond, conf)
+	assert.Equal(t, uint64(10), ps.BatchSize)
+	assert.Equal(t, 20*time.Millisecond, ps.Latency)
+	ps.succeeded(10, 200*time.Millisecond, conf)
+	assert.Equal(t, uint64(10), ps.BatchSize)
+	assert.Equal(t, uint64(15), ps.BlocksFetched)
+	scoreBefore := ps.score()
+
+	// Slow responses shrink the batch size
+	ps.succeeded(10, 900*time.Millisecond, conf)
+	assert.Equal(t, uint64(5), ps.BatchSize)
+	assert.True(t, ps.score() > scoreBefore)
+	scoreBefore = ps.score()
+
+	// So do failures, which also worsen the score
+	ps.failed()
+	ps.failed()
+	ps.failed()
+	assert.Equal(t, uint64(1), ps.BatchSize)
+	assert.Equal(t, 3, ps.Failures)
+	assert.Equal(t, 4*scoreBefore, ps.score())
+	ps.failed()
+	assert.Equal(t, uint64(1), ps.BatchSize)
+
+	ps.succeeded(1, 10*time.Millisecond, conf)
+	assert.Equal(t, 0, ps.consecutiveFailures)
+	assert.Equal(t, 4, ps.Successes)
+
+	// Peers that never served a request score worse than peers that did
+	unresponsive := &peerScore{PeerStats: PeerStats{BatchSize: 5}}
+	assert.Zero(t, unresponsive.score())
+	unresponsive.failed()
+	assert.True(t, unresponsive.score() > ps.score())
+}
+
+// catchUpLedger is a ledger that records the order in which blocks are committed
+type catchUpLedger struct {
+	sync.Mutex
+	height    uint64
+	committed []uint64
+}
+
+func (l *catchUpLedger) StoreBlock(block *pcomm.Block, data gutil.PvtDataCollections) error {
+	l.Lock()
+	defer l.Unlock()
+	if block.Header.Number != l.height {
+		return errors.Errorf("expected block %d but got block %d", l.height, block.Header.Number)
+	}
+	l.height++
+	l.committed = append(l.committed, block.Header.Number)
+	return nil
+}
+
+func (l *catchUpLedger) StorePvtData(txid string, privData *rwset.TxPvtReadWriteSet) error {
+	return nil
+}
+
+func (l *catchUpLedger) GetPvtDataAndBlockByNum(seqNum uint64, peerAuthInfo pcomm.SignedData) (*pcomm.Block, gutil.PvtDataCollections, error) {
+	return nil, nil, errors.New("not implemented")
+}
+
+func (l *catchUpLedger) LedgerHeight() (uint64, error) {
+	l.Lock()
+	defer l.Unlock()
+	return l.height, nil
+}
+
+func (l *catchUpLedger) Close() {
+}
+
+// catchUpPeerMock is a peer that serves state requests,
+// unless it is silent in which case it never responds
+type catchUpPeerMock struct {
+	member discovery.NetworkMember
+	silent bool
+}
+
+// catchUpGossip serves state requests from the blocks of its peers
+type catchUpGossip struct {
+	sync.Mutex
+	peers    map[string]*catchUpPeerMock
+	commChan chan proto.ReceivedMessage
+	requests map[string]int
+}
+
+func newCatchUpGossip(t *testing.T, height uint64, silentPeers int, peers int) *catchUpGossip {
+	g := &catchUpGossip{
+		peers:    make(map[string]*catchUpPeerMock),
+		commChan: make(chan proto.ReceivedMessage, defChannelBufferSize),
+		requests: make(map[string]int),
+	}
+	metaBytes, err := common.NewNodeMetastate(height).Bytes()
+	assert.NoError(t, err)
+	for i := 0; i < peers; i++ {
+		endpoint := "peer" + string(rune('0'+i)) + ":7051"
+		g.peers[endpoint] = &catchUpPeerMock{
+			member: discovery.NetworkMember{
+				PKIid:    common.PKIidType(endpoint),
+				Endpoint: endpoint,
+				Metadata: metaBytes,
+			},
+			silent: i < silentPeers,
+		}
+	}
+	return g
+}
+
+func (g *catchUpGossip) Send(msg *proto.GossipMessage, peers ...*comm.RemotePeer) {
+	request := msg.GetStateRequest()
+	for _, p := range peers {
+		g.Lock()
+		g.requests[p.Endpoint]++
+		g.Unlock()
+		if g.peers[p.Endpoint].silent {
+			continue
+		}
+		response := &proto.RemoteStateResponse{}
+		for seq := request.StartSeqNum; seq <= request.EndSeqNum; seq++ {
+			b, _ := pb.Marshal(pcomm.NewBlock(seq, []byte{}))
+			response.Payloads = append(response.Payloads, &proto.Payload{SeqNum: seq, Data: b})
+		}
+		signedMsg, _ := (&proto.GossipMessage{
+			Nonce:   msg.Nonce,
+			Channel: msg.Channel,
+			Tag:     proto.GossipMessage_CHAN_OR_ORG,
+			Content: &proto.GossipMessage_StateResponse{StateResponse: response},
+		}).NoopSign()
+		go func() {
+			g.commChan <- &catchUpResponse{msg: signedMsg}
+		}()
+	}
+}
+
+func (g *catchUpGossip) Accept(acceptor common.MessageAcceptor, passThrough bool) (<-chan *proto.GossipMessage, <-chan proto.ReceivedMessage) {
+	if passThrough {
+		return nil, g.commChan
+	}
+	return make(chan *proto.GossipMessage), nil
+}
+
+func (g *catchUpGossip) UpdateChannelMetadata(metadata []byte, chainID common.ChainID) {
+}
+
+func (g *catchUpGossip) PeersOfChannel(common.ChainID) []discovery.NetworkMember {
+	var members []discovery.NetworkMember
+	for _, p := range g.peers {
+		members = append(members, p.member)
+	}
+	return members
+}
+
+func (g *catchUpGossip) requestsTo(endpoint string) int {
+	g.Lock()
+	defer g.Unlock()
+	return g.requests[endpoint]
+}
+
+type catchUpResponse struct {
+	proto.ReceivedMessage
+	msg *proto.SignedGossipMessage
+}
+
+func (r *catchUpResponse) GetGossipMessage() *proto.SignedGossipMessage {
+	return r.msg
+}
+
+func TestCatchUp(t *testing.T) {
+	l := &catchUpLedger{height: 1}
+	g := newCatchUpGossip(t, 300, 1, 4)
+	mediator := &ServicesMediator{GossipAdapter: g, MCSAdapter: &cryptoServiceMock{acceptor: noopPeerIdentityAcceptor}}
+	s := NewGossipStateProvider(util.GetTestChainID(), mediator, l).(*GossipStateProviderImpl)
+	defer s.Stop()
+	s.catchUpConf.maxPeers = 2
+	s.catchUpConf.responseTimeout = 100 * time.Millisecond
+	s.catchUpConf.window = 50
+
+	// All peers have the blocks, but only maxPeers of them are selected
+	assert.Len(t, s.selectPeersToCatchUpFrom(1), 2)
+	s.catchUpConf.maxPeers = 4
+	gauges := &gaugeScope{values: make(map[string]float64)}
+	s.metricsScope = gauges
+
+	assert.True(t, s.catchUp(1, 300))
+	waitUntilTrueOrTimeout(t, func() bool {
+		height, _ := l.LedgerHeight()
+		return height == 301
+	}, 30*time.Second)
+
+	l.Lock()
+	for i, seq := range l.committed {
+		assert.Equal(t, uint64(i+1), seq)
+	}
+	l.Unlock()
+
+	status := s.CatchUpStatus()
+	assert.False(t, status.Active)
+	assert.Equal(t, uint64(1), status.StartSeq)
+	assert.Equal(t, uint64(300), status.TargetSeq)
+	assert.Equal(t, uint64(300), status.BlocksFetched)
+	assert.Len(t, status.Peers, 4)
+	silentFailures := 0
+	for _, ps := range status.Peers {
+		if g.peers[ps.Endpoint].silent {
+			assert.Equal(t, 0, ps.Successes)
+			assert.True(t, ps.BatchSize < s.catchUpConf.maxBatchSize/2)
+			silentFailures = ps.Failures
+			continue
+		}
+		assert.NotZero(t, ps.Successes)
+		assert.NotZero(t, ps.BlocksFetched)
+	}
+	assert.NotZero(t, silentFailures)
+
+	// The progress is reported through metrics
+	assert.Equal(t, map[string]float64{
+		"active":         0,
+		"blocks_fetched": 300,
+		"blocks_total":   300,
+		"peers":          4,
+	}, gauges.gauges())
+
+	// The silent peer has the worst score, hence it isn't selected
+	// once there are enough peers with better scores
+	s.catchUpConf.maxPeers = 3
+	for _, p := range s.selectPeersToCatchUpFrom(300) {
+		assert.False(t, g.peers[p.Endpoint].silent)
+	}
+	silentRequests := 0
+	for endpoint, p := range g.peers {
+		if p.silent {
+			silentRequests = g.requestsTo(endpoint)
+		}
+	}
+	assert.Equal(t, silentFailures, silentRequests)
+}
+
+func TestCatchUpNoPeers(t *testing.T) {
+	l := &catchUpLedger{height: 1}
+	g := newCatchUpGossip(t, 5, 0, 2)
+	mediator := &ServicesMediator{GossipAdapter: g, MCSAdapter: &cryptoServiceMock{acceptor: noopPeerIdentityAcceptor}}
+	s := NewGossipStateProvider(util.GetTestChainID(), mediator, l).(*GossipStateProviderImpl)
+	defer s.Stop()
+
+	// None of the peers has the blocks, hence they are requested sequentially
+	assert.False(t, s.catchUp(10, 20))
+	assert.Equal(t, CatchUpStatus{Peers: []PeerStats{}}, s.CatchUpStatus())
+	assert.Empty(t, g.requests)
+}
+
+func TestRefreshPeerScores(t *testing.T) {
+	l := &catchUpLedger{height: 1}
+	g := newCatchUpGossip(t, 5, 0, 1)
+	mediator := &ServicesMediator{GossipAdapter: g, MCSAdapter: &cryptoServiceMock{acceptor: noopPeerIdentityAcceptor}}
+	s := NewGossipStateProvider(util.GetTestChainID(), mediator, l).(*GossipStateProviderImpl)
+	defer s.Stop()
+
+	member := s.peerScore(&comm.RemotePeer{Endpoint: "peer0:7051", PKIID: common.PKIidType("peer0:7051")})
+	departed := s.peerScore(&comm.RemotePeer{Endpoint: "peer9:7051", PKIID: common.PKIidType("peer9:7051")})
+	for i := 0; i <= defAntiEntropyMaxRetries; i++ {
+		member.failed()
+		departed.failed()
+	}
+
+	// The peer that gave up on the member is asked again in the next catch-up,
+	// and the score of the peer that left the channel is dropped
+	s.refreshPeerScores()
+	assert.Equal(t, (defAntiEntropyMaxRetries+1)/2, member.consecutiveFailures)
+	assert.Equal(t, defAntiEntropyMaxRetries+1, member.Failures)
+	assert.Len(t, s.CatchUpStatus().Peers, 1)
+	assert.Equal(t, "peer0:7051", s.CatchUpStatus().Peers[0].Endpoint)
+}
+
+// gaugeScope records the values of the gauges of a metrics scope
+type gaugeScope struct {
+	sync.Mutex
+	metrics.Scope
+	values map[string]float64
+}
+
+func (gs *gaugeScope) Gauge(name string) metrics.Gauge {
+	return &recordedGauge{scope: gs, name: name}
+}
+
+func (gs *gaugeScope) gauges() map[string]float64 {
+	gs.Lock()
+	defer gs.Unlock()
+	values := make(map[string]float64)
+	for name, value := range gs.values {
+		values[name] = value
+	}
+	return values
+}
+
+type recordedGauge struct {
+	scope *gaugeScope
+	name  string
+}
+
+func (rg *recordedGauge) Update(value float64) {
+	rg.scope.Lock()
+	defer rg.scope.Unlock()
+	rg.scope.values[rg.name] = value
+}
+
+// notifyingLedger is a ledger that notifies of the blocks once they are committed
+type notifyingLedger struct {
+	catchUpLedger
+	commitHandler func(block *pcomm.Block)
+}
+
+func (l *notifyingLedger) SetCommitHandler(handler func(block *pcomm.Block)) {
+	l.commitHandler = handler
+}
+
+// metadataGossip records the metadata that the peer publishes
+type metadataGossip struct {
+	*catchUpGossip
+	metadata [][]byte
+}
+
+func (g *metadataGossip) UpdateChannelMetadata(metadata []byte, chainID common.ChainID) {
+	g.Lock()
+	defer g.Unlock()
+	g.metadata = append(g.metadata, metadata)
+}
+
+func (g *metadataGossip) publishedMetadata() [][]byte {
+	g.Lock()
+	defer g.Unlock()
+	return g.metadata
+}
+
+func TestLedgerHeightAdvertisedOnCommit(t *testing.T) {
+	l := &notifyingLedger{catchUpLedger: catchUpLedger{height: 1}}
+	g := &metadataGossip{catchUpGossip: newCatchUpGossip(t, 1, 0, 0)}
+	mediator := &ServicesMediator{GossipAdapter: g, MCSAdapter: &cryptoServiceMock{acceptor: noopPeerIdentityAcceptor}}
+	s := NewGossipStateProvider(util.GetTestChainID(), mediator, l).(*GossipStateProviderImpl)
+	defer s.Stop()
+	assert.Len(t, g.publishedMetadata(), 1)
+
+	// The height isn't advertised when the block is stored, but once it is committed
+	block := pcomm.NewBlock(1, []byte{})
+	assert.NoError(t, s.commitBlock(block, nil))
+	assert.Len(t, g.publishedMetadata(), 1)
+	l.commitHandler(block)
+	expected, err := common.NewNodeMetastate(1).Bytes()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, g.publishedMetadata()[1])
+}
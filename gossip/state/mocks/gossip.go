@@ -54,6 +54,22 @@ func (g *GossipMock) UpdateMetadata(metadata []byte) {
 func (g *GossipMock) UpdateChannelMetadata(metadata []byte, chainID common.ChainID) {
 }
 
+func (g *GossipMock) UpdateChaincodes(chaincodes []*proto.Chaincode, chainID common.ChainID) {
+}
+
+func (g *GossipMock) UpdateInstalledChaincodes(chaincodes []*proto.Chaincode) {
+}
+
+func (g *GossipMock) SelfChannelInfo(chainID common.ChainID) *proto.SignedGossipMessage {
+	args := g.Called(chainID)
+	return args.Get(0).(*proto.SignedGossipMessage)
+}
+
+func (g *GossipMock) PeersOfChannelMatching(chainID common.ChainID, criteria gossip.PeerCriteria) []discovery.NetworkMember {
+	args := g.Called(chainID, criteria)
+	return args.Get(0).([]discovery.NetworkMember)
+}
+
 func (g *GossipMock) Gossip(msg *proto.GossipMessage) {
 	g.Called(msg)
 }
@@ -14,6 +14,7 @@ import (
 
 	pb "github.com/golang/protobuf/proto"
 	vsccErrors "github.com/hyperledger/fabric/common/errors"
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/gossip/api"
 	"github.com/hyperledger/fabric/gossip/comm"
 	common2 "github.com/hyperledger/fabric/gossip/common"
@@ -32,6 +33,10 @@ import (
 type GossipStateProvider interface {
 	AddPayload(payload *proto.Payload) error
 
+	// CatchUpStatus returns the progress of the parallel catch-up
+	// of peers that are far behind the other peers of the channel
+	CatchUpStatus() CatchUpStatus
+
 	// Stop terminates state transfer object
 	Stop()
 }
@@ -109,6 +114,13 @@ type ledgerResources interface {
 	Close()
 }
 
+// commitNotifier is implemented by the ledger resources that notify a handler of
+// each block once it is committed, which may happen after StoreBlock returns
+type commitNotifier interface {
+	// SetCommitHandler sets the handler that is notified of each block once it is committed
+	SetCommitHandler(handler func(block *common.Block))
+}
+
 // ServicesMediator aggregated adapter to compound all mediator
 // required by state transfer into single struct
 type ServicesMediator struct {
@@ -146,6 +158,28 @@ type GossipStateProviderImpl struct {
 	once sync.Once
 
 	stateTransferActive int32
+
+	// Maximal number of blocks requested and served in a single state request
+	batchSize uint64
+
+	catchUpConf catchUpConfig
+
+	catchUpLock sync.RWMutex
+
+	catchUpStatus CatchUpStatus
+
+	catchUpFetched uint64
+
+	// Scores of the peers blocks were fetched from, by PKI-ID
+	peerScores map[string]*peerScore
+
+	// metricsScope is the scope the progress of the catch-up mode is reported
+	// through, and is nil if metrics aren't initialized
+	metricsScope metrics.Scope
+
+	// commitNotified indicates that the ledger notifies of the blocks once they are
+	// committed, and the ledger height is advertised upon these notifications
+	commitNotified bool
 }
 
 var logger = util.GetLogger(util.LoggingStateModule, "")
@@ -223,6 +257,16 @@ func NewGossipStateProvider(chainID string, services *ServicesMediator, ledger l
 		stateTransferActive: 0,
 
 		once: sync.Once{},
+
+		batchSize: stateBatchSize(),
+
+		catchUpConf: readCatchUpConfig(),
+
+		peerScores: make(map[string]*peerScore),
+	}
+
+	if metrics.RootScope != nil {
+		s.metricsScope = metrics.RootScope.SubScope(catchUpMetricsSubScope).Tagged(map[string]string{"channel": chainID})
 	}
 
 	nodeMetastate := common2.NewNodeMetastate(height - 1)
@@ -238,6 +282,13 @@ func NewGossipStateProvider(chainID string, services *ServicesMediator, ledger l
 		logger.Errorf("Unable to serialize node meta nodeMetastate, error = %+v", errors.WithStack(err))
 	}
 
+	// The ledger height is advertised only once blocks are committed,
+	// which may happen after they are stored when commits are pipelined
+	if notifier, isNotifier := ledger.(commitNotifier); isNotifier {
+		notifier.SetCommitHandler(s.updateLedgerHeight)
+		s.commitNotified = true
+	}
+
 	s.done.Add(4)
 
 	// Listen for incoming communication
@@ -378,9 +429,9 @@ func (s *GossipStateProviderImpl) handleStateRequest(msg proto.ReceivedMessage)
 	request := msg.GetGossipMessage().GetStateRequest()
 
 	batchSize := request.EndSeqNum - request.StartSeqNum
-	if batchSize > defAntiEntropyBatchSize {
+	if batchSize > s.batchSize {
 		logger.Errorf("Requesting blocks batchSize size (%d) greater than configured allowed"+
-			" (%d) batching for anti-entropy. Ignoring request...", batchSize, defAntiEntropyBatchSize)
+			" (%d) batching for anti-entropy. Ignoring request...", batchSize, s.batchSize)
 		return
 	}
 
@@ -593,6 +644,10 @@ func (s *GossipStateProviderImpl) antiEntropy() {
 				continue
 			}
 
+			// Blocks are requested sequentially if there are no peers to catch up from
+			if s.catchUpConf.enabled && max-current+1 >= s.catchUpConf.threshold && s.catchUp(current, max) {
+				continue
+			}
 			s.requestBlocksInRange(uint64(current), uint64(max))
 		}
 	}
@@ -603,13 +658,7 @@ func (s *GossipStateProviderImpl) antiEntropy() {
 func (s *GossipStateProviderImpl) maxAvailableLedgerHeight() uint64 {
 	max := uint64(0)
 	for _, p := range s.mediator.PeersOfChannel(common2.ChainID(s.chainID)) {
-		var peerHeight uint64
-		if p.Properties != nil {
-			peerHeight = p.Properties.LedgerHeight
-		} else if nodeMetastate, err := common2.FromBytes(p.Metadata); err == nil {
-			peerHeight = nodeMetastate.LedgerHeight
-		}
-
+		peerHeight, _ := ledgerHeightOf(p)
 		if max < peerHeight {
 			max = peerHeight
 		}
@@ -624,7 +673,7 @@ func (s *GossipStateProviderImpl) requestBlocksInRange(start uint64, end uint64)
 	defer atomic.StoreInt32(&s.stateTransferActive, 0)
 
 	for prev := start; prev <= end; {
-		next := min(end, prev+defAntiEntropyBatchSize)
+		next := min(end, prev+s.batchSize)
 
 		gossipMsg := s.stateRequestMessage(prev, next)
 
@@ -777,6 +826,18 @@ func (s *GossipStateProviderImpl) commitBlock(block *common.Block, pvtData util.
 		return err
 	}
 
+	if !s.commitNotified {
+		s.updateLedgerHeight(block)
+	}
+
+	logger.Debugf("Channel [%s]: Created block [%d] with %d transaction(s)",
+		s.chainID, block.Header.Number, len(block.Data.Data))
+
+	return nil
+}
+
+// updateLedgerHeight advertises the ledger height, up to the given committed block, within node metadata
+func (s *GossipStateProviderImpl) updateLedgerHeight(block *common.Block) {
 	// Update ledger level within node metadata
 	nodeMetastate := common2.NewNodeMetastate(block.Header.Number)
 	// Decode nodeMetastate to byte array
@@ -787,11 +848,6 @@ func (s *GossipStateProviderImpl) commitBlock(block *common.Block, pvtData util.
 
 		logger.Errorf("Unable to serialize node meta nodeMetastate, error = %+v", errors.WithStack(err))
 	}
-
-	logger.Debugf("Channel [%s]: Created block [%d] with %d transaction(s)",
-		s.chainID, block.Header.Number, len(block.Data.Data))
-
-	return nil
 }
 
 func min(a uint64, b uint64) uint64 {
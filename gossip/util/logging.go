@@ -25,6 +25,7 @@ const (
 	LoggingServiceModule   = "gossip/service"
 	LoggingStateModule     = "gossip/state"
 	LoggingPrivModule      = "gossip/privdata"
+	LoggingIdentityModule  = "gossip/identity"
 )
 
 var loggersByModules = make(map[string]*logging.Logger)
@@ -15,7 +15,9 @@ It has these top-level messages:
 	Credential
 	CredRequest
 	Signature
+	NonRevocationProof
 	NymSignature
+	CredentialRevocationInformation
 */
 package idemix
 
@@ -335,19 +337,23 @@ func (m *CredRequest) GetProofS2() []byte {
 // ProofSRNym - a zero-knowledge proof of knowledge of the
 // user secret inside Nym
 type Signature struct {
-	APrime       *ECP     `protobuf:"bytes,1,opt,name=APrime" json:"APrime,omitempty"`
-	ABar         *ECP     `protobuf:"bytes,2,opt,name=ABar" json:"ABar,omitempty"`
-	BPrime       *ECP     `protobuf:"bytes,3,opt,name=BPrime" json:"BPrime,omitempty"`
-	ProofC       []byte   `protobuf:"bytes,4,opt,name=ProofC,proto3" json:"ProofC,omitempty"`
-	ProofSSk     []byte   `protobuf:"bytes,5,opt,name=ProofSSk,proto3" json:"ProofSSk,omitempty"`
-	ProofSE      []byte   `protobuf:"bytes,6,opt,name=ProofSE,proto3" json:"ProofSE,omitempty"`
-	ProofSR2     []byte   `protobuf:"bytes,7,opt,name=ProofSR2,proto3" json:"ProofSR2,omitempty"`
-	ProofSR3     []byte   `protobuf:"bytes,8,opt,name=ProofSR3,proto3" json:"ProofSR3,omitempty"`
-	ProofSSPrime []byte   `protobuf:"bytes,9,opt,name=ProofSSPrime,proto3" json:"ProofSSPrime,omitempty"`
-	ProofSAttrs  [][]byte `protobuf:"bytes,10,rep,name=ProofSAttrs,proto3" json:"ProofSAttrs,omitempty"`
-	Nonce        []byte   `protobuf:"bytes,11,opt,name=Nonce,proto3" json:"Nonce,omitempty"`
-	Nym          *ECP     `protobuf:"bytes,12,opt,name=Nym" json:"Nym,omitempty"`
-	ProofSRNym   []byte   `protobuf:"bytes,13,opt,name=ProofSRNym,proto3" json:"ProofSRNym,omitempty"`
+	APrime             *ECP                `protobuf:"bytes,1,opt,name=APrime" json:"APrime,omitempty"`
+	ABar               *ECP                `protobuf:"bytes,2,opt,name=ABar" json:"ABar,omitempty"`
+	BPrime             *ECP                `protobuf:"bytes,3,opt,name=BPrime" json:"BPrime,omitempty"`
+	ProofC             []byte              `protobuf:"bytes,4,opt,name=ProofC,proto3" json:"ProofC,omitempty"`
+	ProofSSk           []byte              `protobuf:"bytes,5,opt,name=ProofSSk,proto3" json:"ProofSSk,omitempty"`
+	ProofSE            []byte              `protobuf:"bytes,6,opt,name=ProofSE,proto3" json:"ProofSE,omitempty"`
+	ProofSR2           []byte              `protobuf:"bytes,7,opt,name=ProofSR2,proto3" json:"ProofSR2,omitempty"`
+	ProofSR3           []byte              `protobuf:"bytes,8,opt,name=ProofSR3,proto3" json:"ProofSR3,omitempty"`
+	ProofSSPrime       []byte              `protobuf:"bytes,9,opt,name=ProofSSPrime,proto3" json:"ProofSSPrime,omitempty"`
+	ProofSAttrs        [][]byte            `protobuf:"bytes,10,rep,name=ProofSAttrs,proto3" json:"ProofSAttrs,omitempty"`
+	Nonce              []byte              `protobuf:"bytes,11,opt,name=Nonce,proto3" json:"Nonce,omitempty"`
+	Nym                *ECP                `protobuf:"bytes,12,opt,name=Nym" json:"Nym,omitempty"`
+	ProofSRNym         []byte              `protobuf:"bytes,13,opt,name=ProofSRNym,proto3" json:"ProofSRNym,omitempty"`
+	RevocationEpochPk  *ECP2               `protobuf:"bytes,14,opt,name=revocation_epoch_pk,json=revocationEpochPk" json:"revocation_epoch_pk,omitempty"`
+	RevocationPkSig    []byte              `protobuf:"bytes,15,opt,name=revocation_pk_sig,json=revocationPkSig,proto3" json:"revocation_pk_sig,omitempty"`
+	Epoch              int64               `protobuf:"varint,16,opt,name=epoch" json:"epoch,omitempty"`
+	NonRevocationProof *NonRevocationProof `protobuf:"bytes,17,opt,name=non_revocation_proof,json=nonRevocationProof" json:"non_revocation_proof,omitempty"`
 }
 
 func (m *Signature) Reset()                    { *m = Signature{} }
@@ -446,6 +452,60 @@ func (m *Signature) GetProofSRNym() []byte {
 	return nil
 }
 
+func (m *Signature) GetRevocationEpochPk() *ECP2 {
+	if m != nil {
+		return m.RevocationEpochPk
+	}
+	return nil
+}
+
+func (m *Signature) GetRevocationPkSig() []byte {
+	if m != nil {
+		return m.RevocationPkSig
+	}
+	return nil
+}
+
+func (m *Signature) GetEpoch() int64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
+func (m *Signature) GetNonRevocationProof() *NonRevocationProof {
+	if m != nil {
+		return m.NonRevocationProof
+	}
+	return nil
+}
+
+// NonRevocationProof contains proof that the credential is not revoked
+// in the epoch of the signature, using the revocation algorithm revocation_alg
+type NonRevocationProof struct {
+	RevocationAlg      int32  `protobuf:"varint,1,opt,name=revocation_alg,json=revocationAlg" json:"revocation_alg,omitempty"`
+	NonRevocationProof []byte `protobuf:"bytes,2,opt,name=non_revocation_proof,json=nonRevocationProof,proto3" json:"non_revocation_proof,omitempty"`
+}
+
+func (m *NonRevocationProof) Reset()                    { *m = NonRevocationProof{} }
+func (m *NonRevocationProof) String() string            { return proto.CompactTextString(m) }
+func (*NonRevocationProof) ProtoMessage()               {}
+func (*NonRevocationProof) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+
+func (m *NonRevocationProof) GetRevocationAlg() int32 {
+	if m != nil {
+		return m.RevocationAlg
+	}
+	return 0
+}
+
+func (m *NonRevocationProof) GetNonRevocationProof() []byte {
+	if m != nil {
+		return m.NonRevocationProof
+	}
+	return nil
+}
+
 // NymSignature specifies a signature object that signs a message
 // with respect to a pseudonym. It differs from the standard idemix.signature in the fact that
 // the  standard signature object also proves that the pseudonym is based on a secret certified by
@@ -465,7 +525,7 @@ type NymSignature struct {
 func (m *NymSignature) Reset()                    { *m = NymSignature{} }
 func (m *NymSignature) String() string            { return proto.CompactTextString(m) }
 func (*NymSignature) ProtoMessage()               {}
-func (*NymSignature) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*NymSignature) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *NymSignature) GetProofC() []byte {
 	if m != nil {
@@ -495,6 +555,64 @@ func (m *NymSignature) GetNonce() []byte {
 	return nil
 }
 
+// CredentialRevocationInformation is published by the revocation authority
+// for every epoch. It consists of
+// epoch - the epoch it is valid for
+// epoch_pk - the public key of the revocation authority for the epoch
+// epoch_pk_sig - a signature of the long term revocation key on the epoch, epoch_pk and revocation_alg
+// revocation_alg - the revocation algorithm used to prove non-revocation
+// revocation_data - algorithm specific data letting the holders of unrevoked
+// credentials prove that they are not revoked
+type CredentialRevocationInformation struct {
+	Epoch          int64  `protobuf:"varint,1,opt,name=epoch" json:"epoch,omitempty"`
+	EpochPk        *ECP2  `protobuf:"bytes,2,opt,name=epoch_pk,json=epochPk" json:"epoch_pk,omitempty"`
+	EpochPkSig     []byte `protobuf:"bytes,3,opt,name=epoch_pk_sig,json=epochPkSig,proto3" json:"epoch_pk_sig,omitempty"`
+	RevocationAlg  int32  `protobuf:"varint,4,opt,name=revocation_alg,json=revocationAlg" json:"revocation_alg,omitempty"`
+	RevocationData []byte `protobuf:"bytes,5,opt,name=revocation_data,json=revocationData,proto3" json:"revocation_data,omitempty"`
+}
+
+func (m *CredentialRevocationInformation) Reset()         { *m = CredentialRevocationInformation{} }
+func (m *CredentialRevocationInformation) String() string { return proto.CompactTextString(m) }
+func (*CredentialRevocationInformation) ProtoMessage()    {}
+func (*CredentialRevocationInformation) Descriptor() ([]byte, []int) {
+	return fileDescriptor0, []int{9}
+}
+
+func (m *CredentialRevocationInformation) GetEpoch() int64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
+func (m *CredentialRevocationInformation) GetEpochPk() *ECP2 {
+	if m != nil {
+		return m.EpochPk
+	}
+	return nil
+}
+
+func (m *CredentialRevocationInformation) GetEpochPkSig() []byte {
+	if m != nil {
+		return m.EpochPkSig
+	}
+	return nil
+}
+
+func (m *CredentialRevocationInformation) GetRevocationAlg() int32 {
+	if m != nil {
+		return m.RevocationAlg
+	}
+	return 0
+}
+
+func (m *CredentialRevocationInformation) GetRevocationData() []byte {
+	if m != nil {
+		return m.RevocationData
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ECP)(nil), "ECP")
 	proto.RegisterType((*ECP2)(nil), "ECP2")
@@ -503,48 +621,62 @@ func init() {
 	proto.RegisterType((*Credential)(nil), "Credential")
 	proto.RegisterType((*CredRequest)(nil), "CredRequest")
 	proto.RegisterType((*Signature)(nil), "Signature")
+	proto.RegisterType((*NonRevocationProof)(nil), "NonRevocationProof")
 	proto.RegisterType((*NymSignature)(nil), "NymSignature")
+	proto.RegisterType((*CredentialRevocationInformation)(nil), "CredentialRevocationInformation")
 }
 
 func init() { proto.RegisterFile("idemix/idemix.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 591 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0x4d, 0x6b, 0xdb, 0x4c,
-	0x18, 0x64, 0xf5, 0xe1, 0xc4, 0x8f, 0xf5, 0xe6, 0x0d, 0x9b, 0x12, 0x96, 0x50, 0x8a, 0x2b, 0x4a,
-	0xc8, 0xc9, 0x21, 0xca, 0x2f, 0x90, 0x8c, 0x5a, 0x87, 0x82, 0x11, 0xd2, 0x21, 0x76, 0x6f, 0xb2,
-	0xbd, 0xb6, 0x85, 0x2d, 0x2b, 0x5d, 0x49, 0x10, 0xff, 0x8e, 0x5e, 0xfb, 0x4f, 0x7b, 0x29, 0xfb,
-	0xa1, 0x4f, 0xe8, 0xc9, 0x9a, 0x19, 0x3d, 0xfb, 0x8c, 0x67, 0x16, 0xc1, 0x4d, 0xb2, 0xa1, 0x69,
-	0xf2, 0xfe, 0x28, 0x7f, 0x26, 0x6f, 0x2c, 0x2b, 0x32, 0xfb, 0x33, 0xe8, 0xfe, 0x34, 0xc0, 0x16,
-	0xa0, 0x05, 0x41, 0x63, 0xf4, 0x60, 0x85, 0x68, 0xc1, 0xd1, 0x92, 0x68, 0x12, 0x2d, 0xed, 0xaf,
-	0x60, 0xf8, 0xd3, 0xc0, 0xc1, 0x57, 0xa0, 0x2d, 0x5c, 0xf5, 0x92, 0xb6, 0x70, 0x05, 0xf6, 0xd4,
-	0x6b, 0xda, 0xc2, 0xe3, 0x78, 0xe9, 0x12, 0x5d, 0xe2, 0xa5, 0xd0, 0x97, 0x1e, 0x31, 0x14, 0xf6,
-	0xec, 0xdf, 0x1a, 0xfc, 0xff, 0x92, 0xe7, 0x25, 0x65, 0x41, 0xb9, 0x3a, 0x26, 0xeb, 0xef, 0xf4,
-	0x8c, 0xef, 0xe1, 0xca, 0x2d, 0x0a, 0x96, 0xac, 0xca, 0x82, 0xce, 0xe3, 0x94, 0xe6, 0x04, 0x8d,
-	0xf5, 0x87, 0x61, 0xd8, 0x63, 0xf1, 0x2d, 0xe8, 0xb3, 0xe8, 0x20, 0x96, 0x8d, 0x1c, 0x63, 0xe2,
-	0x4f, 0x83, 0x90, 0x13, 0xf8, 0x0e, 0xcc, 0x59, 0x18, 0x9f, 0x36, 0x62, 0x6d, 0xa5, 0x48, 0x0a,
-	0x7f, 0x84, 0xc1, 0x8c, 0x1f, 0x93, 0x13, 0x63, 0xac, 0xd7, 0xa2, 0xe2, 0xf0, 0x0d, 0xa0, 0x57,
-	0x62, 0x8a, 0x29, 0x93, 0x0b, 0x4e, 0x88, 0x5e, 0xf9, 0x71, 0x5e, 0xcc, 0xbe, 0x3d, 0x91, 0x41,
-	0xfb, 0x38, 0x41, 0x55, 0x9a, 0x43, 0x2e, 0xfa, 0x9a, 0x83, 0x6f, 0x61, 0x10, 0xb0, 0x2c, 0xdb,
-	0x4e, 0xc9, 0xa5, 0xf8, 0xbb, 0x0a, 0xd5, 0x7c, 0x44, 0x86, 0x2d, 0x3e, 0xc2, 0x18, 0x8c, 0x59,
-	0x9c, 0xef, 0x09, 0x08, 0x56, 0x3c, 0xdb, 0x2e, 0x0c, 0x65, 0x3a, 0x3c, 0x97, 0x6b, 0xd0, 0x5f,
-	0xa2, 0x83, 0x0a, 0x9b, 0x3f, 0x62, 0x1b, 0xf4, 0x97, 0xa0, 0x4a, 0xe0, 0x7a, 0xd2, 0x0b, 0x32,
-	0xe4, 0xa2, 0xbd, 0x05, 0x98, 0x32, 0xba, 0xa1, 0xa7, 0x22, 0x89, 0x8f, 0x18, 0x03, 0x92, 0x75,
-	0x55, 0x66, 0x91, 0xcb, 0x39, 0xaf, 0x93, 0x22, 0xf2, 0x78, 0xdb, 0xbe, 0xaa, 0x0d, 0xf9, 0x1c,
-	0x45, 0xaa, 0x34, 0x14, 0xe1, 0x0f, 0x60, 0xca, 0x08, 0xcd, 0xb1, 0xfe, 0x60, 0x85, 0x12, 0xd8,
-	0xbf, 0x10, 0x8c, 0xf8, 0xa2, 0x90, 0xfe, 0x2c, 0x69, 0x5e, 0xf0, 0x76, 0xe6, 0xe7, 0xb4, 0xb3,
-	0x8b, 0x13, 0x78, 0x0c, 0x23, 0xe9, 0x73, 0x9e, 0x9d, 0xd6, 0x54, 0x5d, 0x95, 0x36, 0xd5, 0x0a,
-	0x4e, 0xef, 0x04, 0x47, 0xe0, 0x42, 0x46, 0xf5, 0xa4, 0xbc, 0x54, 0xb0, 0x51, 0x1c, 0xd1, 0x5e,
-	0xad, 0x38, 0xf6, 0x1f, 0x0d, 0x86, 0x51, 0xb2, 0x3b, 0xc5, 0x45, 0xc9, 0x28, 0x6f, 0xdf, 0x0d,
-	0x58, 0x92, 0xd2, 0x8e, 0x2d, 0xc5, 0x61, 0x02, 0x86, 0xeb, 0xc5, 0xac, 0x13, 0x85, 0x60, 0xf8,
-	0x9c, 0x27, 0xe7, 0xda, 0x57, 0x4a, 0x71, 0x2d, 0xbf, 0x46, 0xc7, 0xef, 0x1d, 0x5c, 0x4a, 0x1b,
-	0xd1, 0x41, 0xd9, 0xaa, 0x71, 0xe3, 0xd8, 0x17, 0xd7, 0xaa, 0x76, 0xec, 0x37, 0x53, 0xa1, 0xbc,
-	0x55, 0xf5, 0x54, 0xe8, 0xb4, 0xb4, 0x67, 0x75, 0xa9, 0x6a, 0x8c, 0x6d, 0xb0, 0xd4, 0xe9, 0xd2,
-	0xa9, 0xbc, 0x5c, 0x1d, 0x8e, 0x67, 0x2f, 0xb1, 0xec, 0x0f, 0x44, 0x7f, 0x6d, 0x8a, 0x77, 0x2b,
-	0x7b, 0x19, 0x89, 0x71, 0xb3, 0x6a, 0x44, 0x74, 0x69, 0xf5, 0xbb, 0xfc, 0x04, 0xa0, 0xf6, 0x73,
-	0xf9, 0x3f, 0x31, 0xd2, 0x62, 0xec, 0x77, 0xb0, 0xe6, 0xe7, 0xb4, 0xc9, 0xbf, 0x49, 0x0a, 0xfd,
-	0x33, 0x29, 0xad, 0x97, 0x54, 0x77, 0x87, 0xde, 0xdf, 0xd1, 0x38, 0x36, 0x5a, 0x8e, 0xbd, 0xfb,
-	0x1f, 0x5f, 0x76, 0x49, 0xb1, 0x2f, 0x57, 0x93, 0x75, 0x96, 0x3e, 0xee, 0xcf, 0x6f, 0x94, 0x1d,
-	0xe9, 0x66, 0x47, 0xd9, 0xe3, 0x36, 0x5e, 0xb1, 0x64, 0xad, 0x3e, 0x78, 0xab, 0x81, 0xf8, 0xe2,
-	0x3d, 0xff, 0x0d, 0x00, 0x00, 0xff, 0xff, 0x06, 0x88, 0xd9, 0x9e, 0x08, 0x05, 0x00, 0x00,
+	// 777 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x55, 0xdb, 0x6e, 0xea, 0x46,
+	0x14, 0xd5, 0x60, 0x9b, 0x84, 0x0d, 0x87, 0xe4, 0x0c, 0x47, 0x47, 0xa3, 0xa3, 0xaa, 0x75, 0xad,
+	0x36, 0x45, 0x7d, 0x20, 0x8d, 0xa3, 0x7e, 0x80, 0x4d, 0xdd, 0x82, 0x2a, 0x21, 0xcb, 0x7e, 0x08,
+	0xf4, 0x05, 0x19, 0x18, 0xc0, 0x02, 0xdb, 0xd4, 0x98, 0x2a, 0x7c, 0x47, 0x5f, 0xfb, 0x27, 0xfd,
+	0x84, 0xfe, 0x54, 0x35, 0x17, 0xf0, 0x18, 0x92, 0xa7, 0xcc, 0x5e, 0x6b, 0xf6, 0x9e, 0x95, 0xb5,
+	0x36, 0x00, 0x9d, 0x78, 0x41, 0x93, 0xf8, 0xf5, 0x51, 0xfc, 0xe9, 0xed, 0xf2, 0xac, 0xc8, 0xac,
+	0x6f, 0x41, 0xf3, 0xfa, 0x3e, 0x6e, 0x01, 0x1a, 0x13, 0x64, 0xa2, 0x6e, 0x2b, 0x40, 0x63, 0x56,
+	0x4d, 0x48, 0x4d, 0x54, 0x13, 0xeb, 0x57, 0xd0, 0xbd, 0xbe, 0x6f, 0xe3, 0x36, 0xd4, 0xc6, 0x8e,
+	0xbc, 0x54, 0x1b, 0x3b, 0xbc, 0x76, 0xe5, 0xb5, 0xda, 0xd8, 0x65, 0xf5, 0xc4, 0x21, 0x9a, 0xa8,
+	0x27, 0x9c, 0x9f, 0xb8, 0x44, 0x97, 0xb5, 0x6b, 0xfd, 0x53, 0x83, 0xbb, 0xe1, 0x7e, 0x7f, 0xa0,
+	0xb9, 0x7f, 0x98, 0x6d, 0xe3, 0xf9, 0xef, 0xf4, 0x88, 0x1f, 0xa0, 0xed, 0x14, 0x45, 0x1e, 0xcf,
+	0x0e, 0x05, 0x1d, 0x45, 0x09, 0xdd, 0x13, 0x64, 0x6a, 0xdd, 0x46, 0x70, 0x81, 0xe2, 0xcf, 0xa0,
+	0x0d, 0xc2, 0x0d, 0x7f, 0xac, 0x69, 0xeb, 0x3d, 0xaf, 0xef, 0x07, 0x0c, 0xc0, 0x5f, 0xc0, 0x18,
+	0x04, 0x51, 0xba, 0x20, 0x9a, 0xc2, 0x08, 0x08, 0x7f, 0x05, 0xf5, 0x01, 0x1b, 0xb3, 0x27, 0xba,
+	0xa9, 0x9d, 0x49, 0x89, 0xe1, 0x0e, 0xa0, 0x17, 0x62, 0xf0, 0x2e, 0x83, 0x11, 0x76, 0x80, 0x5e,
+	0xd8, 0x38, 0x37, 0xca, 0x7f, 0x7b, 0x22, 0x75, 0x75, 0x1c, 0x87, 0x4e, 0x9c, 0x4d, 0x6e, 0x2e,
+	0x39, 0x1b, 0x7f, 0x86, 0xba, 0x9f, 0x67, 0xd9, 0xb2, 0x4f, 0x6e, 0xf9, 0xbf, 0x2b, 0xab, 0x33,
+	0x1e, 0x92, 0x86, 0x82, 0x87, 0x18, 0x83, 0x3e, 0x88, 0xf6, 0x6b, 0x02, 0x1c, 0xe5, 0x67, 0xcb,
+	0x81, 0x86, 0x70, 0x87, 0xf9, 0x72, 0x0f, 0xda, 0x30, 0xdc, 0x48, 0xb3, 0xd9, 0x11, 0x5b, 0xa0,
+	0x0d, 0xfd, 0x93, 0x03, 0xf7, 0xbd, 0x0b, 0x23, 0x03, 0x46, 0x5a, 0x4b, 0x80, 0x7e, 0x4e, 0x17,
+	0x34, 0x2d, 0xe2, 0x68, 0x8b, 0x31, 0x20, 0x11, 0xd7, 0x49, 0x2c, 0x72, 0x18, 0xe6, 0x56, 0x5c,
+	0x44, 0x2e, 0x4b, 0xdb, 0x93, 0xb1, 0x21, 0x8f, 0x55, 0xa1, 0x0c, 0x0d, 0x85, 0xf8, 0x13, 0x18,
+	0xc2, 0x42, 0xc3, 0xd4, 0xba, 0xad, 0x40, 0x14, 0xd6, 0xdf, 0x08, 0x9a, 0xec, 0xa1, 0x80, 0xfe,
+	0x79, 0xa0, 0xfb, 0x82, 0xa5, 0x33, 0x3a, 0x26, 0x95, 0xb7, 0x18, 0x80, 0x4d, 0x68, 0x0a, 0x9d,
+	0xa3, 0x2c, 0x9d, 0x53, 0xb9, 0x2a, 0x2a, 0xa4, 0x18, 0xa7, 0x55, 0x8c, 0x23, 0x70, 0xc3, 0x4f,
+	0xe1, 0x93, 0xd4, 0x72, 0x2a, 0x4b, 0xc6, 0x26, 0x86, 0xca, 0xd8, 0xd6, 0xbf, 0x3a, 0x34, 0xc2,
+	0x78, 0x95, 0x46, 0xc5, 0x21, 0xa7, 0x2c, 0x7d, 0xc7, 0xcf, 0xe3, 0x84, 0x56, 0x64, 0x49, 0x0c,
+	0x13, 0xd0, 0x1d, 0x37, 0xca, 0x2b, 0x56, 0x70, 0x84, 0xf5, 0xb9, 0xa2, 0x4f, 0x5d, 0x29, 0x89,
+	0x29, 0x7a, 0xf5, 0x8a, 0xde, 0x2f, 0x70, 0x2b, 0x64, 0x84, 0x1b, 0x29, 0xeb, 0x5c, 0x97, 0x8a,
+	0x3d, 0x52, 0x57, 0x15, 0x7b, 0x65, 0x57, 0x20, 0xb6, 0xea, 0xdc, 0x15, 0xd8, 0x0a, 0xf7, 0x2c,
+	0x97, 0xea, 0x5c, 0x63, 0x0b, 0x5a, 0x72, 0xba, 0x50, 0x2a, 0x96, 0xab, 0x82, 0x31, 0xef, 0x45,
+	0x2d, 0xf2, 0x03, 0x9e, 0x9f, 0x0a, 0xb1, 0x6c, 0x45, 0x2e, 0x4d, 0xde, 0x6e, 0x9c, 0x12, 0xe1,
+	0x59, 0xb6, 0x2e, 0xb3, 0xfc, 0x1a, 0x40, 0xbe, 0xcf, 0xe8, 0x0f, 0xbc, 0x45, 0x41, 0xf0, 0xcf,
+	0xd0, 0xc9, 0xe9, 0x5f, 0xd9, 0x3c, 0x2a, 0xe2, 0x2c, 0x9d, 0xd2, 0x5d, 0x36, 0x5f, 0x4f, 0x77,
+	0x1b, 0xd2, 0x56, 0x3f, 0x61, 0x1f, 0xcb, 0x1b, 0x1e, 0xbb, 0xe0, 0x6f, 0xf0, 0x8f, 0xa0, 0x80,
+	0xd3, 0xdd, 0x66, 0xba, 0x8f, 0x57, 0xe4, 0x8e, 0x4f, 0xbf, 0x2b, 0x09, 0x7f, 0x13, 0xc6, 0x2b,
+	0x26, 0x98, 0xcf, 0x25, 0xf7, 0x26, 0xea, 0x6a, 0x81, 0x28, 0xb0, 0x07, 0x9f, 0xd2, 0x2c, 0x9d,
+	0xaa, 0x53, 0x98, 0x2a, 0xf2, 0x91, 0xbf, 0xdc, 0xe9, 0x8d, 0xb2, 0x34, 0x28, 0x07, 0x31, 0x2a,
+	0xc0, 0xe9, 0x15, 0x66, 0x25, 0x80, 0xaf, 0x6f, 0xe2, 0xef, 0xa1, 0xad, 0x0c, 0x8e, 0xb6, 0x2b,
+	0xbe, 0x4d, 0x46, 0xf0, 0xa1, 0x44, 0x9d, 0xed, 0x0a, 0xff, 0xf4, 0x8e, 0x06, 0xb1, 0xf1, 0x6f,
+	0x3d, 0xf7, 0x0a, 0xad, 0xd1, 0x31, 0x29, 0xd7, 0xb5, 0x5c, 0x2c, 0xf4, 0xee, 0x62, 0xd5, 0x2e,
+	0x16, 0xab, 0x1a, 0x89, 0x76, 0x15, 0xc9, 0x39, 0x60, 0x5d, 0x09, 0xd8, 0xfa, 0x0f, 0xc1, 0x37,
+	0xe5, 0xb7, 0x44, 0xa9, 0x6b, 0x98, 0x2e, 0xb3, 0x3c, 0xe1, 0xc7, 0xd2, 0x69, 0xa4, 0x3a, 0x6d,
+	0xc2, 0xed, 0x39, 0xd7, 0x9a, 0x9a, 0xeb, 0x0d, 0x95, 0x69, 0x9a, 0xd0, 0x3a, 0xdd, 0xe0, 0x41,
+	0x4a, 0x4d, 0x92, 0x66, 0x19, 0x5e, 0x1b, 0xaa, 0xbf, 0x65, 0xe8, 0x0f, 0xa0, 0xa4, 0x3f, 0x5d,
+	0x44, 0x45, 0x24, 0x3f, 0x56, 0x4a, 0xf7, 0x2f, 0x51, 0x11, 0xb9, 0x0f, 0x7f, 0x7c, 0xb7, 0x8a,
+	0x8b, 0xf5, 0x61, 0xd6, 0x9b, 0x67, 0xc9, 0xe3, 0xfa, 0xb8, 0xa3, 0xf9, 0x96, 0x2e, 0x56, 0x34,
+	0x7f, 0x5c, 0x46, 0xb3, 0x3c, 0x9e, 0xcb, 0x5f, 0xbb, 0x59, 0x9d, 0xff, 0xdc, 0x3d, 0xff, 0x3f,
+	0x00, 0xea, 0xc8, 0x9a, 0xe1, 0x05, 0x07, 0x00, 0x00,
 }
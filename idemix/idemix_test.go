@@ -100,10 +100,11 @@ func TestIdemix(t *testing.T) {
 
 	disclosure := []byte{0, 0, 0, 0, 0}
 	msg := []byte{1, 2, 3, 4, 5}
-	sig, err := NewSignature(cred, sk, Nym, RandNym, key.IPk, disclosure, msg, rng)
+	rhindex := 0
+	sig, err := NewSignature(cred, sk, Nym, RandNym, key.IPk, disclosure, msg, rhindex, nil, rng)
 	assert.NoError(t, err)
 
-	err = sig.Ver(disclosure, key.IPk, msg, nil)
+	err = sig.Ver(disclosure, key.IPk, msg, nil, rhindex, nil, 0)
 	if err != nil {
 		t.Fatalf("Signature should be valid but verification returned error: %s", err)
 		return
@@ -111,15 +112,89 @@ func TestIdemix(t *testing.T) {
 
 	// Test signing selective disclosure
 	disclosure = []byte{0, 1, 1, 1, 1}
-	sig, err = NewSignature(cred, sk, Nym, RandNym, key.IPk, disclosure, msg, rng)
+	sig, err = NewSignature(cred, sk, Nym, RandNym, key.IPk, disclosure, msg, rhindex, nil, rng)
 	assert.NoError(t, err)
 
-	err = sig.Ver(disclosure, key.IPk, msg, attrs)
+	err = sig.Ver(disclosure, key.IPk, msg, attrs, rhindex, nil, 0)
 	if err != nil {
 		t.Fatalf("Signature should be valid but verification returned error: %s", err)
 		return
 	}
 
+	// Disclosing the revocation handle should fail
+	_, err = NewSignature(cred, sk, Nym, RandNym, key.IPk, []byte{1, 1, 1, 1, 1}, msg, rhindex, nil, rng)
+	assert.Error(t, err, "signing with a disclosed revocation handle should fail")
+
+	// Credentials without revocation handle sign without non-revocation proof
+	sig, err = NewSignature(cred, sk, Nym, RandNym, key.IPk, []byte{1, 1, 1, 1, 1}, msg, -1, nil, rng)
+	assert.NoError(t, err)
+	assert.Nil(t, sig.NonRevocationProof)
+	assert.NoError(t, sig.Ver([]byte{1, 1, 1, 1, 1}, key.IPk, msg, attrs, -1, nil, 0))
+	assert.Error(t, sig.Ver([]byte{1, 1, 1, 1, 1}, key.IPk, msg, attrs, 0, nil, 0), "signature without non-revocation proof should be invalid with a revocation handle")
+
+	// Test revocation
+	revocationKey, err := GenerateLongTermRevocationKey()
+	assert.NoError(t, err)
+
+	epoch := 0
+	cri, err := CreateCRI(revocationKey, []*FP256BN.BIG{attrs[rhindex], FP256BN.NewBIGint(42)}, epoch, ALG_WBB, rng)
+	assert.NoError(t, err)
+	assert.NoError(t, VerifyEpochPK(&revocationKey.PublicKey, cri.EpochPk, cri.EpochPkSig, epoch, ALG_WBB), "epoch public key should be valid")
+	assert.Error(t, VerifyEpochPK(&revocationKey.PublicKey, cri.EpochPk, cri.EpochPkSig, epoch+1, ALG_WBB), "epoch public key should be invalid for another epoch")
+
+	handles, err := UnrevokedHandles(cri)
+	assert.NoError(t, err)
+	assert.Len(t, handles, 2)
+	assert.Equal(t, BigToBytes(FP256BN.NewBIGint(42)), BigToBytes(handles[1]))
+
+	// The CRI of an epoch can be extended with new handles, keeping the same epoch public key
+	criExtended, err := CreateCRI(revocationKey, []*FP256BN.BIG{attrs[rhindex], FP256BN.NewBIGint(42), FP256BN.NewBIGint(43)}, epoch, ALG_WBB, rng)
+	assert.NoError(t, err)
+	assert.True(t, Ecp2FromProto(cri.EpochPk).Equals(Ecp2FromProto(criExtended.EpochPk)), "epoch public key should only depend on the epoch")
+
+	sig, err = NewSignature(cred, sk, Nym, RandNym, key.IPk, disclosure, msg, rhindex, cri, rng)
+	assert.NoError(t, err)
+	err = sig.Ver(disclosure, key.IPk, msg, attrs, rhindex, &revocationKey.PublicKey, epoch)
+	if err != nil {
+		t.Fatalf("Signature should be valid but verification returned error: %s", err)
+		return
+	}
+
+	// The signature is not valid for another epoch
+	assert.Error(t, sig.Ver(disclosure, key.IPk, msg, attrs, rhindex, &revocationKey.PublicKey, epoch+1), "signature should be invalid for another epoch")
+
+	// The signature is not valid for another revocation authority
+	otherKey, err := GenerateLongTermRevocationKey()
+	assert.NoError(t, err)
+	assert.Error(t, sig.Ver(disclosure, key.IPk, msg, attrs, rhindex, &otherKey.PublicKey, epoch), "signature should be invalid for another revocation public key")
+
+	// Tampering with the non-revocation proof makes the signature invalid
+	proof := sig.NonRevocationProof.NonRevocationProof
+	sig.NonRevocationProof.NonRevocationProof = append([]byte{}, proof...)
+	copy(sig.NonRevocationProof.NonRevocationProof[ProofBytes[ALG_WBB]-FieldBytes:], BigToBytes(RandModOrder(rng)))
+	assert.Error(t, sig.Ver(disclosure, key.IPk, msg, attrs, rhindex, &revocationKey.PublicKey, epoch), "signature with a broken non-revocation proof should be invalid")
+	sig.NonRevocationProof.NonRevocationProof = proof
+
+	// Revoke the credential in the next epoch
+	epoch++
+	cri, err = CreateCRI(revocationKey, []*FP256BN.BIG{FP256BN.NewBIGint(42)}, epoch, ALG_WBB, rng)
+	assert.NoError(t, err)
+	_, err = NewSignature(cred, sk, Nym, RandNym, key.IPk, disclosure, msg, rhindex, cri, rng)
+	assert.Error(t, err, "signing with a revoked credential should fail")
+	assert.Error(t, sig.Ver(disclosure, key.IPk, msg, attrs, rhindex, &revocationKey.PublicKey, epoch), "signature for an old epoch should be invalid")
+
+	// A CRI without revocation is accepted when signed by the revocation authority
+	cri, err = CreateCRI(revocationKey, nil, epoch, ALG_NO_REVOCATION, rng)
+	assert.NoError(t, err)
+	sig, err = NewSignature(cred, sk, Nym, RandNym, key.IPk, disclosure, msg, rhindex, cri, rng)
+	assert.NoError(t, err)
+	assert.NoError(t, sig.Ver(disclosure, key.IPk, msg, attrs, rhindex, &revocationKey.PublicKey, epoch))
+
+	// Revocation requires a revocation handle
+	_, err = NewSignature(cred, sk, Nym, RandNym, key.IPk, disclosure, msg, -1, cri, rng)
+	assert.Error(t, err, "signing with revocation information but no revocation handle should fail")
+	assert.Error(t, sig.Ver(disclosure, key.IPk, msg, attrs, -1, &revocationKey.PublicKey, epoch), "verifying revocation without revocation handle should fail")
+
 	// Test NymSignatures
 	nymsig, err := NewNymSignature(sk, Nym, RandNym, key.IPk, []byte("testing"), rng)
 	assert.NoError(t, err)
@@ -0,0 +1,106 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package idemix
+
+import (
+	"github.com/milagro-crypto/amcl/version3/go/amcl"
+	"github.com/milagro-crypto/amcl/version3/go/amcl/FP256BN"
+	"github.com/pkg/errors"
+)
+
+// nonRevokedProver is the Prover of the ZK proof system that is used for revocation.
+// The proof is a sub-proof of the signature proof: the revocation handle is one of the
+// hidden attributes of the credential, and the randomness rRh used for it is the same
+// randomness used for this attribute in the signature proof.
+type nonRevokedProver interface {
+	// getFSContribution returns the non-revocation contribution to the Fiat-Shamir hash, forming the challenge of the ZKP
+	getFSContribution(rh *FP256BN.BIG, rRh *FP256BN.BIG, cri *CredentialRevocationInformation, rng *amcl.RAND) ([]byte, error)
+
+	// getNonRevokedProof returns a proof of non-revocation with the respect to passed challenge
+	getNonRevokedProof(chal *FP256BN.BIG) (*NonRevocationProof, error)
+}
+
+// nopNonRevokedProver is a concrete nonRevokedProver for RevocationAlgorithm ALG_NO_REVOCATION
+type nopNonRevokedProver struct{}
+
+func (prover *nopNonRevokedProver) getFSContribution(rh *FP256BN.BIG, rRh *FP256BN.BIG, cri *CredentialRevocationInformation, rng *amcl.RAND) ([]byte, error) {
+	return nil, nil
+}
+
+func (prover *nopNonRevokedProver) getNonRevokedProof(chal *FP256BN.BIG) (*NonRevocationProof, error) {
+	ret := &NonRevocationProof{}
+	ret.RevocationAlg = int32(ALG_NO_REVOCATION)
+	return ret, nil
+}
+
+// wbbNonRevokedProver is a concrete nonRevokedProver for RevocationAlgorithm ALG_WBB.
+// It proves knowledge of a weak Boneh-Boyen signature sigma = g1^{1/(x+rh)} of the revocation
+// authority on the hidden revocation handle rh, where x is the secret key of the epoch.
+// The signature is randomized as sigmaPrime = sigma^rho, and the proof shows knowledge of rh and rho
+// such that sigmaBar = sigmaPrime^{-rh} * g1^rho, which holds iff e(sigmaPrime, W_epoch) = e(sigmaBar, g2).
+type wbbNonRevokedProver struct {
+	rh         *FP256BN.BIG
+	rho        *FP256BN.BIG
+	rRho       *FP256BN.BIG
+	sigmaPrime *FP256BN.ECP
+	sigmaBar   *FP256BN.ECP
+}
+
+func (prover *wbbNonRevokedProver) getFSContribution(rh *FP256BN.BIG, rRh *FP256BN.BIG, cri *CredentialRevocationInformation, rng *amcl.RAND) ([]byte, error) {
+	sigma, err := wbbSignature(cri, rh)
+	if err != nil {
+		return nil, err
+	}
+
+	prover.rh = rh
+	prover.rho = RandModOrder(rng)
+	prover.rRho = RandModOrder(rng)
+
+	prover.sigmaPrime = FP256BN.G1mul(sigma, prover.rho)
+	prover.sigmaBar = FP256BN.G1mul(GenG1, prover.rho)
+	prover.sigmaBar.Sub(FP256BN.G1mul(prover.sigmaPrime, rh))
+
+	t := FP256BN.G1mul(GenG1, prover.rRho)
+	t.Sub(FP256BN.G1mul(prover.sigmaPrime, rRh))
+
+	contribution := make([]byte, 3*(2*FieldBytes+1))
+	index := appendBytesG1(contribution, 0, prover.sigmaPrime)
+	index = appendBytesG1(contribution, index, prover.sigmaBar)
+	appendBytesG1(contribution, index, t)
+	return contribution, nil
+}
+
+func (prover *wbbNonRevokedProver) getNonRevokedProof(chal *FP256BN.BIG) (*NonRevocationProof, error) {
+	if prover.sigmaPrime == nil {
+		return nil, errors.Errorf("the Fiat-Shamir contribution of the non-revocation proof was not computed")
+	}
+
+	proofSRho := Modadd(prover.rRho, FP256BN.Modmul(chal, prover.rho, GroupOrder), GroupOrder)
+
+	proof := make([]byte, ProofBytes[ALG_WBB])
+	index := appendBytesG1(proof, 0, prover.sigmaPrime)
+	index = appendBytesG1(proof, index, prover.sigmaBar)
+	appendBytesBig(proof, index, proofSRho)
+
+	ret := &NonRevocationProof{}
+	ret.RevocationAlg = int32(ALG_WBB)
+	ret.NonRevocationProof = proof
+	return ret, nil
+}
+
+// getNonRevocationProver returns the nonRevokedProver bound to the passed revocation algorithm
+func getNonRevocationProver(algorithm RevocationAlgorithm) (nonRevokedProver, error) {
+	switch algorithm {
+	case ALG_NO_REVOCATION:
+		return &nopNonRevokedProver{}, nil
+	case ALG_WBB:
+		return &wbbNonRevokedProver{}, nil
+	default:
+		// unknown revocation algorithm
+		return nil, errors.Errorf("unknown revocation algorithm %d", algorithm)
+	}
+}
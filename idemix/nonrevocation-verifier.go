@@ -0,0 +1,79 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package idemix
+
+import (
+	"github.com/milagro-crypto/amcl/version3/go/amcl/FP256BN"
+	"github.com/pkg/errors"
+)
+
+// nonRevocationVerifier is the verifier of the non-revocation proof.
+// It recomputes the non-revocation contribution to the Fiat-Shamir hash using the s-value
+// that the signature proof contains for the (hidden) revocation handle.
+type nonRevocationVerifier interface {
+	// recomputeFSContribution recomputes the contribution of the non-revocation proof to the ZKP challenge
+	recomputeFSContribution(proof *NonRevocationProof, chal *FP256BN.BIG, epochPK *FP256BN.ECP2, proofSRh *FP256BN.BIG) ([]byte, error)
+}
+
+// nopNonRevocationVerifier is a concrete nonRevocationVerifier for RevocationAlgorithm ALG_NO_REVOCATION
+type nopNonRevocationVerifier struct{}
+
+func (verifier *nopNonRevocationVerifier) recomputeFSContribution(proof *NonRevocationProof, chal *FP256BN.BIG, epochPK *FP256BN.ECP2, proofSRh *FP256BN.BIG) ([]byte, error) {
+	return nil, nil
+}
+
+// wbbNonRevocationVerifier is a concrete nonRevocationVerifier for RevocationAlgorithm ALG_WBB
+type wbbNonRevocationVerifier struct{}
+
+func (verifier *wbbNonRevocationVerifier) recomputeFSContribution(proof *NonRevocationProof, chal *FP256BN.BIG, epochPK *FP256BN.ECP2, proofSRh *FP256BN.BIG) ([]byte, error) {
+	if epochPK == nil {
+		return nil, errors.Errorf("non-revocation proof invalid: missing epoch public key")
+	}
+	if len(proof.NonRevocationProof) != ProofBytes[ALG_WBB] {
+		return nil, errors.Errorf("non-revocation proof invalid: incorrect length")
+	}
+
+	sigmaPrime := FP256BN.ECP_fromBytes(proof.NonRevocationProof[0 : 2*FieldBytes+1])
+	sigmaBar := FP256BN.ECP_fromBytes(proof.NonRevocationProof[2*FieldBytes+1 : 2*(2*FieldBytes+1)])
+	proofSRho := FP256BN.FromBytes(proof.NonRevocationProof[2*(2*FieldBytes+1):])
+
+	if sigmaPrime.Is_infinity() {
+		return nil, errors.Errorf("non-revocation proof invalid: sigmaPrime = 1")
+	}
+
+	// check that sigmaBar = sigmaPrime^x, where x is the secret key of the epoch
+	temp1 := FP256BN.Ate(epochPK, sigmaPrime)
+	temp2 := FP256BN.Ate(GenG2, sigmaBar)
+	temp2.Inverse()
+	temp1.Mul(temp2)
+	if !FP256BN.Fexp(temp1).Isunity() {
+		return nil, errors.Errorf("non-revocation proof invalid: sigmaPrime and sigmaBar don't have the expected structure")
+	}
+
+	t := FP256BN.G1mul(GenG1, proofSRho)
+	t.Sub(FP256BN.G1mul(sigmaPrime, proofSRh))
+	t.Sub(FP256BN.G1mul(sigmaBar, chal))
+
+	contribution := make([]byte, 3*(2*FieldBytes+1))
+	index := appendBytesG1(contribution, 0, sigmaPrime)
+	index = appendBytesG1(contribution, index, sigmaBar)
+	appendBytesG1(contribution, index, t)
+	return contribution, nil
+}
+
+// getNonRevocationVerifier returns the nonRevocationVerifier bound to the passed revocation algorithm
+func getNonRevocationVerifier(algorithm RevocationAlgorithm) (nonRevocationVerifier, error) {
+	switch algorithm {
+	case ALG_NO_REVOCATION:
+		return &nopNonRevocationVerifier{}, nil
+	case ALG_WBB:
+		return &wbbNonRevocationVerifier{}, nil
+	default:
+		// unknown revocation algorithm
+		return nil, errors.Errorf("unknown revocation algorithm %d", algorithm)
+	}
+}
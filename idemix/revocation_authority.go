@@ -0,0 +1,192 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package idemix
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"math/big"
+
+	"github.com/milagro-crypto/amcl/version3/go/amcl"
+	"github.com/milagro-crypto/amcl/version3/go/amcl/FP256BN"
+	"github.com/pkg/errors"
+)
+
+// The revocation authority (RA) of an issuer lets the verifiers of idemix signatures
+// reject signatures made with revoked credentials.
+// Every credential contains a revocation handle (an attribute which is never disclosed).
+// Time is divided in epochs, and for each epoch the RA publishes a CredentialRevocationInformation (CRI)
+// that lets the holders of unrevoked credentials prove in zero-knowledge that their revocation
+// handle is not revoked in that epoch.
+// The CRI contains an epoch public key, signed by the long term revocation key of the RA.
+// Verifiers know the long term revocation public key and the current epoch, and only accept
+// signatures with a non-revocation proof for the current epoch.
+
+// RevocationAlgorithm identifies the algorithm used to prove non-revocation
+type RevocationAlgorithm int32
+
+const (
+	// ALG_NO_REVOCATION means that no revocation is performed: every credential is valid
+	ALG_NO_REVOCATION RevocationAlgorithm = iota
+
+	// ALG_WBB means that the RA places a weak Boneh-Boyen signature on every
+	// unrevoked revocation handle, and the holder of the credential proves knowledge
+	// of the signature on its (hidden) revocation handle
+	// (see e.g. "Short Signatures Without Random Oracles" by Dan Boneh and Xavier Boyen)
+	ALG_WBB
+)
+
+// ProofBytes is the length in bytes of a non-revocation proof for each algorithm
+var ProofBytes = map[RevocationAlgorithm]int{
+	ALG_NO_REVOCATION: 0,
+	ALG_WBB:           2*(2*FieldBytes+1) + FieldBytes,
+}
+
+// wbbEntryBytes is the length of an entry of the revocation data of ALG_WBB,
+// which is a revocation handle followed by the signature on it
+var wbbEntryBytes = FieldBytes + 2*FieldBytes + 1
+
+// epochKeyLabel is the label used to derive the epoch secret key from the long term revocation key
+const epochKeyLabel = "epoch"
+
+// GenerateLongTermRevocationKey generates a long term signing key that will be used for revocation
+func GenerateLongTermRevocationKey() (*ecdsa.PrivateKey, error) {
+	return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+}
+
+// CreateCRI creates the CredentialRevocationInformation for a certain time period (epoch).
+// The epoch secret key is derived from the long term revocation key, so creating the CRI again
+// for the same epoch, for instance to add the revocation handles of newly issued credentials,
+// results in the same epoch public key.
+func CreateCRI(key *ecdsa.PrivateKey, unrevokedHandles []*FP256BN.BIG, epoch int, alg RevocationAlgorithm, rng *amcl.RAND) (*CredentialRevocationInformation, error) {
+	if key == nil || rng == nil {
+		return nil, errors.Errorf("CreateCRI received nil input")
+	}
+
+	cri := &CredentialRevocationInformation{}
+	cri.RevocationAlg = int32(alg)
+	cri.Epoch = int64(epoch)
+
+	var epochSk *FP256BN.BIG
+	switch alg {
+	case ALG_NO_REVOCATION:
+		// put a dummy public key in the CRI
+		epochSk = RandModOrder(rng)
+	case ALG_WBB:
+		epochSk = epochSecretKey(key, epoch)
+		data := make([]byte, 0, len(unrevokedHandles)*wbbEntryBytes)
+		for _, rh := range unrevokedHandles {
+			exp := Modadd(epochSk, rh, GroupOrder)
+			exp.Invmodp(GroupOrder)
+			entry := make([]byte, wbbEntryBytes)
+			index := appendBytesBig(entry, 0, rh)
+			appendBytesG1(entry, index, GenG1.Mul(exp))
+			data = append(data, entry...)
+		}
+		cri.RevocationData = data
+	default:
+		return nil, errors.Errorf("the specified revocation algorithm is not supported")
+	}
+
+	epochPk := GenG2.Mul(epochSk)
+	cri.EpochPk = Ecp2ToProto(epochPk)
+
+	sig, err := signEpochPK(key, epochPk, epoch, alg)
+	if err != nil {
+		return nil, err
+	}
+	cri.EpochPkSig = sig
+
+	return cri, nil
+}
+
+// VerifyEpochPK verifies that the revocation PK for a certain epoch is valid,
+// by checking that it was signed with the long term revocation key
+func VerifyEpochPK(pk *ecdsa.PublicKey, epochPK *ECP2, epochPkSig []byte, epoch int, alg RevocationAlgorithm) error {
+	if pk == nil || epochPK == nil {
+		return errors.Errorf("EpochPK invalid: received nil input")
+	}
+
+	sig := &ecdsaSignature{}
+	_, err := asn1.Unmarshal(epochPkSig, sig)
+	if err != nil {
+		return errors.Wrap(err, "failed unmarshalling the signature on the epoch public key")
+	}
+	if sig.R == nil || sig.S == nil {
+		return errors.Errorf("EpochPKSig invalid")
+	}
+
+	digest := epochPKDigest(Ecp2FromProto(epochPK), epoch, alg)
+	if !ecdsa.Verify(pk, digest, sig.R, sig.S) {
+		return errors.Errorf("EpochPKSig invalid")
+	}
+
+	return nil
+}
+
+// UnrevokedHandles returns the revocation handles which are not revoked in the epoch of cri
+func UnrevokedHandles(cri *CredentialRevocationInformation) ([]*FP256BN.BIG, error) {
+	if RevocationAlgorithm(cri.RevocationAlg) != ALG_WBB {
+		return nil, errors.Errorf("the revocation handles are not available for revocation algorithm %d", cri.RevocationAlg)
+	}
+	if len(cri.RevocationData)%wbbEntryBytes != 0 {
+		return nil, errors.Errorf("revocation data has invalid length %d", len(cri.RevocationData))
+	}
+
+	handles := make([]*FP256BN.BIG, 0, len(cri.RevocationData)/wbbEntryBytes)
+	for index := 0; index < len(cri.RevocationData); index += wbbEntryBytes {
+		handles = append(handles, FP256BN.FromBytes(cri.RevocationData[index:index+FieldBytes]))
+	}
+	return handles, nil
+}
+
+// wbbSignature returns the signature of the revocation authority on rh from
+// the revocation data of a CRI using ALG_WBB
+func wbbSignature(cri *CredentialRevocationInformation, rh *FP256BN.BIG) (*FP256BN.ECP, error) {
+	rhBytes := BigToBytes(rh)
+	for index := 0; index+wbbEntryBytes <= len(cri.RevocationData); index += wbbEntryBytes {
+		if bytes.Equal(cri.RevocationData[index:index+FieldBytes], rhBytes) {
+			return FP256BN.ECP_fromBytes(cri.RevocationData[index+FieldBytes : index+wbbEntryBytes]), nil
+		}
+	}
+	return nil, errors.Errorf("the revocation handle is revoked in epoch %d", cri.Epoch)
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+func signEpochPK(key *ecdsa.PrivateKey, epochPk *FP256BN.ECP2, epoch int, alg RevocationAlgorithm) ([]byte, error) {
+	r, s, err := ecdsa.Sign(rand.Reader, key, epochPKDigest(epochPk, epoch, alg))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed signing the epoch public key")
+	}
+	return asn1.Marshal(ecdsaSignature{r, s})
+}
+
+// epochPKDigest returns the digest signed by the long term revocation key
+func epochPKDigest(epochPk *FP256BN.ECP2, epoch int, alg RevocationAlgorithm) []byte {
+	data := make([]byte, 4*FieldBytes+2*FieldBytes)
+	index := appendBytesG2(data, 0, epochPk)
+	index = appendBytesBig(data, index, FP256BN.NewBIGint(epoch))
+	appendBytesBig(data, index, FP256BN.NewBIGint(int(alg)))
+	digest := sha256.Sum256(data)
+	return digest[:]
+}
+
+// epochSecretKey derives the secret key of an epoch from the long term revocation key
+func epochSecretKey(key *ecdsa.PrivateKey, epoch int) *FP256BN.BIG {
+	data := make([]byte, len(key.D.Bytes())+len([]byte(epochKeyLabel))+FieldBytes)
+	copy(data, key.D.Bytes())
+	index := appendBytesString(data, len(key.D.Bytes()), epochKeyLabel)
+	appendBytesBig(data, index, FP256BN.NewBIGint(epoch))
+	return HashModOrder(data)
+}
@@ -7,6 +7,9 @@ SPDX-License-Identifier: Apache-2.0
 package idemix
 
 import (
+	"crypto/ecdsa"
+	"sort"
+
 	"github.com/milagro-crypto/amcl/version3/go/amcl"
 	"github.com/milagro-crypto/amcl/version3/go/amcl/FP256BN"
 	"github.com/pkg/errors"
@@ -43,11 +46,28 @@ func hiddenIndices(Disclosure []byte) []int {
 // The []byte Disclosure steers which attributes are disclosed:
 // if Disclosure[i] == 0 then attribute i remains hidden and otherwise it is disclosed.
 // We use the zero-knowledge proof by http://eprint.iacr.org/2016/663.pdf to prove knowledge of a BBS+ signature
-func NewSignature(cred *Credential, sk *FP256BN.BIG, Nym *FP256BN.ECP, RNym *FP256BN.BIG, ipk *IssuerPublicKey, Disclosure []byte, msg []byte, rng *amcl.RAND) (*Signature, error) {
+// The attribute at index rhIndex is the revocation handle of the credential, which must remain hidden:
+// the signature proves that it is not revoked with respect to the credential revocation information cri.
+// A nil cri means that no revocation is performed (RevocationAlgorithm ALG_NO_REVOCATION).
+// A negative rhIndex means that the credential has no revocation handle, as issued by issuers
+// whose keys predate revocation, in which case the signature carries no non-revocation proof.
+func NewSignature(cred *Credential, sk *FP256BN.BIG, Nym *FP256BN.ECP, RNym *FP256BN.BIG, ipk *IssuerPublicKey, Disclosure []byte, msg []byte, rhIndex int, cri *CredentialRevocationInformation, rng *amcl.RAND) (*Signature, error) {
 	if cred == nil || sk == nil || Nym == nil || RNym == nil || ipk == nil || rng == nil {
 		return nil, errors.Errorf("cannot create idemix signature: received nil input")
 	}
 
+	if rhIndex >= len(ipk.AttributeNames) || len(Disclosure) != len(ipk.AttributeNames) {
+		return nil, errors.Errorf("cannot create idemix signature: received invalid input")
+	}
+
+	if rhIndex < 0 && cri != nil {
+		return nil, errors.Errorf("cannot create idemix signature: credential revocation information requires a revocation handle attribute")
+	}
+
+	if rhIndex >= 0 && Disclosure[rhIndex] == 1 {
+		return nil, errors.Errorf("Attribute %d is disclosed but also used as revocation handle attribute, which should remain hidden.", rhIndex)
+	}
+
 	HiddenIndices := hiddenIndices(Disclosure)
 
 	// Start sig
@@ -100,13 +120,38 @@ func NewSignature(cred *Credential, sk *FP256BN.BIG, Nym *FP256BN.ECP, RNym *FP2
 
 	t3 := HSk.Mul2(rSk, HRand, rRNym)
 
+	// Compute the non-revocation contribution, using the randomness of the revocation handle attribute
+	var prover nonRevokedProver
+	var nonRevokedProofHashData []byte
+	if rhIndex >= 0 {
+		alg := ALG_NO_REVOCATION
+		if cri != nil {
+			alg = RevocationAlgorithm(cri.RevocationAlg)
+		}
+		var err error
+		prover, err = getNonRevocationProver(alg)
+		if err != nil {
+			return nil, err
+		}
+		nonRevokedProofHashData, err = prover.getFSContribution(FP256BN.FromBytes(cred.Attrs[rhIndex]), rAttrs[sort.SearchInts(HiddenIndices, rhIndex)], cri, rng)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to compute non-revoked proof")
+		}
+	}
+	epochPkBytes := []byte{}
+	if cri != nil {
+		epochPkBytes = make([]byte, 4*FieldBytes)
+		appendBytesG2(epochPkBytes, 0, Ecp2FromProto(cri.EpochPk))
+	}
+
 	// proofData is the data being hashed, it consists of:
 	// the signature label
 	// 7 elements of G1 each taking 2*FieldBytes+1 bytes
 	// one bigint (hash of the issuer public key) of length FieldBytes
 	// disclosed attributes
 	// message being signed
-	proofData := make([]byte, len([]byte(signLabel))+7*(2*FieldBytes+1)+FieldBytes+len(Disclosure)+len(msg))
+	// the epoch public key (if any) and the non-revocation contribution
+	proofData := make([]byte, len([]byte(signLabel))+7*(2*FieldBytes+1)+FieldBytes+len(Disclosure)+len(msg)+len(epochPkBytes)+len(nonRevokedProofHashData))
 	index := 0
 	index = appendBytesString(proofData, index, signLabel)
 	index = appendBytesG1(proofData, index, t1)
@@ -121,6 +166,10 @@ func NewSignature(cred *Credential, sk *FP256BN.BIG, Nym *FP256BN.ECP, RNym *FP2
 	copy(proofData[index:], Disclosure)
 	index = index + len(Disclosure)
 	copy(proofData[index:], msg)
+	index = index + len(msg)
+	copy(proofData[index:], epochPkBytes)
+	index = index + len(epochPkBytes)
+	copy(proofData[index:], nonRevokedProofHashData)
 	c := HashModOrder(proofData)
 
 	// add the previous hash and the nonce and hash again to compute a second hash (C value)
@@ -141,27 +190,63 @@ func NewSignature(cred *Credential, sk *FP256BN.BIG, Nym *FP256BN.ECP, RNym *FP2
 		ProofSAttrs[i] = BigToBytes(Modadd(rAttrs[i], FP256BN.Modmul(ProofC, FP256BN.FromBytes(cred.Attrs[j]), GroupOrder), GroupOrder))
 	}
 
-	return &Signature{
-			EcpToProto(APrime),
-			EcpToProto(ABar),
-			EcpToProto(BPrime),
-			BigToBytes(ProofC),
-			BigToBytes(ProofSSk),
-			BigToBytes(ProofSE),
-			BigToBytes(ProofSR2),
-			BigToBytes(ProofSR3),
-			BigToBytes(ProofSSPrime),
-			ProofSAttrs,
-			BigToBytes(Nonce),
-			EcpToProto(Nym),
-			BigToBytes(ProofSRNym)},
-		nil
+	var nonRevokedProof *NonRevocationProof
+	if prover != nil {
+		var err error
+		nonRevokedProof, err = prover.getNonRevokedProof(ProofC)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sig := &Signature{
+		APrime:             EcpToProto(APrime),
+		ABar:               EcpToProto(ABar),
+		BPrime:             EcpToProto(BPrime),
+		ProofC:             BigToBytes(ProofC),
+		ProofSSk:           BigToBytes(ProofSSk),
+		ProofSE:            BigToBytes(ProofSE),
+		ProofSR2:           BigToBytes(ProofSR2),
+		ProofSR3:           BigToBytes(ProofSR3),
+		ProofSSPrime:       BigToBytes(ProofSSPrime),
+		ProofSAttrs:        ProofSAttrs,
+		Nonce:              BigToBytes(Nonce),
+		Nym:                EcpToProto(Nym),
+		ProofSRNym:         BigToBytes(ProofSRNym),
+		NonRevocationProof: nonRevokedProof,
+	}
+	if cri != nil {
+		sig.RevocationEpochPk = cri.EpochPk
+		sig.RevocationPkSig = cri.EpochPkSig
+		sig.Epoch = cri.Epoch
+	}
+	return sig, nil
 }
 
 // Ver verifies an idemix signature
 // Disclosure steers which attributes it expects to be disclosed
 // attributeValues[i] contains the desired attribute value for the i-th undisclosed attribute in Disclosure
-func (sig *Signature) Ver(Disclosure []byte, ipk *IssuerPublicKey, msg []byte, attributeValues []*FP256BN.BIG) error {
+// rhIndex is the index of the (hidden) revocation handle attribute.
+// If revPk is not nil, the signature must prove non-revocation in the passed epoch,
+// with respect to an epoch public key signed by revPk, the long term public key of the revocation authority.
+// A negative rhIndex means that the credential has no revocation handle, in which case revPk must be nil.
+func (sig *Signature) Ver(Disclosure []byte, ipk *IssuerPublicKey, msg []byte, attributeValues []*FP256BN.BIG, rhIndex int, revPk *ecdsa.PublicKey, epoch int) error {
+	if ipk == nil {
+		return errors.Errorf("cannot verify idemix signature: received nil input")
+	}
+
+	if rhIndex >= len(ipk.AttributeNames) || len(Disclosure) != len(ipk.AttributeNames) {
+		return errors.Errorf("cannot verify idemix signature: received invalid input")
+	}
+
+	if rhIndex < 0 && revPk != nil {
+		return errors.Errorf("cannot verify idemix signature: revocation requires a revocation handle attribute")
+	}
+
+	if rhIndex >= 0 && Disclosure[rhIndex] == 1 {
+		return errors.Errorf("Attribute %d is disclosed but is also used as revocation handle, which should remain hidden.", rhIndex)
+	}
+
 	HiddenIndices := hiddenIndices(Disclosure)
 
 	APrime := EcpFromProto(sig.GetAPrime())
@@ -230,13 +315,49 @@ func (sig *Signature) Ver(Disclosure []byte, ipk *IssuerPublicKey, msg []byte, a
 	t3 := HSk.Mul2(ProofSSk, HRand, ProofSRNym)
 	t3.Sub(Nym.Mul(ProofC))
 
+	// verify the non-revocation proof, if the credential has a revocation handle
+	epochPkBytes := []byte{}
+	var nonRevokedProofHashData []byte
+	if rhIndex >= 0 {
+		if sig.NonRevocationProof == nil {
+			return errors.Errorf("signature invalid: missing non-revocation proof")
+		}
+		alg := RevocationAlgorithm(sig.NonRevocationProof.RevocationAlg)
+		if revPk != nil {
+			if sig.RevocationEpochPk == nil {
+				return errors.Errorf("signature invalid: missing epoch public key")
+			}
+			if sig.Epoch != int64(epoch) {
+				return errors.Errorf("signature invalid: non-revocation proof is for epoch %d, expected epoch %d", sig.Epoch, epoch)
+			}
+			if err := VerifyEpochPK(revPk, sig.RevocationEpochPk, sig.RevocationPkSig, int(sig.Epoch), alg); err != nil {
+				return errors.Wrap(err, "signature invalid: epoch public key is not valid")
+			}
+		}
+		var epochPk *FP256BN.ECP2
+		if sig.RevocationEpochPk != nil {
+			epochPk = Ecp2FromProto(sig.RevocationEpochPk)
+			epochPkBytes = make([]byte, 4*FieldBytes)
+			appendBytesG2(epochPkBytes, 0, epochPk)
+		}
+		verifier, err := getNonRevocationVerifier(alg)
+		if err != nil {
+			return err
+		}
+		nonRevokedProofHashData, err = verifier.recomputeFSContribution(sig.NonRevocationProof, ProofC, epochPk, ProofSAttrs[sort.SearchInts(HiddenIndices, rhIndex)])
+		if err != nil {
+			return errors.Wrap(err, "signature invalid")
+		}
+	}
+
 	// proofData is the data being hashed, it consists of:
 	// the signature label
 	// 7 elements of G1 each taking 2*FieldBytes+1 bytes
 	// one bigint (hash of the issuer public key) of length FieldBytes
 	// disclosed attributes
 	// message that was signed
-	proofData := make([]byte, len([]byte(signLabel))+7*(2*FieldBytes+1)+FieldBytes+len(Disclosure)+len(msg))
+	// the epoch public key (if any) and the non-revocation contribution
+	proofData := make([]byte, len([]byte(signLabel))+7*(2*FieldBytes+1)+FieldBytes+len(Disclosure)+len(msg)+len(epochPkBytes)+len(nonRevokedProofHashData))
 	index := 0
 	index = appendBytesString(proofData, index, signLabel)
 	index = appendBytesG1(proofData, index, t1)
@@ -251,6 +372,10 @@ func (sig *Signature) Ver(Disclosure []byte, ipk *IssuerPublicKey, msg []byte, a
 	copy(proofData[index:], Disclosure)
 	index = index + len(Disclosure)
 	copy(proofData[index:], msg)
+	index = index + len(msg)
+	copy(proofData[index:], epochPkBytes)
+	index = index + len(epochPkBytes)
+	copy(proofData[index:], nonRevokedProofHashData)
 
 	c := HashModOrder(proofData)
 	index = 0
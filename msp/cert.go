@@ -112,7 +112,7 @@ func sanitizeECDSASignedCert(cert *x509.Certificate, parentCert *x509.Certificat
 	}
 
 	// 4. parse newRaw to get an x509 certificate
-	return x509.ParseCertificate(newRaw)
+	return utils.DERToX509Certificate(newRaw)
 }
 
 func certFromX509Cert(cert *x509.Certificate) (certificate, error) {
@@ -16,6 +16,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
+	"github.com/hyperledger/fabric/idemix"
 	"github.com/hyperledger/fabric/protos/msp"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
@@ -362,6 +363,9 @@ const (
 	IdemixConfigDirUser             = "user"
 	IdemixConfigFileIssuerPublicKey = "IssuerPublicKey"
 	IdemixConfigFileSigner          = "SignerConfig"
+
+	IdemixConfigFileRevocationPublicKey   = "RevocationPublicKey"
+	IdemixConfigFileRevocationInformation = "RevocationInformation"
 )
 
 // GetIdemixMspConfig returns the configuration for the Idemix MSP
@@ -376,6 +380,27 @@ func GetIdemixMspConfig(dir string, ID string) (*msp.MSPConfig, error) {
 		IPk:  ipkBytes,
 	}
 
+	// the revocation public key is optional: without it, revocation is not checked
+	revocationPkBytes, err := readFile(filepath.Join(dir, IdemixConfigDirMsp, IdemixConfigFileRevocationPublicKey))
+	if err == nil {
+		idemixConfig.RevocationPk = revocationPkBytes
+	}
+
+	// the revocation information of the current epoch is optional as well
+	var criBytes []byte
+	if _, err := os.Stat(filepath.Join(dir, IdemixConfigDirMsp, IdemixConfigFileRevocationInformation)); err == nil {
+		criBytes, err = readFile(filepath.Join(dir, IdemixConfigDirMsp, IdemixConfigFileRevocationInformation))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read revocation information file")
+		}
+		cri := &idemix.CredentialRevocationInformation{}
+		err = proto.Unmarshal(criBytes, cri)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal revocation information")
+		}
+		idemixConfig.Epoch = cri.Epoch
+	}
+
 	signerBytes, err := readFile(filepath.Join(dir, IdemixConfigDirUser, IdemixConfigFileSigner))
 	if err == nil {
 		signerConfig := &msp.IdemixMSPSignerConfig{}
@@ -383,6 +408,10 @@ func GetIdemixMspConfig(dir string, ID string) (*msp.MSPConfig, error) {
 		if err != nil {
 			return nil, err
 		}
+		// the revocation information of the msp is the one of the current epoch
+		if criBytes != nil {
+			signerConfig.CredentialRevocationInformation = criBytes
+		}
 		idemixConfig.Signer = signerConfig
 	}
 
@@ -9,12 +9,14 @@ package msp
 import (
 	"bytes"
 	"encoding/hex"
+	"encoding/pem"
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/bccsp"
+	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/idemix"
 	m "github.com/hyperledger/fabric/protos/msp"
-	"github.com/milagro-crypto/amcl/version3/go/amcl"
 	"github.com/milagro-crypto/amcl/version3/go/amcl/FP256BN"
 	"github.com/op/go-logging"
 	"github.com/pkg/errors"
@@ -26,24 +28,37 @@ const (
 
 	// AttributeNameRole is the attribute name of the Role attribute
 	AttributeNameRole = "Role"
+
+	// AttributeNameRevocationHandle is the attribute name of the revocation handle attribute
+	AttributeNameRevocationHandle = "RevocationHandle"
 )
 
-// discloseFlags will be passed to the idemix signing and verification routines.
-// It informs idemix to disclose both attributes (OU and Role) when signing.
-var discloseFlags = []byte{1, 1}
+// index of the revocation handle attribute in the credential
+const rhIndex = 2
+
+// noRevocationHandle is the revocation handle index of credentials issued by
+// issuer keys which predate revocation, whose only attributes are OU and Role
+const noRevocationHandle = -1
 
 type idemixmsp struct {
-	ipk    *idemix.IssuerPublicKey
-	rng    *amcl.RAND
+	csp    bccsp.BCCSP
+	ipk    bccsp.Key
 	signer *idemixSigningIdentity
 	name   string
+	// revocationPK is the long term public key of the revocation authority;
+	// if set, identities must prove that they are not revoked in epoch
+	revocationPK bccsp.Key
+	epoch        int
+	// rhIndex is the index of the revocation handle attribute of the
+	// credentials, or noRevocationHandle
+	rhIndex int
 }
 
 // newIdemixMsp creates a new instance of idemixmsp
 func newIdemixMsp() (MSP, error) {
 	mspLogger.Debugf("Creating Idemix-based MSP instance")
 
-	msp := idemixmsp{}
+	msp := idemixmsp{csp: factory.GetDefault()}
 	return &msp, nil
 }
 
@@ -67,33 +82,33 @@ func (msp *idemixmsp) Setup(conf1 *m.MSPConfig) error {
 	msp.name = conf.Name
 	mspLogger.Debugf("Setting up Idemix MSP instance %s", msp.name)
 
-	ipk := new(idemix.IssuerPublicKey)
-	err = proto.Unmarshal(conf.IPk, ipk)
-	if err != nil {
-		return errors.Wrap(err, "failed to unmarshal ipk from idemix msp config")
-	}
-	err = ipk.SetHash()
-	if err != nil {
-		return errors.WithMessage(err, "setting the hash of the issuer public key failed")
-	}
-
-	if len(ipk.AttributeNames) < 2 || ipk.AttributeNames[0] != AttributeNameOU || ipk.AttributeNames[1] != AttributeNameRole {
-		return errors.Errorf("ipk must have have attributes OU and Role")
-	}
-
-	err = ipk.Check()
+	// Import the issuer public key, which must have the attributes OU, Role and RevocationHandle,
+	// or only OU and Role for issuer keys which predate revocation
+	attributeNames := []string{AttributeNameOU, AttributeNameRole, AttributeNameRevocationHandle}
+	msp.rhIndex = rhIndex
+	if isLegacyIssuerPublicKey(conf.IPk) {
+		attributeNames = []string{AttributeNameOU, AttributeNameRole}
+		msp.rhIndex = noRevocationHandle
+	}
+	msp.ipk, err = msp.csp.KeyImport(conf.IPk, &bccsp.IdemixIssuerPublicKeyImportOpts{
+		Temporary:      true,
+		AttributeNames: attributeNames,
+	})
 	if err != nil {
 		return errors.WithMessage(err, "cannot setup idemix msp with invalid public key")
 	}
-	msp.ipk = ipk
 
-	rng, err := idemix.GetRand()
-	if err != nil {
-		return errors.Wrap(err, "error initializing PRNG for idemix msp")
+	if len(conf.RevocationPk) != 0 {
+		if msp.rhIndex == noRevocationHandle {
+			return errors.New("cannot setup idemix msp with revocation: the issuer public key has no revocation handle attribute")
+		}
+		msp.revocationPK, err = msp.importRevocationPublicKey(conf.RevocationPk)
+		if err != nil {
+			return errors.WithMessage(err, "cannot setup idemix msp with invalid revocation public key")
+		}
+		msp.epoch = int(conf.Epoch)
 	}
 
-	msp.rng = rng
-
 	if conf.Signer == nil {
 		// No credential in config, so we don't setup a default signer
 		mspLogger.Debug("idemix msp setup as verification only msp (no key material found)")
@@ -107,9 +122,6 @@ func (msp *idemixmsp) Setup(conf1 *m.MSPConfig) error {
 		return errors.Wrap(err, "Failed to unmarshal credential from config")
 	}
 
-	sk := FP256BN.FromBytes(conf.Signer.Sk)
-
-	Nym, RandNym := idemix.MakeNym(sk, msp.ipk, rng)
 	role := &m.MSPRole{
 		MspIdentifier: msp.name,
 		Role:          m.MSPRole_MEMBER,
@@ -121,12 +133,12 @@ func (msp *idemixmsp) Setup(conf1 *m.MSPConfig) error {
 	ou := &m.OrganizationUnit{
 		MspIdentifier:                msp.name,
 		OrganizationalUnitIdentifier: conf.Signer.OrganizationalUnitIdentifier,
-		CertifiersIdentifier:         ipk.Hash,
+		CertifiersIdentifier:         msp.ipk.SKI(),
 	}
 
-	// Check if credential contains the right amount of attribute values (Role and OU)
-	if len(cred.Attrs) != 2 {
-		return errors.Errorf("Credential contains %d attribute values, but expected 2", len(cred.Attrs))
+	// Check if credential contains the right amount of attribute values (Role, OU and revocation handle, if any)
+	if len(cred.Attrs) != len(attributeNames) {
+		return errors.Errorf("Credential contains %d attribute values, but expected %d", len(cred.Attrs), len(attributeNames))
 	}
 
 	// Check if credential contains the correct OU attribute value
@@ -135,25 +147,51 @@ func (msp *idemixmsp) Setup(conf1 *m.MSPConfig) error {
 		return errors.New("Credential does not contain the correct OU attribute value")
 	}
 
-	// Check if credential contains the correct OU attribute value
+	// Check if credential contains the correct Role attribute value
 	if !bytes.Equal(idemix.BigToBytes(FP256BN.NewBIGint(int(role.Role))), cred.Attrs[1]) {
 		return errors.New("Credential does not contain the correct Role attribute value")
 	}
 
-	// Verify that the credential is cryptographically valid
-	err = cred.Ver(sk, msp.ipk)
+	// The credential revocation information lets the signer prove that its credential is not revoked
+	if len(conf.Signer.CredentialRevocationInformation) == 0 && msp.revocationPK != nil {
+		return errors.New("the signer config contains no credential revocation information but the msp requires revocation")
+	}
+
+	userKey, err := msp.csp.KeyImport(conf.Signer.Sk, &bccsp.IdemixUserSecretKeyImportOpts{Temporary: true})
 	if err != nil {
-		return errors.Wrap(err, "Credential is not cryptographically valid")
+		return errors.WithMessage(err, "failed importing the user secret key")
 	}
 
-	// Create the cryptographic evidence that this identity is valid
-	proof, err := idemix.NewSignature(cred, sk, Nym, RandNym, ipk, discloseFlags, nil, rng)
+	nymKey, err := msp.csp.KeyDeriv(userKey, &bccsp.IdemixNymKeyDerivationOpts{Temporary: true, IssuerPK: msp.ipk})
 	if err != nil {
-		return errors.Wrap(err, "Failed to setup cryptographic proof of identity")
+		return errors.WithMessage(err, "failed deriving a pseudonym")
+	}
+	nymPK, err := nymKey.PublicKey()
+	if err != nil {
+		return errors.WithMessage(err, "failed getting the public part of the pseudonym")
+	}
+	nymBytes, err := nymPK.Bytes()
+	if err != nil {
+		return errors.WithMessage(err, "failed serializing the pseudonym")
+	}
+
+	// Create the cryptographic evidence that this identity is valid.
+	// The proof signs no message, as it only proves the possession of the credential.
+	// The signer verifies that the credential is cryptographically valid first.
+	proof, err := msp.csp.Sign(userKey, nil, &bccsp.IdemixSignerOpts{
+		Nym:        nymKey,
+		IssuerPK:   msp.ipk,
+		Credential: conf.Signer.Cred,
+		Attributes: msp.identityAttributes(ou, role),
+		RhIndex:    msp.rhIndex,
+		CRI:        conf.Signer.CredentialRevocationInformation,
+	})
+	if err != nil {
+		return errors.WithMessage(err, "Failed to setup cryptographic proof of identity")
 	}
 
 	// Set up default signer
-	msp.signer = &idemixSigningIdentity{newIdemixIdentity(msp, Nym, role, ou, proof), rng, cred, sk, RandNym}
+	msp.signer = &idemixSigningIdentity{newIdemixIdentity(msp, nymPK, nymBytes, role, ou, proof), userKey, nymKey}
 
 	return nil
 }
@@ -205,10 +243,14 @@ func (msp *idemixmsp) deserializeIdentityInternal(serializedID []byte) (Identity
 	if err != nil {
 		return nil, errors.Wrap(err, "could not deserialize a SerializedIdemixIdentity")
 	}
-	if serialized.NymX == nil || serialized.NymY == nil {
+	if len(serialized.NymX) != idemix.FieldBytes || len(serialized.NymY) != idemix.FieldBytes {
 		return nil, errors.Errorf("unable to deserialize idemix identity: pseudonym is invalid")
 	}
-	Nym := FP256BN.NewECPbigs(FP256BN.FromBytes(serialized.NymX), FP256BN.FromBytes(serialized.NymY))
+	nymBytes := append(append([]byte{}, serialized.NymX...), serialized.NymY...)
+	nymPK, err := msp.csp.KeyImport(nymBytes, &bccsp.IdemixNymPublicKeyImportOpts{Temporary: true})
+	if err != nil {
+		return nil, errors.WithMessage(err, "unable to deserialize idemix identity: failed importing the pseudonym")
+	}
 
 	ou := &m.OrganizationUnit{}
 	err = proto.Unmarshal(serialized.OU, ou)
@@ -221,13 +263,7 @@ func (msp *idemixmsp) deserializeIdentityInternal(serializedID []byte) (Identity
 		return nil, errors.Wrap(err, "cannot deserialize the role of the identity")
 	}
 
-	proof := &idemix.Signature{}
-	err = proto.Unmarshal(serialized.Proof, proof)
-	if err != nil {
-		return nil, errors.Wrap(err, "cannot deserialize the proof of the identity")
-	}
-
-	return newIdemixIdentity(msp, Nym, role, ou, proof), nil
+	return newIdemixIdentity(msp, nymPK, nymBytes, role, ou, serialized.Proof), nil
 
 }
 
@@ -250,10 +286,57 @@ func (msp *idemixmsp) Validate(id Identity) error {
 }
 
 func (id *idemixidentity) verifyProof() error {
-	ouBytes := []byte(id.OU.OrganizationalUnitIdentifier)
-	attributeValues := []*FP256BN.BIG{idemix.HashModOrder(ouBytes), FP256BN.NewBIGint(int(id.Role.Role))}
+	valid, err := id.msp.csp.Verify(id.msp.ipk, id.associationProof, nil, &bccsp.IdemixSignerOpts{
+		Attributes:          id.msp.identityAttributes(id.OU, id.Role),
+		RhIndex:             id.msp.rhIndex,
+		RevocationPublicKey: id.msp.revocationPK,
+		Epoch:               id.msp.epoch,
+	})
+	if err != nil {
+		return err
+	}
+	if !valid {
+		return errors.New("the proof of the identity is not valid")
+	}
+	return nil
+}
+
+// identityAttributes returns the attributes disclosed by the association proof
+// of an identity: the OU and the role. The revocation handle is never disclosed.
+func (msp *idemixmsp) identityAttributes(ou *m.OrganizationUnit, role *m.MSPRole) []bccsp.IdemixAttribute {
+	attrs := []bccsp.IdemixAttribute{
+		{Type: bccsp.IdemixBytesAttribute, Value: []byte(ou.OrganizationalUnitIdentifier)},
+		{Type: bccsp.IdemixIntAttribute, Value: int(role.Role)},
+	}
+	if msp.rhIndex != noRevocationHandle {
+		attrs = append(attrs, bccsp.IdemixAttribute{Type: bccsp.IdemixHiddenAttribute})
+	}
+	return attrs
+}
+
+// isLegacyIssuerPublicKey returns whether the issuer public key predates
+// revocation, having only the attributes OU and Role
+func isLegacyIssuerPublicKey(raw []byte) bool {
+	ipk := &idemix.IssuerPublicKey{}
+	if err := proto.Unmarshal(raw, ipk); err != nil {
+		return false
+	}
+	return len(ipk.AttributeNames) == 2 &&
+		ipk.AttributeNames[0] == AttributeNameOU &&
+		ipk.AttributeNames[1] == AttributeNameRole
+}
 
-	return id.associationProof.Ver(discloseFlags, id.msp.ipk, nil, attributeValues)
+// importRevocationPublicKey imports the PEM encoded long term public key of the revocation authority
+func (msp *idemixmsp) importRevocationPublicKey(raw []byte) (bccsp.Key, error) {
+	block, _ := pem.Decode(raw)
+	if block == nil {
+		return nil, errors.New("failed to decode revocation public key")
+	}
+	pk, err := msp.csp.KeyImport(block.Bytes, &bccsp.ECDSAPKIXPublicKeyImportOpts{Temporary: true})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to import revocation public key")
+	}
+	return pk, nil
 }
 
 func (msp *idemixmsp) SatisfiesPrincipal(id Identity, principal *m.MSPPrincipal) error {
@@ -310,7 +393,9 @@ func (msp *idemixmsp) SatisfiesPrincipal(id Identity, principal *m.MSPPrincipal)
 		}
 		return errors.Errorf("the identities do not match")
 
-	case m.MSPPrincipal_ORGANIZATION_UNIT:
+	case m.MSPPrincipal_ORGANIZATION_UNIT, m.MSPPrincipal_ORGANIZATION_UNIT_NAME:
+		// idemix identities carry no certifiers, so both principal types
+		// are matched by the name of the unit only
 		ou := &m.OrganizationUnit{}
 		err := proto.Unmarshal(principal.Principal, ou)
 		if err != nil {
@@ -365,22 +450,26 @@ func (msp *idemixmsp) GetTLSIntermediateCerts() [][]byte {
 }
 
 type idemixidentity struct {
-	Nym  *FP256BN.ECP
-	msp  *idemixmsp
-	id   *IdentityIdentifier
-	Role *m.MSPRole
-	OU   *m.OrganizationUnit
+	// Nym is the public part of the pseudonym of this identity
+	Nym      bccsp.Key
+	nymBytes []byte
+	msp      *idemixmsp
+	id       *IdentityIdentifier
+	Role     *m.MSPRole
+	OU       *m.OrganizationUnit
 	// associationProof contains cryptographic proof that this identity
 	// belongs to the MSP id.msp, i.e., it proves that the pseudonym
 	// is constructed from a secret key on which the CA issued a credential.
-	associationProof *idemix.Signature
+	associationProof []byte
 }
 
-func newIdemixIdentity(msp *idemixmsp, nym *FP256BN.ECP, role *m.MSPRole, ou *m.OrganizationUnit, proof *idemix.Signature) *idemixidentity {
+func newIdemixIdentity(msp *idemixmsp, nym bccsp.Key, nymBytes []byte, role *m.MSPRole, ou *m.OrganizationUnit, proof []byte) *idemixidentity {
 	id := &idemixidentity{}
 	id.Nym = nym
+	id.nymBytes = nymBytes
 	id.msp = msp
-	id.id = &IdentityIdentifier{Mspid: msp.name, Id: proto.MarshalTextString(idemix.EcpToProto(nym))}
+	nymProto := &idemix.ECP{X: nymBytes[:idemix.FieldBytes], Y: nymBytes[idemix.FieldBytes:]}
+	id.id = &IdentityIdentifier{Mspid: msp.name, Id: proto.MarshalTextString(nymProto)}
 	id.Role = role
 	id.OU = ou
 	id.associationProof = proof
@@ -388,8 +477,8 @@ func newIdemixIdentity(msp *idemixmsp, nym *FP256BN.ECP, role *m.MSPRole, ou *m.
 }
 
 func (id *idemixidentity) ExpiresAt() time.Time {
-	// Idemix MSP currently does not use expiration dates or revocation,
-	// so we return the zero time to indicate this.
+	// Idemix MSP does not use expiration dates: credentials are revoked
+	// through the revocation authority instead, so we return the zero time to indicate this.
 	return time.Time{}
 }
 
@@ -404,7 +493,7 @@ func (id *idemixidentity) GetMSPIdentifier() string {
 
 func (id *idemixidentity) GetOrganizationalUnits() []*OUIdentifier {
 	// we use the (serialized) public key of this MSP as the CertifiersIdentifier
-	certifiersIdentifier, err := proto.Marshal(id.msp.ipk)
+	certifiersIdentifier, err := id.msp.ipk.Bytes()
 	if err != nil {
 		mspIdentityLogger.Errorf("Failed to marshal ipk in GetOrganizationalUnits: %s", err)
 		return nil
@@ -422,13 +511,14 @@ func (id *idemixidentity) Verify(msg []byte, sig []byte) error {
 		mspIdentityLogger.Debugf("Verify Idemix sig: sig = %s", hex.Dump(sig))
 	}
 
-	signature := new(idemix.NymSignature)
-	err := proto.Unmarshal(sig, signature)
+	valid, err := id.msp.csp.Verify(id.Nym, sig, msg, &bccsp.IdemixNymSignerOpts{IssuerPK: id.msp.ipk})
 	if err != nil {
-		return errors.Wrap(err, "error unmarshalling signature")
+		return err
 	}
-
-	return signature.Ver(id.Nym, id.msp.ipk, msg)
+	if !valid {
+		return errors.New("the signature is not valid")
+	}
+	return nil
 }
 
 func (id *idemixidentity) SatisfiesPrincipal(principal *m.MSPPrincipal) error {
@@ -437,8 +527,8 @@ func (id *idemixidentity) SatisfiesPrincipal(principal *m.MSPPrincipal) error {
 
 func (id *idemixidentity) Serialize() ([]byte, error) {
 	serialized := &m.SerializedIdemixIdentity{}
-	serialized.NymX = idemix.BigToBytes(id.Nym.GetX())
-	serialized.NymY = idemix.BigToBytes(id.Nym.GetY())
+	serialized.NymX = id.nymBytes[:idemix.FieldBytes]
+	serialized.NymY = id.nymBytes[idemix.FieldBytes:]
 	ouBytes, err := proto.Marshal(id.OU)
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not marshal OU of identity %s", id.id)
@@ -451,11 +541,7 @@ func (id *idemixidentity) Serialize() ([]byte, error) {
 
 	serialized.OU = ouBytes
 	serialized.Role = roleBytes
-
-	serialized.Proof, err = proto.Marshal(id.associationProof)
-	if err != nil {
-		return nil, err
-	}
+	serialized.Proof = id.associationProof
 
 	idemixIDBytes, err := proto.Marshal(serialized)
 	if err != nil {
@@ -473,19 +559,15 @@ func (id *idemixidentity) Serialize() ([]byte, error) {
 
 type idemixSigningIdentity struct {
 	*idemixidentity
-	rng     *amcl.RAND
-	Cred    *idemix.Credential
-	Sk      *FP256BN.BIG
-	RandNym *FP256BN.BIG
+	// UserKey is the secret key of the user
+	UserKey bccsp.Key
+	// NymKey is the pseudonym used by this signing identity
+	NymKey bccsp.Key
 }
 
 func (id *idemixSigningIdentity) Sign(msg []byte) ([]byte, error) {
 	mspLogger.Debugf("Idemix identity %s is signing", id.GetIdentifier())
-	sig, err := idemix.NewNymSignature(id.Sk, id.Nym, id.RandNym, id.msp.ipk, msg, id.rng)
-	if err != nil {
-		return nil, err
-	}
-	return proto.Marshal(sig)
+	return id.msp.csp.Sign(id.UserKey, msg, &bccsp.IdemixNymSignerOpts{Nym: id.NymKey, IssuerPK: id.msp.ipk})
 }
 
 func (id *idemixSigningIdentity) GetPublicVersion() Identity {
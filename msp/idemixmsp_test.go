@@ -139,6 +139,104 @@ func TestSigning(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestIdemixRevocation(t *testing.T) {
+	msp1, err := setup("testdata/idemix/MSP1OU1", "MSP1")
+	assert.NoError(t, err)
+
+	id, err := getDefaultSigner(msp1)
+	assert.NoError(t, err)
+	idBytes, err := id.Serialize()
+	assert.NoError(t, err)
+
+	conf, err := GetIdemixMspConfig("testdata/idemix/MSP1Verifier", "MSP1")
+	assert.NoError(t, err)
+	idemixconfig := &msp.IdemixMSPConfig{}
+	err = proto.Unmarshal(conf.Config, idemixconfig)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, idemixconfig.RevocationPk)
+
+	// A verifier in the current epoch accepts the identity
+	verMsp, err := newIdemixMsp()
+	assert.NoError(t, err)
+	assert.NoError(t, verMsp.Setup(conf))
+	verId, err := verMsp.DeserializeIdentity(idBytes)
+	assert.NoError(t, err)
+	assert.NoError(t, verMsp.Validate(verId))
+
+	// A verifier in a later epoch rejects the identity, whose non-revocation proof is for the current epoch
+	idemixconfig.Epoch++
+	conf.Config, err = proto.Marshal(idemixconfig)
+	assert.NoError(t, err)
+	verMsp, err = newIdemixMsp()
+	assert.NoError(t, err)
+	assert.NoError(t, verMsp.Setup(conf))
+	verId, err = verMsp.DeserializeIdentity(idBytes)
+	assert.NoError(t, err)
+	err = verMsp.Validate(verId)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "non-revocation proof is for epoch")
+
+	// A verifier without revocation public key does not check revocation
+	idemixconfig.RevocationPk = nil
+	conf.Config, err = proto.Marshal(idemixconfig)
+	assert.NoError(t, err)
+	verMsp, err = newIdemixMsp()
+	assert.NoError(t, err)
+	assert.NoError(t, verMsp.Setup(conf))
+	verId, err = verMsp.DeserializeIdentity(idBytes)
+	assert.NoError(t, err)
+	assert.NoError(t, verMsp.Validate(verId))
+
+	// Setup fails with an invalid revocation public key
+	idemixconfig.RevocationPk = []byte("barf")
+	conf.Config, err = proto.Marshal(idemixconfig)
+	assert.NoError(t, err)
+	verMsp, err = newIdemixMsp()
+	assert.NoError(t, err)
+	assert.Error(t, verMsp.Setup(conf))
+}
+
+func TestIdemixLegacyIssuerKey(t *testing.T) {
+	// issuer keys which predate revocation only have the attributes OU and Role
+	msp1, err := setup("testdata/idemix/MSP1OU1Legacy", "MSP1OU1")
+	assert.NoError(t, err)
+
+	id, err := getDefaultSigner(msp1)
+	assert.NoError(t, err)
+	msg := []byte("TestMessage")
+	sig, err := id.Sign(msg)
+	assert.NoError(t, err)
+	assert.NoError(t, id.Verify(msg, sig))
+
+	// verifiers with the same issuer key accept the identity
+	idBytes, err := id.Serialize()
+	assert.NoError(t, err)
+	verMsp, err := setup("testdata/idemix/MSP1Legacy", "MSP1OU1")
+	assert.NoError(t, err)
+	verID, err := verMsp.DeserializeIdentity(idBytes)
+	assert.NoError(t, err)
+	assert.NoError(t, verMsp.Validate(verID))
+	assert.NoError(t, verID.Verify(msg, sig))
+
+	// revocation cannot be required with such keys
+	conf, err := GetIdemixMspConfig("testdata/idemix/MSP1OU1Legacy", "MSP1OU1")
+	assert.NoError(t, err)
+	idemixconfig := &msp.IdemixMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(conf.Config, idemixconfig))
+	revConf, err := GetIdemixMspConfig("testdata/idemix/MSP1Verifier", "MSP1")
+	assert.NoError(t, err)
+	revIdemixConfig := &msp.IdemixMSPConfig{}
+	assert.NoError(t, proto.Unmarshal(revConf.Config, revIdemixConfig))
+	idemixconfig.RevocationPk = revIdemixConfig.RevocationPk
+	conf.Config, err = proto.Marshal(idemixconfig)
+	assert.NoError(t, err)
+	verMsp, err = newIdemixMsp()
+	assert.NoError(t, err)
+	err = verMsp.Setup(conf)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no revocation handle attribute")
+}
+
 func TestSigningBad(t *testing.T) {
 	msp, err := setup("testdata/idemix/MSP1OU1", "MSP1OU1")
 	assert.NoError(t, err)
@@ -17,6 +17,7 @@ limitations under the License.
 package mgmt
 
 import (
+	"bytes"
 	"reflect"
 	"sync"
 
@@ -25,6 +26,7 @@ import (
 	"github.com/hyperledger/fabric/core/config"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/msp/cache"
+	mspproto "github.com/hyperledger/fabric/protos/msp"
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 )
@@ -35,12 +37,10 @@ func LoadLocalMspWithType(dir string, bccspConfig *factory.FactoryOpts, mspID, m
 		return errors.New("the local MSP must have an ID")
 	}
 
-	conf, err := msp.GetLocalMspConfigWithType(dir, bccspConfig, mspID, mspType)
-	if err != nil {
-		return err
+	loadConfig := func() (*mspproto.MSPConfig, error) {
+		return msp.GetLocalMspConfigWithType(dir, bccspConfig, mspID, mspType)
 	}
-
-	return GetLocalMSP().Setup(conf)
+	return setupLocalMsp(loadConfig)
 }
 
 // LoadLocalMsp loads the local MSP from the specified directory
@@ -50,12 +50,91 @@ func LoadLocalMsp(dir string, bccspConfig *factory.FactoryOpts, mspID string) er
 	}
 	//获取msp配置文件
 	//GetLocalMspConfig 方法位于:fabric-analyse/msp/configbuilder.go
-	conf, err := msp.GetLocalMspConfig(dir, bccspConfig, mspID)
+	loadConfig := func() (*mspproto.MSPConfig, error) {
+		return msp.GetLocalMspConfig(dir, bccspConfig, mspID)
+	}
+	return setupLocalMsp(loadConfig)
+}
+
+func setupLocalMsp(loadConfig func() (*mspproto.MSPConfig, error)) error {
+	conf, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	err = GetLocalMSP().Setup(conf)
 	if err != nil {
 		return err
 	}
 
-	return GetLocalMSP().Setup(conf)
+	m.Lock()
+	localMspConfigLoader = loadConfig
+	m.Unlock()
+	return nil
+}
+
+// ReloadLocalMsp sets up a new local MSP from the directory the local MSP was
+// loaded from, and swaps it in for the current one. The current local MSP stays
+// in use unless the new one is set up successfully and its default signing
+// identity is valid
+func ReloadLocalMsp() error {
+	return reloadLocalMsp(nil)
+}
+
+// ReloadLocalMspWithIdentity reloads the local MSP like ReloadLocalMsp does, but
+// keeps the current one if the signing identity of the new one is not the given
+// serialized identity, which components that cannot change their identity at
+// runtime have advertised
+func ReloadLocalMspWithIdentity(serializedIdentity []byte) error {
+	return reloadLocalMsp(serializedIdentity)
+}
+
+func reloadLocalMsp(serializedIdentity []byte) error {
+	m.Lock()
+	loadConfig := localMspConfigLoader
+	m.Unlock()
+	if loadConfig == nil {
+		return errors.New("the local MSP was not loaded from a directory")
+	}
+
+	conf, err := loadConfig()
+	if err != nil {
+		return errors.WithMessage(err, "failed loading the local MSP configuration")
+	}
+
+	newMsp, err := newLocalMsp()
+	if err != nil {
+		return err
+	}
+	err = newMsp.Setup(conf)
+	if err != nil {
+		return errors.WithMessage(err, "failed setting up the local MSP")
+	}
+
+	signer, err := newMsp.GetDefaultSigningIdentity()
+	if err != nil {
+		return errors.WithMessage(err, "failed getting the signing identity of the local MSP")
+	}
+	err = signer.Validate()
+	if err != nil {
+		return errors.WithMessage(err, "the signing identity of the local MSP is not valid")
+	}
+	if serializedIdentity != nil {
+		newIdentity, err := signer.Serialize()
+		if err != nil {
+			return errors.WithMessage(err, "failed serializing the signing identity of the local MSP")
+		}
+		if !bytes.Equal(newIdentity, serializedIdentity) {
+			return errors.New("the signing certificate of the local MSP changed, which requires a restart")
+		}
+	}
+
+	m.Lock()
+	localMsp = newMsp
+	m.Unlock()
+
+	mspLogger.Infof("Reloaded local MSP %s, whose signing identity expires at %s", signer.GetMSPIdentifier(), signer.ExpiresAt())
+	return nil
 }
 
 // Loads the development local MSP for use in testing.  Not valid for production/runtime context
@@ -75,6 +154,7 @@ func LoadDevMsp() error {
 
 var m sync.Mutex
 var localMsp msp.MSP
+var localMspConfigLoader func() (*mspproto.MSPConfig, error)
 var mspMap map[string]msp.MSPManager = make(map[string]msp.MSPManager)
 var mspLogger = flogging.MustGetLogger("msp")
 
@@ -156,26 +236,6 @@ func GetLocalMSP() msp.MSP {
 	var lclMsp msp.MSP
 	var created bool = false
 	{
-		//默认会使用bccspMSP
-		mspType := viper.GetString("peer.localMspType")
-		if mspType == "" {
-			mspType = msp.ProviderTypeToString(msp.FABRIC)
-		}
-
-		//目前msp基础类型为FABRIC和IDEMIX,可以在这两种类型基础上添加新类型
-		//这两种类型定义在msp/msp.go中,其中msp.FABRIC = bccsp 和 msp.IDEMIX = idemix
-		
-		var newOpts msp.NewOpts
-		switch mspType {
-		case msp.ProviderTypeToString(msp.FABRIC):
-			//结构体位于:msp/factory.go
-			newOpts = &msp.BCCSPNewOpts{NewBaseOpts: msp.NewBaseOpts{Version: msp.MSPv1_0}}
-		case msp.ProviderTypeToString(msp.IDEMIX):
-			newOpts = &msp.IdemixNewOpts{msp.NewBaseOpts{Version: msp.MSPv1_1}}
-		default:
-			panic("msp type " + mspType + " unknown")
-		}
-
 		m.Lock()
 		defer m.Unlock()
 
@@ -184,22 +244,10 @@ func GetLocalMSP() msp.MSP {
 			var err error
 			created = true
 
-			mspInst, err := msp.New(newOpts)
+			lclMsp, err = newLocalMsp()
 			if err != nil {
 				mspLogger.Fatalf("Failed to initialize local MSP, received err %+v", err)
 			}
-
-			switch mspType {
-			case msp.ProviderTypeToString(msp.FABRIC):
-				lclMsp, err = cache.New(mspInst)
-				if err != nil {
-					mspLogger.Fatalf("Failed to initialize local MSP, received err %+v", err)
-				}
-			case msp.ProviderTypeToString(msp.IDEMIX):
-				lclMsp = mspInst
-			default:
-				panic("msp type " + mspType + " unknown")
-			}
 			localMsp = lclMsp
 		}
 	}
@@ -213,6 +261,43 @@ func GetLocalMSP() msp.MSP {
 	return lclMsp
 }
 
+// newLocalMsp creates a local MSP, which is yet to be set up, of the configured type
+func newLocalMsp() (msp.MSP, error) {
+	//默认会使用bccspMSP
+	mspType := viper.GetString("peer.localMspType")
+	if mspType == "" {
+		mspType = msp.ProviderTypeToString(msp.FABRIC)
+	}
+
+	//目前msp基础类型为FABRIC和IDEMIX,可以在这两种类型基础上添加新类型
+	//这两种类型定义在msp/msp.go中,其中msp.FABRIC = bccsp 和 msp.IDEMIX = idemix
+
+	var newOpts msp.NewOpts
+	switch mspType {
+	case msp.ProviderTypeToString(msp.FABRIC):
+		//结构体位于:msp/factory.go
+		newOpts = &msp.BCCSPNewOpts{NewBaseOpts: msp.NewBaseOpts{Version: msp.MSPv1_0}}
+	case msp.ProviderTypeToString(msp.IDEMIX):
+		newOpts = &msp.IdemixNewOpts{msp.NewBaseOpts{Version: msp.MSPv1_1}}
+	default:
+		panic("msp type " + mspType + " unknown")
+	}
+
+	mspInst, err := msp.New(newOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	switch mspType {
+	case msp.ProviderTypeToString(msp.FABRIC):
+		return cache.New(mspInst)
+	case msp.ProviderTypeToString(msp.IDEMIX):
+		return mspInst, nil
+	default:
+		panic("msp type " + mspType + " unknown")
+	}
+}
+
 // GetIdentityDeserializer returns the IdentityDeserializer for the given chain
 func GetIdentityDeserializer(chainID string) msp.IdentityDeserializer {
 	if chainID == "" {
@@ -17,6 +17,9 @@ limitations under the License.
 package mgmt
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hyperledger/fabric/common/util"
@@ -122,6 +125,66 @@ func TestNewMSPMgmtMgr(t *testing.T) {
 	assert.NotNil(t, idBack, "deserialized identity should not have been nil")
 }
 
+func TestReloadLocalMsp(t *testing.T) {
+	devMspDir, err := config.GetDevMspDir()
+	assert.NoError(t, err)
+	dir, err := ioutil.TempDir("", "msp")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// copy the development MSP so that its signing certificate can be changed
+	err = filepath.Walk(devMspDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, path[len(devMspDir):])
+		if info.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(target, contents, 0644)
+	})
+	assert.NoError(t, err)
+
+	assert.NoError(t, LoadLocalMsp(dir, nil, "DEFAULT"))
+	loaded := GetLocalMSP()
+
+	assert.NoError(t, ReloadLocalMsp())
+	reloaded := GetLocalMSP()
+	assert.True(t, loaded != reloaded, "a new local MSP should have been swapped in")
+	loadedID, err := loaded.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	reloadedID, err := reloaded.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+	assert.Equal(t, loadedID.GetIdentifier(), reloadedID.GetIdentifier())
+
+	// the local MSP is reloaded as long as its signing certificate is unchanged
+	serializedID, err := reloadedID.Serialize()
+	assert.NoError(t, err)
+	assert.NoError(t, ReloadLocalMspWithIdentity(serializedID))
+	assert.True(t, reloaded != GetLocalMSP(), "a new local MSP should have been swapped in")
+	reloaded = GetLocalMSP()
+	err = ReloadLocalMspWithIdentity([]byte("another identity"))
+	assert.EqualError(t, err, "the signing certificate of the local MSP changed, which requires a restart")
+	assert.True(t, reloaded == GetLocalMSP(), "the local MSP should not have been replaced")
+
+	signcerts, err := ioutil.ReadDir(filepath.Join(dir, "signcerts"))
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "signcerts", signcerts[0].Name()), []byte("garbage"), 0644))
+	err = ReloadLocalMsp()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed setting up the local MSP")
+	assert.True(t, reloaded == GetLocalMSP(), "the local MSP should not have been replaced")
+
+	m.Lock()
+	localMspConfigLoader = nil
+	m.Unlock()
+	assert.EqualError(t, ReloadLocalMsp(), "the local MSP was not loaded from a directory")
+}
+
 func LoadMSPSetupForTesting() error {
 	dir, err := config.GetDevMspDir()
 	if err != nil {
@@ -710,6 +710,49 @@ func TestOUPolicyPrincipalBadPath(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestOUNamePolicyPrincipal(t *testing.T) {
+	id, err := localMsp.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+
+	// the certifiers of the unit are ignored, unlike those of ORGANIZATION_UNIT principals
+	for _, ou := range []*msp.OrganizationUnit{
+		{OrganizationalUnitIdentifier: "COP", MspIdentifier: "DEFAULT"},
+		{OrganizationalUnitIdentifier: "COP", MspIdentifier: "DEFAULT", CertifiersIdentifier: []byte{0, 1, 2, 3, 4}},
+	} {
+		bytes, err := proto.Marshal(ou)
+		assert.NoError(t, err)
+
+		principal := &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_ORGANIZATION_UNIT_NAME,
+			Principal:               bytes,
+		}
+		err = id.SatisfiesPrincipal(principal)
+		assert.NoError(t, err)
+	}
+
+	for _, ou := range []*msp.OrganizationUnit{
+		{OrganizationalUnitIdentifier: "COPbarf", MspIdentifier: "DEFAULT"},
+		{OrganizationalUnitIdentifier: "COP", MspIdentifier: "DEFAULTbarfbarf"},
+	} {
+		bytes, err := proto.Marshal(ou)
+		assert.NoError(t, err)
+
+		principal := &msp.MSPPrincipal{
+			PrincipalClassification: msp.MSPPrincipal_ORGANIZATION_UNIT_NAME,
+			Principal:               bytes,
+		}
+		err = id.SatisfiesPrincipal(principal)
+		assert.Error(t, err)
+	}
+
+	principal := &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_ORGANIZATION_UNIT_NAME,
+		Principal:               []byte("barf"),
+	}
+	err = id.SatisfiesPrincipal(principal)
+	assert.Error(t, err)
+}
+
 func TestPolicyPrincipalBogusType(t *testing.T) {
 	id, err := localMsp.GetDefaultSigningIdentity()
 	assert.NoError(t, err)
@@ -862,6 +905,42 @@ func TestIdentityPolicyPrincipalBadBytes(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestIdentityHashPolicyPrincipal(t *testing.T) {
+	id, err := localMsp.GetDefaultSigningIdentity()
+	assert.NoError(t, err)
+
+	hash, err := hex.DecodeString(id.GetIdentifier().Id)
+	assert.NoError(t, err)
+
+	principalBytes, err := proto.Marshal(&msp.IdentityHash{MspIdentifier: "DEFAULT", Hash: hash})
+	assert.NoError(t, err)
+
+	principal := &msp.MSPPrincipal{
+		PrincipalClassification: msp.MSPPrincipal_IDENTITY_HASH,
+		Principal:               principalBytes}
+
+	err = id.SatisfiesPrincipal(principal)
+	assert.NoError(t, err)
+
+	// a different hash does not match
+	principalBytes, err = proto.Marshal(&msp.IdentityHash{MspIdentifier: "DEFAULT", Hash: []byte{0, 1, 2, 3, 4}})
+	assert.NoError(t, err)
+	principal.Principal = principalBytes
+	err = id.SatisfiesPrincipal(principal)
+	assert.Error(t, err)
+
+	// nor does a different MSP
+	principalBytes, err = proto.Marshal(&msp.IdentityHash{MspIdentifier: "OTHER", Hash: hash})
+	assert.NoError(t, err)
+	principal.Principal = principalBytes
+	err = id.SatisfiesPrincipal(principal)
+	assert.Error(t, err)
+
+	principal.Principal = []byte("barf")
+	err = id.SatisfiesPrincipal(principal)
+	assert.Error(t, err)
+}
+
 func TestMSPOus(t *testing.T) {
 	// Set the OUIdentifiers
 	backup := localMsp.(*bccspmsp).ouIdentifiers
@@ -8,6 +8,7 @@ package msp
 
 import (
 	"bytes"
+	"crypto/ed25519"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/hex"
@@ -18,6 +19,7 @@ import (
 	"github.com/hyperledger/fabric/bccsp"
 	"github.com/hyperledger/fabric/bccsp/factory"
 	"github.com/hyperledger/fabric/bccsp/signer"
+	"github.com/hyperledger/fabric/bccsp/utils"
 	m "github.com/hyperledger/fabric/protos/msp"
 	"github.com/pkg/errors"
 )
@@ -128,7 +130,7 @@ func (msp *bccspmsp) getCertFromPem(idBytes []byte) (*x509.Certificate, error) {
 
 	// get a cert
 	var cert *x509.Certificate
-	cert, err := x509.ParseCertificate(pemCert.Bytes)
+	cert, err := utils.DERToX509Certificate(pemCert.Bytes)
 	if err != nil {
 		return nil, errors.Wrap(err, "getCertFromPem error: failed to parse x509 cert")
 	}
@@ -175,9 +177,13 @@ func (msp *bccspmsp) getSigningIdentityFromConf(sidInfo *m.SigningIdentityInfo)
 		}
 
 		pemKey, _ := pem.Decode(sidInfo.PrivateSigner.KeyMaterial)
-		privKey, err = msp.bccsp.KeyImport(pemKey.Bytes, &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true})
+		var importOpts bccsp.KeyImportOpts = &bccsp.ECDSAPrivateKeyImportOpts{Temporary: true}
+		if _, isEd25519 := idPub.(*identity).cert.PublicKey.(ed25519.PublicKey); isEd25519 {
+			importOpts = &bccsp.ED25519PrivateKeyImportOpts{Temporary: true}
+		}
+		privKey, err = msp.bccsp.KeyImport(pemKey.Bytes, importOpts)
 		if err != nil {
-			return nil, errors.WithMessage(err, "getIdentityFromBytes error: Failed to import EC private key")
+			return nil, errors.WithMessage(err, "getIdentityFromBytes error: Failed to import private key")
 		}
 	}
 
@@ -345,7 +351,7 @@ func (msp *bccspmsp) deserializeIdentityInternal(serializedIdentity []byte) (Ide
 	if bl == nil {
 		return nil, errors.New("could not decode the PEM structure")
 	}
-	cert, err := x509.ParseCertificate(bl.Bytes)
+	cert, err := utils.DERToX509Certificate(bl.Bytes)
 	if err != nil {
 		return nil, errors.Wrap(err, "parseCertificate failed")
 	}
@@ -432,6 +438,24 @@ func (msp *bccspmsp) SatisfiesPrincipal(id Identity, principal *m.MSPPrincipal)
 		}
 
 		return errors.New("The identities do not match")
+	case m.MSPPrincipal_IDENTITY_HASH:
+		// Principal contains the hash of the certificate of the identity,
+		// which is what the identifier of the identity is computed from
+		idHash := &m.IdentityHash{}
+		err := proto.Unmarshal(principal.Principal, idHash)
+		if err != nil {
+			return errors.Wrap(err, "could not unmarshal IdentityHash from principal")
+		}
+
+		if idHash.MspIdentifier != msp.name {
+			return errors.Errorf("the identity is a member of a different MSP (expected %s, got %s)", idHash.MspIdentifier, id.GetMSPIdentifier())
+		}
+
+		if id.GetIdentifier().Id != hex.EncodeToString(idHash.Hash) {
+			return errors.New("The identities do not match")
+		}
+
+		return msp.Validate(id)
 	case m.MSPPrincipal_ORGANIZATION_UNIT:
 		// Principal contains the OrganizationUnit
 		OU := &m.OrganizationUnit{}
@@ -462,6 +486,31 @@ func (msp *bccspmsp) SatisfiesPrincipal(id Identity, principal *m.MSPPrincipal)
 		}
 
 		// if we are here, no match was found, return an error
+		return errors.New("The identities do not match")
+	case m.MSPPrincipal_ORGANIZATION_UNIT_NAME:
+		// Principal contains the OrganizationUnit, whose certifiers are ignored
+		OU := &m.OrganizationUnit{}
+		err := proto.Unmarshal(principal.Principal, OU)
+		if err != nil {
+			return errors.Wrap(err, "could not unmarshal OrganizationUnit from principal")
+		}
+
+		if OU.MspIdentifier != msp.name {
+			return errors.Errorf("the identity is a member of a different MSP (expected %s, got %s)", OU.MspIdentifier, id.GetMSPIdentifier())
+		}
+
+		err = msp.Validate(id)
+		if err != nil {
+			return err
+		}
+
+		// any of this identity's OUs carrying the requested name is a match
+		for _, ou := range id.GetOrganizationalUnits() {
+			if ou.OrganizationalUnitIdentifier == OU.OrganizationalUnitIdentifier {
+				return nil
+			}
+		}
+
 		return errors.New("The identities do not match")
 	default:
 		return errors.Errorf("invalid principal type %d", int32(principal.PrincipalClassification))
@@ -623,6 +672,6 @@ func (msp *bccspmsp) IsWellFormed(identity *m.SerializedIdentity) error {
 	if bl.Type != "CERTIFICATE" && bl.Type != "" {
 		return errors.Errorf("pem type is %s, should be 'CERTIFICATE' or missing", bl.Type)
 	}
-	_, err := x509.ParseCertificate(bl.Bytes)
+	_, err := utils.DERToX509Certificate(bl.Bytes)
 	return err
 }
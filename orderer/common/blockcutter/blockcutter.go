@@ -7,8 +7,13 @@ SPDX-License-Identifier: Apache-2.0
 package blockcutter
 
 import (
+	"context"
+	"time"
+
 	"github.com/hyperledger/fabric/common/channelconfig"
+	"github.com/hyperledger/fabric/common/tracing"
 	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/utils"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/op/go-logging"
@@ -38,6 +43,8 @@ type receiver struct {
 	sharedConfigManager   channelconfig.Orderer
 	pendingBatch          []*cb.Envelope
 	pendingBatchSizeBytes uint32
+	// pendingEnqueued is when each message of the pending batch was enqueued
+	pendingEnqueued []time.Time
 }
 
 // NewReceiverImpl creates a Receiver implementation based on the given configtxorderer manager
@@ -96,6 +103,7 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 
 	logger.Debugf("Enqueuing message into batch")
 	r.pendingBatch = append(r.pendingBatch, msg)
+	r.pendingEnqueued = append(r.pendingEnqueued, time.Now())
 	r.pendingBatchSizeBytes += messageSizeBytes
 	pending = true
 
@@ -112,11 +120,45 @@ func (r *receiver) Ordered(msg *cb.Envelope) (messageBatches [][]*cb.Envelope, p
 // Cut returns the current batch and starts a new one
 func (r *receiver) Cut() []*cb.Envelope {
 	batch := r.pendingBatch
+	traceBatch(batch, r.pendingBatchSizeBytes, r.pendingEnqueued)
 	r.pendingBatch = nil
+	r.pendingEnqueued = nil
 	r.pendingBatchSizeBytes = 0
 	return batch
 }
 
+// traceBatch times how long the messages of a batch waited to be cut into a block.
+// A batch gathers the messages of many traces, so its span is the root of a trace of
+// its own, with a child span per message tagged with the transaction ID of the message
+func traceBatch(batch []*cb.Envelope, sizeBytes uint32, enqueued []time.Time) {
+	if len(batch) == 0 || !tracing.Enabled() {
+		return
+	}
+	chdrs := make([]*cb.ChannelHeader, len(batch))
+	var channelID string
+	for i, msg := range batch {
+		chdr, err := utils.ChannelHeader(msg)
+		if err != nil {
+			continue
+		}
+		chdrs[i] = chdr
+		channelID = chdr.ChannelId
+	}
+	span, ctx := tracing.StartSpan(context.Background(), "blockcutter.Cut", tracing.StartTime(enqueued[0]),
+		tracing.Tag("channel", channelID),
+		tracing.Tag("message_count", len(batch)),
+		tracing.Tag("size_bytes", sizeBytes))
+	for i, chdr := range chdrs {
+		opts := []tracing.StartOption{tracing.StartTime(enqueued[i]), tracing.Tag("message_number", i)}
+		if chdr != nil && chdr.TxId != "" {
+			opts = append(opts, tracing.Tag("txid", chdr.TxId))
+		}
+		msgSpan, _ := tracing.StartSpan(ctx, "blockcutter.Message", opts...)
+		msgSpan.Finish()
+	}
+	span.Finish()
+}
+
 func messageSizeBytes(message *cb.Envelope) uint32 {
 	return uint32(len(message.Payload) + len(message.Signature))
 }
@@ -20,8 +20,10 @@ import (
 	"testing"
 
 	mockconfig "github.com/hyperledger/fabric/common/mocks/config"
+	"github.com/hyperledger/fabric/common/tracing"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
+	"github.com/hyperledger/fabric/protos/utils"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/stretchr/testify/assert"
@@ -105,3 +107,40 @@ func TestBatchSizePreferredMaxBytesOverflowNoPending(t *testing.T) {
 		assert.Len(t, batch, 1, "Should have had one normal tx in batch %d", i)
 	}
 }
+
+func TestTraceBatch(t *testing.T) {
+	exporter := tracing.NewInMemoryExporter()
+	tracing.SetExporter(exporter)
+	defer tracing.SetExporter(nil)
+
+	r := NewReceiverImpl(&mockconfig.Orderer{BatchSizeVal: &ab.BatchSize{MaxMessageCount: 2, AbsoluteMaxBytes: 1000, PreferredMaxBytes: 1000}})
+
+	txWithID := func(txID string) *cb.Envelope {
+		chdr := utils.MakeChannelHeader(cb.HeaderType_ENDORSER_TRANSACTION, 0, "mychannel", 0)
+		chdr.TxId = txID
+		payload := &cb.Payload{Header: utils.MakePayloadHeader(chdr, &cb.SignatureHeader{})}
+		return &cb.Envelope{Payload: utils.MarshalOrPanic(payload)}
+	}
+	r.Ordered(txWithID("tx1"))
+	batches, _ := r.Ordered(txWithID("tx2"))
+	assert.Len(t, batches, 1)
+
+	// cutting an empty batch doesn't trace anything
+	assert.Empty(t, r.Cut())
+
+	// the spans of the messages are children of the span of the batch
+	spans := exporter.Spans()
+	assert.Len(t, spans, 3)
+	batch := spans[2]
+	assert.Equal(t, "blockcutter.Cut", batch.Name)
+	assert.Equal(t, "mychannel", batch.Tags["channel"])
+	assert.Equal(t, "2", batch.Tags["message_count"])
+	for i, txID := range []string{"tx1", "tx2"} {
+		assert.Equal(t, "blockcutter.Message", spans[i].Name)
+		assert.Equal(t, batch.SpanID, spans[i].ParentID)
+		assert.Equal(t, batch.TraceID, spans[i].TraceID)
+		assert.Equal(t, txID, spans[i].Tags["txid"])
+	}
+	assert.False(t, spans[1].Start.Before(spans[0].Start), "the second message was enqueued after the first one")
+	assert.Equal(t, spans[0].Start, batch.Start)
+}
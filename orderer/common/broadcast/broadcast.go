@@ -10,6 +10,7 @@ import (
 	"io"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	cb "github.com/hyperledger/fabric/protos/common"
@@ -90,55 +91,75 @@ func (bh *handlerImpl) Handle(srv ab.AtomicBroadcast_BroadcastServer) error {
 			return err
 		}
 
-		chdr, isConfig, processor, err := bh.sm.BroadcastChannelSupport(msg)
+		span, _ := tracing.StartSpan(srv.Context(), "broadcast.Handle")
+		resp := bh.processMessage(addr, msg, span)
+		span.SetTag("status", resp.Status)
+		if resp.Status != cb.Status_SUCCESS {
+			span.SetError(errors.New(resp.Info))
+			span.Finish()
+			return srv.Send(resp)
+		}
+		span.Finish()
+
+		err = srv.Send(resp)
 		if err != nil {
-			logger.Warningf("[channel: %s] Could not get message processor for serving %s: %s", chdr.ChannelId, addr, err)
-			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_INTERNAL_SERVER_ERROR, Info: err.Error()})
+			logger.Warningf("Error sending to %s: %s", addr, err)
+			return err
 		}
+	}
+}
+
+// processMessage validates and enqueues a message for ordering, and returns the
+// response to send back to the client. The span times the processing of the message
+func (bh *handlerImpl) processMessage(addr string, msg *cb.Envelope, span *tracing.Span) *ab.BroadcastResponse {
+	chdr, isConfig, processor, err := bh.sm.BroadcastChannelSupport(msg)
+	if err != nil {
+		logger.Warningf("[channel: %s] Could not get message processor for serving %s: %s", chdr.ChannelId, addr, err)
+		return &ab.BroadcastResponse{Status: cb.Status_INTERNAL_SERVER_ERROR, Info: err.Error()}
+	}
+
+	span.SetTag("channel", chdr.ChannelId)
+	span.SetTag("txid", chdr.TxId)
+	span.SetTag("type", cb.HeaderType_name[chdr.Type])
+
+	if err = processor.WaitReady(); err != nil {
+		logger.Warningf("[channel: %s] Rejecting broadcast of message from %s with SERVICE_UNAVAILABLE: rejected by Consenter: %s", chdr.ChannelId, addr, err)
+		return &ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()}
+	}
+
+	if !isConfig {
+		logger.Debugf("[channel: %s] Broadcast is processing normal message from %s with txid '%s' of type %s", chdr.ChannelId, addr, chdr.TxId, cb.HeaderType_name[chdr.Type])
 
-		if err = processor.WaitReady(); err != nil {
-			logger.Warningf("[channel: %s] Rejecting broadcast of message from %s with SERVICE_UNAVAILABLE: rejected by Consenter: %s", chdr.ChannelId, addr, err)
-			return srv.Send(&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()})
+		configSeq, err := processor.ProcessNormalMsg(msg)
+		if err != nil {
+			logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s because of error: %s", chdr.ChannelId, addr, err)
+			return &ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()}
 		}
 
-		if !isConfig {
-			logger.Debugf("[channel: %s] Broadcast is processing normal message from %s with txid '%s' of type %s", chdr.ChannelId, addr, chdr.TxId, cb.HeaderType_name[chdr.Type])
-
-			configSeq, err := processor.ProcessNormalMsg(msg)
-			if err != nil {
-				logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s because of error: %s", chdr.ChannelId, addr, err)
-				return srv.Send(&ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()})
-			}
-
-			err = processor.Order(msg, configSeq)
-			if err != nil {
-				logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s with SERVICE_UNAVAILABLE: rejected by Order: %s", chdr.ChannelId, addr, err)
-				return srv.Send(&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()})
-			}
-		} else { // isConfig
-			logger.Debugf("[channel: %s] Broadcast is processing config update message from %s", chdr.ChannelId, addr)
-
-			config, configSeq, err := processor.ProcessConfigUpdateMsg(msg)
-			if err != nil {
-				logger.Warningf("[channel: %s] Rejecting broadcast of config message from %s because of error: %s", chdr.ChannelId, addr, err)
-				return srv.Send(&ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()})
-			}
-
-			err = processor.Configure(config, configSeq)
-			if err != nil {
-				logger.Warningf("[channel: %s] Rejecting broadcast of config message from %s with SERVICE_UNAVAILABLE: rejected by Configure: %s", chdr.ChannelId, addr, err)
-				return srv.Send(&ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()})
-			}
+		err = processor.Order(msg, configSeq)
+		if err != nil {
+			logger.Warningf("[channel: %s] Rejecting broadcast of normal message from %s with SERVICE_UNAVAILABLE: rejected by Order: %s", chdr.ChannelId, addr, err)
+			return &ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()}
 		}
+	} else { // isConfig
+		logger.Debugf("[channel: %s] Broadcast is processing config update message from %s", chdr.ChannelId, addr)
 
-		logger.Debugf("[channel: %s] Broadcast has successfully enqueued message of type %s from %s", chdr.ChannelId, cb.HeaderType_name[chdr.Type], addr)
+		config, configSeq, err := processor.ProcessConfigUpdateMsg(msg)
+		if err != nil {
+			logger.Warningf("[channel: %s] Rejecting broadcast of config message from %s because of error: %s", chdr.ChannelId, addr, err)
+			return &ab.BroadcastResponse{Status: ClassifyError(err), Info: err.Error()}
+		}
 
-		err = srv.Send(&ab.BroadcastResponse{Status: cb.Status_SUCCESS})
+		err = processor.Configure(config, configSeq)
 		if err != nil {
-			logger.Warningf("[channel: %s] Error sending to %s: %s", chdr.ChannelId, addr, err)
-			return err
+			logger.Warningf("[channel: %s] Rejecting broadcast of config message from %s with SERVICE_UNAVAILABLE: rejected by Configure: %s", chdr.ChannelId, addr, err)
+			return &ab.BroadcastResponse{Status: cb.Status_SERVICE_UNAVAILABLE, Info: err.Error()}
 		}
 	}
+
+	logger.Debugf("[channel: %s] Broadcast has successfully enqueued message of type %s from %s", chdr.ChannelId, cb.HeaderType_name[chdr.Type], addr)
+
+	return &ab.BroadcastResponse{Status: cb.Status_SUCCESS}
 }
 
 // ClassifyError converts an error type into a status code.
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/orderer/common/msgprocessor"
 	cb "github.com/hyperledger/fabric/protos/common"
 	ab "github.com/hyperledger/fabric/protos/orderer"
@@ -283,3 +284,32 @@ func TestBadStreamSend(t *testing.T) {
 	m := &erroneousSendMockB{recvVal: nil}
 	assert.Error(t, bh.Handle(m), "Should catch unexpected stream error")
 }
+
+func TestTracing(t *testing.T) {
+	exporter := tracing.NewInMemoryExporter()
+	tracing.SetExporter(exporter)
+	defer tracing.SetExporter(nil)
+
+	mm := getMockSupportManager()
+	bh := NewHandlerImpl(mm)
+	m := newMockB()
+	defer close(m.recvChan)
+	go bh.Handle(m)
+
+	m.recvChan <- nil
+	reply := <-m.sendChan
+	assert.Equal(t, cb.Status_SUCCESS, reply.Status)
+
+	mm.MsgProcessorVal.rejectEnqueue = true
+	m.recvChan <- nil
+	reply = <-m.sendChan
+	assert.Equal(t, cb.Status_SERVICE_UNAVAILABLE, reply.Status)
+
+	spans := exporter.Spans()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "broadcast.Handle", spans[0].Name)
+	assert.Equal(t, "SUCCESS", spans[0].Tags["status"])
+	assert.Empty(t, spans[0].Error)
+	assert.Equal(t, "SERVICE_UNAVAILABLE", spans[1].Tags["status"])
+	assert.Equal(t, "Reject", spans[1].Error)
+}
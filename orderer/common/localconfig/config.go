@@ -87,6 +87,10 @@ type General struct {
 	LocalMSPID     string
 	BCCSP          *bccsp.FactoryOpts
 	Authentication Authentication
+	Reload         Reload
+	CertExpiration CertExpiration
+	Tracing        Tracing
+	Metrics        Metrics
 }
 
 // Keepalive contains configuration for gRPC servers
@@ -112,6 +116,48 @@ type Authentication struct {
 	TimeWindow time.Duration
 }
 
+// Reload contains configuration for the reload of the TLS key pair and the
+// local MSP once they change, or once the orderer receives SIGHUP.
+type Reload struct {
+	Interval time.Duration
+}
+
+// CertExpiration contains configuration for the checks of the expiration dates
+// of the certificates of the orderer and of the MSPs of its channels.
+type CertExpiration struct {
+	Interval       time.Duration
+	WarningPeriod  time.Duration
+	CriticalPeriod time.Duration
+}
+
+// Tracing contains configuration for the tracing of the processing of requests.
+type Tracing struct {
+	Enabled  bool
+	Exporter string
+	File     string
+}
+
+// Metrics contains configuration for the reporting of metrics.
+type Metrics struct {
+	Enabled        bool
+	Reporter       string
+	Interval       time.Duration
+	StatsdReporter StatsdReporter
+	PromReporter   PromReporter
+}
+
+// StatsdReporter contains configuration for the reporting of metrics to statsd.
+type StatsdReporter struct {
+	Address       string
+	FlushInterval time.Duration
+	FlushBytes    int
+}
+
+// PromReporter contains configuration for the reporting of metrics to Prometheus.
+type PromReporter struct {
+	ListenAddress string
+}
+
 // Profile contains configuration for Go pprof profiling.
 type Profile struct {
 	Enabled bool
@@ -199,6 +245,24 @@ var defaults = TopLevel{
 			Enabled: false,
 			Address: "0.0.0.0:6060",
 		},
+		Tracing: Tracing{
+			Enabled:  false,
+			Exporter: "file",
+			File:     "traces.json",
+		},
+		Metrics: Metrics{
+			Enabled:  false,
+			Reporter: "statsd",
+			Interval: time.Second,
+			StatsdReporter: StatsdReporter{
+				Address:       "0.0.0.0:8125",
+				FlushInterval: 2 * time.Second,
+				FlushBytes:    1432,
+			},
+			PromReporter: PromReporter{
+				ListenAddress: "0.0.0.0:8080",
+			},
+		},
 		LogLevel:    "INFO",
 		LogFormat:   "%{color}%{time:2006-01-02 15:04:05.000 MST} [%{module}] %{shortfunc} -> %{level:.4s} %{id:03x}%{color:reset} %{message}",
 		LocalMSPDir: "msp",
@@ -289,6 +353,7 @@ func (c *TopLevel) completeInitialization(configDir string) {
 		cf.TranslatePathInPlace(configDir, &c.General.TLS.Certificate)
 		cf.TranslatePathInPlace(configDir, &c.General.GenesisFile)
 		cf.TranslatePathInPlace(configDir, &c.General.LocalMSPDir)
+		cf.TranslatePathInPlace(configDir, &c.General.Tracing.File)
 	}()
 
 	for {
@@ -331,6 +396,26 @@ func (c *TopLevel) completeInitialization(configDir string) {
 			logger.Infof("Profiling enabled and General.Profile.Address unset, setting to %s", defaults.General.Profile.Address)
 			c.General.Profile.Address = defaults.General.Profile.Address
 
+		case c.General.Tracing.Enabled && c.General.Tracing.Exporter == "":
+			logger.Infof("Tracing enabled and General.Tracing.Exporter unset, setting to %s", defaults.General.Tracing.Exporter)
+			c.General.Tracing.Exporter = defaults.General.Tracing.Exporter
+		case c.General.Tracing.Enabled && c.General.Tracing.Exporter == "file" && c.General.Tracing.File == "":
+			logger.Infof("Tracing enabled and General.Tracing.File unset, setting to %s", defaults.General.Tracing.File)
+			c.General.Tracing.File = defaults.General.Tracing.File
+
+		case c.General.Metrics.Enabled && c.General.Metrics.Reporter == "":
+			logger.Infof("Metrics enabled and General.Metrics.Reporter unset, setting to %s", defaults.General.Metrics.Reporter)
+			c.General.Metrics.Reporter = defaults.General.Metrics.Reporter
+		case c.General.Metrics.Enabled && c.General.Metrics.Interval == 0:
+			logger.Infof("Metrics enabled and General.Metrics.Interval unset, setting to %s", defaults.General.Metrics.Interval)
+			c.General.Metrics.Interval = defaults.General.Metrics.Interval
+		case c.General.Metrics.Enabled && c.General.Metrics.StatsdReporter.FlushInterval == 0:
+			logger.Infof("Metrics enabled and General.Metrics.StatsdReporter.FlushInterval unset, setting to %s", defaults.General.Metrics.StatsdReporter.FlushInterval)
+			c.General.Metrics.StatsdReporter.FlushInterval = defaults.General.Metrics.StatsdReporter.FlushInterval
+		case c.General.Metrics.Enabled && c.General.Metrics.StatsdReporter.FlushBytes == 0:
+			logger.Infof("Metrics enabled and General.Metrics.StatsdReporter.FlushBytes unset, setting to %d", defaults.General.Metrics.StatsdReporter.FlushBytes)
+			c.General.Metrics.StatsdReporter.FlushBytes = defaults.General.Metrics.StatsdReporter.FlushBytes
+
 		case c.General.LocalMSPDir == "":
 			logger.Infof("General.LocalMSPDir unset, setting to %s", defaults.General.LocalMSPDir)
 			c.General.LocalMSPDir = defaults.General.LocalMSPDir
@@ -133,3 +133,16 @@ func TestProfileConfig(t *testing.T) {
 	uconf.completeInitialization(DummyPath)
 	assert.Equal(t, defaults.General.Profile.Address, uconf.General.Profile.Address, "Expected profile address to be filled with default value")
 }
+
+func TestMetricsConfig(t *testing.T) {
+	uconf := &TopLevel{General: General{Metrics: Metrics{Enabled: true}}}
+	uconf.completeInitialization(DummyPath)
+	assert.Equal(t, defaults.General.Metrics.Reporter, uconf.General.Metrics.Reporter, "Expected metrics reporter to be filled with default value")
+	assert.Equal(t, defaults.General.Metrics.Interval, uconf.General.Metrics.Interval, "Expected metrics interval to be filled with default value")
+	assert.Equal(t, defaults.General.Metrics.StatsdReporter.FlushInterval, uconf.General.Metrics.StatsdReporter.FlushInterval, "Expected statsd flush interval to be filled with default value")
+	assert.Equal(t, defaults.General.Metrics.StatsdReporter.FlushBytes, uconf.General.Metrics.StatsdReporter.FlushBytes, "Expected statsd flush bytes to be filled with default value")
+
+	conf, err := Load()
+	assert.NoError(t, err)
+	assert.False(t, conf.General.Metrics.Enabled, "Metrics should be disabled by default")
+}
@@ -51,6 +51,7 @@ func (sf *SigFilter) Apply(message *cb.Envelope) error {
 
 	err = policy.Evaluate(signedData)
 	if err != nil {
+		policies.DebugExplain(logger, sf.policyName, policy, signedData)
 		return errors.Wrap(errors.WithStack(ErrPermissionDenied), err.Error())
 	}
 	return nil
@@ -11,6 +11,7 @@ package multichannel
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/hyperledger/fabric/common/channelconfig"
 	"github.com/hyperledger/fabric/common/configtx"
@@ -293,6 +294,17 @@ func (r *Registrar) newChain(configtx *cb.Envelope) {
 	r.chains = newChains
 }
 
+// ChannelIDs returns the IDs of the current channels.
+func (r *Registrar) ChannelIDs() []string {
+	chains := r.chains
+	channelIDs := make([]string, 0, len(chains))
+	for chainID := range chains {
+		channelIDs = append(channelIDs, chainID)
+	}
+	sort.Strings(channelIDs)
+	return channelIDs
+}
+
 // ChannelsCount returns the count of the current total number of channels.
 func (r *Registrar) ChannelsCount() int {
 	return len(r.chains)
@@ -226,6 +226,7 @@ func TestNewChain(t *testing.T) {
 	if !ok {
 		t.Fatalf("Should have gotten new chain which was created")
 	}
+	assert.Equal(t, []string{newChainID, genesisconfig.TestChainID}, manager.ChannelIDs())
 
 	messages := make([]*cb.Envelope, conf.Orderer.BatchSize.MaxMessageCount)
 	for i := 0; i < int(conf.Orderer.BatchSize.MaxMessageCount); i++ {
@@ -0,0 +1,73 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"github.com/hyperledger/fabric/common/expiration"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/comm"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/hyperledger/fabric/orderer/common/multichannel"
+	"github.com/pkg/errors"
+)
+
+// initializeExpirationMonitor checks the expiration dates of the signing certificate
+// of the orderer, of its TLS certificate, and of the CA and admin certificates of the
+// MSPs of its channels, at startup and periodically, and reports the days left until they
+// expire through the metrics scope, which must be initialized beforehand
+func initializeExpirationMonitor(conf *config.TopLevel, grpcServer comm.GRPCServer, registrar *multichannel.Registrar) *expiration.Monitor {
+	monitorConf := expiration.Config{
+		Interval:       conf.General.CertExpiration.Interval,
+		WarningPeriod:  conf.General.CertExpiration.WarningPeriod,
+		CriticalPeriod: conf.General.CertExpiration.CriticalPeriod,
+	}
+	collectors := []expiration.Collector{localSigningCert}
+	if grpcServer.TLSEnabled() {
+		collectors = append(collectors, func() ([]*expiration.Certificate, error) {
+			return expiration.FromTLSCertificate("TLS server certificate", grpcServer.ServerCertificate())
+		})
+	}
+	collectors = append(collectors, func() ([]*expiration.Certificate, error) {
+		return channelCerts(registrar), nil
+	})
+
+	m := expiration.NewMonitor(monitorConf, metrics.RootScope, collectors...)
+	m.Start()
+	return m
+}
+
+func localSigningCert() ([]*expiration.Certificate, error) {
+	signer, err := mspmgmt.GetLocalMSP().GetDefaultSigningIdentity()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed obtaining the signing identity")
+	}
+	serializedIdentity, err := signer.Serialize()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed serializing the signing identity")
+	}
+	return expiration.FromSerializedIdentity("signing certificate of the local MSP", serializedIdentity)
+}
+
+// channelCerts returns the certificates of the MSPs of the channels of the orderer.
+// Channels whose certificates cannot be read are skipped
+func channelCerts(registrar *multichannel.Registrar) []*expiration.Certificate {
+	var certs []*expiration.Certificate
+	for _, channelID := range registrar.ChannelIDs() {
+		cs, exists := registrar.GetChain(channelID)
+		if !exists {
+			continue
+		}
+		channelCerts, err := expiration.FromChannelConfig(channelID, cs.ConfigProto())
+		if err != nil {
+			logger.Warningf("Failed collecting the certificates of channel %s: %s", channelID, err)
+			continue
+		}
+		certs = append(certs, channelCerts...)
+	}
+	return certs
+}
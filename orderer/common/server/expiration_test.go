@@ -0,0 +1,56 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/core/chaincode/accesscontrol"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInitializeExpirationMonitor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca, err := accesscontrol.NewCA()
+	assert.NoError(t, err)
+	keyPair, err := ca.NewServerCertKeyPair("localhost")
+	assert.NoError(t, err)
+
+	conf := genesisConfig(t)
+	conf.General.ListenAddress = "localhost"
+	conf.General.TLS = config.TLS{
+		Enabled:     true,
+		Certificate: filepath.Join(dir, "server.crt"),
+		PrivateKey:  filepath.Join(dir, "server.key"),
+	}
+	assert.NoError(t, ioutil.WriteFile(conf.General.TLS.Certificate, keyPair.Cert, 0600))
+	assert.NoError(t, ioutil.WriteFile(conf.General.TLS.PrivateKey, keyPair.Key, 0600))
+
+	initializeLocalMsp(conf)
+	grpcServer := initializeGrpcServer(conf, initializeServerConfig(conf))
+	defer grpcServer.Listener().Close()
+	registrar := initializeMultichannelRegistrar(conf, localmsp.NewSigner())
+
+	m := initializeExpirationMonitor(conf, grpcServer, registrar)
+	defer m.Stop()
+
+	sources := map[string]bool{}
+	for _, s := range m.Status() {
+		sources[s.Source] = true
+	}
+	assert.True(t, sources["signing certificate of the local MSP"])
+	assert.True(t, sources["TLS server certificate"])
+	assert.True(t, sources["root CA certificate of MSP DEFAULT of channel testchainid"])
+}
@@ -20,8 +20,10 @@ import (
 	"github.com/hyperledger/fabric/common/crypto"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/ledger/blockledger"
+	"github.com/hyperledger/fabric/common/metrics"
 	"github.com/hyperledger/fabric/common/tools/configtxgen/encoder"
 	genesisconfig "github.com/hyperledger/fabric/common/tools/configtxgen/localconfig"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/core/comm"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/orderer/common/bootstrap/file"
@@ -126,8 +128,17 @@ func Start(cmd string, conf *config.TopLevel) {
 		logger.Infof("Starting %s", metadata.GetVersionInfo())
 		//利用go和pprof来分析系统性能
 		initializeProfilingService(conf)
+		initializeTracing(conf)
+		initializeMetrics(conf)
+		defer metrics.Shutdown()
 		//注册原子广播服务
 		ab.RegisterAtomicBroadcastServer(grpcServer.Server(), server)
+		// watch the TLS key pair and the local MSP for changes
+		for _, w := range initializeReloaders(conf, grpcServer) {
+			defer w.Stop()
+		}
+		// check the expiration dates of the certificates of the orderer and of its channels
+		defer initializeExpirationMonitor(conf, grpcServer, manager).Stop()
 		logger.Info("Beginning to serve requests")
 		//启动grpc
 		grpcServer.Start()
@@ -158,6 +169,41 @@ func initializeProfilingService(conf *config.TopLevel) {
 	}
 }
 
+// Initialize tracing, which is disabled unless configured otherwise
+func initializeTracing(conf *config.TopLevel) {
+	err := tracing.Init(tracing.Config{
+		Enabled:  conf.General.Tracing.Enabled,
+		Exporter: conf.General.Tracing.Exporter,
+		File:     conf.General.Tracing.File,
+	})
+	if err != nil {
+		logger.Fatal("Failed to initialize tracing:", err)
+	}
+}
+
+// Initialize the metrics scope, which is a no-op unless metrics are enabled
+func initializeMetrics(conf *config.TopLevel) {
+	err := metrics.Init(metrics.Opts{
+		Enabled:  conf.General.Metrics.Enabled,
+		Reporter: conf.General.Metrics.Reporter,
+		Interval: conf.General.Metrics.Interval,
+		StatsdReporterOpts: metrics.StatsdReporterOpts{
+			Address:       conf.General.Metrics.StatsdReporter.Address,
+			FlushInterval: conf.General.Metrics.StatsdReporter.FlushInterval,
+			FlushBytes:    conf.General.Metrics.StatsdReporter.FlushBytes,
+		},
+		PromReporterOpts: metrics.PromReporterOpts{
+			ListenAddress: conf.General.Metrics.PromReporter.ListenAddress,
+		},
+	})
+	if err != nil {
+		logger.Fatal("Failed to initialize metrics:", err)
+	}
+	if err := metrics.Start(); err != nil {
+		logger.Fatal("Failed to start metrics:", err)
+	}
+}
+
 // conf目前读区的已经是orderer.yaml配置文件中的数据了
 func initializeServerConfig(conf *config.TopLevel) comm.ServerConfig {
 	// secure server config
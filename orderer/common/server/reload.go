@@ -0,0 +1,65 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"path/filepath"
+	"syscall"
+
+	"github.com/hyperledger/fabric/common/reload"
+	"github.com/hyperledger/fabric/core/comm"
+	mspmgmt "github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/pkg/errors"
+)
+
+// initializeReloaders watches the TLS key pair and the local MSP of the orderer,
+// and reloads them whenever they change or whenever the orderer receives SIGHUP
+func initializeReloaders(conf *config.TopLevel, grpcServer comm.GRPCServer) []*reload.Watcher {
+	interval := conf.General.Reload.Interval
+
+	var watchers []*reload.Watcher
+	if grpcServer.TLSEnabled() {
+		tlsPaths := []string{conf.General.TLS.Certificate, conf.General.TLS.PrivateKey}
+		watchers = append(watchers, reload.NewWatcher("TLS key pair", tlsPaths, interval, func() error {
+			return reloadTLS(conf, grpcServer)
+		}))
+	}
+	watchers = append(watchers, reload.NewWatcher("local MSP", localMspPaths(conf), interval, mspmgmt.ReloadLocalMsp))
+
+	for _, w := range watchers {
+		w.Start()
+	}
+	reload.NotifyOnSignal(watchers, syscall.SIGHUP)
+	return watchers
+}
+
+func localMspPaths(conf *config.TopLevel) []string {
+	paths := []string{
+		filepath.Join(conf.General.LocalMSPDir, "signcerts"),
+		filepath.Join(conf.General.LocalMSPDir, "keystore"),
+		filepath.Join(conf.General.LocalMSPDir, "crls"),
+	}
+	if bccspConf := conf.General.BCCSP; bccspConf != nil && bccspConf.SwOpts != nil && bccspConf.SwOpts.FileKeystore != nil &&
+		bccspConf.SwOpts.FileKeystore.KeyStorePath != "" {
+		paths = append(paths, bccspConf.SwOpts.FileKeystore.KeyStorePath)
+	}
+	return paths
+}
+
+// reloadTLS validates the TLS key pair of the orderer before swapping it in for the server.
+// Established connections keep using the previous key pair until they are reestablished
+func reloadTLS(conf *config.TopLevel, grpcServer comm.GRPCServer) error {
+	cert, err := comm.LoadTLSKeyPair(conf.General.TLS.Certificate, conf.General.TLS.PrivateKey)
+	if err != nil {
+		return errors.WithMessage(err, "invalid TLS server key pair")
+	}
+
+	grpcServer.SetServerCertificate(cert)
+	logger.Infof("The TLS server certificate now expires at %s", cert.Leaf.NotAfter)
+	return nil
+}
@@ -0,0 +1,65 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/accesscontrol"
+	"github.com/hyperledger/fabric/orderer/common/localconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReloadTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ca, err := accesscontrol.NewCA()
+	assert.NoError(t, err)
+	conf := &config.TopLevel{
+		General: config.General{
+			ListenAddress: "localhost",
+			TLS: config.TLS{
+				Enabled:     true,
+				Certificate: filepath.Join(dir, "server.crt"),
+				PrivateKey:  filepath.Join(dir, "server.key"),
+			},
+		},
+	}
+	writeKeyPair := func() {
+		keyPair, err := ca.NewServerCertKeyPair("localhost")
+		assert.NoError(t, err)
+		assert.NoError(t, ioutil.WriteFile(conf.General.TLS.Certificate, keyPair.Cert, 0600))
+		assert.NoError(t, ioutil.WriteFile(conf.General.TLS.PrivateKey, keyPair.Key, 0600))
+	}
+
+	writeKeyPair()
+	grpcServer := initializeGrpcServer(conf, initializeServerConfig(conf))
+	defer grpcServer.Listener().Close()
+	initialCert := grpcServer.ServerCertificate()
+
+	writeKeyPair()
+	assert.NoError(t, reloadTLS(conf, grpcServer))
+	reloadedCert := grpcServer.ServerCertificate()
+	assert.NotEqual(t, initialCert.Certificate, reloadedCert.Certificate)
+
+	// invalid key pairs are not swapped in
+	assert.NoError(t, ioutil.WriteFile(conf.General.TLS.PrivateKey, []byte("garbage"), 0600))
+	err = reloadTLS(conf, grpcServer)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid TLS server key pair")
+	assert.Equal(t, reloadedCert.Certificate, grpcServer.ServerCertificate().Certificate)
+}
+
+func TestLocalMspPaths(t *testing.T) {
+	conf := &config.TopLevel{General: config.General{LocalMSPDir: "msp"}}
+	assert.Equal(t, []string{"msp/signcerts", "msp/keystore", "msp/crls"}, localMspPaths(conf))
+}
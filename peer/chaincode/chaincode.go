@@ -8,6 +8,7 @@ package chaincode
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/peer/common"
@@ -17,8 +18,8 @@ import (
 
 const (
 	chainFuncName = "chaincode"
-	shortDes      = "Operate a chaincode: install|instantiate|invoke|package|query|signpackage|upgrade|list."
-	longDes       = "Operate a chaincode: install|instantiate|invoke|package|query|signpackage|upgrade|list."
+	shortDes      = "Operate a chaincode: install|instantiate|invoke|package|inspect|query|signpackage|upgrade|list."
+	longDes       = "Operate a chaincode: install|instantiate|invoke|package|inspect|query|signpackage|upgrade|list."
 )
 
 var logger = flogging.MustGetLogger("chaincodeCmd")
@@ -37,6 +38,7 @@ func Cmd(cf *ChaincodeCmdFactory) *cobra.Command {
 	chaincodeCmd.AddCommand(instantiateCmd(cf))
 	chaincodeCmd.AddCommand(invokeCmd(cf))
 	chaincodeCmd.AddCommand(packageCmd(cf, nil))
+	chaincodeCmd.AddCommand(inspectCmd())
 	chaincodeCmd.AddCommand(queryCmd(cf))
 	chaincodeCmd.AddCommand(signpackageCmd(cf))
 	chaincodeCmd.AddCommand(upgradeCmd(cf))
@@ -64,6 +66,10 @@ var (
 	transient             string
 	collectionsConfigFile string
 	collectionConfigBytes []byte
+	peerAddresses         []string
+	tlsRootCertFiles      []string
+	waitForEvent          bool
+	waitForEventTimeout   time.Duration
 )
 
 var chaincodeCmd = &cobra.Command{
@@ -111,6 +117,14 @@ func resetFlags() {
 		"Get the instantiated chaincodes on a channel")
 	flags.StringVar(&collectionsConfigFile, "collections-config", common.UndefinedParamValue,
 		fmt.Sprint("The file containing the configuration for the chaincode's collection"))
+	flags.StringSliceVar(&peerAddresses, "peerAddresses", []string{},
+		fmt.Sprint("The addresses of the peers to connect to"))
+	flags.StringSliceVar(&tlsRootCertFiles, "tlsRootCertFiles", []string{},
+		fmt.Sprint("If TLS is enabled, the paths to the TLS root cert files of the peers to connect to. The order and number of certs specified should match the --peerAddresses flag"))
+	flags.BoolVar(&waitForEvent, "waitForEvent", false,
+		fmt.Sprint("Whether to wait for the event from each peer's deliver filtered service signifying that the 'invoke' transaction has been committed successfully"))
+	flags.DurationVar(&waitForEventTimeout, "waitForEventTimeout", 30*time.Second,
+		fmt.Sprint("Time to wait for the event from each peer's deliver filtered service signifying that the 'invoke' transaction has been committed successfully"))
 }
 
 func attachFlags(cmd *cobra.Command, names []string) {
@@ -7,14 +7,19 @@ SPDX-License-Identifier: Apache-2.0
 package chaincode
 
 import (
+	"bytes"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
 	"strings"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/hyperledger/fabric/common/cauthdsl"
+	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/util"
 	"github.com/hyperledger/fabric/core/chaincode"
 	"github.com/hyperledger/fabric/core/chaincode/platforms"
 	"github.com/hyperledger/fabric/core/chaincode/shim"
@@ -22,6 +27,7 @@ import (
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/peer/common"
 	pcommon "github.com/hyperledger/fabric/protos/common"
+	ab "github.com/hyperledger/fabric/protos/orderer"
 	pb "github.com/hyperledger/fabric/protos/peer"
 	putils "github.com/hyperledger/fabric/protos/utils"
 	"github.com/pkg/errors"
@@ -105,7 +111,9 @@ func chaincodeInvokeOrQuery(cmd *cobra.Command, args []string, invoke bool, cf *
 		channelID,
 		invoke,
 		cf.Signer,
-		cf.EndorserClient,
+		cf.Certificate,
+		cf.endorserClients(),
+		cf.DeliverClients,
 		cf.BroadcastClient)
 
 	if err != nil {
@@ -294,18 +302,95 @@ func checkChaincodeCmdParams(cmd *cobra.Command) error {
 // ChaincodeCmdFactory holds the clients used by ChaincodeCmd
 type ChaincodeCmdFactory struct {
 	EndorserClient  pb.EndorserClient
+	EndorserClients []pb.EndorserClient
+	DeliverClients  []pb.DeliverClient
+	Certificate     tls.Certificate
 	Signer          msp.SigningIdentity
 	BroadcastClient common.BroadcastClient
 }
 
+// endorserClients returns the clients of the peers which endorse the
+// proposals, falling back to the single EndorserClient
+func (cf *ChaincodeCmdFactory) endorserClients() []pb.EndorserClient {
+	if len(cf.EndorserClients) == 0 && cf.EndorserClient != nil {
+		return []pb.EndorserClient{cf.EndorserClient}
+	}
+	return cf.EndorserClients
+}
+
+// validatePeerConnectionParameters checks that a TLS root certificate file
+// is provided for each of the peer addresses if TLS is enabled
+func validatePeerConnectionParameters() error {
+	if len(peerAddresses) == 0 {
+		if len(tlsRootCertFiles) != 0 {
+			return errors.New("the --tlsRootCertFiles flag requires the --peerAddresses flag")
+		}
+		return nil
+	}
+	if !viper.GetBool("peer.tls.enabled") {
+		if len(tlsRootCertFiles) != 0 {
+			logger.Warning("TLS is disabled, the --tlsRootCertFiles flag is ignored")
+		}
+		return nil
+	}
+	if len(tlsRootCertFiles) != len(peerAddresses) {
+		return errors.Errorf("the number of peer addresses (%d) does not match the number of TLS root cert files (%d)", len(peerAddresses), len(tlsRootCertFiles))
+	}
+	return nil
+}
+
 // InitCmdFactory init the ChaincodeCmdFactory with default clients
 func InitCmdFactory(isEndorserRequired, isOrdererRequired bool) (*ChaincodeCmdFactory, error) {
 	var err error
-	var endorserClient pb.EndorserClient
+	var endorserClients []pb.EndorserClient
+	var deliverClients []pb.DeliverClient
 	if isEndorserRequired {
-		endorserClient, err = common.GetEndorserClientFnc()
+		if err = validatePeerConnectionParameters(); err != nil {
+			return nil, errors.WithMessage(err, "error validating peer connection parameters")
+		}
+		for i, address := range peerAddresses {
+			var tlsRootCertFile string
+			if i < len(tlsRootCertFiles) {
+				tlsRootCertFile = tlsRootCertFiles[i]
+			}
+			endorserClient, err := common.GetPeerEndorserClientFnc(address, tlsRootCertFile)
+			if err != nil {
+				return nil, fmt.Errorf("Error getting endorser client for %s from %s: %s", chainFuncName, address, err)
+			}
+			endorserClients = append(endorserClients, endorserClient)
+			if waitForEvent {
+				deliverClient, err := common.GetDeliverClientFnc(address, tlsRootCertFile)
+				if err != nil {
+					return nil, fmt.Errorf("Error getting deliver client for %s from %s: %s", chainFuncName, address, err)
+				}
+				deliverClients = append(deliverClients, deliverClient)
+			}
+		}
+		if len(peerAddresses) == 0 {
+			endorserClient, err := common.GetEndorserClientFnc()
+			if err != nil {
+				return nil, fmt.Errorf("Error getting endorser client %s: %s", chainFuncName, err)
+			}
+			endorserClients = append(endorserClients, endorserClient)
+			if waitForEvent {
+				deliverClient, err := common.GetDeliverClientFnc("", "")
+				if err != nil {
+					return nil, fmt.Errorf("Error getting deliver client %s: %s", chainFuncName, err)
+				}
+				deliverClients = append(deliverClients, deliverClient)
+			}
+		}
+	}
+	var endorserClient pb.EndorserClient
+	if len(endorserClients) > 0 {
+		endorserClient = endorserClients[0]
+	}
+
+	var certificate tls.Certificate
+	if waitForEvent {
+		certificate, err = common.GetCertificateFnc()
 		if err != nil {
-			return nil, fmt.Errorf("Error getting endorser client %s: %s", chainFuncName, err)
+			return nil, fmt.Errorf("Error getting client certificate: %s", err)
 		}
 	}
 
@@ -337,6 +422,9 @@ func InitCmdFactory(isEndorserRequired, isOrdererRequired bool) (*ChaincodeCmdFa
 	}
 	return &ChaincodeCmdFactory{
 		EndorserClient:  endorserClient,
+		EndorserClients: endorserClients,
+		DeliverClients:  deliverClients,
+		Certificate:     certificate,
 		Signer:          signer,
 		BroadcastClient: broadcastClient,
 	}, nil
@@ -349,6 +437,11 @@ func InitCmdFactory(isEndorserRequired, isOrdererRequired bool) (*ChaincodeCmdFa
 // The printable form is optionally (-x, --hex) a hexadecimal representation
 // of the query response. If the query response is NIL, nothing is output.
 //
+// The proposal is sent to each of the endorserClients, whose responses must
+// match. If deliverClients are provided, the INVOKE form waits until each of
+// the corresponding peers has committed the transaction, and returns an error
+// if the transaction was not valid.
+//
 // NOTE - Query will likely go away as all interactions with the endorser are
 // Proposal and ProposalResponses
 func ChaincodeInvokeOrQuery(
@@ -356,7 +449,9 @@ func ChaincodeInvokeOrQuery(
 	cID string,
 	invoke bool,
 	signer msp.SigningIdentity,
-	endorserClient pb.EndorserClient,
+	certificate tls.Certificate,
+	endorserClients []pb.EndorserClient,
+	deliverClients []pb.DeliverClient,
 	bc common.BroadcastClient,
 ) (*pb.ProposalResponse, error) {
 	// Build the ChaincodeInvocationSpec message
@@ -375,6 +470,10 @@ func ChaincodeInvokeOrQuery(
 		funcName = "query"
 	}
 
+	if len(endorserClients) == 0 {
+		return nil, fmt.Errorf("Error endorsing %s: no endorser clients", funcName)
+	}
+
 	// extract the transient field if it exists
 	var tMap map[string][]byte
 	if transient != "" {
@@ -384,7 +483,8 @@ func ChaincodeInvokeOrQuery(
 	}
 
 	var prop *pb.Proposal
-	prop, _, err = putils.CreateChaincodeProposalWithTransient(pcommon.HeaderType_ENDORSER_TRANSACTION, cID, invocation, creator, tMap)
+	var txid string
+	prop, txid, err = putils.CreateChaincodeProposalWithTransient(pcommon.HeaderType_ENDORSER_TRANSACTION, cID, invocation, creator, tMap)
 	if err != nil {
 		return nil, fmt.Errorf("Error creating proposal  %s: %s", funcName, err)
 	}
@@ -395,29 +495,165 @@ func ChaincodeInvokeOrQuery(
 		return nil, fmt.Errorf("Error creating signed proposal  %s: %s", funcName, err)
 	}
 
-	var proposalResp *pb.ProposalResponse
-	proposalResp, err = endorserClient.ProcessProposal(context.Background(), signedProp)
-	if err != nil {
-		return nil, fmt.Errorf("Error endorsing %s: %s", funcName, err)
+	var responses []*pb.ProposalResponse
+	for _, endorser := range endorserClients {
+		proposalResp, err := endorser.ProcessProposal(context.Background(), signedProp)
+		if err != nil {
+			return nil, fmt.Errorf("Error endorsing %s: %s", funcName, err)
+		}
+		responses = append(responses, proposalResp)
 	}
 
+	// all responses are checked when signing the transaction, so only the
+	// first one is returned
+	proposalResp := responses[0]
+
 	if invoke {
 		if proposalResp != nil {
-			if proposalResp.Response.Status >= shim.ERROR {
-				return proposalResp, nil
+			for _, resp := range responses {
+				if resp == nil || resp.Response == nil {
+					return proposalResp, fmt.Errorf("Error endorsing %s: received an empty proposal response", funcName)
+				}
+				if resp.Response.Status >= shim.ERROR {
+					return resp, nil
+				}
+				if conflict := resp.GetMvccConflict(); conflict != nil {
+					logger.Warningf("The transaction is bound to be invalidated, its read set conflicts with the committed state: %v", conflict)
+				}
+			}
+			for i, resp := range responses[1:] {
+				if !bytes.Equal(proposalResp.Payload, resp.Payload) {
+					return proposalResp, fmt.Errorf("Error endorsing %s: the proposal response from peer %d does not match the one from peer 0", funcName, i+1)
+				}
 			}
+
 			// assemble a signed transaction (it's an Envelope message)
-			env, err := putils.CreateSignedTx(prop, signer, proposalResp)
+			env, err := putils.CreateSignedTx(prop, signer, responses...)
 			if err != nil {
 				return proposalResp, fmt.Errorf("Could not assemble transaction, err %s", err)
 			}
 
+			var ctx context.Context
+			var cancel context.CancelFunc
+			var streams []pb.Deliver_DeliverFilteredClient
+			if len(deliverClients) > 0 {
+				ctx, cancel = context.WithTimeout(context.Background(), waitForEventTimeout)
+				defer cancel()
+				// connect to the deliver services before the transaction is
+				// sent, so that its block cannot be missed
+				streams, err = startDeliver(ctx, cID, certificate, deliverClients)
+				if err != nil {
+					return proposalResp, fmt.Errorf("Error connecting to deliver service: %s", err)
+				}
+			}
+
 			// send the envelope for ordering
 			if err = bc.Send(env); err != nil {
 				return proposalResp, fmt.Errorf("Error sending transaction %s: %s", funcName, err)
 			}
+
+			if len(streams) > 0 {
+				if err = waitForTxEvent(ctx, txid, streams); err != nil {
+					return proposalResp, err
+				}
+			}
 		}
 	}
 
 	return proposalResp, nil
 }
+
+// startDeliver opens a stream of filtered blocks on each of the deliver
+// clients, starting from the newest block of the channel
+func startDeliver(ctx context.Context, channelID string, certificate tls.Certificate, deliverClients []pb.DeliverClient) ([]pb.Deliver_DeliverFilteredClient, error) {
+	var tlsCertHash []byte
+	if len(certificate.Certificate) > 0 {
+		tlsCertHash = util.ComputeSHA256(certificate.Certificate[0])
+	}
+
+	seekInfo := &ab.SeekInfo{
+		Start: &ab.SeekPosition{
+			Type: &ab.SeekPosition_Newest{Newest: &ab.SeekNewest{}},
+		},
+		Stop: &ab.SeekPosition{
+			Type: &ab.SeekPosition_Specified{Specified: &ab.SeekSpecified{Number: math.MaxUint64}},
+		},
+		Behavior: ab.SeekInfo_BLOCK_UNTIL_READY,
+	}
+	env, err := putils.CreateSignedEnvelopeWithTLSBinding(
+		pcommon.HeaderType_DELIVER_SEEK_INFO, channelID, localmsp.NewSigner(),
+		seekInfo, int32(0), uint64(0), tlsCertHash)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error signing seek envelope")
+	}
+
+	var streams []pb.Deliver_DeliverFilteredClient
+	for _, deliverClient := range deliverClients {
+		stream, err := deliverClient.DeliverFiltered(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err = stream.Send(env); err != nil {
+			return nil, errors.WithMessage(err, "error sending seek envelope")
+		}
+		streams = append(streams, stream)
+	}
+	return streams, nil
+}
+
+// waitForTxEvent waits until the transaction txid is found in a block
+// delivered by each of the streams, and returns an error if any of the peers
+// did not validate it
+func waitForTxEvent(ctx context.Context, txid string, streams []pb.Deliver_DeliverFilteredClient) error {
+	errs := make(chan error, len(streams))
+	for i, stream := range streams {
+		go func(i int, stream pb.Deliver_DeliverFilteredClient) {
+			code, err := readTxValidationCode(txid, stream)
+			if err != nil {
+				errs <- errors.WithMessage(err, fmt.Sprintf("error waiting for transaction %s from peer %d", txid, i))
+				return
+			}
+			logger.Infof("txid [%s] committed with status (%s) at peer %d", txid, code, i)
+			if code != pb.TxValidationCode_VALID {
+				errs <- errors.Errorf("transaction %s invalidated by peer %d with status (%s)", txid, i, code)
+				return
+			}
+			errs <- nil
+		}(i, stream)
+	}
+
+	for range streams {
+		select {
+		case err := <-errs:
+			if err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return errors.Errorf("timed out waiting for transaction %s to be committed", txid)
+		}
+	}
+	return nil
+}
+
+// readTxValidationCode reads filtered blocks from stream until it finds the
+// transaction txid, and returns its validation code
+func readTxValidationCode(txid string, stream pb.Deliver_DeliverFilteredClient) (pb.TxValidationCode, error) {
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return pb.TxValidationCode_INVALID_OTHER_REASON, err
+		}
+		switch r := resp.Type.(type) {
+		case *pb.DeliverResponse_FilteredBlock:
+			for _, tx := range r.FilteredBlock.FilteredTransactions {
+				if tx.Txid == txid {
+					return tx.TxValidationCode, nil
+				}
+			}
+		case *pb.DeliverResponse_Status:
+			return pb.TxValidationCode_INVALID_OTHER_REASON, errors.Errorf("deliver completed with status (%s) before the transaction was found", r.Status)
+		default:
+			return pb.TxValidationCode_INVALID_OTHER_REASON, errors.Errorf("received unexpected response type (%T)", r)
+		}
+	}
+}
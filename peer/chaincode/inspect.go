@@ -0,0 +1,126 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/common/ccpackage"
+	"github.com/hyperledger/fabric/core/common/ccprovider"
+	pcommon "github.com/hyperledger/fabric/protos/common"
+	"github.com/hyperledger/fabric/protos/msp"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/cobra"
+)
+
+const inspectDesc = "Inspect the specified chaincode package, in any format."
+
+// inspectCmd returns the cobra command for inspecting a package
+func inspectCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:       "inspect",
+		Short:     inspectDesc,
+		Long:      inspectDesc,
+		ValidArgs: []string{"1"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("peer chaincode inspect <package>")
+			}
+			return inspect(cmd.OutOrStdout(), args[0])
+		},
+	}
+}
+
+// inspect prints the chaincode of a package, its signatures and the statedb
+// indexes it holds
+func inspect(w io.Writer, ccpackfile string) error {
+	b, err := ioutil.ReadFile(ccpackfile)
+	if err != nil {
+		return err
+	}
+
+	ccpack, err := ccprovider.GetCCPackage(b)
+	if err != nil {
+		return fmt.Errorf("error reading chaincode package %s: %s", ccpackfile, err)
+	}
+
+	var instantiationPolicy []byte
+	var endorsements []*pb.Endorsement
+	switch p := ccpack.(type) {
+	case *ccprovider.TGZPackage:
+		fmt.Fprintln(w, "Format: tgz")
+		fmt.Fprintf(w, "Package ID: %s\n", p.GetPackageID())
+		fmt.Fprintf(w, "Label: %s\n", p.GetMetadata().Label)
+		instantiationPolicy = p.GetInstantiationPolicy()
+		endorsements = p.GetOwnerEndorsements()
+	case *ccprovider.SignedCDSPackage:
+		fmt.Fprintln(w, "Format: signed cds")
+		_, sDepSpec, err := ccpackage.ExtractSignedCCDepSpec(p.GetPackageObject().(*pcommon.Envelope))
+		if err != nil {
+			return err
+		}
+		instantiationPolicy = sDepSpec.InstantiationPolicy
+		endorsements = sDepSpec.OwnerEndorsements
+	default:
+		fmt.Fprintln(w, "Format: cds")
+	}
+
+	cds := ccpack.GetDepSpec()
+	ccid := cds.ChaincodeSpec.ChaincodeId
+	fmt.Fprintf(w, "Name: %s\n", ccid.Name)
+	fmt.Fprintf(w, "Version: %s\n", ccid.Version)
+	fmt.Fprintf(w, "Type: %s\n", strings.ToLower(cds.ChaincodeSpec.Type.String()))
+	fmt.Fprintf(w, "Path: %s\n", ccid.Path)
+	fmt.Fprintf(w, "Id: %s\n", hex.EncodeToString(ccpack.GetId()))
+
+	if instantiationPolicy != nil {
+		policy := &pcommon.SignaturePolicyEnvelope{}
+		if err := proto.Unmarshal(instantiationPolicy, policy); err != nil {
+			return fmt.Errorf("error reading instantiation policy: %s", err)
+		}
+		fmt.Fprintf(w, "Instantiation policy: %s\n", proto.CompactTextString(policy))
+	}
+	if len(endorsements) > 0 {
+		fmt.Fprintln(w, "Signed by:")
+		for _, endorsement := range endorsements {
+			sid := &msp.SerializedIdentity{}
+			if err := proto.Unmarshal(endorsement.Endorser, sid); err != nil {
+				return fmt.Errorf("error reading owner endorsement: %s", err)
+			}
+			fmt.Fprintf(w, "  %s\n", sid.Mspid)
+		}
+	}
+
+	// the statedb indexes are in the META-INF directory of the code package
+	if len(cds.CodePackage) == 0 {
+		return nil
+	}
+	files, err := ccpackage.ListCodePackage(cds.CodePackage)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Code package: %d files\n", len(files))
+	var indexes []string
+	for _, file := range files {
+		if strings.HasPrefix(file, "META-INF/statedb/") {
+			indexes = append(indexes, file)
+		}
+	}
+	if len(indexes) > 0 {
+		fmt.Fprintln(w, "Statedb indexes:")
+		for _, index := range indexes {
+			fmt.Fprintf(w, "  %s\n", index)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package chaincode
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/common/ccpackage"
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockCodePackageCDSFactory returns a deployment spec whose code package
+// holds a source file and a statedb index
+func mockCodePackageCDSFactory(spec *pb.ChaincodeSpec) (*pb.ChaincodeDeploymentSpec, error) {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	files := []string{
+		"src/some/go/package/cc.go", "package main",
+		"META-INF/statedb/couchdb/indexes/indexOwner.json", `{"index":{"fields":["owner"]},"ddoc":"indexOwnerDoc","name":"indexOwner","type":"json"}`,
+	}
+	for i := 0; i < len(files); i += 2 {
+		if err := tw.WriteHeader(&tar.Header{Name: files[i], Mode: 0644, Size: int64(len(files[i+1]))}); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write([]byte(files[i+1])); err != nil {
+			return nil, err
+		}
+	}
+	tw.Close()
+	gw.Close()
+	return &pb.ChaincodeDeploymentSpec{ChaincodeSpec: spec, CodePackage: buf.Bytes()}, nil
+}
+
+func createInspectedPackage(t *testing.T, ccpackfile string, args ...string) {
+	InitMSP()
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	cmd := packageCmd(&ChaincodeCmdFactory{Signer: signer}, mockCodePackageCDSFactory)
+	addFlags(cmd)
+	cmd.SetArgs(append([]string{"-n", "somecc", "-p", "some/go/package", "-v", "0"}, append(args, ccpackfile)...))
+	assert.NoError(t, cmd.Execute())
+}
+
+func inspectPackage(t *testing.T, ccpackfile string) string {
+	cmd := inspectCmd()
+	buf := &bytes.Buffer{}
+	cmd.SetOutput(buf)
+	cmd.SetArgs([]string{ccpackfile})
+	assert.NoError(t, cmd.Execute())
+	return buf.String()
+}
+
+func TestInspectTGZPackage(t *testing.T) {
+	pdir := newTempDir()
+	defer os.RemoveAll(pdir)
+
+	ccpackfile := pdir + "/ccpack.tgz"
+	createInspectedPackage(t, ccpackfile, "--format", "tgz", "--label", "mylabel", "-s", "-S", "-i", "AND('DEFAULT.admin')")
+	b, err := ioutil.ReadFile(ccpackfile)
+	assert.NoError(t, err)
+
+	output := inspectPackage(t, ccpackfile)
+	assert.Contains(t, output, "Format: tgz\n")
+	assert.Contains(t, output, "Package ID: "+ccpackage.PackageID("mylabel", b)+"\n")
+	assert.Contains(t, output, "Label: mylabel\n")
+	assert.Contains(t, output, "Name: somecc\nVersion: 0\nType: golang\nPath: some/go/package\n")
+	assert.Contains(t, output, "Instantiation policy: ")
+	assert.Contains(t, output, "Signed by:\n  DEFAULT\n")
+	assert.Contains(t, output, "Code package: 2 files\n")
+	assert.Contains(t, output, "Statedb indexes:\n  META-INF/statedb/couchdb/indexes/indexOwner.json\n")
+}
+
+func TestInspectCDSPackages(t *testing.T) {
+	pdir := newTempDir()
+	defer os.RemoveAll(pdir)
+
+	ccpackfile := pdir + "/ccpack.out"
+	createInspectedPackage(t, ccpackfile)
+	output := inspectPackage(t, ccpackfile)
+	assert.Contains(t, output, "Format: cds\n")
+	assert.Contains(t, output, "Name: somecc\nVersion: 0\n")
+	assert.NotContains(t, output, "Package ID")
+	assert.NotContains(t, output, "Signed by")
+	assert.Contains(t, output, "Statedb indexes:\n  META-INF/statedb/couchdb/indexes/indexOwner.json\n")
+
+	createInspectedPackage(t, ccpackfile, "-s", "-S")
+	output = inspectPackage(t, ccpackfile)
+	assert.Contains(t, output, "Format: signed cds\n")
+	assert.Contains(t, output, "Signed by:\n  DEFAULT\n")
+}
+
+func TestInspectErrors(t *testing.T) {
+	pdir := newTempDir()
+	defer os.RemoveAll(pdir)
+
+	cmd := inspectCmd()
+	cmd.SetOutput(ioutil.Discard)
+	cmd.SetArgs([]string{})
+	assert.EqualError(t, cmd.Execute(), "peer chaincode inspect <package>")
+
+	cmd.SetArgs([]string{pdir + "/missing"})
+	assert.Error(t, cmd.Execute())
+
+	ccpackfile := pdir + "/ccpack.out"
+	assert.NoError(t, ioutil.WriteFile(ccpackfile, []byte("really bad CC package"), 0600))
+	cmd.SetArgs([]string{ccpackfile})
+	assert.Error(t, cmd.Execute())
+}
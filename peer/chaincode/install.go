@@ -13,6 +13,7 @@ import (
 	"github.com/golang/protobuf/proto"
 	"golang.org/x/net/context"
 
+	"github.com/hyperledger/fabric/core/chaincode/shim"
 	"github.com/hyperledger/fabric/core/common/ccpackage"
 	"github.com/hyperledger/fabric/core/common/ccprovider"
 	"github.com/hyperledger/fabric/peer/common"
@@ -56,14 +57,14 @@ func installCmd(cf *ChaincodeCmdFactory) *cobra.Command {
 	return chaincodeInstallCmd
 }
 
-//install the depspec to "peer.address"
-func install(msg proto.Message, cf *ChaincodeCmdFactory) error {
+//install the package to "peer.address"
+func install(ccpackbytes []byte, cf *ChaincodeCmdFactory) error {
 	creator, err := cf.Signer.Serialize()
 	if err != nil {
 		return fmt.Errorf("Error serializing identity for %s: %s", cf.Signer.GetIdentifier(), err)
 	}
 
-	prop, _, err := utils.CreateInstallProposalFromCDS(msg, creator)
+	prop, _, err := utils.CreateInstallProposalFromPackage(ccpackbytes, creator)
 	if err != nil {
 		return fmt.Errorf("Error creating proposal  %s: %s", chainFuncName, err)
 	}
@@ -81,6 +82,11 @@ func install(msg proto.Message, cf *ChaincodeCmdFactory) error {
 
 	if proposalResponse != nil {
 		logger.Debugf("Installed remotely %v", proposalResponse)
+
+		//the peer returns the package ID of the tar.gz packages it installs
+		if ccpackage.IsTGZ(ccpackbytes) && proposalResponse.Response != nil && proposalResponse.Response.Status < shim.ERRORTHRESHOLD {
+			fmt.Printf("Installed package with ID %s\n", proposalResponse.Response.Payload)
+		}
 	}
 
 	return nil
@@ -106,18 +112,23 @@ func genChaincodeDeploymentSpec(cmd *cobra.Command, chaincodeName, chaincodeVers
 }
 
 //getPackageFromFile get the chaincode package from file and the extracted ChaincodeDeploymentSpec
-func getPackageFromFile(ccpackfile string) (proto.Message, *pb.ChaincodeDeploymentSpec, error) {
+func getPackageFromFile(ccpackfile string) ([]byte, *pb.ChaincodeDeploymentSpec, error) {
 	b, err := ioutil.ReadFile(ccpackfile)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	//the bytes should be a valid package (CDS or SignedCDS)
+	//the bytes should be a valid package (CDS, SignedCDS or tar.gz)
 	ccpack, err := ccprovider.GetCCPackage(b)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	//a tar.gz package is installed as is
+	if tgzpack, ok := ccpack.(*ccprovider.TGZPackage); ok {
+		return b, tgzpack.GetDepSpec(), nil
+	}
+
 	//either CDS or Envelope
 	o := ccpack.GetPackageObject()
 
@@ -143,7 +154,7 @@ func getPackageFromFile(ccpackfile string) (proto.Message, *pb.ChaincodeDeployme
 		}
 	}
 
-	return o, cds, nil
+	return b, cds, nil
 }
 
 // chaincodeInstall installs the chaincode. If remoteinstall, does it via a lscc call
@@ -156,21 +167,25 @@ func chaincodeInstall(cmd *cobra.Command, ccpackfile string, cf *ChaincodeCmdFac
 		}
 	}
 
-	var ccpackmsg proto.Message
+	var ccpackbytes []byte
 	if ccpackfile == "" {
 		if chaincodePath == common.UndefinedParamValue || chaincodeVersion == common.UndefinedParamValue || chaincodeName == common.UndefinedParamValue {
 			return fmt.Errorf("Must supply value for %s name, path and version parameters.", chainFuncName)
 		}
 		//generate a raw ChaincodeDeploymentSpec
-		ccpackmsg, err = genChaincodeDeploymentSpec(cmd, chaincodeName, chaincodeVersion)
+		cds, err := genChaincodeDeploymentSpec(cmd, chaincodeName, chaincodeVersion)
 		if err != nil {
 			return err
 		}
+		ccpackbytes, err = proto.Marshal(cds)
+		if err != nil {
+			return fmt.Errorf("Error marshalling chaincode deployment spec : %s", err)
+		}
 	} else {
 		//read in a package generated by the "package" sub-command (and perhaps signed
 		//by multiple owners with the "signpackage" sub-command)
 		var cds *pb.ChaincodeDeploymentSpec
-		ccpackmsg, cds, err = getPackageFromFile(ccpackfile)
+		ccpackbytes, cds, err = getPackageFromFile(ccpackfile)
 
 		if err != nil {
 			return err
@@ -191,7 +206,7 @@ func chaincodeInstall(cmd *cobra.Command, ccpackfile string, cf *ChaincodeCmdFac
 		}
 	}
 
-	err = install(ccpackmsg, cf)
+	err = install(ccpackbytes, cf)
 
 	return err
 }
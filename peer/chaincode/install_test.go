@@ -24,9 +24,13 @@ import (
 
 	"github.com/hyperledger/fabric/peer/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
 )
 
 func initInstallTest(fsPath string, t *testing.T) (*cobra.Command, *ChaincodeCmdFactory) {
@@ -126,6 +130,59 @@ func TestInstallFromPackage(t *testing.T) {
 	}
 }
 
+// recordingEndorserClient records the proposals sent to an endorser
+type recordingEndorserClient struct {
+	pb.EndorserClient
+	proposals []*pb.SignedProposal
+}
+
+func (c *recordingEndorserClient) ProcessProposal(ctx context.Context, in *pb.SignedProposal, opts ...grpc.CallOption) (*pb.ProposalResponse, error) {
+	c.proposals = append(c.proposals, in)
+	return c.EndorserClient.ProcessProposal(ctx, in, opts...)
+}
+
+// TestInstallFromTGZPackage installs a tar.gz package as is
+func TestInstallFromTGZPackage(t *testing.T) {
+	pdir := newTempDir()
+	defer os.RemoveAll(pdir)
+
+	ccpackfile := pdir + "/ccpack.tgz"
+	err := createSignedCDSPackage([]string{"-n", "somecc", "-p", "some/go/package", "-v", "0", "--format", "tgz", ccpackfile}, false)
+	assert.NoError(t, err)
+
+	fsPath := "/tmp/installtest"
+
+	cmd, mockCF := initInstallTest(fsPath, t)
+	defer cleanupInstallTest(fsPath)
+
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Endorsement: &pb.Endorsement{},
+	}
+
+	endorserClient := &recordingEndorserClient{EndorserClient: common.GetMockEndorserClient(mockResponse, nil)}
+	mockCF.EndorserClient = endorserClient
+
+	cmd.SetArgs([]string{ccpackfile})
+	assert.NoError(t, cmd.Execute())
+
+	assert.Len(t, endorserClient.proposals, 1)
+	prop, err := utils.GetProposal(endorserClient.proposals[0].ProposalBytes)
+	assert.NoError(t, err)
+	cis, err := utils.GetChaincodeInvocationSpec(prop)
+	assert.NoError(t, err)
+	b, err := ioutil.ReadFile(ccpackfile)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("install"), b}, cis.ChaincodeSpec.Input.Args)
+
+	// the name and the version given are checked against the package
+	cmd, mockCF = initInstallTest(fsPath, t)
+	mockCF.EndorserClient = endorserClient
+	cmd.SetArgs([]string{"-n", "othercc", ccpackfile})
+	assert.EqualError(t, cmd.Execute(), "chaincode name othercc does not match name somecc in package")
+	chaincodeName = common.UndefinedParamValue
+}
+
 // TestInstallFromBadPackage tests bad package failure
 func TestInstallFromBadPackage(t *testing.T) {
 	pdir := newTempDir()
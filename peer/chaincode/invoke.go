@@ -40,6 +40,10 @@ func invokeCmd(cf *ChaincodeCmdFactory) *cobra.Command {
 		"name",
 		"ctor",
 		"channelID",
+		"peerAddresses",
+		"tlsRootCertFiles",
+		"waitForEvent",
+		"waitForEventTimeout",
 	}
 	attachFlags(chaincodeInvokeCmd, flagList)
 
@@ -18,9 +18,11 @@ package chaincode
 
 import (
 	"bytes"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/util"
@@ -30,7 +32,10 @@ import (
 	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	logging "github.com/op/go-logging"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
 )
 
 func TestInvokeCmd(t *testing.T) {
@@ -177,6 +182,216 @@ func TestInvokeCmdEndorsementFailure(t *testing.T) {
 
 }
 
+func TestInvokeCmdMultiplePeers(t *testing.T) {
+	InitMSP()
+	defer resetFlags()
+	mockCF, err := getMockChaincodeCmdFactory()
+	assert.NoError(t, err, "Error getting mock chaincode command factory")
+
+	getPeerEndorserClient := common.GetPeerEndorserClientFnc
+	getOrdererEndpointOfChain := common.GetOrdererEndpointOfChainFnc
+	getBroadcastClient := common.GetBroadcastClientFnc
+	defer func() {
+		common.GetPeerEndorserClientFnc = getPeerEndorserClient
+		common.GetOrdererEndpointOfChainFnc = getOrdererEndpointOfChain
+		common.GetBroadcastClientFnc = getBroadcastClient
+		viper.Set("peer.tls.enabled", false)
+	}()
+	var addresses []string
+	common.GetPeerEndorserClientFnc = func(address, tlsRootCertFile string) (pb.EndorserClient, error) {
+		addresses = append(addresses, address)
+		return mockCF.EndorserClient, nil
+	}
+	common.GetOrdererEndpointOfChainFnc = func(chainID string, signer msp.SigningIdentity, endorserClient pb.EndorserClient) ([]string, error) {
+		return []string{"localhost:9999"}, nil
+	}
+	common.GetBroadcastClientFnc = func() (common.BroadcastClient, error) {
+		return mockCF.BroadcastClient, nil
+	}
+
+	cmd := invokeCmd(nil)
+	addFlags(cmd)
+	args := []string{"-n", "example02", "-c", "{\"Args\": [\"invoke\",\"a\",\"b\",\"10\"]}", "-C", "mychannel",
+		"--peerAddresses", "peer0:7051", "--peerAddresses", "peer1:7051"}
+	cmd.SetArgs(args)
+	err = cmd.Execute()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"peer0:7051", "peer1:7051"}, addresses)
+
+	// with TLS enabled, a root cert file is required for each peer
+	viper.Set("peer.tls.enabled", true)
+	resetFlags()
+	cmd = invokeCmd(nil)
+	addFlags(cmd)
+	cmd.SetArgs(append(args, "--tlsRootCertFiles", "ca0.crt"))
+	err = cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the number of peer addresses (2) does not match the number of TLS root cert files (1)")
+}
+
+func TestChaincodeInvokeOrQueryMismatchedResponses(t *testing.T) {
+	InitMSP()
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	endorserClients := []pb.EndorserClient{
+		common.GetMockEndorserClient(&pb.ProposalResponse{
+			Response:    &pb.Response{Status: 200},
+			Payload:     []byte("payload"),
+			Endorsement: &pb.Endorsement{},
+		}, nil),
+		common.GetMockEndorserClient(&pb.ProposalResponse{
+			Response:    &pb.Response{Status: 200},
+			Payload:     []byte("another payload"),
+			Endorsement: &pb.Endorsement{},
+		}, nil),
+	}
+	_, err = ChaincodeInvokeOrQuery(createCIS().ChaincodeSpec, "mychannel", true, signer, tls.Certificate{},
+		endorserClients, nil, common.GetMockBroadcastClient(nil))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the proposal response from peer 1 does not match the one from peer 0")
+
+	// a query only returns the first response
+	resp, err := ChaincodeInvokeOrQuery(createCIS().ChaincodeSpec, "mychannel", false, signer, tls.Certificate{},
+		endorserClients, nil, common.GetMockBroadcastClient(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("payload"), resp.Payload)
+}
+
+func TestChaincodeInvokeOrQueryWaitForEvent(t *testing.T) {
+	InitMSP()
+	defer resetFlags()
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+	mockResponse := &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200},
+		Endorsement: &pb.Endorsement{},
+	}
+	endorserClients := []pb.EndorserClient{
+		common.GetMockEndorserClient(mockResponse, nil),
+		common.GetMockEndorserClient(mockResponse, nil),
+	}
+	waitForEventTimeout = 5 * time.Second
+
+	// the transaction is valid on all peers
+	bc := newMockTxBroadcastClient()
+	deliverClients := []pb.DeliverClient{
+		&mockDeliverClient{bc: bc, code: pb.TxValidationCode_VALID},
+		&mockDeliverClient{bc: bc, code: pb.TxValidationCode_VALID},
+	}
+	_, err = ChaincodeInvokeOrQuery(createCIS().ChaincodeSpec, "mychannel", true, signer, tls.Certificate{},
+		endorserClients, deliverClients, bc)
+	assert.NoError(t, err)
+
+	// the transaction is invalidated by a peer
+	bc = newMockTxBroadcastClient()
+	deliverClients = []pb.DeliverClient{
+		&mockDeliverClient{bc: bc, code: pb.TxValidationCode_VALID},
+		&mockDeliverClient{bc: bc, code: pb.TxValidationCode_MVCC_READ_CONFLICT},
+	}
+	_, err = ChaincodeInvokeOrQuery(createCIS().ChaincodeSpec, "mychannel", true, signer, tls.Certificate{},
+		endorserClients, deliverClients, bc)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalidated by peer 1 with status (MVCC_READ_CONFLICT)")
+
+	// the deliver service fails
+	bc = newMockTxBroadcastClient()
+	deliverClients = []pb.DeliverClient{
+		&mockDeliverClient{bc: bc, err: errors.New("deliver error")},
+	}
+	_, err = ChaincodeInvokeOrQuery(createCIS().ChaincodeSpec, "mychannel", true, signer, tls.Certificate{},
+		endorserClients[:1], deliverClients, bc)
+	assert.EqualError(t, err, "Error connecting to deliver service: deliver error")
+
+	// the transaction is never committed
+	waitForEventTimeout = 100 * time.Millisecond
+	bc = newMockTxBroadcastClient()
+	deliverClients = []pb.DeliverClient{
+		&mockDeliverClient{bc: bc, code: pb.TxValidationCode_VALID, block: true},
+	}
+	_, err = ChaincodeInvokeOrQuery(createCIS().ChaincodeSpec, "mychannel", true, signer, tls.Certificate{},
+		endorserClients[:1], deliverClients, bc)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out waiting for transaction")
+}
+
+// mockTxBroadcastClient records the transaction which is sent for ordering
+type mockTxBroadcastClient struct {
+	sent chan struct{}
+	txid string
+}
+
+func newMockTxBroadcastClient() *mockTxBroadcastClient {
+	return &mockTxBroadcastClient{sent: make(chan struct{})}
+}
+
+func (m *mockTxBroadcastClient) Send(env *cb.Envelope) error {
+	payload, err := utils.UnmarshalPayload(env.Payload)
+	if err != nil {
+		return err
+	}
+	chdr, err := utils.UnmarshalChannelHeader(payload.Header.ChannelHeader)
+	if err != nil {
+		return err
+	}
+	m.txid = chdr.TxId
+	close(m.sent)
+	return nil
+}
+
+func (m *mockTxBroadcastClient) Close() error {
+	return nil
+}
+
+// mockDeliverClient delivers a filtered block containing the transaction
+// sent through bc with the given validation code
+type mockDeliverClient struct {
+	bc    *mockTxBroadcastClient
+	code  pb.TxValidationCode
+	err   error
+	block bool
+}
+
+func (m *mockDeliverClient) Deliver(ctx context.Context, opts ...grpc.CallOption) (pb.Deliver_DeliverClient, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockDeliverClient) DeliverFiltered(ctx context.Context, opts ...grpc.CallOption) (pb.Deliver_DeliverFilteredClient, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &mockDeliverFilteredStream{ctx: ctx, client: m}, nil
+}
+
+type mockDeliverFilteredStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	client *mockDeliverClient
+}
+
+func (m *mockDeliverFilteredStream) Send(env *cb.Envelope) error {
+	return nil
+}
+
+func (m *mockDeliverFilteredStream) Recv() (*pb.DeliverResponse, error) {
+	<-m.client.bc.sent
+	if m.client.block {
+		<-m.ctx.Done()
+		return nil, m.ctx.Err()
+	}
+	return &pb.DeliverResponse{
+		Type: &pb.DeliverResponse_FilteredBlock{
+			FilteredBlock: &pb.FilteredBlock{
+				ChannelId: "mychannel",
+				Number:    1,
+				FilteredTransactions: []*pb.FilteredTransaction{
+					{Txid: m.client.bc.txid, TxValidationCode: m.client.code},
+				},
+			},
+		},
+	}, nil
+}
+
 // Returns mock chaincode command factory
 func getMockChaincodeCmdFactory() (*ChaincodeCmdFactory, error) {
 	signer, err := common.GetDefaultSigner()
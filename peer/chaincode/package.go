@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"io/ioutil"
+	"strings"
 
 	"github.com/golang/protobuf/proto"
 	"github.com/spf13/cobra"
@@ -37,6 +38,8 @@ var chaincodePackageCmd *cobra.Command
 var createSignedCCDepSpec bool
 var signCCDepSpec bool
 var instantiationPolicy string
+var packageFormat string
+var packageLabel string
 
 const packageCmdName = "package"
 const packageDesc = "Package the specified chaincode into a deployment spec."
@@ -77,6 +80,8 @@ func packageCmd(cf *ChaincodeCmdFactory, cdsFact ccDepSpecFactory) *cobra.Comman
 	chaincodePackageCmd.Flags().BoolVarP(&createSignedCCDepSpec, "cc-package", "s", false, "create CC deployment spec for owner endorsements instead of raw CC deployment spec")
 	chaincodePackageCmd.Flags().BoolVarP(&signCCDepSpec, "sign", "S", false, "if creating CC deployment spec package for owner endorsements, also sign it with local MSP")
 	chaincodePackageCmd.Flags().StringVarP(&instantiationPolicy, "instantiate-policy", "i", "", "instantiation policy for the chaincode")
+	chaincodePackageCmd.Flags().StringVarP(&packageFormat, "format", "", "cds", "format of the package, either cds (deployment spec) or tgz (tar.gz with a metadata.json and a code.tar.gz)")
+	chaincodePackageCmd.Flags().StringVarP(&packageLabel, "label", "", "", "label of a tgz package, which its package ID is derived from (defaults to <name>_<version>)")
 
 	return chaincodePackageCmd
 }
@@ -89,6 +94,73 @@ func getInstantiationPolicy(policy string) (*pcommon.SignaturePolicyEnvelope, er
 	return p, nil
 }
 
+//getInstantiationPolicyOrDefault returns the instantiation policy given by
+//the user, or the default one
+func getInstantiationPolicyOrDefault() (*pcommon.SignaturePolicyEnvelope, error) {
+	ip := instantiationPolicy
+	if ip == "" {
+		//if an instantiation policy is not given, default
+		//to "admin  must sign chaincode instantiation proposals"
+		mspid, err := mspmgmt.GetLocalMSP().GetIdentifier()
+		if err != nil {
+			return nil, err
+		}
+		ip = "AND('" + mspid + ".admin')"
+	}
+
+	return getInstantiationPolicy(ip)
+}
+
+//getChaincodeTGZPackage returns a tar.gz package made of the metadata and the
+//code package of the ChaincodeDeploymentSpec, along with its label. If the
+//package is for owner endorsements, it also holds the instantiation policy
+//and (optionally) the signature of the local MSP
+func getChaincodeTGZPackage(cds *pb.ChaincodeDeploymentSpec, cf *ChaincodeCmdFactory) ([]byte, string, error) {
+	ccid := cds.ChaincodeSpec.ChaincodeId
+	label := packageLabel
+	if label == "" {
+		label = ccid.Name + "_" + ccid.Version
+	}
+	if err := ccpackage.ValidateLabel(label); err != nil {
+		return nil, "", err
+	}
+
+	tgz := &ccpackage.TGZ{
+		Metadata: &ccpackage.TGZMetadata{
+			Type:    strings.ToLower(cds.ChaincodeSpec.Type.String()),
+			Path:    ccid.Path,
+			Label:   label,
+			Name:    ccid.Name,
+			Version: ccid.Version,
+		},
+		Code: cds.CodePackage,
+	}
+
+	if createSignedCCDepSpec {
+		sp, err := getInstantiationPolicyOrDefault()
+		if err != nil {
+			return nil, "", err
+		}
+		tgz.InstantiationPolicy = utils.MarshalOrPanic(sp)
+
+		if signCCDepSpec {
+			if cf.Signer == nil {
+				return nil, "", fmt.Errorf("Error getting signer")
+			}
+			if err = tgz.Sign(cf.Signer); err != nil {
+				return nil, "", err
+			}
+		}
+	}
+
+	b, err := tgz.Bytes()
+	if err != nil {
+		return nil, "", fmt.Errorf("Error creating chaincode package : %s", err)
+	}
+
+	return b, label, nil
+}
+
 //getChaincodeInstallPackage returns either a raw ChaincodeDeploymentSpec or
 //a Envelope with ChaincodeDeploymentSpec and (optional) signature
 func getChaincodeInstallPackage(cds *pb.ChaincodeDeploymentSpec, cf *ChaincodeCmdFactory) ([]byte, error) {
@@ -115,18 +187,7 @@ func getChaincodeInstallPackage(cds *pb.ChaincodeDeploymentSpec, cf *ChaincodeCm
 		}
 	}
 
-	ip := instantiationPolicy
-	if ip == "" {
-		//if an instantiation policy is not given, default
-		//to "admin  must sign chaincode instantiation proposals"
-		mspid, err := mspmgmt.GetLocalMSP().GetIdentifier()
-		if err != nil {
-			return nil, err
-		}
-		ip = "AND('" + mspid + ".admin')"
-	}
-
-	sp, err := getInstantiationPolicy(ip)
+	sp, err := getInstantiationPolicyOrDefault()
 	if err != nil {
 		return nil, err
 	}
@@ -161,6 +222,10 @@ func chaincodePackage(cmd *cobra.Command, args []string, cdsFact ccDepSpecFactor
 			return err
 		}
 	}
+	if packageFormat != "cds" && packageFormat != "tgz" {
+		return fmt.Errorf("unknown package format %s, expected cds or tgz", packageFormat)
+	}
+
 	spec, err := getChaincodeSpec(cmd)
 	if err != nil {
 		return err
@@ -172,7 +237,13 @@ func chaincodePackage(cmd *cobra.Command, args []string, cdsFact ccDepSpecFactor
 	}
 
 	var bytesToWrite []byte
-	if createSignedCCDepSpec {
+	var label string
+	if packageFormat == "tgz" {
+		bytesToWrite, label, err = getChaincodeTGZPackage(cds, cf)
+		if err != nil {
+			return err
+		}
+	} else if createSignedCCDepSpec {
 		bytesToWrite, err = getChaincodeInstallPackage(cds, cf)
 		if err != nil {
 			return err
@@ -189,5 +260,9 @@ func chaincodePackage(cmd *cobra.Command, args []string, cdsFact ccDepSpecFactor
 		return err
 	}
 
+	if packageFormat == "tgz" {
+		fmt.Printf("Wrote package %s with ID %s\n", fileToWrite, ccpackage.PackageID(label, bytesToWrite))
+	}
+
 	return err
 }
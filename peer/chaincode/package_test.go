@@ -24,10 +24,12 @@ import (
 
 	"github.com/golang/protobuf/proto"
 
+	"github.com/hyperledger/fabric/core/common/ccpackage"
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/peer/common"
 	pcommon "github.com/hyperledger/fabric/protos/common"
 	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
 )
 
 func newTempDir() string {
@@ -202,3 +204,64 @@ func TestInvalidPolicy(t *testing.T) {
 		t.Fatalf("Expected error with nil signer but succeeded")
 	}
 }
+
+// TestTGZPackage generates a tar.gz package with a metadata.json and a code.tar.gz
+func TestTGZPackage(t *testing.T) {
+	pdir := newTempDir()
+	defer os.RemoveAll(pdir)
+
+	ccpackfile := pdir + "/ccpack.tgz"
+	err := createSignedCDSPackage([]string{"-n", "somecc", "-p", "some/go/package", "-v", "0", "--format", "tgz", ccpackfile}, false)
+	assert.NoError(t, err)
+
+	b, err := ioutil.ReadFile(ccpackfile)
+	assert.NoError(t, err)
+	tgz, err := ccpackage.ParseTGZ(b)
+	assert.NoError(t, err)
+	assert.Equal(t, &ccpackage.TGZMetadata{Type: "golang", Path: "some/go/package", Label: "somecc_0", Name: "somecc", Version: "0"}, tgz.Metadata)
+	assert.Equal(t, []byte("somecode"), tgz.Code)
+	assert.Nil(t, tgz.InstantiationPolicy)
+	assert.Empty(t, tgz.OwnerEndorsements)
+
+	err = createSignedCDSPackage([]string{"-n", "somecc", "-p", "some/go/package", "-v", "0", "--format", "tgz", "--label", "mylabel", ccpackfile}, false)
+	assert.NoError(t, err)
+	b, err = ioutil.ReadFile(ccpackfile)
+	assert.NoError(t, err)
+	tgz, err = ccpackage.ParseTGZ(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "mylabel", tgz.Metadata.Label)
+}
+
+// TestSignedTGZPackage generates a tar.gz package with an instantiation policy
+// and signs it with local MSP
+func TestSignedTGZPackage(t *testing.T) {
+	pdir := newTempDir()
+	defer os.RemoveAll(pdir)
+
+	ccpackfile := pdir + "/ccpack.tgz"
+	err := createSignedCDSPackage([]string{"-n", "somecc", "-p", "some/go/package", "-v", "0", "--format", "tgz", "-s", "-S", ccpackfile}, true)
+	assert.NoError(t, err)
+
+	b, err := ioutil.ReadFile(ccpackfile)
+	assert.NoError(t, err)
+	tgz, err := ccpackage.ParseTGZ(b)
+	assert.NoError(t, err)
+	assert.NotNil(t, tgz.InstantiationPolicy)
+	assert.Len(t, tgz.OwnerEndorsements, 1)
+
+	err = createSignedCDSPackage([]string{"-n", "somecc", "-p", "some/go/package", "-v", "0", "--format", "tgz", "-s", "-S", ccpackfile}, false)
+	assert.EqualError(t, err, "Error getting signer")
+}
+
+func TestInvalidTGZPackage(t *testing.T) {
+	pdir := newTempDir()
+	defer os.RemoveAll(pdir)
+
+	ccpackfile := pdir + "/ccpack.tgz"
+	err := createSignedCDSPackage([]string{"-n", "somecc", "-p", "some/go/package", "-v", "0", "--format", "zip", ccpackfile}, false)
+	assert.EqualError(t, err, "unknown package format zip, expected cds or tgz")
+
+	err = createSignedCDSPackage([]string{"-n", "somecc", "-p", "some/go/package", "-v", "0", "--format", "tgz", "--label", "my:label", ccpackfile}, false)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid label 'my:label'")
+}
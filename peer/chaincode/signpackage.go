@@ -58,6 +58,10 @@ func signpackage(cmd *cobra.Command, ipackageFile string, opackageFile string, c
 		return err
 	}
 
+	if ccpackage.IsTGZ(b) {
+		return signTGZPackage(b, opackageFile, cf)
+	}
+
 	env := utils.UnmarshalEnvelopeOrPanic(b)
 
 	env, err = ccpackage.SignExistingPackage(env, cf.Signer)
@@ -75,3 +79,30 @@ func signpackage(cmd *cobra.Command, ipackageFile string, opackageFile string, c
 
 	return nil
 }
+
+// signTGZPackage adds the signature of the local MSP to a tar.gz package. The
+// package ID changes with the signatures of the package
+func signTGZPackage(b []byte, opackageFile string, cf *ChaincodeCmdFactory) error {
+	tgz, err := ccpackage.ParseTGZ(b)
+	if err != nil {
+		return err
+	}
+
+	if err = tgz.Sign(cf.Signer); err != nil {
+		return err
+	}
+
+	b, err = tgz.Bytes()
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(opackageFile, b, 0700)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote signed package to %s successfully with ID %s\n", opackageFile, ccpackage.PackageID(tgz.Metadata.Label, b))
+
+	return nil
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 
+	"github.com/hyperledger/fabric/core/common/ccpackage"
 	"github.com/hyperledger/fabric/peer/common"
 	pcommon "github.com/hyperledger/fabric/protos/common"
 )
@@ -132,3 +133,46 @@ func TestFailSignUnsignedPackage(t *testing.T) {
 		t.Fatalf("expected signing a package that's not originally signed to fail")
 	}
 }
+
+// TestSignTGZPackage adds a signature to a tar.gz package
+func TestSignTGZPackage(t *testing.T) {
+	pdir := newTempDir()
+	defer os.RemoveAll(pdir)
+
+	ccpackfile := pdir + "/ccpack.tgz"
+	err := createSignedCDSPackage([]string{"-n", "somecc", "-p", "some/go/package", "-v", "0", "--format", "tgz", "-s", "-S", ccpackfile}, true)
+	if err != nil {
+		t.Fatalf("error creating signed :%v", err)
+	}
+
+	signedfile := pdir + "/signed.tgz"
+	err = signExistingPackage(nil, ccpackfile, signedfile)
+	if err != nil {
+		t.Fatalf("could not sign package: %v", err)
+	}
+
+	b, err := ioutil.ReadFile(signedfile)
+	if err != nil {
+		t.Fatalf("signed package file %s not created", signedfile)
+	}
+
+	tgz, err := ccpackage.ParseTGZ(b)
+	if err != nil {
+		t.Fatalf("could not parse signed package: %v", err)
+	}
+
+	if len(tgz.OwnerEndorsements) != 2 {
+		t.Fatalf("expected 2 endorserments but found %d", len(tgz.OwnerEndorsements))
+	}
+
+	//a package without instantiation policy cannot be signed
+	err = createSignedCDSPackage([]string{"-n", "somecc", "-p", "some/go/package", "-v", "0", "--format", "tgz", ccpackfile}, true)
+	if err != nil {
+		t.Fatalf("error creating package :%v", err)
+	}
+
+	err = signExistingPackage(nil, ccpackfile, signedfile)
+	if err == nil {
+		t.Fatalf("expected signing a package without instantiation policy to fail")
+	}
+}
@@ -30,8 +30,8 @@ import (
 
 const (
 	channelFuncName = "channel"
-	shortDes        = "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo."
-	longDes         = "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo."
+	shortDes        = "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo|gettxs|getblockbytime."
+	longDes         = "Operate a channel: create|fetch|join|list|update|signconfigtx|getinfo|gettxs|getblockbytime."
 )
 
 var logger = flogging.MustGetLogger("channelCmd")
@@ -54,6 +54,13 @@ var (
 	channelID     string
 	channelTxFile string
 	timeout       int
+
+	// transaction and block query related variables
+	startBlock     string
+	endBlock       string
+	creatorMSPID   string
+	chaincodeName  string
+	blockTimestamp string
 )
 
 // Cmd returns the cobra command for Node
@@ -67,6 +74,8 @@ func Cmd(cf *ChannelCmdFactory) *cobra.Command {
 	channelCmd.AddCommand(updateCmd(cf))
 	channelCmd.AddCommand(signconfigtxCmd(cf))
 	channelCmd.AddCommand(getinfoCmd(cf))
+	channelCmd.AddCommand(gettxsCmd(cf))
+	channelCmd.AddCommand(getblockbytimeCmd(cf))
 
 	return channelCmd
 }
@@ -90,6 +99,11 @@ func resetFlags() {
 	flags.StringVarP(&channelID, "channelID", "c", common.UndefinedParamValue, "In case of a newChain command, the channel ID to create.")
 	flags.StringVarP(&channelTxFile, "file", "f", "", "Configuration transaction file generated by a tool such as configtxgen for submitting to orderer")
 	flags.IntVarP(&timeout, "timeout", "t", 5, "Channel creation timeout")
+	flags.StringVarP(&startBlock, "startblock", "", "", "Number of the first block of the range of blocks to query, defaults to the genesis block")
+	flags.StringVarP(&endBlock, "endblock", "", "", "Number of the last block of the range of blocks to query, defaults to the newest block")
+	flags.StringVarP(&creatorMSPID, "creator", "", "", "MSP ID of the creator of the transactions to query")
+	flags.StringVarP(&chaincodeName, "chaincode", "", "", "Name of the chaincode touched by the transactions to query")
+	flags.StringVarP(&blockTimestamp, "timestamp", "", "", "Timestamp in RFC 3339 format, e.g. 2018-01-02T15:04:05Z")
 }
 
 func attachFlags(cmd *cobra.Command, names []string) {
@@ -0,0 +1,87 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/scc/qscc"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func getblockbytimeCmd(cf *ChannelCmdFactory) *cobra.Command {
+	getblockbytimeCmd := &cobra.Command{
+		Use:   "getblockbytime",
+		Short: "get the first block of a specified channel at or after a timestamp.",
+		Long:  "get the first block of a specified channel at or after a timestamp. Requires '-c' and '--timestamp'.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return getblockbytime(cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"timestamp",
+	}
+	attachFlags(getblockbytimeCmd, flagList)
+
+	return getblockbytimeCmd
+}
+
+func (cc *endorserClient) getBlockByTimestamp(timestamp time.Time) (*cb.Block, error) {
+	payload, err := cc.queryQSCC(qscc.GetBlockByTimestamp, channelID, timestamp.Format(time.RFC3339Nano))
+	if err != nil {
+		return nil, err
+	}
+
+	block := &cb.Block{}
+	err = proto.Unmarshal(payload, block)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read qscc response")
+	}
+
+	return block, nil
+}
+
+func getblockbytime(cf *ChannelCmdFactory) error {
+	//the global chainID filled by the "-c" command
+	if channelID == common.UndefinedParamValue {
+		return errors.New("Must supply channel ID")
+	}
+	if blockTimestamp == "" {
+		return errors.New("Must supply timestamp")
+	}
+	timestamp, err := time.Parse(time.RFC3339Nano, blockTimestamp)
+	if err != nil {
+		return errors.Wrap(err, "timestamp must be in RFC 3339 format")
+	}
+
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserRequired, OrdererNotRequired)
+		if err != nil {
+			return err
+		}
+	}
+
+	client := &endorserClient{cf}
+
+	block, err := client.getBlockByTimestamp(timestamp)
+	if err != nil {
+		return err
+	}
+	if block.Header == nil {
+		return errors.New("received block without header")
+	}
+
+	fmt.Printf("Block number: %d\n", block.Header.Number)
+
+	return nil
+}
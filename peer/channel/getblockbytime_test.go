@@ -0,0 +1,75 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/peer/common"
+	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBlockByTime(t *testing.T) {
+	InitMSP()
+	resetFlags()
+
+	mockPayload, err := proto.Marshal(cb.NewBlock(5, []byte("PreviousHash")))
+	assert.NoError(t, err)
+
+	mockResponse := &pb.ProposalResponse{
+		Response: &pb.Response{
+			Status:  200,
+			Payload: mockPayload,
+		},
+		Endorsement: &pb.Endorsement{},
+	}
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	cmd := getblockbytimeCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel, "--timestamp", "2018-01-02T15:04:05Z"})
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestGetBlockByTimeBadParameters(t *testing.T) {
+	InitMSP()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	mockCF := &ChannelCmdFactory{
+		Signer: signer,
+	}
+
+	for _, testCase := range []struct {
+		args        []string
+		expectedErr string
+	}{
+		{args: []string{}, expectedErr: "Must supply channel ID"},
+		{args: []string{"-c", mockChannel}, expectedErr: "Must supply timestamp"},
+		{args: []string{"-c", mockChannel, "--timestamp", "yesterday"}, expectedErr: "timestamp must be in RFC 3339 format"},
+	} {
+		resetFlags()
+		cmd := getblockbytimeCmd(mockCF)
+		AddFlags(cmd)
+		cmd.SetArgs(testCase.args)
+		err := cmd.Execute()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), testCase.expectedErr)
+	}
+}
@@ -40,13 +40,35 @@ func getinfoCmd(cf *ChannelCmdFactory) *cobra.Command {
 	return getinfoCmd
 }
 func (cc *endorserClient) getBlockChainInfo() (*cb.BlockchainInfo, error) {
+	payload, err := cc.queryQSCC(qscc.GetChainInfo, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	blockChainInfo := &cb.BlockchainInfo{}
+	err = proto.Unmarshal(payload, blockChainInfo)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read qscc response")
+	}
+
+	return blockChainInfo, nil
+
+}
+
+// queryQSCC invokes the given function of qscc with the given arguments
+// and returns the payload of the response
+func (cc *endorserClient) queryQSCC(fname string, args ...string) ([]byte, error) {
 	var err error
 
+	input := &pb.ChaincodeInput{Args: [][]byte{[]byte(fname)}}
+	for _, arg := range args {
+		input.Args = append(input.Args, []byte(arg))
+	}
 	invocation := &pb.ChaincodeInvocationSpec{
 		ChaincodeSpec: &pb.ChaincodeSpec{
 			Type:        pb.ChaincodeSpec_Type(pb.ChaincodeSpec_Type_value["GOLANG"]),
 			ChaincodeId: &pb.ChaincodeID{Name: "qscc"},
-			Input:       &pb.ChaincodeInput{Args: [][]byte{[]byte(qscc.GetChainInfo), []byte(channelID)}},
+			Input:       input,
 		},
 	}
 
@@ -72,14 +94,7 @@ func (cc *endorserClient) getBlockChainInfo() (*cb.BlockchainInfo, error) {
 		return nil, errors.Errorf("received bad response, status %d", proposalResp.Response.Status)
 	}
 
-	blockChainInfo := &cb.BlockchainInfo{}
-	err = proto.Unmarshal(proposalResp.Response.Payload, blockChainInfo)
-	if err != nil {
-		return nil, errors.Wrap(err, "cannot read qscc response")
-	}
-
-	return blockChainInfo, nil
-
+	return proposalResp.Response.Payload, nil
 }
 
 func getinfo(cf *ChannelCmdFactory) error {
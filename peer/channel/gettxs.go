@@ -0,0 +1,107 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/core/scc/qscc"
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+func gettxsCmd(cf *ChannelCmdFactory) *cobra.Command {
+	gettxsCmd := &cobra.Command{
+		Use:   "gettxs",
+		Short: "list the transactions of a specified channel along with their validation codes.",
+		Long: "list the transactions of a range of blocks of a specified channel along with their validation codes. " +
+			"Requires '-c'. The transactions can be restricted to the ones created by an MSP with '--creator', " +
+			"or to the ones that touched a chaincode with '--chaincode'.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return gettxs(cf)
+		},
+	}
+	flagList := []string{
+		"channelID",
+		"startblock",
+		"endblock",
+		"creator",
+		"chaincode",
+	}
+	attachFlags(gettxsCmd, flagList)
+
+	return gettxsCmd
+}
+
+// getTxStatuses queries the transactions of the blocks starting at start. The response
+// ends before the end of the range if the peer stopped the query at its result limit.
+func (cc *endorserClient) getTxStatuses(start string) (*pb.TransactionStatuses, error) {
+	var payload []byte
+	var err error
+	switch {
+	case creatorMSPID != "" && chaincodeName != "":
+		return nil, errors.New("'--creator' and '--chaincode' cannot be used together")
+	case creatorMSPID != "":
+		payload, err = cc.queryQSCC(qscc.GetTxStatusesByCreator, channelID, creatorMSPID, start, endBlock)
+	case chaincodeName != "":
+		payload, err = cc.queryQSCC(qscc.GetTxStatusesByChaincode, channelID, chaincodeName, start, endBlock)
+	default:
+		payload, err = cc.queryQSCC(qscc.GetTxStatusesByBlockRange, channelID, start, endBlock)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	txStatuses := &pb.TransactionStatuses{}
+	err = proto.Unmarshal(payload, txStatuses)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read qscc response")
+	}
+
+	return txStatuses, nil
+}
+
+func gettxs(cf *ChannelCmdFactory) error {
+	//the global chainID filled by the "-c" command
+	if channelID == common.UndefinedParamValue {
+		return errors.New("Must supply channel ID")
+	}
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory(EndorserRequired, OrdererNotRequired)
+		if err != nil {
+			return err
+		}
+	}
+
+	client := &endorserClient{cf}
+
+	txStatuses := &pb.TransactionStatuses{}
+	for start := startBlock; ; {
+		page, err := client.getTxStatuses(start)
+		if err != nil {
+			return err
+		}
+		txStatuses.Transactions = append(txStatuses.Transactions, page.Transactions...)
+		if page.NextBlockNumber == 0 {
+			break
+		}
+		start = strconv.FormatUint(page.NextBlockNumber, 10)
+	}
+
+	fmt.Printf("Transactions: %d\n", len(txStatuses.Transactions))
+	for _, txStatus := range txStatuses.Transactions {
+		fmt.Printf("%d\t%d\t%s\t%s\n", txStatus.BlockNumber, txStatus.TxNumber, txStatus.TxId, txStatus.ValidationCode)
+	}
+
+	return nil
+}
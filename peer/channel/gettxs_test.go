@@ -0,0 +1,165 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package channel
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/hyperledger/fabric/protos/utils"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+)
+
+func TestGetTxs(t *testing.T) {
+	InitMSP()
+
+	mockTxStatuses := &pb.TransactionStatuses{
+		Transactions: []*pb.TransactionStatus{
+			{TxId: "tx1", BlockNumber: 1, TxNumber: 0, ValidationCode: pb.TxValidationCode_VALID},
+			{TxId: "tx2", BlockNumber: 1, TxNumber: 1, ValidationCode: pb.TxValidationCode_MVCC_READ_CONFLICT},
+		},
+	}
+	mockPayload, err := proto.Marshal(mockTxStatuses)
+	assert.NoError(t, err)
+
+	mockResponse := &pb.ProposalResponse{
+		Response: &pb.Response{
+			Status:  200,
+			Payload: mockPayload,
+		},
+		Endorsement: &pb.Endorsement{},
+	}
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   common.GetMockEndorserClient(mockResponse, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	for _, args := range [][]string{
+		{"-c", mockChannel},
+		{"-c", mockChannel, "--startblock", "1", "--endblock", "5"},
+		{"-c", mockChannel, "--creator", "Org1MSP"},
+		{"-c", mockChannel, "--chaincode", "mycc", "--startblock", "1"},
+	} {
+		resetFlags()
+		cmd := gettxsCmd(mockCF)
+		AddFlags(cmd)
+		cmd.SetArgs(args)
+		assert.NoError(t, cmd.Execute(), "gettxs %v", args)
+	}
+
+	client := &endorserClient{mockCF}
+	txStatuses, err := client.getTxStatuses("")
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(mockTxStatuses, txStatuses))
+}
+
+// pagedEndorserClient returns its responses in turn and records the start blocks queried
+type pagedEndorserClient struct {
+	responses   []*pb.TransactionStatuses
+	startBlocks []string
+}
+
+func (c *pagedEndorserClient) ProcessProposal(ctx context.Context, in *pb.SignedProposal, opts ...grpc.CallOption) (*pb.ProposalResponse, error) {
+	prop, err := utils.GetProposal(in.ProposalBytes)
+	if err != nil {
+		return nil, err
+	}
+	cis, err := utils.GetChaincodeInvocationSpec(prop)
+	if err != nil {
+		return nil, err
+	}
+	c.startBlocks = append(c.startBlocks, string(cis.ChaincodeSpec.Input.Args[2]))
+
+	payload, err := proto.Marshal(c.responses[0])
+	if err != nil {
+		return nil, err
+	}
+	c.responses = c.responses[1:]
+	return &pb.ProposalResponse{
+		Response:    &pb.Response{Status: 200, Payload: payload},
+		Endorsement: &pb.Endorsement{},
+	}, nil
+}
+
+func TestGetTxsPaged(t *testing.T) {
+	InitMSP()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	endorserClient := &pagedEndorserClient{
+		responses: []*pb.TransactionStatuses{
+			{
+				Transactions:    []*pb.TransactionStatus{{TxId: "tx1", BlockNumber: 1}},
+				NextBlockNumber: 3,
+			},
+			{
+				Transactions: []*pb.TransactionStatus{{TxId: "tx2", BlockNumber: 3}},
+			},
+		},
+	}
+	mockCF := &ChannelCmdFactory{
+		EndorserClient:   endorserClient,
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	// the query is continued from the block returned by the peer until the range is complete
+	resetFlags()
+	cmd := gettxsCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel, "--startblock", "1"})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, []string{"1", "3"}, endorserClient.startBlocks)
+	assert.Empty(t, endorserClient.responses)
+}
+
+func TestGetTxsFailures(t *testing.T) {
+	InitMSP()
+
+	signer, err := common.GetDefaultSigner()
+	assert.NoError(t, err)
+
+	mockCF := &ChannelCmdFactory{
+		EndorserClient: common.GetMockEndorserClient(&pb.ProposalResponse{
+			Response:    &pb.Response{Status: 500, Message: "Failed to get transactions"},
+			Endorsement: &pb.Endorsement{},
+		}, nil),
+		BroadcastFactory: mockBroadcastClientFactory,
+		Signer:           signer,
+	}
+
+	// The channel ID is required
+	resetFlags()
+	cmd := gettxsCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{})
+	assert.EqualError(t, cmd.Execute(), "Must supply channel ID")
+
+	// The creator and the chaincode filters are mutually exclusive
+	resetFlags()
+	cmd = gettxsCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel, "--creator", "Org1MSP", "--chaincode", "mycc"})
+	assert.EqualError(t, cmd.Execute(), "'--creator' and '--chaincode' cannot be used together")
+
+	// Failed queries are reported
+	resetFlags()
+	cmd = gettxsCmd(mockCF)
+	AddFlags(cmd)
+	cmd.SetArgs([]string{"-c", mockChannel})
+	assert.EqualError(t, cmd.Execute(), "received bad response, status 500")
+}
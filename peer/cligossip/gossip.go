@@ -0,0 +1,54 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cligossip
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/spf13/cobra"
+)
+
+const (
+	gossipFuncName = "gossip"
+	shortDes       = "Gossip membership and leadership: peers|yield."
+	longDes        = "Gossip membership and leadership: peers|yield."
+)
+
+var channelID string
+
+// Cmd returns the cobra command for Gossip
+func Cmd(cf *GossipCmdFactory) *cobra.Command {
+	gossipCmd.AddCommand(peersCmd(cf))
+	gossipCmd.AddCommand(yieldCmd(cf))
+
+	return gossipCmd
+}
+
+var gossipCmd = &cobra.Command{
+	Use:   gossipFuncName,
+	Short: fmt.Sprint(shortDes),
+	Long:  fmt.Sprint(longDes),
+}
+
+// GossipCmdFactory holds the clients used by GossipCmd
+type GossipCmdFactory struct {
+	AdminClient pb.AdminClient
+}
+
+// InitCmdFactory init the GossipCmdFactory with default admin client
+func InitCmdFactory() (*GossipCmdFactory, error) {
+	adminClient, err := common.GetAdminClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return &GossipCmdFactory{
+		AdminClient: adminClient,
+	}, nil
+}
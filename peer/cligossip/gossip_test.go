@@ -0,0 +1,93 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cligossip
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hyperledger/fabric/peer/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPeersCmd(t *testing.T) {
+	membership := &pb.ChannelMembershipResponse{
+		Peers: []*pb.PeerMembershipInfo{
+			{
+				Endpoint:            "peer0.org1.example.com:7051",
+				LedgerHeight:        5,
+				Roles:               []string{"endorser", "committer"},
+				InstalledChaincodes: []*pb.ChaincodeInfo{{Name: "mycc", Version: "1.0"}, {Name: "mycc", Version: "2.0"}},
+				Chaincodes:          []*pb.ChaincodeInfo{{Name: "mycc", Version: "1.0"}},
+				Self:                true,
+			},
+			{
+				PkiId:        []byte{1, 2, 3},
+				LedgerHeight: 4,
+			},
+		},
+	}
+	cf := &GossipCmdFactory{
+		AdminClient: common.GetMockAdminClientWithMembership(membership, nil),
+	}
+	cmd := peersCmd(cf)
+
+	channelID = ""
+	cmd.SetArgs([]string{})
+	assert.EqualError(t, cmd.Execute(), "the required parameter 'channelID' is empty. Rerun the command with -C flag")
+
+	cmd.SetArgs([]string{"-C", "mychannel", "extra"})
+	assert.EqualError(t, cmd.Execute(), "more parameters than necessary were provided. Expected 0, received 1")
+
+	channelID = "mychannel"
+	out := &bytes.Buffer{}
+	err := peers(cf, cmd, []string{}, out)
+	assert.NoError(t, err)
+	assert.Equal(t, "Peers of channel mychannel:\n"+
+		"Endpoint: peer0.org1.example.com:7051 (self), Ledger height: 5, Roles: [endorser, committer], "+
+		"Installed chaincodes: [mycc:1.0, mycc:2.0], Instantiated chaincodes: [mycc:1.0]\n"+
+		"Endpoint: 010203, Ledger height: 4, Roles: [], Installed chaincodes: [], Instantiated chaincodes: []\n",
+		out.String())
+
+	cf.AdminClient = common.GetMockAdminClientWithMembership(nil, errors.New("peer is not a member of channel mychannel"))
+	err = peers(cf, cmd, []string{}, out)
+	assert.EqualError(t, err, "failed retrieving the peers of channel mychannel: peer is not a member of channel mychannel")
+}
+
+func TestCmd(t *testing.T) {
+	cmd := Cmd(&GossipCmdFactory{AdminClient: common.GetMockAdminClient(nil)})
+	assert.Equal(t, "gossip", cmd.Name())
+	assert.Len(t, cmd.Commands(), 2)
+	assert.Equal(t, "peers", cmd.Commands()[0].Name())
+	assert.Equal(t, "yield", cmd.Commands()[1].Name())
+}
+
+func TestYieldCmd(t *testing.T) {
+	cf := &GossipCmdFactory{
+		AdminClient: common.GetMockAdminClient(nil),
+	}
+	cmd := yieldCmd(cf)
+
+	channelID = ""
+	cmd.SetArgs([]string{})
+	assert.EqualError(t, cmd.Execute(), "the required parameter 'channelID' is empty. Rerun the command with -C flag")
+
+	cmd.SetArgs([]string{"-C", "mychannel", "extra"})
+	assert.EqualError(t, cmd.Execute(), "more parameters than necessary were provided. Expected 0, received 1")
+
+	channelID = "mychannel"
+	out := &bytes.Buffer{}
+	err := yield(cf, cmd, []string{}, out)
+	assert.NoError(t, err)
+	assert.Equal(t, "Yielded the leadership of channel mychannel\n", out.String())
+
+	cf.AdminClient = common.GetMockAdminClient(errors.New("peer is not a leader of channel mychannel"))
+	err = yield(cf, cmd, []string{}, out)
+	assert.EqualError(t, err, "failed yielding the leadership of channel mychannel: peer is not a leader of channel mychannel")
+}
@@ -0,0 +1,85 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cligossip
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+func peersCmd(cf *GossipCmdFactory) *cobra.Command {
+	var gossipPeersCmd = &cobra.Command{
+		Use:   "peers",
+		Short: "Lists the peers of a channel as seen by the peer.",
+		Long:  `Lists the peers of a channel as seen by the gossip layer of the peer, along with their ledger height, chaincodes and roles.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return peers(cf, cmd, args, os.Stdout)
+		},
+	}
+	flags := gossipPeersCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "C", "", "The channel whose peers are listed")
+
+	return gossipPeersCmd
+}
+
+func peers(cf *GossipCmdFactory, cmd *cobra.Command, args []string, out io.Writer) error {
+	if len(args) > 0 {
+		return errors.Errorf("more parameters than necessary were provided. Expected 0, received %d", len(args))
+	}
+	if channelID == "" {
+		return errors.New("the required parameter 'channelID' is empty. Rerun the command with -C flag")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory()
+		if err != nil {
+			return err
+		}
+	}
+	membership, err := cf.AdminClient.GetChannelMembership(context.Background(), &pb.ChannelMembershipRequest{ChannelId: channelID})
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed retrieving the peers of channel %s", channelID))
+	}
+
+	fmt.Fprintf(out, "Peers of channel %s:\n", channelID)
+	for _, p := range membership.Peers {
+		fmt.Fprintln(out, formatPeer(p))
+	}
+	return nil
+}
+
+func formatPeer(p *pb.PeerMembershipInfo) string {
+	endpoint := p.Endpoint
+	if endpoint == "" {
+		endpoint = hex.EncodeToString(p.PkiId)
+	}
+	if p.Self {
+		endpoint += " (self)"
+	}
+	return fmt.Sprintf("Endpoint: %s, Ledger height: %d, Roles: [%s], Installed chaincodes: [%s], Instantiated chaincodes: [%s]",
+		endpoint, p.LedgerHeight, strings.Join(p.Roles, ", "),
+		formatChaincodes(p.InstalledChaincodes), formatChaincodes(p.Chaincodes))
+}
+
+func formatChaincodes(chaincodes []*pb.ChaincodeInfo) string {
+	var ccs []string
+	for _, cc := range chaincodes {
+		ccs = append(ccs, fmt.Sprintf("%s:%s", cc.Name, cc.Version))
+	}
+	return strings.Join(ccs, ", ")
+}
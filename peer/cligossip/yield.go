@@ -0,0 +1,59 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cligossip
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	pb "github.com/hyperledger/fabric/protos/peer"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+)
+
+func yieldCmd(cf *GossipCmdFactory) *cobra.Command {
+	var gossipYieldCmd = &cobra.Command{
+		Use:   "yield",
+		Short: "Makes the peer yield its leadership of a channel.",
+		Long:  `Makes the peer relinquish its leadership of a channel, so that another peer of its organization is elected to pull blocks from the ordering service.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return yield(cf, cmd, args, os.Stdout)
+		},
+	}
+	flags := gossipYieldCmd.Flags()
+	flags.StringVarP(&channelID, "channelID", "C", "", "The channel whose leadership is yielded")
+
+	return gossipYieldCmd
+}
+
+func yield(cf *GossipCmdFactory, cmd *cobra.Command, args []string, out io.Writer) error {
+	if len(args) > 0 {
+		return errors.Errorf("more parameters than necessary were provided. Expected 0, received %d", len(args))
+	}
+	if channelID == "" {
+		return errors.New("the required parameter 'channelID' is empty. Rerun the command with -C flag")
+	}
+	// Parsing of the command line is done so silence cmd usage
+	cmd.SilenceUsage = true
+
+	var err error
+	if cf == nil {
+		cf, err = InitCmdFactory()
+		if err != nil {
+			return err
+		}
+	}
+	_, err = cf.AdminClient.YieldLeadership(context.Background(), &pb.YieldLeadershipRequest{ChannelId: channelID})
+	if err != nil {
+		return errors.WithMessage(err, fmt.Sprintf("failed yielding the leadership of channel %s", channelID))
+	}
+
+	fmt.Fprintf(out, "Yielded the leadership of channel %s\n", channelID)
+	return nil
+}
@@ -7,6 +7,7 @@ SPDX-License-Identifier: Apache-2.0
 package common
 
 import (
+	"crypto/tls"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -41,6 +42,20 @@ var (
 	// by default it is set to GetEndorserClient function
 	GetEndorserClientFnc func() (pb.EndorserClient, error)
 
+	// GetPeerEndorserClientFnc is a function that returns a new endorser client
+	// connection to the peer at the given address,
+	// by default it is set to GetPeerEndorserClient function
+	GetPeerEndorserClientFnc func(address, tlsRootCertFile string) (pb.EndorserClient, error)
+
+	// GetDeliverClientFnc is a function that returns a new client for the
+	// Deliver service of the peer at the given address,
+	// by default it is set to GetDeliverClient function
+	GetDeliverClientFnc func(address, tlsRootCertFile string) (pb.DeliverClient, error)
+
+	// GetCertificateFnc is a function that returns the TLS client certificate,
+	// by default it is set to GetCertificate function
+	GetCertificateFnc func() (tls.Certificate, error)
+
 	// GetDefaultSignerFnc is a function that returns a default Signer(Default/PERR)
 	// by default it is set to GetDefaultSigner function
 	GetDefaultSignerFnc func() (msp.SigningIdentity, error)
@@ -63,6 +78,9 @@ type commonClient struct {
 
 func init() {
 	GetEndorserClientFnc = GetEndorserClient
+	GetPeerEndorserClientFnc = GetPeerEndorserClient
+	GetDeliverClientFnc = GetDeliverClient
+	GetCertificateFnc = GetCertificate
 	GetDefaultSignerFnc = GetDefaultSigner
 	GetBroadcastClientFnc = GetBroadcastClient
 	GetOrdererEndpointOfChainFnc = GetOrdererEndpointOfChain
@@ -62,9 +62,16 @@ func GetMockAdminClient(err error) pb.AdminClient {
 	return &mockAdminClient{err: err}
 }
 
+// GetMockAdminClientWithMembership returns an admin client that
+// returns the given channel membership and err(nil or error)
+func GetMockAdminClientWithMembership(membership *pb.ChannelMembershipResponse, err error) pb.AdminClient {
+	return &mockAdminClient{membership: membership, err: err}
+}
+
 type mockAdminClient struct {
-	status *pb.ServerStatus
-	err    error
+	status     *pb.ServerStatus
+	membership *pb.ChannelMembershipResponse
+	err        error
 }
 
 func (m *mockAdminClient) GetStatus(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*pb.ServerStatus, error) {
@@ -94,3 +101,11 @@ func (m *mockAdminClient) SetModuleLogLevel(ctx context.Context, in *pb.LogLevel
 func (m *mockAdminClient) RevertLogLevels(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*empty.Empty, error) {
 	return &empty.Empty{}, m.err
 }
+
+func (m *mockAdminClient) GetChannelMembership(ctx context.Context, in *pb.ChannelMembershipRequest, opts ...grpc.CallOption) (*pb.ChannelMembershipResponse, error) {
+	return m.membership, m.err
+}
+
+func (m *mockAdminClient) YieldLeadership(ctx context.Context, in *pb.YieldLeadershipRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	return &empty.Empty{}, m.err
+}
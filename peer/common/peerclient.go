@@ -6,7 +6,9 @@ SPDX-License-Identifier: Apache-2.0
 package common
 
 import (
+	"crypto/tls"
 	"fmt"
+	"io/ioutil"
 	"time"
 
 	"github.com/hyperledger/fabric/core/comm"
@@ -42,6 +44,45 @@ func NewPeerClientFromEnv() (*PeerClient, error) {
 	return pClient, nil
 }
 
+// NewPeerClientForAddress creates an instance of a PeerClient for the peer
+// at the given address.  The remaining settings are taken from the global
+// Viper instance.  If TLS is enabled, tlsRootCertFile is used to verify the
+// peer's TLS certificate.  An empty address selects the peer from the
+// configuration setting "peer.address".
+func NewPeerClientForAddress(address, tlsRootCertFile string) (*PeerClient, error) {
+	if address == "" {
+		return NewPeerClientFromEnv()
+	}
+
+	_, _, clientConfig, err := configFromEnv("peer")
+	if err != nil {
+		return nil, errors.WithMessage(err,
+			"failed to load config for PeerClient")
+	}
+	if clientConfig.SecOpts.UseTLS {
+		if tlsRootCertFile == "" {
+			return nil, errors.Errorf("no TLS root certificate file provided for peer %s", address)
+		}
+		caPEM, err := ioutil.ReadFile(tlsRootCertFile)
+		if err != nil {
+			return nil, errors.WithMessage(err,
+				fmt.Sprintf("unable to load TLS root certificate file %s", tlsRootCertFile))
+		}
+		clientConfig.SecOpts.ServerRootCAs = [][]byte{caPEM}
+	}
+	// set timeout
+	clientConfig.Timeout = time.Second * 3
+	gClient, err := comm.NewGRPCClient(clientConfig)
+	if err != nil {
+		return nil, errors.WithMessage(err,
+			"failed to create PeerClient from config")
+	}
+	return &PeerClient{
+		commonClient: commonClient{
+			GRPCClient: gClient,
+			address:    address}}, nil
+}
+
 // Endorser returns a client for the Endorser service
 func (pc *PeerClient) Endorser() (pb.EndorserClient, error) {
 	conn, err := pc.commonClient.NewConnection(pc.address, pc.sn)
@@ -52,6 +93,21 @@ func (pc *PeerClient) Endorser() (pb.EndorserClient, error) {
 	return pb.NewEndorserClient(conn), nil
 }
 
+// Deliver returns a client for the Deliver service
+func (pc *PeerClient) Deliver() (pb.DeliverClient, error) {
+	conn, err := pc.commonClient.NewConnection(pc.address, pc.sn)
+	if err != nil {
+		return nil, errors.WithMessage(err,
+			fmt.Sprintf("deliver client failed to connect to %s", pc.address))
+	}
+	return pb.NewDeliverClient(conn), nil
+}
+
+// Certificate returns the TLS client certificate (if available)
+func (pc *PeerClient) Certificate() tls.Certificate {
+	return pc.commonClient.Certificate()
+}
+
 // Admin returns a client for the Admin service
 func (pc *PeerClient) Admin() (pb.AdminClient, error) {
 	conn, err := pc.commonClient.NewConnection(pc.address, pc.sn)
@@ -81,3 +137,34 @@ func GetAdminClient() (pb.AdminClient, error) {
 	}
 	return peerClient.Admin()
 }
+
+// GetPeerEndorserClient returns a new endorser client for the peer at the
+// given address, see NewPeerClientForAddress
+func GetPeerEndorserClient(address, tlsRootCertFile string) (pb.EndorserClient, error) {
+	peerClient, err := NewPeerClientForAddress(address, tlsRootCertFile)
+	if err != nil {
+		return nil, err
+	}
+	return peerClient.Endorser()
+}
+
+// GetDeliverClient returns a new client for the Deliver service of the peer
+// at the given address, see NewPeerClientForAddress
+func GetDeliverClient(address, tlsRootCertFile string) (pb.DeliverClient, error) {
+	peerClient, err := NewPeerClientForAddress(address, tlsRootCertFile)
+	if err != nil {
+		return nil, err
+	}
+	return peerClient.Deliver()
+}
+
+// GetCertificate returns the TLS client certificate of the peer client,
+// which is the same for all the peers.  The settings are taken from the
+// global Viper instance
+func GetCertificate() (tls.Certificate, error) {
+	peerClient, err := NewPeerClientFromEnv()
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return peerClient.Certificate(), nil
+}
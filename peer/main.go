@@ -22,6 +22,7 @@ import (
 	"github.com/hyperledger/fabric/msp"
 	"github.com/hyperledger/fabric/peer/chaincode"
 	"github.com/hyperledger/fabric/peer/channel"
+	"github.com/hyperledger/fabric/peer/cligossip"
 	"github.com/hyperledger/fabric/peer/clilogging"
 	"github.com/hyperledger/fabric/peer/common"
 	"github.com/hyperledger/fabric/peer/node"
@@ -83,6 +84,7 @@ func main() {
 	  peer chaincode 有关合约的命令
 	  peer clilogging 有关日志的命令
 	  peer channel  有关通道的命令
+	  peer gossip   有关gossip成员视图的命令
 	**/
 	mainFlags := mainCmd.PersistentFlags()
 	mainFlags.BoolVarP(&versionFlag, "version", "v", false, "Display current version of fabric peer server")
@@ -95,6 +97,7 @@ func main() {
 	mainCmd.AddCommand(chaincode.Cmd(nil))
 	mainCmd.AddCommand(clilogging.Cmd(nil))
 	mainCmd.AddCommand(channel.Cmd(nil))
+	mainCmd.AddCommand(cligossip.Cmd(nil))
 
 	//初始化配置文件
 	//首先会检查环境变量,如果FABRIC_CFG_PATH存在,会作为配置文件目录,如果不存在会以$GOPATH为准
@@ -0,0 +1,83 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"github.com/hyperledger/fabric/common/expiration"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/peer"
+	"github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// startExpirationMonitor checks the expiration dates of the signing certificate of the
+// peer, of its TLS certificates, and of the CA and admin certificates of the MSPs of
+// the channels it has joined, at startup and periodically
+func startExpirationMonitor(peerServer comm.GRPCServer) *expiration.Monitor {
+	conf := expiration.Config{
+		Interval:       viper.GetDuration("peer.certExpiration.interval"),
+		WarningPeriod:  viper.GetDuration("peer.certExpiration.warningPeriod"),
+		CriticalPeriod: viper.GetDuration("peer.certExpiration.criticalPeriod"),
+	}
+	collectors := []expiration.Collector{localSigningCert}
+	if peerServer.TLSEnabled() {
+		collectors = append(collectors, func() ([]*expiration.Certificate, error) {
+			return tlsCerts(peerServer)
+		})
+	}
+	collectors = append(collectors, channelCerts)
+
+	m := expiration.NewMonitor(conf, metrics.RootScope, collectors...)
+	m.Start()
+	return m
+}
+
+func localSigningCert() ([]*expiration.Certificate, error) {
+	signer, err := mgmt.GetLocalMSP().GetDefaultSigningIdentity()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed obtaining the signing identity")
+	}
+	serializedIdentity, err := signer.Serialize()
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed serializing the signing identity")
+	}
+	return expiration.FromSerializedIdentity("signing certificate of the local MSP", serializedIdentity)
+}
+
+func tlsCerts(peerServer comm.GRPCServer) ([]*expiration.Certificate, error) {
+	certs, err := expiration.FromTLSCertificate("TLS server certificate", peerServer.ServerCertificate())
+	if err != nil {
+		return nil, err
+	}
+	clientCerts, err := expiration.FromTLSCertificate("TLS client certificate", comm.GetCredentialSupport().GetClientCertificate())
+	if err != nil {
+		return certs, err
+	}
+	return append(certs, clientCerts...), nil
+}
+
+// channelCerts returns the certificates of the MSPs of the channels the peer has
+// joined so far. Channels whose certificates cannot be read are skipped
+func channelCerts() ([]*expiration.Certificate, error) {
+	configSupport := peer.NewConfigSupport()
+	var certs []*expiration.Certificate
+	for _, channel := range peer.GetChannelsInfo() {
+		config := configSupport.GetChannelConfig(channel.ChannelId)
+		if config == nil {
+			continue
+		}
+		channelCerts, err := expiration.FromChannelConfig(channel.ChannelId, config.ConfigProto())
+		if err != nil {
+			logger.Warningf("Failed collecting the certificates of channel %s: %s", channel.ChannelId, err)
+			continue
+		}
+		certs = append(certs, channelCerts...)
+	}
+	return certs, nil
+}
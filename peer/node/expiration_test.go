@@ -0,0 +1,47 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/accesscontrol"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartExpirationMonitor(t *testing.T) {
+	assert.NoError(t, mgmt.LoadDevMsp())
+
+	ca, err := accesscontrol.NewCA()
+	assert.NoError(t, err)
+	keyPair, err := ca.NewServerCertKeyPair("localhost")
+	assert.NoError(t, err)
+	server, err := comm.NewGRPCServer("localhost:0", comm.ServerConfig{
+		SecOpts: &comm.SecureOptions{
+			UseTLS:      true,
+			Certificate: keyPair.Cert,
+			Key:         keyPair.Key,
+		},
+	})
+	assert.NoError(t, err)
+	defer server.Stop()
+	comm.GetCredentialSupport().SetClientCertificate(server.ServerCertificate())
+
+	m := startExpirationMonitor(server)
+	defer m.Stop()
+
+	var sources []string
+	for _, s := range m.Status() {
+		sources = append(sources, s.Source)
+	}
+	assert.Len(t, sources, 3)
+	assert.Contains(t, sources, "signing certificate of the local MSP")
+	assert.Contains(t, sources, "TLS server certificate")
+	assert.Contains(t, sources, "TLS client certificate")
+}
@@ -0,0 +1,104 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"path/filepath"
+	"syscall"
+
+	"github.com/hyperledger/fabric/common/reload"
+	"github.com/hyperledger/fabric/core/comm"
+	"github.com/hyperledger/fabric/core/config"
+	"github.com/hyperledger/fabric/core/peer"
+	common2 "github.com/hyperledger/fabric/gossip/common"
+	"github.com/hyperledger/fabric/msp/mgmt"
+	"github.com/pkg/errors"
+	"github.com/spf13/viper"
+)
+
+// startReloaders watches the TLS key pairs and the local MSP of the peer, and
+// reloads them whenever they change or whenever the peer receives SIGHUP.
+// TLS key pairs are watched only if TLS is enabled, that is if the gossip
+// TLS certificates are not nil
+func startReloaders(servers []comm.GRPCServer, gossipCerts *common2.TLSCertificates, gossipIdentity []byte) []*reload.Watcher {
+	interval := viper.GetDuration("peer.reload.interval")
+
+	var watchers []*reload.Watcher
+	if gossipCerts != nil {
+		watchers = append(watchers, reload.NewWatcher("TLS key pairs", tlsPaths(), interval, func() error {
+			return reloadTLS(servers, gossipCerts)
+		}))
+	}
+	watchers = append(watchers, reload.NewWatcher("local MSP", localMspPaths(), interval, func() error {
+		return reloadLocalMsp(gossipIdentity)
+	}))
+
+	for _, w := range watchers {
+		w.Start()
+	}
+	reload.NotifyOnSignal(watchers, syscall.SIGHUP)
+	return watchers
+}
+
+func tlsPaths() []string {
+	paths := []string{config.GetPath("peer.tls.cert.file"), config.GetPath("peer.tls.key.file")}
+	for _, key := range []string{"peer.tls.clientCert.file", "peer.tls.clientKey.file"} {
+		if viper.GetString(key) != "" {
+			paths = append(paths, config.GetPath(key))
+		}
+	}
+	return paths
+}
+
+func localMspPaths() []string {
+	mspDir := config.GetPath("peer.mspConfigPath")
+	paths := []string{
+		filepath.Join(mspDir, "signcerts"),
+		filepath.Join(mspDir, "keystore"),
+		filepath.Join(mspDir, "crls"),
+	}
+	if keyStore := viper.GetString("peer.BCCSP.SW.FileKeyStore.KeyStore"); keyStore != "" {
+		paths = append(paths, keyStore)
+	}
+	return paths
+}
+
+// reloadTLS validates the TLS key pairs of the peer before swapping them in for the
+// servers, the client connections, and gossip. Established connections keep using
+// the previous key pairs until they are reestablished
+func reloadTLS(servers []comm.GRPCServer, gossipCerts *common2.TLSCertificates) error {
+	serverCert, err := comm.LoadTLSKeyPair(config.GetPath("peer.tls.cert.file"), config.GetPath("peer.tls.key.file"))
+	if err != nil {
+		return errors.WithMessage(err, "invalid TLS server key pair")
+	}
+	clientCert, err := peer.GetClientCertificate()
+	if err == nil {
+		err = comm.ValidateTLSCertificate(&clientCert)
+	}
+	if err != nil {
+		return errors.WithMessage(err, "invalid TLS client key pair")
+	}
+
+	for _, server := range servers {
+		server.SetServerCertificate(serverCert)
+	}
+	comm.GetCredentialSupport().SetClientCertificate(clientCert)
+	gossipCerts.TLSServerCert.Store(&serverCert)
+	gossipCerts.TLSClientCert.Store(&clientCert)
+
+	logger.Infof("The TLS server certificate now expires at %s, and the TLS client certificate at %s",
+		serverCert.Leaf.NotAfter, clientCert.Leaf.NotAfter)
+	return nil
+}
+
+// reloadLocalMsp reloads the local MSP, which the peer uses for signing from then on.
+// The gossip identity of the peer, from which its gossip PKI-ID is derived, cannot
+// change without restarting the peer, so the local MSP is only reloaded if its
+// signing certificate is unchanged, as when the CRLs change
+func reloadLocalMsp(gossipIdentity []byte) error {
+	return mgmt.ReloadLocalMspWithIdentity(gossipIdentity)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright IBM Corp. All Rights Reserved.
+
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package node
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hyperledger/fabric/core/chaincode/accesscontrol"
+	"github.com/hyperledger/fabric/core/comm"
+	common2 "github.com/hyperledger/fabric/gossip/common"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReloadTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tls")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	viper.Set("peer.tls.cert.file", certFile)
+	viper.Set("peer.tls.key.file", keyFile)
+	viper.Set("peer.tls.clientCert.file", "")
+	viper.Set("peer.tls.clientKey.file", "")
+	defer viper.Set("peer.tls.cert.file", "")
+	defer viper.Set("peer.tls.key.file", "")
+
+	ca, err := accesscontrol.NewCA()
+	assert.NoError(t, err)
+	writeKeyPair := func() []byte {
+		keyPair, err := ca.NewServerCertKeyPair("localhost")
+		assert.NoError(t, err)
+		assert.NoError(t, ioutil.WriteFile(certFile, keyPair.Cert, 0600))
+		assert.NoError(t, ioutil.WriteFile(keyFile, keyPair.Key, 0600))
+		return keyPair.Cert
+	}
+
+	initialCert := writeKeyPair()
+	server, err := comm.NewGRPCServer("localhost:0", comm.ServerConfig{
+		SecOpts: &comm.SecureOptions{
+			UseTLS:      true,
+			Certificate: initialCert,
+			Key:         mustReadFile(t, keyFile),
+		},
+	})
+	assert.NoError(t, err)
+	defer server.Stop()
+
+	serverCert := server.ServerCertificate()
+	gossipCerts := &common2.TLSCertificates{}
+	gossipCerts.TLSServerCert.Store(&serverCert)
+	gossipCerts.TLSClientCert.Store(&serverCert)
+
+	assert.Equal(t, []string{certFile, keyFile}, tlsPaths())
+
+	writeKeyPair()
+	assert.NoError(t, reloadTLS([]comm.GRPCServer{server}, gossipCerts))
+	reloadedCert := server.ServerCertificate()
+	assert.NotEqual(t, serverCert.Certificate, reloadedCert.Certificate)
+	assert.Equal(t, reloadedCert.Certificate, gossipCerts.TLSServerCert.Load().(*tls.Certificate).Certificate)
+	assert.Equal(t, reloadedCert.Certificate, gossipCerts.TLSClientCert.Load().(*tls.Certificate).Certificate)
+	assert.Equal(t, reloadedCert.Certificate, comm.GetCredentialSupport().GetClientCertificate().Certificate)
+
+	// invalid key pairs are not swapped in
+	assert.NoError(t, ioutil.WriteFile(keyFile, []byte("garbage"), 0600))
+	err = reloadTLS([]comm.GRPCServer{server}, gossipCerts)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid TLS server key pair")
+	assert.Equal(t, reloadedCert.Certificate, server.ServerCertificate().Certificate)
+}
+
+func mustReadFile(t *testing.T, file string) []byte {
+	contents, err := ioutil.ReadFile(file)
+	assert.NoError(t, err)
+	return contents
+}
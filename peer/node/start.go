@@ -22,6 +22,8 @@ import (
 	"github.com/hyperledger/fabric/common/deliver"
 	"github.com/hyperledger/fabric/common/flogging"
 	"github.com/hyperledger/fabric/common/localmsp"
+	"github.com/hyperledger/fabric/common/metrics"
+	"github.com/hyperledger/fabric/common/tracing"
 	"github.com/hyperledger/fabric/common/viperutil"
 	"github.com/hyperledger/fabric/core"
 	"github.com/hyperledger/fabric/core/aclmgmt"
@@ -139,6 +141,24 @@ func serve(args []string) error {
 		return err
 	}
 
+	// initialize the metrics scope, which is a no-op unless metrics are enabled
+	if err := metrics.Init(metrics.NewOpts()); err != nil {
+		return errors.WithMessage(err, "failed initializing metrics")
+	}
+	if err := metrics.Start(); err != nil {
+		return errors.WithMessage(err, "failed starting metrics")
+	}
+	defer metrics.Shutdown()
+
+	// initialize tracing, which is disabled unless configured otherwise
+	if err := tracing.Init(tracing.Config{
+		Enabled:  viper.GetBool("peer.tracing.enabled"),
+		Exporter: viper.GetString("peer.tracing.exporter"),
+		File:     config.GetPath("peer.tracing.file"),
+	}); err != nil {
+		return errors.WithMessage(err, "failed initializing tracing")
+	}
+
 	peerEndpoint, err := peer.GetPeerEndpoint()
 	if err != nil {
 		err = fmt.Errorf("Failed to get Peer Endpoint: %s", err)
@@ -191,6 +211,9 @@ func serve(args []string) error {
 	abServer := peer.NewDeliverEventsServer(mutualTLS, policyCheckerProvider, &peer.DeliverSupportManager{})
 	pb.RegisterDeliverServer(peerServer.Server(), abServer)
 
+	ccEventsServer := peer.NewChaincodeEventsServer(mutualTLS, policyCheckerProvider, &peer.DeliverSupportManager{})
+	pb.RegisterChaincodeEventsServer(peerServer.Server(), ccEventsServer)
+
 	// enable the cache of chaincode info
 	ccprovider.EnableCCInfoCache()
 
@@ -282,6 +305,15 @@ func serve(args []string) error {
 	}
 	defer service.GetGossipService().Stop()
 
+	// watch the TLS key pairs and the local MSP for changes
+	reloadServers := []comm.GRPCServer{peerServer}
+	if ehubGrpcServer != nil {
+		reloadServers = append(reloadServers, ehubGrpcServer)
+	}
+	for _, w := range startReloaders(reloadServers, certs, serializedIdentity) {
+		defer w.Stop()
+	}
+
 	//initialize system chaincodes
 	initSysCCs()
 
@@ -291,6 +323,9 @@ func serve(args []string) error {
 		scc.DeploySysCCs(cid)
 	})
 
+	// check the expiration dates of the certificates of the peer and of its channels
+	defer startExpirationMonitor(peerServer).Stop()
+
 	logger.Infof("Starting peer with ID=[%s], network ID=[%s], address=[%s]",
 		peerEndpoint.Id, viper.GetString("peer.networkId"), peerEndpoint.Address)
 
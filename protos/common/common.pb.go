@@ -103,6 +103,7 @@ const (
 	BlockMetadataIndex_LAST_CONFIG         BlockMetadataIndex = 1
 	BlockMetadataIndex_TRANSACTIONS_FILTER BlockMetadataIndex = 2
 	BlockMetadataIndex_ORDERER             BlockMetadataIndex = 3
+	BlockMetadataIndex_MVCC_CONFLICTS      BlockMetadataIndex = 4
 )
 
 var BlockMetadataIndex_name = map[int32]string{
@@ -110,12 +111,14 @@ var BlockMetadataIndex_name = map[int32]string{
 	1: "LAST_CONFIG",
 	2: "TRANSACTIONS_FILTER",
 	3: "ORDERER",
+	4: "MVCC_CONFLICTS",
 }
 var BlockMetadataIndex_value = map[string]int32{
 	"SIGNATURES":          0,
 	"LAST_CONFIG":         1,
 	"TRANSACTIONS_FILTER": 2,
 	"ORDERER":             3,
+	"MVCC_CONFLICTS":      4,
 }
 
 func (x BlockMetadataIndex) String() string {
@@ -510,65 +513,65 @@ func init() {
 func init() { proto.RegisterFile("common/common.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 950 bytes of a gzipped FileDescriptorProto
+	// 960 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x55, 0xcf, 0x6f, 0xe3, 0x44,
-	0x18, 0x5d, 0xc7, 0xf9, 0xd1, 0x7c, 0x6e, 0x5a, 0x77, 0xd2, 0xb2, 0xa6, 0xb0, 0xda, 0xca, 0xb0,
-	0xa8, 0xb4, 0x52, 0x2a, 0xca, 0x05, 0x8e, 0x8e, 0x3d, 0x69, 0xad, 0xa6, 0x76, 0x18, 0x3b, 0x8b,
-	0xd8, 0x45, 0xb2, 0x9c, 0x64, 0x9a, 0x44, 0x24, 0x76, 0x64, 0x4f, 0xaa, 0xf6, 0xcc, 0x1d, 0x21,
-	0xc1, 0x95, 0xbf, 0x82, 0x7f, 0x80, 0x23, 0x7f, 0x10, 0x88, 0x2b, 0x1a, 0x8f, 0xed, 0x4d, 0xca,
-	0x4a, 0x9c, 0x32, 0xef, 0xcd, 0xcb, 0xf7, 0xbd, 0xf9, 0xde, 0xd8, 0x86, 0xf6, 0x38, 0x5e, 0x2e,
-	0xe3, 0xe8, 0x42, 0xfc, 0x74, 0x56, 0x49, 0xcc, 0x62, 0x54, 0x17, 0xe8, 0xf8, 0xe5, 0x34, 0x8e,
-	0xa7, 0x0b, 0x7a, 0x91, 0xb1, 0xa3, 0xf5, 0xdd, 0x05, 0x9b, 0x2f, 0x69, 0xca, 0xc2, 0xe5, 0x4a,
-	0x08, 0x75, 0x1d, 0xa0, 0x1f, 0xa6, 0xcc, 0x8c, 0xa3, 0xbb, 0xf9, 0x14, 0x1d, 0x42, 0x6d, 0x1e,
-	0x4d, 0xe8, 0x83, 0x26, 0x9d, 0x48, 0xa7, 0x55, 0x22, 0x80, 0xfe, 0x16, 0x76, 0x6e, 0x29, 0x0b,
-	0x27, 0x21, 0x0b, 0xb9, 0xe2, 0x3e, 0x5c, 0xac, 0x69, 0xa6, 0xd8, 0x25, 0x02, 0xa0, 0xaf, 0x01,
-	0xd2, 0xf9, 0x34, 0x0a, 0xd9, 0x3a, 0xa1, 0xa9, 0x56, 0x39, 0x91, 0x4f, 0x95, 0xcb, 0x0f, 0x3b,
-	0xb9, 0xa3, 0xe2, 0xbf, 0x5e, 0xa1, 0x20, 0x1b, 0x62, 0xfd, 0x7b, 0x38, 0xf8, 0x8f, 0x00, 0x7d,
-	0x0e, 0x6a, 0x29, 0x09, 0x66, 0x34, 0x9c, 0xd0, 0x24, 0x6f, 0xb8, 0x5f, 0xf2, 0xd7, 0x19, 0x8d,
-	0x3e, 0x86, 0x66, 0x49, 0x69, 0x95, 0x4c, 0xf3, 0x8e, 0xd0, 0xdf, 0x40, 0x3d, 0xd7, 0xbd, 0x82,
-	0xbd, 0xf1, 0x2c, 0x8c, 0x22, 0xba, 0xd8, 0x2e, 0xd8, 0xca, 0xd9, 0x5c, 0xf6, 0xbe, 0xce, 0x95,
-	0xf7, 0x76, 0xd6, 0x7f, 0xac, 0x40, 0xcb, 0xdc, 0xfa, 0x33, 0x82, 0x2a, 0x7b, 0x5c, 0x89, 0xd9,
-	0xd4, 0x48, 0xb6, 0x46, 0x1a, 0x34, 0xee, 0x69, 0x92, 0xce, 0xe3, 0x28, 0xab, 0x53, 0x23, 0x05,
-	0x44, 0x5f, 0x41, 0xb3, 0x4c, 0x43, 0x93, 0x4f, 0xa4, 0x53, 0xe5, 0xf2, 0xb8, 0x23, 0xf2, 0xea,
-	0x14, 0x79, 0x75, 0xfc, 0x42, 0x41, 0xde, 0x89, 0xd1, 0x0b, 0x80, 0xe2, 0x2c, 0xf3, 0x89, 0x56,
-	0x3d, 0x91, 0x4e, 0x9b, 0xa4, 0x99, 0x33, 0xf6, 0x04, 0xb5, 0xa1, 0xc6, 0x1e, 0xf8, 0x4e, 0x2d,
-	0xdb, 0xa9, 0xb2, 0x07, 0x7b, 0xc2, 0x83, 0xa3, 0xab, 0x78, 0x3c, 0xd3, 0xea, 0x22, 0xda, 0x0c,
-	0xf0, 0xe9, 0xd1, 0x07, 0x46, 0xa3, 0xcc, 0x5f, 0x43, 0x4c, 0xaf, 0x24, 0x90, 0x0e, 0x2d, 0xb6,
-	0x48, 0x83, 0x31, 0x4d, 0x58, 0x30, 0x0b, 0xd3, 0x99, 0xb6, 0x93, 0x29, 0x14, 0xb6, 0x48, 0x4d,
-	0x9a, 0xb0, 0xeb, 0x30, 0x9d, 0xe9, 0x06, 0xec, 0x7b, 0x4f, 0x22, 0xd1, 0xa0, 0x31, 0x4e, 0x68,
-	0xc8, 0xe2, 0x62, 0xc6, 0x05, 0xe4, 0x26, 0xa2, 0x38, 0x1a, 0x17, 0x41, 0x09, 0xa0, 0x63, 0x68,
-	0x0c, 0xc2, 0xc7, 0x45, 0x1c, 0x4e, 0xd0, 0x67, 0x50, 0xdf, 0x48, 0x47, 0xb9, 0xdc, 0x2b, 0x2e,
-	0x91, 0x28, 0x4d, 0xf2, 0x5d, 0x3e, 0x69, 0x7e, 0x63, 0xf2, 0x3a, 0xd9, 0x5a, 0xef, 0xc2, 0x0e,
-	0x8e, 0xee, 0xe9, 0x22, 0x16, 0x53, 0x5f, 0x89, 0x92, 0x85, 0x85, 0x1c, 0xfe, 0xcf, 0x7d, 0xf9,
-	0x49, 0x82, 0x5a, 0x77, 0x11, 0x8f, 0x7f, 0x40, 0xe7, 0x4f, 0x9c, 0xb4, 0x0b, 0x27, 0xd9, 0xf6,
-	0x13, 0x3b, 0xaf, 0x36, 0xec, 0x28, 0x97, 0x07, 0x5b, 0x52, 0x2b, 0x64, 0xa1, 0x70, 0x88, 0xbe,
-	0x80, 0x9d, 0x65, 0x7e, 0xd7, 0xf3, 0xc0, 0x8f, 0xb6, 0xa4, 0xc5, 0x83, 0x40, 0x4a, 0x99, 0x3e,
-	0x05, 0x65, 0xa3, 0x21, 0xfa, 0x00, 0xea, 0xd1, 0x7a, 0x39, 0xca, 0x5d, 0x55, 0x49, 0x8e, 0xd0,
-	0x27, 0xd0, 0x5a, 0x25, 0xf4, 0x7e, 0x1e, 0xaf, 0x53, 0x91, 0x94, 0x38, 0xd9, 0x6e, 0x41, 0xf2,
-	0xa8, 0xd0, 0x47, 0xd0, 0xe4, 0x35, 0x85, 0x40, 0xce, 0x04, 0x3b, 0x9c, 0xc8, 0x72, 0x7c, 0x09,
-	0xcd, 0xd2, 0x6e, 0x39, 0x5e, 0xe9, 0x44, 0x2e, 0xc7, 0x7b, 0x0e, 0xad, 0x2d, 0x93, 0xe8, 0x78,
-	0xe3, 0x34, 0x42, 0x58, 0xe2, 0xb3, 0x3f, 0x24, 0xa8, 0x7b, 0x2c, 0x64, 0xeb, 0x14, 0x29, 0xd0,
-	0x18, 0x3a, 0x37, 0x8e, 0xfb, 0xad, 0xa3, 0x3e, 0x43, 0xbb, 0xd0, 0xf0, 0x86, 0xa6, 0x89, 0x3d,
-	0x4f, 0xfd, 0x53, 0x42, 0x2a, 0x28, 0x5d, 0xc3, 0x0a, 0x08, 0xfe, 0x66, 0x88, 0x3d, 0x5f, 0xfd,
-	0x59, 0x46, 0x7b, 0xd0, 0xec, 0xb9, 0xa4, 0x6b, 0x5b, 0x16, 0x76, 0xd4, 0x5f, 0x32, 0xec, 0xb8,
-	0x7e, 0xd0, 0x73, 0x87, 0x8e, 0xa5, 0xfe, 0x2a, 0xa3, 0x17, 0xa0, 0xe5, 0xea, 0x00, 0x3b, 0xbe,
-	0xed, 0x7f, 0x17, 0xf8, 0xae, 0x1b, 0xf4, 0x0d, 0x72, 0x85, 0xd5, 0xdf, 0x64, 0x74, 0x0c, 0x47,
-	0xb6, 0xe3, 0x63, 0xe2, 0x18, 0xfd, 0xc0, 0xc3, 0xe4, 0x35, 0x26, 0x01, 0x26, 0xc4, 0x25, 0xea,
-	0x5f, 0x32, 0x3a, 0x84, 0x7d, 0x5e, 0xca, 0xbe, 0x1d, 0xf4, 0xf1, 0x2d, 0x76, 0x7c, 0x6c, 0xa9,
-	0x7f, 0xcb, 0x48, 0x83, 0x36, 0x17, 0xda, 0x26, 0x0e, 0x86, 0x8e, 0xf1, 0xda, 0xb0, 0xfb, 0x46,
-	0xb7, 0x8f, 0xd5, 0x7f, 0xe4, 0xb3, 0xdf, 0x25, 0x00, 0x31, 0x75, 0x9f, 0x3f, 0xc7, 0x0a, 0x34,
-	0x6e, 0xb1, 0xe7, 0x19, 0x57, 0x58, 0x7d, 0x86, 0x00, 0xea, 0xa6, 0xeb, 0xf4, 0xec, 0x2b, 0x55,
-	0x42, 0x07, 0xd0, 0x12, 0xeb, 0x60, 0x38, 0xb0, 0x0c, 0x1f, 0xab, 0x15, 0xa4, 0xc1, 0x21, 0x76,
-	0x2c, 0x97, 0x78, 0x98, 0x04, 0x3e, 0x31, 0x1c, 0xcf, 0x30, 0x7d, 0xdb, 0x75, 0x54, 0x19, 0x3d,
-	0x87, 0xb6, 0x4b, 0x2c, 0x4c, 0x9e, 0x6c, 0x54, 0xd1, 0x11, 0x1c, 0x58, 0xb8, 0x6f, 0x73, 0xc7,
-	0x1e, 0xc6, 0x37, 0x81, 0xed, 0xf4, 0x5c, 0xb5, 0xc6, 0x69, 0xf3, 0xda, 0xb0, 0x1d, 0xd3, 0xb5,
-	0x70, 0x30, 0x30, 0xcc, 0x1b, 0xde, 0xbf, 0xce, 0x1b, 0x0c, 0x30, 0x26, 0x01, 0xc1, 0x9e, 0x3b,
-	0x24, 0xdc, 0xbb, 0x68, 0xdd, 0x38, 0x7b, 0x0b, 0x68, 0x2b, 0x25, 0x9b, 0xbf, 0xc1, 0xd1, 0x1e,
-	0x80, 0x67, 0x5f, 0x39, 0x86, 0x3f, 0x24, 0xd8, 0x53, 0x9f, 0xa1, 0x7d, 0x50, 0xfa, 0x86, 0xe7,
-	0x07, 0xe5, 0x21, 0x9e, 0x43, 0x7b, 0xc3, 0x8f, 0x17, 0xf4, 0xec, 0xbe, 0x8f, 0x89, 0x5a, 0xe1,
-	0xc7, 0xce, 0x0d, 0xab, 0x72, 0xd7, 0x83, 0x4f, 0xe3, 0x64, 0xda, 0x99, 0x3d, 0xae, 0x68, 0xb2,
-	0xa0, 0x93, 0x29, 0x4d, 0x3a, 0x77, 0xe1, 0x28, 0x99, 0x8f, 0xc5, 0xfb, 0x2a, 0xcd, 0x2f, 0xf3,
-	0x9b, 0xf3, 0xe9, 0x9c, 0xcd, 0xd6, 0x23, 0x0e, 0x2f, 0x36, 0xc4, 0x17, 0x42, 0x2c, 0x3e, 0x46,
-	0x69, 0xfe, 0xc1, 0x1a, 0xd5, 0x33, 0xf8, 0xe5, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xe2, 0x37,
-	0xfd, 0x02, 0xc8, 0x06, 0x00, 0x00,
+	0x18, 0x6d, 0xe2, 0xfc, 0x68, 0xbe, 0x34, 0xed, 0x74, 0xd2, 0xb2, 0xa6, 0xb0, 0xda, 0xca, 0xb0,
+	0xa8, 0xb4, 0x52, 0x2a, 0xca, 0x05, 0x8e, 0x8e, 0x3d, 0x6d, 0xad, 0xba, 0x76, 0x99, 0x71, 0x8a,
+	0x58, 0x90, 0x2c, 0x37, 0x99, 0x26, 0x11, 0x89, 0x1d, 0xd9, 0x93, 0xaa, 0x3d, 0x73, 0x47, 0x48,
+	0x70, 0xe5, 0xaf, 0xe0, 0x1f, 0xe0, 0xc8, 0x1f, 0x04, 0xe2, 0x8a, 0xec, 0xb1, 0xbd, 0x49, 0x59,
+	0x69, 0x4f, 0xf1, 0x7b, 0xf3, 0xe6, 0xfb, 0xde, 0x7c, 0x6f, 0x62, 0x43, 0x77, 0x18, 0xcd, 0xe7,
+	0x51, 0x78, 0x2a, 0x7f, 0x7a, 0x8b, 0x38, 0x12, 0x11, 0x6e, 0x48, 0x74, 0xf0, 0x6a, 0x1c, 0x45,
+	0xe3, 0x19, 0x3f, 0xcd, 0xd8, 0xbb, 0xe5, 0xfd, 0xa9, 0x98, 0xce, 0x79, 0x22, 0x82, 0xf9, 0x42,
+	0x0a, 0x35, 0x0d, 0xc0, 0x0e, 0x12, 0x61, 0x44, 0xe1, 0xfd, 0x74, 0x8c, 0xf7, 0xa0, 0x3e, 0x0d,
+	0x47, 0xfc, 0x51, 0xad, 0x1c, 0x56, 0x8e, 0x6a, 0x54, 0x02, 0xed, 0x7b, 0xd8, 0xbc, 0xe6, 0x22,
+	0x18, 0x05, 0x22, 0x48, 0x15, 0x0f, 0xc1, 0x6c, 0xc9, 0x33, 0xc5, 0x16, 0x95, 0x00, 0x7f, 0x0d,
+	0x90, 0x4c, 0xc7, 0x61, 0x20, 0x96, 0x31, 0x4f, 0xd4, 0xea, 0xa1, 0x72, 0xd4, 0x3e, 0xfb, 0xb0,
+	0x97, 0x3b, 0x2a, 0xf6, 0xb2, 0x42, 0x41, 0x57, 0xc4, 0xda, 0x0f, 0xb0, 0xfb, 0x3f, 0x01, 0xfe,
+	0x1c, 0x50, 0x29, 0xf1, 0x27, 0x3c, 0x18, 0xf1, 0x38, 0x6f, 0xb8, 0x53, 0xf2, 0x97, 0x19, 0x8d,
+	0x3f, 0x86, 0x56, 0x49, 0xa9, 0xd5, 0x4c, 0xf3, 0x96, 0xd0, 0xde, 0x40, 0x23, 0xd7, 0xbd, 0x86,
+	0xed, 0xe1, 0x24, 0x08, 0x43, 0x3e, 0x5b, 0x2f, 0xd8, 0xc9, 0xd9, 0x5c, 0xf6, 0xae, 0xce, 0xd5,
+	0x77, 0x76, 0xd6, 0x7e, 0xaa, 0x42, 0xc7, 0x58, 0xdb, 0x8c, 0xa1, 0x26, 0x9e, 0x16, 0x72, 0x36,
+	0x75, 0x9a, 0x3d, 0x63, 0x15, 0x9a, 0x0f, 0x3c, 0x4e, 0xa6, 0x51, 0x98, 0xd5, 0xa9, 0xd3, 0x02,
+	0xe2, 0xaf, 0xa0, 0x55, 0xa6, 0xa1, 0x2a, 0x87, 0x95, 0xa3, 0xf6, 0xd9, 0x41, 0x4f, 0xe6, 0xd5,
+	0x2b, 0xf2, 0xea, 0x79, 0x85, 0x82, 0xbe, 0x15, 0xe3, 0x97, 0x00, 0xc5, 0x59, 0xa6, 0x23, 0xb5,
+	0x76, 0x58, 0x39, 0x6a, 0xd1, 0x56, 0xce, 0x58, 0x23, 0xdc, 0x85, 0xba, 0x78, 0x4c, 0x57, 0xea,
+	0xd9, 0x4a, 0x4d, 0x3c, 0x5a, 0xa3, 0x34, 0x38, 0xbe, 0x88, 0x86, 0x13, 0xb5, 0x21, 0xa3, 0xcd,
+	0x40, 0x3a, 0x3d, 0xfe, 0x28, 0x78, 0x98, 0xf9, 0x6b, 0xca, 0xe9, 0x95, 0x04, 0xd6, 0xa0, 0x23,
+	0x66, 0x89, 0x3f, 0xe4, 0xb1, 0xf0, 0x27, 0x41, 0x32, 0x51, 0x37, 0x33, 0x45, 0x5b, 0xcc, 0x12,
+	0x83, 0xc7, 0xe2, 0x32, 0x48, 0x26, 0x9a, 0x0e, 0x3b, 0xec, 0x59, 0x24, 0x2a, 0x34, 0x87, 0x31,
+	0x0f, 0x44, 0x54, 0xcc, 0xb8, 0x80, 0xa9, 0x89, 0x30, 0x0a, 0x87, 0x45, 0x50, 0x12, 0x68, 0x04,
+	0x9a, 0x37, 0xc1, 0xd3, 0x2c, 0x0a, 0x46, 0xf8, 0x33, 0x68, 0xac, 0xa4, 0xd3, 0x3e, 0xdb, 0x2e,
+	0x2e, 0x91, 0x2c, 0x4d, 0x1b, 0x93, 0x72, 0xd2, 0xe9, 0x8d, 0xc9, 0xeb, 0x64, 0xcf, 0x5a, 0x1f,
+	0x36, 0x49, 0xf8, 0xc0, 0x67, 0x91, 0x9c, 0xfa, 0x42, 0x96, 0x2c, 0x2c, 0xe4, 0xf0, 0x3d, 0xf7,
+	0xe5, 0xe7, 0x0a, 0xd4, 0xfb, 0xb3, 0x68, 0xf8, 0x23, 0x3e, 0x79, 0xe6, 0xa4, 0x5b, 0x38, 0xc9,
+	0x96, 0x9f, 0xd9, 0x79, 0xbd, 0x62, 0xa7, 0x7d, 0xb6, 0xbb, 0x26, 0x35, 0x03, 0x11, 0x48, 0x87,
+	0xf8, 0x0b, 0xd8, 0x9c, 0xe7, 0x77, 0x3d, 0x0f, 0x7c, 0x7f, 0x4d, 0x5a, 0xfc, 0x11, 0x68, 0x29,
+	0xd3, 0xc6, 0xd0, 0x5e, 0x69, 0x88, 0x3f, 0x80, 0x46, 0xb8, 0x9c, 0xdf, 0xe5, 0xae, 0x6a, 0x34,
+	0x47, 0xf8, 0x13, 0xe8, 0x2c, 0x62, 0xfe, 0x30, 0x8d, 0x96, 0x89, 0x4c, 0x4a, 0x9e, 0x6c, 0xab,
+	0x20, 0xd3, 0xa8, 0xf0, 0x47, 0xd0, 0x4a, 0x6b, 0x4a, 0x81, 0x92, 0x09, 0x36, 0x53, 0x22, 0xcb,
+	0xf1, 0x15, 0xb4, 0x4a, 0xbb, 0xe5, 0x78, 0x2b, 0x87, 0x4a, 0x39, 0xde, 0x13, 0xe8, 0xac, 0x99,
+	0xc4, 0x07, 0x2b, 0xa7, 0x91, 0xc2, 0x12, 0x1f, 0xff, 0x59, 0x81, 0x06, 0x13, 0x81, 0x58, 0x26,
+	0xb8, 0x0d, 0xcd, 0x81, 0x73, 0xe5, 0xb8, 0xdf, 0x3a, 0x68, 0x03, 0x6f, 0x41, 0x93, 0x0d, 0x0c,
+	0x83, 0x30, 0x86, 0xfe, 0xaa, 0x60, 0x04, 0xed, 0xbe, 0x6e, 0xfa, 0x94, 0x7c, 0x33, 0x20, 0xcc,
+	0x43, 0xbf, 0x28, 0x78, 0x1b, 0x5a, 0xe7, 0x2e, 0xed, 0x5b, 0xa6, 0x49, 0x1c, 0xf4, 0x6b, 0x86,
+	0x1d, 0xd7, 0xf3, 0xcf, 0xdd, 0x81, 0x63, 0xa2, 0xdf, 0x14, 0xfc, 0x12, 0xd4, 0x5c, 0xed, 0x13,
+	0xc7, 0xb3, 0xbc, 0xef, 0x7c, 0xcf, 0x75, 0x7d, 0x5b, 0xa7, 0x17, 0x04, 0xfd, 0xae, 0xe0, 0x03,
+	0xd8, 0xb7, 0x1c, 0x8f, 0x50, 0x47, 0xb7, 0x7d, 0x46, 0xe8, 0x2d, 0xa1, 0x3e, 0xa1, 0xd4, 0xa5,
+	0xe8, 0x6f, 0x05, 0xef, 0xc1, 0x4e, 0x5a, 0xca, 0xba, 0xbe, 0xb1, 0xc9, 0x35, 0x71, 0x3c, 0x62,
+	0xa2, 0x7f, 0x14, 0xac, 0x42, 0x37, 0x15, 0x5a, 0x06, 0xf1, 0x07, 0x8e, 0x7e, 0xab, 0x5b, 0xb6,
+	0xde, 0xb7, 0x09, 0xfa, 0x57, 0x39, 0xfe, 0xa3, 0x02, 0x20, 0xa7, 0xee, 0xa5, 0xff, 0xe3, 0x36,
+	0x34, 0xaf, 0x09, 0x63, 0xfa, 0x05, 0x41, 0x1b, 0x18, 0xa0, 0x61, 0xb8, 0xce, 0xb9, 0x75, 0x81,
+	0x2a, 0x78, 0x17, 0x3a, 0xf2, 0xd9, 0x1f, 0xdc, 0x98, 0xba, 0x47, 0x50, 0x15, 0xab, 0xb0, 0x47,
+	0x1c, 0xd3, 0xa5, 0x8c, 0x50, 0xdf, 0xa3, 0xba, 0xc3, 0x74, 0xc3, 0xb3, 0x5c, 0x07, 0x29, 0xf8,
+	0x05, 0x74, 0x5d, 0x6a, 0x12, 0xfa, 0x6c, 0xa1, 0x86, 0xf7, 0x61, 0xd7, 0x24, 0xb6, 0x95, 0x3a,
+	0x66, 0x84, 0x5c, 0xf9, 0x96, 0x73, 0xee, 0xa2, 0x7a, 0x4a, 0x1b, 0x97, 0xba, 0xe5, 0x18, 0xae,
+	0x49, 0xfc, 0x1b, 0xdd, 0xb8, 0x4a, 0xfb, 0x37, 0xd2, 0x06, 0x37, 0x84, 0x50, 0x9f, 0x12, 0xe6,
+	0x0e, 0xa8, 0x41, 0x8a, 0xd6, 0xcd, 0xe3, 0x08, 0xf0, 0x5a, 0x4a, 0x56, 0xfa, 0x06, 0xc7, 0xdb,
+	0x00, 0xcc, 0xba, 0x70, 0x74, 0x6f, 0x40, 0x09, 0x43, 0x1b, 0x78, 0x07, 0xda, 0xb6, 0xce, 0x3c,
+	0xbf, 0x3c, 0xc4, 0x0b, 0xe8, 0xae, 0xf8, 0x61, 0xfe, 0xb9, 0x65, 0x7b, 0x84, 0xa2, 0x6a, 0x7a,
+	0xec, 0xdc, 0x30, 0x52, 0x30, 0x86, 0xed, 0xeb, 0x5b, 0xc3, 0xc8, 0xb6, 0xd9, 0x96, 0xe1, 0x31,
+	0x54, 0xeb, 0x33, 0xf8, 0x34, 0x8a, 0xc7, 0xbd, 0xc9, 0xd3, 0x82, 0xc7, 0x33, 0x3e, 0x1a, 0xf3,
+	0xb8, 0x77, 0x1f, 0xdc, 0xc5, 0xd3, 0xa1, 0x7c, 0x87, 0x25, 0xf9, 0x05, 0x7f, 0x73, 0x32, 0x9e,
+	0x8a, 0xc9, 0xf2, 0x2e, 0x85, 0xa7, 0x2b, 0xe2, 0x53, 0x29, 0x96, 0x1f, 0xa8, 0x24, 0xff, 0x88,
+	0xdd, 0x35, 0x32, 0xf8, 0xe5, 0x7f, 0x03, 0x00, 0x9a, 0xcf, 0xb8, 0x47, 0xdc, 0x06, 0x00, 0x00,
 }
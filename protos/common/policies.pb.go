@@ -133,6 +133,7 @@ type SignaturePolicy struct {
 	// Types that are valid to be assigned to Type:
 	//	*SignaturePolicy_SignedBy
 	//	*SignaturePolicy_NOutOf_
+	//	*SignaturePolicy_PolicyRef
 	Type isSignaturePolicy_Type `protobuf_oneof:"Type"`
 }
 
@@ -151,9 +152,13 @@ type SignaturePolicy_SignedBy struct {
 type SignaturePolicy_NOutOf_ struct {
 	NOutOf *SignaturePolicy_NOutOf `protobuf:"bytes,2,opt,name=n_out_of,json=nOutOf,oneof"`
 }
+type SignaturePolicy_PolicyRef struct {
+	PolicyRef string `protobuf:"bytes,3,opt,name=policy_ref,json=policyRef,oneof"`
+}
 
-func (*SignaturePolicy_SignedBy) isSignaturePolicy_Type() {}
-func (*SignaturePolicy_NOutOf_) isSignaturePolicy_Type()  {}
+func (*SignaturePolicy_SignedBy) isSignaturePolicy_Type()  {}
+func (*SignaturePolicy_NOutOf_) isSignaturePolicy_Type()   {}
+func (*SignaturePolicy_PolicyRef) isSignaturePolicy_Type() {}
 
 func (m *SignaturePolicy) GetType() isSignaturePolicy_Type {
 	if m != nil {
@@ -176,11 +181,19 @@ func (m *SignaturePolicy) GetNOutOf() *SignaturePolicy_NOutOf {
 	return nil
 }
 
+func (m *SignaturePolicy) GetPolicyRef() string {
+	if x, ok := m.GetType().(*SignaturePolicy_PolicyRef); ok {
+		return x.PolicyRef
+	}
+	return ""
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*SignaturePolicy) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _SignaturePolicy_OneofMarshaler, _SignaturePolicy_OneofUnmarshaler, _SignaturePolicy_OneofSizer, []interface{}{
 		(*SignaturePolicy_SignedBy)(nil),
 		(*SignaturePolicy_NOutOf_)(nil),
+		(*SignaturePolicy_PolicyRef)(nil),
 	}
 }
 
@@ -196,6 +209,9 @@ func _SignaturePolicy_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
 		if err := b.EncodeMessage(x.NOutOf); err != nil {
 			return err
 		}
+	case *SignaturePolicy_PolicyRef:
+		b.EncodeVarint(3<<3 | proto.WireBytes)
+		b.EncodeStringBytes(x.PolicyRef)
 	case nil:
 	default:
 		return fmt.Errorf("SignaturePolicy.Type has unexpected type %T", x)
@@ -221,6 +237,13 @@ func _SignaturePolicy_OneofUnmarshaler(msg proto.Message, tag, wire int, b *prot
 		err := b.DecodeMessage(msg)
 		m.Type = &SignaturePolicy_NOutOf_{msg}
 		return true, err
+	case 3: // Type.policy_ref
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeStringBytes()
+		m.Type = &SignaturePolicy_PolicyRef{x}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -238,6 +261,10 @@ func _SignaturePolicy_OneofSizer(msg proto.Message) (n int) {
 		n += proto.SizeVarint(2<<3 | proto.WireBytes)
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *SignaturePolicy_PolicyRef:
+		n += proto.SizeVarint(3<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(len(x.PolicyRef)))
+		n += len(x.PolicyRef)
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -314,35 +341,37 @@ func init() {
 func init() { proto.RegisterFile("common/policies.proto", fileDescriptor5) }
 
 var fileDescriptor5 = []byte{
-	// 480 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x52, 0xdf, 0x8b, 0xda, 0x40,
-	0x10, 0x76, 0xfd, 0x11, 0x75, 0xf4, 0xda, 0x74, 0xb9, 0xa2, 0x1c, 0xb4, 0x95, 0x50, 0x8a, 0x70,
-	0x34, 0x01, 0xaf, 0x4f, 0x7d, 0xd3, 0x56, 0x7a, 0x69, 0x4d, 0x94, 0xd5, 0xa3, 0x5c, 0x5f, 0x82,
-	0xd1, 0xd5, 0x5b, 0x88, 0xbb, 0x4b, 0x76, 0x23, 0xf5, 0xbf, 0xe8, 0x53, 0xff, 0x99, 0xfe, 0x73,
-	0x25, 0x59, 0x2d, 0x72, 0xe5, 0xde, 0xe6, 0x9b, 0xfd, 0x66, 0xf6, 0xfb, 0x66, 0x06, 0x5e, 0xae,
-	0xc4, 0x6e, 0x27, 0xb8, 0x27, 0x45, 0xc2, 0x56, 0x8c, 0x2a, 0x57, 0xa6, 0x42, 0x0b, 0x6c, 0x99,
-	0xf4, 0x55, 0x67, 0xa7, 0xa4, 0xb7, 0x53, 0x32, 0x92, 0x29, 0xe3, 0x2b, 0x26, 0x97, 0x89, 0x21,
-	0x38, 0x3f, 0xc1, 0x9a, 0xe5, 0x25, 0x07, 0x8c, 0xa1, 0xaa, 0x0f, 0x92, 0x76, 0x51, 0x0f, 0xf5,
-	0x6b, 0xa4, 0x88, 0xf1, 0x25, 0xd4, 0xf6, 0xcb, 0x24, 0xa3, 0xdd, 0x72, 0x0f, 0xf5, 0xdb, 0xc4,
-	0x00, 0xe7, 0x33, 0x80, 0xa9, 0x59, 0xe4, 0x9c, 0x16, 0xd4, 0xef, 0xc2, 0x6f, 0xe1, 0xf4, 0x7b,
-	0x68, 0x97, 0xf0, 0x05, 0x34, 0xe7, 0xfe, 0x97, 0x70, 0xb8, 0xb8, 0x23, 0x63, 0x1b, 0xe1, 0x3a,
-	0x54, 0x82, 0xf9, 0xcc, 0x2e, 0xe3, 0x17, 0x70, 0xe1, 0x07, 0xb3, 0x89, 0xff, 0xc9, 0x5f, 0x44,
-	0xc1, 0x78, 0x31, 0xb4, 0x2b, 0xce, 0x6f, 0x04, 0x9d, 0x39, 0xdb, 0xf2, 0xa5, 0xce, 0x52, 0x6a,
-	0xfa, 0x8d, 0xf9, 0x9e, 0x26, 0x42, 0x52, 0xdc, 0x85, 0xfa, 0x9e, 0xa6, 0x8a, 0x09, 0x7e, 0x94,
-	0x73, 0x82, 0xf8, 0x1a, 0xaa, 0x69, 0x96, 0x18, 0x41, 0xad, 0x41, 0xc7, 0x35, 0xfe, 0xdc, 0x47,
-	0x8d, 0x48, 0x41, 0xc2, 0x1f, 0x00, 0xd8, 0x9a, 0x72, 0xcd, 0x34, 0xa3, 0xaa, 0x5b, 0xe9, 0x55,
-	0xfa, 0xad, 0xc1, 0xe5, 0xa9, 0x24, 0x98, 0xcf, 0x66, 0xa7, 0x61, 0x90, 0x33, 0x9e, 0xf3, 0x07,
-	0xc1, 0xf3, 0x47, 0xfd, 0xf0, 0x2b, 0x68, 0x2a, 0xb6, 0xe5, 0x74, 0x1d, 0xc5, 0x07, 0x23, 0xe9,
-	0xb6, 0x44, 0x1a, 0x26, 0x35, 0x3a, 0xe0, 0x8f, 0xd0, 0xe0, 0x91, 0xc8, 0x74, 0x24, 0x36, 0x47,
-	0x65, 0xaf, 0x9f, 0x50, 0xe6, 0x86, 0xd3, 0x4c, 0x4f, 0x37, 0xb7, 0x25, 0x62, 0xf1, 0x22, 0xba,
-	0x1a, 0x83, 0x65, 0x72, 0xb8, 0x0d, 0xe8, 0xe4, 0x17, 0x71, 0xfc, 0x1e, 0x6a, 0xb9, 0x09, 0xd5,
-	0x2d, 0x17, 0xba, 0x9f, 0xb4, 0x6a, 0x58, 0x23, 0x0b, 0xaa, 0xf9, 0x3a, 0x9c, 0x5f, 0x08, 0xb0,
-	0xbf, 0x93, 0xf9, 0x15, 0xe8, 0x80, 0xea, 0xe5, 0x3f, 0x03, 0xa0, 0xb2, 0x38, 0x2a, 0xce, 0xc3,
-	0x38, 0x68, 0x92, 0xa6, 0xca, 0xe2, 0xe3, 0xf3, 0xcd, 0xd9, 0x58, 0x9f, 0x0d, 0xde, 0x9c, 0xfe,
-	0xfa, 0xbf, 0x91, 0x4b, 0xb2, 0x84, 0x9a, 0xf1, 0x3a, 0xef, 0xa0, 0x9a, 0xa3, 0x7c, 0xcb, 0xc3,
-	0xf0, 0xde, 0x2e, 0x15, 0xc1, 0x64, 0x62, 0x23, 0xdc, 0x86, 0x46, 0x30, 0xfc, 0x3a, 0x25, 0xfe,
-	0xe2, 0xde, 0x2e, 0x8f, 0xe6, 0xf0, 0x56, 0xa4, 0x5b, 0xf7, 0xe1, 0x20, 0x69, 0x9a, 0xd0, 0xf5,
-	0x96, 0xa6, 0xee, 0x66, 0x19, 0xa7, 0x6c, 0x65, 0x6e, 0x50, 0x1d, 0x7f, 0xfb, 0x71, 0xbd, 0x65,
-	0xfa, 0x21, 0x8b, 0x73, 0xe8, 0x9d, 0x91, 0x3d, 0x43, 0xf6, 0x0c, 0xd9, 0x33, 0xe4, 0xd8, 0x2a,
-	0xe0, 0xcd, 0xdf, 0x00, 0x00, 0x00, 0xff, 0xff, 0x34, 0xab, 0x8a, 0xb5, 0xf9, 0x02, 0x00, 0x00,
+	// 497 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x52, 0x51, 0x8b, 0xda, 0x40,
+	0x10, 0x76, 0x8d, 0x46, 0x33, 0x7a, 0x6d, 0xba, 0x5c, 0x31, 0x1c, 0xb4, 0x27, 0xa1, 0x14, 0xe1,
+	0x68, 0x02, 0x5e, 0x9f, 0xfa, 0xa6, 0xad, 0xd4, 0xb4, 0x26, 0xca, 0xea, 0x51, 0xae, 0x2f, 0xc1,
+	0xe8, 0xea, 0x2d, 0xc4, 0x24, 0x64, 0x37, 0xd2, 0xfc, 0x8b, 0x3e, 0xf5, 0xd7, 0xf5, 0xc7, 0x94,
+	0x64, 0x4d, 0x91, 0x2b, 0xbd, 0xb7, 0xf9, 0x66, 0xbf, 0x99, 0xf9, 0xe6, 0xdb, 0x81, 0x97, 0x9b,
+	0xf8, 0x70, 0x88, 0x23, 0x3b, 0x89, 0x43, 0xb6, 0x61, 0x94, 0x5b, 0x49, 0x1a, 0x8b, 0x18, 0xab,
+	0x32, 0x7d, 0xd5, 0x3b, 0xf0, 0xc4, 0x3e, 0xf0, 0xc4, 0x4f, 0x52, 0x16, 0x6d, 0x58, 0xb2, 0x0e,
+	0x25, 0xc1, 0xfc, 0x01, 0xea, 0xa2, 0x28, 0xc9, 0x31, 0x86, 0x86, 0xc8, 0x13, 0x6a, 0xa0, 0x3e,
+	0x1a, 0x34, 0x49, 0x19, 0xe3, 0x4b, 0x68, 0x1e, 0xd7, 0x61, 0x46, 0x8d, 0x7a, 0x1f, 0x0d, 0xba,
+	0x44, 0x02, 0xf3, 0x13, 0x80, 0xac, 0x59, 0x15, 0x9c, 0x0e, 0xb4, 0xee, 0xbc, 0xaf, 0xde, 0xfc,
+	0x9b, 0xa7, 0xd7, 0xf0, 0x05, 0x68, 0x4b, 0xe7, 0xb3, 0x37, 0x5a, 0xdd, 0x91, 0x89, 0x8e, 0x70,
+	0x0b, 0x14, 0x77, 0xb9, 0xd0, 0xeb, 0xf8, 0x05, 0x5c, 0x38, 0xee, 0x62, 0xe6, 0x7c, 0x74, 0x56,
+	0xbe, 0x3b, 0x59, 0x8d, 0x74, 0xc5, 0xfc, 0x85, 0xa0, 0xb7, 0x64, 0xfb, 0x68, 0x2d, 0xb2, 0x94,
+	0xca, 0x7e, 0x93, 0xe8, 0x48, 0xc3, 0x38, 0xa1, 0xd8, 0x80, 0xd6, 0x91, 0xa6, 0x9c, 0xc5, 0xd1,
+	0x49, 0x4e, 0x05, 0xf1, 0x0d, 0x34, 0xd2, 0x2c, 0x94, 0x82, 0x3a, 0xc3, 0x9e, 0x25, 0xf7, 0xb3,
+	0x1e, 0x35, 0x22, 0x25, 0x09, 0xbf, 0x07, 0x60, 0x5b, 0x1a, 0x09, 0x26, 0x18, 0xe5, 0x86, 0xd2,
+	0x57, 0x06, 0x9d, 0xe1, 0x65, 0x55, 0xe2, 0x2e, 0x17, 0x8b, 0xca, 0x0c, 0x72, 0xc6, 0x33, 0x7f,
+	0x23, 0x78, 0xfe, 0xa8, 0x1f, 0x7e, 0x05, 0x1a, 0x67, 0xfb, 0x88, 0x6e, 0xfd, 0x20, 0x97, 0x92,
+	0xa6, 0x35, 0xd2, 0x96, 0xa9, 0x71, 0x8e, 0x3f, 0x40, 0x3b, 0xf2, 0xe3, 0x4c, 0xf8, 0xf1, 0xee,
+	0xa4, 0xec, 0xf5, 0x7f, 0x94, 0x59, 0xde, 0x3c, 0x13, 0xf3, 0xdd, 0xb4, 0x46, 0xd4, 0xa8, 0x8c,
+	0xf0, 0x35, 0x40, 0xf9, 0x69, 0xb9, 0x9f, 0xd2, 0x9d, 0xa1, 0xf4, 0xd1, 0x40, 0x9b, 0xd6, 0x88,
+	0x26, 0x73, 0x84, 0xee, 0xae, 0x26, 0xa0, 0xca, 0x22, 0xdc, 0x05, 0x54, 0x19, 0x82, 0x22, 0xfc,
+	0x0e, 0x9a, 0xc5, 0x96, 0xdc, 0xa8, 0xf7, 0x95, 0xa7, 0xbc, 0x90, 0xac, 0xb1, 0x0a, 0x8d, 0xe2,
+	0xbf, 0xcc, 0x9f, 0x08, 0xb0, 0x73, 0x48, 0x8a, 0x33, 0x11, 0x2e, 0x15, 0xeb, 0xbf, 0x1b, 0x02,
+	0xcf, 0x02, 0x5f, 0x8e, 0x2d, 0x87, 0x68, 0x44, 0xe3, 0x59, 0x70, 0x7a, 0xbe, 0x3d, 0xf3, 0xfd,
+	0xd9, 0xf0, 0xba, 0x9a, 0xf5, 0x6f, 0x23, 0x8b, 0x64, 0x21, 0x95, 0xfe, 0x9b, 0x6f, 0xa1, 0x51,
+	0xa0, 0xe2, 0x0c, 0x46, 0xde, 0xbd, 0x5e, 0x2b, 0x83, 0xd9, 0x4c, 0x47, 0xb8, 0x0b, 0x6d, 0x77,
+	0xf4, 0x65, 0x4e, 0x9c, 0xd5, 0xbd, 0x5e, 0x1f, 0x2f, 0xe1, 0x4d, 0x9c, 0xee, 0xad, 0x87, 0x3c,
+	0xa1, 0x69, 0x48, 0xb7, 0x7b, 0x9a, 0x5a, 0xbb, 0x75, 0x90, 0xb2, 0x8d, 0x3c, 0x52, 0x7e, 0x9a,
+	0xf6, 0xfd, 0x66, 0xcf, 0xc4, 0x43, 0x16, 0x14, 0xd0, 0x3e, 0x23, 0xdb, 0x92, 0x6c, 0x4b, 0xb2,
+	0x2d, 0xc9, 0x81, 0x5a, 0xc2, 0xdb, 0x3f, 0x03, 0x00, 0xe2, 0xab, 0x97, 0x72, 0x1a, 0x03, 0x00,
+	0x00,
 }
@@ -14,6 +14,7 @@ It has these top-level messages:
 	GossipMessage
 	StateInfo
 	Properties
+	Chaincode
 	StateInfoSnapshot
 	StateInfoPullRequest
 	ConnEstablish
@@ -1073,6 +1074,12 @@ func (m *StateInfo) GetProperties() *Properties {
 type Properties struct {
 	LedgerHeight uint64 `protobuf:"varint,1,opt,name=ledger_height,json=ledgerHeight" json:"ledger_height,omitempty"`
 	LeftChannel  bool   `protobuf:"varint,2,opt,name=left_channel,json=leftChannel" json:"left_channel,omitempty"`
+	// chaincodes are the chaincodes instantiated on the channel
+	Chaincodes []*Chaincode `protobuf:"bytes,3,rep,name=chaincodes" json:"chaincodes,omitempty"`
+	// installed_chaincodes are all the chaincodes installed on the peer
+	InstalledChaincodes []*Chaincode `protobuf:"bytes,4,rep,name=installed_chaincodes,json=installedChaincodes" json:"installed_chaincodes,omitempty"`
+	// roles are the roles the peer takes on, i.e endorser, committer
+	Roles []string `protobuf:"bytes,5,rep,name=roles" json:"roles,omitempty"`
 }
 
 func (m *Properties) Reset()                    { *m = Properties{} }
@@ -1094,6 +1101,53 @@ func (m *Properties) GetLeftChannel() bool {
 	return false
 }
 
+func (m *Properties) GetChaincodes() []*Chaincode {
+	if m != nil {
+		return m.Chaincodes
+	}
+	return nil
+}
+
+func (m *Properties) GetInstalledChaincodes() []*Chaincode {
+	if m != nil {
+		return m.InstalledChaincodes
+	}
+	return nil
+}
+
+func (m *Properties) GetRoles() []string {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+// Chaincode represents a Chaincode that is either
+// installed on a peer or instantiated on a channel
+type Chaincode struct {
+	Name    string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Version string `protobuf:"bytes,2,opt,name=version" json:"version,omitempty"`
+}
+
+func (m *Chaincode) Reset()                    { *m = Chaincode{} }
+func (m *Chaincode) String() string            { return proto.CompactTextString(m) }
+func (*Chaincode) ProtoMessage()               {}
+func (*Chaincode) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *Chaincode) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Chaincode) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
 // StateInfoSnapshot is an aggregation of StateInfo messages
 type StateInfoSnapshot struct {
 	Elements []*Envelope `protobuf:"bytes,1,rep,name=elements" json:"elements,omitempty"`
@@ -1102,7 +1156,7 @@ type StateInfoSnapshot struct {
 func (m *StateInfoSnapshot) Reset()                    { *m = StateInfoSnapshot{} }
 func (m *StateInfoSnapshot) String() string            { return proto.CompactTextString(m) }
 func (*StateInfoSnapshot) ProtoMessage()               {}
-func (*StateInfoSnapshot) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+func (*StateInfoSnapshot) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
 
 func (m *StateInfoSnapshot) GetElements() []*Envelope {
 	if m != nil {
@@ -1123,7 +1177,7 @@ type StateInfoPullRequest struct {
 func (m *StateInfoPullRequest) Reset()                    { *m = StateInfoPullRequest{} }
 func (m *StateInfoPullRequest) String() string            { return proto.CompactTextString(m) }
 func (*StateInfoPullRequest) ProtoMessage()               {}
-func (*StateInfoPullRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{7} }
+func (*StateInfoPullRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
 
 func (m *StateInfoPullRequest) GetChannel_MAC() []byte {
 	if m != nil {
@@ -1144,7 +1198,7 @@ type ConnEstablish struct {
 func (m *ConnEstablish) Reset()                    { *m = ConnEstablish{} }
 func (m *ConnEstablish) String() string            { return proto.CompactTextString(m) }
 func (*ConnEstablish) ProtoMessage()               {}
-func (*ConnEstablish) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{8} }
+func (*ConnEstablish) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
 
 func (m *ConnEstablish) GetPkiId() []byte {
 	if m != nil {
@@ -1179,7 +1233,7 @@ type PeerIdentity struct {
 func (m *PeerIdentity) Reset()                    { *m = PeerIdentity{} }
 func (m *PeerIdentity) String() string            { return proto.CompactTextString(m) }
 func (*PeerIdentity) ProtoMessage()               {}
-func (*PeerIdentity) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{9} }
+func (*PeerIdentity) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
 
 func (m *PeerIdentity) GetPkiId() []byte {
 	if m != nil {
@@ -1213,7 +1267,7 @@ type DataRequest struct {
 func (m *DataRequest) Reset()                    { *m = DataRequest{} }
 func (m *DataRequest) String() string            { return proto.CompactTextString(m) }
 func (*DataRequest) ProtoMessage()               {}
-func (*DataRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10} }
+func (*DataRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
 
 func (m *DataRequest) GetNonce() uint64 {
 	if m != nil {
@@ -1247,7 +1301,7 @@ type GossipHello struct {
 func (m *GossipHello) Reset()                    { *m = GossipHello{} }
 func (m *GossipHello) String() string            { return proto.CompactTextString(m) }
 func (*GossipHello) ProtoMessage()               {}
-func (*GossipHello) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*GossipHello) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *GossipHello) GetNonce() uint64 {
 	if m != nil {
@@ -1281,7 +1335,7 @@ type DataUpdate struct {
 func (m *DataUpdate) Reset()                    { *m = DataUpdate{} }
 func (m *DataUpdate) String() string            { return proto.CompactTextString(m) }
 func (*DataUpdate) ProtoMessage()               {}
-func (*DataUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*DataUpdate) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *DataUpdate) GetNonce() uint64 {
 	if m != nil {
@@ -1315,7 +1369,7 @@ type DataDigest struct {
 func (m *DataDigest) Reset()                    { *m = DataDigest{} }
 func (m *DataDigest) String() string            { return proto.CompactTextString(m) }
 func (*DataDigest) ProtoMessage()               {}
-func (*DataDigest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*DataDigest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *DataDigest) GetNonce() uint64 {
 	if m != nil {
@@ -1346,7 +1400,7 @@ type DataMessage struct {
 func (m *DataMessage) Reset()                    { *m = DataMessage{} }
 func (m *DataMessage) String() string            { return proto.CompactTextString(m) }
 func (*DataMessage) ProtoMessage()               {}
-func (*DataMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*DataMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *DataMessage) GetPayload() *Payload {
 	if m != nil {
@@ -1365,7 +1419,7 @@ type PrivateDataMessage struct {
 func (m *PrivateDataMessage) Reset()                    { *m = PrivateDataMessage{} }
 func (m *PrivateDataMessage) String() string            { return proto.CompactTextString(m) }
 func (*PrivateDataMessage) ProtoMessage()               {}
-func (*PrivateDataMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*PrivateDataMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 func (m *PrivateDataMessage) GetPayload() *PrivatePayload {
 	if m != nil {
@@ -1384,7 +1438,7 @@ type Payload struct {
 func (m *Payload) Reset()                    { *m = Payload{} }
 func (m *Payload) String() string            { return proto.CompactTextString(m) }
 func (*Payload) ProtoMessage()               {}
-func (*Payload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*Payload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *Payload) GetSeqNum() uint64 {
 	if m != nil {
@@ -1420,7 +1474,7 @@ type PrivatePayload struct {
 func (m *PrivatePayload) Reset()                    { *m = PrivatePayload{} }
 func (m *PrivatePayload) String() string            { return proto.CompactTextString(m) }
 func (*PrivatePayload) ProtoMessage()               {}
-func (*PrivatePayload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*PrivatePayload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *PrivatePayload) GetCollectionName() string {
 	if m != nil {
@@ -1461,7 +1515,7 @@ type AliveMessage struct {
 func (m *AliveMessage) Reset()                    { *m = AliveMessage{} }
 func (m *AliveMessage) String() string            { return proto.CompactTextString(m) }
 func (*AliveMessage) ProtoMessage()               {}
-func (*AliveMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*AliveMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *AliveMessage) GetMembership() *Member {
 	if m != nil {
@@ -1490,12 +1544,18 @@ type LeadershipMessage struct {
 	PkiId         []byte    `protobuf:"bytes,1,opt,name=pki_id,json=pkiId,proto3" json:"pki_id,omitempty"`
 	Timestamp     *PeerTime `protobuf:"bytes,2,opt,name=timestamp" json:"timestamp,omitempty"`
 	IsDeclaration bool      `protobuf:"varint,3,opt,name=is_declaration,json=isDeclaration" json:"is_declaration,omitempty"`
+	// weight is the configured weight of the peer,
+	// peers with higher weights are preferred as leaders
+	Weight uint64 `protobuf:"varint,4,opt,name=weight" json:"weight,omitempty"`
+	// ledger_height is the ledger height of the peer
+	// at the time the message was created
+	LedgerHeight uint64 `protobuf:"varint,5,opt,name=ledger_height,json=ledgerHeight" json:"ledger_height,omitempty"`
 }
 
 func (m *LeadershipMessage) Reset()                    { *m = LeadershipMessage{} }
 func (m *LeadershipMessage) String() string            { return proto.CompactTextString(m) }
 func (*LeadershipMessage) ProtoMessage()               {}
-func (*LeadershipMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*LeadershipMessage) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *LeadershipMessage) GetPkiId() []byte {
 	if m != nil {
@@ -1518,6 +1578,20 @@ func (m *LeadershipMessage) GetIsDeclaration() bool {
 	return false
 }
 
+func (m *LeadershipMessage) GetWeight() uint64 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *LeadershipMessage) GetLedgerHeight() uint64 {
+	if m != nil {
+		return m.LedgerHeight
+	}
+	return 0
+}
+
 // PeerTime defines the logical time of a peer's life
 type PeerTime struct {
 	IncNum uint64 `protobuf:"varint,1,opt,name=inc_num,json=incNum" json:"inc_num,omitempty"`
@@ -1527,7 +1601,7 @@ type PeerTime struct {
 func (m *PeerTime) Reset()                    { *m = PeerTime{} }
 func (m *PeerTime) String() string            { return proto.CompactTextString(m) }
 func (*PeerTime) ProtoMessage()               {}
-func (*PeerTime) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*PeerTime) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *PeerTime) GetIncNum() uint64 {
 	if m != nil {
@@ -1553,7 +1627,7 @@ type MembershipRequest struct {
 func (m *MembershipRequest) Reset()                    { *m = MembershipRequest{} }
 func (m *MembershipRequest) String() string            { return proto.CompactTextString(m) }
 func (*MembershipRequest) ProtoMessage()               {}
-func (*MembershipRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
+func (*MembershipRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
 
 func (m *MembershipRequest) GetSelfInformation() *Envelope {
 	if m != nil {
@@ -1578,7 +1652,7 @@ type MembershipResponse struct {
 func (m *MembershipResponse) Reset()                    { *m = MembershipResponse{} }
 func (m *MembershipResponse) String() string            { return proto.CompactTextString(m) }
 func (*MembershipResponse) ProtoMessage()               {}
-func (*MembershipResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+func (*MembershipResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
 
 func (m *MembershipResponse) GetAlive() []*Envelope {
 	if m != nil {
@@ -1605,7 +1679,7 @@ type Member struct {
 func (m *Member) Reset()                    { *m = Member{} }
 func (m *Member) String() string            { return proto.CompactTextString(m) }
 func (*Member) ProtoMessage()               {}
-func (*Member) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+func (*Member) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
 
 func (m *Member) GetEndpoint() string {
 	if m != nil {
@@ -1635,7 +1709,7 @@ type Empty struct {
 func (m *Empty) Reset()                    { *m = Empty{} }
 func (m *Empty) String() string            { return proto.CompactTextString(m) }
 func (*Empty) ProtoMessage()               {}
-func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+func (*Empty) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
 
 // RemoteStateRequest is used to ask a set of blocks
 // from a remote peer
@@ -1647,7 +1721,7 @@ type RemoteStateRequest struct {
 func (m *RemoteStateRequest) Reset()                    { *m = RemoteStateRequest{} }
 func (m *RemoteStateRequest) String() string            { return proto.CompactTextString(m) }
 func (*RemoteStateRequest) ProtoMessage()               {}
-func (*RemoteStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+func (*RemoteStateRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
 
 func (m *RemoteStateRequest) GetStartSeqNum() uint64 {
 	if m != nil {
@@ -1672,7 +1746,7 @@ type RemoteStateResponse struct {
 func (m *RemoteStateResponse) Reset()                    { *m = RemoteStateResponse{} }
 func (m *RemoteStateResponse) String() string            { return proto.CompactTextString(m) }
 func (*RemoteStateResponse) ProtoMessage()               {}
-func (*RemoteStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+func (*RemoteStateResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
 
 func (m *RemoteStateResponse) GetPayloads() []*Payload {
 	if m != nil {
@@ -1690,7 +1764,7 @@ type RemotePvtDataRequest struct {
 func (m *RemotePvtDataRequest) Reset()                    { *m = RemotePvtDataRequest{} }
 func (m *RemotePvtDataRequest) String() string            { return proto.CompactTextString(m) }
 func (*RemotePvtDataRequest) ProtoMessage()               {}
-func (*RemotePvtDataRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{27} }
+func (*RemotePvtDataRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
 func (m *RemotePvtDataRequest) GetDigests() []*PvtDataDigest {
 	if m != nil {
@@ -1711,7 +1785,7 @@ type PvtDataDigest struct {
 func (m *PvtDataDigest) Reset()                    { *m = PvtDataDigest{} }
 func (m *PvtDataDigest) String() string            { return proto.CompactTextString(m) }
 func (*PvtDataDigest) ProtoMessage()               {}
-func (*PvtDataDigest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
+func (*PvtDataDigest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
 
 func (m *PvtDataDigest) GetTxId() string {
 	if m != nil {
@@ -1757,7 +1831,7 @@ type RemotePvtDataResponse struct {
 func (m *RemotePvtDataResponse) Reset()                    { *m = RemotePvtDataResponse{} }
 func (m *RemotePvtDataResponse) String() string            { return proto.CompactTextString(m) }
 func (*RemotePvtDataResponse) ProtoMessage()               {}
-func (*RemotePvtDataResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{29} }
+func (*RemotePvtDataResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
 
 func (m *RemotePvtDataResponse) GetElements() []*PvtDataElement {
 	if m != nil {
@@ -1775,7 +1849,7 @@ type PvtDataElement struct {
 func (m *PvtDataElement) Reset()                    { *m = PvtDataElement{} }
 func (m *PvtDataElement) String() string            { return proto.CompactTextString(m) }
 func (*PvtDataElement) ProtoMessage()               {}
-func (*PvtDataElement) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{30} }
+func (*PvtDataElement) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
 
 func (m *PvtDataElement) GetDigest() *PvtDataDigest {
 	if m != nil {
@@ -1803,7 +1877,7 @@ type PvtDataPayload struct {
 func (m *PvtDataPayload) Reset()                    { *m = PvtDataPayload{} }
 func (m *PvtDataPayload) String() string            { return proto.CompactTextString(m) }
 func (*PvtDataPayload) ProtoMessage()               {}
-func (*PvtDataPayload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{31} }
+func (*PvtDataPayload) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
 
 func (m *PvtDataPayload) GetTxSeqInBlock() uint64 {
 	if m != nil {
@@ -1826,7 +1900,7 @@ type Acknowledgement struct {
 func (m *Acknowledgement) Reset()                    { *m = Acknowledgement{} }
 func (m *Acknowledgement) String() string            { return proto.CompactTextString(m) }
 func (*Acknowledgement) ProtoMessage()               {}
-func (*Acknowledgement) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+func (*Acknowledgement) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
 
 func (m *Acknowledgement) GetError() string {
 	if m != nil {
@@ -1842,6 +1916,7 @@ func init() {
 	proto.RegisterType((*GossipMessage)(nil), "gossip.GossipMessage")
 	proto.RegisterType((*StateInfo)(nil), "gossip.StateInfo")
 	proto.RegisterType((*Properties)(nil), "gossip.Properties")
+	proto.RegisterType((*Chaincode)(nil), "gossip.Chaincode")
 	proto.RegisterType((*StateInfoSnapshot)(nil), "gossip.StateInfoSnapshot")
 	proto.RegisterType((*StateInfoPullRequest)(nil), "gossip.StateInfoPullRequest")
 	proto.RegisterType((*ConnEstablish)(nil), "gossip.ConnEstablish")
@@ -2018,116 +2093,122 @@ var _Gossip_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("gossip/message.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1773 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xdb, 0x6e, 0xe3, 0xc6,
-	0x19, 0x16, 0xad, 0x23, 0x7f, 0x1d, 0x2c, 0x8f, 0xbd, 0xbb, 0x8c, 0x93, 0xa6, 0x0e, 0xdb, 0x4d,
-	0xb6, 0x75, 0x62, 0x2f, 0x9c, 0x16, 0x0d, 0x90, 0xb6, 0x0b, 0xdb, 0x52, 0x2c, 0x21, 0x2b, 0xaf,
-	0x4b, 0x7b, 0xd1, 0xba, 0x37, 0xc4, 0x98, 0x1c, 0x53, 0xac, 0xc9, 0x21, 0xcd, 0x19, 0x6f, 0xec,
-	0xcb, 0xa2, 0x17, 0x05, 0x7a, 0xd7, 0x47, 0xe8, 0xb3, 0xf4, 0xc5, 0x8a, 0x99, 0xe1, 0x51, 0xb2,
-	0x16, 0xd8, 0x05, 0x7a, 0xa7, 0xff, 0x3c, 0xf3, 0xcd, 0x7f, 0xa2, 0x60, 0xcb, 0x8b, 0x18, 0xf3,
-	0xe3, 0xfd, 0x90, 0x30, 0x86, 0x3d, 0xb2, 0x17, 0x27, 0x11, 0x8f, 0x50, 0x4b, 0x71, 0xcd, 0x7f,
-	0x68, 0xd0, 0x19, 0xd3, 0x77, 0x24, 0x88, 0x62, 0x82, 0x0c, 0x68, 0xc7, 0xf8, 0x21, 0x88, 0xb0,
-	0x6b, 0x68, 0x3b, 0xda, 0x8b, 0x9e, 0x95, 0x91, 0xe8, 0x33, 0xd0, 0x99, 0xef, 0x51, 0xcc, 0xef,
-	0x12, 0x62, 0xac, 0x49, 0x59, 0xc1, 0x40, 0xaf, 0x60, 0x9d, 0x11, 0x27, 0x21, 0xdc, 0x26, 0xa9,
-	0x2b, 0xa3, 0xbe, 0xa3, 0xbd, 0xe8, 0x1e, 0x3c, 0xdd, 0x53, 0x61, 0xf6, 0xce, 0xa5, 0x38, 0x0b,
-	0x64, 0x0d, 0x58, 0x85, 0x36, 0x27, 0x30, 0xa8, 0x6a, 0x7c, 0xec, 0x51, 0xcc, 0x43, 0x68, 0x29,
-	0x4f, 0xe8, 0x6b, 0x18, 0xfa, 0x94, 0x93, 0x84, 0xe2, 0x60, 0x4c, 0xdd, 0x38, 0xf2, 0x29, 0x97,
-	0xae, 0xf4, 0x49, 0xcd, 0x5a, 0x92, 0x1c, 0xe9, 0xd0, 0x76, 0x22, 0xca, 0x09, 0xe5, 0xe6, 0x3f,
-	0xbb, 0xd0, 0x3f, 0x91, 0xc7, 0x9e, 0x29, 0xc8, 0xd0, 0x16, 0x34, 0x69, 0x44, 0x1d, 0x22, 0xed,
-	0x1b, 0x96, 0x22, 0xc4, 0x11, 0x9d, 0x39, 0xa6, 0x94, 0x04, 0xe9, 0x31, 0x32, 0x12, 0xed, 0x42,
-	0x9d, 0x63, 0x4f, 0x62, 0x30, 0x38, 0xf8, 0x24, 0xc3, 0xa0, 0xe2, 0x73, 0xef, 0x02, 0x7b, 0x96,
-	0xd0, 0x42, 0xdf, 0x82, 0x8e, 0x03, 0xff, 0x1d, 0xb1, 0x43, 0xe6, 0x19, 0x4d, 0x09, 0xdb, 0x56,
-	0x66, 0x72, 0x28, 0x04, 0xa9, 0xc5, 0xa4, 0x66, 0x75, 0xa4, 0xe2, 0x8c, 0x79, 0xe8, 0x37, 0xd0,
-	0x0e, 0x49, 0x68, 0x27, 0xe4, 0xd6, 0x68, 0x49, 0x93, 0x3c, 0xca, 0x8c, 0x84, 0x57, 0x24, 0x61,
-	0x73, 0x3f, 0xb6, 0xc8, 0xed, 0x1d, 0x61, 0x7c, 0x52, 0xb3, 0x5a, 0x21, 0x09, 0x2d, 0x72, 0x8b,
-	0x7e, 0x9b, 0x59, 0x31, 0xa3, 0x2d, 0xad, 0xb6, 0x1f, 0xb3, 0x62, 0x71, 0x44, 0x19, 0xc9, 0xcd,
-	0x18, 0x7a, 0x09, 0x1d, 0x17, 0x73, 0x2c, 0x0f, 0xd8, 0x91, 0x76, 0x9b, 0x99, 0xdd, 0x08, 0x73,
-	0x5c, 0x9c, 0xaf, 0x2d, 0xd4, 0xc4, 0xf1, 0x76, 0xa1, 0x39, 0x27, 0x41, 0x10, 0x19, 0x7a, 0x55,
-	0x5d, 0x41, 0x30, 0x11, 0xa2, 0x49, 0xcd, 0x52, 0x3a, 0x68, 0x3f, 0x75, 0xef, 0xfa, 0x9e, 0x01,
-	0x52, 0x1f, 0x95, 0xdd, 0x8f, 0x7c, 0x4f, 0xdd, 0x42, 0x7a, 0x1f, 0xf9, 0x5e, 0x7e, 0x1e, 0x71,
-	0xfb, 0xee, 0xf2, 0x79, 0x8a, 0x7b, 0x4b, 0x0b, 0x75, 0xf1, 0xae, 0xb4, 0xb8, 0x8b, 0x5d, 0xcc,
-	0x89, 0xd1, 0x5b, 0x8e, 0xf2, 0x56, 0x4a, 0x26, 0x35, 0x0b, 0xdc, 0x9c, 0x42, 0xcf, 0xa1, 0x49,
-	0xc2, 0x98, 0x3f, 0x18, 0x7d, 0x69, 0xd0, 0xcf, 0x0c, 0xc6, 0x82, 0x29, 0x2e, 0x20, 0xa5, 0x68,
-	0x17, 0x1a, 0x4e, 0x44, 0xa9, 0x31, 0x90, 0x5a, 0x4f, 0x32, 0xad, 0xe3, 0x88, 0xd2, 0x31, 0xe3,
-	0xf8, 0x2a, 0xf0, 0xd9, 0x7c, 0x52, 0xb3, 0xa4, 0x12, 0x3a, 0x00, 0x60, 0x1c, 0x73, 0x62, 0xfb,
-	0xf4, 0x3a, 0x32, 0xd6, 0xa5, 0xc9, 0x46, 0x5e, 0x26, 0x42, 0x32, 0xa5, 0xd7, 0x02, 0x1d, 0x9d,
-	0x65, 0x04, 0x3a, 0x82, 0x81, 0xb2, 0x61, 0x14, 0xc7, 0x6c, 0x1e, 0x71, 0x63, 0x58, 0x7d, 0xf4,
-	0xdc, 0xee, 0x3c, 0x55, 0x98, 0xd4, 0xac, 0xbe, 0x34, 0xc9, 0x18, 0x68, 0x06, 0x9b, 0x45, 0x5c,
-	0x3b, 0xbe, 0x0b, 0x02, 0x89, 0xdf, 0x86, 0x74, 0xf4, 0xd9, 0x92, 0xa3, 0xb3, 0xbb, 0x20, 0x28,
-	0x80, 0x1c, 0xb2, 0x05, 0x3e, 0x3a, 0x04, 0xe5, 0x5f, 0x38, 0x11, 0x4a, 0x06, 0xaa, 0x26, 0x94,
-	0x45, 0xc2, 0x88, 0x13, 0xe9, 0xae, 0x70, 0xd3, 0x63, 0x25, 0x1a, 0x8d, 0xb2, 0x5b, 0x25, 0x69,
-	0xca, 0x19, 0x9b, 0xd2, 0xc7, 0xa7, 0x8f, 0xfa, 0xc8, 0xb3, 0xb2, 0xcf, 0xca, 0x0c, 0x81, 0x4d,
-	0x40, 0xb0, 0xab, 0x92, 0x57, 0xa6, 0xe8, 0x56, 0x15, 0x9b, 0xd7, 0xb9, 0xb4, 0x48, 0xd4, 0x7e,
-	0x61, 0x22, 0xd2, 0xf5, 0x7b, 0xe8, 0xc7, 0x84, 0x24, 0xb6, 0xef, 0x12, 0xca, 0x7d, 0xfe, 0x60,
-	0x3c, 0xa9, 0x96, 0xe1, 0x19, 0x21, 0xc9, 0x34, 0x95, 0x89, 0x6b, 0xc4, 0x25, 0x5a, 0x14, 0x3b,
-	0x76, 0x6e, 0x8c, 0xa7, 0xd2, 0xe4, 0x59, 0x5e, 0xb9, 0xce, 0x0d, 0x8d, 0x7e, 0x0a, 0x88, 0xeb,
-	0x91, 0x90, 0x50, 0x71, 0x79, 0xa1, 0x85, 0xfe, 0x08, 0x10, 0x27, 0xfe, 0x3b, 0x85, 0x82, 0xf1,
-	0xac, 0x0a, 0xbe, 0xba, 0xef, 0xd9, 0x3b, 0x5e, 0xcd, 0xe2, 0x92, 0x05, 0x7a, 0x55, 0xb2, 0x67,
-	0x86, 0x21, 0xed, 0x7f, 0xb6, 0xc2, 0x3e, 0x47, 0xac, 0x64, 0x82, 0x5e, 0x41, 0x2f, 0xa5, 0x6c,
-	0x91, 0xe8, 0xc6, 0x27, 0xd5, 0x67, 0x3b, 0x53, 0xb2, 0x6a, 0x59, 0x77, 0xe3, 0x82, 0x6b, 0xda,
-	0x50, 0xbf, 0xc0, 0x1e, 0xea, 0x83, 0xfe, 0xf6, 0x74, 0x34, 0xfe, 0x61, 0x7a, 0x3a, 0x1e, 0x0d,
-	0x6b, 0x48, 0x87, 0xe6, 0x78, 0x76, 0x76, 0x71, 0x39, 0xd4, 0x50, 0x0f, 0x3a, 0x6f, 0xac, 0x13,
-	0xfb, 0xcd, 0xe9, 0xeb, 0xcb, 0xe1, 0x9a, 0xd0, 0x3b, 0x9e, 0x1c, 0x9e, 0x2a, 0xb2, 0x8e, 0x86,
-	0xd0, 0x93, 0xe4, 0xe1, 0xe9, 0xc8, 0x7e, 0x63, 0x9d, 0x0c, 0x1b, 0x68, 0x1d, 0xba, 0x4a, 0xc1,
-	0x92, 0x8c, 0x66, 0xb9, 0x13, 0xff, 0x57, 0x03, 0x3d, 0xcf, 0x48, 0xb4, 0x0d, 0x9d, 0x90, 0x70,
-	0x2c, 0x8f, 0xad, 0x66, 0x42, 0x4e, 0xa3, 0x3d, 0xd0, 0xb9, 0x1f, 0x12, 0xc6, 0x71, 0x18, 0xcb,
-	0x6e, 0xdc, 0x3d, 0x18, 0x96, 0x5f, 0xef, 0xc2, 0x0f, 0x89, 0x55, 0xa8, 0xa0, 0x27, 0xd0, 0x8a,
-	0x6f, 0x7c, 0xdb, 0x77, 0x65, 0x93, 0xee, 0x59, 0xcd, 0xf8, 0xc6, 0x9f, 0xba, 0xe8, 0xe7, 0xd0,
-	0x4d, 0x7b, 0xb8, 0x3d, 0x3b, 0x3c, 0x36, 0x1a, 0x52, 0x06, 0x29, 0x6b, 0x76, 0x78, 0x2c, 0xaa,
-	0x37, 0x4e, 0xa2, 0x98, 0x24, 0xdc, 0x27, 0x2c, 0xed, 0xd6, 0xa8, 0x00, 0x2f, 0x93, 0x58, 0x25,
-	0x2d, 0xf3, 0x02, 0xa0, 0x90, 0xa0, 0x5f, 0x40, 0x5f, 0x66, 0x45, 0x62, 0xcf, 0x89, 0xef, 0xcd,
-	0x79, 0x3a, 0x53, 0x7a, 0x8a, 0x39, 0x91, 0x3c, 0xf4, 0x05, 0xf4, 0x02, 0x72, 0xcd, 0xed, 0xf2,
-	0x7c, 0xe9, 0x58, 0x5d, 0xc1, 0x3b, 0x56, 0x2c, 0xf3, 0x10, 0x36, 0x96, 0xaa, 0x1e, 0x7d, 0x0d,
-	0x1d, 0x12, 0xc8, 0x84, 0x63, 0x86, 0xb6, 0x53, 0x2f, 0xa3, 0x90, 0xcf, 0xde, 0x5c, 0xc3, 0xfc,
-	0x1d, 0x6c, 0x3d, 0x56, 0xef, 0x8b, 0x28, 0x68, 0x8b, 0x28, 0x98, 0xd7, 0xd0, 0xaf, 0x34, 0xb7,
-	0x12, 0x9c, 0x5a, 0x19, 0xce, 0x6d, 0xe8, 0xe4, 0x25, 0xa5, 0x46, 0x64, 0x4e, 0x23, 0x13, 0xfa,
-	0x3c, 0x60, 0xb6, 0x43, 0x12, 0x6e, 0xcf, 0x31, 0x9b, 0xa7, 0x0f, 0xd1, 0xe5, 0x01, 0x3b, 0x26,
-	0x09, 0x9f, 0x60, 0x36, 0x37, 0xdf, 0x42, 0xaf, 0x5c, 0x7a, 0xab, 0xc2, 0x20, 0x68, 0x08, 0x37,
-	0x69, 0x08, 0xf9, 0xbb, 0x92, 0x2c, 0xf5, 0x6a, 0xb2, 0x98, 0x21, 0x74, 0x4b, 0x15, 0xb6, 0x7a,
-	0xba, 0xbb, 0x72, 0xf2, 0x30, 0x63, 0x6d, 0xa7, 0xfe, 0x42, 0xb7, 0x32, 0x12, 0xed, 0x41, 0x27,
-	0x64, 0x9e, 0xcd, 0x1f, 0xd2, 0x35, 0x67, 0x50, 0x8c, 0x1f, 0x81, 0xe2, 0x8c, 0x79, 0x17, 0x0f,
-	0x31, 0xb1, 0xda, 0xa1, 0xfa, 0x61, 0x46, 0xd0, 0x2d, 0xcd, 0xbd, 0x15, 0xe1, 0xca, 0xe7, 0x5d,
-	0x5b, 0x4a, 0xee, 0x0f, 0x0b, 0x78, 0x0f, 0x50, 0x8c, 0xb4, 0x15, 0xf1, 0x7e, 0x09, 0x8d, 0x34,
-	0xd6, 0xe3, 0x59, 0xd2, 0xf8, 0xa8, 0xc8, 0x81, 0x8a, 0xac, 0x46, 0xf6, 0xff, 0x1d, 0xd8, 0xef,
-	0xd4, 0x3b, 0x66, 0x5b, 0xda, 0xaf, 0xaa, 0x2b, 0x63, 0xf7, 0x60, 0x3d, 0xb7, 0x56, 0xec, 0x7c,
-	0x87, 0x34, 0x7f, 0x00, 0xb4, 0xdc, 0xe9, 0xd0, 0xcb, 0x45, 0x07, 0x4f, 0x17, 0xda, 0xe2, 0x92,
-	0x9f, 0x4b, 0x68, 0xa7, 0x3c, 0xf4, 0x0c, 0xda, 0x8c, 0xdc, 0xda, 0xf4, 0x2e, 0x4c, 0xaf, 0xdb,
-	0x62, 0xe4, 0xf6, 0xf4, 0x2e, 0x14, 0xd9, 0x59, 0x7a, 0x55, 0x85, 0xeb, 0x17, 0x0b, 0x5d, 0xb8,
-	0xbe, 0x53, 0x17, 0xb9, 0x5f, 0xee, 0xb3, 0xff, 0xd6, 0x60, 0x50, 0x0d, 0x8b, 0xbe, 0x82, 0x75,
-	0x27, 0x0a, 0x02, 0xe2, 0x70, 0x3f, 0xa2, 0x36, 0xc5, 0xa1, 0x42, 0x56, 0xb7, 0x06, 0x05, 0xfb,
-	0x14, 0x87, 0x44, 0xac, 0xc8, 0x42, 0xca, 0x62, 0xec, 0xa8, 0x15, 0x59, 0xb7, 0x0a, 0x06, 0xda,
-	0x84, 0x26, 0xbf, 0xcf, 0x5a, 0x9f, 0x6e, 0x35, 0xf8, 0xfd, 0xd4, 0x15, 0x6d, 0x29, 0x3b, 0x51,
-	0xf2, 0x13, 0x23, 0x3c, 0xed, 0x7d, 0xd9, 0x31, 0x2d, 0xc1, 0x33, 0xff, 0xa5, 0x41, 0xaf, 0xbc,
-	0x92, 0xa2, 0x3d, 0x80, 0x30, 0xdf, 0x1c, 0x53, 0xd0, 0x06, 0xd5, 0x9d, 0xd2, 0x2a, 0x69, 0x7c,
-	0x70, 0x9b, 0x2e, 0x37, 0x90, 0x46, 0xb5, 0x81, 0x98, 0x7f, 0xd7, 0x60, 0x63, 0x69, 0xb6, 0xaf,
-	0x6a, 0x11, 0x1f, 0x1a, 0xf8, 0x39, 0x0c, 0x7c, 0x66, 0xbb, 0xc4, 0x09, 0x70, 0x82, 0x05, 0xae,
-	0x12, 0xac, 0x8e, 0xd5, 0xf7, 0xd9, 0xa8, 0x60, 0x9a, 0xbf, 0x87, 0x4e, 0x66, 0x2d, 0x12, 0xc0,
-	0xa7, 0x4e, 0x39, 0x01, 0x7c, 0xea, 0x88, 0x04, 0x28, 0x65, 0xc6, 0x5a, 0x39, 0x33, 0xcc, 0x6b,
-	0xd8, 0x58, 0xda, 0xd6, 0xd1, 0xf7, 0x30, 0x64, 0x24, 0xb8, 0x96, 0x6b, 0x5a, 0x12, 0xaa, 0xd8,
-	0x5a, 0xf5, 0xc0, 0x79, 0x91, 0xae, 0x0b, 0xcd, 0x69, 0xa1, 0x28, 0x2a, 0x4e, 0xac, 0x1d, 0x54,
-	0x56, 0x56, 0xcf, 0x52, 0x84, 0x79, 0x05, 0x68, 0x79, 0xbf, 0x47, 0x5f, 0x42, 0x53, 0x7e, 0x4e,
-	0xac, 0x1c, 0x14, 0x4a, 0x2c, 0x3b, 0x05, 0xc1, 0xee, 0x7b, 0x3a, 0x05, 0xc1, 0xae, 0xf9, 0x67,
-	0x68, 0xa9, 0x18, 0xe2, 0xcd, 0x48, 0xe5, 0x7b, 0xcb, 0xca, 0xe9, 0xf7, 0x76, 0xb9, 0xc7, 0x47,
-	0xb2, 0xd9, 0x86, 0xa6, 0x5c, 0xb7, 0xcd, 0xbf, 0x00, 0x5a, 0x5e, 0x2a, 0xc5, 0x18, 0x61, 0x1c,
-	0x27, 0xdc, 0xae, 0x16, 0x5f, 0x57, 0x32, 0xcf, 0x55, 0x05, 0x7e, 0x0e, 0x5d, 0x42, 0x5d, 0xbb,
-	0xfa, 0x08, 0x3a, 0xa1, 0xae, 0x92, 0x9b, 0x47, 0xb0, 0xf9, 0xc8, 0xaa, 0x89, 0x76, 0xa1, 0x93,
-	0xd6, 0x79, 0x36, 0x4c, 0x97, 0x1a, 0x4a, 0xae, 0x60, 0x9e, 0xc0, 0xd6, 0x63, 0xeb, 0x1b, 0xda,
-	0x2f, 0xba, 0x9d, 0xf2, 0x91, 0x7f, 0x1e, 0xa4, 0x8a, 0xaa, 0x57, 0xe6, 0x4d, 0xd0, 0xfc, 0x8f,
-	0x06, 0xfd, 0x8a, 0xa8, 0xa8, 0x57, 0xad, 0x54, 0xaf, 0xef, 0x2f, 0xf1, 0xcf, 0x01, 0x8a, 0x96,
-	0x90, 0xd6, 0x79, 0x89, 0x83, 0x3e, 0x05, 0xfd, 0x2a, 0x88, 0x9c, 0x1b, 0x81, 0x89, 0x2c, 0xac,
-	0x86, 0xd5, 0x91, 0x8c, 0x73, 0x72, 0x8b, 0x76, 0xa0, 0x27, 0xa0, 0xf2, 0xa9, 0x2d, 0x59, 0x72,
-	0xcb, 0x69, 0x58, 0xc0, 0xc8, 0xed, 0x94, 0x1e, 0x09, 0x8e, 0xf9, 0x23, 0x3c, 0x79, 0x74, 0xd7,
-	0x44, 0x07, 0x4b, 0xfb, 0xc7, 0xd3, 0x85, 0xeb, 0x8e, 0x95, 0xb8, 0xb4, 0x85, 0x5c, 0xc2, 0xa0,
-	0x2a, 0x43, 0xdf, 0x40, 0x4b, 0xa1, 0x91, 0x26, 0xfe, 0x0a, 0xc8, 0x52, 0xa5, 0xf2, 0x5f, 0x05,
-	0x2a, 0xed, 0xf3, 0xf6, 0xfc, 0xa7, 0xdc, 0x75, 0xd6, 0x42, 0x9f, 0xc3, 0x3a, 0xbf, 0xb7, 0x2b,
-	0xd7, 0x4b, 0xf7, 0x2f, 0x7e, 0x7f, 0x9e, 0x5f, 0xb0, 0xea, 0xb2, 0xfc, 0xef, 0x83, 0xf9, 0x15,
-	0xac, 0x2f, 0xac, 0xf6, 0xa2, 0xe8, 0x48, 0x92, 0x44, 0x49, 0xfa, 0x3e, 0x8a, 0xf8, 0xf5, 0x1f,
-	0xa0, 0x5b, 0x1a, 0x5a, 0x8b, 0xfb, 0x72, 0x1f, 0xf4, 0xa3, 0xd7, 0x6f, 0x8e, 0x7f, 0xb4, 0x67,
-	0xe7, 0x27, 0x43, 0x4d, 0xac, 0xc5, 0xd3, 0xd1, 0xf8, 0xf4, 0x62, 0x7a, 0x71, 0x29, 0x39, 0x6b,
-	0x07, 0x7f, 0x83, 0x96, 0x5a, 0x1a, 0xd0, 0x77, 0xd0, 0x53, 0xbf, 0xce, 0x79, 0x42, 0x70, 0x88,
-	0x96, 0x2a, 0x70, 0x7b, 0x89, 0x63, 0xd6, 0x5e, 0x68, 0x2f, 0x35, 0xf4, 0x25, 0x34, 0xce, 0x7c,
-	0xea, 0xa1, 0xea, 0x77, 0xeb, 0x76, 0x95, 0x34, 0x6b, 0x47, 0xdf, 0xfc, 0x75, 0xd7, 0xf3, 0xf9,
-	0xfc, 0xee, 0x6a, 0xcf, 0x89, 0xc2, 0xfd, 0xf9, 0x43, 0x4c, 0x12, 0xb5, 0x8d, 0xee, 0x5f, 0xe3,
-	0xab, 0xc4, 0x77, 0xf6, 0xe5, 0x5f, 0x46, 0x6c, 0x5f, 0x99, 0x5d, 0xb5, 0x24, 0xf9, 0xed, 0xff,
-	0x02, 0x00, 0x00, 0xff, 0xff, 0xb4, 0xb4, 0x9a, 0xc5, 0x59, 0x12, 0x00, 0x00,
+	// 1865 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xdd, 0x72, 0xe3, 0x48,
+	0x15, 0xb6, 0x12, 0xdb, 0xb1, 0x8e, 0x7f, 0xe2, 0xe9, 0x64, 0x32, 0xda, 0xec, 0xb0, 0x04, 0xc1,
+	0xec, 0x0e, 0x64, 0x37, 0x19, 0xb2, 0x50, 0x2c, 0xb5, 0xc0, 0x54, 0x62, 0x7b, 0x63, 0xd7, 0x8e,
+	0x33, 0x41, 0xc9, 0x14, 0x84, 0x1b, 0x95, 0x22, 0x75, 0x6c, 0x11, 0xa9, 0xa5, 0xa8, 0x3b, 0x99,
+	0xe4, 0x9a, 0x0b, 0xaa, 0xb8, 0xe3, 0x11, 0x78, 0x11, 0x6e, 0x78, 0x14, 0x5e, 0x84, 0xea, 0x6e,
+	0xfd, 0xb4, 0x22, 0x7b, 0xaa, 0x66, 0xab, 0xb8, 0xd3, 0xf9, 0xed, 0xee, 0xd3, 0xe7, 0x7c, 0xe7,
+	0xb4, 0x60, 0x73, 0x16, 0x51, 0xea, 0xc7, 0xfb, 0x21, 0xa6, 0xd4, 0x99, 0xe1, 0xbd, 0x38, 0x89,
+	0x58, 0x84, 0x9a, 0x92, 0x6b, 0xfe, 0x4d, 0x83, 0xd6, 0x88, 0xdc, 0xe1, 0x20, 0x8a, 0x31, 0x32,
+	0x60, 0x2d, 0x76, 0x1e, 0x82, 0xc8, 0xf1, 0x0c, 0x6d, 0x47, 0x7b, 0xd9, 0xb1, 0x32, 0x12, 0x3d,
+	0x07, 0x9d, 0xfa, 0x33, 0xe2, 0xb0, 0xdb, 0x04, 0x1b, 0x2b, 0x42, 0x56, 0x30, 0xd0, 0x6b, 0x58,
+	0xa7, 0xd8, 0x4d, 0x30, 0xb3, 0x71, 0xea, 0xca, 0x58, 0xdd, 0xd1, 0x5e, 0xb6, 0x0f, 0xb6, 0xf6,
+	0xe4, 0x32, 0x7b, 0x67, 0x42, 0x9c, 0x2d, 0x64, 0xf5, 0x68, 0x89, 0x36, 0xc7, 0xd0, 0x2b, 0x6b,
+	0xfc, 0xd0, 0xad, 0x98, 0x87, 0xd0, 0x94, 0x9e, 0xd0, 0x97, 0xd0, 0xf7, 0x09, 0xc3, 0x09, 0x71,
+	0x82, 0x11, 0xf1, 0xe2, 0xc8, 0x27, 0x4c, 0xb8, 0xd2, 0xc7, 0x35, 0xab, 0x22, 0x39, 0xd2, 0x61,
+	0xcd, 0x8d, 0x08, 0xc3, 0x84, 0x99, 0x7f, 0x6f, 0x43, 0xf7, 0x58, 0x6c, 0x7b, 0x2a, 0x43, 0x86,
+	0x36, 0xa1, 0x41, 0x22, 0xe2, 0x62, 0x61, 0x5f, 0xb7, 0x24, 0xc1, 0xb7, 0xe8, 0xce, 0x1d, 0x42,
+	0x70, 0x90, 0x6e, 0x23, 0x23, 0xd1, 0x2e, 0xac, 0x32, 0x67, 0x26, 0x62, 0xd0, 0x3b, 0xf8, 0x24,
+	0x8b, 0x41, 0xc9, 0xe7, 0xde, 0xb9, 0x33, 0xb3, 0xb8, 0x16, 0xfa, 0x1a, 0x74, 0x27, 0xf0, 0xef,
+	0xb0, 0x1d, 0xd2, 0x99, 0xd1, 0x10, 0x61, 0xdb, 0xcc, 0x4c, 0x0e, 0xb9, 0x20, 0xb5, 0x18, 0xd7,
+	0xac, 0x96, 0x50, 0x9c, 0xd2, 0x19, 0xfa, 0x15, 0xac, 0x85, 0x38, 0xb4, 0x13, 0x7c, 0x63, 0x34,
+	0x85, 0x49, 0xbe, 0xca, 0x14, 0x87, 0x97, 0x38, 0xa1, 0x73, 0x3f, 0xb6, 0xf0, 0xcd, 0x2d, 0xa6,
+	0x6c, 0x5c, 0xb3, 0x9a, 0x21, 0x0e, 0x2d, 0x7c, 0x83, 0x7e, 0x9d, 0x59, 0x51, 0x63, 0x4d, 0x58,
+	0x6d, 0x2f, 0xb2, 0xa2, 0x71, 0x44, 0x28, 0xce, 0xcd, 0x28, 0x7a, 0x05, 0x2d, 0xcf, 0x61, 0x8e,
+	0xd8, 0x60, 0x4b, 0xd8, 0x6d, 0x64, 0x76, 0x43, 0x87, 0x39, 0xc5, 0xfe, 0xd6, 0xb8, 0x1a, 0xdf,
+	0xde, 0x2e, 0x34, 0xe6, 0x38, 0x08, 0x22, 0x43, 0x2f, 0xab, 0xcb, 0x10, 0x8c, 0xb9, 0x68, 0x5c,
+	0xb3, 0xa4, 0x0e, 0xda, 0x4f, 0xdd, 0x7b, 0xfe, 0xcc, 0x00, 0xa1, 0x8f, 0x54, 0xf7, 0x43, 0x7f,
+	0x26, 0x4f, 0x21, 0xbc, 0x0f, 0xfd, 0x59, 0xbe, 0x1f, 0x7e, 0xfa, 0x76, 0x75, 0x3f, 0xc5, 0xb9,
+	0x85, 0x85, 0x3c, 0x78, 0x5b, 0x58, 0xdc, 0xc6, 0x9e, 0xc3, 0xb0, 0xd1, 0xa9, 0xae, 0xf2, 0x4e,
+	0x48, 0xc6, 0x35, 0x0b, 0xbc, 0x9c, 0x42, 0x2f, 0xa0, 0x81, 0xc3, 0x98, 0x3d, 0x18, 0x5d, 0x61,
+	0xd0, 0xcd, 0x0c, 0x46, 0x9c, 0xc9, 0x0f, 0x20, 0xa4, 0x68, 0x17, 0xea, 0x6e, 0x44, 0x88, 0xd1,
+	0x13, 0x5a, 0x4f, 0x33, 0xad, 0x41, 0x44, 0xc8, 0x88, 0x32, 0xe7, 0x32, 0xf0, 0xe9, 0x7c, 0x5c,
+	0xb3, 0x84, 0x12, 0x3a, 0x00, 0xa0, 0xcc, 0x61, 0xd8, 0xf6, 0xc9, 0x55, 0x64, 0xac, 0x0b, 0x93,
+	0x27, 0x79, 0x99, 0x70, 0xc9, 0x84, 0x5c, 0xf1, 0xe8, 0xe8, 0x34, 0x23, 0xd0, 0x11, 0xf4, 0xa4,
+	0x0d, 0x25, 0x4e, 0x4c, 0xe7, 0x11, 0x33, 0xfa, 0xe5, 0x4b, 0xcf, 0xed, 0xce, 0x52, 0x85, 0x71,
+	0xcd, 0xea, 0x0a, 0x93, 0x8c, 0x81, 0xa6, 0xb0, 0x51, 0xac, 0x6b, 0xc7, 0xb7, 0x41, 0x20, 0xe2,
+	0xf7, 0x44, 0x38, 0x7a, 0x5e, 0x71, 0x74, 0x7a, 0x1b, 0x04, 0x45, 0x20, 0xfb, 0xf4, 0x11, 0x1f,
+	0x1d, 0x82, 0xf4, 0x6f, 0x27, 0x52, 0xc9, 0x40, 0xe5, 0x84, 0xb2, 0x70, 0x18, 0x31, 0x2c, 0xdc,
+	0x15, 0x6e, 0x3a, 0x54, 0xa1, 0xd1, 0x30, 0x3b, 0x55, 0x92, 0xa6, 0x9c, 0xb1, 0x21, 0x7c, 0x7c,
+	0xba, 0xd0, 0x47, 0x9e, 0x95, 0x5d, 0xaa, 0x32, 0x78, 0x6c, 0x02, 0xec, 0x78, 0x32, 0x79, 0x45,
+	0x8a, 0x6e, 0x96, 0x63, 0xf3, 0x26, 0x97, 0x16, 0x89, 0xda, 0x2d, 0x4c, 0x78, 0xba, 0x7e, 0x0b,
+	0xdd, 0x18, 0xe3, 0xc4, 0xf6, 0x3d, 0x4c, 0x98, 0xcf, 0x1e, 0x8c, 0xa7, 0xe5, 0x32, 0x3c, 0xc5,
+	0x38, 0x99, 0xa4, 0x32, 0x7e, 0x8c, 0x58, 0xa1, 0x79, 0xb1, 0x3b, 0xee, 0xb5, 0xb1, 0x25, 0x4c,
+	0x9e, 0xe5, 0x95, 0xeb, 0x5e, 0x93, 0xe8, 0x7d, 0x80, 0xbd, 0x19, 0x0e, 0x31, 0xe1, 0x87, 0xe7,
+	0x5a, 0xe8, 0x0f, 0x00, 0x71, 0xe2, 0xdf, 0xc9, 0x28, 0x18, 0xcf, 0xca, 0xc1, 0x97, 0xe7, 0x3d,
+	0xbd, 0x63, 0xe5, 0x2c, 0x56, 0x2c, 0xd0, 0x6b, 0xc5, 0x9e, 0x1a, 0x86, 0xb0, 0xff, 0xd1, 0x12,
+	0xfb, 0x3c, 0x62, 0x8a, 0x09, 0x7a, 0x0d, 0x9d, 0x94, 0xb2, 0x79, 0xa2, 0x1b, 0x9f, 0x94, 0xaf,
+	0xed, 0x54, 0xca, 0xca, 0x65, 0xdd, 0x8e, 0x0b, 0xae, 0x69, 0xc3, 0xea, 0xb9, 0x33, 0x43, 0x5d,
+	0xd0, 0xdf, 0x9d, 0x0c, 0x47, 0xdf, 0x4d, 0x4e, 0x46, 0xc3, 0x7e, 0x0d, 0xe9, 0xd0, 0x18, 0x4d,
+	0x4f, 0xcf, 0x2f, 0xfa, 0x1a, 0xea, 0x40, 0xeb, 0xad, 0x75, 0x6c, 0xbf, 0x3d, 0x79, 0x73, 0xd1,
+	0x5f, 0xe1, 0x7a, 0x83, 0xf1, 0xe1, 0x89, 0x24, 0x57, 0x51, 0x1f, 0x3a, 0x82, 0x3c, 0x3c, 0x19,
+	0xda, 0x6f, 0xad, 0xe3, 0x7e, 0x1d, 0xad, 0x43, 0x5b, 0x2a, 0x58, 0x82, 0xd1, 0x50, 0x91, 0xf8,
+	0x3f, 0x1a, 0xe8, 0x79, 0x46, 0xa2, 0x6d, 0x68, 0x85, 0x98, 0x39, 0x62, 0xdb, 0xb2, 0x27, 0xe4,
+	0x34, 0xda, 0x03, 0x9d, 0xf9, 0x21, 0xa6, 0xcc, 0x09, 0x63, 0x81, 0xc6, 0xed, 0x83, 0xbe, 0x7a,
+	0x7b, 0xe7, 0x7e, 0x88, 0xad, 0x42, 0x05, 0x3d, 0x85, 0x66, 0x7c, 0xed, 0xdb, 0xbe, 0x27, 0x40,
+	0xba, 0x63, 0x35, 0xe2, 0x6b, 0x7f, 0xe2, 0xa1, 0x1f, 0x43, 0x3b, 0xc5, 0x70, 0x7b, 0x7a, 0x38,
+	0x30, 0xea, 0x42, 0x06, 0x29, 0x6b, 0x7a, 0x38, 0xe0, 0xd5, 0x1b, 0x27, 0x51, 0x8c, 0x13, 0xe6,
+	0x63, 0x6a, 0x34, 0xca, 0x38, 0x72, 0x9a, 0x4b, 0x2c, 0x45, 0xcb, 0xfc, 0xaf, 0x06, 0x50, 0x88,
+	0xd0, 0x4f, 0xa1, 0x2b, 0xd2, 0x22, 0xb1, 0xe7, 0xd8, 0x9f, 0xcd, 0x59, 0xda, 0x54, 0x3a, 0x92,
+	0x39, 0x16, 0x3c, 0xf4, 0x13, 0xe8, 0x04, 0xf8, 0x8a, 0xd9, 0x6a, 0x83, 0x69, 0x59, 0x6d, 0xce,
+	0x1b, 0x48, 0x16, 0xfa, 0x25, 0xf0, 0x8d, 0xf9, 0xc4, 0x8d, 0x3c, 0x4c, 0x8d, 0xd5, 0x9d, 0x55,
+	0x15, 0x48, 0x06, 0x99, 0xc4, 0x52, 0x94, 0xd0, 0x10, 0x36, 0x7d, 0x42, 0x99, 0x13, 0x04, 0xd8,
+	0xb3, 0x15, 0xe3, 0xfa, 0x32, 0xe3, 0x8d, 0x5c, 0x7d, 0x50, 0x78, 0xd9, 0x84, 0x46, 0x12, 0x05,
+	0xe2, 0xf8, 0xab, 0x2f, 0x75, 0x4b, 0x12, 0xe6, 0x6f, 0x41, 0xcf, 0x75, 0x10, 0x82, 0x3a, 0x71,
+	0x42, 0xd9, 0x2f, 0x75, 0x4b, 0x7c, 0xf3, 0x76, 0x79, 0x87, 0x13, 0xea, 0x47, 0x44, 0x9c, 0x46,
+	0xb7, 0x32, 0xd2, 0x3c, 0x84, 0x27, 0x15, 0x00, 0x43, 0x5f, 0x42, 0x0b, 0x07, 0xa2, 0x76, 0xa8,
+	0xa1, 0xed, 0xac, 0xaa, 0x17, 0x9a, 0x8f, 0x11, 0xb9, 0x86, 0xf9, 0x1b, 0xd8, 0x5c, 0x04, 0x5d,
+	0x8f, 0x2f, 0x54, 0x7b, 0x7c, 0xa1, 0xe6, 0x15, 0x74, 0x4b, 0x38, 0xad, 0x64, 0x86, 0xa6, 0x66,
+	0xc6, 0x36, 0xb4, 0x72, 0x74, 0x90, 0xdd, 0x3e, 0xa7, 0x91, 0x09, 0x5d, 0x16, 0x50, 0xdb, 0xc5,
+	0x09, 0xb3, 0xe7, 0x0e, 0x9d, 0xa7, 0x39, 0xd5, 0x66, 0x01, 0x1d, 0xe0, 0x84, 0x8d, 0x1d, 0x3a,
+	0x37, 0xdf, 0x41, 0x47, 0x45, 0x91, 0x65, 0xcb, 0x20, 0xa8, 0x73, 0x37, 0xe9, 0x12, 0xe2, 0xbb,
+	0x94, 0xf7, 0xab, 0xe5, 0xbc, 0x37, 0x43, 0x68, 0x2b, 0x60, 0xb1, 0x7c, 0x50, 0xf1, 0x44, 0x13,
+	0xa5, 0xc6, 0x8a, 0xb8, 0xb2, 0x8c, 0x44, 0x7b, 0xd0, 0x0a, 0xe9, 0xcc, 0x66, 0x0f, 0xe9, 0xc4,
+	0xd6, 0x2b, 0x3a, 0x29, 0x8f, 0xe2, 0x94, 0xce, 0xce, 0x1f, 0x62, 0x6c, 0xad, 0x85, 0xf2, 0xc3,
+	0x8c, 0xa0, 0xad, 0xb4, 0xf0, 0x25, 0xcb, 0xa9, 0xfb, 0x5d, 0xa9, 0xd4, 0xe9, 0xc7, 0x2d, 0x78,
+	0x0f, 0x50, 0x74, 0xe7, 0x25, 0xeb, 0xfd, 0x0c, 0xea, 0xe9, 0x5a, 0x8b, 0xb3, 0xa4, 0xfe, 0x83,
+	0x56, 0x0e, 0x00, 0x8a, 0xe9, 0xe3, 0xff, 0x1e, 0xd8, 0x6f, 0xa0, 0xad, 0x60, 0x2e, 0xfa, 0x79,
+	0x79, 0xfa, 0x6d, 0x1f, 0xac, 0xe7, 0xd6, 0x92, 0x9d, 0x8f, 0xc3, 0xe6, 0x77, 0x80, 0xaa, 0xa0,
+	0x8d, 0x5e, 0x3d, 0x76, 0xb0, 0xf5, 0x08, 0xe1, 0x2b, 0x7e, 0x2e, 0x60, 0x2d, 0xe5, 0xa1, 0x67,
+	0xb0, 0x46, 0xf1, 0x8d, 0x4d, 0x6e, 0xc3, 0xf4, 0xb8, 0x4d, 0x8a, 0x6f, 0x4e, 0x6e, 0x43, 0x9e,
+	0x9d, 0xca, 0xad, 0x8a, 0x6f, 0x8e, 0x54, 0xa5, 0x86, 0xc2, 0x81, 0xa8, 0x53, 0x6e, 0x19, 0xff,
+	0xd4, 0xa0, 0x57, 0x5e, 0x16, 0x7d, 0x01, 0xeb, 0x6e, 0x14, 0x04, 0xd8, 0x65, 0x7e, 0x44, 0x6c,
+	0x05, 0x2b, 0x7a, 0x05, 0xfb, 0x84, 0xa3, 0xc6, 0x73, 0xd0, 0xb9, 0x94, 0xc6, 0x8e, 0x8b, 0x53,
+	0xdc, 0x28, 0x18, 0x68, 0x03, 0x1a, 0xec, 0x3e, 0x43, 0x71, 0xdd, 0xaa, 0xb3, 0xfb, 0x89, 0xc7,
+	0x01, 0x36, 0xdb, 0x51, 0xf2, 0x9e, 0x62, 0x96, 0xc2, 0x78, 0xb6, 0x4d, 0x8b, 0xf3, 0xcc, 0x7f,
+	0x68, 0xd0, 0x51, 0xa7, 0x6b, 0xb4, 0x07, 0x10, 0xe6, 0x43, 0x70, 0x1a, 0xb4, 0x5e, 0x79, 0x3c,
+	0xb6, 0x14, 0x8d, 0x8f, 0xee, 0x38, 0x2a, 0x80, 0xd4, 0xcb, 0x00, 0x62, 0xfe, 0x5b, 0x83, 0x27,
+	0x95, 0x31, 0x65, 0x19, 0x44, 0x7c, 0xec, 0xc2, 0x2f, 0xa0, 0xe7, 0x53, 0xdb, 0xc3, 0x6e, 0xe0,
+	0x24, 0x0e, 0x8f, 0xab, 0x08, 0x56, 0xcb, 0xea, 0xfa, 0x74, 0x58, 0x30, 0xd1, 0x16, 0x34, 0xdf,
+	0xcb, 0x7e, 0x54, 0x97, 0x77, 0x2e, 0xa9, 0x6a, 0xbb, 0x6a, 0x54, 0xdb, 0x95, 0xf9, 0x3b, 0x68,
+	0x65, 0x4b, 0xf3, 0xec, 0xf1, 0x89, 0xab, 0x66, 0x8f, 0x4f, 0x5c, 0x9e, 0x3d, 0x4a, 0x5a, 0xad,
+	0xa8, 0x69, 0x65, 0x5e, 0xc1, 0x93, 0xca, 0xab, 0x05, 0x7d, 0x0b, 0x7d, 0x8a, 0x83, 0x2b, 0x31,
+	0xae, 0x26, 0xa1, 0xdc, 0xb8, 0xb6, 0xa3, 0x2d, 0xac, 0xf0, 0x75, 0xae, 0x39, 0x29, 0x14, 0x79,
+	0xb9, 0xf2, 0xf1, 0x8b, 0x88, 0xb2, 0xec, 0x58, 0x92, 0x30, 0x2f, 0x01, 0x55, 0xdf, 0x39, 0xe8,
+	0x73, 0x68, 0x88, 0x67, 0xd5, 0xd2, 0x2e, 0x23, 0xc5, 0x02, 0x66, 0xb0, 0xe3, 0x7d, 0x00, 0x66,
+	0xb0, 0xe3, 0x99, 0x7f, 0x82, 0xa6, 0x5c, 0x83, 0x5f, 0x38, 0x2e, 0xbd, 0x3b, 0xad, 0x9c, 0xfe,
+	0x20, 0x44, 0x2e, 0x1e, 0x4d, 0xcc, 0x35, 0x68, 0x88, 0x67, 0x87, 0xf9, 0x67, 0x40, 0xd5, 0xe1,
+	0x9a, 0xf7, 0x20, 0xca, 0x9c, 0x84, 0xd9, 0xe5, 0xca, 0x6d, 0x0b, 0xe6, 0x99, 0x2c, 0xdf, 0xcf,
+	0xa0, 0x8d, 0x89, 0x67, 0x97, 0x2f, 0x41, 0xc7, 0xc4, 0x93, 0x72, 0xf3, 0x08, 0x36, 0x16, 0x8c,
+	0xdc, 0x68, 0x17, 0x5a, 0x29, 0x48, 0x64, 0x9d, 0xb8, 0x82, 0x46, 0xb9, 0x82, 0x79, 0x0c, 0x9b,
+	0x8b, 0xc6, 0x58, 0xb4, 0x5f, 0x40, 0xa5, 0xf4, 0x91, 0x3f, 0x93, 0x52, 0x45, 0x09, 0xb4, 0x39,
+	0x82, 0x9a, 0xff, 0xd2, 0xa0, 0x5b, 0x12, 0x15, 0xc5, 0xae, 0x29, 0xc5, 0xfe, 0x61, 0x7c, 0xf8,
+	0x0c, 0xa0, 0xc0, 0x93, 0x14, 0x24, 0x14, 0x0e, 0xfa, 0x14, 0xf4, 0xcb, 0x20, 0x72, 0xaf, 0x79,
+	0x4c, 0xd2, 0xbc, 0x6f, 0x09, 0xc6, 0x19, 0xbe, 0x41, 0x3b, 0xd0, 0xe1, 0xa1, 0xf2, 0x89, 0x2d,
+	0x58, 0x69, 0xe2, 0x03, 0xc5, 0x37, 0x13, 0x72, 0xc4, 0x39, 0xe6, 0xf7, 0xf0, 0x74, 0xe1, 0xcc,
+	0x8d, 0x0e, 0x2a, 0xc3, 0xcb, 0xd6, 0xa3, 0xe3, 0x8e, 0xa4, 0x58, 0x19, 0x61, 0x2e, 0xa0, 0x57,
+	0x96, 0xa1, 0xaf, 0xa0, 0x29, 0xa3, 0x91, 0x26, 0xfe, 0x92, 0x90, 0xa5, 0x4a, 0xea, 0x2f, 0x13,
+	0x99, 0xf6, 0x19, 0x69, 0xfe, 0x31, 0x77, 0x9d, 0xe1, 0xef, 0x0b, 0x58, 0x67, 0xf7, 0x76, 0xe9,
+	0x78, 0xe9, 0x18, 0xca, 0xee, 0xcf, 0xf2, 0x03, 0x96, 0x5d, 0xaa, 0x7f, 0x61, 0xcc, 0x2f, 0x60,
+	0xfd, 0xd1, 0x13, 0x87, 0x17, 0x1d, 0x4e, 0x92, 0x28, 0x49, 0xef, 0x47, 0x12, 0xbf, 0xf8, 0x3d,
+	0xb4, 0x95, 0x8e, 0xf7, 0xf8, 0xdd, 0xd0, 0x05, 0xfd, 0xe8, 0xcd, 0xdb, 0xc1, 0xf7, 0xf6, 0xf4,
+	0xec, 0xb8, 0xaf, 0xf1, 0xe7, 0xc1, 0x64, 0x38, 0x3a, 0x39, 0x9f, 0x9c, 0x5f, 0x08, 0xce, 0xca,
+	0xc1, 0x5f, 0xa1, 0x29, 0x27, 0x0e, 0xf4, 0x0d, 0x74, 0xe4, 0xd7, 0x19, 0x4b, 0xb0, 0x13, 0xa2,
+	0x4a, 0x05, 0x6e, 0x57, 0x38, 0x66, 0xed, 0xa5, 0xf6, 0x4a, 0x43, 0x9f, 0x43, 0xfd, 0xd4, 0x27,
+	0x33, 0x54, 0x7e, 0xbf, 0x6f, 0x97, 0x49, 0xb3, 0x76, 0xf4, 0xd5, 0x5f, 0x76, 0x67, 0x3e, 0x9b,
+	0xdf, 0x5e, 0xee, 0xb9, 0x51, 0xb8, 0x3f, 0x7f, 0x88, 0x71, 0x22, 0x51, 0x6e, 0xff, 0xca, 0xb9,
+	0x4c, 0x7c, 0x77, 0x5f, 0xfc, 0x3a, 0xa3, 0xfb, 0xd2, 0xec, 0xb2, 0x29, 0xc8, 0xaf, 0xff, 0x37,
+	0x00, 0x4f, 0xbd, 0xb5, 0x1b, 0x61, 0x13, 0x00, 0x00,
 }
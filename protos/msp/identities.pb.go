@@ -23,6 +23,7 @@ It has these top-level messages:
 	FabricNodeOUs
 	MSPPrincipal
 	OrganizationUnit
+	IdentityHash
 	MSPRole
 */
 package msp
@@ -220,6 +220,10 @@ type IdemixMSPConfig struct {
 	IPk []byte `protobuf:"bytes,2,opt,name=IPk,proto3" json:"IPk,omitempty"`
 	// signer may contain crypto material to configure a default signer
 	Signer *IdemixMSPSignerConfig `protobuf:"bytes,3,opt,name=signer" json:"signer,omitempty"`
+	// revocation_pk is the public key used for revocation of credentials
+	RevocationPk []byte `protobuf:"bytes,4,opt,name=revocation_pk,json=revocationPk,proto3" json:"revocation_pk,omitempty"`
+	// epoch represents the current epoch (time interval) used for revocation
+	Epoch int64 `protobuf:"varint,5,opt,name=epoch" json:"epoch,omitempty"`
 }
 
 func (m *IdemixMSPConfig) Reset()                    { *m = IdemixMSPConfig{} }
@@ -248,6 +252,20 @@ func (m *IdemixMSPConfig) GetSigner() *IdemixMSPSignerConfig {
 	return nil
 }
 
+func (m *IdemixMSPConfig) GetRevocationPk() []byte {
+	if m != nil {
+		return m.RevocationPk
+	}
+	return nil
+}
+
+func (m *IdemixMSPConfig) GetEpoch() int64 {
+	if m != nil {
+		return m.Epoch
+	}
+	return 0
+}
+
 // IdemixMSPSIgnerConfig contains the crypto material to set up an idemix signing identity
 type IdemixMSPSignerConfig struct {
 	// Cred represents the serialized idemix credential of the default signer
@@ -258,6 +276,8 @@ type IdemixMSPSignerConfig struct {
 	OrganizationalUnitIdentifier string `protobuf:"bytes,3,opt,name=organizational_unit_identifier,json=organizationalUnitIdentifier" json:"organizational_unit_identifier,omitempty"`
 	// is_admin defines whether the default signer is admin or not
 	IsAdmin bool `protobuf:"varint,4,opt,name=is_admin,json=isAdmin" json:"is_admin,omitempty"`
+	// credential_revocation_information contains a serialized CredentialRevocationInformation
+	CredentialRevocationInformation []byte `protobuf:"bytes,5,opt,name=credential_revocation_information,json=credentialRevocationInformation,proto3" json:"credential_revocation_information,omitempty"`
 }
 
 func (m *IdemixMSPSignerConfig) Reset()                    { *m = IdemixMSPSignerConfig{} }
@@ -293,6 +313,13 @@ func (m *IdemixMSPSignerConfig) GetIsAdmin() bool {
 	return false
 }
 
+func (m *IdemixMSPSignerConfig) GetCredentialRevocationInformation() []byte {
+	if m != nil {
+		return m.CredentialRevocationInformation
+	}
+	return nil
+}
+
 // SigningIdentityInfo represents the configuration information
 // related to the signing identity the peer is to use for generating
 // endorsements
@@ -446,53 +473,57 @@ func init() {
 func init() { proto.RegisterFile("msp/msp_config.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 763 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0x4d, 0x6f, 0x1a, 0x49,
-	0x10, 0xd5, 0x00, 0xc6, 0x50, 0x0c, 0xe0, 0x6d, 0x7f, 0xec, 0xec, 0x6a, 0xed, 0xc5, 0xb3, 0x1b,
-	0x85, 0x4b, 0x40, 0xc2, 0x91, 0x92, 0x43, 0x2e, 0x31, 0x89, 0x13, 0x94, 0x38, 0xb6, 0x06, 0xf9,
-	0x92, 0xcb, 0xa8, 0x19, 0x9a, 0xa1, 0xc5, 0x7c, 0xa9, 0xbb, 0xb1, 0x42, 0x94, 0x7f, 0x91, 0x6b,
-	0x7e, 0x43, 0x6e, 0xf9, 0x7f, 0x51, 0x7f, 0x80, 0x87, 0x98, 0x90, 0xdc, 0xba, 0xeb, 0xbd, 0x57,
-	0x5d, 0xf3, 0xaa, 0xba, 0x07, 0x0e, 0x62, 0x9e, 0x75, 0x63, 0x9e, 0xf9, 0x41, 0x9a, 0x4c, 0x68,
-	0xd8, 0xc9, 0x58, 0x2a, 0x52, 0x54, 0x8c, 0x79, 0xe6, 0x3e, 0x81, 0xea, 0xe5, 0xf0, 0xba, 0xaf,
-	0xe2, 0x08, 0x41, 0x49, 0x2c, 0x32, 0xe2, 0x58, 0x2d, 0xab, 0xbd, 0xe3, 0xa9, 0x35, 0x3a, 0x82,
-	0xb2, 0x56, 0x39, 0x85, 0x96, 0xd5, 0xb6, 0x3d, 0xb3, 0x73, 0xbf, 0x96, 0xa0, 0x79, 0x81, 0x47,
-	0x8c, 0x06, 0x6b, 0xfa, 0x04, 0xc7, 0x5a, 0x5f, 0xf5, 0xd4, 0x1a, 0x1d, 0x03, 0xb0, 0x34, 0x15,
-	0x7e, 0x40, 0x98, 0xe0, 0x4e, 0xa1, 0x55, 0x6c, 0xdb, 0x5e, 0x55, 0x46, 0xfa, 0x32, 0x80, 0x1e,
-	0x01, 0xa2, 0x89, 0x20, 0x2c, 0x26, 0x63, 0x8a, 0x05, 0x31, 0xb4, 0xa2, 0xa2, 0xfd, 0x91, 0x47,
-	0x34, 0xfd, 0x08, 0xca, 0x78, 0x1c, 0xd3, 0x84, 0x3b, 0x25, 0x45, 0x31, 0x3b, 0xf4, 0x10, 0x9a,
-	0x8c, 0xdc, 0xa6, 0x01, 0x16, 0x34, 0x4d, 0xfc, 0x88, 0x72, 0xe1, 0xec, 0x28, 0x42, 0xe3, 0x2e,
-	0xfc, 0x96, 0x72, 0x81, 0xfa, 0xb0, 0xc7, 0x69, 0x98, 0xd0, 0x24, 0xf4, 0xe9, 0x98, 0x24, 0x82,
-	0x8a, 0x85, 0x53, 0x6e, 0x59, 0xed, 0x5a, 0xcf, 0xe9, 0xc4, 0x3c, 0xeb, 0x0c, 0x35, 0x38, 0x30,
-	0xd8, 0x20, 0x99, 0xa4, 0x5e, 0x93, 0xaf, 0x07, 0x91, 0x0f, 0xff, 0xa6, 0x2c, 0xc4, 0x09, 0xfd,
-	0xa8, 0x12, 0xe3, 0xc8, 0x9f, 0x27, 0x54, 0x98, 0x84, 0x13, 0x4a, 0x18, 0x77, 0x76, 0x5b, 0xc5,
-	0x76, 0xad, 0xf7, 0xa7, 0xca, 0xa9, 0x6d, 0xba, 0xba, 0x19, 0xac, 0x70, 0xef, 0x78, 0x5d, 0x7f,
-	0x93, 0x50, 0x71, 0x87, 0x72, 0xf4, 0x0c, 0xea, 0x01, 0x5b, 0x64, 0x22, 0x35, 0x1d, 0x73, 0x2a,
-	0xaa, 0xc4, 0x7c, 0xba, 0xbe, 0xc2, 0xb5, 0xf1, 0x9e, 0x1d, 0xe4, 0x76, 0xe8, 0x7f, 0x68, 0x88,
-	0x88, 0xfb, 0x39, 0xdb, 0xab, 0xca, 0x0b, 0x5b, 0x44, 0xdc, 0x5b, 0x39, 0xff, 0x18, 0x8e, 0x24,
-	0x6b, 0x83, 0xfb, 0xa0, 0xd8, 0x07, 0x22, 0xe2, 0x83, 0x7b, 0x0d, 0x78, 0x0a, 0x75, 0x7d, 0xfe,
-	0xbb, 0x74, 0x4c, 0xae, 0x6e, 0xb8, 0x53, 0x53, 0x95, 0xa1, 0x5c, 0x65, 0x06, 0xf1, 0xd6, 0x89,
-	0xee, 0x67, 0x0b, 0xd0, 0xfd, 0xd2, 0x51, 0x0f, 0x0e, 0xa5, 0xbd, 0x58, 0xcc, 0x19, 0xf1, 0xa7,
-	0x98, 0x4f, 0xfd, 0x09, 0x8e, 0x69, 0xb4, 0x30, 0x43, 0xb4, 0xbf, 0x02, 0x5f, 0x63, 0x3e, 0xbd,
-	0x50, 0x10, 0x1a, 0xc0, 0xe9, 0xb2, 0x79, 0x39, 0xd3, 0x8d, 0x7a, 0x9e, 0x04, 0xd2, 0x54, 0x35,
-	0xae, 0x55, 0xef, 0x64, 0x49, 0xbc, 0xb3, 0x57, 0x25, 0x32, 0x2c, 0x77, 0x06, 0xcd, 0xc1, 0x98,
-	0xc4, 0xf4, 0xc3, 0xf6, 0x29, 0xde, 0x83, 0xe2, 0xe0, 0x7a, 0x66, 0xae, 0x80, 0x5c, 0xa2, 0x1e,
-	0x94, 0x65, 0x69, 0x84, 0x39, 0x45, 0xe5, 0xc0, 0xdf, 0xca, 0x81, 0x55, 0xae, 0xa1, 0xc2, 0x4c,
-	0x7b, 0x0c, 0xd3, 0xfd, 0x62, 0xc1, 0xe1, 0x46, 0x86, 0x3c, 0xb3, 0xcf, 0xc8, 0x58, 0x9d, 0x69,
-	0x7b, 0x6a, 0x8d, 0x1a, 0x50, 0x18, 0x2e, 0x8f, 0x2c, 0x0c, 0x67, 0xe8, 0x05, 0x9c, 0x6c, 0x9f,
-	0x3a, 0x55, 0x49, 0xd5, 0xfb, 0x67, 0xdb, 0x6c, 0xa1, 0xbf, 0xa0, 0x42, 0xb9, 0xaf, 0xae, 0x8d,
-	0x53, 0x6a, 0x59, 0xed, 0x8a, 0xb7, 0x4b, 0xf9, 0x73, 0xb9, 0x75, 0x53, 0xd8, 0xdf, 0x30, 0xfe,
-	0xe8, 0x3f, 0xa8, 0x67, 0xf3, 0x51, 0x44, 0x03, 0xdf, 0x7c, 0xb0, 0x2e, 0xd2, 0xd6, 0x41, 0xfd,
-	0x19, 0xe8, 0x0c, 0x1a, 0x19, 0xa3, 0xb7, 0x72, 0x88, 0x0c, 0xab, 0xa0, 0x6c, 0xb1, 0x95, 0x2d,
-	0x6f, 0x88, 0xbe, 0x49, 0x75, 0xc3, 0xd1, 0x22, 0x77, 0x08, 0xbb, 0x06, 0x41, 0x0f, 0xa0, 0x31,
-	0x23, 0xf9, 0x6e, 0x1a, 0xfb, 0xeb, 0x33, 0x92, 0x6b, 0x1d, 0x3a, 0x05, 0x5b, 0xd2, 0x62, 0x2c,
-	0x08, 0xa3, 0x38, 0x32, 0xee, 0xd4, 0x66, 0x64, 0x71, 0x69, 0x42, 0xee, 0xa7, 0xe5, 0x98, 0xe5,
-	0x2f, 0x1c, 0x6a, 0x41, 0x4d, 0x0e, 0x37, 0x9d, 0xd0, 0x00, 0x0b, 0x62, 0x3e, 0x21, 0x1f, 0xfa,
-	0x0d, 0x7b, 0x0b, 0xbf, 0xb6, 0xd7, 0xfd, 0x66, 0xfd, 0x70, 0x41, 0xe4, 0x93, 0xf5, 0x32, 0xc1,
-	0xa3, 0x48, 0x1f, 0x5a, 0xf1, 0xcc, 0x0e, 0xbd, 0x02, 0x14, 0x44, 0x94, 0x24, 0x22, 0x5f, 0xa7,
-	0x71, 0xed, 0xa7, 0xef, 0xc6, 0x06, 0x89, 0x7c, 0xd2, 0x32, 0x42, 0xd8, 0x5a, 0x9a, 0xe2, 0xf6,
-	0x34, 0xf7, 0x04, 0xe7, 0x3e, 0x9c, 0xa6, 0x2c, 0xec, 0x4c, 0x17, 0x19, 0x61, 0x11, 0x19, 0x87,
-	0x84, 0x75, 0x26, 0x4a, 0xa7, 0x7f, 0x16, 0x5c, 0x66, 0x3a, 0xdf, 0xbb, 0xe4, 0x99, 0x1e, 0xd8,
-	0x6b, 0x1c, 0xcc, 0x70, 0x48, 0xde, 0xb7, 0x43, 0x2a, 0xa6, 0xf3, 0x51, 0x27, 0x48, 0xe3, 0x6e,
-	0x4e, 0xdb, 0xd5, 0xda, 0xae, 0xd6, 0xca, 0x5f, 0xcf, 0xa8, 0xac, 0xd6, 0x67, 0xdf, 0x03, 0x00,
-	0x00, 0xff, 0xff, 0x77, 0x69, 0x37, 0x77, 0x8c, 0x06, 0x00, 0x00,
+	// 820 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x55, 0xcd, 0x6e, 0x23, 0x45,
+	0x10, 0xd6, 0xd8, 0x89, 0x13, 0x97, 0xc7, 0x4e, 0xe8, 0xcd, 0x86, 0x01, 0xb1, 0xbb, 0xce, 0x00,
+	0xc2, 0x17, 0x1c, 0x29, 0x8b, 0x04, 0x07, 0x2e, 0xac, 0x61, 0x61, 0x80, 0xb0, 0x51, 0x5b, 0xb9,
+	0x70, 0x19, 0xb5, 0xc7, 0xed, 0x71, 0x6b, 0x66, 0xba, 0x47, 0xdd, 0xed, 0x15, 0x46, 0xbc, 0x05,
+	0xef, 0xc0, 0x91, 0x1b, 0xef, 0xc4, 0x63, 0xa0, 0xfe, 0x89, 0x3d, 0x8e, 0xa3, 0xb0, 0xb7, 0xae,
+	0xaa, 0xef, 0xab, 0xa9, 0xfe, 0xaa, 0xaa, 0x07, 0xce, 0x2a, 0x55, 0x5f, 0x56, 0xaa, 0x4e, 0x33,
+	0xc1, 0x17, 0x2c, 0x1f, 0xd7, 0x52, 0x68, 0x81, 0xda, 0x95, 0xaa, 0xe3, 0x2f, 0xa1, 0x7b, 0x3d,
+	0xbd, 0x99, 0x58, 0x3f, 0x42, 0x70, 0xa0, 0xd7, 0x35, 0x8d, 0x82, 0x61, 0x30, 0x3a, 0xc4, 0xf6,
+	0x8c, 0xce, 0xa1, 0xe3, 0x58, 0x51, 0x6b, 0x18, 0x8c, 0x42, 0xec, 0xad, 0xf8, 0xef, 0x03, 0x38,
+	0x79, 0x4d, 0x66, 0x92, 0x65, 0x3b, 0x7c, 0x4e, 0x2a, 0xc7, 0xef, 0x62, 0x7b, 0x46, 0xcf, 0x00,
+	0xa4, 0x10, 0x3a, 0xcd, 0xa8, 0xd4, 0x2a, 0x6a, 0x0d, 0xdb, 0xa3, 0x10, 0x77, 0x8d, 0x67, 0x62,
+	0x1c, 0xe8, 0x73, 0x40, 0x8c, 0x6b, 0x2a, 0x2b, 0x3a, 0x67, 0x44, 0x53, 0x0f, 0x6b, 0x5b, 0xd8,
+	0x7b, 0xcd, 0x88, 0x83, 0x9f, 0x43, 0x87, 0xcc, 0x2b, 0xc6, 0x55, 0x74, 0x60, 0x21, 0xde, 0x42,
+	0x9f, 0xc1, 0x89, 0xa4, 0x6f, 0x45, 0x46, 0x34, 0x13, 0x3c, 0x2d, 0x99, 0xd2, 0xd1, 0xa1, 0x05,
+	0x0c, 0xb6, 0xee, 0x9f, 0x99, 0xd2, 0x68, 0x02, 0xa7, 0x8a, 0xe5, 0x9c, 0xf1, 0x3c, 0x65, 0x73,
+	0xca, 0x35, 0xd3, 0xeb, 0xa8, 0x33, 0x0c, 0x46, 0xbd, 0xab, 0x68, 0x5c, 0xa9, 0x7a, 0x3c, 0x75,
+	0xc1, 0xc4, 0xc7, 0x12, 0xbe, 0x10, 0xf8, 0x44, 0xed, 0x3a, 0x51, 0x0a, 0x2f, 0x84, 0xcc, 0x09,
+	0x67, 0xbf, 0xdb, 0xc4, 0xa4, 0x4c, 0x57, 0x9c, 0x69, 0x9f, 0x70, 0xc1, 0xa8, 0x54, 0xd1, 0xd1,
+	0xb0, 0x3d, 0xea, 0x5d, 0xbd, 0x6f, 0x73, 0x3a, 0x99, 0xde, 0xdc, 0x26, 0x9b, 0x38, 0x7e, 0xb6,
+	0xcb, 0xbf, 0xe5, 0x4c, 0x6f, 0xa3, 0x0a, 0x7d, 0x0d, 0xfd, 0x4c, 0xae, 0x6b, 0x2d, 0x7c, 0xc7,
+	0xa2, 0xe3, 0x61, 0x70, 0x2f, 0xdd, 0xc4, 0xc6, 0x9d, 0xf0, 0x38, 0xcc, 0x1a, 0x16, 0xfa, 0x04,
+	0x06, 0xba, 0x54, 0x69, 0x43, 0xf6, 0xae, 0xd5, 0x22, 0xd4, 0xa5, 0xc2, 0x1b, 0xe5, 0xbf, 0x80,
+	0x73, 0x83, 0x7a, 0x40, 0x7d, 0xb0, 0xe8, 0x33, 0x5d, 0xaa, 0x64, 0xaf, 0x01, 0x5f, 0x41, 0xdf,
+	0x7d, 0xff, 0x17, 0x31, 0xa7, 0x6f, 0x6e, 0x55, 0xd4, 0xb3, 0x95, 0xa1, 0x46, 0x65, 0x3e, 0x82,
+	0x77, 0x81, 0xf1, 0x9f, 0x01, 0xa0, 0xfd, 0xd2, 0xd1, 0x15, 0x3c, 0x35, 0xf2, 0x12, 0xbd, 0x92,
+	0x34, 0x5d, 0x12, 0xb5, 0x4c, 0x17, 0xa4, 0x62, 0xe5, 0xda, 0x0f, 0xd1, 0x93, 0x4d, 0xf0, 0x07,
+	0xa2, 0x96, 0xaf, 0x6d, 0x08, 0x25, 0x70, 0x71, 0xd7, 0xbc, 0x86, 0xe8, 0x9e, 0xbd, 0xe2, 0x99,
+	0x11, 0xd5, 0x8e, 0x6b, 0x17, 0x3f, 0xbf, 0x03, 0x6e, 0xe5, 0xb5, 0x89, 0x3c, 0x2a, 0xfe, 0x2b,
+	0x80, 0x93, 0x64, 0x4e, 0x2b, 0xf6, 0xdb, 0xe3, 0x63, 0x7c, 0x0a, 0xed, 0xe4, 0xa6, 0xf0, 0x3b,
+	0x60, 0x8e, 0xe8, 0x0a, 0x3a, 0xa6, 0x36, 0x2a, 0xa3, 0xb6, 0x95, 0xe0, 0x43, 0x2b, 0xc1, 0x26,
+	0xd7, 0xd4, 0xc6, 0x7c, 0x7f, 0x3c, 0x12, 0x7d, 0x0c, 0xfd, 0xc6, 0x98, 0xd6, 0x45, 0x74, 0x60,
+	0xf3, 0x85, 0x5b, 0xe7, 0x4d, 0x81, 0xce, 0xe0, 0x90, 0xd6, 0x22, 0x5b, 0x46, 0x87, 0xc3, 0x60,
+	0xd4, 0xc6, 0xce, 0x88, 0xff, 0x0d, 0xe0, 0xe9, 0x83, 0xc9, 0x4d, 0xb9, 0x13, 0x49, 0xe7, 0xb6,
+	0xdc, 0x10, 0xdb, 0x33, 0x1a, 0x40, 0x6b, 0x7a, 0x57, 0x6d, 0x6b, 0x5a, 0xa0, 0x6f, 0xe1, 0xf9,
+	0xe3, 0x13, 0x6b, 0x2f, 0xd1, 0xc5, 0x1f, 0x3d, 0x36, 0x97, 0xe8, 0x03, 0x38, 0x66, 0x2a, 0xb5,
+	0x2b, 0x67, 0x2b, 0x3f, 0xc6, 0x47, 0x4c, 0x7d, 0x63, 0x4c, 0xf4, 0x23, 0x5c, 0x64, 0x92, 0x5a,
+	0x28, 0x29, 0xd3, 0xc6, 0x25, 0x19, 0x5f, 0x08, 0x59, 0xd9, 0xb3, 0xbd, 0x50, 0x88, 0x5f, 0x6c,
+	0x81, 0x78, 0x83, 0x4b, 0xb6, 0xb0, 0x58, 0xc0, 0x93, 0x07, 0xd6, 0xd0, 0x88, 0x57, 0xaf, 0x66,
+	0x25, 0xcb, 0x52, 0xaf, 0xbb, 0xbb, 0x70, 0xe8, 0x9c, 0x4e, 0x12, 0xf4, 0x12, 0x06, 0xb5, 0x64,
+	0x6f, 0xcd, 0x30, 0x7b, 0x54, 0xcb, 0x76, 0x27, 0xb4, 0xdd, 0xf9, 0x89, 0xba, 0x8d, 0xee, 0x7b,
+	0x8c, 0x23, 0xc5, 0x53, 0x38, 0xf2, 0x11, 0xf4, 0x29, 0x0c, 0x0a, 0xda, 0x9c, 0x2a, 0x3f, 0x05,
+	0xfd, 0x82, 0x36, 0x46, 0x08, 0x5d, 0x40, 0x68, 0x60, 0x15, 0xd1, 0x54, 0x32, 0x52, 0x7a, 0xa5,
+	0x7b, 0x05, 0x5d, 0x5f, 0x7b, 0x57, 0xfc, 0x07, 0xa0, 0xfd, 0xc5, 0x47, 0x43, 0xe8, 0x99, 0x25,
+	0x63, 0x0b, 0x96, 0x11, 0x4d, 0xfd, 0x15, 0x9a, 0xae, 0x77, 0x68, 0x55, 0xeb, 0xff, 0x5b, 0x15,
+	0xff, 0x13, 0xdc, 0x5b, 0x54, 0xf3, 0x74, 0x7e, 0xc7, 0xc9, 0xac, 0x74, 0x1f, 0x3d, 0xc6, 0xde,
+	0x42, 0xdf, 0x03, 0xca, 0x4a, 0x46, 0xb9, 0x6e, 0xd6, 0x19, 0xb5, 0xf6, 0x1e, 0x9c, 0x66, 0x18,
+	0x3f, 0x40, 0x31, 0x4f, 0x6b, 0x4d, 0xa9, 0xdc, 0x49, 0xd3, 0x7e, 0x3c, 0xcd, 0x1e, 0xe1, 0x55,
+	0x0a, 0x17, 0x42, 0xe6, 0xe3, 0xe5, 0xba, 0xa6, 0xb2, 0xa4, 0xf3, 0x9c, 0xca, 0xf1, 0xc2, 0xf2,
+	0xdc, 0x4f, 0x4b, 0x99, 0x4c, 0xaf, 0x4e, 0xaf, 0x55, 0xed, 0x86, 0xff, 0x86, 0x64, 0x05, 0xc9,
+	0xe9, 0xaf, 0xa3, 0x9c, 0xe9, 0xe5, 0x6a, 0x36, 0xce, 0x44, 0x75, 0xd9, 0xe0, 0x5e, 0x3a, 0xee,
+	0xa5, 0xe3, 0x9a, 0x5f, 0xe0, 0xac, 0x63, 0xcf, 0x2f, 0xff, 0x1b, 0x00, 0x1b, 0x5b, 0x4b, 0xe9,
+	0x14, 0x07, 0x00, 0x00,
 }
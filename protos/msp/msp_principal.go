@@ -33,8 +33,10 @@ func (mp *MSPPrincipal) VariablyOpaqueFieldProto(name string) (proto.Message, er
 	switch mp.PrincipalClassification {
 	case MSPPrincipal_ROLE:
 		return &MSPRole{}, nil
-	case MSPPrincipal_ORGANIZATION_UNIT:
+	case MSPPrincipal_ORGANIZATION_UNIT, MSPPrincipal_ORGANIZATION_UNIT_NAME:
 		return &OrganizationUnit{}, nil
+	case MSPPrincipal_IDENTITY_HASH:
+		return &IdentityHash{}, nil
 	case MSPPrincipal_IDENTITY:
 		return nil, fmt.Errorf("unable to decode MSP type IDENTITY until the protos are fixed to include the IDENTITY proto in protos/msp")
 	default:
@@ -23,17 +23,27 @@ const (
 	// E.g., this can well be represented by an MSP's
 	// Organization unit
 	MSPPrincipal_IDENTITY MSPPrincipal_Classification = 2
+	// identity
+	// Denotes a single identity referred to by the hash of its certificate
+	MSPPrincipal_IDENTITY_HASH MSPPrincipal_Classification = 3
+	// Denotes the members of an organization unit
+	// referred to by its name only, whichever the certifiers of the unit
+	MSPPrincipal_ORGANIZATION_UNIT_NAME MSPPrincipal_Classification = 4
 )
 
 var MSPPrincipal_Classification_name = map[int32]string{
 	0: "ROLE",
 	1: "ORGANIZATION_UNIT",
 	2: "IDENTITY",
+	3: "IDENTITY_HASH",
+	4: "ORGANIZATION_UNIT_NAME",
 }
 var MSPPrincipal_Classification_value = map[string]int32{
-	"ROLE":              0,
-	"ORGANIZATION_UNIT": 1,
-	"IDENTITY":          2,
+	"ROLE":                   0,
+	"ORGANIZATION_UNIT":      1,
+	"IDENTITY":               2,
+	"IDENTITY_HASH":          3,
+	"ORGANIZATION_UNIT_NAME": 4,
 }
 
 func (x MSPPrincipal_Classification) String() string {
@@ -68,7 +78,7 @@ var MSPRole_MSPRoleType_value = map[string]int32{
 func (x MSPRole_MSPRoleType) String() string {
 	return proto.EnumName(MSPRole_MSPRoleType_name, int32(x))
 }
-func (MSPRole_MSPRoleType) EnumDescriptor() ([]byte, []int) { return fileDescriptor2, []int{2, 0} }
+func (MSPRole_MSPRoleType) EnumDescriptor() ([]byte, []int) { return fileDescriptor2, []int{3, 0} }
 
 // MSPPrincipal aims to represent an MSP-centric set of identities.
 // In particular, this structure allows for definition of
@@ -165,6 +175,37 @@ func (m *OrganizationUnit) GetCertifiersIdentifier() []byte {
 	return nil
 }
 
+// IdentityHash governs the organization of the Principal
+// field of a policy principal when a single identity is referred
+// to by the hash of its certificate rather than by the certificate itself.
+type IdentityHash struct {
+	// MSPIdentifier represents the identifier of the MSP the identity
+	// belongs to
+	MspIdentifier string `protobuf:"bytes,1,opt,name=msp_identifier,json=mspIdentifier" json:"msp_identifier,omitempty"`
+	// Hash is the hash of the certificate of the identity, as computed
+	// for the identifier of the identity by the MSP
+	Hash []byte `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (m *IdentityHash) Reset()                    { *m = IdentityHash{} }
+func (m *IdentityHash) String() string            { return proto.CompactTextString(m) }
+func (*IdentityHash) ProtoMessage()               {}
+func (*IdentityHash) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{2} }
+
+func (m *IdentityHash) GetMspIdentifier() string {
+	if m != nil {
+		return m.MspIdentifier
+	}
+	return ""
+}
+
+func (m *IdentityHash) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
 // MSPRole governs the organization of the Principal
 // field of an MSPPrincipal when it aims to define one of the
 // two dedicated roles within an MSP: Admin and Members.
@@ -180,7 +221,7 @@ type MSPRole struct {
 func (m *MSPRole) Reset()                    { *m = MSPRole{} }
 func (m *MSPRole) String() string            { return proto.CompactTextString(m) }
 func (*MSPRole) ProtoMessage()               {}
-func (*MSPRole) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{2} }
+func (*MSPRole) Descriptor() ([]byte, []int) { return fileDescriptor2, []int{3} }
 
 func (m *MSPRole) GetMspIdentifier() string {
 	if m != nil {
@@ -199,6 +240,7 @@ func (m *MSPRole) GetRole() MSPRole_MSPRoleType {
 func init() {
 	proto.RegisterType((*MSPPrincipal)(nil), "common.MSPPrincipal")
 	proto.RegisterType((*OrganizationUnit)(nil), "common.OrganizationUnit")
+	proto.RegisterType((*IdentityHash)(nil), "common.IdentityHash")
 	proto.RegisterType((*MSPRole)(nil), "common.MSPRole")
 	proto.RegisterEnum("common.MSPPrincipal_Classification", MSPPrincipal_Classification_name, MSPPrincipal_Classification_value)
 	proto.RegisterEnum("common.MSPRole_MSPRoleType", MSPRole_MSPRoleType_name, MSPRole_MSPRoleType_value)
@@ -207,31 +249,33 @@ func init() {
 func init() { proto.RegisterFile("msp/msp_principal.proto", fileDescriptor2) }
 
 var fileDescriptor2 = []byte{
-	// 402 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0x4d, 0x6f, 0xd3, 0x30,
-	0x18, 0xc7, 0xe7, 0x6c, 0x94, 0xf5, 0xa1, 0x44, 0xc6, 0x62, 0x5a, 0x25, 0x26, 0x34, 0x05, 0x90,
-	0x7a, 0x4a, 0xa4, 0xed, 0xc6, 0x05, 0x75, 0x6b, 0x84, 0x2c, 0x2d, 0x2f, 0xf2, 0xb2, 0x03, 0x3b,
-	0x10, 0xa5, 0x99, 0x9b, 0x59, 0x4a, 0x62, 0xcb, 0xc9, 0x0e, 0xe3, 0xbb, 0xf0, 0x2d, 0xf8, 0x1a,
-	0x7c, 0x27, 0x94, 0x84, 0xa6, 0x2e, 0x27, 0x4e, 0x89, 0x9f, 0xff, 0xef, 0xf7, 0xf8, 0x15, 0x4e,
-	0xab, 0x46, 0x79, 0x55, 0xa3, 0x52, 0xa5, 0x45, 0x9d, 0x0b, 0x95, 0x95, 0xae, 0xd2, 0xb2, 0x95,
-	0x64, 0x92, 0xcb, 0xaa, 0x92, 0xb5, 0xf3, 0x1b, 0xc1, 0x2c, 0xb8, 0x8d, 0xe3, 0x6d, 0x4c, 0xbe,
-	0xc3, 0x7c, 0x64, 0xd3, 0xbc, 0xcc, 0x9a, 0x46, 0x6c, 0x44, 0x9e, 0xb5, 0x42, 0xd6, 0x73, 0x74,
-	0x8e, 0x16, 0xf6, 0xc5, 0x07, 0x77, 0x70, 0x5d, 0xd3, 0x73, 0xaf, 0xf7, 0x50, 0x76, 0x3a, 0x36,
-	0xd9, 0x0f, 0xc8, 0x19, 0x4c, 0xc7, 0x68, 0x6e, 0x9d, 0xa3, 0xc5, 0x8c, 0xed, 0x0a, 0xce, 0x17,
-	0xb0, 0xff, 0xe1, 0x8f, 0xe1, 0x88, 0x45, 0x37, 0x3e, 0x3e, 0x20, 0x27, 0xf0, 0x26, 0x62, 0x5f,
-	0x97, 0x21, 0xbd, 0x5f, 0x26, 0x34, 0x0a, 0xd3, 0xbb, 0x90, 0x26, 0x18, 0x91, 0x19, 0x1c, 0xd3,
-	0x95, 0x1f, 0x26, 0x34, 0xf9, 0x86, 0x2d, 0xe7, 0x17, 0x02, 0x1c, 0xe9, 0x22, 0xab, 0xc5, 0x8f,
-	0xde, 0xbf, 0xab, 0x45, 0x4b, 0x3e, 0x81, 0xdd, 0x9d, 0x81, 0x78, 0xe0, 0x75, 0x2b, 0x36, 0x82,
-	0xeb, 0x7e, 0x27, 0x53, 0xf6, 0xba, 0x6a, 0x14, 0x1d, 0x8b, 0x64, 0x05, 0xef, 0xa5, 0xa1, 0x66,
-	0x65, 0xfa, 0x54, 0x8b, 0xd6, 0xd4, 0xac, 0x5e, 0x3b, 0xdb, 0xa7, 0xba, 0x29, 0x8c, 0x2e, 0x97,
-	0x70, 0x92, 0x73, 0x3d, 0x0c, 0x1a, 0x53, 0x3e, 0xec, 0x37, 0xfb, 0x76, 0x17, 0xee, 0x24, 0xe7,
-	0x27, 0x82, 0x97, 0xc1, 0x6d, 0xcc, 0x64, 0xc9, 0xff, 0x77, 0xb5, 0x1e, 0x1c, 0x69, 0x59, 0xf2,
-	0x7e, 0x4d, 0xf6, 0xc5, 0x3b, 0xe3, 0x52, 0xba, 0x2e, 0xdb, 0x6f, 0xf2, 0xac, 0x38, 0xeb, 0x41,
-	0xe7, 0x33, 0xbc, 0x32, 0x8a, 0x04, 0x60, 0x12, 0xf8, 0xc1, 0x95, 0xcf, 0xf0, 0x01, 0x99, 0xc2,
-	0x8b, 0xe5, 0x2a, 0xa0, 0x21, 0x46, 0x5d, 0xf9, 0xfa, 0x86, 0xfa, 0x61, 0x82, 0xad, 0xee, 0xec,
-	0x63, 0xdf, 0x67, 0xf8, 0xf0, 0x2a, 0x86, 0x8f, 0x52, 0x17, 0xee, 0xe3, 0xb3, 0xe2, 0xba, 0xe4,
-	0x0f, 0x05, 0xd7, 0xee, 0x26, 0x5b, 0x6b, 0x91, 0x0f, 0xcf, 0xa9, 0xf9, 0x3b, 0xfb, 0xfd, 0xa2,
-	0x10, 0xed, 0xe3, 0xd3, 0xba, 0x1b, 0x7a, 0x06, 0xec, 0x0d, 0xb0, 0x37, 0xc0, 0xdd, 0x83, 0x5c,
-	0x4f, 0xfa, 0xff, 0xcb, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x0e, 0xfd, 0x10, 0x47, 0xa2, 0x02,
-	0x00, 0x00,
+	// 442 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0xc1, 0x6e, 0x9b, 0x40,
+	0x10, 0x86, 0x83, 0xed, 0xba, 0xf1, 0xd4, 0xb1, 0x36, 0xab, 0xa6, 0xb1, 0xda, 0xa8, 0x8a, 0x68,
+	0x2b, 0xf9, 0x04, 0x52, 0x72, 0xeb, 0x8d, 0xc4, 0xa8, 0x5e, 0x29, 0x60, 0xb4, 0x26, 0x87, 0xe6,
+	0x50, 0x84, 0xc9, 0xda, 0xac, 0x04, 0x2c, 0xda, 0x25, 0x07, 0xf7, 0x5d, 0xfa, 0x16, 0x7d, 0xbc,
+	0x1e, 0x2a, 0x20, 0xb6, 0xd7, 0xed, 0x25, 0x27, 0x76, 0x66, 0xfe, 0xef, 0x1f, 0x18, 0x66, 0xe1,
+	0x3c, 0x57, 0xa5, 0x9d, 0xab, 0x32, 0x2a, 0x25, 0x2f, 0x12, 0x5e, 0xc6, 0x99, 0x55, 0x4a, 0x51,
+	0x09, 0xdc, 0x4f, 0x44, 0x9e, 0x8b, 0xc2, 0xfc, 0x63, 0xc0, 0xd0, 0x5b, 0x04, 0xc1, 0xb6, 0x8c,
+	0x7f, 0xc0, 0x78, 0xa7, 0x8d, 0x92, 0x2c, 0x56, 0x8a, 0xaf, 0x78, 0x12, 0x57, 0x5c, 0x14, 0x63,
+	0xe3, 0xd2, 0x98, 0x8c, 0xae, 0x3e, 0x59, 0x2d, 0x6b, 0xe9, 0x9c, 0x75, 0x7b, 0x20, 0xa5, 0xe7,
+	0x3b, 0x93, 0xc3, 0x02, 0xbe, 0x80, 0xc1, 0xae, 0x34, 0xee, 0x5c, 0x1a, 0x93, 0x21, 0xdd, 0x27,
+	0xcc, 0x02, 0x46, 0xff, 0xe8, 0x8f, 0xa1, 0x47, 0xe7, 0x77, 0x2e, 0x3a, 0xc2, 0x67, 0x70, 0x3a,
+	0xa7, 0xdf, 0x1c, 0x9f, 0x3c, 0x38, 0x21, 0x99, 0xfb, 0xd1, 0xbd, 0x4f, 0x42, 0x64, 0xe0, 0x21,
+	0x1c, 0x93, 0xa9, 0xeb, 0x87, 0x24, 0xfc, 0x8e, 0x3a, 0xf8, 0x14, 0x4e, 0xb6, 0x51, 0x34, 0x73,
+	0x16, 0x33, 0xd4, 0xc5, 0xef, 0xe1, 0xdd, 0x7f, 0x5c, 0xe4, 0x3b, 0x9e, 0x8b, 0x7a, 0xe6, 0x6f,
+	0x03, 0xd0, 0x5c, 0xae, 0xe3, 0x82, 0xff, 0x6c, 0xda, 0xdd, 0x17, 0xbc, 0xc2, 0x5f, 0x60, 0x54,
+	0x8f, 0x8c, 0x3f, 0xb2, 0xa2, 0xe2, 0x2b, 0xce, 0x64, 0xf3, 0xe1, 0x03, 0x7a, 0x92, 0xab, 0x92,
+	0xec, 0x92, 0x78, 0x0a, 0x1f, 0x85, 0x86, 0xc6, 0x59, 0xf4, 0x54, 0xf0, 0x4a, 0xc7, 0x3a, 0x0d,
+	0x76, 0x71, 0xa8, 0xaa, 0x5b, 0x68, 0x2e, 0xd7, 0x70, 0x96, 0x30, 0xd9, 0x06, 0x4a, 0x87, 0xbb,
+	0xcd, 0x6c, 0xde, 0xee, 0x8b, 0x7b, 0xc8, 0x24, 0x30, 0x6c, 0xa3, 0x6a, 0x33, 0x8b, 0x55, 0xfa,
+	0xd2, 0x37, 0xc6, 0xd0, 0x4b, 0x63, 0x95, 0x3e, 0x8f, 0xbd, 0x39, 0x9b, 0xbf, 0x0c, 0x78, 0xed,
+	0x2d, 0x02, 0x2a, 0x32, 0xf6, 0x52, 0x1b, 0x1b, 0x7a, 0x52, 0x64, 0xac, 0xb1, 0x19, 0x5d, 0x7d,
+	0xd0, 0xd6, 0xa1, 0x76, 0xd9, 0x3e, 0xc3, 0x4d, 0xc9, 0x68, 0x23, 0x34, 0xbf, 0xc2, 0x1b, 0x2d,
+	0x89, 0x01, 0xfa, 0x9e, 0xeb, 0xdd, 0xb8, 0x14, 0x1d, 0xe1, 0x01, 0xbc, 0x72, 0xa6, 0x1e, 0xf1,
+	0x91, 0x51, 0xa7, 0x6f, 0xef, 0x88, 0xeb, 0x87, 0xa8, 0x53, 0xff, 0xf5, 0xc0, 0x75, 0x29, 0xea,
+	0xde, 0x04, 0xf0, 0x59, 0xc8, 0xb5, 0x95, 0x6e, 0x4a, 0x26, 0x33, 0xf6, 0xb8, 0x66, 0xd2, 0x5a,
+	0xc5, 0x4b, 0xc9, 0x93, 0x76, 0x91, 0xd5, 0x73, 0xf7, 0x87, 0xc9, 0x9a, 0x57, 0xe9, 0xd3, 0xb2,
+	0x0e, 0x6d, 0x4d, 0x6c, 0xb7, 0x62, 0xbb, 0x15, 0xd7, 0x57, 0x61, 0xd9, 0x6f, 0xce, 0xd7, 0x7f,
+	0x07, 0x00, 0xdb, 0x39, 0x98, 0x71, 0x1c, 0x03, 0x00, 0x00,
 }
@@ -23,6 +23,10 @@ It has these top-level messages:
 	ServerStatus
 	LogLevelRequest
 	LogLevelResponse
+	ChannelMembershipRequest
+	ChannelMembershipResponse
+	PeerMembershipInfo
+	YieldLeadershipRequest
 	ChaincodeID
 	ChaincodeInput
 	ChaincodeSpec
@@ -54,6 +58,11 @@ It has these top-level messages:
 	SignedEvent
 	Event
 	DeliverResponse
+	ChaincodeEventsRequest
+	ChaincodeEventsCheckpoint
+	ChaincodeEventInfo
+	ChaincodeEventsBlock
+	ChaincodeEventsResponse
 	PeerID
 	PeerEndpoint
 	SignedProposal
@@ -65,11 +74,13 @@ It has these top-level messages:
 	Response
 	ProposalResponsePayload
 	Endorsement
+	MVCCConflict
 	ChaincodeQueryResponse
 	ChaincodeInfo
 	ChannelQueryResponse
 	ChannelInfo
 	APIResource
+	ACLs
 	ChaincodeIdentifier
 	ChaincodeValidation
 	VSCCArgs
@@ -78,6 +89,10 @@ It has these top-level messages:
 	SignedChaincodeDeploymentSpec
 	SignedTransaction
 	ProcessedTransaction
+	TransactionStatus
+	TransactionStatuses
+	TxMVCCConflict
+	TxMVCCConflicts
 	Transaction
 	TransactionAction
 	ChaincodeActionPayload
@@ -203,10 +218,135 @@ func (m *LogLevelResponse) GetLogLevel() string {
 	return ""
 }
 
+type ChannelMembershipRequest struct {
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+}
+
+func (m *ChannelMembershipRequest) Reset()                    { *m = ChannelMembershipRequest{} }
+func (m *ChannelMembershipRequest) String() string            { return proto.CompactTextString(m) }
+func (*ChannelMembershipRequest) ProtoMessage()               {}
+func (*ChannelMembershipRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{3} }
+
+func (m *ChannelMembershipRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+// ChannelMembershipResponse is the view of a channel's membership
+// as seen by the gossip layer of the peer
+type ChannelMembershipResponse struct {
+	Peers []*PeerMembershipInfo `protobuf:"bytes,1,rep,name=peers" json:"peers,omitempty"`
+}
+
+func (m *ChannelMembershipResponse) Reset()                    { *m = ChannelMembershipResponse{} }
+func (m *ChannelMembershipResponse) String() string            { return proto.CompactTextString(m) }
+func (*ChannelMembershipResponse) ProtoMessage()               {}
+func (*ChannelMembershipResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{4} }
+
+func (m *ChannelMembershipResponse) GetPeers() []*PeerMembershipInfo {
+	if m != nil {
+		return m.Peers
+	}
+	return nil
+}
+
+// PeerMembershipInfo describes a peer and the
+// properties it advertises for the channel
+type PeerMembershipInfo struct {
+	Endpoint     string `protobuf:"bytes,1,opt,name=endpoint" json:"endpoint,omitempty"`
+	PkiId        []byte `protobuf:"bytes,2,opt,name=pki_id,json=pkiId,proto3" json:"pki_id,omitempty"`
+	LedgerHeight uint64 `protobuf:"varint,3,opt,name=ledger_height,json=ledgerHeight" json:"ledger_height,omitempty"`
+	// chaincodes are the chaincodes instantiated on the channel
+	Chaincodes []*ChaincodeInfo `protobuf:"bytes,4,rep,name=chaincodes" json:"chaincodes,omitempty"`
+	// installed_chaincodes are the chaincodes installed on the peer
+	InstalledChaincodes []*ChaincodeInfo `protobuf:"bytes,5,rep,name=installed_chaincodes,json=installedChaincodes" json:"installed_chaincodes,omitempty"`
+	Roles               []string         `protobuf:"bytes,6,rep,name=roles" json:"roles,omitempty"`
+	// self is true for the peer that answered the request
+	Self bool `protobuf:"varint,7,opt,name=self" json:"self,omitempty"`
+}
+
+func (m *PeerMembershipInfo) Reset()                    { *m = PeerMembershipInfo{} }
+func (m *PeerMembershipInfo) String() string            { return proto.CompactTextString(m) }
+func (*PeerMembershipInfo) ProtoMessage()               {}
+func (*PeerMembershipInfo) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{5} }
+
+func (m *PeerMembershipInfo) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *PeerMembershipInfo) GetPkiId() []byte {
+	if m != nil {
+		return m.PkiId
+	}
+	return nil
+}
+
+func (m *PeerMembershipInfo) GetLedgerHeight() uint64 {
+	if m != nil {
+		return m.LedgerHeight
+	}
+	return 0
+}
+
+func (m *PeerMembershipInfo) GetChaincodes() []*ChaincodeInfo {
+	if m != nil {
+		return m.Chaincodes
+	}
+	return nil
+}
+
+func (m *PeerMembershipInfo) GetInstalledChaincodes() []*ChaincodeInfo {
+	if m != nil {
+		return m.InstalledChaincodes
+	}
+	return nil
+}
+
+func (m *PeerMembershipInfo) GetRoles() []string {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+func (m *PeerMembershipInfo) GetSelf() bool {
+	if m != nil {
+		return m.Self
+	}
+	return false
+}
+
+// YieldLeadershipRequest asks the peer to relinquish
+// its leadership of the given channel
+type YieldLeadershipRequest struct {
+	ChannelId string `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+}
+
+func (m *YieldLeadershipRequest) Reset()                    { *m = YieldLeadershipRequest{} }
+func (m *YieldLeadershipRequest) String() string            { return proto.CompactTextString(m) }
+func (*YieldLeadershipRequest) ProtoMessage()               {}
+func (*YieldLeadershipRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{6} }
+
+func (m *YieldLeadershipRequest) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*ServerStatus)(nil), "protos.ServerStatus")
 	proto.RegisterType((*LogLevelRequest)(nil), "protos.LogLevelRequest")
 	proto.RegisterType((*LogLevelResponse)(nil), "protos.LogLevelResponse")
+	proto.RegisterType((*ChannelMembershipRequest)(nil), "protos.ChannelMembershipRequest")
+	proto.RegisterType((*ChannelMembershipResponse)(nil), "protos.ChannelMembershipResponse")
+	proto.RegisterType((*PeerMembershipInfo)(nil), "protos.PeerMembershipInfo")
+	proto.RegisterType((*YieldLeadershipRequest)(nil), "protos.YieldLeadershipRequest")
 	proto.RegisterEnum("protos.ServerStatus_StatusCode", ServerStatus_StatusCode_name, ServerStatus_StatusCode_value)
 }
 
@@ -227,6 +367,8 @@ type AdminClient interface {
 	GetModuleLogLevel(ctx context.Context, in *LogLevelRequest, opts ...grpc.CallOption) (*LogLevelResponse, error)
 	SetModuleLogLevel(ctx context.Context, in *LogLevelRequest, opts ...grpc.CallOption) (*LogLevelResponse, error)
 	RevertLogLevels(ctx context.Context, in *google_protobuf.Empty, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
+	GetChannelMembership(ctx context.Context, in *ChannelMembershipRequest, opts ...grpc.CallOption) (*ChannelMembershipResponse, error)
+	YieldLeadership(ctx context.Context, in *YieldLeadershipRequest, opts ...grpc.CallOption) (*google_protobuf.Empty, error)
 }
 
 type adminClient struct {
@@ -282,6 +424,24 @@ func (c *adminClient) RevertLogLevels(ctx context.Context, in *google_protobuf.E
 	return out, nil
 }
 
+func (c *adminClient) GetChannelMembership(ctx context.Context, in *ChannelMembershipRequest, opts ...grpc.CallOption) (*ChannelMembershipResponse, error) {
+	out := new(ChannelMembershipResponse)
+	err := grpc.Invoke(ctx, "/protos.Admin/GetChannelMembership", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) YieldLeadership(ctx context.Context, in *YieldLeadershipRequest, opts ...grpc.CallOption) (*google_protobuf.Empty, error) {
+	out := new(google_protobuf.Empty)
+	err := grpc.Invoke(ctx, "/protos.Admin/YieldLeadership", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Admin service
 
 type AdminServer interface {
@@ -291,6 +451,8 @@ type AdminServer interface {
 	GetModuleLogLevel(context.Context, *LogLevelRequest) (*LogLevelResponse, error)
 	SetModuleLogLevel(context.Context, *LogLevelRequest) (*LogLevelResponse, error)
 	RevertLogLevels(context.Context, *google_protobuf.Empty) (*google_protobuf.Empty, error)
+	GetChannelMembership(context.Context, *ChannelMembershipRequest) (*ChannelMembershipResponse, error)
+	YieldLeadership(context.Context, *YieldLeadershipRequest) (*google_protobuf.Empty, error)
 }
 
 func RegisterAdminServer(s *grpc.Server, srv AdminServer) {
@@ -387,6 +549,42 @@ func _Admin_RevertLogLevels_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Admin_GetChannelMembership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChannelMembershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetChannelMembership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/GetChannelMembership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetChannelMembership(ctx, req.(*ChannelMembershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_YieldLeadership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(YieldLeadershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).YieldLeadership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/protos.Admin/YieldLeadership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).YieldLeadership(ctx, req.(*YieldLeadershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Admin_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "protos.Admin",
 	HandlerType: (*AdminServer)(nil),
@@ -411,6 +609,14 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RevertLogLevels",
 			Handler:    _Admin_RevertLogLevels_Handler,
 		},
+		{
+			MethodName: "GetChannelMembership",
+			Handler:    _Admin_GetChannelMembership_Handler,
+		},
+		{
+			MethodName: "YieldLeadership",
+			Handler:    _Admin_YieldLeadership_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "peer/admin.proto",
@@ -419,32 +625,47 @@ var _Admin_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("peer/admin.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 418 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x93, 0x41, 0x6f, 0xd3, 0x30,
-	0x14, 0xc7, 0x9b, 0x42, 0x0b, 0x79, 0x1b, 0xcc, 0x58, 0x08, 0xaa, 0x4e, 0x08, 0x94, 0x13, 0x5c,
-	0x1c, 0x69, 0x1c, 0x38, 0x20, 0x0e, 0xdd, 0x12, 0x06, 0x62, 0x4b, 0x23, 0x67, 0x15, 0x02, 0x09,
-	0x4d, 0x49, 0xf3, 0xe6, 0x55, 0x38, 0x73, 0xb0, 0x9d, 0x4a, 0xfb, 0x3a, 0x7c, 0x2e, 0x3e, 0x0c,
-	0x4a, 0xdc, 0x68, 0x13, 0xb0, 0x03, 0x82, 0x93, 0xf3, 0xde, 0xfb, 0xff, 0xff, 0x71, 0x7e, 0xb1,
-	0x81, 0xd4, 0x88, 0x3a, 0xcc, 0xcb, 0x6a, 0x75, 0xc1, 0x6a, 0xad, 0xac, 0xa2, 0xe3, 0x6e, 0x31,
-	0xd3, 0x5d, 0xa1, 0x94, 0x90, 0x18, 0x76, 0x65, 0xd1, 0x9c, 0x85, 0x58, 0xd5, 0xf6, 0xd2, 0x89,
-	0x82, 0xef, 0x1e, 0x6c, 0x67, 0xa8, 0xd7, 0xa8, 0x33, 0x9b, 0xdb, 0xc6, 0xd0, 0x57, 0x30, 0x36,
-	0xdd, 0xd3, 0xc4, 0x7b, 0xe6, 0x3d, 0xbf, 0xbf, 0xf7, 0xd4, 0x09, 0x0d, 0xbb, 0xae, 0x62, 0x6e,
-	0x39, 0x50, 0x25, 0xf2, 0x8d, 0x3c, 0xf8, 0x04, 0x70, 0xd5, 0xa5, 0xf7, 0xc0, 0x5f, 0x24, 0x51,
-	0xfc, 0xf6, 0x7d, 0x12, 0x47, 0x64, 0x40, 0xb7, 0xe0, 0x4e, 0x76, 0x32, 0xe3, 0x27, 0x71, 0x44,
-	0x3c, 0x57, 0xcc, 0xd3, 0x34, 0x8e, 0xc8, 0x90, 0x02, 0x8c, 0xd3, 0xd9, 0x22, 0x8b, 0x23, 0x72,
-	0x8b, 0xfa, 0x30, 0x8a, 0x39, 0x9f, 0x73, 0x72, 0xbb, 0xd5, 0x2c, 0x92, 0x0f, 0xc9, 0xfc, 0x63,
-	0x42, 0x46, 0xc1, 0x31, 0xec, 0x1c, 0x29, 0x71, 0x84, 0x6b, 0x94, 0x1c, 0xbf, 0x35, 0x68, 0x2c,
-	0x7d, 0x02, 0x20, 0x95, 0x38, 0xad, 0x54, 0xd9, 0x48, 0xec, 0xb6, 0xea, 0x73, 0x5f, 0x2a, 0x71,
-	0xdc, 0x35, 0xe8, 0x2e, 0xb4, 0xc5, 0xa9, 0x6c, 0x2d, 0x93, 0x61, 0x37, 0xbd, 0x2b, 0x37, 0x11,
-	0x41, 0x02, 0xe4, 0x2a, 0xce, 0xd4, 0xea, 0xc2, 0xe0, 0xbf, 0xe4, 0xed, 0xfd, 0x18, 0xc2, 0x68,
-	0xd6, 0x82, 0xa7, 0xaf, 0xc1, 0x3f, 0x44, 0xbb, 0x21, 0xf9, 0x88, 0x39, 0xf0, 0xac, 0x07, 0xcf,
-	0xe2, 0x16, 0xfc, 0xf4, 0xe1, 0x9f, 0x88, 0x06, 0x03, 0xfa, 0x06, 0xb6, 0x32, 0x9b, 0x6b, 0xeb,
-	0xda, 0x7f, 0x6d, 0x7f, 0x07, 0x0f, 0x0e, 0xd1, 0xba, 0xfd, 0xf6, 0x9f, 0x47, 0x1f, 0xf7, 0xe2,
-	0x5f, 0xf8, 0x4d, 0x27, 0xbf, 0x0f, 0x1c, 0x09, 0x97, 0x94, 0xfd, 0x9f, 0xa4, 0x03, 0xd8, 0xe1,
-	0xb8, 0x46, 0x6d, 0xfb, 0xd9, 0xcd, 0x54, 0x6e, 0xe8, 0x07, 0x83, 0xfd, 0x2f, 0x10, 0x28, 0x2d,
-	0xd8, 0xf9, 0x65, 0x8d, 0x5a, 0x62, 0x29, 0x50, 0xb3, 0xb3, 0xbc, 0xd0, 0xab, 0x65, 0xff, 0xe2,
-	0xf6, 0xe4, 0xef, 0x6f, 0x77, 0x7f, 0x20, 0xcd, 0x97, 0x5f, 0x73, 0x81, 0x9f, 0x5f, 0x88, 0x95,
-	0x3d, 0x6f, 0x0a, 0xb6, 0x54, 0x55, 0x78, 0xcd, 0x18, 0x3a, 0xa3, 0xbb, 0x0a, 0x26, 0x6c, 0x8d,
-	0x85, 0xbb, 0x26, 0x2f, 0x7f, 0x06, 0x00, 0x00, 0xff, 0xff, 0x6e, 0xe6, 0xef, 0xb7, 0x41, 0x03,
+	// 658 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x54, 0x51, 0x4f, 0xda, 0x50,
+	0x14, 0x16, 0xa1, 0x28, 0x47, 0x9c, 0xdd, 0x1d, 0xba, 0xae, 0x66, 0x1b, 0xeb, 0x5e, 0xd8, 0x4b,
+	0x59, 0x5c, 0x16, 0xb3, 0x2c, 0x7b, 0x50, 0xe8, 0x94, 0x28, 0x48, 0x8a, 0x66, 0x71, 0x89, 0x21,
+	0x85, 0x1e, 0x4a, 0xe3, 0xa5, 0xb7, 0xde, 0x5e, 0x4c, 0xfc, 0x07, 0xfb, 0x07, 0x7b, 0xdf, 0x2f,
+	0x5d, 0xda, 0xdb, 0x0a, 0x51, 0x34, 0x31, 0xdb, 0x13, 0x3d, 0xe7, 0x7c, 0xdf, 0x97, 0xaf, 0x9c,
+	0xf3, 0x15, 0xd4, 0x10, 0x91, 0xd7, 0x1d, 0x77, 0xe2, 0x07, 0x66, 0xc8, 0x99, 0x60, 0xa4, 0x98,
+	0xfc, 0x44, 0xfa, 0xb6, 0xc7, 0x98, 0x47, 0xb1, 0x9e, 0x94, 0x83, 0xe9, 0xa8, 0x8e, 0x93, 0x50,
+	0xdc, 0x48, 0x90, 0x2e, 0x69, 0x57, 0x53, 0xe4, 0x69, 0xc7, 0xf8, 0x93, 0x83, 0x72, 0x0f, 0xf9,
+	0x35, 0xf2, 0x9e, 0x70, 0xc4, 0x34, 0x22, 0xbb, 0x50, 0x8c, 0x92, 0x27, 0x2d, 0x57, 0xcd, 0xd5,
+	0x9e, 0xed, 0xbc, 0x95, 0xc0, 0xc8, 0x9c, 0x47, 0x99, 0xf2, 0xa7, 0xc1, 0x5c, 0xb4, 0x53, 0xb8,
+	0x71, 0x0e, 0x30, 0xeb, 0x92, 0x75, 0x28, 0x9d, 0x75, 0x9a, 0xd6, 0xf7, 0x56, 0xc7, 0x6a, 0xaa,
+	0x4b, 0x64, 0x0d, 0x56, 0x7a, 0xa7, 0x7b, 0xf6, 0xa9, 0xd5, 0x54, 0x73, 0xb2, 0x38, 0xe9, 0x76,
+	0xad, 0xa6, 0xba, 0x4c, 0x00, 0x8a, 0xdd, 0xbd, 0xb3, 0x9e, 0xd5, 0x54, 0xf3, 0xa4, 0x04, 0x8a,
+	0x65, 0xdb, 0x27, 0xb6, 0x5a, 0x88, 0x31, 0x67, 0x9d, 0xa3, 0xce, 0xc9, 0x8f, 0x8e, 0xaa, 0x18,
+	0x6d, 0xd8, 0x38, 0x66, 0xde, 0x31, 0x5e, 0x23, 0xb5, 0xf1, 0x6a, 0x8a, 0x91, 0x20, 0xaf, 0x01,
+	0x28, 0xf3, 0xfa, 0x13, 0xe6, 0x4e, 0x29, 0x26, 0x56, 0x4b, 0x76, 0x89, 0x32, 0xaf, 0x9d, 0x34,
+	0xc8, 0x36, 0xc4, 0x45, 0x9f, 0xc6, 0x14, 0x6d, 0x39, 0x99, 0xae, 0xd2, 0x54, 0xc2, 0xe8, 0x80,
+	0x3a, 0x93, 0x8b, 0x42, 0x16, 0x44, 0xf8, 0x4f, 0x7a, 0x5f, 0x40, 0x6b, 0x8c, 0x9d, 0x20, 0x40,
+	0xda, 0xc6, 0xc9, 0x00, 0x79, 0x34, 0xf6, 0xc3, 0x39, 0x9f, 0x43, 0x39, 0xeb, 0xfb, 0x6e, 0xa6,
+	0x9b, 0x76, 0x5a, 0xae, 0xd1, 0x86, 0x57, 0x0b, 0xa8, 0xa9, 0xa7, 0x8f, 0xa0, 0xc4, 0xfb, 0x8a,
+	0x37, 0x91, 0xaf, 0xad, 0xed, 0xe8, 0xd9, 0x26, 0xba, 0x88, 0x7c, 0x06, 0x6f, 0x05, 0x23, 0x66,
+	0x4b, 0xa0, 0xf1, 0x7b, 0x19, 0xc8, 0xfd, 0x29, 0xd1, 0x61, 0x15, 0x03, 0x37, 0x64, 0x7e, 0x20,
+	0x52, 0x0b, 0xb7, 0x35, 0xd9, 0x84, 0x62, 0x78, 0xe9, 0xc7, 0xe6, 0xe2, 0xd7, 0x2a, 0xdb, 0x4a,
+	0x78, 0xe9, 0xb7, 0x5c, 0xf2, 0x1e, 0xd6, 0x29, 0xba, 0x1e, 0xf2, 0xfe, 0x18, 0x7d, 0x6f, 0x2c,
+	0xb4, 0x7c, 0x35, 0x57, 0x2b, 0xd8, 0x65, 0xd9, 0x3c, 0x4c, 0x7a, 0xe4, 0x73, 0xf2, 0x72, 0x7e,
+	0x30, 0x64, 0x2e, 0x46, 0x5a, 0x21, 0x71, 0xb9, 0x99, 0xb9, 0x6c, 0x64, 0x93, 0xc4, 0xe0, 0x1c,
+	0x90, 0x1c, 0x42, 0xc5, 0x0f, 0x22, 0xe1, 0x50, 0x8a, 0x6e, 0x7f, 0x4e, 0x40, 0x79, 0x4c, 0xe0,
+	0xc5, 0x2d, 0xa5, 0x31, 0x53, 0xaa, 0x80, 0xc2, 0x19, 0xc5, 0x48, 0x2b, 0x56, 0xf3, 0xb5, 0x92,
+	0x2d, 0x0b, 0x42, 0xa0, 0x10, 0x21, 0x1d, 0x69, 0x2b, 0xd5, 0x5c, 0x6d, 0xd5, 0x4e, 0x9e, 0x8d,
+	0x5d, 0xd8, 0x3a, 0xf7, 0x91, 0xba, 0xc7, 0xe8, 0xb8, 0x4f, 0xd9, 0xd0, 0xce, 0xaf, 0x02, 0x28,
+	0x7b, 0x71, 0xce, 0xc8, 0x57, 0x28, 0x1d, 0xa0, 0x48, 0x63, 0xb2, 0x65, 0xca, 0x9c, 0x99, 0x59,
+	0xce, 0x4c, 0x2b, 0xce, 0x99, 0x5e, 0x59, 0x14, 0x17, 0x63, 0x89, 0x7c, 0x83, 0xb5, 0x9e, 0x70,
+	0xb8, 0x90, 0xed, 0x27, 0xd3, 0x0f, 0xe1, 0xf9, 0x01, 0x0a, 0x79, 0x8c, 0xd9, 0xed, 0x92, 0x97,
+	0x19, 0xf8, 0x4e, 0x38, 0x74, 0xed, 0xfe, 0x40, 0x9e, 0x94, 0x54, 0xea, 0xfd, 0x1f, 0xa5, 0x06,
+	0x6c, 0xd8, 0x78, 0x8d, 0x5c, 0x64, 0xb3, 0x87, 0xff, 0x95, 0x07, 0xfa, 0xc6, 0x12, 0xb9, 0x80,
+	0xca, 0x01, 0x8a, 0x7b, 0x19, 0x20, 0xd5, 0xb9, 0x2b, 0x58, 0x98, 0x2c, 0xfd, 0xdd, 0x23, 0x88,
+	0x5b, 0x8f, 0x47, 0xb0, 0x71, 0x67, 0xed, 0xe4, 0x4d, 0xc6, 0x5b, 0x7c, 0x0f, 0x0f, 0x7b, 0xdd,
+	0xbf, 0x00, 0x83, 0x71, 0xcf, 0x1c, 0xdf, 0x84, 0xc8, 0x65, 0x0e, 0xcc, 0x91, 0x33, 0xe0, 0xfe,
+	0x30, 0x53, 0x8c, 0x43, 0xb8, 0x5f, 0x4e, 0xae, 0xa5, 0xeb, 0x0c, 0x2f, 0x1d, 0x0f, 0x7f, 0x7e,
+	0xf0, 0x7c, 0x31, 0x9e, 0x0e, 0xcc, 0x21, 0x9b, 0xd4, 0xe7, 0x88, 0x75, 0x49, 0x94, 0x5f, 0xe9,
+	0xa8, 0x1e, 0x13, 0x07, 0xf2, 0x0b, 0xfe, 0xe9, 0xef, 0x00, 0x21, 0x46, 0x17, 0x07, 0xdc, 0x05,
 	0x00, 0x00,
 }
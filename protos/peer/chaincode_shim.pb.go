@@ -21,25 +21,26 @@ var _ = math.Inf
 type ChaincodeMessage_Type int32
 
 const (
-	ChaincodeMessage_UNDEFINED           ChaincodeMessage_Type = 0
-	ChaincodeMessage_REGISTER            ChaincodeMessage_Type = 1
-	ChaincodeMessage_REGISTERED          ChaincodeMessage_Type = 2
-	ChaincodeMessage_INIT                ChaincodeMessage_Type = 3
-	ChaincodeMessage_READY               ChaincodeMessage_Type = 4
-	ChaincodeMessage_TRANSACTION         ChaincodeMessage_Type = 5
-	ChaincodeMessage_COMPLETED           ChaincodeMessage_Type = 6
-	ChaincodeMessage_ERROR               ChaincodeMessage_Type = 7
-	ChaincodeMessage_GET_STATE           ChaincodeMessage_Type = 8
-	ChaincodeMessage_PUT_STATE           ChaincodeMessage_Type = 9
-	ChaincodeMessage_DEL_STATE           ChaincodeMessage_Type = 10
-	ChaincodeMessage_INVOKE_CHAINCODE    ChaincodeMessage_Type = 11
-	ChaincodeMessage_RESPONSE            ChaincodeMessage_Type = 13
-	ChaincodeMessage_GET_STATE_BY_RANGE  ChaincodeMessage_Type = 14
-	ChaincodeMessage_GET_QUERY_RESULT    ChaincodeMessage_Type = 15
-	ChaincodeMessage_QUERY_STATE_NEXT    ChaincodeMessage_Type = 16
-	ChaincodeMessage_QUERY_STATE_CLOSE   ChaincodeMessage_Type = 17
-	ChaincodeMessage_KEEPALIVE           ChaincodeMessage_Type = 18
-	ChaincodeMessage_GET_HISTORY_FOR_KEY ChaincodeMessage_Type = 19
+	ChaincodeMessage_UNDEFINED             ChaincodeMessage_Type = 0
+	ChaincodeMessage_REGISTER              ChaincodeMessage_Type = 1
+	ChaincodeMessage_REGISTERED            ChaincodeMessage_Type = 2
+	ChaincodeMessage_INIT                  ChaincodeMessage_Type = 3
+	ChaincodeMessage_READY                 ChaincodeMessage_Type = 4
+	ChaincodeMessage_TRANSACTION           ChaincodeMessage_Type = 5
+	ChaincodeMessage_COMPLETED             ChaincodeMessage_Type = 6
+	ChaincodeMessage_ERROR                 ChaincodeMessage_Type = 7
+	ChaincodeMessage_GET_STATE             ChaincodeMessage_Type = 8
+	ChaincodeMessage_PUT_STATE             ChaincodeMessage_Type = 9
+	ChaincodeMessage_DEL_STATE             ChaincodeMessage_Type = 10
+	ChaincodeMessage_INVOKE_CHAINCODE      ChaincodeMessage_Type = 11
+	ChaincodeMessage_RESPONSE              ChaincodeMessage_Type = 13
+	ChaincodeMessage_GET_STATE_BY_RANGE    ChaincodeMessage_Type = 14
+	ChaincodeMessage_GET_QUERY_RESULT      ChaincodeMessage_Type = 15
+	ChaincodeMessage_QUERY_STATE_NEXT      ChaincodeMessage_Type = 16
+	ChaincodeMessage_QUERY_STATE_CLOSE     ChaincodeMessage_Type = 17
+	ChaincodeMessage_KEEPALIVE             ChaincodeMessage_Type = 18
+	ChaincodeMessage_GET_HISTORY_FOR_KEY   ChaincodeMessage_Type = 19
+	ChaincodeMessage_GET_PRIVATE_DATA_HASH ChaincodeMessage_Type = 20
 )
 
 var ChaincodeMessage_Type_name = map[int32]string{
@@ -62,27 +63,29 @@ var ChaincodeMessage_Type_name = map[int32]string{
 	17: "QUERY_STATE_CLOSE",
 	18: "KEEPALIVE",
 	19: "GET_HISTORY_FOR_KEY",
+	20: "GET_PRIVATE_DATA_HASH",
 }
 var ChaincodeMessage_Type_value = map[string]int32{
-	"UNDEFINED":           0,
-	"REGISTER":            1,
-	"REGISTERED":          2,
-	"INIT":                3,
-	"READY":               4,
-	"TRANSACTION":         5,
-	"COMPLETED":           6,
-	"ERROR":               7,
-	"GET_STATE":           8,
-	"PUT_STATE":           9,
-	"DEL_STATE":           10,
-	"INVOKE_CHAINCODE":    11,
-	"RESPONSE":            13,
-	"GET_STATE_BY_RANGE":  14,
-	"GET_QUERY_RESULT":    15,
-	"QUERY_STATE_NEXT":    16,
-	"QUERY_STATE_CLOSE":   17,
-	"KEEPALIVE":           18,
-	"GET_HISTORY_FOR_KEY": 19,
+	"UNDEFINED":             0,
+	"REGISTER":              1,
+	"REGISTERED":            2,
+	"INIT":                  3,
+	"READY":                 4,
+	"TRANSACTION":           5,
+	"COMPLETED":             6,
+	"ERROR":                 7,
+	"GET_STATE":             8,
+	"PUT_STATE":             9,
+	"DEL_STATE":             10,
+	"INVOKE_CHAINCODE":      11,
+	"RESPONSE":              13,
+	"GET_STATE_BY_RANGE":    14,
+	"GET_QUERY_RESULT":      15,
+	"QUERY_STATE_NEXT":      16,
+	"QUERY_STATE_CLOSE":     17,
+	"KEEPALIVE":             18,
+	"GET_HISTORY_FOR_KEY":   19,
+	"GET_PRIVATE_DATA_HASH": 20,
 }
 
 func (x ChaincodeMessage_Type) String() string {
@@ -102,6 +105,10 @@ type ChaincodeMessage struct {
 	ChaincodeEvent *ChaincodeEvent `protobuf:"bytes,6,opt,name=chaincode_event,json=chaincodeEvent" json:"chaincode_event,omitempty"`
 	// channel id
 	ChannelId string `protobuf:"bytes,7,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+	// span context of the transaction the message belongs to, formatted as a
+	// W3C trace context traceparent. It is set by the peer on INIT and
+	// TRANSACTION messages, and echoed by the shim on its requests to the peer
+	TraceParent string `protobuf:"bytes,8,opt,name=trace_parent,json=traceParent" json:"trace_parent,omitempty"`
 }
 
 func (m *ChaincodeMessage) Reset()                    { *m = ChaincodeMessage{} }
@@ -158,6 +165,13 @@ func (m *ChaincodeMessage) GetChannelId() string {
 	return ""
 }
 
+func (m *ChaincodeMessage) GetTraceParent() string {
+	if m != nil {
+		return m.TraceParent
+	}
+	return ""
+}
+
 type GetState struct {
 	Key        string `protobuf:"bytes,1,opt,name=key" json:"key,omitempty"`
 	Collection string `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
@@ -512,57 +526,59 @@ var _ChaincodeSupport_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("peer/chaincode_shim.proto", fileDescriptor3) }
 
 var fileDescriptor3 = []byte{
-	// 825 bytes of a gzipped FileDescriptorProto
+	// 859 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x95, 0x51, 0x6f, 0xe2, 0x46,
-	0x10, 0xc7, 0x8f, 0x00, 0xc1, 0x0c, 0x09, 0xec, 0x6d, 0xae, 0xa9, 0x0f, 0xe9, 0x5a, 0x8a, 0xfa,
-	0x40, 0x5f, 0xa0, 0xa5, 0x7d, 0xe8, 0xc3, 0x49, 0x15, 0xc1, 0x1b, 0x62, 0x85, 0xd8, 0xdc, 0xda,
-	0x39, 0x1d, 0x7d, 0xb1, 0x1c, 0xbc, 0x67, 0xac, 0x1a, 0xaf, 0x6b, 0x2f, 0xa7, 0xf3, 0x67, 0xe8,
-	0x07, 0xeb, 0xd7, 0xaa, 0xd6, 0xc6, 0x84, 0x23, 0x8a, 0x4e, 0xea, 0x13, 0xfe, 0xcf, 0xfc, 0x66,
-	0xe6, 0x3f, 0xd6, 0xb2, 0x86, 0xd7, 0x31, 0x63, 0xc9, 0x68, 0xb5, 0x76, 0x83, 0x68, 0xc5, 0x3d,
-	0xe6, 0xa4, 0xeb, 0x60, 0x33, 0x8c, 0x13, 0x2e, 0x38, 0x3e, 0xcd, 0x7f, 0xd2, 0x6e, 0xf7, 0x08,
-	0x61, 0x9f, 0x58, 0x24, 0x0a, 0xa6, 0x7b, 0x91, 0xe7, 0xe2, 0x84, 0xc7, 0x3c, 0x75, 0xc3, 0x5d,
-	0xf0, 0x7b, 0x9f, 0x73, 0x3f, 0x64, 0xa3, 0x5c, 0x3d, 0x6c, 0x3f, 0x8e, 0x44, 0xb0, 0x61, 0xa9,
-	0x70, 0x37, 0x71, 0x01, 0xf4, 0xff, 0xa9, 0x03, 0x9a, 0x96, 0xfd, 0xee, 0x58, 0x9a, 0xba, 0x3e,
-	0xc3, 0xbf, 0x40, 0x4d, 0x64, 0x31, 0x53, 0x2b, 0xbd, 0xca, 0xa0, 0x3d, 0x7e, 0x53, 0xa0, 0xe9,
-	0xf0, 0x98, 0x1b, 0xda, 0x59, 0xcc, 0x68, 0x8e, 0xe2, 0xdf, 0xa1, 0xb9, 0x6f, 0xad, 0x9e, 0xf4,
-	0x2a, 0x83, 0xd6, 0xb8, 0x3b, 0x2c, 0x86, 0x0f, 0xcb, 0xe1, 0x43, 0xbb, 0x24, 0xe8, 0x23, 0x8c,
-	0x55, 0x68, 0xc4, 0x6e, 0x16, 0x72, 0xd7, 0x53, 0xab, 0xbd, 0xca, 0xe0, 0x8c, 0x96, 0x12, 0x63,
-	0xa8, 0x89, 0xcf, 0x81, 0xa7, 0xd6, 0x7a, 0x95, 0x41, 0x93, 0xe6, 0xcf, 0x78, 0x0c, 0x4a, 0xb9,
-	0xa2, 0x5a, 0xcf, 0xc7, 0x5c, 0x96, 0xf6, 0xac, 0xc0, 0x8f, 0x98, 0xb7, 0xd8, 0x65, 0xe9, 0x9e,
-	0xc3, 0x7f, 0x40, 0xe7, 0xe8, 0x95, 0xa9, 0xa7, 0x5f, 0x96, 0xee, 0x37, 0x23, 0x32, 0x4b, 0xdb,
-	0xab, 0x2f, 0x34, 0x7e, 0x03, 0xb0, 0x5a, 0xbb, 0x51, 0xc4, 0x42, 0x27, 0xf0, 0xd4, 0x46, 0x6e,
-	0xa7, 0xb9, 0x8b, 0xe8, 0x5e, 0xff, 0xdf, 0x13, 0xa8, 0xc9, 0x57, 0x81, 0xcf, 0xa1, 0x79, 0x6f,
-	0x68, 0xe4, 0x5a, 0x37, 0x88, 0x86, 0x5e, 0xe0, 0x33, 0x50, 0x28, 0x99, 0xe9, 0x96, 0x4d, 0x28,
-	0xaa, 0xe0, 0x36, 0x40, 0xa9, 0x88, 0x86, 0x4e, 0xb0, 0x02, 0x35, 0xdd, 0xd0, 0x6d, 0x54, 0xc5,
-	0x4d, 0xa8, 0x53, 0x32, 0xd1, 0x96, 0xa8, 0x86, 0x3b, 0xd0, 0xb2, 0xe9, 0xc4, 0xb0, 0x26, 0x53,
-	0x5b, 0x37, 0x0d, 0x54, 0x97, 0x2d, 0xa7, 0xe6, 0xdd, 0x62, 0x4e, 0x6c, 0xa2, 0xa1, 0x53, 0x89,
-	0x12, 0x4a, 0x4d, 0x8a, 0x1a, 0x32, 0x33, 0x23, 0xb6, 0x63, 0xd9, 0x13, 0x9b, 0x20, 0x45, 0xca,
-	0xc5, 0x7d, 0x29, 0x9b, 0x52, 0x6a, 0x64, 0xbe, 0x93, 0x80, 0x5f, 0x01, 0xd2, 0x8d, 0xf7, 0xe6,
-	0x2d, 0x71, 0xa6, 0x37, 0x13, 0xdd, 0x98, 0x9a, 0x1a, 0x41, 0xad, 0xc2, 0xa0, 0xb5, 0x30, 0x0d,
-	0x8b, 0xa0, 0x73, 0x7c, 0x09, 0x78, 0xdf, 0xd0, 0xb9, 0x5a, 0x3a, 0x74, 0x62, 0xcc, 0x08, 0x6a,
-	0xcb, 0x5a, 0x19, 0x7f, 0x77, 0x4f, 0xe8, 0xd2, 0xa1, 0xc4, 0xba, 0x9f, 0xdb, 0xa8, 0x23, 0xa3,
-	0x45, 0xa4, 0xe0, 0x0d, 0xf2, 0xc1, 0x46, 0x08, 0x7f, 0x03, 0x2f, 0x0f, 0xa3, 0xd3, 0xb9, 0x69,
-	0x11, 0xf4, 0x52, 0xba, 0xb9, 0x25, 0x64, 0x31, 0x99, 0xeb, 0xef, 0x09, 0xc2, 0xf8, 0x5b, 0xb8,
-	0x90, 0x1d, 0x6f, 0x74, 0xcb, 0x36, 0xe9, 0xd2, 0xb9, 0x36, 0xa9, 0x73, 0x4b, 0x96, 0xe8, 0xa2,
-	0xff, 0x16, 0x94, 0x19, 0x13, 0x96, 0x70, 0x05, 0xc3, 0x08, 0xaa, 0x7f, 0xb1, 0x2c, 0x3f, 0x83,
-	0x4d, 0x2a, 0x1f, 0xf1, 0x77, 0x00, 0x2b, 0x1e, 0x86, 0x6c, 0x25, 0x02, 0x1e, 0xe5, 0x87, 0xac,
-	0x49, 0x0f, 0x22, 0x7d, 0x0a, 0xca, 0x62, 0xfb, 0x6c, 0xf5, 0x2b, 0xa8, 0x7f, 0x72, 0xc3, 0x2d,
-	0xcb, 0x0b, 0xcf, 0x68, 0x21, 0x8e, 0x7a, 0x56, 0x9f, 0xf4, 0x7c, 0x0b, 0x8a, 0xc6, 0xc2, 0xff,
-	0xeb, 0x88, 0x41, 0xa7, 0xdc, 0xe7, 0x2a, 0xa3, 0x6e, 0xe4, 0x33, 0xdc, 0x05, 0x25, 0x15, 0x6e,
-	0x22, 0x6e, 0xf7, 0x9d, 0xf6, 0x1a, 0x5f, 0xc2, 0x29, 0x8b, 0x3c, 0x99, 0x29, 0x5a, 0xed, 0xd4,
-	0x57, 0x4d, 0x5e, 0x43, 0x7b, 0xc6, 0xc4, 0xbb, 0x2d, 0x4b, 0x32, 0xca, 0xd2, 0x6d, 0x28, 0xe4,
-	0xb2, 0x7f, 0x4b, 0xb9, 0x1b, 0x51, 0x88, 0xaf, 0xda, 0xfd, 0x11, 0xd0, 0x8c, 0x89, 0x9b, 0x20,
-	0x15, 0x3c, 0xc9, 0xae, 0x79, 0x22, 0x67, 0x3f, 0x59, 0xba, 0xdf, 0x83, 0x76, 0x3e, 0x2a, 0x5f,
-	0xcb, 0x60, 0x9f, 0x05, 0x6e, 0xc3, 0x49, 0xe0, 0xed, 0x90, 0x93, 0xc0, 0xeb, 0xff, 0x00, 0x9d,
-	0x47, 0x62, 0x1a, 0xf2, 0x94, 0x3d, 0x41, 0x7e, 0x03, 0x74, 0xe0, 0xf7, 0x2a, 0x13, 0x2c, 0xc5,
-	0x3d, 0x68, 0x25, 0x8f, 0x32, 0x87, 0xcf, 0xe8, 0x61, 0xa8, 0x1f, 0xc1, 0x79, 0x59, 0x15, 0xf3,
-	0x28, 0x65, 0x78, 0x0c, 0x8d, 0x22, 0x2f, 0xf1, 0xea, 0xa0, 0x35, 0x56, 0xcb, 0xbf, 0xf4, 0x71,
-	0x77, 0x5a, 0x82, 0xf8, 0x35, 0x28, 0x6b, 0x37, 0x75, 0x36, 0x3c, 0x29, 0xce, 0x82, 0x42, 0x1b,
-	0x6b, 0x37, 0xbd, 0xe3, 0x49, 0xe9, 0xb2, 0x5a, 0xba, 0x1c, 0x7f, 0x38, 0xb8, 0x1c, 0xad, 0x6d,
-	0x1c, 0xf3, 0x44, 0x60, 0x0d, 0x14, 0xca, 0xfc, 0x20, 0x15, 0x2c, 0xc1, 0xea, 0x73, 0x57, 0x63,
-	0xf7, 0xd9, 0x4c, 0xff, 0xc5, 0xa0, 0xf2, 0x73, 0xe5, 0xca, 0x84, 0x3e, 0x4f, 0xfc, 0xe1, 0x3a,
-	0x8b, 0x59, 0x12, 0x32, 0xcf, 0x67, 0xc9, 0xf0, 0xa3, 0xfb, 0x90, 0x04, 0xab, 0xb2, 0x4e, 0xde,
-	0xe6, 0x7f, 0xfe, 0xe4, 0x07, 0x62, 0xbd, 0x7d, 0x18, 0xae, 0xf8, 0x66, 0x74, 0x80, 0x8e, 0x0a,
-	0xb4, 0xb8, 0xd5, 0xd3, 0x91, 0x44, 0x1f, 0x8a, 0x4f, 0xc4, 0xaf, 0xff, 0x05, 0x00, 0x00, 0xff,
-	0xff, 0x18, 0x6a, 0x79, 0xe7, 0x46, 0x06, 0x00, 0x00,
+	0x10, 0xc7, 0x8f, 0x40, 0x12, 0x33, 0x10, 0xd8, 0xdb, 0xe4, 0x52, 0x07, 0xe9, 0x5a, 0x0e, 0xf5,
+	0x81, 0xbe, 0x40, 0x4b, 0xfb, 0xd0, 0x87, 0x93, 0x2a, 0x07, 0x6f, 0xc0, 0x0a, 0xb1, 0xb9, 0xb5,
+	0x13, 0x1d, 0x7d, 0xb1, 0x1c, 0xbc, 0x07, 0x56, 0x8d, 0xd7, 0xb5, 0x97, 0xd3, 0xf1, 0x11, 0xfb,
+	0x35, 0xfa, 0x49, 0xaa, 0xb5, 0x31, 0xe1, 0x88, 0xa2, 0x93, 0xfa, 0x04, 0xff, 0x99, 0xdf, 0xfc,
+	0x67, 0xc6, 0x5a, 0xed, 0xc2, 0x55, 0xcc, 0x58, 0xd2, 0x9f, 0x2f, 0xbd, 0x20, 0x9a, 0x73, 0x9f,
+	0xb9, 0xe9, 0x32, 0x58, 0xf5, 0xe2, 0x84, 0x0b, 0x8e, 0x4f, 0xb2, 0x9f, 0xb4, 0xd5, 0x3a, 0x40,
+	0xd8, 0x67, 0x16, 0x89, 0x9c, 0x69, 0x9d, 0x67, 0xb9, 0x38, 0xe1, 0x31, 0x4f, 0xbd, 0x70, 0x1b,
+	0xfc, 0x61, 0xc1, 0xf9, 0x22, 0x64, 0xfd, 0x4c, 0x3d, 0xae, 0x3f, 0xf5, 0x45, 0xb0, 0x62, 0xa9,
+	0xf0, 0x56, 0x71, 0x0e, 0x74, 0xfe, 0x39, 0x06, 0x34, 0x2c, 0xfc, 0xee, 0x58, 0x9a, 0x7a, 0x0b,
+	0x86, 0x7f, 0x81, 0x8a, 0xd8, 0xc4, 0x4c, 0x2d, 0xb5, 0x4b, 0xdd, 0xc6, 0xe0, 0x6d, 0x8e, 0xa6,
+	0xbd, 0x43, 0xae, 0xe7, 0x6c, 0x62, 0x46, 0x33, 0x14, 0xff, 0x0e, 0xd5, 0x9d, 0xb5, 0x7a, 0xd4,
+	0x2e, 0x75, 0x6b, 0x83, 0x56, 0x2f, 0x6f, 0xde, 0x2b, 0x9a, 0xf7, 0x9c, 0x82, 0xa0, 0x4f, 0x30,
+	0x56, 0xe1, 0x34, 0xf6, 0x36, 0x21, 0xf7, 0x7c, 0xb5, 0xdc, 0x2e, 0x75, 0xeb, 0xb4, 0x90, 0x18,
+	0x43, 0x45, 0x7c, 0x09, 0x7c, 0xb5, 0xd2, 0x2e, 0x75, 0xab, 0x34, 0xfb, 0x8f, 0x07, 0xa0, 0x14,
+	0x2b, 0xaa, 0xc7, 0x59, 0x9b, 0xcb, 0x62, 0x3c, 0x3b, 0x58, 0x44, 0xcc, 0x9f, 0x6e, 0xb3, 0x74,
+	0xc7, 0xe1, 0x3f, 0xa0, 0x79, 0xf0, 0xc9, 0xd4, 0x93, 0xaf, 0x4b, 0x77, 0x9b, 0x11, 0x99, 0xa5,
+	0x8d, 0xf9, 0x57, 0x1a, 0xbf, 0x05, 0x98, 0x2f, 0xbd, 0x28, 0x62, 0xa1, 0x1b, 0xf8, 0xea, 0x69,
+	0x36, 0x4e, 0x75, 0x1b, 0x31, 0x7c, 0xfc, 0x0e, 0xea, 0x22, 0xf1, 0xe6, 0xcc, 0x8d, 0xbd, 0x44,
+	0x9a, 0x2b, 0x19, 0x50, 0xcb, 0x62, 0xd3, 0x2c, 0xd4, 0xf9, 0xf7, 0x08, 0x2a, 0xf2, 0x6b, 0xe1,
+	0x33, 0xa8, 0xde, 0x9b, 0x3a, 0xb9, 0x31, 0x4c, 0xa2, 0xa3, 0x57, 0xb8, 0x0e, 0x0a, 0x25, 0x23,
+	0xc3, 0x76, 0x08, 0x45, 0x25, 0xdc, 0x00, 0x28, 0x14, 0xd1, 0xd1, 0x11, 0x56, 0xa0, 0x62, 0x98,
+	0x86, 0x83, 0xca, 0xb8, 0x0a, 0xc7, 0x94, 0x68, 0xfa, 0x0c, 0x55, 0x70, 0x13, 0x6a, 0x0e, 0xd5,
+	0x4c, 0x5b, 0x1b, 0x3a, 0x86, 0x65, 0xa2, 0x63, 0x69, 0x39, 0xb4, 0xee, 0xa6, 0x13, 0xe2, 0x10,
+	0x1d, 0x9d, 0x48, 0x94, 0x50, 0x6a, 0x51, 0x74, 0x2a, 0x33, 0x23, 0xe2, 0xb8, 0xb6, 0xa3, 0x39,
+	0x04, 0x29, 0x52, 0x4e, 0xef, 0x0b, 0x59, 0x95, 0x52, 0x27, 0x93, 0xad, 0x04, 0x7c, 0x01, 0xc8,
+	0x30, 0x1f, 0xac, 0x5b, 0xe2, 0x0e, 0xc7, 0x9a, 0x61, 0x0e, 0x2d, 0x9d, 0xa0, 0x5a, 0x3e, 0xa0,
+	0x3d, 0xb5, 0x4c, 0x9b, 0xa0, 0x33, 0x7c, 0x09, 0x78, 0x67, 0xe8, 0x5e, 0xcf, 0x5c, 0xaa, 0x99,
+	0x23, 0x82, 0x1a, 0xb2, 0x56, 0xc6, 0x3f, 0xdc, 0x13, 0x3a, 0x73, 0x29, 0xb1, 0xef, 0x27, 0x0e,
+	0x6a, 0xca, 0x68, 0x1e, 0xc9, 0x79, 0x93, 0x7c, 0x74, 0x10, 0xc2, 0x6f, 0xe0, 0xf5, 0x7e, 0x74,
+	0x38, 0xb1, 0x6c, 0x82, 0x5e, 0xcb, 0x69, 0x6e, 0x09, 0x99, 0x6a, 0x13, 0xe3, 0x81, 0x20, 0x8c,
+	0xbf, 0x83, 0x73, 0xe9, 0x38, 0x36, 0x6c, 0xc7, 0xa2, 0x33, 0xf7, 0xc6, 0xa2, 0xee, 0x2d, 0x99,
+	0xa1, 0x73, 0x7c, 0x05, 0x6f, 0x64, 0x62, 0x4a, 0x8d, 0x07, 0x59, 0xae, 0x6b, 0x8e, 0xe6, 0x8e,
+	0x35, 0x7b, 0x8c, 0x2e, 0x3a, 0xef, 0x41, 0x19, 0x31, 0x61, 0x0b, 0x4f, 0x30, 0x8c, 0xa0, 0xfc,
+	0x17, 0xdb, 0x64, 0x27, 0xb8, 0x4a, 0xe5, 0x5f, 0xfc, 0x3d, 0xc0, 0x9c, 0x87, 0x21, 0x9b, 0x8b,
+	0x80, 0x47, 0xd9, 0x11, 0xad, 0xd2, 0xbd, 0x48, 0x87, 0x82, 0x32, 0x5d, 0xbf, 0x58, 0x7d, 0x01,
+	0xc7, 0x9f, 0xbd, 0x70, 0xcd, 0xb2, 0xc2, 0x3a, 0xcd, 0xc5, 0x81, 0x67, 0xf9, 0x99, 0xe7, 0x7b,
+	0x50, 0x74, 0x16, 0xfe, 0xdf, 0x89, 0x18, 0x34, 0x8b, 0x7d, 0xae, 0x37, 0xd4, 0x8b, 0x16, 0x0c,
+	0xb7, 0x40, 0x49, 0x85, 0x97, 0x88, 0xdb, 0x9d, 0xd3, 0x4e, 0xe3, 0x4b, 0x38, 0x61, 0x91, 0x2f,
+	0x33, 0xb9, 0xd5, 0x56, 0x7d, 0x73, 0xc8, 0x1b, 0x68, 0x8c, 0x98, 0xf8, 0xb0, 0x66, 0xc9, 0x86,
+	0xb2, 0x74, 0x1d, 0x0a, 0xb9, 0xec, 0xdf, 0x52, 0x6e, 0x5b, 0xe4, 0xe2, 0x9b, 0xe3, 0xfe, 0x08,
+	0x68, 0xc4, 0xc4, 0x38, 0x48, 0x05, 0x4f, 0x36, 0x37, 0x3c, 0x91, 0xbd, 0x9f, 0x2d, 0xdd, 0x69,
+	0x43, 0x23, 0x6b, 0x95, 0xad, 0x65, 0xb2, 0x2f, 0x02, 0x37, 0xe0, 0x28, 0xf0, 0xb7, 0xc8, 0x51,
+	0xe0, 0x77, 0xde, 0x41, 0xf3, 0x89, 0x18, 0x86, 0x3c, 0x65, 0xcf, 0x90, 0xdf, 0x00, 0xed, 0xcd,
+	0x7b, 0xbd, 0x11, 0x2c, 0xc5, 0x6d, 0xa8, 0x25, 0x4f, 0x32, 0x83, 0xeb, 0x74, 0x3f, 0xd4, 0x89,
+	0xe0, 0xac, 0xa8, 0x8a, 0x79, 0x94, 0x32, 0x3c, 0x80, 0xd3, 0x3c, 0x2f, 0xf1, 0x72, 0xb7, 0x36,
+	0x50, 0x8b, 0x0b, 0xe1, 0xd0, 0x9d, 0x16, 0x20, 0xbe, 0x02, 0x65, 0xe9, 0xa5, 0xee, 0x8a, 0x27,
+	0xf9, 0x59, 0x50, 0xe8, 0xe9, 0xd2, 0x4b, 0xef, 0x78, 0x52, 0x4c, 0x59, 0x2e, 0xa6, 0x1c, 0x7c,
+	0xdc, 0xbb, 0x5a, 0xed, 0x75, 0x1c, 0xf3, 0x44, 0x60, 0x1d, 0x14, 0xca, 0x16, 0x41, 0x2a, 0x58,
+	0x82, 0xd5, 0x97, 0x2e, 0xd6, 0xd6, 0x8b, 0x99, 0xce, 0xab, 0x6e, 0xe9, 0xe7, 0xd2, 0xb5, 0x05,
+	0x1d, 0x9e, 0x2c, 0x7a, 0xcb, 0x4d, 0xcc, 0x92, 0x90, 0xf9, 0x0b, 0x96, 0xf4, 0x3e, 0x79, 0x8f,
+	0x49, 0x30, 0x2f, 0xea, 0xe4, 0x5b, 0xf0, 0xe7, 0x4f, 0x8b, 0x40, 0x2c, 0xd7, 0x8f, 0xbd, 0x39,
+	0x5f, 0xf5, 0xf7, 0xd0, 0x7e, 0x8e, 0xe6, 0x6f, 0x42, 0xda, 0x97, 0xe8, 0x63, 0xfe, 0xc0, 0xfc,
+	0xfa, 0xdf, 0x00, 0xdc, 0xd3, 0x55, 0xe8, 0x84, 0x06, 0x00, 0x00,
 }
@@ -101,6 +101,8 @@ func (ccv *DynamicApplicationConfigValue) VariablyOpaqueFieldProto(name string)
 	switch ccv.name {
 	case "Capabilities":
 		return &common.Capabilities{}, nil
+	case "ACLs":
+		return &ACLs{}, nil
 	default:
 		return nil, fmt.Errorf("Unknown Application ConfigValue name: %s", ccv.name)
 	}
@@ -885,6 +885,291 @@ func _DeliverResponse_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+// ChaincodeEventsRequest is carried in the extension of the channel header of
+// the DELIVER_SEEK_INFO envelope sent to the ChaincodeEvents service, and selects
+// the events that are delivered from the blocks the SeekInfo of the envelope refers to
+type ChaincodeEventsRequest struct {
+	// chaincode_id is the name of the chaincode whose events are delivered
+	ChaincodeId string `protobuf:"bytes,1,opt,name=chaincode_id,json=chaincodeId" json:"chaincode_id,omitempty"`
+	// event_name_filter is a regular expression that the names of the delivered
+	// events must match, all the events of the chaincode are delivered if it is empty
+	EventNameFilter string `protobuf:"bytes,2,opt,name=event_name_filter,json=eventNameFilter" json:"event_name_filter,omitempty"`
+	// include_invalid makes events of invalid transactions be delivered as well
+	IncludeInvalid bool `protobuf:"varint,3,opt,name=include_invalid,json=includeInvalid" json:"include_invalid,omitempty"`
+	// checkpoint is the position to resume delivering events from,
+	// events that precede it are not delivered
+	Checkpoint *ChaincodeEventsCheckpoint `protobuf:"bytes,4,opt,name=checkpoint" json:"checkpoint,omitempty"`
+}
+
+func (m *ChaincodeEventsRequest) Reset()                    { *m = ChaincodeEventsRequest{} }
+func (m *ChaincodeEventsRequest) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeEventsRequest) ProtoMessage()               {}
+func (*ChaincodeEventsRequest) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{12} }
+
+func (m *ChaincodeEventsRequest) GetChaincodeId() string {
+	if m != nil {
+		return m.ChaincodeId
+	}
+	return ""
+}
+
+func (m *ChaincodeEventsRequest) GetEventNameFilter() string {
+	if m != nil {
+		return m.EventNameFilter
+	}
+	return ""
+}
+
+func (m *ChaincodeEventsRequest) GetIncludeInvalid() bool {
+	if m != nil {
+		return m.IncludeInvalid
+	}
+	return false
+}
+
+func (m *ChaincodeEventsRequest) GetCheckpoint() *ChaincodeEventsCheckpoint {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return nil
+}
+
+// ChaincodeEventsCheckpoint is a position in the ledger from which events can be
+// delivered again without loss or duplicates, after a client reconnects
+type ChaincodeEventsCheckpoint struct {
+	BlockNumber uint64 `protobuf:"varint,1,opt,name=block_number,json=blockNumber" json:"block_number,omitempty"`
+	// transaction_index is the index in the block of the next transaction whose events are delivered
+	TransactionIndex uint64 `protobuf:"varint,2,opt,name=transaction_index,json=transactionIndex" json:"transaction_index,omitempty"`
+}
+
+func (m *ChaincodeEventsCheckpoint) Reset()                    { *m = ChaincodeEventsCheckpoint{} }
+func (m *ChaincodeEventsCheckpoint) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeEventsCheckpoint) ProtoMessage()               {}
+func (*ChaincodeEventsCheckpoint) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{13} }
+
+func (m *ChaincodeEventsCheckpoint) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *ChaincodeEventsCheckpoint) GetTransactionIndex() uint64 {
+	if m != nil {
+		return m.TransactionIndex
+	}
+	return 0
+}
+
+// ChaincodeEventInfo is a chaincode event along with the transaction that emitted it
+type ChaincodeEventInfo struct {
+	ChaincodeEvent   *ChaincodeEvent  `protobuf:"bytes,1,opt,name=chaincode_event,json=chaincodeEvent" json:"chaincode_event,omitempty"`
+	BlockNumber      uint64           `protobuf:"varint,2,opt,name=block_number,json=blockNumber" json:"block_number,omitempty"`
+	TransactionIndex uint64           `protobuf:"varint,3,opt,name=transaction_index,json=transactionIndex" json:"transaction_index,omitempty"`
+	TxValidationCode TxValidationCode `protobuf:"varint,4,opt,name=tx_validation_code,json=txValidationCode,enum=protos.TxValidationCode" json:"tx_validation_code,omitempty"`
+	// checkpoint is the position that follows the event
+	Checkpoint *ChaincodeEventsCheckpoint `protobuf:"bytes,5,opt,name=checkpoint" json:"checkpoint,omitempty"`
+}
+
+func (m *ChaincodeEventInfo) Reset()                    { *m = ChaincodeEventInfo{} }
+func (m *ChaincodeEventInfo) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeEventInfo) ProtoMessage()               {}
+func (*ChaincodeEventInfo) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{14} }
+
+func (m *ChaincodeEventInfo) GetChaincodeEvent() *ChaincodeEvent {
+	if m != nil {
+		return m.ChaincodeEvent
+	}
+	return nil
+}
+
+func (m *ChaincodeEventInfo) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *ChaincodeEventInfo) GetTransactionIndex() uint64 {
+	if m != nil {
+		return m.TransactionIndex
+	}
+	return 0
+}
+
+func (m *ChaincodeEventInfo) GetTxValidationCode() TxValidationCode {
+	if m != nil {
+		return m.TxValidationCode
+	}
+	return TxValidationCode_VALID
+}
+
+func (m *ChaincodeEventInfo) GetCheckpoint() *ChaincodeEventsCheckpoint {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return nil
+}
+
+// ChaincodeEventsBlock holds the chaincode events of a block that match a ChaincodeEventsRequest
+type ChaincodeEventsBlock struct {
+	ChannelId   string                `protobuf:"bytes,1,opt,name=channel_id,json=channelId" json:"channel_id,omitempty"`
+	BlockNumber uint64                `protobuf:"varint,2,opt,name=block_number,json=blockNumber" json:"block_number,omitempty"`
+	Events      []*ChaincodeEventInfo `protobuf:"bytes,3,rep,name=events" json:"events,omitempty"`
+	// checkpoint is the position that follows the block
+	Checkpoint *ChaincodeEventsCheckpoint `protobuf:"bytes,4,opt,name=checkpoint" json:"checkpoint,omitempty"`
+}
+
+func (m *ChaincodeEventsBlock) Reset()                    { *m = ChaincodeEventsBlock{} }
+func (m *ChaincodeEventsBlock) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeEventsBlock) ProtoMessage()               {}
+func (*ChaincodeEventsBlock) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{15} }
+
+func (m *ChaincodeEventsBlock) GetChannelId() string {
+	if m != nil {
+		return m.ChannelId
+	}
+	return ""
+}
+
+func (m *ChaincodeEventsBlock) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *ChaincodeEventsBlock) GetEvents() []*ChaincodeEventInfo {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *ChaincodeEventsBlock) GetCheckpoint() *ChaincodeEventsCheckpoint {
+	if m != nil {
+		return m.Checkpoint
+	}
+	return nil
+}
+
+// ChaincodeEventsResponse
+type ChaincodeEventsResponse struct {
+	// Types that are valid to be assigned to Type:
+	//	*ChaincodeEventsResponse_Status
+	//	*ChaincodeEventsResponse_ChaincodeEvents
+	Type isChaincodeEventsResponse_Type `protobuf_oneof:"Type"`
+}
+
+func (m *ChaincodeEventsResponse) Reset()                    { *m = ChaincodeEventsResponse{} }
+func (m *ChaincodeEventsResponse) String() string            { return proto.CompactTextString(m) }
+func (*ChaincodeEventsResponse) ProtoMessage()               {}
+func (*ChaincodeEventsResponse) Descriptor() ([]byte, []int) { return fileDescriptor5, []int{16} }
+
+type isChaincodeEventsResponse_Type interface {
+	isChaincodeEventsResponse_Type()
+}
+
+type ChaincodeEventsResponse_Status struct {
+	Status common.Status `protobuf:"varint,1,opt,name=status,enum=common.Status,oneof"`
+}
+type ChaincodeEventsResponse_ChaincodeEvents struct {
+	ChaincodeEvents *ChaincodeEventsBlock `protobuf:"bytes,2,opt,name=chaincode_events,json=chaincodeEvents,oneof"`
+}
+
+func (*ChaincodeEventsResponse_Status) isChaincodeEventsResponse_Type()          {}
+func (*ChaincodeEventsResponse_ChaincodeEvents) isChaincodeEventsResponse_Type() {}
+
+func (m *ChaincodeEventsResponse) GetType() isChaincodeEventsResponse_Type {
+	if m != nil {
+		return m.Type
+	}
+	return nil
+}
+
+func (m *ChaincodeEventsResponse) GetStatus() common.Status {
+	if x, ok := m.GetType().(*ChaincodeEventsResponse_Status); ok {
+		return x.Status
+	}
+	return common.Status_UNKNOWN
+}
+
+func (m *ChaincodeEventsResponse) GetChaincodeEvents() *ChaincodeEventsBlock {
+	if x, ok := m.GetType().(*ChaincodeEventsResponse_ChaincodeEvents); ok {
+		return x.ChaincodeEvents
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*ChaincodeEventsResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _ChaincodeEventsResponse_OneofMarshaler, _ChaincodeEventsResponse_OneofUnmarshaler, _ChaincodeEventsResponse_OneofSizer, []interface{}{
+		(*ChaincodeEventsResponse_Status)(nil),
+		(*ChaincodeEventsResponse_ChaincodeEvents)(nil),
+	}
+}
+
+func _ChaincodeEventsResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*ChaincodeEventsResponse)
+	// Type
+	switch x := m.Type.(type) {
+	case *ChaincodeEventsResponse_Status:
+		b.EncodeVarint(1<<3 | proto.WireVarint)
+		b.EncodeVarint(uint64(x.Status))
+	case *ChaincodeEventsResponse_ChaincodeEvents:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ChaincodeEvents); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("ChaincodeEventsResponse.Type has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _ChaincodeEventsResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*ChaincodeEventsResponse)
+	switch tag {
+	case 1: // Type.status
+		if wire != proto.WireVarint {
+			return true, proto.ErrInternalBadWireType
+		}
+		x, err := b.DecodeVarint()
+		m.Type = &ChaincodeEventsResponse_Status{common.Status(x)}
+		return true, err
+	case 2: // Type.chaincode_events
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ChaincodeEventsBlock)
+		err := b.DecodeMessage(msg)
+		m.Type = &ChaincodeEventsResponse_ChaincodeEvents{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _ChaincodeEventsResponse_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*ChaincodeEventsResponse)
+	// Type
+	switch x := m.Type.(type) {
+	case *ChaincodeEventsResponse_Status:
+		n += proto.SizeVarint(1<<3 | proto.WireVarint)
+		n += proto.SizeVarint(uint64(x.Status))
+	case *ChaincodeEventsResponse_ChaincodeEvents:
+		s := proto.Size(x.ChaincodeEvents)
+		n += proto.SizeVarint(2<<3 | proto.WireBytes)
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
 func init() {
 	proto.RegisterType((*ChaincodeReg)(nil), "protos.ChaincodeReg")
 	proto.RegisterType((*Interest)(nil), "protos.Interest")
@@ -898,6 +1183,11 @@ func init() {
 	proto.RegisterType((*SignedEvent)(nil), "protos.SignedEvent")
 	proto.RegisterType((*Event)(nil), "protos.Event")
 	proto.RegisterType((*DeliverResponse)(nil), "protos.DeliverResponse")
+	proto.RegisterType((*ChaincodeEventsRequest)(nil), "protos.ChaincodeEventsRequest")
+	proto.RegisterType((*ChaincodeEventsCheckpoint)(nil), "protos.ChaincodeEventsCheckpoint")
+	proto.RegisterType((*ChaincodeEventInfo)(nil), "protos.ChaincodeEventInfo")
+	proto.RegisterType((*ChaincodeEventsBlock)(nil), "protos.ChaincodeEventsBlock")
+	proto.RegisterType((*ChaincodeEventsResponse)(nil), "protos.ChaincodeEventsResponse")
 	proto.RegisterEnum("protos.EventType", EventType_name, EventType_value)
 }
 
@@ -1176,72 +1466,188 @@ var _Deliver_serviceDesc = grpc.ServiceDesc{
 	Metadata: "peer/events.proto",
 }
 
+// Client API for ChaincodeEvents service
+
+type ChaincodeEventsClient interface {
+	// deliver first requires an Envelope of type DELIVER_SEEK_INFO with Payload data as a marshaled orderer.SeekInfo message,
+	// and a marshaled ChaincodeEventsRequest as the extension of its channel header,
+	// then a stream of the chaincode events of the blocks that contain matching events is received.
+	Deliver(ctx context.Context, opts ...grpc.CallOption) (ChaincodeEvents_DeliverClient, error)
+}
+
+type chaincodeEventsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewChaincodeEventsClient(cc *grpc.ClientConn) ChaincodeEventsClient {
+	return &chaincodeEventsClient{cc}
+}
+
+func (c *chaincodeEventsClient) Deliver(ctx context.Context, opts ...grpc.CallOption) (ChaincodeEvents_DeliverClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ChaincodeEvents_serviceDesc.Streams[0], c.cc, "/protos.ChaincodeEvents/Deliver", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &chaincodeEventsDeliverClient{stream}
+	return x, nil
+}
+
+type ChaincodeEvents_DeliverClient interface {
+	Send(*common.Envelope) error
+	Recv() (*ChaincodeEventsResponse, error)
+	grpc.ClientStream
+}
+
+type chaincodeEventsDeliverClient struct {
+	grpc.ClientStream
+}
+
+func (x *chaincodeEventsDeliverClient) Send(m *common.Envelope) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *chaincodeEventsDeliverClient) Recv() (*ChaincodeEventsResponse, error) {
+	m := new(ChaincodeEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for ChaincodeEvents service
+
+type ChaincodeEventsServer interface {
+	// deliver first requires an Envelope of type DELIVER_SEEK_INFO with Payload data as a marshaled orderer.SeekInfo message,
+	// and a marshaled ChaincodeEventsRequest as the extension of its channel header,
+	// then a stream of the chaincode events of the blocks that contain matching events is received.
+	Deliver(ChaincodeEvents_DeliverServer) error
+}
+
+func RegisterChaincodeEventsServer(s *grpc.Server, srv ChaincodeEventsServer) {
+	s.RegisterService(&_ChaincodeEvents_serviceDesc, srv)
+}
+
+func _ChaincodeEvents_Deliver_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ChaincodeEventsServer).Deliver(&chaincodeEventsDeliverServer{stream})
+}
+
+type ChaincodeEvents_DeliverServer interface {
+	Send(*ChaincodeEventsResponse) error
+	Recv() (*common.Envelope, error)
+	grpc.ServerStream
+}
+
+type chaincodeEventsDeliverServer struct {
+	grpc.ServerStream
+}
+
+func (x *chaincodeEventsDeliverServer) Send(m *ChaincodeEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *chaincodeEventsDeliverServer) Recv() (*common.Envelope, error) {
+	m := new(common.Envelope)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _ChaincodeEvents_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "protos.ChaincodeEvents",
+	HandlerType: (*ChaincodeEventsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Deliver",
+			Handler:       _ChaincodeEvents_Deliver_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "peer/events.proto",
+}
+
 func init() { proto.RegisterFile("peer/events.proto", fileDescriptor5) }
 
 var fileDescriptor5 = []byte{
-	// 1009 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xdd, 0x72, 0xdb, 0x44,
-	0x14, 0xb6, 0x62, 0xc7, 0xb1, 0x8e, 0xe3, 0xd4, 0xd9, 0xb4, 0xa9, 0xc6, 0x05, 0x5a, 0xc4, 0xc0,
-	0x04, 0x2e, 0xec, 0x60, 0x3a, 0x0c, 0xd3, 0x0b, 0x98, 0xf8, 0x27, 0xc8, 0x34, 0x4d, 0x32, 0x1b,
-	0x87, 0x8b, 0x5e, 0xa0, 0x59, 0xcb, 0x6b, 0x59, 0xad, 0x2c, 0x79, 0x76, 0xd7, 0x99, 0xe4, 0x11,
-	0x78, 0x03, 0xde, 0x80, 0x19, 0x5e, 0x85, 0x17, 0xe2, 0x92, 0xd1, 0x6a, 0x57, 0x52, 0x6c, 0xda,
-	0x21, 0x57, 0xf6, 0xf9, 0xfb, 0xf6, 0xfc, 0x7c, 0x67, 0x57, 0xb0, 0xbf, 0xa4, 0x94, 0x75, 0xe8,
-	0x0d, 0x8d, 0x04, 0x6f, 0x2f, 0x59, 0x2c, 0x62, 0x54, 0x95, 0x3f, 0xbc, 0x75, 0xe0, 0xc5, 0x8b,
-	0x45, 0x1c, 0x75, 0xd2, 0x9f, 0xd4, 0xd8, 0x7a, 0xee, 0xc7, 0xb1, 0x1f, 0xd2, 0x8e, 0x94, 0x26,
-	0xab, 0x59, 0x47, 0x04, 0x0b, 0xca, 0x05, 0x59, 0x2c, 0x95, 0x43, 0x4b, 0x02, 0x7a, 0x73, 0x12,
-	0x44, 0x5e, 0x3c, 0xa5, 0xae, 0x84, 0x56, 0xb6, 0x43, 0x69, 0x13, 0x8c, 0x44, 0x9c, 0x78, 0x22,
-	0xd0, 0xa0, 0xf6, 0x25, 0xec, 0xf6, 0x75, 0x00, 0xa6, 0x3e, 0xfa, 0x1c, 0x76, 0x73, 0x80, 0x60,
-	0x6a, 0x19, 0x2f, 0x8c, 0x23, 0x13, 0xd7, 0x33, 0xdd, 0x68, 0x8a, 0x3e, 0x05, 0x90, 0xc8, 0x6e,
-	0x44, 0x16, 0xd4, 0xda, 0x92, 0x0e, 0xa6, 0xd4, 0x9c, 0x93, 0x05, 0xb5, 0xff, 0x34, 0xa0, 0x36,
-	0x8a, 0x04, 0x65, 0x94, 0x0b, 0x74, 0xac, 0x7d, 0xc5, 0xdd, 0x92, 0x4a, 0xb0, 0xbd, 0xee, 0x7e,
-	0x7a, 0x34, 0x6f, 0x0f, 0x13, 0xcb, 0xf8, 0x6e, 0x49, 0x55, 0x78, 0xf2, 0x17, 0x0d, 0x00, 0xe5,
-	0x09, 0x30, 0xea, 0xbb, 0x41, 0x34, 0x8b, 0xe5, 0x29, 0xf5, 0xee, 0x63, 0x1d, 0x59, 0x4c, 0xd9,
-	0x29, 0xe1, 0xa6, 0x57, 0x90, 0x47, 0xd1, 0x2c, 0x46, 0x16, 0xec, 0x48, 0xdd, 0x68, 0x60, 0x95,
-	0x65, 0x82, 0x5a, 0xec, 0x99, 0xb0, 0xa3, 0x9c, 0xec, 0x97, 0x50, 0xc3, 0xd4, 0x0f, 0xb8, 0xa0,
-	0x0c, 0x1d, 0x41, 0x35, 0x9d, 0x84, 0x65, 0xbc, 0x28, 0x1f, 0xd5, 0xbb, 0x4d, 0x7d, 0x94, 0x2e,
-	0x05, 0x2b, 0xbb, 0xfd, 0x06, 0x4c, 0x4c, 0xdf, 0x51, 0xd9, 0x44, 0xf4, 0x05, 0x6c, 0x89, 0x5b,
-	0x59, 0x57, 0xbd, 0x7b, 0xa0, 0x43, 0xc6, 0x79, 0x97, 0xf1, 0x96, 0xb8, 0x45, 0xcf, 0xc0, 0xa4,
-	0x8c, 0xc5, 0xcc, 0x5d, 0x70, 0x5f, 0xf5, 0xab, 0x26, 0x15, 0x6f, 0xb8, 0x6f, 0x7f, 0x0f, 0x70,
-	0x1d, 0xb1, 0x87, 0xa7, 0xf1, 0x87, 0x01, 0x8d, 0xd3, 0x20, 0x4c, 0xb4, 0xd3, 0x5e, 0x18, 0x7b,
-	0xef, 0x93, 0xb9, 0x78, 0x73, 0x12, 0x45, 0x34, 0xcc, 0x07, 0x67, 0x2a, 0xcd, 0x68, 0x8a, 0x0e,
-	0xa1, 0x1a, 0xad, 0x16, 0x13, 0xca, 0x64, 0x0a, 0x15, 0xac, 0x24, 0x74, 0x09, 0x4f, 0x66, 0x0a,
-	0xc7, 0x2d, 0xf0, 0x83, 0x5b, 0x15, 0x99, 0xc1, 0x33, 0x9d, 0x81, 0x3e, 0xac, 0x58, 0xdd, 0xe3,
-	0xd9, 0xa6, 0x92, 0xdb, 0xff, 0x18, 0x70, 0xf0, 0x1f, 0xde, 0x08, 0x41, 0x45, 0xdc, 0x66, 0xa9,
-	0xc9, 0xff, 0xe8, 0x2b, 0xa8, 0x48, 0x6a, 0x6c, 0x49, 0x6a, 0xa0, 0xb6, 0x62, 0xbc, 0x43, 0xc9,
-	0x94, 0x32, 0xc9, 0x0d, 0x69, 0x47, 0xa7, 0x80, 0xc4, 0xad, 0x7b, 0x43, 0xc2, 0x60, 0x4a, 0x12,
-	0x30, 0x37, 0x99, 0xb6, 0x9c, 0xed, 0x5e, 0xd7, 0xca, 0x1a, 0x7f, 0xfb, 0x6b, 0xe6, 0xd0, 0x4f,
-	0xd8, 0xd0, 0x14, 0x6b, 0x1a, 0x74, 0x0d, 0x07, 0x85, 0x22, 0xdd, 0xbc, 0xd6, 0x64, 0x82, 0xf6,
-	0x47, 0x6a, 0x3d, 0x49, 0x3d, 0x9d, 0x12, 0x46, 0x62, 0x43, 0xdb, 0xab, 0x42, 0x65, 0x40, 0x04,
-	0xb1, 0xdf, 0x41, 0xeb, 0xc3, 0xb1, 0xe8, 0x0c, 0xf6, 0x73, 0x6e, 0xeb, 0xa3, 0xd3, 0x41, 0x3f,
-	0x5f, 0x3f, 0x3a, 0xa3, 0x78, 0x1a, 0x5c, 0xe0, 0xb8, 0x42, 0xb3, 0xdf, 0xc2, 0xd3, 0x0f, 0x38,
-	0xa3, 0x9f, 0xe0, 0xd1, 0xda, 0x35, 0xa0, 0x38, 0x7a, 0xb8, 0xb1, 0x41, 0x72, 0x09, 0xf1, 0x9e,
-	0x77, 0x4f, 0xb6, 0x5f, 0x43, 0xfd, 0x2a, 0xf0, 0x23, 0x3a, 0x95, 0x22, 0xfa, 0x04, 0x4c, 0x1e,
-	0xf8, 0x11, 0x11, 0x2b, 0x96, 0x6e, 0xf1, 0x2e, 0xce, 0x15, 0xe8, 0x33, 0xb5, 0xe4, 0xbd, 0x3b,
-	0x41, 0xb9, 0x9c, 0xe4, 0x2e, 0x2e, 0x68, 0xec, 0xbf, 0xcb, 0xb0, 0x9d, 0xe2, 0xb4, 0xa1, 0xa6,
-	0xa9, 0xae, 0x12, 0xca, 0x08, 0xae, 0x37, 0xd1, 0x29, 0xe1, 0xcc, 0x07, 0x7d, 0x09, 0xdb, 0x93,
-	0x84, 0xdb, 0x6a, 0xff, 0x1b, 0x9a, 0x1e, 0x92, 0xf0, 0x4e, 0x09, 0xa7, 0x56, 0x74, 0xb2, 0x59,
-	0x6e, 0xf9, 0x63, 0xe5, 0x3a, 0xa5, 0xf5, 0x82, 0xd1, 0xb7, 0x60, 0x32, 0xbd, 0xd5, 0x8a, 0x0d,
-	0xfb, 0x79, 0x6a, 0xca, 0xe0, 0x94, 0x70, 0xee, 0x85, 0x5e, 0x02, 0xac, 0xb2, 0xcd, 0xb5, 0xb6,
-	0x65, 0x0c, 0xd2, 0x31, 0xf9, 0x4e, 0x3b, 0x25, 0x5c, 0xf0, 0x43, 0x3f, 0xc2, 0x5e, 0xb6, 0x6e,
-	0x69, 0x6d, 0x3b, 0x32, 0xf2, 0xc9, 0x3a, 0x01, 0x74, 0x8d, 0x8d, 0xd9, 0xbd, 0x2d, 0x4f, 0x6e,
-	0x36, 0x46, 0x89, 0x88, 0x99, 0x55, 0x95, 0x9d, 0xd6, 0x22, 0xfa, 0x01, 0xcc, 0xec, 0x45, 0xb0,
-	0x6a, 0x12, 0xb4, 0xd5, 0x4e, 0xdf, 0x8c, 0xb6, 0x7e, 0x33, 0xda, 0x63, 0xed, 0x81, 0x73, 0x67,
-	0x64, 0x43, 0x43, 0x84, 0xdc, 0xf5, 0x28, 0x13, 0xee, 0x9c, 0xf0, 0xb9, 0x65, 0x4a, 0xe4, 0xba,
-	0x08, 0x79, 0x9f, 0x32, 0xe1, 0x10, 0x3e, 0xef, 0xed, 0xa8, 0x19, 0xda, 0x7f, 0x19, 0xf0, 0x68,
-	0x40, 0xc3, 0xe0, 0x86, 0x32, 0x4c, 0xf9, 0x32, 0x8e, 0x38, 0x4d, 0xae, 0x2d, 0x2e, 0x88, 0x58,
-	0x71, 0x75, 0xc5, 0xef, 0xe9, 0x41, 0x5d, 0x49, 0xad, 0x53, 0xc2, 0xca, 0xfe, 0x7f, 0x27, 0xba,
-	0xd9, 0xa5, 0xf2, 0x43, 0xba, 0x94, 0xec, 0x63, 0x72, 0x79, 0x7c, 0x73, 0x0d, 0x66, 0xf6, 0xca,
-	0xa0, 0x5d, 0xa8, 0xe1, 0xe1, 0xcf, 0xa3, 0xab, 0xf1, 0x10, 0x37, 0x4b, 0xc8, 0x84, 0xed, 0xde,
-	0xd9, 0x45, 0xff, 0x75, 0xd3, 0x40, 0x0d, 0x30, 0xfb, 0xce, 0xc9, 0xe8, 0xbc, 0x7f, 0x31, 0x18,
-	0x36, 0xb7, 0x12, 0x11, 0x0f, 0x7f, 0x19, 0xf6, 0xc7, 0xa3, 0x8b, 0xf3, 0x66, 0x19, 0xed, 0x43,
-	0xe3, 0x74, 0x74, 0x36, 0x1e, 0xe2, 0xe1, 0x20, 0x0d, 0xa8, 0x74, 0x5f, 0x41, 0x55, 0xc2, 0x72,
-	0x74, 0x0c, 0x95, 0xfe, 0x9c, 0x08, 0x94, 0x5d, 0xfe, 0x85, 0xb5, 0x69, 0x35, 0xee, 0xbd, 0x74,
-	0x76, 0xe9, 0xc8, 0x38, 0x36, 0xba, 0xbf, 0x1b, 0xb0, 0xa3, 0xfa, 0x87, 0x5e, 0xe5, 0x7f, 0x9b,
-	0xba, 0x13, 0xc3, 0xe8, 0x86, 0x86, 0xf1, 0x92, 0xb6, 0x9e, 0xea, 0xe8, 0xb5, 0x6e, 0xa7, 0x38,
-	0xa8, 0x97, 0x8d, 0x41, 0xf7, 0xe2, 0xc1, 0x18, 0xbd, 0xdf, 0xc0, 0x8e, 0x99, 0xdf, 0x9e, 0xdf,
-	0x2d, 0x29, 0x0b, 0xe9, 0xd4, 0xa7, 0xac, 0x3d, 0x23, 0x13, 0x16, 0x78, 0x3a, 0x2c, 0xf9, 0x6a,
-	0xe8, 0x35, 0xd2, 0x5a, 0x2f, 0x89, 0xf7, 0x9e, 0xf8, 0xf4, 0xed, 0xd7, 0x7e, 0x20, 0xe6, 0xab,
-	0x49, 0x72, 0x56, 0xa7, 0x10, 0xd9, 0x49, 0x23, 0xd3, 0xcf, 0x13, 0xde, 0x49, 0x22, 0x27, 0xe9,
-	0xf7, 0xcc, 0x77, 0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0x7f, 0xfc, 0x9e, 0x06, 0xeb, 0x08, 0x00,
-	0x00,
+	// 1235 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x57, 0x4f, 0x73, 0xdb, 0x44,
+	0x14, 0xb7, 0x6c, 0x27, 0xb1, 0x9e, 0xe3, 0xc4, 0xde, 0xb4, 0xa9, 0x71, 0x0b, 0x6d, 0xc5, 0x00,
+	0xa1, 0xcc, 0xd8, 0xc5, 0x74, 0x18, 0xa6, 0x07, 0x98, 0xf8, 0x4f, 0xb1, 0x68, 0x9b, 0x76, 0xb6,
+	0x0e, 0x87, 0x1e, 0xd0, 0xc8, 0xf2, 0x5a, 0x56, 0x63, 0x4b, 0x66, 0x77, 0x9d, 0x71, 0x3e, 0x02,
+	0x5f, 0x80, 0xe1, 0x1b, 0x30, 0xc3, 0x89, 0xef, 0xc1, 0x81, 0x03, 0x5f, 0x86, 0x23, 0xa3, 0xd5,
+	0xae, 0x24, 0xdb, 0x71, 0x26, 0x01, 0x4e, 0xd6, 0xbe, 0x7d, 0xff, 0xdf, 0xef, 0xbd, 0xb7, 0x86,
+	0xca, 0x8c, 0x10, 0xda, 0x20, 0xe7, 0xc4, 0xe7, 0xac, 0x3e, 0xa3, 0x01, 0x0f, 0xd0, 0xb6, 0xf8,
+	0x61, 0xb5, 0x03, 0x27, 0x98, 0x4e, 0x03, 0xbf, 0x11, 0xfd, 0x44, 0x97, 0xb5, 0xfb, 0x6e, 0x10,
+	0xb8, 0x13, 0xd2, 0x10, 0xa7, 0xc1, 0x7c, 0xd4, 0xe0, 0xde, 0x94, 0x30, 0x6e, 0x4f, 0x67, 0x92,
+	0xa1, 0x26, 0x14, 0x3a, 0x63, 0xdb, 0xf3, 0x9d, 0x60, 0x48, 0x2c, 0xa1, 0x5a, 0xde, 0x1d, 0x8a,
+	0x3b, 0x4e, 0x6d, 0x9f, 0xd9, 0x0e, 0xf7, 0x94, 0x52, 0xe3, 0x35, 0xec, 0xb6, 0x95, 0x00, 0x26,
+	0x2e, 0x7a, 0x08, 0xbb, 0x89, 0x02, 0x6f, 0x58, 0xd5, 0x1e, 0x68, 0x47, 0x3a, 0x2e, 0xc6, 0x34,
+	0x73, 0x88, 0xde, 0x07, 0x10, 0x9a, 0x2d, 0xdf, 0x9e, 0x92, 0x6a, 0x56, 0x30, 0xe8, 0x82, 0x72,
+	0x62, 0x4f, 0x89, 0xf1, 0xab, 0x06, 0x05, 0xd3, 0xe7, 0x84, 0x12, 0xc6, 0xd1, 0x63, 0xc5, 0xcb,
+	0x2f, 0x66, 0x44, 0x28, 0xdb, 0x6b, 0x56, 0x22, 0xd3, 0xac, 0xde, 0x0d, 0x6f, 0xfa, 0x17, 0x33,
+	0x22, 0xc5, 0xc3, 0x4f, 0xd4, 0x01, 0x94, 0x38, 0x40, 0x89, 0x6b, 0x79, 0xfe, 0x28, 0x10, 0x56,
+	0x8a, 0xcd, 0x5b, 0x4a, 0x32, 0xed, 0x72, 0x2f, 0x83, 0xcb, 0x4e, 0xea, 0x6c, 0xfa, 0xa3, 0x00,
+	0x55, 0x61, 0x47, 0xd0, 0xcc, 0x4e, 0x35, 0x27, 0x1c, 0x54, 0xc7, 0x96, 0x0e, 0x3b, 0x92, 0xc9,
+	0x78, 0x02, 0x05, 0x4c, 0x5c, 0x8f, 0x71, 0x42, 0xd1, 0x11, 0x6c, 0x47, 0x95, 0xa8, 0x6a, 0x0f,
+	0x72, 0x47, 0xc5, 0x66, 0x59, 0x99, 0x52, 0xa1, 0x60, 0x79, 0x6f, 0xbc, 0x04, 0x1d, 0x93, 0x77,
+	0x44, 0x24, 0x11, 0x7d, 0x08, 0x59, 0xbe, 0x10, 0x71, 0x15, 0x9b, 0x07, 0x4a, 0xa4, 0x9f, 0x64,
+	0x19, 0x67, 0xf9, 0x02, 0xdd, 0x05, 0x9d, 0x50, 0x1a, 0x50, 0x6b, 0xca, 0x5c, 0x99, 0xaf, 0x82,
+	0x20, 0xbc, 0x64, 0xae, 0xf1, 0x25, 0xc0, 0xa9, 0x4f, 0x6f, 0xee, 0xc6, 0x2f, 0x1a, 0x94, 0x9e,
+	0x79, 0x93, 0x90, 0x3a, 0x6c, 0x4d, 0x02, 0xe7, 0x2c, 0xac, 0x8b, 0x33, 0xb6, 0x7d, 0x9f, 0x4c,
+	0x92, 0xc2, 0xe9, 0x92, 0x62, 0x0e, 0xd1, 0x21, 0x6c, 0xfb, 0xf3, 0xe9, 0x80, 0x50, 0xe1, 0x42,
+	0x1e, 0xcb, 0x13, 0x7a, 0x0d, 0xb7, 0x47, 0x52, 0x8f, 0x95, 0xc2, 0x07, 0xab, 0xe6, 0x85, 0x07,
+	0x77, 0x95, 0x07, 0xca, 0x58, 0x3a, 0xba, 0x5b, 0xa3, 0x75, 0x22, 0x33, 0xfe, 0xd6, 0xe0, 0xe0,
+	0x12, 0x6e, 0x84, 0x20, 0xcf, 0x17, 0xb1, 0x6b, 0xe2, 0x1b, 0x7d, 0x0c, 0x79, 0x01, 0x8d, 0xac,
+	0x80, 0x06, 0xaa, 0x4b, 0xc4, 0xf7, 0x88, 0x3d, 0x24, 0x54, 0x60, 0x43, 0xdc, 0xa3, 0x67, 0x80,
+	0xf8, 0xc2, 0x3a, 0xb7, 0x27, 0xde, 0xd0, 0x0e, 0x95, 0x59, 0x61, 0xb5, 0x45, 0x6d, 0xf7, 0x9a,
+	0xd5, 0x38, 0xf1, 0x8b, 0xef, 0x63, 0x86, 0x76, 0x88, 0x86, 0x32, 0x5f, 0xa1, 0xa0, 0x53, 0x38,
+	0x48, 0x05, 0x69, 0x25, 0xb1, 0x86, 0x15, 0x34, 0xae, 0x88, 0xf5, 0x38, 0xe2, 0xec, 0x65, 0x30,
+	0xe2, 0x6b, 0xd4, 0xd6, 0x36, 0xe4, 0x3b, 0x36, 0xb7, 0x8d, 0x77, 0x50, 0xdb, 0x2c, 0x8b, 0x5e,
+	0x40, 0x25, 0xc1, 0xb6, 0x32, 0x1d, 0x15, 0xfa, 0xfe, 0xaa, 0xe9, 0x18, 0xe2, 0x91, 0x70, 0x0a,
+	0xe3, 0x52, 0x9b, 0xf1, 0x16, 0xee, 0x6c, 0x60, 0x46, 0xdf, 0xc0, 0xfe, 0xca, 0x18, 0x90, 0x18,
+	0x3d, 0x5c, 0xeb, 0x20, 0xd1, 0x84, 0x78, 0xcf, 0x59, 0x3a, 0x1b, 0xcf, 0xa1, 0xf8, 0xc6, 0x73,
+	0x7d, 0x32, 0x14, 0x47, 0x74, 0x0f, 0x74, 0xe6, 0xb9, 0xbe, 0xcd, 0xe7, 0x34, 0xea, 0xe2, 0x5d,
+	0x9c, 0x10, 0xd0, 0x07, 0xb2, 0xc9, 0x5b, 0x17, 0x9c, 0x30, 0x51, 0xc9, 0x5d, 0x9c, 0xa2, 0x18,
+	0x7f, 0xe4, 0x60, 0x2b, 0xd2, 0x53, 0x87, 0x82, 0x82, 0xba, 0x74, 0x28, 0x06, 0xb8, 0xea, 0xc4,
+	0x5e, 0x06, 0xc7, 0x3c, 0xe8, 0x23, 0xd8, 0x1a, 0x84, 0xd8, 0x96, 0xfd, 0x5f, 0x52, 0xf0, 0x10,
+	0x80, 0xef, 0x65, 0x70, 0x74, 0x8b, 0x8e, 0xd7, 0xc3, 0xcd, 0x5d, 0x15, 0x6e, 0x2f, 0xb3, 0x1a,
+	0x30, 0xfa, 0x1c, 0x74, 0xaa, 0xba, 0x5a, 0xa2, 0xa1, 0x92, 0xb8, 0x26, 0x2f, 0x7a, 0x19, 0x9c,
+	0x70, 0xa1, 0x27, 0x00, 0xf3, 0xb8, 0x73, 0xab, 0x5b, 0x42, 0x06, 0x29, 0x99, 0xa4, 0xa7, 0x7b,
+	0x19, 0x9c, 0xe2, 0x43, 0x5f, 0xc3, 0x5e, 0xdc, 0x6e, 0x51, 0x6c, 0x3b, 0x42, 0xf2, 0xf6, 0x2a,
+	0x00, 0x54, 0x8c, 0xa5, 0xd1, 0x52, 0x97, 0x87, 0x93, 0x8d, 0x12, 0x9b, 0x07, 0xb4, 0xba, 0x2d,
+	0x32, 0xad, 0x8e, 0xe8, 0x2b, 0xd0, 0xe3, 0x8d, 0x50, 0x2d, 0x08, 0xa5, 0xb5, 0x7a, 0xb4, 0x33,
+	0xea, 0x6a, 0x67, 0xd4, 0xfb, 0x8a, 0x03, 0x27, 0xcc, 0xc8, 0x80, 0x12, 0x9f, 0x30, 0xcb, 0x21,
+	0x94, 0x5b, 0x63, 0x9b, 0x8d, 0xab, 0xba, 0xd0, 0x5c, 0xe4, 0x13, 0xd6, 0x26, 0x94, 0xf7, 0x6c,
+	0x36, 0x6e, 0xed, 0xc8, 0x1a, 0x1a, 0xbf, 0x69, 0xb0, 0xdf, 0x21, 0x13, 0xef, 0x9c, 0x50, 0x4c,
+	0xd8, 0x2c, 0xf0, 0x19, 0x09, 0xc7, 0x16, 0xe3, 0x36, 0x9f, 0x33, 0x39, 0xe2, 0xf7, 0x54, 0xa1,
+	0xde, 0x08, 0x6a, 0x2f, 0x83, 0xe5, 0xfd, 0x75, 0x2b, 0xba, 0x9e, 0xa5, 0xdc, 0x4d, 0xb2, 0x14,
+	0xf6, 0x63, 0x38, 0x3c, 0x8c, 0xbf, 0x34, 0x38, 0x5c, 0xae, 0x3d, 0xc3, 0xe4, 0xc7, 0x79, 0xb8,
+	0x9a, 0xae, 0xb1, 0xe9, 0x1e, 0x41, 0x25, 0xd9, 0x74, 0x56, 0x64, 0x41, 0x0e, 0xf0, 0xfd, 0x78,
+	0xe1, 0x45, 0x9e, 0xa0, 0x4f, 0x60, 0xdf, 0xf3, 0x9d, 0xc9, 0x3c, 0x54, 0xe6, 0x8b, 0x39, 0x25,
+	0x5c, 0x2e, 0xe0, 0x3d, 0x49, 0x36, 0x23, 0x2a, 0x3a, 0x0e, 0xc7, 0x34, 0x71, 0xce, 0x66, 0x81,
+	0xe7, 0x73, 0x09, 0xb5, 0x87, 0x97, 0xe3, 0x94, 0xb5, 0x63, 0x46, 0x9c, 0x12, 0x32, 0xce, 0xe0,
+	0xbd, 0x8d, 0x8c, 0x61, 0x5c, 0x22, 0x63, 0x96, 0x9c, 0xf6, 0x9a, 0x98, 0xf6, 0x45, 0x41, 0x3b,
+	0x11, 0x24, 0xf4, 0x19, 0x54, 0xd2, 0x43, 0xd0, 0xf3, 0x87, 0x64, 0x21, 0xb7, 0x42, 0x39, 0x75,
+	0x61, 0x86, 0x74, 0xe3, 0xf7, 0x2c, 0xa0, 0x65, 0x6b, 0x62, 0xc3, 0xfe, 0xd7, 0x11, 0xb3, 0xe6,
+	0x67, 0xf6, 0x9a, 0x7e, 0xe6, 0x2e, 0xf7, 0x73, 0xc3, 0x86, 0xc8, 0xdf, 0x78, 0x43, 0x2c, 0xd7,
+	0x67, 0xeb, 0xdf, 0xd4, 0xe7, 0x4f, 0x0d, 0x6e, 0xad, 0x70, 0x5e, 0x6b, 0x45, 0x5f, 0x23, 0x25,
+	0xcd, 0xf8, 0x81, 0x90, 0x13, 0x7b, 0xa3, 0x76, 0xb9, 0x67, 0x61, 0x89, 0xd4, 0x53, 0xe1, 0xff,
+	0x40, 0xdc, 0xcf, 0x1a, 0xdc, 0x59, 0xeb, 0xa3, 0x1b, 0x37, 0xbf, 0x09, 0xe5, 0x15, 0xcc, 0x30,
+	0x39, 0x07, 0xee, 0x6d, 0x70, 0x47, 0xb5, 0xf7, 0xbe, 0xb3, 0x42, 0x97, 0x0d, 0xfe, 0xe8, 0x14,
+	0xf4, 0xf8, 0x19, 0x89, 0x76, 0xa1, 0x80, 0xbb, 0xdf, 0x9a, 0x6f, 0xfa, 0x5d, 0x5c, 0xce, 0x20,
+	0x1d, 0xb6, 0x5a, 0x2f, 0x5e, 0xb5, 0x9f, 0x97, 0x35, 0x54, 0x02, 0xbd, 0xdd, 0x3b, 0x36, 0x4f,
+	0xda, 0xaf, 0x3a, 0xdd, 0x72, 0x36, 0x3c, 0xe2, 0xee, 0x77, 0xdd, 0x76, 0xdf, 0x7c, 0x75, 0x52,
+	0xce, 0xa1, 0x0a, 0x94, 0x9e, 0x99, 0x2f, 0xfa, 0x5d, 0xdc, 0xed, 0x44, 0x02, 0xf9, 0xe6, 0x53,
+	0xd8, 0x8e, 0x0c, 0xa1, 0xc7, 0x90, 0x6f, 0x8f, 0x6d, 0x8e, 0xe2, 0xd7, 0x5d, 0x6a, 0x2f, 0xd6,
+	0x4a, 0x4b, 0x4f, 0x59, 0x23, 0x73, 0xa4, 0x3d, 0xd6, 0x9a, 0x3f, 0x69, 0xb0, 0x23, 0x07, 0x24,
+	0x7a, 0x9a, 0x7c, 0x96, 0x55, 0x5a, 0xba, 0xfe, 0x39, 0x99, 0x04, 0x33, 0x52, 0xbb, 0xa3, 0xa4,
+	0x57, 0xc6, 0x69, 0xa4, 0x07, 0xb5, 0xe2, 0x39, 0xab, 0x86, 0xdd, 0x8d, 0x75, 0x34, 0x4f, 0x61,
+	0x7f, 0x25, 0xa3, 0xa8, 0x75, 0x95, 0x4b, 0xf7, 0x37, 0xd4, 0x61, 0x59, 0x6d, 0xeb, 0x07, 0x30,
+	0x02, 0xea, 0xd6, 0xc7, 0x17, 0x33, 0x42, 0x27, 0x64, 0xe8, 0x12, 0x5a, 0x1f, 0xd9, 0x03, 0xea,
+	0x39, 0x4a, 0x7c, 0x46, 0x08, 0x6d, 0x95, 0x22, 0xd9, 0xd7, 0xb6, 0x73, 0x66, 0xbb, 0xe4, 0xed,
+	0xa7, 0xae, 0xc7, 0xc7, 0xf3, 0x41, 0x68, 0xb3, 0x91, 0x92, 0x6c, 0x44, 0x92, 0xd1, 0xdf, 0x1a,
+	0xd6, 0x08, 0x25, 0x07, 0xd1, 0xff, 0xa0, 0x2f, 0xfe, 0x19, 0x00, 0x97, 0xde, 0xfc, 0xf8, 0x23,
+	0x0d, 0x00, 0x00,
 }
@@ -7,6 +7,7 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import google_protobuf1 "github.com/golang/protobuf/ptypes/timestamp"
+import kvrwset "github.com/hyperledger/fabric/protos/ledger/rwset/kvrwset"
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
@@ -35,6 +36,12 @@ type ProposalResponse struct {
 	// The endorsement of the proposal, basically
 	// the endorser's signature over the payload
 	Endorsement *Endorsement `protobuf:"bytes,6,opt,name=endorsement" json:"endorsement,omitempty"`
+	// A conflict of the read set of the proposal with the committed state, which
+	// is only set when the endorser is configured to re-validate read sets. The
+	// check is best-effort: it only sees the blocks committed between the end of
+	// the simulation and the response, so the absence of a conflict does not mean
+	// that the transaction will be valid. It is not covered by the endorsement
+	MvccConflict *MVCCConflict `protobuf:"bytes,7,opt,name=mvcc_conflict,json=mvccConflict" json:"mvcc_conflict,omitempty"`
 }
 
 func (m *ProposalResponse) Reset()                    { *m = ProposalResponse{} }
@@ -77,6 +84,13 @@ func (m *ProposalResponse) GetEndorsement() *Endorsement {
 	return nil
 }
 
+func (m *ProposalResponse) GetMvccConflict() *MVCCConflict {
+	if m != nil {
+		return m.MvccConflict
+	}
+	return nil
+}
+
 // A response with a representation similar to an HTTP response that can
 // be used within another message.
 type Response struct {
@@ -199,38 +213,134 @@ func (m *Endorsement) GetSignature() []byte {
 	return nil
 }
 
+// MVCCConflict describes the read of a transaction that does not match the
+// committed state, and that causes the transaction to be invalidated with
+// MVCC_READ_CONFLICT or PHANTOM_READ_CONFLICT
+type MVCCConflict struct {
+	// The namespace of the read, i.e. the name of the chaincode
+	Namespace string `protobuf:"bytes,1,opt,name=namespace" json:"namespace,omitempty"`
+	// The collection of the read, which is only set for the reads of private
+	// data, along with the hash of their key in place of the key
+	Collection string `protobuf:"bytes,2,opt,name=collection" json:"collection,omitempty"`
+	Key        string `protobuf:"bytes,3,opt,name=key" json:"key,omitempty"`
+	KeyHash    []byte `protobuf:"bytes,4,opt,name=key_hash,json=keyHash,proto3" json:"key_hash,omitempty"`
+	// The range of the keys of a range query whose results have changed, which
+	// is set in place of the key for phantom reads
+	RangeStartKey string `protobuf:"bytes,5,opt,name=range_start_key,json=rangeStartKey" json:"range_start_key,omitempty"`
+	RangeEndKey   string `protobuf:"bytes,6,opt,name=range_end_key,json=rangeEndKey" json:"range_end_key,omitempty"`
+	// The version of the key read by the transaction, and its version in the
+	// committed state or as written by a preceding valid transaction of the
+	// same block. They are unset when the key does not exist
+	ReadVersion      *kvrwset.Version `protobuf:"bytes,7,opt,name=read_version,json=readVersion" json:"read_version,omitempty"`
+	CommittedVersion *kvrwset.Version `protobuf:"bytes,8,opt,name=committed_version,json=committedVersion" json:"committed_version,omitempty"`
+}
+
+func (m *MVCCConflict) Reset()                    { *m = MVCCConflict{} }
+func (m *MVCCConflict) String() string            { return proto.CompactTextString(m) }
+func (*MVCCConflict) ProtoMessage()               {}
+func (*MVCCConflict) Descriptor() ([]byte, []int) { return fileDescriptor8, []int{4} }
+
+func (m *MVCCConflict) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *MVCCConflict) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *MVCCConflict) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *MVCCConflict) GetKeyHash() []byte {
+	if m != nil {
+		return m.KeyHash
+	}
+	return nil
+}
+
+func (m *MVCCConflict) GetRangeStartKey() string {
+	if m != nil {
+		return m.RangeStartKey
+	}
+	return ""
+}
+
+func (m *MVCCConflict) GetRangeEndKey() string {
+	if m != nil {
+		return m.RangeEndKey
+	}
+	return ""
+}
+
+func (m *MVCCConflict) GetReadVersion() *kvrwset.Version {
+	if m != nil {
+		return m.ReadVersion
+	}
+	return nil
+}
+
+func (m *MVCCConflict) GetCommittedVersion() *kvrwset.Version {
+	if m != nil {
+		return m.CommittedVersion
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ProposalResponse)(nil), "protos.ProposalResponse")
 	proto.RegisterType((*Response)(nil), "protos.Response")
 	proto.RegisterType((*ProposalResponsePayload)(nil), "protos.ProposalResponsePayload")
 	proto.RegisterType((*Endorsement)(nil), "protos.Endorsement")
+	proto.RegisterType((*MVCCConflict)(nil), "protos.MVCCConflict")
 }
 
 func init() { proto.RegisterFile("peer/proposal_response.proto", fileDescriptor8) }
 
 var fileDescriptor8 = []byte{
-	// 367 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0x51, 0x4b, 0xfb, 0x30,
-	0x14, 0xc5, 0xe9, 0xfe, 0xff, 0xcd, 0x2d, 0x9b, 0x30, 0x2a, 0x68, 0x19, 0x03, 0x47, 0x7d, 0x99,
-	0x20, 0x29, 0x28, 0x82, 0xcf, 0x03, 0xd1, 0xc7, 0x11, 0xc4, 0x07, 0x11, 0x24, 0xdd, 0xee, 0xd2,
-	0x62, 0xdb, 0x84, 0xdc, 0x54, 0xdc, 0x07, 0xf6, 0x7b, 0x48, 0xd3, 0xa6, 0xab, 0xe2, 0xd3, 0x38,
-	0x77, 0x27, 0xbf, 0x7b, 0xcf, 0xed, 0x25, 0x73, 0x05, 0xa0, 0x23, 0xa5, 0xa5, 0x92, 0xc8, 0xb3,
-	0x37, 0x0d, 0xa8, 0x64, 0x81, 0x40, 0x95, 0x96, 0x46, 0xfa, 0x03, 0xfb, 0x83, 0xb3, 0x73, 0x21,
-	0xa5, 0xc8, 0x20, 0xb2, 0x32, 0x2e, 0x77, 0x91, 0x49, 0x73, 0x40, 0xc3, 0x73, 0x55, 0x1b, 0xc3,
-	0x2f, 0x8f, 0x4c, 0xd7, 0x0d, 0x84, 0x35, 0x0c, 0x3f, 0x20, 0x47, 0x1f, 0xa0, 0x31, 0x95, 0x45,
-	0xe0, 0x2d, 0xbc, 0x65, 0x9f, 0x39, 0xe9, 0xdf, 0x91, 0x51, 0x4b, 0x08, 0x7a, 0x0b, 0x6f, 0x39,
-	0xbe, 0x9e, 0xd1, 0xba, 0x07, 0x75, 0x3d, 0xe8, 0x93, 0x73, 0xb0, 0x83, 0xd9, 0xbf, 0x22, 0x43,
-	0x37, 0x63, 0xf0, 0xdf, 0x3e, 0x9c, 0xd6, 0x2f, 0x90, 0xba, 0xbe, 0xac, 0x75, 0x54, 0x13, 0x28,
-	0xbe, 0xcf, 0x24, 0xdf, 0x06, 0xfd, 0x85, 0xb7, 0x9c, 0x30, 0x27, 0xfd, 0x5b, 0x32, 0x86, 0x62,
-	0x2b, 0x35, 0x42, 0x0e, 0x85, 0x09, 0x06, 0x16, 0x75, 0xe2, 0x50, 0xf7, 0x87, 0xbf, 0x58, 0xd7,
-	0x17, 0x3e, 0x93, 0x61, 0x1b, 0xef, 0x94, 0x0c, 0xd0, 0x70, 0x53, 0x62, 0x93, 0xae, 0x51, 0x55,
-	0xd3, 0x1c, 0x10, 0xb9, 0x00, 0x1b, 0x6d, 0xc4, 0x9c, 0xec, 0x8e, 0xf3, 0xef, 0xc7, 0x38, 0xe1,
-	0x2b, 0x39, 0xfb, 0xbd, 0xbe, 0x75, 0x33, 0xe9, 0x05, 0x39, 0x6e, 0x3f, 0x4f, 0xc2, 0x31, 0xb1,
-	0xdd, 0x26, 0x6c, 0xe2, 0x8a, 0x8f, 0x1c, 0x13, 0x7f, 0x4e, 0x46, 0xf0, 0x69, 0xa0, 0xb0, 0xcb,
-	0xee, 0x59, 0xc3, 0xa1, 0x10, 0x3e, 0x90, 0x71, 0x27, 0x91, 0x3f, 0x23, 0xc3, 0x26, 0x93, 0x6e,
-	0x60, 0xad, 0xae, 0x40, 0x98, 0x8a, 0x82, 0x9b, 0x52, 0x83, 0x03, 0xb5, 0x85, 0x55, 0x42, 0x42,
-	0xa9, 0x05, 0x4d, 0xf6, 0x0a, 0x74, 0x06, 0x5b, 0x01, 0x9a, 0xee, 0x78, 0xac, 0xd3, 0x8d, 0x5b,
-	0x5c, 0x75, 0x4d, 0xab, 0x3f, 0xa2, 0x6c, 0xde, 0xb9, 0x80, 0x97, 0x4b, 0x91, 0x9a, 0xa4, 0x8c,
-	0xe9, 0x46, 0xe6, 0x51, 0x87, 0x11, 0xd5, 0x8c, 0xfa, 0xba, 0x30, 0xaa, 0x18, 0x71, 0x7d, 0x79,
-	0x37, 0xdf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x0e, 0x52, 0x0b, 0x35, 0xa0, 0x02, 0x00, 0x00,
+	// 558 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0xdf, 0x6b, 0xd4, 0x40,
+	0x10, 0xe6, 0xae, 0xed, 0xf5, 0x32, 0x97, 0xe2, 0xb9, 0x8a, 0xc6, 0xa3, 0x68, 0x49, 0x41, 0x2a,
+	0x48, 0x02, 0x16, 0x41, 0x1f, 0x7c, 0x69, 0x29, 0x0a, 0x22, 0x94, 0x55, 0xfa, 0x20, 0x42, 0xd8,
+	0x4b, 0xa6, 0x49, 0xb8, 0x24, 0x1b, 0x76, 0xf7, 0xaa, 0xf9, 0x6f, 0xfc, 0x37, 0xfc, 0xef, 0x24,
+	0xfb, 0x23, 0x17, 0x45, 0x9f, 0x92, 0xf9, 0xf2, 0xcd, 0x37, 0x33, 0xdf, 0xce, 0x06, 0x8e, 0x5b,
+	0x44, 0x11, 0xb7, 0x82, 0xb7, 0x5c, 0xb2, 0x2a, 0x11, 0x28, 0x5b, 0xde, 0x48, 0x8c, 0x5a, 0xc1,
+	0x15, 0x27, 0x33, 0xfd, 0x90, 0xab, 0x67, 0x39, 0xe7, 0x79, 0x85, 0xb1, 0x0e, 0xd7, 0xdb, 0xdb,
+	0x58, 0x95, 0x35, 0x4a, 0xc5, 0xea, 0xd6, 0x10, 0x57, 0xa7, 0x15, 0x66, 0x39, 0x8a, 0x58, 0x7c,
+	0x97, 0xa8, 0xe2, 0xcd, 0x9d, 0x7b, 0x26, 0xfa, 0xc5, 0x90, 0xc2, 0x9f, 0x53, 0x58, 0x5e, 0xdb,
+	0x4a, 0xd4, 0x16, 0x22, 0x01, 0x1c, 0xde, 0xa1, 0x90, 0x25, 0x6f, 0x82, 0xc9, 0xc9, 0xe4, 0xec,
+	0x80, 0xba, 0x90, 0xbc, 0x01, 0x6f, 0x28, 0x13, 0x4c, 0x4f, 0x26, 0x67, 0x8b, 0x57, 0xab, 0xc8,
+	0x34, 0x12, 0xb9, 0x46, 0xa2, 0x2f, 0x8e, 0x41, 0x77, 0x64, 0xf2, 0x12, 0xe6, 0x6e, 0x90, 0x60,
+	0x5f, 0x27, 0x2e, 0x4d, 0x86, 0x8c, 0x5c, 0x5d, 0x3a, 0x17, 0xa3, 0x0e, 0x5a, 0xd6, 0x55, 0x9c,
+	0x65, 0xc1, 0xc1, 0xc9, 0xe4, 0xcc, 0xa7, 0x2e, 0x24, 0xaf, 0x61, 0x81, 0x4d, 0xc6, 0x85, 0xc4,
+	0x1a, 0x1b, 0x15, 0xcc, 0xb4, 0xd4, 0x03, 0x27, 0x75, 0xb5, 0xfb, 0x44, 0xc7, 0x3c, 0xf2, 0x16,
+	0x8e, 0xea, 0xbb, 0x34, 0x4d, 0x52, 0xde, 0xdc, 0x56, 0x65, 0xaa, 0x82, 0x43, 0x9d, 0xf8, 0xd0,
+	0x25, 0x7e, 0xba, 0xb9, 0xbc, 0xbc, 0xb4, 0xdf, 0xa8, 0xdf, 0x53, 0x5d, 0x14, 0xde, 0xc0, 0x7c,
+	0x70, 0xe6, 0x11, 0xcc, 0xa4, 0x62, 0x6a, 0x2b, 0xad, 0x31, 0x36, 0xea, 0xfb, 0xad, 0x51, 0x4a,
+	0x96, 0xa3, 0x76, 0xc5, 0xa3, 0x2e, 0x1c, 0x4f, 0xb2, 0xf7, 0xc7, 0x24, 0xe1, 0x37, 0x78, 0xfc,
+	0xb7, 0xf3, 0xd7, 0x76, 0xc8, 0x53, 0x38, 0x1a, 0x8e, 0xbf, 0x60, 0xb2, 0xd0, 0xd5, 0x7c, 0xea,
+	0x3b, 0xf0, 0x03, 0x93, 0x05, 0x39, 0x06, 0x0f, 0x7f, 0x28, 0x6c, 0xf4, 0x39, 0x4d, 0x35, 0x61,
+	0x07, 0x84, 0xef, 0x61, 0x31, 0x32, 0x83, 0xac, 0x60, 0x6e, 0xed, 0x10, 0x56, 0x6c, 0x88, 0x7b,
+	0x21, 0x59, 0xe6, 0x0d, 0x53, 0x5b, 0x81, 0x4e, 0x68, 0x00, 0xc2, 0x5f, 0x53, 0xf0, 0xc7, 0xee,
+	0xf4, 0xf4, 0x86, 0xd5, 0x28, 0x5b, 0x96, 0xa2, 0xd6, 0xf2, 0xe8, 0x0e, 0x20, 0x4f, 0x01, 0x52,
+	0x5e, 0x55, 0x98, 0x2a, 0xd7, 0x96, 0x47, 0x47, 0x08, 0x59, 0xc2, 0xde, 0x06, 0x3b, 0xed, 0x85,
+	0x47, 0xfb, 0x57, 0xf2, 0x04, 0xe6, 0x1b, 0xec, 0xcc, 0x9c, 0xfb, 0xc6, 0xa2, 0x0d, 0x76, 0x7a,
+	0xc4, 0xe7, 0x70, 0x4f, 0xb0, 0x26, 0xc7, 0x44, 0x2a, 0x26, 0x54, 0xd2, 0x27, 0x1e, 0xe8, 0xc4,
+	0x23, 0x0d, 0x7f, 0xee, 0xd1, 0x8f, 0xd8, 0x91, 0x10, 0x0c, 0x90, 0x60, 0x93, 0x69, 0xd6, 0x4c,
+	0xb3, 0x16, 0x1a, 0xbc, 0x6a, 0xb2, 0x9e, 0x73, 0x0e, 0xbe, 0x40, 0x96, 0x25, 0x6e, 0xb3, 0x0f,
+	0xed, 0x12, 0xda, 0x8b, 0x11, 0xdd, 0x18, 0x9c, 0x2e, 0x7a, 0x96, 0x0d, 0xc8, 0x3b, 0xb8, 0x9f,
+	0xf2, 0xba, 0x2e, 0x95, 0xc2, 0x5d, 0xe6, 0xfc, 0x3f, 0x99, 0xcb, 0x81, 0x6a, 0x91, 0x8b, 0x02,
+	0x42, 0x2e, 0xf2, 0xa8, 0xe8, 0x5a, 0x14, 0xe6, 0x36, 0x46, 0xb7, 0x6c, 0x2d, 0xca, 0xd4, 0xad,
+	0x5d, 0x8b, 0x28, 0x2e, 0xfe, 0xb1, 0x06, 0xe9, 0x86, 0xe5, 0xf8, 0xf5, 0x45, 0x5e, 0xaa, 0x62,
+	0xbb, 0x8e, 0x52, 0x5e, 0xc7, 0x23, 0x8d, 0xd8, 0x68, 0x98, 0x9b, 0x2f, 0xe3, 0x5e, 0x63, 0x6d,
+	0xfe, 0x0a, 0xe7, 0xbf, 0x07, 0x00, 0x5e, 0x04, 0x51, 0x15, 0x3c, 0x04, 0x00, 0x00,
 }
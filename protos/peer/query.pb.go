@@ -54,6 +54,9 @@ type ChaincodeInfo struct {
 	//                H(CodePackage)
 	//              )
 	Id []byte `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"`
+	// the ID of the package the chaincode was installed from, made of its label
+	// and its hash. This is only set for installed tar.gz packages.
+	PackageId string `protobuf:"bytes,8,opt,name=package_id,json=packageId" json:"package_id,omitempty"`
 }
 
 func (m *ChaincodeInfo) Reset()                    { *m = ChaincodeInfo{} }
@@ -110,6 +113,13 @@ func (m *ChaincodeInfo) GetId() []byte {
 	return nil
 }
 
+func (m *ChaincodeInfo) GetPackageId() string {
+	if m != nil {
+		return m.PackageId
+	}
+	return ""
+}
+
 // ChannelQueryResponse returns information about each channel that pertains
 // to a query in lscc.go, such as GetChannels (returns all channels for a
 // given peer)
@@ -156,24 +166,25 @@ func init() {
 func init() { proto.RegisterFile("peer/query.proto", fileDescriptor9) }
 
 var fileDescriptor9 = []byte{
-	// 296 bytes of a gzipped FileDescriptorProto
+	// 307 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x91, 0xdf, 0x4a, 0xc3, 0x30,
-	0x14, 0xc6, 0xe9, 0xfe, 0xba, 0x33, 0x15, 0x89, 0x53, 0x72, 0x23, 0x8c, 0x5e, 0x4d, 0x90, 0x16,
-	0x14, 0x5f, 0xc0, 0x5d, 0xc8, 0xae, 0x86, 0xbd, 0xf4, 0x46, 0xba, 0xe4, 0x6c, 0x0d, 0x6c, 0x49,
-	0x4c, 0xba, 0xc1, 0x9e, 0xc6, 0x57, 0x95, 0x93, 0xac, 0xa3, 0xbb, 0xea, 0x39, 0xbf, 0xef, 0x17,
-	0xca, 0x97, 0xc0, 0x9d, 0x45, 0x74, 0xf9, 0xef, 0x1e, 0xdd, 0x31, 0xb3, 0xce, 0xd4, 0x86, 0x0d,
-	0xc2, 0xc7, 0xa7, 0x4b, 0x78, 0x9c, 0x57, 0xa5, 0xd2, 0xc2, 0x48, 0xfc, 0xa2, 0xbc, 0x40, 0x6f,
-	0x8d, 0xf6, 0xc8, 0xde, 0x01, 0x44, 0x93, 0x78, 0x9e, 0x4c, 0xbb, 0xb3, 0xf1, 0xeb, 0x43, 0x3c,
-	0xed, 0xb3, 0xf3, 0x99, 0x85, 0x5e, 0x9b, 0xa2, 0x25, 0xa6, 0x7f, 0x09, 0xdc, 0x5c, 0xa4, 0x8c,
-	0x41, 0x4f, 0x97, 0x3b, 0xe4, 0xc9, 0x34, 0x99, 0x8d, 0x8a, 0x30, 0x33, 0x0e, 0xc3, 0x03, 0x3a,
-	0xaf, 0x8c, 0xe6, 0x9d, 0x80, 0x9b, 0x95, 0x6c, 0x5b, 0xd6, 0x15, 0xef, 0x46, 0x9b, 0x66, 0x36,
-	0x81, 0xbe, 0xd2, 0x76, 0x5f, 0xf3, 0x5e, 0x80, 0x71, 0x21, 0x13, 0xbd, 0x10, 0xbc, 0x1f, 0x4d,
-	0x9a, 0x89, 0x1d, 0x88, 0x0d, 0x22, 0xa3, 0x99, 0xdd, 0x42, 0x47, 0x49, 0x3e, 0x9c, 0x26, 0xb3,
-	0xeb, 0xa2, 0xa3, 0x64, 0xfa, 0x09, 0x93, 0x79, 0x55, 0x6a, 0x8d, 0xdb, 0xcb, 0xc2, 0x39, 0x5c,
-	0x89, 0xc8, 0x9b, 0xba, 0xf7, 0xad, 0xba, 0xc4, 0x43, 0xd9, 0xb3, 0x94, 0xbe, 0xc0, 0xb8, 0x15,
-	0xb0, 0xa7, 0x70, 0x61, 0xb4, 0xfe, 0x28, 0x79, 0x6a, 0x3b, 0x3a, 0x91, 0x85, 0xfc, 0x58, 0x42,
-	0x6a, 0xdc, 0x26, 0xab, 0x8e, 0x16, 0xdd, 0x16, 0xe5, 0x06, 0x5d, 0xb6, 0x2e, 0x57, 0x4e, 0x89,
-	0xe6, 0x27, 0xf4, 0x46, 0xdf, 0xcf, 0x1b, 0x55, 0x57, 0xfb, 0x55, 0x26, 0xcc, 0x2e, 0x6f, 0xa9,
-	0x79, 0x54, 0xf3, 0xa8, 0xe6, 0xa4, 0xae, 0xe2, 0x13, 0xbe, 0xfd, 0x07, 0x00, 0x00, 0xff, 0xff,
-	0x79, 0x94, 0xb3, 0xbd, 0xdd, 0x01, 0x00, 0x00,
+	0x14, 0xc6, 0xe9, 0xfe, 0xef, 0x4c, 0x45, 0xe2, 0x94, 0xdc, 0x08, 0xa3, 0x57, 0x13, 0xa4, 0x05,
+	0xc5, 0x17, 0x70, 0x17, 0xb2, 0xab, 0x61, 0x2f, 0xbd, 0x91, 0x2e, 0x39, 0x6b, 0x83, 0x5b, 0x12,
+	0x93, 0x6e, 0xb0, 0x97, 0xf3, 0xd9, 0xe4, 0x34, 0xeb, 0xe8, 0xae, 0x72, 0xf2, 0xfb, 0x7e, 0xe1,
+	0xc0, 0x17, 0xb8, 0xb5, 0x88, 0x2e, 0xfd, 0xdd, 0xa3, 0x3b, 0x26, 0xd6, 0x99, 0xca, 0xb0, 0x41,
+	0x7d, 0xf8, 0x78, 0x05, 0x0f, 0x8b, 0x32, 0x57, 0x5a, 0x18, 0x89, 0x9f, 0x94, 0x67, 0xe8, 0xad,
+	0xd1, 0x1e, 0xd9, 0x1b, 0x80, 0x68, 0x12, 0xcf, 0xa3, 0x59, 0x77, 0x3e, 0x79, 0xb9, 0x0f, 0xaf,
+	0x7d, 0x72, 0x7e, 0xb3, 0xd4, 0x1b, 0x93, 0xb5, 0xc4, 0xf8, 0x2f, 0x82, 0xeb, 0x8b, 0x94, 0x31,
+	0xe8, 0xe9, 0x7c, 0x87, 0x3c, 0x9a, 0x45, 0xf3, 0x71, 0x56, 0xcf, 0x8c, 0xc3, 0xf0, 0x80, 0xce,
+	0x2b, 0xa3, 0x79, 0xa7, 0xc6, 0xcd, 0x95, 0x6c, 0x9b, 0x57, 0x25, 0xef, 0x06, 0x9b, 0x66, 0x36,
+	0x85, 0xbe, 0xd2, 0x76, 0x5f, 0xf1, 0x5e, 0x0d, 0xc3, 0x85, 0x4c, 0xf4, 0x42, 0xf0, 0x7e, 0x30,
+	0x69, 0x26, 0x76, 0x20, 0x36, 0x08, 0x8c, 0x66, 0x76, 0x03, 0x1d, 0x25, 0xf9, 0x70, 0x16, 0xcd,
+	0xaf, 0xb2, 0x8e, 0x92, 0xec, 0x11, 0xc0, 0xe6, 0xe2, 0x27, 0x2f, 0xf0, 0x5b, 0x49, 0x3e, 0xaa,
+	0xcd, 0xf1, 0x89, 0x2c, 0x65, 0xfc, 0x01, 0xd3, 0x45, 0x99, 0x6b, 0x8d, 0xdb, 0xcb, 0x3e, 0x52,
+	0x18, 0x89, 0xc0, 0x9b, 0x36, 0xee, 0x5a, 0x6d, 0x10, 0xaf, 0xbb, 0x38, 0x4b, 0xf1, 0x33, 0x4c,
+	0x5a, 0x01, 0xad, 0x3d, 0x45, 0xb4, 0x36, 0x94, 0x31, 0x3e, 0x91, 0xa5, 0x7c, 0x5f, 0x41, 0x6c,
+	0x5c, 0x91, 0x94, 0x47, 0x8b, 0x6e, 0x8b, 0xb2, 0x40, 0x97, 0x6c, 0xf2, 0xb5, 0x53, 0xa2, 0x59,
+	0x42, 0x5f, 0xf8, 0xf5, 0x54, 0xa8, 0xaa, 0xdc, 0xaf, 0x13, 0x61, 0x76, 0x69, 0x4b, 0x4d, 0x83,
+	0x9a, 0x06, 0x35, 0x25, 0x75, 0x1d, 0x7e, 0xf8, 0xf5, 0x7f, 0x00, 0x76, 0xaf, 0x90, 0xca, 0xfc,
+	0x01, 0x00, 0x00,
 }
@@ -30,6 +30,25 @@ func (m *APIResource) GetPolicyRef() string {
 	return ""
 }
 
+// ACLs provides mappings for resources in a channel, as the ACLs value of the
+// Application group of the channel configuration. APIResource encapsulates the
+// reference to the policy used to determine the ACL for the resource
+type ACLs struct {
+	Acls map[string]*APIResource `protobuf:"bytes,1,rep,name=acls" json:"acls,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+}
+
+func (m *ACLs) Reset()                    { *m = ACLs{} }
+func (m *ACLs) String() string            { return proto.CompactTextString(m) }
+func (*ACLs) ProtoMessage()               {}
+func (*ACLs) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{1} }
+
+func (m *ACLs) GetAcls() map[string]*APIResource {
+	if m != nil {
+		return m.Acls
+	}
+	return nil
+}
+
 // ChaincodeIdentifier identifies a piece of chaincode.  For a peer to accept invocations of
 // this chaincode, the hash of the installed code must match, as must the version string
 // included with the install command.
@@ -41,7 +60,7 @@ type ChaincodeIdentifier struct {
 func (m *ChaincodeIdentifier) Reset()                    { *m = ChaincodeIdentifier{} }
 func (m *ChaincodeIdentifier) String() string            { return proto.CompactTextString(m) }
 func (*ChaincodeIdentifier) ProtoMessage()               {}
-func (*ChaincodeIdentifier) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{1} }
+func (*ChaincodeIdentifier) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{2} }
 
 func (m *ChaincodeIdentifier) GetHash() []byte {
 	if m != nil {
@@ -70,7 +89,7 @@ type ChaincodeValidation struct {
 func (m *ChaincodeValidation) Reset()                    { *m = ChaincodeValidation{} }
 func (m *ChaincodeValidation) String() string            { return proto.CompactTextString(m) }
 func (*ChaincodeValidation) ProtoMessage()               {}
-func (*ChaincodeValidation) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{2} }
+func (*ChaincodeValidation) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{3} }
 
 func (m *ChaincodeValidation) GetName() string {
 	if m != nil {
@@ -95,7 +114,7 @@ type VSCCArgs struct {
 func (m *VSCCArgs) Reset()                    { *m = VSCCArgs{} }
 func (m *VSCCArgs) String() string            { return proto.CompactTextString(m) }
 func (*VSCCArgs) ProtoMessage()               {}
-func (*VSCCArgs) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{3} }
+func (*VSCCArgs) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{4} }
 
 func (m *VSCCArgs) GetEndorsementPolicyRef() string {
 	if m != nil {
@@ -115,7 +134,7 @@ type ChaincodeEndorsement struct {
 func (m *ChaincodeEndorsement) Reset()                    { *m = ChaincodeEndorsement{} }
 func (m *ChaincodeEndorsement) String() string            { return proto.CompactTextString(m) }
 func (*ChaincodeEndorsement) ProtoMessage()               {}
-func (*ChaincodeEndorsement) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{4} }
+func (*ChaincodeEndorsement) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{5} }
 
 func (m *ChaincodeEndorsement) GetName() string {
 	if m != nil {
@@ -134,7 +153,7 @@ type ConfigTree struct {
 func (m *ConfigTree) Reset()                    { *m = ConfigTree{} }
 func (m *ConfigTree) String() string            { return proto.CompactTextString(m) }
 func (*ConfigTree) ProtoMessage()               {}
-func (*ConfigTree) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{5} }
+func (*ConfigTree) Descriptor() ([]byte, []int) { return fileDescriptor10, []int{6} }
 
 func (m *ConfigTree) GetChannelConfig() *common3.Config {
 	if m != nil {
@@ -152,6 +171,7 @@ func (m *ConfigTree) GetResourcesConfig() *common3.Config {
 
 func init() {
 	proto.RegisterType((*APIResource)(nil), "protos.APIResource")
+	proto.RegisterType((*ACLs)(nil), "protos.ACLs")
 	proto.RegisterType((*ChaincodeIdentifier)(nil), "protos.ChaincodeIdentifier")
 	proto.RegisterType((*ChaincodeValidation)(nil), "protos.ChaincodeValidation")
 	proto.RegisterType((*VSCCArgs)(nil), "protos.VSCCArgs")
@@ -162,27 +182,31 @@ func init() {
 func init() { proto.RegisterFile("peer/resources.proto", fileDescriptor10) }
 
 var fileDescriptor10 = []byte{
-	// 342 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0x4b, 0x4b, 0xeb, 0x40,
-	0x14, 0xc7, 0x69, 0xb9, 0xdc, 0xdb, 0x9e, 0xf6, 0x56, 0x19, 0xab, 0x94, 0x82, 0x20, 0x59, 0xa9,
-	0x48, 0x02, 0x3e, 0x16, 0xee, 0xac, 0xa1, 0x8b, 0xae, 0x2c, 0x51, 0xba, 0x70, 0x53, 0xa6, 0x93,
-	0x93, 0x64, 0x20, 0x99, 0x09, 0x67, 0x52, 0xb1, 0x1b, 0x3f, 0xbb, 0x64, 0xa6, 0x8d, 0x05, 0xbb,
-	0xca, 0x79, 0xfc, 0xce, 0x3f, 0xe7, 0x31, 0x30, 0x2c, 0x11, 0x29, 0x20, 0x34, 0x7a, 0x4d, 0x02,
-	0x8d, 0x5f, 0x92, 0xae, 0x34, 0xfb, 0x6b, 0x3f, 0x66, 0x7c, 0x2a, 0x74, 0x51, 0x68, 0x15, 0x08,
-	0xad, 0x12, 0x99, 0x56, 0x9f, 0x2e, 0xed, 0xdd, 0x40, 0x6f, 0x32, 0x9f, 0x45, 0xdb, 0x22, 0x76,
-	0x0e, 0x50, 0xea, 0x5c, 0x8a, 0xcd, 0x92, 0x30, 0x19, 0xb5, 0x2e, 0x5a, 0x97, 0xdd, 0xa8, 0xeb,
-	0x22, 0x11, 0x26, 0x5e, 0x08, 0x27, 0x61, 0xc6, 0xa5, 0x12, 0x3a, 0xc6, 0x59, 0x8c, 0xaa, 0x92,
-	0x89, 0x44, 0x62, 0x0c, 0xfe, 0x64, 0xdc, 0x64, 0x96, 0xef, 0x47, 0xd6, 0x66, 0x23, 0xf8, 0xf7,
-	0x81, 0x64, 0xa4, 0x56, 0xa3, 0xb6, 0x95, 0xd9, 0xb9, 0xde, 0x74, 0x4f, 0x64, 0xc1, 0x73, 0x19,
-	0xf3, 0x4a, 0x6a, 0x55, 0x8b, 0x28, 0x5e, 0xe0, 0xf6, 0xa7, 0xd6, 0x66, 0x63, 0xe8, 0x70, 0x4a,
-	0xd7, 0x05, 0xaa, 0xca, 0xaa, 0xf4, 0xa3, 0xc6, 0xf7, 0x9e, 0xa0, 0xb3, 0x78, 0x0d, 0xc3, 0x09,
-	0xa5, 0x86, 0xdd, 0xc3, 0x19, 0xaa, 0x58, 0x93, 0xc1, 0x3a, 0xb5, 0xfc, 0x35, 0xc2, 0x70, 0x2f,
-	0x3b, 0x6f, 0xa6, 0xb9, 0x86, 0x61, 0xd3, 0xc8, 0xf4, 0x07, 0x38, 0xd4, 0x89, 0xf7, 0x05, 0x10,
-	0xda, 0xcd, 0xbd, 0x11, 0x22, 0x7b, 0x80, 0x81, 0xc8, 0xb8, 0x52, 0x98, 0x2f, 0xdd, 0x3e, 0x2d,
-	0xdb, 0xbb, 0x1d, 0xf8, 0x6e, 0xcb, 0xbe, 0x63, 0xa3, 0xff, 0x5b, 0xca, 0xb9, 0xec, 0x11, 0x8e,
-	0x9b, 0xf3, 0xec, 0x0a, 0xdb, 0x07, 0x0b, 0x8f, 0x1a, 0xce, 0x05, 0x9e, 0x5f, 0xc0, 0xd3, 0x94,
-	0xfa, 0xd9, 0xa6, 0x44, 0xca, 0x31, 0x4e, 0x91, 0xfc, 0x84, 0xaf, 0x48, 0x0a, 0x77, 0x47, 0xe3,
-	0xd7, 0xc7, 0x7f, 0xbf, 0x4a, 0x65, 0x95, 0xad, 0x57, 0xb5, 0x58, 0xb0, 0x87, 0x06, 0x0e, 0x0d,
-	0x1c, 0x1a, 0xd4, 0xe8, 0xca, 0xbd, 0x8b, 0xbb, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0x20, 0xfe,
-	0xe3, 0xfb, 0x36, 0x02, 0x00, 0x00,
+	// 415 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0xcf, 0x6b, 0xdb, 0x30,
+	0x14, 0xc7, 0x71, 0x9a, 0x6d, 0xcd, 0x4b, 0xd7, 0x15, 0x35, 0x2b, 0x21, 0x30, 0x08, 0x3e, 0xa5,
+	0x65, 0xd8, 0x90, 0x6d, 0xb0, 0xed, 0xb4, 0xcc, 0xe4, 0x50, 0x28, 0xac, 0x68, 0xa3, 0x87, 0x5d,
+	0x82, 0x22, 0x3f, 0xdb, 0x62, 0x8e, 0x14, 0x9e, 0x9c, 0x32, 0x5f, 0xc6, 0xfe, 0xf4, 0x61, 0xc9,
+	0x71, 0x03, 0xcb, 0x49, 0xef, 0xc7, 0xe7, 0x7d, 0x79, 0xe8, 0xfb, 0x60, 0xb4, 0x45, 0xa4, 0x98,
+	0xd0, 0x9a, 0x1d, 0x49, 0xb4, 0xd1, 0x96, 0x4c, 0x65, 0xd8, 0x73, 0xf7, 0xd8, 0xc9, 0x6b, 0x69,
+	0x36, 0x1b, 0xa3, 0x63, 0x69, 0x74, 0xa6, 0xf2, 0xea, 0xb7, 0x6f, 0x87, 0x6f, 0x61, 0xb8, 0xb8,
+	0xbf, 0xe5, 0xed, 0x10, 0x7b, 0x03, 0xb0, 0x35, 0xa5, 0x92, 0xf5, 0x8a, 0x30, 0x1b, 0x07, 0xd3,
+	0x60, 0x36, 0xe0, 0x03, 0x5f, 0xe1, 0x98, 0x85, 0x7f, 0x03, 0xe8, 0x2f, 0x92, 0x3b, 0xcb, 0x6e,
+	0xa0, 0x2f, 0x64, 0x69, 0xc7, 0xc1, 0xf4, 0x64, 0x36, 0x9c, 0x5f, 0x79, 0x31, 0x1b, 0x35, 0xbd,
+	0x68, 0x21, 0x4b, 0xbb, 0xd4, 0x15, 0xd5, 0xdc, 0x31, 0x93, 0x3b, 0x18, 0x74, 0x25, 0x76, 0x01,
+	0x27, 0xbf, 0xb0, 0x6e, 0x95, 0x9b, 0x90, 0x5d, 0xc3, 0xb3, 0x47, 0x51, 0xee, 0x70, 0xdc, 0x9b,
+	0x06, 0xb3, 0xe1, 0xfc, 0xb2, 0xd3, 0x7a, 0x5a, 0x8b, 0x7b, 0xe2, 0x73, 0xef, 0x63, 0x10, 0x26,
+	0x70, 0x99, 0x14, 0x42, 0x69, 0x69, 0x52, 0xbc, 0x4d, 0x51, 0x57, 0x2a, 0x53, 0x48, 0x8c, 0x41,
+	0xbf, 0x10, 0xb6, 0x70, 0xc2, 0x67, 0xdc, 0xc5, 0x6c, 0x0c, 0x2f, 0x1e, 0x91, 0xac, 0x32, 0xda,
+	0x69, 0x0f, 0xf8, 0x3e, 0x0d, 0x97, 0x07, 0x22, 0x0f, 0xa2, 0x54, 0xa9, 0xa8, 0x94, 0xd1, 0x8d,
+	0x88, 0x16, 0x1b, 0x6c, 0xb7, 0x73, 0x31, 0x9b, 0xc0, 0xa9, 0xa0, 0x7c, 0xb7, 0x41, 0x5d, 0x39,
+	0x95, 0x33, 0xde, 0xe5, 0xe1, 0x17, 0x38, 0x7d, 0xf8, 0x9e, 0x24, 0x0b, 0xca, 0x2d, 0x7b, 0x0f,
+	0x57, 0xa8, 0x53, 0x43, 0x16, 0x9b, 0xd6, 0xea, 0xbf, 0x5f, 0x1c, 0x1d, 0x74, 0xef, 0xbb, 0x0f,
+	0xbd, 0x81, 0x51, 0xb7, 0xc8, 0xf2, 0x09, 0x38, 0xb6, 0x49, 0xf8, 0x07, 0x20, 0x71, 0xe6, 0xfd,
+	0x20, 0x44, 0xf6, 0x01, 0xce, 0x65, 0x21, 0xb4, 0xc6, 0x72, 0xe5, 0x2d, 0x75, 0xec, 0x70, 0x7e,
+	0x1e, 0x79, 0xa3, 0x23, 0xcf, 0xf2, 0x97, 0x2d, 0xe5, 0x53, 0xf6, 0x09, 0x2e, 0xba, 0x0b, 0xd9,
+	0x0f, 0xf6, 0x8e, 0x0e, 0xbe, 0xea, 0x38, 0x5f, 0xf8, 0xfa, 0x0d, 0x42, 0x43, 0x79, 0x54, 0xd4,
+	0x5b, 0xa4, 0x12, 0xd3, 0x1c, 0x29, 0xca, 0xc4, 0x9a, 0x94, 0xdc, 0x3b, 0xd6, 0xdc, 0xdf, 0xcf,
+	0xeb, 0x5c, 0x55, 0xc5, 0x6e, 0xdd, 0x88, 0xc5, 0x07, 0x68, 0xec, 0xd1, 0xd8, 0xa3, 0x71, 0x83,
+	0xae, 0xfd, 0x69, 0xbe, 0xfb, 0x37, 0x00, 0xd5, 0xea, 0xee, 0xfa, 0xb9, 0x02, 0x00, 0x00,
 }
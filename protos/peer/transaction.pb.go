@@ -168,6 +168,137 @@ func (m *ProcessedTransaction) GetValidationCode() int32 {
 	return 0
 }
 
+// TransactionStatus holds the position of a committed transaction in the ledger,
+// along with the indication of whether it was validated or invalidated by committing peer
+type TransactionStatus struct {
+	TxId           string           `protobuf:"bytes,1,opt,name=tx_id,json=txId" json:"tx_id,omitempty"`
+	BlockNumber    uint64           `protobuf:"varint,2,opt,name=block_number,json=blockNumber" json:"block_number,omitempty"`
+	TxNumber       uint64           `protobuf:"varint,3,opt,name=tx_number,json=txNumber" json:"tx_number,omitempty"`
+	ValidationCode TxValidationCode `protobuf:"varint,4,opt,name=validation_code,json=validationCode,enum=protos.TxValidationCode" json:"validation_code,omitempty"`
+}
+
+func (m *TransactionStatus) Reset()                    { *m = TransactionStatus{} }
+func (m *TransactionStatus) String() string            { return proto.CompactTextString(m) }
+func (*TransactionStatus) ProtoMessage()               {}
+func (*TransactionStatus) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{2} }
+
+func (m *TransactionStatus) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *TransactionStatus) GetBlockNumber() uint64 {
+	if m != nil {
+		return m.BlockNumber
+	}
+	return 0
+}
+
+func (m *TransactionStatus) GetTxNumber() uint64 {
+	if m != nil {
+		return m.TxNumber
+	}
+	return 0
+}
+
+func (m *TransactionStatus) GetValidationCode() TxValidationCode {
+	if m != nil {
+		return m.ValidationCode
+	}
+	return TxValidationCode_VALID
+}
+
+// TransactionStatuses is the list of transactions returned by the ledger queries
+// that look up transactions by block range, creator or chaincode
+type TransactionStatuses struct {
+	Transactions []*TransactionStatus `protobuf:"bytes,1,rep,name=transactions" json:"transactions,omitempty"`
+	// next_block_number is set when the number of transactions reached the limit of a query.
+	// The remaining transactions are returned by the same query starting at that block.
+	// It is 0 when all the transactions of the requested range were returned
+	NextBlockNumber uint64 `protobuf:"varint,2,opt,name=next_block_number,json=nextBlockNumber" json:"next_block_number,omitempty"`
+}
+
+func (m *TransactionStatuses) Reset()                    { *m = TransactionStatuses{} }
+func (m *TransactionStatuses) String() string            { return proto.CompactTextString(m) }
+func (*TransactionStatuses) ProtoMessage()               {}
+func (*TransactionStatuses) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{3} }
+
+func (m *TransactionStatuses) GetTransactions() []*TransactionStatus {
+	if m != nil {
+		return m.Transactions
+	}
+	return nil
+}
+
+func (m *TransactionStatuses) GetNextBlockNumber() uint64 {
+	if m != nil {
+		return m.NextBlockNumber
+	}
+	return 0
+}
+
+// TxMVCCConflict records the conflict that caused a transaction to be
+// invalidated by the MVCC validation of committing peers
+type TxMVCCConflict struct {
+	TxId           string           `protobuf:"bytes,1,opt,name=tx_id,json=txId" json:"tx_id,omitempty"`
+	TxNumber       uint64           `protobuf:"varint,2,opt,name=tx_number,json=txNumber" json:"tx_number,omitempty"`
+	ValidationCode TxValidationCode `protobuf:"varint,3,opt,name=validation_code,json=validationCode,enum=protos.TxValidationCode" json:"validation_code,omitempty"`
+	Conflict       *MVCCConflict    `protobuf:"bytes,4,opt,name=conflict" json:"conflict,omitempty"`
+}
+
+func (m *TxMVCCConflict) Reset()                    { *m = TxMVCCConflict{} }
+func (m *TxMVCCConflict) String() string            { return proto.CompactTextString(m) }
+func (*TxMVCCConflict) ProtoMessage()               {}
+func (*TxMVCCConflict) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{4} }
+
+func (m *TxMVCCConflict) GetTxId() string {
+	if m != nil {
+		return m.TxId
+	}
+	return ""
+}
+
+func (m *TxMVCCConflict) GetTxNumber() uint64 {
+	if m != nil {
+		return m.TxNumber
+	}
+	return 0
+}
+
+func (m *TxMVCCConflict) GetValidationCode() TxValidationCode {
+	if m != nil {
+		return m.ValidationCode
+	}
+	return TxValidationCode_VALID
+}
+
+func (m *TxMVCCConflict) GetConflict() *MVCCConflict {
+	if m != nil {
+		return m.Conflict
+	}
+	return nil
+}
+
+// TxMVCCConflicts is stored by committing peers in the MVCC_CONFLICTS block
+// metadata, alongside the validation flags of the transactions of the block
+type TxMVCCConflicts struct {
+	Conflicts []*TxMVCCConflict `protobuf:"bytes,1,rep,name=conflicts" json:"conflicts,omitempty"`
+}
+
+func (m *TxMVCCConflicts) Reset()                    { *m = TxMVCCConflicts{} }
+func (m *TxMVCCConflicts) String() string            { return proto.CompactTextString(m) }
+func (*TxMVCCConflicts) ProtoMessage()               {}
+func (*TxMVCCConflicts) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{5} }
+
+func (m *TxMVCCConflicts) GetConflicts() []*TxMVCCConflict {
+	if m != nil {
+		return m.Conflicts
+	}
+	return nil
+}
+
 // The transaction to be sent to the ordering service. A transaction contains
 // one or more TransactionAction. Each TransactionAction binds a proposal to
 // potentially multiple actions. The transaction is atomic meaning that either
@@ -189,7 +320,7 @@ type Transaction struct {
 func (m *Transaction) Reset()                    { *m = Transaction{} }
 func (m *Transaction) String() string            { return proto.CompactTextString(m) }
 func (*Transaction) ProtoMessage()               {}
-func (*Transaction) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{2} }
+func (*Transaction) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{6} }
 
 func (m *Transaction) GetActions() []*TransactionAction {
 	if m != nil {
@@ -211,7 +342,7 @@ type TransactionAction struct {
 func (m *TransactionAction) Reset()                    { *m = TransactionAction{} }
 func (m *TransactionAction) String() string            { return proto.CompactTextString(m) }
 func (*TransactionAction) ProtoMessage()               {}
-func (*TransactionAction) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{3} }
+func (*TransactionAction) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{7} }
 
 func (m *TransactionAction) GetHeader() []byte {
 	if m != nil {
@@ -248,7 +379,7 @@ type ChaincodeActionPayload struct {
 func (m *ChaincodeActionPayload) Reset()                    { *m = ChaincodeActionPayload{} }
 func (m *ChaincodeActionPayload) String() string            { return proto.CompactTextString(m) }
 func (*ChaincodeActionPayload) ProtoMessage()               {}
-func (*ChaincodeActionPayload) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{4} }
+func (*ChaincodeActionPayload) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{8} }
 
 func (m *ChaincodeActionPayload) GetChaincodeProposalPayload() []byte {
 	if m != nil {
@@ -279,7 +410,7 @@ type ChaincodeEndorsedAction struct {
 func (m *ChaincodeEndorsedAction) Reset()                    { *m = ChaincodeEndorsedAction{} }
 func (m *ChaincodeEndorsedAction) String() string            { return proto.CompactTextString(m) }
 func (*ChaincodeEndorsedAction) ProtoMessage()               {}
-func (*ChaincodeEndorsedAction) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{5} }
+func (*ChaincodeEndorsedAction) Descriptor() ([]byte, []int) { return fileDescriptor12, []int{9} }
 
 func (m *ChaincodeEndorsedAction) GetProposalResponsePayload() []byte {
 	if m != nil {
@@ -298,6 +429,10 @@ func (m *ChaincodeEndorsedAction) GetEndorsements() []*Endorsement {
 func init() {
 	proto.RegisterType((*SignedTransaction)(nil), "protos.SignedTransaction")
 	proto.RegisterType((*ProcessedTransaction)(nil), "protos.ProcessedTransaction")
+	proto.RegisterType((*TransactionStatus)(nil), "protos.TransactionStatus")
+	proto.RegisterType((*TransactionStatuses)(nil), "protos.TransactionStatuses")
+	proto.RegisterType((*TxMVCCConflict)(nil), "protos.TxMVCCConflict")
+	proto.RegisterType((*TxMVCCConflicts)(nil), "protos.TxMVCCConflicts")
 	proto.RegisterType((*Transaction)(nil), "protos.Transaction")
 	proto.RegisterType((*TransactionAction)(nil), "protos.TransactionAction")
 	proto.RegisterType((*ChaincodeActionPayload)(nil), "protos.ChaincodeActionPayload")
@@ -308,58 +443,69 @@ func init() {
 func init() { proto.RegisterFile("peer/transaction.proto", fileDescriptor12) }
 
 var fileDescriptor12 = []byte{
-	// 833 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0xd1, 0x6e, 0xe2, 0x46,
-	0x14, 0x2d, 0xd9, 0x4d, 0xd2, 0x0c, 0xd9, 0x64, 0x32, 0x10, 0x42, 0x50, 0xd4, 0x5d, 0xf1, 0x50,
-	0x6d, 0x5b, 0x09, 0xa4, 0xec, 0x43, 0xa5, 0xaa, 0x2f, 0x83, 0x3d, 0x09, 0x56, 0xcd, 0x8c, 0x35,
-	0x1e, 0x08, 0xe9, 0x43, 0x47, 0x06, 0xcf, 0x12, 0x54, 0xb0, 0x2d, 0xdb, 0x59, 0x35, 0xaf, 0xfd,
-	0x80, 0xf6, 0x3f, 0xfb, 0x13, 0xad, 0xc6, 0x63, 0x03, 0xc9, 0x76, 0x5f, 0x30, 0x73, 0xcf, 0xb9,
-	0xf7, 0x9c, 0x7b, 0xaf, 0x3d, 0xa0, 0x95, 0x28, 0x95, 0xf6, 0xf3, 0x34, 0x88, 0xb2, 0x60, 0x9e,
-	0x2f, 0xe3, 0xa8, 0x97, 0xa4, 0x71, 0x1e, 0xa3, 0x83, 0xe2, 0x91, 0x75, 0xde, 0x2e, 0xe2, 0x78,
-	0xb1, 0x52, 0xfd, 0xe2, 0x38, 0x7b, 0xfc, 0xd8, 0xcf, 0x97, 0x6b, 0x95, 0xe5, 0xc1, 0x3a, 0x31,
-	0xc4, 0xce, 0x55, 0x51, 0x20, 0x49, 0xe3, 0x24, 0xce, 0x82, 0x95, 0x4c, 0x55, 0x96, 0xc4, 0x51,
-	0xa6, 0x4a, 0xb4, 0x31, 0x8f, 0xd7, 0xeb, 0x38, 0xea, 0x9b, 0x87, 0x09, 0x76, 0x7f, 0x03, 0x67,
-	0xfe, 0x72, 0x11, 0xa9, 0x50, 0x6c, 0x65, 0xd1, 0x0f, 0xe0, 0x6c, 0xc7, 0x85, 0x9c, 0x3d, 0xe5,
-	0x2a, 0x6b, 0xd7, 0xde, 0xd5, 0xde, 0x1f, 0x73, 0xb8, 0x03, 0x0c, 0x74, 0x1c, 0x5d, 0x81, 0xa3,
-	0x6c, 0xb9, 0x88, 0x82, 0xfc, 0x31, 0x55, 0xed, 0xbd, 0x82, 0xb4, 0x0d, 0x74, 0xff, 0xac, 0x81,
-	0xa6, 0x97, 0xc6, 0x73, 0x95, 0x65, 0xcf, 0x35, 0x06, 0xa0, 0xb1, 0x53, 0x8a, 0x44, 0x9f, 0xd4,
-	0x2a, 0x4e, 0x54, 0xa1, 0x52, 0xbf, 0x86, 0xbd, 0xd2, 0x64, 0x15, 0xe7, 0xff, 0x47, 0x46, 0xdf,
-	0x82, 0x93, 0x4f, 0xc1, 0x6a, 0x19, 0x06, 0x3a, 0x6a, 0xc5, 0xa1, 0xd1, 0xdf, 0xe7, 0x2f, 0xa2,
-	0xdd, 0x01, 0xa8, 0xef, 0x4a, 0x7f, 0x00, 0x87, 0xe6, 0x9f, 0x6e, 0xea, 0xd5, 0xfb, 0xfa, 0xf5,
-	0xa5, 0x19, 0x46, 0xd6, 0xdb, 0x61, 0xe1, 0xe2, 0x97, 0x57, 0xcc, 0x2e, 0x01, 0x67, 0x9f, 0xa1,
-	0xa8, 0x05, 0x0e, 0x1e, 0x54, 0x10, 0xaa, 0xb4, 0x9c, 0x4e, 0x79, 0x42, 0x6d, 0x70, 0x98, 0x04,
-	0x4f, 0xab, 0x38, 0x08, 0xcb, 0x89, 0x54, 0xc7, 0xee, 0xdf, 0x35, 0xd0, 0xb2, 0x1e, 0x82, 0x65,
-	0x34, 0x8f, 0x43, 0x65, 0xaa, 0x78, 0x06, 0x42, 0x3f, 0x83, 0xce, 0xbc, 0x42, 0xe4, 0x66, 0x89,
-	0x55, 0x1d, 0x23, 0xd0, 0xde, 0x30, 0xbc, 0x92, 0x50, 0x65, 0xff, 0x08, 0x0e, 0x8c, 0xb5, 0x42,
-	0xb1, 0x7e, 0xfd, 0xb6, 0xea, 0x69, 0xa3, 0x46, 0xa2, 0x30, 0x4e, 0x33, 0x15, 0x96, 0x9d, 0x95,
-	0xf4, 0xee, 0x5f, 0x35, 0x70, 0xf1, 0x05, 0x0e, 0xfa, 0x09, 0x5c, 0x7e, 0xf6, 0x36, 0xbd, 0x70,
-	0x74, 0x51, 0x11, 0x78, 0x89, 0x6f, 0x0d, 0x1d, 0x2b, 0x53, 0x6d, 0xad, 0xa2, 0x3c, 0x6b, 0xef,
-	0x15, 0xa3, 0x6e, 0x54, 0xb6, 0xc8, 0x16, 0xe3, 0xcf, 0x88, 0xdf, 0xff, 0xf3, 0x1a, 0x40, 0xf1,
-	0xc7, 0xe4, 0xd9, 0x0a, 0xd1, 0x11, 0xd8, 0x9f, 0x60, 0xd7, 0xb1, 0xe1, 0x57, 0x08, 0x82, 0x63,
-	0xea, 0xb8, 0x92, 0xd0, 0x09, 0x71, 0x99, 0x47, 0x60, 0x0d, 0x9d, 0x82, 0xfa, 0x00, 0xdb, 0xd2,
-	0xc3, 0xf7, 0x2e, 0xc3, 0x36, 0xdc, 0x43, 0xe7, 0xe0, 0x4c, 0x07, 0x2c, 0x36, 0x1a, 0x31, 0x2a,
-	0x87, 0x04, 0xdb, 0x84, 0xc3, 0x57, 0xe8, 0x12, 0x9c, 0x17, 0x61, 0x4e, 0xb0, 0x60, 0x5c, 0xfa,
-	0xce, 0x2d, 0xc5, 0x62, 0xcc, 0x09, 0x7c, 0x8d, 0xde, 0x81, 0x2b, 0x87, 0x16, 0x0a, 0x92, 0x50,
-	0x9b, 0x71, 0x9f, 0x70, 0x29, 0x38, 0xa6, 0x3e, 0xb6, 0x84, 0xc3, 0x28, 0xdc, 0x47, 0xdf, 0x80,
-	0x4e, 0xc5, 0xb0, 0x18, 0xbd, 0x71, 0x6e, 0x9f, 0xe1, 0x07, 0xa8, 0x03, 0x5a, 0x63, 0xea, 0x8f,
-	0x3d, 0x8f, 0x71, 0x41, 0x6c, 0x29, 0xa6, 0x1b, 0x3f, 0x87, 0x95, 0x1f, 0x8f, 0x33, 0x8f, 0xf9,
-	0xd8, 0x95, 0x62, 0xea, 0xd8, 0xf0, 0x6b, 0x84, 0xc0, 0x89, 0x3d, 0xf6, 0x5c, 0xc7, 0xc2, 0x82,
-	0x98, 0xd8, 0x91, 0x96, 0x29, 0x0d, 0x8c, 0x08, 0x15, 0xd2, 0x63, 0xae, 0x63, 0xdd, 0xcb, 0x1b,
-	0xec, 0xb8, 0xda, 0x28, 0x40, 0x2d, 0x80, 0x46, 0x13, 0xcb, 0x92, 0x9c, 0x60, 0x63, 0xc4, 0x75,
-	0x2c, 0x01, 0xeb, 0xba, 0x37, 0x6f, 0x88, 0xa9, 0x60, 0xa3, 0x17, 0xd0, 0x31, 0x6a, 0x80, 0xd3,
-	0x31, 0xfd, 0x85, 0xb2, 0x3b, 0xaa, 0x5d, 0x89, 0x7b, 0x8f, 0xc0, 0x37, 0xda, 0xae, 0xc0, 0xfc,
-	0x96, 0x08, 0x69, 0x0d, 0xb1, 0x43, 0x25, 0x65, 0x42, 0xde, 0xb0, 0x31, 0xb5, 0xe1, 0x09, 0x6a,
-	0x02, 0x38, 0xc2, 0xdc, 0x1f, 0x16, 0x4e, 0x25, 0xe1, 0x9c, 0x71, 0x78, 0x5a, 0xcd, 0x5d, 0x4c,
-	0xcb, 0x96, 0xa1, 0x6e, 0x8b, 0x4c, 0x3d, 0x87, 0x13, 0xdb, 0x14, 0xb1, 0x98, 0x4d, 0xe0, 0x99,
-	0x6e, 0x61, 0x73, 0x94, 0x13, 0xc2, 0x7d, 0x87, 0xd1, 0xad, 0x1f, 0x84, 0xda, 0xa0, 0xa9, 0xa7,
-	0x61, 0xd6, 0x22, 0xc9, 0x54, 0x10, 0xaa, 0x29, 0xb0, 0xa1, 0x9b, 0x2b, 0x16, 0x34, 0xc4, 0x94,
-	0x12, 0xb7, 0x5a, 0x5c, 0xb3, 0xca, 0xe0, 0xc4, 0xf7, 0x18, 0xf5, 0xc9, 0x66, 0xb2, 0xe7, 0xe8,
-	0x0d, 0x38, 0x2a, 0x90, 0x3b, 0x9f, 0x08, 0xd8, 0xd2, 0xce, 0x1d, 0xd7, 0x25, 0xb7, 0xd8, 0x95,
-	0x77, 0xdc, 0x11, 0x44, 0x47, 0x2f, 0xd0, 0x25, 0x68, 0x56, 0xab, 0x63, 0x62, 0x48, 0xb8, 0x9e,
-	0x90, 0xcf, 0x28, 0xfc, 0xb7, 0x36, 0x98, 0x83, 0x6e, 0x9c, 0x2e, 0x7a, 0x0f, 0x4f, 0x89, 0x4a,
-	0x57, 0x2a, 0x5c, 0xa8, 0xb4, 0xf7, 0x31, 0x98, 0xa5, 0xcb, 0x79, 0xf5, 0x9e, 0xea, 0x2b, 0x75,
-	0x80, 0x76, 0x3e, 0x7d, 0x2f, 0x98, 0xff, 0x1e, 0x2c, 0xd4, 0xaf, 0xdf, 0x2d, 0x96, 0xf9, 0xc3,
-	0xe3, 0x4c, 0xdf, 0x54, 0xfd, 0x9d, 0xf4, 0xbe, 0x49, 0x37, 0x97, 0x74, 0xd6, 0xd7, 0xe9, 0x33,
-	0x73, 0x81, 0x7f, 0xf8, 0x2f, 0x00, 0x00, 0xff, 0xff, 0x19, 0x1c, 0xb2, 0xe4, 0xe1, 0x05, 0x00,
-	0x00,
+	// 1018 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x55, 0xdb, 0x6e, 0xea, 0x46,
+	0x17, 0xfe, 0xc9, 0x99, 0x05, 0x3b, 0x98, 0x81, 0x10, 0x92, 0x3f, 0xea, 0x4e, 0xb9, 0xa8, 0xd2,
+	0x5d, 0x29, 0x54, 0xd9, 0x95, 0x2a, 0x55, 0xed, 0x85, 0x31, 0x93, 0x60, 0xd5, 0xcc, 0x58, 0xe3,
+	0x21, 0x87, 0x5e, 0x74, 0x64, 0x60, 0x42, 0xd0, 0x06, 0x1b, 0xd9, 0xce, 0x16, 0xb9, 0xab, 0xfa,
+	0x00, 0xed, 0x83, 0xf4, 0x01, 0xfa, 0x4e, 0x7d, 0x89, 0x56, 0xe3, 0x03, 0x87, 0x24, 0xd5, 0x56,
+	0x6f, 0xb0, 0x66, 0x7d, 0xdf, 0x5a, 0xeb, 0x5b, 0xdf, 0xcc, 0x30, 0x50, 0x9b, 0x49, 0x19, 0x34,
+	0xa3, 0xc0, 0xf5, 0x42, 0x77, 0x10, 0x8d, 0x7d, 0xef, 0x7c, 0x16, 0xf8, 0x91, 0x8f, 0x76, 0xe2,
+	0x4f, 0x78, 0xfc, 0x76, 0xe4, 0xfb, 0xa3, 0x89, 0x6c, 0xc6, 0xcb, 0xfe, 0xe3, 0x7d, 0x33, 0x1a,
+	0x4f, 0x65, 0x18, 0xb9, 0xd3, 0x59, 0x42, 0x3c, 0x3e, 0x89, 0x0b, 0xcc, 0x02, 0x7f, 0xe6, 0x87,
+	0xee, 0x44, 0x04, 0x32, 0x9c, 0xf9, 0x5e, 0x28, 0x53, 0xb4, 0x32, 0xf0, 0xa7, 0x53, 0xdf, 0x6b,
+	0x26, 0x9f, 0x24, 0xd8, 0xf8, 0x19, 0xca, 0xce, 0x78, 0xe4, 0xc9, 0x21, 0x5f, 0xb6, 0x45, 0x5f,
+	0x41, 0x79, 0x45, 0x85, 0xe8, 0x3f, 0x45, 0x32, 0xac, 0xe7, 0x4e, 0x73, 0x67, 0x45, 0xa6, 0xad,
+	0x00, 0x2d, 0x15, 0x47, 0x27, 0x90, 0x0f, 0xc7, 0x23, 0xcf, 0x8d, 0x1e, 0x03, 0x59, 0xdf, 0x88,
+	0x49, 0xcb, 0x40, 0xe3, 0xd7, 0x1c, 0x54, 0xed, 0xc0, 0x1f, 0xc8, 0x30, 0x5c, 0xef, 0xd1, 0x82,
+	0xca, 0x4a, 0x29, 0xec, 0x7d, 0x94, 0x13, 0x7f, 0x26, 0xe3, 0x2e, 0x85, 0x0b, 0xed, 0x3c, 0x15,
+	0x99, 0xc5, 0xd9, 0x6b, 0x64, 0xf4, 0x05, 0xec, 0x7f, 0x74, 0x27, 0xe3, 0xa1, 0xab, 0xa2, 0x86,
+	0x3f, 0x4c, 0xfa, 0x6f, 0xb3, 0x67, 0xd1, 0xc6, 0x1f, 0x39, 0x28, 0xaf, 0xf4, 0x76, 0x22, 0x37,
+	0x7a, 0x0c, 0x51, 0x05, 0xb6, 0xa3, 0xb9, 0x18, 0x0f, 0xe3, 0x9e, 0x79, 0xb6, 0x15, 0xcd, 0xcd,
+	0x21, 0xfa, 0x1c, 0x8a, 0xfd, 0x89, 0x3f, 0xf8, 0x20, 0xbc, 0xc7, 0x69, 0x5f, 0x06, 0x71, 0xc1,
+	0x2d, 0x56, 0x88, 0x63, 0x24, 0x0e, 0xa1, 0xff, 0x43, 0x3e, 0x9a, 0x67, 0xf8, 0x66, 0x8c, 0xef,
+	0x45, 0xf3, 0x14, 0xd4, 0xa1, 0xb4, 0x6c, 0x2e, 0x06, 0x4a, 0xd3, 0xd6, 0x69, 0xee, 0x6c, 0xff,
+	0xa2, 0x9e, 0x18, 0x1e, 0x9e, 0xf3, 0xf9, 0xf5, 0x9a, 0xba, 0x17, 0x6a, 0x7f, 0xc9, 0x41, 0xe5,
+	0x85, 0x5a, 0x19, 0xa2, 0x1f, 0xa0, 0xb8, 0x62, 0x82, 0xda, 0x90, 0xcd, 0xb3, 0xc2, 0xc5, 0xd1,
+	0xa2, 0xee, 0xf3, 0x14, 0xb6, 0x46, 0x47, 0xef, 0xa0, 0xec, 0xc9, 0x79, 0x24, 0x5e, 0x19, 0xaf,
+	0xa4, 0x80, 0xd6, 0x72, 0xc4, 0xc6, 0x9f, 0x39, 0xd8, 0xe7, 0xf3, 0xee, 0xb5, 0x61, 0x18, 0xbe,
+	0x77, 0x3f, 0x19, 0x0f, 0xa2, 0xd7, 0xdd, 0x5a, 0xb3, 0x62, 0xe3, 0xd3, 0x56, 0x6c, 0xfe, 0x37,
+	0x2b, 0xd0, 0xd7, 0xb0, 0x37, 0x48, 0x05, 0xc4, 0x36, 0x16, 0x2e, 0xaa, 0x59, 0xee, 0xaa, 0x38,
+	0xb6, 0x60, 0x35, 0xae, 0xa0, 0xb4, 0x2e, 0x3c, 0x44, 0xdf, 0x40, 0x3e, 0x83, 0x33, 0xd3, 0x6a,
+	0x4b, 0x05, 0x6b, 0x75, 0x96, 0xc4, 0x46, 0x0b, 0x0a, 0xab, 0xc7, 0xf5, 0x3d, 0xec, 0x7e, 0xda,
+	0x77, 0x3d, 0xfe, 0x65, 0x19, 0xb3, 0x81, 0xa1, 0xfc, 0x02, 0x45, 0x35, 0xd8, 0x79, 0x90, 0xee,
+	0x50, 0x06, 0xe9, 0x8d, 0x4a, 0x57, 0xa8, 0x0e, 0xbb, 0x33, 0xf7, 0x69, 0xe2, 0xbb, 0xc3, 0xf4,
+	0x16, 0x65, 0xcb, 0xc6, 0xef, 0x39, 0xa8, 0x19, 0x0f, 0xee, 0xd8, 0x53, 0x1e, 0x26, 0x55, 0xec,
+	0x04, 0x42, 0xdf, 0xc3, 0xf1, 0x20, 0x43, 0xc4, 0xe2, 0xe2, 0x67, 0x75, 0x92, 0x06, 0xf5, 0x05,
+	0xc3, 0x4e, 0x09, 0x59, 0xf6, 0xb7, 0xb0, 0x93, 0x48, 0x8b, 0x3b, 0x16, 0x2e, 0xde, 0x66, 0x33,
+	0x2d, 0xba, 0x61, 0x6f, 0xe8, 0x07, 0xa1, 0x1c, 0xa6, 0x93, 0xa5, 0xf4, 0xc6, 0x6f, 0x39, 0x38,
+	0xfc, 0x17, 0x0e, 0xfa, 0x0e, 0x8e, 0x5e, 0xfc, 0x03, 0x3d, 0x53, 0x74, 0x98, 0x11, 0x58, 0x8a,
+	0x2f, 0x05, 0x15, 0x65, 0x52, 0x6d, 0x2a, 0xbd, 0x28, 0xac, 0x6f, 0xc4, 0x56, 0x57, 0x32, 0x59,
+	0x78, 0x89, 0xb1, 0x35, 0xe2, 0xbb, 0xbf, 0xb6, 0x40, 0x7b, 0x7e, 0x9a, 0x50, 0x1e, 0xb6, 0xaf,
+	0x75, 0xcb, 0x6c, 0x6b, 0xff, 0x43, 0x1a, 0x14, 0x89, 0x69, 0x09, 0x4c, 0xae, 0xb1, 0x45, 0x6d,
+	0xac, 0xe5, 0x50, 0x09, 0x0a, 0x2d, 0xbd, 0x2d, 0x6c, 0xfd, 0xce, 0xa2, 0x7a, 0x5b, 0xdb, 0x40,
+	0x07, 0x50, 0x56, 0x01, 0x83, 0x76, 0xbb, 0x94, 0x88, 0x0e, 0xd6, 0xdb, 0x98, 0x69, 0x9b, 0xe8,
+	0x08, 0x0e, 0xe2, 0x30, 0xc3, 0x3a, 0xa7, 0x4c, 0x38, 0xe6, 0x15, 0xd1, 0x79, 0x8f, 0x61, 0x6d,
+	0x0b, 0x9d, 0xc2, 0x89, 0x49, 0xe2, 0x0e, 0x02, 0x93, 0x36, 0x65, 0x0e, 0x66, 0x82, 0x33, 0x9d,
+	0x38, 0xba, 0xc1, 0x4d, 0x4a, 0xb4, 0x6d, 0xf4, 0x19, 0x1c, 0x67, 0x0c, 0x83, 0x92, 0x4b, 0xf3,
+	0x6a, 0x0d, 0xdf, 0x41, 0xc7, 0x50, 0xeb, 0x11, 0xa7, 0x67, 0xdb, 0x94, 0x71, 0xdc, 0x16, 0xfc,
+	0x76, 0xa1, 0x67, 0x37, 0xd3, 0x63, 0x33, 0x6a, 0x53, 0x47, 0xb7, 0x04, 0xbf, 0x35, 0xdb, 0xda,
+	0x1e, 0x42, 0xb0, 0xdf, 0xee, 0xd9, 0x96, 0x69, 0xe8, 0x1c, 0x27, 0xb1, 0xbc, 0x6a, 0x93, 0x0a,
+	0xe8, 0x62, 0xc2, 0x85, 0x4d, 0x2d, 0xd3, 0xb8, 0x13, 0x97, 0xba, 0x69, 0x29, 0xa1, 0x80, 0x6a,
+	0x80, 0xd4, 0x31, 0x17, 0x0c, 0xeb, 0x89, 0x10, 0xcb, 0x34, 0xb8, 0x56, 0x50, 0xb3, 0xd9, 0x1d,
+	0x9d, 0x70, 0xda, 0x7d, 0x06, 0x15, 0x51, 0x05, 0x4a, 0x3d, 0xf2, 0x23, 0xa1, 0x37, 0x44, 0xa9,
+	0xe2, 0x77, 0x36, 0xd6, 0xde, 0x28, 0xb9, 0x5c, 0x67, 0x57, 0x98, 0x0b, 0xa3, 0xa3, 0x9b, 0x44,
+	0x10, 0xca, 0xc5, 0x25, 0xed, 0x91, 0xb6, 0xb6, 0x8f, 0xaa, 0xa0, 0x75, 0x75, 0xe6, 0x74, 0x62,
+	0xa5, 0x02, 0x33, 0x46, 0x99, 0x56, 0xca, 0x7c, 0xe7, 0xb7, 0xe9, 0xc8, 0x9a, 0x1a, 0x0b, 0xdf,
+	0xda, 0x26, 0xc3, 0xed, 0xa4, 0x88, 0x41, 0xdb, 0x58, 0x2b, 0xab, 0x11, 0x16, 0x4b, 0x71, 0x8d,
+	0x99, 0x63, 0x52, 0xb2, 0xd4, 0x83, 0x50, 0x1d, 0xaa, 0xca, 0x8d, 0x64, 0x5b, 0x04, 0xbe, 0xe5,
+	0x98, 0x28, 0x8a, 0x56, 0x51, 0xc3, 0xc5, 0x1b, 0xd4, 0xd1, 0x09, 0xc1, 0x56, 0xb6, 0x71, 0xd5,
+	0x2c, 0x83, 0x61, 0xc7, 0xa6, 0xc4, 0xc1, 0x0b, 0x67, 0x0f, 0xd0, 0x1b, 0xc8, 0xc7, 0xc8, 0x8d,
+	0x83, 0xb9, 0x56, 0x53, 0xca, 0x4d, 0xcb, 0xc2, 0x57, 0xba, 0x25, 0x6e, 0x98, 0xc9, 0xb1, 0x8a,
+	0x1e, 0xa2, 0x23, 0xa8, 0x66, 0x5b, 0x47, 0x79, 0x07, 0x33, 0xe5, 0x90, 0x43, 0x89, 0xf6, 0x77,
+	0xae, 0x35, 0x80, 0x86, 0x1f, 0x8c, 0xce, 0x1f, 0x9e, 0x66, 0x32, 0x98, 0xc8, 0xe1, 0x48, 0x06,
+	0xe7, 0xf7, 0x6e, 0x3f, 0x18, 0x0f, 0xb2, 0x73, 0xaa, 0x9e, 0xe1, 0x16, 0x5a, 0xb9, 0xfa, 0xb6,
+	0x3b, 0xf8, 0xe0, 0x8e, 0xe4, 0x4f, 0x5f, 0x8e, 0xc6, 0xd1, 0xc3, 0x63, 0x5f, 0xbd, 0x6e, 0xcd,
+	0x95, 0xf4, 0x66, 0x92, 0x9e, 0x3c, 0xec, 0x61, 0x53, 0xa5, 0xf7, 0x93, 0x47, 0xff, 0xfd, 0x3f,
+	0x03, 0x00, 0x6a, 0xdb, 0x31, 0xba, 0x15, 0x08, 0x00, 0x00,
 }
@@ -21,6 +21,7 @@ import (
 
 	"github.com/golang/protobuf/proto"
 	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
 )
 
 // GetChainIDFromBlockBytes returns chain ID given byte array which represents the block
@@ -101,6 +102,19 @@ func GetLastConfigIndexFromBlockOrPanic(block *cb.Block) uint64 {
 	return index
 }
 
+// GetMVCCConflictsFromBlock retrieves the MVCC conflicts of the invalid transactions of a block,
+// as recorded in the block metadata by the committing peer
+func GetMVCCConflictsFromBlock(block *cb.Block) (*pb.TxMVCCConflicts, error) {
+	conflicts := &pb.TxMVCCConflicts{}
+	if block.Metadata == nil || len(block.Metadata.Metadata) <= int(cb.BlockMetadataIndex_MVCC_CONFLICTS) {
+		return conflicts, nil
+	}
+	if err := proto.Unmarshal(block.Metadata.Metadata[cb.BlockMetadataIndex_MVCC_CONFLICTS], conflicts); err != nil {
+		return nil, fmt.Errorf("error unmarshaling MVCC conflicts(%s)", err)
+	}
+	return conflicts, nil
+}
+
 // GetBlockFromBlockBytes marshals the bytes into Block
 func GetBlockFromBlockBytes(blockBytes []byte) (*cb.Block, error) {
 	block := &cb.Block{}
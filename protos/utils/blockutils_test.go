@@ -24,6 +24,7 @@ import (
 	configtxtest "github.com/hyperledger/fabric/common/configtx/test"
 	"github.com/hyperledger/fabric/protos/common"
 	cb "github.com/hyperledger/fabric/protos/common"
+	pb "github.com/hyperledger/fabric/protos/peer"
 	"github.com/hyperledger/fabric/protos/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -215,3 +216,32 @@ func TestGetLastConfigIndexFromBlock(t *testing.T) {
 		_ = utils.GetLastConfigIndexFromBlockOrPanic(block)
 	}, "Expected panic with malformed last config metadata")
 }
+
+func TestGetMVCCConflictsFromBlock(t *testing.T) {
+	// block without room for the conflicts in its metadata
+	block := &cb.Block{Metadata: &cb.BlockMetadata{Metadata: [][]byte{{}, {}, {}, {}}}}
+	conflicts, err := utils.GetMVCCConflictsFromBlock(block)
+	assert.NoError(t, err, "Unexpected error returning MVCC conflicts")
+	assert.Empty(t, conflicts.Conflicts, "Expected no MVCC conflicts")
+
+	block = common.NewBlock(0, nil)
+	conflicts, err = utils.GetMVCCConflictsFromBlock(block)
+	assert.NoError(t, err, "Unexpected error returning MVCC conflicts")
+	assert.Empty(t, conflicts.Conflicts, "Expected no MVCC conflicts")
+
+	conflict := &pb.TxMVCCConflict{
+		TxId:           "txid",
+		TxNumber:       1,
+		ValidationCode: pb.TxValidationCode_MVCC_READ_CONFLICT,
+		Conflict:       &pb.MVCCConflict{Namespace: "ns", Key: "key"},
+	}
+	block.Metadata.Metadata[cb.BlockMetadataIndex_MVCC_CONFLICTS] = utils.MarshalOrPanic(&pb.TxMVCCConflicts{Conflicts: []*pb.TxMVCCConflict{conflict}})
+	conflicts, err = utils.GetMVCCConflictsFromBlock(block)
+	assert.NoError(t, err, "Unexpected error returning MVCC conflicts")
+	assert.True(t, proto.Equal(&pb.TxMVCCConflicts{Conflicts: []*pb.TxMVCCConflict{conflict}}, conflicts), "Unexpected MVCC conflicts returned from block")
+
+	// malformed metadata
+	block.Metadata.Metadata[cb.BlockMetadataIndex_MVCC_CONFLICTS] = []byte("bad metadata")
+	_, err = utils.GetMVCCConflictsFromBlock(block)
+	assert.Error(t, err, "Expected error with malformed MVCC conflicts metadata")
+}
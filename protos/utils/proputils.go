@@ -448,6 +448,13 @@ func CreateInstallProposalFromCDS(ccpack proto.Message, creator []byte) (*peer.P
 	return createProposalFromCDS("", ccpack, creator, "install")
 }
 
+// CreateInstallProposalFromPackage returns a install proposal given a serialized identity and the bytes of
+// a chaincode package, which may not be a proto message, as tar.gz packages
+func CreateInstallProposalFromPackage(ccpack []byte, creator []byte) (*peer.Proposal, string, error) {
+	ccinp := &peer.ChaincodeInput{Args: [][]byte{[]byte("install"), ccpack}}
+	return createLSCCProposal("", ccinp, creator)
+}
+
 // CreateDeployProposalFromCDS returns a deploy proposal given a serialized identity and a ChaincodeDeploymentSpec
 func CreateDeployProposalFromCDS(
 	chainID string,
@@ -497,6 +504,11 @@ func createProposalFromCDS(chainID string, msg proto.Message, creator []byte, pr
 		ccinp = &peer.ChaincodeInput{Args: [][]byte{[]byte(propType), b}}
 	}
 
+	return createLSCCProposal(chainID, ccinp, creator)
+}
+
+// createLSCCProposal returns a proposal invoking lscc with the supplied input
+func createLSCCProposal(chainID string, ccinp *peer.ChaincodeInput, creator []byte) (*peer.Proposal, string, error) {
 	//wrap the deployment in an invocation spec to lscc...
 	lsccSpec := &peer.ChaincodeInvocationSpec{
 		ChaincodeSpec: &peer.ChaincodeSpec{
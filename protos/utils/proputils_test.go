@@ -209,6 +209,15 @@ func TestCDSProposals(t *testing.T) {
 	assert.NoError(t, err, "Unexpected error creating install proposal")
 	assert.NotEqual(t, "", txid, "txid should not be empty")
 
+	// install from package bytes
+	prop, txid, err = utils.CreateInstallProposalFromPackage([]byte("package"), creator)
+	assert.NotNil(t, prop, "Install proposal should not be nil")
+	assert.NoError(t, err, "Unexpected error creating install proposal")
+	assert.NotEqual(t, "", txid, "txid should not be empty")
+	cis, err := utils.GetChaincodeInvocationSpec(prop)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{[]byte("install"), []byte("package")}, cis.ChaincodeSpec.Input.Args)
+
 	// deploy
 	prop, txid, err = utils.CreateDeployProposalFromCDS(chainID, cds, creator, policy, escc, vscc, nil)
 	assert.NotNil(t, prop, "Deploy proposal should not be nil")
@@ -19,9 +19,12 @@ package chainmgmt
 // ChainMgrConf captures the configurations meant at the level of chainMgr
 // DataDir field specifies the filesystem location where the chains data is maintained
 // NumChains field specifies the number of chains to instantiate
+// ValidationParallelism field specifies the maximum number of transactions of a block whose read sets
+// are validated concurrently by the ledger (zero leaves the default value of the ledger unchanged)
 type ChainMgrConf struct {
-	DataDir   string
-	NumChains int
+	DataDir               string
+	NumChains             int
+	ValidationParallelism int
 }
 
 // BatchConf captures the batch related configurations
@@ -31,6 +31,9 @@ type TestEnv struct {
 // for each of the chains. For configurations options, see comments on specific configuration type
 func InitTestEnv(mgrConf *ChainMgrConf, batchConf *BatchConf, initOperation chainInitOp) *TestEnv {
 	viper.Set("peer.fileSystemPath", mgrConf.DataDir)
+	if mgrConf.ValidationParallelism > 0 {
+		viper.Set("ledger.state.validationParallelism", mgrConf.ValidationParallelism)
+	}
 	mgr := newChainsMgr(mgrConf, batchConf, initOperation)
 	chains := mgr.createOrOpenChains()
 	for _, chain := range chains {
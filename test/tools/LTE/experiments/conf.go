@@ -73,6 +73,8 @@ func confFromTestParams(testParams []string) *configuration {
 	// chainMgrConf
 	dataDir := flags.String("DataDir", conf.chainMgrConf.DataDir, "Dir for ledger data")
 	numChains := flags.Int("NumChains", conf.chainMgrConf.NumChains, "Number of chains")
+	validationParallelism := flags.Int("ValidationParallelism",
+		conf.chainMgrConf.ValidationParallelism, "Number of transactions of a block validated concurrently")
 
 	// txConf
 	numParallelTxsPerChain := flags.Int("NumParallelTxPerChain",
@@ -104,6 +106,7 @@ func confFromTestParams(testParams []string) *configuration {
 
 	conf.chainMgrConf.DataDir = *dataDir
 	conf.chainMgrConf.NumChains = *numChains
+	conf.chainMgrConf.ValidationParallelism = *validationParallelism
 	conf.txConf.numParallelTxsPerChain = *numParallelTxsPerChain
 	conf.txConf.numTotalTxs = *numTotalTxs
 	conf.txConf.numWritesPerTx = *numWritesPerTx
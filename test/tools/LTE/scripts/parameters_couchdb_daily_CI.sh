@@ -16,6 +16,7 @@ NumWritesPerTx=4
 NumReadsPerTx=4
 BatchSize=50
 KVSize=200
+ValidationParallelism=0
 
 # Each test consists of several test-runs, where one single parameter is varied
 # between the test-runs and rest of the parameters remain same. Each array below
@@ -38,3 +39,4 @@ ArrayBatchSize=(10 20 100 500)
 ArrayNumParallelTxWithSingleChain=(1 5 10 20 50 100)
 ArrayNumChainsWithNoParallelism=(1 5 10 20 50)
 ArrayNumTxs=(10000 20000 50000 100000)
+ArrayValidationParallelism=(1 2 4 8 16)
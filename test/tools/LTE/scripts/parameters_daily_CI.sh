@@ -13,6 +13,7 @@ NumWritesPerTx=4
 NumReadsPerTx=4
 BatchSize=50
 KVSize=200
+ValidationParallelism=0
 
 # Each test consists of several test-runs, where one single parameter is varied
 # between the test-runs and rest of the parameters remain same. Each array below
@@ -35,3 +36,4 @@ ArrayBatchSize=(10 20 100 500)
 ArrayNumParallelTxWithSingleChain=(1 5 10 20 50 100 500 2000)
 ArrayNumChainsWithNoParallelism=(1 5 10 20 50 100 500 2000)
 ArrayNumTxs=(100000 200000 500000 1000000)
+ArrayValidationParallelism=(1 2 4 8 16)
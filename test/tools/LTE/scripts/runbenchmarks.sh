@@ -77,6 +77,14 @@ function varyNumTxs {
     done
 }
 
+function varyValidationParallelism {
+    for v in "${ArrayValidationParallelism[@]}"
+    do
+        ValidationParallelism=$v
+        rm -rf $DataDir;runInsertTxs;runReadWriteTxs
+    done
+}
+
 function runLargeDataExperiment {
     NumKVs=10000000
     NumTotalTx=10000000
@@ -117,6 +125,7 @@ varyNumWritesPerTx
 varyKVSize
 varyBatchSize
 varyNumTxs
+varyValidationParallelism
 runLargeDataExperiment\n"
 }
 
@@ -166,6 +175,8 @@ case $1 in
     varyBatchSize ;;
   varyNumTxs)
     varyNumTxs ;;
+  varyValidationParallelism)
+    varyValidationParallelism ;;
   runLargeDataExperiment)
     runLargeDataExperiment ;;
   help)
@@ -180,6 +191,7 @@ case $1 in
     varyKVSize
     varyBatchSize
     varyNumTxs
+    varyValidationParallelism
     runLargeDataExperiment ;;
   *)
     printf "Error: test name empty/incorrect!\n"  >> /dev/stderr